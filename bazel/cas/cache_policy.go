@@ -0,0 +1,65 @@
+package cas
+
+import (
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+// Optional gRPC header carrying the ResultsCachePolicy priority of blobs uploaded with ByteStream Write
+// or BatchUpdateBlobs, since the API only lets clients set a priority on ActionResults.
+// Sent with UpdateActionResult, it's the priority the result's outputs were uploaded with, see resultCachePriority.
+// Clients can send it with every request, ex: Bazel's --remote_header=scoot-cas-cache-priority=-1.
+const CachePriorityHeader = "scoot-cas-cache-priority"
+
+// Returns how long to keep data written with a ResultsCachePolicy priority. Per the API, lower values
+// mean longer retention: zero is the server's default, so data is kept for DefaultTTL, negative
+// priorities are long-lived, ex: release builds, and positive ones ephemeral, ex: scratch CI runs.
+func TTLForCachePriority(priority int32) time.Duration {
+	switch {
+	case priority < 0:
+		return LongLivedTTL
+	case priority > 0:
+		return EphemeralTTL
+	default:
+		return DefaultTTL
+	}
+}
+
+// Returns the priority sent in CachePriorityHeader with a request received with ctx, zero if there's none
+// or it isn't a number.
+func incomingCachePriority(ctx context.Context) int32 {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[CachePriorityHeader]) == 0 {
+		return 0
+	}
+	p, err := strconv.ParseInt(md[CachePriorityHeader][0], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(p)
+}
+
+// Returns the priority to cache an ActionResult with, given its ResultsCachePolicy priority and the
+// priority its outputs were uploaded with. A result must not outlive its outputs, so it's kept for the
+// shorter of the two. Workers and most clients upload outputs without a priority, so results are only
+// long-lived if their outputs were uploaded as long-lived too.
+func resultCachePriority(policy, outputs int32) int32 {
+	if TTLForCachePriority(outputs) < TTLForCachePriority(policy) {
+		return outputs
+	}
+	return policy
+}
+
+// Returns the TTL to write data with a ResultsCachePolicy priority with, nil if the Store isn't configured with TTLs.
+func (s *casServer) cacheTTL(priority int32) *store.TTLValue {
+	ttl := store.GetTTLValue(s.storeConfig.TTLCfg)
+	if ttl != nil {
+		ttl.TTL = time.Now().Add(TTLForCachePriority(priority))
+	}
+	return ttl
+}
@@ -0,0 +1,63 @@
+package cas
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+func TestTTLForCachePriority(t *testing.T) {
+	for _, c := range []struct {
+		priority int32
+		ttl      time.Duration
+	}{{0, DefaultTTL}, {-1, LongLivedTTL}, {-100, LongLivedTTL}, {1, EphemeralTTL}, {100, EphemeralTTL}} {
+		if ttl := TTLForCachePriority(c.priority); ttl != c.ttl {
+			t.Errorf("Expected priority %d to map to %v, got %v", c.priority, c.ttl, ttl)
+		}
+	}
+
+	s := casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}}
+	if ttl := s.cacheTTL(-1); ttl != nil {
+		t.Errorf("Expected no TTL without a TTLConfig, got %v", ttl)
+	}
+	s.storeConfig.TTLCfg = &store.TTLConfig{TTL: time.Hour, TTLKey: "key"}
+	before := time.Now()
+	ttl := s.cacheTTL(-1)
+	if ttl == nil || ttl.TTLKey != "key" || ttl.TTL.Before(before.Add(LongLivedTTL)) || ttl.TTL.After(time.Now().Add(LongLivedTTL)) {
+		t.Errorf("Expected a long-lived TTL, got %v", ttl)
+	}
+}
+
+func TestIncomingCachePriority(t *testing.T) {
+	for _, c := range []struct {
+		md       metadata.MD
+		priority int32
+	}{
+		{nil, 0},
+		{metadata.Pairs(CachePriorityHeader, "-2"), -2},
+		{metadata.Pairs(CachePriorityHeader, "3"), 3},
+		{metadata.Pairs(CachePriorityHeader, "high"), 0},
+	} {
+		ctx := context.Background()
+		if c.md != nil {
+			ctx = metadata.NewIncomingContext(ctx, c.md)
+		}
+		if p := incomingCachePriority(ctx); p != c.priority {
+			t.Errorf("Expected priority %d from %v, got %d", c.priority, c.md, p)
+		}
+	}
+}
+
+func TestResultCachePriority(t *testing.T) {
+	for _, c := range []struct {
+		policy, outputs, priority int32
+	}{{0, 0, 0}, {-1, 0, 0}, {-1, -2, -1}, {1, 0, 1}, {0, 1, 1}, {-1, 1, 1}} {
+		if p := resultCachePriority(c.policy, c.outputs); p != c.priority {
+			t.Errorf("Expected policy %d with outputs %d to be cached with %d, got %d", c.policy, c.outputs, c.priority, p)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cas
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// Optional gRPC headers carrying hex encoded CRC32C (Castagnoli) checksums of uploaded data, so corruption
+// on the wire is detected, and located, before the data is hashed and persisted under its digest's name.
+// Checksums may be comma separated or sent as repeated header values.
+const (
+	// ByteStream Write: one checksum per WriteRequest's data, in order. Each chunk is verified as it arrives.
+	ChunkCRC32CHeader = "scoot-cas-chunk-crc32c"
+	// ByteStream Write: the checksum of the whole blob, verified once the client finishes the Write,
+	// before the blob is committed to the Store.
+	CommitCRC32CHeader = "scoot-cas-crc32c"
+	// BatchUpdateBlobs: one checksum per request, in order.
+	BatchCRC32CHeader = "scoot-cas-batch-crc32c"
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Returns the hex encoded CRC32C of data, as sent in the checksum headers.
+func CRC32C(data []byte) string {
+	return fmt.Sprintf("%08x", crc32.Checksum(data, crc32cTable))
+}
+
+// Returns the checksums sent in header with a request received with ctx, nil if there are none.
+func incomingChecksums(ctx context.Context, header string) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[header]) == 0 {
+		return nil
+	}
+	sums := []string{}
+	for _, v := range md[header] {
+		for _, sum := range strings.Split(v, ",") {
+			sums = append(sums, strings.ToLower(strings.TrimSpace(sum)))
+		}
+	}
+	return sums
+}
+
+// Returns ctx with the checksums added to its outgoing header, for clients.
+func WithChecksums(ctx context.Context, header string, sums ...string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, header, strings.Join(sums, ","))
+}
@@ -2,6 +2,8 @@ package cas
 
 import (
 	"fmt"
+	"math/rand"
+	"time"
 
 	uuid "github.com/nu7hatch/gouuid"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
@@ -34,6 +36,16 @@ func (e *NotFoundError) Error() string {
 	return e.Err
 }
 
+// Base wait before the first retry of an idempotent read by the CAS client helpers. Each further retry waits
+// twice as long as the last. Retries are jittered so clients that failed together don't retry together.
+var RetryBackoff = 50 * time.Millisecond
+
+// Sleeps before retrying a read, given the number of attempts made so far.
+func retryBackoff(attempts int) {
+	backoff := RetryBackoff << uint(attempts-1)
+	time.Sleep(backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)))
+}
+
 // Returns true if an error is of type NotFoundError
 func IsNotFoundError(err error) bool {
 	if _, ok := err.(*NotFoundError); ok {
@@ -45,7 +57,7 @@ func IsNotFoundError(err error) bool {
 // Read data as bytes from a CAS. Takes a Resolver for addressing and a bazel Digest to read.
 // Returns bytes read or an error. If the requested resource was not found,
 // returns a NotFoundError
-// If retries > 0, does simple retry attempts when encountering errors
+// If retries > 0, retries errors with jittered exponential backoff from RetryBackoff
 func ByteStreamRead(r dialer.Resolver, digest *remoteexecution.Digest, retries int) (bytes []byte, err error) {
 	// skip request processing for empty sha
 	if digest == nil || bazel.IsEmptyDigest(digest) {
@@ -55,12 +67,13 @@ func ByteStreamRead(r dialer.Resolver, digest *remoteexecution.Digest, retries i
 		retries = 0
 	}
 
-	for ; retries >= 0; retries-- {
+	for attempt := 1; ; attempt++ {
 		bytes, err = byteStreamRead(r, digest)
 
-		if err == nil || IsNotFoundError(err) {
+		if err == nil || IsNotFoundError(err) || attempt > retries {
 			break
 		}
+		retryBackoff(attempt)
 	}
 	return bytes, err
 }
@@ -193,17 +206,18 @@ func writeFromClient(bsc bytestream.ByteStreamClient, req *bytestream.WriteReque
 }
 
 // Client function for GetActionResult requests. Takes a Resolver for ActionCache server and Digest to get.
-// If retries > 0, does simple retry attempts when encountering errors
+// If retries > 0, retries errors with jittered exponential backoff from RetryBackoff
 func GetCacheResult(r dialer.Resolver, digest *remoteexecution.Digest, retries int) (ar *remoteexecution.ActionResult, err error) {
 	if retries < 0 {
 		retries = 0
 	}
-	for ; retries >= 0; retries-- {
+	for attempt := 1; ; attempt++ {
 		ar, err = getCacheResult(r, digest)
 
-		if err == nil || IsNotFoundError(err) {
+		if err == nil || IsNotFoundError(err) || attempt > retries {
 			break
 		}
+		retryBackoff(attempt)
 	}
 	return ar, err
 }
@@ -242,15 +256,16 @@ func getCacheFromClient(acc remoteexecution.ActionCacheClient,
 	return ar, nil
 }
 
-// Client function for UpdateActionResult requests. Takes a Resolver for ActionCache server and Digest/ActionResult to update.
+// Client function for UpdateActionResult requests. Takes a Resolver for ActionCache server and Digest/ActionResult to update,
+// and the ResultsCachePolicy the result is cached with, which may be nil for the server's default.
 // If retries > 0, does simple retry attempts when encountering errors
-func UpdateCacheResult(r dialer.Resolver, digest *remoteexecution.Digest,
-	ar *remoteexecution.ActionResult, retries int) (out *remoteexecution.ActionResult, err error) {
+func UpdateCacheResult(r dialer.Resolver, digest *remoteexecution.Digest, ar *remoteexecution.ActionResult,
+	policy *remoteexecution.ResultsCachePolicy, retries int) (out *remoteexecution.ActionResult, err error) {
 	if retries < 0 {
 		retries = 0
 	}
 	for ; retries >= 0; retries-- {
-		out, err = updateCacheResult(r, digest, ar)
+		out, err = updateCacheResult(r, digest, ar, policy)
 
 		if err == nil {
 			break
@@ -259,8 +274,8 @@ func UpdateCacheResult(r dialer.Resolver, digest *remoteexecution.Digest,
 	return out, err
 }
 
-func updateCacheResult(r dialer.Resolver,
-	digest *remoteexecution.Digest, ar *remoteexecution.ActionResult) (*remoteexecution.ActionResult, error) {
+func updateCacheResult(r dialer.Resolver, digest *remoteexecution.Digest, ar *remoteexecution.ActionResult,
+	policy *remoteexecution.ResultsCachePolicy) (*remoteexecution.ActionResult, error) {
 	serverAddr, err := r.Resolve()
 	if err != nil {
 		return nil, fmt.Errorf("Failed to resolve server address: %s", err)
@@ -272,7 +287,7 @@ func updateCacheResult(r dialer.Resolver,
 	}
 	defer cc.Close()
 
-	req := &remoteexecution.UpdateActionResultRequest{ActionDigest: digest, ActionResult: ar}
+	req := &remoteexecution.UpdateActionResultRequest{ActionDigest: digest, ActionResult: ar, ResultsCachePolicy: policy}
 
 	acc := remoteexecution.NewActionCacheClient(cc)
 	return updateCacheFromClient(acc, req)
@@ -21,16 +21,30 @@ const (
 	// ActionCache constants
 	ResultAddressKey = "ActionCacheResult"
 
+	// Largest stdout, stderr or output file inlined in a GetActionResult response when requested,
+	// and the most inlined bytes in total, keeping responses well under gRPC message size limits
+	MaxInlineBlobSize  = 64 * 1024
+	MaxInlineTotalSize = 1024 * 1024
+
 	// GRPC Server connection-related setting limits recommended for CAS
 	MaxSimultaneousConnections = 200 // limits total simultaneous connections via the Listener
 	MaxRequestsPerSecond       = 100 // limits total incoming requests allowed per second
 	MaxRequestsBurst           = 20  // allows this many requests in a burst faster than MaxRPS average
 	MaxConcurrentStreams       = 10  // limits concurrent streams _per client_
+
+	// Largest blob accepted by Write and BatchUpdateBlobs. Writes are buffered in memory before
+	// being stored, so this bounds the memory a single upload can use.
+	DefaultMaxBlobSize = 1024 * 1024 * 1024
 )
 
 // Resource naming format guidelines
-var ResourceReadFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<hash>/<size>[/filename]", ResourceNameType)
-var ResourceWriteFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<uuid>/%s/<hash>/<size>[/filename]", ResourceNameAction, ResourceNameType)
-
-// Default TTL for CAS-based operations
-var DefaultTTL time.Duration = time.Hour * 24 * 7
+var ResourceReadFormatStr string = fmt.Sprintf("[<instance-name>/]%s/[<digest-function>/]<hash>/<size>[/filename]", ResourceNameType)
+var ResourceWriteFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<uuid>/%s/[<digest-function>/]<hash>/<size>[/filename]", ResourceNameAction, ResourceNameType)
+
+// TTLs of CAS-based operations for each class of ResultsCachePolicy priority, see TTLForCachePriority.
+// DefaultTTL is used for the default priority, zero.
+var (
+	DefaultTTL   time.Duration = time.Hour * 24 * 7
+	LongLivedTTL time.Duration = time.Hour * 24 * 90
+	EphemeralTTL time.Duration = time.Hour * 24
+)
@@ -0,0 +1,191 @@
+package cas
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+)
+
+// Configuration for caching the results of Store existence checks made by FindMissingBlobs.
+type ExistenceCacheConfig struct {
+	// How long a digest confirmed missing is reported as missing without checking the Store.
+	// Writes through this server clear the entry, but writes to other servers sharing the Store
+	// aren't seen until it expires, so this should be short. Zero disables the negative cache.
+	MissingTTL time.Duration
+	// Maximum number of missing digests remembered at once.
+	MissingMaxEntries int
+	// Expected number of present digests tracked by each bloom filter generation.
+	// Zero disables the bloom filter.
+	BloomItems int
+	// Target false positive rate of the bloom filter. A false positive reports a missing blob
+	// as present, causing clients to skip uploading it, so this should be very small.
+	BloomFalsePositiveRate float64
+	// Maximum age of a bloom filter entry. Blobs expire from the Store, so the filter
+	// is periodically rotated to forget them. This should be well under the CAS TTL.
+	BloomMaxAge time.Duration
+}
+
+const (
+	DefaultMissingTTL             = 2 * time.Second
+	DefaultMissingMaxEntries      = 100000
+	DefaultBloomFalsePositiveRate = 1e-6
+	DefaultBloomMaxAge            = time.Hour
+)
+
+// By default only the negative cache is enabled, as it can't cause a present blob to be reported missing
+// for longer than MissingTTL, whereas bloom filter false positives can cause missing inputs.
+var DefaultExistenceCacheConfig = ExistenceCacheConfig{
+	MissingTTL:        DefaultMissingTTL,
+	MissingMaxEntries: DefaultMissingMaxEntries,
+}
+
+// Result of looking up a store name in the existenceCache
+type existence int
+
+const (
+	existenceUnknown existence = iota
+	existenceMissing
+	existencePresent
+)
+
+// Caches Store existence checks: a short-TTL negative cache of names confirmed missing,
+// and an optional bloom filter of names known to be present, maintained on write.
+// A nil *existenceCache is valid and caches nothing.
+type existenceCache struct {
+	cfg ExistenceCacheConfig
+
+	mu        sync.Mutex
+	missing   map[string]time.Time // name -> expiration
+	current   *bloomFilter
+	previous  *bloomFilter
+	rotatedAt time.Time
+}
+
+func newExistenceCache(cfg ExistenceCacheConfig) *existenceCache {
+	c := &existenceCache{cfg: cfg, missing: make(map[string]time.Time)}
+	if cfg.BloomItems > 0 {
+		if c.cfg.BloomFalsePositiveRate <= 0 || c.cfg.BloomFalsePositiveRate >= 1 {
+			c.cfg.BloomFalsePositiveRate = DefaultBloomFalsePositiveRate
+		}
+		if c.cfg.BloomMaxAge <= 0 {
+			c.cfg.BloomMaxAge = DefaultBloomMaxAge
+		}
+		c.current = newBloomFilter(c.cfg.BloomItems, c.cfg.BloomFalsePositiveRate)
+		c.rotatedAt = time.Now()
+	}
+	return c
+}
+
+func (c *existenceCache) lookup(name string) existence {
+	if c == nil {
+		return existenceUnknown
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if exp, ok := c.missing[name]; ok {
+		if now.Before(exp) {
+			return existenceMissing
+		}
+		delete(c.missing, name)
+	}
+	if c.current != nil {
+		c.maybeRotate(now)
+		if c.current.contains(name) || (c.previous != nil && c.previous.contains(name)) {
+			return existencePresent
+		}
+	}
+	return existenceUnknown
+}
+
+func (c *existenceCache) recordMissing(name string) {
+	if c == nil || c.cfg.MissingTTL <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.missing) >= c.cfg.MissingMaxEntries {
+		for n, exp := range c.missing {
+			if !now.Before(exp) {
+				delete(c.missing, n)
+			}
+		}
+		if len(c.missing) >= c.cfg.MissingMaxEntries {
+			return
+		}
+	}
+	c.missing[name] = now.Add(c.cfg.MissingTTL)
+}
+
+func (c *existenceCache) recordPresent(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.missing, name)
+	if c.current != nil {
+		c.maybeRotate(time.Now())
+		c.current.add(name)
+	}
+}
+
+// Start a new bloom filter generation once the current one is half of BloomMaxAge old or full,
+// so entries are kept for at most BloomMaxAge and the false positive rate stays near its target.
+// Caller must hold the lock.
+func (c *existenceCache) maybeRotate(now time.Time) {
+	if now.Sub(c.rotatedAt) < c.cfg.BloomMaxAge/2 && c.current.count < c.cfg.BloomItems {
+		return
+	}
+	if now.Sub(c.rotatedAt) >= c.cfg.BloomMaxAge {
+		c.previous = nil
+	} else {
+		c.previous = c.current
+	}
+	c.current = newBloomFilter(c.cfg.BloomItems, c.cfg.BloomFalsePositiveRate)
+	c.rotatedAt = now
+}
+
+// Standard bloom filter using double hashing to derive k bit positions from two 64-bit hashes.
+type bloomFilter struct {
+	bits  []uint64
+	m     uint64
+	k     uint64
+	count int
+}
+
+// Size the filter for n items at false positive rate p.
+func newBloomFilter(n int, p float64) *bloomFilter {
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+	return &bloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+func (b *bloomFilter) add(name string) {
+	h1, h2 := bloomHashes(name)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+	b.count++
+}
+
+func (b *bloomFilter) contains(name string) bool {
+	h1, h2 := bloomHashes(name)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bloomHashes(name string) (uint64, uint64) {
+	h1, h2 := fnv.New64a(), fnv.New64()
+	h1.Write([]byte(name))
+	h2.Write([]byte(name))
+	return h1.Sum64(), h2.Sum64() | 1
+}
@@ -0,0 +1,60 @@
+package cas
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestExistenceCacheMissingExpires(t *testing.T) {
+	c := newExistenceCache(ExistenceCacheConfig{MissingTTL: time.Millisecond, MissingMaxEntries: 1})
+	c.recordMissing("a")
+	c.recordMissing("b")
+	if e := c.lookup("a"); e != existenceMissing {
+		t.Fatalf("Expected a to be cached as missing, got %v", e)
+	}
+	if e := c.lookup("b"); e != existenceUnknown {
+		t.Fatalf("Expected b not to be cached beyond MissingMaxEntries, got %v", e)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if e := c.lookup("a"); e != existenceUnknown {
+		t.Fatalf("Expected a to expire, got %v", e)
+	}
+
+	var nilCache *existenceCache
+	nilCache.recordMissing("a")
+	if e := nilCache.lookup("a"); e != existenceUnknown {
+		t.Fatalf("Expected nil cache to cache nothing, got %v", e)
+	}
+}
+
+func TestExistenceCacheBloomRotation(t *testing.T) {
+	c := newExistenceCache(ExistenceCacheConfig{BloomItems: 100, BloomMaxAge: time.Hour})
+	for i := 0; i < 100; i++ {
+		c.recordPresent(fmt.Sprintf("blob%d", i))
+	}
+	for i := 0; i < 100; i++ {
+		if e := c.lookup(fmt.Sprintf("blob%d", i)); e != existencePresent {
+			t.Fatalf("Expected blob%d to be present, got %v", i, e)
+		}
+	}
+	falsePositives := 0
+	for i := 100; i < 10100; i++ {
+		if c.lookup(fmt.Sprintf("blob%d", i)) == existencePresent {
+			falsePositives++
+		}
+	}
+	if falsePositives > 10 {
+		t.Fatalf("Expected false positive rate near %v, got %d/10000", DefaultBloomFalsePositiveRate, falsePositives)
+	}
+
+	// A full filter is kept as the previous generation, which is dropped once older than BloomMaxAge.
+	c.recordPresent("new")
+	if c.lookup("blob0") != existencePresent || c.lookup("new") != existencePresent {
+		t.Fatalf("Expected entries from both generations to be present")
+	}
+	c.rotatedAt = c.rotatedAt.Add(-time.Hour)
+	if c.lookup("blob0") != existenceUnknown || c.lookup("new") != existenceUnknown {
+		t.Fatalf("Expected entries older than BloomMaxAge to be forgotten")
+	}
+}
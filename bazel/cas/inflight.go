@@ -0,0 +1,120 @@
+package cas
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Tracks blobs being uploaded through ByteStream Write, so a concurrent Read of the same
+// store name can stream data as it arrives rather than returning NotFound until the Write finishes.
+// A nil *inflightBlobs is valid and tracks nothing.
+type inflightBlobs struct {
+	mu    sync.Mutex
+	blobs map[string]*inflightBlob
+}
+
+func newInflightBlobs() *inflightBlobs {
+	return &inflightBlobs{blobs: make(map[string]*inflightBlob)}
+}
+
+// Returns a buffer for a Write of name, published to readers unless
+// another Write of name is already in flight.
+func (f *inflightBlobs) start(name string, size int64) *inflightBlob {
+	b := &inflightBlob{data: make([]byte, 0, size)}
+	b.cond = sync.NewCond(&b.mu)
+	if f == nil {
+		return b
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.blobs[name]; !ok {
+		f.blobs[name] = b
+	}
+	return b
+}
+
+// Ends the Write of name registered as b. A nil err means b is now in the Store,
+// otherwise readers still streaming b fail with err.
+func (f *inflightBlobs) finish(name string, b *inflightBlob, err error) {
+	if f != nil {
+		f.mu.Lock()
+		if f.blobs[name] == b {
+			delete(f.blobs, name)
+		}
+		f.mu.Unlock()
+	}
+	b.close(err)
+}
+
+// Returns a reader streaming the in-flight Write of name, or nil if there isn't one.
+func (f *inflightBlobs) open(name string) io.ReadCloser {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if b, ok := f.blobs[name]; ok {
+		return &inflightReader{blob: b}
+	}
+	return nil
+}
+
+var errWriteAborted = errors.New("In-flight Write ended without storing the blob")
+
+// Data received so far by an in-flight Write. Unverified until the Write completes,
+// so readers only see io.EOF once the blob has been hashed and stored.
+// Only the writing goroutine appends to data, so it may read data without locking.
+type inflightBlob struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	data []byte
+	done bool
+	err  error
+}
+
+func (b *inflightBlob) append(p []byte) {
+	b.mu.Lock()
+	b.data = append(b.data, p...)
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// Marks the Write as ended with err. Only the first call has any effect.
+func (b *inflightBlob) close(err error) {
+	b.mu.Lock()
+	if b.done {
+		b.mu.Unlock()
+		return
+	}
+	b.done = true
+	b.err = err
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// Reads an inflightBlob from the start, blocking until more data arrives or the Write ends.
+type inflightReader struct {
+	blob *inflightBlob
+	off  int
+}
+
+func (r *inflightReader) Read(p []byte) (int, error) {
+	b := r.blob
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for r.off >= len(b.data) && !b.done {
+		b.cond.Wait()
+	}
+	if r.off < len(b.data) {
+		n := copy(p, b.data[r.off:])
+		r.off += n
+		return n, nil
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return 0, io.EOF
+}
+
+func (r *inflightReader) Close() error { return nil }
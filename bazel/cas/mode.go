@@ -0,0 +1,153 @@
+package cas
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Path the CAS ModeSwitch's admin endpoint is served at by Apiserver
+const ModeHttpPath = "/admin/cas/mode"
+
+// Mode restricts which requests a CAS server serves, ex: during storage migrations or to contain an incident.
+type Mode int32
+
+const (
+	// Serve all requests
+	ModeNormal Mode = iota
+	// Serve reads, reject writes to the CAS and ActionCache with FAILED_PRECONDITION, and bundle uploads
+	ModeReadOnly
+	// Reject all CAS, ByteStream and ActionCache requests with UNAVAILABLE, so clients retry elsewhere or later,
+	// and bundle uploads
+	ModeMaintenance
+)
+
+var modeNames = map[Mode]string{
+	ModeNormal:      "normal",
+	ModeReadOnly:    "read_only",
+	ModeMaintenance: "maintenance",
+}
+
+func (m Mode) String() string {
+	if name, ok := modeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mode(%d)", int32(m))
+}
+
+// Parses a Mode from its name: normal, read_only or maintenance.
+func ParseMode(s string) (Mode, error) {
+	for m, name := range modeNames {
+		if s == name {
+			return m, nil
+		}
+	}
+	return ModeNormal, fmt.Errorf("Invalid CAS mode %q, expected normal, read_only or maintenance", s)
+}
+
+// ModeSwitch holds the Mode of a CAS server, which can be changed while it's serving.
+// A nil ModeSwitch is always in ModeNormal.
+// It implements http.Handler as an admin endpoint: GET returns the current mode,
+// and POST sets the mode named by the 'mode' form value, ex: POST /admin/cas/mode?mode=read_only
+// POSTs must carry the admin token as "Authorization: Bearer <token>", and are refused if there's no token.
+type ModeSwitch struct {
+	mode  int32
+	token string
+	stat  stats.StatsReceiver
+}
+
+// Creates a ModeSwitch in mode m, whose mode can be changed over HTTP with adminToken.
+func NewModeSwitch(m Mode, adminToken string, stat stats.StatsReceiver) *ModeSwitch {
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	ms := &ModeSwitch{token: adminToken, stat: stat}
+	ms.Set(m)
+	return ms
+}
+
+func (ms *ModeSwitch) Mode() Mode {
+	if ms == nil {
+		return ModeNormal
+	}
+	return Mode(atomic.LoadInt32(&ms.mode))
+}
+
+func (ms *ModeSwitch) Set(m Mode) {
+	atomic.StoreInt32(&ms.mode, int32(m))
+	ms.stat.Gauge(stats.BzModeGauge).Update(int64(m))
+}
+
+// Returns an error to fail a request with if the current mode doesn't allow it, counting the rejection.
+func (ms *ModeSwitch) check(write bool) error {
+	if ms == nil {
+		return nil
+	}
+	var err error
+	switch ms.Mode() {
+	case ModeMaintenance:
+		err = status.Error(codes.Unavailable, "CAS server is in maintenance mode")
+	case ModeReadOnly:
+		if write {
+			err = status.Error(codes.FailedPrecondition, "CAS server is in read-only mode")
+		}
+	}
+	if err != nil {
+		ms.stat.Counter(stats.BzModeRejectedCounter).Inc(1)
+	}
+	return err
+}
+
+// Returns an error if the current mode doesn't allow writes, counting the rejection.
+// For writes outside the CAS API, like bundle uploads.
+func (ms *ModeSwitch) CheckWrite() error {
+	if err := ms.check(true); err != nil {
+		return errors.New(status.Convert(err).Message())
+	}
+	return nil
+}
+
+func (ms *ModeSwitch) checkToken(req *http.Request) error {
+	if ms.token == "" {
+		return errors.New("Changing the CAS mode is disabled, no admin token is configured")
+	}
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(ms.token)) != 1 {
+		return errors.New("Invalid admin token")
+	}
+	return nil
+}
+
+func (ms *ModeSwitch) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case "GET":
+	case "POST":
+		if err := ms.checkToken(req); err != nil {
+			log.Infof("Refusing to change CAS mode (from %v): %v", req.RemoteAddr, err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		m, err := ParseMode(req.FormValue("mode"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if prev := ms.Mode(); prev != m {
+			log.Infof("Changing CAS mode from %s to %s (from %v)", prev, m, req.RemoteAddr)
+			ms.Set(m)
+		}
+	default:
+		http.Error(w, "only support POST and GET", http.StatusMethodNotAllowed)
+		return
+	}
+	fmt.Fprintln(w, ms.Mode())
+}
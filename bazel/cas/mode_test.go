@@ -0,0 +1,99 @@
+package cas
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+func TestModeRejectsRequests(t *testing.T) {
+	f := &store.FakeStore{}
+	ms := NewModeSwitch(ModeReadOnly, "", nil)
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, mode: ms, stat: stats.NilStatsReceiver()}
+
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	if err := f.Write(bazel.DigestStoreName(d), bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	findReq := &remoteexecution.FindMissingBlobsRequest{BlobDigests: []*remoteexecution.Digest{d}}
+	updateReq := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{{Digest: d, Data: testData1}},
+	}
+
+	// Read-only serves reads but not writes
+	if _, err := s.FindMissingBlobs(context.Background(), findReq); err != nil {
+		t.Fatalf("Expected reads to be served in read-only mode, got: %v", err)
+	}
+	if _, err := s.BatchUpdateBlobs(context.Background(), updateReq); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition for writes in read-only mode, got: %v", err)
+	}
+	caps, _ := s.GetCapabilities(context.Background(), &remoteexecution.GetCapabilitiesRequest{})
+	if caps.GetCacheCapabilities().GetActionCacheUpdateCapabilities().GetUpdateEnabled() {
+		t.Fatal("Expected ActionCache updates to be advertised as disabled in read-only mode")
+	}
+
+	// Maintenance serves neither
+	ms.Set(ModeMaintenance)
+	if _, err := s.FindMissingBlobs(context.Background(), findReq); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable for reads in maintenance mode, got: %v", err)
+	}
+	if _, err := s.BatchUpdateBlobs(context.Background(), updateReq); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected Unavailable for writes in maintenance mode, got: %v", err)
+	}
+
+	ms.Set(ModeNormal)
+	if _, err := s.BatchUpdateBlobs(context.Background(), updateReq); err != nil {
+		t.Fatalf("Expected writes to be served in normal mode, got: %v", err)
+	}
+}
+
+func TestModeHandler(t *testing.T) {
+	ms := NewModeSwitch(ModeNormal, "secret", nil)
+	post := func(query, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", ModeHttpPath+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		ms.ServeHTTP(w, req)
+		return w
+	}
+
+	// Changing the mode takes the admin token
+	for _, token := range []string{"", "wrong"} {
+		if w := post("?mode=read_only", token); w.Code != 401 || ms.Mode() != ModeNormal {
+			t.Fatalf("Expected 401 without the admin token and no change, got %d, mode %s", w.Code, ms.Mode())
+		}
+	}
+
+	w := post("?mode=read_only", "secret")
+	if w.Code != 200 || strings.TrimSpace(w.Body.String()) != "read_only" || ms.Mode() != ModeReadOnly {
+		t.Fatalf("Expected read_only mode to be set, got %d %q, mode %s", w.Code, w.Body.String(), ms.Mode())
+	}
+
+	w = httptest.NewRecorder()
+	ms.ServeHTTP(w, httptest.NewRequest("GET", ModeHttpPath, nil))
+	if strings.TrimSpace(w.Body.String()) != "read_only" {
+		t.Fatalf("Expected GET to return read_only, got %q", w.Body.String())
+	}
+
+	if w = post("?mode=readonly", "secret"); w.Code != 400 || ms.Mode() != ModeReadOnly {
+		t.Fatalf("Expected 400 for an invalid mode and no change, got %d, mode %s", w.Code, ms.Mode())
+	}
+
+	// Without an admin token the mode can't be changed over HTTP
+	ms = NewModeSwitch(ModeNormal, "", nil)
+	if w = post("?mode=read_only", ""); w.Code != 401 || ms.Mode() != ModeNormal {
+		t.Fatalf("Expected 401 with no admin token configured, got %d, mode %s", w.Code, ms.Mode())
+	}
+}
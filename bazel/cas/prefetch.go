@@ -0,0 +1,80 @@
+package cas
+
+import (
+	"io"
+)
+
+// Number of chunks a prefetchReader buffers: one being sent while the next is read.
+const prefetchBuffers = 2
+
+// A chunk of a Store resource read ahead by a prefetchReader. err is the error returned
+// by the Read that filled data, which may be non-nil alongside data as with io.Reader.
+type prefetchChunk struct {
+	data []byte
+	err  error
+}
+
+// Reads chunks of an io.Reader on a separate goroutine, so a Store read of the next chunk
+// overlaps sending the current one. Chunk buffers are reused once released, so at most
+// prefetchBuffers chunks are held in memory.
+type prefetchReader struct {
+	chunks chan prefetchChunk
+	free   chan []byte
+	done   chan struct{}
+	exited chan struct{}
+}
+
+// Starts reading r in chunks of up to size bytes.
+func newPrefetchReader(r io.Reader, size int64) *prefetchReader {
+	p := &prefetchReader{
+		chunks: make(chan prefetchChunk, prefetchBuffers),
+		free:   make(chan []byte, prefetchBuffers),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	for i := 0; i < prefetchBuffers; i++ {
+		p.free <- make([]byte, size)
+	}
+	go p.loop(r)
+	return p
+}
+
+func (p *prefetchReader) loop(r io.Reader) {
+	defer close(p.exited)
+	defer close(p.chunks)
+	for {
+		var buf []byte
+		select {
+		case buf = <-p.free:
+		case <-p.done:
+			return
+		}
+		n, err := r.Read(buf[:cap(buf)])
+		select {
+		case p.chunks <- prefetchChunk{data: buf[:n], err: err}:
+		case <-p.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Returns the next chunk, which must be released once its data is no longer used.
+// Must not be called again after a chunk with a non-nil err.
+func (p *prefetchReader) next() prefetchChunk {
+	return <-p.chunks
+}
+
+// Returns a chunk's buffer to be filled again.
+func (p *prefetchReader) release(c prefetchChunk) {
+	p.free <- c.data
+}
+
+// Stops reading ahead and waits for an in progress read to return,
+// after which the underlying io.Reader may be closed.
+func (p *prefetchReader) stop() {
+	close(p.done)
+	<-p.exited
+}
@@ -0,0 +1,49 @@
+package cas
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPrefetchReader(t *testing.T) {
+	data := []byte("0123456789abcdefghij")
+	p := newPrefetchReader(bytes.NewReader(data), 3)
+	defer p.stop()
+
+	read := []byte{}
+	for {
+		c := p.next()
+		if len(c.data) > 3 {
+			t.Fatalf("Expected chunks of at most 3 bytes, got %q", c.data)
+		}
+		read = append(read, c.data...)
+		p.release(c)
+		if c.err == io.EOF {
+			break
+		} else if c.err != nil {
+			t.Fatalf("Unexpected error: %v", c.err)
+		}
+	}
+	if !bytes.Equal(read, data) {
+		t.Fatalf("Expected %q, got %q", data, read)
+	}
+}
+
+func TestPrefetchReaderError(t *testing.T) {
+	p := newPrefetchReader(iotest.TimeoutReader(bytes.NewReader([]byte("abcdef"))), 4)
+	defer p.stop()
+
+	if c := p.next(); string(c.data) != "abcd" || c.err != nil {
+		t.Fatalf("Expected the first chunk, got %q: %v", c.data, c.err)
+	}
+	if c := p.next(); c.err != iotest.ErrTimeout {
+		t.Fatalf("Expected %v, got %q: %v", iotest.ErrTimeout, c.data, c.err)
+	}
+
+	// Stopping before the end doesn't wait for the remaining chunks to be read.
+	p = newPrefetchReader(bytes.NewReader(make([]byte, 64)), 4)
+	p.next()
+	p.stop()
+}
@@ -0,0 +1,299 @@
+package cas
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Default period over which a tenant's uploads count against its quota
+	DefaultQuotaWindow = 24 * time.Hour
+
+	// Tenant of requests that carry no known token
+	AnonymousTenant = "anonymous"
+
+	// gRPC metadata key and HTTP header carrying a tenant's token, as "Bearer <token>"
+	authorizationHeader = "authorization"
+)
+
+// Per-tenant limits on bytes stored, so one tenant's uploads can't evict everyone else's blobs from the Store.
+// Quotas count CAS blobs, ActionCache results and bundles. Tenants are authenticated by the bearer token
+// their requests carry in an "authorization" header, as gRPC metadata or an HTTP header, so a tenant can't
+// spend another's quota by naming it. Uploads of blobs already in the Store don't count against a quota,
+// as they take no more space.
+type QuotaConfig struct {
+	// Bytes each tenant may store per Window, keyed by tenant.
+	Bytes map[string]int64
+	// Bytes tenants not in Bytes may each store per Window, including AnonymousTenant.
+	// Zero is interpretted as unlimited.
+	DefaultBytes int64
+	// Period after which usage is reset. If <= 0, DefaultQuotaWindow.
+	Window time.Duration
+	// Tenants keyed by the token that authenticates them. Requests without one of these tokens
+	// are AnonymousTenant's.
+	Tokens map[string]string
+}
+
+// Parses a comma-separated list of 'tenant=bytes' quotas.
+func ParseQuotas(s string) (map[string]int64, error) {
+	quotas := map[string]int64{}
+	if s == "" {
+		return quotas, nil
+	}
+	for _, q := range strings.Split(s, ",") {
+		parts := strings.SplitN(q, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid quota %q, expected 'tenant=bytes'", q)
+		}
+		bytes, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || bytes < 0 {
+			return nil, fmt.Errorf("Invalid quota %q, bytes must be a non-negative integer", q)
+		}
+		quotas[strings.TrimSpace(parts[0])] = bytes
+	}
+	return quotas, nil
+}
+
+// Parses 'tenant=token' lines, ignoring blank lines and those starting with '#'.
+// Returns tenants keyed by token.
+func ParseQuotaTokens(s string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid quota token on line %d, expected 'tenant=token'", i+1)
+		}
+		token := strings.TrimSpace(parts[1])
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("Invalid quota token on line %d, the token is already used", i+1)
+		}
+		tokens[token] = strings.TrimSpace(parts[0])
+	}
+	return tokens, nil
+}
+
+// Error returned when an upload would take a tenant over its quota
+type QuotaExceededError struct {
+	Tenant      string
+	Used, Limit int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("Tenant %q exceeded its storage quota: %d of %d bytes used", e.Tenant, e.Used, e.Limit)
+}
+
+// Accounts bytes stored by each tenant and rejects uploads over the tenant's quota.
+// Tenants come from the config, so each gets its own stats scope.
+// A nil *QuotaTracker is valid and enforces nothing.
+type QuotaTracker struct {
+	cfg  QuotaConfig
+	stat stats.StatsReceiver
+
+	mu          sync.Mutex
+	used        map[string]int64 // tenant -> bytes stored this window
+	windowStart time.Time
+}
+
+// Returns a QuotaTracker for cfg reporting usage every DefaultUsageReportInterval, or nil if cfg is nil.
+func StartQuotaTracker(cfg *QuotaConfig, stat stats.StatsReceiver) *QuotaTracker {
+	q := newQuotaTracker(cfg, stat)
+	if q != nil {
+		go q.loop(DefaultUsageReportInterval)
+	}
+	return q
+}
+
+func newQuotaTracker(cfg *QuotaConfig, stat stats.StatsReceiver) *QuotaTracker {
+	if cfg == nil {
+		return nil
+	}
+	q := &QuotaTracker{cfg: *cfg, stat: stat, used: make(map[string]int64), windowStart: time.Now()}
+	if q.cfg.Window <= 0 {
+		q.cfg.Window = DefaultQuotaWindow
+	}
+	return q
+}
+
+// Returns the tenant authenticated by authorization, an "authorization" header value of "Bearer <token>",
+// or AnonymousTenant if it has no known token.
+func (q *QuotaTracker) Tenant(authorization string) string {
+	if q == nil || !strings.HasPrefix(authorization, "Bearer ") {
+		return AnonymousTenant
+	}
+	token := []byte(strings.TrimPrefix(authorization, "Bearer "))
+	tenant := AnonymousTenant
+	// Every token is compared, in constant time, so timing doesn't reveal how close a guess was.
+	for t, ten := range q.cfg.Tokens {
+		if subtle.ConstantTimeCompare(token, []byte(t)) == 1 {
+			tenant = ten
+		}
+	}
+	return tenant
+}
+
+// Returns the tenant authenticated by the metadata of a gRPC request received with ctx.
+func (q *QuotaTracker) contextTenant(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[authorizationHeader]) == 0 {
+		return AnonymousTenant
+	}
+	return q.Tenant(md[authorizationHeader][0])
+}
+
+// Returns the tenant's quota in bytes, zero if unlimited.
+func (q *QuotaTracker) limit(tenant string) int64 {
+	if limit, ok := q.cfg.Bytes[tenant]; ok {
+		return limit
+	}
+	return q.cfg.DefaultBytes
+}
+
+// Records an upload of size bytes by tenant before it's stored, so concurrent uploads can't
+// overrun the quota together. Returns a *QuotaExceededError without recording it if it's over quota.
+func (q *QuotaTracker) Reserve(tenant string, size int64) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rotate(time.Now())
+	used := q.used[tenant]
+	if limit := q.limit(tenant); limit > 0 && used+size > limit {
+		q.stat.Scope("quota", tenant).Counter(stats.BzQuotaExceededCounter).Inc(1)
+		return &QuotaExceededError{Tenant: tenant, Used: used, Limit: limit}
+	}
+	q.used[tenant] = used + size
+	return nil
+}
+
+// Returns bytes reserved for an upload that wasn't stored.
+func (q *QuotaTracker) Release(tenant string, size int64) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if used, ok := q.used[tenant]; ok {
+		q.used[tenant] = used - size
+		if q.used[tenant] <= 0 {
+			delete(q.used, tenant)
+		}
+	}
+}
+
+// Returns a reader of r that reserves the bytes read from it against tenant's quota, for uploads whose
+// size isn't known until they're stored. Reads fail with a *QuotaExceededError once the quota is reached.
+func (q *QuotaTracker) Reader(tenant string, r io.Reader) *QuotaReader {
+	return &QuotaReader{q: q, tenant: tenant, r: r}
+}
+
+// Reader reserving the bytes it reads against a tenant's quota, see QuotaTracker.Reader.
+type QuotaReader struct {
+	q        *QuotaTracker
+	tenant   string
+	r        io.Reader
+	reserved int64
+	err      *QuotaExceededError
+}
+
+func (r *QuotaReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if qerr := r.q.Reserve(r.tenant, int64(n)); qerr != nil {
+			r.err = qerr.(*QuotaExceededError)
+			return 0, r.err
+		}
+		r.reserved += int64(n)
+	}
+	return n, err
+}
+
+// Returns the error the upload failed with if it went over quota, nil otherwise.
+func (r *QuotaReader) Exceeded() *QuotaExceededError {
+	return r.err
+}
+
+// Returns the bytes reserved so far, for an upload that wasn't stored.
+func (r *QuotaReader) Release() {
+	r.q.Release(r.tenant, r.reserved)
+	r.reserved = 0
+}
+
+// Resets usage if the current window has ended. Caller must hold the lock.
+func (q *QuotaTracker) rotate(now time.Time) {
+	if now.Sub(q.windowStart) >= q.cfg.Window {
+		q.used = make(map[string]int64)
+		q.windowStart = now
+	}
+}
+
+// Tenant usage during the current window
+type quotaUsage struct {
+	Tenant string
+	Used   int64
+	Limit  int64
+}
+
+// Log the usage of each tenant in the current window, ordered by most used first.
+// Returns the reported usage.
+func (q *QuotaTracker) report() []quotaUsage {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	q.rotate(time.Now())
+	usage := make([]quotaUsage, 0, len(q.used))
+	for tenant, used := range q.used {
+		usage = append(usage, quotaUsage{Tenant: tenant, Used: used, Limit: q.limit(tenant)})
+	}
+	tenants := map[string]bool{AnonymousTenant: true}
+	for _, tenant := range q.cfg.Tokens {
+		tenants[tenant] = true
+	}
+	for tenant := range tenants {
+		q.stat.Scope("quota", tenant).Gauge(stats.BzQuotaUsedBytesGauge).Update(q.used[tenant])
+	}
+	q.mu.Unlock()
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Used != usage[j].Used {
+			return usage[i].Used > usage[j].Used
+		}
+		return usage[i].Tenant < usage[j].Tenant
+	})
+	for _, u := range usage {
+		log.WithFields(
+			log.Fields{
+				"tenant":     u.Tenant,
+				"usedBytes":  u.Used,
+				"limitBytes": u.Limit,
+			}).Info("Storage quota usage")
+	}
+	return usage
+}
+
+// Report usage every interval. Never returns.
+func (q *QuotaTracker) loop(interval time.Duration) {
+	for range time.NewTicker(interval).C {
+		q.report()
+	}
+}
@@ -0,0 +1,136 @@
+package cas
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+func TestParseQuotas(t *testing.T) {
+	quotas, err := ParseQuotas("teamA=100, teamB = 200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != 2 || quotas["teamA"] != 100 || quotas["teamB"] != 200 {
+		t.Fatalf("Unexpected quotas: %v", quotas)
+	}
+	for _, s := range []string{"teamA", "teamA=-1", "teamA=lots"} {
+		if _, err := ParseQuotas(s); err == nil {
+			t.Fatalf("Expected error parsing %q", s)
+		}
+	}
+}
+
+func TestParseQuotaTokens(t *testing.T) {
+	tokens, err := ParseQuotaTokens("# tenants\nteamA=secretA\n\nteamB = c2VjcmV0Qg==\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens["secretA"] != "teamA" || tokens["c2VjcmV0Qg=="] != "teamB" {
+		t.Fatalf("Unexpected tokens: %v", tokens)
+	}
+	for _, s := range []string{"teamA", "teamA=", "=secretA", "teamA=secretA\nteamB=secretA"} {
+		if _, err := ParseQuotaTokens(s); err == nil {
+			t.Fatalf("Expected error parsing %q", s)
+		}
+	}
+}
+
+func TestQuotaTracker(t *testing.T) {
+	q := newQuotaTracker(&QuotaConfig{Bytes: map[string]int64{"teamA": 10}, DefaultBytes: 5}, stats.NilStatsReceiver())
+
+	if err := q.Reserve("teamA", 8); err != nil {
+		t.Fatalf("Unexpected error reserving under quota: %v", err)
+	}
+	if err := q.Reserve("teamA", 3); err == nil {
+		t.Fatal("Expected error reserving over quota")
+	}
+	// Other tenants get the default quota, each separately
+	if err := q.Reserve("teamB", 5); err != nil {
+		t.Fatalf("Unexpected error reserving under default quota: %v", err)
+	}
+	if err := q.Reserve("teamC", 6); err == nil {
+		t.Fatal("Expected error reserving over default quota")
+	}
+
+	// Released bytes can be reserved again
+	q.Release("teamA", 8)
+	if err := q.Reserve("teamA", 10); err != nil {
+		t.Fatalf("Unexpected error reserving released quota: %v", err)
+	}
+
+	usage := q.report()
+	if len(usage) != 2 || usage[0] != (quotaUsage{Tenant: "teamA", Used: 10, Limit: 10}) ||
+		usage[1] != (quotaUsage{Tenant: "teamB", Used: 5, Limit: 5}) {
+		t.Fatalf("Unexpected usage: %+v", usage)
+	}
+
+	// Usage is reset once the window ends
+	q.windowStart = time.Now().Add(-q.cfg.Window)
+	if err := q.Reserve("teamA", 10); err != nil {
+		t.Fatalf("Unexpected error reserving in a new window: %v", err)
+	}
+}
+
+func TestQuotaTrackerTenant(t *testing.T) {
+	q := newQuotaTracker(&QuotaConfig{Tokens: map[string]string{"secretA": "teamA"}}, stats.NilStatsReceiver())
+	for auth, tenant := range map[string]string{
+		"Bearer secretA": "teamA",
+		"Bearer secret":  AnonymousTenant,
+		"secretA":        AnonymousTenant,
+		"":               AnonymousTenant,
+	} {
+		if got := q.Tenant(auth); got != tenant {
+			t.Errorf("Expected %q to be tenant %q, got %q", auth, tenant, got)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secretA"))
+	if got := q.contextTenant(ctx); got != "teamA" {
+		t.Errorf("Expected request metadata to authenticate teamA, got %q", got)
+	}
+	if got := q.contextTenant(context.Background()); got != AnonymousTenant {
+		t.Errorf("Expected request without metadata to be anonymous, got %q", got)
+	}
+}
+
+func TestQuotaReader(t *testing.T) {
+	q := newQuotaTracker(&QuotaConfig{DefaultBytes: 5}, stats.NilStatsReceiver())
+
+	r := q.Reader("teamA", strings.NewReader("four"))
+	if data, err := ioutil.ReadAll(r); err != nil || string(data) != "four" || r.Exceeded() != nil {
+		t.Fatalf("Unexpected read under quota: %q, %v", data, err)
+	}
+	r = q.Reader("teamA", strings.NewReader("two"))
+	if _, err := ioutil.ReadAll(r); err == nil || r.Exceeded() == nil {
+		t.Fatalf("Expected read over quota to fail, got %v", err)
+	}
+
+	// Bytes of uploads that weren't stored are given back
+	r = q.Reader("teamB", strings.NewReader("four"))
+	ioutil.ReadAll(r)
+	r.Release()
+	if usage := q.report(); len(usage) != 1 || usage[0].Used != 4 {
+		t.Fatalf("Unexpected usage: %+v", usage)
+	}
+}
+
+func TestQuotaTrackerNil(t *testing.T) {
+	var q *QuotaTracker
+	if err := q.Reserve("teamA", 1<<40); err != nil {
+		t.Fatalf("Unexpected error from nil QuotaTracker: %v", err)
+	}
+	q.Release("teamA", 1)
+	if q.Tenant("Bearer secretA") != AnonymousTenant {
+		t.Fatal("Expected every request to be anonymous with a nil QuotaTracker")
+	}
+	if q.report() != nil {
+		t.Fatal("Expected no usage from nil QuotaTracker")
+	}
+}
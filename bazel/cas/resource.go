@@ -16,16 +16,23 @@ import (
 // Keep track of a Resource specified by a client.
 // Instance - optional parameter identifying a server instance
 // Digest - Bazel Digest identifier
+// DigestFunction - hash function of the Digest, named in the resource or inferred from the hash
 // UUID - client identifier attached to write requests
 //	Unused by Scoot currently except for tracking/logging
 type Resource struct {
-	Instance string
-	Digest   *remoteexecution.Digest
-	UUID     uuid.UUID
+	Instance       string
+	Digest         *remoteexecution.Digest
+	DigestFunction remoteexecution.DigestFunction
+	UUID           uuid.UUID
 }
 
 func (r *Resource) String() string {
-	return fmt.Sprintf("Instance: %s, Digest: %s, UUID: %s", r.Instance, r.Digest, r.UUID)
+	return fmt.Sprintf("Instance: %s, Digest: %s, DigestFunction: %s, UUID: %s", r.Instance, r.Digest, r.DigestFunction, r.UUID)
+}
+
+// Returns the name of the Resource's blob in the underlying store
+func (r *Resource) StoreName() string {
+	return bazel.DigestFunctionStoreName(r.DigestFunction, r.Digest)
 }
 
 // Return a valid read resource string based on individual components. Errors on invalid inputs.
@@ -49,7 +56,7 @@ func GetDefaultReadResourceName(hash string, size int64) (string, error) {
 }
 
 // Parses a name string from the Read API into a Resource for bazel artifacts.
-// Valid read format: "[<instance>/]blobs/<hash>/<size>[/<filename>]"
+// Valid read format: "[<instance>/]blobs/[<digest_function>/]<hash>/<size>[/<filename>]"
 // Scoot does not currently use/track the filename portion of resource names
 func ParseReadResource(name string) (*Resource, error) {
 	elems := strings.Split(name, "/")
@@ -57,20 +64,23 @@ func ParseReadResource(name string) (*Resource, error) {
 		return nil, resourceError("len elems '/' mismatch", name, ResourceReadFormatStr)
 	}
 
-	var instance, hash, sizeStr string
+	var instance string
+	var rest []string
 	if elems[0] == ResourceNameType {
 		instance = bazel.DefaultInstanceName
-		hash = elems[1]
-		sizeStr = elems[2]
+		rest = elems[1:]
 	} else if elems[1] == ResourceNameType && len(elems) > 3 {
 		instance = elems[0]
-		hash = elems[2]
-		sizeStr = elems[3]
+		rest = elems[2:]
 	} else {
 		return nil, resourceError("resource type not found", name, ResourceReadFormatStr)
 	}
 
-	return ParseResource(instance, "", hash, sizeStr, name, ResourceReadFormatStr)
+	fn, rest := splitDigestFunction(rest)
+	if len(rest) < 2 {
+		return nil, resourceError("len elems '/' mismatch", name, ResourceReadFormatStr)
+	}
+	return parseResource(instance, "", fn, rest[0], rest[1], name, ResourceReadFormatStr)
 }
 
 // Return a valid write resource string based on individual components. Errors on invalid inputs
@@ -94,7 +104,7 @@ func GetDefaultWriteResourceName(uuid, hash string, size int64) (string, error)
 }
 
 // Parses a name string from the Write API into a Resource for bazel artifacts.
-// Valid read format: "[<instance>/]uploads/<uuid>/blobs/<hash>/<size>[/<filename>]"
+// Valid write format: "[<instance>/]uploads/<uuid>/blobs/[<digest_function>/]<hash>/<size>[/<filename>]"
 // Scoot does not currently use/track the filename portion of resource names
 func ParseWriteResource(name string) (*Resource, error) {
 	elems := strings.Split(name, "/")
@@ -120,14 +130,33 @@ func ParseWriteResource(name string) (*Resource, error) {
 	}
 
 	id = rest[0]
-	hash = rest[2]
-	sizeStr = rest[3]
+	fn, rest := splitDigestFunction(rest[2:])
+	if len(rest) < 2 {
+		return nil, resourceError("len elems '/' mismatch", name, ResourceWriteFormatStr)
+	}
+	hash = rest[0]
+	sizeStr = rest[1]
+
+	return parseResource(instance, id, fn, hash, sizeStr, name, ResourceWriteFormatStr)
+}
 
-	return ParseResource(instance, id, hash, sizeStr, name, ResourceWriteFormatStr)
+// Splits an optional leading digest function name off resource name elements.
+// Returns DigestFunction_UNKNOWN if the elements don't start with one.
+func splitDigestFunction(elems []string) (remoteexecution.DigestFunction, []string) {
+	if len(elems) > 0 {
+		if fn, ok := bazel.ParseDigestFunction(elems[0]); ok {
+			return fn, elems[1:]
+		}
+	}
+	return remoteexecution.DigestFunction_UNKNOWN, elems
 }
 
-// Underlying Resource parser from separated URI components
+// Underlying Resource parser from separated URI components, with the digest function inferred from the hash
 func ParseResource(instance, id, hash, sizeStr, name, format string) (*Resource, error) {
+	return parseResource(instance, id, remoteexecution.DigestFunction_UNKNOWN, hash, sizeStr, name, format)
+}
+
+func parseResource(instance, id string, fn remoteexecution.DigestFunction, hash, sizeStr, name, format string) (*Resource, error) {
 	var uid uuid.UUID
 	if id != "" {
 		u, err := uuid.ParseHex(id)
@@ -142,11 +171,19 @@ func ParseResource(instance, id, hash, sizeStr, name, format string) (*Resource,
 		return nil, resourceError("size value could not be parsed as int64", name, format)
 	}
 
-	if !bazel.IsValidDigest(hash, size) {
+	if fn == remoteexecution.DigestFunction_UNKNOWN {
+		fn = bazel.InferDigestFunction(hash)
+	}
+	if !bazel.IsValidDigestFunction(fn, hash, size) {
 		return nil, resourceError("digest hash/size invalid", name, format)
 	}
 
-	return &Resource{Instance: instance, Digest: &remoteexecution.Digest{Hash: hash, SizeBytes: size}, UUID: uid}, nil
+	return &Resource{
+		Instance:       instance,
+		Digest:         &remoteexecution.Digest{Hash: hash, SizeBytes: size},
+		DigestFunction: fn,
+		UUID:           uid,
+	}, nil
 }
 
 // helper for descriptive resource error messages
@@ -1,6 +1,7 @@
 package cas
 
 import (
+	"strings"
 	"testing"
 
 	uuid "github.com/nu7hatch/gouuid"
@@ -136,6 +137,46 @@ func TestGetAndParseResources(t *testing.T) {
 	}
 }
 
+func TestParseResourceDigestFunction(t *testing.T) {
+	sha256Hash := "01ba4719c80b6fe911b091a7c05124b64eeece964e09c058ef8f9805daca546b"
+	sha512Hash := sha256Hash + sha256Hash
+
+	for name, fn := range map[string]remoteexecution.DigestFunction{
+		"blobs/" + sha256Hash + "/5":                                                      remoteexecution.DigestFunction_SHA256,
+		"instance/blobs/sha256/" + sha256Hash + "/5":                                      remoteexecution.DigestFunction_SHA256,
+		"blobs/" + sha512Hash + "/5":                                                      remoteexecution.DigestFunction_SHA512,
+		"instance/blobs/sha512/" + sha512Hash + "/5/foo.f":                                remoteexecution.DigestFunction_SHA512,
+		"uploads/6ba7b814-9dad-11d1-80b4-00c04fd430c8/blobs/sha512/" + sha512Hash + "/5":  remoteexecution.DigestFunction_SHA512,
+		"i/uploads/6ba7b814-9dad-11d1-80b4-00c04fd430c8/blobs/" + sha256Hash + "/5/foo.f": remoteexecution.DigestFunction_SHA256,
+	} {
+		parse := ParseReadResource
+		if strings.Contains(name, ResourceNameAction) {
+			parse = ParseWriteResource
+		}
+		r, err := parse(name)
+		if err != nil {
+			t.Fatalf("Failed to parse valid resource name: %s: %v", name, err)
+		}
+		if r.DigestFunction != fn {
+			t.Fatalf("Expected digest function %s parsing %s, got %s", fn, name, r.DigestFunction)
+		}
+	}
+
+	for _, name := range []string{
+		"blobs/sha512/" + sha256Hash + "/5",
+		"blobs/sha256/" + sha512Hash + "/5",
+		"blobs/sha512/5",
+	} {
+		if r, err := ParseReadResource(name); err == nil {
+			t.Fatalf("Expected failure to parse resource name with mismatched digest function: %s, got resource: %s", name, r)
+		}
+	}
+	name := "uploads/6ba7b814-9dad-11d1-80b4-00c04fd430c8/blobs/sha512/" + sha256Hash + "/5"
+	if r, err := ParseWriteResource(name); err == nil {
+		t.Fatalf("Expected failure to parse resource name with mismatched digest function: %s, got resource: %s", name, r)
+	}
+}
+
 func resourceEq(r1 *Resource, r2 *Resource) bool {
 	if (r1 == nil) != (r2 == nil) {
 		return false
@@ -0,0 +1,298 @@
+package cas
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+const (
+	// How often the scrubber starts a pass over the store
+	DefaultScrubInterval = 24 * time.Hour
+
+	// Blobs modified more recently than this are skipped, as they may still be being written
+	DefaultScrubMinAge = 10 * time.Minute
+
+	// Directory, relative to the store root, that corrupted blobs are moved to when quarantining
+	ScrubQuarantineDir = "quarantine"
+)
+
+// Matches the store names of CAS blobs, capturing the digest function if not SHA256, and the hash.
+// See bazel.DigestFunctionStoreName
+var blobNameRE = regexp.MustCompile(`^` + bazel.StorePrefix + `-(?:([a-z0-9]+)-)?([a-f0-9]+)\.` + bazel.StorePrefix + `$`)
+
+// Configuration for the background scrubber, which re-hashes CAS blobs in a FileStore directory
+// and removes blobs whose contents no longer match the digest in their name. Zero values use the defaults.
+type ScrubConfig struct {
+	Interval time.Duration
+	MinAge   time.Duration
+	// Maximum bytes read per second, so a pass doesn't starve requests of disk bandwidth.
+	// Zero is interpretted as unlimited.
+	BytesPerSec int64
+	// If true corrupted blobs are moved to ScrubQuarantineDir for inspection, otherwise they're deleted.
+	Quarantine bool
+}
+
+// Results of a single scrub pass
+type ScrubResult struct {
+	Checked      int
+	CheckedBytes int64
+	Corrupted    []string
+}
+
+// Verifies every CAS blob in dir, the root of a FileStore, and in its pack files against the digest in its name,
+// quarantining or deleting the corrupted ones. Blobs that can't be read are logged and skipped.
+//
+// ActionCache results are stored under names that look like CAS blobs but aren't the digest
+// of their contents (see cacheResultAddress), so mismatched blobs that are well formed
+// ActionResults are left alone. This means a corrupted ActionResult may go undetected,
+// but the scrubber never removes a valid one.
+func Scrub(dir string, cfg ScrubConfig) (ScrubResult, error) {
+	cfg = scrubConfigWithDefaults(cfg)
+	result := ScrubResult{}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return result, err
+	}
+	cutoff := time.Now().Add(-cfg.MinAge)
+	for _, info := range infos {
+		fn, hash, isBlob := parseBlobName(info.Name())
+		if info.IsDir() || !isBlob || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		start := time.Now()
+		ok, err := verifyBlob(openFile(filepath.Join(dir, info.Name())), fn, hash)
+		if err != nil {
+			// Most likely the blob expired or was rewritten since the directory was listed
+			log.Infof("Scrubber skipping %s: %v", info.Name(), err)
+			continue
+		}
+		result.Checked++
+		result.CheckedBytes += info.Size()
+		if !ok {
+			result.Corrupted = append(result.Corrupted, info.Name())
+			if err := removeCorruptedBlob(dir, info.Name(), cfg.Quarantine); err != nil {
+				return result, err
+			}
+		}
+		throttle(start, info.Size(), cfg.BytesPerSec)
+	}
+	return result, scrubPacked(dir, cfg, cutoff, &result)
+}
+
+// Scrubs the CAS blobs in the pack files of the FileStore at dir. Packed blobs that were also rewritten
+// to the root are read from the root, so they're left to the root's pass.
+func scrubPacked(dir string, cfg ScrubConfig, cutoff time.Time, result *ScrubResult) error {
+	fs, err := store.MakeFileStore(dir)
+	if err != nil {
+		return err
+	}
+	corrupted := []string{}
+	for _, b := range fs.PackedBlobs() {
+		fn, hash, isBlob := parseBlobName(b.Name)
+		if !isBlob || !b.ModTime.Before(cutoff) {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(dir, b.Name)); err == nil {
+			continue
+		}
+		start := time.Now()
+		ok, err := verifyBlob(func() (io.ReadCloser, error) { return fs.OpenForRead(b.Name) }, fn, hash)
+		if err != nil {
+			log.Infof("Scrubber skipping packed %s: %v", b.Name, err)
+			continue
+		}
+		result.Checked++
+		result.CheckedBytes += b.Size
+		if !ok {
+			result.Corrupted = append(result.Corrupted, b.Name)
+			corrupted = append(corrupted, b.Name)
+			if cfg.Quarantine {
+				if err := quarantinePackedBlob(fs, dir, b.Name); err != nil {
+					return err
+				}
+			}
+		}
+		throttle(start, b.Size, cfg.BytesPerSec)
+	}
+	if len(corrupted) == 0 {
+		return nil
+	}
+	if !cfg.Quarantine {
+		log.Errorf("Scrubber deleting corrupted packed CAS blobs in %s: %v", dir, corrupted)
+	}
+	_, err = fs.RemovePacked(corrupted)
+	return err
+}
+
+// Scrubs dir every cfg.Interval. Never returns.
+func ScrubPeriodically(dir string, cfg ScrubConfig, stat stats.StatsReceiver) {
+	cfg = scrubConfigWithDefaults(cfg)
+	for range time.NewTicker(cfg.Interval).C {
+		start := time.Now()
+		result, err := Scrub(dir, cfg)
+		stat.Counter(stats.BzScrubCheckedCounter).Inc(int64(result.Checked))
+		stat.Counter(stats.BzScrubCheckedBytesCounter).Inc(result.CheckedBytes)
+		stat.Counter(stats.BzScrubCorruptedCounter).Inc(int64(len(result.Corrupted)))
+		stat.Gauge(stats.BzScrubLastPassLatency_ms).Update(int64(time.Since(start) / time.Millisecond))
+		if err != nil {
+			stat.Counter(stats.BzScrubFailureCounter).Inc(1)
+			log.Errorf("Error scrubbing CAS blobs in %s: %v", dir, err)
+		}
+		if len(result.Corrupted) > 0 {
+			log.Errorf("Scrubber found %d corrupted CAS blobs in %s: %v", len(result.Corrupted), dir, result.Corrupted)
+		}
+		log.Infof("Scrubbed %d CAS blobs (%d bytes) in %s in %v", result.Checked, result.CheckedBytes, dir, time.Since(start))
+	}
+}
+
+func scrubConfigWithDefaults(cfg ScrubConfig) ScrubConfig {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultScrubInterval
+	}
+	if cfg.MinAge <= 0 {
+		cfg.MinAge = DefaultScrubMinAge
+	}
+	return cfg
+}
+
+// Returns whether name is the store name of a CAS blob, or an ActionCache result, see bazel.DigestFunctionStoreName.
+func IsBlobName(name string) bool {
+	_, _, ok := parseBlobName(name)
+	return ok
+}
+
+// Returns true if the contents of the file at path are a valid blob for the store name name: they hash to the
+// digest in the name, or are an ActionResult. CAS blobs written outside the CAS API, ex: replicated from another
+// region over HTTP, are checked with this first.
+func VerifyBlobFile(path, name string) (bool, error) {
+	fn, hash, ok := parseBlobName(name)
+	if !ok {
+		return false, fmt.Errorf("Not a CAS blob name: %s", name)
+	}
+	return verifyBlob(openFile(path), fn, hash)
+}
+
+// Returns the digest function and hash of a CAS blob's store name, and false if it isn't one.
+func parseBlobName(name string) (remoteexecution.DigestFunction, string, bool) {
+	m := blobNameRE.FindStringSubmatch(name)
+	if m == nil {
+		return remoteexecution.DigestFunction_UNKNOWN, "", false
+	}
+	fn := remoteexecution.DigestFunction_SHA256
+	if m[1] != "" {
+		var known bool
+		if fn, known = bazel.ParseDigestFunction(m[1]); !known {
+			return remoteexecution.DigestFunction_UNKNOWN, "", false
+		}
+	}
+	if !bazel.IsValidDigestFunction(fn, m[2], 0) {
+		return remoteexecution.DigestFunction_UNKNOWN, "", false
+	}
+	return fn, m[2], true
+}
+
+func openFile(path string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) { return os.Open(path) }
+}
+
+// Returns true if the contents of the blob opened by open hash to the given hash under digest function fn,
+// or are an ActionResult.
+func verifyBlob(open func() (io.ReadCloser, error), fn remoteexecution.DigestFunction, hash string) (bool, error) {
+	h, err := bazel.NewDigestHash(fn)
+	if err != nil {
+		return false, err
+	}
+	r, err := open()
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+	if _, err := io.Copy(h, r); err != nil {
+		return false, err
+	}
+	if hex.EncodeToString(h.Sum(nil)) == hash {
+		return true, nil
+	}
+	r2, err := open()
+	if err != nil {
+		return false, err
+	}
+	defer r2.Close()
+	data, err := ioutil.ReadAll(r2)
+	if err != nil {
+		return false, err
+	}
+	return isActionResult(data), nil
+}
+
+// Returns true if data is a serialized ActionResult, as written by UpdateActionResult.
+// Requiring it to reserialize to the same bytes rejects most data that merely happens to parse.
+func isActionResult(data []byte) bool {
+	ar := &remoteexecution.ActionResult{}
+	if len(data) == 0 || proto.Unmarshal(data, ar) != nil {
+		return false
+	}
+	b, err := proto.Marshal(ar)
+	return err == nil && bytes.Equal(b, data)
+}
+
+func removeCorruptedBlob(dir, name string, quarantine bool) error {
+	path := filepath.Join(dir, name)
+	if !quarantine {
+		log.Errorf("Scrubber deleting corrupted CAS blob %s", path)
+		return os.Remove(path)
+	}
+	qdir := filepath.Join(dir, ScrubQuarantineDir)
+	if err := os.MkdirAll(qdir, 0755); err != nil {
+		return err
+	}
+	log.Errorf("Scrubber quarantining corrupted CAS blob %s in %s", path, qdir)
+	return os.Rename(path, filepath.Join(qdir, name))
+}
+
+// Copies the packed blob name to the quarantine dir. The caller removes it from its pack.
+func quarantinePackedBlob(fs *store.FileStore, dir, name string) error {
+	qdir := filepath.Join(dir, ScrubQuarantineDir)
+	if err := os.MkdirAll(qdir, 0755); err != nil {
+		return err
+	}
+	r, err := fs.OpenForRead(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := os.Create(filepath.Join(qdir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	log.Errorf("Scrubber quarantining corrupted packed CAS blob %s in %s", name, qdir)
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// Sleeps long enough that reading size bytes since start doesn't exceed bytesPerSec.
+func throttle(start time.Time, size, bytesPerSec int64) {
+	if bytesPerSec <= 0 {
+		return
+	}
+	want := time.Duration(float64(size) / float64(bytesPerSec) * float64(time.Second))
+	if elapsed := time.Since(start); elapsed < want {
+		time.Sleep(want - elapsed)
+	}
+}
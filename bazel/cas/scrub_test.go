@@ -0,0 +1,125 @@
+package cas
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+func writeScrubTestBlob(t *testing.T, dir, name string, data []byte, modTime time.Time) {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func scrubTestBlobName(data []byte) string {
+	return fmt.Sprintf("blob-%x.blob", sha256.Sum256(data))
+}
+
+func TestScrub(t *testing.T) {
+	for _, quarantine := range []bool{false, true} {
+		dir, err := ioutil.TempDir("", "scrub")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		old := time.Now().Add(-time.Hour)
+		good := []byte("good")
+		writeScrubTestBlob(t, dir, scrubTestBlobName(good), good, old)
+
+		corrupted := scrubTestBlobName([]byte("original"))
+		writeScrubTestBlob(t, dir, corrupted, []byte("0riginal"), old)
+
+		// Written too recently to be checked
+		recent := scrubTestBlobName([]byte("in progress"))
+		writeScrubTestBlob(t, dir, recent, []byte("in prog"), time.Now())
+
+		// ActionCache results aren't named by the digest of their contents
+		ar, _ := proto.Marshal(&remoteexecution.ActionResult{ExitCode: 1, StdoutRaw: []byte("out")})
+		acName := scrubTestBlobName([]byte("action-ActionCacheResult"))
+		writeScrubTestBlob(t, dir, acName, ar, old)
+
+		// Not a CAS blob
+		writeScrubTestBlob(t, dir, "bundle.bin", []byte("bundle"), old)
+
+		result, err := Scrub(dir, ScrubConfig{Quarantine: quarantine})
+		if err != nil {
+			t.Fatalf("Unexpected error scrubbing: %v", err)
+		}
+		if result.Checked != 3 || len(result.Corrupted) != 1 || result.Corrupted[0] != corrupted {
+			t.Errorf("Expected 3 blobs checked and %s corrupted, got %+v", corrupted, result)
+		}
+
+		for _, name := range []string{scrubTestBlobName(good), recent, acName, "bundle.bin"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("Expected %s to be kept, got %v", name, err)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, corrupted)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", corrupted, err)
+		}
+		_, err = os.Stat(filepath.Join(dir, ScrubQuarantineDir, corrupted))
+		if quarantine && err != nil {
+			t.Errorf("Expected %s to be quarantined, got %v", corrupted, err)
+		} else if !quarantine && !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted, got %v", corrupted, err)
+		}
+	}
+}
+
+func TestScrubPacked(t *testing.T) {
+	for _, quarantine := range []bool{false, true} {
+		dir, err := ioutil.TempDir("", "scrub")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		fs, err := store.MakeFileStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		old := time.Now().Add(-2 * time.Hour)
+		good := []byte("good")
+		writeScrubTestBlob(t, dir, scrubTestBlobName(good), good, old)
+		corrupted := scrubTestBlobName([]byte("original"))
+		writeScrubTestBlob(t, dir, corrupted, []byte("0riginal"), old)
+		if result, err := fs.Compact(store.CompactionConfig{}); err != nil || result.Packed != 2 {
+			t.Fatalf("Expected 2 blobs packed, got %+v %v", result, err)
+		}
+
+		result, err := Scrub(dir, ScrubConfig{Quarantine: quarantine})
+		if err != nil {
+			t.Fatalf("Unexpected error scrubbing: %v", err)
+		}
+		if result.Checked != 2 || len(result.Corrupted) != 1 || result.Corrupted[0] != corrupted {
+			t.Errorf("Expected 2 blobs checked and %s corrupted, got %+v", corrupted, result)
+		}
+		if ok, err := fs.Exists(scrubTestBlobName(good)); !ok || err != nil {
+			t.Errorf("Expected %s to be kept, got %t %v", scrubTestBlobName(good), ok, err)
+		}
+		if ok, err := fs.Exists(corrupted); ok || err != nil {
+			t.Errorf("Expected %s to be removed, got %t %v", corrupted, ok, err)
+		}
+		_, err = os.Stat(filepath.Join(dir, ScrubQuarantineDir, corrupted))
+		if quarantine && err != nil {
+			t.Errorf("Expected %s to be quarantined, got %v", corrupted, err)
+		} else if !quarantine && !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted, got %v", corrupted, err)
+		}
+	}
+}
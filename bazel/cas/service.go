@@ -10,7 +10,6 @@ import (
 	"io/ioutil"
 	"net"
 	"sync"
-	"time"
 
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
@@ -29,34 +28,87 @@ import (
 )
 
 // Implements GRPCServer, remoteexecution.ContentAddressableStoreServer,
-// remoteexecution.ActionCacheServer, bytestream.ByteStreamServer interfaces
+// remoteexecution.ActionCacheServer, remoteexecution.CapabilitiesServer, bytestream.ByteStreamServer interfaces
 type casServer struct {
 	listener    net.Listener
 	server      *grpc.Server
 	storeConfig *store.StoreConfig
+	existence   *existenceCache
+	inflight    *inflightBlobs
+	usage       *usageTracker
+	ttl         *ttlTracker
+	quota       *QuotaTracker
+	shards      *shardRouter
+	mode        *ModeSwitch
+	upstream    *upstreamCAS
 	stat        stats.StatsReceiver
+	// Zero is interpretted as unlimited
+	maxBlobSize int64
 }
 
+// Limits on blobs uploaded to the CAS
+type BlobLimitConfig struct {
+	// Largest blob accepted, in bytes. Zero is interpretted as unlimited.
+	MaxBlobSize int64
+}
+
+var DefaultBlobLimitConfig = BlobLimitConfig{MaxBlobSize: DefaultMaxBlobSize}
+
 // Creates a new GRPCServer (CASServer/ByteStreamServer/ActionCacheServer)
-// based on GRPCConfig, StoreConfig, and StatsReceiver, and preregisters the service
-func MakeCASServer(gc *bazel.GRPCConfig, sc *store.StoreConfig, stat stats.StatsReceiver) *casServer {
+// based on GRPCConfig, StoreConfig, ExistenceCacheConfig, ShardConfig, BlobLimitConfig, ModeSwitch, UpstreamConfig,
+// QuotaTracker, TTLReportConfig and StatsReceiver, and preregisters the service. If ec is nil, DefaultExistenceCacheConfig is used. If shc is nil,
+// this server stores all digests itself, otherwise requests for digests owned by other servers in shc.Cluster are
+// forwarded to them. If bl is nil, DefaultBlobLimitConfig is used. If ms is nil, the server always runs in ModeNormal.
+// If up is non-nil, the server is a caching proxy for the upstream CAS at up.Addr.
+// Blobs and ActionResults stored count against the quota of their tenant in qt, which may be nil for no quotas.
+// If tc is nil, the TTL report samples at DefaultTTLSampleRate and doesn't persist across restarts.
+func MakeCASServer(gc *bazel.GRPCConfig, sc *store.StoreConfig, ec *ExistenceCacheConfig, shc *ShardConfig,
+	bl *BlobLimitConfig, ms *ModeSwitch, up *UpstreamConfig, qt *QuotaTracker, tc *TTLReportConfig,
+	stat stats.StatsReceiver) *casServer {
 	if gc == nil {
 		return nil
 	}
+	if ec == nil {
+		ec = &DefaultExistenceCacheConfig
+	}
+	if bl == nil {
+		bl = &DefaultBlobLimitConfig
+	}
+	if tc == nil {
+		tc = &TTLReportConfig{}
+	}
 
 	l, err := gc.NewListener()
 	if err != nil {
 		panic(err)
 	}
-	gs := gc.NewGRPCServer()
+	gs := gc.NewGRPCServer(stat)
 	g := casServer{
 		listener:    l,
 		server:      gs,
 		storeConfig: sc,
+		existence:   newExistenceCache(*ec),
+		inflight:    newInflightBlobs(),
+		usage:       newUsageTracker(stat),
+		ttl:         newTTLTracker(*tc, stat),
+		quota:       qt,
+		mode:        ms,
 		stat:        stat,
+		maxBlobSize: bl.MaxBlobSize,
+	}
+	if shc != nil {
+		g.shards = newShardRouter(*shc, stat)
+	}
+	if up != nil {
+		if g.upstream, err = newUpstreamCAS(*up, stat); err != nil {
+			panic(err)
+		}
 	}
+	go g.usage.loop(DefaultUsageReportInterval)
+	go g.ttl.loop(DefaultTTLReportInterval)
 	remoteexecution.RegisterContentAddressableStorageServer(g.server, &g)
 	remoteexecution.RegisterActionCacheServer(g.server, &g)
+	remoteexecution.RegisterCapabilitiesServer(g.server, &g)
 	bytestream.RegisterByteStreamServer(g.server, &g)
 	return &g
 }
@@ -70,6 +122,15 @@ func (s *casServer) IsInitialized() bool {
 	return true
 }
 
+// Returns an error if a blob of the given size can't be accepted
+func (s *casServer) checkBlobSize(size int64) error {
+	if s.maxBlobSize > 0 && size > s.maxBlobSize {
+		s.stat.Counter(stats.BzBlobTooLargeCounter).Inc(1)
+		return fmt.Errorf("Blob size %d exceeds max blob size %d", size, s.maxBlobSize)
+	}
+	return nil
+}
+
 func (s *casServer) Serve() error {
 	log.Info("Serving GRPC CAS API on: ", s.listener.Addr())
 	return s.server.Serve(s.listener)
@@ -86,6 +147,9 @@ func (s *casServer) FindMissingBlobs(
 	if !s.IsInitialized() {
 		return nil, status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(false); err != nil {
+		return nil, err
+	}
 
 	var err error = nil
 	var length int64 = 0
@@ -108,6 +172,15 @@ func (s *casServer) FindMissingBlobs(
 	length = int64(len(req.GetBlobDigests()))
 	log.Infof("Processing CAS FindMissingBlobs request of length: %d", length)
 
+	// Ask the owners of digests owned by other servers
+	digests, byOwner := s.shards.partition(ctx, req.GetBlobDigests())
+	var forwarded []*remoteexecution.Digest
+	if len(byOwner) > 0 {
+		if forwarded, err = s.shards.findMissingBlobs(ctx, req.GetInstanceName(), byOwner); err != nil {
+			return nil, err
+		}
+	}
+
 	go func() {
 		for d := range resultCh {
 			if d != nil {
@@ -119,7 +192,7 @@ func (s *casServer) FindMissingBlobs(
 	}()
 
 	// Perform operations in goroutines
-	for _, digest := range req.GetBlobDigests() {
+	for _, digest := range digests {
 		if err != nil {
 			break
 		}
@@ -134,12 +207,30 @@ func (s *casServer) FindMissingBlobs(
 			}
 
 			storeName := bazel.DigestStoreName(d)
+			switch s.existence.lookup(storeName) {
+			case existenceMissing:
+				s.stat.Counter(stats.BzFindBlobsMissingCacheHitCounter).Inc(1)
+				s.ttl.recordRead(storeName, false)
+				resultCh <- d
+				return
+			case existencePresent:
+				s.stat.Counter(stats.BzFindBlobsPresentCacheHitCounter).Inc(1)
+				s.ttl.recordRead(storeName, true)
+				resultCh <- nil
+				return
+			}
+
 			if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
 				log.Errorf("Error checking existence of %s: %v", storeName, err)
 				err = fmt.Errorf("Store failed checking existence of one or more digests")
 			} else if !exists {
+				s.existence.recordMissing(storeName)
+				s.ttl.recordRead(storeName, false)
 				resultCh <- d
 				return
+			} else {
+				s.existence.recordPresent(storeName)
+				s.ttl.recordRead(storeName, true)
 			}
 			resultCh <- nil
 		}(digest)
@@ -150,6 +241,9 @@ func (s *casServer) FindMissingBlobs(
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// Blobs missing locally are only missing if the upstream doesn't have them either
+	res.MissingBlobDigests = s.upstream.findMissing(ctx, req.GetInstanceName(), res.MissingBlobDigests)
+	res.MissingBlobDigests = append(res.MissingBlobDigests, forwarded...)
 	log.Infof("Returning CAS FindMissingBlobs missing digests: %s", res.MissingBlobDigests)
 	return &res, nil
 }
@@ -163,6 +257,9 @@ func (s *casServer) BatchUpdateBlobs(
 	if !s.IsInitialized() {
 		return nil, status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(true); err != nil {
+		return nil, err
+	}
 
 	var length int64 = 0
 	var err error = nil
@@ -187,6 +284,41 @@ func (s *casServer) BatchUpdateBlobs(
 	length = int64(len(req.GetRequests()))
 	log.Infof("Processing CAS BatchUpdateBlobs request of length: %d", length)
 
+	// Reject blobs that fail their optional checksums before they're written or forwarded
+	sums := incomingChecksums(ctx, BatchCRC32CHeader)
+	if sums != nil && len(sums) != len(req.GetRequests()) {
+		err = fmt.Errorf("Got %d requests, %s has %d checksums", len(req.GetRequests()), BatchCRC32CHeader, len(sums))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	priority := incomingCachePriority(ctx)
+	tenant := s.quota.contextTenant(ctx)
+
+	// Blobs owned by other servers are written by their owners
+	blobReqs := []*remoteexecution.BatchUpdateBlobsRequest_Request{}
+	byOwner := map[string][]*remoteexecution.BatchUpdateBlobsRequest_Request{}
+	for i, r := range req.GetRequests() {
+		if sums != nil {
+			if sum := CRC32C(r.GetData()); sum != sums[i] {
+				log.Errorf("Blob %s has CRC32C %s, expected %s", r.GetDigest().GetHash(), sum, sums[i])
+				s.stat.Counter(stats.BzChecksumMismatchCounter).Inc(1)
+				res.Responses = append(res.GetResponses(), &remoteexecution.BatchUpdateBlobsResponse_Response{
+					Digest: r.GetDigest(),
+					Status: &google_rpc_status.Status{
+						Code:    int32(google_rpc_code.Code_DATA_LOSS),
+						Message: fmt.Sprintf("Request %d failed CRC32C verification", i),
+					},
+				})
+				continue
+			}
+		}
+		if addr, ok := s.shards.owner(ctx, r.GetDigest()); ok {
+			byOwner[addr] = append(byOwner[addr], r)
+		} else {
+			blobReqs = append(blobReqs, r)
+		}
+	}
+
 	go func() {
 		for r := range resultCh {
 			res.Responses = append(res.GetResponses(), r)
@@ -196,7 +328,7 @@ func (s *casServer) BatchUpdateBlobs(
 	}()
 
 	// Perform operations in goroutines
-	for _, blobReq := range req.GetRequests() {
+	for _, blobReq := range blobReqs {
 		if err != nil {
 			break
 		}
@@ -214,6 +346,17 @@ func (s *casServer) BatchUpdateBlobs(
 				return
 			}
 
+			// Reject blobs over the size limit
+			if sizeErr := s.checkBlobSize(r.GetDigest().GetSizeBytes()); sizeErr != nil {
+				log.Errorf("Rejecting blob %s: %v", r.GetDigest().GetHash(), sizeErr)
+				writeRes.Status = &google_rpc_status.Status{
+					Code:    int32(google_rpc_code.Code_INVALID_ARGUMENT),
+					Message: sizeErr.Error(),
+				}
+				resultCh <- writeRes
+				return
+			}
+
 			// Verify data length with Digest size
 			if int64(len(r.GetData())) != r.GetDigest().GetSizeBytes() {
 				log.Errorf("Data length/digest mismatch: %d/%d", len(r.GetData()), r.GetDigest().GetSizeBytes())
@@ -224,8 +367,9 @@ func (s *casServer) BatchUpdateBlobs(
 				resultCh <- writeRes
 				return
 			}
-			// Verify buffer SHA with Digest SHA
-			if bufferHash := fmt.Sprintf("%x", sha256.Sum256(r.GetData())); bufferHash != r.GetDigest().GetHash() {
+			// Verify buffer hash with Digest hash
+			fn := bazel.InferDigestFunction(r.GetDigest().GetHash())
+			if bufferHash, _ := bazel.HashData(fn, r.GetData()); bufferHash != r.GetDigest().GetHash() {
 				log.Errorf("Data hash/digest hash mismatch: %s/%s", bufferHash, r.GetDigest().GetHash())
 				writeRes.Status = &google_rpc_status.Status{
 					Code:    int32(google_rpc_code.Code_INVALID_ARGUMENT),
@@ -236,13 +380,39 @@ func (s *casServer) BatchUpdateBlobs(
 			}
 
 			storeName := bazel.DigestStoreName(r.GetDigest())
+
+			// Reject blobs not yet in the Store that would take the tenant over its quota
+			reserved := false
+			if s.quota != nil {
+				if exists, _ := s.storeConfig.Store.Exists(storeName); !exists {
+					if quotaErr := s.quota.Reserve(tenant, r.GetDigest().GetSizeBytes()); quotaErr != nil {
+						log.Errorf("Rejecting blob %s: %v", r.GetDigest().GetHash(), quotaErr)
+						writeRes.Status = &google_rpc_status.Status{
+							Code:    int32(google_rpc_code.Code_RESOURCE_EXHAUSTED),
+							Message: quotaErr.Error(),
+						}
+						resultCh <- writeRes
+						return
+					}
+					reserved = true
+				}
+			}
+
 			buffer := bytes.NewReader(r.GetData())
-			writeErr := s.writeToStore(storeName, buffer)
+			writeErr := s.writeToStore(storeName, buffer, priority)
 			if writeErr != nil {
+				if reserved {
+					s.quota.Release(tenant, r.GetDigest().GetSizeBytes())
+				}
 				writeRes.Status = &google_rpc_status.Status{
 					Code:    int32(google_rpc_code.Code_INTERNAL),
 					Message: writeErr.Error(),
 				}
+			} else if upErr := s.upstream.write(ctx, req.GetInstanceName(), r.GetDigest(), r.GetData()); upErr != nil {
+				writeRes.Status = &google_rpc_status.Status{
+					Code:    int32(google_rpc_code.Code_UNAVAILABLE),
+					Message: fmt.Sprintf("Failed writing to upstream CAS: %v", upErr),
+				}
 			} else {
 				writeRes.Status = &google_rpc_status.Status{
 					Code: int32(google_rpc_code.Code_OK),
@@ -257,6 +427,17 @@ func (s *casServer) BatchUpdateBlobs(
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	var uploadedBlobs, uploadedBytes int64
+	for _, r := range res.GetResponses() {
+		if r.GetStatus().GetCode() == int32(google_rpc_code.Code_OK) {
+			uploadedBlobs++
+			uploadedBytes += r.GetDigest().GetSizeBytes()
+		}
+	}
+	s.usage.recordUpload(ctx, uploadedBlobs, uploadedBytes)
+	if len(byOwner) > 0 {
+		res.Responses = append(res.GetResponses(), s.shards.batchUpdateBlobs(ctx, req.GetInstanceName(), byOwner)...)
+	}
 	log.Infof("Finished processing CAS BatchUpdateBlobs Request of length: %d", length)
 	return res, err
 }
@@ -270,6 +451,9 @@ func (s *casServer) BatchReadBlobs(
 	if !s.IsInitialized() {
 		return nil, status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(false); err != nil {
+		return nil, err
+	}
 
 	var length int64 = 0
 	var err error = nil
@@ -294,6 +478,12 @@ func (s *casServer) BatchReadBlobs(
 	length = int64(len(req.GetDigests()))
 	log.Infof("Processing CAS BatchReadBlobs request of length: %d", length)
 
+	// Blobs owned by other servers are read from their owners
+	digests, byOwner := s.shards.partition(ctx, req.GetDigests())
+	if len(byOwner) > 0 {
+		res.Responses = s.shards.batchReadBlobs(ctx, req.GetInstanceName(), byOwner)
+	}
+
 	go func() {
 		for r := range resultCh {
 			res.Responses = append(res.GetResponses(), r)
@@ -303,7 +493,7 @@ func (s *casServer) BatchReadBlobs(
 	}()
 
 	// Perform operations in goroutines
-	for _, digest := range req.GetDigests() {
+	for _, digest := range digests {
 		if err != nil {
 			break
 		}
@@ -321,9 +511,15 @@ func (s *casServer) BatchReadBlobs(
 				return
 			}
 
-			// Read and return result. We interpret read errors as Not Found
+			// Read and return result. We interpret read errors as Not Found, unless the upstream has the blob
 			storeName := bazel.DigestStoreName(d)
 			r, openErr := s.storeConfig.Store.OpenForRead(storeName)
+			s.ttl.recordRead(storeName, openErr == nil)
+			if openErr != nil {
+				if fr, fetchErr := s.fetchUpstream(ctx, req.GetInstanceName(), d); fetchErr == nil {
+					r, openErr = fr, nil
+				}
+			}
 			if openErr != nil {
 				readRes.Status = &google_rpc_status.Status{
 					Code: int32(google_rpc_code.Code_NOT_FOUND),
@@ -385,6 +581,9 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 	if !s.IsInitialized() {
 		return status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(false); err != nil {
+		return err
+	}
 
 	var length int64 = 0
 	var err error = nil
@@ -419,16 +618,33 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 		return status.Error(codes.InvalidArgument, "Read limit < 0 invalid")
 	}
 
+	// Stream from the owner if another server owns the digest
+	if addr, ok := s.shards.owner(ser.Context(), resource.Digest); ok {
+		err = s.shards.read(addr, req, ser)
+		return err
+	}
+
 	// Map digest to underlying store name
-	storeName := bazel.DigestStoreName(resource.Digest)
+	storeName := resource.StoreName()
 
 	var r io.ReadCloser
 	// If client requested to read Empty data, fulfil the request with a blank interface to bypass the Store
 	if resource.Digest.GetHash() == bazel.EmptySha {
 		r = &nilReader{}
+	} else if r = s.inflight.open(storeName); r != nil {
+		// A Write of this resource is in progress, stream its data as it arrives.
+		// Checked before the Store, as the Write stays in flight until it's stored.
+		log.Infof("Streaming in-flight Write of resource: %s", storeName)
+		s.stat.Counter(stats.BzReadInflightCounter).Inc(1)
 	} else {
 		log.Infof("Opening store resource for reading: %s", storeName)
 		r, err = s.storeConfig.Store.OpenForRead(storeName)
+		s.ttl.recordRead(storeName, err == nil)
+		if err != nil {
+			if fr, fetchErr := s.fetchUpstream(ser.Context(), resource.Instance, resource.Digest); fetchErr == nil {
+				r, err = fr, nil
+			}
+		}
 		if err != nil {
 			// If an error occurred opening the underlying resource, we interpret this as NotFound.
 			// Although we return an error response to the caller to indicate this, we regard this
@@ -458,22 +674,24 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 		c = req.GetReadLimit()
 	}
 
-	// Read data in chunks and stream to client
-	p := make([]byte, 0, c)
+	// Read data in chunks and stream to client, reading the next chunk from the Store while sending the current one
+	p := newPrefetchReader(r, c)
+	defer p.stop()
 	for {
-		n, err := r.Read(p[:cap(p)])
-		p = p[:n]
+		chunk := p.next()
+		n, err := len(chunk.data), chunk.err
 		length += int64(n)
 
 		if n > 0 {
-			res.Data = p
-			err = ser.Send(res)
+			res.Data = chunk.data
+			err := ser.Send(res)
 			if err != nil {
 				log.Errorf("Failed to Send(): %v", err)
 				return status.Error(codes.Internal, fmt.Sprintf("Failed to send ReadResponse: %v", err))
 			}
 			res.Reset()
 		}
+		p.release(chunk)
 
 		if err == nil {
 			continue
@@ -496,6 +714,7 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 // store.Stores do not support partial Writes, and neither does our implementation.
 // We can support partial Write by keeping buffers for inflight requests in the casServer.
 // When the entire Write is buffered, we can Write to the Store and return a response with the result.
+// Data is buffered in an inflightBlob, so concurrent Reads of the resource stream it as it arrives.
 // NOTE We also no not currently attempt any resolution between multiple client UUIDs writing the same resource
 func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	log.Debug("Received CAS Write request")
@@ -503,14 +722,23 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	if !s.IsInitialized() {
 		return status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(true); err != nil {
+		return err
+	}
 
-	var p []byte
-	var buffer *bytes.Buffer
+	var buffer *inflightBlob
+	var reserved bool
+	tenant := s.quota.contextTenant(ser.Context())
 	var committed int64 = 0
 	var resource *Resource = nil
 	resourceName, storeName := "", ""
 	var err error = nil
 
+	// Optional checksums of each chunk and of the whole blob, from the client's headers
+	chunkSums := incomingChecksums(ser.Context(), ChunkCRC32CHeader)
+	commitSums := incomingChecksums(ser.Context(), CommitCRC32CHeader)
+	chunks := 0
+
 	// Record metrics based on final error condition
 	defer func() {
 		if err == nil {
@@ -524,6 +752,16 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	}()
 	defer s.stat.Latency(stats.BzWriteLatency_ms).Time().Stop()
 
+	// Fail any Reads streaming this Write and give back its quota if it doesn't finish storing the data
+	defer func() {
+		if buffer != nil {
+			s.inflight.finish(storeName, buffer, errWriteAborted)
+		}
+		if reserved {
+			s.quota.Release(tenant, resource.Digest.GetSizeBytes())
+		}
+	}()
+
 	// As indicated above, not supporting partial/resumable Writes for now.
 	// Reads in a stream of data from the client, and proceeds when we've gotten it all.
 	for {
@@ -556,12 +794,20 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 				return nil
 			}
 
-			p = make([]byte, 0, resource.Digest.GetSizeBytes())
-			buffer = bytes.NewBuffer(p)
+			// Stream to the owner if another server owns the digest
+			if addr, ok := s.shards.owner(ser.Context(), resource.Digest); ok {
+				return s.shards.write(addr, wr, ser)
+			}
+
+			// Reject oversized blobs before allocating a buffer for them
+			if err := s.checkBlobSize(resource.Digest.GetSizeBytes()); err != nil {
+				log.Errorf("Rejecting Write of %s: %v", resourceName, err)
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
 
 			// If data Exists, terminate immediately with size of existing data (Store is immutable)
 			// Note that Store does not support `stat`, so we trust client-provided size to avoid reading the data
-			storeName = bazel.DigestStoreName(resource.Digest)
+			storeName = resource.StoreName()
 			if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
 				log.Errorf("Error checking existence: %v", err)
 				return status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
@@ -575,6 +821,15 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 				}
 				return nil
 			}
+
+			// Only blobs not yet in the Store count against the tenant's quota
+			if err := s.quota.Reserve(tenant, resource.Digest.GetSizeBytes()); err != nil {
+				log.Errorf("Rejecting Write of %s: %v", resourceName, err)
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			reserved = true
+
+			buffer = s.inflight.start(storeName, resource.Digest.GetSizeBytes())
 		}
 
 		// Validate subsequent WriteRequest fields
@@ -587,7 +842,27 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 			return status.Error(codes.InvalidArgument, fmt.Sprintf("WriteOffset invalid: got %d after committing %d bytes", wr.GetWriteOffset(), committed))
 		}
 
-		buffer.Write(wr.GetData())
+		// Stop buffering as soon as the client sends more than the Digest declared
+		if committed+int64(len(wr.GetData())) > resource.Digest.GetSizeBytes() {
+			log.Errorf("Data exceeds digest size: %d", resource.Digest.GetSizeBytes())
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written exceeds request Digest size: %d", resource.Digest.GetSizeBytes()))
+		}
+
+		// Verify the chunk before Reads streaming this Write can see it
+		if chunkSums != nil {
+			if chunks >= len(chunkSums) {
+				log.Errorf("Write of %s sent more chunks than the %d checksums in its header", resourceName, len(chunkSums))
+				return status.Error(codes.InvalidArgument, fmt.Sprintf("Chunk %d has no checksum in %s", chunks, ChunkCRC32CHeader))
+			}
+			if sum := CRC32C(wr.GetData()); sum != chunkSums[chunks] {
+				log.Errorf("Chunk %d of %s at offset %d has CRC32C %s, expected %s", chunks, resourceName, committed, sum, chunkSums[chunks])
+				s.stat.Counter(stats.BzChecksumMismatchCounter).Inc(1)
+				return status.Error(codes.DataLoss, fmt.Sprintf("Chunk %d at offset %d failed CRC32C verification", chunks, committed))
+			}
+		}
+		chunks++
+
+		buffer.append(wr.GetData())
 		committed += int64(len(wr.GetData()))
 
 		// Per API, client indicates all data has been sent
@@ -601,18 +876,37 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 		log.Errorf("Data length/digest mismatch: %d/%d", committed, resource.Digest.GetSizeBytes())
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written len: %d mismatch with request Digest size: %d", committed, resource.Digest.GetSizeBytes()))
 	}
-	// Verify buffer SHA with Digest SHA
-	if bufferHash := fmt.Sprintf("%x", sha256.Sum256(buffer.Bytes())); bufferHash != resource.Digest.GetHash() {
+	// Verify the commit checksum, so corruption is reported as such rather than as a Digest mismatch
+	if chunkSums != nil && chunks != len(chunkSums) {
+		log.Errorf("Write of %s sent %d chunks, expected %d", resourceName, chunks, len(chunkSums))
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Got %d chunks, %s has %d checksums", chunks, ChunkCRC32CHeader, len(chunkSums)))
+	}
+	if commitSums != nil {
+		if sum := CRC32C(buffer.data); len(commitSums) != 1 || sum != commitSums[0] {
+			log.Errorf("Write of %s has CRC32C %s, expected %v", resourceName, sum, commitSums)
+			s.stat.Counter(stats.BzChecksumMismatchCounter).Inc(1)
+			return status.Error(codes.DataLoss, fmt.Sprintf("Data to be written failed CRC32C verification"))
+		}
+	}
+	// Verify buffer hash with Digest hash
+	if bufferHash, _ := bazel.HashData(resource.DigestFunction, buffer.data); bufferHash != resource.Digest.GetHash() {
 		log.Errorf("Data hash/digest hash mismatch: %s/%s", bufferHash, resource.Digest.GetHash())
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written did not hash to given Digest"))
 	}
 
 	// Write to underlying Store
-	err = s.writeToStore(storeName, buffer)
+	err = s.writeToStore(storeName, bytes.NewReader(buffer.data), incomingCachePriority(ser.Context()))
 	if err != nil {
 		log.Errorf("Store failed to Write: %v", err)
 		return status.Error(codes.Internal, fmt.Sprintf("Store failed writing to %s: %v", storeName, err))
 	}
+	s.inflight.finish(storeName, buffer, nil)
+	reserved = false
+
+	// Only acknowledge the Write once the upstream has the data too
+	if err = s.upstream.write(ser.Context(), resource.Instance, resource.Digest, buffer.data); err != nil {
+		return status.Error(codes.Unavailable, fmt.Sprintf("Failed writing %s to upstream CAS: %v", storeName, err))
+	}
 
 	res := &bytestream.WriteResponse{CommittedSize: committed}
 	err = ser.SendAndClose(res)
@@ -621,6 +915,7 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 		return status.Error(codes.Internal, fmt.Sprintf("Failed to SendAndClose WriteResponse: %v", err))
 	}
 
+	s.usage.recordUpload(ser.Context(), 1, committed)
 	log.Infof("Finished handling Write request for %s, %d bytes", storeName, committed)
 	return nil
 }
@@ -631,6 +926,22 @@ func (s *casServer) QueryWriteStatus(
 	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot - Writes are not resumable")
 }
 
+// Capabilities APIs
+
+// GetCapabilities advertises the digest functions blobs can be addressed by, so clients can negotiate one
+func (s *casServer) GetCapabilities(
+	ctx context.Context, req *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
+	log.Debugf("Received GetCapabilities request: %s", req)
+	return &remoteexecution.ServerCapabilities{
+		CacheCapabilities: &remoteexecution.CacheCapabilities{
+			DigestFunction:                bazel.DigestFunctions(),
+			ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{UpdateEnabled: s.mode.Mode() == ModeNormal},
+		},
+		LowApiVersion:  &remoteexecution.SemVer{Major: 2},
+		HighApiVersion: &remoteexecution.SemVer{Major: 2, Minor: 1},
+	}, nil
+}
+
 // ActionCache APIs
 
 // V2 API requires explicit uploading of Actions prior to Execution. Because of this,
@@ -672,6 +983,9 @@ func (s *casServer) GetActionResult(ctx context.Context,
 	if !s.IsInitialized() {
 		return nil, status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(false); err != nil {
+		return nil, err
+	}
 
 	var err error = nil
 
@@ -696,6 +1010,13 @@ func (s *casServer) GetActionResult(ctx context.Context,
 		return &remoteexecution.ActionResult{}, nil
 	}
 
+	// Results are owned by the server owning their ActionDigest
+	if addr, ok := s.shards.owner(ctx, req.GetActionDigest()); ok {
+		var ar *remoteexecution.ActionResult
+		ar, err = s.shards.getActionResult(ctx, addr, req)
+		return ar, err
+	}
+
 	address, err := makeCacheResultAddress(req.GetActionDigest())
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to create cache result address: %v", err))
@@ -705,13 +1026,21 @@ func (s *casServer) GetActionResult(ctx context.Context,
 	log.Infof("Opening store resource for reading: %s", address.storeName)
 
 	r, err := s.storeConfig.Store.OpenForRead(address.storeName)
+	s.ttl.recordRead(address.storeName, err == nil)
 	if err != nil {
+		if ar, fetchErr := s.fetchUpstreamResult(ctx, req, address); fetchErr == nil {
+			err = nil
+			s.usage.recordACLookup(ctx, bazel.DigestToStr(req.GetActionDigest()), true)
+			log.Infof("GetActionResult returning result fetched from upstream: %s", ar)
+			return ar, nil
+		}
 		// If an error occurred opening the underlying resource, we interpret this as NotFound.
 		// Although we return an error response to the caller to indicate this, we regard this
 		// as a normal defined behavior of the API, and don't count it towards failure metrics.
 		// Reset err to nil to prevent recording as a failure.
 		openErr := err
 		err = nil
+		s.usage.recordACLookup(ctx, bazel.DigestToStr(req.GetActionDigest()), false)
 		log.Errorf("Failed to OpenForRead: %v", openErr)
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("Failed opening %s for read, returning NotFound. Err: %v", address.storeName, openErr))
 	}
@@ -731,10 +1060,55 @@ func (s *casServer) GetActionResult(ctx context.Context,
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Error deserializing ActionResult: %s", err))
 	}
 
+	s.inlineOutputs(ar, req)
+	s.usage.recordACLookup(ctx, bazel.DigestToStr(req.GetActionDigest()), true)
 	log.Infof("GetActionResult returning cached result: %s", ar)
 	return ar, nil
 }
 
+// Inlines the stdout, stderr and output file contents requested by req into ar, reading them from the Store.
+// Blobs over MaxInlineBlobSize, or that would take the response over MaxInlineTotalSize, are left for the
+// client to fetch from the CAS, as are any that fail to be read, since inlining is only an optimization.
+func (s *casServer) inlineOutputs(ar *remoteexecution.ActionResult, req *remoteexecution.GetActionResultRequest) {
+	var inlined int64 = 0
+	inline := func(d *remoteexecution.Digest) []byte {
+		if d == nil || d.GetSizeBytes() > MaxInlineBlobSize || inlined+d.GetSizeBytes() > MaxInlineTotalSize {
+			return nil
+		}
+		if d.GetHash() == bazel.EmptySha {
+			return []byte{}
+		}
+		data, err := s.readFromStore(bazel.DigestStoreName(d))
+		if err != nil {
+			log.Errorf("Failed to read %s for inlining: %v", bazel.DigestToStr(d), err)
+			return nil
+		}
+		inlined += int64(len(data))
+		return data
+	}
+
+	if req.GetInlineStdout() && ar.GetStdoutRaw() == nil {
+		ar.StdoutRaw = inline(ar.GetStdoutDigest())
+	}
+	if req.GetInlineStderr() && ar.GetStderrRaw() == nil {
+		ar.StderrRaw = inline(ar.GetStderrDigest())
+	}
+	if len(req.GetInlineOutputFiles()) > 0 {
+		paths := map[string]bool{}
+		for _, p := range req.GetInlineOutputFiles() {
+			paths[p] = true
+		}
+		for _, f := range ar.GetOutputFiles() {
+			if paths[f.GetPath()] && f.GetContents() == nil {
+				f.Contents = inline(f.GetDigest())
+			}
+		}
+	}
+	if inlined > 0 {
+		s.stat.Counter(stats.BzGetActionInlinedBytesCounter).Inc(inlined)
+	}
+}
+
 // Client-facing service for caching ActionResults. Support is optional per Bazel API,
 // as the server can still cache and retrieve results internally.
 func (s *casServer) UpdateActionResult(ctx context.Context,
@@ -744,6 +1118,9 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 	if !s.IsInitialized() {
 		return nil, status.Error(codes.Internal, "Server not initialized")
 	}
+	if err := s.mode.check(true); err != nil {
+		return nil, err
+	}
 
 	var err error = nil
 
@@ -771,6 +1148,13 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 		return &remoteexecution.ActionResult{}, nil
 	}
 
+	// Results are owned by the server owning their ActionDigest
+	if addr, ok := s.shards.owner(ctx, req.GetActionDigest()); ok {
+		var ar *remoteexecution.ActionResult
+		ar, err = s.shards.updateActionResult(ctx, addr, req)
+		return ar, err
+	}
+
 	// serialize the AR as bytes, then Store.Write.
 	asBytes, err := proto.Marshal(req.GetActionResult())
 	if err != nil {
@@ -783,18 +1167,28 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to create cache result address: %v", err))
 	}
 
-	// Write to store
-	// Use CAS Default TTL setting until API supports cache priority settings
-	ttl := store.GetTTLValue(s.storeConfig.TTLCfg)
-	if ttl != nil {
-		ttl.TTL = time.Now().Add(DefaultTTL)
+	// Results count against the tenant's quota like blobs do
+	tenant := s.quota.contextTenant(ctx)
+	if err = s.quota.Reserve(tenant, int64(len(asBytes))); err != nil {
+		log.Errorf("Rejecting UpdateActionResult of %s: %v", req.GetActionDigest().GetHash(), err)
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
 	}
 
+	// Write to store, kept for as long as the ResultsCachePolicy's priority calls for, but no longer than its outputs
+	priority := resultCachePriority(req.GetResultsCachePolicy().GetPriority(), incomingCachePriority(ctx))
+	ttl := s.cacheTTL(priority)
 	err = s.storeConfig.Store.Write(address.storeName, bytes.NewReader(asBytes), ttl)
 	if err != nil {
+		s.quota.Release(tenant, int64(len(asBytes)))
 		log.Errorf("Store failed to Write: %v", err)
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Store failed writing to %s: %v", address.storeName, err))
 	}
+	if ttl != nil {
+		s.ttl.recordWrite(address.storeName, int64(len(asBytes)), priority, TTLForCachePriority(priority))
+	}
+	if err = s.upstream.updateActionResult(ctx, req); err != nil {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("Failed writing ActionResult to upstream ActionCache: %v", err))
+	}
 
 	log.Infof("UpdateActionResult wrote result to cache: %s", req.GetActionResult())
 	return req.GetActionResult(), nil
@@ -802,15 +1196,29 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 
 // Internal functions
 
-func (s *casServer) writeToStore(name string, data io.Reader) error {
-	// Using CAS Default TTL setting until API supports cache priority settings
-	ttl := store.GetTTLValue(s.storeConfig.TTLCfg)
-	if ttl != nil {
-		ttl.TTL = time.Now().Add(DefaultTTL)
+func (s *casServer) readFromStore(name string) ([]byte, error) {
+	r, err := s.storeConfig.Store.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// Writes data to the Store, kept for as long as the ResultsCachePolicy priority calls for, see TTLForCachePriority.
+func (s *casServer) writeToStore(name string, data io.Reader, priority int32) error {
+	ttl := s.cacheTTL(priority)
+	size := int64(-1)
+	if l, ok := data.(interface{ Len() int }); ok {
+		size = int64(l.Len())
 	}
 	if err := s.storeConfig.Store.Write(name, data, ttl); err != nil {
 		return err
 	}
+	s.existence.recordPresent(name)
+	if ttl != nil && size >= 0 {
+		s.ttl.recordWrite(name, size, priority, TTLForCachePriority(priority))
+	}
 	return nil
 }
 
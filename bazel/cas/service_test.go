@@ -2,9 +2,13 @@ package cas
 
 import (
 	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	uuid "github.com/nu7hatch/gouuid"
@@ -14,6 +18,7 @@ import (
 	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/twitter/scoot/bazel"
@@ -39,6 +44,9 @@ var testData5 []byte = []byte("eeeeeee")
 var testHash6 string = "5252f52cb79e2276783cfdca50304fed06a0eabc8dbcc3abfe3aaac5792c4fc6"
 var testSize6 int64 = 7
 var testData6 []byte = []byte("rrrrrrr")
+var testHashLarge string = "286aa2b9b1c0b39495413b0a6892d294e3d5c5487acad1d5fbd5f7d70536a76a"
+var testSizeLarge int64 = 14
+var testDataLarge []byte = []byte("abc1234efg9876")
 
 // TODO make batch tests easier to programmatically test above BatchParallelism threshold
 
@@ -87,6 +95,49 @@ func TestFindMissingBlobs(t *testing.T) {
 	}
 }
 
+func TestFindMissingBlobsExistenceCache(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{
+		storeConfig: &store.StoreConfig{Store: f},
+		existence:   newExistenceCache(ExistenceCacheConfig{MissingTTL: time.Hour, MissingMaxEntries: 10, BloomItems: 10}),
+		stat:        stats.NilStatsReceiver(),
+	}
+	d1 := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	d2 := &remoteexecution.Digest{Hash: testHash2, SizeBytes: testSize2}
+	findMissing := func() []*remoteexecution.Digest {
+		req := &remoteexecution.FindMissingBlobsRequest{BlobDigests: []*remoteexecution.Digest{d1, d2}}
+		res, err := s.FindMissingBlobs(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Error response from FindMissingBlobs: %v", err)
+		}
+		return res.GetMissingBlobDigests()
+	}
+
+	if missing := findMissing(); len(missing) != 2 {
+		t.Fatalf("Expected both digests missing, got %v", missing)
+	}
+
+	// Writes that bypass the server aren't seen until the negative cache entry expires
+	f.Write(bazel.DigestStoreName(d1), bytes.NewReader(testData1), nil)
+	if missing := findMissing(); len(missing) != 2 {
+		t.Fatalf("Expected both digests still cached as missing, got %v", missing)
+	}
+
+	// Writes through the server clear the negative cache and are tracked as present
+	req := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{
+			&remoteexecution.BatchUpdateBlobsRequest_Request{Digest: d2, Data: testData2},
+		},
+	}
+	if _, err := s.BatchUpdateBlobs(context.Background(), req); err != nil {
+		t.Fatalf("Error response from BatchUpdateBlobs: %v", err)
+	}
+	f.Files.Delete(bazel.DigestStoreName(d2))
+	if missing := findMissing(); len(missing) != 1 || missing[0] != d1 {
+		t.Fatalf("Expected only d1 missing, got %v", missing)
+	}
+}
+
 func TestRead(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -175,6 +226,68 @@ func TestReadMissing(t *testing.T) {
 	r.reset()
 }
 
+func TestReadInflight(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, inflight: newInflightBlobs(), stat: stats.NilStatsReceiver()}
+
+	// Start a Write without storing anything, and read it while data arrives
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	storeName := bazel.DigestStoreName(d)
+	blob := s.inflight.start(storeName, testSize1)
+
+	req := &bytestream.ReadRequest{ResourceName: fmt.Sprintf("blobs/%s/%d", testHash1, testSize1)}
+	r := makeFakeReadServer()
+	errCh := make(chan error)
+	go func() { errCh <- s.Read(req, r) }()
+
+	blob.append(testData1[:2])
+	blob.append(testData1[2:])
+	if err := f.Write(storeName, bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	s.inflight.finish(storeName, blob, nil)
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("Error response from Read: %v", err)
+	}
+	b, err := ioutil.ReadAll(r.buffer)
+	if err != nil {
+		t.Fatalf("Error reading from fake server data: %v", err)
+	}
+	if bytes.Compare(b, testData1) != 0 {
+		t.Fatalf("Data read from fake server did not match - expected: %s, got: %s", testData1, b)
+	}
+	if s.inflight.open(storeName) != nil {
+		t.Fatal("Expected finished Write to no longer be in flight")
+	}
+}
+
+func TestReadInflightAborted(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, inflight: newInflightBlobs(), stat: stats.NilStatsReceiver()}
+
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	storeName := bazel.DigestStoreName(d)
+	blob := s.inflight.start(storeName, testSize1)
+	blob.append(testData1[:2])
+	s.inflight.finish(storeName, blob, errWriteAborted)
+
+	// The aborted Write is no longer in flight and was never stored
+	req := &bytestream.ReadRequest{ResourceName: fmt.Sprintf("blobs/%s/%d", testHash1, testSize1)}
+	if err := s.Read(req, makeFakeReadServer()); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound reading aborted Write, got: %v", err)
+	}
+
+	// Readers that opened the Write before it ended fail rather than seeing truncated data
+	blob = s.inflight.start(storeName, testSize1)
+	rc := s.inflight.open(storeName)
+	blob.append(testData1[:2])
+	s.inflight.finish(storeName, blob, errWriteAborted)
+	if _, err := ioutil.ReadAll(rc); err != errWriteAborted {
+		t.Fatalf("Expected %v reading aborted Write, got: %v", errWriteAborted, err)
+	}
+}
+
 func TestWrite(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -204,6 +317,81 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestWriteChecksums(t *testing.T) {
+	chunkSums := []string{CRC32C(testData1[0:2]), CRC32C(testData1[2:4]), CRC32C(testData1[4:])}
+	for _, c := range []struct {
+		md   metadata.MD
+		code codes.Code
+	}{
+		{metadata.Pairs(ChunkCRC32CHeader, strings.Join(chunkSums, ","), CommitCRC32CHeader, CRC32C(testData1)), codes.OK},
+		{metadata.Pairs(ChunkCRC32CHeader, chunkSums[0], ChunkCRC32CHeader, chunkSums[1], ChunkCRC32CHeader, chunkSums[2]), codes.OK},
+		{metadata.Pairs(ChunkCRC32CHeader, strings.Join([]string{chunkSums[0], chunkSums[0], chunkSums[2]}, ",")), codes.DataLoss},
+		{metadata.Pairs(ChunkCRC32CHeader, strings.Join(chunkSums[:2], ",")), codes.InvalidArgument},
+		{metadata.Pairs(CommitCRC32CHeader, CRC32C(testData2)), codes.DataLoss},
+	} {
+		f := &store.FakeStore{}
+		s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+		w := makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+		w.ctx = metadata.NewIncomingContext(context.Background(), c.md)
+
+		err := s.Write(w)
+		if code := status.Code(err); code != c.code {
+			t.Fatalf("Expected %v writing with %v, got: %v", c.code, c.md, err)
+		}
+		exists, _ := f.Exists(bazel.DigestStoreName(&remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}))
+		if exists != (c.code == codes.OK) {
+			t.Fatalf("Expected blob to exist only if Write succeeded, exists: %t with %v", exists, c.md)
+		}
+	}
+}
+
+func TestWriteSHA512(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	sum := sha512.Sum512(testData1)
+	hash := hex.EncodeToString(sum[:])
+	w := makeFakeWriteServer(hash, testSize1, testData1, 3)
+	w.resourceName = strings.Replace(w.resourceName, "/blobs/", "/blobs/sha512/", 1)
+
+	if err := s.Write(w); err != nil {
+		t.Fatalf("Error response from Write: %v", err)
+	}
+	if w.committedSize != testSize1 {
+		t.Fatalf("Size committed to fake server did not match - expected: %d, got: %d", testSize1, w.committedSize)
+	}
+
+	// SHA512 blobs are stored under names including the digest function
+	d := &remoteexecution.Digest{Hash: hash, SizeBytes: testSize1}
+	resourceName := bazel.DigestFunctionStoreName(remoteexecution.DigestFunction_SHA512, d)
+	if resourceName != bazel.DigestStoreName(d) || !strings.Contains(resourceName, "sha512") {
+		t.Fatalf("Unexpected store name for SHA512 digest: %s", resourceName)
+	}
+	if _, err := readAndCompare(f, resourceName, testData1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Data is verified against the named digest function
+	w = makeFakeWriteServer(testHash1, testSize1, testData1, 1)
+	w.resourceName = strings.Replace(w.resourceName, "/blobs/", "/blobs/sha512/", 1)
+	if err := s.Write(w); err == nil {
+		t.Fatal("Expected SHA256 hash to be rejected as a SHA512 digest")
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	s := casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}, stat: stats.NilStatsReceiver()}
+
+	c, err := s.GetCapabilities(context.Background(), &remoteexecution.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("Error response from GetCapabilities: %v", err)
+	}
+	fns := c.GetCacheCapabilities().GetDigestFunction()
+	if len(fns) < 2 || fns[0] != remoteexecution.DigestFunction_SHA256 || fns[1] != remoteexecution.DigestFunction_SHA512 {
+		t.Fatalf("Expected SHA256 and SHA512 digest functions, got %v", fns)
+	}
+}
+
 func TestWriteEmpty(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -256,6 +444,173 @@ func TestWriteExisting(t *testing.T) {
 	}
 }
 
+func TestWriteTooLarge(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), maxBlobSize: testSize1 - 1}
+
+	w := makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+
+	// Write should be rejected on the first request, before any data is buffered
+	err := s.Write(w)
+	if err == nil {
+		t.Fatal("Expected error response from Write exceeding max blob size")
+	}
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument from Write, got: %v", err)
+	}
+	if w.recvCount != 1 {
+		t.Fatalf("Number of write chunks to fake server did not match - expected: %d, got: %d", 1, w.recvCount)
+	}
+}
+
+func TestWriteOverQuota(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(),
+		quota: newQuotaTracker(&QuotaConfig{
+			Bytes:        map[string]int64{"teamA": testSize1},
+			DefaultBytes: testSize1 - 1,
+			Tokens:       map[string]string{"secretA": "teamA"},
+		}, stats.NilStatsReceiver())}
+
+	w := makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+
+	// Write should be rejected on the first request, before any data is buffered
+	err := s.Write(w)
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted from Write, got: %v", err)
+	}
+	if w.recvCount != 1 {
+		t.Fatalf("Number of write chunks to fake server did not match - expected: %d, got: %d", 1, w.recvCount)
+	}
+
+	// Naming a tenant in the instance doesn't spend its quota, only its token does
+	w = makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+	w.resourceName = "teamA/" + w.resourceName
+	if err := s.Write(w); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted from Write naming another tenant, got: %v", err)
+	}
+	w = makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+	w.ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secretA"))
+	if err := s.Write(w); err != nil {
+		t.Fatalf("Error response from Write within the tenant's quota: %v", err)
+	}
+
+	// Blobs already in the Store don't count against the quota
+	if err := s.Write(makeFakeWriteServer(testHash1, testSize1, testData1, 3)); err != nil {
+		t.Fatalf("Error response from Write of existing blob: %v", err)
+	}
+}
+
+func TestUpdateActionResultOverQuota(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(),
+		quota: newQuotaTracker(&QuotaConfig{DefaultBytes: 1}, stats.NilStatsReceiver())}
+
+	ad := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	req := &remoteexecution.UpdateActionResultRequest{ActionDigest: ad, ActionResult: &remoteexecution.ActionResult{ExitCode: 42}}
+	if _, err := s.UpdateActionResult(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted from UpdateActionResult, got: %v", err)
+	}
+}
+
+func TestWriteExceedsDigestSize(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	// Declare a smaller size than the data actually sent
+	w := makeFakeWriteServer(testHash1, 1, testData1, 3)
+
+	err := s.Write(w)
+	if err == nil {
+		t.Fatal("Expected error response from Write exceeding digest size")
+	}
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument from Write, got: %v", err)
+	}
+	if w.recvCount != 1 {
+		t.Fatalf("Number of write chunks to fake server did not match - expected: %d, got: %d", 1, w.recvCount)
+	}
+}
+
+func TestBatchUpdateBlobsTooLarge(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), maxBlobSize: testSize1}
+
+	req := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{
+			&remoteexecution.BatchUpdateBlobsRequest_Request{
+				Digest: &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1},
+				Data:   testData1,
+			},
+			// over max blob size
+			&remoteexecution.BatchUpdateBlobsRequest_Request{
+				Digest: &remoteexecution.Digest{Hash: testHashLarge, SizeBytes: testSizeLarge},
+				Data:   testDataLarge,
+			},
+		},
+	}
+
+	res, err := s.BatchUpdateBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchUpdateBlobs: %s", err)
+	}
+	if len(res.GetResponses()) != 2 {
+		t.Fatalf("Expected 2 responses, got: %d", len(res.GetResponses()))
+	}
+	for _, writeRes := range res.GetResponses() {
+		expected := int32(google_rpc_code.Code_OK)
+		if writeRes.GetDigest().GetSizeBytes() > testSize1 {
+			expected = int32(google_rpc_code.Code_INVALID_ARGUMENT)
+		}
+		if writeRes.Status.Code != expected {
+			t.Fatalf("Unexpected status code %d for hash %s: %d", writeRes.Status.Code, writeRes.GetDigest().GetHash(), expected)
+		}
+	}
+}
+
+func TestBatchUpdateBlobsChecksums(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	d1 := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	d2 := &remoteexecution.Digest{Hash: testHash2, SizeBytes: testSize2}
+	req := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{
+			&remoteexecution.BatchUpdateBlobsRequest_Request{Digest: d1, Data: testData1},
+			&remoteexecution.BatchUpdateBlobsRequest_Request{Digest: d2, Data: testData2},
+		},
+	}
+
+	// A checksum per request is required
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(BatchCRC32CHeader, CRC32C(testData1)))
+	if _, err := s.BatchUpdateBlobs(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument with too few checksums, got: %v", err)
+	}
+
+	// The second blob was corrupted after its checksum was computed
+	sums := CRC32C(testData1) + "," + CRC32C(testData3)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(BatchCRC32CHeader, sums))
+	res, err := s.BatchUpdateBlobs(ctx, req)
+	if err != nil {
+		t.Fatalf("Error response from BatchUpdateBlobs: %s", err)
+	}
+	if len(res.GetResponses()) != 2 {
+		t.Fatalf("Expected 2 responses, got: %d", len(res.GetResponses()))
+	}
+	for _, writeRes := range res.GetResponses() {
+		expected := int32(google_rpc_code.Code_OK)
+		if writeRes.GetDigest().GetHash() == testHash2 {
+			expected = int32(google_rpc_code.Code_DATA_LOSS)
+		}
+		if writeRes.Status.Code != expected {
+			t.Fatalf("Unexpected status code %d for hash %s: %d", writeRes.Status.Code, writeRes.GetDigest().GetHash(), expected)
+		}
+	}
+	if exists, _ := f.Exists(bazel.DigestStoreName(d2)); exists {
+		t.Fatal("Expected blob failing its checksum not to be written")
+	}
+}
+
 func TestQueryWriteStatusStub(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -519,6 +874,69 @@ func TestGetActionResult(t *testing.T) {
 	}
 }
 
+func TestGetActionResultInline(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	// Write an AR whose stdout and output files are in the Store, and whose stderr is missing
+	d1 := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	d2 := &remoteexecution.Digest{Hash: testHash2, SizeBytes: testSize2}
+	d3 := &remoteexecution.Digest{Hash: testHash3, SizeBytes: testSize3}
+	for _, b := range []struct {
+		d    *remoteexecution.Digest
+		data []byte
+	}{{d1, testData1}, {d2, testData2}} {
+		if err := f.Write(bazel.DigestStoreName(b.d), bytes.NewReader(b.data), nil); err != nil {
+			t.Fatalf("Failed to write into FakeStore: %v", err)
+		}
+	}
+	ar := &remoteexecution.ActionResult{
+		OutputFiles: []*remoteexecution.OutputFile{
+			&remoteexecution.OutputFile{Path: "dir/inlined", Digest: d2},
+			&remoteexecution.OutputFile{Path: "dir/notinlined", Digest: d2},
+		},
+		StdoutDigest: d1,
+		StderrDigest: d3,
+	}
+	arAsBytes, err := proto.Marshal(ar)
+	if err != nil {
+		t.Fatalf("Error serializing ActionResult: %s", err)
+	}
+	ad := &remoteexecution.Digest{Hash: testHash4, SizeBytes: testSize4}
+	address, err := makeCacheResultAddress(ad)
+	if err != nil {
+		t.Fatalf("Failed to create cache result adress: %v", err)
+	}
+	err = f.Write(address.storeName, bytes.NewReader(arAsBytes), nil)
+	if err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+
+	req := &remoteexecution.GetActionResultRequest{
+		ActionDigest:      ad,
+		InlineStdout:      true,
+		InlineStderr:      true,
+		InlineOutputFiles: []string{"dir/inlined"},
+	}
+	resAr, err := s.GetActionResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error from GetActionResult: %v", err)
+	}
+
+	if bytes.Compare(resAr.GetStdoutRaw(), testData1) != 0 {
+		t.Fatalf("Expected stdout to be inlined as %s, got: %s", testData1, resAr.GetStdoutRaw())
+	}
+	if resAr.GetStderrRaw() != nil {
+		t.Fatalf("Expected missing stderr not to be inlined, got: %s", resAr.GetStderrRaw())
+	}
+	if bytes.Compare(resAr.GetOutputFiles()[0].GetContents(), testData2) != 0 {
+		t.Fatalf("Expected output file to be inlined as %s, got: %s", testData2, resAr.GetOutputFiles()[0].GetContents())
+	}
+	if resAr.GetOutputFiles()[1].GetContents() != nil {
+		t.Fatalf("Expected unrequested output file not to be inlined, got: %s", resAr.GetOutputFiles()[1].GetContents())
+	}
+}
+
 func TestGetActionResultMissing(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -601,6 +1019,10 @@ func (s *fakeReadServer) Send(r *bytestream.ReadResponse) error {
 	return nil
 }
 
+func (s *fakeReadServer) Context() context.Context {
+	return context.Background()
+}
+
 func (s *fakeReadServer) reset() {
 	s.buffer.Reset()
 	s.sendCount = 0
@@ -619,6 +1041,7 @@ type fakeWriteServer struct {
 	recvCount     int
 	offset        int64
 	committedSize int64
+	ctx           context.Context
 	grpc.ServerStream
 }
 
@@ -633,6 +1056,13 @@ func makeFakeWriteServer(hash string, size int64, data []byte, chunks int) *fake
 	}
 }
 
+func (s *fakeWriteServer) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
 func (s *fakeWriteServer) SendAndClose(res *bytestream.WriteResponse) error {
 	s.committedSize = res.GetCommittedSize()
 	return nil
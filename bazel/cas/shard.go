@@ -0,0 +1,167 @@
+package cas
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/dialer"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Number of points each node gets on the ring. More points spread digests more evenly.
+const DefaultShardReplicas = 100
+
+// Configuration for partitioning digests across multiple CAS servers.
+// Each digest is owned by one server, chosen by consistent hashing over the cluster's members,
+// so adding or removing a server only moves the digests it gains or loses.
+type ShardConfig struct {
+	// Cluster of CAS servers, including this one, from the same member source as the deployment's other
+	// clusters, ex: a hosts file. Node ids must be the servers' gRPC 'host:port' addrs.
+	Cluster *cluster.Cluster
+	// This server's gRPC addr, as it appears in Cluster.
+	Self string
+	// Points per node on the ring. If <= 0, DefaultShardReplicas is used.
+	Replicas int
+}
+
+// ShardRing maps digests to the CAS server that owns them using consistent hashing.
+// ActionCache results are owned by the server owning their ActionDigest.
+// A ShardRing is safe for concurrent use.
+type ShardRing struct {
+	replicas int
+
+	mu      sync.RWMutex
+	points  []uint64          // sorted
+	owners  map[uint64]string // point -> node addr
+	members []string
+}
+
+// Creates a ring over the given node addrs.
+func NewShardRing(replicas int, addrs ...string) *ShardRing {
+	if replicas <= 0 {
+		replicas = DefaultShardReplicas
+	}
+	r := &ShardRing{replicas: replicas}
+	r.Set(addrs)
+	return r
+}
+
+// Creates a ring over the members of c that's kept up to date as the membership changes.
+// If onChange isn't nil it's called with the ring's members after each change.
+func WatchShardRing(c *cluster.Cluster, replicas int, stat stats.StatsReceiver, onChange func(members []string)) *ShardRing {
+	r := NewShardRing(replicas)
+	sub := c.Subscribe()
+	update := func() {
+		r.setNodes(c.Members(), stat)
+		if onChange != nil {
+			onChange(r.Members())
+		}
+	}
+	update()
+	go func() {
+		for range sub.Updates {
+			update()
+		}
+	}()
+	return r
+}
+
+func (r *ShardRing) setNodes(nodes []cluster.Node, stat stats.StatsReceiver) {
+	addrs := []string{}
+	for _, n := range nodes {
+		addrs = append(addrs, string(n.Id()))
+	}
+	r.Set(addrs)
+	log.Infof("CAS shard ring members: %v", addrs)
+	stat.Gauge(stats.BzShardMembersGauge).Update(int64(len(addrs)))
+}
+
+// Replaces the ring's members.
+func (r *ShardRing) Set(addrs []string) {
+	points := make([]uint64, 0, len(addrs)*r.replicas)
+	owners := make(map[uint64]string, len(addrs)*r.replicas)
+	members := []string{}
+	seen := map[string]bool{}
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		members = append(members, addr)
+		for i := 0; i < r.replicas; i++ {
+			p := hashKey(addr + "#" + strconv.Itoa(i))
+			// On the (unlikely) collision keep the smaller addr so every server agrees on the owner.
+			if prev, ok := owners[p]; ok {
+				if prev < addr {
+					continue
+				}
+			} else {
+				points = append(points, p)
+			}
+			owners[p] = addr
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+	sort.Strings(members)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.points, r.owners, r.members = points, owners, members
+}
+
+// Returns the addrs of the ring's members, sorted.
+func (r *ShardRing) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string{}, r.members...)
+}
+
+// Returns the addr of the server owning the digest with the given hash, or "" if the ring is empty.
+func (r *ShardRing) Owner(hash string) string {
+	h := hashKey(hash)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return ""
+	}
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// Returns a Resolver for the server owning digest, for routing requests client side, ex:
+// ByteStreamRead(ring.Resolver(d), d, retries). The owner is looked up on each Resolve,
+// so retries follow membership changes.
+func (r *ShardRing) Resolver(digest *remoteexecution.Digest) dialer.Resolver {
+	return &shardResolver{ring: r, hash: digest.GetHash()}
+}
+
+type shardResolver struct {
+	ring *ShardRing
+	hash string
+}
+
+func (r *shardResolver) Resolve() (string, error) {
+	return r.ring.Owner(r.hash), nil
+}
+
+func (r *shardResolver) ResolveAll() ([]string, error) {
+	if addr := r.ring.Owner(r.hash); addr != "" {
+		return []string{addr}, nil
+	}
+	return []string{}, nil
+}
+
+func hashKey(s string) uint64 {
+	sum := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint64(sum[:8])
+}
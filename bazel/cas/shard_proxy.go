@@ -0,0 +1,348 @@
+package cas
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/bytestream"
+	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
+	google_rpc_status "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// gRPC header set on requests forwarded by a CAS server to the owner of their digests.
+// Forwarded requests are always served locally, so servers that briefly disagree about
+// the ring's membership can't forward a request back and forth.
+const ShardForwardedHeader = "scoot-cas-forwarded"
+
+// Forwards requests for digests this server doesn't own to the server that does.
+// A nil *shardRouter is valid and forwards nothing.
+type shardRouter struct {
+	ring *ShardRing
+	self string
+	stat stats.StatsReceiver
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newShardRouter(cfg ShardConfig, stat stats.StatsReceiver) *shardRouter {
+	r := &shardRouter{
+		self:  cfg.Self,
+		stat:  stat,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+	r.ring = WatchShardRing(cfg.Cluster, cfg.Replicas, stat, r.prune)
+	return r
+}
+
+// Returns the addr of the server owning d and true if a request for d received with ctx should be forwarded to it.
+func (r *shardRouter) owner(ctx context.Context, d *remoteexecution.Digest) (string, bool) {
+	if r == nil || d.GetHash() == bazel.EmptySha || isForwarded(ctx) {
+		return "", false
+	}
+	addr := r.ring.Owner(d.GetHash())
+	if addr == "" || addr == r.self {
+		return "", false
+	}
+	return addr, true
+}
+
+// Splits digests into those served locally and those to forward, grouped by owner addr.
+func (r *shardRouter) partition(ctx context.Context,
+	digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, map[string][]*remoteexecution.Digest) {
+	local := []*remoteexecution.Digest{}
+	byOwner := map[string][]*remoteexecution.Digest{}
+	for _, d := range digests {
+		if addr, ok := r.owner(ctx, d); ok {
+			byOwner[addr] = append(byOwner[addr], d)
+		} else {
+			local = append(local, d)
+		}
+	}
+	return local, byOwner
+}
+
+func isForwarded(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md[ShardForwardedHeader]) > 0
+}
+
+// Returns a context for forwarding a request received with ctx, preserving the client's metadata
+// (ex: its RequestMetadata, for usage tracking on the owner) and marking the request as forwarded.
+func forwardContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md[ShardForwardedHeader] = []string{"true"}
+	// Batch checksums were verified here, and won't line up with the subset of requests forwarded
+	delete(md, BatchCRC32CHeader)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Returns a connection to addr, reused across requests.
+func (r *shardRouter) conn(addr string) (*grpc.ClientConn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cc, ok := r.conns[addr]; ok {
+		return cc, nil
+	}
+	cc, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to dial CAS shard %s: %s", addr, err)
+	}
+	r.conns[addr] = cc
+	return cc, nil
+}
+
+// Closes the connections to servers that are no longer members, so connections to servers that left don't leak.
+// Requests still using them fail as UNAVAILABLE, as they would have with the server gone.
+func (r *shardRouter) prune(members []string) {
+	current := map[string]bool{}
+	for _, addr := range members {
+		current[addr] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for addr, cc := range r.conns {
+		if !current[addr] {
+			log.Infof("Closing connection to CAS shard %s, no longer a member", addr)
+			cc.Close()
+			delete(r.conns, addr)
+		}
+	}
+}
+
+func (r *shardRouter) record(addr string, err error) {
+	r.stat.Counter(stats.BzShardForwardCounter).Inc(1)
+	if err != nil {
+		r.stat.Counter(stats.BzShardForwardFailureCounter).Inc(1)
+		log.Errorf("Failed forwarding CAS request to shard %s: %v", addr, err)
+	}
+}
+
+// Records the result of a forwarded request and returns err as a gRPC status error for the client.
+// Status errors from the owner, ex: NotFound, are passed through as is. NotFound is a normal result
+// and isn't counted as a failure.
+func (r *shardRouter) result(addr string, err error) error {
+	if err == nil {
+		r.record(addr, nil)
+		return nil
+	}
+	if s, ok := status.FromError(err); ok {
+		if s.Code() == codes.NotFound {
+			r.record(addr, nil)
+		} else {
+			r.record(addr, err)
+		}
+		return err
+	}
+	r.record(addr, err)
+	return status.Error(codes.Unavailable, fmt.Sprintf("Failed forwarding to CAS shard %s: %v", addr, err))
+}
+
+// Asks the owners of digests, grouped by owner addr, which are missing. Owners are queried in parallel.
+func (r *shardRouter) findMissingBlobs(
+	ctx context.Context, instance string, byOwner map[string][]*remoteexecution.Digest) ([]*remoteexecution.Digest, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var missing []*remoteexecution.Digest
+	var firstErr error
+	for addr, digests := range byOwner {
+		wg.Add(1)
+		go func(addr string, digests []*remoteexecution.Digest) {
+			defer wg.Done()
+			res, err := r.forwardFindMissing(ctx, addr, &remoteexecution.FindMissingBlobsRequest{
+				InstanceName: instance, BlobDigests: digests})
+			err = r.result(addr, err)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			missing = append(missing, res.GetMissingBlobDigests()...)
+		}(addr, digests)
+	}
+	wg.Wait()
+	return missing, firstErr
+}
+
+func (r *shardRouter) forwardFindMissing(ctx context.Context,
+	addr string, req *remoteexecution.FindMissingBlobsRequest) (*remoteexecution.FindMissingBlobsResponse, error) {
+	cc, err := r.conn(addr)
+	if err != nil {
+		return nil, err
+	}
+	return remoteexecution.NewContentAddressableStorageClient(cc).FindMissingBlobs(forwardContext(ctx), req)
+}
+
+// Writes blobs, grouped by owner addr, to their owners in parallel.
+// Blobs whose owner couldn't be reached get an UNAVAILABLE status.
+func (r *shardRouter) batchUpdateBlobs(ctx context.Context, instance string,
+	byOwner map[string][]*remoteexecution.BatchUpdateBlobsRequest_Request) []*remoteexecution.BatchUpdateBlobsResponse_Response {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var responses []*remoteexecution.BatchUpdateBlobsResponse_Response
+	for addr, reqs := range byOwner {
+		wg.Add(1)
+		go func(addr string, reqs []*remoteexecution.BatchUpdateBlobsRequest_Request) {
+			defer wg.Done()
+			var res *remoteexecution.BatchUpdateBlobsResponse
+			cc, err := r.conn(addr)
+			if err == nil {
+				res, err = remoteexecution.NewContentAddressableStorageClient(cc).BatchUpdateBlobs(
+					forwardContext(ctx), &remoteexecution.BatchUpdateBlobsRequest{InstanceName: instance, Requests: reqs})
+			}
+			r.record(addr, err)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				responses = append(responses, res.GetResponses()...)
+				return
+			}
+			for _, req := range reqs {
+				responses = append(responses, &remoteexecution.BatchUpdateBlobsResponse_Response{
+					Digest: req.GetDigest(), Status: unavailableStatus(addr, err)})
+			}
+		}(addr, reqs)
+	}
+	wg.Wait()
+	return responses
+}
+
+// Reads blobs, grouped by owner addr, from their owners in parallel.
+// Blobs whose owner couldn't be reached get an UNAVAILABLE status.
+func (r *shardRouter) batchReadBlobs(ctx context.Context, instance string,
+	byOwner map[string][]*remoteexecution.Digest) []*remoteexecution.BatchReadBlobsResponse_Response {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var responses []*remoteexecution.BatchReadBlobsResponse_Response
+	for addr, digests := range byOwner {
+		wg.Add(1)
+		go func(addr string, digests []*remoteexecution.Digest) {
+			defer wg.Done()
+			var res *remoteexecution.BatchReadBlobsResponse
+			cc, err := r.conn(addr)
+			if err == nil {
+				res, err = remoteexecution.NewContentAddressableStorageClient(cc).BatchReadBlobs(
+					forwardContext(ctx), &remoteexecution.BatchReadBlobsRequest{InstanceName: instance, Digests: digests})
+			}
+			r.record(addr, err)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				responses = append(responses, res.GetResponses()...)
+				return
+			}
+			for _, d := range digests {
+				responses = append(responses, &remoteexecution.BatchReadBlobsResponse_Response{
+					Digest: d, Status: unavailableStatus(addr, err)})
+			}
+		}(addr, digests)
+	}
+	wg.Wait()
+	return responses
+}
+
+func unavailableStatus(addr string, err error) *google_rpc_status.Status {
+	return &google_rpc_status.Status{
+		Code:    int32(google_rpc_code.Code_UNAVAILABLE),
+		Message: fmt.Sprintf("Failed forwarding to CAS shard %s: %v", addr, err),
+	}
+}
+
+// Streams a Read from the owner at addr back to the client.
+// Errors from the owner, ex: NotFound, are returned to the client as is.
+func (r *shardRouter) read(addr string, req *bytestream.ReadRequest, ser bytestream.ByteStream_ReadServer) error {
+	return r.result(addr, r.proxyRead(addr, req, ser))
+}
+
+func (r *shardRouter) proxyRead(addr string, req *bytestream.ReadRequest, ser bytestream.ByteStream_ReadServer) error {
+	cc, err := r.conn(addr)
+	if err != nil {
+		return err
+	}
+	rc, err := bytestream.NewByteStreamClient(cc).Read(forwardContext(ser.Context()), req)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := rc.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := ser.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// Streams a Write from the client to the owner at addr, starting with first, the request already received.
+func (r *shardRouter) write(addr string, first *bytestream.WriteRequest, ser bytestream.ByteStream_WriteServer) error {
+	return r.result(addr, r.proxyWrite(addr, first, ser))
+}
+
+func (r *shardRouter) proxyWrite(addr string, wr *bytestream.WriteRequest, ser bytestream.ByteStream_WriteServer) error {
+	cc, err := r.conn(addr)
+	if err != nil {
+		return err
+	}
+	wc, err := bytestream.NewByteStreamClient(cc).Write(forwardContext(ser.Context()))
+	if err != nil {
+		return err
+	}
+	for {
+		if err := wc.Send(wr); err != nil {
+			return err
+		}
+		if wr.GetFinishWrite() {
+			break
+		}
+		if wr, err = ser.Recv(); err != nil {
+			return err
+		}
+	}
+	res, err := wc.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return ser.SendAndClose(res)
+}
+
+func (r *shardRouter) getActionResult(ctx context.Context,
+	addr string, req *remoteexecution.GetActionResultRequest) (*remoteexecution.ActionResult, error) {
+	cc, err := r.conn(addr)
+	if err != nil {
+		return nil, r.result(addr, err)
+	}
+	ar, err := remoteexecution.NewActionCacheClient(cc).GetActionResult(forwardContext(ctx), req)
+	return ar, r.result(addr, err)
+}
+
+func (r *shardRouter) updateActionResult(ctx context.Context,
+	addr string, req *remoteexecution.UpdateActionResultRequest) (*remoteexecution.ActionResult, error) {
+	cc, err := r.conn(addr)
+	if err != nil {
+		return nil, r.result(addr, err)
+	}
+	ar, err := remoteexecution.NewActionCacheClient(cc).UpdateActionResult(forwardContext(ctx), req)
+	return ar, r.result(addr, err)
+}
@@ -0,0 +1,157 @@
+package cas
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+func testHashes(n int) []string {
+	hashes := []string{}
+	for i := 0; i < n; i++ {
+		hashes = append(hashes, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i)))))
+	}
+	return hashes
+}
+
+func TestShardRingOwner(t *testing.T) {
+	if owner := NewShardRing(0).Owner(testHashes(1)[0]); owner != "" {
+		t.Fatalf("Expected no owner in empty ring, got %s", owner)
+	}
+
+	addrs := []string{"cas1:9099", "cas2:9099", "cas3:9099"}
+	r := NewShardRing(0, addrs...)
+	// Rings with the same members agree on owners regardless of member order.
+	other := NewShardRing(0, addrs[2], addrs[0], addrs[1], addrs[0])
+	if m := other.Members(); len(m) != 3 {
+		t.Fatalf("Expected duplicate members to be ignored, got %v", m)
+	}
+
+	counts := map[string]int{}
+	hashes := testHashes(3000)
+	for _, h := range hashes {
+		owner := r.Owner(h)
+		if owner != other.Owner(h) {
+			t.Fatalf("Rings disagree on owner of %s: %s, %s", h, owner, other.Owner(h))
+		}
+		counts[owner]++
+	}
+	for _, addr := range addrs {
+		if counts[addr] < len(hashes)/6 {
+			t.Fatalf("Expected digests spread across members, got %v", counts)
+		}
+	}
+}
+
+func TestShardRingMembershipChange(t *testing.T) {
+	r := NewShardRing(0, "cas1:9099", "cas2:9099", "cas3:9099")
+	hashes := testHashes(3000)
+	before := map[string]string{}
+	for _, h := range hashes {
+		before[h] = r.Owner(h)
+	}
+
+	// Adding a member only moves digests to the new member.
+	r.Set([]string{"cas1:9099", "cas2:9099", "cas3:9099", "cas4:9099"})
+	moved := 0
+	for _, h := range hashes {
+		if owner := r.Owner(h); owner != before[h] {
+			if owner != "cas4:9099" {
+				t.Fatalf("Expected %s to stay on %s or move to cas4, moved to %s", h, before[h], owner)
+			}
+			moved++
+		}
+	}
+	if moved == 0 || moved > len(hashes)/2 {
+		t.Fatalf("Expected about a quarter of digests to move, %d of %d moved", moved, len(hashes))
+	}
+
+	// Removing a member only moves its digests.
+	r.Set([]string{"cas1:9099", "cas3:9099", "cas4:9099"})
+	for _, h := range hashes {
+		if before[h] != "cas2:9099" && r.Owner(h) == "cas2:9099" {
+			t.Fatalf("Removed member still owns %s", h)
+		}
+	}
+}
+
+func TestShardResolver(t *testing.T) {
+	r := NewShardRing(0)
+	d := &remoteexecution.Digest{Hash: testHashes(1)[0], SizeBytes: 1}
+	res := r.Resolver(d)
+	if addr, err := res.Resolve(); err != nil || addr != "" {
+		t.Fatalf("Expected no addr from empty ring, got %q %v", addr, err)
+	}
+
+	r.Set([]string{"cas1:9099", "cas2:9099"})
+	addr, err := res.Resolve()
+	if err != nil || addr != r.Owner(d.GetHash()) {
+		t.Fatalf("Expected owner %s, got %q %v", r.Owner(d.GetHash()), addr, err)
+	}
+	if all, _ := res.ResolveAll(); len(all) != 1 || all[0] != addr {
+		t.Fatalf("Expected [%s], got %v", addr, all)
+	}
+}
+
+func TestShardRouterOwner(t *testing.T) {
+	var nilRouter *shardRouter
+	d := &remoteexecution.Digest{Hash: testHashes(1)[0], SizeBytes: 1}
+	if _, ok := nilRouter.owner(context.Background(), d); ok {
+		t.Fatal("Expected nil router not to forward")
+	}
+
+	ring := NewShardRing(0, "self:9099", "other:9099")
+	r := &shardRouter{ring: ring, self: "self:9099", stat: stats.NilStatsReceiver()}
+	local, byOwner := r.partition(context.Background(), []*remoteexecution.Digest{
+		{Hash: bazel.EmptySha, SizeBytes: bazel.EmptySize}, d})
+	if len(local)+len(byOwner["other:9099"]) != 2 || local[0].GetHash() != bazel.EmptySha {
+		t.Fatalf("Unexpected partition: %v %v", local, byOwner)
+	}
+	addr, ok := r.owner(context.Background(), d)
+	if owner := ring.Owner(d.GetHash()); ok != (owner == "other:9099") || (ok && addr != owner) {
+		t.Fatalf("Unexpected routing to %q %v for digest owned by %s", addr, ok, owner)
+	}
+
+	// Forwarded requests are always served locally.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ShardForwardedHeader, "true"))
+	for _, h := range testHashes(20) {
+		if _, ok := r.owner(ctx, &remoteexecution.Digest{Hash: h, SizeBytes: 1}); ok {
+			t.Fatal("Expected forwarded request not to be forwarded again")
+		}
+	}
+}
+
+func TestShardRouterPrune(t *testing.T) {
+	updateCh := make(chan cluster.ClusterUpdate)
+	c := cluster.NewCluster([]cluster.Node{cluster.NewIdNode("self:9099"), cluster.NewIdNode("other:9099")}, updateCh, nil)
+	r := newShardRouter(ShardConfig{Cluster: c, Self: "self:9099"}, stats.NilStatsReceiver())
+	if _, err := r.conn("other:9099"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Connections to servers that leave are closed.
+	updateCh <- []cluster.Node{cluster.NewIdNode("self:9099")}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		r.mu.Lock()
+		n := len(r.conns)
+		r.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the connection to the removed server to be closed")
+		}
+	}
+	if m := r.ring.Members(); len(m) != 1 || m[0] != "self:9099" {
+		t.Fatalf("Expected only self in the ring, got %v", m)
+	}
+}
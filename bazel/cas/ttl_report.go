@@ -0,0 +1,316 @@
+package cas
+
+import (
+	"encoding/json"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// How often the TTL report is logged and its stats updated
+	DefaultTTLReportInterval = time.Hour
+
+	// One in this many CAS blobs and ActionResults, chosen by a hash of their store name,
+	// has its accesses tracked for the TTL report
+	DefaultTTLSampleRate = 100
+
+	// Maximum number of sampled entries tracked at once, bounding the tracker's memory.
+	// New entries aren't sampled while it's full, until tracked ones expire and age out.
+	MaxTTLSampledEntries = 100000
+
+	// Fewest ages at last access a TTL class needs in a report for a TTL to be suggested for it
+	MinTTLSuggestionSamples = 20
+)
+
+// TTL classes, by the ResultsCachePolicy priority entries were written with. See TTLForCachePriority.
+const (
+	ttlClassLongLived = "longLived"
+	ttlClassDefault   = "default"
+	ttlClassEphemeral = "ephemeral"
+)
+
+func ttlClass(priority int32) string {
+	switch {
+	case priority < 0:
+		return ttlClassLongLived
+	case priority > 0:
+		return ttlClassEphemeral
+	default:
+		return ttlClassDefault
+	}
+}
+
+// Configures the CAS TTL report
+type TTLReportConfig struct {
+	// One in this many CAS blobs and ActionResults is sampled. If <= 0, DefaultTTLSampleRate.
+	SampleRate int
+	// File the sampled entries are saved to after each report and loaded from on startup, so entries
+	// written before a restart are still reported. If empty, a restart starts sampling over.
+	StateFile string
+}
+
+// Samples accesses of CAS blobs and ActionResults to compare how long entries are used with how long
+// they're kept, so TTLs can be tuned from data. For each TTL class, a report every interval gives
+// the storage wasted keeping entries after they were last read, and the premature evictions of entries
+// needed again after their TTL ran out, along with the ages entries were last read at and a TTL that
+// would have kept 95% of them. Existence checks count as reads, since clients check for the blobs they need.
+// A nil *ttlTracker is valid and tracks nothing.
+type ttlTracker struct {
+	stat       stats.StatsReceiver
+	sampleRate uint32
+	stateFile  string
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*ttlEntry // store name -> sampled entry
+	classes map[string]*TTLClassReport
+}
+
+// A sampled entry written to the store, exported to be saved in the state file
+type ttlEntry struct {
+	Class      string
+	Size       int64
+	Written    time.Time
+	Expires    time.Time
+	LastAccess time.Time // zero if it hasn't been read since it was written
+	Expired    bool      // set once the entry's expiry has been reported
+}
+
+// Tracked accesses of one TTL class since the last report
+type TTLClassReport struct {
+	Class string
+	// Entries whose TTL ran out, and of those, entries never read after they were written
+	Expired int64
+	Unused  int64
+	// Storage held by expired entries after they were last read, or written if never read
+	WastedByteHours float64
+	// Reads and rewrites of entries after their TTL ran out
+	PrematureEvictions int64
+	// Ages entries were last read at, including reads after their TTL ran out
+	AccessAgeP50 time.Duration
+	AccessAgeP95 time.Duration
+	// The P95 age, if there were at least MinTTLSuggestionSamples ages, otherwise zero
+	SuggestedTTL time.Duration
+
+	ages []time.Duration
+}
+
+func newTTLTracker(cfg TTLReportConfig, stat stats.StatsReceiver) *ttlTracker {
+	if cfg.SampleRate <= 0 {
+		cfg.SampleRate = DefaultTTLSampleRate
+	}
+	t := &ttlTracker{
+		stat:       stat,
+		sampleRate: uint32(cfg.SampleRate),
+		stateFile:  cfg.StateFile,
+		now:        time.Now,
+		entries:    make(map[string]*ttlEntry),
+		classes:    make(map[string]*TTLClassReport),
+	}
+	if err := t.load(); err != nil {
+		log.Errorf("Unable to load TTL report state from %s, sampling starts over: %v", t.stateFile, err)
+	}
+	return t
+}
+
+// Loads the sampled entries saved in the state file, if there is one.
+func (t *ttlTracker) load() error {
+	if t.stateFile == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(t.stateFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	entries := make(map[string]*ttlEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for name, e := range entries {
+		if len(t.entries) >= MaxTTLSampledEntries {
+			break
+		}
+		if t.sampled(name) {
+			t.entries[name] = e
+		}
+	}
+	log.Infof("Loaded %d sampled entries for the TTL report from %s", len(t.entries), t.stateFile)
+	return nil
+}
+
+// Saves the sampled entries to the state file, if there is one, replacing it whole so a crash
+// while saving leaves the previous state.
+func (t *ttlTracker) save() error {
+	if t.stateFile == "" {
+		return nil
+	}
+	t.mu.Lock()
+	data, err := json.Marshal(t.entries)
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(t.stateFile), filepath.Base(t.stateFile))
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), t.stateFile)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func (t *ttlTracker) sampled(name string) bool {
+	return crc32.ChecksumIEEE([]byte(name))%t.sampleRate == 0
+}
+
+// Record an entry written to the store with a TTL of ttl, zero if the store doesn't expire it.
+func (t *ttlTracker) recordWrite(name string, size int64, priority int32, ttl time.Duration) {
+	if t == nil || ttl <= 0 || !t.sampled(name) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	if e, ok := t.entries[name]; ok && now.After(e.Expires) {
+		t.prematureEviction(e, now)
+	} else if !ok && len(t.entries) >= MaxTTLSampledEntries {
+		return
+	}
+	t.entries[name] = &ttlEntry{Class: ttlClass(priority), Size: size, Written: now, Expires: now.Add(ttl)}
+}
+
+// Record a read of an entry, found if it was in the store.
+func (t *ttlTracker) recordRead(name string, found bool) {
+	if t == nil || !t.sampled(name) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[name]
+	if !ok {
+		return
+	}
+	now := t.now()
+	if now.After(e.Expires) {
+		if !found {
+			t.prematureEviction(e, now)
+			delete(t.entries, name)
+		}
+		return
+	}
+	e.LastAccess = now
+}
+
+// Caller must hold the lock.
+func (t *ttlTracker) prematureEviction(e *ttlEntry, now time.Time) {
+	c := t.class(e.Class)
+	c.PrematureEvictions++
+	c.ages = append(c.ages, now.Sub(e.Written))
+}
+
+// Caller must hold the lock.
+func (t *ttlTracker) class(name string) *TTLClassReport {
+	c, ok := t.classes[name]
+	if !ok {
+		c = &TTLClassReport{Class: name}
+		t.classes[name] = c
+	}
+	return c
+}
+
+// Log, export stats for and reset the accesses tracked since the last report, ordered by class,
+// then save the sampled entries. Returns the reports.
+func (t *ttlTracker) report() []*TTLClassReport {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	now := t.now()
+	for name, e := range t.entries {
+		if !e.Expired && now.After(e.Expires) {
+			e.Expired = true
+			c := t.class(e.Class)
+			c.Expired++
+			lastUse := e.Written
+			if e.LastAccess.IsZero() {
+				c.Unused++
+			} else {
+				lastUse = e.LastAccess
+				c.ages = append(c.ages, e.LastAccess.Sub(e.Written))
+			}
+			c.WastedByteHours += float64(e.Size) * e.Expires.Sub(lastUse).Hours()
+		}
+		// Entries are kept for another TTL after they expire to catch late reads, then forgotten.
+		if e.Expired && now.After(e.Expires.Add(e.Expires.Sub(e.Written))) {
+			delete(t.entries, name)
+		}
+	}
+	reports := make([]*TTLClassReport, 0, len(t.classes))
+	for _, c := range t.classes {
+		reports = append(reports, c)
+	}
+	t.classes = make(map[string]*TTLClassReport)
+	t.mu.Unlock()
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Class < reports[j].Class })
+	for _, c := range reports {
+		if len(c.ages) > 0 {
+			sort.Slice(c.ages, func(i, j int) bool { return c.ages[i] < c.ages[j] })
+			c.AccessAgeP50 = c.ages[(len(c.ages)-1)*50/100]
+			c.AccessAgeP95 = c.ages[(len(c.ages)-1)*95/100]
+			if len(c.ages) >= MinTTLSuggestionSamples {
+				c.SuggestedTTL = c.AccessAgeP95
+			}
+		}
+		classStat := t.stat.Scope("ttl", c.Class)
+		classStat.Counter(stats.BzTTLExpiredCounter).Inc(c.Expired)
+		classStat.Counter(stats.BzTTLUnusedCounter).Inc(c.Unused)
+		classStat.Counter(stats.BzTTLPrematureEvictionCounter).Inc(c.PrematureEvictions)
+		classStat.Counter(stats.BzTTLWastedByteHoursCounter).Inc(int64(c.WastedByteHours))
+		if c.SuggestedTTL > 0 {
+			classStat.Gauge(stats.BzTTLSuggestedTTLGauge_ms).Update(int64(c.SuggestedTTL / time.Millisecond))
+		}
+		log.WithFields(
+			log.Fields{
+				"class":              c.Class,
+				"sampleRate":         t.sampleRate,
+				"expired":            c.Expired,
+				"unused":             c.Unused,
+				"wastedByteHours":    c.WastedByteHours,
+				"prematureEvictions": c.PrematureEvictions,
+				"accessAgeP50":       c.AccessAgeP50,
+				"accessAgeP95":       c.AccessAgeP95,
+				"suggestedTTL":       c.SuggestedTTL,
+			}).Info("CAS TTL report")
+	}
+	if err := t.save(); err != nil {
+		log.Errorf("Unable to save TTL report state to %s: %v", t.stateFile, err)
+	}
+	return reports
+}
+
+// Report every interval. Never returns.
+func (t *ttlTracker) loop(interval time.Duration) {
+	for range time.NewTicker(interval).C {
+		t.report()
+	}
+}
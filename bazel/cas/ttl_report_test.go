@@ -0,0 +1,119 @@
+package cas
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+func TestTTLTrackerReport(t *testing.T) {
+	reg := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return reg }, 0)
+	tr := newTTLTracker(TTLReportConfig{SampleRate: 1}, stat)
+	now := time.Unix(0, 0)
+	tr.now = func() time.Time { return now }
+
+	// Read an hour after being written, then kept until the 3 hour default TTL ran out
+	tr.recordWrite("read", 100, 0, 3*time.Hour)
+	// Never read
+	tr.recordWrite("unused", 10, 0, 3*time.Hour)
+	// Needed again after its 1 hour ephemeral TTL ran out
+	tr.recordWrite("evicted", 1, 1, time.Hour)
+	// Not tracked, as the store doesn't expire it
+	tr.recordWrite("forever", 1, 0, 0)
+
+	now = now.Add(time.Hour)
+	tr.recordRead("read", true)
+	now = now.Add(time.Hour)
+	tr.recordRead("evicted", false)
+	tr.recordRead("forever", true)
+	now = now.Add(2 * time.Hour)
+
+	reports := tr.report()
+	if len(reports) != 2 {
+		t.Fatalf("Expected reports for 2 TTL classes, got %+v", reports)
+	}
+	if r := reports[0]; r.Class != ttlClassDefault || r.Expired != 2 || r.Unused != 1 ||
+		r.WastedByteHours != 230 || r.PrematureEvictions != 0 || r.AccessAgeP95 != time.Hour || r.SuggestedTTL != 0 {
+		t.Fatalf("Unexpected default TTL report: %+v", r)
+	}
+	if r := reports[1]; r.Class != ttlClassEphemeral || r.Expired != 0 || r.PrematureEvictions != 1 ||
+		r.AccessAgeP50 != 2*time.Hour {
+		t.Fatalf("Unexpected ephemeral TTL report: %+v", r)
+	}
+	if !stats.StatsOk("", reg, t,
+		map[string]stats.Rule{
+			"ttl/default/" + stats.BzTTLExpiredCounter:             {Checker: stats.Int64EqTest, Value: 2},
+			"ttl/default/" + stats.BzTTLUnusedCounter:              {Checker: stats.Int64EqTest, Value: 1},
+			"ttl/default/" + stats.BzTTLWastedByteHoursCounter:     {Checker: stats.Int64EqTest, Value: 230},
+			"ttl/ephemeral/" + stats.BzTTLPrematureEvictionCounter: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	if reports := tr.report(); len(reports) != 0 {
+		t.Fatalf("Expected expired entries to be reported once, got %+v", reports)
+	}
+}
+
+func TestTTLTrackerSuggestedTTL(t *testing.T) {
+	tr := newTTLTracker(TTLReportConfig{SampleRate: 1}, stats.NilStatsReceiver())
+	now := time.Unix(0, 0)
+	tr.now = func() time.Time { return now }
+
+	for i := 0; i < MinTTLSuggestionSamples; i++ {
+		tr.recordWrite(string(rune('a'+i)), 1, -1, 24*time.Hour)
+	}
+	for i := 0; i < MinTTLSuggestionSamples; i++ {
+		now = now.Add(time.Minute)
+		tr.recordRead(string(rune('a'+i)), true)
+	}
+	now = now.Add(24 * time.Hour)
+
+	reports := tr.report()
+	if len(reports) != 1 || reports[0].Class != ttlClassLongLived || reports[0].Unused != 0 {
+		t.Fatalf("Expected a long-lived TTL report without unused entries, got %+v", reports)
+	}
+	if reports[0].SuggestedTTL != 19*time.Minute {
+		t.Fatalf("Expected a suggested TTL of the P95 age at last access, got %v", reports[0].SuggestedTTL)
+	}
+}
+
+func TestTTLTrackerNil(t *testing.T) {
+	var tr *ttlTracker
+	tr.recordWrite("blob", 1, 0, time.Hour)
+	tr.recordRead("blob", true)
+	if reports := tr.report(); reports != nil {
+		t.Fatalf("Expected a nil tracker to report nothing, got %+v", reports)
+	}
+}
+
+func TestTTLTrackerState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ttl_report_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := TTLReportConfig{SampleRate: 1, StateFile: filepath.Join(dir, "state.json")}
+	now := time.Unix(0, 0)
+
+	tr := newTTLTracker(cfg, stats.NilStatsReceiver())
+	tr.now = func() time.Time { return now }
+	tr.recordWrite("read", 100, 0, 3*time.Hour)
+	now = now.Add(time.Hour)
+	tr.recordRead("read", true)
+	tr.report()
+
+	// A restarted tracker reports entries written before the restart
+	tr = newTTLTracker(cfg, stats.NilStatsReceiver())
+	tr.now = func() time.Time { return now }
+	now = now.Add(3 * time.Hour)
+	reports := tr.report()
+	if len(reports) != 1 || reports[0].Expired != 1 || reports[0].Unused != 0 || reports[0].AccessAgeP50 != time.Hour {
+		t.Fatalf("Expected the saved entry to be reported after a restart, got %+v", reports)
+	}
+}
@@ -0,0 +1,309 @@
+package cas
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	uuid "github.com/nu7hatch/gouuid"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Configuration for running a CAS server as a caching proxy in front of an upstream Remote Execution
+// CAS and ActionCache. Blobs and ActionResults missing locally are fetched from the upstream and
+// cached in the local Store, and writes are only acknowledged once they've reached both.
+type UpstreamConfig struct {
+	// gRPC 'host:port' addr of the upstream server
+	Addr string
+	// Instance name used for all upstream requests. If empty, clients' instance names are passed through.
+	InstanceName string
+	// Connect to the upstream with TLS, verifying its certificate against the system roots
+	TLS bool
+}
+
+// Client for the upstream of a proxying CAS server.
+// A nil *upstreamCAS is valid and has nothing upstream: it finds everything missing and writes nothing.
+type upstreamCAS struct {
+	addr     string
+	instance string
+	cc       *grpc.ClientConn
+	stat     stats.StatsReceiver
+
+	mu      sync.Mutex
+	fetches map[string]*upstreamFetch // store name -> fetch in progress
+}
+
+// A fetch of a blob from the upstream into the Store, shared by all requests for the blob while it's in progress
+type upstreamFetch struct {
+	done chan struct{}
+	err  error
+}
+
+func newUpstreamCAS(cfg UpstreamConfig, stat stats.StatsReceiver) (*upstreamCAS, error) {
+	opt := grpc.WithInsecure()
+	if cfg.TLS {
+		opt = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	}
+	cc, err := grpc.Dial(cfg.Addr, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to dial upstream CAS %s: %s", cfg.Addr, err)
+	}
+	log.Infof("Proxying CAS requests to upstream %s", cfg.Addr)
+	return &upstreamCAS{addr: cfg.Addr, instance: cfg.InstanceName, cc: cc, stat: stat, fetches: map[string]*upstreamFetch{}}, nil
+}
+
+func (u *upstreamCAS) instanceName(instance string) string {
+	if u.instance != "" {
+		return u.instance
+	}
+	return instance
+}
+
+// Records a failed upstream request. NotFound is a normal result and isn't counted as a failure.
+func (u *upstreamCAS) record(err error) {
+	if err == nil || status.Code(err) == codes.NotFound {
+		return
+	}
+	u.stat.Counter(stats.BzUpstreamFailureCounter).Inc(1)
+	log.Errorf("Failed request to upstream CAS %s: %v", u.addr, err)
+}
+
+// Returns the digests, all missing locally, that are also missing upstream.
+// If the upstream can't be reached, all of them are reported missing so clients upload them again.
+func (u *upstreamCAS) findMissing(ctx context.Context,
+	instance string, digests []*remoteexecution.Digest) []*remoteexecution.Digest {
+	if u == nil || len(digests) == 0 {
+		return digests
+	}
+	res, err := remoteexecution.NewContentAddressableStorageClient(u.cc).FindMissingBlobs(ctx,
+		&remoteexecution.FindMissingBlobsRequest{InstanceName: u.instanceName(instance), BlobDigests: digests})
+	if err != nil {
+		u.record(err)
+		return digests
+	}
+	return res.GetMissingBlobDigests()
+}
+
+// Reads the blob d from the upstream into w, verifying it against d. Data is written to w as it arrives,
+// so what was written must be discarded if an error is returned.
+func (u *upstreamCAS) read(ctx context.Context, instance string, d *remoteexecution.Digest, w io.Writer) error {
+	err := u.proxyRead(ctx, instance, d, w)
+	u.record(err)
+	return err
+}
+
+func (u *upstreamCAS) proxyRead(ctx context.Context, instance string, d *remoteexecution.Digest, w io.Writer) error {
+	name, err := GetReadResourceName(u.instanceName(instance), d.GetHash(), d.GetSizeBytes(), "")
+	if err != nil {
+		return err
+	}
+	h, err := bazel.NewDigestHash(bazel.InferDigestFunction(d.GetHash()))
+	if err != nil {
+		return err
+	}
+	rc, err := bytestream.NewByteStreamClient(u.cc).Read(ctx, &bytestream.ReadRequest{ResourceName: name})
+	if err != nil {
+		return err
+	}
+	var size int64
+	for {
+		res, err := rc.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if size += int64(len(res.GetData())); size > d.GetSizeBytes() {
+			return status.Error(codes.DataLoss, fmt.Sprintf("Upstream sent more than %d bytes for %s", d.GetSizeBytes(), d.GetHash()))
+		}
+		h.Write(res.GetData())
+		if _, err := w.Write(res.GetData()); err != nil {
+			return err
+		}
+	}
+	if hash := hex.EncodeToString(h.Sum(nil)); size != d.GetSizeBytes() || hash != d.GetHash() {
+		return status.Error(codes.DataLoss, fmt.Sprintf("Upstream data for %s/%d did not match its Digest", d.GetHash(), d.GetSizeBytes()))
+	}
+	return nil
+}
+
+// Returns the fetch of name in progress, or starts one if there isn't one, in which case first is true
+// and the caller must end it with endFetch.
+func (u *upstreamCAS) startFetch(name string) (f *upstreamFetch, first bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if f, ok := u.fetches[name]; ok {
+		return f, false
+	}
+	f = &upstreamFetch{done: make(chan struct{})}
+	u.fetches[name] = f
+	return f, true
+}
+
+func (u *upstreamCAS) endFetch(name string, f *upstreamFetch, err error) {
+	u.mu.Lock()
+	delete(u.fetches, name)
+	u.mu.Unlock()
+	f.err = err
+	close(f.done)
+}
+
+// Writes the blob d to the upstream, returning once the upstream has committed it.
+func (u *upstreamCAS) write(ctx context.Context, instance string, d *remoteexecution.Digest, data []byte) error {
+	if u == nil {
+		return nil
+	}
+	err := u.proxyWrite(ctx, instance, d, data)
+	u.record(err)
+	if err == nil {
+		u.stat.Counter(stats.BzUpstreamWriteCounter).Inc(1)
+	}
+	return err
+}
+
+func (u *upstreamCAS) proxyWrite(ctx context.Context, instance string, d *remoteexecution.Digest, data []byte) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	name, err := GetWriteResourceName(u.instanceName(instance), id.String(), d.GetHash(), d.GetSizeBytes(), "")
+	if err != nil {
+		return err
+	}
+	wc, err := bytestream.NewByteStreamClient(u.cc).Write(ctx)
+	if err != nil {
+		return err
+	}
+	// Chunk the data so large blobs stay under gRPC message size limits
+	for off := 0; ; off += DefaultReadCapacity {
+		end := off + DefaultReadCapacity
+		if end >= len(data) {
+			end = len(data)
+		}
+		wr := &bytestream.WriteRequest{WriteOffset: int64(off), Data: data[off:end], FinishWrite: end == len(data)}
+		if off == 0 {
+			wr.ResourceName = name
+		}
+		if err := wc.Send(wr); err != nil {
+			// The stream's status is only returned by CloseAndRecv
+			break
+		}
+		if wr.FinishWrite {
+			break
+		}
+	}
+	res, err := wc.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	// The upstream may end the Write early with the size of data it already has
+	if res.GetCommittedSize() != d.GetSizeBytes() {
+		return fmt.Errorf("Upstream committed %d bytes for %s, expected %d", res.GetCommittedSize(), d.GetHash(), d.GetSizeBytes())
+	}
+	return nil
+}
+
+func (u *upstreamCAS) getActionResult(ctx context.Context,
+	req *remoteexecution.GetActionResultRequest) (*remoteexecution.ActionResult, error) {
+	req = proto.Clone(req).(*remoteexecution.GetActionResultRequest)
+	req.InstanceName = u.instanceName(req.GetInstanceName())
+	ar, err := remoteexecution.NewActionCacheClient(u.cc).GetActionResult(ctx, req)
+	u.record(err)
+	return ar, err
+}
+
+func (u *upstreamCAS) updateActionResult(ctx context.Context, req *remoteexecution.UpdateActionResultRequest) error {
+	if u == nil {
+		return nil
+	}
+	req = proto.Clone(req).(*remoteexecution.UpdateActionResultRequest)
+	req.InstanceName = u.instanceName(req.GetInstanceName())
+	_, err := remoteexecution.NewActionCacheClient(u.cc).UpdateActionResult(ctx, req)
+	u.record(err)
+	if err == nil {
+		u.stat.Counter(stats.BzUpstreamWriteCounter).Inc(1)
+	}
+	return err
+}
+
+// Fetches the blob d, missing from the Store, from the upstream and stores it so later requests are served locally,
+// then opens it for reading. Concurrent requests for d share one fetch.
+// Returns a NotFound error if there's no upstream.
+func (s *casServer) fetchUpstream(ctx context.Context, instance string, d *remoteexecution.Digest) (io.ReadCloser, error) {
+	if s.upstream == nil {
+		return nil, status.Error(codes.NotFound, "No upstream CAS")
+	}
+	name := bazel.DigestStoreName(d)
+	f, first := s.upstream.startFetch(name)
+	if first {
+		s.upstream.endFetch(name, f, s.fetchUpstreamToStore(ctx, instance, d))
+	} else {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return s.storeConfig.Store.OpenForRead(name)
+}
+
+// Streams the blob d from the upstream into a temp file, so it's only written to the Store once verified
+// and is never held in memory whole.
+func (s *casServer) fetchUpstreamToStore(ctx context.Context, instance string, d *remoteexecution.Digest) error {
+	tmp, err := ioutil.TempFile("", "cas-upstream-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if err := s.upstream.read(ctx, instance, d, tmp); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.stat.Counter(stats.BzUpstreamFetchCounter).Inc(1)
+	if err := s.writeToStore(bazel.DigestStoreName(d), tmp, 0); err != nil {
+		log.Errorf("Failed caching %s fetched from upstream: %v", bazel.DigestToStr(d), err)
+		return err
+	}
+	return nil
+}
+
+// Fetches the ActionResult for req, missing from the Store, from the upstream and stores it at address.
+// Returns a NotFound error if there's no upstream.
+func (s *casServer) fetchUpstreamResult(ctx context.Context,
+	req *remoteexecution.GetActionResultRequest, address *cacheResultAddress) (*remoteexecution.ActionResult, error) {
+	if s.upstream == nil {
+		return nil, status.Error(codes.NotFound, "No upstream ActionCache")
+	}
+	ar, err := s.upstream.getActionResult(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.stat.Counter(stats.BzUpstreamFetchCounter).Inc(1)
+	if asBytes, err := proto.Marshal(ar); err != nil {
+		log.Errorf("Failed to serialize ActionResult fetched from upstream: %v", err)
+	} else if err := s.writeToStore(address.storeName, bytes.NewReader(asBytes), 0); err != nil {
+		log.Errorf("Failed caching ActionResult fetched from upstream at %s: %v", address.storeName, err)
+	}
+	return ar, nil
+}
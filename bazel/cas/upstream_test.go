@@ -0,0 +1,161 @@
+package cas
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/bytestream"
+	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+// Starts a CAS server on a FakeStore to proxy to, returning the proxy and the upstream's store.
+func makeUpstreamTestServers(t *testing.T) (*casServer, *store.FakeStore, func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	upStore := &store.FakeStore{}
+	up := &casServer{storeConfig: &store.StoreConfig{Store: upStore}, stat: stats.NilStatsReceiver()}
+	gs := grpc.NewServer()
+	remoteexecution.RegisterContentAddressableStorageServer(gs, up)
+	remoteexecution.RegisterActionCacheServer(gs, up)
+	bytestream.RegisterByteStreamServer(gs, up)
+	go gs.Serve(l)
+
+	u, err := newUpstreamCAS(UpstreamConfig{Addr: l.Addr().String()}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf("Failed to make upstream: %v", err)
+	}
+	s := &casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}, upstream: u, stat: stats.NilStatsReceiver()}
+	return s, upStore, gs.Stop
+}
+
+func TestUpstreamFetchesMisses(t *testing.T) {
+	s, upStore, stop := makeUpstreamTestServers(t)
+	defer stop()
+
+	dUp := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	dMissing := &remoteexecution.Digest{Hash: testHash2, SizeBytes: testSize2}
+	if err := upStore.Write(bazel.DigestStoreName(dUp), bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+
+	res, err := s.FindMissingBlobs(context.Background(),
+		&remoteexecution.FindMissingBlobsRequest{BlobDigests: []*remoteexecution.Digest{dUp, dMissing}})
+	if err != nil {
+		t.Fatalf("Error response from FindMissingBlobs: %v", err)
+	}
+	if len(res.GetMissingBlobDigests()) != 1 || res.GetMissingBlobDigests()[0].GetHash() != testHash2 {
+		t.Fatalf("Expected only %s missing locally and upstream, got %v", testHash2, res.GetMissingBlobDigests())
+	}
+
+	readRes, err := s.BatchReadBlobs(context.Background(),
+		&remoteexecution.BatchReadBlobsRequest{Digests: []*remoteexecution.Digest{dUp, dMissing}})
+	if err != nil {
+		t.Fatalf("Error response from BatchReadBlobs: %v", err)
+	}
+	for _, r := range readRes.GetResponses() {
+		switch r.GetDigest().GetHash() {
+		case testHash1:
+			if r.GetStatus().GetCode() != int32(google_rpc_code.Code_OK) || !bytes.Equal(r.GetData(), testData1) {
+				t.Fatalf("Expected %s to be fetched from upstream, got %v", testHash1, r)
+			}
+		case testHash2:
+			if r.GetStatus().GetCode() != int32(google_rpc_code.Code_NOT_FOUND) {
+				t.Fatalf("Expected %s to be NotFound, got %v", testHash2, r)
+			}
+		}
+	}
+	if exists, _ := s.storeConfig.Store.Exists(bazel.DigestStoreName(dUp)); !exists {
+		t.Fatal("Expected blob fetched from upstream to be cached locally")
+	}
+}
+
+func TestUpstreamWritesThrough(t *testing.T) {
+	s, upStore, stop := makeUpstreamTestServers(t)
+	defer stop()
+
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	res, err := s.BatchUpdateBlobs(context.Background(), &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{{Digest: d, Data: testData1}},
+	})
+	if err != nil {
+		t.Fatalf("Error response from BatchUpdateBlobs: %v", err)
+	}
+	if code := res.GetResponses()[0].GetStatus().GetCode(); code != int32(google_rpc_code.Code_OK) {
+		t.Fatalf("Expected OK, got %d", code)
+	}
+	for _, st := range []store.Store{s.storeConfig.Store, upStore} {
+		if exists, _ := st.Exists(bazel.DigestStoreName(d)); !exists {
+			t.Fatal("Expected blob to be written locally and upstream")
+		}
+	}
+
+	// Results written through the proxy are served by the upstream, and results only upstream by the proxy
+	ad := &remoteexecution.Digest{Hash: testHash2, SizeBytes: testSize2}
+	ar := &remoteexecution.ActionResult{ExitCode: 42}
+	if _, err := s.UpdateActionResult(context.Background(),
+		&remoteexecution.UpdateActionResultRequest{ActionDigest: ad, ActionResult: ar}); err != nil {
+		t.Fatalf("Error response from UpdateActionResult: %v", err)
+	}
+	other := &casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}, upstream: s.upstream, stat: stats.NilStatsReceiver()}
+	got, err := other.GetActionResult(context.Background(), &remoteexecution.GetActionResultRequest{ActionDigest: ad})
+	if err != nil {
+		t.Fatalf("Error response from GetActionResult: %v", err)
+	}
+	if got.GetExitCode() != 42 {
+		t.Fatalf("Expected ActionResult fetched from upstream, got %v", got)
+	}
+}
+
+func TestUpstreamFetch(t *testing.T) {
+	s, upStore, stop := makeUpstreamTestServers(t)
+	defer stop()
+
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	name := bazel.DigestStoreName(d)
+
+	// Data that doesn't match its Digest isn't cached
+	if err := upStore.Write(name, bytes.NewReader(testData2), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	if _, err := s.fetchUpstream(context.Background(), "", d); err == nil {
+		t.Fatal("Expected fetching mismatched data to fail")
+	}
+	if exists, _ := s.storeConfig.Store.Exists(name); exists {
+		t.Fatal("Expected mismatched data not to be cached locally")
+	}
+
+	// Requests for a blob already being fetched wait for that fetch
+	f, first := s.upstream.startFetch(name)
+	if !first {
+		t.Fatal("Expected no fetch in progress")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.fetchUpstream(ctx, "", d); err != context.Canceled {
+		t.Fatalf("Expected to wait for the fetch in progress, got %v", err)
+	}
+	s.upstream.endFetch(name, f, nil)
+
+	if err := upStore.Write(name, bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	r, err := s.fetchUpstream(context.Background(), "", d)
+	if err != nil {
+		t.Fatalf("Error fetching from upstream: %v", err)
+	}
+	defer r.Close()
+	if data, err := ioutil.ReadAll(r); err != nil || !bytes.Equal(data, testData1) {
+		t.Fatalf("Expected %s fetched from upstream, got %v %v", testHash1, data, err)
+	}
+}
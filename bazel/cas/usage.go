@@ -0,0 +1,174 @@
+package cas
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// How often aggregated ActionCache and CAS usage is reported
+	DefaultUsageReportInterval = time.Minute
+
+	// Maximum number of distinct tool names given their own stats scope. Tool names
+	// come from clients, so this bounds the number of stats we export.
+	MaxUsageTools = 20
+
+	// Maximum number of ActionCache misses per invocation included in a report
+	MaxUsageMissSamples = 10
+
+	// Name used for requests without RequestMetadata and for tools beyond MaxUsageTools
+	unknownTool = "unknown"
+	otherTool   = "other"
+)
+
+// Aggregates ActionCache hits and misses and CAS upload volume by tool and tool invocation,
+// using the RequestMetadata sent by clients like Bazel. Per-tool counts are exported as stats
+// scoped by tool name, and per-invocation counts are logged every report interval,
+// so it's possible to see which builds never hit the cache and which actions they missed on.
+// A nil *usageTracker is valid and tracks nothing.
+type usageTracker struct {
+	stat stats.StatsReceiver
+
+	mu          sync.Mutex
+	tools       map[string]stats.StatsReceiver // at most MaxUsageTools, other tools are counted as otherTool
+	other       stats.StatsReceiver
+	invocations map[string]*invocationUsage // tool invocation id -> usage since the last report
+}
+
+// Usage by a single tool invocation during one report interval
+type invocationUsage struct {
+	Tool          string
+	Invocation    string
+	ACHits        int64
+	ACMisses      int64
+	UploadedBlobs int64
+	UploadedBytes int64
+	MissedActions []string // up to MaxUsageMissSamples action digests that missed the cache
+}
+
+func (u *invocationUsage) hitRate() float64 {
+	if u.ACHits+u.ACMisses == 0 {
+		return 0
+	}
+	return float64(u.ACHits) / float64(u.ACHits+u.ACMisses)
+}
+
+func newUsageTracker(stat stats.StatsReceiver) *usageTracker {
+	return &usageTracker{
+		stat:        stat,
+		tools:       make(map[string]stats.StatsReceiver),
+		other:       stat.Scope("tool", otherTool),
+		invocations: make(map[string]*invocationUsage),
+	}
+}
+
+// Record the result of an ActionCache lookup for the given action digest.
+func (t *usageTracker) recordACLookup(ctx context.Context, action string, hit bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	toolStat, u := t.get(ctx)
+	if hit {
+		toolStat.Counter(stats.BzUsageACHitCounter).Inc(1)
+		u.ACHits++
+	} else {
+		toolStat.Counter(stats.BzUsageACMissCounter).Inc(1)
+		u.ACMisses++
+		if len(u.MissedActions) < MaxUsageMissSamples {
+			u.MissedActions = append(u.MissedActions, action)
+		}
+	}
+}
+
+// Record blobs uploaded to the CAS.
+func (t *usageTracker) recordUpload(ctx context.Context, blobs, bytes int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	toolStat, u := t.get(ctx)
+	toolStat.Counter(stats.BzUsageUploadedBlobsCounter).Inc(blobs)
+	toolStat.Counter(stats.BzUsageUploadedBytesCounter).Inc(bytes)
+	u.UploadedBlobs += blobs
+	u.UploadedBytes += bytes
+}
+
+// Get the stats scope and usage for the request's tool and invocation. Caller must hold the lock.
+func (t *usageTracker) get(ctx context.Context) (stats.StatsReceiver, *invocationUsage) {
+	rm := bazel.RequestMetadataFromContext(ctx)
+	tool := rm.GetToolDetails().GetToolName()
+	if tool == "" {
+		tool = unknownTool
+	}
+	invocation := rm.GetToolInvocationId()
+
+	toolStat, ok := t.tools[tool]
+	if !ok {
+		if len(t.tools) < MaxUsageTools {
+			toolStat = t.stat.Scope("tool", tool)
+			t.tools[tool] = toolStat
+		} else {
+			tool, toolStat = otherTool, t.other
+		}
+	}
+
+	u, ok := t.invocations[invocation]
+	if !ok {
+		u = &invocationUsage{Tool: tool, Invocation: invocation}
+		t.invocations[invocation] = u
+	}
+	return toolStat, u
+}
+
+// Log and reset the usage of each invocation seen since the last report,
+// ordered by most ActionCache misses first. Returns the reported usage.
+func (t *usageTracker) report() []*invocationUsage {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	usage := make([]*invocationUsage, 0, len(t.invocations))
+	for _, u := range t.invocations {
+		usage = append(usage, u)
+	}
+	t.invocations = make(map[string]*invocationUsage)
+	t.mu.Unlock()
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].ACMisses != usage[j].ACMisses {
+			return usage[i].ACMisses > usage[j].ACMisses
+		}
+		return usage[i].Invocation < usage[j].Invocation
+	})
+	for _, u := range usage {
+		log.WithFields(
+			log.Fields{
+				"tool":          u.Tool,
+				"invocation":    u.Invocation,
+				"acHits":        u.ACHits,
+				"acMisses":      u.ACMisses,
+				"acHitRate":     u.hitRate(),
+				"uploadedBlobs": u.UploadedBlobs,
+				"uploadedBytes": u.UploadedBytes,
+				"missedActions": u.MissedActions,
+			}).Info("ActionCache and CAS usage")
+	}
+	return usage
+}
+
+// Report usage every interval. Never returns.
+func (t *usageTracker) loop(interval time.Duration) {
+	for range time.NewTicker(interval).C {
+		t.report()
+	}
+}
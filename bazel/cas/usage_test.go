@@ -0,0 +1,77 @@
+package cas
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+)
+
+func makeRequestMetadataContext(t *testing.T, tool, invocation string) context.Context {
+	rm := &remoteexecution.RequestMetadata{
+		ToolDetails:      &remoteexecution.ToolDetails{ToolName: tool},
+		ToolInvocationId: invocation,
+	}
+	b, err := proto.Marshal(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(bazel.RequestMetadataHeader, string(b)))
+}
+
+func TestUsageTrackerReport(t *testing.T) {
+	u := newUsageTracker(stats.NilStatsReceiver())
+	inv1 := makeRequestMetadataContext(t, "bazel", "inv1")
+	inv2 := makeRequestMetadataContext(t, "bazel", "inv2")
+
+	u.recordACLookup(inv1, "action1", true)
+	u.recordACLookup(inv1, "action2", false)
+	u.recordUpload(inv1, 2, 100)
+	u.recordACLookup(inv2, "action3", false)
+	u.recordACLookup(inv2, "action4", false)
+	u.recordACLookup(context.Background(), "action5", true)
+
+	usage := u.report()
+	if len(usage) != 3 {
+		t.Fatalf("Expected usage for 3 invocations, got %+v", usage)
+	}
+	if usage[0].Invocation != "inv2" || usage[0].ACMisses != 2 || len(usage[0].MissedActions) != 2 {
+		t.Fatalf("Expected inv2 with the most misses first, got %+v", usage[0])
+	}
+	if usage[1].Invocation != "inv1" || usage[1].Tool != "bazel" || usage[1].hitRate() != 0.5 ||
+		usage[1].UploadedBlobs != 2 || usage[1].UploadedBytes != 100 {
+		t.Fatalf("Unexpected usage for inv1: %+v", usage[1])
+	}
+	if usage[2].Tool != unknownTool || usage[2].ACHits != 1 {
+		t.Fatalf("Expected requests without metadata to be tracked under %s, got %+v", unknownTool, usage[2])
+	}
+
+	if usage := u.report(); len(usage) != 0 {
+		t.Fatalf("Expected usage to be reset after a report, got %+v", usage)
+	}
+}
+
+func TestUsageTrackerMaxTools(t *testing.T) {
+	u := newUsageTracker(stats.NilStatsReceiver())
+	for i := 0; i < MaxUsageTools+5; i++ {
+		u.recordACLookup(makeRequestMetadataContext(t, fmt.Sprintf("tool%d", i), fmt.Sprintf("inv%d", i)), "action", true)
+	}
+	if len(u.tools) != MaxUsageTools {
+		t.Fatalf("Expected %d tools to be tracked, got %d", MaxUsageTools, len(u.tools))
+	}
+	other := 0
+	for _, usage := range u.report() {
+		if usage.Tool == otherTool {
+			other++
+		}
+	}
+	if other != 5 {
+		t.Fatalf("Expected 5 invocations counted as %s, got %d", otherTool, other)
+	}
+}
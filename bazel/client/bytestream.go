@@ -0,0 +1,213 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	uuid "github.com/nu7hatch/gouuid"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// Default size of each chunk sent when uploading to the CAS.
+const DefaultChunkSize = cas.DefaultReadCapacity
+
+// Read reads the blob identified by digest from the CAS.
+// If the blob doesn't exist, returns a cas.NotFoundError.
+func (c *Client) Read(ctx context.Context, digest *remoteexecution.Digest) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.Download(ctx, digest, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Download streams the blob identified by digest from the CAS into w.
+// Retried attempts resume from the last offset written to w.
+// If the blob doesn't exist, returns a cas.NotFoundError.
+func (c *Client) Download(ctx context.Context, digest *remoteexecution.Digest, w io.Writer) error {
+	// skip request processing for empty sha
+	if digest == nil || bazel.IsEmptyDigest(digest) {
+		return nil
+	}
+	rname, err := cas.GetDefaultReadResourceName(digest.GetHash(), digest.GetSizeBytes())
+	if err != nil {
+		return err
+	}
+
+	offset := int64(0)
+	return c.call(ctx, func(cc *grpc.ClientConn) error {
+		req := &bytestream.ReadRequest{
+			ResourceName: rname,
+			ReadOffset:   offset,
+			ReadLimit:    digest.GetSizeBytes() - offset,
+		}
+		n, err := download(ctx, bytestream.NewByteStreamClient(cc), req, w)
+		offset += n
+		return err
+	})
+}
+
+// download Recv's from the server until the ReadLimit is reached, returning the number of bytes written to w.
+func download(ctx context.Context, bsc bytestream.ByteStreamClient, req *bytestream.ReadRequest, w io.Writer) (int64, error) {
+	rc, err := bsc.Read(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	written := int64(0)
+	for written < req.ReadLimit {
+		res, err := rc.Recv()
+		if err == io.EOF {
+			return written, fmt.Errorf("Unexpected EOF after %d of %d bytes", written, req.ReadLimit)
+		} else if err != nil {
+			return written, notFoundOrErr(err)
+		}
+		n, err := w.Write(res.GetData())
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Write uploads data identified by digest to the CAS in chunks of c.ChunkSize.
+// The CRC32C of each chunk and of data are sent too, so the server detects data corrupted on the wire.
+func (c *Client) Write(ctx context.Context, digest *remoteexecution.Digest, data []byte) error {
+	sums := []string{}
+	for offset, chunkSize := 0, c.chunkSize(); offset < len(data); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		sums = append(sums, cas.CRC32C(data[offset:end]))
+	}
+	ctx = cas.WithChecksums(ctx, cas.ChunkCRC32CHeader, sums...)
+	ctx = cas.WithChecksums(ctx, cas.CommitCRC32CHeader, cas.CRC32C(data))
+	return c.Upload(ctx, digest, bytes.NewReader(data))
+}
+
+// Upload streams the content of r, identified by digest, to the CAS in chunks of c.ChunkSize.
+// r is rewound to its start on retried attempts.
+func (c *Client) Upload(ctx context.Context, digest *remoteexecution.Digest, r io.ReadSeeker) error {
+	// skip request processing for empty sha
+	if digest == nil || bazel.IsEmptyDigest(digest) {
+		return nil
+	}
+	chunkSize := c.chunkSize()
+
+	return c.call(ctx, func(cc *grpc.ClientConn) error {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		uid, _ := uuid.NewV4()
+		wname, err := cas.GetDefaultWriteResourceName(uid.String(), digest.GetHash(), digest.GetSizeBytes())
+		if err != nil {
+			return err
+		}
+		return upload(ctx, bytestream.NewByteStreamClient(cc), wname, digest.GetSizeBytes(), r, chunkSize)
+	})
+}
+
+func (c *Client) chunkSize() int {
+	if c.ChunkSize <= 0 {
+		return DefaultChunkSize
+	}
+	return c.ChunkSize
+}
+
+// upload sends size bytes from r to the server as a sequence of WriteRequests of at most chunkSize bytes.
+func upload(ctx context.Context, bsc bytestream.ByteStreamClient, wname string, size int64, r io.Reader, chunkSize int) error {
+	wc, err := bsc.Write(ctx)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	offset := int64(0)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+		finish := readErr != nil || offset+int64(n) >= size
+		req := &bytestream.WriteRequest{
+			WriteOffset: offset,
+			FinishWrite: finish,
+			Data:        buf[:n],
+		}
+		// Per the ByteStream API, only the first request needs the resource name.
+		if offset == 0 {
+			req.ResourceName = wname
+		}
+		if err := wc.Send(req); err != nil {
+			return err
+		}
+		offset += int64(n)
+		if finish {
+			break
+		}
+	}
+
+	res, err := wc.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if res.GetCommittedSize() != offset {
+		return fmt.Errorf("Committed size %d did not match data len %d", res.GetCommittedSize(), offset)
+	}
+	return nil
+}
+
+// FindMissingBlobs returns the subset of digests that are not present in the CAS.
+func (c *Client) FindMissingBlobs(ctx context.Context, digests []*remoteexecution.Digest) (missing []*remoteexecution.Digest, err error) {
+	err = c.call(ctx, func(cc *grpc.ClientConn) error {
+		req := &remoteexecution.FindMissingBlobsRequest{BlobDigests: digests}
+		res, err := remoteexecution.NewContentAddressableStorageClient(cc).FindMissingBlobs(ctx, req)
+		if err != nil {
+			return err
+		}
+		missing = res.GetMissingBlobDigests()
+		return nil
+	})
+	return missing, err
+}
+
+// GetActionResult gets the cached ActionResult for an Action's digest.
+// If there is no cached result, returns a cas.NotFoundError.
+func (c *Client) GetActionResult(ctx context.Context, digest *remoteexecution.Digest) (ar *remoteexecution.ActionResult, err error) {
+	err = c.call(ctx, func(cc *grpc.ClientConn) error {
+		req := &remoteexecution.GetActionResultRequest{ActionDigest: digest}
+		ar, err = remoteexecution.NewActionCacheClient(cc).GetActionResult(ctx, req)
+		return notFoundOrErr(err)
+	})
+	return ar, err
+}
+
+// UpdateActionResult caches an ActionResult for an Action's digest.
+func (c *Client) UpdateActionResult(ctx context.Context, digest *remoteexecution.Digest,
+	ar *remoteexecution.ActionResult) (out *remoteexecution.ActionResult, err error) {
+	err = c.call(ctx, func(cc *grpc.ClientConn) error {
+		req := &remoteexecution.UpdateActionResultRequest{ActionDigest: digest, ActionResult: ar}
+		out, err = remoteexecution.NewActionCacheClient(cc).UpdateActionResult(ctx, req)
+		return err
+	})
+	return out, err
+}
+
+// Converts grpc NOT_FOUND Statuses to a cas.NotFoundError, otherwise returns err unchanged.
+func notFoundOrErr(err error) error {
+	if grpcStatus, ok := status.FromError(err); ok && err != nil && grpcStatus.Code() == codes.NotFound {
+		return &cas.NotFoundError{Err: grpcStatus.Message()}
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+// Package client provides a gRPC client library for Scoot's Bazel services: the Execution
+// and Longrunning APIs served by the scheduler, and the CAS ByteStream and ActionCache APIs
+// served by the apiserver. It wraps connection management, retries with backoff and
+// chunked transfers so callers don't have to hand-roll gRPC plumbing.
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/dialer"
+)
+
+// Dial resolves a server address using r and dials it with an insecure connection.
+// The caller is responsible for closing the returned connection.
+func Dial(r dialer.Resolver) (*grpc.ClientConn, error) {
+	serverAddr, err := r.Resolve()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to resolve server address: %s", err)
+	}
+
+	cc, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to dial server %s: %s", serverAddr, err)
+	}
+	return cc, nil
+}
+
+// Client is a reusable connection to a server supporting some or all of the Bazel APIs.
+// The connection is established lazily and re-established after retryable errors,
+// at which point the Resolver is consulted again for a (possibly new) address.
+// Client is safe for concurrent use.
+type Client struct {
+	resolver dialer.Resolver
+	retry    RetryPolicy
+
+	// Size of each WriteRequest sent by Upload. Defaults to DefaultChunkSize.
+	ChunkSize int
+	// Digest function of the blobs added to Uploaders. Defaults to SHA256.
+	DigestFunction remoteexecution.DigestFunction
+
+	mu sync.Mutex
+	cc *grpc.ClientConn
+}
+
+// NewClient creates a Client that dials addresses from r and retries failed requests per policy.
+func NewClient(r dialer.Resolver, policy RetryPolicy) *Client {
+	return &Client{resolver: r, retry: policy, ChunkSize: DefaultChunkSize, DigestFunction: remoteexecution.DigestFunction_SHA256}
+}
+
+// Close releases the underlying connection, if any. The Client may be reused after Close.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cc == nil {
+		return nil
+	}
+	err := c.cc.Close()
+	c.cc = nil
+	return err
+}
+
+// conn returns the current connection, dialing a new one if needed.
+func (c *Client) conn() (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cc == nil {
+		cc, err := Dial(c.resolver)
+		if err != nil {
+			return nil, err
+		}
+		c.cc = cc
+	}
+	return c.cc, nil
+}
+
+// reset drops the connection cc if it is still current, so the next request redials.
+func (c *Client) reset(cc *grpc.ClientConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cc == cc {
+		c.cc.Close()
+		c.cc = nil
+	}
+}
+
+// call runs fn with a connection, retrying per the Client's RetryPolicy.
+func (c *Client) call(ctx context.Context, fn func(cc *grpc.ClientConn) error) error {
+	return c.retry.Do(ctx, func() error {
+		cc, err := c.conn()
+		if err != nil {
+			return err
+		}
+		err = fn(cc)
+		if err != nil && IsRetryable(err) {
+			c.reset(cc)
+		}
+		return err
+	})
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/bazel/cas/mock_bytestream"
+)
+
+func TestRetryPolicyDo(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2}
+
+	attempts := 0
+	err := p.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "")
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("Expected success after 3 attempts, got %d attempts and err: %v", attempts, err)
+	}
+
+	attempts = 0
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		return status.Error(codes.InvalidArgument, "")
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("Expected no retry of non-retryable error, got %d attempts and err: %v", attempts, err)
+	}
+
+	attempts = 0
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		return errors.New("dial failure")
+	})
+	if err == nil || attempts != 3 {
+		t.Fatalf("Expected 3 failed attempts, got %d attempts and err: %v", attempts, err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if IsRetryable(nil) {
+		t.Fatal("Expected nil to be non-retryable")
+	}
+	if IsRetryable(&cas.NotFoundError{}) || IsRetryable(status.Error(codes.NotFound, "")) {
+		t.Fatal("Expected NotFound to be non-retryable")
+	}
+	if !IsRetryable(status.Error(codes.ResourceExhausted, "")) {
+		t.Fatal("Expected ResourceExhausted to be retryable")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	for attempt, max := range map[int]time.Duration{1: 100, 2: 200, 3: 300, 10: 300} {
+		max = max * time.Millisecond
+		if b := p.Backoff(attempt); b > max || b < max*8/10 {
+			t.Fatalf("Attempt %d: expected backoff in [%v, %v], got %v", attempt, max*8/10, max, b)
+		}
+	}
+}
+
+func TestUploadChunks(t *testing.T) {
+	data := []byte("0123456789")
+	wname := "uploads/uuid/blobs/hash/10"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bsClientMock := mock_bytestream.NewMockByteStreamClient(mockCtrl)
+	bsWriteClientMock := mock_bytestream.NewMockByteStream_WriteClient(mockCtrl)
+
+	bsClientMock.EXPECT().Write(context.Background()).Return(bsWriteClientMock, nil)
+	gomock.InOrder(
+		bsWriteClientMock.EXPECT().Send(&bytestream.WriteRequest{ResourceName: wname, WriteOffset: 0, Data: data[0:4]}).Return(nil),
+		bsWriteClientMock.EXPECT().Send(&bytestream.WriteRequest{WriteOffset: 4, Data: data[4:8]}).Return(nil),
+		bsWriteClientMock.EXPECT().Send(&bytestream.WriteRequest{WriteOffset: 8, FinishWrite: true, Data: data[8:10]}).Return(nil),
+		bsWriteClientMock.EXPECT().CloseAndRecv().Return(&bytestream.WriteResponse{CommittedSize: 10}, nil),
+	)
+
+	if err := upload(context.Background(), bsClientMock, wname, 10, bytes.NewReader(data), 4); err != nil {
+		t.Fatalf("Error from chunked upload: %v", err)
+	}
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	req := &bytestream.ReadRequest{ResourceName: "blobs/hash/10", ReadLimit: 10}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bsClientMock := mock_bytestream.NewMockByteStreamClient(mockCtrl)
+	bsReadClientMock := mock_bytestream.NewMockByteStream_ReadClient(mockCtrl)
+
+	bsClientMock.EXPECT().Read(context.Background(), req).Return(bsReadClientMock, nil)
+	bsReadClientMock.EXPECT().Recv().Return(nil, status.Error(codes.NotFound, ""))
+
+	var buf bytes.Buffer
+	if _, err := download(context.Background(), bsClientMock, req, &buf); !cas.IsNotFoundError(err) {
+		t.Fatalf("Expected NotFoundError, got: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// ExecuteOperation is a longrunning.Operation from the Execution API with its
+// Metadata and (if Done) Response deserialized.
+type ExecuteOperation struct {
+	Operation *longrunning.Operation
+	Metadata  *remoteexecution.ExecuteOperationMetadata
+	// Nil until the Operation is Done
+	Response *remoteexecution.ExecuteResponse
+}
+
+// Name of the Operation, for use with GetOperation
+func (o *ExecuteOperation) Name() string {
+	return o.Operation.GetName()
+}
+
+// Done returns true if the Operation has completed and Response is set
+func (o *ExecuteOperation) Done() bool {
+	return o.Operation.GetDone()
+}
+
+// Execute makes an Execute request, returning the first Operation received from the stream.
+// By convention the stream is then closed and the caller polls the Operation using GetOperation.
+func (c *Client) Execute(ctx context.Context, req *remoteexecution.ExecuteRequest) (op *ExecuteOperation, err error) {
+	err = c.call(ctx, func(cc *grpc.ClientConn) error {
+		execClient, err := remoteexecution.NewExecutionClient(cc).Execute(ctx, req)
+		if err != nil {
+			return err
+		}
+		lop, err := execClient.Recv()
+		if err != nil {
+			return err
+		}
+		execClient.CloseSend()
+		op, err = ParseExecuteOperation(lop)
+		return err
+	})
+	return op, err
+}
+
+// GetOperation gets the current state of a named Operation started by Execute.
+func (c *Client) GetOperation(ctx context.Context, name string) (op *ExecuteOperation, err error) {
+	err = c.call(ctx, func(cc *grpc.ClientConn) error {
+		req := &longrunning.GetOperationRequest{Name: name}
+		lop, err := longrunning.NewOperationsClient(cc).GetOperation(ctx, req)
+		if err != nil {
+			return err
+		}
+		op, err = ParseExecuteOperation(lop)
+		return err
+	})
+	return op, err
+}
+
+// GetOperations gets the current state of many named Operations with as few requests as possible,
+// using ListOperations with a names Filter. Operations are returned in the order of names.
+// Operations the server failed to retrieve are Done, with the failure in Operation.GetError().
+func (c *Client) GetOperations(ctx context.Context, names []string) ([]*ExecuteOperation, error) {
+	ops := []*ExecuteOperation{}
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > bazel.MaxListOperations {
+			batch = batch[:bazel.MaxListOperations]
+		}
+		names = names[len(batch):]
+
+		token := ""
+		for {
+			var res *longrunning.ListOperationsResponse
+			err := c.call(ctx, func(cc *grpc.ClientConn) error {
+				req := &longrunning.ListOperationsRequest{
+					Filter:    bazel.OperationNamesFilter(batch),
+					PageToken: token,
+				}
+				var err error
+				res, err = longrunning.NewOperationsClient(cc).ListOperations(ctx, req)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, lop := range res.GetOperations() {
+				op, err := ParseExecuteOperation(lop)
+				if err != nil {
+					return nil, err
+				}
+				ops = append(ops, op)
+			}
+			if token = res.GetNextPageToken(); token == "" {
+				break
+			}
+		}
+	}
+	return ops, nil
+}
+
+// ParseExecuteOperation deserializes Operation.Metadata as an ExecuteOperationMetadata, and
+// Operation.Result (if present) as an ExecuteResponse, per the Execution API.
+func ParseExecuteOperation(lop *longrunning.Operation) (*ExecuteOperation, error) {
+	if lop == nil {
+		return nil, fmt.Errorf("Attempting to parse nil Operation")
+	}
+
+	op := &ExecuteOperation{Operation: lop, Metadata: &remoteexecution.ExecuteOperationMetadata{}}
+	if err := ptypes.UnmarshalAny(lop.GetMetadata(), op.Metadata); err != nil {
+		return nil, fmt.Errorf("Error deserializing metadata as ExecuteOperationMetadata: %s", err)
+	}
+
+	if lop.GetResponse() == nil {
+		return op, nil
+	}
+
+	op.Response = &remoteexecution.ExecuteResponse{}
+	if err := ptypes.UnmarshalAny(lop.GetResponse(), op.Response); err != nil {
+		return nil, fmt.Errorf("Error deserializing response as ExecuteResponse: %s", err)
+	}
+	return op, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel/cas"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// Reasonable default for how long to wait on a replica before hedging to the next one.
+const DefaultHedgeDelay = 500 * time.Millisecond
+
+var errNoReplicas = errors.New("No replicas to send request to")
+
+// Hedged sends idempotent CAS reads to replicas of the same CAS, trading extra load for lower tail latency.
+// A request is sent to the first replica, and if it hasn't succeeded within the hedge delay,
+// or fails sooner, it's also sent to the next replica. The first success wins and outstanding
+// requests are cancelled. Each replica's Client still retries per its own RetryPolicy.
+type Hedged struct {
+	replicas []*Client
+	delay    time.Duration
+}
+
+// NewHedged creates a Hedged that tries replicas in order, hedging after delay.
+// A delay <= 0 uses DefaultHedgeDelay.
+func NewHedged(delay time.Duration, replicas ...*Client) *Hedged {
+	if delay <= 0 {
+		delay = DefaultHedgeDelay
+	}
+	return &Hedged{replicas: replicas, delay: delay}
+}
+
+// Read reads the blob identified by digest from the first replica to return it.
+// Returns a cas.NotFoundError only if every replica reports the blob missing.
+func (h *Hedged) Read(ctx context.Context, digest *remoteexecution.Digest) ([]byte, error) {
+	results := make([][]byte, len(h.replicas))
+	i, err := hedge(ctx, len(h.replicas), h.delay, func(ctx context.Context, i int) (err error) {
+		results[i], err = h.replicas[i].Read(ctx, digest)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[i], nil
+}
+
+// FindMissingBlobs returns the subset of digests that the first replica to respond doesn't have.
+func (h *Hedged) FindMissingBlobs(ctx context.Context, digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, error) {
+	results := make([][]*remoteexecution.Digest, len(h.replicas))
+	i, err := hedge(ctx, len(h.replicas), h.delay, func(ctx context.Context, i int) (err error) {
+		results[i], err = h.replicas[i].FindMissingBlobs(ctx, digests)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[i], nil
+}
+
+// Close closes every replica's Client, returning the first error.
+func (h *Hedged) Close() (err error) {
+	for _, c := range h.replicas {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+type hedgeResult struct {
+	i   int
+	err error
+}
+
+// hedge calls attempt for each of n replicas in turn, starting the next when the previous
+// ones have been outstanding for delay or have all failed. Returns the index of the first
+// successful attempt. If all fail, a cas.NotFoundError is only returned if every attempt returned one,
+// since a replica may not have a blob yet while another does. Attempts still running
+// when hedge returns have their context cancelled, and must only write state owned by their index.
+func hedge(ctx context.Context, n int, delay time.Duration, attempt func(ctx context.Context, i int) error) (int, error) {
+	if n == 0 {
+		return -1, errNoReplicas
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan hedgeResult, n)
+	start := func(i int) {
+		go func() { done <- hedgeResult{i, attempt(ctx, i)} }()
+	}
+	start(0)
+	started, failed := 1, 0
+	var err error
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case r := <-done:
+			if r.err == nil {
+				return r.i, nil
+			}
+			if err == nil || cas.IsNotFoundError(err) {
+				err = r.err
+			}
+			failed++
+			if failed == n {
+				return -1, err
+			}
+			if failed == started {
+				// Nothing outstanding, don't wait for the delay to try the next replica.
+				start(started)
+				started++
+				timer.Reset(delay)
+			}
+		case <-timer.C:
+			if started < n {
+				log.Infof("Hedging bazel client request to replica %d after %v", started, delay)
+				start(started)
+				started++
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel/cas"
+)
+
+func TestHedgeSlowReplica(t *testing.T) {
+	// The first replica hangs until cancelled, so the request is hedged to the second.
+	cancelled := make(chan struct{})
+	i, err := hedge(context.Background(), 2, 10*time.Millisecond, func(ctx context.Context, i int) error {
+		if i == 0 {
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		}
+		return nil
+	})
+	if err != nil || i != 1 {
+		t.Fatalf("Expected replica 1 to win, got %d, %v", i, err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the slow attempt to be cancelled")
+	}
+}
+
+func TestHedgeFastFailure(t *testing.T) {
+	// A failure moves to the next replica without waiting for the delay.
+	start := time.Now()
+	i, err := hedge(context.Background(), 3, time.Hour, func(ctx context.Context, i int) error {
+		if i < 2 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	if err != nil || i != 2 {
+		t.Fatalf("Expected replica 2 to win, got %d, %v", i, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("Expected failed attempts to hedge immediately")
+	}
+}
+
+func TestHedgeAllFail(t *testing.T) {
+	notFound := &cas.NotFoundError{Err: "missing"}
+	unavailable := errors.New("unavailable")
+
+	_, err := hedge(context.Background(), 2, time.Hour, func(ctx context.Context, i int) error {
+		return notFound
+	})
+	if !cas.IsNotFoundError(err) {
+		t.Fatalf("Expected NotFound when every replica is missing the blob, got %v", err)
+	}
+
+	_, err = hedge(context.Background(), 2, time.Hour, func(ctx context.Context, i int) error {
+		if i == 0 {
+			return notFound
+		}
+		return unavailable
+	})
+	if err != unavailable {
+		t.Fatalf("Expected the unavailable replica's error, got %v", err)
+	}
+
+	if _, err := hedge(context.Background(), 0, time.Hour, nil); err == nil {
+		t.Fatal("Expected an error with no replicas")
+	}
+}
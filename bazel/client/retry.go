@@ -0,0 +1,91 @@
+package client
+
+import (
+	"math/rand"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel/cas"
+)
+
+// RetryPolicy describes how many times to attempt a request and how long to wait between attempts.
+// Backoff grows exponentially from InitialBackoff by Multiplier up to MaxBackoff, with up to 20% jitter.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// Reasonable defaults for interactive and service-to-service requests.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// Makes a single attempt per request.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// Backoff returns how long to wait after the given (1-based) failed attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+		if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+			backoff = float64(p.MaxBackoff)
+			break
+		}
+	}
+	jitter := backoff * 0.2 * rand.Float64()
+	return time.Duration(backoff - jitter)
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, MaxAttempts is reached,
+// or ctx is done. Returns the last error from fn.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) (err error) {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil || !IsRetryable(err) || attempt == attempts {
+			return err
+		}
+		backoff := p.Backoff(attempt)
+		log.WithFields(
+			log.Fields{
+				"attempt": attempt,
+				"backoff": backoff,
+				"err":     err,
+			}).Info("Retrying bazel client request")
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+	return err
+}
+
+// IsRetryable returns true if err indicates a transient failure that may succeed if retried.
+// Errors that aren't grpc Statuses (ex: failed dials) are considered retryable, except NotFoundErrors.
+func IsRetryable(err error) bool {
+	if err == nil || cas.IsNotFoundError(err) {
+		return false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
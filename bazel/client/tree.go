@@ -0,0 +1,281 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// A blob to upload to the CAS: either serialized Directory data or the path of a local file.
+type inputBlob struct {
+	digest *remoteexecution.Digest
+	data   []byte
+	path   string
+}
+
+// The blobs making up an input root built from a local directory, keyed by bazel.DigestToStr,
+// and the digest function they're addressed by.
+type inputTree struct {
+	blobs map[string]*inputBlob
+	fn    remoteexecution.DigestFunction
+}
+
+// UploadDir uploads the contents of dir to the CAS as an input root and returns the digest of its
+// root Directory, for use as an Action's InputRootDigest. Only blobs missing from the CAS are uploaded.
+// Symlinks are uploaded as symlinks, not followed, so links outside dir or in loops are preserved as they are.
+func (c *Client) UploadDir(ctx context.Context, dir string) (*remoteexecution.Digest, error) {
+	u := c.NewUploader()
+	root, err := u.AddDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Upload(ctx); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Adds the files and subdirectories of dir to the tree, and returns the digest of dir's Directory.
+func (t *inputTree) addDir(dir string) (*remoteexecution.Digest, error) {
+	_, digest, err := t.addDirectory(dir, nil)
+	return digest, err
+}
+
+// Adds the files and subdirectories of dir to the tree, and returns dir's Directory and its digest.
+// Entries are sorted by name as the Remote Execution API requires. Symlinks are added as SymlinkNodes
+// and files other than regular files, directories and symlinks are an error. If children isn't nil,
+// the Directory of each subdirectory is added to it, keyed by its digest, ex: to build a Tree.
+func (t *inputTree) addDirectory(dir string,
+	children map[string]*remoteexecution.Directory) (*remoteexecution.Directory, *remoteexecution.Digest, error) {
+	names, err := readDirNames(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := &remoteexecution.Directory{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return nil, nil, err
+			}
+			d.Symlinks = append(d.Symlinks, &remoteexecution.SymlinkNode{Name: name, Target: filepath.ToSlash(target)})
+			continue
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil, nil, fmt.Errorf("Unsupported file %s with mode %s", path, info.Mode())
+		}
+		if info.IsDir() {
+			sub, digest, err := t.addDirectory(path, children)
+			if err != nil {
+				return nil, nil, err
+			}
+			if children != nil {
+				children[bazel.DigestToStr(digest)] = sub
+			}
+			d.Directories = append(d.Directories, &remoteexecution.DirectoryNode{Name: name, Digest: digest})
+			continue
+		}
+		digest, err := t.fileDigest(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		t.blobs[bazel.DigestToStr(digest)] = &inputBlob{digest: digest, path: path}
+		d.Files = append(d.Files, &remoteexecution.FileNode{
+			Name:         name,
+			Digest:       digest,
+			IsExecutable: info.Mode()&0111 != 0,
+		})
+	}
+	digest, err := t.addMessage(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, digest, nil
+}
+
+// Adds a serialized message to the tree and returns its digest.
+func (t *inputTree) addMessage(m proto.Message) (*remoteexecution.Digest, error) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := t.dataDigest(data)
+	if err != nil {
+		return nil, err
+	}
+	t.blobs[bazel.DigestToStr(digest)] = &inputBlob{digest: digest, data: data}
+	return digest, nil
+}
+
+func readDirNames(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (t *inputTree) fileDigest(path string) (*remoteexecution.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h, err := bazel.NewDigestHash(t.fn)
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteexecution.Digest{Hash: hex.EncodeToString(h.Sum(nil)), SizeBytes: size}, nil
+}
+
+func (t *inputTree) dataDigest(data []byte) (*remoteexecution.Digest, error) {
+	hash, err := bazel.HashData(t.fn, data)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteexecution.Digest{Hash: hash, SizeBytes: int64(len(data))}, nil
+}
+
+// WaitOperation polls the named Operation every interval until it's Done or ctx is done.
+func (c *Client) WaitOperation(ctx context.Context, name string, interval time.Duration) (*ExecuteOperation, error) {
+	for {
+		op, err := c.GetOperation(ctx, name)
+		if err != nil || op.Done() {
+			return op, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// DownloadOutputs writes the output files and directories of ar under dir, at their paths relative
+// to the Action's working directory, and returns its stdout and stderr.
+func (c *Client) DownloadOutputs(ctx context.Context, ar *remoteexecution.ActionResult, dir string) (stdout, stderr []byte, err error) {
+	for _, f := range ar.GetOutputFiles() {
+		if err := c.downloadFile(ctx, f.GetDigest(), f.GetContents(), f.GetIsExecutable(), filepath.Join(dir, f.GetPath())); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, d := range ar.GetOutputDirectories() {
+		tree := &remoteexecution.Tree{}
+		data, err := c.Read(ctx, d.GetTreeDigest())
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := proto.Unmarshal(data, tree); err != nil {
+			return nil, nil, fmt.Errorf("Error deserializing Tree for %s: %s", d.GetPath(), err)
+		}
+		children, err := treeChildren(tree, bazel.InferDigestFunction(d.GetTreeDigest().GetHash()))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := c.downloadDir(ctx, tree.GetRoot(), children, filepath.Join(dir, d.GetPath())); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if stdout = ar.GetStdoutRaw(); stdout == nil && ar.GetStdoutDigest() != nil {
+		if stdout, err = c.Read(ctx, ar.GetStdoutDigest()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if stderr = ar.GetStderrRaw(); stderr == nil && ar.GetStderrDigest() != nil {
+		if stderr, err = c.Read(ctx, ar.GetStderrDigest()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return stdout, stderr, nil
+}
+
+// Indexes the children of a Tree by the digest of their serialized Directory, using digest function fn.
+func treeChildren(tree *remoteexecution.Tree, fn remoteexecution.DigestFunction) (map[string]*remoteexecution.Directory, error) {
+	children := map[string]*remoteexecution.Directory{}
+	for _, child := range tree.GetChildren() {
+		data, err := proto.Marshal(child)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := bazel.HashData(fn, data)
+		if err != nil {
+			return nil, err
+		}
+		children[bazel.DigestToStr(&remoteexecution.Digest{Hash: hash, SizeBytes: int64(len(data))})] = child
+	}
+	return children, nil
+}
+
+func (c *Client) downloadDir(ctx context.Context, d *remoteexecution.Directory,
+	children map[string]*remoteexecution.Directory, path string) error {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	for _, f := range d.GetFiles() {
+		if err := c.downloadFile(ctx, f.GetDigest(), nil, f.GetIsExecutable(), filepath.Join(path, f.GetName())); err != nil {
+			return err
+		}
+	}
+	for _, l := range d.GetSymlinks() {
+		if err := os.Symlink(filepath.FromSlash(l.GetTarget()), filepath.Join(path, l.GetName())); err != nil {
+			return err
+		}
+	}
+	for _, sub := range d.GetDirectories() {
+		child, ok := children[bazel.DigestToStr(sub.GetDigest())]
+		if !ok {
+			return fmt.Errorf("Tree is missing directory %s (%s)", sub.GetName(), bazel.DigestToStr(sub.GetDigest()))
+		}
+		if err := c.downloadDir(ctx, child, children, filepath.Join(path, sub.GetName())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes a file with the given digest to path, using contents if they were inlined.
+func (c *Client) downloadFile(ctx context.Context, digest *remoteexecution.Digest, contents []byte, executable bool, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if executable {
+		mode = 0755
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if contents != nil || bazel.IsEmptyDigest(digest) {
+		_, err = f.Write(contents)
+		return err
+	}
+	return c.Download(ctx, digest, f)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+func TestInputTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inputtree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "run.sh"), []byte("echo hi"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "b.txt"), []byte("same"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "sub", "c.txt"), []byte("same"), 0644)
+
+	tree := &inputTree{blobs: map[string]*inputBlob{}, fn: remoteexecution.DigestFunction_SHA256}
+	root, err := tree.addDir(dir)
+	if err != nil {
+		t.Fatalf("Error building input tree: %v", err)
+	}
+	// Two Directories, plus two distinct file contents.
+	if len(tree.blobs) != 4 {
+		t.Fatalf("Expected 4 blobs, got %d", len(tree.blobs))
+	}
+
+	rootDir := &remoteexecution.Directory{}
+	if err := proto.Unmarshal(tree.blobs[bazel.DigestToStr(root)].data, rootDir); err != nil {
+		t.Fatal(err)
+	}
+	files := rootDir.GetFiles()
+	if len(files) != 2 || files[0].GetName() != "b.txt" || files[1].GetName() != "run.sh" {
+		t.Fatalf("Expected files b.txt and run.sh in order, got %v", files)
+	}
+	if files[0].GetIsExecutable() || !files[1].GetIsExecutable() {
+		t.Errorf("Expected only run.sh to be executable, got %v", files)
+	}
+	if len(rootDir.GetDirectories()) != 1 || rootDir.GetDirectories()[0].GetName() != "sub" {
+		t.Fatalf("Expected directory sub, got %v", rootDir.GetDirectories())
+	}
+
+	children, err := treeChildren(&remoteexecution.Tree{
+		Root:     rootDir,
+		Children: []*remoteexecution.Directory{{Files: []*remoteexecution.FileNode{{Name: "c.txt", Digest: files[0].GetDigest()}}}},
+	}, remoteexecution.DigestFunction_SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := children[bazel.DigestToStr(rootDir.GetDirectories()[0].GetDigest())]; !ok {
+		t.Errorf("Expected Tree child to be found by its digest, got %v", children)
+	}
+}
+
+func TestInputTreeSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inputtree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+	os.Symlink("a.txt", filepath.Join(dir, "link"))
+	// A loop, which would recurse forever if links were followed
+	os.Symlink("..", filepath.Join(dir, "sub", "up"))
+
+	tree := &inputTree{blobs: map[string]*inputBlob{}, fn: remoteexecution.DigestFunction_SHA256}
+	root, err := tree.addDir(dir)
+	if err != nil {
+		t.Fatalf("Error building input tree: %v", err)
+	}
+	rootDir := &remoteexecution.Directory{}
+	if err := proto.Unmarshal(tree.blobs[bazel.DigestToStr(root)].data, rootDir); err != nil {
+		t.Fatal(err)
+	}
+	if len(rootDir.GetFiles()) != 1 || len(rootDir.GetSymlinks()) != 1 ||
+		rootDir.GetSymlinks()[0].GetName() != "link" || rootDir.GetSymlinks()[0].GetTarget() != "a.txt" {
+		t.Fatalf("Expected file a.txt and symlink link to it, got %v", rootDir)
+	}
+	subDir := &remoteexecution.Directory{}
+	if err := proto.Unmarshal(tree.blobs[bazel.DigestToStr(rootDir.GetDirectories()[0].GetDigest())].data, subDir); err != nil {
+		t.Fatal(err)
+	}
+	if len(subDir.GetSymlinks()) != 1 || subDir.GetSymlinks()[0].GetTarget() != ".." {
+		t.Fatalf("Expected symlink up to .., got %v", subDir)
+	}
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// Default number of blobs an Uploader writes to the CAS at once.
+const DefaultUploadParallelism = 8
+
+// Most digests sent in a single FindMissingBlobs request by an Uploader.
+const maxFindMissingBatch = 1000
+
+// Uploader collects local files, directories and messages to upload to the CAS, computing their
+// digests as they're added so callers can reference them, ex: in an Action, before anything is sent.
+// Upload then writes only the blobs missing from the CAS, several at a time.
+// An Uploader isn't safe for concurrent use.
+type Uploader struct {
+	client *Client
+	tree   *inputTree
+
+	// Most blobs written at once by Upload. Defaults to DefaultUploadParallelism.
+	Parallelism int
+}
+
+// NewUploader creates an empty Uploader that writes to c's CAS, with digests of c.DigestFunction.
+func (c *Client) NewUploader() *Uploader {
+	return &Uploader{
+		client:      c,
+		tree:        &inputTree{blobs: map[string]*inputBlob{}, fn: c.DigestFunction},
+		Parallelism: DefaultUploadParallelism,
+	}
+}
+
+// AddDir adds the files and subdirectories of dir, and returns the digest of its Directory,
+// ex: for use as an Action's InputRootDigest. Symlinks are added as symlinks rather than followed.
+func (u *Uploader) AddDir(dir string) (*remoteexecution.Digest, error) {
+	return u.tree.addDir(dir)
+}
+
+// AddTree adds the files and subdirectories of dir, and returns the digest of a Tree of them,
+// ex: for an ActionResult's OutputDirectory.
+func (u *Uploader) AddTree(dir string) (*remoteexecution.Digest, error) {
+	children := map[string]*remoteexecution.Directory{}
+	root, _, err := u.tree.addDirectory(dir, children)
+	if err != nil {
+		return nil, err
+	}
+	tree := &remoteexecution.Tree{Root: root}
+	for _, k := range sortedDirectoryKeys(children) {
+		tree.Children = append(tree.Children, children[k])
+	}
+	return u.AddMessage(tree)
+}
+
+// AddFile adds a local file, which is read again when it's uploaded, and returns its digest.
+func (u *Uploader) AddFile(path string) (*remoteexecution.Digest, error) {
+	digest, err := u.tree.fileDigest(path)
+	if err != nil {
+		return nil, err
+	}
+	u.tree.blobs[bazel.DigestToStr(digest)] = &inputBlob{digest: digest, path: path}
+	return digest, nil
+}
+
+// AddData adds a blob held in memory and returns its digest.
+func (u *Uploader) AddData(data []byte) (*remoteexecution.Digest, error) {
+	digest, err := u.tree.dataDigest(data)
+	if err != nil {
+		return nil, err
+	}
+	u.tree.blobs[bazel.DigestToStr(digest)] = &inputBlob{digest: digest, data: data}
+	return digest, nil
+}
+
+// AddMessage adds a serialized message, ex: a Command or Action, and returns its digest.
+func (u *Uploader) AddMessage(m proto.Message) (*remoteexecution.Digest, error) {
+	return u.tree.addMessage(m)
+}
+
+// Upload writes the added blobs missing from the CAS, at most u.Parallelism at a time.
+// Returns the first error, after which the remaining writes are cancelled.
+func (u *Uploader) Upload(ctx context.Context) error {
+	digests := []*remoteexecution.Digest{}
+	for _, b := range u.tree.blobs {
+		if !bazel.IsEmptyDigest(b.digest) {
+			digests = append(digests, b.digest)
+		}
+	}
+	missing := []*remoteexecution.Digest{}
+	for start := 0; start < len(digests); start += maxFindMissingBatch {
+		end := start + maxFindMissingBatch
+		if end > len(digests) {
+			end = len(digests)
+		}
+		m, err := u.client.FindMissingBlobs(ctx, digests[start:end])
+		if err != nil {
+			return err
+		}
+		missing = append(missing, m...)
+	}
+
+	parallelism := u.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultUploadParallelism
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var uploadErr error
+	for _, d := range missing {
+		b := u.tree.blobs[bazel.DigestToStr(d)]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := u.client.uploadBlob(ctx, b); err != nil {
+				once.Do(func() {
+					uploadErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if uploadErr == nil {
+		uploadErr = ctx.Err()
+	}
+	return uploadErr
+}
+
+func (c *Client) uploadBlob(ctx context.Context, b *inputBlob) error {
+	if b == nil {
+		return fmt.Errorf("CAS reported a blob missing that wasn't requested")
+	}
+	if b.path == "" {
+		return c.Write(ctx, b.digest, b.data)
+	}
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Upload(ctx, b.digest, f)
+}
+
+// NewCommand builds a Command, sorting its environment variables, outputs and platform
+// properties as the Remote Execution API requires so equal Commands have equal digests.
+func NewCommand(argv []string, env map[string]string, outputFiles, outputDirs []string,
+	platform map[string]string) *remoteexecution.Command {
+	envVars := []*remoteexecution.Command_EnvironmentVariable{}
+	for _, k := range sortedKeys(env) {
+		envVars = append(envVars, &remoteexecution.Command_EnvironmentVariable{Name: k, Value: env[k]})
+	}
+	props := []*remoteexecution.Platform_Property{}
+	for _, k := range sortedKeys(platform) {
+		props = append(props, &remoteexecution.Platform_Property{Name: k, Value: platform[k]})
+	}
+	outputFiles = append([]string{}, outputFiles...)
+	sort.Strings(outputFiles)
+	outputDirs = append([]string{}, outputDirs...)
+	sort.Strings(outputDirs)
+
+	return &remoteexecution.Command{
+		Arguments:            argv,
+		EnvironmentVariables: envVars,
+		OutputFiles:          outputFiles,
+		OutputDirectories:    outputDirs,
+		Platform:             &remoteexecution.Platform{Properties: props},
+	}
+}
+
+// Children are sorted by digest so equal Trees have equal digests.
+func sortedDirectoryKeys(m map[string]*remoteexecution.Directory) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
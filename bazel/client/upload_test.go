@@ -0,0 +1,115 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	scootproto "github.com/twitter/scoot/common/proto"
+)
+
+func TestUploaderDigests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uploader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "a.txt")
+	ioutil.WriteFile(path, []byte("same"), 0644)
+
+	u := NewClient(nil, DefaultRetryPolicy).NewUploader()
+	fileDigest, err := u.AddFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Identical content is only uploaded once.
+	if d, _ := u.AddData([]byte("same")); !reflect.DeepEqual(d, fileDigest) {
+		t.Fatalf("Expected file and data digests to match, got %s and %s", bazel.DigestToStr(fileDigest), bazel.DigestToStr(d))
+	}
+	if fileDigest.GetSizeBytes() != 4 || len(u.tree.blobs) != 1 {
+		t.Fatalf("Expected one blob of size 4, got %d blobs and digest %s", len(u.tree.blobs), bazel.DigestToStr(fileDigest))
+	}
+
+	cmd := NewCommand([]string{"true"}, nil, nil, nil, nil)
+	cmdDigest, err := u.AddMessage(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, size, _ := scootproto.GetSha256(cmd)
+	if cmdDigest.GetHash() != hash || cmdDigest.GetSizeBytes() != size || len(u.tree.blobs) != 2 {
+		t.Fatalf("Expected the Command to be added as %s/%d, got %s", hash, size, bazel.DigestToStr(cmdDigest))
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand([]string{"run"}, map[string]string{"B": "2", "A": "1"},
+		[]string{"out/b", "out/a"}, []string{"d"}, map[string]string{"os": "linux", "cpu": "x86"})
+	expected := &remoteexecution.Command{
+		Arguments: []string{"run"},
+		EnvironmentVariables: []*remoteexecution.Command_EnvironmentVariable{
+			{Name: "A", Value: "1"}, {Name: "B", Value: "2"},
+		},
+		OutputFiles:       []string{"out/a", "out/b"},
+		OutputDirectories: []string{"d"},
+		Platform: &remoteexecution.Platform{Properties: []*remoteexecution.Platform_Property{
+			{Name: "cpu", Value: "x86"}, {Name: "os", Value: "linux"},
+		}},
+	}
+	if !reflect.DeepEqual(cmd, expected) {
+		t.Fatalf("Expected %v, got %v", expected, cmd)
+	}
+}
+
+func TestUploaderDigestFunction(t *testing.T) {
+	c := NewClient(nil, DefaultRetryPolicy)
+	c.DigestFunction = remoteexecution.DigestFunction_SHA512
+	u := c.NewUploader()
+	d, err := u.AddData([]byte("same"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash, _ := bazel.HashData(remoteexecution.DigestFunction_SHA512, []byte("same")); d.GetHash() != hash {
+		t.Fatalf("Expected a SHA512 digest %s, got %s", hash, d.GetHash())
+	}
+
+	c.DigestFunction = remoteexecution.DigestFunction_MD5
+	if _, err := c.NewUploader().AddData([]byte("same")); err == nil {
+		t.Fatal("Expected an error adding data with an unsupported digest function")
+	}
+}
+
+func TestUploaderTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uploader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "a", "b"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "a", "b", "c.txt"), []byte("c"), 0644)
+
+	u := NewClient(nil, DefaultRetryPolicy).NewUploader()
+	treeDigest, err := u.AddTree(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := &remoteexecution.Tree{}
+	if err := proto.Unmarshal(u.tree.blobs[bazel.DigestToStr(treeDigest)].data, tree); err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.GetRoot().GetDirectories()) != 1 || len(tree.GetChildren()) != 2 {
+		t.Fatalf("Expected a root with one directory and two children, got %v", tree)
+	}
+	children, err := treeChildren(tree, remoteexecution.DigestFunction_SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := children[bazel.DigestToStr(tree.GetRoot().GetDirectories()[0].GetDigest())]; !ok {
+		t.Errorf("Expected the root's directory to be a child of the Tree, got %v", children)
+	}
+}
@@ -9,7 +9,7 @@ const (
 
 	// Snapshot-related constants
 	SnapshotIDPrefix = "bz"
-	InvalidIDMsg     = "Expected ID to be of format bz-<sha256>-<sizeBytes>, was"
+	InvalidIDMsg     = "Expected ID to be of format bz-<hash>-<sizeBytes>, was"
 
 	// Nil-data/Empty SHA-256 data
 	EmptySha  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
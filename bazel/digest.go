@@ -4,25 +4,37 @@ package bazel
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/snapshot/snapid"
 )
 
-// Validate Digest hash and size components assuming SHA256
+// Validate Digest hash and size components, with the digest function inferred from the hash
 // Size -1 indicates unknown size to support certain size-less operations
 //	* this is a deviation from the public API
 func IsValidDigest(hash string, size int64) bool {
-	return len(hash) == 64 && size >= -1
+	return IsValidDigestFunction(InferDigestFunction(hash), hash, size)
 }
 
-// Translate a Bazel Digest into a unique resource name for use in a bundleStore
-// Only use hash in store name, size is dropped
+// Translate a Bazel Digest into a unique resource name for use in a bundleStore,
+// with the digest function inferred from the hash
 func DigestStoreName(digest *remoteexecution.Digest) string {
-	if digest != nil {
+	return DigestFunctionStoreName(InferDigestFunction(digest.GetHash()), digest)
+}
+
+// Translate a Bazel Digest of digest function f into a unique resource name for use in a bundleStore
+// Only use hash in store name, size is dropped. Digest functions other than SHA256 are included in the name,
+// so blobs with the same hash under different functions don't collide.
+func DigestFunctionStoreName(f remoteexecution.DigestFunction, digest *remoteexecution.Digest) string {
+	if digest == nil {
+		return ""
+	}
+	if f == remoteexecution.DigestFunction_SHA256 {
 		return fmt.Sprintf("%s-%s.%s", StorePrefix, digest.GetHash(), StorePrefix)
 	}
-	return ""
+	return fmt.Sprintf("%s-%s-%s.%s", StorePrefix, DigestFunctionName(f), digest.GetHash(), StorePrefix)
 }
 
 // Create a Digest from a proprietary string format: "<hash>/<size>".
@@ -32,11 +44,15 @@ func DigestFromString(s string) (*remoteexecution.Digest, error) {
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("Invalid format, expected '<hash>/<size>'")
 	}
-	snap := fmt.Sprintf("%s-%s-%s", SnapshotIDPrefix, parts[0], parts[1])
-	if err := ValidateID(snap); err != nil {
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid size %q, expected '<hash>/<size>'", parts[1])
+	}
+	snap := snapid.Bazel(parts[0], size)
+	if err := snap.Validate(); err != nil {
 		return nil, err
 	}
-	return DigestFromSnapshotID(snap)
+	return snap.Digest()
 }
 
 func DigestToStr(d *remoteexecution.Digest) string {
@@ -0,0 +1,119 @@
+package bazel
+
+// Digest function utilities for Bazel
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+	"sync"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/snapshot/snapid"
+)
+
+// A hash function blobs can be addressed by.
+type digestFunction struct {
+	name    string
+	hexLen  int
+	newHash func() hash.Hash
+}
+
+var (
+	digestFunctionsMu sync.RWMutex
+	digestFunctions   = map[remoteexecution.DigestFunction]*digestFunction{
+		remoteexecution.DigestFunction_SHA256: {name: "sha256", hexLen: 64, newHash: sha256.New},
+		remoteexecution.DigestFunction_SHA512: {name: "sha512", hexLen: 128, newHash: sha512.New},
+	}
+)
+
+// Adds a digest function whose implementation isn't vendored, ex: BLAKE3, producing hashes of hexLen characters.
+// Only functions with an implementation are supported and advertised, so newHash is required.
+func RegisterDigestFunction(f remoteexecution.DigestFunction, hexLen int, newHash func() hash.Hash) error {
+	if _, ok := remoteexecution.DigestFunction_name[int32(f)]; !ok || f == remoteexecution.DigestFunction_UNKNOWN {
+		return fmt.Errorf("Unknown digest function %s", f)
+	}
+	if hexLen <= 0 || newHash == nil {
+		return fmt.Errorf("Digest function %s needs a hash length and implementation", f)
+	}
+	digestFunctionsMu.Lock()
+	defer digestFunctionsMu.Unlock()
+	if _, ok := digestFunctions[f]; ok {
+		return fmt.Errorf("Digest function %s is already registered", f)
+	}
+	digestFunctions[f] = &digestFunction{name: DigestFunctionName(f), hexLen: hexLen, newHash: newHash}
+	snapid.RegisterDigestFunction(f, hexLen)
+	return nil
+}
+
+func lookupDigestFunction(f remoteexecution.DigestFunction) (*digestFunction, bool) {
+	digestFunctionsMu.RLock()
+	defer digestFunctionsMu.RUnlock()
+	df, ok := digestFunctions[f]
+	return df, ok
+}
+
+// Returns the digest functions blobs can be addressed by, to be advertised in Capabilities
+func DigestFunctions() []remoteexecution.DigestFunction {
+	digestFunctionsMu.RLock()
+	defer digestFunctionsMu.RUnlock()
+	fs := []remoteexecution.DigestFunction{}
+	for f := range digestFunctions {
+		fs = append(fs, f)
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i] < fs[j] })
+	return fs
+}
+
+// Returns the supported digest function with the given name, as used in resource names, e.g. "sha512"
+func ParseDigestFunction(name string) (remoteexecution.DigestFunction, bool) {
+	f := remoteexecution.DigestFunction(remoteexecution.DigestFunction_value[strings.ToUpper(name)])
+	if _, ok := lookupDigestFunction(f); !ok {
+		return remoteexecution.DigestFunction_UNKNOWN, false
+	}
+	return f, true
+}
+
+// Returns the name of a digest function as used in resource names and store names
+func DigestFunctionName(f remoteexecution.DigestFunction) string {
+	return strings.ToLower(f.String())
+}
+
+// Returns the digest function of a hash that wasn't given one explicitly, determined by its length.
+// Hashes the length of a SHA256 are taken to be SHA256, which is also the default for unrecognized lengths.
+func InferDigestFunction(hash string) remoteexecution.DigestFunction {
+	if df, ok := lookupDigestFunction(remoteexecution.DigestFunction_SHA512); ok && len(hash) == df.hexLen {
+		return remoteexecution.DigestFunction_SHA512
+	}
+	return remoteexecution.DigestFunction_SHA256
+}
+
+// Validate Digest hash and size components for digest function f
+// Size -1 indicates unknown size, see IsValidDigest
+func IsValidDigestFunction(f remoteexecution.DigestFunction, hash string, size int64) bool {
+	df, ok := lookupDigestFunction(f)
+	return ok && len(hash) == df.hexLen && size >= -1
+}
+
+// Returns the hex encoded hash of data using digest function f
+func HashData(f remoteexecution.DigestFunction, data []byte) (string, error) {
+	h, err := NewDigestHash(f)
+	if err != nil {
+		return "", err
+	}
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Returns a new hash.Hash for digest function f
+func NewDigestHash(f remoteexecution.DigestFunction) (hash.Hash, error) {
+	df, ok := lookupDigestFunction(f)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported digest function %s", f)
+	}
+	return df.newHash(), nil
+}
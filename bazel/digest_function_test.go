@@ -0,0 +1,78 @@
+package bazel
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+func TestDigestFunctions(t *testing.T) {
+	sha512Hash := strings.Repeat(EmptySha, 2)
+	if f := InferDigestFunction(EmptySha); f != remoteexecution.DigestFunction_SHA256 {
+		t.Fatalf("Expected SHA256 hash to be inferred as SHA256, got %s", f)
+	}
+	if f := InferDigestFunction(sha512Hash); f != remoteexecution.DigestFunction_SHA512 {
+		t.Fatalf("Expected SHA512 hash to be inferred as SHA512, got %s", f)
+	}
+	if !IsValidDigest(sha512Hash, 5) {
+		t.Fatalf("Expected SHA512 hash %s to be valid", sha512Hash)
+	}
+	if IsValidDigestFunction(remoteexecution.DigestFunction_SHA512, EmptySha, 5) {
+		t.Fatal("Expected SHA256 hash to be invalid as a SHA512 digest")
+	}
+
+	h, err := HashData(remoteexecution.DigestFunction_SHA512, []byte("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a" +
+		"2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"
+	if h != expected {
+		t.Fatalf("Expected SHA512 of abc %s, got %s", expected, h)
+	}
+
+	d := &remoteexecution.Digest{Hash: EmptySha, SizeBytes: 0}
+	if n := DigestFunctionStoreName(remoteexecution.DigestFunction_SHA256, d); n != DigestStoreName(d) || n != "blob-"+EmptySha+".blob" {
+		t.Fatalf("Expected SHA256 store name to be unchanged, got %s", n)
+	}
+	if n := DigestFunctionStoreName(remoteexecution.DigestFunction_BLAKE3, d); n != "blob-blake3-"+EmptySha+".blob" {
+		t.Fatalf("Expected BLAKE3 store name to include the digest function, got %s", n)
+	}
+}
+
+func TestRegisterDigestFunction(t *testing.T) {
+	if _, ok := ParseDigestFunction("blake3"); ok {
+		t.Fatal("Expected BLAKE3 to be unsupported until it's registered")
+	}
+	if fs := DigestFunctions(); len(fs) != 2 {
+		t.Fatalf("Expected only SHA256 and SHA512 to be advertised, got %v", fs)
+	}
+	if err := RegisterDigestFunction(remoteexecution.DigestFunction_BLAKE3, 64, nil); err == nil {
+		t.Fatal("Expected registering a digest function without an implementation to fail")
+	}
+	if err := RegisterDigestFunction(remoteexecution.DigestFunction_SHA256, 64, sha256.New); err == nil {
+		t.Fatal("Expected registering a digest function twice to fail")
+	}
+
+	// Any 32 byte hash stands in for a BLAKE3 implementation
+	if err := RegisterDigestFunction(remoteexecution.DigestFunction_BLAKE3, 64, sha256.New); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		digestFunctionsMu.Lock()
+		delete(digestFunctions, remoteexecution.DigestFunction_BLAKE3)
+		digestFunctionsMu.Unlock()
+	}()
+	if f, ok := ParseDigestFunction("blake3"); !ok || f != remoteexecution.DigestFunction_BLAKE3 {
+		t.Fatalf("Expected BLAKE3 to be supported once registered, got %s %v", f, ok)
+	}
+	fs := DigestFunctions()
+	if len(fs) != 3 || fs[2] != remoteexecution.DigestFunction_BLAKE3 {
+		t.Fatalf("Expected BLAKE3 to be advertised once registered, got %v", fs)
+	}
+	if !IsValidDigestFunction(remoteexecution.DigestFunction_BLAKE3, EmptySha, 0) {
+		t.Fatal("Expected 64 character hash to be a valid BLAKE3 digest")
+	}
+}
@@ -0,0 +1,238 @@
+package execution
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	log "github.com/sirupsen/logrus"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
+	build "google.golang.org/genproto/googleapis/devtools/build/v1"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+
+	"github.com/twitter/scoot/bazel/client"
+	"github.com/twitter/scoot/common/dialer"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// BESResolver resolves the address of a Build Event Service that execution progress is published to.
+// Defined as its own type so it can be injected via ICE. If nil, no events are published.
+type BESResolver dialer.Resolver
+
+const (
+	// Maximum number of operations published concurrently. Further operations aren't published,
+	// which is logged and counted in BzBESDroppedCounter.
+	DefaultMaxBESStreams = 1000
+
+	// Consecutive failures to get an operation's status before publishing it is abandoned
+	maxBESStatusFailures = 10
+)
+
+// besPublisher publishes the progress of operations run through the execution server to a Build Event Service,
+// so build dashboards consuming BES see remote execution activity alongside the client's own events.
+//
+// Each operation gets its own event stream from the WORKER component. Its BuildId is the client's
+// correlated invocations ID, or its tool invocation ID, from the RequestMetadata sent with Execute,
+// so dashboards can attribute it to the build that requested it. Its InvocationId is the operation's name.
+// A BuildExecutionEvent holding the operation's ExecuteOperationMetadata is sent each time its stage changes,
+// then one holding its ExecuteResponse once it's done, and finally a ComponentStreamFinished event.
+// Operations aren't polled, their status is read again each time their job's saga logs a message.
+//
+// Publishing is best effort: a stream that fails is logged and abandoned without affecting the operation.
+// A nil *besPublisher is valid and publishes nothing.
+type besPublisher struct {
+	resolver dialer.Resolver
+	getOp    func(name string) (*longrunning.Operation, error)
+	watch    func(name string) (<-chan struct{}, func())
+	open     func(ctx context.Context) (build.PublishBuildEvent_PublishBuildToolEventStreamClient, error)
+	stat     stats.StatsReceiver
+
+	mu      sync.Mutex
+	cc      *grpc.ClientConn
+	streams int
+}
+
+// Returns nil if r is nil. getOp returns the current state of an operation, ex: executionServer.getOperation,
+// and watch signals changes to it, ex: saga.SagaCoordinator.Watch.
+func newBESPublisher(r BESResolver, getOp func(string) (*longrunning.Operation, error),
+	watch func(string) (<-chan struct{}, func()), stat stats.StatsReceiver) *besPublisher {
+	if r == nil {
+		return nil
+	}
+	p := &besPublisher{resolver: r, getOp: getOp, watch: watch, stat: stat}
+	p.open = p.openStream
+	return p
+}
+
+// Starts publishing the operation with the given name in the background, if under the stream limit.
+func (p *besPublisher) publish(name string, actionDigest *remoteexecution.Digest, rm *remoteexecution.RequestMetadata) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	if p.streams >= DefaultMaxBESStreams {
+		p.mu.Unlock()
+		log.Errorf("Not publishing operation %s to BES, %d streams already open", name, DefaultMaxBESStreams)
+		p.stat.Counter(stats.BzBESDroppedCounter).Inc(1)
+		return
+	}
+	p.streams++
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			p.streams--
+			p.mu.Unlock()
+		}()
+		if err := p.publishOperation(name, actionDigest, rm); err != nil {
+			log.Errorf("Failed publishing operation %s to BES: %v", name, err)
+			p.stat.Counter(stats.BzBESFailureCounter).Inc(1)
+		}
+	}()
+}
+
+// Returns the number of operations being published.
+func (p *besPublisher) openStreams() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.streams
+}
+
+func (p *besPublisher) openStream(ctx context.Context) (build.PublishBuildEvent_PublishBuildToolEventStreamClient, error) {
+	p.mu.Lock()
+	if p.cc == nil {
+		cc, err := client.Dial(p.resolver)
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		p.cc = cc
+	}
+	cc := p.cc
+	p.mu.Unlock()
+	return build.NewPublishBuildEventClient(cc).PublishBuildToolEventStream(ctx)
+}
+
+// Publishes the operation's events until it's done, then waits for the service to acknowledge them all.
+func (p *besPublisher) publishOperation(name string, actionDigest *remoteexecution.Digest,
+	rm *remoteexecution.RequestMetadata) error {
+	// Watch before reading the operation's status so no change is missed.
+	changed, stop := p.watch(name)
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := p.open(ctx)
+	if err != nil {
+		return err
+	}
+	acked := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := stream.Recv(); err == io.EOF {
+				acked <- nil
+				return
+			} else if err != nil {
+				acked <- err
+				return
+			}
+		}
+	}()
+
+	streamID := besStreamID(name, rm)
+	seq := int64(0)
+	send := func(e *build.BuildEvent) error {
+		seq++
+		e.EventTime = ptypes.TimestampNow()
+		req := &build.PublishBuildToolEventStreamRequest{
+			OrderedBuildEvent: &build.OrderedBuildEvent{StreamId: streamID, SequenceNumber: seq, Event: e},
+		}
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		p.stat.Counter(stats.BzBESEventsCounter).Inc(1)
+		return nil
+	}
+
+	// Operations start queued, as returned by Execute
+	queued, err := marshalAny(&remoteexecution.ExecuteOperationMetadata{
+		Stage:        remoteexecution.ExecuteOperationMetadata_QUEUED,
+		ActionDigest: actionDigest,
+	})
+	if err != nil {
+		return err
+	}
+	if err := send(executionEvent(queued)); err != nil {
+		return err
+	}
+	stage := remoteexecution.ExecuteOperationMetadata_QUEUED
+
+	// Read the operation's status again each time it changes
+	for failures := 0; ; <-changed {
+		op, err := p.getOp(name)
+		if err != nil {
+			if failures++; failures >= maxBESStatusFailures {
+				return fmt.Errorf("Failed to get operation %d times: %v", failures, err)
+			}
+			continue
+		}
+		failures = 0
+		if opStage := operationStage(op); opStage != stage {
+			stage = opStage
+			if err := send(executionEvent(op.GetMetadata())); err != nil {
+				return err
+			}
+		}
+		if op.GetDone() {
+			res, err := operationResponse(op)
+			if err != nil {
+				return err
+			}
+			if err := send(executionEvent(res)); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	if err := send(&build.BuildEvent{
+		Event: &build.BuildEvent_ComponentStreamFinished{
+			ComponentStreamFinished: &build.BuildEvent_BuildComponentStreamFinished{
+				Type: build.BuildEvent_BuildComponentStreamFinished_FINISHED,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	return <-acked
+}
+
+func besStreamID(name string, rm *remoteexecution.RequestMetadata) *build.StreamId {
+	buildID := rm.GetCorrelatedInvocationsId()
+	if buildID == "" {
+		buildID = rm.GetToolInvocationId()
+	}
+	if buildID == "" {
+		buildID = name
+	}
+	return &build.StreamId{BuildId: buildID, InvocationId: name, Component: build.StreamId_WORKER}
+}
+
+func executionEvent(a *any.Any) *build.BuildEvent {
+	return &build.BuildEvent{Event: &build.BuildEvent_BuildExecutionEvent{BuildExecutionEvent: a}}
+}
+
+// Returns the ExecuteResponse of a done operation as an Any, making one from its error if it failed.
+func operationResponse(op *longrunning.Operation) (*any.Any, error) {
+	if res := op.GetResponse(); res != nil {
+		return res, nil
+	}
+	return marshalAny(&remoteexecution.ExecuteResponse{Status: op.GetError()})
+}
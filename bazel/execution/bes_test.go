@@ -0,0 +1,125 @@
+package execution
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
+	build "google.golang.org/genproto/googleapis/devtools/build/v1"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+func TestBESPublishOperation(t *testing.T) {
+	stages := []remoteexecution.ExecuteOperationMetadata_Stage{
+		remoteexecution.ExecuteOperationMetadata_QUEUED,
+		remoteexecution.ExecuteOperationMetadata_EXECUTING,
+		remoteexecution.ExecuteOperationMetadata_EXECUTING,
+		remoteexecution.ExecuteOperationMetadata_COMPLETED,
+	}
+	polls := 0
+	getOp := func(name string) (*longrunning.Operation, error) {
+		stage := stages[polls]
+		polls++
+		eom, _ := marshalAny(&remoteexecution.ExecuteOperationMetadata{Stage: stage})
+		op := &longrunning.Operation{Name: name, Metadata: eom}
+		if stage == remoteexecution.ExecuteOperationMetadata_COMPLETED {
+			res, _ := marshalAny(&remoteexecution.ExecuteResponse{Result: &remoteexecution.ActionResult{ExitCode: 1}})
+			op.Done = true
+			op.Result = &longrunning.Operation_Response{Response: res}
+		}
+		return op, nil
+	}
+	// The status is read once, then again after each change.
+	changed := make(chan struct{}, len(stages)-1)
+	for i := 1; i < len(stages); i++ {
+		changed <- struct{}{}
+	}
+	stopped := false
+	stream := &fakeBESStream{closed: make(chan struct{})}
+	p := &besPublisher{
+		getOp: getOp,
+		watch: func(string) (<-chan struct{}, func()) {
+			return changed, func() { stopped = true }
+		},
+		open: func(context.Context) (build.PublishBuildEvent_PublishBuildToolEventStreamClient, error) {
+			return stream, nil
+		},
+		stat: stats.NilStatsReceiver(),
+	}
+
+	rm := &remoteexecution.RequestMetadata{ToolInvocationId: "inv1"}
+	if err := p.publishOperation("op1", &remoteexecution.Digest{Hash: "abc", SizeBytes: 1}, rm); err != nil {
+		t.Fatalf("Error publishing operation: %v", err)
+	}
+
+	// Queued, executing, completed, the response and the end of the stream
+	if len(stream.sent) != 5 {
+		t.Fatalf("Expected 5 events, got %d: %v", len(stream.sent), stream.sent)
+	}
+	for i, req := range stream.sent {
+		obe := req.GetOrderedBuildEvent()
+		if obe.GetSequenceNumber() != int64(i+1) || obe.GetStreamId().GetBuildId() != "inv1" ||
+			obe.GetStreamId().GetInvocationId() != "op1" {
+			t.Fatalf("Unexpected event %d: %v", i, obe)
+		}
+	}
+	for i, stage := range []remoteexecution.ExecuteOperationMetadata_Stage{
+		remoteexecution.ExecuteOperationMetadata_QUEUED,
+		remoteexecution.ExecuteOperationMetadata_EXECUTING,
+		remoteexecution.ExecuteOperationMetadata_COMPLETED,
+	} {
+		eom := &remoteexecution.ExecuteOperationMetadata{}
+		if err := ptypes.UnmarshalAny(stream.sent[i].GetOrderedBuildEvent().GetEvent().GetBuildExecutionEvent(), eom); err != nil {
+			t.Fatalf("Expected event %d to hold ExecuteOperationMetadata: %v", i, err)
+		}
+		if eom.GetStage() != stage {
+			t.Fatalf("Expected event %d to be stage %v, got %v", i, stage, eom.GetStage())
+		}
+	}
+	res := &remoteexecution.ExecuteResponse{}
+	if err := ptypes.UnmarshalAny(stream.sent[3].GetOrderedBuildEvent().GetEvent().GetBuildExecutionEvent(), res); err != nil ||
+		res.GetResult().GetExitCode() != 1 {
+		t.Fatalf("Expected event 3 to hold the ExecuteResponse, got %v: %v", res, err)
+	}
+	if stream.sent[4].GetOrderedBuildEvent().GetEvent().GetComponentStreamFinished() == nil {
+		t.Fatal("Expected the last event to finish the stream")
+	}
+	if polls != len(stages) || !stopped {
+		t.Fatalf("Expected the status to be read %d times and the watch stopped, got %d reads", len(stages), polls)
+	}
+}
+
+func TestBESPublishNil(t *testing.T) {
+	var p *besPublisher
+	p.publish("op1", nil, nil)
+}
+
+type fakeBESStream struct {
+	mu     sync.Mutex
+	sent   []*build.PublishBuildToolEventStreamRequest
+	closed chan struct{}
+	grpc.ClientStream
+}
+
+func (s *fakeBESStream) Send(req *build.PublishBuildToolEventStreamRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func (s *fakeBESStream) Recv() (*build.PublishBuildToolEventStreamResponse, error) {
+	<-s.closed
+	return nil, io.EOF
+}
+
+func (s *fakeBESStream) CloseSend() error {
+	close(s.closed)
+	return nil
+}
@@ -1,6 +1,18 @@
 package execution
 
+import "time"
+
 const (
 	TaskIDPrefix   = "Bazel_ExecuteRequest"
 	CommandDefault = "BZ_PLACEHOLDER"
+
+	// Response header metadata keys reporting the scheduler's queue to clients of Execute and GetOperation
+	QueueDepthMetadataKey = "scoot-queue-depth"
+	QueueWaitMetadataKey  = "scoot-queue-wait-ms"
+
+	// How long clients are told to wait before retrying an Execute rejected by the concurrency limit
+	DefaultExecuteRetryAfter = 5 * time.Second
+
+	// How long clients are told to wait before retrying an action whose run failed for reasons retrying may fix
+	DefaultFailedRunRetryAfter = 10 * time.Second
 )
@@ -5,19 +5,25 @@ package execution
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	log "github.com/sirupsen/logrus"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
 	"golang.org/x/net/context"
 	"google.golang.org/genproto/googleapis/longrunning"
-	google_rpc_status "google.golang.org/genproto/googleapis/rpc/status"
+	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/bazel/client"
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
+	"github.com/twitter/scoot/common/dialer"
 	loghelpers "github.com/twitter/scoot/common/log/helpers"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
@@ -26,17 +32,37 @@ import (
 	"github.com/twitter/scoot/scootapi/server/api"
 )
 
+// CASResolver resolves the address of the CAS server that Actions are validated against before scheduling.
+// Defined as its own type so it can be injected via ICE. If nil, Actions are not validated.
+type CASResolver dialer.Resolver
+
+// ExecuteLimit is the maximum number of Execute requests processed concurrently, zero for unlimited.
+// Defined as its own type so it can be injected via ICE.
+type ExecuteLimit int
+
+// blobReader is the subset of CAS client functionality used to validate Actions.
+type blobReader interface {
+	Read(ctx context.Context, digest *remoteexecution.Digest) ([]byte, error)
+	FindMissingBlobs(ctx context.Context, digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, error)
+}
+
 // Implements GRPCServer, remoteexecution.ExecutionServer, and longrunning.OperationsServer interfaces
 type executionServer struct {
 	listener  net.Listener
 	sagaCoord saga.SagaCoordinator
 	server    *grpc.Server
 	scheduler scheduler.Scheduler
+	cas       blobReader
 	stat      stats.StatsReceiver
+	// Holds a token for each Execute request being processed, nil if unlimited
+	executeSem chan struct{}
+	bes        *besPublisher
 }
 
-// Creates a new GRPCServer (executionServer) based on a GRPC config, scheduler, and stats, and preregisters the service
-func MakeExecutionServer(gc *bazel.GRPCConfig, s scheduler.Scheduler, stat stats.StatsReceiver) *executionServer {
+// Creates a new GRPCServer (executionServer) based on a GRPC config, scheduler, CAS resolver,
+// Execute concurrency limit, BES resolver and stats, and preregisters the service
+func MakeExecutionServer(gc *bazel.GRPCConfig, s scheduler.Scheduler, cr CASResolver,
+	el ExecuteLimit, br BESResolver, stat stats.StatsReceiver) *executionServer {
 	if gc == nil {
 		return nil
 	}
@@ -45,7 +71,7 @@ func MakeExecutionServer(gc *bazel.GRPCConfig, s scheduler.Scheduler, stat stats
 	if err != nil {
 		panic(err)
 	}
-	gs := gc.NewGRPCServer()
+	gs := gc.NewGRPCServer(stat)
 	g := executionServer{
 		listener:  l,
 		sagaCoord: s.GetSagaCoord(),
@@ -53,6 +79,13 @@ func MakeExecutionServer(gc *bazel.GRPCConfig, s scheduler.Scheduler, stat stats
 		scheduler: s,
 		stat:      stat,
 	}
+	if cr != nil {
+		g.cas = client.NewClient(cr, client.DefaultRetryPolicy)
+	}
+	if el > 0 {
+		g.executeSem = make(chan struct{}, el)
+	}
+	g.bes = newBESPublisher(br, g.getOperation, g.sagaCoord.Watch, stat)
 	remoteexecution.RegisterExecutionServer(g.server, &g)
 	longrunning.RegisterOperationsServer(g.server, &g)
 	return &g
@@ -98,6 +131,17 @@ func (s *executionServer) Execute(
 	}()
 	defer s.stat.Latency(stats.BzExecLatency_ms).Time().Stop()
 
+	// Reject rather than queue requests over the concurrency limit, so clients back off
+	if !s.acquireExecute() {
+		s.stat.Counter(stats.BzExecConcurrencyLimitCounter).Inc(1)
+		err = throttledStatusError(&scheduler.ThrottledError{
+			RetryAfter: DefaultExecuteRetryAfter,
+			Reason:     fmt.Sprintf("over %d concurrent Execute requests", cap(s.executeSem)),
+		})
+		return err
+	}
+	defer s.releaseExecute()
+
 	// Transform ExecuteRequest into Scoot Job, validate and schedule
 	// If we encounter an error here, assume it was due to an InvalidArgument
 	job, err := execReqToScoot(req)
@@ -106,14 +150,32 @@ func (s *executionServer) Execute(
 		return status.Error(codes.InvalidArgument, fmt.Sprintf("Error converting request to internal definition: %s", err))
 	}
 
+	// Labels the client attached to the request are kept on the job, so it can be found by them
+	job.Labels = bazel.LabelsFromContext(execServer.Context())
+	err = sched.ValidateLabels(job.Labels)
+	if err != nil {
+		log.Errorf("Invalid labels for request: %s", err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	err = sched.ValidateJob(job)
 	if err != nil {
 		log.Errorf("Scoot Job generated from request invalid: %s", err)
 		return status.Error(codes.Internal, fmt.Sprintf("Internal job definition invalid: %s", err))
 	}
 
+	// Verify inputs exist before scheduling, rather than failing later on the worker
+	if s.cas != nil {
+		err = s.validateActionInputs(execServer.Context(), req.GetActionDigest())
+		if err != nil {
+			return err
+		}
+	}
+
 	id, err := s.scheduler.ScheduleJob(job)
-	if err != nil {
+	if te, ok := err.(*scheduler.ThrottledError); ok {
+		return throttledStatusError(te)
+	} else if err != nil {
 		log.Errorf("Failed to schedule Scoot job: %s", err)
 		return status.Error(codes.Internal, fmt.Sprintf("Failed to schedule Scoot job: %s", err))
 	}
@@ -121,6 +183,19 @@ func (s *executionServer) Execute(
 		log.Fields{
 			"jobID": id,
 		}).Info("Scheduled execute request as Scoot job")
+	s.bes.publish(id, req.GetActionDigest(), bazel.RequestMetadataFromContext(execServer.Context()))
+
+	// Tell the client how long the job is likely to be queued before it starts
+	if qs, ok := s.queueStatus(); ok {
+		if err := execServer.SetHeader(queueMetadata(qs)); err != nil {
+			log.Errorf("Failed to set queue metadata header: %s", err)
+		}
+	}
+	if len(job.Labels) > 0 {
+		if err := execServer.SetHeader(bazel.LabelMetadata(job.Labels)); err != nil {
+			log.Errorf("Failed to set label metadata header: %s", err)
+		}
+	}
 
 	eom := &remoteexecution.ExecuteOperationMetadata{
 		Stage:        remoteexecution.ExecuteOperationMetadata_QUEUED,
@@ -150,6 +225,105 @@ func (s *executionServer) Execute(
 	return nil
 }
 
+// Takes a token for an Execute request, returning false if the concurrency limit is reached.
+func (s *executionServer) acquireExecute() bool {
+	if s.executeSem == nil {
+		return true
+	}
+	select {
+	case s.executeSem <- struct{}{}:
+		s.stat.Gauge(stats.BzExecInFlightGauge).Update(int64(len(s.executeSem)))
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *executionServer) releaseExecute() {
+	if s.executeSem != nil {
+		<-s.executeSem
+		s.stat.Gauge(stats.BzExecInFlightGauge).Update(int64(len(s.executeSem)))
+	}
+}
+
+// Returns the scheduler's queue status, recording it to stats, if the scheduler reports one.
+func (s *executionServer) queueStatus() (scheduler.QueueStatus, bool) {
+	qr, ok := s.scheduler.(scheduler.QueueReporter)
+	if !ok {
+		return scheduler.QueueStatus{}, false
+	}
+	qs := qr.GetQueueStatus()
+	s.stat.Histogram(stats.BzExecQueueDepthHistogram).Update(int64(qs.WaitingTasks))
+	s.stat.Histogram(stats.BzExecQueueWaitEstimateHistogram_ms).Update(int64(qs.EstimatedWait / time.Millisecond))
+	return qs, true
+}
+
+// Checks that the Action and the Command and input root it references all exist in the CAS.
+// Returns a FAILED_PRECONDITION status with a PreconditionFailure detail listing any missing blobs,
+// as specified by the Execution API.
+func (s *executionServer) validateActionInputs(ctx context.Context, actionDigest *remoteexecution.Digest) error {
+	defer s.stat.Latency(stats.BzExecValidateInputsLatency_ms).Time().Stop()
+
+	actionBytes, err := s.cas.Read(ctx, actionDigest)
+	s.recordCASResult(err)
+	if cas.IsNotFoundError(err) {
+		return s.missingInputsError([]*remoteexecution.Digest{actionDigest})
+	} else if err != nil {
+		log.Errorf("Failed to read Action %s from CAS: %s", bazel.DigestToStr(actionDigest), err)
+		return status.Error(codes.Unavailable, fmt.Sprintf("Failed to read Action from CAS: %s", err))
+	}
+
+	action := &remoteexecution.Action{}
+	if err := proto.Unmarshal(actionBytes, action); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to unmarshal bytes as remoteexecution.Action: %s", err))
+	}
+
+	digests := []*remoteexecution.Digest{}
+	for _, d := range []*remoteexecution.Digest{action.GetCommandDigest(), action.GetInputRootDigest()} {
+		if d != nil && !bazel.IsEmptyDigest(d) {
+			digests = append(digests, d)
+		}
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	missing, err := s.cas.FindMissingBlobs(ctx, digests)
+	s.recordCASResult(err)
+	if err != nil {
+		log.Errorf("Failed to find missing blobs for Action %s: %s", bazel.DigestToStr(actionDigest), err)
+		return status.Error(codes.Unavailable, fmt.Sprintf("Failed to check CAS for Action inputs: %s", err))
+	}
+	if len(missing) > 0 {
+		return s.missingInputsError(missing)
+	}
+	return nil
+}
+
+// Report CAS request results to the scheduler so it can throttle new jobs while the CAS is unhealthy.
+// A NotFoundError means the CAS is working as intended.
+func (s *executionServer) recordCASResult(err error) {
+	if r, ok := s.scheduler.(scheduler.CASHealthRecorder); ok {
+		if cas.IsNotFoundError(err) {
+			err = nil
+		}
+		r.RecordCASResult(err)
+	}
+}
+
+func (s *executionServer) missingInputsError(missing []*remoteexecution.Digest) error {
+	s.stat.Counter(stats.BzExecMissingInputsCounter).Inc(1)
+	log.WithFields(
+		log.Fields{
+			"missing": missing,
+		}).Info("Rejecting execute request with inputs missing from CAS")
+	st, err := getFailedPreconditionStatus(missing)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return status.ErrorProto(st)
+}
+
 func (s *executionServer) WaitExecution(
 	*remoteexecution.WaitExecutionRequest,
 	remoteexecution.Execution_WaitExecutionServer) error {
@@ -163,7 +337,7 @@ func (s *executionServer) WaitExecution(
 // Note that the ActionDigest field in the ExecuteOperationMetadata is not always available for
 // tasks that have not completed.
 func (s *executionServer) GetOperation(
-	_ context.Context,
+	ctx context.Context,
 	req *longrunning.GetOperationRequest) (*longrunning.Operation, error) {
 	log.Debugf("Received GetOperation request: %v", req)
 
@@ -183,9 +357,112 @@ func (s *executionServer) GetOperation(
 	}()
 	defer s.stat.Latency(stats.BzGetOpLatency_ms).Time().Stop()
 
-	rs, err := s.getRunStatusAndValidate(req.Name)
+	op, err := s.getOperation(req.Name)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+
+	// Operations still queued get the same queue metadata as the initial Execute response
+	if !op.GetDone() && operationStage(op) == remoteexecution.ExecuteOperationMetadata_QUEUED {
+		if qs, ok := s.queueStatus(); ok {
+			if err := grpc.SetHeader(ctx, queueMetadata(qs)); err != nil {
+				log.Debugf("Failed to set queue metadata header: %s", err)
+			}
+		}
+	}
+
+	// Echo the labels the operation was requested with, if the scheduler still has them
+	if finder, ok := s.scheduler.(scheduler.JobFinder); ok {
+		if j, ok := finder.FindJob(req.Name); ok && len(j.Labels) > 0 {
+			if err := grpc.SetHeader(ctx, bazel.LabelMetadata(j.Labels)); err != nil {
+				log.Debugf("Failed to set label metadata header: %s", err)
+			}
+		}
+	}
+
+	log.Debug("GetOperationRequest completed successfully")
+	return op, nil
+}
+
+// Takes a ListOperations request whose Filter names the operations to return, as
+// "names=<name1>,<name2>,...", and returns their Operations in the order requested.
+// This lets clients tracking many operations poll them all with one request instead of one
+// GetOperation per operation. At most bazel.MaxListOperations are returned per response, a
+// NextPageToken is set if more remain and should be passed back with the same Filter.
+// Operations that can't be retrieved are returned as done, with the error as their Result,
+// rather than failing the whole request.
+func (s *executionServer) ListOperations(
+	_ context.Context,
+	req *longrunning.ListOperationsRequest) (*longrunning.ListOperationsResponse, error) {
+	log.Debugf("Received ListOperations request: %v", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+
+	var err error = nil
+
+	// Record metrics based on final error condition
+	defer func() {
+		if err == nil {
+			s.stat.Counter(stats.BzListOpsSuccessCounter).Inc(1)
+		} else {
+			s.stat.Counter(stats.BzListOpsFailureCounter).Inc(1)
+		}
+	}()
+	defer s.stat.Latency(stats.BzListOpsLatency_ms).Time().Stop()
+
+	names, err := bazel.ParseOperationNamesFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	start, err := parsePageToken(req.GetPageToken(), len(names))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 || pageSize > bazel.MaxListOperations {
+		pageSize = bazel.MaxListOperations
+	}
+	end := start + pageSize
+	if end > len(names) {
+		end = len(names)
+	}
+	s.stat.Histogram(stats.BzListOpsLengthHistogram).Update(int64(end - start))
+
+	res := &longrunning.ListOperationsResponse{}
+	for _, name := range names[start:end] {
+		op, opErr := s.getOperation(name)
+		if opErr != nil {
+			log.Infof("Failed to get operation %s for ListOperations: %v", name, opErr)
+			op = errorOperation(name, opErr)
+		}
+		res.Operations = append(res.Operations, op)
+	}
+	if end < len(names) {
+		res.NextPageToken = strconv.Itoa(end)
+	}
+
+	log.Debug("ListOperationsRequest completed successfully")
+	return res, nil
+}
+
+// TODO hook up to Job Kill API
+func (s *executionServer) DeleteOperation(context.Context, *longrunning.DeleteOperationRequest) (*empty.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+}
+
+func (s *executionServer) CancelOperation(context.Context, *longrunning.CancelOperationRequest) (*empty.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+}
+
+// Internal functions
+
+// Builds the Operation for the job with the given name. Returned errors are gRPC status errors.
+func (s *executionServer) getOperation(name string) (*longrunning.Operation, error) {
+	rs, err := s.getRunStatusAndValidate(name)
+	if err != nil {
+		return nil, status.Error(jobStatusErrorCode(err), err.Error())
 	}
 
 	actionResult := bazelapi.MakeActionResultDomainFromThrift(rs.GetBazelResult())
@@ -203,26 +480,24 @@ func (s *executionServer) GetOperation(
 
 	isDone := runStatusToDoneBool(rs)
 	op := longrunning.Operation{
-		Name:     req.Name,
+		Name:     name,
 		Metadata: eomAsPBAny,
 		Done:     runStatusToDoneBool(rs),
 	}
 
 	// If done, create ExecuteResponse in protobuf.Any format and include in Operation.Result.
-	// If the run status' bazelapi.ActionResult contains a google rpc Status, return that
-	// in the Response, otherwise convert the run status to a google rpc Status.
+	// Per the API, failures to execute the action are reported in the Response's Status
+	// rather than as the Operation's error, see executeResponseStatus.
 	if isDone {
-		var grpcs *google_rpc_status.Status
-		if actionResult != nil && actionResult.GRPCStatus != nil {
-			grpcs = actionResult.GetGRPCStatus()
-		} else {
-			grpcs = runStatusToGoogleRpcStatus(rs)
-		}
+		grpcs := executeResponseStatus(rs, actionResult)
 		res := &remoteexecution.ExecuteResponse{
 			Result:       actionResult.GetResult(),
 			CachedResult: actionResult.GetCached(),
 			Status:       grpcs,
 		}
+		if grpcs.GetCode() != int32(google_rpc_code.Code_OK) {
+			res.ServerLogs = failedActionServerLogs(actionResult.GetResult())
+		}
 		resAsPBAny, err := marshalAny(res)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
@@ -231,26 +506,39 @@ func (s *executionServer) GetOperation(
 			Response: resAsPBAny,
 		}
 	}
-
-	log.Debug("GetOperationRequest completed successfully")
 	return &op, nil
 }
 
-func (s *executionServer) ListOperations(context.Context, *longrunning.ListOperationsRequest) (*longrunning.ListOperationsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
-}
-
-// TODO hook up to Job Kill API
-func (s *executionServer) DeleteOperation(context.Context, *longrunning.DeleteOperationRequest) (*empty.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+// Returns a done Operation with err as its Result, for operations ListOperations couldn't retrieve.
+func errorOperation(name string, err error) *longrunning.Operation {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Internal, err.Error())
+	}
+	op := &longrunning.Operation{
+		Name:   name,
+		Done:   true,
+		Result: &longrunning.Operation_Error{Error: st.Proto()},
+	}
+	// Include metadata so clients can parse the Operation like any other
+	if eom, err := marshalAny(&remoteexecution.ExecuteOperationMetadata{
+		Stage: remoteexecution.ExecuteOperationMetadata_UNKNOWN}); err == nil {
+		op.Metadata = eom
+	}
+	return op
 }
 
-func (s *executionServer) CancelOperation(context.Context, *longrunning.CancelOperationRequest) (*empty.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+func parsePageToken(token string, n int) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	start, err := strconv.Atoi(token)
+	if err != nil || start < 0 || start > n {
+		return 0, fmt.Errorf("Invalid page token %q", token)
+	}
+	return start, nil
 }
 
-// Internal functions
-
 func (s *executionServer) getRunStatusAndValidate(jobID string) (*runStatus, error) {
 	js, err := api.GetJobStatus(jobID, s.sagaCoord)
 	if err != nil {
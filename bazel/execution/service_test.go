@@ -1,18 +1,28 @@
 package execution
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
 	"golang.org/x/net/context"
 	"google.golang.org/genproto/googleapis/longrunning"
+	google_rpc_errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
 	scootproto "github.com/twitter/scoot/common/proto"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
 )
 
@@ -47,6 +57,151 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+// Determine that Execute reports the scheduler's queue depth and estimated wait as header metadata
+func TestExecuteQueueMetadata(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	sc.EXPECT().ScheduleJob(gomock.Any()).Return("testJobID", nil)
+	qs := scheduler.QueueStatus{WaitingTasks: 12, Nodes: 4, EstimatedWait: 90 * time.Second}
+
+	s := executionServer{scheduler: &queueingScheduler{sc, qs}, stat: stats.NilStatsReceiver()}
+	fs := &fakeExecServer{}
+
+	err := s.Execute(&remoteexecution.ExecuteRequest{ActionDigest: emptyActionDigest(t)}, fs)
+	if err != nil {
+		t.Fatalf("Non-nil error from Execute: %v", err)
+	}
+	if d := fs.header[QueueDepthMetadataKey]; len(d) != 1 || d[0] != "12" {
+		t.Fatalf("Expected queue depth header 12, got: %v", d)
+	}
+	if w := fs.header[QueueWaitMetadataKey]; len(w) != 1 || w[0] != "90000" {
+		t.Fatalf("Expected queue wait header 90000, got: %v", w)
+	}
+}
+
+// Determine that labels sent as request headers and the RequestMetadata's invocation id are added
+// to the scheduled job and echoed in the response header
+func TestExecuteLabels(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	var labels map[string]string
+	sc.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		labels = def.Labels
+	}).Return("testJobID", nil)
+
+	s := executionServer{scheduler: sc, stat: stats.NilStatsReceiver()}
+	rm, err := proto.Marshal(&remoteexecution.RequestMetadata{ToolInvocationId: "invocation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := metadata.Pairs(bazel.LabelHeaderPrefix+"pipeline", "nightly", bazel.RequestMetadataHeader, string(rm))
+	fs := &fakeExecServer{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+	err = s.Execute(&remoteexecution.ExecuteRequest{ActionDigest: emptyActionDigest(t)}, fs)
+	if err != nil {
+		t.Fatalf("Non-nil error from Execute: %v", err)
+	}
+	if len(labels) != 2 || labels["pipeline"] != "nightly" || labels[bazel.ToolInvocationIdLabel] != "invocation" {
+		t.Fatalf("Expected pipeline and invocation id labels, got: %v", labels)
+	}
+	if p := fs.header[bazel.LabelHeaderPrefix+"pipeline"]; len(p) != 1 || p[0] != "nightly" {
+		t.Fatalf("Expected pipeline label header, got: %v", fs.header)
+	}
+}
+
+// Determine that Execute rejects requests over the concurrency limit with RESOURCE_EXHAUSTED
+func TestExecuteConcurrencyLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	sc.EXPECT().ScheduleJob(gomock.Any()).Return("testJobID", nil)
+
+	s := executionServer{scheduler: sc, stat: stats.NilStatsReceiver(), executeSem: make(chan struct{}, 1)}
+	req := &remoteexecution.ExecuteRequest{ActionDigest: emptyActionDigest(t)}
+
+	// Occupy the only slot, as if another Execute were in progress
+	s.executeSem <- struct{}{}
+	err := s.Execute(req, &fakeExecServer{})
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted from Execute over the limit, got: %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("Expected RetryInfo detail, got: %v", st.Details())
+	}
+
+	// Once the slot is free the request is scheduled, and the slot released afterwards
+	<-s.executeSem
+	if err := s.Execute(req, &fakeExecServer{}); err != nil {
+		t.Fatalf("Non-nil error from Execute under the limit: %v", err)
+	}
+	if len(s.executeSem) != 0 {
+		t.Fatalf("Expected Execute to release its slot, %d in use", len(s.executeSem))
+	}
+}
+
+func emptyActionDigest(t *testing.T) *remoteexecution.Digest {
+	actionSha, actionLen, err := scootproto.GetSha256(&remoteexecution.Action{})
+	if err != nil {
+		t.Fatalf("Failed to get sha: %v", err)
+	}
+	return &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}
+}
+
+// Determine that Execute rejects requests whose inputs are missing from the CAS with FAILED_PRECONDITION
+func TestExecuteMissingInputs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+
+	cmdDigest := &remoteexecution.Digest{Hash: "abc123", SizeBytes: 10}
+	a := &remoteexecution.Action{CommandDigest: cmdDigest}
+	actionBytes, err := proto.Marshal(a)
+	if err != nil {
+		t.Fatalf("Failed to marshal Action: %v", err)
+	}
+	actionSha, actionLen, err := scootproto.GetSha256(a)
+	if err != nil {
+		t.Fatalf("Failed to get sha: %v", err)
+	}
+	actionDigest := &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}
+
+	fc := &fakeCAS{blobs: map[string][]byte{actionSha: actionBytes}}
+	s := executionServer{scheduler: sc, cas: fc, stat: stats.NilStatsReceiver()}
+
+	req := remoteexecution.ExecuteRequest{
+		InstanceName:    "test",
+		SkipCacheLookup: true,
+		ActionDigest:    actionDigest,
+	}
+
+	err = s.Execute(&req, &fakeExecServer{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition, got: %v", err)
+	}
+	st, _ := status.FromError(err)
+	if len(st.Details()) != 1 {
+		t.Fatalf("Expected 1 detail, got: %v", st.Details())
+	}
+	pcf, ok := st.Details()[0].(*google_rpc_errdetails.PreconditionFailure)
+	if !ok || len(pcf.GetViolations()) != 1 {
+		t.Fatalf("Expected PreconditionFailure with 1 violation, got: %v", st.Details()[0])
+	}
+	if pcf.GetViolations()[0].GetSubject() != "blobs/abc123/10" {
+		t.Fatalf("Unexpected violation subject: %s", pcf.GetViolations()[0].GetSubject())
+	}
+
+	// Once the Command is present the request is scheduled
+	fc.blobs["abc123"] = []byte{}
+	sc.EXPECT().ScheduleJob(gomock.Any()).Return("testJobID", nil)
+	err = s.Execute(&req, &fakeExecServer{})
+	if err != nil {
+		t.Fatalf("Non-nil error from Execute: %v", err)
+	}
+}
+
 // Determine that GetOperation can accept a well-formed request and returns a well-formed response
 func TestGetOperation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
@@ -91,12 +246,117 @@ func TestGetOperation(t *testing.T) {
 	}
 }
 
+// Determine that ListOperations returns the operations named in its filter a page at a time,
+// and reports operations that can't be retrieved individually
+func TestListOperations(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	mockSagaLog := saga.NewMockSagaLog(mockCtrl)
+	sagaC := saga.MakeSagaCoordinator(mockSagaLog)
+	mockSagaLog.EXPECT().GetMessages("testJobID1").Return([]saga.SagaMessage{}, nil)
+	mockSagaLog.EXPECT().GetMessages("testJobID2").Return(nil, errors.New("test error"))
+
+	s := executionServer{
+		scheduler: sc,
+		sagaCoord: sagaC,
+		stat:      stats.NilStatsReceiver(),
+	}
+	ctx := context.Background()
+
+	_, err := s.ListOperations(ctx, &longrunning.ListOperationsRequest{})
+	if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for request without names filter, got: %v", err)
+	}
+
+	req := longrunning.ListOperationsRequest{
+		Filter:   bazel.OperationNamesFilter([]string{"testJobID1", "testJobID2"}),
+		PageSize: 1,
+	}
+	res, err := s.ListOperations(ctx, &req)
+	if err != nil {
+		t.Fatalf("Non-nil error from ListOperations: %v", err)
+	}
+	if len(res.GetOperations()) != 1 || res.GetOperations()[0].GetName() != "testJobID1" {
+		t.Fatalf("Expected first page to contain testJobID1, got: %s", res)
+	}
+	if res.GetOperations()[0].GetDone() {
+		t.Fatal("Expected testJobID1 to not be done")
+	}
+	if res.GetNextPageToken() == "" {
+		t.Fatal("Expected next page token")
+	}
+
+	req.PageToken = res.GetNextPageToken()
+	res, err = s.ListOperations(ctx, &req)
+	if err != nil {
+		t.Fatalf("Non-nil error from ListOperations: %v", err)
+	}
+	if len(res.GetOperations()) != 1 || res.GetNextPageToken() != "" {
+		t.Fatalf("Expected last page to contain one operation, got: %s", res)
+	}
+	op := res.GetOperations()[0]
+	if op.GetName() != "testJobID2" || !op.GetDone() || op.GetError() == nil {
+		t.Fatalf("Expected testJobID2 to be done with an error, got: %s", op)
+	}
+	if op.GetMetadata() == nil {
+		t.Fatalf("Nil metadata from operation: %s", op)
+	}
+}
+
 // Fake Execution_ExecuteServer
 // Implements Execution_ExecuteServer interface
 type fakeExecServer struct {
 	grpc.ServerStream
+	header metadata.MD
+	ctx    context.Context
+}
+
+func (s *fakeExecServer) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
 }
 
 func (s *fakeExecServer) Send(op *longrunning.Operation) error {
 	return nil
 }
+
+func (s *fakeExecServer) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
+// Scheduler reporting a fixed queue status
+type queueingScheduler struct {
+	*scheduler.MockScheduler
+	qs scheduler.QueueStatus
+}
+
+func (s *queueingScheduler) GetQueueStatus() scheduler.QueueStatus {
+	return s.qs
+}
+
+// Fake CAS that serves blobs from memory, keyed by hash
+type fakeCAS struct {
+	blobs map[string][]byte
+}
+
+func (f *fakeCAS) Read(ctx context.Context, digest *remoteexecution.Digest) ([]byte, error) {
+	if b, ok := f.blobs[digest.GetHash()]; ok {
+		return b, nil
+	}
+	return nil, &cas.NotFoundError{Err: "not found"}
+}
+
+func (f *fakeCAS) FindMissingBlobs(
+	ctx context.Context, digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, error) {
+	missing := []*remoteexecution.Digest{}
+	for _, d := range digests {
+		if _, ok := f.blobs[d.GetHash()]; !ok {
+			missing = append(missing, d)
+		}
+	}
+	return missing, nil
+}
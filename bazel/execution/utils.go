@@ -2,6 +2,7 @@ package execution
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -9,14 +10,20 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	log "github.com/sirupsen/logrus"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"google.golang.org/genproto/googleapis/longrunning"
 	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
+	google_rpc_errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	google_rpc_status "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/twitter/scoot/bazel"
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
 	bazelthrift "github.com/twitter/scoot/bazel/execution/bazelapi/gen-go/bazel"
 	scootproto "github.com/twitter/scoot/common/proto"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 )
 
@@ -152,8 +159,17 @@ func runStatusToGoogleRpcStatus(rs *runStatus) *google_rpc_status.Status {
 			Code: int32(google_rpc_code.Code_OK),
 		}
 	case scoot.RunStatusState_FAILED:
+		// The command itself failing isn't an error, that's reported by the ActionResult's exit code.
+		// Only failures to run it are, and only infrastructure failures are worth retrying.
+		code := google_rpc_code.Code_INTERNAL
+		if rs.GetFailureKind() == scoot.FailureKind_REQUEST {
+			code = google_rpc_code.Code_FAILED_PRECONDITION
+		} else if isRetryable(rs) {
+			code = google_rpc_code.Code_UNAVAILABLE
+		}
 		return &google_rpc_status.Status{
-			Code: int32(google_rpc_code.Code_INTERNAL),
+			Code:    int32(code),
+			Message: rs.GetError(),
 		}
 	// NOTE: The API does not indicate that ABORTED as an acceptable error, however
 	// given both the prevalence of Abort behavior in Scoot and the obviousness of the
@@ -166,9 +182,16 @@ func runStatusToGoogleRpcStatus(rs *runStatus) *google_rpc_status.Status {
 		return &google_rpc_status.Status{
 			Code: int32(google_rpc_code.Code_DEADLINE_EXCEEDED),
 		}
+	// Workers also reject requests as BADREQUEST when they're busy, so only those classified
+	// as request failures are the client's fault.
 	case scoot.RunStatusState_BADREQUEST:
+		code := google_rpc_code.Code_UNAVAILABLE
+		if rs.GetFailureKind() == scoot.FailureKind_REQUEST {
+			code = google_rpc_code.Code_INVALID_ARGUMENT
+		}
 		return &google_rpc_status.Status{
-			Code: int32(google_rpc_code.Code_INTERNAL),
+			Code:    int32(code),
+			Message: rs.GetError(),
 		}
 	default:
 		return &google_rpc_status.Status{
@@ -177,6 +200,86 @@ func runStatusToGoogleRpcStatus(rs *runStatus) *google_rpc_status.Status {
 	}
 }
 
+// Returns true if retrying a failed run may succeed, which is the case for infrastructure failures.
+// Failures the worker didn't classify are inferred from the run's state, as the worker does.
+func isRetryable(rs *runStatus) bool {
+	if rs == nil || rs.RunStatus == nil {
+		return false
+	}
+	switch rs.GetFailureKind() {
+	case scoot.FailureKind_INFRA:
+		return true
+	case scoot.FailureKind_UNCLASSIFIED:
+		switch rs.Status {
+		case scoot.RunStatusState_FAILED, scoot.RunStatusState_UNKNOWN, scoot.RunStatusState_BADREQUEST:
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the Status of the ExecuteResponse for a completed run: the Status the worker set in the run's
+// ActionResult if any, otherwise one converted from the run status. Errors are given the run's error as
+// their message if they have none, and retryable failures are reported as UNAVAILABLE rather than
+// INTERNAL, with RetryInfo, so clients can tell failures worth retrying from the rest.
+func executeResponseStatus(rs *runStatus, ar *bazelapi.ActionResult) *google_rpc_status.Status {
+	var st *google_rpc_status.Status
+	if ar != nil && ar.GRPCStatus != nil {
+		st = proto.Clone(ar.GetGRPCStatus()).(*google_rpc_status.Status)
+	} else {
+		st = runStatusToGoogleRpcStatus(rs)
+	}
+	if st.GetCode() == int32(google_rpc_code.Code_OK) || rs == nil || rs.RunStatus == nil {
+		return st
+	}
+	if st.GetMessage() == "" {
+		st.Message = rs.GetError()
+	}
+	if !isRetryable(rs) {
+		return st
+	}
+	if st.GetCode() == int32(google_rpc_code.Code_INTERNAL) || st.GetCode() == int32(google_rpc_code.Code_UNKNOWN) {
+		st.Code = int32(google_rpc_code.Code_UNAVAILABLE)
+	}
+	if st.GetCode() == int32(google_rpc_code.Code_UNAVAILABLE) {
+		if ri, err := marshalAny(&google_rpc_errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(DefaultFailedRunRetryAfter),
+		}); err == nil {
+			st.Details = append(st.Details, ri)
+		}
+	}
+	return st
+}
+
+// Returns the stdout and stderr of a failed action, when they were uploaded to the CAS,
+// as ExecuteResponse server logs so clients can display them with the error.
+func failedActionServerLogs(ar *remoteexecution.ActionResult) map[string]*remoteexecution.LogFile {
+	logs := map[string]*remoteexecution.LogFile{}
+	if d := ar.GetStdoutDigest(); d != nil && d.GetSizeBytes() > 0 {
+		logs["stdout"] = &remoteexecution.LogFile{Digest: d, HumanReadable: true}
+	}
+	if d := ar.GetStderrDigest(); d != nil && d.GetSizeBytes() > 0 {
+		logs["stderr"] = &remoteexecution.LogFile{Digest: d, HumanReadable: true}
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+	return logs
+}
+
+// Returns the gRPC code for an error getting a job's status: InvalidArgument for bad job ids,
+// Unavailable for transient errors reading the saga log, and Internal for anything else.
+func jobStatusErrorCode(err error) codes.Code {
+	switch err.(type) {
+	case *scoot.InvalidRequest:
+		return codes.InvalidArgument
+	case *scoot.ScootServerError:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
 func runStatusToDoneBool(rs *runStatus) bool {
 	if rs == nil || rs.RunStatus == nil {
 		return false
@@ -202,3 +305,60 @@ func runStatusToDoneBool(rs *runStatus) bool {
 		return false
 	}
 }
+
+// Create a google RPC status "failed precondition" error with missing violation data for
+// a list of non-existing Digests per Bazel API
+func getFailedPreconditionStatus(missing []*remoteexecution.Digest) (*google_rpc_status.Status, error) {
+	pcf := &google_rpc_errdetails.PreconditionFailure{
+		Violations: []*google_rpc_errdetails.PreconditionFailure_Violation{},
+	}
+
+	for _, m := range missing {
+		pcf.Violations = append(pcf.Violations, &google_rpc_errdetails.PreconditionFailure_Violation{
+			Type:        bazelapi.PreconditionMissing,
+			Subject:     fmt.Sprintf("blobs/%s/%d", m.GetHash(), m.GetSizeBytes()),
+			Description: "Blob not found in CAS",
+		})
+	}
+
+	pcfAsAny, err := marshalAny(pcf)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to serialize PreconditionFailure data: %s", err)
+	}
+
+	return &google_rpc_status.Status{
+		Code:    int32(google_rpc_code.Code_FAILED_PRECONDITION),
+		Message: fmt.Sprintf("%d blob(s) required by the Action are missing from CAS", len(missing)),
+		Details: []*any.Any{pcfAsAny},
+	}, nil
+}
+
+// Create a RESOURCE_EXHAUSTED status error with RetryInfo for a job the scheduler throttled,
+// so clients know when to resubmit the request
+func throttledStatusError(te *scheduler.ThrottledError) error {
+	st := status.New(codes.ResourceExhausted, te.Error())
+	stWithInfo, err := st.WithDetails(&google_rpc_errdetails.RetryInfo{
+		RetryDelay: ptypes.DurationProto(te.RetryAfter),
+	})
+	if err != nil {
+		log.Errorf("Failed to add RetryInfo to throttled status: %s", err)
+		return st.Err()
+	}
+	return stWithInfo.Err()
+}
+
+// Formats the scheduler's queue status as response header metadata
+func queueMetadata(qs scheduler.QueueStatus) metadata.MD {
+	return metadata.Pairs(
+		QueueDepthMetadataKey, strconv.Itoa(qs.WaitingTasks),
+		QueueWaitMetadataKey, strconv.FormatInt(int64(qs.EstimatedWait/time.Millisecond), 10))
+}
+
+// Returns the stage from an Operation's ExecuteOperationMetadata, or UNKNOWN if it can't be read
+func operationStage(op *longrunning.Operation) remoteexecution.ExecuteOperationMetadata_Stage {
+	eom := &remoteexecution.ExecuteOperationMetadata{}
+	if err := ptypes.UnmarshalAny(op.GetMetadata(), eom); err != nil {
+		return remoteexecution.ExecuteOperationMetadata_UNKNOWN
+	}
+	return eom.GetStage()
+}
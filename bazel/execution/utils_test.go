@@ -3,10 +3,14 @@ package execution
 import (
 	"testing"
 
+	"github.com/golang/protobuf/ptypes"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
+	google_rpc_errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	google_rpc_status "google.golang.org/genproto/googleapis/rpc/status"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/execution/bazelapi"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 )
 
@@ -90,6 +94,53 @@ func TestRunStatusToGoogleRpcStatus(t *testing.T) {
 		expected.Details != nil {
 		t.Fatalf("Expected %#v, got %#v", expected, s)
 	}
+
+	errStr := "inputs missing"
+	request := scoot.FailureKind_REQUEST
+	failed := &runStatus{&scoot.RunStatus{Status: scoot.RunStatusState_FAILED, Error: &errStr, FailureKind: &request}}
+	if s := runStatusToGoogleRpcStatus(failed); s.Code != int32(google_rpc_code.Code_FAILED_PRECONDITION) || s.Message != errStr {
+		t.Fatalf("Expected FAILED_PRECONDITION for a request failure, got %#v", s)
+	}
+	infra := scoot.FailureKind_INFRA
+	failed.FailureKind = &infra
+	if s := runStatusToGoogleRpcStatus(failed); s.Code != int32(google_rpc_code.Code_UNAVAILABLE) {
+		t.Fatalf("Expected UNAVAILABLE for an infrastructure failure, got %#v", s)
+	}
+}
+
+func TestExecuteResponseStatus(t *testing.T) {
+	errStr := "checkout failed"
+	infra := scoot.FailureKind_INFRA
+	failed := &runStatus{&scoot.RunStatus{Status: scoot.RunStatusState_FAILED, Error: &errStr, FailureKind: &infra}}
+
+	// Retryable failures reported by the worker as INTERNAL are made UNAVAILABLE with RetryInfo
+	workerStatus := &google_rpc_status.Status{Code: int32(google_rpc_code.Code_INTERNAL)}
+	s := executeResponseStatus(failed, &bazelapi.ActionResult{GRPCStatus: workerStatus})
+	if s.Code != int32(google_rpc_code.Code_UNAVAILABLE) || s.Message != errStr || len(s.Details) != 1 {
+		t.Fatalf("Expected UNAVAILABLE with the run's error and RetryInfo, got %#v", s)
+	}
+	ri := &google_rpc_errdetails.RetryInfo{}
+	if err := ptypes.UnmarshalAny(s.Details[0], ri); err != nil || ri.GetRetryDelay() == nil {
+		t.Fatalf("Expected RetryInfo detail, got %v, %v", s.Details[0], err)
+	}
+	if workerStatus.Code != int32(google_rpc_code.Code_INTERNAL) {
+		t.Fatal("Expected the ActionResult's status to be left unmodified")
+	}
+
+	// Other failures keep the worker's status
+	request := scoot.FailureKind_REQUEST
+	failed.FailureKind = &request
+	workerStatus = &google_rpc_status.Status{Code: int32(google_rpc_code.Code_FAILED_PRECONDITION), Message: "missing"}
+	s = executeResponseStatus(failed, &bazelapi.ActionResult{GRPCStatus: workerStatus})
+	if s.Code != int32(google_rpc_code.Code_FAILED_PRECONDITION) || s.Message != "missing" || len(s.Details) != 0 {
+		t.Fatalf("Expected the worker's FAILED_PRECONDITION status, got %#v", s)
+	}
+
+	stderr := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10}
+	logs := failedActionServerLogs(&remoteexecution.ActionResult{StderrDigest: stderr})
+	if len(logs) != 1 || logs["stderr"].GetDigest() != stderr {
+		t.Fatalf("Expected stderr server log, got %v", logs)
+	}
 }
 
 func TestRunStatusToDoneBool(t *testing.T) {
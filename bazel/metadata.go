@@ -0,0 +1,111 @@
+package bazel
+
+// RequestMetadata utilities for Bazel
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// gRPC header in which clients send a serialized RequestMetadata
+const RequestMetadataHeader = "build.bazel.remote.execution.v2.requestmetadata-bin"
+
+// Prefix of optional gRPC headers labeling an Execute request, ex: "scoot-label-pipeline: nightly".
+// Labels are added to the Scoot job the request is scheduled as, and echoed in the response headers
+// of Execute and GetOperation, so tooling can map operations back to, ex: the CI pipelines that ran them.
+const LabelHeaderPrefix = "scoot-label-"
+
+// Labels set from a request's RequestMetadata, unless the client sent labels with the same keys
+const (
+	ToolInvocationIdLabel        = "bazel-tool-invocation-id"
+	CorrelatedInvocationsIdLabel = "bazel-correlated-invocations-id"
+)
+
+// Extract the RequestMetadata sent by the client of an incoming gRPC request.
+// Returns nil if the client didn't send any or it couldn't be parsed.
+func RequestMetadataFromContext(ctx context.Context) *remoteexecution.RequestMetadata {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	vals := md[RequestMetadataHeader]
+	if len(vals) == 0 {
+		return nil
+	}
+	rm := &remoteexecution.RequestMetadata{}
+	if err := proto.Unmarshal([]byte(vals[0]), rm); err != nil {
+		log.Debugf("Failed to deserialize RequestMetadata: %v", err)
+		return nil
+	}
+	return rm
+}
+
+// Extract the labels of an incoming gRPC request, from its label headers and its RequestMetadata's invocation ids.
+// Label keys are lowercase, as gRPC header names are. Returns nil if there are none.
+func LabelsFromContext(ctx context.Context) map[string]string {
+	var labels map[string]string
+	add := func(k, v string) {
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, vals := range md {
+		if strings.HasPrefix(k, LabelHeaderPrefix) && len(k) > len(LabelHeaderPrefix) && len(vals) > 0 {
+			add(strings.TrimPrefix(k, LabelHeaderPrefix), vals[0])
+		}
+	}
+	if rm := RequestMetadataFromContext(ctx); rm != nil {
+		if id := rm.GetToolInvocationId(); id != "" {
+			add(ToolInvocationIdLabel, id)
+		}
+		if id := rm.GetCorrelatedInvocationsId(); id != "" {
+			add(CorrelatedInvocationsIdLabel, id)
+		}
+	}
+	return labels
+}
+
+// Returns ctx with labels added to its outgoing header, for clients.
+func WithLabels(ctx context.Context, labels map[string]string) context.Context {
+	kv := []string{}
+	for k, v := range labels {
+		kv = append(kv, LabelHeaderPrefix+k, v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// Formats labels as response header metadata. Labels whose keys aren't valid in header names,
+// or whose values aren't printable ASCII, are left out.
+func LabelMetadata(labels map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for k, v := range labels {
+		if validLabelHeader(k, v) {
+			md[LabelHeaderPrefix+k] = []string{v}
+		}
+	}
+	return md
+}
+
+func validLabelHeader(k, v string) bool {
+	for _, c := range k {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	for _, c := range v {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
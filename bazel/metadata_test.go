@@ -0,0 +1,25 @@
+package bazel
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLabels(t *testing.T) {
+	if labels := LabelsFromContext(context.Background()); labels != nil {
+		t.Fatalf("Expected no labels, got %v", labels)
+	}
+
+	out, _ := metadata.FromOutgoingContext(WithLabels(context.Background(), map[string]string{"pipeline": "nightly"}))
+	labels := LabelsFromContext(metadata.NewIncomingContext(context.Background(), out))
+	if len(labels) != 1 || labels["pipeline"] != "nightly" {
+		t.Fatalf("Expected pipeline label, got %v", labels)
+	}
+
+	md := LabelMetadata(map[string]string{"pipeline": "nightly", "Build URL": "x", "emoji": "☃"})
+	if len(md) != 1 || md[LabelHeaderPrefix+"pipeline"][0] != "nightly" {
+		t.Fatalf("Expected only the valid label in metadata, got %v", md)
+	}
+}
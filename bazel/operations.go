@@ -0,0 +1,39 @@
+package bazel
+
+// Longrunning Operations utilities for Bazel
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Prefix of a ListOperations Filter selecting operations by name, ex: "names=<name1>,<name2>"
+	OperationNamesFilterPrefix = "names="
+
+	// Maximum number of Operations returned by a single ListOperations request
+	MaxListOperations = 1000
+)
+
+// Create a ListOperations Filter selecting the named operations.
+func OperationNamesFilter(names []string) string {
+	return OperationNamesFilterPrefix + strings.Join(names, ",")
+}
+
+// Parse a ListOperations Filter created with OperationNamesFilter.
+// Returns the operation names, or an error if filter doesn't select any.
+func ParseOperationNamesFilter(filter string) ([]string, error) {
+	if !strings.HasPrefix(filter, OperationNamesFilterPrefix) {
+		return nil, fmt.Errorf("Expected filter of format %s<name1>,<name2>,..., was %q", OperationNamesFilterPrefix, filter)
+	}
+	names := []string{}
+	for _, name := range strings.Split(strings.TrimPrefix(filter, OperationNamesFilterPrefix), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No operation names in filter %q", filter)
+	}
+	return names, nil
+}
@@ -0,0 +1,22 @@
+package bazel
+
+import (
+	"testing"
+)
+
+func TestOperationNamesFilter(t *testing.T) {
+	names := []string{"job1", "job2"}
+	parsed, err := ParseOperationNamesFilter(OperationNamesFilter(names))
+	if err != nil {
+		t.Fatalf("Failed to parse filter: %v", err)
+	}
+	if len(parsed) != 2 || parsed[0] != "job1" || parsed[1] != "job2" {
+		t.Fatalf("Expected %v, got %v", names, parsed)
+	}
+
+	for _, filter := range []string{"", "job1,job2", "names=", "names= , "} {
+		if _, err := ParseOperationNamesFilter(filter); err == nil {
+			t.Fatalf("Expected error parsing filter %q", filter)
+		}
+	}
+}
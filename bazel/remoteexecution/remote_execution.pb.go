@@ -37,6 +37,8 @@ const (
 	DigestFunction_SHA256  DigestFunction = 1
 	DigestFunction_SHA1    DigestFunction = 2
 	DigestFunction_MD5     DigestFunction = 3
+	DigestFunction_SHA512  DigestFunction = 6
+	DigestFunction_BLAKE3  DigestFunction = 9
 )
 
 var DigestFunction_name = map[int32]string{
@@ -44,19 +46,23 @@ var DigestFunction_name = map[int32]string{
 	1: "SHA256",
 	2: "SHA1",
 	3: "MD5",
+	6: "SHA512",
+	9: "BLAKE3",
 }
 var DigestFunction_value = map[string]int32{
 	"UNKNOWN": 0,
 	"SHA256":  1,
 	"SHA1":    2,
 	"MD5":     3,
+	"SHA512":  6,
+	"BLAKE3":  9,
 }
 
 func (x DigestFunction) String() string {
 	return proto.EnumName(DigestFunction_name, int32(x))
 }
 func (DigestFunction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{0}
 }
 
 // The current stage of execution.
@@ -93,7 +99,7 @@ func (x ExecuteOperationMetadata_Stage) String() string {
 	return proto.EnumName(ExecuteOperationMetadata_Stage_name, int32(x))
 }
 func (ExecuteOperationMetadata_Stage) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{19, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{19, 0}
 }
 
 // Describes how the server treats absolute symlink targets.
@@ -125,7 +131,7 @@ func (x CacheCapabilities_SymlinkAbsolutePathStrategy) String() string {
 	return proto.EnumName(CacheCapabilities_SymlinkAbsolutePathStrategy_name, int32(x))
 }
 func (CacheCapabilities_SymlinkAbsolutePathStrategy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{35, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{35, 0}
 }
 
 // An `Action` captures all the information about an execution which is required
@@ -190,7 +196,7 @@ func (m *Action) Reset()         { *m = Action{} }
 func (m *Action) String() string { return proto.CompactTextString(m) }
 func (*Action) ProtoMessage()    {}
 func (*Action) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{0}
 }
 func (m *Action) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Action.Unmarshal(m, b)
@@ -308,7 +314,36 @@ type Command struct {
 	// The working directory, relative to the input root, for the command to run
 	// in. It must be a directory which exists in the input tree. If it is left
 	// empty, then the action is run in the input root.
-	WorkingDirectory     string   `protobuf:"bytes,6,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	WorkingDirectory string `protobuf:"bytes,6,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// A list of the output paths that the client expects to retrieve from the
+	// action. Only the listed paths will be returned to the client as output.
+	// The type of the output (file or directory) is not specified, and will be
+	// determined by the server after action execution. If the resulting path is
+	// a file, it will be returned in an
+	// [OutputFile][build.bazel.remote.execution.v2.OutputFile] typed field.
+	// If the path is a directory, the entire directory structure will be returned
+	// as a [Tree][build.bazel.remote.execution.v2.Tree] message digest, see
+	// [OutputDirectory][build.bazel.remote.execution.v2.OutputDirectory]
+	// Other files or directories that may be created during command execution
+	// are discarded.
+	//
+	// The paths are relative to the working directory of the action execution.
+	// The paths are specified using a single forward slash (`/`) as a path
+	// separator, even if the execution platform natively uses a different
+	// separator. The path MUST NOT include a trailing slash, nor a leading slash,
+	// being a relative path.
+	//
+	// In order to ensure consistent hashing of the same Action, the output paths
+	// MUST be deduplicated and sorted lexicographically by code point (or,
+	// equivalently, by UTF-8 bytes).
+	//
+	// Directories leading up to the output paths are created by the worker prior
+	// to execution, even if they are not explicitly part of the input root.
+	//
+	// New in v2.1: this field supersedes the DEPRECATED `output_files` and
+	// `output_directories` fields. If `output_paths` is used, `output_files` and
+	// `output_directories` will be ignored!
+	OutputPaths          []string `protobuf:"bytes,7,rep,name=output_paths,json=outputPaths,proto3" json:"output_paths,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -318,7 +353,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{1}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{1}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -380,6 +415,13 @@ func (m *Command) GetWorkingDirectory() string {
 	return ""
 }
 
+func (m *Command) GetOutputPaths() []string {
+	if m != nil {
+		return m.OutputPaths
+	}
+	return nil
+}
+
 // An `EnvironmentVariable` is one variable to set in the running program's
 // environment.
 type Command_EnvironmentVariable struct {
@@ -396,7 +438,7 @@ func (m *Command_EnvironmentVariable) Reset()         { *m = Command_Environment
 func (m *Command_EnvironmentVariable) String() string { return proto.CompactTextString(m) }
 func (*Command_EnvironmentVariable) ProtoMessage()    {}
 func (*Command_EnvironmentVariable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{1, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{1, 0}
 }
 func (m *Command_EnvironmentVariable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command_EnvironmentVariable.Unmarshal(m, b)
@@ -450,7 +492,7 @@ func (m *Platform) Reset()         { *m = Platform{} }
 func (m *Platform) String() string { return proto.CompactTextString(m) }
 func (*Platform) ProtoMessage()    {}
 func (*Platform) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{2}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{2}
 }
 func (m *Platform) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Platform.Unmarshal(m, b)
@@ -508,7 +550,7 @@ func (m *Platform_Property) Reset()         { *m = Platform_Property{} }
 func (m *Platform_Property) String() string { return proto.CompactTextString(m) }
 func (*Platform_Property) ProtoMessage()    {}
 func (*Platform_Property) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{2, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{2, 0}
 }
 func (m *Platform_Property) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Platform_Property.Unmarshal(m, b)
@@ -619,7 +661,7 @@ func (m *Directory) Reset()         { *m = Directory{} }
 func (m *Directory) String() string { return proto.CompactTextString(m) }
 func (*Directory) ProtoMessage()    {}
 func (*Directory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{3}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{3}
 }
 func (m *Directory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Directory.Unmarshal(m, b)
@@ -677,7 +719,7 @@ func (m *FileNode) Reset()         { *m = FileNode{} }
 func (m *FileNode) String() string { return proto.CompactTextString(m) }
 func (*FileNode) ProtoMessage()    {}
 func (*FileNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{4}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{4}
 }
 func (m *FileNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileNode.Unmarshal(m, b)
@@ -738,7 +780,7 @@ func (m *DirectoryNode) Reset()         { *m = DirectoryNode{} }
 func (m *DirectoryNode) String() string { return proto.CompactTextString(m) }
 func (*DirectoryNode) ProtoMessage()    {}
 func (*DirectoryNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{5}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{5}
 }
 func (m *DirectoryNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DirectoryNode.Unmarshal(m, b)
@@ -792,7 +834,7 @@ func (m *SymlinkNode) Reset()         { *m = SymlinkNode{} }
 func (m *SymlinkNode) String() string { return proto.CompactTextString(m) }
 func (*SymlinkNode) ProtoMessage()    {}
 func (*SymlinkNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{6}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{6}
 }
 func (m *SymlinkNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SymlinkNode.Unmarshal(m, b)
@@ -872,7 +914,7 @@ func (m *Digest) Reset()         { *m = Digest{} }
 func (m *Digest) String() string { return proto.CompactTextString(m) }
 func (*Digest) ProtoMessage()    {}
 func (*Digest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{7}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{7}
 }
 func (m *Digest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Digest.Unmarshal(m, b)
@@ -937,7 +979,7 @@ func (m *ExecutedActionMetadata) Reset()         { *m = ExecutedActionMetadata{}
 func (m *ExecutedActionMetadata) String() string { return proto.CompactTextString(m) }
 func (*ExecutedActionMetadata) ProtoMessage()    {}
 func (*ExecutedActionMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{8}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{8}
 }
 func (m *ExecutedActionMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecutedActionMetadata.Unmarshal(m, b)
@@ -1167,7 +1209,7 @@ func (m *ActionResult) Reset()         { *m = ActionResult{} }
 func (m *ActionResult) String() string { return proto.CompactTextString(m) }
 func (*ActionResult) ProtoMessage()    {}
 func (*ActionResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{9}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{9}
 }
 func (m *ActionResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionResult.Unmarshal(m, b)
@@ -1271,7 +1313,13 @@ type OutputFile struct {
 	// The digest of the file's content.
 	Digest *Digest `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
 	// True if file is executable, false otherwise.
-	IsExecutable         bool     `protobuf:"varint,4,opt,name=is_executable,json=isExecutable,proto3" json:"is_executable,omitempty"`
+	IsExecutable bool `protobuf:"varint,4,opt,name=is_executable,json=isExecutable,proto3" json:"is_executable,omitempty"`
+	// The contents of the file if inlining was requested. The server SHOULD NOT inline
+	// file contents unless requested by the client in the
+	// [GetActionResultRequest][build.bazel.remote.execution.v2.GetActionResultRequest]
+	// message. The server MAY omit inlining, even if requested, and MUST do so if inlining
+	// would cause the response to exceed message size limits.
+	Contents             []byte   `protobuf:"bytes,5,opt,name=contents,proto3" json:"contents,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1281,7 +1329,7 @@ func (m *OutputFile) Reset()         { *m = OutputFile{} }
 func (m *OutputFile) String() string { return proto.CompactTextString(m) }
 func (*OutputFile) ProtoMessage()    {}
 func (*OutputFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{10}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{10}
 }
 func (m *OutputFile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OutputFile.Unmarshal(m, b)
@@ -1322,6 +1370,13 @@ func (m *OutputFile) GetIsExecutable() bool {
 	return false
 }
 
+func (m *OutputFile) GetContents() []byte {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
 // A `Tree` contains all the
 // [Directory][build.bazel.remote.execution.v2.Directory] protos in a
 // single directory Merkle tree, compressed into one message.
@@ -1342,7 +1397,7 @@ func (m *Tree) Reset()         { *m = Tree{} }
 func (m *Tree) String() string { return proto.CompactTextString(m) }
 func (*Tree) ProtoMessage()    {}
 func (*Tree) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{11}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{11}
 }
 func (m *Tree) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Tree.Unmarshal(m, b)
@@ -1397,7 +1452,7 @@ func (m *OutputDirectory) Reset()         { *m = OutputDirectory{} }
 func (m *OutputDirectory) String() string { return proto.CompactTextString(m) }
 func (*OutputDirectory) ProtoMessage()    {}
 func (*OutputDirectory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{12}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{12}
 }
 func (m *OutputDirectory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OutputDirectory.Unmarshal(m, b)
@@ -1457,7 +1512,7 @@ func (m *OutputSymlink) Reset()         { *m = OutputSymlink{} }
 func (m *OutputSymlink) String() string { return proto.CompactTextString(m) }
 func (*OutputSymlink) ProtoMessage()    {}
 func (*OutputSymlink) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{13}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{13}
 }
 func (m *OutputSymlink) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OutputSymlink.Unmarshal(m, b)
@@ -1512,7 +1567,7 @@ func (m *ExecutionPolicy) Reset()         { *m = ExecutionPolicy{} }
 func (m *ExecutionPolicy) String() string { return proto.CompactTextString(m) }
 func (*ExecutionPolicy) ProtoMessage()    {}
 func (*ExecutionPolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{14}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{14}
 }
 func (m *ExecutionPolicy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecutionPolicy.Unmarshal(m, b)
@@ -1560,7 +1615,7 @@ func (m *ResultsCachePolicy) Reset()         { *m = ResultsCachePolicy{} }
 func (m *ResultsCachePolicy) String() string { return proto.CompactTextString(m) }
 func (*ResultsCachePolicy) ProtoMessage()    {}
 func (*ResultsCachePolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{15}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{15}
 }
 func (m *ResultsCachePolicy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResultsCachePolicy.Unmarshal(m, b)
@@ -1619,7 +1674,7 @@ func (m *ExecuteRequest) Reset()         { *m = ExecuteRequest{} }
 func (m *ExecuteRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecuteRequest) ProtoMessage()    {}
 func (*ExecuteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{16}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{16}
 }
 func (m *ExecuteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecuteRequest.Unmarshal(m, b)
@@ -1693,7 +1748,7 @@ func (m *LogFile) Reset()         { *m = LogFile{} }
 func (m *LogFile) String() string { return proto.CompactTextString(m) }
 func (*LogFile) ProtoMessage()    {}
 func (*LogFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{17}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{17}
 }
 func (m *LogFile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogFile.Unmarshal(m, b)
@@ -1765,7 +1820,7 @@ func (m *ExecuteResponse) Reset()         { *m = ExecuteResponse{} }
 func (m *ExecuteResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecuteResponse) ProtoMessage()    {}
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{18}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{18}
 }
 func (m *ExecuteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecuteResponse.Unmarshal(m, b)
@@ -1840,7 +1895,7 @@ func (m *ExecuteOperationMetadata) Reset()         { *m = ExecuteOperationMetada
 func (m *ExecuteOperationMetadata) String() string { return proto.CompactTextString(m) }
 func (*ExecuteOperationMetadata) ProtoMessage()    {}
 func (*ExecuteOperationMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{19}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{19}
 }
 func (m *ExecuteOperationMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecuteOperationMetadata.Unmarshal(m, b)
@@ -1903,7 +1958,7 @@ func (m *WaitExecutionRequest) Reset()         { *m = WaitExecutionRequest{} }
 func (m *WaitExecutionRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitExecutionRequest) ProtoMessage()    {}
 func (*WaitExecutionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{20}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{20}
 }
 func (m *WaitExecutionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WaitExecutionRequest.Unmarshal(m, b)
@@ -1941,7 +1996,17 @@ type GetActionResultRequest struct {
 	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
 	// The digest of the [Action][build.bazel.remote.execution.v2.Action]
 	// whose result is requested.
-	ActionDigest         *Digest  `protobuf:"bytes,2,opt,name=action_digest,json=actionDigest,proto3" json:"action_digest,omitempty"`
+	ActionDigest *Digest `protobuf:"bytes,2,opt,name=action_digest,json=actionDigest,proto3" json:"action_digest,omitempty"`
+	// A hint to the server to request inlining stdout in the
+	// [ActionResult][build.bazel.remote.execution.v2.ActionResult] message.
+	InlineStdout bool `protobuf:"varint,3,opt,name=inline_stdout,json=inlineStdout,proto3" json:"inline_stdout,omitempty"`
+	// A hint to the server to request inlining stderr in the
+	// [ActionResult][build.bazel.remote.execution.v2.ActionResult] message.
+	InlineStderr bool `protobuf:"varint,4,opt,name=inline_stderr,json=inlineStderr,proto3" json:"inline_stderr,omitempty"`
+	// A hint to the server to inline the contents of the listed output files.
+	// Each path needs to exactly match one path in `output_files` in the
+	// [Command][build.bazel.remote.execution.v2.Command] message.
+	InlineOutputFiles    []string `protobuf:"bytes,5,rep,name=inline_output_files,json=inlineOutputFiles,proto3" json:"inline_output_files,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1951,7 +2016,7 @@ func (m *GetActionResultRequest) Reset()         { *m = GetActionResultRequest{}
 func (m *GetActionResultRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionResultRequest) ProtoMessage()    {}
 func (*GetActionResultRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{21}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{21}
 }
 func (m *GetActionResultRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionResultRequest.Unmarshal(m, b)
@@ -1985,6 +2050,27 @@ func (m *GetActionResultRequest) GetActionDigest() *Digest {
 	return nil
 }
 
+func (m *GetActionResultRequest) GetInlineStdout() bool {
+	if m != nil {
+		return m.InlineStdout
+	}
+	return false
+}
+
+func (m *GetActionResultRequest) GetInlineStderr() bool {
+	if m != nil {
+		return m.InlineStderr
+	}
+	return false
+}
+
+func (m *GetActionResultRequest) GetInlineOutputFiles() []string {
+	if m != nil {
+		return m.InlineOutputFiles
+	}
+	return nil
+}
+
 // A request message for
 // [ActionCache.UpdateActionResult][build.bazel.remote.execution.v2.ActionCache.UpdateActionResult].
 type UpdateActionResultRequest struct {
@@ -2013,7 +2099,7 @@ func (m *UpdateActionResultRequest) Reset()         { *m = UpdateActionResultReq
 func (m *UpdateActionResultRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateActionResultRequest) ProtoMessage()    {}
 func (*UpdateActionResultRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{22}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{22}
 }
 func (m *UpdateActionResultRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateActionResultRequest.Unmarshal(m, b)
@@ -2081,7 +2167,7 @@ func (m *FindMissingBlobsRequest) Reset()         { *m = FindMissingBlobsRequest
 func (m *FindMissingBlobsRequest) String() string { return proto.CompactTextString(m) }
 func (*FindMissingBlobsRequest) ProtoMessage()    {}
 func (*FindMissingBlobsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{23}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{23}
 }
 func (m *FindMissingBlobsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMissingBlobsRequest.Unmarshal(m, b)
@@ -2129,7 +2215,7 @@ func (m *FindMissingBlobsResponse) Reset()         { *m = FindMissingBlobsRespon
 func (m *FindMissingBlobsResponse) String() string { return proto.CompactTextString(m) }
 func (*FindMissingBlobsResponse) ProtoMessage()    {}
 func (*FindMissingBlobsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{24}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{24}
 }
 func (m *FindMissingBlobsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMissingBlobsResponse.Unmarshal(m, b)
@@ -2176,7 +2262,7 @@ func (m *BatchUpdateBlobsRequest) Reset()         { *m = BatchUpdateBlobsRequest
 func (m *BatchUpdateBlobsRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchUpdateBlobsRequest) ProtoMessage()    {}
 func (*BatchUpdateBlobsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{25}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{25}
 }
 func (m *BatchUpdateBlobsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchUpdateBlobsRequest.Unmarshal(m, b)
@@ -2225,7 +2311,7 @@ func (m *BatchUpdateBlobsRequest_Request) Reset()         { *m = BatchUpdateBlob
 func (m *BatchUpdateBlobsRequest_Request) String() string { return proto.CompactTextString(m) }
 func (*BatchUpdateBlobsRequest_Request) ProtoMessage()    {}
 func (*BatchUpdateBlobsRequest_Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{25, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{25, 0}
 }
 func (m *BatchUpdateBlobsRequest_Request) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchUpdateBlobsRequest_Request.Unmarshal(m, b)
@@ -2273,7 +2359,7 @@ func (m *BatchUpdateBlobsResponse) Reset()         { *m = BatchUpdateBlobsRespon
 func (m *BatchUpdateBlobsResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchUpdateBlobsResponse) ProtoMessage()    {}
 func (*BatchUpdateBlobsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{26}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{26}
 }
 func (m *BatchUpdateBlobsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchUpdateBlobsResponse.Unmarshal(m, b)
@@ -2315,7 +2401,7 @@ func (m *BatchUpdateBlobsResponse_Response) Reset()         { *m = BatchUpdateBl
 func (m *BatchUpdateBlobsResponse_Response) String() string { return proto.CompactTextString(m) }
 func (*BatchUpdateBlobsResponse_Response) ProtoMessage()    {}
 func (*BatchUpdateBlobsResponse_Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{26, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{26, 0}
 }
 func (m *BatchUpdateBlobsResponse_Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchUpdateBlobsResponse_Response.Unmarshal(m, b)
@@ -2369,7 +2455,7 @@ func (m *BatchReadBlobsRequest) Reset()         { *m = BatchReadBlobsRequest{} }
 func (m *BatchReadBlobsRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchReadBlobsRequest) ProtoMessage()    {}
 func (*BatchReadBlobsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{27}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{27}
 }
 func (m *BatchReadBlobsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchReadBlobsRequest.Unmarshal(m, b)
@@ -2417,7 +2503,7 @@ func (m *BatchReadBlobsResponse) Reset()         { *m = BatchReadBlobsResponse{}
 func (m *BatchReadBlobsResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchReadBlobsResponse) ProtoMessage()    {}
 func (*BatchReadBlobsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{28}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{28}
 }
 func (m *BatchReadBlobsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchReadBlobsResponse.Unmarshal(m, b)
@@ -2461,7 +2547,7 @@ func (m *BatchReadBlobsResponse_Response) Reset()         { *m = BatchReadBlobsR
 func (m *BatchReadBlobsResponse_Response) String() string { return proto.CompactTextString(m) }
 func (*BatchReadBlobsResponse_Response) ProtoMessage()    {}
 func (*BatchReadBlobsResponse_Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{28, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{28, 0}
 }
 func (m *BatchReadBlobsResponse_Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchReadBlobsResponse_Response.Unmarshal(m, b)
@@ -2534,7 +2620,7 @@ func (m *GetTreeRequest) Reset()         { *m = GetTreeRequest{} }
 func (m *GetTreeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTreeRequest) ProtoMessage()    {}
 func (*GetTreeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{29}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{29}
 }
 func (m *GetTreeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetTreeRequest.Unmarshal(m, b)
@@ -2601,7 +2687,7 @@ func (m *GetTreeResponse) Reset()         { *m = GetTreeResponse{} }
 func (m *GetTreeResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTreeResponse) ProtoMessage()    {}
 func (*GetTreeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{30}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{30}
 }
 func (m *GetTreeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetTreeResponse.Unmarshal(m, b)
@@ -2653,7 +2739,7 @@ func (m *GetCapabilitiesRequest) Reset()         { *m = GetCapabilitiesRequest{}
 func (m *GetCapabilitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCapabilitiesRequest) ProtoMessage()    {}
 func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{31}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{31}
 }
 func (m *GetCapabilitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCapabilitiesRequest.Unmarshal(m, b)
@@ -2702,7 +2788,7 @@ func (m *ServerCapabilities) Reset()         { *m = ServerCapabilities{} }
 func (m *ServerCapabilities) String() string { return proto.CompactTextString(m) }
 func (*ServerCapabilities) ProtoMessage()    {}
 func (*ServerCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{32}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{32}
 }
 func (m *ServerCapabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerCapabilities.Unmarshal(m, b)
@@ -2769,7 +2855,7 @@ func (m *ActionCacheUpdateCapabilities) Reset()         { *m = ActionCacheUpdate
 func (m *ActionCacheUpdateCapabilities) String() string { return proto.CompactTextString(m) }
 func (*ActionCacheUpdateCapabilities) ProtoMessage()    {}
 func (*ActionCacheUpdateCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{33}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{33}
 }
 func (m *ActionCacheUpdateCapabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionCacheUpdateCapabilities.Unmarshal(m, b)
@@ -2810,7 +2896,7 @@ func (m *PriorityCapabilities) Reset()         { *m = PriorityCapabilities{} }
 func (m *PriorityCapabilities) String() string { return proto.CompactTextString(m) }
 func (*PriorityCapabilities) ProtoMessage()    {}
 func (*PriorityCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{34}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{34}
 }
 func (m *PriorityCapabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PriorityCapabilities.Unmarshal(m, b)
@@ -2850,7 +2936,7 @@ func (m *PriorityCapabilities_PriorityRange) Reset()         { *m = PriorityCapa
 func (m *PriorityCapabilities_PriorityRange) String() string { return proto.CompactTextString(m) }
 func (*PriorityCapabilities_PriorityRange) ProtoMessage()    {}
 func (*PriorityCapabilities_PriorityRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{34, 0}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{34, 0}
 }
 func (m *PriorityCapabilities_PriorityRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PriorityCapabilities_PriorityRange.Unmarshal(m, b)
@@ -2909,7 +2995,7 @@ func (m *CacheCapabilities) Reset()         { *m = CacheCapabilities{} }
 func (m *CacheCapabilities) String() string { return proto.CompactTextString(m) }
 func (*CacheCapabilities) ProtoMessage()    {}
 func (*CacheCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{35}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{35}
 }
 func (m *CacheCapabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CacheCapabilities.Unmarshal(m, b)
@@ -2981,7 +3067,7 @@ func (m *ExecutionCapabilities) Reset()         { *m = ExecutionCapabilities{} }
 func (m *ExecutionCapabilities) String() string { return proto.CompactTextString(m) }
 func (*ExecutionCapabilities) ProtoMessage()    {}
 func (*ExecutionCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{36}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{36}
 }
 func (m *ExecutionCapabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecutionCapabilities.Unmarshal(m, b)
@@ -3037,7 +3123,7 @@ func (m *ToolDetails) Reset()         { *m = ToolDetails{} }
 func (m *ToolDetails) String() string { return proto.CompactTextString(m) }
 func (*ToolDetails) ProtoMessage()    {}
 func (*ToolDetails) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{37}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{37}
 }
 func (m *ToolDetails) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ToolDetails.Unmarshal(m, b)
@@ -3099,7 +3185,7 @@ func (m *RequestMetadata) Reset()         { *m = RequestMetadata{} }
 func (m *RequestMetadata) String() string { return proto.CompactTextString(m) }
 func (*RequestMetadata) ProtoMessage()    {}
 func (*RequestMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_remote_execution_cff8d2ce421b2abb, []int{38}
+	return fileDescriptor_remote_execution_1091f96054150e46, []int{38}
 }
 func (m *RequestMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RequestMetadata.Unmarshal(m, b)
@@ -4029,201 +4115,205 @@ var _Capabilities_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("build/bazel/remote/execution/v2/remote_execution.proto", fileDescriptor_remote_execution_cff8d2ce421b2abb)
-}
-
-var fileDescriptor_remote_execution_cff8d2ce421b2abb = []byte{
-	// 3061 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x4d, 0x6c, 0x1b, 0xc7,
-	0xf5, 0xff, 0x2f, 0x49, 0x51, 0xe4, 0xa3, 0x24, 0xd2, 0xf3, 0x97, 0x65, 0x99, 0x8a, 0x63, 0x7b,
-	0x83, 0xa4, 0xaa, 0x1c, 0x93, 0x0e, 0xdd, 0x38, 0x89, 0xf2, 0xe1, 0xea, 0x83, 0xb6, 0xec, 0xc8,
-	0x92, 0xba, 0x94, 0x1c, 0xa7, 0x4d, 0xb3, 0x19, 0x72, 0x47, 0xd4, 0x56, 0xe4, 0x2e, 0xbd, 0x3b,
-	0x94, 0x2c, 0x07, 0x46, 0x81, 0x02, 0x6d, 0xd0, 0xa4, 0xc8, 0x25, 0x3d, 0xb5, 0xa7, 0xf6, 0x58,
-	0xf4, 0xd8, 0x4b, 0xd1, 0xf6, 0xd2, 0x53, 0x7b, 0x6d, 0x81, 0xf6, 0xd8, 0x4b, 0x0f, 0x2d, 0x90,
-	0x53, 0x81, 0xdc, 0x7a, 0x28, 0xe6, 0x63, 0xbf, 0x48, 0xca, 0x4b, 0xca, 0x4e, 0xd1, 0x13, 0x77,
-	0xdf, 0xcc, 0xfb, 0xbd, 0x37, 0x6f, 0xde, 0xc7, 0xbc, 0x59, 0xc2, 0xb5, 0x7a, 0xd7, 0x6c, 0x19,
-	0xe5, 0x3a, 0x7e, 0x48, 0x5a, 0x65, 0x87, 0xb4, 0x6d, 0x4a, 0xca, 0xe4, 0x01, 0x69, 0x74, 0xa9,
-	0x69, 0x5b, 0xe5, 0x83, 0x8a, 0xa4, 0xe9, 0x3e, 0xad, 0xd4, 0x71, 0x6c, 0x6a, 0xa3, 0xf3, 0x9c,
-	0xaf, 0xc4, 0xf9, 0x4a, 0x62, 0x4e, 0x29, 0x98, 0x73, 0x50, 0x29, 0x9e, 0x0f, 0x03, 0xbb, 0xa4,
-	0x7d, 0x40, 0x1c, 0xf9, 0x23, 0x10, 0x8a, 0xcf, 0x34, 0x6d, 0xbb, 0xd9, 0x22, 0x65, 0xdc, 0x31,
-	0xcb, 0xd8, 0xb2, 0x6c, 0x8a, 0x19, 0xab, 0x2b, 0x47, 0x9f, 0x93, 0xa3, 0x2d, 0xdb, 0x6a, 0x3a,
-	0x5d, 0xcb, 0x32, 0xad, 0x66, 0xd9, 0xee, 0x10, 0x27, 0x32, 0xe9, 0x59, 0x39, 0x89, 0xbf, 0xd5,
-	0xbb, 0xbb, 0x65, 0xa3, 0x2b, 0x26, 0xc8, 0xf1, 0xf3, 0xbd, 0xe3, 0xd4, 0x6c, 0x13, 0x97, 0xe2,
-	0x76, 0x47, 0x4e, 0x38, 0x23, 0x27, 0x38, 0x9d, 0x46, 0xd9, 0xa5, 0x98, 0x76, 0x25, 0xb2, 0xfa,
-	0x71, 0x02, 0xd2, 0x4b, 0x0d, 0x06, 0x85, 0x36, 0x60, 0xaa, 0x61, 0xb7, 0xdb, 0xd8, 0x32, 0x74,
-	0xc3, 0x6c, 0x12, 0x97, 0xce, 0x2a, 0x17, 0x94, 0xf9, 0x5c, 0xe5, 0x2b, 0xa5, 0x18, 0x13, 0x94,
-	0x56, 0xf9, 0x74, 0x6d, 0x52, 0xb2, 0x8b, 0x57, 0x54, 0x83, 0x53, 0xa6, 0xd5, 0xe9, 0x52, 0xdd,
-	0xb1, 0x6d, 0xea, 0x41, 0x26, 0x46, 0x83, 0xcc, 0x73, 0x04, 0xcd, 0xb6, 0xa9, 0x04, 0xbd, 0x0a,
-	0xe3, 0x6c, 0x6d, 0x76, 0x97, 0xce, 0xa6, 0x39, 0xd4, 0xd9, 0x92, 0x58, 0x5a, 0xc9, 0x5b, 0x7b,
-	0x69, 0x55, 0xda, 0x46, 0xf3, 0x66, 0xa2, 0x0b, 0x30, 0x61, 0xd8, 0xba, 0x65, 0x53, 0xbd, 0x81,
-	0x1b, 0x7b, 0x64, 0x76, 0xfc, 0x82, 0x32, 0x9f, 0xd1, 0xc0, 0xb0, 0x37, 0x6c, 0xba, 0xc2, 0x28,
-	0xb7, 0x53, 0x99, 0x64, 0x21, 0xad, 0xfe, 0x2c, 0x09, 0xe3, 0x2b, 0x62, 0x0d, 0xe8, 0x19, 0xc8,
-	0x62, 0xa7, 0xd9, 0x6d, 0x13, 0x8b, 0xba, 0xb3, 0xca, 0x85, 0xe4, 0x7c, 0x56, 0x0b, 0x08, 0xe8,
-	0x3e, 0x9c, 0x26, 0xd6, 0x81, 0xe9, 0xd8, 0x16, 0x7b, 0xd7, 0x0f, 0xb0, 0x63, 0xe2, 0x7a, 0x8b,
-	0xb8, 0xb3, 0x89, 0x0b, 0xc9, 0xf9, 0x5c, 0xe5, 0x8d, 0xd8, 0xf5, 0x49, 0x31, 0xa5, 0x6a, 0x80,
-	0x72, 0x57, 0x82, 0x68, 0xd3, 0xa4, 0x9f, 0xe8, 0xa2, 0x8b, 0x30, 0x61, 0x77, 0x29, 0xb3, 0xe7,
-	0xae, 0xc9, 0x24, 0x25, 0xb9, 0x4e, 0x39, 0x41, 0xbb, 0xc1, 0x48, 0xe8, 0x32, 0x20, 0x39, 0xc5,
-	0x30, 0x1d, 0xd2, 0xa0, 0xb6, 0x63, 0x12, 0x77, 0x36, 0xc5, 0x27, 0x9e, 0x12, 0x23, 0xab, 0xc1,
-	0x00, 0xaa, 0x42, 0xa6, 0xd3, 0xc2, 0x74, 0xd7, 0x76, 0xda, 0xb3, 0x63, 0xdc, 0x98, 0x5f, 0x8d,
-	0xd5, 0x7b, 0x4b, 0x32, 0x68, 0x3e, 0x2b, 0xba, 0x04, 0xa7, 0x0e, 0x6d, 0x67, 0xdf, 0xb4, 0x9a,
-	0xbe, 0xd8, 0x23, 0xbe, 0x39, 0x59, 0xad, 0x20, 0x07, 0x3c, 0xa9, 0x47, 0xc5, 0xeb, 0xf0, 0xff,
-	0x03, 0x96, 0x8c, 0x10, 0xa4, 0x2c, 0xdc, 0x26, 0xdc, 0xe3, 0xb2, 0x1a, 0x7f, 0x46, 0xd3, 0x30,
-	0x76, 0x80, 0x5b, 0x5d, 0xc2, 0x7d, 0x26, 0xab, 0x89, 0x17, 0xf5, 0xc7, 0x0a, 0x64, 0x3c, 0x25,
-	0x90, 0x06, 0xd0, 0x71, 0x58, 0xb4, 0x50, 0xb6, 0x50, 0x85, 0xdb, 0xbe, 0x32, 0xf4, 0x1a, 0x4a,
-	0x5b, 0x82, 0xf7, 0x48, 0x0b, 0xa1, 0x14, 0xbf, 0x06, 0x19, 0x8f, 0x3e, 0x82, 0x5a, 0xff, 0x50,
-	0x20, 0xeb, 0xaf, 0x12, 0x5d, 0x87, 0x31, 0xb1, 0x49, 0x42, 0xa5, 0x78, 0xb3, 0xb2, 0xfd, 0xdb,
-	0xb0, 0x0d, 0xa2, 0x09, 0x3e, 0xb4, 0x05, 0xb9, 0xf0, 0x16, 0x0a, 0xaf, 0x2a, 0x0d, 0x11, 0x35,
-	0x52, 0x03, 0x8e, 0x15, 0x86, 0x40, 0x6b, 0x90, 0x71, 0x8f, 0xda, 0x2d, 0xd3, 0xda, 0x17, 0xae,
-	0x93, 0xab, 0xbc, 0x18, 0x0b, 0x57, 0x13, 0x0c, 0x1c, 0xcc, 0xe7, 0x56, 0x3f, 0x56, 0x20, 0xe3,
-	0xe9, 0x3b, 0xd0, 0x42, 0xd7, 0x21, 0x7d, 0xb2, 0x68, 0x97, 0x6c, 0xe8, 0x39, 0x98, 0x34, 0x5d,
-	0x99, 0x89, 0x99, 0x7b, 0xcc, 0xa6, 0x78, 0xc0, 0x4e, 0x98, 0x6e, 0xd5, 0xa7, 0xf1, 0x90, 0x4d,
-	0xa9, 0x06, 0x4c, 0x46, 0x16, 0xfd, 0xa5, 0x28, 0xa4, 0xbe, 0x06, 0xb9, 0x90, 0x2d, 0x06, 0xca,
-	0x98, 0x81, 0x34, 0xc5, 0x4e, 0x93, 0x50, 0xe9, 0x17, 0xf2, 0x4d, 0x7d, 0x1d, 0xd2, 0x32, 0x75,
-	0x21, 0x48, 0xed, 0x61, 0x77, 0xcf, 0xe3, 0x62, 0xcf, 0xe8, 0x1c, 0x80, 0x6b, 0x3e, 0x24, 0x7a,
-	0xfd, 0x88, 0xf2, 0x6d, 0x56, 0xe6, 0x93, 0x5a, 0x96, 0x51, 0x96, 0x19, 0x41, 0xfd, 0x5b, 0x1a,
-	0x66, 0xc4, 0x92, 0x89, 0x21, 0xb2, 0xf4, 0x1d, 0x42, 0xb1, 0x81, 0x29, 0x66, 0xf2, 0x58, 0x70,
-	0x11, 0x47, 0xe2, 0xc9, 0x37, 0x54, 0x85, 0xc2, 0xfd, 0x2e, 0xe9, 0x12, 0x43, 0xf7, 0x6b, 0x80,
-	0x5c, 0x75, 0xb1, 0x2f, 0x53, 0x6e, 0x7b, 0x33, 0xb4, 0xbc, 0xe0, 0xf1, 0x09, 0x68, 0x0b, 0x66,
-	0x04, 0xa0, 0xee, 0x52, 0xec, 0xd0, 0x10, 0x58, 0x32, 0x16, 0x6c, 0x5a, 0x70, 0xd6, 0x18, 0x63,
-	0x80, 0x78, 0x0f, 0x8a, 0x12, 0xb1, 0x61, 0xb7, 0x3b, 0x2d, 0x42, 0x23, 0x2a, 0xa6, 0x62, 0x51,
-	0x67, 0x05, 0xf7, 0x8a, 0xc7, 0x1c, 0x20, 0xbf, 0x0b, 0x73, 0xa2, 0xd0, 0xec, 0x12, 0xda, 0xd8,
-	0xeb, 0x53, 0x78, 0x2c, 0x1e, 0x9a, 0xb3, 0xdf, 0x60, 0xdc, 0x3d, 0x4a, 0x63, 0x38, 0x1f, 0x86,
-	0x1e, 0xa4, 0x79, 0x3a, 0x16, 0xfe, 0x99, 0x00, 0x7e, 0x80, 0xf6, 0x77, 0xe1, 0xac, 0xef, 0x7a,
-	0x7d, 0xba, 0x8f, 0xc7, 0x82, 0x9f, 0xf1, 0x99, 0x7b, 0x54, 0x7f, 0x1f, 0xce, 0x05, 0xb8, 0x83,
-	0x14, 0xcf, 0xc4, 0x62, 0xcf, 0xf9, 0x00, 0x03, 0xf4, 0xfe, 0x36, 0x9c, 0x93, 0xc5, 0xa6, 0xdb,
-	0x69, 0xd9, 0xd8, 0xe8, 0xd3, 0x3d, 0x1b, 0x8b, 0x5f, 0x14, 0x00, 0x3b, 0x9c, 0xbf, 0x47, 0x7d,
-	0x02, 0x17, 0xa3, 0xf0, 0x83, 0x96, 0x00, 0xb1, 0x22, 0x9e, 0x0d, 0x8b, 0xe8, 0x5f, 0x85, 0xfa,
-	0xaf, 0x31, 0x98, 0x10, 0x91, 0xa5, 0x11, 0xb7, 0xdb, 0xa2, 0x68, 0xa3, 0xa7, 0xcc, 0x8a, 0xd4,
-	0x7b, 0x29, 0x36, 0x63, 0x6c, 0xfa, 0x75, 0x38, 0x5a, 0x93, 0x3f, 0x80, 0xe9, 0x10, 0x9e, 0xee,
-	0xe7, 0x60, 0x18, 0x32, 0xa5, 0x0b, 0x5c, 0x99, 0x7d, 0x34, 0x14, 0x40, 0x4b, 0x92, 0x8b, 0xf4,
-	0x81, 0x55, 0x5f, 0xe4, 0xf8, 0x2b, 0x43, 0xe2, 0xfb, 0x39, 0x74, 0xd0, 0x39, 0xe1, 0x3b, 0x70,
-	0xb6, 0x47, 0xc0, 0x51, 0xb0, 0x8e, 0xdc, 0x89, 0xd6, 0x71, 0x26, 0x2a, 0xe5, 0xc8, 0x5f, 0xcc,
-	0x1c, 0x64, 0xc9, 0x03, 0x93, 0xea, 0x0d, 0xdb, 0x10, 0x69, 0x7f, 0x4c, 0xcb, 0x30, 0xc2, 0x0a,
-	0xcb, 0xbb, 0x2c, 0x5b, 0x52, 0xc3, 0x66, 0x47, 0x4a, 0x7c, 0xc8, 0xe3, 0x7a, 0x42, 0xcb, 0x0a,
-	0x8a, 0x86, 0x0f, 0xd1, 0x3a, 0x4c, 0xca, 0x61, 0x99, 0xed, 0xd3, 0xa3, 0x65, 0xfb, 0x09, 0xc1,
-	0x2d, 0xd3, 0xb5, 0x10, 0x46, 0x1c, 0x87, 0x0b, 0x1b, 0xf7, 0x85, 0x11, 0xc7, 0x09, 0x84, 0xb1,
-	0x61, 0x29, 0x2c, 0x33, 0xba, 0x30, 0xe2, 0x38, 0x52, 0xd8, 0x2e, 0xa0, 0x20, 0x58, 0xdb, 0x32,
-	0xc7, 0xcb, 0x08, 0x7a, 0x25, 0x16, 0x72, 0x70, 0x89, 0xd0, 0x4e, 0xf9, 0x93, 0x3c, 0xd2, 0xed,
-	0x54, 0x46, 0x29, 0x24, 0xd4, 0x1f, 0x29, 0x00, 0x81, 0xbf, 0xb2, 0xc2, 0xd4, 0xc1, 0xd4, 0x2f,
-	0x4c, 0xec, 0xf9, 0xbf, 0x5a, 0xc3, 0x3f, 0x55, 0x20, 0xb5, 0xed, 0x10, 0x82, 0xde, 0x82, 0x14,
-	0xeb, 0x15, 0x64, 0xdf, 0xb1, 0x30, 0xfc, 0x71, 0x47, 0xe3, 0x7c, 0xe8, 0x06, 0x64, 0x1a, 0x7b,
-	0x66, 0xcb, 0x70, 0x88, 0x25, 0xe3, 0x76, 0x14, 0x0c, 0x9f, 0x57, 0xed, 0x42, 0xbe, 0x27, 0x2c,
-	0x06, 0xda, 0x68, 0x0d, 0x72, 0xd4, 0x21, 0xc4, 0x73, 0x80, 0xe4, 0x68, 0x86, 0x02, 0xc6, 0x2b,
-	0x9e, 0x6f, 0xa7, 0x32, 0x89, 0x42, 0x52, 0x7d, 0x1d, 0x26, 0x23, 0x51, 0x32, 0x50, 0xe8, 0x71,
-	0xe7, 0x8c, 0xcb, 0x90, 0xaf, 0x7a, 0x52, 0xb6, 0xec, 0x96, 0xd9, 0x38, 0x42, 0x45, 0xc8, 0x74,
-	0x1c, 0xd3, 0x76, 0x4c, 0x7a, 0xc4, 0x21, 0xc6, 0x34, 0xff, 0x5d, 0xbd, 0x02, 0x48, 0x24, 0x3c,
-	0x97, 0x37, 0x40, 0x43, 0x70, 0x7c, 0x3f, 0x09, 0x53, 0xd2, 0xd1, 0x34, 0x72, 0xbf, 0xeb, 0xed,
-	0xb1, 0xe5, 0x52, 0x6c, 0x35, 0x88, 0x1e, 0x3a, 0x10, 0x4d, 0x78, 0xc4, 0x0d, 0x76, 0x30, 0x5a,
-	0x80, 0x53, 0xee, 0xbe, 0xd9, 0x11, 0xad, 0x97, 0xde, 0xb2, 0xed, 0xfd, 0xae, 0x38, 0x44, 0x64,
-	0xb4, 0x3c, 0x1b, 0xe0, 0xf2, 0xd7, 0x39, 0x99, 0x05, 0x15, 0xe6, 0x3e, 0x7c, 0xd2, 0x08, 0x16,
-	0xdc, 0x32, 0xa8, 0xbe, 0x05, 0x85, 0x20, 0xa8, 0x3a, 0x7c, 0x85, 0xb2, 0xa0, 0x5e, 0x19, 0x32,
-	0xa4, 0x7c, 0x5b, 0x6a, 0x79, 0xd2, 0x63, 0x5c, 0x02, 0xd3, 0x8e, 0x30, 0xa0, 0x5c, 0x99, 0x14,
-	0x20, 0xd2, 0xc0, 0xd5, 0x58, 0x01, 0xfd, 0xd6, 0xd7, 0x90, 0xd3, 0x47, 0x13, 0x9e, 0x71, 0x3b,
-	0x95, 0x49, 0x15, 0xc6, 0x6e, 0xa7, 0x32, 0x63, 0x85, 0xb4, 0x7a, 0x1f, 0xc6, 0xd7, 0xed, 0x26,
-	0x0f, 0xdc, 0x20, 0x48, 0x95, 0x93, 0x05, 0xe9, 0xf3, 0x30, 0xb5, 0xd7, 0x6d, 0x63, 0x4b, 0x77,
-	0x08, 0x36, 0x78, 0x94, 0x26, 0xf8, 0xc6, 0x4c, 0x72, 0xaa, 0x26, 0x89, 0xea, 0x17, 0x09, 0xcf,
-	0xb9, 0x88, 0x46, 0xdc, 0x8e, 0x6d, 0xb9, 0x04, 0x55, 0x21, 0x2d, 0xd4, 0x95, 0xb2, 0x2f, 0xc7,
-	0xca, 0x0e, 0x97, 0x59, 0x4d, 0x32, 0x33, 0x17, 0xe2, 0xe6, 0x33, 0x74, 0x89, 0x26, 0x14, 0x98,
-	0x10, 0x44, 0x59, 0x93, 0x17, 0x20, 0x2d, 0x2e, 0x2d, 0x64, 0x8c, 0x21, 0xaf, 0xe0, 0x3b, 0x9d,
-	0x46, 0xa9, 0xc6, 0x47, 0x34, 0x39, 0x03, 0x61, 0xc8, 0xb9, 0xc4, 0x39, 0x20, 0x8e, 0xde, 0xb2,
-	0x9b, 0xa2, 0xf9, 0xcd, 0x55, 0xbe, 0x3e, 0x6c, 0x0a, 0xf5, 0x96, 0x57, 0xaa, 0x71, 0x8c, 0x75,
-	0xbb, 0xe9, 0x56, 0x2d, 0xea, 0x1c, 0x69, 0xe0, 0xfa, 0x84, 0x62, 0x13, 0xf2, 0x3d, 0xc3, 0xa8,
-	0x00, 0xc9, 0x7d, 0x72, 0x24, 0xfd, 0x9f, 0x3d, 0xa2, 0xb7, 0xc2, 0x6d, 0x62, 0xae, 0x32, 0x1f,
-	0xab, 0x81, 0xdc, 0x54, 0xd9, 0x50, 0x2e, 0x26, 0x5e, 0x55, 0xd4, 0xcf, 0x13, 0x30, 0x2b, 0x15,
-	0xdb, 0xf4, 0xae, 0x84, 0xfc, 0x06, 0x60, 0x07, 0xc6, 0x5c, 0x8a, 0x9b, 0x22, 0xe8, 0xa6, 0x2a,
-	0xd7, 0x87, 0x5d, 0x62, 0x1f, 0x12, 0xb3, 0x60, 0x93, 0x68, 0x02, 0xad, 0x3f, 0x04, 0x13, 0x4f,
-	0x12, 0x82, 0x2f, 0x02, 0x92, 0x25, 0xd9, 0xa5, 0x0e, 0xc1, 0x6d, 0x91, 0x26, 0x92, 0xe2, 0x72,
-	0x40, 0x8c, 0xd4, 0xf8, 0x00, 0x4f, 0x15, 0x62, 0x36, 0xab, 0xa9, 0xe1, 0xd9, 0x29, 0x7f, 0x36,
-	0x71, 0x9c, 0x60, 0xb6, 0xba, 0x09, 0x63, 0x5c, 0x73, 0x94, 0x83, 0xf1, 0x9d, 0x8d, 0xb7, 0x37,
-	0x36, 0xdf, 0xd9, 0x28, 0xfc, 0x1f, 0xca, 0x43, 0x6e, 0x65, 0x69, 0x65, 0xad, 0xaa, 0xaf, 0xac,
-	0x55, 0x57, 0xde, 0x2e, 0x28, 0x08, 0x20, 0xfd, 0x8d, 0x9d, 0xea, 0x4e, 0x75, 0xb5, 0x90, 0x40,
-	0x93, 0x90, 0xad, 0xde, 0xab, 0xae, 0xec, 0x6c, 0xdf, 0xda, 0xb8, 0x59, 0x48, 0xb2, 0xd7, 0x95,
-	0xcd, 0x3b, 0x5b, 0xeb, 0xd5, 0xed, 0xea, 0x6a, 0x21, 0xa5, 0x2e, 0xc0, 0xf4, 0x3b, 0xd8, 0xa4,
-	0x7e, 0xe8, 0x7b, 0x69, 0x6e, 0x40, 0xbb, 0xa7, 0x7e, 0xa2, 0xc0, 0xcc, 0x4d, 0x42, 0x23, 0x3e,
-	0x3d, 0x4a, 0x56, 0x7c, 0xaa, 0x66, 0x56, 0xff, 0x98, 0x80, 0xb3, 0x3b, 0x1d, 0x03, 0x53, 0xf2,
-	0xbf, 0xa1, 0x10, 0xd2, 0x7c, 0x34, 0x19, 0xd6, 0xc9, 0x93, 0x24, 0x09, 0x89, 0x29, 0xb3, 0xc0,
-	0x71, 0x19, 0x37, 0xf5, 0x54, 0x33, 0xae, 0xfa, 0xb1, 0x02, 0x67, 0x6e, 0x98, 0x96, 0x71, 0xc7,
-	0x74, 0x5d, 0xd3, 0x6a, 0x2e, 0xb7, 0xec, 0xba, 0x3b, 0x92, 0x25, 0x6f, 0xc3, 0x44, 0xbd, 0x65,
-	0xd7, 0xa5, 0x1d, 0xbd, 0x0e, 0x62, 0x68, 0x43, 0xe6, 0x18, 0xb3, 0x78, 0x76, 0xd5, 0x2e, 0xcc,
-	0xf6, 0xeb, 0x22, 0x33, 0xf0, 0xbb, 0x30, 0xdd, 0x16, 0x74, 0xfd, 0x49, 0xe4, 0xa1, 0x76, 0x00,
-	0xee, 0x89, 0xfd, 0xb7, 0x02, 0x67, 0x96, 0x31, 0x6d, 0xec, 0x09, 0xa7, 0x1a, 0xdd, 0x06, 0xef,
-	0x41, 0xc6, 0x11, 0xf3, 0x3d, 0x7d, 0xe2, 0x53, 0xf0, 0x31, 0x02, 0x4b, 0xf2, 0x57, 0xf3, 0x11,
-	0x8b, 0xef, 0xc3, 0xb8, 0xa7, 0xcd, 0x13, 0x97, 0x40, 0x04, 0x29, 0x7e, 0xd6, 0x4e, 0xf0, 0x03,
-	0x3e, 0x7f, 0x56, 0xbf, 0x50, 0x60, 0xb6, 0x5f, 0x1b, 0x69, 0xf6, 0x0f, 0x20, 0xeb, 0xc8, 0x67,
-	0xef, 0x7e, 0x6f, 0xf9, 0x04, 0x6b, 0x93, 0x75, 0xc6, 0x7b, 0xd0, 0x02, 0xd0, 0xe2, 0x21, 0x64,
-	0x7c, 0x69, 0x4f, 0xbc, 0xbe, 0xa0, 0x76, 0x26, 0xe2, 0x6a, 0xa7, 0xfa, 0x5d, 0x38, 0xcd, 0x15,
-	0x65, 0x85, 0x7f, 0xf4, 0x3d, 0x5f, 0x82, 0xf1, 0x13, 0xba, 0xa0, 0xc7, 0xa7, 0xfe, 0x20, 0x01,
-	0x33, 0xbd, 0x1a, 0x48, 0x43, 0xbc, 0xdf, 0x6f, 0xf6, 0x21, 0x5d, 0xaa, 0x0f, 0x6b, 0xa0, 0xd1,
-	0x3f, 0x51, 0x9e, 0xa6, 0xd5, 0x07, 0x78, 0xd5, 0x28, 0xa7, 0x18, 0xf5, 0x37, 0x0a, 0x4c, 0xdd,
-	0x24, 0x94, 0x75, 0x45, 0x23, 0xed, 0xc1, 0x1a, 0xe4, 0x9e, 0xe0, 0x6b, 0x0b, 0x38, 0xc1, 0x87,
-	0x96, 0x39, 0xc8, 0x76, 0x70, 0x93, 0xe8, 0xae, 0xf9, 0x50, 0x14, 0x6c, 0xd6, 0x0b, 0xe0, 0x26,
-	0xa9, 0x99, 0x0f, 0x79, 0x23, 0xce, 0x07, 0xa9, 0xbd, 0x4f, 0x2c, 0x59, 0xa0, 0xf9, 0xf4, 0x6d,
-	0x46, 0x50, 0x3f, 0x52, 0x20, 0xef, 0x6b, 0x2f, 0x4d, 0xba, 0x1e, 0xbd, 0xd1, 0x56, 0x46, 0x6e,
-	0xcf, 0x22, 0xb7, 0xd9, 0x2f, 0x40, 0xde, 0x22, 0x0f, 0xa8, 0x1e, 0xd2, 0x42, 0xb4, 0x43, 0x93,
-	0x8c, 0xbc, 0xe5, 0x6b, 0xf2, 0x26, 0xaf, 0xd2, 0x2b, 0xb8, 0x83, 0xeb, 0x66, 0xcb, 0xa4, 0x26,
-	0x19, 0xc9, 0xa5, 0xd5, 0xdf, 0x27, 0x01, 0x89, 0xa3, 0x5e, 0x18, 0x02, 0x61, 0x40, 0xa2, 0x02,
-	0x35, 0x42, 0x54, 0xe9, 0x2a, 0xf1, 0x9f, 0x1f, 0x78, 0xb1, 0x89, 0xa8, 0x74, 0xaa, 0xd1, 0x4b,
-	0x42, 0x6d, 0x98, 0x09, 0xdd, 0xde, 0x85, 0xc5, 0x88, 0x3d, 0xbd, 0x36, 0x7c, 0x07, 0x13, 0x11,
-	0x75, 0x9a, 0x0c, 0x22, 0xa3, 0x2d, 0x98, 0x31, 0x48, 0xc7, 0x21, 0x0d, 0x4c, 0x89, 0xa1, 0xe3,
-	0x8e, 0xa9, 0x1f, 0x10, 0xc7, 0x35, 0x6d, 0xcb, 0xbf, 0xee, 0x0d, 0x8b, 0x93, 0x9f, 0x37, 0x6b,
-	0xa4, 0x7d, 0x97, 0x38, 0xda, 0x74, 0xc0, 0xb9, 0xd4, 0x31, 0xef, 0x0a, 0x3e, 0xb4, 0x0c, 0xf9,
-	0x96, 0x7d, 0x18, 0x81, 0x4a, 0xc5, 0x42, 0x4d, 0xb6, 0xec, 0xc3, 0x10, 0xc6, 0x2a, 0x14, 0xf6,
-	0xcc, 0xe6, 0x5e, 0x04, 0x64, 0x2c, 0x16, 0x64, 0x8a, 0xf1, 0x04, 0x28, 0xea, 0x0d, 0x38, 0x27,
-	0x4e, 0x15, 0xdc, 0xf0, 0x22, 0x09, 0x47, 0x16, 0xff, 0x3c, 0x4c, 0x75, 0x39, 0x55, 0x27, 0x16,
-	0xeb, 0x77, 0x0c, 0xbe, 0x95, 0x19, 0x6d, 0x52, 0x50, 0xab, 0x82, 0xa8, 0xfe, 0x49, 0x81, 0xe9,
-	0x2d, 0xd9, 0x0d, 0x47, 0xf8, 0x1b, 0x00, 0xb2, 0x4b, 0x0e, 0x3c, 0x7b, 0x25, 0xfe, 0x2b, 0xd4,
-	0x00, 0x28, 0x9f, 0xa8, 0x61, 0xab, 0x49, 0xb4, 0x10, 0x6c, 0x71, 0x07, 0x26, 0x23, 0x83, 0xe8,
-	0x22, 0x4c, 0xb4, 0x4d, 0x4b, 0xef, 0xe9, 0xd7, 0x73, 0x6d, 0xd3, 0xf2, 0xe6, 0xf1, 0x29, 0xf8,
-	0x41, 0x30, 0x25, 0x21, 0xa7, 0xe0, 0x07, 0xde, 0x14, 0xf5, 0x87, 0x63, 0x70, 0xaa, 0xcf, 0x21,
-	0xd1, 0x3d, 0xc8, 0x8b, 0x0c, 0xa2, 0xef, 0x76, 0x2d, 0x6e, 0x3b, 0xbe, 0xac, 0xa9, 0x4a, 0x79,
-	0xc8, 0x54, 0x72, 0x43, 0xb2, 0x69, 0x53, 0x46, 0xe4, 0x1d, 0x7d, 0xa4, 0xc0, 0x05, 0x79, 0x32,
-	0x14, 0x21, 0x24, 0x2d, 0x3f, 0xc0, 0xc5, 0xdf, 0x1a, 0xf2, 0xb0, 0x78, 0xcc, 0xb6, 0x6a, 0xe7,
-	0xf0, 0x63, 0x77, 0xbd, 0x0b, 0x73, 0xf2, 0x18, 0x29, 0x6d, 0x11, 0xd5, 0x41, 0xf8, 0xfd, 0xcb,
-	0x27, 0xda, 0x46, 0xed, 0x2c, 0x47, 0x1e, 0xe8, 0x2c, 0x8b, 0x50, 0x64, 0x7b, 0x52, 0x67, 0x95,
-	0x49, 0xa7, 0x36, 0xc5, 0x2d, 0x3d, 0xf4, 0x05, 0x28, 0xc5, 0xbf, 0x00, 0xcd, 0xb4, 0xf1, 0x03,
-	0x5e, 0xba, 0xb6, 0xd9, 0x78, 0xcd, 0xfb, 0x1c, 0x84, 0x3e, 0x53, 0xe0, 0x59, 0x79, 0xf1, 0xaa,
-	0xe3, 0xba, 0x6b, 0xb7, 0xba, 0x94, 0xe8, 0x1d, 0x4c, 0xf7, 0x58, 0xbf, 0x84, 0x29, 0x69, 0x1e,
-	0xf1, 0xf0, 0x98, 0xaa, 0x6c, 0x8c, 0x9e, 0x84, 0xbc, 0x8f, 0x7d, 0x4b, 0x12, 0x77, 0x0b, 0xd3,
-	0xbd, 0x9a, 0x44, 0xd5, 0xe6, 0xdc, 0xe3, 0x07, 0xd5, 0x9b, 0x30, 0xf7, 0x18, 0xde, 0x68, 0x77,
-	0x36, 0x05, 0xb0, 0x7a, 0xab, 0xb6, 0xb4, 0xbe, 0xbe, 0xf9, 0x4e, 0x75, 0xb5, 0xa0, 0xb0, 0x41,
-	0xef, 0x25, 0xa1, 0x7e, 0x96, 0x80, 0xd3, 0x03, 0xb3, 0xd6, 0x60, 0x7f, 0x54, 0x9e, 0x86, 0x3f,
-	0x5e, 0x84, 0x09, 0x36, 0xdd, 0x8f, 0x7c, 0x71, 0xfd, 0x90, 0x63, 0x34, 0x19, 0xf7, 0xe8, 0x11,
-	0x9c, 0x0f, 0x5d, 0x23, 0x3d, 0x7d, 0x67, 0x09, 0x3e, 0xd3, 0x0c, 0x1a, 0x56, 0xef, 0x40, 0x6e,
-	0xdb, 0xb6, 0x5b, 0xab, 0x84, 0x62, 0xb3, 0xc5, 0x2f, 0xc8, 0xa9, 0x6d, 0xb7, 0xc2, 0x35, 0x2b,
-	0xc3, 0x08, 0xbc, 0xfc, 0x5f, 0x84, 0x09, 0x3e, 0xe8, 0x25, 0x4b, 0x51, 0x13, 0x73, 0x8c, 0xe6,
-	0x65, 0xc3, 0x7f, 0x2a, 0x90, 0x97, 0x35, 0xd0, 0xbf, 0x4a, 0xd8, 0x94, 0x6c, 0x86, 0x90, 0x21,
-	0x2b, 0x59, 0xfc, 0xf7, 0xe1, 0x90, 0x5e, 0x42, 0x48, 0x48, 0x49, 0x19, 0xe4, 0xa6, 0x21, 0x95,
-	0xc8, 0x08, 0xc2, 0x2d, 0x83, 0x75, 0xf9, 0x5c, 0x9a, 0x69, 0x1d, 0xd8, 0x0d, 0xec, 0xcd, 0x92,
-	0x77, 0x02, 0x6c, 0xe4, 0x96, 0x3f, 0x70, 0xcb, 0x40, 0x8b, 0x70, 0xb6, 0x61, 0x3b, 0x0e, 0x69,
-	0xf1, 0xca, 0x14, 0xf0, 0xb8, 0x8c, 0x49, 0x9c, 0x3c, 0xce, 0x04, 0x13, 0x02, 0x56, 0xf7, 0x96,
-	0xb1, 0xf0, 0x06, 0x4c, 0x45, 0xb7, 0x3f, 0xea, 0x8c, 0x00, 0xe9, 0xda, 0xda, 0x52, 0xe5, 0xe5,
-	0x6b, 0x05, 0x05, 0x65, 0x20, 0x55, 0x5b, 0x5b, 0x7a, 0xa9, 0x90, 0x40, 0xe3, 0x90, 0xbc, 0xb3,
-	0xfa, 0x72, 0x21, 0x59, 0xf9, 0x75, 0x02, 0xb2, 0xbe, 0x3b, 0xa2, 0x4f, 0x15, 0x18, 0x97, 0x37,
-	0x28, 0xa8, 0x3c, 0xfc, 0x75, 0x12, 0x37, 0x74, 0xf1, 0x9c, 0x77, 0xd4, 0x0b, 0xfd, 0xcb, 0xa7,
-	0xe4, 0x5f, 0xc4, 0xa8, 0x2f, 0x7d, 0xef, 0xcf, 0x7f, 0xff, 0x2c, 0x71, 0x49, 0x7d, 0xa1, 0x7c,
-	0x50, 0x29, 0x7f, 0x18, 0x39, 0x96, 0xbc, 0xb9, 0xb0, 0xf0, 0xa8, 0x2c, 0x4c, 0xe7, 0x2e, 0x0a,
-	0x11, 0x64, 0x51, 0x59, 0xb8, 0xa2, 0xa0, 0x9f, 0x28, 0x30, 0x19, 0xb9, 0xae, 0x40, 0xf1, 0xfe,
-	0x37, 0xe8, 0x7a, 0x63, 0x34, 0xe5, 0xb8, 0x4e, 0xc1, 0xff, 0x93, 0xca, 0x0b, 0x0b, 0x8f, 0x16,
-	0x0f, 0xc3, 0xa8, 0x5c, 0xb9, 0xca, 0x5f, 0x92, 0x90, 0x0b, 0x65, 0x67, 0xf4, 0x57, 0x71, 0x22,
-	0x8c, 0x7c, 0x69, 0x8b, 0xff, 0xae, 0x31, 0xf8, 0x82, 0xa5, 0x38, 0xda, 0x2d, 0x82, 0xfa, 0x1e,
-	0x5f, 0xc0, 0x5d, 0xb4, 0xfd, 0x58, 0xeb, 0xca, 0xdb, 0x81, 0xf2, 0x87, 0x91, 0x5b, 0x90, 0xd2,
-	0x1e, 0x76, 0xf7, 0x1e, 0xf5, 0x12, 0x83, 0x84, 0xfd, 0x08, 0x7d, 0xae, 0x00, 0xea, 0xbf, 0x7a,
-	0x41, 0x8b, 0xb1, 0x3a, 0x1e, 0x7b, 0x5f, 0x33, 0xea, 0xfa, 0xf6, 0xf9, 0xfa, 0x48, 0xf1, 0x4b,
-	0x59, 0xdf, 0x62, 0xf4, 0x1e, 0xa7, 0xf2, 0xd3, 0x34, 0x9c, 0x5d, 0xb1, 0x2d, 0x4a, 0x2c, 0xba,
-	0x64, 0x18, 0x0e, 0x71, 0x5d, 0x96, 0x24, 0x6b, 0xd4, 0x76, 0x70, 0x93, 0xa0, 0xdf, 0x2a, 0x50,
-	0xe8, 0xbd, 0xaf, 0x40, 0xaf, 0x0e, 0xf1, 0xef, 0x97, 0x81, 0xd7, 0x2d, 0xc5, 0xd7, 0x4e, 0xc0,
-	0x29, 0xda, 0x0d, 0xf5, 0x2a, 0x37, 0xca, 0x65, 0x75, 0xfe, 0x18, 0xa3, 0xd4, 0xd9, 0xec, 0xc5,
-	0xdd, 0x80, 0x7d, 0x51, 0x59, 0xe0, 0xea, 0xf7, 0x76, 0xea, 0x43, 0xa8, 0x7f, 0xcc, 0xc5, 0xc5,
-	0x10, 0xea, 0x1f, 0x77, 0x2d, 0x30, 0xa4, 0xfa, 0xf5, 0x80, 0x9d, 0xa9, 0xff, 0x2b, 0x05, 0xa6,
-	0xa2, 0x1d, 0x2f, 0xba, 0x36, 0x72, 0x8b, 0x2c, 0x54, 0x7f, 0xe5, 0x84, 0xad, 0x75, 0x6c, 0x2a,
-	0x0b, 0x29, 0xce, 0x98, 0x99, 0xda, 0x7f, 0x50, 0x60, 0x5c, 0x76, 0x8b, 0x43, 0x64, 0xd6, 0x68,
-	0x57, 0x5c, 0xbc, 0x32, 0x3c, 0x83, 0xd4, 0xf0, 0x1e, 0xd7, 0x50, 0x43, 0x5b, 0x8f, 0xd3, 0xb0,
-	0xfc, 0x61, 0xa8, 0x8d, 0xf6, 0x82, 0x24, 0x4c, 0x0a, 0x87, 0x48, 0x53, 0x48, 0xb8, 0xa2, 0x54,
-	0x7e, 0xa7, 0xc0, 0x44, 0xe4, 0xe0, 0xf2, 0x4b, 0x91, 0xf7, 0x22, 0xb4, 0xa1, 0xf2, 0xde, 0x80,
-	0x96, 0xb5, 0x18, 0x7f, 0xc5, 0xd9, 0xdf, 0xab, 0xaa, 0x97, 0xf8, 0x72, 0x9f, 0x47, 0xcf, 0x1d,
-	0xb3, 0xdc, 0xf0, 0x39, 0x66, 0xd9, 0x81, 0xb8, 0xbf, 0xbb, 0x2e, 0x4f, 0x6b, 0x9c, 0x18, 0x7c,
-	0x1f, 0x73, 0x6c, 0x6a, 0x6f, 0x29, 0xdf, 0xcc, 0x8b, 0xc9, 0xfe, 0xdc, 0x9f, 0x27, 0x92, 0x5a,
-	0xf5, 0xde, 0x2f, 0x12, 0xe7, 0x97, 0x39, 0xe0, 0x32, 0x07, 0x14, 0xbc, 0x41, 0x6b, 0x5a, 0xba,
-	0x5b, 0xa9, 0xa7, 0xf9, 0xbf, 0x36, 0xae, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff, 0xc5, 0x4f, 0x3f,
-	0xd6, 0xa1, 0x2b, 0x00, 0x00,
+	proto.RegisterFile("build/bazel/remote/execution/v2/remote_execution.proto", fileDescriptor_remote_execution_1091f96054150e46)
+}
+
+var fileDescriptor_remote_execution_1091f96054150e46 = []byte{
+	// 3136 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x4d, 0x90, 0x1b, 0x47,
+	0xf5, 0xff, 0x8f, 0xa4, 0xd5, 0x4a, 0x4f, 0xbb, 0x2b, 0xb9, 0xb3, 0xb6, 0x65, 0x39, 0x8e, 0xed,
+	0x49, 0x25, 0x7f, 0xb3, 0x8e, 0x25, 0x47, 0xc6, 0x4e, 0xb2, 0x21, 0x31, 0xfb, 0x21, 0x7f, 0x65,
+	0xbd, 0x5e, 0x46, 0xbb, 0x8e, 0x03, 0x21, 0x93, 0x59, 0x4d, 0x5b, 0x3b, 0x58, 0x9a, 0x96, 0x7b,
+	0x5a, 0x6b, 0x6f, 0x52, 0x2e, 0xaa, 0xa8, 0x0a, 0x29, 0x42, 0x55, 0x2e, 0xe1, 0x04, 0x37, 0x4e,
+	0x14, 0xc5, 0x91, 0x0b, 0x05, 0x5c, 0x28, 0x0e, 0x70, 0x85, 0x2a, 0x38, 0x72, 0xe1, 0x00, 0x55,
+	0x39, 0x51, 0x95, 0x1b, 0x07, 0xaa, 0x3f, 0xe6, 0x4b, 0xd2, 0x7a, 0xa4, 0xb5, 0x43, 0x71, 0xd2,
+	0xf4, 0xeb, 0xf7, 0x7e, 0xef, 0xf5, 0xeb, 0x7e, 0xaf, 0xbb, 0x5f, 0x0b, 0x2e, 0x6d, 0xf7, 0x9d,
+	0x8e, 0x5d, 0xdb, 0xb6, 0x3e, 0xc0, 0x9d, 0x1a, 0xc5, 0x5d, 0xc2, 0x70, 0x0d, 0x3f, 0xc4, 0xad,
+	0x3e, 0x73, 0x88, 0x5b, 0xdb, 0xad, 0x2b, 0x9a, 0x19, 0xd0, 0xaa, 0x3d, 0x4a, 0x18, 0x41, 0x27,
+	0x85, 0x5c, 0x55, 0xc8, 0x55, 0x25, 0x4f, 0x35, 0xe4, 0xd9, 0xad, 0x57, 0x4e, 0x46, 0x81, 0x3d,
+	0xdc, 0xdd, 0xc5, 0x54, 0xfd, 0x48, 0x84, 0xca, 0xb3, 0x6d, 0x42, 0xda, 0x1d, 0x5c, 0xb3, 0x7a,
+	0x4e, 0xcd, 0x72, 0x5d, 0xc2, 0x2c, 0x2e, 0xea, 0xa9, 0xde, 0xe7, 0x55, 0x6f, 0x87, 0xb8, 0x6d,
+	0xda, 0x77, 0x5d, 0xc7, 0x6d, 0xd7, 0x48, 0x0f, 0xd3, 0x18, 0xd3, 0x73, 0x8a, 0x49, 0xb4, 0xb6,
+	0xfb, 0x77, 0x6b, 0x76, 0x5f, 0x32, 0xa8, 0xfe, 0x93, 0x83, 0xfd, 0xcc, 0xe9, 0x62, 0x8f, 0x59,
+	0xdd, 0x9e, 0x62, 0x38, 0xaa, 0x18, 0x68, 0xaf, 0x55, 0xf3, 0x98, 0xc5, 0xfa, 0x0a, 0x59, 0xff,
+	0x24, 0x05, 0xd9, 0xa5, 0x16, 0x87, 0x42, 0xeb, 0x30, 0xd7, 0x22, 0xdd, 0xae, 0xe5, 0xda, 0xa6,
+	0xed, 0xb4, 0xb1, 0xc7, 0xca, 0xda, 0x29, 0xed, 0x4c, 0xa1, 0xfe, 0xff, 0xd5, 0x04, 0x17, 0x54,
+	0x57, 0x05, 0xbb, 0x31, 0xab, 0xc4, 0x65, 0x13, 0x35, 0xe1, 0x90, 0xe3, 0xf6, 0xfa, 0xcc, 0xa4,
+	0x84, 0x30, 0x1f, 0x32, 0x35, 0x19, 0x64, 0x51, 0x20, 0x18, 0x84, 0x30, 0x05, 0x7a, 0x01, 0xa6,
+	0xf9, 0xd8, 0x48, 0x9f, 0x95, 0xb3, 0x02, 0xea, 0x58, 0x55, 0x0e, 0xad, 0xea, 0x8f, 0xbd, 0xba,
+	0xaa, 0x7c, 0x63, 0xf8, 0x9c, 0xe8, 0x14, 0xcc, 0xd8, 0xc4, 0x74, 0x09, 0x33, 0x5b, 0x56, 0x6b,
+	0x07, 0x97, 0xa7, 0x4f, 0x69, 0x67, 0x72, 0x06, 0xd8, 0x64, 0x9d, 0xb0, 0x15, 0x4e, 0xb9, 0x91,
+	0xc9, 0xa5, 0x4b, 0x59, 0xfd, 0xf7, 0x69, 0x98, 0x5e, 0x91, 0x63, 0x40, 0xcf, 0x42, 0xde, 0xa2,
+	0xed, 0x7e, 0x17, 0xbb, 0xcc, 0x2b, 0x6b, 0xa7, 0xd2, 0x67, 0xf2, 0x46, 0x48, 0x40, 0xf7, 0xe1,
+	0x30, 0x76, 0x77, 0x1d, 0x4a, 0x5c, 0xde, 0x36, 0x77, 0x2d, 0xea, 0x58, 0xdb, 0x1d, 0xec, 0x95,
+	0x53, 0xa7, 0xd2, 0x67, 0x0a, 0xf5, 0xaf, 0x25, 0x8e, 0x4f, 0xa9, 0xa9, 0x36, 0x42, 0x94, 0xdb,
+	0x0a, 0xc4, 0x98, 0xc7, 0xc3, 0x44, 0x0f, 0x9d, 0x86, 0x19, 0xd2, 0x67, 0xdc, 0x9f, 0x77, 0x1d,
+	0xae, 0x29, 0x2d, 0x6c, 0x2a, 0x48, 0xda, 0x15, 0x4e, 0x42, 0xe7, 0x00, 0x29, 0x16, 0xdb, 0xa1,
+	0xb8, 0xc5, 0x08, 0x75, 0xb0, 0x57, 0xce, 0x08, 0xc6, 0x43, 0xb2, 0x67, 0x35, 0xec, 0x40, 0x0d,
+	0xc8, 0xf5, 0x3a, 0x16, 0xbb, 0x4b, 0x68, 0xb7, 0x3c, 0x25, 0x9c, 0xf9, 0x95, 0x44, 0xbb, 0x37,
+	0x94, 0x80, 0x11, 0x88, 0xa2, 0xb3, 0x70, 0xe8, 0x01, 0xa1, 0xf7, 0x1c, 0xb7, 0x1d, 0xa8, 0xdd,
+	0x13, 0x93, 0x93, 0x37, 0x4a, 0xaa, 0xc3, 0xd7, 0xba, 0x17, 0x19, 0x45, 0xcf, 0x62, 0x3b, 0x5e,
+	0x79, 0x3a, 0x3a, 0x8a, 0x0d, 0x4e, 0xaa, 0x5c, 0x86, 0x67, 0x46, 0x78, 0x05, 0x21, 0xc8, 0xb8,
+	0x56, 0x17, 0x8b, 0x45, 0x99, 0x37, 0xc4, 0x37, 0x9a, 0x87, 0xa9, 0x5d, 0xab, 0xd3, 0xc7, 0x62,
+	0x59, 0xe5, 0x0d, 0xd9, 0xd0, 0x7f, 0xa4, 0x41, 0xce, 0xb7, 0x13, 0x19, 0x00, 0x3d, 0xca, 0x03,
+	0x8a, 0x39, 0x58, 0x4e, 0x64, 0xa1, 0x5e, 0x1f, 0x7b, 0x98, 0xd5, 0x0d, 0x29, 0xbb, 0x67, 0x44,
+	0x50, 0x2a, 0x5f, 0x85, 0x9c, 0x4f, 0x9f, 0xc0, 0xac, 0x7f, 0x68, 0x90, 0x0f, 0x1d, 0x71, 0x19,
+	0xa6, 0xe4, 0x3c, 0x4a, 0x93, 0x92, 0x3d, 0xcf, 0xa7, 0x78, 0x9d, 0xd8, 0xd8, 0x90, 0x72, 0x68,
+	0x03, 0x0a, 0xd1, 0x59, 0x96, 0x0b, 0xaf, 0x3a, 0x46, 0x60, 0x29, 0x0b, 0x04, 0x56, 0x14, 0x02,
+	0x5d, 0x83, 0x9c, 0xb7, 0xd7, 0xed, 0x38, 0xee, 0x3d, 0xb9, 0xba, 0x0a, 0xf5, 0x97, 0x12, 0xe1,
+	0x9a, 0x52, 0x40, 0x80, 0x05, 0xd2, 0xfa, 0x27, 0x1a, 0xe4, 0x7c, 0x7b, 0x47, 0x7a, 0xe8, 0x32,
+	0x64, 0x0f, 0x96, 0x10, 0x94, 0x18, 0x7a, 0x1e, 0x66, 0x1d, 0x4f, 0x25, 0x6b, 0xbe, 0x3c, 0xca,
+	0x19, 0x11, 0xd3, 0x33, 0x8e, 0xd7, 0x08, 0x68, 0x22, 0xaa, 0x33, 0xba, 0x0d, 0xb3, 0xb1, 0x41,
+	0x7f, 0x29, 0x06, 0xe9, 0xaf, 0x41, 0x21, 0xe2, 0x8b, 0x91, 0x3a, 0x8e, 0x40, 0x96, 0x59, 0xb4,
+	0x8d, 0x99, 0x5a, 0x17, 0xaa, 0xa5, 0xbf, 0x0e, 0x59, 0x95, 0xdd, 0x10, 0x64, 0x76, 0x2c, 0x6f,
+	0xc7, 0x97, 0xe2, 0xdf, 0xe8, 0x04, 0x80, 0xe7, 0x7c, 0x80, 0xcd, 0xed, 0x3d, 0x26, 0xa6, 0x59,
+	0x3b, 0x93, 0x36, 0xf2, 0x9c, 0xb2, 0xcc, 0x09, 0xfa, 0xdf, 0xb2, 0x70, 0x44, 0x0e, 0x19, 0xdb,
+	0x32, 0x91, 0xdf, 0xc4, 0xcc, 0xb2, 0x2d, 0x66, 0x71, 0x7d, 0x3c, 0xfe, 0x30, 0x55, 0x78, 0xaa,
+	0x85, 0x1a, 0x50, 0xba, 0xdf, 0xc7, 0x7d, 0x6c, 0x9b, 0xc1, 0x36, 0xa1, 0x46, 0x5d, 0x19, 0x4a,
+	0xa6, 0x9b, 0x3e, 0x87, 0x51, 0x94, 0x32, 0x01, 0x01, 0x6d, 0xc0, 0x11, 0x09, 0x68, 0x7a, 0xcc,
+	0xa2, 0x2c, 0x02, 0x96, 0x4e, 0x04, 0x9b, 0x97, 0x92, 0x4d, 0x2e, 0x18, 0x22, 0xde, 0x81, 0x8a,
+	0x42, 0x6c, 0x91, 0x6e, 0xaf, 0x83, 0x59, 0xcc, 0xc4, 0x4c, 0x22, 0x6a, 0x59, 0x4a, 0xaf, 0xf8,
+	0xc2, 0x21, 0xf2, 0x3b, 0x70, 0x5c, 0xee, 0x45, 0x77, 0x31, 0x6b, 0xed, 0x0c, 0x19, 0x3c, 0x95,
+	0x0c, 0x2d, 0xc4, 0xaf, 0x70, 0xe9, 0x01, 0xa3, 0x2d, 0x38, 0x19, 0x85, 0x1e, 0x65, 0x79, 0x36,
+	0x11, 0xfe, 0xd9, 0x10, 0x7e, 0x84, 0xf5, 0xb7, 0xe1, 0x58, 0xb0, 0xf4, 0x86, 0x6c, 0x9f, 0x4e,
+	0x04, 0x3f, 0x1a, 0x08, 0x0f, 0x98, 0xfe, 0x1e, 0x9c, 0x08, 0x71, 0x47, 0x19, 0x9e, 0x4b, 0xc4,
+	0x3e, 0x1e, 0x00, 0x8c, 0xb0, 0xfb, 0xdb, 0x70, 0x42, 0x25, 0xfb, 0x7e, 0xaf, 0x43, 0x2c, 0x7b,
+	0xc8, 0xf6, 0x7c, 0x22, 0x7e, 0x45, 0x02, 0x6c, 0x09, 0xf9, 0x01, 0xf3, 0x31, 0x9c, 0x8e, 0xc3,
+	0x8f, 0x1a, 0x02, 0x24, 0xaa, 0x78, 0x2e, 0xaa, 0x62, 0x78, 0x14, 0xfa, 0xbf, 0xa6, 0x60, 0x46,
+	0x46, 0x96, 0x81, 0xbd, 0x7e, 0x87, 0xa1, 0xf5, 0x81, 0x9d, 0x58, 0xa6, 0xde, 0xb3, 0x89, 0x19,
+	0xe3, 0x56, 0xb0, 0x55, 0xc7, 0xb7, 0xed, 0xf7, 0x61, 0x3e, 0x82, 0x67, 0x06, 0x39, 0x18, 0xc6,
+	0x4c, 0xe9, 0x12, 0x57, 0x65, 0x1f, 0x03, 0x85, 0xd0, 0x8a, 0xe4, 0x21, 0x73, 0xe4, 0xc1, 0x40,
+	0xe6, 0xf8, 0xf3, 0x63, 0xe2, 0x07, 0x39, 0x74, 0xd4, 0x51, 0xe2, 0x3b, 0x70, 0x6c, 0x40, 0xc1,
+	0x5e, 0x38, 0x8e, 0xc2, 0x81, 0xc6, 0x71, 0x34, 0xae, 0x65, 0x2f, 0x18, 0xcc, 0x71, 0xc8, 0xe3,
+	0x87, 0x0e, 0x33, 0x5b, 0xc4, 0x96, 0x69, 0x7f, 0xca, 0xc8, 0x71, 0xc2, 0x0a, 0xcf, 0xbb, 0x3c,
+	0x5b, 0x32, 0x9b, 0xf0, 0x53, 0xa7, 0xf5, 0x40, 0xc4, 0xf5, 0x8c, 0x91, 0x97, 0x14, 0xc3, 0x7a,
+	0x80, 0xd6, 0x60, 0x56, 0x75, 0xab, 0x6c, 0x9f, 0x9d, 0x2c, 0xdb, 0xcf, 0x48, 0x69, 0xd9, 0x52,
+	0xca, 0x30, 0xa5, 0x42, 0xd9, 0x74, 0xa0, 0x0c, 0x53, 0x1a, 0x2a, 0xe3, 0xdd, 0x4a, 0x59, 0x6e,
+	0x72, 0x65, 0x98, 0x52, 0xa5, 0xec, 0x2e, 0xa0, 0x30, 0x58, 0xbb, 0x2a, 0xc7, 0xab, 0x08, 0x7a,
+	0x25, 0x11, 0x72, 0xf4, 0x16, 0x61, 0x1c, 0x0a, 0x98, 0x7c, 0xd2, 0x8d, 0x4c, 0x4e, 0x2b, 0xa5,
+	0xf4, 0x9f, 0x69, 0x00, 0xe1, 0x7a, 0xe5, 0x1b, 0x13, 0x3f, 0xaf, 0xf9, 0x1b, 0x13, 0xff, 0xfe,
+	0xef, 0xec, 0xe1, 0xa8, 0x02, 0xb9, 0x16, 0x71, 0x99, 0x38, 0x86, 0xcb, 0xe9, 0x0c, 0xda, 0x6a,
+	0x7f, 0xff, 0x54, 0x83, 0xcc, 0x26, 0xc5, 0x18, 0xbd, 0x09, 0x19, 0x4a, 0x88, 0x7f, 0x6d, 0x59,
+	0x18, 0xff, 0x28, 0x64, 0x08, 0x39, 0x74, 0x05, 0x72, 0xad, 0x1d, 0xa7, 0x63, 0x53, 0xec, 0xaa,
+	0x98, 0x9e, 0x04, 0x23, 0x90, 0xd5, 0xfb, 0x50, 0x1c, 0x08, 0x99, 0x91, 0xfe, 0xbb, 0x06, 0x05,
+	0x46, 0x31, 0xf6, 0x17, 0x47, 0x7a, 0x32, 0x27, 0x02, 0x97, 0x95, 0xdf, 0x37, 0x32, 0xb9, 0x54,
+	0x29, 0xad, 0xbf, 0x0e, 0xb3, 0xb1, 0x08, 0x1a, 0xa9, 0x74, 0xbf, 0x33, 0xc8, 0x39, 0x28, 0x36,
+	0x7c, 0x2d, 0x1b, 0xa4, 0xe3, 0xb4, 0xf6, 0xb8, 0xe7, 0x7b, 0xd4, 0x21, 0xd4, 0x61, 0x7b, 0x02,
+	0x62, 0xca, 0x08, 0xda, 0xfa, 0x79, 0x40, 0x32, 0x19, 0x7a, 0xe2, 0xfe, 0x34, 0x86, 0xc4, 0x47,
+	0x69, 0x98, 0x93, 0x1a, 0xb0, 0x81, 0xef, 0xf7, 0xfd, 0xf9, 0x77, 0x3d, 0x66, 0xb9, 0x2d, 0x6c,
+	0x46, 0x0e, 0x4b, 0x33, 0x3e, 0x71, 0x9d, 0x1f, 0x9a, 0x16, 0xe0, 0x90, 0x77, 0xcf, 0xe9, 0xc9,
+	0x9b, 0x9b, 0xd9, 0x21, 0xe4, 0x5e, 0x5f, 0x1e, 0x30, 0x72, 0x46, 0x91, 0x77, 0x08, 0xfd, 0x6b,
+	0x82, 0xcc, 0x03, 0xce, 0x12, 0xeb, 0xfb, 0xa0, 0xd1, 0x2d, 0xa5, 0x65, 0x0b, 0x7d, 0x0b, 0x4a,
+	0x61, 0xc0, 0xf5, 0xc4, 0x08, 0xd5, 0x66, 0x7b, 0x7e, 0xcc, 0x70, 0x0b, 0x7c, 0x69, 0x14, 0xf1,
+	0x80, 0x73, 0x31, 0xcc, 0x53, 0xe9, 0x40, 0x35, 0x32, 0xa5, 0x40, 0xa6, 0x88, 0x0b, 0x89, 0x0a,
+	0x86, 0xbd, 0x6f, 0x20, 0x3a, 0x44, 0x93, 0x2b, 0xe3, 0x46, 0x26, 0x97, 0x29, 0x4d, 0xdd, 0xc8,
+	0xe4, 0xa6, 0x4a, 0x59, 0xfd, 0x3e, 0x4c, 0xaf, 0x91, 0xb6, 0x08, 0xea, 0x30, 0x80, 0xb5, 0x83,
+	0x05, 0xf0, 0x0b, 0x30, 0xb7, 0xd3, 0xef, 0x5a, 0xae, 0x49, 0xb1, 0x65, 0x8b, 0x08, 0x4e, 0x89,
+	0x89, 0x99, 0x15, 0x54, 0x43, 0x11, 0xf5, 0x2f, 0x52, 0xfe, 0xe2, 0xc2, 0x06, 0xf6, 0x7a, 0xc4,
+	0xf5, 0x30, 0x6a, 0x40, 0x56, 0x9a, 0xab, 0x74, 0x9f, 0x4b, 0xd4, 0x1d, 0xdd, 0x82, 0x0d, 0x25,
+	0xcc, 0x97, 0x90, 0x70, 0x9f, 0x6d, 0x2a, 0x34, 0x69, 0xc0, 0x8c, 0x24, 0xaa, 0xfd, 0x7a, 0x01,
+	0xb2, 0xb2, 0xe6, 0xa1, 0x62, 0x0c, 0xf9, 0x87, 0x01, 0xda, 0x6b, 0x55, 0x9b, 0xa2, 0xc7, 0x50,
+	0x1c, 0xc8, 0x82, 0x82, 0x87, 0xe9, 0x2e, 0xa6, 0x66, 0x87, 0xb4, 0xe5, 0xdd, 0xb9, 0x50, 0xff,
+	0xfa, 0xb8, 0xe9, 0xd5, 0x1f, 0x5e, 0xb5, 0x29, 0x30, 0xd6, 0x48, 0xdb, 0x6b, 0xb8, 0x8c, 0xee,
+	0x19, 0xe0, 0x05, 0x84, 0x4a, 0x1b, 0x8a, 0x03, 0xdd, 0xa8, 0x04, 0xe9, 0x7b, 0x78, 0x4f, 0xad,
+	0x7f, 0xfe, 0x89, 0xde, 0x8c, 0x5e, 0x21, 0x0b, 0xf5, 0x33, 0x89, 0x16, 0xa8, 0x49, 0x55, 0x97,
+	0xcd, 0xc5, 0xd4, 0xab, 0x9a, 0xfe, 0x79, 0x0a, 0xca, 0xca, 0xb0, 0x5b, 0x7e, 0x45, 0x29, 0xb8,
+	0x1c, 0x6c, 0xc1, 0x94, 0xc7, 0xac, 0xb6, 0x0c, 0xba, 0xb9, 0xfa, 0xe5, 0x71, 0x87, 0x38, 0x84,
+	0xc4, 0x3d, 0xd8, 0xc6, 0x86, 0x44, 0x1b, 0x0e, 0xc1, 0xd4, 0x93, 0x84, 0xe0, 0x4b, 0x80, 0xd4,
+	0x76, 0xed, 0x31, 0x8a, 0xad, 0xae, 0x4c, 0x13, 0x69, 0x59, 0x5b, 0x90, 0x3d, 0x4d, 0xd1, 0x21,
+	0x52, 0x85, 0xe4, 0xe6, 0xfb, 0x6d, 0x94, 0x3b, 0x13, 0x70, 0x63, 0x4a, 0x43, 0x6e, 0xfd, 0x16,
+	0x4c, 0x09, 0xcb, 0x51, 0x01, 0xa6, 0xb7, 0xd6, 0xdf, 0x5a, 0xbf, 0xf5, 0xf6, 0x7a, 0xe9, 0xff,
+	0x50, 0x11, 0x0a, 0x2b, 0x4b, 0x2b, 0xd7, 0x1a, 0xe6, 0xca, 0xb5, 0xc6, 0xca, 0x5b, 0x25, 0x0d,
+	0x01, 0x64, 0xbf, 0xb1, 0xd5, 0xd8, 0x6a, 0xac, 0x96, 0x52, 0x68, 0x16, 0xf2, 0x8d, 0x3b, 0x8d,
+	0x95, 0xad, 0xcd, 0xeb, 0xeb, 0x57, 0x4b, 0x69, 0xde, 0x5c, 0xb9, 0x75, 0x73, 0x63, 0xad, 0xb1,
+	0xd9, 0x58, 0x2d, 0x65, 0xf4, 0x05, 0x98, 0x7f, 0xdb, 0x72, 0x58, 0x10, 0xfa, 0x7e, 0x9a, 0x1b,
+	0x71, 0x15, 0xd4, 0x3f, 0x4a, 0xc1, 0x91, 0xab, 0x98, 0xc5, 0xd6, 0xf4, 0x24, 0x59, 0xf1, 0xe9,
+	0xba, 0x59, 0xa8, 0xec, 0x38, 0x2e, 0x36, 0xa5, 0x4f, 0x55, 0x7e, 0x9d, 0x91, 0xc4, 0xa6, 0xa0,
+	0xc5, 0x99, 0x30, 0xa5, 0xc1, 0x6e, 0xed, 0x33, 0x61, 0x4a, 0x51, 0x15, 0x9e, 0x51, 0x4c, 0xb1,
+	0x13, 0xf2, 0x94, 0x2c, 0x41, 0xc9, 0xae, 0xf0, 0x58, 0xe1, 0xe9, 0x7f, 0x4c, 0xc1, 0xb1, 0xad,
+	0x9e, 0x6d, 0x31, 0xfc, 0x3f, 0xe2, 0x0a, 0x23, 0x40, 0x53, 0x09, 0x25, 0x7d, 0x90, 0xf4, 0x34,
+	0x63, 0x45, 0x5a, 0xfb, 0xe6, 0xfa, 0xcc, 0x53, 0xcd, 0xf5, 0xbc, 0xe8, 0x72, 0xf4, 0x8a, 0xe3,
+	0xda, 0x37, 0x1d, 0xcf, 0x73, 0xdc, 0xf6, 0x72, 0x87, 0x6c, 0x7b, 0x13, 0x79, 0xf2, 0x06, 0xcc,
+	0x6c, 0x77, 0xc8, 0xb6, 0xf2, 0xa3, 0x7f, 0xaf, 0x19, 0xdb, 0x91, 0x05, 0x2e, 0x2c, 0xbf, 0x3d,
+	0xbd, 0x0f, 0xe5, 0x61, 0x5b, 0x54, 0xee, 0x7f, 0x07, 0xe6, 0xbb, 0x92, 0x6e, 0x3e, 0x89, 0x3e,
+	0xd4, 0x0d, 0xc1, 0x7d, 0xb5, 0xff, 0xd6, 0xe0, 0xe8, 0xb2, 0xc5, 0x5a, 0x3b, 0x72, 0x51, 0x4d,
+	0xee, 0x83, 0x77, 0x21, 0x47, 0x25, 0xbf, 0x6f, 0x4f, 0x72, 0xf2, 0xdf, 0x47, 0x61, 0x55, 0xfd,
+	0x1a, 0x01, 0x62, 0xe5, 0x3d, 0x98, 0xf6, 0xad, 0x79, 0xe2, 0xcd, 0x17, 0x41, 0x46, 0xdc, 0x00,
+	0x52, 0xe2, 0x50, 0x2c, 0xbe, 0xf5, 0x2f, 0x34, 0x28, 0x0f, 0x5b, 0xa3, 0xdc, 0xfe, 0x3e, 0xe4,
+	0xa9, 0xfa, 0xf6, 0xab, 0x8e, 0xcb, 0x07, 0x18, 0x9b, 0x44, 0xa8, 0xfa, 0x1f, 0x46, 0x08, 0x5a,
+	0x79, 0x00, 0xb9, 0x40, 0xdb, 0x13, 0x8f, 0x2f, 0xdc, 0xb5, 0x53, 0x49, 0xbb, 0xb6, 0xfe, 0x5d,
+	0x38, 0x2c, 0x0c, 0xe5, 0x47, 0x8e, 0xc9, 0xe7, 0x7c, 0x09, 0xa6, 0x0f, 0xb8, 0x04, 0x7d, 0x39,
+	0xfd, 0xfb, 0x29, 0x38, 0x32, 0x68, 0x81, 0x72, 0xc4, 0x7b, 0xc3, 0x6e, 0x1f, 0x73, 0x49, 0x0d,
+	0x61, 0x8d, 0x74, 0xfa, 0x0f, 0xb5, 0xa7, 0xe9, 0xf5, 0x11, 0xab, 0x6a, 0x92, 0xf3, 0x93, 0xfe,
+	0x6b, 0x0d, 0xe6, 0xae, 0x62, 0xc6, 0xef, 0x63, 0x13, 0xcd, 0xc1, 0x35, 0x28, 0x3c, 0xc1, 0x33,
+	0x11, 0xd0, 0xf0, 0x85, 0xe8, 0x38, 0xe4, 0x7b, 0x56, 0x1b, 0x9b, 0xbc, 0x44, 0x5a, 0x4e, 0xab,
+	0x5b, 0x88, 0xd5, 0xc6, 0x4d, 0xe7, 0x03, 0x51, 0x1e, 0x10, 0x9d, 0x8c, 0xdc, 0xc3, 0xae, 0x3a,
+	0x1a, 0x08, 0xf6, 0x4d, 0x4e, 0xd0, 0x3f, 0xd6, 0xa0, 0x18, 0x58, 0xaf, 0x5c, 0xba, 0x16, 0xaf,
+	0xb3, 0x6b, 0x13, 0x5f, 0x0c, 0xa3, 0xe2, 0xe8, 0x45, 0x28, 0xba, 0xf8, 0x21, 0x33, 0x23, 0x56,
+	0xc8, 0x8b, 0xd8, 0x2c, 0x27, 0x6f, 0x04, 0x96, 0xbc, 0x21, 0xce, 0x07, 0x2b, 0x56, 0xcf, 0xda,
+	0x76, 0x3a, 0x0e, 0x73, 0xf0, 0x44, 0x4b, 0x5a, 0xff, 0x5d, 0x1a, 0x90, 0x3c, 0x64, 0x46, 0x21,
+	0x90, 0x05, 0x48, 0xee, 0x40, 0xad, 0x08, 0x55, 0x2d, 0x95, 0xe4, 0x47, 0x11, 0xb1, 0xd9, 0xc4,
+	0x4c, 0x3a, 0xd4, 0x1a, 0x24, 0xa1, 0x2e, 0x1c, 0x89, 0xd4, 0x14, 0xa3, 0x6a, 0xe4, 0x9c, 0x5e,
+	0x1a, 0xff, 0xee, 0x14, 0x53, 0x75, 0x18, 0x8f, 0x22, 0xf3, 0x22, 0xb4, 0x8d, 0x7b, 0x14, 0xb7,
+	0x2c, 0x5e, 0xf6, 0xb3, 0x7a, 0x8e, 0xb9, 0x8b, 0xa9, 0xe7, 0x10, 0x37, 0x28, 0x42, 0x47, 0xd5,
+	0xa9, 0x77, 0xd9, 0x26, 0xee, 0xde, 0xc6, 0xd4, 0x98, 0x0f, 0x25, 0x97, 0x7a, 0xce, 0x6d, 0x29,
+	0x87, 0x96, 0xa1, 0xd8, 0x21, 0x0f, 0x62, 0x50, 0x99, 0x44, 0xa8, 0xd9, 0x0e, 0x79, 0x10, 0xc1,
+	0x58, 0x85, 0xd2, 0x8e, 0xd3, 0xde, 0x89, 0x81, 0x4c, 0x25, 0x82, 0xcc, 0x71, 0x99, 0x10, 0x45,
+	0xbf, 0x02, 0x27, 0xe4, 0xa9, 0x42, 0x38, 0x5e, 0x26, 0xe1, 0xd8, 0xe0, 0x5f, 0x80, 0xb9, 0xbe,
+	0xa0, 0x9a, 0xd8, 0xe5, 0x37, 0x2d, 0x5b, 0x4c, 0x65, 0xce, 0x98, 0x95, 0xd4, 0x86, 0x24, 0xea,
+	0x7f, 0xd2, 0x60, 0x7e, 0x43, 0xdd, 0xc3, 0x63, 0xf2, 0x2d, 0xfe, 0x36, 0x26, 0xe8, 0xe1, 0xca,
+	0x5e, 0x49, 0x7e, 0x1b, 0x1b, 0x01, 0x15, 0x10, 0x0d, 0xcb, 0x6d, 0x63, 0x23, 0x02, 0x5b, 0xd9,
+	0x82, 0xd9, 0x58, 0x27, 0x7f, 0x02, 0xec, 0x3a, 0xae, 0x39, 0x50, 0x29, 0x28, 0x74, 0x1d, 0xd7,
+	0xe7, 0x13, 0x2c, 0xd6, 0xc3, 0x90, 0x25, 0xa5, 0x58, 0xac, 0x87, 0x3e, 0x8b, 0xfe, 0x83, 0x29,
+	0x38, 0x34, 0xb4, 0x20, 0xd1, 0x1d, 0x28, 0xca, 0x0c, 0x62, 0xde, 0xed, 0xbb, 0xc2, 0x77, 0x62,
+	0x58, 0x73, 0xf5, 0xda, 0x98, 0xa9, 0xe4, 0x8a, 0x12, 0x33, 0xe6, 0xec, 0x58, 0x1b, 0x7d, 0xac,
+	0xc1, 0x29, 0x75, 0x32, 0x94, 0x21, 0xa4, 0x3c, 0x3f, 0x62, 0x89, 0xbf, 0x39, 0xe6, 0x61, 0x71,
+	0x9f, 0x69, 0x35, 0x4e, 0x58, 0x8f, 0x9d, 0xf5, 0x3e, 0x1c, 0x57, 0xc7, 0x48, 0xe5, 0x8b, 0xb8,
+	0x0d, 0x72, 0xdd, 0x5f, 0x3c, 0xd0, 0x34, 0x1a, 0xc7, 0x04, 0xf2, 0xc8, 0xc5, 0xb2, 0x08, 0x15,
+	0x3e, 0x27, 0xdb, 0x7c, 0x67, 0x32, 0x19, 0x61, 0x56, 0xc7, 0x8c, 0xbc, 0x4b, 0x65, 0xc4, 0xbb,
+	0xd4, 0x91, 0xae, 0xf5, 0x50, 0x6c, 0x5d, 0x9b, 0xbc, 0xbf, 0xe9, 0x3f, 0x52, 0xa1, 0xcf, 0x34,
+	0x78, 0x4e, 0x95, 0x83, 0x4d, 0x6b, 0xdb, 0x23, 0x9d, 0x3e, 0xc3, 0xe2, 0x01, 0x98, 0xdf, 0xd4,
+	0x2c, 0x86, 0xdb, 0x7b, 0x22, 0x3c, 0xe6, 0xea, 0xeb, 0x93, 0x27, 0x21, 0xff, 0x09, 0x72, 0x49,
+	0xe1, 0xf2, 0x47, 0xe4, 0xa6, 0x42, 0x35, 0x8e, 0x7b, 0xfb, 0x77, 0xea, 0x57, 0xe1, 0xf8, 0x63,
+	0x64, 0xe3, 0xf7, 0xc2, 0x39, 0x80, 0xd5, 0xeb, 0xcd, 0xa5, 0xb5, 0xb5, 0x5b, 0x6f, 0x37, 0x56,
+	0x4b, 0x1a, 0xef, 0xf4, 0x1b, 0x29, 0xfd, 0xb3, 0x14, 0x1c, 0x1e, 0x99, 0xb5, 0x46, 0xaf, 0x47,
+	0xed, 0x69, 0xac, 0xc7, 0xd3, 0x30, 0xc3, 0xd9, 0x83, 0xc8, 0x97, 0x85, 0x8f, 0x02, 0xa7, 0xa9,
+	0xb8, 0x47, 0x8f, 0xe0, 0x64, 0xa4, 0x80, 0xf5, 0xf4, 0x17, 0x4b, 0xf8, 0x78, 0x34, 0xaa, 0x5b,
+	0xbf, 0x09, 0x85, 0x4d, 0x42, 0x3a, 0xab, 0x98, 0x59, 0x4e, 0x47, 0x94, 0xed, 0x19, 0x21, 0x9d,
+	0xe8, 0x9e, 0x95, 0xe3, 0x04, 0xb1, 0xfd, 0x9f, 0x86, 0x19, 0xd1, 0xe9, 0x27, 0x4b, 0xb9, 0x27,
+	0x16, 0x38, 0xcd, 0xcf, 0x86, 0xff, 0xd4, 0xa0, 0xa8, 0xf6, 0xc0, 0xa0, 0x88, 0x71, 0x4b, 0x89,
+	0xd9, 0x52, 0x87, 0xda, 0xc9, 0x92, 0x5f, 0xad, 0x23, 0x76, 0x49, 0x25, 0x11, 0x23, 0x55, 0x90,
+	0x3b, 0xb6, 0x32, 0x22, 0x27, 0x09, 0xd7, 0x6d, 0x5e, 0x5f, 0x10, 0xda, 0x1c, 0x77, 0x97, 0xb4,
+	0x2c, 0x9f, 0x4b, 0x55, 0x23, 0x78, 0xcf, 0xf5, 0xa0, 0xe3, 0xba, 0x8d, 0x16, 0xe1, 0x58, 0x8b,
+	0x50, 0x8a, 0x3b, 0x62, 0x67, 0x0a, 0x65, 0x3c, 0x2e, 0x24, 0x4f, 0x1e, 0x47, 0x43, 0x86, 0x50,
+	0xd4, 0xbb, 0x6e, 0x2f, 0x6c, 0xc2, 0x5c, 0x7c, 0xfa, 0xe3, 0x8b, 0x11, 0x20, 0xdb, 0xbc, 0xb6,
+	0x54, 0xbf, 0x78, 0xa9, 0xa4, 0xa1, 0x1c, 0x64, 0x9a, 0xd7, 0x96, 0x5e, 0x2e, 0xa5, 0xd0, 0x34,
+	0xa4, 0x6f, 0xae, 0x5e, 0x2c, 0xa5, 0x55, 0xf7, 0xc5, 0x97, 0xeb, 0xa5, 0x2c, 0xff, 0x5e, 0x5e,
+	0x5b, 0x7a, 0xab, 0x71, 0xa1, 0x94, 0xaf, 0xff, 0x2a, 0x05, 0xf9, 0x60, 0x99, 0xa2, 0x4f, 0x35,
+	0x98, 0x96, 0x2d, 0x8c, 0x6a, 0xe3, 0x17, 0xb8, 0xc4, 0x04, 0x54, 0x4e, 0xf8, 0x47, 0xc0, 0xc8,
+	0xdf, 0x96, 0xaa, 0x41, 0x69, 0x48, 0x7f, 0xf9, 0x7b, 0x7f, 0xfe, 0xfb, 0x67, 0xa9, 0xb3, 0xfa,
+	0x8b, 0xfc, 0x8f, 0x55, 0x1f, 0xc6, 0x8e, 0x2b, 0x6f, 0x2c, 0x2c, 0x3c, 0xaa, 0x49, 0x97, 0x7a,
+	0x8b, 0x52, 0x05, 0x5e, 0xd4, 0x16, 0xce, 0x6b, 0xe8, 0xc7, 0x1a, 0xcc, 0xc6, 0x0a, 0x28, 0x28,
+	0x79, 0x5d, 0x8e, 0x2a, 0xb8, 0x4c, 0x66, 0x9c, 0xb0, 0x29, 0xfc, 0xc3, 0x55, 0x6d, 0x61, 0xe1,
+	0xd1, 0xe2, 0x83, 0x28, 0xaa, 0x30, 0xae, 0xfe, 0x97, 0x34, 0x14, 0x22, 0x59, 0x1b, 0xfd, 0x55,
+	0x9e, 0x14, 0x63, 0xef, 0x82, 0xc9, 0xaf, 0x30, 0xa3, 0x4b, 0x3e, 0x95, 0xc9, 0xaa, 0x0b, 0xfa,
+	0xbb, 0x62, 0x00, 0xb7, 0xd1, 0xe6, 0x63, 0xbd, 0x2b, 0x99, 0xbd, 0xda, 0x87, 0xb1, 0xea, 0x48,
+	0x95, 0xff, 0xa7, 0xe0, 0xd1, 0x20, 0x31, 0x4c, 0xe4, 0x8f, 0xd0, 0xe7, 0x1a, 0xa0, 0xe1, 0x92,
+	0x0c, 0x5a, 0x4c, 0xb4, 0x71, 0xdf, 0x3a, 0xce, 0xa4, 0xe3, 0xbb, 0x27, 0xc6, 0x87, 0x2b, 0x5f,
+	0xca, 0xf8, 0x16, 0xe3, 0xf5, 0x9d, 0xfa, 0x4f, 0xb2, 0x70, 0x6c, 0x45, 0x3e, 0x28, 0x2d, 0xd9,
+	0x36, 0xc5, 0x9e, 0xc7, 0x93, 0x67, 0x93, 0x11, 0xca, 0x6b, 0x83, 0xbf, 0xd1, 0xa0, 0x34, 0x58,
+	0xc7, 0x40, 0xaf, 0x8e, 0xf1, 0x5f, 0x9d, 0x91, 0x65, 0x98, 0xca, 0x6b, 0x07, 0x90, 0x94, 0xd7,
+	0x10, 0xfd, 0x82, 0x70, 0xca, 0x39, 0xfd, 0xcc, 0x3e, 0x4e, 0xe1, 0x95, 0x14, 0x6f, 0xf1, 0x6e,
+	0x28, 0xbe, 0xa8, 0x2d, 0x08, 0xf3, 0x07, 0x6f, 0xf0, 0x63, 0x98, 0xbf, 0x4f, 0x41, 0xa3, 0xf2,
+	0xda, 0x01, 0x24, 0x27, 0x32, 0x7f, 0x3b, 0x14, 0xe7, 0xe6, 0xff, 0x52, 0x83, 0xb9, 0xf8, 0x4d,
+	0x18, 0x5d, 0x9a, 0xf8, 0xea, 0x2c, 0x4d, 0x7f, 0xe5, 0x80, 0x57, 0xee, 0xc4, 0x54, 0x16, 0x31,
+	0x9c, 0x0b, 0x73, 0xb3, 0xff, 0xa0, 0xc1, 0xb4, 0xba, 0x45, 0x8e, 0x91, 0x59, 0xe3, 0xb7, 0xe5,
+	0xca, 0xf9, 0xf1, 0x05, 0x94, 0x85, 0x77, 0x84, 0x85, 0x06, 0xda, 0x78, 0x9c, 0x85, 0xb5, 0x0f,
+	0x23, 0xd7, 0x6b, 0x3f, 0x48, 0xa2, 0xa4, 0x68, 0x88, 0xb4, 0xa5, 0x86, 0xf3, 0x5a, 0xfd, 0xb7,
+	0x1a, 0xcc, 0xc4, 0x0e, 0x34, 0xbf, 0x90, 0x79, 0x2f, 0x46, 0x1b, 0x2b, 0xef, 0x8d, 0xb8, 0xca,
+	0x56, 0x92, 0x4b, 0x9f, 0xc3, 0x77, 0x58, 0xfd, 0xac, 0x18, 0xee, 0x0b, 0xe8, 0xf9, 0x7d, 0x86,
+	0x1b, 0x3d, 0xdf, 0x2c, 0x53, 0x48, 0xfa, 0xff, 0xee, 0xf2, 0xbc, 0x21, 0x88, 0xe1, 0x8b, 0x1d,
+	0x25, 0x8c, 0x6c, 0x68, 0xdf, 0x2c, 0x4a, 0xe6, 0x80, 0xf7, 0xa7, 0xa9, 0xb4, 0xd1, 0xb8, 0xf3,
+	0xf3, 0xd4, 0xc9, 0x65, 0x01, 0xb8, 0x2c, 0x00, 0xa5, 0x6c, 0x78, 0x65, 0xad, 0xde, 0xae, 0x6f,
+	0x67, 0xc5, 0x7f, 0x4c, 0x2e, 0xfc, 0x67, 0x00, 0x12, 0xf6, 0xbc, 0x14, 0x72, 0x2c, 0x00, 0x00,
 }
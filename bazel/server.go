@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/tap"
 
 	"github.com/twitter/scoot/common/grpchelpers"
+	"github.com/twitter/scoot/common/stats"
 )
 
 // Wrapping interface for gRPC servers to work seamlessly with magicbag semantics
@@ -48,9 +49,10 @@ func (c *GRPCConfig) NewListener() (net.Listener, error) {
 	return listener, nil
 }
 
-// Creates a new *grpc.Server configured with ServerOptions based on the GRPCConfig fields
-func (c *GRPCConfig) NewGRPCServer() *grpc.Server {
-	var serverOpts []grpc.ServerOption
+// Creates a new *grpc.Server configured with ServerOptions based on the GRPCConfig fields,
+// recording per-method request stats to stat
+func (c *GRPCConfig) NewGRPCServer(stat stats.StatsReceiver) *grpc.Server {
+	serverOpts := grpchelpers.StatsServerOptions(stat)
 
 	// 0 is a valid Limiter that rejects all requests, but that's not useful, so we interpret 0 as unlimited
 	if c.RateLimitPerSec > 0 && c.BurstLimitPerSec > 0 {
@@ -4,15 +4,14 @@ package bazel
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/snapshot/snapid"
 )
 
 // Generate a SnapshotID based on digest sha and size
 func SnapshotID(sha string, size int64) string {
-	return fmt.Sprintf("%s-%s-%d", SnapshotIDPrefix, sha, size)
+	return snapid.Bazel(sha, size).String()
 }
 
 // Generate a SnapshotID directly from a Digest
@@ -20,48 +19,41 @@ func SnapshotIDFromDigest(d *remoteexecution.Digest) string {
 	if d == nil {
 		return ""
 	}
-	return SnapshotID(d.GetHash(), d.GetSizeBytes())
+	return snapid.FromDigest(d).String()
 }
 
 // Checks that ID is well formed
 func ValidateID(id string) error {
-	sha, size, err := GetShaAndSize(id)
-	if err != nil {
-		return err
-	}
-	if !IsValidDigest(sha, size) {
-		return fmt.Errorf("Error: Invalid digest. SHA: %s, size: %d", sha, size)
-	}
-	return nil
+	_, err := parseID(id)
+	return err
 }
 
 // Get sha and size components from valid bazel SnapshotID
 func GetShaAndSize(id string) (string, int64, error) {
-	s, err := splitID(id)
-	if err != nil {
-		return "", 0, err
-	}
-	size, err := strconv.ParseInt(s[2], 10, 64)
+	s, err := parseID(id)
 	if err != nil {
 		return "", 0, err
 	}
-	return s[1], size, nil
+	return s.SHA, s.Size, nil
 }
 
 // Get a remoteexecution Digest from SnapshotID
 func DigestFromSnapshotID(id string) (*remoteexecution.Digest, error) {
-	sha, size, err := GetShaAndSize(id)
+	s, err := parseID(id)
 	if err != nil {
 		return nil, err
 	}
-	return &remoteexecution.Digest{Hash: sha, SizeBytes: size}, nil
+	return s.Digest()
 }
 
-// Split valid bazel SnapshotID into prefix, sha and size components as strings
-func splitID(id string) ([]string, error) {
-	s := strings.Split(id, "-")
-	if len(s) < 3 || s[0] != SnapshotIDPrefix {
-		return nil, fmt.Errorf("%s %s", InvalidIDMsg, id)
+// Parse a valid bazel SnapshotID
+func parseID(id string) (snapid.ID, error) {
+	s, err := snapid.Parse(id)
+	if err != nil {
+		return snapid.ID{}, fmt.Errorf("%s %s: %v", InvalidIDMsg, id, err)
+	}
+	if !s.IsBazel() {
+		return snapid.ID{}, fmt.Errorf("%s %s", InvalidIDMsg, id)
 	}
 	return s, nil
 }
@@ -24,23 +24,20 @@ func TestGetShaAndSize(t *testing.T) {
 	}
 }
 
-func TestSplitIdValid(t *testing.T) {
+func TestParseIdValid(t *testing.T) {
 	id := SnapshotID(EmptySha, size5)
-	result, err := splitID(id)
+	result, err := parseID(id)
 	if err != nil {
 		t.Fatal(err)
 	}
-	expected := []string{SnapshotIDPrefix, EmptySha, "5"}
-	for idx, _ := range result {
-		if result[idx] != expected[idx] {
-			t.Fatalf("Expected %v, received %v", expected, result)
-		}
+	if result.SHA != EmptySha || result.Size != size5 {
+		t.Fatalf("Expected %s %d, received %v", EmptySha, size5, result)
 	}
 }
 
-func TestSplitIdInvalid(t *testing.T) {
+func TestParseIdInvalid(t *testing.T) {
 	id := fmt.Sprintf("bs-%s-%d", EmptySha, size5)
-	_, err := splitID(id)
+	_, err := parseID(id)
 	if err == nil || !strings.Contains(err.Error(), InvalidIDMsg) {
 		t.Fatalf("Expected error to contain \"%s\", received \"%v\"", InvalidIDMsg, err)
 	}
@@ -8,20 +8,25 @@ package main
 // Supports subcommands (--help for usage):
 // * remoteexecution.Command protobuf data structure insertion to a CAS
 // * longrunning.GetOperation polling of operation/scootjob by name and pretty print of result
+// * end-to-end run of a local command and input dir: upload, Execute, wait and download outputs
 
 import (
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
 
 	"github.com/twitter/scoot/bazel"
 	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/bazel/client"
 	"github.com/twitter/scoot/bazel/execution"
 	"github.com/twitter/scoot/common"
 	"github.com/twitter/scoot/common/dialer"
@@ -34,20 +39,18 @@ var uploadCmdStr string = "upload_command"
 var uploadActionStr string = "upload_action"
 var execCmdStr string = "execute"
 var getOpCmdStr string = "get_operation"
+var runCmdStr string = "run"
 var supportedCommands map[string]bool = map[string]bool{
 	uploadCmdStr:    true,
 	uploadActionStr: true,
 	execCmdStr:      true,
 	getOpCmdStr:     true,
+	runCmdStr:       true,
 }
 
 func main() {
 	log.AddHook(hooks.NewContextHook())
 
-	// TODO additional commands
-	// - upload Directory (tbd)
-	// - download outputs, stdout/err
-
 	// Subcommand flag definitions
 
 	// Upload Command
@@ -80,6 +83,22 @@ func main() {
 	getName := getCommand.String("name", "", "Operation name to query")
 	getJson := getCommand.Bool("json", false, "Print operation as JSON")
 
+	// Run
+	runCommand := flag.NewFlagSet(runCmdStr, flag.ExitOnError)
+	runCasAddr := runCommand.String("cas_addr", scootapi.DefaultApiBundlestore_GRPC, "'host:port' of grpc CAS server")
+	runExecAddr := runCommand.String("grpc_addr", scootapi.DefaultSched_GRPC, "'host:port' of grpc Exec server")
+	runInputRoot := runCommand.String("input_root", ".", "Local dir uploaded as the command's input root")
+	runOutputDir := runCommand.String("output_dir", ".", "Local dir outputs are downloaded to")
+	runEnv := runCommand.String("env", "", "comma-separated command environment variables, i.e. \"key1=val1,key2=val2\"")
+	runOutputFiles := runCommand.String("output_files", "", "Output files to download as comma-separated list: 'file1,dir/file2'")
+	runOutputDirs := runCommand.String("output_dirs", "", "Output dirs to download as comma-separated list: 'dir'")
+	runPlatformProps := runCommand.String("platform_props", "", "comma-separated command platoform properties, i.e. \"key1=val1,key2=val2\"")
+	runTimeout := runCommand.Duration("timeout", 0, "Action execution timeout, zero for the server default")
+	runNoCache := runCommand.Bool("no_cache", false, "Flag to prevent result caching")
+	runSkipCache := runCommand.Bool("skip_cache", false, "Skip checking for cached results")
+	runPoll := runCommand.Duration("poll_interval", time.Second, "How often the operation is polled until it's done")
+	runLabels := runCommand.String("labels", "", "comma-separated labels added to the operation's Scoot job, i.e. \"pipeline=nightly,build=1234\"")
+
 	// Parse input flags
 	if len(os.Args) < 2 {
 		printSupported()
@@ -94,6 +113,8 @@ func main() {
 		execCommand.Parse(os.Args[2:])
 	case getOpCmdStr:
 		getCommand.Parse(os.Args[2:])
+	case runCmdStr:
+		runCommand.Parse(os.Args[2:])
 	default:
 		printSupported()
 		os.Exit(1)
@@ -121,49 +142,21 @@ func main() {
 			log.Fatalf("name required for %s", getOpCmdStr)
 		}
 		getOperation(*getAddr, *getName, *getJson)
+	} else if runCommand.Parsed() {
+		runArgv := runCommand.Args()
+		if len(runArgv) == 0 {
+			log.Fatalf("Argv required for %s - will interpret all non-flag arguments as Argv", runCmdStr)
+		}
+		cmd := makeBzCommand(runArgv, *runEnv, *runOutputFiles, *runOutputDirs, *runPlatformProps)
+		os.Exit(run(cmd, *runCasAddr, *runExecAddr, *runInputRoot, *runOutputDir, *runTimeout, *runNoCache, *runSkipCache, *runPoll,
+			common.SplitCommaSepToMap(*runLabels)))
 	} else {
 		log.Fatal("No expected commands parsed")
 	}
 }
 
 func uploadBzCommand(cmdArgs []string, casAddr, env, outputFilesStr, outputDirsStr, platformProps string, uploadJson bool) {
-	envMap := common.SplitCommaSepToMap(env)
-	platMap := common.SplitCommaSepToMap(platformProps)
-	log.Infof("Using argv: %q env: %s platform properties: %s", cmdArgs, envMap, platMap)
-
-	// create Command struct from inputs
-	cmdEnvVars := []*remoteexecution.Command_EnvironmentVariable{}
-	for k, v := range envMap {
-		cmdEnvVars = append(cmdEnvVars, &remoteexecution.Command_EnvironmentVariable{Name: k, Value: v})
-	}
-	cmdPlatformProperties := []*remoteexecution.Platform_Property{}
-	for k, v := range platMap {
-		cmdPlatformProperties = append(cmdPlatformProperties, &remoteexecution.Platform_Property{Name: k, Value: v})
-	}
-	cmdPlatform := &remoteexecution.Platform{Properties: cmdPlatformProperties}
-
-	outputFiles := []string{}
-	outputDirs := []string{}
-	for _, f := range strings.Split(outputFilesStr, ",") {
-		if f == "" {
-			continue
-		}
-		outputFiles = append(outputFiles, f)
-	}
-	for _, d := range strings.Split(outputDirsStr, ",") {
-		if d == "" {
-			continue
-		}
-		outputDirs = append(outputDirs, d)
-	}
-
-	cmd := &remoteexecution.Command{
-		Arguments:            cmdArgs,
-		EnvironmentVariables: cmdEnvVars,
-		OutputFiles:          outputFiles,
-		OutputDirectories:    outputDirs,
-		Platform:             cmdPlatform,
-	}
+	cmd := makeBzCommand(cmdArgs, env, outputFilesStr, outputDirsStr, platformProps)
 
 	// serialize and get hash/size
 	bytes, err := proto.Marshal(cmd)
@@ -194,6 +187,29 @@ func uploadBzCommand(cmdArgs []string, casAddr, env, outputFilesStr, outputDirsS
 	}
 }
 
+func makeBzCommand(cmdArgs []string, env, outputFilesStr, outputDirsStr, platformProps string) *remoteexecution.Command {
+	envMap := common.SplitCommaSepToMap(env)
+	platMap := common.SplitCommaSepToMap(platformProps)
+	log.Infof("Using argv: %q env: %s platform properties: %s", cmdArgs, envMap, platMap)
+
+	outputFiles := []string{}
+	outputDirs := []string{}
+	for _, f := range strings.Split(outputFilesStr, ",") {
+		if f == "" {
+			continue
+		}
+		outputFiles = append(outputFiles, f)
+	}
+	for _, d := range strings.Split(outputDirsStr, ",") {
+		if d == "" {
+			continue
+		}
+		outputDirs = append(outputDirs, d)
+	}
+
+	return client.NewCommand(cmdArgs, envMap, outputFiles, outputDirs, platMap)
+}
+
 func uploadBzAction(casAddr, commandDigestStr, rootDigestStr string, noCache, actionJson bool) {
 	commandDigest, err := bazel.DigestFromString(commandDigestStr)
 	if err != nil {
@@ -278,6 +294,75 @@ func getOperation(execAddr, opName string, getJson bool) {
 	}
 }
 
+// Runs cmd remotely with the contents of inputRoot, downloads its outputs to outputDir,
+// prints its stdout and stderr, and returns its exit code. The operation is labeled with labels.
+func run(cmd *remoteexecution.Command, casAddr, execAddr, inputRoot, outputDir string,
+	timeout time.Duration, noCache, skipCache bool, poll time.Duration, labels map[string]string) int {
+	ctx := context.Background()
+	casClient := client.NewClient(dialer.NewConstantResolver(casAddr), client.DefaultRetryPolicy)
+	defer casClient.Close()
+	execClient := client.NewClient(dialer.NewConstantResolver(execAddr), client.DefaultRetryPolicy)
+	defer execClient.Close()
+
+	// Upload the input root, command and action together, so blobs already in the CAS are found in one pass
+	uploader := casClient.NewUploader()
+	rootDigest, err := uploader.AddDir(inputRoot)
+	if err != nil {
+		log.Fatalf("Error reading input root %s: %s", inputRoot, err)
+	}
+	cmdDigest, err := uploader.AddMessage(cmd)
+	if err != nil {
+		log.Fatalf("Error serializing command: %s", err)
+	}
+	action := &remoteexecution.Action{
+		CommandDigest:   cmdDigest,
+		InputRootDigest: rootDigest,
+		DoNotCache:      noCache,
+	}
+	if timeout > 0 {
+		action.Timeout = scootproto.GetDurationFromMs(int64(timeout / time.Millisecond))
+	}
+	actionDigest, err := uploader.AddMessage(action)
+	if err != nil {
+		log.Fatalf("Error serializing action: %s", err)
+	}
+	if err := uploader.Upload(ctx); err != nil {
+		log.Fatalf("Error uploading action: %s", err)
+	}
+	log.Infof("Executing action %s with input root %s", bazel.DigestToStr(actionDigest), bazel.DigestToStr(rootDigest))
+
+	op, err := execClient.Execute(
+		bazel.WithLabels(ctx, labels), &remoteexecution.ExecuteRequest{ActionDigest: actionDigest, SkipCacheLookup: skipCache})
+	if err != nil {
+		log.Fatalf("Error making Execute request: %s", err)
+	}
+	name := op.Name()
+	log.Infof("Waiting for operation %s", name)
+	if op, err = execClient.WaitOperation(ctx, name, poll); err != nil {
+		log.Fatalf("Error waiting for operation %s: %s", name, err)
+	}
+	log.Info(execution.ExecuteOperationToStr(op.Operation))
+	if opErr := op.Operation.GetError(); opErr != nil {
+		log.Fatalf("Operation failed: %s", opErr.GetMessage())
+	}
+	if st := op.Response.GetStatus(); st.GetCode() != 0 {
+		log.Fatalf("Execution failed: %s", st.GetMessage())
+	}
+
+	ar := op.Response.GetResult()
+	stdout, stderr, err := casClient.DownloadOutputs(ctx, ar, outputDir)
+	if err != nil {
+		log.Fatalf("Error downloading outputs: %s", err)
+	}
+	os.Stdout.Write(stdout)
+	os.Stderr.Write(stderr)
+	if abs, err := filepath.Abs(outputDir); err == nil {
+		log.Infof("Downloaded %d output files and %d output dirs to %s",
+			len(ar.GetOutputFiles()), len(ar.GetOutputDirectories()), abs)
+	}
+	return int(ar.GetExitCode())
+}
+
 func printSupported() {
 	cmds := make([]string, 0, len(supportedCommands))
 	for k := range supportedCommands {
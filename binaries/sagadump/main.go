@@ -0,0 +1,137 @@
+package main
+
+// Command line tool to dump the message timeline of sagas, for debugging stuck or failed jobs.
+// Sagas are read directly from a file or BoltDB SagaLog, or for other SagaLogs (ex: in memory)
+// from a running scheduler's UI endpoint.
+// Not part of production deployment
+//
+// Usage:
+//   sagadump -sagalog_dir=/tmp/sagalog <sagaId>...
+//   sagadump -bolt_file=/tmp/sagalog.db -json <sagaId>...
+//   sagadump -sched_addr=localhost:9091 <sagaId>...
+//   sagadump -sagalog_dir=/tmp/sagalog -active
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/scootapi/server/ui"
+)
+
+func main() {
+	log.AddHook(hooks.NewContextHook())
+
+	sagaDir := flag.String("sagalog_dir", "", "Directory of a file SagaLog")
+	boltFile := flag.String("bolt_file", "", "Database file of a BoltDB SagaLog, which can't be open in a running scheduler")
+	schedAddr := flag.String("sched_addr", "", "'host:port' of a scheduler's HTTP server, to read sagas from its SagaLog")
+	active := flag.Bool("active", false, "List active sagas instead of dumping sagas")
+	asJson := flag.Bool("json", false, "Print timelines as JSON, including message data")
+	flag.Parse()
+
+	var dump func(sagaId string) (*saga.Timeline, error)
+	var slog saga.SagaLog
+	var err error
+	switch {
+	case *sagaDir != "":
+		// MakeFileSagaLog creates missing directories, don't let a typo create an empty log
+		if _, err := os.Stat(*sagaDir); err != nil {
+			log.Fatalf("Error opening SagaLog directory: %v", err)
+		}
+		slog, err = sagalogs.MakeFileSagaLog(*sagaDir)
+	case *boltFile != "":
+		// MakeBoltSagaLog creates missing files, don't let a typo create an empty log
+		if _, err := os.Stat(*boltFile); err != nil {
+			log.Fatalf("Error opening SagaLog file: %v", err)
+		}
+		slog, err = sagalogs.MakeBoltSagaLog(*boltFile, 0)
+	case *schedAddr != "":
+		if *active {
+			log.Fatal("-active isn't supported with -sched_addr, see the scheduler UI for jobs in progress")
+		}
+		dump = func(sagaId string) (*saga.Timeline, error) {
+			return getTimeline(*schedAddr, sagaId)
+		}
+	default:
+		log.Fatal("One of -sagalog_dir, -bolt_file or -sched_addr is required")
+	}
+	if err != nil {
+		log.Fatalf("Error opening SagaLog: %v", err)
+	}
+
+	if slog != nil {
+		sc := saga.MakeSagaCoordinator(slog)
+		if *active {
+			ids, err := sc.Startup()
+			if err != nil {
+				log.Fatalf("Error listing active sagas: %v", err)
+			}
+			for _, id := range ids {
+				fmt.Println(id)
+			}
+			return
+		}
+		dump = func(sagaId string) (*saga.Timeline, error) {
+			return sc.GetTimeline(sagaId, *asJson)
+		}
+	}
+
+	if flag.NArg() == 0 {
+		log.Fatal("At least one sagaId is required")
+	}
+	failed := false
+	for _, id := range flag.Args() {
+		tl, err := dump(id)
+		if err != nil {
+			log.Errorf("Error reading saga %s: %v", id, err)
+			failed = true
+			continue
+		}
+		if *asJson {
+			b, err := json.MarshalIndent(tl, "", "  ")
+			if err != nil {
+				log.Fatalf("Error serializing saga %s as JSON: %v", id, err)
+			}
+			fmt.Println(string(b))
+		} else {
+			tl.WriteText(os.Stdout)
+			fmt.Println()
+		}
+		if tl.State == saga.TimelineNotFound {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// Gets a saga's Timeline, with message data, from the scheduler UI at addr.
+func getTimeline(addr, sagaId string) (*saga.Timeline, error) {
+	resp, err := http.Get(fmt.Sprintf("http://%s%sapi/saga/%s", addr, ui.HttpPath, sagaId))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return saga.MakeTimeline(sagaId, nil, false), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	tl := &saga.Timeline{}
+	if err := json.NewDecoder(resp.Body).Decode(tl); err != nil {
+		return nil, err
+	}
+	return tl, nil
+}
@@ -47,10 +47,24 @@ func main() {
 type injector struct {
 	// URL to bundlestore server
 	storeURL string
+	// Bundles larger than this are uploaded in resumable chunks, zero to upload them in one request.
+	uploadChunkSize int64
+	// URLs of bundlestores to fall back to in order when storeURL fails
+	fallbackURLs []string
+	// How failing bundlestores are taken out of rotation
+	failover store.FailoverConfig
 }
 
 func (i *injector) RegisterFlags(rootCmd *cobra.Command) {
 	rootCmd.PersistentFlags().StringVar(&i.storeURL, "bundlestore_url", "", "bundlestore URL")
+	rootCmd.PersistentFlags().Int64Var(&i.uploadChunkSize, "bundlestore_upload_chunk_size", 0,
+		"upload bundles larger than this many bytes in resumable chunks of this size, 0 to upload in one request")
+	rootCmd.PersistentFlags().StringSliceVar(&i.fallbackURLs, "bundlestore_fallback_urls", nil,
+		"bundlestore URLs to fall back to in order when bundlestore_url fails")
+	rootCmd.PersistentFlags().IntVar(&i.failover.MaxFailures, "bundlestore_failover_max_failures",
+		store.DefaultFailoverMaxFailures, "consecutive failures after which a bundlestore is tried last")
+	rootCmd.PersistentFlags().DurationVar(&i.failover.Cooldown, "bundlestore_failover_cooldown",
+		store.DefaultFailoverCooldown, "how long a failing bundlestore is tried last")
 }
 
 func (i *injector) Inject() (snapshot.DB, error) {
@@ -86,10 +100,16 @@ func (i *injector) Inject() (snapshot.DB, error) {
 		return nil, err
 	}
 
-	store := store.MakeHTTPStore(url)
+	cfg := &gitdb.BundlestoreConfig{
+		Store:           store.MakeHTTPStore(url),
+		Failover:        i.failover,
+		UploadChunkSize: i.uploadChunkSize,
+	}
+	for _, u := range i.fallbackURLs {
+		cfg.Fallbacks = append(cfg.Fallbacks, store.MakeHTTPStore(u))
+	}
 	return gitdb.MakeDBFromRepo(
-			dataRepo, nil, tempDir, nil, nil,
-			&gitdb.BundlestoreConfig{Store: store},
+			dataRepo, nil, tempDir, nil, nil, cfg,
 			gitdb.AutoUploadBundlestore,
 			stats.NilStatsReceiver()),
 		nil
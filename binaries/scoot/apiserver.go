@@ -0,0 +1,336 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/local"
+	"github.com/twitter/scoot/common/endpoints"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/config/jsonconfig"
+	"github.com/twitter/scoot/config/scootconfig"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner/runlogs"
+	"github.com/twitter/scoot/scootapi"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+	"github.com/twitter/scoot/snapshot/git/gitdb"
+	"github.com/twitter/scoot/snapshot/snapshots"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+// Which of the apiserver's servers a daemon runs.
+type apiServerRole string
+
+const (
+	apiServerAll         apiServerRole = "apiserver"
+	apiServerBundlestore apiServerRole = "bundlestore"
+	apiServerCAS         apiServerRole = "cas"
+)
+
+func runApiServer(args []string) {
+	runApiServerRole(apiServerAll, args)
+}
+
+// Serves bundles, snapshot views and run logs over HTTP, without a CAS.
+func runBundlestore(args []string) {
+	runApiServerRole(apiServerBundlestore, args)
+}
+
+// Serves the CAS and ActionCache over GRPC, with only stats and the CAS mode switch served over HTTP.
+func runCAS(args []string) {
+	runApiServerRole(apiServerCAS, args)
+}
+
+func runApiServerRole(role apiServerRole, args []string) {
+	name := string(role)
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	daemon := newDaemonFlags(flags, name, "{}")
+	httpAddr := flags.String("http_addr", scootapi.DefaultApiBundlestore_HTTP, "'host:port' addr to serve http on")
+	grpcAddr := flags.String("grpc_addr", scootapi.DefaultApiBundlestore_GRPC, "Bind address for grpc server")
+	cacheSize := flags.Int64("cache_size", 2*1024*1024*1024, "In-memory bundle cache size in bytes")
+	grpcConns := flags.Int("max_grpc_conn", cas.MaxSimultaneousConnections, "max grpc listener connections")
+	grpcRate := flags.Int("max_grpc_rps", cas.MaxRequestsPerSecond, "max grpc incoming requests per second")
+	grpcBurst := flags.Int("max_grpc_rps_burst", cas.MaxRequestsBurst, "max grpc incoming requests burst")
+	grpcStreams := flags.Int("max_grpc_streams", cas.MaxConcurrentStreams, "max grpc streams per client")
+	missingTTL := flags.Duration("cas_missing_ttl", cas.DefaultMissingTTL, "how long CAS digests confirmed missing are cached, zero to disable")
+	bloomItems := flags.Int("cas_bloom_items", 0, "expected number of present CAS digests tracked by a bloom filter, zero to disable")
+	maxBlobSize := flags.Int64("cas_max_blob_size", cas.DefaultMaxBlobSize, "largest blob in bytes accepted by CAS uploads, zero for unlimited")
+	casQuotas := flags.String("cas_quotas", "", "comma-separated 'tenant=bytes' limits on bytes each tenant may store through the CAS, ActionCache and bundle uploads per quota window")
+	casDefaultQuota := flags.Int64("cas_default_quota", 0, "bytes each tenant not in cas_quotas, including anonymous requests, may store per quota window, zero for unlimited")
+	casQuotaWindow := flags.Duration("cas_quota_window", cas.DefaultQuotaWindow, "period after which quota usage is reset")
+	casQuotaTokens := flags.String("cas_quota_tokens_file", "", "file of 'tenant=token' lines, requests carrying 'authorization: Bearer <token>' count against the tenant's quota, others against the anonymous tenant's")
+	casShard := flags.Bool("cas_shard", false, "partition CAS digests across all apiservers by consistent hashing, forwarding requests to their owners")
+	casShardHosts := flags.String("cas_shard_hosts_file", "", "file of the grpc 'host:port' addrs of the CAS servers sharing digests, one per line, watched for changes; if unset, CAS servers on this host are found with ps")
+	storeReplicas := flags.String("store_replicas", "", "comma-separated dirs or bundlestore URIs that bundles are replicated to in addition to the local store")
+	storeReplication := flags.String("store_replication", store.ReplicateQuorum, "how writes to replicas complete (quorum|async)")
+	storeWriteQuorum := flags.Int("store_write_quorum", 0, "number of replicas, including the local store, writes must succeed on in quorum mode, zero for a majority")
+	logRetention := flags.Duration("log_retention", runlogs.DefaultRetention, "how long run logs persisted by workers are kept before they're swept")
+	storeProxy := flags.String("store_proxy", "", "root URI of an HTTP artifact service (GET/HEAD/PUT) to store bundles and CAS blobs in instead of local dirs")
+	storeProxyHeaders := flags.String("store_proxy_headers", "", "comma-separated 'Name: value' headers sent to the store proxy, values are expanded from the environment")
+	storeMemory := flags.Bool("store_memory", false, "keep bundles and CAS blobs in a size-bounded in-memory store, spilling to temp, instead of local dirs; for tests and small deployments")
+	storeMemoryBytes := flags.Int64("store_memory_bytes", store.DefaultMemoryStoreMemBytes, "bytes the in-memory store keeps in memory before spilling the least recently used to disk")
+	storeMemoryMaxBytes := flags.Int64("store_memory_max_bytes", store.DefaultMemoryStoreMaxBytes, "bytes the in-memory store keeps in total before evicting the least recently used, zero for unlimited")
+	storeProxyTries := flags.Int("store_proxy_tries", store.DefaultHttpTries, "total tries per store proxy request, retrying connection errors and 5xx responses")
+	listTokenEnv := flags.String("list_token_env", "SCOOT_LIST_TOKEN", "name of the env var holding the token requests listing the store must carry, listing is refused if it's unset; also sent when listing bundlestore replicas")
+	storeRemote := flags.String("store_remote_region", "", "bundlestore URI of another region's apiserver that bundles are replicated to in the background and read from when missing locally")
+	coldStore := flags.String("cas_cold_store", "", "root URI of an HTTP artifact service (GET/HEAD/PUT), like an S3 infrequent access gateway, that CAS blobs are mirrored to and restored from when missing")
+	coldStoreHeaders := flags.String("cas_cold_store_headers", "", "comma-separated 'Name: value' headers sent to the cold store, values are expanded from the environment")
+	scrubInterval := flags.Duration("cas_scrub_interval", 0, "how often CAS blobs in local store dirs are re-hashed to find corruption, zero to disable")
+	scrubRate := flags.Int64("cas_scrub_bytes_per_sec", 0, "max bytes per second read by the CAS scrubber, zero for unlimited")
+	scrubQuarantine := flags.Bool("cas_scrub_quarantine", false, "move corrupted CAS blobs to a quarantine dir instead of deleting them")
+	compactInterval := flags.Duration("cas_compact_interval", 0, "how often small CAS blobs in local store dirs are packed into pack files, zero to disable")
+	compactMaxBlobSize := flags.Int64("cas_compact_max_blob_size", store.DefaultCompactMaxBlobSize, "largest CAS blob in bytes packed by compaction")
+	casMode := flags.String("cas_mode", cas.ModeNormal.String(), "mode the CAS starts in (normal|read_only|maintenance), changed at runtime by POSTing mode=<mode> to "+cas.ModeHttpPath)
+	adminTokenEnv := flags.String("admin_token_env", "SCOOT_ADMIN_TOKEN", "name of the env var holding the token requests changing the CAS mode must carry, changes are refused if it's unset")
+	casUpstream := flags.String("cas_upstream", "", "'host:port' addr of a Remote Execution CAS to proxy: local misses are fetched from and cached, writes go to both")
+	casUpstreamInstance := flags.String("cas_upstream_instance", "", "instance name for requests to the upstream CAS, empty to pass through clients' instance names")
+	casUpstreamTLS := flags.Bool("cas_upstream_tls", false, "connect to the upstream CAS with TLS")
+	casTTLSampleRate := flags.Int("cas_ttl_sample_rate", cas.DefaultTTLSampleRate, "one in this many CAS blobs and ActionResults is sampled for the TTL report")
+	casTTLState := flags.String("cas_ttl_report_state", "", "file the TTL report's sampled entries are saved to and loaded from on restart, empty to start sampling over on restart")
+	flags.Parse(args)
+	configText := daemon.setup()
+
+	initialMode, err := cas.ParseMode(*casMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	listToken := os.Getenv(*listTokenEnv)
+	adminToken := os.Getenv(*adminTokenEnv)
+
+	type StoreAndHandler struct {
+		store    store.Store
+		handler  http.Handler
+		endpoint string
+	}
+
+	bag := bundlestore.Defaults()
+	schema := jsonconfig.EmptySchema()
+	bag.InstallModule(gitdb.Module())
+	bag.InstallModule(temp.Module())
+	bag.InstallModule(bundlestore.Module())
+	bag.InstallModule(snapshots.Module())
+	bag.InstallModule(endpoints.Module())
+	bag.PutMany(
+		func() endpoints.StatScope { return endpoints.StatScope(name) },
+		func() endpoints.Addr { return endpoints.Addr(*httpAddr) },
+		func(bs *bundlestore.Server, vs *snapshots.ViewServer, sh *StoreAndHandler, ms *cas.ModeSwitch) map[string]http.Handler {
+			handlers := map[string]http.Handler{sh.endpoint: sh.handler}
+			if role != apiServerBundlestore {
+				handlers[cas.ModeHttpPath] = ms
+			}
+			if role != apiServerCAS {
+				handlers["/bundle/"] = bs
+				// Because we don't have any stream configured,
+				// for now our view server will only work for snapshots
+				// in a bundle with no basis
+				handlers["/view/"] = vs
+				handlers[runlogs.HttpPath] = runlogs.NewHandler(sh.store)
+			}
+			return handlers
+		},
+		func(fileStore *store.FileStore, stat stats.StatsReceiver, ttlc *store.TTLConfig, tmp *temp.TempDir) (*StoreAndHandler, error) {
+			cfg := &store.GroupcacheConfig{
+				Name:         "apiserver",
+				Memory_bytes: *cacheSize,
+				AddrSelf:     *httpAddr,
+				Endpoint:     "/groupcache",
+				Cluster:      createCluster(name, "http_addr", stat.Scope("groupcache")),
+			}
+			var underlying store.Store
+			var fileStores []*store.FileStore
+			if *storeProxy != "" {
+				headers, err := store.ParseProxyHeaders(*storeProxyHeaders)
+				if err != nil {
+					return nil, err
+				}
+				underlying = store.MakeProxyStore(store.ProxyStoreConfig{
+					RootURI: *storeProxy,
+					Headers: headers,
+					Tries:   *storeProxyTries,
+				})
+			} else if *storeMemory {
+				var err error
+				if underlying, err = store.MakeMemoryStoreInTemp(tmp, *storeMemoryBytes, *storeMemoryMaxBytes); err != nil {
+					return nil, err
+				}
+			} else {
+				var err error
+				underlying, fileStores, err = makeReplicatedStore(fileStore, *storeReplicas, listToken, store.ReplicatingStoreConfig{
+					Mode:        *storeReplication,
+					WriteQuorum: *storeWriteQuorum,
+					TTL:         ttlc,
+				}, stat)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if *storeRemote != "" {
+				underlying = store.MakeRoutingStore(underlying, store.MakeHTTPStoreWithListToken(*storeRemote, listToken), store.RoutingStoreConfig{}, stat)
+			}
+			if *coldStore != "" {
+				headers, err := store.ParseProxyHeaders(*coldStoreHeaders)
+				if err != nil {
+					return nil, err
+				}
+				cold := store.MakeProxyStore(store.ProxyStoreConfig{RootURI: *coldStore, Headers: headers, Tries: *storeProxyTries})
+				underlying = store.MakeMirroringStore(underlying, cold, store.MirroringStoreConfig{Prefix: bazel.StorePrefix + "-"}, stat)
+			}
+			gcStore, handler, err := store.MakeGroupcacheStore(underlying, cfg, ttlc, stat)
+			if err != nil {
+				return nil, err
+			}
+			scrubCfg := cas.ScrubConfig{Interval: *scrubInterval, BytesPerSec: *scrubRate, Quarantine: *scrubQuarantine}
+			compactCfg := store.CompactionConfig{
+				Prefix:      bazel.StorePrefix + "-",
+				MaxBlobSize: *compactMaxBlobSize,
+				Interval:    *compactInterval,
+			}
+			if len(fileStores) == 0 {
+				log.Info("Persisted run logs aren't swept here, the store expires them by the TTL they're written with")
+			}
+			for _, fs := range fileStores {
+				go runlogs.SweepPeriodically(fs.Root(), *logRetention, runlogs.DefaultSweepInterval, stat)
+				if *scrubInterval > 0 {
+					go cas.ScrubPeriodically(fs.Root(), scrubCfg, stat)
+				}
+				if *compactInterval > 0 {
+					go fs.CompactPeriodically(compactCfg, stat)
+				}
+			}
+			return &StoreAndHandler{gcStore, handler, cfg.Endpoint + cfg.Name + "/"}, nil
+		},
+		func(sh *StoreAndHandler) store.Store {
+			return sh.store
+		},
+		func() *bazel.GRPCConfig {
+			if role == apiServerBundlestore {
+				return nil
+			}
+			return &bazel.GRPCConfig{
+				GRPCAddr:          *grpcAddr,
+				ListenerMaxConns:  *grpcConns,
+				RateLimitPerSec:   *grpcRate,
+				BurstLimitPerSec:  *grpcBurst,
+				ConcurrentStreams: *grpcStreams,
+			}
+		},
+		func() *cas.ExistenceCacheConfig {
+			return &cas.ExistenceCacheConfig{
+				MissingTTL:             *missingTTL,
+				MissingMaxEntries:      cas.DefaultMissingMaxEntries,
+				BloomItems:             *bloomItems,
+				BloomFalsePositiveRate: cas.DefaultBloomFalsePositiveRate,
+				BloomMaxAge:            cas.DefaultBloomMaxAge,
+			}
+		},
+		func() *cas.BlobLimitConfig {
+			return &cas.BlobLimitConfig{MaxBlobSize: *maxBlobSize}
+		},
+		func() (*cas.QuotaConfig, error) {
+			quotas, err := cas.ParseQuotas(*casQuotas)
+			if err != nil {
+				return nil, err
+			}
+			if len(quotas) == 0 && *casDefaultQuota == 0 {
+				return nil, nil
+			}
+			tokens := map[string]string{}
+			if *casQuotaTokens != "" {
+				data, err := ioutil.ReadFile(*casQuotaTokens)
+				if err != nil {
+					return nil, err
+				}
+				if tokens, err = cas.ParseQuotaTokens(string(data)); err != nil {
+					return nil, err
+				}
+			}
+			return &cas.QuotaConfig{Bytes: quotas, DefaultBytes: *casDefaultQuota, Window: *casQuotaWindow, Tokens: tokens}, nil
+		},
+		func(stat stats.StatsReceiver) *cas.ModeSwitch {
+			return cas.NewModeSwitch(initialMode, adminToken, stat)
+		},
+		func() *cas.UpstreamConfig {
+			if *casUpstream == "" {
+				return nil
+			}
+			return &cas.UpstreamConfig{Addr: *casUpstream, InstanceName: *casUpstreamInstance, TLS: *casUpstreamTLS}
+		},
+		func() *cas.TTLReportConfig {
+			return &cas.TTLReportConfig{SampleRate: *casTTLSampleRate, StateFile: *casTTLState}
+		},
+		func() bundlestore.ListToken {
+			return bundlestore.ListToken(listToken)
+		},
+		func(stat stats.StatsReceiver) (*cas.ShardConfig, error) {
+			if !*casShard {
+				return nil, nil
+			}
+			c, err := createHostsCluster(*casShardHosts, name, "grpc_addr", stat.Scope("casShard"))
+			if err != nil {
+				return nil, err
+			}
+			return &cas.ShardConfig{
+				Cluster:  c,
+				Self:     *grpcAddr,
+				Replicas: cas.DefaultShardReplicas,
+			}, nil
+		},
+	)
+	bundlestore.RunServer(bag, schema, configText)
+}
+
+// Returns fileStore replicated to replicas, a comma-separated list of dirs or bundlestore URIs, or fileStore
+// itself if there are none. Bundlestores are listed with listToken. Also returns the local FileStores,
+// whose dirs persisted run logs must be swept from.
+func makeReplicatedStore(fileStore *store.FileStore, replicas, listToken string,
+	cfg store.ReplicatingStoreConfig, stat stats.StatsReceiver) (store.Store, []*store.FileStore, error) {
+	if replicas == "" {
+		return fileStore, []*store.FileStore{fileStore}, nil
+	}
+	stores := []store.Store{fileStore}
+	fileStores := []*store.FileStore{fileStore}
+	for _, r := range strings.Split(replicas, ",") {
+		if strings.HasPrefix(r, "http://") || strings.HasPrefix(r, "https://") {
+			stores = append(stores, store.MakeHTTPStoreWithListToken(r, listToken))
+			continue
+		}
+		fs, err := store.MakeFileStore(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		stores = append(stores, fs)
+		fileStores = append(fileStores, fs)
+	}
+	rs, err := store.MakeReplicatingStore(stores, cfg, stat)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rs, fileStores, nil
+}
+
+// Creates a cluster of the addrs in the hosts file at path, updated whenever it changes, like the scheduler's
+// file cluster of workers, see scootconfig.ClusterFileConfig. If path is empty, falls back to createCluster.
+func createHostsCluster(path, name, addrFlag string, stat stats.StatsReceiver) (*cluster.Cluster, error) {
+	if path == "" {
+		return createCluster(name, addrFlag, stat), nil
+	}
+	return (&scootconfig.ClusterFileConfig{Path: path}).Create(stat)
+}
+
+// Creates a cluster of the scoot daemons run with the named subcommand on this machine,
+// identified by the addr they were given with addrFlag.
+func createCluster(name, addrFlag string, stat stats.StatsReceiver) *cluster.Cluster {
+	f := local.MakeFetcher("scoot "+name, addrFlag)
+	nodes, _ := f.Fetch()
+	updates := cluster.MakeFetchCron(f, time.NewTicker(time.Duration(1*time.Second)).C, stat)
+	return cluster.NewCluster(nodes, updates, stat)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+)
+
+var tests = map[string][]string{
+	"scheduler": {"local.memory", "local.local"},
+	"worker":    {"local.local"},
+}
+
+// Tests to ensure config is properly specified
+// and that they parse correctly
+func TestConfigParses(t *testing.T) {
+	for daemon, configFiles := range tests {
+		for _, configFile := range configFiles {
+			if _, err := loadConfig(daemon, configFile); err != nil {
+				t.Errorf("Error Loading %v Config File %v, with Error %v", daemon, configFile, err)
+			}
+		}
+	}
+}
+
+func TestConfigInvalid(t *testing.T) {
+	invalid := map[string]string{
+		"scheduler": `{"SagaLog": {"Type": "memory", "ExpirationSecs": 0}}`,
+		"worker":    `{"Cluster": {"Type": "local"}}`,
+		"cas":       `{`,
+	}
+	for daemon, config := range invalid {
+		if _, err := loadConfig(daemon, config); err == nil {
+			t.Errorf("Expected %v config %v to be invalid", daemon, config)
+		}
+	}
+	if _, err := loadConfig("frontend", "{}"); err == nil {
+		t.Error("Expected an unknown daemon to be an error")
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+// The Scoot daemons in one binary, run as a subcommand (--help for usage):
+// * scheduler - the Cloud Scoot API server and scheduler
+// * worker - a worker running the scheduler's tasks
+// * apiserver - the bundlestore and Bazel CAS servers together
+// * bundlestore - only the bundlestore's HTTP server
+// * cas - only the Bazel CAS's GRPC server, and HTTP stats and mode endpoints
+// * config check - validates a daemon's config offline
+//
+// Each daemon's config is loaded the same way, see loadConfig, and checked against its schema before it starts.
+
+//go:generate sh -c "cd scheduler && go-bindata -pkg config -o ./config/config.go config && go fmt ./config/config.go"
+//go:generate sh -c "cd worker && go-bindata -pkg config -o ./config/config.go config && go fmt ./config/config.go"
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	schedconfig "github.com/twitter/scoot/binaries/scoot/scheduler/config"
+	workerconfig "github.com/twitter/scoot/binaries/scoot/worker/config"
+	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/config/jsonconfig"
+	"github.com/twitter/scoot/scootapi/server"
+)
+
+const configCheckUsage = "'config check -daemon <daemon> <config>' validates a daemon's config and exits"
+
+type command struct {
+	usage string
+	run   func(args []string)
+}
+
+var commands = map[string]command{
+	"scheduler":   {"run the Cloud Scoot API server and scheduler", runScheduler},
+	"worker":      {"run a worker", runWorker},
+	"apiserver":   {"run the bundlestore and Bazel CAS servers", runApiServer},
+	"bundlestore": {"run only the bundlestore HTTP server", runBundlestore},
+	"cas":         {"run only the Bazel CAS GRPC server", runCAS},
+	"config":      {configCheckUsage, runConfig},
+}
+
+// Where a daemon's named config files and its schema come from.
+type daemonConfig struct {
+	asset  func(string) ([]byte, error)
+	schema func() jsonconfig.Schema
+}
+
+var daemonConfigs = map[string]daemonConfig{
+	"scheduler": {
+		asset: schedconfig.Asset,
+		schema: func() jsonconfig.Schema {
+			_, schema := server.Defaults()
+			return schema
+		},
+	},
+	"worker":      {workerconfig.Asset, jsonconfig.EmptySchema},
+	"apiserver":   {noAssets, jsonconfig.EmptySchema},
+	"bundlestore": {noAssets, jsonconfig.EmptySchema},
+	"cas":         {noAssets, jsonconfig.EmptySchema},
+}
+
+func noAssets(name string) ([]byte, error) {
+	return nil, fmt.Errorf("no config files: %s", name)
+}
+
+func main() {
+	log.AddHook(hooks.NewContextHook())
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		printUsage()
+		os.Exit(1)
+	}
+	cmd.run(os.Args[2:])
+}
+
+func printUsage() {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags], where command is one of:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].usage)
+	}
+}
+
+// Flags every daemon takes.
+type daemonFlags struct {
+	name     string
+	config   *string
+	logLevel *string
+}
+
+func newDaemonFlags(flags *flag.FlagSet, name, defaultConfig string) daemonFlags {
+	return daemonFlags{
+		name: name,
+		config: flags.String("config", defaultConfig,
+			"Config, either a filename like local.local, a path to a JSON file starting with '/' or '.', or JSON text"),
+		logLevel: flags.String("log_level", "info", "Log everything at this level and above (error|info|debug)"),
+	}
+}
+
+// Sets the log level and returns the daemon's config text, exiting if either is invalid.
+func (d daemonFlags) setup() []byte {
+	level, err := log.ParseLevel(*d.logLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.SetLevel(level)
+
+	configText, err := loadConfig(d.name, *d.config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return configText
+}
+
+// Returns the text of a daemon's config, given as a filename of one of its assets, a path or JSON text,
+// after checking it against the daemon's schema.
+func loadConfig(daemon, configFlag string) ([]byte, error) {
+	dc, ok := daemonConfigs[daemon]
+	if !ok {
+		return nil, fmt.Errorf("Unknown daemon %q", daemon)
+	}
+	configText, err := jsonconfig.GetConfigText(configFlag, dc.asset)
+	if err != nil {
+		return nil, err
+	}
+	if err := dc.schema().Validate(configText); err != nil {
+		return nil, fmt.Errorf("Config for %s: %v", daemon, err)
+	}
+	return configText, nil
+}
+
+func runConfig(args []string) {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, configCheckUsage)
+		os.Exit(1)
+	}
+	flags := flag.NewFlagSet("config check", flag.ExitOnError)
+	daemon := flags.String("daemon", "", "Daemon whose config is checked (scheduler|worker|apiserver|bundlestore|cas)")
+	flags.Parse(args[1:])
+	if *daemon == "" || flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, configCheckUsage)
+		os.Exit(1)
+	}
+	log.SetLevel(log.ErrorLevel)
+
+	if _, err := loadConfig(*daemon, flags.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s config OK\n", *daemon)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/execution"
+	"github.com/twitter/scoot/common/dialer"
+	"github.com/twitter/scoot/common/endpoints"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/config/scootconfig"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/scootapi"
+	"github.com/twitter/scoot/scootapi/server"
+	"github.com/twitter/scoot/scootapi/server/api"
+	"github.com/twitter/scoot/scootapi/server/audit"
+	"github.com/twitter/scoot/scootapi/server/ui"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+func runScheduler(args []string) {
+	flags := flag.NewFlagSet("scheduler", flag.ExitOnError)
+	daemon := newDaemonFlags(flags, "scheduler", "local.memory")
+	thriftAddr := flags.String("thrift_addr", scootapi.DefaultSched_Thrift, "Bind address for api server")
+	httpAddr := flags.String("http_addr", scootapi.DefaultSched_HTTP, "Bind address for http server")
+	grpcAddr := flags.String("grpc_addr", scootapi.DefaultSched_GRPC, "Bind address for grpc server")
+	grpcConns := flags.Int("max_grpc_conn", 0, "max grpc listener connections")
+	grpcRate := flags.Int("max_grpc_rps", 0, "max grpc incoming requests per second")
+	grpcBurst := flags.Int("max_grpc_rps_burst", 0, "max grpc incoming requests burst")
+	grpcStreams := flags.Int("max_grpc_streams", 0, "max grpc streams per client")
+	maxExecutes := flags.Int("max_concurrent_executes", 0, "max Execute requests processed concurrently, zero for unlimited")
+	casAddr := flags.String("cas_addr", "", "'host:port' of a CAS server used to verify Action inputs before scheduling")
+	bundlestoreAddr := flags.String("bundlestore_addr", "", "'host:port' of an apiserver whose bundlestore is checked for the bundles of snapshots when validating jobs")
+	besAddr := flags.String("bes_addr", "", "'host:port' of a Build Event Service that execution progress is published to")
+	auditLogPath := flags.String("audit_log", "", "File administrative actions are appended to, kept in memory if unset")
+	uiLogURL := flags.String("ui_log_url", "", "URL prefix the web UI links persisted run logs under, ex: http://apiserver:9098/log/")
+	flags.Parse(args)
+	configText := daemon.setup()
+
+	bag, schema := server.Defaults()
+	bag.PutMany(
+		func() (thrift.TServerTransport, error) {
+			return thrift.NewTServerSocket(*thriftAddr)
+		},
+
+		func() scootconfig.ClientTimeout {
+			return scootconfig.ClientTimeout(scootconfig.DefaultClientTimeout)
+		},
+
+		func(s stats.StatsReceiver, handlers map[string]http.Handler) *endpoints.TwitterServer {
+			return endpoints.NewTwitterServer(endpoints.Addr(*httpAddr), s, handlers)
+		},
+
+		func() ui.Config {
+			return ui.Config{LogURLPrefix: *uiLogURL}
+		},
+
+		func() (audit.Log, error) {
+			if *auditLogPath == "" {
+				return audit.NewMemoryLog(), nil
+			}
+			return audit.NewFileLog(*auditLogPath)
+		},
+
+		func() *bazel.GRPCConfig {
+			return &bazel.GRPCConfig{
+				GRPCAddr:          *grpcAddr,
+				ListenerMaxConns:  *grpcConns,
+				RateLimitPerSec:   *grpcRate,
+				BurstLimitPerSec:  *grpcBurst,
+				ConcurrentStreams: *grpcStreams,
+			}
+		},
+
+		func() (*temp.TempDir, error) {
+			return temp.NewTempDir("", "sched")
+		},
+
+		func() execution.CASResolver {
+			if *casAddr == "" {
+				return nil
+			}
+			return dialer.NewConstantResolver(*casAddr)
+		},
+
+		func() execution.ExecuteLimit {
+			return execution.ExecuteLimit(*maxExecutes)
+		},
+
+		func() api.BundleFinder {
+			if *bundlestoreAddr == "" {
+				return nil
+			}
+			return store.MakeHTTPStore(scootapi.APIAddrToBundlestoreURI(*bundlestoreAddr))
+		},
+
+		func() execution.BESResolver {
+			if *besAddr == "" {
+				return nil
+			}
+			return dialer.NewConstantResolver(*besAddr)
+		},
+	)
+
+	log.Info("Starting Cloud Scoot API Server & Scheduler on", *thriftAddr)
+	server.RunServer(bag, schema, configText)
+}
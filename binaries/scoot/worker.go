@@ -0,0 +1,319 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster/local"
+	"github.com/twitter/scoot/common/dialer"
+	"github.com/twitter/scoot/common/endpoints"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/config/jsonconfig"
+	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/gpu"
+	"github.com/twitter/scoot/runner/runlogs"
+	"github.com/twitter/scoot/runner/runners"
+	"github.com/twitter/scoot/runner/secrets"
+	"github.com/twitter/scoot/scootapi"
+	"github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/bazel"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+	"github.com/twitter/scoot/snapshot/git/gitdb"
+	"github.com/twitter/scoot/snapshot/git/repo"
+	"github.com/twitter/scoot/snapshot/store"
+	"github.com/twitter/scoot/workerapi/server"
+)
+
+func runWorker(args []string) {
+	flags := flag.NewFlagSet("worker", flag.ExitOnError)
+	daemon := newDaemonFlags(flags, "worker", "local.local")
+	thriftAddr := flags.String("thrift_addr", scootapi.DefaultWorker_Thrift, "addr to serve thrift on")
+	httpAddr := flags.String("http_addr", scootapi.DefaultWorker_HTTP, "addr to serve http on")
+	memCapFlag := flags.Uint64("mem_cap", 0, "Kill runs that exceed this amount of memory, in bytes. Zero means no limit.")
+	abortGrace := flags.Duration("abort_grace_period", 0, "Give aborted or timed out runs this long to exit after SIGTERM before killing them. Zero kills them immediately.")
+	pidNamespace := flags.Bool("pid_namespace", false, "Start each run in its own PID namespace so none of its descendants outlive it. Requires root on linux, ignored elsewhere.")
+	repoDir := flags.String("repo", "", "Abs dir path to a git repo to run against (don't use important repos yet!).")
+	storeHandle := flags.String("bundlestore", "", "Abs file path or an http 'host:port' to store/get bundles.")
+	storeFallbacks := flags.String("bundlestore_fallbacks", "", "Comma separated bundlestores, abs file paths or http 'host:port's, to fall back to in order when -bundlestore fails.")
+	streamName := flags.String("stream_name", "", "Name of the stream snapshots may be based on, ex: \"sm\". Empty for no stream.")
+	streamRemote := flags.String("stream_remote", "upstream", "Remote of -repo the stream is fetched from.")
+	streamRefSpec := flags.String("stream_refspec", "refs/remotes/upstream/master", "Ref of -repo the stream follows.")
+	streamMirrors := flags.String("stream_mirrors", "", "Comma separated remotes of -repo mirroring -stream_remote, fetched from in order when it fails.")
+	failoverMaxFailures := flags.Int("failover_max_failures", store.DefaultFailoverMaxFailures, "Consecutive failures after which a bundlestore fallback or stream mirror is tried last.")
+	failoverCooldown := flags.Duration("failover_cooldown", store.DefaultFailoverCooldown, "How long a failing bundlestore fallback or stream mirror is tried last.")
+	casAddr := flags.String("cas_addr", "", "'host:port' of a server supporting CAS API over GRPC")
+	peerBundles := flags.Bool("peer_bundles", false, "Fetch bundles from peer workers before falling back to the bundlestore.")
+	peerHosts := flags.String("peer_hosts_file", "", "File of the http 'host:port' addrs of peer workers, one per line, watched for changes. If unset, workers on this host are found with ps.")
+	peerMaxBytes := flags.Int64("peer_bundles_max_bytes", store.DefaultPeerBundlesMaxBytes, "Bytes of bundles kept for peers, the least recently read are removed beyond it. Zero for unlimited.")
+	peerTTL := flags.Duration("peer_bundles_ttl", store.DefaultPeerBundlesTTL, "How long bundles kept for peers are kept after they were last read. Zero for forever.")
+	preRunHook := flags.String("pre_run_hook", "", "Command run in each run's checkout before the run, split on whitespace.")
+	postRunHook := flags.String("post_run_hook", "", "Command run in each run's checkout after the run, split on whitespace.")
+	hookTimeout := flags.Duration("run_hook_timeout", runners.DefaultRunHookTimeout, "Kill run hooks that take longer than this.")
+	secretsEnvFile := flags.String("secrets_env_file", "", "Abs path to a file of NAME=VALUE secrets that runs may request.")
+	secretsPlugin := flags.String("secrets_plugin", "", "Abs path to an executable that prints the secret named by its argument.")
+	historyDir := flags.String("run_history_dir", "", "Abs dir path finished runs are kept in so they can be queried after a restart. If unset, they're kept in the temp dir and lost on restart.")
+	persistLogs := flags.Bool("persist_logs", false, "Persist each run's combined stdout/stderr to the bundlestore.")
+	logRetention := flags.Duration("log_retention", runlogs.DefaultRetention, "How long persisted run logs are kept.")
+	gpusFlag := flags.String("gpus", "", "GPU device IDs runs may request, ex: \"0,1\", or \"auto\" to detect with nvidia-smi. If unset, GPUs aren't allocated and CUDA_VISIBLE_DEVICES is left as is.")
+	actionCacheTTL := flags.Duration("local_action_cache_ttl", 0, "Reuse results of bazel actions this worker ran for this long if the ActionCache is unreachable. Zero disables.")
+	envAllow := flags.String("env_allow", "", "Comma separated worker env vars runs inherit, or prefixes ending in '*'. Empty for all.")
+	logTail := flags.Int64("log_tail_bytes", 0, "Bytes kept from the end of each run's stdout and stderr in its status, ex: 4096. Zero disables.")
+	runAsUser := flags.String("run_as_user", "", "Run commands as this unprivileged user name or uid, with its primary group, so they can't read worker credentials. Requires root. Run hooks run as this user too.")
+	envDeny := flags.String("env_deny", "", "Comma separated worker env vars runs never inherit, or prefixes ending in '*', ex: credentials.")
+	selfTest := flags.Bool("selftest", false, "Run a command, a snapshot checkout and a CAS round trip, then exit, non-zero if any failed.")
+	startupSelfTest := flags.Bool("startup_selftest", true, "Don't serve tasks until the self-test passes, retrying it periodically.")
+	adminTokenEnv := flags.String("admin_token_env", "SCOOT_ADMIN_TOKEN", "Name of the env var holding the token requests to change debug settings must carry. They're refused if it's empty.")
+	checkoutSpotChecks := flags.Int("checkout_spot_checks", 0, "Hash this many random files of each snapshot checkout and check it out again if any don't match. Zero disables.")
+	flags.Parse(args)
+	configText := daemon.setup()
+	gitdb.CheckoutSpotChecks = *checkoutSpotChecks
+
+	bag := ice.NewMagicBag()
+	schema := jsonconfig.EmptySchema()
+	bag.InstallModule(temp.Module())
+	bag.InstallModule(gitdb.Module())
+	bag.InstallModule(bundlestore.Module())
+	bag.InstallModule(endpoints.Module())
+	bag.InstallModule(runners.Module())
+	bag.InstallModule(server.Module())
+	bag.InstallModule(bazel.Module())
+	bag.PutMany(
+		func() endpoints.StatScope { return "workerserver" },
+		func() endpoints.Addr { return endpoints.Addr(*httpAddr) },
+		func() (thrift.TServerTransport, error) { return thrift.NewTServerSocket(*thriftAddr) },
+		func() (*repo.Repository, error) {
+			return repo.NewRepository(*repoDir)
+		},
+		func(tmp *temp.TempDir) (runners.HttpOutputCreator, error) {
+			outDir, err := tmp.FixedDir("output")
+			if err != nil {
+				return nil, err
+			}
+			return runners.NewHttpOutputCreator(outDir, ("http://" + *httpAddr + "/output/"))
+		},
+		func(oc runners.HttpOutputCreator) runner.OutputCreator {
+			return oc
+		},
+		func(outputCreator runners.HttpOutputCreator, s store.Store) map[string]http.Handler {
+			handlers := map[string]http.Handler{outputCreator.HttpPath(): outputCreator}
+			if ps, ok := s.(*store.PeerStore); ok {
+				handlers[store.PeerBundlePath] = ps
+			}
+			return handlers
+		},
+		func() execer.Memory {
+			return execer.Memory(*memCapFlag)
+		},
+		func() execer.AbortGracePeriod {
+			return execer.AbortGracePeriod(*abortGrace)
+		},
+		func() execer.PIDNamespace {
+			return execer.PIDNamespace(*pidNamespace)
+		},
+		func() runners.RunHistoryDir {
+			return runners.RunHistoryDir(*historyDir)
+		},
+		func() (*execer.RunAs, error) {
+			if *runAsUser == "" {
+				return nil, nil
+			}
+			return execer.LookupRunAs(*runAsUser)
+		},
+		func() (secrets.Provider, error) {
+			switch {
+			case *secretsEnvFile != "" && *secretsPlugin != "":
+				return nil, fmt.Errorf("At most one of secrets_env_file and secrets_plugin may be set")
+			case *secretsEnvFile != "":
+				return secrets.NewEnvFileProvider(*secretsEnvFile)
+			case *secretsPlugin != "":
+				return secrets.NewPluginProvider(*secretsPlugin, secrets.DefaultPluginTimeout)
+			}
+			return nil, nil
+		},
+		func(s store.Store, stat stats.StatsReceiver) *runlogs.Persister {
+			if !*persistLogs {
+				return nil
+			}
+			return runlogs.NewPersister(s, runlogs.Config{Retention: *logRetention}, stat)
+		},
+		func() *runners.RunHooks {
+			if *preRunHook == "" && *postRunHook == "" {
+				return nil
+			}
+			return &runners.RunHooks{
+				PreRun:  strings.Fields(*preRunHook),
+				PostRun: strings.Fields(*postRunHook),
+				Timeout: *hookTimeout,
+			}
+		},
+		// Use storeHandle if provided, else try Fetching, then GetScootApiAddr(), then fallback to tmp file store.
+		// If peer_bundles is set, bundles are fetched from other workers before going to that store.
+		func(tmp *temp.TempDir, stat stats.StatsReceiver) (store.Store, error) {
+			upstream, err := makeUpstreamStore(*storeHandle, tmp)
+			if err != nil || !*peerBundles {
+				return upstream, err
+			}
+			peerDir, err := tmp.FixedDir("peer-bundles")
+			if err != nil {
+				return nil, err
+			}
+			peers, err := createHostsCluster(*peerHosts, "worker", "http_addr", stat.Scope("peers"))
+			if err != nil {
+				return nil, err
+			}
+			cfg := store.PeerStoreConfig{MaxBytes: *peerMaxBytes, TTL: *peerTTL}
+			return store.MakePeerStore(peerDir.Dir, upstream, peers, *httpAddr, cfg, stat)
+		},
+		func(s store.Store, tmp *temp.TempDir) (*gitdb.BundlestoreConfig, error) {
+			cfg := &gitdb.BundlestoreConfig{
+				Store:    s,
+				Failover: store.FailoverConfig{MaxFailures: *failoverMaxFailures, Cooldown: *failoverCooldown},
+			}
+			for _, handle := range splitList(*storeFallbacks) {
+				fallback, err := makeUpstreamStore(handle, tmp)
+				if err != nil {
+					return nil, err
+				}
+				cfg.Fallbacks = append(cfg.Fallbacks, fallback)
+			}
+			return cfg, nil
+		},
+		func() *gitdb.StreamConfig {
+			if *streamName == "" {
+				return nil
+			}
+			return &gitdb.StreamConfig{
+				Name:     *streamName,
+				Remote:   *streamRemote,
+				Mirrors:  splitList(*streamMirrors),
+				Failover: store.FailoverConfig{MaxFailures: *failoverMaxFailures, Cooldown: *failoverCooldown},
+				RefSpec:  *streamRefSpec,
+			}
+		},
+		// Create BzFiler to handle Bazel API requests
+		func(tmp *temp.TempDir) (*bazel.BzFiler, error) {
+			addr := ""
+			if *casAddr != "" {
+				addr = *casAddr
+			} else {
+				nodes, _ := local.MakeFetcher("apiserver", "grpc_addr").Fetch()
+				if len(nodes) > 0 {
+					r := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+					addr = string(nodes[r.Intn(len(nodes))].Id())
+					log.Info("No grpc cas servers specified, but successfully fetched apiserver addr: ", nodes, " --> ", addr)
+				}
+			}
+			resolver := dialer.NewConstantResolver(addr)
+			return bazel.MakeBzFiler(tmp, resolver)
+		},
+		// Initialize map of Filers w/ init chans based on RunTypes
+		// GitDB is created from its ice module defaults and handles Scoot API requests
+		func(gitDB *gitdb.DB, bzFiler *bazel.BzFiler) runner.RunTypeMap {
+			gitFiler := snapshot.NewDBAdapter(gitDB)
+
+			var filerMap runner.RunTypeMap = runner.MakeRunTypeMap()
+			filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: gitFiler, IDC: gitDB.InitDoneCh}
+			filerMap[runner.RunTypeBazel] = snapshot.FilerAndInitDoneCh{Filer: bzFiler, IDC: nil}
+			return filerMap
+		},
+		func() (*gpu.Allocator, error) {
+			devices := gpu.ParseDevices(*gpusFlag)
+			if *gpusFlag == "auto" {
+				var err error
+				if devices, err = gpu.Detect(); err != nil {
+					return nil, err
+				}
+			}
+			if len(devices) == 0 {
+				return nil, nil
+			}
+			log.Infof("Runs may request GPUs: %v", devices)
+			return gpu.NewAllocator(devices), nil
+		},
+		func() *runners.LocalActionCache {
+			if *actionCacheTTL <= 0 {
+				return nil
+			}
+			return runners.NewLocalActionCache(runners.DefaultLocalActionCacheCapacity, *actionCacheTTL)
+		},
+		func() *runners.EnvPolicy {
+			return runners.ParseEnvPolicy(*envAllow, *envDeny)
+		},
+		func() *runners.LogTailer {
+			return runners.NewLogTailer(*logTail)
+		},
+		func() server.AdminToken {
+			return server.AdminToken(os.Getenv(*adminTokenEnv))
+		},
+		func(rtm runner.RunTypeMap, tmp *temp.TempDir, gpus *gpu.Allocator) *server.CapabilitiesConfig {
+			c := server.NewCapabilitiesConfig("gitdb", rtm, tmp.Dir)
+			c.AddGPUs(gpus.Devices())
+			if *memCapFlag != 0 {
+				// Runs are killed above mem_cap, so that's all the memory they can use.
+				c.Resources.MemoryBytes = int64(*memCapFlag)
+			}
+			return c
+		},
+	)
+
+	if !*selfTest && !*startupSelfTest {
+		bag.Put(func() *server.SelfTest { return nil })
+	}
+	if *selfTest {
+		if err := server.RunSelfTest(bag, schema, configText); err != nil {
+			log.Fatal(err)
+		}
+		log.Info("Self-test passed")
+		return
+	}
+
+	log.Info("Serving thrift on", *thriftAddr) //It's hard to access the thriftAddr value downstream, print it here.
+	server.RunServer(bag, schema, configText)
+}
+
+func makeUpstreamStore(storeHandle string, tmp *temp.TempDir) (store.Store, error) {
+	if storeHandle != "" {
+		if strings.HasPrefix(storeHandle, "/") {
+			return store.MakeFileStoreInTemp(&temp.TempDir{Dir: storeHandle})
+		} else {
+			return store.MakeHTTPStore(scootapi.APIAddrToBundlestoreURI(storeHandle)), nil
+		}
+	}
+	storeAddr := ""
+	nodes, _ := local.MakeFetcher("apiserver", "http_addr").Fetch()
+	if len(nodes) > 0 {
+		r := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+		storeAddr = string(nodes[r.Intn(len(nodes))].Id())
+		log.Info("No stores specified, but successfully fetched store addr: ", nodes, " --> ", storeAddr)
+	} else {
+		_, storeAddr, _ = scootapi.GetScootapiAddr()
+		log.Info("No stores specified, but successfully read .cloudscootaddr: ", storeAddr)
+	}
+	if storeAddr != "" {
+		return store.MakeHTTPStore(scootapi.APIAddrToBundlestoreURI(storeAddr)), nil
+	}
+	log.Info("No stores specified or found, creating a tmp file store")
+	return store.MakeFileStoreInTemp(tmp)
+}
+
+// Returns the non-empty elements of the comma separated list l.
+func splitList(l string) []string {
+	elems := []string{}
+	for _, e := range strings.Split(l, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			elems = append(elems, e)
+		}
+	}
+	return elems
+}
@@ -4,15 +4,38 @@
 package cluster
 
 import (
+	"fmt"
 	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
 )
 
+// How often the time since the cluster was last updated is reported, so stalled updates can be alarmed on.
+const StatsInterval = 5 * time.Second
+
 // Cluster represents a group of Nodes and has mechanisms for receiving updates.
 type Cluster struct {
 	state    *state
 	reqCh    chan interface{}
 	updateCh chan ClusterUpdate
 	subs     []chan []NodeUpdate
+	stat     stats.StatsReceiver
+
+	lastUpdate time.Time
+	queued     int64 // updates waiting in subscriber queues, accessed atomically
+	// Sorted []Node of the current members, replaced by loop after each update so Members doesn't wait on it
+	members atomic.Value
+
+	// How long updates are held to be coalesced with the updates following them, zero to send them right away
+	coalesceWindow time.Duration
+	pending        []NodeUpdate // updates held in the current window
+
+	// Nodes added by hand that discovery hasn't reported yet, kept across its updates, and nodes removed
+	// by hand that discovery still reports, left out of its updates. See Add and Remove.
+	added   map[NodeId]Node
+	removed map[NodeId]bool
 }
 
 // Clusters can be updated in two ways:
@@ -21,22 +44,76 @@ type Cluster struct {
 type ClusterUpdate interface{}
 
 // Cluster's ch channel accepts []Node and []NodeUpdate types, which then
-// get passed to its state to either SetAndDiff or UpdateAndFilter
-func NewCluster(state []Node, updateCh chan ClusterUpdate) *Cluster {
+// get passed to its state to either SetAndDiff or UpdateAndFilter.
+// Membership stats are recorded to stat, which may be nil.
+func NewCluster(state []Node, updateCh chan ClusterUpdate, stat stats.StatsReceiver) *Cluster {
+	return NewCoalescingCluster(state, updateCh, 0, stat)
+}
+
+// NewCoalescingCluster is NewCluster, but holds updates for coalesceWindow after the first one
+// and merges them with the updates received in the meantime into net updates for each node before
+// sending them to subscribers, see coalesce. This spares subscribers like the scheduler churn when many
+// nodes flap at once, ex: during discovery storms, at the cost of seeing changes up to coalesceWindow late.
+// Members isn't delayed. Zero coalesceWindow sends updates right away.
+func NewCoalescingCluster(
+	state []Node, updateCh chan ClusterUpdate, coalesceWindow time.Duration, stat stats.StatsReceiver) *Cluster {
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
 	s := makeState(state)
 	c := &Cluster{
-		state:    s,
-		reqCh:    make(chan interface{}),
-		updateCh: updateCh,
-		subs:     nil,
+		state:          s,
+		reqCh:          make(chan interface{}),
+		updateCh:       updateCh,
+		subs:           nil,
+		stat:           stat,
+		lastUpdate:     time.Now(),
+		coalesceWindow: coalesceWindow,
+		added:          map[NodeId]Node{},
+		removed:        map[NodeId]bool{},
 	}
+	c.members.Store(c.current())
+	stat.Gauge(stats.ClusterMembersGauge).Update(int64(len(s.nodes)))
 	go c.loop()
 	return c
 }
 
+// Returns the current members, sorted, without waiting on updates being applied.
+// Updates sent to the cluster may not be reflected yet, see Sync.
 func (c *Cluster) Members() []Node {
-	ch := make(chan []Node)
+	return append([]Node(nil), c.members.Load().([]Node)...)
+}
+
+// Waits until updates the cluster has already received are applied and sent to subscribers,
+// ex: so Members reflects an update sent to it. Updates held in a coalescing window are sent right away.
+func (c *Cluster) Sync() {
+	ch := make(chan struct{})
 	c.reqCh <- ch
+	<-ch
+}
+
+// Adds a node by hand, ahead of discovery, ex: to bring up a replacement while discovery is slow or unavailable.
+// The node is kept across discovery's updates until discovery reports it too, after which discovery may remove it.
+// Fails if the node is already a member.
+func (c *Cluster) Add(n Node) error {
+	return c.manual(NewAdd(n))
+}
+
+// Removes a node by hand, ahead of discovery. The node is left out of discovery's updates until discovery
+// stops reporting it, after which discovery may add it back. Fails if the node isn't a member.
+func (c *Cluster) Remove(id NodeId) error {
+	return c.manual(NewRemove(id))
+}
+
+// A node added or removed by hand, see Add and Remove.
+type manualReq struct {
+	update NodeUpdate
+	errCh  chan error
+}
+
+func (c *Cluster) manual(u NodeUpdate) error {
+	ch := make(chan error)
+	c.reqCh <- manualReq{update: u, errCh: ch}
 	return <-ch
 }
 
@@ -56,6 +133,9 @@ func (c *Cluster) done() bool {
 }
 
 func (c *Cluster) loop() {
+	ticker := time.NewTicker(StatsInterval)
+	defer ticker.Stop()
+	var windowCh <-chan time.Time
 	for !c.done() {
 		select {
 		case nodesOrUpdates, ok := <-c.updateCh:
@@ -65,22 +145,42 @@ func (c *Cluster) loop() {
 			}
 			outgoing := []NodeUpdate{}
 			if updates, ok := nodesOrUpdates.([]NodeUpdate); ok {
-				outgoing = c.state.filterAndUpdate(updates)
+				outgoing = c.state.filterAndUpdate(c.filterManual(updates))
 			} else if nodes, ok := nodesOrUpdates.([]Node); ok {
+				nodes = c.mergeManual(nodes)
 				sort.Sort(NodeSorter(nodes))
 				outgoing = c.state.setAndDiff(nodes)
 			}
-			for _, sub := range c.subs {
-				sub <- outgoing
+			c.members.Store(c.current())
+			c.recordUpdate(outgoing)
+			if c.coalesceWindow <= 0 {
+				c.send(outgoing)
+				continue
+			}
+			if windowCh == nil {
+				windowCh = time.After(c.coalesceWindow)
+			}
+			c.pending = append(c.pending, outgoing...)
+		case <-windowCh:
+			windowCh = nil
+			c.flush()
+		case <-ticker.C:
+			// Clusters without updates, ex: in memory clusters, never go stale
+			if c.updateCh != nil {
+				c.stat.Gauge(stats.ClusterTimeSinceUpdate_ms).Update(int64(time.Since(c.lastUpdate) / time.Millisecond))
 			}
 		case req, ok := <-c.reqCh:
 			if !ok {
 				c.reqCh = nil
 				continue
 			}
+			// Subscribers get the held updates first, so new ones start from the members after them
+			windowCh = nil
+			c.flush()
 			c.handleReq(req)
 		}
 	}
+	c.flush()
 	for _, sub := range c.subs {
 		close(sub)
 	}
@@ -88,15 +188,18 @@ func (c *Cluster) loop() {
 
 func (c *Cluster) handleReq(req interface{}) {
 	switch req := req.(type) {
-	case chan []Node:
-		// Members()
-		req <- c.current()
+	case chan struct{}:
+		// Sync()
+		close(req)
 	case chan Subscription:
 		// Subscribe()
 		ch := make(chan []NodeUpdate)
 		s := makeSubscription(c.current(), c, ch)
 		c.subs = append(c.subs, ch)
 		req <- s
+	case manualReq:
+		// Add() or Remove()
+		req.errCh <- c.applyManual(req.update)
 	case chan []NodeUpdate:
 		// close of a subscription
 		for i, sub := range c.subs {
@@ -111,6 +214,125 @@ func (c *Cluster) handleReq(req interface{}) {
 	}
 }
 
+func (c *Cluster) send(outgoing []NodeUpdate) {
+	for _, sub := range c.subs {
+		sub <- outgoing
+	}
+}
+
+// Sends the updates held in the coalescing window as net updates, if there are any.
+func (c *Cluster) flush() {
+	if len(c.pending) == 0 {
+		return
+	}
+	outgoing := coalesce(c.pending)
+	c.stat.Counter(stats.ClusterCoalescedUpdatesCounter).Inc(int64(len(c.pending) - len(outgoing)))
+	c.pending = nil
+	c.send(outgoing)
+}
+
+// Applies a node added or removed by hand and sends it to subscribers.
+func (c *Cluster) applyManual(u NodeUpdate) error {
+	_, member := c.state.nodes[u.Id]
+	if u.UpdateType == NodeAdded {
+		if member {
+			return fmt.Errorf("node %s is already a member of the cluster", u.Id)
+		}
+		delete(c.removed, u.Id)
+		c.added[u.Id] = u.Node
+	} else {
+		if !member {
+			return fmt.Errorf("node %s isn't a member of the cluster", u.Id)
+		}
+		delete(c.added, u.Id)
+		c.removed[u.Id] = true
+	}
+	outgoing := c.state.filterAndUpdate([]NodeUpdate{u})
+	c.members.Store(c.current())
+	c.recordMembers(outgoing)
+	c.send(outgoing)
+	return nil
+}
+
+// Drops updates from discovery that would undo nodes added or removed by hand, and forgets nodes
+// added by hand once discovery adds them, or removed by hand once discovery removes them.
+func (c *Cluster) filterManual(updates []NodeUpdate) []NodeUpdate {
+	if len(c.added) == 0 && len(c.removed) == 0 {
+		return updates
+	}
+	filtered := []NodeUpdate{}
+	for _, u := range updates {
+		_, added := c.added[u.Id]
+		switch {
+		case u.UpdateType == NodeAdded && c.removed[u.Id]:
+			continue
+		case u.UpdateType == NodeAdded:
+			delete(c.added, u.Id)
+		case u.UpdateType == NodeRemoved && added:
+			continue
+		case u.UpdateType == NodeRemoved:
+			delete(c.removed, u.Id)
+		}
+		filtered = append(filtered, u)
+	}
+	return filtered
+}
+
+// Returns the nodes reported by discovery with the nodes added by hand and without the nodes removed
+// by hand, forgetting those discovery now agrees with.
+func (c *Cluster) mergeManual(nodes []Node) []Node {
+	if len(c.added) == 0 && len(c.removed) == 0 {
+		return nodes
+	}
+	reported := map[NodeId]bool{}
+	merged := []Node{}
+	for _, n := range nodes {
+		reported[n.Id()] = true
+		if !c.removed[n.Id()] {
+			merged = append(merged, n)
+		}
+	}
+	for id := range c.removed {
+		if !reported[id] {
+			delete(c.removed, id)
+		}
+	}
+	for id, n := range c.added {
+		if reported[id] {
+			delete(c.added, id)
+		} else {
+			merged = append(merged, n)
+		}
+	}
+	return merged
+}
+
+// Records an update received from updateCh, which results in outgoing updates to members.
+// Updates that don't change membership still count as updates, they show the source of updates is alive.
+func (c *Cluster) recordUpdate(outgoing []NodeUpdate) {
+	c.lastUpdate = time.Now()
+	c.stat.Gauge(stats.ClusterTimeSinceUpdate_ms).Update(0)
+	c.recordMembers(outgoing)
+}
+
+// Records the members after outgoing updates to them.
+func (c *Cluster) recordMembers(outgoing []NodeUpdate) {
+	c.stat.Gauge(stats.ClusterMembersGauge).Update(int64(len(c.state.nodes)))
+	for _, u := range outgoing {
+		switch u.UpdateType {
+		case NodeAdded:
+			c.stat.Counter(stats.ClusterNodesAddedCounter).Inc(1)
+		case NodeRemoved:
+			c.stat.Counter(stats.ClusterNodesRemovedCounter).Inc(1)
+		}
+	}
+}
+
+// Adds delta to the number of updates waiting in subscriber queues.
+func (c *Cluster) addQueued(delta int) {
+	c.stat.Gauge(stats.ClusterSubscriberQueuedGauge).Update(atomic.AddInt64(&c.queued, int64(delta)))
+}
+
 func (c *Cluster) closeSubscription(s *subscriber) {
 	c.reqCh <- s.inCh
 }
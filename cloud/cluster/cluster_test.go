@@ -1,8 +1,11 @@
 package cluster_test
 
 import (
-	"github.com/twitter/scoot/cloud/cluster"
 	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
 )
 
 func TestMembers(t *testing.T) {
@@ -26,6 +29,13 @@ func TestMembers(t *testing.T) {
 	h.assertMembers("node3", "node4")
 }
 
+// Members is read without going through the cluster's loop, so it's still answered once the loop has exited.
+func TestMembersAfterClose(t *testing.T) {
+	c := cluster.NewCluster(makeNodes("node2", "node1"), nil, nil)
+	c.Close()
+	assertMembersEqual(makeNodes("node1", "node2"), c.Members(), t)
+}
+
 func TestSubscribe(t *testing.T) {
 	h := makeHelper(t)
 	defer h.close()
@@ -65,6 +75,91 @@ func TestSubscribe(t *testing.T) {
 	h.assertUpdates(s2, add("node1"), add("node2"), remove("node3"))
 }
 
+func TestCoalescing(t *testing.T) {
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	h := &helper{t: t, ch: make(chan cluster.ClusterUpdate)}
+	// The window never ends on its own, the held updates are sent by Sync.
+	h.c = cluster.NewCoalescingCluster(makeNodes("node1", "node2"), h.ch, time.Hour, statsReceiver)
+	defer h.close()
+	s := h.subscribe()
+	defer s.Closer.Close()
+
+	h.add("node3")
+	h.remove("node3", "node1")
+	h.add("node1", "node4")
+	h.remove("node2")
+	h.assertMembers("node1", "node4")
+	h.assertUpdates(s, remove("node1"), add("node1"), add("node4"), remove("node2"))
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.ClusterCoalescedUpdatesCounter: {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func TestManualNodes(t *testing.T) {
+	h := makeHelper(t)
+	defer h.close()
+	s := h.subscribe()
+	defer s.Closer.Close()
+
+	if err := h.c.Add(cluster.NewIdNode("node1")); err != nil {
+		t.Fatal(err)
+	}
+	h.assertUpdates(s, add("node1"))
+	if err := h.c.Add(cluster.NewIdNode("node1")); err == nil {
+		t.Fatal("Expected adding a member to fail")
+	}
+	if err := h.c.Remove("node2"); err == nil {
+		t.Fatal("Expected removing a node that isn't a member to fail")
+	}
+
+	// Nodes added by hand are kept until discovery reports them.
+	h.changeStateTo("node2", "node3")
+	h.assertMembers("node1", "node2", "node3")
+	h.assertUpdates(s, add("node2"), add("node3"))
+	h.changeStateTo("node1", "node2", "node3")
+	h.changeStateTo("node2", "node3")
+	h.assertMembers("node2", "node3")
+	h.assertUpdates(s, remove("node1"))
+
+	// Nodes removed by hand are left out until discovery drops them.
+	if err := h.c.Remove("node2"); err != nil {
+		t.Fatal(err)
+	}
+	h.assertUpdates(s, remove("node2"))
+	h.changeStateTo("node2", "node3")
+	h.add("node2")
+	h.assertMembers("node3")
+	h.changeStateTo("node3")
+	h.changeStateTo("node2", "node3")
+	h.assertMembers("node2", "node3")
+	h.assertUpdates(s, add("node2"))
+}
+
+func TestStats(t *testing.T) {
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	ch := make(chan cluster.ClusterUpdate)
+	c := cluster.NewCluster(makeNodes("node1"), ch, statsReceiver)
+	defer c.Close()
+
+	ch <- makeNodes("node2", "node3")
+	// Calling Sync makes sure the update has been applied
+	c.Sync()
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.ClusterMembersGauge:        {Checker: stats.Int64EqTest, Value: 2},
+			stats.ClusterNodesAddedCounter:   {Checker: stats.Int64EqTest, Value: 2},
+			stats.ClusterNodesRemovedCounter: {Checker: stats.Int64EqTest, Value: 1},
+			stats.ClusterTimeSinceUpdate_ms:  {Checker: stats.Int64EqTest, Value: 0},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
 // Below here are helpers that make it easy to write more fluent tests.
 
 type helper struct {
@@ -78,7 +173,7 @@ type helper struct {
 func makeHelper(t *testing.T) *helper {
 	h := &helper{t: t}
 	h.ch = make(chan cluster.ClusterUpdate)
-	h.c = cluster.NewCluster(nil, h.ch)
+	h.c = cluster.NewCluster(nil, h.ch, nil)
 	return h
 }
 
@@ -87,6 +182,7 @@ func (h *helper) close() {
 }
 
 func (h *helper) assertMembers(node ...string) {
+	h.c.Sync()
 	assertMembersEqual(makeNodes(node...), h.c.Members(), h.t)
 }
 
@@ -120,8 +216,8 @@ func (h *helper) assertInitialMembers(s cluster.Subscription, node ...string) {
 }
 
 func (h *helper) assertUpdates(s cluster.Subscription, expected ...cluster.NodeUpdate) {
-	// Calling Members makes sure that any updates sent have propagated from the cluster to the subscription
-	h.c.Members()
+	// Calling Sync makes sure that any updates sent have propagated from the cluster to the subscription
+	h.c.Sync()
 	actual := <-s.Updates
 	h.assertUpdatesEqual(expected, actual)
 }
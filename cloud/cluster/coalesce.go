@@ -0,0 +1,55 @@
+package cluster
+
+// Merges a sequence of updates applied to the cluster into the net updates for each node,
+// in the order nodes first appear. Updates are expected to be applicable in order, as
+// filterAndUpdate leaves them, so a node's adds and removes alternate.
+// * A node added and then removed, ex: flapping during discovery, has no updates.
+// * A node removed and then added back is removed and added once, so members reset its state.
+// * Only the last NodeUpdated of a node present at the end is kept, after its add if it was added.
+func coalesce(updates []NodeUpdate) []NodeUpdate {
+	type net struct {
+		wasMember bool        // whether the node was a member before the updates
+		first     *NodeUpdate // first add or remove
+		last      *NodeUpdate // last add or remove
+		updated   *NodeUpdate // last NodeUpdated after last
+	}
+	order := []NodeId{}
+	nets := map[NodeId]*net{}
+	for i := range updates {
+		u := &updates[i]
+		n, ok := nets[u.Id]
+		if !ok {
+			n = &net{wasMember: u.UpdateType != NodeAdded}
+			nets[u.Id] = n
+			order = append(order, u.Id)
+		}
+		if u.UpdateType == NodeUpdated {
+			n.updated = u
+			continue
+		}
+		if n.first == nil {
+			n.first = u
+		}
+		n.last = u
+		n.updated = nil
+	}
+
+	result := []NodeUpdate{}
+	for _, id := range order {
+		n := nets[id]
+		isMember := n.wasMember
+		if n.last != nil {
+			isMember = n.last.UpdateType == NodeAdded
+		}
+		if n.wasMember && n.first != nil {
+			result = append(result, *n.first)
+		}
+		if isMember && n.last != nil {
+			result = append(result, *n.last)
+		}
+		if isMember && n.updated != nil {
+			result = append(result, *n.updated)
+		}
+	}
+	return result
+}
@@ -2,12 +2,17 @@ package cluster
 
 import (
 	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
 )
 
 type fetchCron struct {
 	tickCh <-chan time.Time
 	f      Fetcher
 	outCh  chan ClusterUpdate
+	stat   stats.StatsReceiver
 }
 
 // Defines the way in which a full set of Nodes in a Cluster is retrieved
@@ -16,13 +21,18 @@ type Fetcher interface {
 }
 
 // Given a Fetcher implementation and a Ticker, returns a channel over which
-// ClusterUpdates will be sent to periodically from a new Goroutine
-func MakeFetchCron(f Fetcher, tickCh <-chan time.Time) chan ClusterUpdate {
+// ClusterUpdates will be sent to periodically from a new Goroutine.
+// Fetch stats are recorded to stat, which may be nil.
+func MakeFetchCron(f Fetcher, tickCh <-chan time.Time, stat stats.StatsReceiver) chan ClusterUpdate {
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
 	outCh := make(chan ClusterUpdate)
 	c := &fetchCron{
 		tickCh: tickCh,
 		f:      f,
 		outCh:  outCh,
+		stat:   stat,
 	}
 	go c.loop()
 	return outCh
@@ -30,9 +40,13 @@ func MakeFetchCron(f Fetcher, tickCh <-chan time.Time) chan ClusterUpdate {
 
 func (c *fetchCron) loop() {
 	for range c.tickCh {
+		latency := c.stat.Latency(stats.ClusterFetchLatency_ms).Time()
 		nodes, err := c.f.Fetch()
+		latency.Stop()
 		if err != nil {
 			// TODO(rcouto): Correctly handle as many errors as possible
+			log.Infof("Failed to fetch cluster nodes: %v", err)
+			c.stat.Counter(stats.ClusterFetchFailureCounter).Inc(1)
 			continue
 		}
 		c.outCh <- nodes
@@ -32,7 +32,7 @@ func makeCronHelper(t *testing.T) *cronHelper {
 		tickCh: make(chan time.Time),
 		f:      &fakeFetcher{},
 	}
-	h.ch = cluster.MakeFetchCron(h.f, h.tickCh)
+	h.ch = cluster.MakeFetchCron(h.f, h.tickCh, nil)
 	return h
 }
 
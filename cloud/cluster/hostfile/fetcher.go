@@ -0,0 +1,48 @@
+// Package hostfile provides a cluster Fetcher implementation that reads
+// worker addresses from a file, for deployments that manage their inventory
+// with config management rather than a discovery service.
+package hostfile
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// Reads nodes from the file at path, which has one worker address per line, ex: "host1:9091".
+// Blank lines and anything after a '#' are ignored.
+func MakeFetcher(path string) cluster.Fetcher {
+	return &fileFetcher{path: path}
+}
+
+type fileFetcher struct {
+	path string
+}
+
+// Implements cluster.Fetcher interface. Fails if the file can't be read,
+// so a missing file doesn't look like a cluster with no nodes.
+func (f *fileFetcher) Fetch() ([]cluster.Node, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseData(data), nil
+}
+
+func parseData(data []byte) []cluster.Node {
+	nodes := []cluster.Node{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		addr := strings.TrimSpace(line)
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		nodes = append(nodes, cluster.NewIdNode(addr))
+	}
+	return nodes
+}
@@ -0,0 +1,47 @@
+package hostfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+func TestFetcher(t *testing.T) {
+	hosts := `
+# rack 1
+host1:9091
+  host2:9091   # spare
+host1:9091
+
+host3:9091`
+	expected := []cluster.Node{
+		cluster.NewIdNode("host1:9091"),
+		cluster.NewIdNode("host2:9091"),
+		cluster.NewIdNode("host3:9091"),
+	}
+	dir, err := ioutil.TempDir("", "hostfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hosts")
+
+	f := MakeFetcher(path)
+	if _, err := f.Fetch(); err == nil {
+		t.Fatal("Expected an error fetching from a missing file")
+	}
+	if err := ioutil.WriteFile(path, []byte(hosts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := f.Fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, nodes) {
+		t.Fatalf("Parsed wrong: %v %v", expected, nodes)
+	}
+}
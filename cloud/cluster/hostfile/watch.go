@@ -0,0 +1,52 @@
+package hostfile
+
+import (
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// How often to check the file for changes when change notifications are missed or unavailable.
+const DefaultPollInterval = 10 * time.Second
+
+// Returns a channel that's sent the current time once immediately, then each time the file
+// at path changes, for use as the tick channel of cluster.MakeFetchCron.
+// Changes are noticed right away on linux using inotify, and within pollInterval otherwise.
+// A pollInterval <= 0 uses DefaultPollInterval.
+func Watch(path string, pollInterval time.Duration) <-chan time.Time {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	notifyCh, err := notifications(path)
+	if err != nil {
+		log.Infof("Watching %s by polling every %s: %v", path, pollInterval, err)
+	}
+	tickCh := make(chan time.Time)
+	go watch(path, pollInterval, notifyCh, tickCh)
+	return tickCh
+}
+
+func watch(path string, pollInterval time.Duration, notifyCh <-chan struct{}, tickCh chan<- time.Time) {
+	last, _ := os.Stat(path)
+	tickCh <- time.Now()
+	for {
+		select {
+		case <-notifyCh:
+		case <-time.After(pollInterval):
+		}
+		fi, _ := os.Stat(path)
+		if changed(last, fi) {
+			last = fi
+			tickCh <- time.Now()
+		}
+	}
+}
+
+// Checks whether the file was modified, replaced, created or removed. Either may be nil if the file doesn't exist.
+func changed(prev, cur os.FileInfo) bool {
+	if prev == nil || cur == nil {
+		return prev != cur
+	}
+	return !os.SameFile(prev, cur) || !prev.ModTime().Equal(cur.ModTime()) || prev.Size() != cur.Size()
+}
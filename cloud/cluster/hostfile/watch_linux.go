@@ -0,0 +1,45 @@
+// +build linux
+
+package hostfile
+
+import (
+	"path/filepath"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Returns a channel that's signalled when the directory containing path changes.
+// The directory is watched rather than the file so edits that write a new file and rename
+// it into place are seen. Signals are coalesced, callers check what actually changed.
+func notifications(path string) (<-chan struct{}, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+	mask := uint32(syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_CREATE |
+		syscall.IN_DELETE | syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM)
+	if _, err := syscall.InotifyAddWatch(fd, filepath.Dir(path), mask); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	notifyCh := make(chan struct{}, 1)
+	go func() {
+		buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+		for {
+			if _, err := syscall.Read(fd, buf); err != nil {
+				if err == syscall.EINTR {
+					continue
+				}
+				log.Errorf("Stopped watching %s with inotify, falling back to polling: %v", path, err)
+				syscall.Close(fd)
+				return
+			}
+			select {
+			case notifyCh <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return notifyCh, nil
+}
@@ -0,0 +1,11 @@
+// +build !linux
+
+package hostfile
+
+import (
+	"errors"
+)
+
+func notifications(path string) (<-chan struct{}, error) {
+	return nil, errors.New("file change notifications are only supported on linux")
+}
@@ -0,0 +1,53 @@
+package hostfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hostfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hosts")
+	if err := ioutil.WriteFile(path, []byte("host1:9091\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tickCh := Watch(path, 50*time.Millisecond)
+	expectTick(t, tickCh, "initial")
+
+	if err := ioutil.WriteFile(path, []byte("host1:9091\nhost2:9091\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectTick(t, tickCh, "edit")
+
+	// Replace the file the way editors and config management do, by renaming a new file over it.
+	tmp := filepath.Join(dir, "hosts.tmp")
+	if err := ioutil.WriteFile(tmp, []byte("host3:9091\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	expectTick(t, tickCh, "rename")
+
+	select {
+	case <-tickCh:
+		t.Fatal("Expected no tick without a change")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func expectTick(t *testing.T, tickCh <-chan time.Time, desc string) {
+	select {
+	case <-tickCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a tick after %s", desc)
+	}
+}
@@ -0,0 +1,166 @@
+// Package k8s provides a cluster Fetcher implementation that reads worker
+// addresses from the endpoints of a Kubernetes service, for schedulers
+// running in the same Kubernetes cluster as their workers.
+package k8s
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// Where Kubernetes mounts the credentials of a pod's service account.
+const ServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// How long a request for the service's endpoints may take.
+const requestTimeout = 10 * time.Second
+
+// Config for fetching workers from a service's endpoints. Zero values use the pod's in-cluster settings.
+type Config struct {
+	// Namespace of the service, defaults to the pod's namespace.
+	Namespace string
+	// Name of the service selecting the workers. Required.
+	Service string
+	// Name of the service port workers serve thrift on, may be empty if the service has one port.
+	Port string
+	// URL of the Kubernetes API server, defaults to https://$KUBERNETES_SERVICE_HOST:$KUBERNETES_SERVICE_PORT.
+	APIServer string
+	// File holding the bearer token requests carry, defaults to the service account's token.
+	// It's read on each fetch since tokens are rotated.
+	TokenPath string
+	// File holding the CA certificate the API server is verified with, defaults to the service account's.
+	CAPath string
+}
+
+// Reads nodes from the ready addresses of the service's endpoints, identified by "ip:port".
+func MakeFetcher(cfg Config) (cluster.Fetcher, error) {
+	if cfg.Service == "" {
+		return nil, fmt.Errorf("A Kubernetes service is required to fetch workers from")
+	}
+	client := &http.Client{Timeout: requestTimeout}
+	if cfg.APIServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("Not running in Kubernetes, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are unset")
+		}
+		cfg.APIServer = "https://" + net.JoinHostPort(host, port)
+		if cfg.TokenPath == "" {
+			cfg.TokenPath = filepath.Join(ServiceAccountDir, "token")
+		}
+		if cfg.CAPath == "" {
+			cfg.CAPath = filepath.Join(ServiceAccountDir, "ca.crt")
+		}
+	}
+	if cfg.Namespace == "" {
+		ns, err := ioutil.ReadFile(filepath.Join(ServiceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("A Kubernetes namespace is required outside a pod: %v", err)
+		}
+		cfg.Namespace = strings.TrimSpace(string(ns))
+	}
+	if cfg.CAPath != "" {
+		ca, err := ioutil.ReadFile(cfg.CAPath)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("No certificates found in %s", cfg.CAPath)
+		}
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	}
+	return &endpointsFetcher{
+		url:       fmt.Sprintf("%s/api/v1/namespaces/%s/endpoints/%s", strings.TrimSuffix(cfg.APIServer, "/"), cfg.Namespace, cfg.Service),
+		port:      cfg.Port,
+		tokenPath: cfg.TokenPath,
+		client:    client,
+	}, nil
+}
+
+type endpointsFetcher struct {
+	url       string
+	port      string
+	tokenPath string
+	client    *http.Client
+}
+
+// The parts of a Kubernetes Endpoints object used to find workers.
+type endpoints struct {
+	Subsets []struct {
+		Addresses []struct {
+			IP string `json:"ip"`
+		} `json:"addresses"`
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"subsets"`
+}
+
+// Implements cluster.Fetcher interface. Fails if the endpoints can't be read,
+// so an unreachable API server doesn't look like a cluster with no nodes.
+func (f *endpointsFetcher) Fetch() ([]cluster.Node, error) {
+	req, err := http.NewRequest("GET", f.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.tokenPath != "" {
+		token, err := ioutil.ReadFile(f.tokenPath)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error fetching %s: %s", f.url, resp.Status)
+	}
+	var ep endpoints
+	if err := json.NewDecoder(resp.Body).Decode(&ep); err != nil {
+		return nil, fmt.Errorf("Error decoding endpoints from %s: %v", f.url, err)
+	}
+	return parseEndpoints(&ep, f.port)
+}
+
+// Returns the addresses of every subset with the named port, or its only port if name is empty.
+func parseEndpoints(ep *endpoints, name string) ([]cluster.Node, error) {
+	nodes := []cluster.Node{}
+	seen := map[string]bool{}
+	for _, s := range ep.Subsets {
+		port := 0
+		for _, p := range s.Ports {
+			if p.Name == name || name == "" && len(s.Ports) == 1 {
+				port = p.Port
+			}
+		}
+		if port == 0 {
+			if len(s.Addresses) > 0 {
+				return nil, fmt.Errorf("Endpoints have no port named %q", name)
+			}
+			continue
+		}
+		for _, a := range s.Addresses {
+			addr := net.JoinHostPort(a.IP, strconv.Itoa(port))
+			if !seen[addr] {
+				seen[addr] = true
+				nodes = append(nodes, cluster.NewIdNode(addr))
+			}
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+const endpointsJSON = `{
+  "kind": "Endpoints",
+  "subsets": [
+    {
+      "addresses": [{"ip": "10.0.0.1"}, {"ip": "10.0.0.2"}],
+      "notReadyAddresses": [{"ip": "10.0.0.3"}],
+      "ports": [{"name": "http", "port": 9090}, {"name": "thrift", "port": 9091}]
+    },
+    {
+      "addresses": [{"ip": "10.0.1.1"}],
+      "ports": [{"name": "thrift", "port": 9191}, {"name": "http", "port": 9190}]
+    }
+  ]
+}`
+
+func TestFetcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "k8s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenPath, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Path != "/api/v1/namespaces/scoot/endpoints/workers" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write([]byte(endpointsJSON))
+	}))
+	defer server.Close()
+
+	f, err := MakeFetcher(Config{Namespace: "scoot", Service: "workers", Port: "thrift", APIServer: server.URL, TokenPath: tokenPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := f.Fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []cluster.Node{
+		cluster.NewIdNode("10.0.0.1:9091"),
+		cluster.NewIdNode("10.0.0.2:9091"),
+		cluster.NewIdNode("10.0.1.1:9191"),
+	}
+	if !reflect.DeepEqual(expected, nodes) {
+		t.Fatalf("Expected %v, got %v", expected, nodes)
+	}
+
+	// Without the named port, or a port name with several ports, there are no workers to find.
+	for _, port := range []string{"grpc", ""} {
+		f, _ = MakeFetcher(Config{Namespace: "scoot", Service: "workers", Port: port, APIServer: server.URL, TokenPath: tokenPath})
+		if _, err := f.Fetch(); err == nil {
+			t.Fatalf("Expected an error fetching port %q", port)
+		}
+	}
+	f, _ = MakeFetcher(Config{Namespace: "scoot", Service: "missing", Port: "thrift", APIServer: server.URL, TokenPath: tokenPath})
+	if _, err := f.Fetch(); err == nil {
+		t.Fatal("Expected an error fetching a missing service")
+	}
+	if _, err := MakeFetcher(Config{Namespace: "scoot", APIServer: server.URL}); err == nil {
+		t.Fatal("Expected an error without a service")
+	}
+}
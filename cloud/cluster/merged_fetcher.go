@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// A named Fetcher combined with others by a MergedFetcher.
+type FetcherSource struct {
+	Name    string
+	Fetcher Fetcher
+}
+
+// Fetches the nodes of several sources, ex: a static list, a hosts file and a discovery service,
+// for fleets that span environments, ex: during a migration from one to another.
+//
+// Sources are in order of precedence: when several report a node with the same id, the node
+// from the first of them is used. A source that fails to fetch keeps contributing the nodes
+// it last fetched, so an outage of one backend doesn't remove its nodes from the cluster.
+// Fetch only fails if every source fails.
+type MergedFetcher struct {
+	sources []FetcherSource
+	stat    stats.StatsReceiver
+
+	mu   sync.Mutex
+	last map[string][]Node // the last nodes fetched from each source, by name
+}
+
+// Creates a MergedFetcher for the given sources, which must have unique names. stat may be nil.
+func NewMergedFetcher(stat stats.StatsReceiver, sources ...FetcherSource) (*MergedFetcher, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("MergedFetcher needs at least one source")
+	}
+	names := map[string]bool{}
+	for _, s := range sources {
+		if names[s.Name] {
+			return nil, fmt.Errorf("Duplicate MergedFetcher source %q", s.Name)
+		}
+		names[s.Name] = true
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	return &MergedFetcher{sources: sources, stat: stat, last: map[string][]Node{}}, nil
+}
+
+// Implements Fetcher. Sources are fetched concurrently.
+func (m *MergedFetcher) Fetch() ([]Node, error) {
+	results := make([][]Node, len(m.sources))
+	errs := make([]error, len(m.sources))
+	var wg sync.WaitGroup
+	for i, s := range m.sources {
+		wg.Add(1)
+		go func(i int, s FetcherSource) {
+			defer wg.Done()
+			results[i], errs[i] = s.Fetcher.Fetch()
+		}(i, s)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	failed := []string{}
+	for i, s := range m.sources {
+		if errs[i] != nil {
+			log.Infof("Failed to fetch nodes from cluster source %s, using the %d it last returned: %v",
+				s.Name, len(m.last[s.Name]), errs[i])
+			m.stat.Counter(stats.ClusterSourceFetchFailureCounter).Inc(1)
+			failed = append(failed, fmt.Sprintf("%s: %v", s.Name, errs[i]))
+			continue
+		}
+		m.last[s.Name] = results[i]
+	}
+	if len(failed) == len(m.sources) {
+		return nil, fmt.Errorf("Failed to fetch nodes from every cluster source, %s", strings.Join(failed, ", "))
+	}
+
+	nodes := []Node{}
+	seen := map[NodeId]string{}
+	for _, s := range m.sources {
+		for _, n := range m.last[s.Name] {
+			if other, ok := seen[n.Id()]; ok {
+				if other != s.Name {
+					log.Debugf("Node %s from cluster source %s is also in %s, which takes precedence", n.Id(), s.Name, other)
+				}
+				continue
+			}
+			seen[n.Id()] = s.Name
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// Returns a Fetcher that always returns the given nodes, ex: workers registered by hand.
+func MakeStaticFetcher(nodes []Node) Fetcher {
+	return staticFetcher(nodes)
+}
+
+type staticFetcher []Node
+
+func (f staticFetcher) Fetch() ([]Node, error) {
+	return f, nil
+}
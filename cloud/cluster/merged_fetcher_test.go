@@ -0,0 +1,73 @@
+package cluster_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+type failingFetcher struct {
+	fakeFetcher
+	err error
+}
+
+func (f *failingFetcher) Fetch() ([]cluster.Node, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.fakeFetcher.Fetch()
+}
+
+func TestMergedFetcher(t *testing.T) {
+	static := cluster.MakeStaticFetcher(nodes([]string{"host1:1234", "host2:1234"}))
+	discovered := &failingFetcher{}
+	discovered.setResult(nodes([]string{"host2:1234", "host3:1234"}))
+	m, err := cluster.NewMergedFetcher(nil,
+		cluster.FetcherSource{Name: "static", Fetcher: static},
+		cluster.FetcherSource{Name: "discovered", Fetcher: discovered},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Nodes in both sources are only returned once, from the first source.
+	expected := nodes([]string{"host1:1234", "host2:1234", "host3:1234"})
+	actual, err := m.Fetch()
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("got %v: %v, expected %v", actual, err, expected)
+	}
+
+	// A failing source keeps its last nodes.
+	discovered.err = errors.New("unavailable")
+	actual, err = m.Fetch()
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("got %v: %v, expected %v", actual, err, expected)
+	}
+
+	discovered.err = nil
+	discovered.setResult(nodes([]string{"host4:1234"}))
+	expected = nodes([]string{"host1:1234", "host2:1234", "host4:1234"})
+	actual, err = m.Fetch()
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("got %v: %v, expected %v", actual, err, expected)
+	}
+}
+
+func TestMergedFetcherAllSourcesFail(t *testing.T) {
+	m, _ := cluster.NewMergedFetcher(nil,
+		cluster.FetcherSource{Name: "a", Fetcher: &failingFetcher{err: errors.New("unavailable")}},
+		cluster.FetcherSource{Name: "b", Fetcher: &failingFetcher{err: errors.New("unavailable")}},
+	)
+	if _, err := m.Fetch(); err == nil {
+		t.Fatal("Expected an error when every source fails")
+	}
+
+	if _, err := cluster.NewMergedFetcher(nil,
+		cluster.FetcherSource{Name: "a", Fetcher: &failingFetcher{}},
+		cluster.FetcherSource{Name: "a", Fetcher: &failingFetcher{}},
+	); err == nil {
+		t.Fatal("Expected an error for sources with the same name")
+	}
+}
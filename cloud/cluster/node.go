@@ -50,6 +50,8 @@ type NodeUpdateType int
 const (
 	NodeAdded NodeUpdateType = iota
 	NodeRemoved
+	// An existing node's metadata changed, ex: it was cordoned.
+	NodeUpdated
 )
 
 var _ Node = (*idNode)(nil)
@@ -60,6 +62,8 @@ type NodeUpdate struct {
 	Id            NodeId
 	Node          Node // Only set for adds
 	UserInitiated bool
+	// Only set for NodeUpdated. A cordoned node remains a member of the cluster but isn't given new tasks.
+	Cordoned bool
 }
 
 func (u *NodeUpdate) String() string {
@@ -94,3 +98,11 @@ func NewUserInitiatedRemove(id NodeId) NodeUpdate {
 	nu.UserInitiated = true
 	return nu
 }
+
+func NewCordon(id NodeId, cordoned bool) NodeUpdate {
+	return NodeUpdate{
+		UpdateType: NodeUpdated,
+		Id:         id,
+		Cordoned:   cordoned,
+	}
+}
@@ -87,6 +87,14 @@ func (s *state) filterAndUpdate(newUpdates []NodeUpdate) []NodeUpdate {
 				unused = append(unused, update)
 				continue
 			}
+		case update.UpdateType == NodeUpdated:
+			if ok {
+				// metadata isn't part of state, pass the update along for members
+				filtered = append(filtered, update)
+			} else {
+				unused = append(unused, update)
+				continue
+			}
 		}
 	}
 	if len(unused) == 0 || len(unused) == len(newUpdates) {
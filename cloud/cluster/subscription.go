@@ -3,6 +3,8 @@ package cluster
 import (
 	"io"
 	"time"
+
+	"github.com/twitter/scoot/common/stats"
 )
 
 // Subscription is way to monitor updates to cluster changes
@@ -19,6 +21,8 @@ type subscriber struct {
 	outCh chan []NodeUpdate
 	cl    *Cluster
 	queue []NodeUpdate
+
+	queuedAt time.Time // when the oldest update in queue was received
 }
 
 type closer struct {
@@ -59,8 +63,15 @@ func (s *subscriber) loop() {
 				s.inCh = nil
 				continue
 			}
+			if len(s.queue) == 0 {
+				s.queuedAt = time.Now()
+			}
 			s.queue = append(s.queue, updates...)
+			s.cl.addQueued(len(updates))
 		case outCh <- outgoing:
+			// How long the subscriber took to take updates, a slow subscriber acts on a stale view of the cluster
+			s.cl.stat.Histogram(stats.ClusterSubscriberLagHistogram_ms).Update(int64(time.Since(s.queuedAt) / time.Millisecond))
+			s.cl.addQueued(-len(s.queue))
 			s.queue = nil
 		}
 	}
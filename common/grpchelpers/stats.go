@@ -0,0 +1,105 @@
+package grpchelpers
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// StatsServerOptions returns ServerOptions installing interceptors that record, per method:
+// request counts, bytes received and sent, latency, and responses by status code.
+// Stats are scoped under "grpc/<Service>.<Method>", ex: "grpc/ByteStream.Read".
+func StatsServerOptions(stat stats.StatsReceiver) []grpc.ServerOption {
+	i := &statsInterceptor{stat: stat.Scope("grpc")}
+	return []grpc.ServerOption{grpc.UnaryInterceptor(i.unary), grpc.StreamInterceptor(i.stream)}
+}
+
+type statsInterceptor struct {
+	stat stats.StatsReceiver
+}
+
+func (i *statsInterceptor) unary(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	stat := i.stat.Scope(methodScope(info.FullMethod))
+	defer stat.Latency(stats.GRPCLatency_ms).Time().Stop()
+	stat.Counter(stats.GRPCRequestCounter).Inc(1)
+	stat.Counter(stats.GRPCBytesReceivedCounter).Inc(messageSize(req))
+
+	resp, err := handler(ctx, req)
+	if err == nil {
+		stat.Counter(stats.GRPCBytesSentCounter).Inc(messageSize(resp))
+	}
+	recordCode(stat, err)
+	return resp, err
+}
+
+func (i *statsInterceptor) stream(
+	srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	stat := i.stat.Scope(methodScope(info.FullMethod))
+	defer stat.Latency(stats.GRPCLatency_ms).Time().Stop()
+	stat.Counter(stats.GRPCRequestCounter).Inc(1)
+
+	err := handler(srv, &statsServerStream{ServerStream: ss, stat: stat})
+	recordCode(stat, err)
+	return err
+}
+
+// Counts the bytes of each message received and sent on a stream.
+type statsServerStream struct {
+	grpc.ServerStream
+	stat stats.StatsReceiver
+}
+
+func (s *statsServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.stat.Counter(stats.GRPCBytesSentCounter).Inc(messageSize(m))
+	}
+	return err
+}
+
+func (s *statsServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.stat.Counter(stats.GRPCBytesReceivedCounter).Inc(messageSize(m))
+	}
+	return err
+}
+
+// Counts the response under its status code, ex: "grpc/ByteStream.Read/NotFound/grpcResponseCounter".
+func recordCode(stat stats.StatsReceiver, err error) {
+	code := codes.OK
+	if err != nil {
+		code = codes.Unknown
+		if st, ok := status.FromError(err); ok {
+			code = st.Code()
+		}
+	}
+	stat.Scope(code.String()).Counter(stats.GRPCResponseCounter).Inc(1)
+}
+
+// Converts a full method name "/package.Service/Method" to "Service.Method".
+func methodScope(fullMethod string) string {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 {
+		return fullMethod
+	}
+	service := parts[0]
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+	return service + "." + parts[1]
+}
+
+func messageSize(m interface{}) int64 {
+	if pm, ok := m.(proto.Message); ok {
+		return int64(proto.Size(pm))
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package grpchelpers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// A ServerStream that receives a fixed message, and discards sent messages.
+type fakeServerStream struct {
+	grpc.ServerStream
+	recv proto.Message
+}
+
+func (f *fakeServerStream) SendMsg(m interface{}) error { return nil }
+
+func (f *fakeServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), f.recv)
+	return nil
+}
+
+func TestStatsInterceptor(t *testing.T) {
+	reg := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return reg }, 0)
+	i := &statsInterceptor{stat: stat.Scope("grpc")}
+
+	req := &remoteexecution.Digest{Hash: "abc", SizeBytes: 3}
+	resp := &remoteexecution.Digest{Hash: "abcdef", SizeBytes: 6}
+	info := &grpc.UnaryServerInfo{FullMethod: "/build.bazel.remote.execution.v2.ContentAddressableStorage/FindMissingBlobs"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return resp, nil }
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+	i.unary(context.Background(), req, info, ok)
+	i.unary(context.Background(), req, info, notFound)
+
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/google.bytestream.ByteStream/Read"}
+	i.stream(nil, &fakeServerStream{recv: req}, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		m := &remoteexecution.Digest{}
+		ss.RecvMsg(m)
+		ss.SendMsg(resp)
+		ss.SendMsg(resp)
+		return errors.New("not a status")
+	})
+
+	cas := "grpc/ContentAddressableStorage.FindMissingBlobs/"
+	bs := "grpc/ByteStream.Read/"
+	if !stats.StatsOk("", reg, t,
+		map[string]stats.Rule{
+			cas + stats.GRPCRequestCounter:                {Checker: stats.Int64EqTest, Value: 2},
+			cas + stats.GRPCBytesReceivedCounter:          {Checker: stats.Int64EqTest, Value: 2 * proto.Size(req)},
+			cas + stats.GRPCBytesSentCounter:              {Checker: stats.Int64EqTest, Value: proto.Size(resp)},
+			cas + "OK/" + stats.GRPCResponseCounter:       {Checker: stats.Int64EqTest, Value: 1},
+			cas + "NotFound/" + stats.GRPCResponseCounter: {Checker: stats.Int64EqTest, Value: 1},
+			bs + stats.GRPCRequestCounter:                 {Checker: stats.Int64EqTest, Value: 1},
+			bs + stats.GRPCBytesReceivedCounter:           {Checker: stats.Int64EqTest, Value: proto.Size(req)},
+			bs + stats.GRPCBytesSentCounter:               {Checker: stats.Int64EqTest, Value: 2 * proto.Size(resp)},
+			bs + "Unknown/" + stats.GRPCResponseCounter:   {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
@@ -12,11 +12,36 @@ const (
 		Free - available, not running
 		Running - running tasks
 		Lost - not responding to status requests
-	*/
-	ClusterAvailableNodes = "availableNodes"
-	ClusterFreeNodes      = "freeNodes"
-	ClusterRunningNodes   = "runningNodes"
-	ClusterLostNodes      = "lostNodes"
+		Cordoned - not given new tasks by an admin, in any other state
+		Blacklisted - not given new tasks after failing tasks that succeeded on other nodes, until a cooldown ends
+	*/
+	ClusterAvailableNodes   = "availableNodes"
+	ClusterFreeNodes        = "freeNodes"
+	ClusterRunningNodes     = "runningNodes"
+	ClusterLostNodes        = "lostNodes"
+	ClusterCordonedNodes    = "cordonedNodes"
+	ClusterBlacklistedNodes = "blacklistedNodes"
+
+	/*
+		Cluster membership metrics, emitted by each cloud/cluster Cluster:
+		* the number of members and the nodes added and removed
+		* the time since the cluster last received an update, ex: from its fetcher. This grows
+		  while fetching fails or stalls, freezing the cluster's view of its members
+		* updates waiting in subscriber queues, and how long subscribers took to take them
+		* the latency and failures of fetching the members, for clusters using a fetcher
+		* failures of individual sources of a MergedFetcher, whose last nodes are used instead
+		* updates dropped by merging the updates in a coalescing window into net updates
+	*/
+	ClusterMembersGauge              = "clusterMembers"
+	ClusterNodesAddedCounter         = "clusterNodesAddedCounter"
+	ClusterNodesRemovedCounter       = "clusterNodesRemovedCounter"
+	ClusterTimeSinceUpdate_ms        = "clusterTimeSinceUpdate_ms"
+	ClusterSubscriberQueuedGauge     = "clusterSubscriberQueuedUpdates"
+	ClusterSubscriberLagHistogram_ms = "clusterSubscriberLag_ms"
+	ClusterFetchLatency_ms           = "clusterFetchLatency_ms"
+	ClusterFetchFailureCounter       = "clusterFetchFailureCounter"
+	ClusterSourceFetchFailureCounter = "clusterSourceFetchFailureCounter"
+	ClusterCoalescedUpdatesCounter   = "clusterCoalescedUpdatesCounter"
 
 	/************************* Bundlestore metrics **************************/
 	/*
@@ -27,6 +52,65 @@ const (
 	BundlestoreDownloadErrCounter = "downloadErrCounter"
 	BundlestoreDownloadOkCounter  = "downloadOkCounter"
 
+	/*
+		Bundlestore persisted run log metrics (Expired logs deleted by the sweeper)
+	*/
+	BundlestoreLogsSweptCounter = "logsSweptCounter"
+
+	/*
+		Bundlestore peer metrics (Bundles fetched from and served to peer workers)
+	*/
+	BundlestorePeerHitCounter    = "peerHitCounter"
+	BundlestorePeerMissCounter   = "peerMissCounter"
+	BundlestorePeerServedCounter = "peerServedCounter"
+
+	/*
+		Bundlestore replication metrics (Writes to replicas that failed, reads that failed over to another replica,
+		missing replicas repaired or failing to be repaired, and repairs dropped because too many were pending)
+	*/
+	BundlestoreReplicaReadFailoverCounter  = "replicaReadFailoverCounter"
+	BundlestoreReplicaRepairCounter        = "replicaRepairCounter"
+	BundlestoreReplicaRepairDroppedCounter = "replicaRepairDroppedCounter"
+	BundlestoreReplicaRepairErrCounter     = "replicaRepairErrCounter"
+	BundlestoreReplicaWriteErrCounter      = "replicaWriteErrCounter"
+
+	/*
+		Bundlestore failover metrics (Reads and writes served by an upstream other than the first one configured,
+		and upstream bundlestores or git remotes marked unhealthy after failing repeatedly)
+	*/
+	BundlestoreFailoverReadCounter   = "failoverReadCounter"
+	BundlestoreFailoverWriteCounter  = "failoverWriteCounter"
+	FailoverUpstreamUnhealthyCounter = "failoverUpstreamUnhealthyCounter"
+
+	/*
+		Bundlestore multi-region routing metrics (Reads served by the local and remote region's stores,
+		and writes replicated, failing to replicate or dropped before replicating to the remote region)
+	*/
+	BundlestoreRoutingLocalHitCounter         = "routingLocalHitCounter"
+	BundlestoreRoutingRemoteHitCounter        = "routingRemoteHitCounter"
+	BundlestoreRoutingReplicateCounter        = "routingReplicateCounter"
+	BundlestoreRoutingReplicateDroppedCounter = "routingReplicateDroppedCounter"
+	BundlestoreRoutingReplicateErrCounter     = "routingReplicateErrCounter"
+
+	/*
+		Bundlestore cold storage metrics (Blobs mirrored, failing to mirror or dropped before mirroring
+		to the cold store, and hot store misses restored from the cold store or failing to be restored)
+	*/
+	BundlestoreMirrorCounter           = "mirrorCounter"
+	BundlestoreMirrorDroppedCounter    = "mirrorDroppedCounter"
+	BundlestoreMirrorErrCounter        = "mirrorErrCounter"
+	BundlestoreMirrorRestoreCounter    = "mirrorRestoreCounter"
+	BundlestoreMirrorRestoreErrCounter = "mirrorRestoreErrCounter"
+
+	/*
+		Bundlestore compaction metrics (Blobs and bytes packed into pack files, pack index entries pruned
+		by garbage collection, and compaction passes that failed)
+	*/
+	BundlestoreCompactedBlobsCounter   = "compactedBlobsCounter"
+	BundlestoreCompactedBytesCounter   = "compactedBytesCounter"
+	BundlestoreCompactionPrunedCounter = "compactionPrunedCounter"
+	BundlestoreCompactionErrCounter    = "compactionErrCounter"
+
 	/*
 		Bundlestore upload metrics (Writes/Puts to top-level Bundlestore/Apiserver)
 	*/
@@ -36,6 +120,27 @@ const (
 	BundlestoreUploadLatency_ms      = "uploadLatency_ms"
 	BundlestoreUploadOkCounter       = "uploadOkCounter"
 
+	/*
+		Bundlestore resumable upload metrics (Upload sessions started, chunks received, bundles assembled
+		from sessions or failing to, sessions in progress and the bytes they hold, and sessions or chunks
+		rejected because the server holds too many)
+	*/
+	BundlestoreUploadChunkCounter       = "uploadChunkCounter"
+	BundlestoreUploadSessionCounter     = "uploadSessionCounter"
+	BundlestoreUploadSessionErrCounter  = "uploadSessionErrCounter"
+	BundlestoreUploadSessionOkCounter   = "uploadSessionOkCounter"
+	BundlestoreUploadSessionsGauge      = "uploadSessionsGauge"
+	BundlestoreUploadSessionBytesGauge  = "uploadSessionBytesGauge"
+	BundlestoreUploadSessionFullCounter = "uploadSessionFullCounter"
+
+	/*
+		Bundlestore listing metrics (List requests, failing or succeeding, and their latency)
+	*/
+	BundlestoreListCounter    = "listCounter"
+	BundlestoreListErrCounter = "listErrCounter"
+	BundlestoreListOkCounter  = "listOkCounter"
+	BundlestoreListLatency_ms = "listLatency_ms"
+
 	/*
 	   Bundlestore request counters and uptime statistics
 	*/
@@ -119,11 +224,21 @@ const (
 
 	SchedAcceptedJobsGauge = "schedAcceptedJobsGauge"
 
+	/*
+		the percentage of recent CAS requests made on behalf of the scheduler that failed
+	*/
+	SchedCASErrorPercentGauge = "schedCASErrorPercentGauge"
+
 	/*
 		the number of tasks that have finished (including those that have been killed)
 	*/
 	SchedCompletedTaskCounter = "completedTaskCounter"
 
+	/*
+		the number of job requests that were admitted after waiting for a saturated cluster or CAS to recover
+	*/
+	SchedDelayedJobsCounter = "schedDelayedJobsCounter"
+
 	/*
 		The number of times any of the following conditions occurred:
 		- the task's command errored while running
@@ -132,6 +247,56 @@ const (
 	*/
 	SchedFailedTaskCounter = "failedTaskCounter"
 
+	/*
+		the number of tasks that failed in a way retrying wouldn't fix (their command failed
+		or the request was invalid), and so were completed without retrying
+	*/
+	SchedNonRetryableTaskFailuresCounter = "nonRetryableTaskFailuresCounter"
+
+	/*
+		the number of tasks failed without running because they need more CPU or memory than any worker has
+	*/
+	SchedUnsatisfiableTasksCounter = "unsatisfiableTasksCounter"
+
+	/*
+		the number of tasks moved to the dead letter queue after repeatedly failing with infrastructure errors,
+		the number requeued from it, and the number currently in it
+	*/
+	SchedDeadLetteredTasksCounter   = "deadLetteredTasksCounter"
+	SchedRequeuedDeadLettersCounter = "requeuedDeadLettersCounter"
+	SchedDeadLetterQueueGauge       = "deadLetterQueueGauge"
+
+	/*
+		the number of times a worker was blacklisted after repeatedly failing tasks that then succeeded on other
+		workers, worth alerting on since it points to a bad host
+	*/
+	SchedWorkersBlacklistedCounter = "workersBlacklistedCounter"
+
+	/*
+		the number of jobs killed and rolled back for running longer than their job timeout
+	*/
+	SchedJobTimeoutsCounter = "jobTimeoutsCounter"
+
+	/*
+		the number of jobs paused and resumed through the PauseJob and ResumeJob APIs
+	*/
+	SchedJobsPausedCounter  = "jobsPausedCounter"
+	SchedJobsResumedCounter = "jobsResumedCounter"
+
+	/*
+		the number of finished jobs added to the job archive, and the number that failed to be added
+	*/
+	SchedArchivedJobsCounter    = "archivedJobsCounter"
+	SchedArchiveFailuresCounter = "archiveFailuresCounter"
+
+	/*
+		Job webhook metrics (events delivered to webhooks, deliveries that failed after retrying,
+		and deliveries dropped because too many were pending)
+	*/
+	SchedJobWebhooksSentCounter    = "jobWebhooksSentCounter"
+	SchedJobWebhookFailuresCounter = "jobWebhookFailuresCounter"
+	SchedJobWebhooksDroppedCounter = "jobWebhooksDroppedCounter"
+
 	/*
 		the number of times the processing failed to serialize the workerapi status object
 	*/
@@ -158,6 +323,17 @@ const (
 	*/
 	SchedJobRequestsCounter = "schedJobRequestsCounter"
 
+	/*
+		the estimated time until a newly scheduled task starts, based on the number of waiting tasks,
+		healthy nodes and the recent average task duration
+	*/
+	SchedEstimatedQueueWait_ms = "schedEstimatedQueueWait_ms"
+
+	/*
+		the number of running and waiting tasks per healthy node, as a percentage
+	*/
+	SchedLoadPercentGauge = "schedLoadPercentGauge"
+
 	/*
 		the number of jobs with tasks running.  Only reported by requestor
 	*/
@@ -169,6 +345,73 @@ const (
 	*/
 	SchedNumRunningTasksGauge = "schedNumRunningTasksGauge"
 
+	/*
+		the number of speculative duplicates started for tasks running longer than their usual duration
+	*/
+	SchedSpeculativeTasksCounter = "schedSpeculativeTasksCounter"
+
+	/*
+		the number of tasks whose result came from a speculative duplicate rather than the original attempt
+	*/
+	SchedSpeculativeWinsCounter = "schedSpeculativeWinsCounter"
+
+	/*
+		the number of speculative duplicates currently running
+	*/
+	SchedSpeculativeTasksGauge = "schedSpeculativeTasksGauge"
+
+	/*
+		the number of waiting tasks given nodes that joined a saturated cluster, ahead of regular scheduling
+	*/
+	SchedRebalancedTasksCounter = "schedRebalancedTasksCounter"
+
+	/*
+		the number of long running tasks aborted to migrate them off cordoned nodes
+	*/
+	SchedMigratedTasksCounter = "schedMigratedTasksCounter"
+
+	/*
+		the number of gang jobs whose waiting tasks were all started together
+	*/
+	SchedGangJobsStartedCounter = "schedGangJobsStartedCounter"
+
+	/*
+		the number of times a gang job's running tasks were aborted to rerun them together with a failed task's retry
+	*/
+	SchedGangJobsRestartedCounter = "schedGangJobsRestartedCounter"
+
+	/*
+		the number of gang jobs killed because one of their tasks failed and wouldn't be retried
+	*/
+	SchedGangJobsFailedCounter = "schedGangJobsFailedCounter"
+
+	/*
+		the number of gang jobs waiting for enough free nodes to start all their waiting tasks at once,
+		and the number of free nodes held back for them, as of the last scheduling pass
+	*/
+	SchedGangJobsWaitingGauge = "schedGangJobsWaitingGauge"
+	SchedGangNodesHeldGauge   = "schedGangNodesHeldGauge"
+
+	/*
+		the number of healthy workers not running a task, as of the last autoscale check
+	*/
+	SchedIdleWorkersGauge = "schedIdleWorkersGauge"
+
+	/*
+		the number of scale-down requests sent to the autoscaler
+	*/
+	SchedAutoscaleDownCounter = "schedAutoscaleDownCounter"
+
+	/*
+		the number of scale-up requests sent to the autoscaler
+	*/
+	SchedAutoscaleUpCounter = "schedAutoscaleUpCounter"
+
+	/*
+		the number of scale requests the autoscaler failed
+	*/
+	SchedAutoscaleFailuresCounter = "schedAutoscaleFailuresCounter"
+
 	/*
 		the number of tasks waiting to start. (Only reported by requestor)
 	*/
@@ -194,6 +437,21 @@ const (
 	*/
 	SchedPriority2JobsGauge = "priority2JobsGauge"
 
+	/*
+		the number of recurring jobs scheduled from job templates
+	*/
+	SchedRecurringJobsCounter = "schedRecurringJobsCounter"
+
+	/*
+		the number of recurring job runs that failed to be scheduled
+	*/
+	SchedRecurringJobsFailedCounter = "schedRecurringJobsFailedCounter"
+
+	/*
+		the number of recurring job runs skipped because the previous run was still in progress
+	*/
+	SchedRecurringJobsSkippedCounter = "schedRecurringJobsSkippedCounter"
+
 	/*
 		the number of times the platform retried sending an end saga message
 	*/
@@ -230,6 +488,26 @@ const (
 	*/
 	SchedServerJobStatusLatency_ms = "jobStatusLatency_ms"
 
+	/*
+		the number of find jobs requests the thrift server received
+	*/
+	SchedServerFindJobsCounter = "findJobsRpmCounter"
+
+	/*
+		the amount of time it took to process a find jobs request (from the server)
+	*/
+	SchedServerFindJobsLatency_ms = "findJobsLatency_ms"
+
+	/*
+		the number of job manifest requests the thrift server received
+	*/
+	SchedServerJobManifestCounter = "jobManifestRpmCounter"
+
+	/*
+		the amount of time it took to process a job manifest request (from the server)
+	*/
+	SchedServerJobManifestLatency_ms = "jobManifestLatency_ms"
+
 	/*
 		the number of job run requests the thrift server received
 	*/
@@ -240,16 +518,58 @@ const (
 	*/
 	SchedServerRunJobLatency_ms = "runJobLatency_ms"
 
+	/*
+		the number of job validation requests the thrift server received
+	*/
+	SchedServerValidateJobCounter = "validateJobRpmCounter"
+
 	/*
 		The amount of time it takes to assign the tasks to nodes
 	*/
 	SchedTaskAssignmentsLatency_ms = "schedTaskAssignmentsLatency_ms"
 
+	/*
+		the number of jobs accepted, recorded under the "tagged" scope broken down by
+		job type, requestor and priority (see TaggedStatsReceiver)
+	*/
+	SchedTaggedJobsCounter = "schedTaggedJobsCounter"
+
+	/*
+		the time from a job being accepted to it completing, recorded under the "tagged" scope
+		broken down by job type, requestor and priority
+	*/
+	SchedTaggedJobLatencyHistogram_ms = "schedTaggedJobLatencyHistogram_ms"
+
+	/*
+		the time successful task runs took, recorded under the "tagged" scope broken down by
+		job type, requestor and priority
+	*/
+	SchedTaggedTaskLatencyHistogram_ms = "schedTaggedTaskLatencyHistogram_ms"
+
+	/*
+		the time from a job being accepted to each of its tasks first starting to run, and the number of
+		tasks that waited longer than their priority's queue SLO, recorded under the "tagged" scope broken
+		down by priority and, separately, by requestor (see scheduler.QueueSLOConfig)
+	*/
+	SchedTaggedTaskQueueTimeHistogram_ms = "schedTaggedTaskQueueTimeHistogram_ms"
+	SchedTaggedQueueSLOBreachCounter     = "schedTaggedQueueSLOBreachCounter"
+
+	/*
+		the number of tasks that have waited longer than their priority's queue SLO and haven't
+		started yet, recorded under the "tagged" scope broken down by priority
+	*/
+	SchedTaggedQueueSLOBreachingTasksGauge = "schedTaggedQueueSLOBreachingTasksGauge"
+
 	/*
 		the number of times the task runner had to retry the task start
 	*/
 	SchedTaskStartRetries = "taskStartRetries"
 
+	/*
+		the number of job requests rejected because the cluster or CAS was saturated
+	*/
+	SchedThrottledJobsCounter = "schedThrottledJobsCounter"
+
 	/*
 		The length of time the server has been running
 	*/
@@ -266,6 +586,39 @@ const (
 	*/
 	SchedStepLatency_ms = "schedStepLatency_ms"
 
+	/****************************** Saga metrics ****************************************/
+	/*
+		Saga log operation latencies and errors, scoped by the log's backend, ex: "sagalog/boltSagaLog/logMessageLatency_ms".
+		LogMessage is on the path of every task state change, so a slow backend slows down scheduling.
+	*/
+	SagaStartLatency_ms       = "startSagaLatency_ms"
+	SagaLogMessageLatency_ms  = "logMessageLatency_ms"
+	SagaGetMessagesLatency_ms = "getMessagesLatency_ms"
+	SagaGetActiveLatency_ms   = "getActiveSagasLatency_ms"
+	SagaLogErrorsCounter      = "sagaLogErrors"
+
+	/*
+		The number of sagas started or recovered that haven't ended
+	*/
+	SagaActiveGauge = "activeSagas"
+
+	/*
+		The number of compensating tasks started and ended, logged when rolling back aborted sagas
+	*/
+	SagaCompTasksStartedCounter = "compensatingTasksStarted"
+	SagaCompTasksEndedCounter   = "compensatingTasksEnded"
+
+	/*
+		Recovery of active sagas on scheduler startup:
+		* how long recovering them all took
+		* the number of sagas recovered and rescheduled
+		* errors reading the log while recovering, which are retried, and fatal ones, whose sagas are skipped
+	*/
+	SagaRecoveryTime_ms            = "sagaRecoveryTime_ms"
+	SagaRecoveredCounter           = "sagasRecovered"
+	SagaRecoveryErrorsCounter      = "sagaRecoveryErrors"
+	SagaRecoveryFatalErrorsCounter = "sagaRecoveryFatalErrors"
+
 	/******************************** Worker metrics **************************************/
 	/*
 		The number of runs the worker has currently running
@@ -312,6 +665,52 @@ const (
 	*/
 	WorkerActiveInitLatency_ms = "workerActiveInitLatency_ms"
 
+	/*
+		the number of bazel runs answered from the worker's local action cache because the
+		central ActionCache couldn't be reached
+	*/
+	WorkerLocalActionCacheHits = "workerLocalActionCacheHits"
+
+	/*
+		the number of runs whose combined stdout/stderr couldn't be persisted to the store
+	*/
+	WorkerLogPersistFailures = "workerLogPersistFailures"
+
+	/*
+		the number of uncompressed bytes of run logs persisted to the store
+	*/
+	WorkerLogPersistedBytes = "workerLogPersistedBytes"
+
+	/*
+		the number of runs whose combined stdout/stderr was persisted to the store
+	*/
+	WorkerLogsPersisted = "workerLogsPersisted"
+
+	/*
+		the number of persistent worker processes started to run commands
+	*/
+	WorkerPersistentWorkerStarts = "workerPersistentWorkerStarts"
+
+	/*
+		the number of commands run on an already running persistent worker process
+	*/
+	WorkerPersistentWorkerReuses = "workerPersistentWorkerReuses"
+
+	/*
+		the number of queued runs whose snapshot was checked out in the background while waiting for a slot
+	*/
+	WorkerPrefetches = "workerPrefetches"
+
+	/*
+		the number of background checkouts of queued runs that failed, those runs check out their snapshot again
+	*/
+	WorkerPrefetchFailures = "workerPrefetchFailures"
+
+	/*
+		the number of times the worker's self-test failed, keeping it from serving tasks
+	*/
+	WorkerSelfTestFailures = "workerSelfTestFailures"
+
 	/*
 		the amount of worker's memory currently consumed by the current command (and its subprocesses)
 		TODO- verify with Ryan that this description is correct
@@ -319,6 +718,37 @@ const (
 	*/
 	WorkerMemory = "memory"
 
+	/*
+		the number of processes found still running in a run's process group after its command exited,
+		which are then killed. scope is osexecer
+	*/
+	WorkerOrphanedProcesses = "orphanedProcesses"
+
+	/*
+		the number of runs that left processes running after their command exited. scope is osexecer
+	*/
+	WorkerOrphanedProcessRuns = "orphanedProcessRuns"
+
+	/*
+		the number of runs whose post-run hook failed
+	*/
+	WorkerPostRunHookFailures = "workerPostRunHookFailures"
+
+	/*
+		the number of runs that failed because their pre-run hook failed
+	*/
+	WorkerPreRunHookFailures = "workerPreRunHookFailures"
+
+	/*
+		the number of runs that failed because a requested secret couldn't be resolved
+	*/
+	WorkerSecretFailures = "workerSecretFailures"
+
+	/*
+		the number of runs that failed because the GPUs they requested couldn't be allocated
+	*/
+	WorkerGPUAllocationFailures = "workerGPUAllocationFailures"
+
 	/*
 		the number of abort requests received by the worker
 	*/
@@ -329,6 +759,21 @@ const (
 	*/
 	WorkerServerClears = "clears"
 
+	/*
+		The number of QueryRunHistory requests received by the worker server
+	*/
+	WorkerServerHistoryQueries = "historyQueries"
+
+	/*
+		the number of times the worker's debug settings were changed
+	*/
+	WorkerServerDebugSettingsChanges = "debugSettingsChanges"
+
+	/*
+		The number of Handshake requests received by the worker server
+	*/
+	WorkerServerHandshakes = "handshakes"
+
 	/*
 		The number of QueryWorker requests received by the worker server
 	*/
@@ -354,6 +799,12 @@ const (
 	*/
 	WorkerTaskLatency_ms = "workerTaskLatency_ms"
 
+	/*
+		The time it takes to run the task (including snapshot handling), recorded under the "tagged" scope
+		broken down by run type (scoot or bazel) and the run's final state
+	*/
+	WorkerTaggedTaskLatencyHistogram_ms = "workerTaggedTaskLatencyHistogram_ms"
+
 	/*
 		Time since the most recent run, status, abort, erase request
 	*/
@@ -396,6 +847,25 @@ const (
 	*/
 	GitStreamUpdateFetches = "gitStreamUpdateFetches"
 
+	/*
+		The number of times a gitdb stream was fetched from a mirror because its Remote failed
+	*/
+	GitStreamMirrorFetches = "gitStreamMirrorFetches"
+
+	/*
+		The number of gitdb checkouts that didn't match their snapshot and were checked out again,
+		and the number that still didn't match afterwards and failed
+	*/
+	GitDBCheckoutCorruptionsCounter    = "gitdbCheckoutCorruptionsCounter"
+	GitDBCheckoutRepairFailuresCounter = "gitdbCheckoutRepairFailuresCounter"
+
+	/*
+		The number of gitdb work trees holding checkouts of git commit snapshots,
+		and the number of released work trees kept to be reused
+	*/
+	GitDBWorkTreesInUseGauge = "gitdbWorkTreesInUseGauge"
+	GitDBWorkTreesIdleGauge  = "gitdbWorkTreesIdleGauge"
+
 	/****************************** Bazel Metrics **********************************************/
 
 	/****************************** Execution Service ******************************************/
@@ -406,6 +876,33 @@ const (
 	BzExecFailureCounter = "bzExecFailureCounter"
 	BzExecLatency_ms     = "bzExecLatency_ms"
 
+	/*
+		Execute requests rejected because the Action or its inputs were missing from the CAS,
+		and the time spent checking the CAS for them
+	*/
+	BzExecMissingInputsCounter     = "bzExecMissingInputsCounter"
+	BzExecValidateInputsLatency_ms = "bzExecValidateInputsLatency_ms"
+
+	/*
+		Execute requests being processed concurrently, and those rejected for exceeding the concurrency limit
+	*/
+	BzExecInFlightGauge           = "bzExecInFlightGauge"
+	BzExecConcurrencyLimitCounter = "bzExecConcurrencyLimitCounter"
+
+	/*
+		Scheduler queue depth and estimated queue wait reported to clients with each scheduled Execute request
+	*/
+	BzExecQueueDepthHistogram           = "bzExecQueueDepthHistogram"
+	BzExecQueueWaitEstimateHistogram_ms = "bzExecQueueWaitEstimateHistogram_ms"
+
+	/*
+		Build Event Service events published for operations by Scheduler, operation streams that failed,
+		and operations not published for exceeding the stream limit
+	*/
+	BzBESEventsCounter  = "bzBESEventsCounter"
+	BzBESFailureCounter = "bzBESFailureCounter"
+	BzBESDroppedCounter = "bzBESDroppedCounter"
+
 	/*
 		Longrunning GetOperation API metrics emitted by Scheduler
 	*/
@@ -413,6 +910,29 @@ const (
 	BzGetOpFailureCounter = "bzGetOpFailureCounter"
 	BzGetOpLatency_ms     = "bzGetOpLatency_ms"
 
+	/*
+		Longrunning ListOperations API metrics emitted by Scheduler, and the number of
+		operations returned per request
+	*/
+	BzListOpsSuccessCounter  = "bzListOpsSuccessCounter"
+	BzListOpsFailureCounter  = "bzListOpsFailureCounter"
+	BzListOpsLatency_ms      = "bzListOpsLatency_ms"
+	BzListOpsLengthHistogram = "bzListOpsLengthHistogram"
+
+	/****************************** gRPC Server metrics *****************************************/
+	/*
+		Recorded for each method of the CAS and Execution gRPC servers, scoped by "grpc/<Service>.<Method>"
+		ex: grpc/ByteStream.Read/grpcRequestCounter
+
+		GRPCResponseCounter is further scoped by the response's status code
+		ex: grpc/ByteStream.Read/NotFound/grpcResponseCounter
+	*/
+	GRPCRequestCounter       = "grpcRequestCounter"
+	GRPCResponseCounter      = "grpcResponseCounter"
+	GRPCBytesReceivedCounter = "grpcBytesReceivedCounter"
+	GRPCBytesSentCounter     = "grpcBytesSentCounter"
+	GRPCLatency_ms           = "grpcLatency_ms"
+
 	/****************************** Worker/Invoker Execution Timings ***************************/
 	/*
 		Execution metadata timing metrics emitted by Worker.
@@ -437,12 +957,19 @@ const (
 	BzFindBlobsLatency_ms      = "bzFindBlobsLatency_ms"
 
 	/*
-		CAS Read API metrics emitted by Apiserver
+		FindMissingBlobs existence cache metrics, counting digests answered without checking the Store
 	*/
-	BzReadSuccessCounter = "bzReadSuccessCounter"
-	BzReadFailureCounter = "bzReadFailureCounter"
-	BzReadBytesHistogram = "bzReadBytesHistogram"
-	BzReadLatency_ms     = "bzReadLatency_ms"
+	BzFindBlobsMissingCacheHitCounter = "bzFindBlobsMissingCacheHitCounter"
+	BzFindBlobsPresentCacheHitCounter = "bzFindBlobsPresentCacheHitCounter"
+
+	/*
+		CAS Read API metrics emitted by Apiserver. BzReadInflightCounter counts Reads streamed from a Write still in progress
+	*/
+	BzReadSuccessCounter  = "bzReadSuccessCounter"
+	BzReadFailureCounter  = "bzReadFailureCounter"
+	BzReadBytesHistogram  = "bzReadBytesHistogram"
+	BzReadLatency_ms      = "bzReadLatency_ms"
+	BzReadInflightCounter = "bzReadInflightCounter"
 
 	/*
 		CAS Write API metrics emitted by Apiserver
@@ -460,6 +987,16 @@ const (
 	BzBatchUpdateLengthHistogram = "bzBatchUpdateLengthHistogram"
 	BzBatchUpdateLatency_ms      = "bzBatchUpdateLatency_ms"
 
+	/*
+		Number of blobs rejected by CAS Write and BatchUpdateBlobs for exceeding the max blob size
+	*/
+	BzBlobTooLargeCounter = "bzBlobTooLargeCounter"
+
+	/*
+		Number of CAS Writes and BatchUpdateBlobs requests rejected for failing their client-sent CRC32C checksums
+	*/
+	BzChecksumMismatchCounter = "bzChecksumMismatchCounter"
+
 	/*
 		CAS BatchReadBlobs API metrics emitted by Apiserver
 	*/
@@ -481,10 +1018,74 @@ const (
 	BzGetActionFailureCounter = "bzGetActionFailureCounter"
 	BzGetActionLatency_ms     = "bzGetActionLatency_ms"
 
+	/*
+		Bytes of stdout, stderr and output files inlined in GetActionResult responses at the client's request
+	*/
+	BzGetActionInlinedBytesCounter = "bzGetActionInlinedBytesCounter"
+
 	/*
 		UpdateActionResult API metrics emitted by Apiserver
 	*/
 	BzUpdateActionSuccessCounter = "bzUpdateActionSuccessCounter"
 	BzUpdateActionFailureCounter = "bzUpdateActionFailureCounter"
 	BzUpdateActionLatency_ms     = "bzUpdateActionLatency_ms"
+
+	/*
+		ActionCache and CAS usage metrics emitted by Apiserver, scoped by the tool name in the client's RequestMetadata
+	*/
+	BzUsageACHitCounter         = "bzUsageACHitCounter"
+	BzUsageACMissCounter        = "bzUsageACMissCounter"
+	BzUsageUploadedBlobsCounter = "bzUsageUploadedBlobsCounter"
+	BzUsageUploadedBytesCounter = "bzUsageUploadedBytesCounter"
+
+	/*
+		CAS quota metrics emitted by Apiserver, scoped by instance name for instances with their own quota:
+		uploads rejected for exceeding the quota, and bytes uploaded in the current quota window
+	*/
+	BzQuotaExceededCounter = "bzQuotaExceededCounter"
+	BzQuotaUsedBytesGauge  = "bzQuotaUsedBytesGauge"
+
+	/*
+		CAS sharding metrics emitted by Apiserver: requests forwarded to the node owning their digests,
+		forwarding failures, and the number of nodes in the shard ring
+	*/
+	BzShardForwardCounter        = "bzShardForwardCounter"
+	BzShardForwardFailureCounter = "bzShardForwardFailureCounter"
+	BzShardMembersGauge          = "bzShardMembersGauge"
+
+	/*
+		CAS upstream proxy metrics emitted by Apiserver: blobs and ActionResults fetched from the upstream
+		on local misses, blobs and ActionResults written through to it, and failed upstream requests
+	*/
+	BzUpstreamFetchCounter   = "bzUpstreamFetchCounter"
+	BzUpstreamWriteCounter   = "bzUpstreamWriteCounter"
+	BzUpstreamFailureCounter = "bzUpstreamFailureCounter"
+
+	/*
+		CAS scrubber metrics emitted by Apiserver: blobs and bytes verified against their digests,
+		corrupted blobs removed, passes that failed and the duration of the last pass
+	*/
+	BzScrubCheckedCounter      = "bzScrubCheckedCounter"
+	BzScrubCheckedBytesCounter = "bzScrubCheckedBytesCounter"
+	BzScrubCorruptedCounter    = "bzScrubCorruptedCounter"
+	BzScrubFailureCounter      = "bzScrubFailureCounter"
+	BzScrubLastPassLatency_ms  = "bzScrubLastPassLatency_ms"
+
+	/*
+		CAS mode metrics emitted by Apiserver: the current mode (0 normal, 1 read-only, 2 maintenance),
+		and requests rejected because the mode doesn't allow them
+	*/
+	BzModeGauge           = "bzModeGauge"
+	BzModeRejectedCounter = "bzModeRejectedCounter"
+
+	/*
+		CAS TTL report metrics emitted by Apiserver for a sample of blobs and ActionResults, scoped by TTL class:
+		entries whose TTL ran out and those never read, storage held after entries were last read in byte-hours,
+		entries needed again after their TTL ran out, and the TTL that would have kept 95% of entries until last read
+	*/
+	BzTTLExpiredCounter           = "bzTTLExpiredCounter"
+	BzTTLUnusedCounter            = "bzTTLUnusedCounter"
+	BzTTLWastedByteHoursCounter   = "bzTTLWastedByteHoursCounter"
+	BzTTLPrematureEvictionCounter = "bzTTLPrematureEvictionCounter"
+	BzTTLSuggestedTTLGauge_ms     = "bzTTLSuggestedTTLGauge_ms"
 )
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"sync"
+)
+
+// The default number of distinct values kept for each tag key.
+const DefaultMaxTagValues = 50
+
+const (
+	// Scope under which tagged stats are recorded
+	TaggedScope = "tagged"
+
+	// Value recorded for tags that are empty
+	NoTagValue = "none"
+
+	// Value recorded for tags whose key already has its maximum number of distinct values
+	OtherTagValue = "other"
+)
+
+// A dimension a stat can be broken down by, ex: the requestor of a job.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// TaggedStatsReceiver records stats broken down by tags so dashboards can compare workloads:
+//
+//	tagged.Tagged(Tag{"requestor", "ci"}, Tag{"priority", "1"}).Counter("jobs")  // is equivalent to
+//	statsReceiver.Counter("tagged", "requestor", "ci", "priority", "1", "jobs")
+//
+// Every tag combination is its own stat, so to cap cardinality each tag key keeps at most
+// maxValues distinct values. Values seen after that are recorded as OtherTagValue.
+// Tags should always be given in the same order so each combination maps to one stat.
+type TaggedStatsReceiver struct {
+	stat      StatsReceiver
+	maxValues int
+
+	mu     sync.Mutex
+	values map[string]map[string]bool
+}
+
+// Creates a TaggedStatsReceiver recording under stat. If maxValues <= 0, DefaultMaxTagValues.
+func NewTaggedStatsReceiver(stat StatsReceiver, maxValues int) *TaggedStatsReceiver {
+	if stat == nil {
+		stat = NilStatsReceiver()
+	}
+	if maxValues <= 0 {
+		maxValues = DefaultMaxTagValues
+	}
+	return &TaggedStatsReceiver{stat: stat, maxValues: maxValues, values: map[string]map[string]bool{}}
+}
+
+// Returns a StatsReceiver whose stats are broken down by tags.
+func (t *TaggedStatsReceiver) Tagged(tags ...Tag) StatsReceiver {
+	scope := []string{TaggedScope}
+	for _, tag := range tags {
+		scope = append(scope, tag.Key, t.value(tag))
+	}
+	return t.stat.Scope(scope...)
+}
+
+// Returns the value to record for tag, replacing it if its key has too many values.
+func (t *TaggedStatsReceiver) value(tag Tag) string {
+	if tag.Value == "" {
+		return NoTagValue
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	seen, ok := t.values[tag.Key]
+	if !ok {
+		seen = map[string]bool{}
+		t.values[tag.Key] = seen
+	}
+	if seen[tag.Value] {
+		return tag.Value
+	}
+	if len(seen) >= t.maxValues {
+		return OtherTagValue
+	}
+	seen[tag.Value] = true
+	return tag.Value
+}
@@ -0,0 +1,25 @@
+package stats
+
+import (
+	"testing"
+)
+
+func TestTaggedStatsReceiver(t *testing.T) {
+	reg := NewFinagleStatsRegistry()
+	stat, _ := NewCustomStatsReceiver(func() StatsRegistry { return reg }, 0)
+	tagged := NewTaggedStatsReceiver(stat, 2)
+
+	for _, requestor := range []string{"a", "b", "c", "a", "d", ""} {
+		tagged.Tagged(Tag{"requestor", requestor}, Tag{"priority", "1"}).Counter("jobs").Inc(1)
+	}
+
+	if !StatsOk("", reg, t, map[string]Rule{
+		"tagged/requestor/a/priority/1/jobs":     {Checker: Int64EqTest, Value: 2},
+		"tagged/requestor/b/priority/1/jobs":     {Checker: Int64EqTest, Value: 1},
+		"tagged/requestor/other/priority/1/jobs": {Checker: Int64EqTest, Value: 2},
+		"tagged/requestor/none/priority/1/jobs":  {Checker: Int64EqTest, Value: 1},
+		"tagged/requestor/c/priority/1/jobs":     {Checker: DoesNotExistTest, Value: nil},
+	}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
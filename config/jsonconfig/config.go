@@ -1,10 +1,13 @@
 package jsonconfig
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
@@ -85,6 +88,43 @@ func (schema Schema) Parse(text []byte) (Configuration, error) {
 	return result, nil
 }
 
+// Validate checks text against the schema more strictly than Parse, to catch mistakes in a config offline.
+// Besides the errors Parse returns, options the schema doesn't have and fields the chosen Implementation
+// doesn't have are errors. All the problems found are returned in one error.
+func (schema Schema) Validate(text []byte) error {
+	if _, err := schema.Parse(text); err != nil {
+		return err
+	}
+	var parsedConfig map[string]json.RawMessage
+	if len(text) == 0 {
+		text = emptyJson
+	}
+	if err := json.Unmarshal(text, &parsedConfig); err != nil {
+		return fmt.Errorf("Couldn't parse top-level config: %v", err)
+	}
+
+	problems := []string{}
+	for optionName, optionText := range parsedConfig {
+		impls, ok := schema[optionName]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown option %v", optionName))
+			continue
+		}
+		implName, _ := parseType(optionText)
+		impl := impls[implName]
+		dec := json.NewDecoder(bytes.NewReader(optionText))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&impl); err != nil {
+			problems = append(problems, fmt.Sprintf("option %v: %v", optionName, err))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("Invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 // Find the type, which is simply the string value for the key "Type"
 func parseType(data json.RawMessage) (string, error) {
 	if len(data) == 0 {
@@ -117,8 +157,13 @@ func mergeMaps(x1, x2 map[string]interface{}) interface{} {
 // GetConfigText finds the right text for a configFlag.
 // If configFlag looks like a filename (of the form foo.bar where foo and bar are just alphanumeric),
 // read it as an asset. This filename can optionally have appended json (foo.bar.<json>) to override the file.
+// If configFlag is a path starting with '/' or '.', read it from disk.
 // Otherwise, assume it's the literal json text.
 func GetConfigText(configFlag string, asset func(string) ([]byte, error)) ([]byte, error) {
+	if strings.HasPrefix(configFlag, "/") || strings.HasPrefix(configFlag, ".") {
+		log.Infof("reading config file %v", configFlag)
+		return ioutil.ReadFile(configFlag)
+	}
 	if matched, _ := regexp.Match(`^[[:alnum:]]*\.[[:alnum:]]*\.*.*$`, []byte(configFlag)); matched {
 		split := strings.SplitN(configFlag, ".", 3)
 		configFileName := path.Join("config", split[0]+"."+split[1])
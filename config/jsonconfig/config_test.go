@@ -134,6 +134,36 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	o := Schema(map[string]Implementations{
+		"Foo": {
+			"default": &fooDefaultConfig{},
+			"":        &fooDefaultConfig{Type: "default"},
+		},
+		"Bar": {
+			"twoarg": &barTwoargConfig{},
+			"":       &barTwoargConfig{Type: "twoarg"},
+		},
+	})
+
+	cases := []struct {
+		input string
+		ok    bool
+	}{
+		{"", true},
+		{config3, true},
+		{`{"Bar": {"Type": "twoarg", "Arg1": 1, "Arg5": 5}}`, false},
+		{`{"Baz": {"Type": "default"}}`, false},
+		{`{"Bar": {"Type": "threearg"}}`, false},
+		{`{"Bar": {"Type": "twoarg", "Arg1": "one"}}`, false},
+	}
+	for i, c := range cases {
+		if err := o.Validate([]byte(c.input)); (err == nil) != c.ok {
+			t.Errorf("Error for %d %v: got %v, expected ok=%v", i, c.input, err, c.ok)
+		}
+	}
+}
+
 func TestGetConfigText(t *testing.T) {
 	assets := map[string][]byte{
 		"config/local.local": []byte(`{"a":"yes","b":"no","c":{"j":"k","x":"y"}}`),
@@ -2,10 +2,14 @@ package scootconfig
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/hostfile"
+	"github.com/twitter/scoot/cloud/cluster/k8s"
 	"github.com/twitter/scoot/cloud/cluster/local"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/ice"
 )
 
@@ -20,25 +24,181 @@ func (c *ClusterMemoryConfig) Install(bag *ice.MagicBag) {
 	bag.Put(c.Create)
 }
 
-func (c *ClusterMemoryConfig) Create() (*cluster.Cluster, error) {
+func (c *ClusterMemoryConfig) Create(stat stats.StatsReceiver) (*cluster.Cluster, error) {
 	workerNodes := make([]cluster.Node, c.Count)
 	for i := 0; i < c.Count; i++ {
 		workerNodes[i] = cluster.NewIdNode(fmt.Sprintf("inmemory%d", i))
 	}
-	return cluster.NewCluster(workerNodes, nil), nil
+	return cluster.NewCluster(workerNodes, nil, stat), nil
 }
 
 // Parameters for configuring a Scoot cluster that will have locally-run components.
+// CoalesceWindow - how long membership updates are held to be merged into net updates, human readable
+// ex: "5s", see cluster.NewCoalescingCluster. Updates are sent right away if it isn't set.
 type ClusterLocalConfig struct {
-	Type string
+	Type           string
+	CoalesceWindow string
 }
 
 func (c *ClusterLocalConfig) Install(bag *ice.MagicBag) {
 	bag.Put(c.Create)
 }
 
-func (c *ClusterLocalConfig) Create() (*cluster.Cluster, error) {
-	f := local.MakeFetcher("workerserver", "thrift_addr")
-	updates := cluster.MakeFetchCron(f, time.NewTicker(time.Second).C)
-	return cluster.NewCluster(nil, updates), nil
+func (c *ClusterLocalConfig) Create(stat stats.StatsReceiver) (*cluster.Cluster, error) {
+	window, err := parseCoalesceWindow(c.CoalesceWindow)
+	if err != nil {
+		return nil, err
+	}
+	f := local.MakeFetcher("scoot worker", "thrift_addr")
+	updates := cluster.MakeFetchCron(f, time.NewTicker(time.Second).C, stat)
+	return cluster.NewCoalescingCluster(nil, updates, window, stat), nil
+}
+
+// Parameters for configuring a Scoot cluster from a file of worker addresses, one per line.
+// The cluster is updated whenever the file changes.
+// PollInterval - how often to check the file if changes are missed, human readable ex: "10s"
+// CoalesceWindow - as for ClusterLocalConfig
+type ClusterFileConfig struct {
+	Type           string
+	Path           string
+	PollInterval   string
+	CoalesceWindow string
+}
+
+func (c *ClusterFileConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+func (c *ClusterFileConfig) Create(stat stats.StatsReceiver) (*cluster.Cluster, error) {
+	if c.Path == "" {
+		return nil, fmt.Errorf("Cluster Path must be set for a file cluster")
+	}
+	var pollInterval time.Duration
+	if c.PollInterval != "" {
+		var err error
+		if pollInterval, err = time.ParseDuration(c.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+	window, err := parseCoalesceWindow(c.CoalesceWindow)
+	if err != nil {
+		return nil, err
+	}
+	f := hostfile.MakeFetcher(c.Path)
+	updates := cluster.MakeFetchCron(f, hostfile.Watch(c.Path, pollInterval), stat)
+	return cluster.NewCoalescingCluster(nil, updates, window, stat), nil
+}
+
+// Parameters for one source of a merged cluster.
+// Type - "static" for the comma separated worker addresses in Addrs, "file" for the hosts file at Path,
+// "local" for workers running on this host, or "k8s" for the ready endpoints of the Kubernetes Service
+// in Namespace, on its port named Port, see k8s.Config.
+// Name - identifies the source in logs, defaults to Type.
+type ClusterSourceConfig struct {
+	Type      string
+	Name      string
+	Addrs     string
+	Path      string
+	Namespace string
+	Service   string
+	Port      string
+}
+
+// Parameters for configuring a Scoot cluster from several sources, see cluster.MergedFetcher.
+// Sources - in order of precedence, a worker in several sources is taken from the first.
+// PollInterval - how often to fetch from every source, human readable ex: "10s".
+// The cluster is also updated whenever the file of a "file" source changes.
+// CoalesceWindow - as for ClusterLocalConfig
+// Workers added or removed by hand with the scheduler's AddWorker and RemoveWorker APIs are applied
+// over the merged sources until they agree, see cluster.Cluster.Add.
+type ClusterMergedConfig struct {
+	Type           string
+	Sources        []ClusterSourceConfig
+	PollInterval   string
+	CoalesceWindow string
+}
+
+// Used if PollInterval isn't set.
+const DefaultClusterMergedPollInterval = 10 * time.Second
+
+func (c *ClusterMergedConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+func (c *ClusterMergedConfig) Create(stat stats.StatsReceiver) (*cluster.Cluster, error) {
+	pollInterval := DefaultClusterMergedPollInterval
+	if c.PollInterval != "" {
+		var err error
+		if pollInterval, err = time.ParseDuration(c.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+	window, err := parseCoalesceWindow(c.CoalesceWindow)
+	if err != nil {
+		return nil, err
+	}
+	sources := []cluster.FetcherSource{}
+	ticks := []<-chan time.Time{time.NewTicker(pollInterval).C}
+	for _, s := range c.Sources {
+		source := cluster.FetcherSource{Name: s.Name}
+		if source.Name == "" {
+			source.Name = s.Type
+		}
+		switch s.Type {
+		case "static":
+			nodes := []cluster.Node{}
+			for _, addr := range strings.Split(s.Addrs, ",") {
+				if addr = strings.TrimSpace(addr); addr != "" {
+					nodes = append(nodes, cluster.NewIdNode(addr))
+				}
+			}
+			source.Fetcher = cluster.MakeStaticFetcher(nodes)
+		case "file":
+			if s.Path == "" {
+				return nil, fmt.Errorf("Cluster source %s needs a Path", source.Name)
+			}
+			source.Fetcher = hostfile.MakeFetcher(s.Path)
+			ticks = append(ticks, hostfile.Watch(s.Path, pollInterval))
+		case "local":
+			source.Fetcher = local.MakeFetcher("scoot worker", "thrift_addr")
+		case "k8s":
+			if source.Fetcher, err = k8s.MakeFetcher(k8s.Config{Namespace: s.Namespace, Service: s.Service, Port: s.Port}); err != nil {
+				return nil, fmt.Errorf("Cluster source %s: %v", source.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("Unknown cluster source type %q, expected static, file, local or k8s", s.Type)
+		}
+		sources = append(sources, source)
+	}
+	f, err := cluster.NewMergedFetcher(stat, sources...)
+	if err != nil {
+		return nil, err
+	}
+	updates := cluster.MakeFetchCron(f, mergeTicks(ticks), stat)
+	return cluster.NewCoalescingCluster(nil, updates, window, stat), nil
+}
+
+// Returns zero if window isn't set.
+func parseCoalesceWindow(window string) (time.Duration, error) {
+	if window == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid cluster CoalesceWindow %q: %v", window, err)
+	}
+	return d, nil
+}
+
+// Returns a channel sent each tick from any of chs.
+func mergeTicks(chs []<-chan time.Time) <-chan time.Time {
+	out := make(chan time.Time)
+	for _, ch := range chs {
+		go func(ch <-chan time.Time) {
+			for t := range ch {
+				out <- t
+			}
+		}(ch)
+	}
+	return out
 }
@@ -42,7 +42,40 @@ func (c *FileSagaLogConfig) Install(bag *ice.MagicBag) {
 	bag.Put(c.Create)
 }
 
-// Creates an instance of the FileSagaLog
+// Creates an instance of the FileSagaLog, migrating any sagas in the legacy text format
 func (c *FileSagaLogConfig) Create() (saga.SagaLog, error) {
-	return sagalogs.MakeFileSagaLog(c.Directory)
+	slog, err := sagalogs.MakeFileSagaLog(c.Directory)
+	if err != nil {
+		return nil, err
+	}
+	slog.MigrateAll()
+	return slog, nil
+}
+
+// BoltSagaLogConfig struct is used by goice to create a SagaLog persisted to a BoltDB file.
+// File is the path of the database file, created if it doesn't exist.
+// MaxBatchDelay is how long writes wait for concurrent ones to be committed together, human readable ex: "2ms".
+// Unset commits each write on its own, which is faster unless many jobs are started or updated at once.
+type BoltSagaLogConfig struct {
+	Type          string
+	File          string
+	MaxBatchDelay string
+}
+
+// Adds the BoltSagaLogConfig Create function to the goice MagicBag
+func (c *BoltSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the BoltDB SagaLog, recovering existing sagas from its file.
+func (c *BoltSagaLogConfig) Create() (saga.SagaLog, error) {
+	var delay time.Duration
+	if c.MaxBatchDelay != "" {
+		var err error
+		delay, err = time.ParseDuration(c.MaxBatchDelay)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sagalogs.MakeBoltSagaLog(c.File, delay)
 }
@@ -1,10 +1,13 @@
 package scootconfig
 
 import (
+	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/sched/archive"
 	"github.com/twitter/scoot/sched/scheduler"
 )
 
@@ -28,18 +31,61 @@ const DefaultReadyFnBackoff = 5 * time.Second
 // RecoverJobsOnStartup - if true, the scheduler recovers active sagas,
 //             from the sagalog, and restarts them.
 // DefaultTaskTimeout - default timeout for tasks, human readable ex: "30m"
+// AdmissionWait, ThrottleRetryAfter - human readable durations ex: "10s"
+// JobTemplates, RecurringJobs - jobs the scheduler runs on a schedule, see scheduler.RecurringJob
+// RequiredWorkerFeatures - comma separated, ex: "Scoot,Bazel"
+// SpeculationPercentile, SpeculationMinRuntime - see scheduler.SpeculationConfig, MinRuntime is human readable ex: "5m"
+// AutoscaleWebhook - URL to POST scale signals to, see scheduler.WebhookAutoscaler. Autoscaling is disabled if empty.
+// AutoscaleIdleThreshold, AutoscaleInterval - see scheduler.AutoscaleConfig, human readable ex: "10m"
+// ArchiveDir - directory finished jobs are archived to, see archive.NewDirArchive. Jobs aren't archived if empty.
+// ArchiveMaxAge, ArchiveMaxJobs - see archive.Retention, MaxAge is human readable ex: "720h"
+// JobWebhooks - comma separated URLs finished jobs are POSTed to, see scheduler.JobWebhookConfig
+// JobWebhookSecretEnv - name of the env var holding the secret job webhooks are signed with, unsigned if empty
+// AdminTokenEnv - name of the env var holding the token requests to add or remove workers must carry, refused if empty
+// RebalanceQueuedAge, RebalanceMigrateAfter - see scheduler.RebalanceConfig, human readable ex: "15m"
+// QueueSLOs - comma separated priority=duration queue time thresholds, ex: "2=30s,1=5m", see scheduler.QueueSLOConfig
+// MaxInfraFailures, DeadLetterCapacity - see scheduler.DeadLetterConfig, the dead letter queue is disabled if MaxInfraFailures is zero
+// MaxConsecutiveWorkerFailures, WorkerBlacklistCooldown - see scheduler.BlacklistConfig, Cooldown is human readable ex: "30m"
 //
 // See scheduler.SchedulerConfig for comments on the remaining fields.
 type StatefulSchedulerConfig struct {
-	Type                 string
-	MaxRetriesPerTask    int
-	DebugMode            bool
-	RecoverJobsOnStartup bool
-	DefaultTaskTimeout   string
-	TaskTimeoutOverhead  string
-	MaxRequestors        int
-	MaxJobsPerRequestor  int
-	Admins               string
+	Type                   string
+	MaxRetriesPerTask      int
+	DebugMode              bool
+	RecoverJobsOnStartup   bool
+	DefaultTaskTimeout     string
+	TaskTimeoutOverhead    string
+	MaxRequestors          int
+	MaxJobsPerRequestor    int
+	Admins                 string
+	MaxLoadFactor          float64
+	MaxCASErrorRate        float64
+	AdmissionWait          string
+	ThrottleRetryAfter     string
+	JobTemplates           []scheduler.JobTemplate
+	RecurringJobs          []scheduler.RecurringJob
+	RequiredWorkerFeatures string
+	SpeculationPercentile  float64
+	SpeculationMinRuntime  string
+	MaxSpeculativeTasks    int
+	AutoscaleWebhook       string
+	AutoscaleIdleThreshold string
+	AutoscaleInterval      string
+	ArchiveDir             string
+	ArchiveMaxAge          string
+	ArchiveMaxJobs         int
+	JobWebhooks            string
+	JobWebhookSecretEnv    string
+	AdminTokenEnv          string
+	RebalanceQueuedAge     string
+	RebalanceMigrateAfter  string
+	MaxMigrations          int
+	QueueSLOs              string
+	MaxInfraFailures       int
+	DeadLetterCapacity     int
+
+	MaxConsecutiveWorkerFailures int
+	WorkerBlacklistCooldown      string
 }
 
 func (c *StatefulSchedulerConfig) Install(bag *ice.MagicBag) {
@@ -62,12 +108,117 @@ func (c *StatefulSchedulerConfig) Create() (scheduler.SchedulerConfig, error) {
 			return scheduler.SchedulerConfig{}, err
 		}
 	}
+	var aw time.Duration
+	if c.AdmissionWait != "" {
+		aw, err = time.ParseDuration(c.AdmissionWait)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var tra time.Duration
+	if c.ThrottleRetryAfter != "" {
+		tra, err = time.ParseDuration(c.ThrottleRetryAfter)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var smr time.Duration
+	if c.SpeculationMinRuntime != "" {
+		smr, err = time.ParseDuration(c.SpeculationMinRuntime)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var ait time.Duration
+	if c.AutoscaleIdleThreshold != "" {
+		ait, err = time.ParseDuration(c.AutoscaleIdleThreshold)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var ai time.Duration
+	if c.AutoscaleInterval != "" {
+		ai, err = time.ParseDuration(c.AutoscaleInterval)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	autoscale := scheduler.AutoscaleConfig{IdleThreshold: ait, Interval: ai}
+	rebalance := scheduler.RebalanceConfig{MaxMigrations: c.MaxMigrations}
+	if c.RebalanceQueuedAge != "" {
+		rebalance.QueuedAge, err = time.ParseDuration(c.RebalanceQueuedAge)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	if c.RebalanceMigrateAfter != "" {
+		rebalance.MigrateAfter, err = time.ParseDuration(c.RebalanceMigrateAfter)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	blacklist := scheduler.BlacklistConfig{MaxConsecutiveFailures: c.MaxConsecutiveWorkerFailures}
+	if c.WorkerBlacklistCooldown != "" {
+		blacklist.Cooldown, err = time.ParseDuration(c.WorkerBlacklistCooldown)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	if c.AutoscaleWebhook != "" {
+		autoscale.Autoscaler = scheduler.NewWebhookAutoscaler(c.AutoscaleWebhook)
+	}
+	var archiveMaxAge time.Duration
+	if c.ArchiveMaxAge != "" {
+		archiveMaxAge, err = time.ParseDuration(c.ArchiveMaxAge)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var jobArchive archive.Archive
+	if c.ArchiveDir != "" {
+		jobArchive, err = archive.NewDirArchive(c.ArchiveDir, archive.Retention{MaxAge: archiveMaxAge, MaxJobs: c.ArchiveMaxJobs})
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	if err := scheduler.ValidateRecurringJobs(c.JobTemplates, c.RecurringJobs); err != nil {
+		return scheduler.SchedulerConfig{}, err
+	}
+	queueSLOs, err := scheduler.ParseQueueSLOThresholds(c.QueueSLOs)
+	if err != nil {
+		return scheduler.SchedulerConfig{}, err
+	}
 	admins := []string{}
 	for _, admin := range strings.Split(c.Admins, ",") {
 		if admin != "" {
 			admins = append(admins, admin)
 		}
 	}
+	webhooks := scheduler.JobWebhookConfig{}
+	for _, url := range strings.Split(c.JobWebhooks, ",") {
+		if url != "" {
+			webhooks.URLs = append(webhooks.URLs, url)
+		}
+	}
+	if c.JobWebhookSecretEnv != "" {
+		webhooks.Secret = os.Getenv(c.JobWebhookSecretEnv)
+		if webhooks.Secret == "" {
+			return scheduler.SchedulerConfig{}, fmt.Errorf("Job webhook secret env var %s is empty", c.JobWebhookSecretEnv)
+		}
+	}
+	adminToken := ""
+	if c.AdminTokenEnv != "" {
+		adminToken = os.Getenv(c.AdminTokenEnv)
+		if adminToken == "" {
+			return scheduler.SchedulerConfig{}, fmt.Errorf("Admin token env var %s is empty", c.AdminTokenEnv)
+		}
+	}
+	features := []string{}
+	for _, feature := range strings.Split(c.RequiredWorkerFeatures, ",") {
+		if feature != "" {
+			features = append(features, feature)
+		}
+	}
 
 	return scheduler.SchedulerConfig{
 		MaxRetriesPerTask:    c.MaxRetriesPerTask,
@@ -81,5 +232,30 @@ func (c *StatefulSchedulerConfig) Create() (scheduler.SchedulerConfig, error) {
 		MaxRequestors:        c.MaxRequestors,
 		MaxJobsPerRequestor:  c.MaxJobsPerRequestor,
 		Admins:               admins,
+		AdminToken:           adminToken,
+		Admission: scheduler.AdmissionConfig{
+			MaxLoadFactor:      c.MaxLoadFactor,
+			MaxCASErrorRate:    c.MaxCASErrorRate,
+			AdmissionWait:      aw,
+			ThrottleRetryAfter: tra,
+		},
+		RequiredWorkerFeatures: features,
+		JobTemplates:           c.JobTemplates,
+		RecurringJobs:          c.RecurringJobs,
+		Speculation: scheduler.SpeculationConfig{
+			Percentile:          c.SpeculationPercentile,
+			MinRuntime:          smr,
+			MaxSpeculativeTasks: c.MaxSpeculativeTasks,
+		},
+		Autoscale:   autoscale,
+		Archive:     jobArchive,
+		JobWebhooks: webhooks,
+		Rebalance:   rebalance,
+		QueueSLO:    scheduler.QueueSLOConfig{Thresholds: queueSLOs},
+		DeadLetter: scheduler.DeadLetterConfig{
+			MaxInfraFailures: c.MaxInfraFailures,
+			Capacity:         c.DeadLetterCapacity,
+		},
+		Blacklist: blacklist,
 	}, nil
 }
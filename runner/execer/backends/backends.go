@@ -0,0 +1,109 @@
+// Package backends lets workers run commands on pluggable execution backends, like docker containers,
+// chroots or static hosts over ssh, selected per task.
+//
+// Backends are registered by name, usually from an init func, so downstream users can add one by importing
+// its package into their worker binary rather than forking the worker. A task selects a backend with the
+// Bazel platform property "execution-backend", or for Scoot tasks the env var SCOOT_EXEC_BACKEND.
+// Tasks that don't select one run on the worker's default Execer.
+package backends
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Bazel platform property a task sets to the name of the backend it runs on.
+const PlatformProperty = "execution-backend"
+
+// Env var a Scoot task sets to the name of the backend it runs on.
+const EnvVar = "SCOOT_EXEC_BACKEND"
+
+// The backend that runs commands on the worker's default Execer, as os processes.
+const Default = "os"
+
+// Creates a backend's Execer. Backends may wrap delegate, the worker's default Execer,
+// ex: to run commands inside a container by rewriting their argv.
+type Factory func(delegate execer.Execer, stat stats.StatsReceiver) (execer.Execer, error)
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{
+		Default: func(delegate execer.Execer, _ stats.StatsReceiver) (execer.Execer, error) { return delegate, nil },
+	}
+)
+
+// Makes a backend available to workers by name. Panics if f is nil or name is already registered.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if f == nil {
+		panic("backends: Register factory is nil for " + name)
+	}
+	if _, ok := factories[name]; ok {
+		panic("backends: Register called twice for " + name)
+	}
+	factories[name] = f
+}
+
+// Returns the sorted names of registered backends.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	names := []string{}
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Execer runs each command on the backend named by its Backend field.
+type Execer struct {
+	backends map[string]execer.Execer
+}
+
+// Creates an Execer with every registered backend, running commands that don't select one on delegate.
+func NewExecer(delegate execer.Execer, stat stats.StatsReceiver) (*Execer, error) {
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	e := &Execer{backends: map[string]execer.Execer{}}
+	for name, f := range factories {
+		b, err := f(delegate, stat.Scope("backend", name))
+		if err != nil {
+			return nil, fmt.Errorf("Error creating execution backend %s: %v", name, err)
+		}
+		e.backends[name] = b
+	}
+	log.Infof("Created execution backends: %v", sortedKeys(e.backends))
+	return e, nil
+}
+
+func (e *Execer) Exec(cmd execer.Command) (execer.Process, error) {
+	name := cmd.Backend
+	if name == "" {
+		name = Default
+	}
+	b, ok := e.backends[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown execution backend %q, expected one of %v", name, sortedKeys(e.backends))
+	}
+	return b.Exec(cmd)
+}
+
+func sortedKeys(m map[string]execer.Execer) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
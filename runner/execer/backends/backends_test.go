@@ -0,0 +1,54 @@
+package backends
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/execers"
+)
+
+// A backend that records the commands it runs before delegating them.
+type recordingExecer struct {
+	delegate execer.Execer
+	argv     [][]string
+}
+
+func (r *recordingExecer) Exec(cmd execer.Command) (execer.Process, error) {
+	r.argv = append(r.argv, cmd.Argv)
+	return r.delegate.Exec(cmd)
+}
+
+func TestBackendSelection(t *testing.T) {
+	rec := &recordingExecer{}
+	Register("recording", func(delegate execer.Execer, _ stats.StatsReceiver) (execer.Execer, error) {
+		rec.delegate = delegate
+		return rec, nil
+	})
+	e, err := NewExecer(execers.NewDoneExecer(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.Exec(execer.Command{Argv: []string{"default"}}); err != nil {
+		t.Fatalf("Unexpected error running on the default backend: %v", err)
+	}
+	if _, err := e.Exec(execer.Command{Argv: []string{"recorded"}, Backend: "recording"}); err != nil {
+		t.Fatalf("Unexpected error running on a registered backend: %v", err)
+	}
+	if len(rec.argv) != 1 || rec.argv[0][0] != "recorded" {
+		t.Fatalf("Expected only the command selecting the backend to run on it, got %v", rec.argv)
+	}
+	if _, err := e.Exec(execer.Command{Argv: []string{"missing"}, Backend: "missing"}); err == nil {
+		t.Fatal("Expected an error for an unknown backend")
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected registering a name twice to panic")
+		}
+	}()
+	Register(Default, func(delegate execer.Execer, _ stats.StatsReceiver) (execer.Execer, error) { return delegate, nil })
+}
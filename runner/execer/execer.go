@@ -1,7 +1,11 @@
 package execer
 
 import (
+	"fmt"
 	"io"
+	"os/user"
+	"strconv"
+	"time"
 
 	"github.com/twitter/scoot/common/log/tags"
 )
@@ -14,13 +18,56 @@ import (
 //FIXME(jschiller) arbitrary commands can spawn dissociated/untracked child processes (ppid=1)
 type Memory uint64
 
+// How long an aborted process is given to exit after SIGTERM before it's killed with SIGKILL.
+// Zero kills it immediately.
+type AbortGracePeriod time.Duration
+
+// Whether commands are started in their own PID namespace, so none of their descendants outlive them.
+// Requires the worker to run as root on linux, and is ignored where PID namespaces aren't available.
+type PIDNamespace bool
+
+// An unprivileged user and group commands are run as, instead of the worker's own user,
+// so they can't read the worker's credentials or signal its processes. Requires the worker to run as root.
+// Nil runs commands as the worker's user.
+type RunAs struct {
+	Uid uint32
+	Gid uint32
+}
+
+// Returns the RunAs for a user name or numeric uid, with the user's primary group.
+func LookupRunAs(name string) (*RunAs, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		if u, err = user.LookupId(name); err != nil {
+			return nil, fmt.Errorf("Unknown user to run commands as %q: %v", name, err)
+		}
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid uid for user %q: %v", name, err)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid gid for user %q: %v", name, err)
+	}
+	return &RunAs{Uid: uint32(uid), Gid: uint32(gid)}, nil
+}
+
 type Command struct {
 	Argv    []string
 	EnvVars map[string]string
 	Dir     string
-	Stdout  io.Writer
-	Stderr  io.Writer
-	MemCh   chan ProcessStatus
+	// The command's stdin, nil for none. Used to talk to long-lived commands, ex: persistent workers.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	MemCh  chan ProcessStatus
+	// Name of the execution backend to run on, empty for the worker's default. See package backends.
+	Backend string
+	// Network access allowed to the command. Execers that can't enforce an isolated policy must fail the command.
+	Network NetworkPolicy
+	// If set the command's environment is only EnvVars, rather than EnvVars added to the worker's environment.
+	ClearEnv bool
 	tags.LogTags
 }
 
@@ -60,6 +107,7 @@ type Process interface {
 	Wait() ProcessStatus
 
 	// Terminates process and does best effort to get ExitCode.
+	// Execers with an AbortGracePeriod send SIGTERM first, and only kill the process if it's still running after it.
 	Abort() ProcessStatus
 }
 
@@ -67,4 +115,24 @@ type ProcessStatus struct {
 	State    ProcessState
 	ExitCode int
 	Error    string
+	Usage    ResourceUsage
+	// Set if the process was aborted and exited on SIGTERM within the grace period, rather than being killed.
+	GracefulShutdown bool
+}
+
+// ResourceUsage describes resources consumed by a process, as reported by wait4/rusage.
+// Zero values mean the usage is unknown (ex: the process was never waited on).
+type ResourceUsage struct {
+	// Peak resident set size
+	MaxRSS Memory
+	// CPU time spent in user and kernel mode
+	UserTime   time.Duration
+	SystemTime time.Duration
+	// Elapsed time from process start to exit
+	WallTime time.Duration
+}
+
+func (u ResourceUsage) String() string {
+	return fmt.Sprintf("MaxRSS: %d # UserTime: %v # SystemTime: %v # WallTime: %v",
+		u.MaxRSS, u.UserTime, u.SystemTime, u.WallTime)
 }
@@ -0,0 +1,54 @@
+package execer
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Bazel platform property a task sets to restrict its command's network access. See ParseNetworkPolicy.
+const NetworkPlatformProperty = "network"
+
+// Env var a Scoot task sets to restrict its command's network access. See ParseNetworkPolicy.
+const NetworkEnvVar = "SCOOT_NETWORK"
+
+// Network access allowed to a command, for tests that should be hermetic.
+// The zero value leaves the command on the worker's network.
+type NetworkPolicy struct {
+	// Run the command in its own network namespace, whose only interface is loopback.
+	Isolated bool
+	// With Isolated, "host:port" addresses the command may still reach. Each is forwarded from the same port
+	// on the command's loopback interface, so the command connects to 127.0.0.1:<port> to reach it.
+	Allow []string
+}
+
+// Parses the value of NetworkPlatformProperty or NetworkEnvVar: "" or "host" for the worker's network,
+// "none" for no network access, or "allow=<host:port>,..." for access to only the listed addresses.
+func ParseNetworkPolicy(s string) (NetworkPolicy, error) {
+	switch {
+	case s == "" || s == "host":
+		return NetworkPolicy{}, nil
+	case s == "none":
+		return NetworkPolicy{Isolated: true}, nil
+	case strings.HasPrefix(s, "allow="):
+		p := NetworkPolicy{Isolated: true}
+		ports := map[string]string{}
+		for _, addr := range strings.Split(strings.TrimPrefix(s, "allow="), ",") {
+			host, port, err := net.SplitHostPort(addr)
+			if err != nil || host == "" {
+				return NetworkPolicy{}, fmt.Errorf("Invalid allowed address %q, expected host:port", addr)
+			}
+			if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+				return NetworkPolicy{}, fmt.Errorf("Invalid port in allowed address %q", addr)
+			}
+			if other, ok := ports[port]; ok {
+				return NetworkPolicy{}, fmt.Errorf("Allowed addresses %q and %q can't share a port", other, addr)
+			}
+			ports[port] = addr
+			p.Allow = append(p.Allow, addr)
+		}
+		return p, nil
+	}
+	return NetworkPolicy{}, fmt.Errorf("Invalid network policy %q, expected host, none or allow=<host:port>,...", s)
+}
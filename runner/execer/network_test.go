@@ -0,0 +1,29 @@
+package execer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNetworkPolicy(t *testing.T) {
+	for s, expected := range map[string]NetworkPolicy{
+		"":                               {},
+		"host":                           {},
+		"none":                           {Isolated: true},
+		"allow=db:5432,cache.local:6379": {Isolated: true, Allow: []string{"db:5432", "cache.local:6379"}},
+	} {
+		p, err := ParseNetworkPolicy(s)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", s, err)
+		}
+		if !reflect.DeepEqual(p, expected) {
+			t.Fatalf("Expected %q to parse as %+v, got %+v", s, expected, p)
+		}
+	}
+
+	for _, s := range []string{"off", "allow=", "allow=db", "allow=:5432", "allow=db:http", "allow=db:5432,replica:5432"} {
+		if _, err := ParseNetworkPolicy(s); err == nil {
+			t.Fatalf("Expected error parsing %q", s)
+		}
+	}
+}
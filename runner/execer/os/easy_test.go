@@ -1,7 +1,11 @@
 package os
 
 import (
+	"bufio"
 	"bytes"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -138,7 +142,7 @@ func TestMemCap(t *testing.T) {
 		MemCh: memCh,
 	}
 	// Terminate nearly immediately, after memory grows to 1MB.
-	e := NewBoundedExecer(execer.Memory(1024*1024), stats.NilStatsReceiver())
+	e := NewBoundedExecer(execer.Memory(1024*1024), 0, nil, false, stats.NilStatsReceiver())
 	process, err := e.Exec(cmd)
 	if err != nil {
 		t.Fatalf(err.Error())
@@ -162,3 +166,129 @@ func TestMemCap(t *testing.T) {
 		t.Fatalf("Memory usage didn't exceed memCap within 2 seconds")
 	}
 }
+
+func TestAbortKillsProcessGroup(t *testing.T) {
+	e := &osExecer{stat: stats.NilStatsReceiver(), pg: &osProcGetter{}}
+	process, err := e.Exec(execer.Command{
+		Argv:   []string{"sh", "-c", "sleep 60 & sleep 60"},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	pid := process.(*osProcess).cmd.Process.Pid
+	process.Abort()
+	assertProcessGroupExits(t, e, pid)
+}
+
+func TestAbortGracePeriod(t *testing.T) {
+	e := &osExecer{stat: stats.NilStatsReceiver(), pg: &osProcGetter{}, gracePeriod: 5 * time.Second}
+	process := execWhenReady(t, e, "trap 'exit 0' TERM; echo ready; while true; do sleep 0.1; done")
+	if st := process.Abort(); !st.GracefulShutdown {
+		t.Fatalf("Expected command handling SIGTERM to shut down gracefully, got %v", st)
+	}
+
+	// Ignoring SIGTERM, the command is killed once the grace period is up.
+	e.gracePeriod = 200 * time.Millisecond
+	process = execWhenReady(t, e, "trap '' TERM; echo ready; while true; do sleep 0.1; done")
+	pid := process.(*osProcess).cmd.Process.Pid
+	if st := process.Abort(); st.GracefulShutdown {
+		t.Fatalf("Expected command ignoring SIGTERM to be killed, got %v", st)
+	}
+	assertProcessGroupExits(t, e, pid)
+}
+
+// Runs script, returning once it prints "ready", so it can't be aborted before installing its traps.
+func execWhenReady(t *testing.T, e *osExecer, script string) execer.Process {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	process, err := e.Exec(execer.Command{
+		Argv:   []string{"sh", "-c", script},
+		Stdout: w,
+		Stderr: &bytes.Buffer{},
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if line, err := bufio.NewReader(r).ReadString('\n'); line != "ready\n" {
+		t.Fatalf("Expected command to print ready, got %q, %v", line, err)
+	}
+	return process
+}
+
+func TestRunAs(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Running commands as another user requires root")
+	}
+	runAs, err := execer.LookupRunAs("nobody")
+	if err != nil {
+		t.Skip(err)
+	}
+	e := NewBoundedExecer(0, 0, runAs, false, stats.NilStatsReceiver())
+	stdout := &bytes.Buffer{}
+	process, err := e.Exec(execer.Command{
+		Argv:   []string{"id", "-u"},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if st := process.Wait(); st.State != execer.COMPLETE || st.ExitCode != 0 {
+		t.Fatalf("Expected command to complete, got %v", st)
+	}
+	if uid := strings.TrimSpace(stdout.String()); uid != fmt.Sprint(runAs.Uid) {
+		t.Fatalf("Expected command to run as uid %d, got %q", runAs.Uid, uid)
+	}
+}
+
+func TestOrphanedProcesses(t *testing.T) {
+	statsReg := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsReg }, 0)
+	e := &osExecer{stat: stat, pg: &osProcGetter{}}
+	process, err := e.Exec(execer.Command{
+		// The orphans inherit the command's stdout and stderr, so Wait only returns promptly if it kills them
+		Argv:   []string{"sh", "-c", "sleep 60 & sleep 60 &"},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	pid := process.(*osProcess).cmd.Process.Pid
+	start := time.Now()
+	if st := process.Wait(); st.State != execer.COMPLETE || st.ExitCode != 0 {
+		t.Fatalf("Expected command to complete, got %v", st)
+	}
+	if elapsed := time.Since(start); elapsed >= outputDrainTimeout {
+		t.Fatalf("Expected Wait to kill orphans holding the output open, took %v", elapsed)
+	}
+	assertProcessGroupExits(t, e, pid)
+	if !stats.StatsOk("", statsReg, t,
+		map[string]stats.Rule{
+			stats.WorkerOrphanedProcesses:   {Checker: stats.Int64EqTest, Value: 2},
+			stats.WorkerOrphanedProcessRuns: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+// Killed processes may briefly remain as zombies, so poll until the group is gone.
+func assertProcessGroupExits(t *testing.T, e *osExecer, pgid int) {
+	for i := 0; i < 20; i++ {
+		_, processGroups, _, err := e.pg.getProcs()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(processGroups[pgid]) == 0 {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Expected all processes in group %d to exit", pgid)
+}
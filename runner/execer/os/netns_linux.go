@@ -0,0 +1,146 @@
+package os
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Timeout dialing an allowed address for a connection from an isolated command
+const forwardDialTimeout = 10 * time.Second
+
+// Starts cmd in a new network namespace whose only interface is loopback, which usually requires root.
+// Connections to each allowed "host:port" address's port on loopback are forwarded to the address,
+// by listeners the returned Closer stops.
+//
+// Namespaces belong to threads, so cmd is started from a locked thread moved into the new namespace,
+// which the child inherits. The thread is never unlocked so it exits, rather than being reused with
+// the wrong namespace, when its goroutine returns.
+func startIsolated(cmd *exec.Cmd, allow []string) (io.Closer, error) {
+	type started struct {
+		f   *forwarder
+		err error
+	}
+	ch := make(chan started)
+	go func() {
+		runtime.LockOSThread()
+		f, err := startInNewNetNS(cmd, allow)
+		ch <- started{f, err}
+	}()
+	s := <-ch
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.f, nil
+}
+
+// Must be called from a locked thread, which is left in the new namespace.
+func startInNewNetNS(cmd *exec.Cmd, allow []string) (*forwarder, error) {
+	if err := syscall.Unshare(syscall.CLONE_NEWNET); err != nil {
+		return nil, fmt.Errorf("Failed to create network namespace: %v", err)
+	}
+	if err := loopbackUp(); err != nil {
+		return nil, fmt.Errorf("Failed to bring up loopback in network namespace: %v", err)
+	}
+	f := &forwarder{}
+	for _, addr := range allow {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		// Sockets stay in the namespace they're created in, though they're served from other threads.
+		l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", port))
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("Failed to forward %s: %v", addr, err)
+		}
+		f.listeners = append(f.listeners, l)
+		go f.serve(l, addr)
+	}
+	if err := cmd.Start(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Sets the IFF_UP flag on the current network namespace's loopback interface, which starts down.
+func loopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+	// struct ifreq with ifr_flags
+	var ifr struct {
+		name  [syscall.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(ifr.name[:], "lo")
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCGIFFLAGS, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	ifr.flags |= syscall.IFF_UP | syscall.IFF_RUNNING
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Forwards connections accepted in an isolated command's network namespace to the allowed addresses,
+// which are dialed from the worker's namespace.
+type forwarder struct {
+	mu        sync.Mutex
+	listeners []net.Listener
+}
+
+func (f *forwarder) serve(l net.Listener, addr string) {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go forward(c, addr)
+	}
+}
+
+func forward(c net.Conn, addr string) {
+	defer c.Close()
+	u, err := net.DialTimeout("tcp", addr, forwardDialTimeout)
+	if err != nil {
+		log.Infof("Failed to forward connection from isolated command to %s: %v", addr, err)
+		return
+	}
+	defer u.Close()
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(u, c)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(c, u)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// Stops accepting connections. Connections already forwarded end when either side closes them.
+func (f *forwarder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, l := range f.listeners {
+		l.Close()
+	}
+	f.listeners = nil
+	return nil
+}
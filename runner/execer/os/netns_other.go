@@ -0,0 +1,14 @@
+// +build !linux
+
+package os
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// Network namespaces are only available on linux, so isolated commands can't be run.
+func startIsolated(cmd *exec.Cmd, allow []string) (io.Closer, error) {
+	return nil, errors.New("Network isolation is only supported on linux")
+}
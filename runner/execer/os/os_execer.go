@@ -22,28 +22,55 @@ import (
 
 const bytesToKB = 1024
 
+// How long Wait waits for the rest of a command's output once the command and its orphans are gone.
+// Only a descendant that escaped the command's process group can hold its stdout or stderr open this long.
+const outputDrainTimeout = 5 * time.Second
+
 func NewExecer() *osExecer {
-	return &osExecer{pg: &osProcGetter{}}
+	return &osExecer{stat: stats.NilStatsReceiver(), pg: &osProcGetter{}}
 }
 
 // For now memory can be capped on a per-execer basis rather than a per-command basis.
 // This is ok since we currently (Q1 2017) only support one run at a time in our codebase.
-func NewBoundedExecer(memCap execer.Memory, stat stats.StatsReceiver) *osExecer {
-	return &osExecer{memCap: memCap, stat: stat.Scope("osexecer"), pg: &osProcGetter{}}
+// Aborted commands are given gracePeriod to exit after SIGTERM before their process group is killed.
+// Commands are run as runAs if it isn't nil, and in their own PID namespace if pidNamespace is set and supported.
+func NewBoundedExecer(memCap execer.Memory, gracePeriod execer.AbortGracePeriod, runAs *execer.RunAs,
+	pidNamespace execer.PIDNamespace, stat stats.StatsReceiver) *osExecer {
+	return &osExecer{
+		memCap:       memCap,
+		gracePeriod:  time.Duration(gracePeriod),
+		runAs:        runAs,
+		stat:         stat.Scope("osexecer"),
+		pg:           &osProcGetter{},
+		pidNamespace: bool(pidNamespace) && pidNamespaceSupported(),
+	}
 }
 
 type osExecer struct {
 	// Best effort monitoring of command to kill it if resident memory usage exceeds this cap. Ignored if zero.
 	memCap execer.Memory
-	stat   stats.StatsReceiver
-	pg     procGetter
+	// How long aborted commands are given to exit after SIGTERM before they're killed. Zero kills them immediately.
+	gracePeriod time.Duration
+	// Unprivileged user and group commands are run as, nil for the worker's user.
+	runAs *execer.RunAs
+	stat  stats.StatsReceiver
+	pg    procGetter
+	// Start each command in its own PID namespace so no descendants outlive it. Requires root on linux.
+	pidNamespace bool
 }
 
 type osProcess struct {
-	cmd    *exec.Cmd
-	wg     *sync.WaitGroup
-	result *execer.ProcessStatus
-	mutex  sync.Mutex
+	cmd       *exec.Cmd
+	wg        *sync.WaitGroup
+	result    *execer.ProcessStatus
+	mutex     sync.Mutex
+	startTime time.Time
+	stat      stats.StatsReceiver
+	pg        procGetter
+	// See osExecer.gracePeriod
+	gracePeriod time.Duration
+	// Forwards the allowed addresses into an isolated command's network namespace, nil if it isn't isolated
+	forwarder io.Closer
 	tags.LogTags
 }
 
@@ -77,15 +104,24 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 
 	cmd := exec.Command(command.Argv[0], command.Argv[1:]...)
 	cmd.Dir = command.Dir
+	cmd.Stdin = command.Stdin
 
 	// Use the parent environment plus whatever additional env vars are provided.
-	cmd.Env = os.Environ()
+	cmd.Env = []string{}
+	if !command.ClearEnv {
+		cmd.Env = os.Environ()
+	}
 	for k, v := range command.EnvVars {
 		cmd.Env = append(cmd.Env, k+"="+v)
 	}
 
-	// Sets pgid of all child processes to cmd's pid
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	// Sets pgid of all child processes to cmd's pid, so the command and its descendants
+	// can be killed together on abort and any left behind when it exits can be found and killed.
+	cmd.SysProcAttr = sysProcAttr(e.pidNamespace)
+	// Drops the worker's supplementary groups along with its uid and gid.
+	if e.runAs != nil {
+		cmd.SysProcAttr.Credential = &syscall.Credential{Uid: e.runAs.Uid, Gid: e.runAs.Gid}
+	}
 
 	// Make sure to get the best possible Writer, so if possible os/exec can connect
 	// the command's stdout/stderr directly to a file, instead of having to go through
@@ -119,22 +155,25 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 	}()
 
 	// Async start of the command.
-	err = cmd.Start()
+	startTime := time.Now()
+	var forwarder io.Closer
+	if command.Network.Isolated {
+		forwarder, err = startIsolated(cmd, command.Network.Allow)
+	} else {
+		err = cmd.Start()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	proc := &osProcess{cmd: cmd, wg: &wg, LogTags: command.LogTags}
+	proc := &osProcess{cmd: cmd, wg: &wg, startTime: startTime, stat: e.stat, pg: e.pg, gracePeriod: e.gracePeriod,
+		forwarder: forwarder, LogTags: command.LogTags}
 	if e.memCap > 0 {
 		go e.monitorMem(proc, command.MemCh)
 	}
 	return proc, nil
 }
 
-// TODO(rcouto): More we can do here to make sure we're
-// cleaning up after ourselves completely / not leaving
-// orphaned processes behind
-//
 // Periodically check to make sure memory constraints are respected,
 // and clean up after ourselves when the process has completed
 func (e *osExecer) monitorMem(p *osProcess, memCh chan execer.ProcessStatus) {
@@ -198,6 +237,7 @@ func (e *osExecer) monitorMem(p *osProcess, memCh chan execer.ProcessStatus) {
 					State:    execer.COMPLETE,
 					Error:    msg,
 					ExitCode: 1,
+					Usage:    execer.ResourceUsage{MaxRSS: mem, WallTime: time.Since(p.startTime)},
 				}
 				if memCh != nil {
 					memCh <- *p.result
@@ -334,10 +374,11 @@ if the command fails and we cannot get the exit code from the command, return FA
 that prevented getting the exit code.
 */
 func (p *osProcess) Wait() (result execer.ProcessStatus) {
-	// Wait for the output goroutines to finish then wait on the process itself to release resources.
-	p.wg.Wait()
+	// Wait on the process itself rather than its output, which stays open while any orphans it left
+	// behind are alive, so they're killed first. The output is collected once they're gone.
+	defer p.drainOutput()
 	pid := p.cmd.Process.Pid
-	err := p.cmd.Wait()
+	state, err := p.cmd.Process.Wait()
 	log.WithFields(
 		log.Fields{
 			"pid":    pid,
@@ -364,37 +405,57 @@ func (p *osProcess) Wait() (result execer.ProcessStatus) {
 		}).Debugf("Current ps for pid %d", pid)
 	cancel()
 
+	p.closeForwarder()
 	if p.result != nil {
 		return *p.result
 	} else {
 		p.result = &result
 	}
-	if err == nil {
-		// the command finished without an error
-		result.State = execer.COMPLETE
-		result.ExitCode = 0
-		// stdout and stderr are collected and set by (invoke.go) runner
+	p.killOrphans(pid, true)
+	result.Usage = usage(state, p.startTime)
+	if err != nil {
+		result.State = execer.FAILED
+		result.Error = err.Error()
 		return result
 	}
-	if err, ok := err.(*exec.ExitError); ok {
-		// the command returned an error, if we can get a WaitStatus from the error,
-		// we can get the commands exit code
-		if status, ok := err.Sys().(syscall.WaitStatus); ok {
-			result.State = execer.COMPLETE
-			result.ExitCode = status.ExitStatus()
-			// stdout and stderr are collected and set by (invoke.go) runner
-			return result
-		}
-		result.State = execer.FAILED
-		result.Error = "Could not find WaitStatus from exiterr.Sys()"
+	// If we can get a WaitStatus from the process state, we can get the command's exit code
+	if status, ok := state.Sys().(syscall.WaitStatus); ok {
+		result.State = execer.COMPLETE
+		result.ExitCode = status.ExitStatus()
+		// stdout and stderr are collected and set by (invoke.go) runner
 		return result
 	}
-
 	result.State = execer.FAILED
-	result.Error = err.Error()
+	result.Error = "Could not find WaitStatus from ProcessState.Sys()"
 	return result
 }
 
+// Waits for the output goroutines to copy everything the command wrote, for up to outputDrainTimeout,
+// then closes the command's pipes, which ends any copy still running.
+func (p *osProcess) drainOutput() {
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(outputDrainTimeout)
+	select {
+	case <-done:
+	case <-timer.C:
+		log.WithFields(
+			log.Fields{
+				"pid":    p.cmd.Process.Pid,
+				"tag":    p.Tag,
+				"jobID":  p.JobID,
+				"taskID": p.TaskID,
+			}).Info("Output still held open by a descendant that left the process group, closing it")
+	}
+	timer.Stop()
+	// The process was already reaped, so this only releases its resources and its error is ignored.
+	p.cmd.Wait()
+	<-done
+}
+
 func (p *osProcess) Abort() (result execer.ProcessStatus) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -407,19 +468,129 @@ func (p *osProcess) Abort() (result execer.ProcessStatus) {
 	result.ExitCode = -1
 	result.Error = "Aborted."
 
-	err := p.cmd.Process.Kill()
-	if err != nil {
-		result.Error = "Aborted. Couldn't kill process. Will still attempt cleanup."
+	pid := p.cmd.Process.Pid
+	type exit struct {
+		state *os.ProcessState
+		err   error
 	}
-	_, err = p.cmd.Process.Wait()
+	exitCh := make(chan exit, 1)
+	go func() {
+		state, err := p.cmd.Process.Wait()
+		exitCh <- exit{state, err}
+	}()
+
+	// Signal the whole process group, not just the command, so descendants don't keep running.
+	var exited exit
+	if p.gracePeriod > 0 && syscall.Kill(-pid, syscall.SIGTERM) == nil {
+		timer := time.NewTimer(p.gracePeriod)
+		select {
+		case exited = <-exitCh:
+			result.GracefulShutdown = true
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+	if !result.GracefulShutdown {
+		if err := syscall.Kill(-pid, syscall.SIGKILL); err != nil {
+			if err := p.cmd.Process.Kill(); err != nil {
+				result.Error = "Aborted. Couldn't kill process. Will still attempt cleanup."
+			}
+		}
+		exited = <-exitCh
+	}
+	log.WithFields(
+		log.Fields{
+			"pid":      pid,
+			"graceful": result.GracefulShutdown,
+			"tag":      p.Tag,
+			"jobID":    p.JobID,
+			"taskID":   p.TaskID,
+		}).Info("Aborted process")
+	state, err := exited.state, exited.err
 	if err, ok := err.(*exec.ExitError); ok {
 		if status, ok := err.Sys().(syscall.WaitStatus); ok {
 			result.ExitCode = status.ExitStatus()
 		}
 	}
+	p.killOrphans(pid, false)
+	p.closeForwarder()
+	result.Usage = usage(state, p.startTime)
 	return result
 }
 
+// Stops forwarding allowed addresses once the command and its descendants are gone. Caller must hold the mutex.
+func (p *osProcess) closeForwarder() {
+	if p.forwarder != nil {
+		p.forwarder.Close()
+		p.forwarder = nil
+	}
+}
+
+// Kills processes still in the command's process group after the command exited, and reaps any that
+// were reparented to this process, ex: when running as pid 1 in a container, so they don't linger as zombies.
+// If report is true, remaining processes are logged and counted as orphans since the command left them behind.
+// Caller must hold the mutex.
+func (p *osProcess) killOrphans(pgid int, report bool) {
+	if p.pg == nil {
+		return
+	}
+	_, processGroups, _, err := p.pg.getProcs()
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"pgid":   pgid,
+				"error":  err,
+				"tag":    p.Tag,
+				"jobID":  p.JobID,
+				"taskID": p.TaskID,
+			}).Error("Error listing processes to find orphans")
+		return
+	}
+	orphans := processGroups[pgid]
+	if len(orphans) == 0 {
+		return
+	}
+	if report {
+		if p.stat != nil {
+			p.stat.Counter(stats.WorkerOrphanedProcesses).Inc(int64(len(orphans)))
+			p.stat.Counter(stats.WorkerOrphanedProcessRuns).Inc(1)
+		}
+		log.WithFields(
+			log.Fields{
+				"pgid":    pgid,
+				"orphans": len(orphans),
+				"args":    p.cmd.Args,
+				"tag":     p.Tag,
+				"jobID":   p.JobID,
+				"taskID":  p.TaskID,
+			}).Info("Command exited leaving processes running, killing them")
+	}
+	cleanupProcs(pgid)
+
+	self := os.Getpid()
+	for _, orphan := range orphans {
+		if orphan.ppid == self {
+			// Wait in the background since a process in uninterruptible sleep may take a while to die.
+			go syscall.Wait4(orphan.pid, nil, 0, nil)
+		}
+	}
+}
+
+// Collects the rusage reported by wait4 for a finished process, or the zero value if not available.
+func usage(state *os.ProcessState, startTime time.Time) (u execer.ResourceUsage) {
+	if state == nil {
+		return u
+	}
+	u.WallTime = time.Since(startTime)
+	u.UserTime = state.UserTime()
+	u.SystemTime = state.SystemTime()
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		// Linux reports Maxrss in kilobytes.
+		u.MaxRSS = execer.Memory(rusage.Maxrss * bytesToKB)
+	}
+	return u
+}
+
 // Kill process along with all child processes, assuming no child processes called setpgid
 func cleanupProcs(pgid int) (err error) {
 	log.WithFields(
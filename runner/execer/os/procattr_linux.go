@@ -0,0 +1,34 @@
+package os
+
+import (
+	"os/exec"
+	"sync"
+	"syscall"
+)
+
+var (
+	pidNamespaceOnce      sync.Once
+	pidNamespaceAvailable bool
+)
+
+// Returns true if commands can be started in their own PID namespace, which usually requires root.
+// When the first process in a PID namespace exits the kernel kills everything else in it,
+// so none of the command's descendants can outlive it, even ones that left its process group.
+func pidNamespaceSupported() bool {
+	pidNamespaceOnce.Do(func() {
+		cmd := exec.Command("true")
+		cmd.SysProcAttr = &syscall.SysProcAttr{Cloneflags: syscall.CLONE_NEWPID}
+		pidNamespaceAvailable = cmd.Run() == nil
+	})
+	return pidNamespaceAvailable
+}
+
+// Start commands in their own process group, whose pgid is the command's pid,
+// and in their own PID namespace if requested.
+func sysProcAttr(pidNamespace bool) *syscall.SysProcAttr {
+	attr := &syscall.SysProcAttr{Setpgid: true}
+	if pidNamespace {
+		attr.Cloneflags = syscall.CLONE_NEWPID
+	}
+	return attr
+}
@@ -0,0 +1,17 @@
+// +build !linux
+
+package os
+
+import (
+	"syscall"
+)
+
+// PID namespaces are only available on linux.
+func pidNamespaceSupported() bool {
+	return false
+}
+
+// Start commands in their own process group, whose pgid is the command's pid.
+func sysProcAttr(pidNamespace bool) *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
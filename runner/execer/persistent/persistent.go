@@ -0,0 +1,444 @@
+// Package persistent runs commands on long-lived worker processes, in the style of Bazel persistent workers,
+// so toolchains like the Java or TypeScript compilers pay their startup cost once rather than on every run.
+//
+// A command opts in by setting the env var SCOOT_PERSISTENT_WORKER to "json". Its argv is split the way
+// Bazel splits it: the trailing @flagfile args hold the work request's arguments, one per line, and the
+// args before them start the worker process, with --persistent_worker appended. Requests and responses use
+// Bazel's JSON worker protocol, one JSON object per line on the worker's stdin and stdout.
+//
+// A worker process is reused by later commands of the same job with the same startup args and env, so jobs
+// never share one. Workers idle for longer than the idle timeout are stopped. Since each run has
+// its own checkout, requests carry the run's directory as sandboxDir, which workers must resolve paths against.
+// Worker processes are started with the Default execer, so they get the same user, process group,
+// memory cap and cleanup as any other command.
+package persistent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Env var a command sets to run on a persistent worker, with the protocol to use.
+const RequestEnvVar = "SCOOT_PERSISTENT_WORKER"
+
+// The supported worker protocol.
+const ProtocolJSON = "json"
+
+// Arg appended to a command's startup args to start it as a persistent worker.
+const WorkerFlag = "--persistent_worker"
+
+// Env vars the runner sets to describe a single run. A worker outlives the run that started it,
+// so it isn't given them, which also lets runs of different tasks share it.
+var runEnvVars = map[string]bool{"SCOOT_RUN_ID": true, "SCOOT_JOB_ID": true, "SCOOT_TASK_ID": true, "SCOOT_SNAPSHOT_ID": true}
+
+// The default number of idle worker processes kept alive.
+const DefaultMaxIdleWorkers = 4
+
+// The default time a worker process is kept alive while idle.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// Returns whether cmd asked to run on a persistent worker.
+func Requested(cmd execer.Command) bool {
+	_, ok := cmd.EnvVars[RequestEnvVar]
+	return ok
+}
+
+// Execer runs commands that request it on persistent workers, and all others with Default.
+type Execer struct {
+	Default execer.Execer
+
+	maxIdle     int
+	idleTimeout time.Duration
+	stat        stats.StatsReceiver
+
+	mu     sync.Mutex
+	idle   []*worker // least recently used first
+	busy   map[*worker]bool
+	closed chan struct{}
+}
+
+// Creates an Execer keeping at most maxIdle idle workers, or DefaultMaxIdleWorkers if maxIdle <= 0,
+// each for at most idleTimeout, or DefaultIdleTimeout if idleTimeout <= 0. Close stops all of them.
+func NewExecer(delegate execer.Execer, maxIdle int, idleTimeout time.Duration, stat stats.StatsReceiver) *Execer {
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleWorkers
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	e := &Execer{Default: delegate, maxIdle: maxIdle, idleTimeout: idleTimeout, stat: stat,
+		busy: map[*worker]bool{}, closed: make(chan struct{})}
+	go e.reapIdle()
+	return e
+}
+
+// A request in Bazel's JSON worker protocol.
+type workRequest struct {
+	Arguments  []string `json:"arguments"`
+	RequestID  int      `json:"requestId"`
+	SandboxDir string   `json:"sandboxDir,omitempty"`
+}
+
+// A response in Bazel's JSON worker protocol.
+type workResponse struct {
+	ExitCode  int    `json:"exitCode"`
+	Output    string `json:"output"`
+	RequestID int    `json:"requestId"`
+}
+
+func (e *Execer) Exec(cmd execer.Command) (execer.Process, error) {
+	if !Requested(cmd) {
+		return e.Default.Exec(cmd)
+	}
+	if cmd.Network.Isolated {
+		return nil, errors.New("Persistent workers can't be network isolated, since they outlive the command")
+	}
+	if p := cmd.EnvVars[RequestEnvVar]; p != ProtocolJSON {
+		return nil, fmt.Errorf("Unsupported persistent worker protocol %q, expected %q", p, ProtocolJSON)
+	}
+	startArgs, flagfiles := splitArgs(cmd.Argv)
+	if len(startArgs) == 0 || len(flagfiles) == 0 {
+		return nil, errors.New("Persistent worker commands need startup args followed by @flagfile args")
+	}
+	args, err := expandFlagfiles(cmd.Dir, flagfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	// Toolchains are usually run from the checkout, ex: bazel-out/host/bin/javabuilder.
+	if strings.Contains(startArgs[0], "/") && !filepath.IsAbs(startArgs[0]) {
+		startArgs = append([]string{filepath.Join(cmd.Dir, startArgs[0])}, startArgs[1:]...)
+	}
+	env := workerEnv(cmd.EnvVars)
+	key := workerKey(cmd.JobID, startArgs, env, cmd.ClearEnv)
+	w := e.take(key)
+	if w == nil {
+		if w, err = e.startWorker(key, startArgs, env, cmd); err != nil {
+			return nil, err
+		}
+		e.stat.Counter(stats.WorkerPersistentWorkerStarts).Inc(1)
+	} else {
+		e.stat.Counter(stats.WorkerPersistentWorkerReuses).Inc(1)
+	}
+	e.mu.Lock()
+	e.busy[w] = true
+	e.mu.Unlock()
+
+	p := &process{done: make(chan execer.ProcessStatus, 1), worker: w}
+	go func() {
+		st := w.do(workRequest{Arguments: args, SandboxDir: cmd.Dir}, cmd.Stderr)
+		e.mu.Lock()
+		delete(e.busy, w)
+		e.mu.Unlock()
+		p.mu.Lock()
+		p.finished = true
+		if st.State == execer.COMPLETE && !p.aborted {
+			e.put(w)
+		} else {
+			w.kill()
+		}
+		p.mu.Unlock()
+		p.done <- st
+	}()
+	return p, nil
+}
+
+// Kills all workers, failing the commands they're running, and waits for them to exit, ex: when the worker
+// shuts down. Commands started after Close still run, but their workers are killed once they're done.
+func (e *Execer) Close() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	select {
+	case <-e.closed:
+		e.mu.Unlock()
+		return
+	default:
+		close(e.closed)
+	}
+	workers := e.idle
+	for w := range e.busy {
+		workers = append(workers, w)
+	}
+	e.idle = nil
+	e.mu.Unlock()
+	for _, w := range workers {
+		w.kill()
+		w.proc.Abort()
+	}
+}
+
+// Kills workers that have been idle for longer than idleTimeout, until Close.
+func (e *Execer) reapIdle() {
+	ticker := time.NewTicker(e.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.closed:
+			return
+		case <-ticker.C:
+		}
+		e.mu.Lock()
+		for len(e.idle) > 0 && time.Since(e.idle[0].idleSince) > e.idleTimeout {
+			e.idle[0].kill()
+			e.idle = e.idle[1:]
+		}
+		e.mu.Unlock()
+	}
+}
+
+// Removes and returns an idle worker for key, or nil if there isn't one.
+func (e *Execer) take(key string) *worker {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i := len(e.idle) - 1; i >= 0; i-- {
+		if w := e.idle[i]; w.key == key {
+			e.idle = append(e.idle[:i], e.idle[i+1:]...)
+			return w
+		}
+	}
+	return nil
+}
+
+// Makes w available to later commands, killing the least recently used worker if too many are idle.
+func (e *Execer) put(w *worker) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	select {
+	case <-e.closed:
+		w.kill()
+		return
+	default:
+	}
+	w.idleSince = time.Now()
+	e.idle = append(e.idle, w)
+	for len(e.idle) > e.maxIdle {
+		e.idle[0].kill()
+		e.idle = e.idle[1:]
+	}
+}
+
+// Splits argv into the worker's startup args and the trailing @flagfile args.
+func splitArgs(argv []string) (startArgs, flagfiles []string) {
+	i := len(argv)
+	for i > 0 && strings.HasPrefix(argv[i-1], "@") {
+		i--
+	}
+	return argv[:i], argv[i:]
+}
+
+// Reads the work request arguments from flagfiles, one per line, relative to dir.
+func expandFlagfiles(dir string, flagfiles []string) ([]string, error) {
+	args := []string{}
+	for _, f := range flagfiles {
+		path := strings.TrimPrefix(f, "@")
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading flagfile %s: %v", f, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line != "" {
+				args = append(args, line)
+			}
+		}
+	}
+	return args, nil
+}
+
+// Returns the env a worker is started with.
+func workerEnv(envVars map[string]string) map[string]string {
+	env := map[string]string{}
+	for k, v := range envVars {
+		if k != RequestEnvVar && !runEnvVars[k] {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+func workerKey(jobID string, startArgs []string, env map[string]string, clearEnv bool) string {
+	kvs := []string{}
+	for k, v := range env {
+		kvs = append(kvs, k+"="+v)
+	}
+	sort.Strings(kvs)
+	return jobID + "\x01" + strings.Join(startArgs, "\x00") + "\x01" + strings.Join(kvs, "\x00") + "\x01" +
+		strconv.FormatBool(clearEnv)
+}
+
+// A running worker process, used by one command at a time.
+type worker struct {
+	key    string
+	args   []string
+	proc   execer.Process
+	stdin  io.WriteCloser
+	out    io.Closer
+	stdout *bufio.Reader
+	// The run the worker's stderr is copied to, nil if idle.
+	stderr *switchWriter
+	// When the worker last became idle.
+	idleSince time.Time
+
+	killOnce sync.Once
+}
+
+// Starts a worker process with e.Default, for the command cmd that asked for it.
+func (e *Execer) startWorker(key string, startArgs []string, env map[string]string, cmd execer.Command) (*worker, error) {
+	// Stdin is an os.File so it's handed to the process as is. Unlike a copying goroutine,
+	// that can't keep the process from being waited on if it exits on its own.
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdoutR, stdoutW := io.Pipe()
+	stderr := &switchWriter{}
+	args := append(append([]string{}, startArgs...), WorkerFlag)
+	proc, err := e.Default.Exec(execer.Command{
+		Argv:     args,
+		EnvVars:  env,
+		Stdin:    stdinR,
+		Stdout:   stdoutW,
+		Stderr:   stderr,
+		ClearEnv: cmd.ClearEnv,
+		LogTags:  cmd.LogTags,
+	})
+	// The process has its own copy once started.
+	stdinR.Close()
+	if err != nil {
+		stdinW.Close()
+		return nil, err
+	}
+	// Unblocks a pending request if the worker exits, ex: when it's over the memory cap.
+	go func() {
+		st := proc.Wait()
+		log.Infof("Persistent worker exited with %v: %v", st, args)
+		stdoutW.Close()
+	}()
+	log.Infof("Started persistent worker: %v", args)
+	return &worker{key: key, args: args, proc: proc, stdin: stdinW, out: stdoutR, stdout: bufio.NewReader(stdoutR), stderr: stderr}, nil
+}
+
+// Sends req to the worker and waits for its response, copying the response output and
+// anything the worker logs meanwhile to output.
+func (w *worker) do(req workRequest, output io.Writer) execer.ProcessStatus {
+	if output == nil {
+		output = ioutil.Discard
+	}
+	w.stderr.set(output)
+	defer w.stderr.set(nil)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return failed(err)
+	}
+	if _, err := w.stdin.Write(append(data, '\n')); err != nil {
+		return failed(fmt.Errorf("Error sending work request: %v", err))
+	}
+	line, err := w.stdout.ReadBytes('\n')
+	if err != nil {
+		return failed(fmt.Errorf("Error reading work response: %v", err))
+	}
+	var resp workResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return failed(fmt.Errorf("Invalid work response %q: %v", line, err))
+	}
+	io.WriteString(output, resp.Output)
+	return execer.ProcessStatus{State: execer.COMPLETE, ExitCode: resp.ExitCode}
+}
+
+// Stops the worker in the background. Closing its output fails any pending request,
+// and keeps anything it writes from blocking its exit.
+func (w *worker) kill() {
+	w.killOnce.Do(func() {
+		log.Infof("Stopping persistent worker: %v", w.args)
+		w.stdin.Close()
+		w.out.Close()
+		go w.proc.Abort()
+	})
+}
+
+func failed(err error) execer.ProcessStatus {
+	return execer.ProcessStatus{State: execer.FAILED, Error: err.Error()}
+}
+
+// A command's view of the worker handling it.
+type process struct {
+	done   chan execer.ProcessStatus
+	worker *worker
+
+	// Guards handing the worker back once the request ends, so an aborted worker is never reused.
+	mu       sync.Mutex
+	finished bool
+	aborted  bool
+
+	waitMu sync.Mutex
+	result *execer.ProcessStatus
+}
+
+func (p *process) Wait() execer.ProcessStatus {
+	p.waitMu.Lock()
+	defer p.waitMu.Unlock()
+	if p.result == nil {
+		st := <-p.done
+		p.result = &st
+	}
+	return *p.result
+}
+
+// Kills the worker if the request is still running, since it can't be cancelled, and waits for it to end.
+func (p *process) Abort() execer.ProcessStatus {
+	p.mu.Lock()
+	if !p.finished {
+		p.aborted = true
+		p.worker.kill()
+	}
+	p.mu.Unlock()
+	st := p.Wait()
+	st.State = execer.FAILED
+	st.Error = "Aborted"
+	return st
+}
+
+// A Writer whose destination can be changed, so a worker's stderr goes to the run it's handling.
+type switchWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *switchWriter) set(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w = w
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.w == nil {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
@@ -0,0 +1,107 @@
+package persistent
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+	osexec "github.com/twitter/scoot/runner/execer/os"
+)
+
+// A worker that answers every request with its own random id, so tests can tell whether it was reused.
+// Its pid isn't used since workers may each be pid 1 in their own PID namespace.
+const workerScript = `id=$(od -An -N8 -tu8 /dev/urandom | tr -d ' ')
+while read req; do echo "{\"exitCode\":3,\"output\":\"$id\",\"requestId\":0}"; done`
+
+func TestSplitArgs(t *testing.T) {
+	start, flagfiles := splitArgs([]string{"java", "-jar", "builder.jar", "@a", "@b"})
+	if !reflect.DeepEqual(start, []string{"java", "-jar", "builder.jar"}) ||
+		!reflect.DeepEqual(flagfiles, []string{"@a", "@b"}) {
+		t.Fatalf("Unexpected split %v %v", start, flagfiles)
+	}
+}
+
+// Returns a func running a command of jobID in dir on a worker of e, returning the worker's id.
+func runner(t *testing.T, e *Execer, dir string) func(jobID string) string {
+	if err := ioutil.WriteFile(filepath.Join(dir, "args"), []byte("-d\nout\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return func(jobID string) string {
+		var out bytes.Buffer
+		cmd := execer.Command{
+			Argv:    []string{"sh", "-c", workerScript, "worker", "@args"},
+			EnvVars: map[string]string{RequestEnvVar: ProtocolJSON},
+			Dir:     dir,
+			Stderr:  &out,
+		}
+		cmd.JobID = jobID
+		p, err := e.Exec(cmd)
+		if err != nil {
+			t.Fatalf("Couldn't exec: %v", err)
+		}
+		if st := p.Wait(); st.State != execer.COMPLETE || st.ExitCode != 3 {
+			t.Fatalf("Unexpected status %v", st)
+		}
+		return out.String()
+	}
+}
+
+func TestWorkerReused(t *testing.T) {
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	e := NewExecer(osexec.NewExecer(), 1, 0, stat)
+	defer e.Close()
+
+	dir, err := ioutil.TempDir("", "persistent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	run := runner(t, e, dir)
+	if first, second := run("job"), run("job"); first == "" || first != second {
+		t.Fatalf("Expected the same worker for both runs, got %q and %q", first, second)
+	}
+	if !stats.StatsOk("", statsRegistry, t, map[string]stats.Rule{
+		stats.WorkerPersistentWorkerStarts: {Checker: stats.Int64EqTest, Value: 1},
+		stats.WorkerPersistentWorkerReuses: {Checker: stats.Int64EqTest, Value: 1},
+	}) {
+		t.Fatal("Unexpected stats")
+	}
+}
+
+func TestUnsupportedProtocol(t *testing.T) {
+	e := NewExecer(osexec.NewExecer(), 0, 0, nil)
+	_, err := e.Exec(execer.Command{
+		Argv:    []string{"sh", "@args"},
+		EnvVars: map[string]string{RequestEnvVar: "proto"},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an unsupported protocol")
+	}
+}
+
+func TestWorkerNotShared(t *testing.T) {
+	e := NewExecer(osexec.NewExecer(), 2, 50*time.Millisecond, nil)
+	defer e.Close()
+
+	dir, err := ioutil.TempDir("", "persistent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	run := runner(t, e, dir)
+	first := run("job1")
+	if other := run("job2"); other == first {
+		t.Fatalf("Expected a different worker for another job, got %q for both", first)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if again := run("job1"); again == first {
+		t.Fatalf("Expected a new worker once the idle one timed out, got %q again", first)
+	}
+}
@@ -0,0 +1,169 @@
+// Package gpu detects a worker's GPU devices and allocates them exclusively to runs.
+//
+// A command requests GPUs by setting the env var SCOOT_GPUS to the number it needs.
+// The worker assigns that many devices to the run for its duration, and tells the command
+// which ones via CUDA_VISIBLE_DEVICES. Commands that don't request GPUs on a worker configured
+// with GPUs get an empty CUDA_VISIBLE_DEVICES, so they can't use devices assigned to others.
+// Workers aren't configured with GPUs by default, and then leave CUDA_VISIBLE_DEVICES alone.
+package gpu
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Env var a command sets to the number of GPUs it needs.
+const RequestEnvVar = "SCOOT_GPUS"
+
+// Env var telling CUDA applications which devices they may use.
+const VisibleDevicesEnvVar = "CUDA_VISIBLE_DEVICES"
+
+// Feature reported in a worker's capabilities if it has GPUs.
+const Feature = "GPU"
+
+// A GPU device on the worker.
+type Device struct {
+	ID    string // Index of the device as used in CUDA_VISIBLE_DEVICES, ex: "0"
+	Model string // ex: "Tesla V100-SXM2-16GB", empty if unknown
+}
+
+// Returns the NVIDIA GPUs on this machine as listed by nvidia-smi,
+// or no devices if nvidia-smi isn't installed.
+func Detect() ([]Device, error) {
+	path, err := exec.LookPath("nvidia-smi")
+	if err != nil {
+		return nil, nil
+	}
+	out, err := exec.Command(path, "--query-gpu=index,name", "--format=csv,noheader").Output()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list GPUs with nvidia-smi: %v", err)
+	}
+	return parseQueryOutput(out), nil
+}
+
+// Parses lines of "<index>, <name>" as output by nvidia-smi --query-gpu=index,name --format=csv,noheader
+func parseQueryOutput(out []byte) []Device {
+	devices := []Device{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ",", 2)
+		id := strings.TrimSpace(fields[0])
+		if id == "" {
+			continue
+		}
+		d := Device{ID: id}
+		if len(fields) > 1 {
+			d.Model = strings.TrimSpace(fields[1])
+		}
+		devices = append(devices, d)
+	}
+	return devices
+}
+
+// Parses a comma-separated list of device IDs, ex: "0,1,3", for configuring devices without detection.
+func ParseDevices(ids string) []Device {
+	devices := []Device{}
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			devices = append(devices, Device{ID: id})
+		}
+	}
+	return devices
+}
+
+// Returns the number of GPUs requested in a command's env, zero if none are.
+func Requested(env map[string]string) (int, error) {
+	v, ok := env[RequestEnvVar]
+	if !ok || v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, was %q", RequestEnvVar, v)
+	}
+	return n, nil
+}
+
+// Returns a copy of env with CUDA_VISIBLE_DEVICES set to the IDs of devices.
+func Env(env map[string]string, devices []Device) map[string]string {
+	ids := []string{}
+	for _, d := range devices {
+		ids = append(ids, d.ID)
+	}
+	withDevices := make(map[string]string, len(env)+1)
+	for k, v := range env {
+		withDevices[k] = v
+	}
+	withDevices[VisibleDevicesEnvVar] = strings.Join(ids, ",")
+	return withDevices
+}
+
+// Allocator assigns each of a worker's devices to at most one run at a time. It is safe for concurrent use.
+type Allocator struct {
+	devices []Device
+
+	mu   sync.Mutex
+	free map[string]bool
+}
+
+func NewAllocator(devices []Device) *Allocator {
+	a := &Allocator{devices: devices, free: map[string]bool{}}
+	for _, d := range devices {
+		a.free[d.ID] = true
+	}
+	return a
+}
+
+// Returns all of the worker's devices, allocated or not. Returns nil if a is nil.
+func (a *Allocator) Devices() []Device {
+	if a == nil {
+		return nil
+	}
+	return a.devices
+}
+
+// Allocates n devices, or returns an error if fewer than n are free.
+// A nil Allocator has no devices.
+func (a *Allocator) Allocate(n int) ([]Device, error) {
+	if n == 0 {
+		return []Device{}, nil
+	}
+	if a == nil {
+		return nil, fmt.Errorf("%d GPUs requested but the worker has none", n)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	allocated := []Device{}
+	for _, d := range a.devices {
+		if len(allocated) == n {
+			break
+		}
+		if a.free[d.ID] {
+			allocated = append(allocated, d)
+		}
+	}
+	if len(allocated) < n {
+		return nil, fmt.Errorf("%d GPUs requested but %d of the worker's %d are free", n, len(allocated), len(a.devices))
+	}
+	for _, d := range allocated {
+		a.free[d.ID] = false
+	}
+	return allocated, nil
+}
+
+// Frees devices previously returned by Allocate.
+func (a *Allocator) Release(devices []Device) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, d := range devices {
+		a.free[d.ID] = true
+	}
+}
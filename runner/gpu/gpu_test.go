@@ -0,0 +1,67 @@
+package gpu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQueryOutput(t *testing.T) {
+	out := []byte("0, Tesla V100-SXM2-16GB\n1, Tesla V100-SXM2-16GB\n\n")
+	expected := []Device{{"0", "Tesla V100-SXM2-16GB"}, {"1", "Tesla V100-SXM2-16GB"}}
+	if devices := parseQueryOutput(out); !reflect.DeepEqual(devices, expected) {
+		t.Fatalf("Expected %v, got %v", expected, devices)
+	}
+	if devices := ParseDevices("0, 2,"); !reflect.DeepEqual(devices, []Device{{ID: "0"}, {ID: "2"}}) {
+		t.Fatalf("Expected devices 0 and 2, got %v", devices)
+	}
+}
+
+func TestRequested(t *testing.T) {
+	if n, err := Requested(map[string]string{}); err != nil || n != 0 {
+		t.Fatalf("Expected no GPUs requested, got %d %v", n, err)
+	}
+	if n, err := Requested(map[string]string{RequestEnvVar: "2"}); err != nil || n != 2 {
+		t.Fatalf("Expected 2 GPUs requested, got %d %v", n, err)
+	}
+	for _, v := range []string{"two", "-1"} {
+		if _, err := Requested(map[string]string{RequestEnvVar: v}); err == nil {
+			t.Fatalf("Expected error for %s=%s", RequestEnvVar, v)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	a := NewAllocator(ParseDevices("0,1,2"))
+
+	first, err := a.Allocate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Allocate(2); err == nil {
+		t.Fatal("Expected error allocating more GPUs than are free")
+	}
+	second, err := a.Allocate(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].ID != "2" {
+		t.Fatalf("Expected the free device 2 to be allocated, got %v", second)
+	}
+
+	a.Release(first)
+	third, err := a.Allocate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env := Env(map[string]string{"A": "a"}, third); !reflect.DeepEqual(env, map[string]string{"A": "a", VisibleDevicesEnvVar: "0,1"}) {
+		t.Fatalf("Expected released devices to be reallocated, got %v", env)
+	}
+
+	var none *Allocator
+	if devices, err := none.Allocate(0); err != nil || len(devices) != 0 {
+		t.Fatalf("Expected no devices without error, got %v %v", devices, err)
+	}
+	if _, err := none.Allocate(1); err == nil {
+		t.Fatal("Expected error allocating GPUs on a worker without any")
+	}
+}
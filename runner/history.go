@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"time"
+)
+
+// history.go describes how to read the history of finished runs.
+
+// RunRecord is the final status of a run along with when it started and finished.
+type RunRecord struct {
+	RunStatus
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// HistoryQuery describes a query for RunRecords.
+// All non-zero fields are and'ed: a RunRecord matches if its RunID equals q.RunID (when set)
+// and it ended in the time range [q.Start, q.End) (when set).
+type HistoryQuery struct {
+	RunID RunID     // Run to query for, or empty to match all runs
+	Start time.Time // Match runs that ended at or after Start. Zero value is ignored.
+	End   time.Time // Match runs that ended before End. Zero value is ignored.
+	Limit int       // Maximum number of records to return, most recent first. Zero means no limit.
+}
+
+// Matches checks if rec matches q (ignoring q.Limit)
+func (q HistoryQuery) Matches(rec RunRecord) bool {
+	if q.RunID != "" && q.RunID != rec.RunID {
+		return false
+	}
+	if !q.Start.IsZero() && rec.EndTime.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && !rec.EndTime.Before(q.End) {
+		return false
+	}
+	return true
+}
+
+// HistoryReader allows reading the history of finished runs, including those that
+// have been Erase'd or evicted from the StatusReader.
+type HistoryReader interface {
+	// QueryHistory returns all RunRecords matching q, most recently ended first.
+	QueryHistory(q HistoryQuery) ([]RunRecord, error)
+}
@@ -0,0 +1,26 @@
+package runner
+
+import (
+	"fmt"
+)
+
+// CPU and memory, either required by a task or available on a worker. Zero values are unspecified.
+type Resources struct {
+	MilliCPUs   int64 // Thousandths of a CPU core, ex: 1500 for one and a half cores.
+	MemoryBytes int64
+}
+
+// Returns true if r fits in capacity. Unspecified capacity fits any requirement.
+func (r Resources) FitsIn(capacity Resources) bool {
+	return (capacity.MilliCPUs == 0 || r.MilliCPUs <= capacity.MilliCPUs) &&
+		(capacity.MemoryBytes == 0 || r.MemoryBytes <= capacity.MemoryBytes)
+}
+
+// Returns true if neither CPU nor memory is specified.
+func (r Resources) Unspecified() bool {
+	return r.MilliCPUs == 0 && r.MemoryBytes == 0
+}
+
+func (r Resources) String() string {
+	return fmt.Sprintf("{milliCPUs:%d, memoryBytes:%d}", r.MilliCPUs, r.MemoryBytes)
+}
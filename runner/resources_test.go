@@ -0,0 +1,24 @@
+package runner
+
+import (
+	"testing"
+)
+
+func TestResourcesFitsIn(t *testing.T) {
+	capacity := Resources{MilliCPUs: 4000, MemoryBytes: 8 << 30}
+	if !(Resources{}).FitsIn(capacity) {
+		t.Error("Expected no requirements to fit")
+	}
+	if !(Resources{MilliCPUs: 4000, MemoryBytes: 8 << 30}).FitsIn(capacity) {
+		t.Error("Expected requirements equal to capacity to fit")
+	}
+	if (Resources{MilliCPUs: 4001}).FitsIn(capacity) {
+		t.Error("Expected too many CPUs not to fit")
+	}
+	if (Resources{MemoryBytes: 16 << 30}).FitsIn(capacity) {
+		t.Error("Expected too much memory not to fit")
+	}
+	if !(Resources{MilliCPUs: 64000, MemoryBytes: 1 << 40}).FitsIn(Resources{}) {
+		t.Error("Expected any requirements to fit unspecified capacity")
+	}
+}
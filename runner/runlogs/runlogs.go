@@ -0,0 +1,378 @@
+// Package runlogs persists each run's combined stdout/stderr to a Store so it survives
+// worker restarts and can be fetched without going to the worker that ran it.
+//
+// A log is stored as gzipped chunks plus a JSON index naming them. The index's name is the
+// log's reference, which workers report in RunStatus.LogRef and which Read and Handler accept.
+package runlogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+const (
+	// Size of uncompressed log data in each stored chunk
+	DefaultChunkSize = 4 * 1024 * 1024
+
+	// How long persisted logs are kept before they're swept
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// How often the sweeper looks for expired logs
+	DefaultSweepInterval = time.Hour
+
+	// Path Handler expects to be served at
+	HttpPath = "/log/"
+
+	namePrefix  = store.LogNamePrefix
+	indexSuffix = ".json"
+	chunkSuffix = ".gz"
+)
+
+// Returns true if name is the name of a log index or chunk.
+func IsLogName(name string) bool {
+	return store.IsLogName(name)
+}
+
+// Returns true if ref is a log reference, i.e. the name of a log index.
+func IsLogRef(ref string) bool {
+	return IsLogName(ref) && strings.HasSuffix(ref, indexSuffix)
+}
+
+// Index describes a persisted log.
+type Index struct {
+	JobID   string
+	TaskID  string
+	RunID   string
+	Created time.Time
+	Size    int64    // Uncompressed size in bytes
+	Chunks  []string // Names of the gzipped chunks, in order
+	// When the log may be swept, if its job asked for a retention. Zero if the sweeper's retention applies.
+	Expires time.Time
+}
+
+// Config for persisting logs. Zero values use the defaults.
+type Config struct {
+	ChunkSize int64
+	Retention time.Duration
+}
+
+// Persister writes logs to a Store.
+type Persister struct {
+	store store.Store
+	cfg   Config
+	stat  stats.StatsReceiver
+}
+
+func NewPersister(s store.Store, cfg Config, stat stats.StatsReceiver) *Persister {
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = DefaultRetention
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	return &Persister{store: s, cfg: cfg, stat: stat}
+}
+
+// Persists the log at path and returns its reference.
+// The log is keyed by job, task and run ID plus the current time, since run IDs restart with the worker.
+// It's kept for retention, or if zero the Persister's configured Retention and the sweeper's default.
+func (p *Persister) PersistFile(path, jobID, taskID, runID string, retention time.Duration) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return p.Persist(f, jobID, taskID, runID, retention)
+}
+
+// Like PersistFile, but returns the log's reference as soon as path is opened and persists it in the background,
+// so callers don't wait on the Store. Reading the reference fails as if there's no log until it's written,
+// and failures are logged and counted rather than returned. path may be removed once this returns.
+func (p *Persister) PersistFileAsync(path, jobID, taskID, runID string, retention time.Duration) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	key := logKeyFor(jobID, taskID, runID, now)
+	go func() {
+		defer f.Close()
+		if _, err := p.record(p.persist(f, key, jobID, taskID, runID, now, retention)); err != nil {
+			log.WithFields(
+				log.Fields{
+					"jobID":  jobID,
+					"taskID": taskID,
+					"runID":  runID,
+					"err":    err,
+				}).Error("Failed to persist run log")
+		}
+	}()
+	return key + indexSuffix, nil
+}
+
+// Persists the log read from r and returns its reference.
+func (p *Persister) Persist(r io.Reader, jobID, taskID, runID string, retention time.Duration) (string, error) {
+	now := time.Now()
+	return p.record(p.persist(r, logKeyFor(jobID, taskID, runID, now), jobID, taskID, runID, now, retention))
+}
+
+// Counts the result of persisting a log.
+func (p *Persister) record(ref string, err error) (string, error) {
+	if err != nil {
+		p.stat.Counter(stats.WorkerLogPersistFailures).Inc(1)
+		return "", err
+	}
+	p.stat.Counter(stats.WorkerLogsPersisted).Inc(1)
+	return ref, nil
+}
+
+// Returns the key shared by the index and chunks of a log persisted at now.
+func logKeyFor(jobID, taskID, runID string, now time.Time) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s/%s/%s/%d", jobID, taskID, runID, now.UnixNano())))
+	return namePrefix + hex.EncodeToString(sum[:])
+}
+
+func (p *Persister) persist(r io.Reader, key, jobID, taskID, runID string, now time.Time, retention time.Duration) (string, error) {
+	idx := Index{JobID: jobID, TaskID: taskID, RunID: runID, Created: now, Chunks: []string{}}
+	ttl := &store.TTLValue{TTL: now.Add(p.cfg.Retention), TTLKey: store.DefaultTTLKey}
+	if retention > 0 {
+		idx.Expires = now.Add(retention)
+		ttl.TTL = idx.Expires
+	}
+
+	for {
+		var chunk bytes.Buffer
+		zw := gzip.NewWriter(&chunk)
+		n, err := io.Copy(zw, io.LimitReader(r, p.cfg.ChunkSize))
+		if err != nil {
+			return "", err
+		}
+		if n == 0 && len(idx.Chunks) > 0 {
+			break
+		}
+		if err := zw.Close(); err != nil {
+			return "", err
+		}
+		name := fmt.Sprintf("%s-%d%s", key, len(idx.Chunks), chunkSuffix)
+		if err := p.store.Write(name, &chunk, ttl); err != nil {
+			return "", fmt.Errorf("Error writing log chunk %s: %v", name, err)
+		}
+		idx.Chunks = append(idx.Chunks, name)
+		idx.Size += n
+		p.stat.Counter(stats.WorkerLogPersistedBytes).Inc(n)
+		if n < p.cfg.ChunkSize {
+			break
+		}
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return "", err
+	}
+	ref := key + indexSuffix
+	if err := p.store.Write(ref, bytes.NewReader(data), ttl); err != nil {
+		return "", fmt.Errorf("Error writing log index %s: %v", ref, err)
+	}
+	return ref, nil
+}
+
+// Reads the index of the log with the given reference.
+func ReadIndex(s store.StoreRead, ref string) (*Index, error) {
+	if !IsLogRef(ref) {
+		return nil, fmt.Errorf("Invalid log ref: %q", ref)
+	}
+	r, err := s.OpenForRead(ref)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	idx := &Index{}
+	if err := json.NewDecoder(r).Decode(idx); err != nil {
+		return nil, fmt.Errorf("Error reading log index %s: %v", ref, err)
+	}
+	return idx, nil
+}
+
+// Writes the uncompressed contents of the log with the given reference to w.
+func Read(s store.StoreRead, ref string, w io.Writer) error {
+	idx, err := ReadIndex(s, ref)
+	if err != nil {
+		return err
+	}
+	for _, name := range idx.Chunks {
+		if err := readChunk(s, name, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readChunk(s store.StoreRead, name string, w io.Writer) error {
+	r, err := s.OpenForRead(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("Error reading log chunk %s: %v", name, err)
+	}
+	defer zr.Close()
+	_, err = io.Copy(w, zr)
+	return err
+}
+
+// Handler serves persisted logs as plain text at HttpPath + ref.
+type Handler struct {
+	store store.StoreRead
+}
+
+func NewHandler(s store.StoreRead) *Handler {
+	return &Handler{store: s}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ref := strings.TrimPrefix(req.URL.Path, HttpPath)
+	if !IsLogRef(ref) {
+		http.Error(w, fmt.Sprintf("Invalid log ref: %q", ref), http.StatusBadRequest)
+		return
+	}
+	idx, err := ReadIndex(h.store, ref)
+	if err != nil {
+		log.Infof("Error reading log %s: %v (from %v)", ref, err, req.RemoteAddr)
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, name := range idx.Chunks {
+		if err := readChunk(h.store, name, w); err != nil {
+			log.Infof("Error reading log %s chunk %s: %v (from %v)", ref, name, err, req.RemoteAddr)
+			return
+		}
+	}
+}
+
+// Deletes persisted log files in dir, the root of a FileStore, and in its pack files that have expired: those of logs
+// whose index has an Expires in the past, and those of other logs that were written more than retention ago.
+// Returns the number of files deleted.
+//
+// Other Stores can't delete, so logs written to them are expired by the store itself: every chunk and index
+// is written with a TTL of its Expires, or of the Persister's Retention.
+func Sweep(dir string, retention time.Duration) (int, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	fs, err := store.MakeFileStore(dir)
+	if err != nil {
+		return 0, err
+	}
+	inRoot := map[string]bool{}
+	for _, info := range infos {
+		if !info.IsDir() && IsLogName(info.Name()) {
+			inRoot[info.Name()] = true
+		}
+	}
+	// Packed files also written to the root are read from the root
+	packed := []store.PackedBlob{}
+	for _, b := range fs.PackedBlobs() {
+		if IsLogName(b.Name) && !inRoot[b.Name] {
+			packed = append(packed, b)
+		}
+	}
+
+	now := time.Now()
+	expires := map[string]time.Time{}
+	for name := range inRoot {
+		if IsLogRef(name) {
+			if exp := readExpires(fs, name); !exp.IsZero() {
+				expires[logKey(name)] = exp
+			}
+		}
+	}
+	for _, b := range packed {
+		if IsLogRef(b.Name) {
+			if exp := readExpires(fs, b.Name); !exp.IsZero() {
+				expires[logKey(b.Name)] = exp
+			}
+		}
+	}
+
+	cutoff := now.Add(-retention)
+	expired := func(name string, modTime time.Time) bool {
+		exp, ok := expires[logKey(name)]
+		return ok && now.After(exp) || !ok && modTime.Before(cutoff)
+	}
+	removed := 0
+	for _, info := range infos {
+		if !inRoot[info.Name()] || !expired(info.Name(), info.ModTime()) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	names := []string{}
+	for _, b := range packed {
+		if expired(b.Name, b.ModTime) {
+			names = append(names, b.Name)
+		}
+	}
+	if len(names) == 0 {
+		return removed, nil
+	}
+	n, err := fs.RemovePacked(names)
+	return removed + n, err
+}
+
+// Returns the key shared by a log's index and chunks, ex: log-<sha1> for log-<sha1>-0.gz.
+func logKey(name string) string {
+	if strings.HasSuffix(name, indexSuffix) {
+		return strings.TrimSuffix(name, indexSuffix)
+	}
+	return name[:strings.LastIndex(name, "-")]
+}
+
+// Returns the Expires of the log index ref, or zero if it has none or can't be read,
+// in which case the sweeper's retention applies.
+func readExpires(s store.StoreRead, ref string) time.Time {
+	idx, err := ReadIndex(s, ref)
+	if err != nil {
+		log.Infof("Error reading log index %s, sweeping it by age: %v", ref, err)
+		return time.Time{}
+	}
+	return idx.Expires
+}
+
+// Sweeps dir every interval. Never returns.
+func SweepPeriodically(dir string, retention, interval time.Duration, stat stats.StatsReceiver) {
+	for range time.NewTicker(interval).C {
+		removed, err := Sweep(dir, retention)
+		stat.Counter(stats.BundlestoreLogsSweptCounter).Inc(int64(removed))
+		if err != nil {
+			log.Errorf("Error sweeping persisted logs in %s: %v", dir, err)
+		} else if removed > 0 {
+			log.Infof("Swept %d expired persisted log files from %s", removed, dir)
+		}
+	}
+}
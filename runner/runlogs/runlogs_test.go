@@ -0,0 +1,263 @@
+package runlogs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+func makeStore(t *testing.T) (*store.FileStore, string) {
+	dir, err := ioutil.TempDir("", "runlogs_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := store.MakeFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, dir
+}
+
+func TestPersistAndRead(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{ChunkSize: 10}, stats.NilStatsReceiver())
+
+	for _, data := range []string{"", "short", strings.Repeat("0123456789", 3), strings.Repeat("x", 25)} {
+		ref, err := p.Persist(strings.NewReader(data), "job", "task", "0", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsLogRef(ref) {
+			t.Fatalf("Expected a log ref, got %q", ref)
+		}
+		idx, err := ReadIndex(s, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx.JobID != "job" || idx.TaskID != "task" || idx.RunID != "0" || idx.Size != int64(len(data)) {
+			t.Fatalf("Unexpected index for %q: %+v", data, idx)
+		}
+		if expected := (len(data) + 9) / 10; len(idx.Chunks) != expected && !(expected == 0 && len(idx.Chunks) == 1) {
+			t.Fatalf("Expected %d chunks for %q, got %v", expected, data, idx.Chunks)
+		}
+		var out bytes.Buffer
+		if err := Read(s, ref, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != data {
+			t.Fatalf("Expected %q, got %q", data, out.String())
+		}
+	}
+
+	// Run IDs restart with the worker so persisting the same run again must not collide.
+	ref1, _ := p.Persist(strings.NewReader("a"), "job", "task", "0", 0)
+	ref2, _ := p.Persist(strings.NewReader("b"), "job", "task", "0", 0)
+	if ref1 == ref2 {
+		t.Fatalf("Expected distinct refs, got %s twice", ref1)
+	}
+	if err := Read(s, "bs-not-a-log.bundle", &bytes.Buffer{}); err == nil {
+		t.Fatal("Expected error reading an invalid ref")
+	}
+}
+
+func TestPersistFileAsync(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{ChunkSize: 4}, nil)
+
+	path := filepath.Join(dir, "stdlog")
+	if err := ioutil.WriteFile(path, []byte("hello world\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := p.PersistFileAsync(path, "job", "task", "0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsLogRef(ref) {
+		t.Fatalf("Expected a log ref, got %q", ref)
+	}
+	// The file was opened before returning, so it can be removed while it's persisted.
+	os.Remove(path)
+
+	var out bytes.Buffer
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		out.Reset()
+		if err := Read(s, ref, &out); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("Log %s wasn't persisted: %v", ref, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if out.String() != "hello world\n" {
+		t.Fatalf("Expected %q, got %q", "hello world\n", out.String())
+	}
+
+	if _, err := p.PersistFileAsync(path, "job", "task", "1", 0); err == nil {
+		t.Fatal("Expected error persisting a missing file")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{ChunkSize: 4}, nil)
+	ref, err := p.Persist(strings.NewReader("hello world\n"), "job", "task", "1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler(s)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", HttpPath+ref, nil))
+	if w.Code != 200 || w.Body.String() != "hello world\n" {
+		t.Fatalf("Unexpected response %d %q", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", HttpPath+"log-"+strings.Repeat("0", 40)+".json", nil))
+	if w.Code != 404 {
+		t.Fatalf("Expected 404 for missing log, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", HttpPath+"foo", nil))
+	if w.Code != 400 {
+		t.Fatalf("Expected 400 for invalid ref, got %d", w.Code)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{}, nil)
+	oldRef, err := p.Persist(strings.NewReader("old"), "job", "task", "0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRef, err := p.Persist(strings.NewReader("new"), "job", "task", "1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := "bs-" + strings.Repeat("0", 40) + ".bundle"
+	if err := ioutil.WriteFile(filepath.Join(dir, bundle), []byte("bundle"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	idx, _ := ReadIndex(s, oldRef)
+	for _, name := range append(idx.Chunks, oldRef, bundle) {
+		if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := Sweep(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Fatalf("Expected 2 files removed, got %d", removed)
+	}
+	if ok, _ := s.Exists(oldRef); ok {
+		t.Fatalf("Expected %s to be swept", oldRef)
+	}
+	for _, name := range []string{newRef, bundle} {
+		if ok, _ := s.Exists(name); !ok {
+			t.Fatalf("Expected %s to be kept", name)
+		}
+	}
+}
+
+func TestSweepRetention(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{}, nil)
+	// Kept past the sweeper's retention, ex: a release build's logs.
+	longRef, err := p.Persist(strings.NewReader("long"), "job", "task", "0", 3*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Swept before the sweeper's retention, ex: a CI scratch run's logs.
+	shortRef, err := p.Persist(strings.NewReader("short"), "job", "task", "1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx, _ := ReadIndex(s, shortRef); idx == nil || idx.Expires.IsZero() {
+		t.Fatalf("Expected %s to record when it expires, got %+v", shortRef, idx)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	idx, _ := ReadIndex(s, longRef)
+	for _, name := range append(idx.Chunks, longRef) {
+		if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	removed, err := Sweep(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Fatalf("Expected 2 files removed, got %d", removed)
+	}
+	if ok, _ := s.Exists(shortRef); ok {
+		t.Fatalf("Expected %s to be swept", shortRef)
+	}
+	if ok, _ := s.Exists(longRef); !ok {
+		t.Fatalf("Expected %s to be kept", longRef)
+	}
+}
+
+func TestSweepPacked(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	p := NewPersister(s, Config{}, nil)
+	oldRef, err := p.Persist(strings.NewReader("old"), "job", "task", "0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	longRef, err := p.Persist(strings.NewReader("long"), "job", "task", "1", 3*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	names := []string{}
+	for _, ref := range []string{oldRef, longRef} {
+		idx, _ := ReadIndex(s, ref)
+		names = append(names, append(idx.Chunks, ref)...)
+	}
+	for _, name := range names {
+		if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if result, err := s.Compact(store.CompactionConfig{}); err != nil || result.Packed != len(names) {
+		t.Fatalf("Expected %d files packed, got %+v %v", len(names), result, err)
+	}
+
+	removed, err := Sweep(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Fatalf("Expected 2 files removed, got %d", removed)
+	}
+	if ok, _ := s.Exists(oldRef); ok {
+		t.Fatalf("Expected %s to be swept", oldRef)
+	}
+	if ok, _ := s.Exists(longRef); !ok {
+		t.Fatalf("Expected %s to be kept", longRef)
+	}
+}
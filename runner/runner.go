@@ -46,6 +46,13 @@ type Command struct {
 	// Runner can optionally use this to run against a particular snapshot. Empty value is ignored.
 	SnapshotID string
 
+	// How long the run's output snapshot and persisted log are kept. Zero uses the worker's defaults.
+	Retention time.Duration
+
+	// Git sparse-checkout patterns, gitignore style, limiting which paths of SnapshotID are checked out.
+	// Empty checks out the whole snapshot, as do Filers that aren't snapshot.SparseCheckouters.
+	SparsePatterns []string
+
 	// TODO(jschiller): get consensus on design and either implement or delete.
 	// Runner can optionally use this to specify content if creating a new snapshot.
 	// Keys: relative src file & dir paths in SnapshotId checkout. May contain '*' wildcard.
@@ -0,0 +1,107 @@
+package runners
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+const (
+	// The default number of action results a worker keeps locally.
+	DefaultLocalActionCacheCapacity = 1000
+
+	// The default time a locally cached action result may be reused.
+	DefaultLocalActionCacheTTL = 10 * time.Minute
+)
+
+// LocalActionCache is a small, in-memory cache of the results of bazel actions this worker ran,
+// keyed by action digest. It's only consulted when the central ActionCache can't be reached,
+// so a worker re-running a recent identical action can still reuse its result.
+// Entries expire after ttl so results can't drift far from what the central ActionCache would return.
+// A nil *LocalActionCache caches nothing.
+type LocalActionCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	capacity int
+	now      func() time.Time
+	entries  map[string]*list.Element
+	order    *list.List // least recently stored at the front
+}
+
+type localActionCacheEntry struct {
+	key     string
+	result  *remoteexecution.ActionResult
+	expires time.Time
+}
+
+// NewLocalActionCache creates a LocalActionCache holding at most capacity results for ttl each.
+// Capacity <= 0 means DefaultLocalActionCacheCapacity, and ttl <= 0 means DefaultLocalActionCacheTTL.
+func NewLocalActionCache(capacity int, ttl time.Duration) *LocalActionCache {
+	if capacity <= 0 {
+		capacity = DefaultLocalActionCacheCapacity
+	}
+	if ttl <= 0 {
+		ttl = DefaultLocalActionCacheTTL
+	}
+	return &LocalActionCache{
+		ttl:      ttl,
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func localActionCacheKey(d *remoteexecution.Digest) string {
+	return fmt.Sprintf("%s-%d", d.GetHash(), d.GetSizeBytes())
+}
+
+// Get returns the unexpired result stored for action digest d, or nil.
+func (c *LocalActionCache) Get(d *remoteexecution.Digest) *remoteexecution.ActionResult {
+	if c == nil || d == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := localActionCacheKey(d)
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := e.Value.(*localActionCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil
+	}
+	return proto.Clone(entry.result).(*remoteexecution.ActionResult)
+}
+
+// Put stores ar as the result of action digest d, evicting the oldest result if the cache is full.
+func (c *LocalActionCache) Put(d *remoteexecution.Digest, ar *remoteexecution.ActionResult) {
+	if c == nil || d == nil || ar == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := localActionCacheKey(d)
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+	}
+	entry := &localActionCacheEntry{
+		key:     key,
+		result:  proto.Clone(ar).(*remoteexecution.ActionResult),
+		expires: c.now().Add(c.ttl),
+	}
+	c.entries[key] = c.order.PushBack(entry)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Front()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*localActionCacheEntry).key)
+	}
+}
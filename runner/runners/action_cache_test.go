@@ -0,0 +1,51 @@
+package runners
+
+import (
+	"testing"
+	"time"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+func TestLocalActionCache(t *testing.T) {
+	c := NewLocalActionCache(2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	d1 := &remoteexecution.Digest{Hash: "aaaa", SizeBytes: 1}
+	d2 := &remoteexecution.Digest{Hash: "bbbb", SizeBytes: 2}
+	d3 := &remoteexecution.Digest{Hash: "cccc", SizeBytes: 3}
+
+	if ar := c.Get(d1); ar != nil {
+		t.Fatalf("Expected empty cache, got %v", ar)
+	}
+	c.Put(d1, &remoteexecution.ActionResult{ExitCode: 1})
+	if ar := c.Get(d1); ar == nil || ar.GetExitCode() != 1 {
+		t.Fatalf("Expected cached result, got %v", ar)
+	}
+	if ar := c.Get(&remoteexecution.Digest{Hash: "aaaa", SizeBytes: 4}); ar != nil {
+		t.Fatalf("Expected miss for a different size, got %v", ar)
+	}
+
+	// The oldest result is evicted past capacity.
+	c.Put(d2, &remoteexecution.ActionResult{ExitCode: 2})
+	c.Put(d3, &remoteexecution.ActionResult{ExitCode: 3})
+	if ar := c.Get(d1); ar != nil {
+		t.Fatalf("Expected oldest result to be evicted, got %v", ar)
+	}
+	if ar := c.Get(d3); ar == nil || ar.GetExitCode() != 3 {
+		t.Fatalf("Expected cached result, got %v", ar)
+	}
+
+	// Results expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	if ar := c.Get(d2); ar != nil {
+		t.Fatalf("Expected result to expire, got %v", ar)
+	}
+
+	var nilCache *LocalActionCache
+	nilCache.Put(d1, &remoteexecution.ActionResult{})
+	if ar := nilCache.Get(d1); ar != nil {
+		t.Fatalf("Expected nil cache to cache nothing, got %v", ar)
+	}
+}
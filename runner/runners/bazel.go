@@ -3,12 +3,11 @@ package runners
 // Bazel-related logic for runners
 
 import (
-	"crypto/sha256"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -16,24 +15,30 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	log "github.com/sirupsen/logrus"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
 	google_rpc_code "google.golang.org/genproto/googleapis/rpc/code"
 	google_rpc_errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	google_rpc_status "google.golang.org/genproto/googleapis/rpc/status"
 
 	"github.com/twitter/scoot/bazel"
 	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/bazel/client"
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
 	scootproto "github.com/twitter/scoot/common/proto"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/backends"
 	"github.com/twitter/scoot/snapshot"
 	bzsnapshot "github.com/twitter/scoot/snapshot/bazel"
 )
 
 // Preliminary setup for handling Bazel commands - verify Filer and populate command.
-// Can return a bazelapi.ActionResult if a result was found already in the ActionCache.
+// Can return a bazelapi.ActionResult if a result was found already in the ActionCache,
+// or in localCache if the ActionCache couldn't be reached.
 // Returns slice of any digests that could not be retrieved.
-func preProcessBazel(filer snapshot.Filer, cmd *runner.Command, rts *runTimes) (*bazelapi.ActionResult, []*remoteexecution.Digest, error) {
+func preProcessBazel(filer snapshot.Filer, cmd *runner.Command, rts *runTimes,
+	localCache *LocalActionCache, stat stats.StatsReceiver) (*bazelapi.ActionResult, []*remoteexecution.Digest, error) {
 	notExist := []*remoteexecution.Digest{}
 
 	bzFiler, ok := filer.(*bzsnapshot.BzFiler)
@@ -54,6 +59,16 @@ func preProcessBazel(filer snapshot.Filer, cmd *runner.Command, rts *runTimes) (
 			// Only treat as an error if we didn't get NotFoundError. We still continue:
 			// cache lookup failure is internal, and should not prevent the run
 			if !cas.IsNotFoundError(err) {
+				// The ActionCache is unreachable, fall back to a result this worker recently produced.
+				if ar := localCache.Get(cmd.ExecuteRequest.GetRequest().GetActionDigest()); ar != nil {
+					log.Infof("Failed to check for cached result, returning locally cached result: %s", err)
+					stat.Counter(stats.WorkerLocalActionCacheHits).Inc(1)
+					return &bazelapi.ActionResult{
+						Result:       ar,
+						ActionDigest: cmd.ExecuteRequest.GetRequest().GetActionDigest(),
+						Cached:       true,
+					}, notExist, nil
+				}
 				log.Errorf("Failed to check for cached result, will execute: %s", err)
 			}
 		} else if ar != nil {
@@ -150,17 +165,57 @@ func setupJDKSymlink(path, filename string) error {
 	return os.Symlink(jh, filepath.Join(path, filename))
 }
 
-// API indicates directories where output files and directories would be created exist before execution
-func createOutputPaths(cmd *runner.Command, coDir string) error {
-	for _, relPath := range cmd.ExecuteRequest.GetCommand().GetOutputFiles() {
-		dir := filepath.Dir(filepath.Join(coDir, relPath))
-		err := os.MkdirAll(dir, 0777)
-		if err != nil {
-			return fmt.Errorf("Failed to create parent output dir %s: %s", dir, err)
+// Returns the dir a Bazel command runs in and its outputs are relative to: the Command's WorkingDirectory
+// within the checkout at coDir, or coDir itself if it's unset.
+func workingDir(cmd *runner.Command, coDir string) (string, error) {
+	wd := cmd.ExecuteRequest.GetCommand().GetWorkingDirectory()
+	if wd == "" {
+		return coDir, nil
+	}
+	clean := filepath.Clean(wd)
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("WorkingDirectory %q must be relative to and within the input root", wd)
+	}
+	return filepath.Join(coDir, clean), nil
+}
+
+// Returns the output paths of a Bazel command whose type is only known after execution, and those
+// that must be files and directories. Commands from v2.1 clients set OutputPaths, which supersedes
+// the deprecated OutputFiles and OutputDirectories still set by older clients.
+func outputPaths(cmd *runner.Command) (paths, files, dirs []string) {
+	c := cmd.ExecuteRequest.GetCommand()
+	if len(c.GetOutputPaths()) > 0 {
+		return c.GetOutputPaths(), nil, nil
+	}
+	return nil, c.GetOutputFiles(), c.GetOutputDirectories()
+}
+
+// Returns the execution backend a command selected with its Bazel platform properties or env,
+// empty for the worker's default.
+func execBackend(cmd *runner.Command) string {
+	for _, pp := range cmd.ExecuteRequest.GetCommand().GetPlatform().GetProperties() {
+		if pp.GetName() == backends.PlatformProperty {
+			return pp.GetValue()
 		}
 	}
-	for _, relPath := range cmd.ExecuteRequest.GetCommand().GetOutputDirectories() {
-		dir := filepath.Dir(filepath.Join(coDir, relPath))
+	return cmd.EnvVars[backends.EnvVar]
+}
+
+// Returns the network access a command allowed itself with its Bazel platform properties or env.
+func execNetwork(cmd *runner.Command) (execer.NetworkPolicy, error) {
+	for _, pp := range cmd.ExecuteRequest.GetCommand().GetPlatform().GetProperties() {
+		if pp.GetName() == execer.NetworkPlatformProperty {
+			return execer.ParseNetworkPolicy(pp.GetValue())
+		}
+	}
+	return execer.ParseNetworkPolicy(cmd.EnvVars[execer.NetworkEnvVar])
+}
+
+// API indicates directories where output files and directories would be created exist before execution
+func createOutputPaths(cmd *runner.Command, workDir string) error {
+	paths, files, dirs := outputPaths(cmd)
+	for _, relPath := range append(append(paths, files...), dirs...) {
+		dir := filepath.Dir(filepath.Join(workDir, relPath))
 		err := os.MkdirAll(dir, 0777)
 		if err != nil {
 			return fmt.Errorf("Failed to create parent output dir %s: %s", dir, err)
@@ -175,38 +230,66 @@ func postProcessBazel(filer snapshot.Filer,
 	coDir string,
 	stdout, stderr runner.Output,
 	st execer.ProcessStatus,
-	rts *runTimes) (*bazelapi.ActionResult, error) {
+	rts *runTimes,
+	localCache *LocalActionCache) (*bazelapi.ActionResult, error) {
 	bzFiler, ok := filer.(*bzsnapshot.BzFiler)
 	if !ok {
 		return nil, fmt.Errorf("Filer could not be asserted as type BzFiler. Type is: %s", reflect.TypeOf(filer))
 	}
 	log.Info("Processing Bazel outputs to CAS")
 
-	stdoutDigest, err := writeFileToCAS(bzFiler, stdout.AsFile())
+	// Outputs are digested with the Action's digest function, then uploaded together,
+	// skipping any the CAS already has.
+	ad := cmd.ExecuteRequest.GetRequest().GetActionDigest()
+	casClient := client.NewClient(bzFiler.CASResolver, client.DefaultRetryPolicy)
+	defer casClient.Close()
+	casClient.DigestFunction = bazel.InferDigestFunction(ad.GetHash())
+	uploader := casClient.NewUploader()
+
+	stdoutDigest, err := uploader.AddFile(stdout.AsFile())
 	if err != nil {
 		errstr := fmt.Sprintf("Error ingesting stdout to CAS: %s", err)
 		log.Error(errstr)
 		return nil, fmt.Errorf(errstr)
 	}
-	stderrDigest, err := writeFileToCAS(bzFiler, stderr.AsFile())
+	stderrDigest, err := uploader.AddFile(stderr.AsFile())
 	if err != nil {
 		errstr := fmt.Sprintf("Error ingesting stderr to CAS: %s", err)
 		log.Error(errstr)
 		return nil, fmt.Errorf(errstr)
 	}
 
-	outputFiles, err := ingestOutputFiles(bzFiler, cmd, coDir)
+	workDir, err := workingDir(cmd, coDir)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	paths, files, dirs := outputPaths(cmd)
+	outputFiles, err := ingestOutputFiles(uploader, files, workDir)
 	if err != nil {
 		errstr := fmt.Sprintf("Error ingesting OutputFiles: %s", err)
 		log.Error(errstr)
 		return nil, fmt.Errorf(errstr)
 	}
-	outputDirs, err := ingestOutputDirs(bzFiler, cmd, coDir)
+	outputDirs, err := ingestOutputDirs(uploader, dirs, workDir)
 	if err != nil {
 		errstr := fmt.Sprintf("Error ingesting OutputDirs: %s", err)
 		log.Error(errstr)
 		return nil, fmt.Errorf(errstr)
 	}
+	pathFiles, pathDirs, err := ingestOutputPaths(uploader, paths, workDir)
+	if err != nil {
+		errstr := fmt.Sprintf("Error ingesting OutputPaths: %s", err)
+		log.Error(errstr)
+		return nil, fmt.Errorf(errstr)
+	}
+	outputFiles = append(outputFiles, pathFiles...)
+	outputDirs = append(outputDirs, pathDirs...)
+	if err := uploader.Upload(context.Background()); err != nil {
+		errstr := fmt.Sprintf("Error uploading outputs to CAS: %s", err)
+		log.Error(errstr)
+		return nil, fmt.Errorf(errstr)
+	}
 
 	rts.outputEnd = stamp()
 	rts.invokeEnd = stamp()
@@ -235,15 +318,18 @@ func postProcessBazel(filer snapshot.Filer,
 		StderrDigest:      stderrDigest,
 		ExecutionMetadata: metadata,
 	}
-	ad := cmd.ExecuteRequest.GetRequest().GetActionDigest()
 
-	// Add result to ActionCache. Errors non-fatal
-	if !cmd.ExecuteRequest.GetAction().GetDoNotCache() {
+	// Add result to ActionCache. Errors non-fatal. Failed commands aren't cached so clients rerun them.
+	// The result is kept for as long as the request's ResultsCachePolicy calls for, but since outputs are
+	// uploaded without a priority the CAS keeps it no longer than them, see cas.CachePriorityHeader.
+	if !cmd.ExecuteRequest.GetAction().GetDoNotCache() && ar.GetExitCode() == 0 {
 		log.Info("Updating results in ActionCache")
-		_, err = cas.UpdateCacheResult(bzFiler.CASResolver, ad, ar, 2)
+		policy := cmd.ExecuteRequest.GetRequest().GetResultsCachePolicy()
+		_, err = cas.UpdateCacheResult(bzFiler.CASResolver, ad, ar, policy, 2)
 		if err != nil {
 			log.Errorf("Error updating result to ActionCache: %s", err)
 		}
+		localCache.Put(ad, ar)
 	}
 
 	return &bazelapi.ActionResult{
@@ -253,34 +339,14 @@ func postProcessBazel(filer snapshot.Filer,
 	}, nil
 }
 
-// Write a file's bytes to the BzFiler's CAS, returning the Digest of the uploaded file.
-// This is distinct from using a Filer to Ingest data into the CAS,
-// which allows for Filer-implementation-specific behavior that could alter the bytes and expected digest.
-// (Our typical BzFiler use case is uploading Files that include relative path and other protobuf data)
-func writeFileToCAS(bzFiler *bzsnapshot.BzFiler, path string) (*remoteexecution.Digest, error) {
-	bytes, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading file %s as bytes: %s", path, err)
-	}
-	sha := fmt.Sprintf("%x", sha256.Sum256(bytes))
-	digest := &remoteexecution.Digest{Hash: sha, SizeBytes: int64(len(bytes))}
-
-	err = cas.ByteStreamWrite(bzFiler.CASResolver, digest, bytes, 2)
-	if err != nil {
-		return nil, fmt.Errorf("Error writing data to CAS server: %s", err)
-	}
-
-	return digest, nil
-}
-
-// Ingest any of a command's OutputFiles that are specified in a Bazel ExecuteRequest.
+// Ingest any of a command's OutputFiles that are specified in a Bazel ExecuteRequest, relative to workDir.
 // Files that do not exist are skipped, and this is not considered an error,
 // but we do error if a specified "file" path results in a directory.
-// Files located are Ingested in to the CAS via the BzFiler
-func ingestOutputFiles(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir string) ([]*remoteexecution.OutputFile, error) {
+// Files located are added to the Uploader
+func ingestOutputFiles(uploader *client.Uploader, relPaths []string, workDir string) ([]*remoteexecution.OutputFile, error) {
 	outputFiles := []*remoteexecution.OutputFile{}
-	for _, relPath := range cmd.ExecuteRequest.GetCommand().GetOutputFiles() {
-		absPath := filepath.Join(coDir, relPath)
+	for _, relPath := range relPaths {
+		absPath := filepath.Join(workDir, relPath)
 		info, err := os.Stat(absPath)
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -296,9 +362,9 @@ func ingestOutputFiles(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir s
 		// check executable bits
 		executable := (info.Mode() & 0111) > 0
 
-		digest, err := ingestPath(bzFiler, absPath)
+		digest, err := uploader.AddFile(absPath)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Error adding output file %s: %s", absPath, err)
 		}
 		log.Infof("Ingested OutputFile: %s", relPath)
 
@@ -312,14 +378,14 @@ func ingestOutputFiles(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir s
 	return outputFiles, nil
 }
 
-// Ingest any of a command's OutputDirectories that are specified in a Bazel ExecuteRequest.
+// Ingest any of a command's OutputDirectories that are specified in a Bazel ExecuteRequest, relative to workDir.
 // Directories that do not exist are skipped, and this is not considered an error,
 // but we do error if a specified "directory" path results in a file.
-// Directories located are Ingested in to the CAS via the BzFiler
-func ingestOutputDirs(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir string) ([]*remoteexecution.OutputDirectory, error) {
+// Directories located are added to the Uploader as Trees
+func ingestOutputDirs(uploader *client.Uploader, relPaths []string, workDir string) ([]*remoteexecution.OutputDirectory, error) {
 	outputDirs := []*remoteexecution.OutputDirectory{}
-	for _, relPath := range cmd.ExecuteRequest.GetCommand().GetOutputDirectories() {
-		absPath := filepath.Join(coDir, relPath)
+	for _, relPath := range relPaths {
+		absPath := filepath.Join(workDir, relPath)
 		info, err := os.Stat(absPath)
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -333,9 +399,9 @@ func ingestOutputDirs(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir st
 			return nil, fmt.Errorf("Expected output dir %s is not a directory", absPath)
 		}
 
-		digest, err := ingestPath(bzFiler, absPath)
+		digest, err := uploader.AddTree(absPath)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Error adding output dir %s: %s", absPath, err)
 		}
 		log.Infof("Ingested OutputDirectory: %s", relPath)
 
@@ -348,18 +414,36 @@ func ingestOutputDirs(bzFiler *bzsnapshot.BzFiler, cmd *runner.Command, coDir st
 	return outputDirs, nil
 }
 
-// Ingest a file into the BzFiler, which can store to a CAS. Take the resulting SnapshotID
-// from Ingestion and return a Bazel Digest (used for direct retrieval from CAS by a client)
-func ingestPath(bzFiler *bzsnapshot.BzFiler, absPath string) (*remoteexecution.Digest, error) {
-	id, err := bzFiler.Ingest(absPath)
+// Ingest any of a command's OutputPaths, relative to workDir, as OutputFiles or OutputDirectories
+// depending on what the command created. Paths that do not exist are skipped.
+func ingestOutputPaths(uploader *client.Uploader, relPaths []string, workDir string) (
+	[]*remoteexecution.OutputFile, []*remoteexecution.OutputDirectory, error) {
+	files, dirs := []string{}, []string{}
+	for _, relPath := range relPaths {
+		absPath := filepath.Join(workDir, relPath)
+		info, err := os.Stat(absPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Infof("Output path %s not present, skipping ingestion", absPath)
+				continue
+			}
+			return nil, nil, fmt.Errorf("Error Statting output path %s: %s", absPath, err)
+		}
+		if info.IsDir() {
+			dirs = append(dirs, relPath)
+		} else {
+			files = append(files, relPath)
+		}
+	}
+	outputFiles, err := ingestOutputFiles(uploader, files, workDir)
 	if err != nil {
-		return nil, fmt.Errorf("Error Ingesting output file %s: %s", absPath, err)
+		return nil, nil, err
 	}
-	digest, err := bazel.DigestFromSnapshotID(id)
+	outputDirs, err := ingestOutputDirs(uploader, dirs, workDir)
 	if err != nil {
-		return nil, fmt.Errorf("Error converting ingested snapshot ID %s to digest: %s", id, err)
+		return nil, nil, err
 	}
-	return digest, nil
+	return outputFiles, outputDirs, nil
 }
 
 // Create a google RPC status "failed precondition" error with missing violation data for
@@ -403,3 +487,10 @@ func getInternalErrorStatus(message string) *google_rpc_status.Status {
 		Message: message,
 	}
 }
+
+func getInvalidArgumentStatus(message string) *google_rpc_status.Status {
+	return &google_rpc_status.Status{
+		Code:    int32(google_rpc_code.Code_INVALID_ARGUMENT),
+		Message: message,
+	}
+}
@@ -0,0 +1,84 @@
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/client"
+	"github.com/twitter/scoot/bazel/execution/bazelapi"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/runner"
+)
+
+func bazelCommand(c *remoteexecution.Command) *runner.Command {
+	return &runner.Command{ExecuteRequest: &bazelapi.ExecuteRequest{Command: c}}
+}
+
+func TestWorkingDir(t *testing.T) {
+	for wd, expected := range map[string]string{
+		"":          "/co",
+		"src/main":  "/co/src/main",
+		"./a/../b/": "/co/b",
+	} {
+		dir, err := workingDir(bazelCommand(&remoteexecution.Command{WorkingDirectory: wd}), "/co")
+		if err != nil || dir != expected {
+			t.Errorf("Expected %q for WorkingDirectory %q, got %q %v", expected, wd, dir, err)
+		}
+	}
+	for _, wd := range []string{"/abs", "..", "a/../../b"} {
+		if _, err := workingDir(bazelCommand(&remoteexecution.Command{WorkingDirectory: wd}), "/co"); err == nil {
+			t.Errorf("Expected error for WorkingDirectory %q", wd)
+		}
+	}
+}
+
+func TestOutputPaths(t *testing.T) {
+	// Older clients only set OutputFiles and OutputDirectories.
+	paths, files, dirs := outputPaths(bazelCommand(&remoteexecution.Command{
+		OutputFiles:       []string{"a.out"},
+		OutputDirectories: []string{"gen"},
+	}))
+	if paths != nil || !reflect.DeepEqual(files, []string{"a.out"}) || !reflect.DeepEqual(dirs, []string{"gen"}) {
+		t.Fatalf("Unexpected output paths %v %v %v", paths, files, dirs)
+	}
+
+	// OutputPaths supersedes them.
+	paths, files, dirs = outputPaths(bazelCommand(&remoteexecution.Command{
+		OutputFiles: []string{"a.out"},
+		OutputPaths: []string{"b.out", "gen"},
+	}))
+	if !reflect.DeepEqual(paths, []string{"b.out", "gen"}) || files != nil || dirs != nil {
+		t.Fatalf("Unexpected output paths %v %v %v", paths, files, dirs)
+	}
+}
+
+func TestIngestOutputPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "outputs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "gen", "sub"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "a.out"), []byte("a"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "gen", "sub", "b.txt"), []byte("b"), 0644)
+
+	c := client.NewClient(nil, client.DefaultRetryPolicy)
+	c.DigestFunction = remoteexecution.DigestFunction_SHA512
+	files, dirs, err := ingestOutputPaths(c.NewUploader(), []string{"a.out", "gen", "missing"}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].GetPath() != "a.out" || !files[0].GetIsExecutable() {
+		t.Fatalf("Expected executable output file a.out, got %v", files)
+	}
+	if hash, _ := bazel.HashData(remoteexecution.DigestFunction_SHA512, []byte("a")); files[0].GetDigest().GetHash() != hash {
+		t.Fatalf("Expected a.out to be digested with SHA512, got %s", bazel.DigestToStr(files[0].GetDigest()))
+	}
+	if len(dirs) != 1 || dirs[0].GetPath() != "gen" || dirs[0].GetTreeDigest() == nil {
+		t.Fatalf("Expected output directory gen with a Tree, got %v", dirs)
+	}
+}
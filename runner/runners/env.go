@@ -0,0 +1,87 @@
+package runners
+
+import (
+	"strings"
+
+	"github.com/twitter/scoot/runner"
+)
+
+// Environment variable holding the snapshot checked out for a run.
+const SnapshotIDEnv = "SCOOT_SNAPSHOT_ID"
+
+// EnvPolicy controls which of the worker's own environment variables runs inherit, ex: to keep
+// the worker's credentials from the commands it runs. Runs are given the standard variables describing
+// the run whether or not there's a policy, see runEnv.
+//
+// Patterns are variable names, or prefixes ending in "*". Variables the run's command requests
+// are always passed, and the standard variables override them. Run hooks keep the worker's environment.
+type EnvPolicy struct {
+	Allow []string // worker env vars runs inherit, empty for all of them
+	Deny  []string // worker env vars runs never inherit, even if allowed
+}
+
+// Parses comma separated Allow and Deny patterns, returning nil if both are empty.
+func ParseEnvPolicy(allow, deny string) *EnvPolicy {
+	p := &EnvPolicy{Allow: splitPatterns(allow), Deny: splitPatterns(deny)}
+	if len(p.Allow) == 0 && len(p.Deny) == 0 {
+		return nil
+	}
+	return p
+}
+
+func splitPatterns(s string) []string {
+	patterns := []string{}
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// Returns whether a run inherits the worker env var with the given name.
+func (p *EnvPolicy) inherits(name string) bool {
+	if matchesEnvPattern(p.Deny, name) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesEnvPattern(p.Allow, name)
+}
+
+func matchesEnvPattern(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") && strings.HasPrefix(name, strings.TrimSuffix(p, "*")) || p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the complete environment of a run, given the worker's environment as from os.Environ,
+// and the env vars requested by the run's command.
+func (p *EnvPolicy) env(workerEnv []string, cmdEnv map[string]string, cmd *runner.Command, id runner.RunID) map[string]string {
+	env := map[string]string{}
+	for _, kv := range workerEnv {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 && p.inherits(parts[0]) {
+			env[parts[0]] = parts[1]
+		}
+	}
+	for k, v := range cmdEnv {
+		env[k] = v
+	}
+	return runEnv(env, cmd, id)
+}
+
+// Returns a copy of env with the standard variables describing the run: SCOOT_RUN_ID, SCOOT_JOB_ID,
+// SCOOT_TASK_ID and SCOOT_SNAPSHOT_ID. They override any the command requested.
+func runEnv(cmdEnv map[string]string, cmd *runner.Command, id runner.RunID) map[string]string {
+	env := make(map[string]string, len(cmdEnv)+4)
+	for k, v := range cmdEnv {
+		env[k] = v
+	}
+	env[HookRunIDEnv] = string(id)
+	env[HookJobIDEnv] = cmd.JobID
+	env[HookTaskIDEnv] = cmd.TaskID
+	env[SnapshotIDEnv] = cmd.SnapshotID
+	return env
+}
@@ -0,0 +1,54 @@
+package runners
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
+)
+
+func TestEnvPolicy(t *testing.T) {
+	if p := ParseEnvPolicy("", " , "); p != nil {
+		t.Fatalf("Expected no policy, got %+v", p)
+	}
+
+	p := ParseEnvPolicy("PATH, HOME, LC_*", "LC_SECRET, AWS_*")
+	workerEnv := []string{"PATH=/bin", "HOME=/home/worker", "LC_ALL=C", "LC_SECRET=x", "AWS_SECRET_ACCESS_KEY=y", "USER=worker"}
+	cmd := &runner.Command{SnapshotID: "snap", LogTags: tags.LogTags{JobID: "job", TaskID: "task"}}
+	env := p.env(workerEnv, map[string]string{"HOME": "/tmp", "AWS_REGION": "us", SnapshotIDEnv: "spoofed"}, cmd, "run")
+
+	expected := map[string]string{
+		"PATH":        "/bin",
+		"HOME":        "/tmp",
+		"LC_ALL":      "C",
+		"AWS_REGION":  "us",
+		HookRunIDEnv:  "run",
+		HookJobIDEnv:  "job",
+		HookTaskIDEnv: "task",
+		SnapshotIDEnv: "snap",
+	}
+	if !reflect.DeepEqual(env, expected) {
+		t.Fatalf("Expected %v, got %v", expected, env)
+	}
+
+	// Without an allowlist, everything but the denied vars is inherited.
+	p = ParseEnvPolicy("", "AWS_*")
+	if !p.inherits("USER") || p.inherits("AWS_SECRET_ACCESS_KEY") {
+		t.Fatalf("Expected only AWS_* to be denied by %+v", p)
+	}
+
+	// The standard variables are set without a policy too, without changing the command's env.
+	cmdEnv := map[string]string{"HOME": "/tmp", SnapshotIDEnv: "spoofed"}
+	env = runEnv(cmdEnv, cmd, "run")
+	expected = map[string]string{
+		"HOME":        "/tmp",
+		HookRunIDEnv:  "run",
+		HookJobIDEnv:  "job",
+		HookTaskIDEnv: "task",
+		SnapshotIDEnv: "snap",
+	}
+	if !reflect.DeepEqual(env, expected) || cmdEnv[SnapshotIDEnv] != "spoofed" {
+		t.Fatalf("Expected %v, got %v", expected, env)
+	}
+}
@@ -0,0 +1,134 @@
+package runners
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/runner"
+)
+
+// The default number of finished runs a worker keeps on disk.
+const DefaultRunHistoryCapacity = 1000
+
+const historyFileSuffix = ".json"
+
+// Directory a worker keeps its run history in. It must outlive the worker process for history to
+// survive restarts, so it shouldn't be under the worker's temp dir. Empty keeps it in the temp dir.
+type RunHistoryDir string
+
+// RunHistory is a bounded, on-disk record of finished runs. Each run is stored as a json file in dir,
+// so history survives worker restarts. Once capacity is exceeded the oldest records are removed.
+// It implements runner.HistoryReader
+type RunHistory struct {
+	mu       sync.RWMutex
+	dir      string
+	capacity int
+	entries  []historyEntry // ordered oldest to newest by EndTime
+}
+
+type historyEntry struct {
+	file string
+	rec  runner.RunRecord
+}
+
+// NewRunHistory creates a RunHistory backed by dir, loading any records previously written there.
+// A capacity of 0 means unbounded.
+func NewRunHistory(dir string, capacity int) (*RunHistory, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	h := &RunHistory{dir: dir, capacity: capacity}
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *RunHistory) load() error {
+	infos, err := ioutil.ReadDir(h.dir)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), historyFileSuffix) {
+			continue
+		}
+		path := filepath.Join(h.dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var rec runner.RunRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			log.Errorf("Removing unreadable run history file %s: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+		h.entries = append(h.entries, historyEntry{file: info.Name(), rec: rec})
+	}
+	sort.SliceStable(h.entries, func(i, j int) bool {
+		return h.entries[i].rec.EndTime.Before(h.entries[j].rec.EndTime)
+	})
+	h.trim()
+	return nil
+}
+
+// Record persists rec to disk, evicting the oldest record if the history is at capacity.
+func (h *RunHistory) Record(rec runner.RunRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	// RunIDs are only unique for the lifetime of a worker process, so prefix with the end time.
+	name := fmt.Sprintf("%d-%s%s", rec.EndTime.UnixNano(), rec.RunID, historyFileSuffix)
+	tmpPath := filepath.Join(h.dir, "."+name)
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(h.dir, name)); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, historyEntry{file: name, rec: rec})
+	h.trim()
+	return nil
+}
+
+// trim removes the oldest entries until we're within capacity. Caller must hold the write lock.
+func (h *RunHistory) trim() {
+	for h.capacity > 0 && len(h.entries) > h.capacity {
+		path := filepath.Join(h.dir, h.entries[0].file)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Errorf("Failed to remove run history file %s: %v", path, err)
+		}
+		h.entries = h.entries[1:]
+	}
+}
+
+// QueryHistory returns all RunRecords matching q, most recently ended first.
+func (h *RunHistory) QueryHistory(q runner.HistoryQuery) ([]runner.RunRecord, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	recs := []runner.RunRecord{}
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if q.Limit > 0 && len(recs) >= q.Limit {
+			break
+		}
+		if q.Matches(h.entries[i].rec) {
+			recs = append(recs, h.entries[i].rec)
+		}
+	}
+	return recs, nil
+}
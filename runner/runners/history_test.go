@@ -0,0 +1,92 @@
+package runners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+)
+
+func TestRunHistoryQueryAndEvict(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewRunHistory(tmp.Dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Now()
+	for i, id := range []runner.RunID{"0", "1", "2", "3"} {
+		end := base.Add(time.Duration(i) * time.Minute)
+		rec := runner.RunRecord{
+			RunStatus: runner.RunStatus{RunID: id, State: runner.COMPLETE, ExitCode: i, StdoutRef: "stdout-" + string(id)},
+			StartTime: end.Add(-time.Second),
+			EndTime:   end,
+		}
+		if err := h.Record(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recs, _ := h.QueryHistory(runner.HistoryQuery{})
+	if len(recs) != 3 || recs[0].RunID != "3" || recs[2].RunID != "1" {
+		t.Fatalf("Expected runs 3,2,1 with 0 evicted, got %v", recs)
+	}
+
+	recs, _ = h.QueryHistory(runner.HistoryQuery{RunID: "2"})
+	if len(recs) != 1 || recs[0].ExitCode != 2 || recs[0].StdoutRef != "stdout-2" {
+		t.Fatalf("Expected run 2, got %v", recs)
+	}
+
+	recs, _ = h.QueryHistory(runner.HistoryQuery{Start: base.Add(90 * time.Second), End: base.Add(3 * time.Minute)})
+	if len(recs) != 1 || recs[0].RunID != "2" {
+		t.Fatalf("Expected only run 2 in time range, got %v", recs)
+	}
+
+	recs, _ = h.QueryHistory(runner.HistoryQuery{Limit: 1})
+	if len(recs) != 1 || recs[0].RunID != "3" {
+		t.Fatalf("Expected only most recent run 3, got %v", recs)
+	}
+
+	// History should survive a restart.
+	h, err = NewRunHistory(tmp.Dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recs, _ = h.QueryHistory(runner.HistoryQuery{})
+	if len(recs) != 3 || recs[0].RunID != "3" {
+		t.Fatalf("Expected reloaded runs 3,2,1, got %v", recs)
+	}
+}
+
+func TestStatusManagerRecordsHistory(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewRunHistory(tmp.Dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewStatusManagerWithHistory(0, h)
+
+	st, _ := s.NewRun()
+	st.State = runner.RUNNING
+	s.Update(st)
+	if recs, _ := h.QueryHistory(runner.HistoryQuery{}); len(recs) != 0 {
+		t.Fatalf("Expected no history for running run, got %v", recs)
+	}
+
+	st.State = runner.COMPLETE
+	st.ExitCode = 1
+	s.Update(st)
+	s.Erase(st.RunID)
+
+	recs, _ := h.QueryHistory(runner.HistoryQuery{RunID: st.RunID})
+	if len(recs) != 1 || recs[0].ExitCode != 1 || recs[0].EndTime.Before(recs[0].StartTime) {
+		t.Fatalf("Expected erased run in history, got %v", recs)
+	}
+}
@@ -0,0 +1,117 @@
+package runners
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Default limit on how long a single hook may run before it's killed and considered failed.
+const DefaultRunHookTimeout = 5 * time.Minute
+
+// Environment variables set for hooks in addition to the run's own environment.
+const (
+	HookRunIDEnv    = "SCOOT_RUN_ID"
+	HookJobIDEnv    = "SCOOT_JOB_ID"
+	HookTaskIDEnv   = "SCOOT_TASK_ID"
+	HookCheckoutEnv = "SCOOT_CHECKOUT_DIR"
+	HookStateEnv    = "SCOOT_RUN_STATE" // post-run only
+	HookExitCodeEnv = "SCOOT_EXIT_CODE" // post-run only, when the run completed
+)
+
+var errHookAborted = errors.New("aborted")
+
+// RunHooks are commands the worker runs in the run's checkout before and after each run,
+// ex: to mount credentials, scrub secrets, or collect core dumps.
+// Hooks get the run's environment plus the Hook*Env variables, and their output is
+// written to the run's combined stdout/stderr log.
+//
+// If PreRun fails the run fails without running its command, and if PostRun fails the run keeps
+// its status. Either way the failure is reported in RunStatus.HookError.
+// PostRun is run whenever PreRun was, even if the command itself failed or was aborted.
+// Aborting a run aborts its PreRun hook, but its PostRun hook is only limited by Timeout.
+type RunHooks struct {
+	PreRun  []string // argv, empty for no hook
+	PostRun []string // argv, empty for no hook
+	Timeout time.Duration
+}
+
+func (h *RunHooks) hasPreRun() bool {
+	return h != nil && len(h.PreRun) > 0
+}
+
+func (h *RunHooks) hasPostRun() bool {
+	return h != nil && len(h.PostRun) > 0
+}
+
+// Environment for a hook: the command's env vars plus details of the run.
+// If st is non-nil it's the run's final status, for post-run hooks.
+func hookEnv(cmd *runner.Command, id runner.RunID, dir string, st *runner.RunStatus) map[string]string {
+	env := map[string]string{}
+	for k, v := range cmd.EnvVars {
+		env[k] = v
+	}
+	env[HookRunIDEnv] = string(id)
+	env[HookJobIDEnv] = cmd.JobID
+	env[HookTaskIDEnv] = cmd.TaskID
+	env[HookCheckoutEnv] = dir
+	if st != nil {
+		env[HookStateEnv] = st.State.String()
+		if st.State == runner.COMPLETE {
+			env[HookExitCodeEnv] = strconv.Itoa(st.ExitCode)
+		}
+	}
+	return env
+}
+
+// Runs a hook to completion, returning an error if it couldn't be started, didn't exit 0,
+// exceeded the hook timeout or memory cap, or was aborted via abortCh.
+func (inv *Invoker) runHook(
+	argv []string, env map[string]string, dir string, out io.Writer, cmd *runner.Command, abortCh chan struct{}) error {
+	memCh := make(chan execer.ProcessStatus, 1)
+	p, err := inv.exec.Exec(execer.Command{
+		Argv:    argv,
+		EnvVars: env,
+		Dir:     dir,
+		Stdout:  out,
+		Stderr:  out,
+		MemCh:   memCh,
+		LogTags: cmd.LogTags,
+	})
+	if err != nil {
+		return fmt.Errorf("could not exec %q: %v", argv, err)
+	}
+
+	timeout := inv.hooks.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRunHookTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	processCh := make(chan execer.ProcessStatus, 1)
+	go func() { processCh <- p.Wait() }()
+
+	select {
+	case <-abortCh:
+		p.Abort()
+		return errHookAborted
+	case <-timer.C:
+		p.Abort()
+		return fmt.Errorf("%q exceeded timeout %v", argv, timeout)
+	case st := <-memCh:
+		return fmt.Errorf("%q exceeded memory cap: %v", argv, st.Error)
+	case st := <-processCh:
+		if st.State != execer.COMPLETE {
+			return fmt.Errorf("%q failed: %v", argv, st.Error)
+		}
+		if st.ExitCode != 0 {
+			return fmt.Errorf("%q exited with code %d", argv, st.ExitCode)
+		}
+		return nil
+	}
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/gpu"
+	"github.com/twitter/scoot/runner/runlogs"
+	"github.com/twitter/scoot/runner/secrets"
 	"github.com/twitter/scoot/snapshot"
 	bzsnapshot "github.com/twitter/scoot/snapshot/bazel"
 	"github.com/twitter/scoot/snapshot/git/gitfiler"
@@ -32,18 +35,29 @@ func NewInvoker(exec execer.Execer, filerMap runner.RunTypeMap, output runner.Ou
 	if stat == nil {
 		stat = stats.NilStatsReceiver()
 	}
-	return &Invoker{exec: exec, filerMap: filerMap, output: output, tmp: tmp, stat: stat}
+	return &Invoker{exec: exec, filerMap: filerMap, output: output, tmp: tmp, stat: stat,
+		taggedStat: stats.NewTaggedStatsReceiver(stat, stats.DefaultMaxTagValues)}
 }
 
 // Invoker Runs a Scoot Command by performing the Scoot setup and gathering.
 // (E.g., checking out a Snapshot, or saving the Output once it's done)
 // Unlike a full Runner, it has no idea of what else is running or has run.
 type Invoker struct {
-	exec     execer.Execer
-	filerMap runner.RunTypeMap
-	output   runner.OutputCreator
-	tmp      *temp.TempDir
-	stat     stats.StatsReceiver
+	exec        execer.Execer
+	filerMap    runner.RunTypeMap
+	output      runner.OutputCreator
+	tmp         *temp.TempDir
+	hooks       *RunHooks
+	secrets     secrets.Provider
+	logs        *runlogs.Persister
+	gpus        *gpu.Allocator
+	actionCache *LocalActionCache
+	envPolicy   *EnvPolicy
+	tails       *LogTailer
+	runAs       *execer.RunAs
+	prefetches  prefetches
+	stat        stats.StatsReceiver
+	taggedStat  *stats.TaggedStatsReceiver
 }
 
 // Run runs cmd
@@ -72,12 +86,17 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			"taskID": cmd.TaskID,
 		}).Info("*Invoker.run()")
 	taskTimer := inv.stat.Latency(stats.WorkerTaskLatency_ms).Time()
+	start := time.Now()
+	var runType runner.RunType
 	defer func() {
 		taskTimer.Stop()
+		inv.taggedStat.Tagged(
+			stats.Tag{Key: "runType", Value: string(runType)},
+			stats.Tag{Key: "state", Value: r.State.String()},
+		).Histogram(stats.WorkerTaggedTaskLatencyHistogram_ms).Update(int64(time.Since(start) / time.Millisecond))
 		updateCh <- r
 		close(updateCh)
 	}()
-	start := time.Now()
 
 	// Records various stages of the run
 	// TODO opporunity for consolidation with existing timers and metrics as part of larger refactor
@@ -89,7 +108,6 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 	// Determine RunType from Command SnapshotID
 	// This invoker supports RunTypeScoot and RunTypeBazel
-	var runType runner.RunType
 	if err := bazel.ValidateID(cmd.SnapshotID); err == nil {
 		runType = runner.RunTypeBazel
 	} else {
@@ -104,7 +122,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 	// We can also receive a cached result here, in which case we skip invocation
 	rts.inputStart = stamp()
 	if runType == runner.RunTypeBazel {
-		cachedResult, notExist, err := preProcessBazel(inv.filerMap[runType].Filer, cmd, rts)
+		cachedResult, notExist, err := preProcessBazel(inv.filerMap[runType].Filer, cmd, rts, inv.actionCache, inv.stat)
 		if err != nil {
 			msg := fmt.Sprintf("Error preprocessing Bazel command: %s", err)
 			failedStatus := runner.FailedStatus(id, errors.New(msg),
@@ -115,6 +133,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			// the failure run status that indicates missing data to client
 			if cas.IsNotFoundError(err) {
 				log.Info("NotFound error during Bazel preprocess - Setting grpc Status error")
+				failedStatus.FailureKind = runner.RequestFailure
 				errStatus, err := getFailedPreconditionStatus(notExist)
 				if err != nil {
 					log.Errorf("Error generating Failed Precondition status: %s", err)
@@ -171,9 +190,16 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 					"jobID":      cmd.JobID,
 					"taskID":     cmd.TaskID,
 					"snapshotID": cmd.SnapshotID,
+					"sparse":     cmd.SparsePatterns,
 				}).Info("Checking out snapshotID")
 			var err error
-			co, err = inv.filerMap[runType].Filer.Checkout(cmd.SnapshotID)
+			if prefetched := inv.takePrefetch(id); prefetched != nil {
+				co = prefetched
+			} else if sc, ok := inv.filerMap[runType].Filer.(snapshot.SparseCheckouter); ok && len(cmd.SparsePatterns) > 0 {
+				co, err = sc.CheckoutSparse(cmd.SnapshotID, cmd.SparsePatterns)
+			} else {
+				co, err = inv.filerMap[runType].Filer.Checkout(cmd.SnapshotID)
+			}
 			checkoutCh <- err
 		}
 	}()
@@ -208,6 +234,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 				failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
 				if _, ok := err.(*bzsnapshot.CheckoutNotExistError); ok {
 					log.Info("Checkout for Bazel command returned CheckoutNotExistError - Setting grpc Status error")
+					failedStatus.FailureKind = runner.RequestFailure
 					errStatus, err := getCheckoutMissingStatus(cmd.SnapshotID)
 					if err != nil {
 						log.Errorf("Error generating Failed Precondition status: %s", err)
@@ -274,8 +301,16 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 	// (via Invoker -> QueueRunner construction) or Command level (job requestor specifies in e.g. a PlatformProperty)
 
 	// Processing/setup post checkout before execution
+	execDir := co.Path()
 	switch runType {
 	case runner.RunTypeBazel:
+		execDir, err = workingDir(cmd, co.Path())
+		if err != nil {
+			failedStatus := runner.FailedStatus(id, err,
+				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+			failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInvalidArgumentStatus(err.Error())}
+			return failedStatus
+		}
 		for _, pp := range cmd.ExecuteRequest.GetCommand().GetPlatform().GetProperties() {
 			if pp.GetName() == "JDK_SYMLINK" {
 				log.Infof("JDK_SYMLINK platform property identified. Creating %s symlink", pp.GetValue())
@@ -291,7 +326,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			}
 		}
 
-		err = createOutputPaths(cmd, co.Path())
+		err = createOutputPaths(cmd, execDir)
 		if err != nil {
 			msg := fmt.Sprintf("Failed setting up output directories: %s", err)
 			failedStatus := runner.FailedStatus(id, errors.New(msg),
@@ -305,6 +340,32 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		stdlog.Write([]byte(header))
 	}
 
+	// Let the user the command runs as read the checkout and write its outputs and a scratch dir.
+	var runAsScratch string
+	if inv.runAs != nil {
+		outputParents := []string{}
+		if runType == runner.RunTypeBazel {
+			paths, files, dirs := outputPaths(cmd)
+			for _, relPath := range append(append(paths, files...), dirs...) {
+				outputParents = append(outputParents, filepath.Dir(filepath.Join(execDir, relPath)))
+			}
+		}
+		err := grantCheckout(inv.tmp.Dir, co.Path(), outputParents, inv.runAs)
+		if err == nil {
+			runAsScratch, err = makeRunAsScratch(inv.tmp.Dir, inv.runAs)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Failed preparing checkout for the user runs execute as: %s", err)
+			failedStatus := runner.FailedStatus(id, errors.New(msg),
+				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+			if runType == runner.RunTypeBazel {
+				failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
+			}
+			return failedStatus
+		}
+		defer os.RemoveAll(runAsScratch)
+	}
+
 	log.WithFields(
 		log.Fields{
 			"runID":  id,
@@ -315,15 +376,169 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			"stderr": stderr.AsFile(),
 			"stdlog": stdlog.AsFile(),
 		}).Debug("Stdout/Stderr output")
+
+	// Persist the combined stdout/stderr once everything, including any post-run hook, has written to it.
+	// The write happens in the background so the run's final status isn't held up by the Store.
+	if inv.logs != nil {
+		defer func() {
+			ref, err := inv.logs.PersistFileAsync(stdlog.AsFile(), cmd.JobID, cmd.TaskID, string(id), cmd.Retention)
+			if err != nil {
+				log.WithFields(
+					log.Fields{
+						"runID":  id,
+						"tag":    cmd.Tag,
+						"jobID":  cmd.JobID,
+						"taskID": cmd.TaskID,
+						"err":    err,
+					}).Error("Failed to persist run log")
+				return
+			}
+			r.LogRef = ref
+		}()
+	}
+
+	// Keep the end of stdout and stderr in the final status, once everything has written to them.
+	if inv.tails != nil {
+		defer func() {
+			r.StdoutTail = inv.tails.Tail(stdout.AsFile())
+			r.StderrTail = inv.tails.Tail(stderr.AsFile())
+		}()
+	}
+
+	// Resolve secret references in the env now so their values are only ever given to the command's process.
+	execEnv, secretNames, err := secrets.Resolve(inv.secrets, cmd.EnvVars)
+	if err != nil {
+		inv.stat.Counter(stats.WorkerSecretFailures).Inc(1)
+		msg := fmt.Sprintf("could not resolve secrets: %v", err)
+		failedStatus := runner.FailedStatus(id, errors.New(msg),
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		if runType == runner.RunTypeBazel {
+			failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
+		}
+		return failedStatus
+	}
+	// Copied so the command's own env isn't changed.
+	if _, ok := execEnv["TMPDIR"]; runAsScratch != "" && !ok {
+		env := map[string]string{"TMPDIR": runAsScratch}
+		for k, v := range execEnv {
+			env[k] = v
+		}
+		execEnv = env
+	}
+	if len(secretNames) > 0 {
+		log.WithFields(
+			log.Fields{
+				"runID":   id,
+				"tag":     cmd.Tag,
+				"jobID":   cmd.JobID,
+				"taskID":  cmd.TaskID,
+				"secrets": secretNames,
+			}).Info("Injecting secrets")
+	}
+
+	// Assign the GPUs the command requested for the duration of the run.
+	n, err := gpu.Requested(execEnv)
+	var devices []gpu.Device
+	if err == nil {
+		devices, err = inv.gpus.Allocate(n)
+	}
+	if err != nil {
+		inv.stat.Counter(stats.WorkerGPUAllocationFailures).Inc(1)
+		msg := fmt.Sprintf("could not allocate GPUs: %v", err)
+		failedStatus := runner.FailedStatus(id, errors.New(msg),
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		if runType == runner.RunTypeBazel {
+			failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
+		}
+		return failedStatus
+	}
+	// On a worker with GPUs, commands only see the devices allocated to them, if any.
+	if inv.gpus != nil {
+		defer inv.gpus.Release(devices)
+		execEnv = gpu.Env(execEnv, devices)
+	}
+	if n > 0 {
+		log.WithFields(
+			log.Fields{
+				"runID":   id,
+				"tag":     cmd.Tag,
+				"jobID":   cmd.JobID,
+				"taskID":  cmd.TaskID,
+				"devices": execEnv[gpu.VisibleDevicesEnvVar],
+			}).Info("Allocated GPUs")
+	}
+
+	// Run hooks, the post-run hook runs after the final status is determined and before the checkout is released.
+	if inv.hooks.hasPostRun() {
+		defer func() {
+			stdlog.Write([]byte(fmt.Sprintf("\n\n%s\n\nSCOOT_POST_RUN_HOOK\n", marker)))
+			env := hookEnv(cmd, id, co.Path(), &r)
+			// The hook gets its own abort channel, which is never signaled, so aborting the run
+			// doesn't kill its cleanup; it's only limited by the hook timeout.
+			hookAbortCh := make(chan struct{})
+			if err := inv.runHook(inv.hooks.PostRun, env, co.Path(), stdlog, cmd, hookAbortCh); err != nil {
+				inv.stat.Counter(stats.WorkerPostRunHookFailures).Inc(1)
+				log.WithFields(
+					log.Fields{
+						"runID":  id,
+						"tag":    cmd.Tag,
+						"jobID":  cmd.JobID,
+						"taskID": cmd.TaskID,
+						"err":    err,
+					}).Error("Post-run hook failed")
+				r.HookError = fmt.Sprintf("post-run hook: %v", err)
+			}
+		}()
+	}
+	if inv.hooks.hasPreRun() {
+		stdlog.Write([]byte(fmt.Sprintf("%s\n\nSCOOT_PRE_RUN_HOOK\n", marker)))
+		env := hookEnv(cmd, id, co.Path(), nil)
+		if err := inv.runHook(inv.hooks.PreRun, env, co.Path(), stdlog, cmd, abortCh); err == errHookAborted {
+			return runner.AbortStatus(id,
+				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		} else if err != nil {
+			inv.stat.Counter(stats.WorkerPreRunHookFailures).Inc(1)
+			msg := fmt.Sprintf("pre-run hook: %v", err)
+			failedStatus := runner.FailedStatus(id, errors.New(msg),
+				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+			failedStatus.HookError = msg
+			if runType == runner.RunTypeBazel {
+				failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
+			}
+			return failedStatus
+		}
+	}
+
+	network, err := execNetwork(cmd)
+	if err != nil {
+		failedStatus := runner.FailedStatus(id, err,
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		if runType == runner.RunTypeBazel {
+			failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInvalidArgumentStatus(err.Error())}
+		}
+		return failedStatus
+	}
+
+	// Replace the worker's environment with the one the policy allows, if any, and describe the run.
+	clearEnv := inv.envPolicy != nil
+	if clearEnv {
+		execEnv = inv.envPolicy.env(os.Environ(), execEnv, cmd, id)
+	} else {
+		execEnv = runEnv(execEnv, cmd, id)
+	}
+
 	rts.execStart = stamp() // candidate for availability via Execer
 	p, err := inv.exec.Exec(execer.Command{
-		Argv:    cmd.Argv,
-		EnvVars: cmd.EnvVars,
-		Dir:     co.Path(),
-		Stdout:  io.MultiWriter(stdout, stdlog),
-		Stderr:  io.MultiWriter(stderr, stdlog),
-		MemCh:   memCh,
-		LogTags: cmd.LogTags,
+		Argv:     cmd.Argv,
+		EnvVars:  execEnv,
+		Dir:      execDir,
+		Stdout:   io.MultiWriter(stdout, stdlog),
+		Stderr:   io.MultiWriter(stderr, stdlog),
+		MemCh:    memCh,
+		Backend:  execBackend(cmd),
+		Network:  network,
+		ClearEnv: clearEnv,
+		LogTags:  cmd.LogTags,
 	})
 	if err != nil {
 		msg := fmt.Sprintf("could not exec: %s", err)
@@ -356,23 +571,31 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		stdout.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask aborted: %v", marker, cmd.String())))
 		stderr.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask aborted: %v", marker, cmd.String())))
 		stdlog.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask aborted: %v", marker, cmd.String())))
-		p.Abort()
-		return runner.AbortStatus(id,
+		abortSt := p.Abort()
+		status := runner.AbortStatus(id,
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		status.Usage = abortSt.Usage
+		status.GracefulShutdown = abortSt.GracefulShutdown
+		return status
 	case <-timeoutCh:
 		stdout.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask exceeded timeout %v: %v", marker, cmd.Timeout, cmd.String())))
 		stderr.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask exceeded timeout %v: %v", marker, cmd.Timeout, cmd.String())))
 		stdlog.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask exceeded timeout %v: %v", marker, cmd.Timeout, cmd.String())))
-		p.Abort()
+		abortSt := p.Abort()
 		log.WithFields(
 			log.Fields{
-				"cmd":    cmd.String(),
-				"tag":    cmd.Tag,
-				"jobID":  cmd.JobID,
-				"taskID": cmd.TaskID,
+				"cmd":      cmd.String(),
+				"tag":      cmd.Tag,
+				"jobID":    cmd.JobID,
+				"taskID":   cmd.TaskID,
+				"usage":    abortSt.Usage,
+				"graceful": abortSt.GracefulShutdown,
 			}).Info("Run timedout")
-		return runner.TimeoutStatus(id,
+		status := runner.TimeoutStatus(id,
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		status.Usage = abortSt.Usage
+		status.GracefulShutdown = abortSt.GracefulShutdown
+		return status
 	case st = <-memCh:
 		stdout.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\n%v", marker, st.Error)))
 		stderr.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\n%v", marker, st.Error)))
@@ -428,7 +651,13 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 			ingestCh := make(chan interface{})
 			go func() {
-				snapshotID, err := inv.filerMap[runType].Filer.Ingest(tmp.Dir)
+				var snapshotID string
+				var err error
+				if ri, ok := inv.filerMap[runType].Filer.(snapshot.RetentionIngester); ok && cmd.Retention > 0 {
+					snapshotID, err = ri.IngestWithRetention(tmp.Dir, cmd.Retention)
+				} else {
+					snapshotID, err = inv.filerMap[runType].Filer.Ingest(tmp.Dir)
+				}
 				if err != nil {
 					ingestCh <- err
 				} else {
@@ -455,6 +684,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			// Note: only modifying stdout/stderr refs when we're actively working with snapshotID.
 			status := runner.CompleteStatus(id, snapshotID, st.ExitCode,
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+			status.Usage = st.Usage
 			if cmd.SnapshotID != "" {
 				status.StdoutRef = snapshotID + "/" + stdoutName
 				status.StderrRef = snapshotID + "/" + stderrName
@@ -473,7 +703,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			// Process Bazel uploads of std* output and other data to CAS
 			ingestCh := make(chan interface{})
 			go func() {
-				actionResult, err := postProcessBazel(inv.filerMap[runType].Filer, cmd, co.Path(), stdout, stderr, st, rts)
+				actionResult, err := postProcessBazel(inv.filerMap[runType].Filer, cmd, co.Path(), stdout, stderr, st, rts, inv.actionCache)
 				if err != nil {
 					ingestCh <- err
 				} else {
@@ -514,6 +744,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			status := runner.CompleteStatus(id, "", st.ExitCode,
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 			status.ActionResult = actionResult
+			status.Usage = st.Usage
 			if st.Error != "" {
 				status.Error = st.Error
 			}
@@ -527,6 +758,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		msg := fmt.Sprintf("error execing: %s", st.Error)
 		failedStatus := runner.FailedStatus(id, errors.New(msg),
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		failedStatus.Usage = st.Usage
 		if runType == runner.RunTypeBazel {
 			failedStatus.ActionResult = &bazelapi.ActionResult{GRPCStatus: getInternalErrorStatus(msg)}
 		}
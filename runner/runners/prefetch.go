@@ -0,0 +1,114 @@
+package runners
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/snapshot"
+)
+
+// A checkout of a queued command's snapshot started before the command runs, see Invoker.Prefetch.
+type prefetchedCheckout struct {
+	doneCh chan struct{} // Closed once the checkout is done.
+	co     snapshot.Checkout
+	err    error
+}
+
+type prefetches struct {
+	mu        sync.Mutex
+	checkouts map[runner.RunID]*prefetchedCheckout
+}
+
+// Prefetch starts checking out cmd's snapshot in the background, for run id to use when it starts
+// instead of waiting on its own checkout. Only Scoot commands with a snapshot are prefetched,
+// Bazel commands may not need a checkout at all if their result is cached.
+// Returns false if nothing is prefetched.
+func (inv *Invoker) Prefetch(cmd *runner.Command, id runner.RunID) bool {
+	if cmd.SnapshotID == "" || bazel.ValidateID(cmd.SnapshotID) == nil {
+		return false
+	}
+	filer, ok := inv.filerMap[runner.RunTypeScoot]
+	if !ok {
+		return false
+	}
+
+	pf := &prefetchedCheckout{doneCh: make(chan struct{})}
+	inv.prefetches.mu.Lock()
+	if inv.prefetches.checkouts == nil {
+		inv.prefetches.checkouts = map[runner.RunID]*prefetchedCheckout{}
+	}
+	if _, ok := inv.prefetches.checkouts[id]; ok {
+		inv.prefetches.mu.Unlock()
+		return false
+	}
+	inv.prefetches.checkouts[id] = pf
+	inv.prefetches.mu.Unlock()
+
+	log.WithFields(
+		log.Fields{
+			"runID":      id,
+			"tag":        cmd.Tag,
+			"jobID":      cmd.JobID,
+			"taskID":     cmd.TaskID,
+			"snapshotID": cmd.SnapshotID,
+		}).Info("Prefetching snapshotID")
+	inv.stat.Counter(stats.WorkerPrefetches).Inc(1)
+	go func() {
+		if sc, ok := filer.Filer.(snapshot.SparseCheckouter); ok && len(cmd.SparsePatterns) > 0 {
+			pf.co, pf.err = sc.CheckoutSparse(cmd.SnapshotID, cmd.SparsePatterns)
+		} else {
+			pf.co, pf.err = filer.Filer.Checkout(cmd.SnapshotID)
+		}
+		if pf.err != nil {
+			inv.stat.Counter(stats.WorkerPrefetchFailures).Inc(1)
+			log.WithFields(
+				log.Fields{
+					"runID":      id,
+					"tag":        cmd.Tag,
+					"jobID":      cmd.JobID,
+					"taskID":     cmd.TaskID,
+					"snapshotID": cmd.SnapshotID,
+					"err":        pf.err,
+				}).Error("Failed to prefetch snapshotID")
+		}
+		close(pf.doneCh)
+	}()
+	return true
+}
+
+// Returns the checkout prefetched for run id, waiting for it to be done, or nil if there's none or it failed.
+func (inv *Invoker) takePrefetch(id runner.RunID) snapshot.Checkout {
+	inv.prefetches.mu.Lock()
+	pf, ok := inv.prefetches.checkouts[id]
+	delete(inv.prefetches.checkouts, id)
+	inv.prefetches.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	<-pf.doneCh
+	if pf.err != nil {
+		return nil
+	}
+	return pf.co
+}
+
+// DropPrefetch releases the checkout prefetched for run id, if any, once it's done. Used when a queued run is aborted.
+func (inv *Invoker) DropPrefetch(id runner.RunID) {
+	inv.prefetches.mu.Lock()
+	pf, ok := inv.prefetches.checkouts[id]
+	delete(inv.prefetches.checkouts, id)
+	inv.prefetches.mu.Unlock()
+	if !ok {
+		return
+	}
+	go func() {
+		<-pf.doneCh
+		if pf.err == nil {
+			pf.co.Release()
+		}
+	}()
+}
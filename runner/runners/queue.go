@@ -14,6 +14,9 @@ import (
 	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/gpu"
+	"github.com/twitter/scoot/runner/runlogs"
+	"github.com/twitter/scoot/runner/secrets"
 	"github.com/twitter/scoot/snapshot"
 )
 
@@ -75,21 +78,57 @@ to complete successfully before serving requests.
 */
 func NewQueueRunner(
 	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, capacity int, stat stats.StatsReceiver) runner.Service {
+	return newQueueRunner(exec, filerMap, output, tmp, capacity, stat, RunnerOptions{})
+}
+
+// Optional parts of a runner, any of which may be nil.
+type RunnerOptions struct {
+	// Persists finished runs
+	History *RunHistory
+	// Run around each run
+	Hooks *RunHooks
+	// Resolves secrets requested by runs
+	Secrets secrets.Provider
+	// Persists each run's combined stdout/stderr
+	Logs *runlogs.Persister
+	// Allocates GPUs requested by runs
+	GPUs *gpu.Allocator
+	// Reuses bazel results when the central ActionCache is unreachable
+	ActionCache *LocalActionCache
+	// Limits the worker env vars runs inherit
+	EnvPolicy *EnvPolicy
+	// Keeps the end of each run's output
+	Tails *LogTailer
+	// The user exec runs commands as, who's handed each run's checkout
+	RunAs *execer.RunAs
+}
+
+func newQueueRunner(
+	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, capacity int, stat stats.StatsReceiver,
+	opts RunnerOptions) runner.Service {
 
 	if stat == nil {
 		stat = stats.NilStatsReceiver()
 	}
 
 	//FIXME(jschiller): proper history config rather than keying off of capacity and if this is a SingleRunner.
-	history := 1
+	historyCapacity := 1
 	if capacity > 0 {
-		history = 0 // unlimited if acting as a queue (vs single runner).
+		historyCapacity = 0 // unlimited if acting as a queue (vs single runner).
 	} else if capacity == 0 {
 		capacity = 1 // singleRunner, override capacity so it can actually run a command.
 	}
 
-	statusManager := NewStatusManager(history)
+	statusManager := NewStatusManagerWithHistory(historyCapacity, opts.History)
 	inv := NewInvoker(exec, filerMap, output, tmp, stat)
+	inv.hooks = opts.Hooks
+	inv.secrets = opts.Secrets
+	inv.logs = opts.Logs
+	inv.gpus = opts.GPUs
+	inv.actionCache = opts.ActionCache
+	inv.envPolicy = opts.EnvPolicy
+	inv.tails = opts.Tails
+	inv.runAs = opts.RunAs
 
 	controller := &QueueController{
 		statusManager: statusManager,
@@ -153,6 +192,13 @@ func NewSingleRunner(
 	return NewQueueRunner(exec, filerMap, output, tmp, 0, stat)
 }
 
+// NewSingleRunnerWithOptions is NewSingleRunner with the optional parts in opts, see RunnerOptions.
+func NewSingleRunnerWithOptions(
+	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver,
+	opts RunnerOptions) runner.Service {
+	return newQueueRunner(exec, filerMap, output, tmp, 0, stat, opts)
+}
+
 // QueueController maintains a queue of commands to run (up to capacity).
 // Manages updates to underlying Filer via Filer's Update interface,
 // if a non-zero update interval is defined (updates and tasks cannot run concurrently)
@@ -168,6 +214,10 @@ type QueueController struct {
 	runningID    runner.RunID
 	runningCmd   *runner.Command
 	runningAbort chan<- struct{}
+	// Set once the running command has its checkout and is running.
+	runningStarted bool
+	// The last queued run whose snapshot was prefetched.
+	prefetchedID runner.RunID
 
 	// used to signal a cmd run request
 	reqCh chan interface{}
@@ -283,6 +333,7 @@ func (c *QueueController) abort(run runner.RunID) (runner.RunStatus, error) {
 						"tag":    cmdID.cmd.Tag,
 					}).Info("Aborting queued run")
 				c.queue = append(c.queue[:i], c.queue[i+1:]...)
+				c.inv.DropPrefetch(run)
 				c.statusManager.Update(runner.AbortStatus(
 					run,
 					tags.LogTags{
@@ -308,6 +359,7 @@ func (c *QueueController) Release() {
 // Although we can still receive run requests, runs and updates are done blocking.
 func (c *QueueController) loop() {
 	var watchCh chan runner.RunStatus
+	var startedCh chan struct{}
 	var updateDoneCh chan interface{}
 	updateRequested := false
 
@@ -348,10 +400,20 @@ func (c *QueueController) loop() {
 		}
 	}
 
+	finishRun := func() {
+		watchCh = nil
+		startedCh = nil
+		c.runningID = ""
+		c.runningCmd = nil
+		c.runningAbort = nil
+		c.runningStarted = false
+		c.queue = c.queue[1:]
+	}
+
 	tryRun := func() {
 		if watchCh == nil && updateDoneCh == nil && len(c.queue) > 0 {
 			cmdID := c.queue[0]
-			watchCh = c.runAndWatch(cmdID)
+			watchCh, startedCh = c.runAndWatch(cmdID)
 		}
 	}
 
@@ -387,27 +449,50 @@ func (c *QueueController) loop() {
 			}
 			switch r := req.(type) {
 			case runReq:
+				// The running command's final status is published just before its watcher reports it done.
+				// Wait for that so a caller that saw the run finish isn't turned away by its queue slot.
+				if watchCh != nil && len(c.queue) >= c.capacity {
+					if st, _, err := c.statusManager.Status(c.runningID); err == nil && st.State.IsDone() {
+						<-watchCh
+						finishRun()
+					}
+				}
 				st, err := c.enqueue(r.cmd)
 				r.resultCh <- result{st, err}
+				c.tryPrefetch()
 			case abortReq:
 				st, err := c.abort(r.runID)
 				r.resultCh <- result{st, err}
 			}
 
+		case <-startedCh:
+			// The running command has its checkout, start on the next command's.
+			startedCh = nil
+			c.runningStarted = true
+			c.tryPrefetch()
+
 		case <-watchCh:
 			// Handle finished run by resetting state.
-			watchCh = nil
-			c.runningID = ""
-			c.runningCmd = nil
-			c.runningAbort = nil
-			c.queue = c.queue[1:]
+			finishRun()
 		}
 	}
 }
 
+// Starts checking out the snapshot of the command queued behind the running one once the running one
+// has its checkout, hiding the checkout's latency when the running command is done.
+// Only the next command is prefetched, since filers that hold one checkout at a time would otherwise
+// hand out checkouts out of queue order, and the running command could end up waiting on a later one.
+func (c *QueueController) tryPrefetch() {
+	if !c.runningStarted || len(c.queue) < 2 || c.queue[1].id == c.prefetchedID {
+		return
+	}
+	c.prefetchedID = c.queue[1].id
+	c.inv.Prefetch(c.queue[1].cmd, c.queue[1].id)
+}
+
 // Run cmd and then start a new goroutine to watch the cmd.
-// Returns a watchCh for goroutine completion.
-func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
+// Returns a watchCh for goroutine completion, and a startedCh signaled once cmd is running.
+func (c *QueueController) runAndWatch(cmdID cmdAndID) (chan runner.RunStatus, chan struct{}) {
 	log.WithFields(
 		log.Fields{
 			"jobID":  cmdID.cmd.JobID,
@@ -417,11 +502,13 @@ func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
 			"tag":    cmdID.cmd.Tag,
 		}).Info("Running")
 	watchCh := make(chan runner.RunStatus)
+	startedCh := make(chan struct{})
 	abortCh, statusUpdateCh := c.inv.Run(cmdID.cmd, cmdID.id)
 	c.runningAbort = abortCh
 	c.runningID = cmdID.id
 	c.runningCmd = cmdID.cmd
 	go func() {
+		started := false
 		for st := range statusUpdateCh {
 			log.WithFields(
 				log.Fields{
@@ -437,11 +524,15 @@ func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
 					"tag":        st.Tag,
 				}).Info("Queue received status update")
 			c.statusManager.Update(st)
+			if st.State == runner.RUNNING && !started {
+				started = true
+				startedCh <- struct{}{}
+			}
 			if st.State.IsDone() {
 				watchCh <- st
 				return
 			}
 		}
 	}()
-	return watchCh
+	return watchCh, startedCh
 }
@@ -3,6 +3,7 @@ package runners
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -202,6 +203,105 @@ func TestAbortQueuedCommand(t *testing.T) {
 	assertWait(t, env.r, run1, aborted())
 }
 
+// A Filer that counts the checkouts and releases of each snapshot.
+type countingFiler struct {
+	snapshot.Filer
+	mu        sync.Mutex
+	checkouts map[string]int
+	releases  map[string]int
+}
+
+func (f *countingFiler) Checkout(id string) (snapshot.Checkout, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checkouts[id]++
+	co, err := f.Filer.Checkout(id)
+	if err != nil {
+		return nil, err
+	}
+	return &countingCheckout{co, f}, nil
+}
+
+func (f *countingFiler) counts(id string) (checkouts, releases int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.checkouts[id], f.releases[id]
+}
+
+func (f *countingFiler) waitForCounts(t *testing.T, id string, checkouts, releases int) {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if c, r := f.counts(id); c == checkouts && r == releases {
+			return
+		}
+	}
+	c, r := f.counts(id)
+	t.Fatalf("Expected %d checkouts and %d releases of %s, got %d and %d", checkouts, releases, id, c, r)
+}
+
+type countingCheckout struct {
+	snapshot.Checkout
+	f *countingFiler
+}
+
+func (c *countingCheckout) Release() error {
+	c.f.mu.Lock()
+	c.f.releases[c.ID()]++
+	c.f.mu.Unlock()
+	return c.Checkout.Release()
+}
+
+func TestPrefetchQueuedCommand(t *testing.T) {
+	sim := execers.NewSimExecer()
+	tmpDir, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	filer := &countingFiler{
+		Filer:     snapshots.MakeNoopFiler(tmpDir.Dir),
+		checkouts: map[string]int{},
+		releases:  map[string]int{},
+	}
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: filer, IDC: nil}
+	r := NewQueueRunner(sim, filerMap, NewNullOutputCreator(), tmpDir, 4, nil)
+
+	runCmd := func(snapshotID string, expected runner.RunStatus, args ...string) runner.RunID {
+		st, err := r.Run(&runner.Command{Argv: args, SnapshotID: snapshotID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertWait(t, r, st.RunID, expected, args...)
+		return st.RunID
+	}
+
+	// The queued command's snapshot is checked out while the first command runs, and used when it runs.
+	run1 := runCmd("snap1", running(), "pause", "complete 0")
+	run2 := runCmd("snap2", pending(), "complete 0")
+	run3 := runCmd("snap3", pending(), "complete 0")
+	filer.waitForCounts(t, "snap2", 1, 0)
+	if c, _ := filer.counts("snap3"); c != 0 {
+		t.Fatalf("Expected only the next queued command to be prefetched, got %d checkouts of snap3", c)
+	}
+	sim.Resume()
+	assertWait(t, r, run1, complete(0))
+	assertWait(t, r, run2, complete(0))
+	assertWait(t, r, run3, complete(0))
+	filer.waitForCounts(t, "snap2", 1, 1)
+	filer.waitForCounts(t, "snap3", 1, 1)
+
+	// The checkout prefetched for an aborted command is released.
+	run4 := runCmd("snap4", running(), "pause", "complete 0")
+	run5 := runCmd("snap5", pending(), "complete 0")
+	filer.waitForCounts(t, "snap5", 1, 0)
+	if _, err := r.Abort(run5); err != nil {
+		t.Fatal(err)
+	}
+	assertWait(t, r, run5, aborted())
+	filer.waitForCounts(t, "snap5", 1, 1)
+	sim.Resume()
+	assertWait(t, r, run4, complete(0))
+}
+
 func setup(capacity int, interval time.Duration, t *testing.T) *env {
 	log.AddHook(hooks.NewContextHook())
 	logrusLevel, _ := log.ParseLevel("debug")
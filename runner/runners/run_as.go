@@ -0,0 +1,105 @@
+package runners
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Lets runAs read the checkout at coDir and write to the parent dirs of its outputs, without handing it
+// the checkout. Ownership of the checkout doesn't change, so git keeps trusting it when it's reused.
+// Only the worker's own dirs below root, its dedicated temp dir, are opened up, and only to runAs's group:
+// coDir is made readable and traversable, and the dirs between it and root traversable but not listable.
+// Dirs above root are never changed, if runAs can't already traverse them the run fails.
+func grantCheckout(root, coDir string, outputParents []string, runAs *execer.RunAs) error {
+	root = filepath.Clean(root)
+	coDir = filepath.Clean(coDir)
+	if err := grantGroup(coDir, runAs, 0050); err != nil {
+		return err
+	}
+	for dir := filepath.Dir(coDir); ; dir = filepath.Dir(dir) {
+		if isUnder(root, dir) {
+			if err := grantGroup(dir, runAs, 0010); err != nil {
+				return err
+			}
+		} else if ok, err := canTraverse(dir, runAs); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%s isn't traversable by uid %d, move the worker's temp dir somewhere it is", dir, runAs.Uid)
+		}
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	// The checkout's own dir is made group writable rather than chowned, since git checks who owns it.
+	for _, dir := range outputParents {
+		dir = filepath.Clean(dir)
+		if dir == coDir {
+			if err := grantGroup(dir, runAs, 0070); err != nil {
+				return err
+			}
+		} else if err := os.Lchown(dir, int(runAs.Uid), int(runAs.Gid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Makes runAs's group the group of dir and adds perm to the group's permissions.
+func grantGroup(dir string, runAs *execer.RunAs, perm os.FileMode) error {
+	if err := os.Lchown(dir, -1, int(runAs.Gid)); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&perm == perm {
+		return nil
+	}
+	return os.Chmod(dir, info.Mode()|perm)
+}
+
+// Returns whether runAs can traverse dir as its owner, group or others.
+func canTraverse(dir string, runAs *execer.RunAs) (bool, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return false, err
+	}
+	perm := info.Mode().Perm()
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		switch {
+		case st.Uid == runAs.Uid:
+			return perm&0100 != 0, nil
+		case st.Gid == runAs.Gid:
+			return perm&0010 != 0, nil
+		}
+	}
+	return perm&0001 != 0, nil
+}
+
+// Returns whether path is dir or below it.
+func isUnder(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// Creates a scratch dir owned by runAs for a run, ex: for its TMPDIR, since it can't write elsewhere in the checkout.
+func makeRunAsScratch(root string, runAs *execer.RunAs) (string, error) {
+	if err := grantGroup(root, runAs, 0010); err != nil {
+		return "", err
+	}
+	dir, err := ioutil.TempDir(root, "run-as-scratch-")
+	if err != nil {
+		return "", err
+	}
+	if err := os.Lchown(dir, int(runAs.Uid), int(runAs.Gid)); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
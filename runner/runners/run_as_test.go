@@ -0,0 +1,61 @@
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/twitter/scoot/runner/execer"
+)
+
+func TestGrantCheckout(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "run-as")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	root := filepath.Join(tmp, "root")
+	checkout := filepath.Join(root, "private", "checkout")
+	if err := os.MkdirAll(filepath.Join(checkout, "out"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(tmp, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without root, dirs can only be handed to the worker's own user and group.
+	runAs := &execer.RunAs{Uid: uint32(os.Getuid()), Gid: uint32(os.Getgid())}
+	if err := grantCheckout(root, checkout, []string{filepath.Join(checkout, "out"), checkout}, runAs); err != nil {
+		t.Fatal(err)
+	}
+	for dir, perm := range map[string]os.FileMode{
+		tmp:                            0700,
+		root:                           0710,
+		filepath.Join(root, "private"): 0710,
+		checkout:                       0770,
+		filepath.Join(checkout, "out"): 0700,
+	} {
+		info, err := os.Stat(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != perm {
+			t.Fatalf("Expected %s to have mode %v, got %v", dir, perm, info.Mode().Perm())
+		}
+		if st := info.Sys().(*syscall.Stat_t); dir != tmp && st.Gid != runAs.Gid {
+			t.Fatalf("Expected %s to have gid %d, got %d", dir, runAs.Gid, st.Gid)
+		}
+	}
+
+	scratch, err := makeRunAsScratch(root, runAs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(scratch); err != nil || !isUnder(root, scratch) {
+		t.Fatalf("Expected scratch dir in %s, got %s %v", root, scratch, err)
+	} else if st := info.Sys().(*syscall.Stat_t); st.Uid != runAs.Uid {
+		t.Fatalf("Expected scratch dir owned by %d, got %d", runAs.Uid, st.Uid)
+	}
+}
@@ -11,3 +11,11 @@ type Service struct {
 	// TODO(dbentley): get rid of StatusEraser from here
 	runner.StatusEraser
 }
+
+// SubscribeStatus subscribes to the StatusReader's events if it supports them, or returns nil.
+func (s *Service) SubscribeStatus(buffer int) <-chan runner.StatusEvent {
+	if sub, ok := s.StatusReader.(runner.StatusSubscriber); ok {
+		return sub.SubscribeStatus(buffer)
+	}
+	return nil
+}
@@ -1,11 +1,20 @@
 package runners
 
 import (
+	log "github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/backends"
 	"github.com/twitter/scoot/runner/execer/execers"
 	osexec "github.com/twitter/scoot/runner/execer/os"
+	"github.com/twitter/scoot/runner/execer/persistent"
+	"github.com/twitter/scoot/runner/gpu"
+	"github.com/twitter/scoot/runner/runlogs"
+	"github.com/twitter/scoot/runner/secrets"
 )
 
 // Module returns a module that creates a new Runner.
@@ -18,9 +27,69 @@ type module struct{}
 // Install installs functions for creating a new Runner.
 func (m module) Install(b *ice.MagicBag) {
 	b.PutMany(
-		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
-			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
+		func(m execer.Memory, g execer.AbortGracePeriod, ra *execer.RunAs, pn execer.PIDNamespace,
+			s stats.StatsReceiver) *persistent.Execer {
+			return persistent.NewExecer(osexec.NewBoundedExecer(m, g, ra, pn, s), persistent.DefaultMaxIdleWorkers, 0, s)
+		},
+		func(pe *persistent.Execer, s stats.StatsReceiver) (execer.Execer, error) {
+			b, err := backends.NewExecer(pe, s)
+			if err != nil {
+				return nil, err
+			}
+			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), b), nil
+		},
+		func() RunHistoryDir {
+			return ""
+		},
+		func(dir RunHistoryDir, tmp *temp.TempDir) (*RunHistory, error) {
+			if dir != "" {
+				return NewRunHistory(string(dir), DefaultRunHistoryCapacity)
+			}
+			log.Warn("No run history dir configured, history is kept in the temp dir and lost on restart")
+			tmpDir, err := tmp.FixedDir("history")
+			if err != nil {
+				return nil, err
+			}
+			return NewRunHistory(tmpDir.Dir, DefaultRunHistoryCapacity)
+		},
+		func(h *RunHistory) runner.HistoryReader {
+			return h
+		},
+		func() *RunHooks {
+			return nil
+		},
+		func() secrets.Provider {
+			return nil
+		},
+		func() *runlogs.Persister {
+			return nil
+		},
+		func() *gpu.Allocator {
+			return nil
+		},
+		func() *LocalActionCache {
+			return nil
+		},
+		func() *EnvPolicy {
+			return nil
+		},
+		func() *LogTailer {
+			return nil
+		},
+		func(history *RunHistory, hooks *RunHooks, sp secrets.Provider, logs *runlogs.Persister, gpus *gpu.Allocator,
+			actionCache *LocalActionCache, envPolicy *EnvPolicy, tails *LogTailer, runAs *execer.RunAs) RunnerOptions {
+			return RunnerOptions{
+				History:     history,
+				Hooks:       hooks,
+				Secrets:     sp,
+				Logs:        logs,
+				GPUs:        gpus,
+				ActionCache: actionCache,
+				EnvPolicy:   envPolicy,
+				Tails:       tails,
+				RunAs:       runAs,
+			}
 		},
-		NewSingleRunner,
+		NewSingleRunnerWithOptions,
 	)
 }
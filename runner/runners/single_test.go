@@ -17,6 +17,7 @@ import (
 	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/runner/execer/execers"
 	os_execer "github.com/twitter/scoot/runner/execer/os"
+	"github.com/twitter/scoot/runner/gpu"
 	"github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/snapshots"
 )
@@ -112,7 +113,7 @@ func TestMemCap(t *testing.T) {
 	str := `import time; exec("x=[]\nfor i in range(50):\n x.append(' ' * 1024*1024)\n time.sleep(.1)")`
 	cmd := &runner.Command{Argv: []string{"python", "-c", str}}
 	tmp, _ := temp.TempDirDefault()
-	e := os_execer.NewBoundedExecer(execer.Memory(10*1024*1024), stats.NilStatsReceiver())
+	e := os_execer.NewBoundedExecer(execer.Memory(10*1024*1024), 0, nil, false, stats.NilStatsReceiver())
 	filerMap := runner.MakeRunTypeMap()
 	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeNoopFiler(tmp.Dir), IDC: nil}
 	r := NewSingleRunner(e, filerMap, NewNullOutputCreator(), tmp, nil)
@@ -207,6 +208,116 @@ func TestTimeout(t *testing.T) {
 	}
 }
 
+func TestRunHooks(t *testing.T) {
+	stat, statsReg := setupTest()
+	tmp, _ := temp.TempDirDefault()
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeNoopFiler(tmp.Dir), IDC: nil}
+	query := runner.Query{AllRuns: true, States: runner.DONE_MASK}
+
+	// A failed post-run hook is reported but doesn't change the run's result.
+	hooks := &RunHooks{PreRun: []string{"complete 0"}, PostRun: []string{"complete 1"}}
+	r := newQueueRunner(execers.NewSimExecer(), filerMap, NewNullOutputCreator(), tmp, 0, stat, RunnerOptions{Hooks: hooks})
+	if _, err := r.Run(&runner.Command{Argv: []string{"complete 0"}, SnapshotID: "dummySnapshotId"}); err != nil {
+		t.Fatal(err)
+	}
+	status, _, err := r.Query(query, runner.Wait{Timeout: 5 * time.Second})
+	if err != nil || len(status) != 1 {
+		t.Fatalf("expected 1 status entry, got %v, err: %v", status, err)
+	}
+	if status[0].State != runner.COMPLETE || status[0].ExitCode != 0 || !strings.HasPrefix(status[0].HookError, "post-run hook:") {
+		t.Fatalf("expected complete run with post-run hook error, got %v", status[0])
+	}
+
+	// A failed pre-run hook fails the run without running the command.
+	hooks = &RunHooks{PreRun: []string{"complete 1"}}
+	r = newQueueRunner(execers.NewSimExecer(), filerMap, NewNullOutputCreator(), tmp, 0, stat, RunnerOptions{Hooks: hooks})
+	if _, err := r.Run(&runner.Command{Argv: []string{"complete 0"}, SnapshotID: "dummySnapshotId"}); err != nil {
+		t.Fatal(err)
+	}
+	status, _, err = r.Query(query, runner.Wait{Timeout: 5 * time.Second})
+	if err != nil || len(status) != 1 {
+		t.Fatalf("expected 1 status entry, got %v, err: %v", status, err)
+	}
+	if status[0].State != runner.FAILED || !strings.HasPrefix(status[0].HookError, "pre-run hook:") {
+		t.Fatalf("expected failed run with pre-run hook error, got %v", status[0])
+	}
+
+	// Aborting a run doesn't abort its post-run hook.
+	hooks = &RunHooks{PostRun: []string{"sleep 50", "complete 0"}}
+	r = newQueueRunner(execers.NewSimExecer(), filerMap, NewNullOutputCreator(), tmp, 0, stat, RunnerOptions{Hooks: hooks})
+	st, err := r.Run(&runner.Command{Argv: []string{"pause", "complete 0"}, SnapshotID: "dummySnapshotId"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := r.Query(runner.Query{Runs: []runner.RunID{st.RunID}, States: runner.RUNNING_MASK}, runner.Wait{Timeout: 5 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Abort(st.RunID); err != nil {
+		t.Fatal(err)
+	}
+	status, _, err = r.Query(query, runner.Wait{Timeout: 5 * time.Second})
+	if err != nil || len(status) != 1 {
+		t.Fatalf("expected 1 status entry, got %v, err: %v", status, err)
+	}
+	if status[0].State != runner.ABORTED || status[0].HookError != "" {
+		t.Fatalf("expected aborted run without hook error, got %v", status[0])
+	}
+
+	if !stats.StatsOk("", statsReg, t,
+		map[string]stats.Rule{
+			stats.WorkerPreRunHookFailures:  {Checker: stats.Int64EqTest, Value: 1},
+			stats.WorkerPostRunHookFailures: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func TestRunGPUs(t *testing.T) {
+	stat, statsReg := setupTest()
+	tmp, _ := temp.TempDirDefault()
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeNoopFiler(tmp.Dir), IDC: nil}
+	query := runner.Query{AllRuns: true, States: runner.DONE_MASK}
+	gpus := gpu.NewAllocator(gpu.ParseDevices("0"))
+
+	for _, c := range []struct {
+		requested string
+		state     runner.RunState
+	}{
+		{"1", runner.COMPLETE},
+		{"2", runner.FAILED},
+	} {
+		r := newQueueRunner(execers.NewSimExecer(), filerMap, NewNullOutputCreator(), tmp, 0, stat, RunnerOptions{GPUs: gpus})
+		cmd := &runner.Command{
+			Argv:       []string{"complete 0"},
+			EnvVars:    map[string]string{gpu.RequestEnvVar: c.requested},
+			SnapshotID: "dummySnapshotId",
+		}
+		if _, err := r.Run(cmd); err != nil {
+			t.Fatal(err)
+		}
+		status, _, err := r.Query(query, runner.Wait{Timeout: 5 * time.Second})
+		if err != nil || len(status) != 1 {
+			t.Fatalf("expected 1 status entry, got %v, err: %v", status, err)
+		}
+		if status[0].State != c.state {
+			t.Fatalf("expected %s run requesting %s GPUs, got %v", c.state, c.requested, status[0])
+		}
+	}
+
+	// The completed run released its GPU.
+	if _, err := gpus.Allocate(1); err != nil {
+		t.Fatalf("expected GPU to be released after the run, got %v", err)
+	}
+	if !stats.StatsOk("", statsReg, t,
+		map[string]stats.Rule{
+			stats.WorkerGPUAllocationFailures: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
 func newRunner() (runner.Service, *execers.SimExecer) {
 	sim := execers.NewSimExecer()
 	tmpDir, err := temp.TempDirDefault()
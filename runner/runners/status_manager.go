@@ -14,7 +14,19 @@ const UnknownRunIDMsg = "unknown run id %v"
 
 // NewStatusManager creates a new empty StatusManager
 func NewStatusManager(capacity int) *StatusManager {
-	return &StatusManager{runs: make(map[runner.RunID]runner.RunStatus), fifo: make([]runner.RunID, 0), capacity: capacity}
+	return NewStatusManagerWithHistory(capacity, nil)
+}
+
+// NewStatusManagerWithHistory creates a new empty StatusManager that records finished runs to history.
+// A nil history disables recording.
+func NewStatusManagerWithHistory(capacity int, history *RunHistory) *StatusManager {
+	return &StatusManager{
+		runs:     make(map[runner.RunID]runner.RunStatus),
+		started:  make(map[runner.RunID]time.Time),
+		fifo:     make([]runner.RunID, 0),
+		capacity: capacity,
+		history:  history,
+	}
 }
 
 // StatusManager is a database of RunStatus'es. It allows clients to Write StatusManager, Query the
@@ -22,11 +34,14 @@ func NewStatusManager(capacity int) *StatusManager {
 type StatusManager struct {
 	mu        sync.RWMutex
 	runs      map[runner.RunID]runner.RunStatus
+	started   map[runner.RunID]time.Time
+	history   *RunHistory
 	fifo      []runner.RunID
 	capacity  int
 	svcStatus runner.ServiceStatus
 	nextRunID int64
 	listeners []queryAndCh
+	subs      []chan runner.StatusEvent
 }
 
 type queryAndCh struct {
@@ -49,11 +64,17 @@ func (s *StatusManager) NewRun() (runner.RunStatus, error) {
 		State: runner.PENDING,
 	}
 	s.runs[id] = st
+	s.started[id] = time.Now()
+
+	s.notify(runner.StatusEvent{Run: &st})
 
 	s.fifo = append(s.fifo, id)
 	if s.capacity != 0 && len(s.fifo) > s.capacity {
-		delete(s.runs, s.fifo[0])
+		evicted := s.fifo[0]
+		delete(s.runs, evicted)
+		delete(s.started, evicted)
 		s.fifo = s.fifo[1:]
+		s.notify(runner.StatusEvent{Erased: evicted})
 	}
 
 	return st, nil
@@ -68,6 +89,7 @@ func (s *StatusManager) UpdateService(svcStatus runner.ServiceStatus) error {
 			"svcStatus": svcStatus,
 		}).Info("StatusManager updating svc")
 	s.svcStatus = svcStatus
+	s.notify(runner.StatusEvent{Service: &svcStatus})
 	return nil
 }
 
@@ -76,6 +98,13 @@ func (s *StatusManager) UpdateService(svcStatus runner.ServiceStatus) error {
 //   cannot change a status once it is Done
 //   cannot erase Stdout/Stderr Refs
 func (s *StatusManager) Update(newStatus runner.RunStatus) error {
+	// History is written to disk after the lock is released, so queries aren't held up by file I/O.
+	var rec *runner.RunRecord
+	defer func() {
+		if rec != nil {
+			s.writeHistory(*rec)
+		}
+	}()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -102,6 +131,10 @@ func (s *StatusManager) Update(newStatus runner.RunStatus) error {
 			"tag":    newStatus.Tag,
 		}).Info("StatusManager is holding status")
 	s.runs[newStatus.RunID] = newStatus
+	if newStatus.State.IsDone() {
+		rec = s.recordHistory(newStatus)
+	}
+	s.notify(runner.StatusEvent{Run: &newStatus})
 
 	listeners := make([]queryAndCh, 0, len(s.listeners))
 	for _, listener := range s.listeners {
@@ -120,6 +153,32 @@ func (s *StatusManager) Update(newStatus runner.RunStatus) error {
 	return nil
 }
 
+// recordHistory returns the record of a finished run to write to history, or nil if history isn't configured.
+// Caller must hold the write lock.
+func (s *StatusManager) recordHistory(st runner.RunStatus) *runner.RunRecord {
+	startTime, ok := s.started[st.RunID]
+	delete(s.started, st.RunID)
+	if s.history == nil {
+		return nil
+	}
+	endTime := time.Now()
+	if !ok {
+		startTime = endTime
+	}
+	return &runner.RunRecord{RunStatus: st, StartTime: startTime, EndTime: endTime}
+}
+
+// writeHistory persists a finished run's record to history. Caller must not hold the lock.
+func (s *StatusManager) writeHistory(rec runner.RunRecord) {
+	if err := s.history.Record(rec); err != nil {
+		log.WithFields(
+			log.Fields{
+				"runID": rec.RunID,
+				"err":   err,
+			}).Error("StatusManager failed to record run history")
+	}
+}
+
 // Reader interface (implements runner.StatusQuerier)
 
 // Query returns all RunStatus'es matching q, waiting as described by w, plus the overall service status.
@@ -174,13 +233,38 @@ func (s *StatusManager) StatusAll() ([]runner.RunStatus, runner.ServiceStatus, e
 func (s *StatusManager) Erase(run runner.RunID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	st := s.runs[run]
-	if st.State.IsDone() {
+	st, ok := s.runs[run]
+	if ok && st.State.IsDone() {
 		delete(s.runs, run)
+		s.notify(runner.StatusEvent{Erased: run})
 	}
 	return nil
 }
 
+// SubscribeStatus returns a channel of all future status changes (implements runner.StatusSubscriber)
+func (s *StatusManager) SubscribeStatus(buffer int) <-chan runner.StatusEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan runner.StatusEvent, buffer)
+	s.subs = append(s.subs, ch)
+	return ch
+}
+
+// notify sends ev to each subscriber without blocking, dropping it for subscribers that are behind.
+// Caller must hold the write lock.
+func (s *StatusManager) notify(ev runner.StatusEvent) {
+	for _, ch := range s.subs {
+		select {
+		case ch <- ev:
+		default:
+			log.WithFields(
+				log.Fields{
+					"event": ev,
+				}).Debug("StatusManager dropped status event for slow subscriber")
+		}
+	}
+}
+
 // queryAndListen performs a query, returning the current results and optionally a channel for
 // listening for future results
 // returns:
@@ -0,0 +1,54 @@
+package runners
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/runner"
+)
+
+func TestSubscribeStatus(t *testing.T) {
+	s := NewStatusManager(1)
+	events := s.SubscribeStatus(10)
+
+	s.UpdateService(runner.ServiceStatus{Initialized: true})
+	if ev := <-events; ev.Service == nil || !ev.Service.Initialized {
+		t.Fatalf("Expected initialized service event, got %+v", ev)
+	}
+
+	st, _ := s.NewRun()
+	if ev := <-events; ev.Run == nil || ev.Run.RunID != st.RunID || ev.Run.State != runner.PENDING {
+		t.Fatalf("Expected pending run %v, got %+v", st.RunID, ev)
+	}
+
+	st.State = runner.FAILED
+	s.Update(st)
+	if ev := <-events; ev.Run == nil || ev.Run.State != runner.FAILED {
+		t.Fatalf("Expected failed run %v, got %+v", st.RunID, ev)
+	}
+
+	// Updates to done runs are ignored, so they aren't sent.
+	st.State = runner.COMPLETE
+	s.Update(st)
+
+	// The new run evicts the first from a StatusManager with capacity 1.
+	next, _ := s.NewRun()
+	if ev := <-events; ev.Run == nil || ev.Run.RunID != next.RunID {
+		t.Fatalf("Expected pending run %v, got %+v", next.RunID, ev)
+	}
+	if ev := <-events; ev.Erased != st.RunID {
+		t.Fatalf("Expected %v to be erased, got %+v", st.RunID, ev)
+	}
+
+	// A full subscriber doesn't block updates.
+	full := s.SubscribeStatus(0)
+	next.State = runner.RUNNING
+	s.Update(next)
+	select {
+	case ev := <-full:
+		t.Fatalf("Expected event to be dropped, got %+v", ev)
+	default:
+	}
+	if ev := <-events; ev.Run == nil || ev.Run.State != runner.RUNNING {
+		t.Fatalf("Expected running run %v, got %+v", next.RunID, ev)
+	}
+}
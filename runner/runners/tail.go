@@ -0,0 +1,65 @@
+package runners
+
+import (
+	"io"
+	"os"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LogTailer keeps the end of each run's stdout and stderr in its final RunStatus, so failures can be
+// triaged from the scheduler UI and scootapi without fetching the full logs from the worker or the Store.
+type LogTailer struct {
+	// Most bytes kept from the end of each of stdout and stderr
+	Size int64
+}
+
+// Returns a LogTailer keeping the last size bytes of output, or nil if size isn't positive.
+func NewLogTailer(size int64) *LogTailer {
+	if size <= 0 {
+		return nil
+	}
+	return &LogTailer{Size: size}
+}
+
+// Returns up to the last t.Size bytes of the file at path, or "" if it can't be read.
+// Only the tail is read, however large the output grew. The tail starts on a UTF-8 character boundary.
+func (t *LogTailer) Tail(path string) string {
+	tail, err := t.tail(path)
+	if err != nil {
+		log.Errorf("Failed to read tail of %s: %v", path, err)
+	}
+	return tail
+}
+
+func (t *LogTailer) tail(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := fi.Size()
+	if size == 0 {
+		return "", nil
+	}
+	start := size - t.Size
+	if start < 0 {
+		start = 0
+	}
+
+	tail := make([]byte, size-start)
+	n, err := f.ReadAt(tail, start)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	tail = tail[:n]
+	for i := 0; start > 0 && i < utf8.UTFMax && len(tail) > 0 && !utf8.RuneStart(tail[0]); i++ {
+		tail = tail[1:]
+	}
+	return string(tail), nil
+}
@@ -0,0 +1,42 @@
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogTailer(t *testing.T) {
+	if NewLogTailer(0) != nil {
+		t.Fatal("Expected no tailer for a zero size")
+	}
+	dir, err := ioutil.TempDir("", "tail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "stdout")
+	tailer := NewLogTailer(8)
+
+	if err := ioutil.WriteFile(path, []byte("short"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if tail := tailer.Tail(path); tail != "short" {
+		t.Fatalf("Expected whole file shorter than the tail size, got %q", tail)
+	}
+
+	// The tail would start partway through the 3-byte '€'
+	data := strings.Repeat("x", os.Getpagesize()) + "€ failed"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if tail := tailer.Tail(path); tail != " failed" {
+		t.Fatalf("Expected tail to start after the partial character, got %q", tail)
+	}
+
+	if tail := tailer.Tail(filepath.Join(dir, "missing")); tail != "" {
+		t.Fatalf("Expected no tail for a missing file, got %q", tail)
+	}
+}
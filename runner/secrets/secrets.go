@@ -0,0 +1,173 @@
+// Package secrets provides named secrets to runs on the worker, so credentials can be
+// injected into a command's environment at exec time instead of being stored in
+// snapshots, CAS entries, or job definitions.
+//
+// A command requests a secret by setting an env var to a reference of the form
+// "scoot-secret:<name>". The worker resolves the reference with its Provider just
+// before exec'ing the command, and only the command's process sees the value.
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Prefix of env var values that refer to a named secret.
+const RefPrefix = "scoot-secret:"
+
+// Default limit on how long a plugin may take to return a secret.
+const DefaultPluginTimeout = 30 * time.Second
+
+// Provider looks up secrets by name.
+type Provider interface {
+	// Returns the value of the named secret, or an error if it doesn't exist or can't be read.
+	// Errors must not include the secret's value.
+	Secret(name string) (string, error)
+}
+
+// Returns the name of the secret referred to by an env var value, and whether it is a reference.
+func ParseRef(value string) (string, bool) {
+	if !strings.HasPrefix(value, RefPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, RefPrefix), true
+}
+
+// Returns a copy of env with each secret reference replaced by the secret's value,
+// and the sorted names of the secrets that were resolved.
+// If env has no references it's returned as is and p may be nil.
+func Resolve(p Provider, env map[string]string) (map[string]string, []string, error) {
+	names := []string{}
+	for _, v := range env {
+		if name, ok := ParseRef(v); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return env, nil, nil
+	}
+	if p == nil {
+		return nil, nil, fmt.Errorf("secrets requested but no secrets provider is configured")
+	}
+
+	resolved := make(map[string]string, len(env))
+	for k, v := range env {
+		name, ok := ParseRef(v)
+		if !ok {
+			resolved[k] = v
+			continue
+		}
+		if name == "" {
+			return nil, nil, fmt.Errorf("env var %s has an empty secret name", k)
+		}
+		value, err := p.Secret(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get secret %q for env var %s: %v", name, k, err)
+		}
+		resolved[k] = value
+	}
+	sort.Strings(names)
+	return resolved, names, nil
+}
+
+// EnvFileProvider reads secrets from a file of NAME=VALUE lines.
+// Blank lines and lines starting with '#' are ignored. The file is read on each lookup,
+// so secrets can be rotated by rewriting it.
+type EnvFileProvider struct {
+	path string
+}
+
+// Creates an EnvFileProvider, returning an error if path isn't a readable env file.
+func NewEnvFileProvider(path string) (*EnvFileProvider, error) {
+	p := &EnvFileProvider{path: path}
+	if _, err := p.read(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *EnvFileProvider) Secret(name string) (string, error) {
+	secrets, err := p.read()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("no secret %q in %s", name, p.path)
+	}
+	return value, nil
+}
+
+func (p *EnvFileProvider) read() (map[string]string, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	secrets := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		kv := strings.SplitN(text, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("%s:%d: expected NAME=VALUE", p.path, line)
+		}
+		secrets[strings.TrimSpace(kv[0])] = kv[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// PluginProvider gets secrets by running an executable with the secret's name as its only argument,
+// ex: a script that reads the secret from Vault. The plugin writes the value to stdout and exits 0,
+// a single trailing newline is removed. Anything written to stderr is included in errors,
+// so plugins must not write secret values to stderr.
+type PluginProvider struct {
+	Path    string
+	Timeout time.Duration
+}
+
+// Creates a PluginProvider, returning an error if path isn't an executable file.
+func NewPluginProvider(path string, timeout time.Duration) (*PluginProvider, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() || fi.Mode()&0111 == 0 {
+		return nil, fmt.Errorf("secrets plugin %s is not executable", path)
+	}
+	if timeout <= 0 {
+		timeout = DefaultPluginTimeout
+	}
+	return &PluginProvider{Path: path, Timeout: timeout}, nil
+}
+
+func (p *PluginProvider) Secret(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path, name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("secrets plugin exceeded timeout %v", p.Timeout)
+		}
+		return "", fmt.Errorf("secrets plugin failed: %v, stderr: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mapProvider map[string]string
+
+func (m mapProvider) Secret(name string) (string, error) {
+	if v, ok := m[name]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("no secret %q", name)
+}
+
+func TestResolve(t *testing.T) {
+	env := map[string]string{"A": "a", "TOKEN": RefPrefix + "token", "KEY": RefPrefix + "key"}
+	resolved, names, err := Resolve(mapProvider{"token": "t0k3n", "key": "k3y"}, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]string{"A": "a", "TOKEN": "t0k3n", "KEY": "k3y"}; !reflect.DeepEqual(resolved, expected) {
+		t.Fatalf("Expected %v, got %v", expected, resolved)
+	}
+	if expected := []string{"key", "token"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected names %v, got %v", expected, names)
+	}
+	if env["TOKEN"] != RefPrefix+"token" {
+		t.Fatalf("Expected env to be unmodified, got %v", env)
+	}
+
+	if _, _, err := Resolve(mapProvider{}, env); err == nil {
+		t.Fatal("Expected error for missing secret")
+	}
+	if _, _, err := Resolve(nil, env); err == nil {
+		t.Fatal("Expected error for secrets without a provider")
+	}
+	plain := map[string]string{"A": "a"}
+	if resolved, names, err := Resolve(nil, plain); err != nil || len(names) != 0 || !reflect.DeepEqual(resolved, plain) {
+		t.Fatalf("Expected env without references unchanged, got %v %v %v", resolved, names, err)
+	}
+}
+
+func TestEnvFileProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secrets.env")
+	if err := ioutil.WriteFile(path, []byte("# comment\n\ntoken=a=b\n key = value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewEnvFileProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := p.Secret("token"); err != nil || v != "a=b" {
+		t.Fatalf("Expected a=b, got %q %v", v, err)
+	}
+	if v, err := p.Secret("key"); err != nil || v != " value" {
+		t.Fatalf("Expected ' value', got %q %v", v, err)
+	}
+	if _, err := p.Secret("missing"); err == nil {
+		t.Fatal("Expected error for missing secret")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("not a secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Secret("token"); err == nil {
+		t.Fatal("Expected error for malformed file")
+	}
+	if _, err := NewEnvFileProvider(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("Expected error for missing file")
+	}
+}
+
+func TestPluginProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "plugin.sh")
+	script := "#!/bin/sh\nif [ \"$1\" = token ]; then echo t0k3n; else echo \"unknown $1\" >&2; exit 1; fi\n"
+	if err := ioutil.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPluginProvider(path, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := p.Secret("token"); err != nil || v != "t0k3n" {
+		t.Fatalf("Expected t0k3n, got %q %v", v, err)
+	}
+	if _, err := p.Secret("other"); err == nil || !strings.Contains(err.Error(), "unknown other") {
+		t.Fatalf("Expected error with plugin stderr, got %v", err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPluginProvider(path, time.Second); err == nil {
+		t.Fatal("Expected error for non-executable plugin")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
 	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner/execer"
 )
 
 type RunID string
@@ -64,6 +65,40 @@ func (p RunState) String() string {
 	}
 }
 
+// Classifies why a run failed, so the scheduler only retries failures that retrying may fix
+// and Bazel clients can tell an action that failed from a system that did.
+type FailureKind int
+
+const (
+	// Not set by the worker, see RunStatus.Failure for how it's inferred.
+	UnclassifiedFailure FailureKind = iota
+	// The run didn't fail (or hasn't finished).
+	NoFailure
+	// The command ran and failed, ex: it exited nonzero or timed out. Retrying would likely fail again.
+	CommandFailure
+	// The worker or infrastructure failed, ex: a checkout or upload failed. Retrying may succeed.
+	InfraFailure
+	// The request can't be run as given, ex: its inputs are missing. Retrying would fail again.
+	RequestFailure
+)
+
+func (k FailureKind) String() string {
+	switch k {
+	case UnclassifiedFailure:
+		return "UNCLASSIFIED"
+	case NoFailure:
+		return "NONE"
+	case CommandFailure:
+		return "COMMAND"
+	case InfraFailure:
+		return "INFRA"
+	case RequestFailure:
+		return "REQUEST"
+	default:
+		panic(fmt.Sprintf("Unexpected FailureKind %v", int(k)))
+	}
+}
+
 // Returned by the coordinator when a run request is made.
 type RunStatus struct {
 	RunID RunID
@@ -83,6 +118,46 @@ type RunStatus struct {
 
 	tags.LogTags
 	ActionResult *bazelapi.ActionResult
+
+	// Resources consumed by the command, if it was started. Zero values mean unknown.
+	Usage execer.ResourceUsage
+
+	// Set if a worker pre-run or post-run hook failed, distinct from any error running the command.
+	HookError string
+
+	// Reference to the run's combined stdout/stderr persisted in the Store, if enabled. See package runlogs.
+	LogRef string
+
+	// The last bytes of stdout and stderr, if enabled, for triage without fetching the full logs.
+	StdoutTail string
+	StderrTail string
+
+	// Why the run failed, if the worker classified it. Use Failure() to also infer it for unclassified runs.
+	FailureKind FailureKind
+
+	// Set if the run was aborted or timed out and its command exited on SIGTERM within the worker's
+	// abort grace period, rather than being killed, ex: after flushing coverage data.
+	GracefulShutdown bool
+}
+
+// Returns why the run failed. If the worker didn't classify the failure it's inferred from
+// the State: FAILED, UNKNOWN and BADREQUEST runs are assumed to be infrastructure failures,
+// since workers also reject requests as BADREQUEST when they're busy.
+func (p RunStatus) Failure() FailureKind {
+	if p.FailureKind != UnclassifiedFailure {
+		return p.FailureKind
+	}
+	switch p.State {
+	case COMPLETE:
+		if p.ExitCode != 0 {
+			return CommandFailure
+		}
+	case TIMEDOUT:
+		return CommandFailure
+	case FAILED, UNKNOWN, BADREQUEST:
+		return InfraFailure
+	}
+	return NoFailure
 }
 
 func (p RunStatus) String() string {
@@ -95,7 +170,23 @@ func (p RunStatus) String() string {
 	if p.State == FAILED || p.State == BADREQUEST {
 		s += fmt.Sprintf(" # Error: %s", p.Error)
 	}
+	if p.FailureKind != UnclassifiedFailure {
+		s += fmt.Sprintf(" # Failure: %s", p.FailureKind)
+	}
+	if p.HookError != "" {
+		s += fmt.Sprintf(" # HookError: %s", p.HookError)
+	}
+	if p.GracefulShutdown {
+		s += " # GracefulShutdown"
+	}
 	s += fmt.Sprintf(" # Stdout: %s # Stderr: %s", p.StdoutRef, p.StderrRef)
+	if p.LogRef != "" {
+		s += fmt.Sprintf(" # Log: %s", p.LogRef)
+	}
+
+	if p.Usage != (execer.ResourceUsage{}) {
+		s += fmt.Sprintf(" # %s", p.Usage)
+	}
 
 	if p.ActionResult != nil {
 		s += fmt.Sprintf("  ActionResult=%s", p.ActionResult)
@@ -168,12 +259,61 @@ func PreparingStatus(runID RunID, tags tags.LogTags) (r RunStatus) {
 	return r
 }
 
-// This is for overall runner status, just 'initialized' status and error for now.
+// This is for overall runner status, 'initialized' status, error and what the runner can run.
 type ServiceStatus struct {
 	Initialized bool
 	Error       error
+	// Nil if unknown, ex: for remote workers that don't report capabilities.
+	Capabilities *Capabilities
 }
 
 func (s ServiceStatus) String() string {
+	if s.Capabilities != nil {
+		return fmt.Sprintf("--- Service Status ---\n\tInitialized:%t\n\tCapabilities:%s\n", s.Initialized, s.Capabilities)
+	}
 	return fmt.Sprintf("--- Service Status ---\n\tInitialized:%t\n", s.Initialized)
 }
+
+// Describes what a worker can run, so it isn't assigned incompatible tasks,
+// and its version, so operators can audit the versions deployed across a fleet.
+type Capabilities struct {
+	Version       string    // Worker binary version, ex: a git sha
+	SnapshotType  string    // Type of snapshots checked out for Scoot runs, ex: "gitdb"
+	Features      []string  // Supported features, ex: the RunTypes the worker can run
+	OS            string    // As GOOS
+	Arch          string    // As GOARCH
+	FreeDiskBytes int64     // Free space on the filesystem runs use
+	GPUs          []GPU     // GPUs runs may request
+	Resources     Resources // Total CPU and memory available to runs
+}
+
+// A GPU device on a worker.
+type GPU struct {
+	ID    string // Index of the device as used in CUDA_VISIBLE_DEVICES
+	Model string
+}
+
+func (c *Capabilities) String() string {
+	return fmt.Sprintf("{version:%s, snapshotType:%s, features:%v, os:%s, arch:%s, freeDiskBytes:%d, gpus:%v, resources:%s}",
+		c.Version, c.SnapshotType, c.Features, c.OS, c.Arch, c.FreeDiskBytes, c.GPUs, c.Resources)
+}
+
+// Returns the features in required that c doesn't support. If c is nil, all of required.
+func (c *Capabilities) MissingFeatures(required []string) []string {
+	missing := []string{}
+	for _, r := range required {
+		found := false
+		if c != nil {
+			for _, f := range c.Features {
+				if f == r {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
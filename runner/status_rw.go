@@ -144,6 +144,22 @@ type StatusEraser interface {
 	Erase(run RunID) error
 }
 
+// StatusEvent is a change to the statuses held by a StatusWriter. Exactly one of its fields is set:
+// Run for a new or updated run, Erased for a run that was erased or evicted, Service for a service update.
+type StatusEvent struct {
+	Run     *RunStatus
+	Erased  RunID
+	Service *ServiceStatus
+}
+
+// StatusSubscriber allows listening for every change in status instead of polling StatusAll.
+type StatusSubscriber interface {
+	// SubscribeStatus returns a channel of all future StatusEvents, buffered by buffer events.
+	// Events are dropped rather than block the writer when the buffer is full, so subscribers
+	// should still resync from StatusAll() periodically. Returns nil if events aren't supported.
+	SubscribeStatus(buffer int) <-chan StatusEvent
+}
+
 func (m StateMask) Matches(state RunState) bool {
 	return MaskForState(state)&m != 0
 }
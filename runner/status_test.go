@@ -68,3 +68,22 @@ func TestProcStatusStringError(t *testing.T) {
 		t.Errorf("Got:\n%s\nExpected:\n%s\n", s, expected)
 	}
 }
+
+func TestRunStatusFailure(t *testing.T) {
+	for _, test := range []struct {
+		st       RunStatus
+		expected FailureKind
+	}{
+		{RunStatus{State: RUNNING}, NoFailure},
+		{RunStatus{State: COMPLETE}, NoFailure},
+		{RunStatus{State: COMPLETE, ExitCode: 1}, CommandFailure},
+		{RunStatus{State: TIMEDOUT}, CommandFailure},
+		{RunStatus{State: FAILED}, InfraFailure},
+		{RunStatus{State: BADREQUEST}, InfraFailure},
+		{RunStatus{State: FAILED, FailureKind: RequestFailure}, RequestFailure},
+	} {
+		if f := test.st.Failure(); f != test.expected {
+			t.Errorf("Expected %s for %s, got %s", test.expected, test.st, f)
+		}
+	}
+}
@@ -94,6 +94,27 @@ func (s *Saga) AbortSaga() error {
 	return s.updateSagaState(MakeAbortSagaMessage(s.id))
 }
 
+//
+// Log a PauseSaga message.  This indicates that no new
+// tasks should be started until the Saga is resumed.
+// data is persisted to the log, ex: who paused the Saga.
+//
+// Returns an error if it fails
+//
+func (s *Saga) PauseSaga(data []byte) error {
+	return s.updateSagaState(MakePauseSagaMessage(s.id, data))
+}
+
+//
+// Log a ResumeSaga message, undoing a PauseSaga.
+// data is persisted to the log, ex: who resumed the Saga.
+//
+// Returns an error if it fails
+//
+func (s *Saga) ResumeSaga(data []byte) error {
+	return s.updateSagaState(MakeResumeSagaMessage(s.id, data))
+}
+
 //
 // Log a StartTask Message to the log.  Returns
 // an error if it fails.
@@ -107,6 +128,18 @@ func (s *Saga) StartTask(taskId string, data []byte) error {
 	return s.updateSagaState(MakeStartTaskMessage(s.id, taskId, data))
 }
 
+//
+// Log a StartTask Message for a numbered attempt at the task, starting at 1.
+// Returns an error if it fails.
+//
+// StartTaskAttempt is idempotent with respect to sagaId, taskId & attempt.
+// Once an attempt's StartTask is logged, repeats are ignored, even after
+// the task has completed.
+//
+func (s *Saga) StartTaskAttempt(taskId string, attempt int, data []byte) error {
+	return s.updateSagaState(MakeStartTaskAttemptMessage(s.id, taskId, attempt, data))
+}
+
 //
 // Log an EndTask Message to the log.  Indicates that this task
 // has been successfully completed. Returns an error if it fails.
@@ -120,6 +153,30 @@ func (s *Saga) EndTask(taskId string, results []byte) error {
 	return s.updateSagaState(MakeEndTaskMessage(s.id, taskId, results))
 }
 
+//
+// Log an EndTask Message for a numbered attempt at the task, starting at 1.
+// Returns an error if it fails.
+//
+// EndTaskAttempt is idempotent with respect to sagaId, taskId & attempt.
+// Once an attempt's EndTask is logged, repeats are ignored and the first
+// results win.
+//
+func (s *Saga) EndTaskAttempt(taskId string, attempt int, results []byte) error {
+	return s.updateSagaState(MakeEndTaskAttemptMessage(s.id, taskId, attempt, results))
+}
+
+//
+// Log a DeadLetterTask Message for a numbered attempt at the task, zero if unnumbered.
+// Indicates the task is set aside rather than retried until it's started again or
+// ended, ex: when it's requeued or its Saga is killed.  data is persisted to the log,
+// ex: the reason.
+//
+// Returns an error if it fails
+//
+func (s *Saga) DeadLetterTask(taskId string, attempt int, data []byte) error {
+	return s.updateSagaState(MakeDeadLetterTaskMessage(s.id, taskId, attempt, data))
+}
+
 //
 // Log a Start Compensating Task Message to the log. Should only be logged after a Saga
 // has been avoided and in Rollback Recovery Mode. Should not be used in ForwardRecovery Mode
@@ -199,6 +256,12 @@ type sagaUpdate struct {
 //
 func logMessage(state *SagaState, msg SagaMessage, log SagaLog) (*SagaState, error) {
 
+	// a task message for an attempt that was already logged, ex: resent after a retry or
+	// failover, is acknowledged without logging it again.
+	if state.isDuplicate(msg) {
+		return state, nil
+	}
+
 	// updateSagaState will mutate state if it's a valid transition, but if we then error storing,
 	// we'll need to revert to the old state.
 	oldState := copySagaState(state)
@@ -1,20 +1,38 @@
 package saga
 
+import (
+	"github.com/twitter/scoot/common/stats"
+)
+
 //
 // Saga Object which provides all Saga Functionality
 // Implementations of SagaLog should provide a factory method
 // which returns a saga based on its implementation.
 //
 type SagaCoordinator struct {
-	log SagaLog
+	log      SagaLog
+	stats    *sagaStats
+	watchers *sagaWatchers
 }
 
 //
 // Make a Saga which uses the specied SagaLog interface for durable storage
 //
 func MakeSagaCoordinator(log SagaLog) SagaCoordinator {
+	return MakeSagaCoordinatorWithStats(log, stats.NilStatsReceiver())
+}
+
+//
+// Make a Saga which uses the specied SagaLog interface for durable storage,
+// recording the log's latency, active sagas and compensating tasks to stat
+//
+func MakeSagaCoordinatorWithStats(log SagaLog, stat stats.StatsReceiver) SagaCoordinator {
+	sagas := &sagaStats{stat: stat}
+	watchers := newSagaWatchers()
 	return SagaCoordinator{
-		log: log,
+		log:      &watchedSagaLog{SagaLog: newStatsSagaLog(log, sagas), watchers: watchers},
+		stats:    sagas,
+		watchers: watchers,
 	}
 }
 
@@ -30,6 +48,23 @@ func (s SagaCoordinator) GetSagaState(sagaId string) (*SagaState, error) {
 	return recoverState(sagaId, s)
 }
 
+// Watch the saga with the given id, returns a channel that receives after messages are logged for it
+// through this SagaCoordinator or the sagas it makes or recovers, and a func to stop watching.
+// Receives are coalesced, so the saga's state should be read again after each.
+func (s SagaCoordinator) Watch(sagaId string) (<-chan struct{}, func()) {
+	return s.watchers.watch(sagaId)
+}
+
+// Read the Timeline of the saga's logged messages, for debugging. Never returns a nil Timeline
+// without an error, if no Saga exists for the requested id its State is TimelineNotFound.
+func (s SagaCoordinator) GetTimeline(sagaId string, includeData bool) (*Timeline, error) {
+	msgs, err := s.log.GetMessages(sagaId)
+	if err != nil {
+		return nil, err
+	}
+	return MakeTimeline(sagaId, msgs, includeData), nil
+}
+
 //
 // Should be called at Saga Creation time.
 // Returns a Slice of In Progress SagaIds
@@ -64,6 +99,9 @@ func (sc SagaCoordinator) RecoverSagaState(sagaId string, recoveryType SagaRecov
 
 	// now that we've recovered the saga initialize its update path
 	saga := rehydrateSaga(sagaId, state, sc.log)
+	if !state.IsSagaCompleted() {
+		sc.stats.sagaStarted()
+	}
 
 	// Check if we can safely proceed forward based on recovery method
 	// RollbackRecovery must check if in a SafeState,
@@ -10,6 +10,9 @@ const (
 	EndTask
 	StartCompTask
 	EndCompTask
+	PauseSaga
+	ResumeSaga
+	DeadLetterTask
 )
 
 func (s SagaMessageType) String() string {
@@ -28,6 +31,12 @@ func (s SagaMessageType) String() string {
 		return "Start Comp Task"
 	case EndCompTask:
 		return "End Comp Task"
+	case PauseSaga:
+		return "Pause Saga"
+	case ResumeSaga:
+		return "Resume Saga"
+	case DeadLetterTask:
+		return "Dead Letter Task"
 	default:
 		return "unknown"
 	}
@@ -44,6 +53,7 @@ type SagaMessage struct {
 	MsgType SagaMessageType
 	Data    []byte
 	TaskId  string
+	Attempt int // Task messages only, numbered from 1 for each run of the task. Zero if unknown.
 }
 
 /*
@@ -81,6 +91,34 @@ func MakeAbortSagaMessage(sagaId string) SagaMessage {
 	}
 }
 
+/*
+ * PauseSaga SagaMessageType
+ *  - sagaId - id of the Saga
+ *  - data   - data that is persisted to the log, useful for
+ *             diagnostic information
+ */
+func MakePauseSagaMessage(sagaId string, data []byte) SagaMessage {
+	return SagaMessage{
+		SagaId:  sagaId,
+		MsgType: PauseSaga,
+		Data:    data,
+	}
+}
+
+/*
+ * ResumeSaga SagaMessageType
+ *  - sagaId - id of the Saga
+ *  - data   - data that is persisted to the log, useful for
+ *             diagnostic information
+ */
+func MakeResumeSagaMessage(sagaId string, data []byte) SagaMessage {
+	return SagaMessage{
+		SagaId:  sagaId,
+		MsgType: ResumeSaga,
+		Data:    data,
+	}
+}
+
 /*
  * StartTask SagaMessageType
  *  - sagaId - id of the Saga
@@ -97,6 +135,21 @@ func MakeStartTaskMessage(sagaId string, taskId string, data []byte) SagaMessage
 	}
 }
 
+/*
+ * StartTask SagaMessageType for a numbered attempt at the task
+ *  - sagaId  - id of the Saga
+ *  - taskId  - id of the started Task
+ *  - attempt - which run of the task this is, starting at 1.  A
+ *              repeated StartTask for the same attempt is ignored
+ *  - data    - data that is persisted to the log, useful for
+ *              diagnostic information
+ */
+func MakeStartTaskAttemptMessage(sagaId string, taskId string, attempt int, data []byte) SagaMessage {
+	msg := MakeStartTaskMessage(sagaId, taskId, data)
+	msg.Attempt = attempt
+	return msg
+}
+
 /*
  * EndTask SagaMessageType
  *  - sagaId - id of the Saga
@@ -112,6 +165,39 @@ func MakeEndTaskMessage(sagaId string, taskId string, results []byte) SagaMessag
 	}
 }
 
+/*
+ * EndTask SagaMessageType for a numbered attempt at the task
+ *  - sagaId  - id of the Saga
+ *  - taskId  - id of the completed Task
+ *  - attempt - which run of the task completed it, starting at 1.
+ *              A repeated EndTask for the same attempt is ignored
+ *  - data    - any results from task completion
+ */
+func MakeEndTaskAttemptMessage(sagaId string, taskId string, attempt int, results []byte) SagaMessage {
+	msg := MakeEndTaskMessage(sagaId, taskId, results)
+	msg.Attempt = attempt
+	return msg
+}
+
+/*
+ * DeadLetterTask SagaMessageType
+ *  - sagaId  - id of the Saga
+ *  - taskId  - id of the Task set aside.  Should be followed by
+ *              a StartTask for a later attempt or an EndTask
+ *  - attempt - which run of the task was set aside, zero if unknown
+ *  - data    - data that is persisted to the log, ex: why the task
+ *              was set aside
+ */
+func MakeDeadLetterTaskMessage(sagaId string, taskId string, attempt int, data []byte) SagaMessage {
+	return SagaMessage{
+		SagaId:  sagaId,
+		MsgType: DeadLetterTask,
+		TaskId:  taskId,
+		Data:    data,
+		Attempt: attempt,
+	}
+}
+
 /*
  * StartCompTask SagaMessageType
  *  - sagaId - id of the Saga
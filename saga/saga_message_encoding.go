@@ -0,0 +1,49 @@
+package saga
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/twitter/scoot/saga/sagapb"
+)
+
+// Version of the binary SagaMessage encoding written by EncodeMessage. Only bumped for
+// changes older readers can't safely ignore, DecodeMessage rejects messages from newer versions.
+const MessageEncodingVersion = 1
+
+// Encodes msg in the versioned protobuf format shared by durable SagaLogs, see sagapb/saga.proto.
+// recordId is optional, see sagapb.SagaMessage.
+func EncodeMessage(msg SagaMessage, recordId string) ([]byte, error) {
+	return proto.Marshal(&sagapb.SagaMessage{
+		Version:  MessageEncodingVersion,
+		SagaId:   msg.SagaId,
+		Type:     sagapb.MessageType(msg.MsgType),
+		TaskId:   msg.TaskId,
+		Data:     msg.Data,
+		Attempt:  int64(msg.Attempt),
+		RecordId: recordId,
+	})
+}
+
+// Decodes a message encoded by EncodeMessage, returning it and its recordId.
+func DecodeMessage(b []byte) (SagaMessage, string, error) {
+	pb := &sagapb.SagaMessage{}
+	if err := proto.Unmarshal(b, pb); err != nil {
+		return SagaMessage{}, "", err
+	}
+	if pb.Version == 0 || pb.Version > MessageEncodingVersion {
+		return SagaMessage{}, "", fmt.Errorf("unsupported saga message encoding version %d, expected <= %d",
+			pb.Version, MessageEncodingVersion)
+	}
+	if _, ok := sagapb.MessageType_name[int32(pb.Type)]; !ok {
+		return SagaMessage{}, "", fmt.Errorf("unknown saga message type %d", pb.Type)
+	}
+	return SagaMessage{
+		SagaId:  pb.SagaId,
+		MsgType: SagaMessageType(pb.Type),
+		TaskId:  pb.TaskId,
+		Data:    pb.Data,
+		Attempt: int(pb.Attempt),
+	}, pb.RecordId, nil
+}
@@ -0,0 +1,59 @@
+package saga
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/twitter/scoot/saga/sagapb"
+)
+
+func TestEncodeMessage_RoundTrip(t *testing.T) {
+	msgs := []SagaMessage{
+		MakeStartSagaMessage("saga1", []byte("job")),
+		MakeStartTaskAttemptMessage("saga1", "task1", 2, []byte("start")),
+		MakeEndTaskMessage("saga1", "task1", []byte("end")),
+		MakeAbortSagaMessage("saga1"),
+		MakeStartCompTaskMessage("saga1", "task1", nil),
+		MakeEndCompTaskMessage("saga1", "task1", []byte("comp")),
+		MakeEndSagaMessage("saga1"),
+	}
+	for _, msg := range msgs {
+		b, err := EncodeMessage(msg, "record1")
+		if err != nil {
+			t.Fatalf("Unexpected error encoding %+v: %v", msg, err)
+		}
+		decoded, recordId, err := DecodeMessage(b)
+		if err != nil {
+			t.Fatalf("Unexpected error decoding %+v: %v", msg, err)
+		}
+		if !reflect.DeepEqual(decoded, msg) || recordId != "record1" {
+			t.Errorf("Expected %+v and record1, got %+v and %s", msg, decoded, recordId)
+		}
+	}
+}
+
+func TestDecodeMessage_Versions(t *testing.T) {
+	// Fields added by a newer compatible writer are ignored.
+	b, _ := proto.Marshal(&sagapb.SagaMessage{
+		Version:          MessageEncodingVersion,
+		SagaId:           "saga1",
+		Type:             sagapb.MessageType_END_SAGA,
+		XXX_unrecognized: []byte{0xf8, 0x01, 0x01}, // field 31, varint 1
+	})
+	if msg, _, err := DecodeMessage(b); err != nil || msg.MsgType != EndSaga {
+		t.Errorf("Expected unknown fields to be ignored, got %+v, %v", msg, err)
+	}
+
+	for _, version := range []uint32{0, MessageEncodingVersion + 1} {
+		b, _ := proto.Marshal(&sagapb.SagaMessage{Version: version, SagaId: "saga1"})
+		if _, _, err := DecodeMessage(b); err == nil {
+			t.Errorf("Expected version %d to be rejected", version)
+		}
+	}
+
+	if _, _, err := DecodeMessage([]byte("Start Saga\n")); err == nil {
+		t.Error("Expected invalid protobuf to be rejected")
+	}
+}
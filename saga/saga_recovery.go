@@ -48,7 +48,7 @@ func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
 	for _, msg := range msgs {
 		// skip applying StartSaga message we already did this
 		// duplicate messages are just ignored since msgs are idempotent
-		if msg.MsgType == StartSaga {
+		if msg.MsgType == StartSaga || state.isDuplicate(msg) {
 			continue
 		}
 
@@ -178,6 +178,39 @@ func TestRecoverState_SuccessfulForwardRecovery(t *testing.T) {
 	}
 }
 
+func TestRecoverState_DuplicateTaskAttempts(t *testing.T) {
+	sagaId := "sagaId"
+	taskId := "taskId"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// A scheduler failed over after logging EndTask and its successor resent the attempt's messages.
+	msgs := []SagaMessage{
+		MakeStartSagaMessage(sagaId, []byte{4, 5, 6}),
+		MakeStartTaskAttemptMessage(sagaId, taskId, 1, []byte{1}),
+		MakeEndTaskAttemptMessage(sagaId, taskId, 1, []byte{2}),
+		MakeStartTaskAttemptMessage(sagaId, taskId, 1, []byte{1}),
+		MakeEndTaskAttemptMessage(sagaId, taskId, 1, []byte{3}),
+	}
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().GetMessages(sagaId).Return(msgs, nil)
+	sc := MakeSagaCoordinator(sagaLogMock)
+
+	saga, err := sc.RecoverSagaState(sagaId, ForwardRecovery)
+	if err != nil {
+		t.Fatalf("Expected duplicate messages to be ignored, got %v", err)
+	}
+	state := saga.state
+	if !state.IsTaskCompleted(taskId) || !bytes.Equal(state.GetEndTaskData(taskId), []byte{2}) {
+		t.Errorf("Expected task to be completed by the first EndTask, got %v", state)
+	}
+	if state.GetTaskAttempt(taskId) != 1 {
+		t.Errorf("Expected attempt 1, got %d", state.GetTaskAttempt(taskId))
+	}
+}
+
 func TestRecoverState_SuccessfulRollbackRecovery(t *testing.T) {
 	sagaId := "sagaId"
 	taskId := "taskId"
@@ -39,6 +39,7 @@ const (
 	TaskCompleted
 	CompTaskStarted
 	CompTaskCompleted
+	TaskDeadLettered
 )
 
 /*
@@ -51,6 +52,7 @@ type taskData struct {
 	taskEnd       []byte
 	compTaskStart []byte
 	compTaskEnd   []byte
+	deadLetter    []byte
 }
 
 /*
@@ -67,11 +69,30 @@ type SagaState struct {
 	// map of taskId to Flag specifying task progress
 	taskState map[string]flag
 
+	// map of taskId to the highest attempt number logged for it
+	taskAttempts map[string]int
+
+	// task messages with attempt numbers applied so far, used to ignore duplicates
+	appliedMsgs map[appliedMsg]bool
+
 	//bool if AbortSaga message logged
 	sagaAborted bool
 
 	//bool if EndSaga message logged
 	sagaCompleted bool
+
+	//bool if a PauseSaga message was logged without a later ResumeSaga
+	sagaPaused bool
+}
+
+/*
+ * Identifies a task message by its type and attempt, the sagaId
+ * is implied by the SagaState it was applied to.
+ */
+type appliedMsg struct {
+	taskId  string
+	msgType SagaMessageType
+	attempt int
 }
 
 /*
@@ -83,6 +104,8 @@ func initializeSagaState() *SagaState {
 		job:           nil,
 		taskState:     make(map[string]flag),
 		taskData:      make(map[string]*taskData),
+		taskAttempts:  make(map[string]int),
+		appliedMsgs:   make(map[appliedMsg]bool),
 		sagaAborted:   false,
 		sagaCompleted: false,
 	}
@@ -203,6 +226,61 @@ func (state *SagaState) GetEndCompTaskData(taskId string) []byte {
 	}
 }
 
+/*
+ * Returns true if the specified Task has been dead lettered and
+ * not started again since, false otherwise
+ */
+func (state *SagaState) IsTaskDeadLettered(taskId string) bool {
+	flags, _ := state.taskState[taskId]
+	return flags&TaskDeadLettered != 0
+}
+
+/*
+ * Get Data Associated with Dead Lettering a Task, supplied as
+ * Part of the DeadLetterTask Message
+ */
+func (state *SagaState) GetDeadLetterTaskData(taskId string) []byte {
+	data, ok := state.taskData[taskId]
+	if ok {
+		return data.deadLetter
+	} else {
+		return nil
+	}
+}
+
+/*
+ * Returns the highest attempt number logged for the specified Task,
+ * 0 if none of its messages had an attempt number
+ */
+func (state *SagaState) GetTaskAttempt(taskId string) int {
+	return state.taskAttempts[taskId]
+}
+
+/*
+ * Returns true if msg is a task message for an attempt that has already
+ * been applied, ex: one resent after a retry or a scheduler failover.
+ * Messages without an attempt number are never duplicates.
+ */
+func (state *SagaState) isDuplicate(msg SagaMessage) bool {
+	if msg.Attempt == 0 {
+		return false
+	}
+	return state.appliedMsgs[appliedMsg{taskId: msg.TaskId, msgType: msg.MsgType, attempt: msg.Attempt}]
+}
+
+/*
+ * Records that a task message with an attempt number has been applied.
+ */
+func (state *SagaState) recordAttempt(msg SagaMessage) {
+	if msg.Attempt == 0 || msg.TaskId == "" {
+		return
+	}
+	state.appliedMsgs[appliedMsg{taskId: msg.TaskId, msgType: msg.MsgType, attempt: msg.Attempt}] = true
+	if msg.Attempt > state.taskAttempts[msg.TaskId] {
+		state.taskAttempts[msg.TaskId] = msg.Attempt
+	}
+}
+
 /*
  * Returns true if this Saga has been Aborted, false otherwise
  */
@@ -210,6 +288,13 @@ func (state *SagaState) IsSagaAborted() bool {
 	return state.sagaAborted
 }
 
+/*
+ * Returns true if this Saga has been Paused and not since Resumed, false otherwise
+ */
+func (state *SagaState) IsSagaPaused() bool {
+	return state.sagaPaused
+}
+
 /*
  * Returns true if this Saga has been Completed, false otherwise
  */
@@ -242,12 +327,16 @@ func (state *SagaState) addTaskData(taskId string, msgType SagaMessageType, data
 
 	case EndCompTask:
 		state.taskData[taskId].compTaskEnd = data
+
+	case DeadLetterTask:
+		state.taskData[taskId].deadLetter = data
 	}
 }
 
 /*
  * Applies the supplied message to the supplied sagaState.
- * Mutates state directly.
+ * Mutates state directly.  Callers should skip messages for which
+ * state.isDuplicate returns true rather than applying them again.
  *
  * Returns an Error if applying the message would result in an invalid Saga State.
  * Client must not use state after updateSagaState returns a non-nil error.
@@ -289,6 +378,26 @@ func updateSagaState(state *SagaState, msg SagaMessage) error {
 
 		state.sagaAborted = true
 
+	case PauseSaga:
+
+		if state.IsSagaCompleted() {
+			return NewInvalidSagaStateError("PauseSaga Message cannot be applied to a Completed Saga")
+		}
+
+		if state.IsSagaAborted() {
+			return NewInvalidSagaStateError("PauseSaga Message cannot be applied to an Aborted Saga")
+		}
+
+		state.sagaPaused = true
+
+	case ResumeSaga:
+
+		if state.IsSagaCompleted() {
+			return NewInvalidSagaStateError("ResumeSaga Message cannot be applied to a Completed Saga")
+		}
+
+		state.sagaPaused = false
+
 	case StartTask:
 		err := validateTaskId(msg.TaskId)
 		if err != nil {
@@ -338,6 +447,35 @@ func updateSagaState(state *SagaState, msg SagaMessage) error {
 			state.addTaskData(msg.TaskId, msg.MsgType, msg.Data)
 		}
 
+	case DeadLetterTask:
+		err := validateTaskId(msg.TaskId)
+		if err != nil {
+			return err
+		}
+
+		if state.IsSagaCompleted() {
+			return NewInvalidSagaStateError("Cannot DeadLetterTask after Saga has been completed")
+		}
+
+		if state.IsSagaAborted() {
+			return NewInvalidSagaStateError("Cannot DeadLetterTask after an Abort Saga Message")
+		}
+
+		// All DeadLetterTask Messages must have a preceding StartTask Message
+		if !state.IsTaskStarted(msg.TaskId) {
+			return NewInvalidSagaStateError(fmt.Sprintf("Cannot have a DeadLetterTask Message Before a StartTask Message, taskId: %s", msg.TaskId))
+		}
+
+		if state.IsTaskCompleted(msg.TaskId) {
+			return NewInvalidSagaStateError(fmt.Sprintf("Cannot DeadLetterTask after it has been completed, taskId: %s", msg.TaskId))
+		}
+
+		state.taskState[msg.TaskId] = state.taskState[msg.TaskId] | TaskDeadLettered
+
+		if msg.Data != nil {
+			state.addTaskData(msg.TaskId, msg.MsgType, msg.Data)
+		}
+
 	case StartCompTask:
 		err := validateTaskId(msg.TaskId)
 		if err != nil {
@@ -401,6 +539,7 @@ func updateSagaState(state *SagaState, msg SagaMessage) error {
 
 	}
 
+	state.recordAttempt(msg)
 	return nil
 }
 
@@ -414,6 +553,7 @@ func copySagaState(s *SagaState) *SagaState {
 		sagaId:        s.sagaId,
 		sagaAborted:   s.sagaAborted,
 		sagaCompleted: s.sagaCompleted,
+		sagaPaused:    s.sagaPaused,
 	}
 
 	newS.taskState = make(map[string]flag)
@@ -421,6 +561,16 @@ func copySagaState(s *SagaState) *SagaState {
 		newS.taskState[key] = value
 	}
 
+	newS.taskAttempts = make(map[string]int)
+	for key, value := range s.taskAttempts {
+		newS.taskAttempts[key] = value
+	}
+
+	newS.appliedMsgs = make(map[appliedMsg]bool)
+	for key, value := range s.appliedMsgs {
+		newS.appliedMsgs[key] = value
+	}
+
 	newS.taskData = make(map[string]*taskData)
 	for key, value := range s.taskData {
 		newS.taskData[key] = &taskData{
@@ -428,6 +578,7 @@ func copySagaState(s *SagaState) *SagaState {
 			taskEnd:       value.taskEnd,
 			compTaskStart: value.compTaskStart,
 			compTaskEnd:   value.compTaskEnd,
+			deadLetter:    value.deadLetter,
 		}
 	}
 
@@ -496,6 +647,9 @@ func (state *SagaState) String() string {
 		if state.IsTaskCompleted(id) {
 			taskState += "Completed|"
 		}
+		if state.IsTaskDeadLettered(id) {
+			taskState += "DeadLettered|"
+		}
 		if state.IsCompTaskStarted(id) {
 			taskState += "CompTaskStarted|"
 		}
@@ -0,0 +1,89 @@
+package saga
+
+import (
+	"reflect"
+	"sync/atomic"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Metrics shared by a SagaCoordinator and the sagas it makes or recovers.
+type sagaStats struct {
+	stat   stats.StatsReceiver
+	active int64 // Updated atomically.
+}
+
+func (s *sagaStats) sagaStarted() {
+	s.stat.Gauge(stats.SagaActiveGauge).Update(atomic.AddInt64(&s.active, 1))
+}
+
+func (s *sagaStats) messageLogged(msg SagaMessage) {
+	switch msg.MsgType {
+	case StartCompTask:
+		s.stat.Counter(stats.SagaCompTasksStartedCounter).Inc(1)
+	case EndCompTask:
+		s.stat.Counter(stats.SagaCompTasksEndedCounter).Inc(1)
+	case EndSaga:
+		s.stat.Gauge(stats.SagaActiveGauge).Update(atomic.AddInt64(&s.active, -1))
+	}
+}
+
+// A SagaLog recording the latency and errors of each operation of the wrapped log, scoped by its backend.
+type statsSagaLog struct {
+	log   SagaLog
+	stat  stats.StatsReceiver
+	sagas *sagaStats
+}
+
+func newStatsSagaLog(log SagaLog, sagas *sagaStats) *statsSagaLog {
+	return &statsSagaLog{log: log, stat: sagas.stat.Scope("sagalog", backendName(log)), sagas: sagas}
+}
+
+// Returns the name of log's type without its package, ex: "boltSagaLog".
+func backendName(log SagaLog) string {
+	t := reflect.TypeOf(log)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func (l *statsSagaLog) StartSaga(sagaId string, job []byte) error {
+	defer l.stat.Latency(stats.SagaStartLatency_ms).Time().Stop()
+	err := l.log.StartSaga(sagaId, job)
+	if err != nil {
+		l.stat.Counter(stats.SagaLogErrorsCounter).Inc(1)
+		return err
+	}
+	l.sagas.sagaStarted()
+	return nil
+}
+
+func (l *statsSagaLog) LogMessage(message SagaMessage) error {
+	defer l.stat.Latency(stats.SagaLogMessageLatency_ms).Time().Stop()
+	err := l.log.LogMessage(message)
+	if err != nil {
+		l.stat.Counter(stats.SagaLogErrorsCounter).Inc(1)
+		return err
+	}
+	l.sagas.messageLogged(message)
+	return nil
+}
+
+func (l *statsSagaLog) GetMessages(sagaId string) ([]SagaMessage, error) {
+	defer l.stat.Latency(stats.SagaGetMessagesLatency_ms).Time().Stop()
+	msgs, err := l.log.GetMessages(sagaId)
+	if err != nil {
+		l.stat.Counter(stats.SagaLogErrorsCounter).Inc(1)
+	}
+	return msgs, err
+}
+
+func (l *statsSagaLog) GetActiveSagas() ([]string, error) {
+	defer l.stat.Latency(stats.SagaGetActiveLatency_ms).Time().Stop()
+	ids, err := l.log.GetActiveSagas()
+	if err != nil {
+		l.stat.Counter(stats.SagaLogErrorsCounter).Inc(1)
+	}
+	return ids, err
+}
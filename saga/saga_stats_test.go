@@ -0,0 +1,45 @@
+package saga
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+func TestStatsSagaLog(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
+	sagaLogMock.EXPECT().LogMessage(MakeEndSagaMessage("testSaga")).Return(errors.New("test error"))
+	sagaLogMock.EXPECT().LogMessage(MakeEndSagaMessage("testSaga"))
+
+	reg := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return reg }, 0)
+	log := newStatsSagaLog(sagaLogMock, &sagaStats{stat: stat})
+
+	log.StartSaga("testSaga", nil)
+	if !stats.StatsOk("", reg, t, map[string]stats.Rule{
+		stats.SagaActiveGauge: {Checker: stats.Int64EqTest, Value: 1},
+	}) {
+		t.Fatal("Expected one active saga")
+	}
+
+	log.LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
+	if err := log.LogMessage(MakeEndSagaMessage("testSaga")); err == nil {
+		t.Error("Expected the log's error to be returned")
+	}
+	log.LogMessage(MakeEndSagaMessage("testSaga"))
+	if !stats.StatsOk("", reg, t, map[string]stats.Rule{
+		stats.SagaActiveGauge:                               {Checker: stats.Int64EqTest, Value: 0},
+		stats.SagaCompTasksStartedCounter:                   {Checker: stats.Int64EqTest, Value: 1},
+		"sagalog/MockSagaLog/" + stats.SagaLogErrorsCounter: {Checker: stats.Int64EqTest, Value: 1},
+	}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
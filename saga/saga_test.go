@@ -94,6 +94,60 @@ func TestAbortSagaLogError(t *testing.T) {
 	}
 }
 
+func TestPauseResumeSaga(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().LogMessage(MakePauseSagaMessage("testSaga", []byte("admin")))
+	sagaLogMock.EXPECT().LogMessage(MakeResumeSagaMessage("testSaga", nil))
+
+	s, err := newSaga("testSaga", nil, sagaLogMock)
+	if err = s.PauseSaga([]byte("admin")); err != nil {
+		t.Error("Expected PauseSaga to not return an error", err)
+	}
+	if !s.GetState().IsSagaPaused() {
+		t.Error("Expected Saga to be paused")
+	}
+
+	if err = s.ResumeSaga(nil); err != nil {
+		t.Error("Expected ResumeSaga to not return an error", err)
+	}
+	if s.GetState().IsSagaPaused() {
+		t.Error("Expected Saga to not be paused")
+	}
+}
+
+func TestDeadLetterTask(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().LogMessage(MakeStartTaskAttemptMessage("testSaga", "task1", 3, nil))
+	sagaLogMock.EXPECT().LogMessage(MakeDeadLetterTaskMessage("testSaga", "task1", 3, []byte("infra")))
+	sagaLogMock.EXPECT().LogMessage(MakeEndTaskAttemptMessage("testSaga", "task1", 3, nil))
+
+	s, err := newSaga("testSaga", nil, sagaLogMock)
+	if err = s.DeadLetterTask("task1", 3, []byte("infra")); err == nil {
+		t.Error("Expected DeadLetterTask before StartTask to return an error")
+	}
+
+	s.StartTaskAttempt("task1", 3, nil)
+	if err = s.DeadLetterTask("task1", 3, []byte("infra")); err != nil {
+		t.Error("Expected DeadLetterTask to not return an error", err)
+	}
+	if !s.GetState().IsTaskDeadLettered("task1") || string(s.GetState().GetDeadLetterTaskData("task1")) != "infra" {
+		t.Error("Expected task1 to be dead lettered with its data")
+	}
+
+	s.EndTaskAttempt("task1", 3, nil)
+	if !s.GetState().IsTaskCompleted("task1") {
+		t.Error("Expected dead lettered task1 to be completed by its EndTask")
+	}
+}
+
 func TestStartTask(t *testing.T) {
 	entry := MakeStartTaskMessage("testSaga", "task1", nil)
 
@@ -116,6 +170,47 @@ func TestStartTask(t *testing.T) {
 	}
 }
 
+func TestTaskAttempts_DuplicatesIgnored(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	gomock.InOrder(
+		sagaLogMock.EXPECT().LogMessage(MakeStartTaskAttemptMessage("testSaga", "task1", 1, []byte("start1"))),
+		sagaLogMock.EXPECT().LogMessage(MakeStartTaskAttemptMessage("testSaga", "task1", 2, []byte("start2"))),
+		sagaLogMock.EXPECT().LogMessage(MakeEndTaskAttemptMessage("testSaga", "task1", 2, []byte("end2"))),
+	)
+
+	s, _ := newSaga("testSaga", nil, sagaLogMock)
+	for _, err := range []error{
+		s.StartTaskAttempt("task1", 1, []byte("start1")),
+		s.StartTaskAttempt("task1", 1, []byte("start1")),
+		s.StartTaskAttempt("task1", 2, []byte("start2")),
+		s.EndTaskAttempt("task1", 2, []byte("end2")),
+		// Resent after the task completed, ex: by a scheduler that took over the job.
+		s.StartTaskAttempt("task1", 2, []byte("start2")),
+		s.EndTaskAttempt("task1", 2, []byte("other")),
+	} {
+		if err != nil {
+			t.Fatalf("Expected duplicate task messages to be ignored, got %v", err)
+		}
+	}
+
+	state := s.GetState()
+	if !state.IsTaskCompleted("task1") || string(state.GetEndTaskData("task1")) != "end2" {
+		t.Errorf("Expected task1 to be completed with the first results, got %v", state)
+	}
+	if state.GetTaskAttempt("task1") != 2 {
+		t.Errorf("Expected task1 attempt 2, got %d", state.GetTaskAttempt("task1"))
+	}
+
+	// Unnumbered messages are never duplicates, a StartTask after completion is still invalid.
+	if err := s.StartTask("task1", nil); err == nil {
+		t.Error("Expected an unnumbered StartTask after completion to be rejected")
+	}
+}
+
 func TestStartTaskLogError(t *testing.T) {
 	entry := MakeStartTaskMessage("testSaga", "task1", nil)
 
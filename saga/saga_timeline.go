@@ -0,0 +1,181 @@
+package saga
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Saga states reported in a Timeline
+const (
+	TimelineNotFound   = "Not Found"
+	TimelineInProgress = "In Progress"
+	TimelineAborted    = "Aborted"
+	TimelinePaused     = "Paused"
+	TimelineCompleted  = "Completed"
+)
+
+// Data longer than this is elided from text dumps
+const maxTextDataLen = 200
+
+/*
+ * A human readable dump of a saga's messages, in the order they were logged, for debugging.
+ * Unlike recovering SagaState, building a Timeline doesn't stop at the first invalid message:
+ * invalid messages are kept and annotated with the reason they're invalid.
+ */
+type Timeline struct {
+	SagaId  string          `json:"sagaId"`
+	State   string          `json:"state"`
+	Entries []TimelineEntry `json:"entries"`
+
+	// Tasks started but not completed, or if the saga was aborted, not yet compensated.
+	// For a stuck saga these are the tasks it's waiting on.
+	PendingTasks []string `json:"pendingTasks"`
+
+	// Number of entries with a Problem
+	Problems int `json:"problems"`
+}
+
+type TimelineEntry struct {
+	// Position of the message in the log, starting at 1
+	Seq    int    `json:"seq"`
+	Type   string `json:"type"`
+	TaskId string `json:"taskId,omitempty"`
+
+	// Size of the message's data. The data itself is only included if requested,
+	// as Data if it's JSON, otherwise as RawData.
+	DataLen int             `json:"dataLen"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	RawData []byte          `json:"rawData,omitempty"`
+
+	// Why the message isn't valid at this point in the saga, if it isn't
+	Problem string `json:"problem,omitempty"`
+
+	data []byte
+}
+
+/*
+ * Builds the Timeline of sagaId from its logged messages.
+ * If includeData is set, entries include their message's data.
+ */
+func MakeTimeline(sagaId string, msgs []SagaMessage, includeData bool) *Timeline {
+	t := &Timeline{SagaId: sagaId, State: TimelineNotFound, Entries: []TimelineEntry{}, PendingTasks: []string{}}
+	if len(msgs) == 0 {
+		return t
+	}
+
+	var state *SagaState
+	for i, msg := range msgs {
+		e := TimelineEntry{Seq: i + 1, Type: msg.MsgType.String(), TaskId: msg.TaskId, DataLen: len(msg.Data), data: msg.Data}
+		if includeData && len(msg.Data) > 0 {
+			if json.Valid(msg.Data) {
+				e.Data = json.RawMessage(msg.Data)
+			} else {
+				e.RawData = msg.Data
+			}
+		}
+
+		var err error
+		switch {
+		case msg.MsgType == StartSaga && state == nil:
+			state, err = makeSagaState(sagaId, msg.Data)
+		case msg.MsgType == StartSaga:
+			// Duplicate StartSaga messages are ignored on recovery
+		case state == nil:
+			err = fmt.Errorf("Saga has not been started")
+		default:
+			// Every check in updateSagaState precedes its changes, so state is unchanged on error
+			err = updateSagaState(state, msg)
+		}
+		if err != nil {
+			e.Problem = err.Error()
+			t.Problems++
+		}
+		t.Entries = append(t.Entries, e)
+	}
+
+	if state == nil {
+		return t
+	}
+	switch {
+	case state.IsSagaCompleted():
+		t.State = TimelineCompleted
+	case state.IsSagaAborted():
+		t.State = TimelineAborted
+	case state.IsSagaPaused():
+		t.State = TimelinePaused
+	default:
+		t.State = TimelineInProgress
+	}
+	for _, id := range state.GetTaskIds() {
+		if (state.IsSagaAborted() && !state.IsCompTaskCompleted(id)) || (!state.IsSagaAborted() && !state.IsTaskCompleted(id)) {
+			t.PendingTasks = append(t.PendingTasks, id)
+		}
+	}
+	sort.Strings(t.PendingTasks)
+	return t
+}
+
+/*
+ * Writes the Timeline as a table, one message per line, followed by pending tasks and invalid messages.
+ */
+func (t *Timeline) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Saga %s: %s, %d messages\n", t.SagaId, t.State, len(t.Entries))
+	if len(t.Entries) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SEQ\tMESSAGE\tTASK\tDATA")
+		for _, e := range t.Entries {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", e.Seq, e.Type, e.TaskId, textData(e.messageData()))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if len(t.PendingTasks) > 0 {
+		fmt.Fprintf(w, "%d pending tasks: %v\n", len(t.PendingTasks), t.PendingTasks)
+	}
+	if t.Problems > 0 {
+		fmt.Fprintf(w, "%d invalid messages:\n", t.Problems)
+		for _, e := range t.Entries {
+			if e.Problem != "" {
+				fmt.Fprintf(w, "  %d: %s\n", e.Seq, e.Problem)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the entry's message data, which may only be in Data or RawData if the entry was deserialized.
+func (e *TimelineEntry) messageData() []byte {
+	switch {
+	case e.data != nil:
+		return e.data
+	case e.Data != nil:
+		return e.Data
+	default:
+		return e.RawData
+	}
+}
+
+// Returns data as a string if it's printable text on a single line, otherwise its size.
+func textData(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) {
+			return fmt.Sprintf("<%d bytes>", len(data))
+		}
+	}
+	if len(data) > maxTextDataLen {
+		return fmt.Sprintf("%s... <%d bytes>", data[:maxTextDataLen], len(data))
+	}
+	return string(data)
+}
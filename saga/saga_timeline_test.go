@@ -0,0 +1,69 @@
+package saga
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTimeline(t *testing.T) {
+	msgs := []SagaMessage{
+		MakeStartSagaMessage("job1", []byte(`{"tasks":2}`)),
+		MakeStartTaskMessage("job1", "task1", nil),
+		MakeEndTaskMessage("job1", "task1", []byte{0, 1, 2}),
+		MakeEndTaskMessage("job1", "task2", nil),
+		MakeStartTaskMessage("job1", "task2", nil),
+	}
+	tl := MakeTimeline("job1", msgs, true)
+	if tl.State != TimelineInProgress || len(tl.Entries) != 5 {
+		t.Fatalf("Expected 5 entries for in progress saga, got %+v", tl)
+	}
+	if tl.Problems != 1 || tl.Entries[3].Problem == "" {
+		t.Fatalf("Expected EndTask before StartTask to be invalid, got %+v", tl.Entries)
+	}
+	if len(tl.PendingTasks) != 1 || tl.PendingTasks[0] != "task2" {
+		t.Fatalf("Expected task2 pending, got %v", tl.PendingTasks)
+	}
+	if string(tl.Entries[0].Data) != `{"tasks":2}` || len(tl.Entries[2].RawData) != 3 {
+		t.Fatalf("Expected JSON and raw data, got %+v", tl.Entries)
+	}
+	if _, err := json.Marshal(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := tl.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []string{"Saga job1: In Progress, 5 messages", `{"tasks":2}`, "<3 bytes>", "1 pending tasks: [task2]", "  4: "} {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("Expected text to contain %q, got:\n%s", e, b.String())
+		}
+	}
+}
+
+func TestTimelineAborted(t *testing.T) {
+	msgs := []SagaMessage{
+		MakeStartSagaMessage("job1", nil),
+		MakeStartTaskMessage("job1", "task1", nil),
+		MakeStartTaskMessage("job1", "task2", nil),
+		MakeAbortSagaMessage("job1"),
+		MakeStartCompTaskMessage("job1", "task1", nil),
+		MakeEndCompTaskMessage("job1", "task1", nil),
+	}
+	tl := MakeTimeline("job1", msgs, false)
+	if tl.State != TimelineAborted || tl.Problems != 0 {
+		t.Fatalf("Expected valid aborted saga, got %+v", tl)
+	}
+	if len(tl.PendingTasks) != 1 || tl.PendingTasks[0] != "task2" {
+		t.Fatalf("Expected task2 pending compensation, got %v", tl.PendingTasks)
+	}
+
+	if tl := MakeTimeline("job2", nil, false); tl.State != TimelineNotFound {
+		t.Fatalf("Expected unknown saga not to be found, got %+v", tl)
+	}
+	if tl := MakeTimeline("job1", msgs[1:], false); tl.State != TimelineNotFound || tl.Problems != len(msgs)-1 {
+		t.Fatalf("Expected messages without StartSaga to be invalid, got %+v", tl)
+	}
+}
@@ -0,0 +1,69 @@
+package saga
+
+import (
+	"sync"
+)
+
+// Notifies watchers of a saga each time a message is logged for it, so they can follow its
+// progress without polling the log.
+type sagaWatchers struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan struct{}]bool // sagaId -> watcher channels
+}
+
+func newSagaWatchers() *sagaWatchers {
+	return &sagaWatchers{watchers: make(map[string]map[chan struct{}]bool)}
+}
+
+// Returns a channel that receives after messages are logged for the saga, and a func to stop watching.
+// Notifications are coalesced, a receive means at least one message was logged since the last one.
+func (w *sagaWatchers) watch(sagaId string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers[sagaId] == nil {
+		w.watchers[sagaId] = make(map[chan struct{}]bool)
+	}
+	w.watchers[sagaId][ch] = true
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers[sagaId], ch)
+		if len(w.watchers[sagaId]) == 0 {
+			delete(w.watchers, sagaId)
+		}
+	}
+}
+
+func (w *sagaWatchers) notify(sagaId string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.watchers[sagaId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// A SagaLog notifying watchers of the messages successfully logged to the wrapped log.
+type watchedSagaLog struct {
+	SagaLog
+	watchers *sagaWatchers
+}
+
+func (l *watchedSagaLog) StartSaga(sagaId string, job []byte) error {
+	if err := l.SagaLog.StartSaga(sagaId, job); err != nil {
+		return err
+	}
+	l.watchers.notify(sagaId)
+	return nil
+}
+
+func (l *watchedSagaLog) LogMessage(message SagaMessage) error {
+	if err := l.SagaLog.LogMessage(message); err != nil {
+		return err
+	}
+	l.watchers.notify(message.SagaId)
+	return nil
+}
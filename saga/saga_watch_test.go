@@ -0,0 +1,48 @@
+package saga
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestWatchedSagaLog(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
+	sagaLogMock.EXPECT().LogMessage(MakeEndTaskMessage("testSaga", "task1", nil))
+	sagaLogMock.EXPECT().LogMessage(MakeEndSagaMessage("testSaga")).Return(errors.New("test error"))
+	sagaLogMock.EXPECT().StartSaga("otherSaga", nil)
+
+	watchers := newSagaWatchers()
+	log := &watchedSagaLog{SagaLog: sagaLogMock, watchers: watchers}
+	ch, stop := watchers.watch("testSaga")
+
+	log.StartSaga("testSaga", nil)
+	select {
+	case <-ch:
+	default:
+		t.Fatal("Expected a notification when the saga started")
+	}
+
+	// Notifications are coalesced, and not sent for failed messages or other sagas.
+	log.LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
+	log.LogMessage(MakeEndTaskMessage("testSaga", "task1", nil))
+	<-ch
+	log.LogMessage(MakeEndSagaMessage("testSaga"))
+	log.StartSaga("otherSaga", nil)
+	select {
+	case <-ch:
+		t.Fatal("Unexpected notification")
+	default:
+	}
+
+	stop()
+	if len(watchers.watchers) != 0 {
+		t.Fatalf("Expected no watchers after stopping, got %v", watchers.watchers)
+	}
+}
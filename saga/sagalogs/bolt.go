@@ -0,0 +1,165 @@
+package sagalogs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// How long to wait for another process to release the database file before failing to open it.
+const DefaultBoltOpenTimeout = 10 * time.Second
+
+// Bucket of per-saga buckets, each holding that saga's messages keyed by a big endian sequence number.
+var boltSagasBucket = []byte("sagas")
+
+// Bucket whose keys are the sagaIds that haven't ended, so GetActiveSagas doesn't scan every saga.
+var boltActiveBucket = []byte("active")
+
+// SagaLog persisted to a BoltDB file on the local disk. Durable across restarts but not machine failure,
+// without running a separate database. Messages are encoded with saga.EncodeMessage.
+//
+// Each write returns only once it's committed, and BoltDB's copy-on-write pages leave the file consistent
+// if the process crashes partway through a commit, so recovery is just reopening the file. With a batch
+// delay, concurrent writes are committed together so a batch costs a single fsync, but every write waits
+// up to the delay for others to join it, even when there are none.
+type boltSagaLog struct {
+	db    *bolt.DB
+	batch bool
+}
+
+// Opens or creates a BoltDB SagaLog in the given file, creating its directory if needed.
+// If maxBatchDelay is zero each write is committed on its own, otherwise writes made within
+// maxBatchDelay of each other are committed together.
+func MakeBoltSagaLog(fileName string, maxBatchDelay time.Duration) (*boltSagaLog, error) {
+	if err := os.MkdirAll(path.Dir(fileName), os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(fileName, 0600, &bolt.Options{Timeout: DefaultBoltOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("Error opening SagaLog %s: %v", fileName, err)
+	}
+	if maxBatchDelay > 0 {
+		db.MaxBatchDelay = maxBatchDelay
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltSagasBucket, boltActiveBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltSagaLog{db: db, batch: maxBatchDelay > 0}, nil
+}
+
+// Commits fn, batched with concurrent writes if the log has a batch delay.
+// A batched fn may be run more than once if another in its batch fails.
+func (log *boltSagaLog) update(fn func(*bolt.Tx) error) error {
+	if log.batch {
+		return log.db.Batch(fn)
+	}
+	return log.db.Update(fn)
+}
+
+// Log a Start Saga Message message to the log.
+// Returns an error if it fails.
+func (log *boltSagaLog) StartSaga(sagaId string, job []byte) error {
+	return log.update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(boltSagasBucket).CreateBucketIfNotExists([]byte(sagaId))
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltActiveBucket).Put([]byte(sagaId), []byte{}); err != nil {
+			return err
+		}
+		return appendBoltRecord(b, saga.MakeStartSagaMessage(sagaId, job))
+	})
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails.
+func (log *boltSagaLog) LogMessage(message saga.SagaMessage) error {
+	return log.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltSagasBucket).Bucket([]byte(message.SagaId))
+		if b == nil {
+			return fmt.Errorf("Cannot log %s for saga %s, it wasn't started", message.MsgType, message.SagaId)
+		}
+		if message.MsgType == saga.EndSaga {
+			if err := tx.Bucket(boltActiveBucket).Delete([]byte(message.SagaId)); err != nil {
+				return err
+			}
+		}
+		return appendBoltRecord(b, message)
+	})
+}
+
+// Appends msg to a saga's bucket under the bucket's next sequence number.
+func appendBoltRecord(b *bolt.Bucket, msg saga.SagaMessage) error {
+	record, err := saga.EncodeMessage(msg, "")
+	if err != nil {
+		return err
+	}
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return b.Put(key, record)
+}
+
+// Returns all of the messages logged so far for the
+// specified saga.
+func (log *boltSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	var msgs []saga.SagaMessage
+	err := log.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltSagasBucket).Bucket([]byte(sagaId))
+		if b == nil {
+			return nil
+		}
+		msgs = make([]saga.SagaMessage, 0)
+		// Keys are big endian, so ForEach visits messages in the order they were logged.
+		return b.ForEach(func(k, v []byte) error {
+			msg, _, err := saga.DecodeMessage(v)
+			if err != nil {
+				return saga.NewCorruptedSagaLogError(sagaId, fmt.Sprintf("Error Decoding SagaLog record: %v", err))
+			}
+			msgs = append(msgs, msg)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Returns the sagaIds that were started and haven't ended.
+func (log *boltSagaLog) GetActiveSagas() ([]string, error) {
+	sagaIds := []string{}
+	err := log.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltActiveBucket).ForEach(func(k, v []byte) error {
+			sagaIds = append(sagaIds, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sagaIds, nil
+}
+
+// Closes the database file, after which the log can't be used.
+func (log *boltSagaLog) Close() error {
+	return log.db.Close()
+}
@@ -0,0 +1,87 @@
+package sagalogs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/saga"
+)
+
+func TestBoltSagaLogRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bolt_sagalog_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := path.Join(dir, "sagas", "sagalog.db")
+
+	slog, err := MakeBoltSagaLog(fileName, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error opening SagaLog: %v", err)
+	}
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("saga1", []byte("job")),
+		saga.MakeStartTaskMessage("saga1", "task1", nil),
+		saga.MakeEndTaskMessage("saga1", "task1", []byte("result")),
+	}
+	if err := slog.StartSaga("saga1", []byte("job")); err != nil {
+		t.Fatalf("Unexpected error starting saga: %v", err)
+	}
+	// Writes made concurrently are committed in batches.
+	var wg sync.WaitGroup
+	for _, id := range []string{"saga2", "saga3", "saga4"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if err := slog.StartSaga(id, nil); err != nil {
+				t.Errorf("Unexpected error starting saga: %v", err)
+			}
+		}(id)
+	}
+	wg.Wait()
+	for _, msg := range expected[1:] {
+		if err := slog.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected error logging message: %v", err)
+		}
+	}
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("saga2")); err != nil {
+		t.Fatalf("Unexpected error ending saga: %v", err)
+	}
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("unknown")); err == nil {
+		t.Fatal("Expected an error logging a message for a saga that wasn't started")
+	}
+	slog.Close()
+
+	// Reopening the file recovers the messages and active sagas, without batching writes this time.
+	slog, err = MakeBoltSagaLog(fileName, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error reopening SagaLog: %v", err)
+	}
+	defer slog.Close()
+	msgs, err := slog.GetMessages("saga1")
+	if err != nil {
+		t.Fatalf("Unexpected error getting messages: %v", err)
+	}
+	if len(msgs) != len(expected) {
+		t.Fatalf("Expected messages %+v, got %+v", expected, msgs)
+	}
+	for i, msg := range msgs {
+		if msg.MsgType != expected[i].MsgType || msg.TaskId != expected[i].TaskId ||
+			!bytes.Equal(msg.Data, expected[i].Data) {
+			t.Fatalf("Expected message %d to be %+v, got %+v", i, expected[i], msg)
+		}
+	}
+	if msgs, err := slog.GetMessages("unknown"); err != nil || msgs != nil {
+		t.Fatalf("Expected no messages for a saga that wasn't started, got %+v: %v", msgs, err)
+	}
+	for id, active := range map[string]bool{"saga1": true, "saga2": false, "saga3": true, "saga4": true} {
+		if isSagaInActiveList(id, slog) != active {
+			t.Fatalf("Expected %s active=%t", id, active)
+		}
+	}
+}
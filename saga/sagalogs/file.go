@@ -2,18 +2,32 @@ package sagalogs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/saga"
 )
 
 // Writes Saga Log to file system.  Not durable beyond machine failure
 // Sagas are stored in a directory.  Each saga has a corresponding directory
-// each saga directory contains a log file, and associated data files.
+// each saga directory contains a log file, and for legacy logs associated data files.
+
+// Log files start with binaryLogHeader followed by messages encoded with
+// saga.EncodeMessage, each preceded by its length as a varint.
+
+// Log files without the header are in the legacy text format below, which is
+// still read and appended to. Migrate rewrites them in the binary format, see MigrateAll.
 
 // StartSaga Message
 // StartSaga \n
@@ -29,9 +43,22 @@ import (
 // taskId \n
 // taskData filename \n
 
+// DeadLetterTask Message
+// DeadLetterTask \n
+// taskId \n
+// taskData filename \n
+
 // AbortSaga Message
 // AbortSaga \n
 
+// PauseSaga Message
+// PauseSaga \n
+// pauseData filename \n
+
+// ResumeSaga Message
+// ResumeSaga \n
+// resumeData filename \n
+
 // StartCompTask Message
 // StartCompTask \n
 // taskId \n
@@ -44,21 +71,53 @@ import (
 
 // EndSaga Message
 // EndSaga
+
+// Task messages with an attempt number are preceded by
+// Attempt \n
+// attempt \n
 type fileSagaLog struct {
 	dirName string
+
+	// Guards writes to the log files and legacy, so a saga isn't migrated while it's being logged to.
+	mu sync.Mutex
+	// Sagas whose log files are in the legacy text format, found when the log is made.
+	// Sagas started since are always in the binary format.
+	legacy map[string]bool
 }
 
+// Precedes the attempt number of a task message.
+const attemptLine = "Attempt"
+
+// First line of log files in the binary format.
+const binaryLogHeader = "ScootSagaLog protobuf\n"
+
 // Creates a FileSagaLog with files stored at the specified directory
 // If the directory does not exist it will create it.
+// Sagas in the legacy text format are left as they are, see MigrateAll.
 func MakeFileSagaLog(dirName string) (*fileSagaLog, error) {
 
 	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
 		return nil, err
 	}
 
-	return &fileSagaLog{
+	slog := &fileSagaLog{
 		dirName: dirName,
-	}, nil
+		legacy:  make(map[string]bool),
+	}
+	sagaIds, err := slog.GetActiveSagas()
+	if err != nil {
+		return nil, err
+	}
+	for _, sagaId := range sagaIds {
+		legacy, err := isLegacyLog(slog.getSagaLogFileName(sagaId))
+		if err != nil {
+			return nil, err
+		}
+		if legacy {
+			slog.legacy[sagaId] = true
+		}
+	}
+	return slog, nil
 }
 
 // all files for a saga log are stored in a directory named
@@ -103,6 +162,8 @@ func (log *fileSagaLog) createJobDataFileName(sagaId string) string {
 // Log a Start Saga Message message to the log.
 // Returns an error if it fails.
 func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
 
 	// Create directory for this saga if it doesn't exist
 	dirName := log.getSagaDirectory(sagaId)
@@ -117,6 +178,11 @@ func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
 		}
 	}
 
+	logFileName := log.getSagaLogFileName(sagaId)
+	if !log.legacy[sagaId] {
+		return appendRecords(logFileName, os.O_CREATE, saga.MakeStartSagaMessage(sagaId, job))
+	}
+
 	// Write Data File
 	dataFileName := log.createJobDataFileName(sagaId)
 	err := ioutil.WriteFile(dataFileName, job, os.ModePerm)
@@ -127,7 +193,6 @@ func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
 	// Write Message to Log File
 	var logFile *os.File
 	defer logFile.Close()
-	logFileName := log.getSagaLogFileName(sagaId)
 
 	// Append StartSaga message to the log
 	// Get File Handle for Saga Create it if it doesn't exist
@@ -164,7 +229,14 @@ func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
 // Update the State of the Saga by Logging a message.
 // Returns an error if it fails.
 func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
 	fileName := log.getSagaLogFileName(message.SagaId)
+	if !log.legacy[message.SagaId] {
+		// Saga wasn't started if the file doesn't exist
+		return appendRecords(fileName, 0, message)
+	}
 
 	// Get file handle for Saga if it doesn't exist return error,
 	// Saga wasn't started.  OpenFile so we can append to it
@@ -174,12 +246,17 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 		return err
 	}
 
-	// Write MessageType
-	msg := []byte(fmt.Sprintf("%v\n", message.MsgType.String()))
+	// Write Attempt, if set, then MessageType
+	msg := []byte{}
+	if message.Attempt != 0 {
+		msg = append(msg, []byte(fmt.Sprintf("%v\n%v\n", attemptLine, message.Attempt))...)
+	}
+	msg = append(msg, []byte(fmt.Sprintf("%v\n", message.MsgType.String()))...)
 
 	// If its a Task Type Write the TaskId and Data
 	if message.MsgType == saga.StartTask ||
 		message.MsgType == saga.EndTask ||
+		message.MsgType == saga.DeadLetterTask ||
 		message.MsgType == saga.StartCompTask ||
 		message.MsgType == saga.EndCompTask {
 
@@ -198,6 +275,16 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 				dataFileName))...)
 	}
 
+	// If it's a Pause or Resume write its Data, ex: who paused the saga and why
+	if message.MsgType == saga.PauseSaga || message.MsgType == saga.ResumeSaga {
+		dataFileName := log.createTaskDataFileName(message.SagaId, "", message.MsgType)
+		err = ioutil.WriteFile(dataFileName, message.Data, os.ModePerm)
+		if err != nil {
+			return err
+		}
+		msg = append(msg, []byte(fmt.Sprintf("%v\n", dataFileName))...)
+	}
+
 	_, err = logFile.Write(msg)
 	if err != nil {
 		return err
@@ -210,6 +297,13 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 // Returns all of the messages logged so far for the
 // specified saga.
 func (log *fileSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	return log.getMessages(sagaId)
+}
+
+// Like GetMessages, the caller must hold the lock.
+func (log *fileSagaLog) getMessages(sagaId string) ([]saga.SagaMessage, error) {
 	fileName := log.getSagaLogFileName(sagaId)
 
 	// check if this saga actually exists
@@ -217,18 +311,20 @@ func (log *fileSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
 		return nil, nil
 	}
 
-	logFile, err := os.Open(fileName)
+	contents, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		return nil, err
 	}
-	defer logFile.Close()
+	if bytes.HasPrefix(contents, []byte(binaryLogHeader)) {
+		return decodeRecords(sagaId, contents[len(binaryLogHeader):])
+	}
 
 	msgs := make([]saga.SagaMessage, 0)
-	scanner := bufio.NewScanner(logFile)
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
 	nextToken := scanner.Scan()
 
 	for nextToken == true {
-		msg, err := parseMessage(sagaId, scanner)
+		msg, err := parseMessage(sagaId, 0, scanner)
 		if err != nil {
 			return nil, err
 		}
@@ -241,11 +337,36 @@ func (log *fileSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
 }
 
 // Helper Function that Parses a SagaMessage.  Returns a message if succesfully parsed
-// Returns and error otherwise
-func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, error) {
+// Returns and error otherwise.  attempt is the attempt line preceding a task message, zero if there was none.
+func parseMessage(sagaId string, attempt int, scanner *bufio.Scanner) (saga.SagaMessage, error) {
 
 	switch scanner.Text() {
 
+	// Parse the Attempt of the following Task Message
+	case attemptLine:
+		if ok := scanner.Scan(); !ok {
+			return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+				sagaId,
+				fmt.Sprintf("Error Parsing SagaLog expected attempt.  Error: %v",
+					createUnexpectedScanEndMsg(scanner)),
+			)
+		}
+		attempt, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+				sagaId,
+				fmt.Sprintf("Error Parsing SagaLog invalid attempt %v", scanner.Text()),
+			)
+		}
+		if ok := scanner.Scan(); !ok {
+			return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+				sagaId,
+				fmt.Sprintf("Error Parsing SagaLog expected Task message after attempt.  Error: %v",
+					createUnexpectedScanEndMsg(scanner)),
+			)
+		}
+		return parseMessage(sagaId, attempt, scanner)
+
 	// Parse Start Saga Message
 	case saga.StartSaga.String():
 		if ok := scanner.Scan(); !ok {
@@ -275,13 +396,29 @@ func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, erro
 	case saga.AbortSaga.String():
 		return saga.MakeAbortSagaMessage(sagaId), nil
 
+		// Parse Pause Saga Message
+	case saga.PauseSaga.String():
+		data, err := parseData(sagaId, scanner)
+		if err != nil {
+			return saga.SagaMessage{}, err
+		}
+		return saga.MakePauseSagaMessage(sagaId, data), nil
+
+		// Parse Resume Saga Message
+	case saga.ResumeSaga.String():
+		data, err := parseData(sagaId, scanner)
+		if err != nil {
+			return saga.SagaMessage{}, err
+		}
+		return saga.MakeResumeSagaMessage(sagaId, data), nil
+
 		// Parse Start Task Message
 	case saga.StartTask.String():
 		taskId, data, err := parseTask(sagaId, scanner)
 		if err != nil {
 			return saga.SagaMessage{}, err
 		}
-		return saga.MakeStartTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeStartTaskAttemptMessage(sagaId, taskId, attempt, data), nil
 
 		// Parse End Task Message
 	case saga.EndTask.String():
@@ -289,7 +426,15 @@ func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, erro
 		if err != nil {
 			return saga.SagaMessage{}, err
 		}
-		return saga.MakeEndTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeEndTaskAttemptMessage(sagaId, taskId, attempt, data), nil
+
+		// Parse Dead Letter Task Message
+	case saga.DeadLetterTask.String():
+		taskId, data, err := parseTask(sagaId, scanner)
+		if err != nil {
+			return saga.SagaMessage{}, err
+		}
+		return saga.MakeDeadLetterTaskMessage(sagaId, taskId, attempt, data), nil
 
 		// Parse Start Comp Task Message
 	case saga.StartCompTask.String():
@@ -316,7 +461,7 @@ func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, erro
 	}
 }
 
-// Helper function that parses a task message, StartTask, EndTask, StartCompTask,
+// Helper function that parses a task message, StartTask, EndTask, DeadLetterTask, StartCompTask,
 // EndCompTasks.  Message is of structure
 // line1: MessageType
 // line2: TaskId
@@ -333,8 +478,18 @@ func parseTask(sagaId string, scanner *bufio.Scanner) (string, []byte, error) {
 			)
 	}
 	taskId := scanner.Text()
+	data, err := parseData(sagaId, scanner)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return taskId, data, nil
+}
+
+// Helper function that parses the data filename on the next line of a message and reads the data.
+func parseData(sagaId string, scanner *bufio.Scanner) ([]byte, error) {
 	if ok := scanner.Scan(); !ok {
-		return "", nil,
+		return nil,
 			saga.NewCorruptedSagaLogError(
 				sagaId,
 				fmt.Sprintf("Error Parsing SagaLog expected Data, Error: %v",
@@ -345,14 +500,142 @@ func parseTask(sagaId string, scanner *bufio.Scanner) (string, []byte, error) {
 
 	data, err := ioutil.ReadFile(dataFileName)
 	if err != nil {
-		return "", nil,
+		return nil,
 			saga.NewCorruptedSagaLogError(
 				sagaId,
 				fmt.Sprintf("Error Reading DataFile %v, Error: %v", dataFileName, err),
 			)
 	}
+	return data, nil
+}
 
-	return taskId, data, nil
+// Returns true if the log file exists and is in the legacy text format.
+func isLegacyLog(fileName string) (bool, error) {
+	logFile, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer logFile.Close()
+
+	header := make([]byte, len(binaryLogHeader))
+	n, err := io.ReadFull(logFile, header)
+	if n == 0 && err == io.EOF {
+		// An empty file, ex: a saga whose StartSaga failed to write.
+		return false, nil
+	}
+	return string(header[:n]) != binaryLogHeader, nil
+}
+
+// Appends msgs to a binary log file, writing the header if the file is empty.
+// flag is added to the flags used to open the file, ex: os.O_CREATE.
+func appendRecords(fileName string, flag int, msgs ...saga.SagaMessage) error {
+	logFile, err := os.OpenFile(fileName, os.O_APPEND|os.O_RDWR|flag, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	info, err := logFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	var buf []byte
+	if info.Size() == 0 {
+		buf = []byte(binaryLogHeader)
+	}
+	for _, msg := range msgs {
+		record, err := saga.EncodeMessage(msg, "")
+		if err != nil {
+			return err
+		}
+		buf = append(buf, proto.EncodeVarint(uint64(len(record)))...)
+		buf = append(buf, record...)
+	}
+	if _, err := logFile.Write(buf); err != nil {
+		return err
+	}
+	return logFile.Sync()
+}
+
+// Decodes the length prefixed messages of a binary log file, following the header.
+func decodeRecords(sagaId string, b []byte) ([]saga.SagaMessage, error) {
+	msgs := make([]saga.SagaMessage, 0)
+	for len(b) > 0 {
+		size, n := proto.DecodeVarint(b)
+		if n == 0 || size > uint64(len(b)-n) {
+			return nil, saga.NewCorruptedSagaLogError(sagaId, "Error Parsing SagaLog truncated record")
+		}
+		msg, _, err := saga.DecodeMessage(b[n : n+int(size)])
+		if err != nil {
+			return nil, saga.NewCorruptedSagaLogError(sagaId, fmt.Sprintf("Error Decoding SagaLog record: %v", err))
+		}
+		msgs = append(msgs, msg)
+		b = b[n+int(size):]
+	}
+	return msgs, nil
+}
+
+// Migrates every saga in the legacy text format, see Migrate. Meant to be called once at startup,
+// before the log is used. Sagas that fail to migrate are logged and left in the legacy format.
+// Returns the number of sagas migrated.
+func (log *fileSagaLog) MigrateAll() int {
+	log.mu.Lock()
+	sagaIds := make([]string, 0, len(log.legacy))
+	for sagaId := range log.legacy {
+		sagaIds = append(sagaIds, sagaId)
+	}
+	log.mu.Unlock()
+
+	migrated := 0
+	for _, sagaId := range sagaIds {
+		if ok, err := log.Migrate(sagaId); err != nil {
+			logrus.Errorf("Error migrating saga %s to the binary SagaLog format, leaving it as is: %v", sagaId, err)
+		} else if ok {
+			migrated++
+		}
+	}
+	return migrated
+}
+
+// Rewrites a saga logged in the legacy text format in the binary format and removes its data files.
+// Returns false if the saga was already in the binary format or doesn't exist.
+func (log *fileSagaLog) Migrate(sagaId string) (bool, error) {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if !log.legacy[sagaId] {
+		return false, nil
+	}
+	fileName := log.getSagaLogFileName(sagaId)
+	msgs, err := log.getMessages(sagaId)
+	if err != nil {
+		return false, err
+	}
+
+	// Write the new log alongside the old one then swap it in, so a failure leaves the old log intact.
+	tmpName := fileName + ".migrating"
+	os.Remove(tmpName)
+	if err := appendRecords(tmpName, os.O_CREATE, msgs...); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return false, err
+	}
+	delete(log.legacy, sagaId)
+
+	files, err := ioutil.ReadDir(log.getSagaDirectory(sagaId))
+	if err != nil {
+		return true, err
+	}
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), "StartSagaData_") || strings.Contains(f.Name(), "_data_") {
+			if err := os.Remove(path.Join(log.getSagaDirectory(sagaId), f.Name())); err != nil {
+				return true, err
+			}
+		}
+	}
+	return true, nil
 }
 
 func createUnexpectedScanEndMsg(scanner *bufio.Scanner) string {
@@ -2,6 +2,7 @@ package sagalogs
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
 	"path"
 	"reflect"
@@ -157,6 +158,134 @@ func TestFullSaga(t *testing.T) {
 	}
 }
 
+func TestTaskAttempts(t *testing.T) {
+	defer testCleanup(t)
+
+	dirName := getDirName()
+	sagaId := "attemptsaga"
+
+	slog, _ := MakeFileSagaLog(dirName)
+	slog.StartSaga(sagaId, nil)
+
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartTaskAttemptMessage(sagaId, "task1", 1, []byte("run task 1")),
+		saga.MakeStartTaskAttemptMessage(sagaId, "task1", 12, []byte("retry task 1")),
+		saga.MakeEndTaskAttemptMessage(sagaId, "task1", 12, []byte("success")),
+	}
+	for _, msg := range loggedMsgs {
+		if err := slog.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
+	}
+
+	rtnMsgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Fatalf("Unexpected Error returned from GetMessages. %v", err)
+	}
+	if !reflect.DeepEqual(rtnMsgs[1:], loggedMsgs) {
+		t.Errorf("Expected Logged Messages and Returned Messages to be Equal.  Expected %v, Actual %v",
+			loggedMsgs, rtnMsgs[1:])
+	}
+}
+
+func TestMigrateLegacySaga(t *testing.T) {
+	defer testCleanup(t)
+
+	dirName := getDirName()
+	sagaId := "legacysaga"
+	sagaDir := path.Join(dirName, sagaId)
+	os.MkdirAll(sagaDir, os.ModePerm)
+
+	// A saga logged in the legacy text format, with data in separate files.
+	jobFile := path.Join(sagaDir, "StartSagaData_1")
+	taskFile := path.Join(sagaDir, "Start Task_task1_data_1")
+	ioutil.WriteFile(jobFile, []byte("job"), os.ModePerm)
+	ioutil.WriteFile(taskFile, []byte("run task 1"), os.ModePerm)
+	ioutil.WriteFile(path.Join(sagaDir, "log"),
+		[]byte("Start Saga\n"+jobFile+"\nStart Task\ntask1\n"+taskFile+"\n"), os.ModePerm)
+
+	slog, _ := MakeFileSagaLog(dirName)
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage(sagaId, []byte("job")),
+		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
+	}
+	if msgs, err := slog.GetMessages(sagaId); err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Fatalf("Expected legacy messages %+v, got %+v, %v", expected, msgs, err)
+	}
+
+	// Legacy logs are appended to in the legacy format until they're migrated, keeping message data.
+	pause := saga.MakePauseSagaMessage(sagaId, []byte("paused by user1"))
+	if err := slog.LogMessage(pause); err != nil {
+		t.Fatalf("Unexpected Error Logging Msg: %v", err)
+	}
+	expected = append(expected, pause)
+	if msgs, err := slog.GetMessages(sagaId); err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Fatalf("Expected legacy messages %+v, got %+v, %v", expected, msgs, err)
+	}
+
+	// Including the attempt of task messages.
+	deadLetter := saga.MakeDeadLetterTaskMessage(sagaId, "task1", 3, []byte("lost worker"))
+	if err := slog.LogMessage(deadLetter); err != nil {
+		t.Fatalf("Unexpected Error Logging Msg: %v", err)
+	}
+	expected = append(expected, deadLetter)
+	if msgs, err := slog.GetMessages(sagaId); err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Fatalf("Expected legacy messages %+v, got %+v, %v", expected, msgs, err)
+	}
+
+	migrated, err := slog.Migrate(sagaId)
+	if err != nil || !migrated {
+		t.Fatalf("Expected saga to be migrated, got %t, %v", migrated, err)
+	}
+	if _, err := os.Stat(taskFile); !os.IsNotExist(err) {
+		t.Errorf("Expected data files to be removed, got %v", err)
+	}
+	if migrated, err := slog.Migrate(sagaId); err != nil || migrated {
+		t.Errorf("Expected a migrated saga not to be migrated again, got %t, %v", migrated, err)
+	}
+
+	// Migrated sagas are appended to in the binary format.
+	endTask := saga.MakeEndTaskMessage(sagaId, "task1", []byte("success"))
+	if err := slog.LogMessage(endTask); err != nil {
+		t.Fatalf("Unexpected Error Logging Msg: %v", err)
+	}
+	expected = append(expected, endTask)
+	if msgs, err := slog.GetMessages(sagaId); err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected migrated messages %+v, got %+v, %v", expected, msgs, err)
+	}
+}
+
+func TestMigrateAll(t *testing.T) {
+	defer testCleanup(t)
+
+	dirName := getDirName()
+	slog, _ := MakeFileSagaLog(dirName)
+	if err := slog.StartSaga("binarysaga", []byte("job")); err != nil {
+		t.Fatalf("Unexpected Error Starting Saga: %v", err)
+	}
+
+	sagaDir := path.Join(dirName, "legacysaga")
+	os.MkdirAll(sagaDir, os.ModePerm)
+	jobFile := path.Join(sagaDir, "StartSagaData_1")
+	ioutil.WriteFile(jobFile, []byte("job"), os.ModePerm)
+	ioutil.WriteFile(path.Join(sagaDir, "log"), []byte("Start Saga\n"+jobFile+"\n"), os.ModePerm)
+
+	slog, _ = MakeFileSagaLog(dirName)
+	if migrated := slog.MigrateAll(); migrated != 1 {
+		t.Fatalf("Expected 1 saga to be migrated, got %d", migrated)
+	}
+	if legacy, err := isLegacyLog(path.Join(sagaDir, "log")); err != nil || legacy {
+		t.Fatalf("Expected a binary log, got legacy %t, %v", legacy, err)
+	}
+	expected := []saga.SagaMessage{saga.MakeStartSagaMessage("legacysaga", []byte("job"))}
+	if msgs, err := slog.GetMessages("legacysaga"); err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected migrated messages %+v, got %+v, %v", expected, msgs, err)
+	}
+	if migrated := slog.MigrateAll(); migrated != 0 {
+		t.Errorf("Expected no sagas to be migrated again, got %d", migrated)
+	}
+}
+
 func TestGetMessages_SagaDoesNotExist(t *testing.T) {
 	defer testCleanup(t)
 	dirName := getDirName()
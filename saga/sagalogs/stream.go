@@ -0,0 +1,228 @@
+package sagalogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	uuid "github.com/nu7hatch/gouuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Stream is a partitioned, append-only log such as a Kafka topic.
+// Records appended with the same key must land in the same partition, in the order they were appended.
+type Stream interface {
+	// Returns the ids of all partitions in the stream.
+	Partitions() ([]int32, error)
+
+	// Durably appends value to the partition chosen for key.
+	Append(key []byte, value []byte) error
+
+	// Returns every record currently in the partition, oldest first.
+	ReadPartition(partition int32) ([][]byte, error)
+}
+
+// How many times an append is retried before LogMessage gives up.
+const DefaultStreamAppendAttempts = 3
+
+// How long to wait between append attempts.
+const DefaultStreamAppendBackoff = 500 * time.Millisecond
+
+// Messages are encoded with saga.EncodeMessage, with a record id that is unique per logged
+// message and is reused when an append is retried, so that a message appended more than
+// once (ex: the first attempt timed out but was committed) is only applied once when the
+// stream is replayed.
+//
+// streamRecord is the JSON encoding of messages appended before saga.EncodeMessage was used.
+// It is still read so existing streams can be replayed.
+type streamRecord struct {
+	Id      string
+	SagaId  string
+	MsgType saga.SagaMessageType
+	TaskId  string `json:",omitempty"`
+	Attempt int    `json:",omitempty"`
+	Data    []byte `json:",omitempty"`
+}
+
+// SagaLog backed by a Stream, partitioned by sagaId.
+// The stream is the source of truth: on creation every partition is replayed to rebuild
+// the in-memory index which then serves reads. Only one streamSagaLog should write to a
+// given stream at a time, and messages of one saga must not be logged concurrently,
+// as the saga package ensures, so they're indexed in the order they're appended.
+type streamSagaLog struct {
+	stream   Stream
+	producer string // prefix for record ids, unique per streamSagaLog instance
+	attempts int
+	backoff  time.Duration
+
+	mutex sync.RWMutex
+	seq   int64
+	sagas map[string][]saga.SagaMessage
+	ended map[string]bool
+}
+
+// Creates a SagaLog backed by stream, replaying it to recover existing sagas.
+func MakeStreamSagaLog(stream Stream) (saga.SagaLog, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	slog := &streamSagaLog{
+		stream:   stream,
+		producer: id.String(),
+		attempts: DefaultStreamAppendAttempts,
+		backoff:  DefaultStreamAppendBackoff,
+		sagas:    make(map[string][]saga.SagaMessage),
+		ended:    make(map[string]bool),
+	}
+	if err := slog.recover(); err != nil {
+		return nil, err
+	}
+	return slog, nil
+}
+
+// Replays every partition of the stream into the in-memory index.
+// Retried appends of a record share its key and so its partition, so duplicates are dropped
+// by the record ids seen in the partition being replayed, which are forgotten after it.
+func (slog *streamSagaLog) recover() error {
+	partitions, err := slog.stream.Partitions()
+	if err != nil {
+		return err
+	}
+	numRecords, numDups := 0, 0
+	for _, p := range partitions {
+		values, err := slog.stream.ReadPartition(p)
+		if err != nil {
+			return err
+		}
+		seen := map[string]bool{}
+		for _, v := range values {
+			id, msg, err := decodeStreamRecord(v)
+			if err != nil {
+				return saga.NewCorruptedSagaLogError("", fmt.Sprintf("Error decoding record in partition %d: %v", p, err))
+			}
+			numRecords++
+			if seen[id] {
+				numDups++
+				continue
+			}
+			seen[id] = true
+			slog.apply(msg)
+		}
+	}
+	log.WithFields(
+		log.Fields{
+			"partitions": len(partitions),
+			"records":    numRecords,
+			"duplicates": numDups,
+			"sagas":      len(slog.sagas),
+		}).Info("Recovered stream saga log")
+	return nil
+}
+
+// Returns the record id and message of a record, in either the protobuf or legacy JSON encoding.
+func decodeStreamRecord(value []byte) (string, saga.SagaMessage, error) {
+	if len(value) > 0 && value[0] == '{' {
+		var rec streamRecord
+		if err := json.Unmarshal(value, &rec); err != nil {
+			return "", saga.SagaMessage{}, err
+		}
+		msg := saga.SagaMessage{SagaId: rec.SagaId, MsgType: rec.MsgType, TaskId: rec.TaskId, Attempt: rec.Attempt, Data: rec.Data}
+		return rec.Id, msg, nil
+	}
+	msg, id, err := saga.DecodeMessage(value)
+	return id, msg, err
+}
+
+// Adds the message to the index. Caller must hold the write lock.
+func (slog *streamSagaLog) apply(msg saga.SagaMessage) {
+	if msg.MsgType == saga.StartSaga {
+		slog.sagas[msg.SagaId] = []saga.SagaMessage{}
+		delete(slog.ended, msg.SagaId)
+	}
+	slog.sagas[msg.SagaId] = append(slog.sagas[msg.SagaId], msg)
+	if msg.MsgType == saga.EndSaga {
+		slog.ended[msg.SagaId] = true
+	}
+}
+
+// Appends msg to the stream, retrying with the same record id, then adds it to the index.
+// The lock isn't held while appending, so reads and other sagas aren't held up by a slow or failing stream.
+func (slog *streamSagaLog) append(msg saga.SagaMessage) error {
+	slog.mutex.Lock()
+	if _, ok := slog.sagas[msg.SagaId]; !ok && msg.MsgType != saga.StartSaga {
+		slog.mutex.Unlock()
+		return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s does not exist in the Log", msg.SagaId))
+	}
+	slog.seq++
+	id := fmt.Sprintf("%s-%d", slog.producer, slog.seq)
+	slog.mutex.Unlock()
+
+	value, err := saga.EncodeMessage(msg, id)
+	if err != nil {
+		return err
+	}
+
+	for i := 1; ; i++ {
+		err = slog.stream.Append([]byte(msg.SagaId), value)
+		if err == nil {
+			break
+		}
+		log.WithFields(
+			log.Fields{
+				"sagaId":  msg.SagaId,
+				"msgType": msg.MsgType,
+				"taskId":  msg.TaskId,
+				"attempt": i,
+				"err":     err,
+			}).Error("Failed to append saga message to stream")
+		if i >= slog.attempts {
+			return saga.NewInternalLogError(fmt.Sprintf("Failed to append to stream: %v", err))
+		}
+		time.Sleep(slog.backoff)
+	}
+
+	slog.mutex.Lock()
+	defer slog.mutex.Unlock()
+	slog.apply(msg)
+	return nil
+}
+
+// Log a Start Saga Message message to the log.
+func (slog *streamSagaLog) StartSaga(sagaId string, job []byte) error {
+	return slog.append(saga.MakeStartSagaMessage(sagaId, job))
+}
+
+// Log a SagaMessage to an existing Saga in the log.
+func (slog *streamSagaLog) LogMessage(msg saga.SagaMessage) error {
+	return slog.append(msg)
+}
+
+// Returns all of the messages logged so far for the specified saga.
+func (slog *streamSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	slog.mutex.RLock()
+	defer slog.mutex.RUnlock()
+
+	msgs, ok := slog.sagas[sagaId]
+	if !ok {
+		return nil, nil
+	}
+	return append([]saga.SagaMessage{}, msgs...), nil
+}
+
+// Returns the ids of all sagas that have not ended.
+func (slog *streamSagaLog) GetActiveSagas() ([]string, error) {
+	slog.mutex.RLock()
+	defer slog.mutex.RUnlock()
+
+	ids := []string{}
+	for id := range slog.sagas {
+		if !slog.ended[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
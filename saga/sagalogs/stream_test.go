@@ -0,0 +1,179 @@
+package sagalogs
+
+import (
+	"errors"
+	"hash/fnv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// In memory Stream, partitioned by key hash like Kafka's default partitioner.
+type fakeStream struct {
+	partitions [][][]byte
+	failNext   int  // number of upcoming appends that fail
+	commitFail bool // if true failed appends are still committed, as if the ack was lost
+}
+
+func newFakeStream(numPartitions int) *fakeStream {
+	return &fakeStream{partitions: make([][][]byte, numPartitions)}
+}
+
+func (f *fakeStream) Partitions() ([]int32, error) {
+	ids := []int32{}
+	for i := range f.partitions {
+		ids = append(ids, int32(i))
+	}
+	return ids, nil
+}
+
+func (f *fakeStream) Append(key []byte, value []byte) error {
+	h := fnv.New32a()
+	h.Write(key)
+	p := h.Sum32() % uint32(len(f.partitions))
+	if f.failNext > 0 {
+		f.failNext--
+		if f.commitFail {
+			f.partitions[p] = append(f.partitions[p], value)
+		}
+		return errors.New("append failed")
+	}
+	f.partitions[p] = append(f.partitions[p], value)
+	return nil
+}
+
+func (f *fakeStream) ReadPartition(partition int32) ([][]byte, error) {
+	return f.partitions[partition], nil
+}
+
+func makeTestStreamSagaLog(t *testing.T, stream Stream) *streamSagaLog {
+	slog, err := MakeStreamSagaLog(stream)
+	if err != nil {
+		t.Fatalf("Unexpected error creating stream saga log: %v", err)
+	}
+	s := slog.(*streamSagaLog)
+	s.backoff = 0
+	return s
+}
+
+func TestStreamSagaLogRecover(t *testing.T) {
+	stream := newFakeStream(4)
+	slog := makeTestStreamSagaLog(t, stream)
+
+	expected := map[string][]saga.SagaMessage{}
+	for _, id := range []string{"s1", "s2", "s3"} {
+		expected[id] = []saga.SagaMessage{
+			saga.MakeStartSagaMessage(id, []byte("job "+id)),
+			saga.MakeStartTaskMessage(id, "task1", []byte("run task 1")),
+			saga.MakeEndTaskMessage(id, "task1", []byte("success")),
+			saga.MakeStartTaskAttemptMessage(id, "task2", 1, []byte("run task 2")),
+			saga.MakeEndTaskAttemptMessage(id, "task2", 1, []byte("success")),
+		}
+		if err := slog.StartSaga(id, []byte("job "+id)); err != nil {
+			t.Fatalf("Unexpected error starting saga: %v", err)
+		}
+		for _, msg := range expected[id][1:] {
+			if err := slog.LogMessage(msg); err != nil {
+				t.Fatalf("Unexpected error logging message: %v", err)
+			}
+		}
+	}
+	expected["s2"] = append(expected["s2"], saga.MakeEndSagaMessage("s2"))
+	slog.LogMessage(saga.MakeEndSagaMessage("s2"))
+
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("missing")); err == nil {
+		t.Fatalf("Expected error logging to a saga that wasn't started")
+	}
+
+	// A new log replaying the same stream should see the same sagas.
+	recovered := makeTestStreamSagaLog(t, stream)
+	for id, msgs := range expected {
+		got, _ := recovered.GetMessages(id)
+		if !reflect.DeepEqual(got, msgs) {
+			t.Errorf("Saga %s: expected %+v, got %+v", id, msgs, got)
+		}
+	}
+	if !isSagaInActiveList("s1", recovered) || !isSagaInActiveList("s3", recovered) {
+		t.Errorf("Expected s1 and s3 to be in active list")
+	}
+	if isSagaInActiveList("s2", recovered) {
+		t.Errorf("Expected ended saga s2 not to be in active list")
+	}
+}
+
+func TestStreamSagaLogRetriesAreIdempotent(t *testing.T) {
+	stream := newFakeStream(2)
+	slog := makeTestStreamSagaLog(t, stream)
+	slog.StartSaga("s1", nil)
+
+	// The first append is committed but reports failure, the retry commits it again.
+	stream.failNext, stream.commitFail = 1, true
+	if err := slog.LogMessage(saga.MakeStartTaskMessage("s1", "task1", nil)); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	recovered := makeTestStreamSagaLog(t, stream)
+	msgs, _ := recovered.GetMessages("s1")
+	if len(msgs) != 2 {
+		t.Fatalf("Expected duplicate append to be dropped on replay, got %+v", msgs)
+	}
+
+	// Appends that never succeed are reported as errors and not applied.
+	stream.failNext, stream.commitFail = DefaultStreamAppendAttempts, false
+	err := slog.LogMessage(saga.MakeEndTaskMessage("s1", "task1", nil))
+	if _, ok := err.(saga.InternalLogError); !ok {
+		t.Fatalf("Expected InternalLogError, got %v", err)
+	}
+	if msgs, _ := slog.GetMessages("s1"); len(msgs) != 2 {
+		t.Fatalf("Expected failed message not to be applied, got %+v", msgs)
+	}
+}
+
+func TestStreamSagaLogReadsWhileRetrying(t *testing.T) {
+	stream := newFakeStream(1)
+	slog := makeTestStreamSagaLog(t, stream)
+	slog.StartSaga("s1", nil)
+
+	// Reads aren't held up while an append backs off before retrying.
+	slog.backoff = time.Second
+	stream.failNext = 1
+	done := make(chan error)
+	go func() {
+		done <- slog.LogMessage(saga.MakeStartTaskMessage("s1", "task1", nil))
+	}()
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	if msgs, _ := slog.GetMessages("s1"); len(msgs) != 1 {
+		t.Fatalf("Expected the retried message not to be applied yet, got %+v", msgs)
+	}
+	if elapsed := time.Since(start); elapsed > slog.backoff/2 {
+		t.Fatalf("Expected GetMessages not to wait on the append's backoff, took %s", elapsed)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+}
+
+func TestStreamSagaLogReadsLegacyRecords(t *testing.T) {
+	stream := newFakeStream(1)
+	stream.Append([]byte("s1"), []byte(`{"Id":"old-1","SagaId":"s1","MsgType":0,"Data":"am9i"}`))
+	stream.Append([]byte("s1"), []byte(`{"Id":"old-2","SagaId":"s1","MsgType":3,"TaskId":"task1"}`))
+
+	// New messages are appended in the protobuf encoding after the legacy ones.
+	slog := makeTestStreamSagaLog(t, stream)
+	if err := slog.LogMessage(saga.MakeEndTaskMessage("s1", "task1", nil)); err != nil {
+		t.Fatalf("Unexpected error logging message: %v", err)
+	}
+
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("s1", []byte("job")),
+		saga.MakeStartTaskMessage("s1", "task1", nil),
+		saga.MakeEndTaskMessage("s1", "task1", nil),
+	}
+	got, _ := makeTestStreamSagaLog(t, stream).GetMessages("s1")
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,229 @@
+// Package archive keeps the final status of finished jobs, so they can still be queried
+// once the scheduler has forgotten them and their sagas have been garbage collected.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/runner"
+)
+
+// How long finished jobs are kept if the Retention doesn't set MaxAge.
+const DefaultMaxAge = 30 * 24 * time.Hour
+
+// How many finished jobs are kept if the Retention doesn't set MaxJobs.
+// Every job kept is also held in memory to serve queries.
+const DefaultMaxJobs = 100000
+
+// Number of jobs a Query returns if it doesn't set a Limit.
+const DefaultQueryLimit = 100
+
+const jobFileSuffix = ".json"
+
+// The final status of a finished job.
+type Job struct {
+	ID         string
+	Requestor  string            `json:",omitempty"`
+	JobType    string            `json:",omitempty"`
+	Tag        string            `json:",omitempty"`
+	Labels     map[string]string `json:",omitempty"`
+	Priority   int
+	Killed     bool // killed by a user or for exceeding its timeout
+	RolledBack bool // the saga was aborted and rolled back rather than completed
+	Created    time.Time
+	Completed  time.Time
+	Tasks      []Task
+}
+
+// The outcome of a finished job's task.
+type Task struct {
+	ID         string
+	Attempts   int             // runs started, including retries
+	State      runner.RunState // state of the run whose result was kept, UNKNOWN if none was
+	ExitCode   int
+	Error      string `json:",omitempty"`
+	SnapshotID string `json:",omitempty"`
+}
+
+// Selects jobs from an Archive. Zero values match everything.
+type Query struct {
+	Since     time.Time // Jobs completed at or after Since
+	Until     time.Time // Jobs completed before Until
+	Tag       string
+	Requestor string
+	Limit     int // Most recently completed jobs returned, DefaultQueryLimit if <= 0
+}
+
+func (q Query) matches(j *Job) bool {
+	return (q.Tag == "" || q.Tag == j.Tag) &&
+		(q.Requestor == "" || q.Requestor == j.Requestor) &&
+		!j.Completed.Before(q.Since) &&
+		(q.Until.IsZero() || j.Completed.Before(q.Until))
+}
+
+// How long an Archive keeps jobs. Jobs are removed once they're older than MaxAge,
+// or if there are more than MaxJobs, oldest first.
+type Retention struct {
+	MaxAge  time.Duration // DefaultMaxAge if <= 0
+	MaxJobs int           // DefaultMaxJobs if <= 0
+}
+
+// Archive is a record of finished jobs.
+type Archive interface {
+	// Adds a finished job to the archive.
+	Add(j Job) error
+
+	// Returns the most recently completed jobs matching q, most recent first.
+	Query(q Query) ([]Job, error)
+}
+
+// Returns an Archive kept in memory, which is lost when the scheduler restarts.
+func NewMemoryArchive(r Retention) Archive {
+	return newArchive("", r)
+}
+
+// Returns an Archive storing each job as a json file in dir, so the archive survives
+// scheduler restarts. Jobs previously written to dir are loaded.
+func NewDirArchive(dir string, r Retention) (Archive, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	a := newArchive(dir, r)
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	log.Infof("Archiving finished jobs to %s, loaded %d", dir, len(a.jobs))
+	return a, nil
+}
+
+type archive struct {
+	dir       string // Jobs are only kept in memory if empty
+	retention Retention
+
+	mu   sync.RWMutex
+	jobs []archivedJob // ordered oldest to newest by Completed
+}
+
+type archivedJob struct {
+	file string
+	job  Job
+}
+
+func newArchive(dir string, r Retention) *archive {
+	if r.MaxAge <= 0 {
+		r.MaxAge = DefaultMaxAge
+	}
+	if r.MaxJobs <= 0 {
+		r.MaxJobs = DefaultMaxJobs
+	}
+	return &archive{dir: dir, retention: r}
+}
+
+func (a *archive) load() error {
+	infos, err := ioutil.ReadDir(a.dir)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), jobFileSuffix) {
+			continue
+		}
+		path := filepath.Join(a.dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var j Job
+		if err := json.Unmarshal(data, &j); err != nil {
+			log.Errorf("Removing unreadable archived job %s: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+		a.jobs = append(a.jobs, archivedJob{file: info.Name(), job: j})
+	}
+	sort.SliceStable(a.jobs, func(i, j int) bool {
+		return a.jobs[i].job.Completed.Before(a.jobs[j].job.Completed)
+	})
+	a.expire(time.Now())
+	return nil
+}
+
+func (a *archive) Add(j Job) error {
+	// Job IDs are unique, the completion time keeps files in order when listed.
+	name := fmt.Sprintf("%d-%s%s", j.Completed.UnixNano(), j.ID, jobFileSuffix)
+	if a.dir != "" {
+		data, err := json.Marshal(j)
+		if err != nil {
+			return err
+		}
+		tmpPath := filepath.Join(a.dir, "."+name)
+		if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+			return err
+		}
+		if err := os.Rename(tmpPath, filepath.Join(a.dir, name)); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Jobs are usually added in the order they complete, so the insertion point is at the end.
+	i := len(a.jobs)
+	for i > 0 && a.jobs[i-1].job.Completed.After(j.Completed) {
+		i--
+	}
+	a.jobs = append(a.jobs, archivedJob{})
+	copy(a.jobs[i+1:], a.jobs[i:])
+	a.jobs[i] = archivedJob{file: name, job: j}
+	a.expire(time.Now())
+	return nil
+}
+
+// Removes the oldest jobs until the archive is within its retention. Caller must hold the write lock.
+func (a *archive) expire(now time.Time) {
+	n := 0
+	for n < len(a.jobs) && (now.Sub(a.jobs[n].job.Completed) > a.retention.MaxAge ||
+		len(a.jobs)-n > a.retention.MaxJobs) {
+		if a.dir != "" {
+			path := filepath.Join(a.dir, a.jobs[n].file)
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Errorf("Failed to remove archived job %s: %v", path, err)
+			}
+		}
+		n++
+	}
+	a.jobs = a.jobs[n:]
+}
+
+func (a *archive) Query(q Query) ([]Job, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	jobs := []Job{}
+	cutoff := time.Now().Add(-a.retention.MaxAge)
+	for i := len(a.jobs) - 1; i >= 0 && len(jobs) < limit; i-- {
+		j := &a.jobs[i].job
+		if j.Completed.Before(cutoff) {
+			// Expired but not yet removed, as nothing's been added since.
+			break
+		}
+		if q.matches(j) {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/runner"
+)
+
+func ids(jobs []Job) []string {
+	ids := []string{}
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	return ids
+}
+
+func TestQuery(t *testing.T) {
+	a := NewMemoryArchive(Retention{})
+	now := time.Now()
+	a.Add(Job{ID: "1", Tag: "a", Requestor: "x", Completed: now.Add(-3 * time.Hour)})
+	a.Add(Job{ID: "3", Tag: "b", Requestor: "x", Completed: now.Add(-time.Hour)})
+	// Added out of order, but still returned in order of completion.
+	a.Add(Job{ID: "2", Tag: "a", Requestor: "y", Completed: now.Add(-2 * time.Hour)})
+
+	for _, c := range []struct {
+		q        Query
+		expected []string
+	}{
+		{Query{}, []string{"3", "2", "1"}},
+		{Query{Limit: 2}, []string{"3", "2"}},
+		{Query{Tag: "a"}, []string{"2", "1"}},
+		{Query{Requestor: "x"}, []string{"3", "1"}},
+		{Query{Since: now.Add(-2 * time.Hour)}, []string{"3", "2"}},
+		{Query{Until: now.Add(-2 * time.Hour)}, []string{"1"}},
+	} {
+		jobs, err := a.Query(c.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ids(jobs); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("Query %+v: expected %v, got %v", c.q, c.expected, got)
+		}
+	}
+}
+
+func TestRetention(t *testing.T) {
+	a := NewMemoryArchive(Retention{MaxAge: time.Hour, MaxJobs: 2})
+	now := time.Now()
+	a.Add(Job{ID: "expired", Completed: now.Add(-2 * time.Hour)})
+	a.Add(Job{ID: "1", Completed: now.Add(-3 * time.Minute)})
+	a.Add(Job{ID: "2", Completed: now.Add(-2 * time.Minute)})
+	a.Add(Job{ID: "3", Completed: now.Add(-time.Minute)})
+
+	jobs, _ := a.Query(Query{})
+	if got := ids(jobs); !reflect.DeepEqual(got, []string{"3", "2"}) {
+		t.Fatalf("Expected the 2 newest jobs, got %v", got)
+	}
+
+	if r := newArchive("", Retention{}).retention; r.MaxAge != DefaultMaxAge || r.MaxJobs != DefaultMaxJobs {
+		t.Fatalf("Expected default retention, got %+v", r)
+	}
+}
+
+func TestDirArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a, err := NewDirArchive(dir, Retention{MaxJobs: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	a.Add(Job{ID: "1", Completed: now.Add(-time.Minute)})
+	a.Add(Job{ID: "2", Labels: map[string]string{"k": "v"}, Completed: now,
+		Tasks: []Task{{ID: "t", Attempts: 2, State: runner.COMPLETE, ExitCode: 1}}})
+	if infos, _ := ioutil.ReadDir(dir); len(infos) != 1 {
+		t.Fatalf("Expected the expired job's file to be removed, got %d files", len(infos))
+	}
+
+	// A new archive in the same dir has the jobs written by the first.
+	a, err = NewDirArchive(dir, Retention{MaxJobs: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, _ := a.Query(Query{})
+	if len(jobs) != 1 || jobs[0].ID != "2" || jobs[0].Labels["k"] != "v" ||
+		len(jobs[0].Tasks) != 1 || jobs[0].Tasks[0] != (Task{ID: "t", Attempts: 2, State: runner.COMPLETE, ExitCode: 1}) {
+		t.Fatalf("Unexpected jobs after reload: %+v", jobs)
+	}
+}
@@ -3,6 +3,7 @@ package sched
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
@@ -16,6 +17,8 @@ import (
 type Job struct {
 	Id  string
 	Def JobDefinition
+	// When the job was first scheduled, kept in its saga so it survives recovery. Zero if unknown.
+	Created time.Time
 }
 
 // Serialize Job to binary slice, and error is
@@ -50,11 +53,25 @@ type JobDefinition struct {
 	Tag       string
 	Priority  Priority
 	Tasks     []TaskDefinition
+	// Key/value tags describing the job, ex: repo, branch, CI build url. Doesn't affect scheduling.
+	Labels map[string]string
+	// Maximum wall clock time for the job, after which unfinished tasks are aborted
+	// and the job is rolled back. Zero means no limit.
+	Timeout time.Duration
+	// How long the job's output snapshots and persisted logs are kept. Zero uses the defaults.
+	Retention time.Duration
+	// If set, the job's tasks waiting to start are started all at once or not at all,
+	// for tasks that coordinate with each other at startup. See getTaskAssignments.
+	// If a task fails and is retried, the gang's running tasks are aborted and rerun with it,
+	// and if it fails and won't be retried, the job is killed.
+	Gang bool
 }
 
 // Task is one task to run
 type TaskDefinition struct {
 	runner.Command
+	// CPU and memory the task needs. The scheduler only places it on workers reporting at least as much.
+	Resources runner.Resources
 }
 
 type OfflineWorkerReq struct {
@@ -67,6 +84,37 @@ type ReinstateWorkerReq struct {
 	Requestor string
 }
 
+// Pauses or resumes a job. A paused job isn't given new tasks, and if AbortRunning is set
+// its running tasks are aborted to be rerun once it's resumed.
+type PauseJobReq struct {
+	JobID        string
+	Requestor    string
+	Paused       bool
+	AbortRunning bool
+}
+
+// Cordons or uncordons a worker. A cordoned worker stays in the cluster but isn't given new tasks.
+type CordonWorkerReq struct {
+	ID        string
+	Requestor string
+	Cordoned  bool
+}
+
+// Adds or removes a worker by hand, ahead of the cluster's discovery.
+// AdminToken must match the scheduler's, Requestor is only recorded.
+type ManualWorkerReq struct {
+	ID         string
+	Requestor  string
+	AdminToken string
+}
+
+// Runs a task from the scheduler's dead letter queue again, in its job.
+type RequeueTaskReq struct {
+	JobID     string
+	TaskID    string
+	Requestor string
+}
+
 // Status for Job & Tasks
 type Status int
 
@@ -118,6 +166,10 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 	tag := ""
 	basis := ""
 	requestor := ""
+	var labels map[string]string
+	var timeout time.Duration
+	var retention time.Duration
+	var gang bool
 
 	thriftJobDef := thriftJob.GetJobDefinition()
 	jobID := thriftJob.GetID()
@@ -129,10 +181,11 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 			execReq := bazelapi.MakeExecReqDomainFromThrift(task.BazelRequest)
 
 			command := runner.Command{
-				Argv:       cmd.GetArgv(),
-				EnvVars:    cmd.GetEnvVars(),
-				Timeout:    time.Duration(cmd.GetTimeout()),
-				SnapshotID: cmd.GetSnapshotId(),
+				Argv:           cmd.GetArgv(),
+				EnvVars:        cmd.GetEnvVars(),
+				Timeout:        time.Duration(cmd.GetTimeout()),
+				SnapshotID:     cmd.GetSnapshotId(),
+				SparsePatterns: cmd.GetSparsePatterns(),
 				LogTags: tags.LogTags{
 					JobID:  jobID,
 					TaskID: task.GetTaskId(),
@@ -141,7 +194,13 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 				ExecuteRequest: execReq,
 			}
 
-			domainTasks = append(domainTasks, TaskDefinition{command})
+			resources := runner.Resources{}
+			if r := task.Resources; r != nil {
+				resources.MilliCPUs = r.GetMilliCpus()
+				resources.MemoryBytes = r.GetMemoryBytes()
+			}
+
+			domainTasks = append(domainTasks, TaskDefinition{Command: command, Resources: resources})
 		}
 
 		jobType = thriftJobDef.GetJobType()
@@ -149,6 +208,10 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 		tag = thriftJobDef.GetTag()
 		basis = thriftJobDef.GetBasis()
 		requestor = thriftJobDef.GetRequestor()
+		labels = thriftJobDef.GetLabels()
+		timeout = time.Duration(thriftJobDef.GetTimeout())
+		retention = time.Duration(thriftJobDef.GetRetention())
+		gang = thriftJobDef.GetGang()
 	}
 
 	domainJobDef := JobDefinition{
@@ -158,11 +221,21 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 		Basis:     basis,
 		Requestor: requestor,
 		Tag:       tag,
+		Labels:    labels,
+		Timeout:   timeout,
+		Retention: retention,
+		Gang:      gang,
+	}
+
+	var created time.Time
+	if thriftJob.IsSetTimeCreated() {
+		created = time.Unix(0, thriftJob.GetTimeCreated())
 	}
 
 	return &Job{
-		Id:  jobID,
-		Def: domainJobDef,
+		Id:      jobID,
+		Def:     domainJobDef,
+		Created: created,
 	}
 }
 
@@ -176,19 +249,27 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 	for _, domainTask := range domainJob.Def.Tasks {
 		to := int64(domainTask.Timeout)
 		cmd := schedthrift.Command{
-			Argv:       domainTask.Argv,
-			EnvVars:    domainTask.EnvVars,
-			Timeout:    &to,
-			SnapshotId: domainTask.SnapshotID,
+			Argv:           domainTask.Argv,
+			EnvVars:        domainTask.EnvVars,
+			Timeout:        &to,
+			SnapshotId:     domainTask.SnapshotID,
+			SparsePatterns: domainTask.SparsePatterns,
 		}
 		taskId := domainTask.TaskID
 		execReq := bazelapi.MakeExecReqThriftFromDomain(domainTask.ExecuteRequest)
 
 		thriftTask := schedthrift.TaskDefinition{Command: &cmd, TaskId: &taskId, BazelRequest: execReq}
+		if !domainTask.Resources.Unspecified() {
+			milliCpus := domainTask.Resources.MilliCPUs
+			memoryBytes := domainTask.Resources.MemoryBytes
+			thriftTask.Resources = &schedthrift.Resources{MilliCpus: &milliCpus, MemoryBytes: &memoryBytes}
+		}
 		thriftTasks = append(thriftTasks, &thriftTask)
 	}
 
 	prio := int32(domainJob.Def.Priority)
+	timeout := int64(domainJob.Def.Timeout)
+	retention := int64(domainJob.Def.Retention)
 	thriftJobDefinition := schedthrift.JobDefinition{
 		JobType:   &(*domainJob).Def.JobType,
 		Tasks:     thriftTasks,
@@ -196,12 +277,20 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 		Tag:       &(domainJob).Def.Tag,
 		Basis:     &(domainJob).Def.Basis,
 		Requestor: &(domainJob).Def.Requestor,
+		Labels:    domainJob.Def.Labels,
+		Timeout:   &timeout,
+		Retention: &retention,
+		Gang:      &domainJob.Def.Gang,
 	}
 
 	thriftJob := schedthrift.Job{
 		ID:            domainJob.Id,
 		JobDefinition: &thriftJobDefinition,
 	}
+	if !domainJob.Created.IsZero() {
+		created := domainJob.Created.UnixNano()
+		thriftJob.TimeCreated = &created
+	}
 
 	return &thriftJob, nil
 }
@@ -211,6 +300,12 @@ func ValidateJob(job JobDefinition) error {
 	if len(job.Tasks) == 0 {
 		return fmt.Errorf("invalid job. Must have at least 1 task; was empty")
 	}
+	if job.Timeout < 0 {
+		return fmt.Errorf("invalid job.Timeout %s. Must not be negative", job.Timeout)
+	}
+	if job.Retention < 0 {
+		return fmt.Errorf("invalid job.Retention %s. Must not be negative", job.Retention)
+	}
 	for _, task := range job.Tasks {
 		if task.TaskID == "" {
 			return fmt.Errorf("invalid task id \"\".")
@@ -218,6 +313,37 @@ func ValidateJob(job JobDefinition) error {
 		if len(task.Command.Argv) == 0 {
 			return fmt.Errorf("invalid task.Command.Argv. Must have at least one argument; was empty")
 		}
+		if task.Resources.MilliCPUs < 0 || task.Resources.MemoryBytes < 0 {
+			return fmt.Errorf("invalid task.Resources %s. Must not be negative", task.Resources)
+		}
+		for _, p := range task.SparsePatterns {
+			// Patterns are passed to git as arguments and written one per line to its sparse-checkout file.
+			if p == "" || strings.HasPrefix(p, "-") || strings.ContainsAny(p, "\r\n") {
+				return fmt.Errorf("invalid task.SparsePatterns %q. Must be non-empty lines not starting with '-'", p)
+			}
+		}
+	}
+	return ValidateLabels(job.Labels)
+}
+
+// Limits on job labels, which are kept in memory by the scheduler for every recent job.
+const (
+	MaxLabels        = 20
+	MaxLabelKeyLen   = 64
+	MaxLabelValueLen = 1024
+)
+
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("invalid labels. Must have at most %d labels; was %d", MaxLabels, len(labels))
+	}
+	for k, v := range labels {
+		if k == "" || len(k) > MaxLabelKeyLen {
+			return fmt.Errorf("invalid label key %q. Must have 1 to %d characters", k, MaxLabelKeyLen)
+		}
+		if len(v) > MaxLabelValueLen {
+			return fmt.Errorf("invalid label %q value. Must have at most %d characters; was %d", k, MaxLabelValueLen, len(v))
+		}
 	}
 	return nil
 }
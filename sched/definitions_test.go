@@ -1,9 +1,12 @@
 package sched
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/twitter/scoot/common/thrifthelpers"
+	"github.com/twitter/scoot/runner"
 	schedthrift "github.com/twitter/scoot/sched/gen-go/sched"
 )
 
@@ -35,3 +38,103 @@ func Test_DeserializeJob_MinThrift(t *testing.T) {
 		t.Errorf("unexpected error converting to Scheduler Job %+v", err)
 	}
 }
+
+func Test_SerializeJob_Created(t *testing.T) {
+	job := GenJob("job1", 1)
+	job.Created = time.Unix(0, 1500000000123456789)
+	binaryJob, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("unexpected error serializing job %+v", err)
+	}
+	deserialized, err := DeserializeJob(binaryJob)
+	if err != nil {
+		t.Fatalf("unexpected error deserializing job %+v", err)
+	}
+	if !deserialized.Created.Equal(job.Created) {
+		t.Errorf("Expected Created %s, got %s", job.Created, deserialized.Created)
+	}
+}
+
+func Test_ValidateJob_Labels(t *testing.T) {
+	job := JobDefinition{Tasks: []TaskDefinition{{}}}
+	job.Tasks[0].TaskID = "task"
+	job.Tasks[0].Argv = []string{"true"}
+	job.Labels = map[string]string{"repo": "source", "pr": "1234"}
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("unexpected error validating labels %v", err)
+	}
+
+	job.Labels = map[string]string{"": "empty"}
+	if err := ValidateJob(job); err == nil {
+		t.Error("Expected empty label key to be invalid")
+	}
+	job.Labels = map[string]string{}
+	for i := 0; i <= MaxLabels; i++ {
+		job.Labels[fmt.Sprint(i)] = ""
+	}
+	if err := ValidateJob(job); err == nil {
+		t.Error("Expected too many labels to be invalid")
+	}
+}
+
+func Test_ValidateJob_Timeout(t *testing.T) {
+	job := JobDefinition{Tasks: []TaskDefinition{{}}}
+	job.Tasks[0].TaskID = "task"
+	job.Tasks[0].Argv = []string{"true"}
+	job.Timeout = time.Hour
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("unexpected error validating timeout %v", err)
+	}
+
+	job.Timeout = -time.Second
+	if err := ValidateJob(job); err == nil {
+		t.Error("Expected negative timeout to be invalid")
+	}
+}
+
+func Test_ValidateJob_Retention(t *testing.T) {
+	job := JobDefinition{Tasks: []TaskDefinition{{}}}
+	job.Tasks[0].TaskID = "task"
+	job.Tasks[0].Argv = []string{"true"}
+	job.Retention = 24 * time.Hour
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("unexpected error validating retention %v", err)
+	}
+
+	job.Retention = -time.Second
+	if err := ValidateJob(job); err == nil {
+		t.Error("Expected negative retention to be invalid")
+	}
+}
+
+func Test_ValidateJob_SparsePatterns(t *testing.T) {
+	job := JobDefinition{Tasks: []TaskDefinition{{}}}
+	job.Tasks[0].TaskID = "task"
+	job.Tasks[0].Argv = []string{"true"}
+	job.Tasks[0].SparsePatterns = []string{"/src/service/", "!/src/service/testdata/"}
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("unexpected error validating sparse patterns %v", err)
+	}
+
+	for _, p := range []string{"", "--cone", "/src/\n/lib/"} {
+		job.Tasks[0].SparsePatterns = []string{p}
+		if err := ValidateJob(job); err == nil {
+			t.Errorf("Expected sparse pattern %q to be invalid", p)
+		}
+	}
+}
+
+func Test_ValidateJob_Resources(t *testing.T) {
+	job := JobDefinition{Tasks: []TaskDefinition{{}}}
+	job.Tasks[0].TaskID = "task"
+	job.Tasks[0].Argv = []string{"true"}
+	job.Tasks[0].Resources = runner.Resources{MilliCPUs: 2000, MemoryBytes: 1 << 30}
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("unexpected error validating resources %v", err)
+	}
+
+	job.Tasks[0].Resources.MemoryBytes = -1
+	if err := ValidateJob(job); err == nil {
+		t.Error("Expected negative memory to be invalid")
+	}
+}
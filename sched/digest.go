@@ -0,0 +1,31 @@
+package sched
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/twitter/scoot/bazel"
+)
+
+// Returns the Bazel Action digest of a task started from an Execute request, otherwise a sha256 over
+// the task's argv, env and timeout, so tasks running the same command have the same digest.
+func CommandDigest(t TaskDefinition) string {
+	if d := bazel.DigestToStr(t.ExecuteRequest.GetRequest().GetActionDigest()); d != "" {
+		return d
+	}
+	h := sha256.New()
+	for _, arg := range t.Argv {
+		fmt.Fprintf(h, "argv\x00%s\x00", arg)
+	}
+	keys := []string{}
+	for k := range t.EnvVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "env\x00%s\x00%s\x00", k, t.EnvVars[k])
+	}
+	fmt.Fprintf(h, "timeout\x00%d\x00", int64(t.Timeout))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
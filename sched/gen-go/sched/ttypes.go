@@ -23,11 +23,13 @@ var GoUnusedProtection__ int
 //  - EnvVars
 //  - Timeout
 //  - SnapshotId
+//  - SparsePatterns
 type Command struct {
-	Argv       []string          `thrift:"argv,1,required" json:"argv"`
-	EnvVars    map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
-	Timeout    *int64            `thrift:"timeout,3" json:"timeout,omitempty"`
-	SnapshotId string            `thrift:"snapshotId,4,required" json:"snapshotId"`
+	Argv           []string          `thrift:"argv,1,required" json:"argv"`
+	EnvVars        map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
+	Timeout        *int64            `thrift:"timeout,3" json:"timeout,omitempty"`
+	SnapshotId     string            `thrift:"snapshotId,4,required" json:"snapshotId"`
+	SparsePatterns []string          `thrift:"sparsePatterns,5" json:"sparsePatterns,omitempty"`
 }
 
 func NewCommand() *Command {
@@ -56,6 +58,12 @@ func (p *Command) GetTimeout() int64 {
 func (p *Command) GetSnapshotId() string {
 	return p.SnapshotId
 }
+
+var Command_SparsePatterns_DEFAULT []string
+
+func (p *Command) GetSparsePatterns() []string {
+	return p.SparsePatterns
+}
 func (p *Command) IsSetEnvVars() bool {
 	return p.EnvVars != nil
 }
@@ -64,6 +72,10 @@ func (p *Command) IsSetTimeout() bool {
 	return p.Timeout != nil
 }
 
+func (p *Command) IsSetSparsePatterns() bool {
+	return p.SparsePatterns != nil
+}
+
 func (p *Command) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -99,6 +111,10 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetSnapshotId = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -188,6 +204,28 @@ func (p *Command) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *Command) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.SparsePatterns = tSlice
+	for i := 0; i < size; i++ {
+		var _elem4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem4 = v
+		}
+		p.SparsePatterns = append(p.SparsePatterns, _elem4)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("Command"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -204,6 +242,9 @@ func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -288,6 +329,29 @@ func (p *Command) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *Command) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSparsePatterns() {
+		if err := oprot.WriteFieldBegin("sparsePatterns", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:sparsePatterns: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.SparsePatterns)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.SparsePatterns {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:sparsePatterns: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *Command) String() string {
 	if p == nil {
 		return "<nil>"
@@ -295,14 +359,164 @@ func (p *Command) String() string {
 	return fmt.Sprintf("Command(%+v)", *p)
 }
 
+// Attributes:
+//  - MilliCpus
+//  - MemoryBytes
+type Resources struct {
+	MilliCpus   *int64 `thrift:"milliCpus,1" json:"milliCpus,omitempty"`
+	MemoryBytes *int64 `thrift:"memoryBytes,2" json:"memoryBytes,omitempty"`
+}
+
+func NewResources() *Resources {
+	return &Resources{}
+}
+
+var Resources_MilliCpus_DEFAULT int64
+
+func (p *Resources) GetMilliCpus() int64 {
+	if !p.IsSetMilliCpus() {
+		return Resources_MilliCpus_DEFAULT
+	}
+	return *p.MilliCpus
+}
+
+var Resources_MemoryBytes_DEFAULT int64
+
+func (p *Resources) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return Resources_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+func (p *Resources) IsSetMilliCpus() bool {
+	return p.MilliCpus != nil
+}
+
+func (p *Resources) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *Resources) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Resources) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MilliCpus = &v
+	}
+	return nil
+}
+
+func (p *Resources) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *Resources) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Resources"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Resources) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMilliCpus() {
+		if err := oprot.WriteFieldBegin("milliCpus", thrift.I64, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:milliCpus: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MilliCpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.milliCpus (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:milliCpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Resources(%+v)", *p)
+}
+
 // Attributes:
 //  - Command
 //  - TaskId
 //  - BazelRequest
+//  - Resources
 type TaskDefinition struct {
 	Command      *Command              `thrift:"command,1,required" json:"command"`
 	TaskId       *string               `thrift:"taskId,2" json:"taskId,omitempty"`
 	BazelRequest *bazel.ExecuteRequest `thrift:"bazelRequest,3" json:"bazelRequest,omitempty"`
+	Resources    *Resources            `thrift:"resources,4" json:"resources,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -335,6 +549,15 @@ func (p *TaskDefinition) GetBazelRequest() *bazel.ExecuteRequest {
 	}
 	return p.BazelRequest
 }
+
+var TaskDefinition_Resources_DEFAULT *Resources
+
+func (p *TaskDefinition) GetResources() *Resources {
+	if !p.IsSetResources() {
+		return TaskDefinition_Resources_DEFAULT
+	}
+	return p.Resources
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
@@ -347,6 +570,10 @@ func (p *TaskDefinition) IsSetBazelRequest() bool {
 	return p.BazelRequest != nil
 }
 
+func (p *TaskDefinition) IsSetResources() bool {
+	return p.Resources != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -376,6 +603,10 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField3(iprot); err != nil {
 				return err
 			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -419,6 +650,14 @@ func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
+	p.Resources = &Resources{}
+	if err := p.Resources.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Resources), err)
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -432,6 +671,9 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -484,6 +726,21 @@ func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResources() {
+		if err := oprot.WriteFieldBegin("resources", thrift.STRUCT, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:resources: ", p), err)
+		}
+		if err := p.Resources.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Resources), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:resources: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -498,6 +755,10 @@ func (p *TaskDefinition) String() string {
 //  - Tag
 //  - Basis
 //  - Requestor
+//  - Labels
+//  - Timeout
+//  - Retention
+//  - Gang
 type JobDefinition struct {
 	JobType   *string           `thrift:"jobType,1" json:"jobType,omitempty"`
 	Tasks     []*TaskDefinition `thrift:"tasks,2" json:"tasks,omitempty"`
@@ -505,6 +766,10 @@ type JobDefinition struct {
 	Tag       *string           `thrift:"tag,4" json:"tag,omitempty"`
 	Basis     *string           `thrift:"basis,5" json:"basis,omitempty"`
 	Requestor *string           `thrift:"requestor,6" json:"requestor,omitempty"`
+	Labels    map[string]string `thrift:"labels,7" json:"labels,omitempty"`
+	Timeout   *int64            `thrift:"timeout,8" json:"timeout,omitempty"`
+	Retention *int64            `thrift:"retention,9" json:"retention,omitempty"`
+	Gang      *bool             `thrift:"gang,10" json:"gang,omitempty"`
 }
 
 func NewJobDefinition() *JobDefinition {
@@ -561,6 +826,39 @@ func (p *JobDefinition) GetRequestor() string {
 	}
 	return *p.Requestor
 }
+
+var JobDefinition_Labels_DEFAULT map[string]string
+
+func (p *JobDefinition) GetLabels() map[string]string {
+	return p.Labels
+}
+
+var JobDefinition_Timeout_DEFAULT int64
+
+func (p *JobDefinition) GetTimeout() int64 {
+	if !p.IsSetTimeout() {
+		return JobDefinition_Timeout_DEFAULT
+	}
+	return *p.Timeout
+}
+
+var JobDefinition_Retention_DEFAULT int64
+
+func (p *JobDefinition) GetRetention() int64 {
+	if !p.IsSetRetention() {
+		return JobDefinition_Retention_DEFAULT
+	}
+	return *p.Retention
+}
+
+var JobDefinition_Gang_DEFAULT bool
+
+func (p *JobDefinition) GetGang() bool {
+	if !p.IsSetGang() {
+		return JobDefinition_Gang_DEFAULT
+	}
+	return *p.Gang
+}
 func (p *JobDefinition) IsSetJobType() bool {
 	return p.JobType != nil
 }
@@ -585,6 +883,22 @@ func (p *JobDefinition) IsSetRequestor() bool {
 	return p.Requestor != nil
 }
 
+func (p *JobDefinition) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *JobDefinition) IsSetTimeout() bool {
+	return p.Timeout != nil
+}
+
+func (p *JobDefinition) IsSetRetention() bool {
+	return p.Retention != nil
+}
+
+func (p *JobDefinition) IsSetGang() bool {
+	return p.Gang != nil
+}
+
 func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -623,6 +937,22 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField6(iprot); err != nil {
 				return err
 			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -703,6 +1033,61 @@ func (p *JobDefinition) readField6(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *JobDefinition) readField7(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key4 = v
+		}
+		var _val5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val5 = v
+		}
+		p.Labels[_key4] = _val5
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Timeout = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.Retention = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.Gang = &v
+	}
+	return nil
+}
+
 func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -725,6 +1110,18 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField6(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -832,6 +1229,77 @@ func (p *JobDefinition) writeField6(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeout() {
+		if err := oprot.WriteFieldBegin("timeout", thrift.I64, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:timeout: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Timeout)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeout (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:timeout: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetention() {
+		if err := oprot.WriteFieldBegin("retention", thrift.I64, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:retention: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Retention)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retention (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:retention: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetGang() {
+		if err := oprot.WriteFieldBegin("gang", thrift.BOOL, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:gang: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Gang)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.gang (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:gang: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -842,9 +1310,11 @@ func (p *JobDefinition) String() string {
 // Attributes:
 //  - ID
 //  - JobDefinition
+//  - TimeCreated
 type Job struct {
 	ID            string         `thrift:"id,1,required" json:"id"`
 	JobDefinition *JobDefinition `thrift:"jobDefinition,2,required" json:"jobDefinition"`
+	TimeCreated   *int64         `thrift:"timeCreated,3" json:"timeCreated,omitempty"`
 }
 
 func NewJob() *Job {
@@ -863,10 +1333,23 @@ func (p *Job) GetJobDefinition() *JobDefinition {
 	}
 	return p.JobDefinition
 }
+
+var Job_TimeCreated_DEFAULT int64
+
+func (p *Job) GetTimeCreated() int64 {
+	if !p.IsSetTimeCreated() {
+		return Job_TimeCreated_DEFAULT
+	}
+	return *p.TimeCreated
+}
 func (p *Job) IsSetJobDefinition() bool {
 	return p.JobDefinition != nil
 }
 
+func (p *Job) IsSetTimeCreated() bool {
+	return p.TimeCreated != nil
+}
+
 func (p *Job) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -894,6 +1377,10 @@ func (p *Job) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetJobDefinition = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -932,6 +1419,15 @@ func (p *Job) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *Job) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TimeCreated = &v
+	}
+	return nil
+}
+
 func (p *Job) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("Job"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -942,6 +1438,9 @@ func (p *Job) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -977,6 +1476,21 @@ func (p *Job) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *Job) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeCreated() {
+		if err := oprot.WriteFieldBegin("timeCreated", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:timeCreated: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.TimeCreated)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeCreated (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:timeCreated: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *Job) String() string {
 	if p == nil {
 		return "<nil>"
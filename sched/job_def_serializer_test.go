@@ -50,6 +50,10 @@ func makeFixedSampleJob() *Job {
 	jobDef.JobType = "jobTypeVal"
 	jobDef.Requestor = "requestor"
 	jobDef.Tag = "tag"
+	jobDef.Labels = map[string]string{"repo": "source", "branch": "master"}
+	jobDef.Timeout = 60
+	jobDef.Retention = 3600
+	jobDef.Gang = true
 	taskDefinition := TaskDefinition{}
 	taskDefinition.SnapshotID = "snapshotIDVal"
 	taskDefinition.Timeout = 3
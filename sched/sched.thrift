@@ -14,12 +14,20 @@ struct Command {
   2: optional map<string, string> envVars
   3: optional i64 timeout
   4: required string snapshotId
+  5: optional list<string> sparsePatterns
+}
+
+# CPU and memory a task needs, unset values are unspecified.
+struct Resources {
+  1: optional i64 milliCpus    # Thousandths of a CPU core.
+  2: optional i64 memoryBytes
 }
 
 struct TaskDefinition {
   1: required Command command
   2: optional string taskId
   3: optional bazel.ExecuteRequest bazelRequest
+  4: optional Resources resources
 }
 
 struct JobDefinition {
@@ -29,9 +37,14 @@ struct JobDefinition {
   4: optional string tag
   5: optional string basis
   6: optional string requestor
+  7: optional map<string, string> labels
+  8: optional i64 timeout
+  9: optional i64 retention
+  10: optional bool gang
 }
 
 struct Job {
   1: required string id
   2: required JobDefinition jobDefinition
+  3: optional i64 timeCreated    # Unix nanoseconds, kept so recovered jobs keep their creation time.
 }
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// How long clients are told to wait before resubmitting a throttled job.
+const DefaultThrottleRetryAfter = 30 * time.Second
+
+// Window over which CAS request results are considered when computing the CAS error rate.
+const DefaultCASErrorWindow = time.Minute
+
+// Minimum number of CAS results in the window before the error rate is used for throttling.
+const DefaultMinCASSamples = 10
+
+// Most CAS results kept in the window, the oldest are dropped beyond it so a burst of requests
+// can't grow the window without bound.
+const MaxCASSamples = 10000
+
+// Admission Config variables read at initialization
+// MaxLoadFactor -
+//     new jobs are throttled while the number of running and waiting tasks per healthy
+//     node exceeds this value. Zero disables load based throttling.
+// MaxCASErrorRate -
+//     new jobs are throttled while the fraction of failed CAS requests in the last
+//     CASErrorWindow exceeds this value. Zero disables CAS based throttling.
+// CASErrorWindow -
+//     how far back to look when computing the CAS error rate.
+// MinCASSamples -
+//     how many CAS results must be in the window before the error rate is trusted.
+// AdmissionWait -
+//     how long ScheduleJob waits for a saturated system to recover before rejecting a job.
+// ThrottleRetryAfter -
+//     how long rejected clients should wait before resubmitting.
+type AdmissionConfig struct {
+	MaxLoadFactor      float64
+	MaxCASErrorRate    float64
+	CASErrorWindow     time.Duration
+	MinCASSamples      int
+	AdmissionWait      time.Duration
+	ThrottleRetryAfter time.Duration
+}
+
+// ThrottledError is returned by ScheduleJob when a job is rejected because the system is saturated.
+// The job was not scheduled and may succeed if resubmitted after RetryAfter.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Reason     string
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("Scheduler is throttling new jobs (%s), retry after %s", e.Reason, e.RetryAfter)
+}
+
+// Returns true if an error is of type ThrottledError
+func IsThrottledError(err error) bool {
+	_, ok := err.(*ThrottledError)
+	return ok
+}
+
+// CASHealthRecorder is implemented by Schedulers that take CAS health into account when admitting jobs.
+// Components that talk to the CAS on the scheduler's behalf report the result of each request.
+type CASHealthRecorder interface {
+	RecordCASResult(err error)
+}
+
+type casResult struct {
+	time   time.Time
+	failed bool
+}
+
+// admissionController decides whether new jobs are admitted based on the most recent
+// cluster load reported by the scheduler loop and on the CAS results reported by clients.
+// It is safe for concurrent use since ScheduleJob is called outside the scheduler loop.
+type admissionController struct {
+	config AdmissionConfig
+	stat   stats.StatsReceiver
+
+	mu         sync.Mutex
+	load       float64
+	casResults []casResult // ordered oldest to newest
+}
+
+func newAdmissionController(config AdmissionConfig, stat stats.StatsReceiver) *admissionController {
+	if config.CASErrorWindow == 0 {
+		config.CASErrorWindow = DefaultCASErrorWindow
+	}
+	if config.MinCASSamples == 0 {
+		config.MinCASSamples = DefaultMinCASSamples
+	}
+	if config.ThrottleRetryAfter == 0 {
+		config.ThrottleRetryAfter = DefaultThrottleRetryAfter
+	}
+	return &admissionController{config: config, stat: stat}
+}
+
+// Update the cluster load given the number of unfinished tasks and healthy nodes.
+func (a *admissionController) updateLoad(remainingTasks, numNodes int) {
+	var load float64
+	if numNodes > 0 {
+		load = float64(remainingTasks) / float64(numNodes)
+	} else if remainingTasks > 0 {
+		load = float64(remainingTasks)
+	}
+	a.stat.Gauge(stats.SchedLoadPercentGauge).Update(int64(load * 100))
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.load = load
+}
+
+// Record the result of a CAS request, err is nil if the request succeeded.
+func (a *admissionController) recordCASResult(err error) {
+	if a.config.MaxCASErrorRate == 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	a.expireCASResults(now)
+	if len(a.casResults) >= MaxCASSamples {
+		a.casResults = a.casResults[len(a.casResults)-MaxCASSamples+1:]
+	}
+	a.casResults = append(a.casResults, casResult{time: now, failed: err != nil})
+}
+
+// Drops CAS results that are older than the window. Caller must hold the lock.
+func (a *admissionController) expireCASResults(now time.Time) {
+	cutoff := now.Add(-a.config.CASErrorWindow)
+	i := 0
+	for i < len(a.casResults) && a.casResults[i].time.Before(cutoff) {
+		i++
+	}
+	a.casResults = a.casResults[i:]
+}
+
+// Returns the fraction of failed CAS requests in the window and the number of requests it's based on.
+// Caller must hold the lock.
+func (a *admissionController) casErrorRate() (float64, int) {
+	a.expireCASResults(time.Now())
+
+	failed := 0
+	for _, r := range a.casResults {
+		if r.failed {
+			failed++
+		}
+	}
+	if len(a.casResults) == 0 {
+		return 0, 0
+	}
+	return float64(failed) / float64(len(a.casResults)), len(a.casResults)
+}
+
+// Returns a ThrottledError if the system is currently saturated, nil otherwise.
+func (a *admissionController) check() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.config.MaxLoadFactor > 0 && a.load > a.config.MaxLoadFactor {
+		return &ThrottledError{
+			RetryAfter: a.config.ThrottleRetryAfter,
+			Reason:     fmt.Sprintf("cluster load %.2f exceeds %.2f", a.load, a.config.MaxLoadFactor),
+		}
+	}
+	if a.config.MaxCASErrorRate > 0 {
+		rate, n := a.casErrorRate()
+		a.stat.Gauge(stats.SchedCASErrorPercentGauge).Update(int64(rate * 100))
+		if n >= a.config.MinCASSamples && rate > a.config.MaxCASErrorRate {
+			return &ThrottledError{
+				RetryAfter: a.config.ThrottleRetryAfter,
+				Reason:     fmt.Sprintf("CAS error rate %.2f exceeds %.2f", rate, a.config.MaxCASErrorRate),
+			}
+		}
+	}
+	return nil
+}
+
+// Waits up to AdmissionWait for the system to recover, returning a ThrottledError if it doesn't.
+func (a *admissionController) admit() error {
+	err := a.check()
+	if err == nil {
+		return nil
+	}
+	deadline := time.Now().Add(a.config.AdmissionWait)
+	for time.Now().Before(deadline) {
+		time.Sleep(TickRate)
+		if err = a.check(); err == nil {
+			a.stat.Counter(stats.SchedDelayedJobsCounter).Inc(1)
+			return nil
+		}
+	}
+	a.stat.Counter(stats.SchedThrottledJobsCounter).Inc(1)
+	log.WithFields(
+		log.Fields{
+			"err": err,
+		}).Info("Throttled job request")
+	return err
+}
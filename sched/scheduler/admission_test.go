@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+func Test_Admission_LoadFactor(t *testing.T) {
+	a := newAdmissionController(AdmissionConfig{MaxLoadFactor: 2}, stats.NilStatsReceiver())
+
+	a.updateLoad(10, 5)
+	if err := a.check(); err != nil {
+		t.Fatalf("Expected job to be admitted at load factor 2, got %v", err)
+	}
+
+	a.updateLoad(11, 5)
+	err := a.check()
+	if !IsThrottledError(err) {
+		t.Fatalf("Expected ThrottledError at load factor 2.2, got %v", err)
+	}
+	if err.(*ThrottledError).RetryAfter != DefaultThrottleRetryAfter {
+		t.Fatalf("Expected default retry after, got %v", err.(*ThrottledError).RetryAfter)
+	}
+
+	// No healthy nodes with work outstanding is saturated.
+	a.updateLoad(3, 0)
+	if err := a.check(); !IsThrottledError(err) {
+		t.Fatalf("Expected ThrottledError with no nodes, got %v", err)
+	}
+}
+
+func Test_Admission_CASErrorRate(t *testing.T) {
+	a := newAdmissionController(
+		AdmissionConfig{MaxCASErrorRate: .5, MinCASSamples: 4, CASErrorWindow: time.Hour}, stats.NilStatsReceiver())
+
+	// Not enough samples to trust the error rate yet.
+	for i := 0; i < 3; i++ {
+		a.recordCASResult(errors.New("unavailable"))
+	}
+	if err := a.check(); err != nil {
+		t.Fatalf("Expected job to be admitted below MinCASSamples, got %v", err)
+	}
+
+	a.recordCASResult(errors.New("unavailable"))
+	if err := a.check(); !IsThrottledError(err) {
+		t.Fatalf("Expected ThrottledError with 100%% CAS errors, got %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		a.recordCASResult(nil)
+	}
+	if err := a.check(); err != nil {
+		t.Fatalf("Expected job to be admitted with 50%% CAS errors, got %v", err)
+	}
+
+	// Results age out of the window.
+	a.config.CASErrorWindow = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, n := a.casErrorRate(); n != 0 {
+		t.Fatalf("Expected all CAS results to expire, got %d", n)
+	}
+
+	// Expired results are dropped as new ones are recorded, and the window is capped.
+	a.recordCASResult(nil)
+	time.Sleep(time.Millisecond)
+	a.recordCASResult(nil)
+	if len(a.casResults) != 1 {
+		t.Fatalf("Expected expired CAS results to be dropped when recording, got %d", len(a.casResults))
+	}
+	a.config.CASErrorWindow = time.Hour
+	for i := 0; i < MaxCASSamples+10; i++ {
+		a.recordCASResult(nil)
+	}
+	if len(a.casResults) != MaxCASSamples {
+		t.Fatalf("Expected at most %d CAS results, got %d", MaxCASSamples, len(a.casResults))
+	}
+}
+
+func Test_StatefulScheduler_ThrottlesJobs(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	deps.config.Admission = AdmissionConfig{MaxLoadFactor: 1, ThrottleRetryAfter: time.Minute}
+	s := makeStatefulSchedulerDeps(deps)
+
+	s.admission.updateLoad(100, len(s.clusterState.nodes))
+	_, err := s.ScheduleJob(sched.GenJobDef(1))
+	if te, ok := err.(*ThrottledError); !ok || te.RetryAfter != time.Minute {
+		t.Fatalf("Expected ThrottledError with 1m retry, got %v", err)
+	}
+
+	// The next step recomputes load from the (empty) set of jobs.
+	s.step()
+	if err := s.admission.check(); err != nil {
+		t.Fatalf("Expected load to drop after step, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched/archive"
+	"github.com/twitter/scoot/workerapi"
+)
+
+// JobArchiver is implemented by schedulers that keep finished jobs in an archive.
+type JobArchiver interface {
+	// Returns the most recently completed archived jobs matching q, most recent first.
+	QueryArchive(q archive.Query) ([]archive.Job, error)
+}
+
+// Returned by QueryArchive if the scheduler doesn't archive finished jobs.
+var ErrNoArchive = errors.New("Scheduler isn't configured with a job archive")
+
+func (s *statefulScheduler) QueryArchive(q archive.Query) ([]archive.Job, error) {
+	if s.config.Archive == nil {
+		return nil, ErrNoArchive
+	}
+	return s.config.Archive.Query(q)
+}
+
+// Returns the record of a job whose saga has ended, as archived and sent to webhooks.
+// Must be called from the scheduler loop.
+func finishedJobRecord(js *jobState) archive.Job {
+	state := js.Saga.GetState()
+	j := archive.Job{
+		ID:         js.Job.Id,
+		Requestor:  js.Job.Def.Requestor,
+		JobType:    js.Job.Def.JobType,
+		Tag:        js.Job.Def.Tag,
+		Labels:     js.Job.Def.Labels,
+		Priority:   int(js.Job.Def.Priority),
+		Killed:     js.JobKilled,
+		RolledBack: state.IsSagaAborted(),
+		Created:    js.TimeCreated,
+		Completed:  time.Now(),
+	}
+	for _, task := range js.Tasks {
+		t := archive.Task{ID: task.TaskId, Attempts: task.Attempts}
+		if data := state.GetEndTaskData(task.TaskId); data != nil {
+			if st, err := workerapi.DeserializeProcessStatus(data); err == nil {
+				t.State = st.State
+				t.ExitCode = st.ExitCode
+				t.Error = st.Error
+				t.SnapshotID = st.SnapshotID
+			}
+		}
+		j.Tasks = append(j.Tasks, t)
+	}
+	return j
+}
+
+// Adds a finished job's record to the archive, if there is one. The archive is written in the background.
+func (s *statefulScheduler) archiveJob(j archive.Job) {
+	if s.config.Archive == nil {
+		return
+	}
+	go func() {
+		if err := s.config.Archive.Add(j); err != nil {
+			s.stat.Counter(stats.SchedArchiveFailuresCounter).Inc(1)
+			log.WithFields(
+				log.Fields{
+					"jobID":     j.ID,
+					"requestor": j.Requestor,
+					"jobType":   j.JobType,
+					"tag":       j.Tag,
+					"err":       err,
+				}).Error("Failed to archive job")
+			return
+		}
+		s.stat.Counter(stats.SchedArchivedJobsCounter).Inc(1)
+	}()
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Workers idle at least this long are offered for scale-down.
+const DefaultAutoscaleIdleThreshold = 10 * time.Minute
+
+// Time between autoscale checks.
+const DefaultAutoscaleInterval = time.Minute
+
+// Timeout for requests to an autoscale webhook.
+const DefaultAutoscaleWebhookTimeout = 10 * time.Second
+
+// Autoscaler is a plugin that resizes the worker pool in response to the scheduler's signals.
+// Signals repeat every check while the condition holds, so implementations should be idempotent.
+type Autoscaler interface {
+	// The given workers have been idle beyond the threshold and may be removed.
+	ScaleDown(workers []IdleWorker) error
+	// Tasks are waiting with no idle worker to run them, count more workers could be used.
+	ScaleUp(count int) error
+}
+
+// AutoscaleConfig enables sending scale signals to an Autoscaler.
+//
+// Autoscaler - receives the signals, autoscaling is disabled if nil.
+// IdleThreshold - workers idle this long are offered for scale-down, DefaultAutoscaleIdleThreshold if zero.
+// Interval - time between checks, DefaultAutoscaleInterval if zero.
+type AutoscaleConfig struct {
+	Autoscaler    Autoscaler
+	IdleThreshold time.Duration
+	Interval      time.Duration
+}
+
+// autoscaler periodically checks the idle workers and queue depth reported by the scheduler loop
+// and signals the Autoscaler. It runs in its own goroutine so a slow plugin never stalls scheduling.
+type autoscaler struct {
+	config AutoscaleConfig
+	idle   *idleTracker
+	queue  *queueTracker
+	stat   stats.StatsReceiver
+	now    func() time.Time
+}
+
+func newAutoscaler(config AutoscaleConfig, idle *idleTracker, queue *queueTracker, stat stats.StatsReceiver) *autoscaler {
+	if config.IdleThreshold == 0 {
+		config.IdleThreshold = DefaultAutoscaleIdleThreshold
+	}
+	if config.Interval == 0 {
+		config.Interval = DefaultAutoscaleInterval
+	}
+	return &autoscaler{config: config, idle: idle, queue: queue, stat: stat, now: time.Now}
+}
+
+func (a *autoscaler) loop() {
+	for range time.Tick(a.config.Interval) {
+		a.check()
+	}
+}
+
+// Signals scale-down when no tasks are waiting and workers have been idle beyond the threshold,
+// and scale-up when tasks are waiting and no worker is idle.
+func (a *autoscaler) check() {
+	now := a.now()
+	waiting := a.queue.get().WaitingTasks
+	idle := a.idle.get(0, now)
+	a.stat.Gauge(stats.SchedIdleWorkersGauge).Update(int64(len(idle)))
+
+	switch {
+	case waiting == 0:
+		idle = a.idle.get(a.config.IdleThreshold, now)
+		if len(idle) == 0 {
+			return
+		}
+		a.stat.Counter(stats.SchedAutoscaleDownCounter).Inc(1)
+		if err := a.config.Autoscaler.ScaleDown(idle); err != nil {
+			a.stat.Counter(stats.SchedAutoscaleFailuresCounter).Inc(1)
+			log.Errorf("Failed to request scale-down of %d idle workers: %v", len(idle), err)
+		}
+	case len(idle) == 0:
+		a.stat.Counter(stats.SchedAutoscaleUpCounter).Inc(1)
+		if err := a.config.Autoscaler.ScaleUp(waiting); err != nil {
+			a.stat.Counter(stats.SchedAutoscaleFailuresCounter).Inc(1)
+			log.Errorf("Failed to request scale-up for %d waiting tasks: %v", waiting, err)
+		}
+	}
+}
+
+// Actions sent to an autoscale webhook.
+const (
+	AutoscaleActionDown = "scale_down"
+	AutoscaleActionUp   = "scale_up"
+)
+
+// JSON body POSTed to an autoscale webhook.
+type AutoscaleRequest struct {
+	Action  string   `json:"action"`
+	Workers []string `json:"workers,omitempty"` // For scale_down, the idle workers that may be removed.
+	Count   int      `json:"count,omitempty"`   // For scale_up, the number of additional workers wanted.
+}
+
+// WebhookAutoscaler is an Autoscaler that POSTs an AutoscaleRequest to a URL, ex: a cluster manager
+// endpoint. Any non-2xx response is an error.
+type WebhookAutoscaler struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookAutoscaler(url string) *WebhookAutoscaler {
+	return &WebhookAutoscaler{URL: url, Client: &http.Client{Timeout: DefaultAutoscaleWebhookTimeout}}
+}
+
+func (w *WebhookAutoscaler) ScaleDown(workers []IdleWorker) error {
+	ids := []string{}
+	for _, worker := range workers {
+		ids = append(ids, string(worker.Id))
+	}
+	return w.post(AutoscaleRequest{Action: AutoscaleActionDown, Workers: ids})
+}
+
+func (w *WebhookAutoscaler) ScaleUp(count int) error {
+	return w.post(AutoscaleRequest{Action: AutoscaleActionUp, Count: count})
+}
+
+func (w *WebhookAutoscaler) post(req AutoscaleRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("autoscale webhook %s returned %s", w.URL, resp.Status)
+	}
+	return nil
+}
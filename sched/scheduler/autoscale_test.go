@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+type recordingAutoscaler struct {
+	down [][]IdleWorker
+	up   []int
+}
+
+func (a *recordingAutoscaler) ScaleDown(workers []IdleWorker) error {
+	a.down = append(a.down, workers)
+	return nil
+}
+
+func (a *recordingAutoscaler) ScaleUp(count int) error {
+	a.up = append(a.up, count)
+	return nil
+}
+
+func Test_StatefulScheduler_IdleWorkers(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.step()
+	if idle := s.GetIdleWorkers(0); len(idle) != 5 {
+		t.Fatalf("Expected all 5 nodes to be idle, got %v", idle)
+	}
+	if idle := s.GetIdleWorkers(time.Hour); len(idle) != 0 {
+		t.Fatalf("Expected no nodes to be idle for an hour, got %v", idle)
+	}
+
+	s.clusterState.taskScheduled("node1", "job1", "task1", "")
+	s.step()
+	for _, w := range s.GetIdleWorkers(0) {
+		if w.Id == "node1" {
+			t.Fatalf("Expected node1 running a task not to be idle")
+		}
+	}
+
+	s.clusterState.taskCompleted("node1", false)
+	s.step()
+	idle := s.GetIdleWorkers(0)
+	if len(idle) != 5 || idle[4].Id != "node1" {
+		t.Fatalf("Expected node1 to be idle for the least time, got %v", idle)
+	}
+}
+
+func Test_Autoscaler_Check(t *testing.T) {
+	now := time.Now()
+	idle := newIdleTracker()
+	idle.set([]IdleWorker{
+		{Id: "node1", IdleSince: now.Add(-time.Hour)},
+		{Id: "node2", IdleSince: now.Add(-time.Second)},
+	})
+	queue := newQueueTracker(stats.NilStatsReceiver())
+	plugin := &recordingAutoscaler{}
+	a := newAutoscaler(AutoscaleConfig{Autoscaler: plugin}, idle, queue, stats.NilStatsReceiver())
+	a.now = func() time.Time { return now }
+
+	// Only the worker idle beyond the threshold is offered for scale-down.
+	a.check()
+	if len(plugin.down) != 1 || len(plugin.down[0]) != 1 || plugin.down[0][0].Id != "node1" || len(plugin.up) != 0 {
+		t.Fatalf("Expected scale-down of node1, got down: %v, up: %v", plugin.down, plugin.up)
+	}
+
+	// Tasks are waiting but workers are idle, perhaps about to take them, so no signal.
+	queue.update(3, 0, 2)
+	a.check()
+	if len(plugin.down) != 1 || len(plugin.up) != 0 {
+		t.Fatalf("Expected no signal, got down: %v, up: %v", plugin.down, plugin.up)
+	}
+
+	// Tasks are waiting and no worker is idle.
+	idle.set([]IdleWorker{})
+	a.check()
+	if !reflect.DeepEqual(plugin.up, []int{3}) {
+		t.Fatalf("Expected scale-up for 3 tasks, got %v", plugin.up)
+	}
+}
+
+func Test_WebhookAutoscaler(t *testing.T) {
+	reqs := []AutoscaleRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		var req AutoscaleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reqs = append(reqs, req)
+	}))
+	defer server.Close()
+
+	w := NewWebhookAutoscaler(server.URL)
+	if err := w.ScaleDown([]IdleWorker{{Id: cluster.NodeId("node1")}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := w.ScaleUp(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []AutoscaleRequest{
+		{Action: AutoscaleActionDown, Workers: []string{"node1"}},
+		{Action: AutoscaleActionUp, Count: 2},
+	}
+	if !reflect.DeepEqual(reqs, expected) {
+		t.Fatalf("Expected %v, got %v", expected, reqs)
+	}
+
+	w.URL = server.URL + "/missing"
+	if err := w.ScaleUp(1); err == nil {
+		t.Fatal("Expected an error for a non-2xx response")
+	}
+}
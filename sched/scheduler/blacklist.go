@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// How long blacklisted workers are kept out of rotation if BlacklistConfig doesn't set a cooldown.
+const DefaultBlacklistCooldown = 30 * time.Minute
+
+// BlacklistConfig stops scheduling tasks to a worker that keeps failing tasks which then succeed when retried
+// on other workers, protecting jobs from a single bad host. The worker is put back in rotation after a cooldown.
+type BlacklistConfig struct {
+	// Workers are blacklisted after failing this many consecutive tasks that then succeeded on another worker.
+	// A task succeeding on the worker resets its count. Zero disables blacklisting.
+	MaxConsecutiveFailures int
+	// How long blacklisted workers aren't given new tasks. DefaultBlacklistCooldown if zero.
+	Cooldown time.Duration
+}
+
+// Tracks the workers each task failed on until the task succeeds elsewhere, and how many such failures
+// each worker had in a row. Only used in the scheduler loop.
+type workerBlacklist struct {
+	config   BlacklistConfig
+	failedOn map[string]map[string][]cluster.NodeId // Keyed by job then task id, nodes the task failed on.
+	failures map[cluster.NodeId]int
+}
+
+func newWorkerBlacklist(config BlacklistConfig) *workerBlacklist {
+	if config.Cooldown == 0 {
+		config.Cooldown = DefaultBlacklistCooldown
+	}
+	return &workerBlacklist{
+		config:   config,
+		failedOn: map[string]map[string][]cluster.NodeId{},
+		failures: map[cluster.NodeId]int{},
+	}
+}
+
+// Records that the task failed on node and is being retried.
+func (b *workerBlacklist) taskFailed(jobId, taskId string, node cluster.NodeId) {
+	if b.config.MaxConsecutiveFailures <= 0 {
+		return
+	}
+	if _, ok := b.failedOn[jobId]; !ok {
+		b.failedOn[jobId] = map[string][]cluster.NodeId{}
+	}
+	b.failedOn[jobId][taskId] = append(b.failedOn[jobId][taskId], node)
+}
+
+// Records that the task succeeded on node, counting a failure against each other node it failed on.
+// Returns the nodes that reached MaxConsecutiveFailures, whose counts start over.
+func (b *workerBlacklist) taskSucceeded(jobId, taskId string, node cluster.NodeId) []cluster.NodeId {
+	if b.config.MaxConsecutiveFailures <= 0 {
+		return nil
+	}
+	delete(b.failures, node)
+	var blacklist []cluster.NodeId
+	for _, failed := range b.failedOn[jobId][taskId] {
+		if failed == node {
+			continue
+		}
+		b.failures[failed]++
+		if b.failures[failed] >= b.config.MaxConsecutiveFailures {
+			delete(b.failures, failed)
+			blacklist = append(blacklist, failed)
+		}
+	}
+	b.taskEnded(jobId, taskId)
+	return blacklist
+}
+
+// Forgets the nodes the task failed on, once it's done whether or not it succeeded.
+func (b *workerBlacklist) taskEnded(jobId, taskId string) {
+	if tasks, ok := b.failedOn[jobId]; ok {
+		delete(tasks, taskId)
+		if len(tasks) == 0 {
+			delete(b.failedOn, jobId)
+		}
+	}
+}
+
+// Stops giving new tasks to the node until the cooldown ends.
+func (s *statefulScheduler) blacklistWorker(nodeId cluster.NodeId) {
+	until := time.Now().Add(s.blacklist.config.Cooldown)
+	if !s.clusterState.blacklistNode(nodeId, until) {
+		return
+	}
+	log.WithFields(
+		log.Fields{
+			"node":                   nodeId,
+			"until":                  until,
+			"maxConsecutiveFailures": s.blacklist.config.MaxConsecutiveFailures,
+		}).Error("Blacklisting worker after it failed tasks that succeeded on other workers")
+	s.stat.Counter(stats.SchedWorkersBlacklistedCounter).Inc(1)
+}
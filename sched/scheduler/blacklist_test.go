@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func TestWorkerBlacklist(t *testing.T) {
+	b := newWorkerBlacklist(BlacklistConfig{MaxConsecutiveFailures: 2})
+	if b.config.Cooldown != DefaultBlacklistCooldown {
+		t.Errorf("Expected the default cooldown, got %v", b.config.Cooldown)
+	}
+
+	b.taskFailed("job1", "task1", "bad")
+	if nodes := b.taskSucceeded("job1", "task1", "good"); len(nodes) != 0 {
+		t.Errorf("Expected nothing blacklisted after one failure, got %v", nodes)
+	}
+	// A task that failed on bad and never succeeded doesn't count against it.
+	b.taskFailed("job1", "task2", "bad")
+	b.taskEnded("job1", "task2")
+	// Nor does a task failing then succeeding on bad itself.
+	b.taskFailed("job2", "task1", "bad")
+	b.taskFailed("job2", "task1", "other")
+	if nodes := b.taskSucceeded("job2", "task1", "bad"); len(nodes) != 0 {
+		t.Errorf("Expected nothing blacklisted, got %v", nodes)
+	}
+	if b.failures["bad"] != 0 || b.failures["other"] != 1 {
+		t.Errorf("Expected bad's count reset by its success, got %v", b.failures)
+	}
+
+	b.taskFailed("job2", "task2", "bad")
+	b.taskFailed("job2", "task3", "bad")
+	if nodes := b.taskSucceeded("job2", "task2", "good"); len(nodes) != 0 {
+		t.Errorf("Expected nothing blacklisted after one failure, got %v", nodes)
+	}
+	if nodes := b.taskSucceeded("job2", "task3", "good"); len(nodes) != 1 || nodes[0] != "bad" {
+		t.Errorf("Expected bad blacklisted after two failures in a row, got %v", nodes)
+	}
+	if len(b.failedOn) != 0 || b.failures["bad"] != 0 {
+		t.Errorf("Expected finished tasks forgotten and bad's count started over, got %v %v", b.failedOn, b.failures)
+	}
+
+	disabled := newWorkerBlacklist(BlacklistConfig{})
+	disabled.taskFailed("job1", "task1", "bad")
+	if nodes := disabled.taskSucceeded("job1", "task1", "good"); len(nodes) != 0 || len(disabled.failedOn) != 0 {
+		t.Errorf("Expected nothing tracked when disabled, got %v %v", nodes, disabled.failedOn)
+	}
+}
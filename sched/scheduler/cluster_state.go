@@ -35,6 +35,7 @@ type clusterState struct {
 	readyFn          ReadyFn                       // If provided, new nodes will be suspended until this returns true.
 	numRunning       int                           // Number of running nodes. running + free + suspended ~= allNodes (may lag)
 	stats            stats.StatsReceiver           // for collecting stats about node availability
+	capacities       *nodeCapacities               // Resources reported by ready nodes, nil if unknown.
 }
 
 type nodeGroup struct {
@@ -54,13 +55,22 @@ type nodeState struct {
 	snapshotId  string
 	timeLost    time.Time        // Time when node was marked lost, if set (lost and flaky are mutually exclusive).
 	timeFlaky   time.Time        // Time when node was marked flaky, if set (lost and flaky are mutually exclusive).
+	timeIdle    time.Time        // Time when node last finished a task or was added, unset while running a task.
+	cordoned    bool             // Set by a NodeUpdated update, the node finishes its current task but isn't given new ones.
 	readyCh     chan interface{} // We create goroutines for each new node which will close this channel once the node is ready.
 	removedCh   chan interface{} // We send nil when a node has been removed and we want the above goroutine to exit.
+	// Set while the node is blacklisted, see BlacklistConfig. Like a cordoned node, it isn't given new tasks until then.
+	blacklistedUntil time.Time
 }
 
 func (n *nodeState) String() string {
-	return fmt.Sprintf("{node:%s, jobId:%s, taskId:%s, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t}",
-		spew.Sdump(n.node), n.runningJob, n.runningTask, n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil))
+	return fmt.Sprintf("{node:%s, jobId:%s, taskId:%s, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t, cordoned:%t, blacklisted:%t}",
+		spew.Sdump(n.node), n.runningJob, n.runningTask, n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil), n.cordoned,
+		n.blacklisted())
+}
+
+func (ns *nodeState) blacklisted() bool {
+	return ns.blacklistedUntil != nilTime
 }
 
 // This node was either reported lost by a NodeUpdate and we keep it around for a bit in case it revives,
@@ -115,6 +125,7 @@ func newNodeState(node cluster.Node) *nodeState {
 		snapshotId:  "",
 		timeLost:    nilTime,
 		timeFlaky:   nilTime,
+		timeIdle:    time.Now(),
 		readyCh:     nil,
 		removedCh:   make(chan interface{}),
 	}
@@ -146,7 +157,28 @@ func newClusterState(initial []cluster.Node, updateCh chan []cluster.NodeUpdate,
 // Number of free nodes that are not in a suspended state.
 func (c *clusterState) numFree() int {
 	// This can go negative due to lost nodes, set lower bound at zero.
-	return max(0, len(c.nodes)-c.numRunning)
+	return max(0, len(c.nodes)-c.numRunning-c.numCordonedIdle())
+}
+
+// Number of healthy nodes that are cordoned or blacklisted and not running anything, which would otherwise count as free.
+func (c *clusterState) numCordonedIdle() int {
+	n := 0
+	for _, ns := range c.nodes {
+		if (ns.cordoned || ns.blacklisted()) && ns.runningTask == noTask {
+			n++
+		}
+	}
+	return n
+}
+
+// Finds a node that's healthy, suspended or offlined.
+func (c *clusterState) findNodeState(nodeId cluster.NodeId) (*nodeState, bool) {
+	for _, nodes := range []map[cluster.NodeId]*nodeState{c.nodes, c.suspendedNodes, c.offlinedNodes} {
+		if ns, ok := nodes[nodeId]; ok {
+			return ns, true
+		}
+	}
+	return nil, false
 }
 
 // Update ClusterState to reflect that a task has been scheduled on a particular node
@@ -168,6 +200,7 @@ func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId, snaps
 	ns.runningJob = jobId
 	ns.runningTask = taskId
 	ns.snapshotId = snapshotId
+	ns.timeIdle = nilTime
 	c.numRunning++
 }
 
@@ -189,6 +222,7 @@ func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
 		}
 		ns.runningJob = noJob
 		ns.runningTask = noTask
+		ns.timeIdle = time.Now()
 		delete(c.nodeGroups[ns.snapshotId].busy, nodeId)
 		c.nodeGroups[ns.snapshotId].idle[nodeId] = ns
 	} else {
@@ -197,6 +231,17 @@ func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
 	c.numRunning--
 }
 
+// Stops giving new tasks to the node until the given time. Returns false if the node isn't known.
+func (c *clusterState) blacklistNode(nodeId cluster.NodeId, until time.Time) bool {
+	ns, ok := c.findNodeState(nodeId)
+	if !ok {
+		log.Infof("Cannot blacklist unknown node: %v", nodeId)
+		return false
+	}
+	ns.blacklistedUntil = until
+	return true
+}
+
 func (c *clusterState) getNodeState(nodeId cluster.NodeId) (*nodeState, bool) {
 	ns, ok := c.nodes[nodeId]
 	return ns, ok
@@ -300,6 +345,14 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 				// We don't know about this node, log spurious remove.
 				log.Infof("Cannot remove unknown node: %v", update.Id)
 			}
+
+		case cluster.NodeUpdated:
+			if ns, ok := c.findNodeState(update.Id); !ok {
+				log.Infof("Cannot update unknown node: %v", update.Id)
+			} else if ns.cordoned != update.Cordoned {
+				ns.cordoned = update.Cordoned
+				log.Infof("Node cordoned=%t: %v (%s), %s", ns.cordoned, update.Id, ns, c.status())
+			}
 		}
 	}
 
@@ -320,6 +373,7 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 			delete(c.suspendedNodes, ns.node.Id())
 			delete(c.nodeGroups[ns.snapshotId].idle, ns.node.Id())
 			delete(c.nodeGroups[ns.snapshotId].busy, ns.node.Id())
+			c.capacities.remove(ns.node.Id())
 			log.Infof("Deleting lost node: %v (%s), %s", ns.node.Id(), ns, c.status())
 			// Try to notify this node's goroutine about removal so it can stop checking readiness if necessary.
 			select {
@@ -345,10 +399,38 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 		}
 	}
 
+	// Give blacklisted nodes new tasks again once their cooldown is over.
+	blacklisted := 0
+	for _, nodes := range []map[cluster.NodeId]*nodeState{c.nodes, c.suspendedNodes, c.offlinedNodes} {
+		for _, ns := range nodes {
+			if ns.blacklisted() && now.After(ns.blacklistedUntil) {
+				ns.blacklistedUntil = nilTime
+				log.Infof("Node blacklist expired, adding back to rotation: %v (%s), %s", ns.node.Id(), ns, c.status())
+			} else if ns.blacklisted() {
+				blacklisted++
+			}
+		}
+	}
+
 	c.stats.Gauge(stats.ClusterAvailableNodes).Update(int64(len(c.nodes)))
 	c.stats.Gauge(stats.ClusterFreeNodes).Update(int64(c.numFree()))
 	c.stats.Gauge(stats.ClusterRunningNodes).Update(int64(c.numRunning))
 	c.stats.Gauge(stats.ClusterLostNodes).Update(int64(len(c.suspendedNodes)))
+	c.stats.Gauge(stats.ClusterCordonedNodes).Update(int64(c.numCordoned()))
+	c.stats.Gauge(stats.ClusterBlacklistedNodes).Update(int64(blacklisted))
+}
+
+// Number of known nodes that are cordoned, whatever their state.
+func (c *clusterState) numCordoned() int {
+	n := 0
+	for _, nodes := range []map[cluster.NodeId]*nodeState{c.nodes, c.suspendedNodes, c.offlinedNodes} {
+		for _, ns := range nodes {
+			if ns.cordoned {
+				n++
+			}
+		}
+	}
+	return n
 }
 
 func (c *clusterState) status() string {
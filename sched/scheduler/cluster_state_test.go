@@ -8,6 +8,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 )
 
 // ensures nodes can be added and removed
@@ -126,6 +127,68 @@ func Test_ClusterState_ReinstateNode(t *testing.T) {
 	}
 }
 
+func Test_ClusterState_CordonNode(t *testing.T) {
+	cs, _, _ := setupTestCluster(nil, "node1", "node2")
+	node1 := cluster.NodeId("node1")
+
+	cs.updateCh <- []cluster.NodeUpdate{cluster.NewCordon(node1, true)}
+	cs.updateCluster()
+	if len(cs.nodes) != 2 || !cs.nodes[node1].cordoned {
+		t.Fatalf("Expected node1 to remain in cs.nodes and be cordoned, got %v", cs.nodes)
+	}
+	if cs.numFree() != 1 {
+		t.Errorf("Expected 1 free node with node1 cordoned, got %d", cs.numFree())
+	}
+	for i := 0; i < 3; i++ {
+		if ns := cs.fitIdleNode(runner.Resources{}, cs.nodeGroups[""].idle, nil); ns == nil || ns.node.Id() == node1 {
+			t.Fatalf("Expected node2 to be picked over the cordoned node1, got %v", ns)
+		}
+	}
+
+	// A cordoned node keeps its running task, and isn't free once it finishes.
+	cs.taskScheduled(node1, "job1", "task1", "")
+	cs.taskCompleted(node1, false)
+	if cs.numFree() != 1 {
+		t.Errorf("Expected 1 free node after cordoned node1 finished its task, got %d", cs.numFree())
+	}
+
+	cs.updateCh <- []cluster.NodeUpdate{cluster.NewCordon(node1, false)}
+	cs.updateCluster()
+	if cs.nodes[node1].cordoned || cs.numFree() != 2 {
+		t.Errorf("Expected node1 uncordoned and 2 free nodes, got %v and %d", cs.nodes[node1], cs.numFree())
+	}
+}
+
+func Test_ClusterState_BlacklistNode(t *testing.T) {
+	cs, _, _ := setupTestCluster(nil, "node1", "node2")
+	node1 := cluster.NodeId("node1")
+
+	if cs.blacklistNode("node3", time.Now().Add(time.Hour)) {
+		t.Error("Expected an unknown node not to be blacklisted")
+	}
+	if !cs.blacklistNode(node1, time.Now().Add(time.Hour)) {
+		t.Fatal("Expected node1 to be blacklisted")
+	}
+	cs.updateCluster()
+	if len(cs.nodes) != 2 || !cs.nodes[node1].blacklisted() {
+		t.Fatalf("Expected node1 to remain in cs.nodes and be blacklisted, got %v", cs.nodes)
+	}
+	if cs.numFree() != 1 {
+		t.Errorf("Expected 1 free node with node1 blacklisted, got %d", cs.numFree())
+	}
+	for i := 0; i < 3; i++ {
+		if ns := cs.fitIdleNode(runner.Resources{}, cs.nodeGroups[""].idle, nil); ns == nil || ns.node.Id() == node1 {
+			t.Fatalf("Expected node2 to be picked over the blacklisted node1, got %v", ns)
+		}
+	}
+
+	cs.nodes[node1].blacklistedUntil = time.Now().Add(-time.Second)
+	cs.updateCluster()
+	if cs.nodes[node1].blacklisted() || cs.numFree() != 2 {
+		t.Errorf("Expected node1's blacklist expired and 2 free nodes, got %v and %d", cs.nodes[node1], cs.numFree())
+	}
+}
+
 func Test_ClusterState_OfflineNodeAlreadyOffline(t *testing.T) {
 	nodeID := "node1"
 	cs, _, _ := setupTestCluster(nil, nodeID)
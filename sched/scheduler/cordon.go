@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/sched"
+)
+
+// Cordoner is implemented by schedulers that can stop giving new tasks to a worker without
+// removing it from the cluster, ex: to drain it for maintenance.
+type Cordoner interface {
+	CordonWorker(req sched.CordonWorkerReq) error
+}
+
+// Cordons or uncordons a worker. Tasks already running on a cordoned worker are left to finish.
+func (s *statefulScheduler) CordonWorker(req sched.CordonWorkerReq) error {
+	if !stringInSlice(req.Requestor, s.config.Admins) && len(s.config.Admins) != 0 {
+		return fmt.Errorf("Requestor %s unauthorized to cordon worker", req.Requestor)
+	}
+	n := cluster.NodeId(req.ID)
+	if _, ok := s.clusterState.findNodeState(n); !ok {
+		return fmt.Errorf("Node %s was not present in the cluster. It can't be cordoned.", req.ID)
+	}
+	log.Infof("Setting worker %s cordoned=%t", req.ID, req.Cordoned)
+	s.clusterState.updateCh <- []cluster.NodeUpdate{cluster.NewCordon(n, req.Cordoned)}
+	return nil
+}
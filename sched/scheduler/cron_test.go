@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	start := time.Date(2018, time.March, 30, 10, 17, 30, 0, time.UTC) // a Friday
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2018, time.March, 30, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2018, time.March, 30, 10, 30, 0, 0, time.UTC)},
+		{"5,10 9-11 * * *", time.Date(2018, time.March, 30, 11, 5, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2018, time.March, 31, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2018, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 12 31 * *", time.Date(2018, time.March, 31, 12, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 0 15 * 1", time.Date(2018, time.April, 2, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", start.Add(90 * time.Minute)},
+	}
+	for _, test := range tests {
+		s, err := ParseCronSchedule(test.spec)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", test.spec, err)
+			continue
+		}
+		if next := s.Next(start); !next.Equal(test.expected) {
+			t.Errorf("%q: expected next %v, got %v", test.spec, test.expected, next)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@every 1ms", "@yearly"} {
+		if _, err := ParseCronSchedule(spec); err == nil {
+			t.Errorf("Expected error parsing %q", spec)
+		}
+	}
+
+	if s, _ := ParseCronSchedule("0 0 31 2 *"); !s.Next(start).IsZero() {
+		t.Errorf("Expected no next time for a schedule that never matches")
+	}
+}
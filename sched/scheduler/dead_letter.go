@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/workerapi"
+)
+
+// Number of dead lettered tasks kept if DeadLetterConfig doesn't set a capacity.
+const DefaultDeadLetterCapacity = 1000
+
+// Number of dead lettered tasks GetDeadLetters returns if the query doesn't set a limit.
+const DefaultDeadLettersLimit = 100
+
+// DeadLetterConfig moves tasks that keep failing with infrastructure errors, ex: a lost worker or a failure
+// to check out their snapshot, to a dead letter queue rather than retrying them. The task is set aside in its
+// job, which stays in progress until the task is requeued once the cause is fixed, or the job is killed.
+// The move is recorded in the job's saga with a DeadLetterTask message, as are the infrastructure failures
+// of each run, so the queue and the failures are recovered with the job if the scheduler restarts.
+type DeadLetterConfig struct {
+	// Tasks are dead lettered on failing with infrastructure errors this many times, and any task given up on
+	// after MaxRetriesPerTask with an infrastructure failure is dead lettered too. Zero disables the queue.
+	MaxInfraFailures int
+	// Most dead lettered tasks kept. When it's full the oldest are ended with the status of their last run,
+	// so their jobs can finish. DefaultDeadLetterCapacity if zero.
+	Capacity int
+}
+
+// A task moved to the dead letter queue.
+type DeadLetter struct {
+	JobID         string
+	TaskID        string
+	Job           sched.JobDefinition // Definition of the task's job, without its tasks.
+	Task          sched.TaskDefinition
+	InfraFailures int
+	Status        runner.RunStatus // Status of the run the task was dead lettered after.
+	Time          time.Time
+}
+
+// Zero values match all dead lettered tasks.
+type DeadLetterQuery struct {
+	JobID     string
+	Requestor string
+	Limit     int // DefaultDeadLettersLimit if <= 0.
+}
+
+// DeadLetterQueue is implemented by schedulers that dead letter tasks repeatedly failing with infrastructure errors.
+type DeadLetterQueue interface {
+	// Returns the dead lettered tasks matching q, most recently dead lettered first.
+	GetDeadLetters(q DeadLetterQuery) []DeadLetter
+	// Removes a task from the dead letter queue and schedules it again in its job, returning the job's id.
+	RequeueDeadLetter(req sched.RequeueTaskReq) (string, error)
+}
+
+// contains the requeue request and callback for the result of processing it
+type deadLetterRequeueRequest struct {
+	req        sched.RequeueTaskReq
+	responseCh chan error
+}
+
+// Persisted as the data of a task's DeadLetterTask saga message.
+type deadLetterRecord struct {
+	InfraFailures int             `json:"infraFailures"`
+	Error         string          `json:"error"`
+	Status        json.RawMessage `json:"status,omitempty"` // Serialized status of the run the task was dead lettered after.
+	Time          time.Time       `json:"time"`
+}
+
+// Persisted as the data of a task's StartTask saga message while the dead letter queue is enabled.
+type taskStartRecord struct {
+	InfraFailures int `json:"infraFailures"` // Infrastructure failures of the task before this run.
+}
+
+// Dead lettered tasks, oldest first, safe to use outside the scheduler loop.
+// Tasks stay in their jobs while queued, and are recovered with them from their sagas.
+type deadLetterQueue struct {
+	mu       sync.Mutex
+	capacity int
+	tasks    []DeadLetter
+	stat     stats.StatsReceiver
+}
+
+func newDeadLetterQueue(config DeadLetterConfig, stat stats.StatsReceiver) *deadLetterQueue {
+	capacity := config.Capacity
+	if capacity <= 0 {
+		capacity = DefaultDeadLetterCapacity
+	}
+	return &deadLetterQueue{capacity: capacity, stat: stat}
+}
+
+// Adds the dead lettered task, returning the oldest tasks dropped if the queue is full.
+func (q *deadLetterQueue) add(dl DeadLetter) []DeadLetter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tasks = append(q.tasks, dl)
+	sort.SliceStable(q.tasks, func(i, j int) bool { return q.tasks[i].Time.Before(q.tasks[j].Time) })
+	var dropped []DeadLetter
+	if len(q.tasks) > q.capacity {
+		dropped = append(dropped, q.tasks[:len(q.tasks)-q.capacity]...)
+		q.tasks = q.tasks[len(q.tasks)-q.capacity:]
+	}
+	q.stat.Gauge(stats.SchedDeadLetterQueueGauge).Update(int64(len(q.tasks)))
+	return dropped
+}
+
+// Removes and returns the dead lettered task, false if it isn't queued.
+func (q *deadLetterQueue) remove(jobID, taskID string) (DeadLetter, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, dl := range q.tasks {
+		if dl.JobID == jobID && dl.TaskID == taskID {
+			q.tasks = append(q.tasks[:i], q.tasks[i+1:]...)
+			q.stat.Gauge(stats.SchedDeadLetterQueueGauge).Update(int64(len(q.tasks)))
+			return dl, true
+		}
+	}
+	return DeadLetter{}, false
+}
+
+func (q *deadLetterQueue) list(query DeadLetterQuery) []DeadLetter {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultDeadLettersLimit
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tasks := []DeadLetter{}
+	for i := len(q.tasks) - 1; i >= 0 && len(tasks) < limit; i-- {
+		dl := q.tasks[i]
+		if (query.JobID == "" || dl.JobID == query.JobID) &&
+			(query.Requestor == "" || dl.Job.Requestor == query.Requestor) {
+			tasks = append(tasks, dl)
+		}
+	}
+	return tasks
+}
+
+// Queues the dead lettered tasks of a job being recovered, returning the oldest tasks dropped if the queue is full.
+func (q *deadLetterQueue) recover(js *jobState) []DeadLetter {
+	var dropped []DeadLetter
+	for _, task := range js.Tasks {
+		if !task.DeadLettered {
+			continue
+		}
+		rec := readDeadLetterRecord(js, task.TaskId)
+		st, _ := workerapi.DeserializeProcessStatus(rec.Status)
+		dropped = append(dropped, q.add(newDeadLetter(js, task, rec.InfraFailures, st, rec.Time))...)
+	}
+	return dropped
+}
+
+// Reads the record of a task's DeadLetterTask saga message, empty if it can't be read.
+func readDeadLetterRecord(js *jobState, taskId string) deadLetterRecord {
+	rec := deadLetterRecord{}
+	if err := json.Unmarshal(js.Saga.GetState().GetDeadLetterTaskData(taskId), &rec); err != nil {
+		log.Errorf("Failed to read dead letter record of task %s, job %s: %v", taskId, js.Job.Id, err)
+	}
+	return rec
+}
+
+func newDeadLetter(js *jobState, task *taskState, infraFailures int, st runner.RunStatus, t time.Time) DeadLetter {
+	job := js.Job.Def
+	job.Tasks = nil
+	return DeadLetter{
+		JobID:         js.Job.Id,
+		TaskID:        task.TaskId,
+		Job:           job,
+		Task:          task.Def,
+		InfraFailures: infraFailures,
+		Status:        st,
+		Time:          t,
+	}
+}
+
+func (s *statefulScheduler) GetDeadLetters(q DeadLetterQuery) []DeadLetter {
+	return s.deadLetters.list(q)
+}
+
+// Requeues a dead lettered task in its job, returning the job's id. Put the request on a channel that is
+// processed by the main scheduler loop, and wait for the response.
+func (s *statefulScheduler) RequeueDeadLetter(req sched.RequeueTaskReq) (string, error) {
+	if !stringInSlice(req.Requestor, s.config.Admins) && len(s.config.Admins) != 0 {
+		return "", fmt.Errorf("Requestor %s unauthorized to requeue dead lettered task", req.Requestor)
+	}
+	responseCh := make(chan error, 1)
+	s.requeueCh <- deadLetterRequeueRequest{req: req, responseCh: responseCh}
+	if err := <-responseCh; err != nil {
+		return "", err
+	}
+	return req.JobID, nil
+}
+
+// process all requeue requests, scheduling each task again with its infrastructure failures and retries reset.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) requeueDeadLetters() {
+	for {
+		select {
+		case r := <-s.requeueCh:
+			r.responseCh <- s.requeueDeadLetter(r.req)
+		default:
+			return
+		}
+	}
+}
+
+func (s *statefulScheduler) requeueDeadLetter(req sched.RequeueTaskReq) error {
+	jobState := s.getJob(req.JobID)
+	var task *taskState
+	if jobState != nil {
+		task = jobState.getTask(req.TaskID)
+	}
+	if task == nil || !task.DeadLettered {
+		return fmt.Errorf("Task %s of Job Id %s isn't dead lettered", req.TaskID, req.JobID)
+	}
+	s.deadLetters.remove(req.JobID, req.TaskID)
+	task.DeadLettered = false
+	task.InfraFailures = 0
+	task.NumTimesTried = 0
+	log.WithFields(
+		log.Fields{
+			"jobID":     req.JobID,
+			"taskID":    req.TaskID,
+			"requestor": req.Requestor,
+		}).Info("Requeued dead lettered task")
+	s.stat.Counter(stats.SchedRequeuedDeadLettersCounter).Inc(1)
+	return nil
+}
+
+// Ends dead lettered tasks dropped from the full queue with the status of their last run, so their jobs can finish.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) endDeadLetters(dropped []DeadLetter) {
+	for _, dl := range dropped {
+		jobState := s.getJob(dl.JobID)
+		if jobState == nil {
+			continue
+		}
+		task := jobState.getTask(dl.TaskID)
+		if task == nil || !task.DeadLettered {
+			continue
+		}
+		logFields := log.Fields{
+			"jobID":     dl.JobID,
+			"taskID":    dl.TaskID,
+			"requestor": jobState.Job.Def.Requestor,
+			"jobType":   jobState.Job.Def.JobType,
+			"tag":       jobState.Job.Def.Tag,
+		}
+		statusAsBytes, err := workerapi.SerializeProcessStatus(dl.Status)
+		if err != nil {
+			s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
+		}
+		if err := jobState.Saga.EndTask(dl.TaskID, statusAsBytes); err != nil {
+			// Still set aside, so it can be requeued by id or ended with its job.
+			logFields["err"] = err
+			log.WithFields(logFields).Error("Dead letter queue full, failed to end dropped task")
+			continue
+		}
+		task.DeadLettered = false
+		jobState.taskCompleted(dl.TaskID, false)
+		s.blacklist.taskEnded(dl.JobID, dl.TaskID)
+		log.WithFields(logFields).Info("Dead letter queue full, ended dropped task")
+	}
+}
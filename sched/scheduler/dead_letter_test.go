@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	q := newDeadLetterQueue(DeadLetterConfig{MaxInfraFailures: 3, Capacity: 2}, stats.NilStatsReceiver())
+	now := time.Now()
+	q.add(DeadLetter{JobID: "job1", TaskID: "task1", Time: now.Add(-2 * time.Minute)})
+	q.add(DeadLetter{JobID: "job2", TaskID: "task1", Job: sched.JobDefinition{Requestor: "someone"}, Time: now})
+	// Recovered out of order, older than job2's task.
+	dropped := q.add(DeadLetter{JobID: "job1", TaskID: "task2", Time: now.Add(-time.Minute)})
+	if len(dropped) != 1 || dropped[0].JobID != "job1" || dropped[0].TaskID != "task1" {
+		t.Fatalf("Expected the oldest task dropped so it can be ended, got %v", dropped)
+	}
+
+	tasks := q.list(DeadLetterQuery{})
+	if len(tasks) != 2 || tasks[0].JobID != "job2" || tasks[1].TaskID != "task2" {
+		t.Fatalf("Expected the oldest task dropped and the rest newest first, got %v", tasks)
+	}
+	if tasks := q.list(DeadLetterQuery{Requestor: "someone"}); len(tasks) != 1 || tasks[0].JobID != "job2" {
+		t.Errorf("Expected job2's task, got %v", tasks)
+	}
+	if tasks := q.list(DeadLetterQuery{JobID: "job1"}); len(tasks) != 1 || tasks[0].TaskID != "task2" {
+		t.Errorf("Expected job1's task2, got %v", tasks)
+	}
+	if tasks := q.list(DeadLetterQuery{Limit: 1}); len(tasks) != 1 || tasks[0].JobID != "job2" {
+		t.Errorf("Expected only the newest task, got %v", tasks)
+	}
+
+	if _, ok := q.remove("job1", "task1"); ok {
+		t.Error("Expected the dropped task not to be queued")
+	}
+	if dl, ok := q.remove("job1", "task2"); !ok || dl.TaskID != "task2" {
+		t.Errorf("Expected task2 removed, got %v %v", dl, ok)
+	}
+	if tasks := q.list(DeadLetterQuery{}); len(tasks) != 1 || tasks[0].JobID != "job2" {
+		t.Errorf("Expected only job2's task left, got %v", tasks)
+	}
+}
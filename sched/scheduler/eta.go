@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// Predicted completion of a job in progress, based on previous durations of its tasks' commands.
+type JobETA struct {
+	// When all of the job's tasks are predicted to complete, only set if Known.
+	Done  time.Time
+	Known bool // false if an unfinished task's command has no history to predict from.
+	// When each unfinished task with a prediction is predicted to complete, by taskId.
+	Tasks map[string]time.Time
+}
+
+// ETAReporter is implemented by schedulers that can predict when in progress jobs will complete.
+type ETAReporter interface {
+	// Returns the job's ETA, or false if the job isn't in progress.
+	GetJobETA(jobId string) (JobETA, bool)
+}
+
+// etaTracker holds the ETAs of in progress jobs as of the last scheduler loop.
+// It is safe to use outside the scheduler loop.
+type etaTracker struct {
+	mu   sync.Mutex
+	etas map[string]JobETA
+}
+
+func newETATracker() *etaTracker {
+	return &etaTracker{etas: map[string]JobETA{}}
+}
+
+func (e *etaTracker) get(jobId string) (JobETA, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	eta, ok := e.etas[jobId]
+	return eta, ok
+}
+
+func (e *etaTracker) set(etas map[string]JobETA) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.etas = etas
+}
+
+// Running tasks are predicted to complete their predicted duration after they started, or now if they're
+// overdue. Waiting tasks are predicted to start after the estimated queue wait. This function is part of the
+// main scheduler loop.
+func (s *statefulScheduler) updateETAs() {
+	now := time.Now()
+	wait := s.queue.get().EstimatedWait
+	etas := make(map[string]JobETA, len(s.inProgressJobs))
+	for _, js := range s.inProgressJobs {
+		eta := JobETA{Done: now, Known: true, Tasks: map[string]time.Time{}}
+		for _, task := range js.Tasks {
+			if task.Status == sched.Completed {
+				continue
+			}
+			if task.AvgDuration == noPrediction {
+				eta.Known = false
+				continue
+			}
+			done := now.Add(wait).Add(task.AvgDuration)
+			if task.Status == sched.InProgress {
+				done = task.TimeStarted.Add(task.AvgDuration)
+				if done.Before(now) {
+					done = now
+				}
+			}
+			eta.Tasks[task.TaskId] = done
+			if done.After(eta.Done) {
+				eta.Done = done
+			}
+		}
+		if !eta.Known {
+			eta.Done = time.Time{}
+		}
+		etas[js.Job.Id] = eta
+	}
+	s.etas.set(etas)
+}
+
+func (s *statefulScheduler) GetJobETA(jobId string) (JobETA, bool) {
+	return s.etas.get(jobId)
+}
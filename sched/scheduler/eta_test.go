@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/tests/testhelpers"
+)
+
+func Test_StatefulScheduler_JobETA(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 3)
+	for i := range job.Def.Tasks {
+		job.Def.Tasks[i].Argv = []string{"task", job.Def.Tasks[i].TaskID}
+	}
+	running, waiting, unknown := job.Def.Tasks[0], job.Def.Tasks[1], job.Def.Tasks[2]
+	s.taskHistory.record(running, 10*time.Minute)
+	s.taskHistory.record(waiting, time.Minute)
+
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinatorNoGC().MakeSaga(job.Id, jobAsBytes)
+	js := newJobState(&job, saga, s.taskHistory)
+	js.taskStarted(running.TaskID, &taskRunner{})
+	s.inProgressJobs = append(s.inProgressJobs, js)
+
+	// The third task's command has never run, so the job can't be predicted.
+	s.updateETAs()
+	eta, ok := s.GetJobETA(job.Id)
+	if !ok || eta.Known || len(eta.Tasks) != 2 {
+		t.Fatalf("Expected ETAs for only the tasks with history, got %+v %t", eta, ok)
+	}
+	if d := eta.Tasks[running.TaskID].Sub(js.getTask(running.TaskID).TimeStarted); d != 10*time.Minute {
+		t.Fatalf("Expected running task to finish 10m after it started, got %v", d)
+	}
+	if d := eta.Tasks[waiting.TaskID].Sub(time.Now()); d > time.Minute || d < 59*time.Second {
+		t.Fatalf("Expected waiting task to finish in about 1m, got %v", d)
+	}
+
+	// Once the unknown task completes, the job completes with its longest task.
+	js.taskCompleted(unknown.TaskID, false)
+	s.updateETAs()
+	eta, _ = s.GetJobETA(job.Id)
+	if !eta.Known || !eta.Done.Equal(eta.Tasks[running.TaskID]) {
+		t.Fatalf("Expected job to complete with its running task, got %+v", eta)
+	}
+
+	if _, ok := s.GetJobETA("missing"); ok {
+		t.Fatalf("Expected no ETA for a job that isn't in progress")
+	}
+}
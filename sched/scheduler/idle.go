@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// A healthy worker that isn't running a task.
+type IdleWorker struct {
+	Id        cluster.NodeId
+	IdleSince time.Time
+}
+
+// IdleReporter is implemented by schedulers that track how long their workers have been idle.
+type IdleReporter interface {
+	// Returns the workers that have been idle for at least minIdle, longest idle first.
+	GetIdleWorkers(minIdle time.Duration) []IdleWorker
+}
+
+// idleTracker holds the idle workers as of the last scheduler loop.
+// It is safe to use outside the scheduler loop.
+type idleTracker struct {
+	mu      sync.Mutex
+	workers []IdleWorker // Sorted longest idle first.
+}
+
+func newIdleTracker() *idleTracker {
+	return &idleTracker{}
+}
+
+func (t *idleTracker) set(workers []IdleWorker) {
+	sort.Slice(workers, func(i, j int) bool { return workers[i].IdleSince.Before(workers[j].IdleSince) })
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.workers = workers
+}
+
+func (t *idleTracker) get(minIdle time.Duration, now time.Time) []IdleWorker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	workers := []IdleWorker{}
+	for _, w := range t.workers {
+		if now.Sub(w.IdleSince) >= minIdle {
+			workers = append(workers, w)
+		}
+	}
+	return workers
+}
+
+// Records the healthy nodes that aren't running a task. Suspended, offlined, cordoned and blacklisted nodes
+// aren't idle, they can't take tasks. This function is part of the main scheduler loop.
+func (s *statefulScheduler) updateIdleWorkers() {
+	workers := []IdleWorker{}
+	for id, ns := range s.clusterState.nodes {
+		if ns.runningTask == noTask && !ns.suspended() && !ns.cordoned && !ns.blacklisted() && ns.timeIdle != nilTime {
+			workers = append(workers, IdleWorker{Id: id, IdleSince: ns.timeIdle})
+		}
+	}
+	s.idle.set(workers)
+}
+
+func (s *statefulScheduler) GetIdleWorkers(minIdle time.Duration) []IdleWorker {
+	return s.idle.get(minIdle, time.Now())
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Number of most recently added jobs kept searchable by FindJobs, including jobs that have finished.
+const DefaultJobIndexSize = 10000
+
+// Number of jobs FindJobs returns if the caller doesn't set a limit.
+const DefaultFindJobsLimit = 100
+
+// Summary of a job found by FindJobs. Status isn't included, it can be read from the job's saga.
+type JobSummary struct {
+	ID        string
+	Requestor string
+	Tag       string
+	Labels    map[string]string
+	Created   time.Time
+}
+
+// JobFinder is implemented by schedulers that can search recent jobs by label.
+type JobFinder interface {
+	// Returns up to limit recent jobs having all of labels, most recent first.
+	// If limit <= 0, at most DefaultFindJobsLimit are returned.
+	FindJobs(labels map[string]string, limit int) []JobSummary
+	// Returns the recent job with the given id, false if it isn't indexed.
+	FindJob(id string) (JobSummary, bool)
+}
+
+// A fixed size ring of the most recently added jobs, safe to use outside the scheduler loop.
+// Jobs are only indexed while the scheduler is up, finished jobs aren't recovered on restart.
+type jobIndex struct {
+	mu   sync.Mutex
+	jobs []JobSummary
+	next int
+}
+
+func newJobIndex(size int) *jobIndex {
+	if size <= 0 {
+		size = DefaultJobIndexSize
+	}
+	return &jobIndex{jobs: make([]JobSummary, 0, size)}
+}
+
+func (i *jobIndex) add(js *jobState) {
+	j := JobSummary{
+		ID:        js.Job.Id,
+		Requestor: js.Job.Def.Requestor,
+		Tag:       js.Job.Def.Tag,
+		Labels:    js.Job.Def.Labels,
+		Created:   js.TimeCreated,
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.jobs) < cap(i.jobs) {
+		i.jobs = append(i.jobs, j)
+		return
+	}
+	i.jobs[i.next] = j
+	i.next = (i.next + 1) % len(i.jobs)
+}
+
+func (i *jobIndex) find(labels map[string]string, limit int) []JobSummary {
+	if limit <= 0 {
+		limit = DefaultFindJobsLimit
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	found := []JobSummary{}
+	// Walk backwards from the most recently added job.
+	for n := 0; n < len(i.jobs) && len(found) < limit; n++ {
+		j := i.jobs[(i.next-1-n+2*len(i.jobs))%len(i.jobs)]
+		if hasLabels(j.Labels, labels) {
+			found = append(found, j)
+		}
+	}
+	return found
+}
+
+func (i *jobIndex) get(id string) (JobSummary, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, j := range i.jobs {
+		if j.ID == id {
+			return j, true
+		}
+	}
+	return JobSummary{}, false
+}
+
+// Returns true if have contains every key/value in want.
+func hasLabels(have, want map[string]string) bool {
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *statefulScheduler) FindJobs(labels map[string]string, limit int) []JobSummary {
+	return s.jobIndex.find(labels, limit)
+}
+
+func (s *statefulScheduler) FindJob(id string) (JobSummary, bool) {
+	return s.jobIndex.get(id)
+}
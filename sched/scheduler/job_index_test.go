@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+)
+
+func makeIndexedJob(id string, labels map[string]string) *jobState {
+	return &jobState{Job: &sched.Job{Id: id, Def: sched.JobDefinition{Labels: labels}}}
+}
+
+func Test_JobIndex_Find(t *testing.T) {
+	i := newJobIndex(3)
+	i.add(makeIndexedJob("1", map[string]string{"repo": "source", "pr": "1"}))
+	i.add(makeIndexedJob("2", map[string]string{"repo": "source", "pr": "2"}))
+	i.add(makeIndexedJob("3", nil))
+
+	if found := i.find(map[string]string{"repo": "source"}, 0); len(found) != 2 || found[0].ID != "2" || found[1].ID != "1" {
+		t.Fatalf("Expected jobs 2, 1 most recent first, got %v", found)
+	}
+	if found := i.find(map[string]string{"pr": "1"}, 0); len(found) != 1 || found[0].ID != "1" {
+		t.Fatalf("Expected job 1, got %v", found)
+	}
+	if found := i.find(nil, 2); len(found) != 2 || found[0].ID != "3" {
+		t.Fatalf("Expected the 2 most recent jobs, got %v", found)
+	}
+
+	// The oldest jobs are dropped once the index is full.
+	i.add(makeIndexedJob("4", map[string]string{"repo": "source", "pr": "4"}))
+	found := i.find(map[string]string{"repo": "source"}, 0)
+	if len(found) != 2 || found[0].ID != "4" || found[1].ID != "2" {
+		t.Fatalf("Expected jobs 4, 2, got %v", found)
+	}
+	if j, ok := i.get("2"); !ok || j.Labels["pr"] != "2" {
+		t.Fatalf("Expected job 2, got %v", j)
+	}
+	if _, ok := i.get("1"); ok {
+		t.Fatal("Expected job 1 to have been dropped")
+	}
+}
+
+func Test_StatefulScheduler_FindJobs(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+	s, _, _ := initializeServices(sc, true)
+
+	ids := []string{}
+	for n := 0; n < 2; n++ {
+		jobDef := sched.GenJobDef(1)
+		jobDef.Labels = map[string]string{"pr": fmt.Sprint(n)}
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		id, err := s.ScheduleJob(jobDef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+		s.step()
+	}
+
+	found := s.FindJobs(map[string]string{"pr": "1"}, 0)
+	if len(found) != 1 || found[0].ID != ids[1] || found[0].Created.IsZero() {
+		t.Fatalf("Expected job %s, got %v", ids[1], found)
+	}
+}
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"encoding/json"
 	"math"
 	"time"
 
@@ -18,6 +19,8 @@ type jobState struct {
 	TasksCompleted int          //number of tasks that've been marked completed so far.
 	TasksRunning   int          //number of tasks that've been scheduled or started.
 	JobKilled      bool         //indicates the job was killed
+	JobTimedOut    bool         //indicates the job was killed for exceeding its timeout, and will be rolled back
+	Paused         bool         //indicates the job was paused, and none of its tasks should be scheduled
 	TimeCreated    time.Time    //when was this job first created
 	TimeMarker     time.Time    //when was this job last marked (i.e. for reporting purposes)
 }
@@ -30,10 +33,16 @@ type taskState struct {
 	Status        sched.Status
 	TimeStarted   time.Time
 	NumTimesTried int
+	Attempts      int  //runs started, including before recovery. Unlike NumTimesTried, never decremented.
+	InfraFailures int  //runs that failed with infrastructure errors since the task was added or requeued, see DeadLetterConfig.
+	DeadLettered  bool //in the dead letter queue, not scheduled until it's requeued.
 	TaskRunner    *taskRunner
-	AvgDuration   time.Duration //average duration for previous runs with this taskId, if any.
+	AvgDuration   time.Duration //predicted duration from previous runs of this command, if any.
 }
 
+// AvgDuration of tasks whose command has no history, so they sort before tasks with a prediction.
+const noPrediction = time.Duration(math.MaxInt64)
+
 type taskStatesByDuration []*taskState
 
 func (s taskStatesByDuration) Len() int {
@@ -48,8 +57,13 @@ func (s taskStatesByDuration) Less(i, j int) bool {
 
 // Creates a New Job State based on the specified Job and Saga
 // The jobState will reflect any previous progress made on this job and logged to the Sagalog
-// Note: taskDurations is optional and only used to enable sorts using taskStatesByDuration above.
-func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]averageDuration) *jobState {
+// Note: history is optional and only used to enable sorts using taskStatesByDuration above.
+func newJobState(job *sched.Job, saga *saga.Saga, history *taskHistory) *jobState {
+	// Jobs logged before their creation time was kept start over when recovered.
+	created := job.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
 	j := &jobState{
 		Job:            job,
 		Saga:           saga,
@@ -58,14 +72,15 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 		TasksCompleted: 0,
 		TasksRunning:   0,
 		JobKilled:      false,
-		TimeCreated:    time.Now(),
+		Paused:         saga.GetState().IsSagaPaused(),
+		TimeCreated:    created,
 		TimeMarker:     time.Now(),
 	}
 
 	for _, taskDef := range job.Def.Tasks {
-		duration := taskDurations[taskDef.TaskID].duration // This is safe since the map value is not a pointer.
-		if duration == 0 {
-			duration = math.MaxInt64 // Set max duration if we don't have the average duration.
+		duration, ok := history.predict(taskDef)
+		if !ok {
+			duration = noPrediction // Set max duration if we don't have a prediction.
 		}
 		task := &taskState{
 			JobId:         job.Id,
@@ -82,11 +97,22 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 	// Assumes Forward Recovery only, tasks are either
 	// done or not done.  Scheduler currently doesn't support
 	// scheduling compensating tasks.  In Progress tasks
-	// are considered not done and will be rescheduled.
-	for _, taskId := range saga.GetState().GetTaskIds() {
-		if saga.GetState().IsTaskCompleted(taskId) {
-			j.getTask(taskId).Status = sched.Completed
+	// are considered not done and will be rescheduled, unless they were dead lettered.
+	state := saga.GetState()
+	for _, taskId := range state.GetTaskIds() {
+		task := j.getTask(taskId)
+		task.Attempts = state.GetTaskAttempt(taskId)
+		if state.IsTaskCompleted(taskId) {
+			task.Status = sched.Completed
 			j.TasksCompleted++
+		} else if state.IsTaskDeadLettered(taskId) {
+			task.DeadLettered = true
+			task.InfraFailures = readDeadLetterRecord(j, taskId).InfraFailures
+		} else {
+			rec := taskStartRecord{}
+			if json.Unmarshal(state.GetStartTaskData(taskId), &rec) == nil {
+				task.InfraFailures = rec.InfraFailures
+			}
 		}
 	}
 
@@ -105,11 +131,14 @@ func (j *jobState) getTask(taskId string) *taskState {
 
 // Returns a list of taskIds that can be scheduled currently.
 func (j *jobState) getUnScheduledTasks() []*taskState {
+	if j.Paused {
+		return nil
+	}
 
 	var tasksToRun []*taskState
 
 	for _, state := range j.Tasks {
-		if state.Status == sched.NotStarted {
+		if state.Status == sched.NotStarted && !state.DeadLettered {
 			tasksToRun = append(tasksToRun, state)
 		}
 	}
@@ -124,6 +153,7 @@ func (j *jobState) taskStarted(taskId string, tr *taskRunner) {
 	taskState.TimeStarted = time.Now()
 	taskState.TaskRunner = tr
 	taskState.NumTimesTried++
+	taskState.Attempts++
 	j.TasksRunning++
 }
 
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched"
@@ -22,6 +23,23 @@ func Test_GetUnscheduledTasks_ReturnsAllUnscheduledTasks(t *testing.T) {
 	}
 }
 
+func Test_NewJobState_RecoveredKeepsTimeCreated(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 1)
+	job.Created = time.Now().Add(-time.Hour)
+	jobAsBytes, _ := job.Serialize()
+
+	// The job recovered from its saga was created when it was first scheduled, not when it was recovered.
+	saga, _ := sagalogs.MakeInMemorySagaCoordinatorNoGC().MakeSaga(job.Id, jobAsBytes)
+	recovered, err := sched.DeserializeJob(saga.GetState().Job())
+	if err != nil {
+		t.Fatalf("Unexpected error deserializing job: %v", err)
+	}
+	jobState := newJobState(recovered, saga, nil)
+	if !jobState.TimeCreated.Equal(job.Created) {
+		t.Errorf("Expected TimeCreated %s, got %s", job.Created, jobState.TimeCreated)
+	}
+}
+
 func Test_NewJobState_PreviousProgress_StartedTasks(t *testing.T) {
 	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 1)
 	jobAsBytes, _ := job.Serialize()
@@ -56,3 +74,43 @@ func Test_NewJobState_PreviousProgress_CompletedTasks(t *testing.T) {
 		t.Errorf("Expected all Tasks to be completed")
 	}
 }
+
+func Test_NewJobState_PreviousProgress_TaskAttempts(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 1)
+	jobAsBytes, _ := job.Serialize()
+
+	// Two attempts were started before recovery, the next run must be numbered after them.
+	saga, _ := sagalogs.MakeInMemorySagaCoordinatorNoGC().MakeSaga(job.Id, jobAsBytes)
+	taskId := job.Def.Tasks[0].TaskID
+	saga.StartTaskAttempt(taskId, 1, nil)
+	saga.StartTaskAttempt(taskId, 2, nil)
+	jobState := newJobState(&job, saga, nil)
+
+	jobState.taskStarted(taskId, &taskRunner{})
+	if attempts := jobState.getTask(taskId).Attempts; attempts != 3 {
+		t.Errorf("Expected the next run to be attempt 3, got %d", attempts)
+	}
+}
+
+func Test_NewJobState_PreviousProgress_DeadLetteredTasks(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 2)
+	jobAsBytes, _ := job.Serialize()
+
+	// One task was dead lettered and the other failed twice with infrastructure errors before recovery.
+	saga, _ := sagalogs.MakeInMemorySagaCoordinatorNoGC().MakeSaga(job.Id, jobAsBytes)
+	deadLettered, retried := job.Def.Tasks[0].TaskID, job.Def.Tasks[1].TaskID
+	saga.StartTaskAttempt(deadLettered, 3, []byte(`{"infraFailures":2}`))
+	saga.DeadLetterTask(deadLettered, 3, []byte(`{"infraFailures":3,"error":"lost worker"}`))
+	saga.StartTaskAttempt(retried, 3, []byte(`{"infraFailures":2}`))
+	jobState := newJobState(&job, saga, nil)
+
+	if task := jobState.getTask(deadLettered); !task.DeadLettered || task.InfraFailures != 3 {
+		t.Errorf("Expected the task dead lettered after 3 infrastructure failures, got %t, %d", task.DeadLettered, task.InfraFailures)
+	}
+	if task := jobState.getTask(retried); task.DeadLettered || task.InfraFailures != 2 {
+		t.Errorf("Expected the task to have 2 infrastructure failures, got %t, %d", task.DeadLettered, task.InfraFailures)
+	}
+	if tasks := jobState.getUnScheduledTasks(); len(tasks) != 1 || tasks[0].TaskId != retried {
+		t.Errorf("Expected only the task that wasn't dead lettered to be unscheduled, got %v", tasks)
+	}
+}
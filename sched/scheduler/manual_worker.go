@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/sched"
+)
+
+// WorkerManager is implemented by schedulers that can add or remove workers by hand, ahead of the
+// cluster's discovery, ex: to bring up a replacement while discovery is slow or unavailable.
+type WorkerManager interface {
+	AddWorker(req sched.ManualWorkerReq) error
+	RemoveWorker(req sched.ManualWorkerReq) error
+}
+
+// Adds a worker to the cluster the scheduler's nodes come from, see cluster.Cluster.Add.
+// It still waits for the readiness check before being given tasks.
+func (s *statefulScheduler) AddWorker(req sched.ManualWorkerReq) error {
+	if err := s.checkManualWorkerReq(req); err != nil {
+		return err
+	}
+	log.Infof("Adding worker %s requested by %s", req.ID, req.Requestor)
+	if err := s.cluster.Add(cluster.NewIdNode(req.ID)); err != nil {
+		return fmt.Errorf("Node %s can't be added: %v", req.ID, err)
+	}
+	return nil
+}
+
+// Removes a worker from the cluster the scheduler's nodes come from, see cluster.Cluster.Remove.
+// It's treated as lost: it's given no new tasks and is forgotten after the lost node timeout.
+func (s *statefulScheduler) RemoveWorker(req sched.ManualWorkerReq) error {
+	if err := s.checkManualWorkerReq(req); err != nil {
+		return err
+	}
+	log.Infof("Removing worker %s requested by %s", req.ID, req.Requestor)
+	if err := s.cluster.Remove(cluster.NodeId(req.ID)); err != nil {
+		return fmt.Errorf("Node %s can't be removed: %v", req.ID, err)
+	}
+	return nil
+}
+
+// Checks the request carries the configured AdminToken. The requestor it names is self-asserted
+// so it isn't trusted, and requests are refused if there's no token to check against.
+func (s *statefulScheduler) checkManualWorkerReq(req sched.ManualWorkerReq) error {
+	if s.config.AdminToken == "" {
+		return errors.New("Workers can't be added or removed by hand, the scheduler has no admin token configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(req.AdminToken), []byte(s.config.AdminToken)) != 1 {
+		return fmt.Errorf("Requestor %s unauthorized to add or remove workers, invalid admin token", req.Requestor)
+	}
+	if s.cluster == nil {
+		return errors.New("Workers can't be added or removed by hand, the scheduler wasn't created from a cluster")
+	}
+	return nil
+}
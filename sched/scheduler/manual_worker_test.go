@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/sched"
+)
+
+func Test_StatefulScheduler_AddRemoveWorker(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	cl := cluster.NewCluster(deps.initialCl, nil, nil)
+	defer cl.Close()
+	sub := cl.Subscribe()
+	defer sub.Closer.Close()
+	deps.initialCl, deps.clUpdates = sub.InitialMembers, sub.Updates
+	deps.config.AdminToken = "secret"
+	s := makeStatefulSchedulerDeps(deps)
+
+	req := sched.ManualWorkerReq{ID: "node6", Requestor: "admin", AdminToken: "secret"}
+	if err := s.AddWorker(req); err == nil {
+		t.Fatal("Expected adding a worker to fail without a cluster")
+	}
+	s.cluster = cl
+	if err := s.AddWorker(sched.ManualWorkerReq{ID: "node6", Requestor: "admin", AdminToken: "guess"}); err == nil {
+		t.Fatal("Expected a request with the wrong admin token to be rejected")
+	}
+	if err := s.RemoveWorker(req); err == nil {
+		t.Fatal("Expected removing a node that isn't in the cluster to fail")
+	}
+	if err := s.AddWorker(req); err != nil {
+		t.Fatalf("Unexpected error adding worker: %v", err)
+	}
+	s.clusterState.update(<-sub.Updates)
+	if _, ok := s.clusterState.findNodeState(cluster.NodeId("node6")); !ok {
+		t.Fatal("Expected node6 to be added to the cluster")
+	}
+	if members := cl.Members(); len(members) != 6 {
+		t.Fatalf("Expected node6 to be a member of the cluster, got %v", members)
+	}
+	if err := s.AddWorker(req); err == nil {
+		t.Fatal("Expected adding a node that's already in the cluster to fail")
+	}
+
+	// A removed node is treated as lost, so it's suspended until the lost node timeout.
+	if err := s.RemoveWorker(req); err != nil {
+		t.Fatalf("Unexpected error removing worker: %v", err)
+	}
+	s.clusterState.update(<-sub.Updates)
+	if _, ok := s.clusterState.nodes[cluster.NodeId("node6")]; ok {
+		t.Fatal("Expected node6 to be removed from the cluster")
+	}
+	if ns, ok := s.clusterState.suspendedNodes[cluster.NodeId("node6")]; !ok || ns.timeLost == nilTime {
+		t.Fatal("Expected node6 to be suspended as lost")
+	}
+
+	// Workers can't be managed by hand without an admin token configured.
+	s.config.AdminToken = ""
+	if err := s.AddWorker(sched.ManualWorkerReq{ID: "node6", Requestor: "admin"}); err == nil {
+		t.Fatal("Expected adding a worker to fail without an admin token configured")
+	}
+}
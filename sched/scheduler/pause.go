@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// Pauser is implemented by schedulers that can stop scheduling a job's tasks without killing it,
+// ex: to free up the cluster for more urgent work.
+type Pauser interface {
+	PauseJob(req sched.PauseJobReq) error
+}
+
+// contains the pause or resume request and callback for the result of processing it
+type jobPauseRequest struct {
+	req        sched.PauseJobReq
+	responseCh chan error
+}
+
+// Pauses or resumes a job. Unless AbortRunning is set, tasks already running when a job is paused
+// are left to finish. Put the request on a channel that is processed by the main scheduler loop,
+// and wait for the response.
+func (s *statefulScheduler) PauseJob(req sched.PauseJobReq) error {
+	if !stringInSlice(req.Requestor, s.config.Admins) && len(s.config.Admins) != 0 {
+		return fmt.Errorf("Requestor %s unauthorized to pause job", req.Requestor)
+	}
+	log.WithFields(
+		log.Fields{
+			"jobID":        req.JobID,
+			"requestor":    req.Requestor,
+			"paused":       req.Paused,
+			"abortRunning": req.AbortRunning,
+		}).Info("PauseJob requested")
+	responseCh := make(chan error, 1)
+	s.pauseJobCh <- jobPauseRequest{req: req, responseCh: responseCh}
+	return <-responseCh
+}
+
+// process all pause and resume requests, recording each in the job's saga so it survives recovery.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) pauseJobs() {
+	for {
+		select {
+		case r := <-s.pauseJobCh:
+			r.responseCh <- s.pauseJob(r.req)
+		default:
+			return
+		}
+	}
+}
+
+func (s *statefulScheduler) pauseJob(req sched.PauseJobReq) error {
+	action := "pause"
+	if !req.Paused {
+		action = "resume"
+	}
+	jobState := s.getJob(req.JobID)
+	if jobState == nil {
+		return fmt.Errorf("Cannot %s Job Id %s, not found. The job may be finished, "+
+			"the request may still be in the queue to be scheduled, or the id may be invalid.", action, req.JobID)
+	} else if jobState.JobKilled {
+		return fmt.Errorf("Cannot %s Job Id %s, it was killed", action, req.JobID)
+	} else if jobState.Paused == req.Paused {
+		return fmt.Errorf("Job Id %s is already %sd, request ignored", req.JobID, action)
+	}
+
+	var err error
+	if req.Paused {
+		err = jobState.Saga.PauseSaga([]byte(req.Requestor))
+	} else {
+		err = jobState.Saga.ResumeSaga([]byte(req.Requestor))
+	}
+	if err != nil {
+		return fmt.Errorf("Error logging %s of Job Id %s: %v", action, req.JobID, err)
+	}
+	jobState.Paused = req.Paused
+
+	logFields := log.Fields{
+		"jobID":     jobState.Job.Id,
+		"requestor": req.Requestor,
+		"jobType":   jobState.Job.Def.JobType,
+		"tag":       jobState.Job.Def.Tag,
+	}
+	if !req.Paused {
+		s.stat.Counter(stats.SchedJobsResumedCounter).Inc(1)
+		log.WithFields(logFields).Info("Resumed job")
+		return nil
+	}
+
+	s.stat.Counter(stats.SchedJobsPausedCounter).Inc(1)
+	aborted := 0
+	if req.AbortRunning {
+		for _, task := range jobState.Tasks {
+			if task.Status != sched.InProgress {
+				continue
+			}
+			if task.TaskRunner.attempts != nil {
+				task.TaskRunner.attempts.abort(false, JobPausedErrStr)
+			} else {
+				task.TaskRunner.Abort(false, JobPausedErrStr)
+			}
+			aborted++
+		}
+	}
+	logFields["abortedTasks"] = aborted
+	log.WithFields(logFields).Info("Paused job")
+	return nil
+}
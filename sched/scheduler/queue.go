@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Weight given to each newly completed task when updating the average task duration used to estimate queue wait.
+const queueDurationWeight = 0.1
+
+// Point-in-time summary of the scheduler's queue, for telling clients how long new work is likely to wait.
+type QueueStatus struct {
+	WaitingTasks int // tasks of in progress jobs that haven't started
+	RunningTasks int
+	Nodes        int // healthy nodes
+	// Estimated time until a newly scheduled task starts, or zero if there's no estimate yet.
+	EstimatedWait time.Duration
+}
+
+// QueueReporter is implemented by schedulers that can report the depth of their queue.
+type QueueReporter interface {
+	GetQueueStatus() QueueStatus
+}
+
+// queueTracker holds the most recent queue counts reported by the scheduler loop and
+// a moving average of task durations. It is safe to use outside the scheduler loop.
+type queueTracker struct {
+	stat stats.StatsReceiver
+
+	mu          sync.Mutex
+	status      QueueStatus
+	avgDuration time.Duration
+}
+
+func newQueueTracker(stat stats.StatsReceiver) *queueTracker {
+	return &queueTracker{stat: stat}
+}
+
+// Update the queue counts. Called from the scheduler loop.
+func (q *queueTracker) update(waitingTasks, runningTasks, nodes int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.status = QueueStatus{WaitingTasks: waitingTasks, RunningTasks: runningTasks, Nodes: nodes}
+	q.status.EstimatedWait = q.estimateWait()
+	q.stat.Gauge(stats.SchedEstimatedQueueWait_ms).Update(int64(q.status.EstimatedWait / time.Millisecond))
+}
+
+// Record the duration of a completed task.
+func (q *queueTracker) recordTaskDuration(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.avgDuration == 0 {
+		q.avgDuration = d
+		return
+	}
+	q.avgDuration += time.Duration(queueDurationWeight * float64(d-q.avgDuration))
+}
+
+// Waiting tasks are assumed to start as nodes free up, each node finishing a task every avgDuration.
+// Must be called with q.mu held.
+func (q *queueTracker) estimateWait() time.Duration {
+	if q.status.WaitingTasks == 0 || q.status.Nodes == 0 {
+		return 0
+	}
+	return time.Duration(float64(q.avgDuration) * float64(q.status.WaitingTasks) / float64(q.status.Nodes))
+}
+
+func (q *queueTracker) get() QueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.status
+}
+
+func (s *statefulScheduler) GetQueueStatus() QueueStatus {
+	return s.queue.get()
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// QueueSLOConfig sets how long tasks of each job priority may wait in the queue, from their job being
+// accepted (or recovered) to their first run starting. Queue times are recorded as histograms broken down
+// by priority and by requestor either way, and tasks of priorities with a threshold that wait longer breach
+// the SLO. Retries and speculative runs aren't counted.
+type QueueSLOConfig struct {
+	Thresholds map[sched.Priority]time.Duration
+}
+
+// Parses comma separated priority=duration thresholds, ex: "2=30s,1=5m".
+func ParseQueueSLOThresholds(s string) (map[sched.Priority]time.Duration, error) {
+	thresholds := map[sched.Priority]time.Duration{}
+	for _, kv := range strings.Split(s, ",") {
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid queue SLO %q, expected priority=duration", kv)
+		}
+		p, err := strconv.Atoi(parts[0])
+		if err != nil || p < int(sched.P0) || p > int(sched.P2) {
+			return nil, fmt.Errorf("Invalid queue SLO priority %q", parts[0])
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid queue SLO duration %q: %v", parts[1], err)
+		}
+		thresholds[sched.Priority(p)] = d
+	}
+	return thresholds, nil
+}
+
+// Records how long a task waited before its first run, called as that run is started.
+func (s *statefulScheduler) recordQueueTime(job *jobState, task *taskState) {
+	if task.Attempts > 0 {
+		return
+	}
+	def := &job.Job.Def
+	queued := time.Since(job.TimeCreated)
+	byPriority := s.taggedStat.Tagged(stats.Tag{Key: "priority", Value: strconv.Itoa(int(def.Priority))})
+	byRequestor := s.taggedStat.Tagged(stats.Tag{Key: "requestor", Value: def.Requestor})
+	for _, stat := range []stats.StatsReceiver{byPriority, byRequestor} {
+		stat.Histogram(stats.SchedTaggedTaskQueueTimeHistogram_ms).Update(int64(queued / time.Millisecond))
+	}
+
+	if threshold, ok := s.config.QueueSLO.Thresholds[def.Priority]; ok && queued > threshold {
+		for _, stat := range []stats.StatsReceiver{byPriority, byRequestor} {
+			stat.Counter(stats.SchedTaggedQueueSLOBreachCounter).Inc(1)
+		}
+	}
+}
+
+// Updates, for each priority with a threshold, the number of tasks that have waited longer than it
+// and still haven't started, so alerts fire while tasks are queueing rather than once they run.
+func (s *statefulScheduler) updateQueueSLOStats() {
+	if len(s.config.QueueSLO.Thresholds) == 0 {
+		return
+	}
+	breaching := map[sched.Priority]int{}
+	for _, job := range s.inProgressJobs {
+		threshold, ok := s.config.QueueSLO.Thresholds[job.Job.Def.Priority]
+		if !ok || time.Since(job.TimeCreated) <= threshold {
+			continue
+		}
+		for _, task := range job.Tasks {
+			if task.Status == sched.NotStarted && task.Attempts == 0 {
+				breaching[job.Job.Def.Priority]++
+			}
+		}
+	}
+	for p := range s.config.QueueSLO.Thresholds {
+		s.taggedStat.Tagged(stats.Tag{Key: "priority", Value: strconv.Itoa(int(p))}).
+			Gauge(stats.SchedTaggedQueueSLOBreachingTasksGauge).Update(int64(breaching[p]))
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+func TestParseQueueSLOThresholds(t *testing.T) {
+	thresholds, err := ParseQueueSLOThresholds("2=30s,1=5m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(thresholds) != 2 || thresholds[sched.P2] != 30*time.Second || thresholds[sched.P1] != 5*time.Minute {
+		t.Fatalf("Unexpected thresholds %v", thresholds)
+	}
+	for _, invalid := range []string{"2", "9=1s", "x=1s", "1=soon"} {
+		if _, err := ParseQueueSLOThresholds(invalid); err == nil {
+			t.Fatalf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func Test_StatefulScheduler_QueueSLO(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	deps.config.QueueSLO = QueueSLOConfig{Thresholds: map[sched.Priority]time.Duration{sched.P0: time.Nanosecond}}
+	s := makeStatefulSchedulerDeps(deps)
+
+	// Six tasks on a five node cluster, so at least one is left waiting.
+	jobDef := sched.GenJobDef(6)
+	jobDef.Requestor = "ci"
+	jobId := scheduleRebalanceJob(t, s, &jobDef)
+	s.step()
+	started := s.getJob(jobId).TasksRunning
+	if started == 0 {
+		t.Fatal("Expected tasks to be started")
+	}
+
+	if !stats.StatsOk("", deps.statsRegistry, t,
+		map[string]stats.Rule{
+			"tagged/priority/0/" + stats.SchedTaggedTaskQueueTimeHistogram_ms + ".count":   {Checker: stats.Int64EqTest, Value: started},
+			"tagged/requestor/ci/" + stats.SchedTaggedTaskQueueTimeHistogram_ms + ".count": {Checker: stats.Int64EqTest, Value: started},
+			"tagged/priority/0/" + stats.SchedTaggedQueueSLOBreachCounter:                  {Checker: stats.Int64EqTest, Value: started},
+			"tagged/requestor/ci/" + stats.SchedTaggedQueueSLOBreachCounter:                {Checker: stats.Int64EqTest, Value: started},
+			"tagged/priority/0/" + stats.SchedTaggedQueueSLOBreachingTasksGauge:            {Checker: stats.Int64EqTest, Value: 6 - started},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
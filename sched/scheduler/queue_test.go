@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+func Test_Queue_EstimatedWait(t *testing.T) {
+	q := newQueueTracker(stats.NilStatsReceiver())
+
+	// No task has completed yet, so there's no estimate.
+	q.update(10, 5, 5)
+	if st := q.get(); st.WaitingTasks != 10 || st.RunningTasks != 5 || st.Nodes != 5 || st.EstimatedWait != 0 {
+		t.Fatalf("Unexpected queue status before any task completed: %+v", st)
+	}
+
+	q.recordTaskDuration(time.Minute)
+	q.update(10, 5, 5)
+	if st := q.get(); st.EstimatedWait != 2*time.Minute {
+		t.Fatalf("Expected 10 tasks waiting on 5 nodes to wait 2m, got %v", st.EstimatedWait)
+	}
+
+	// New durations move the average by queueDurationWeight.
+	q.recordTaskDuration(11 * time.Minute)
+	q.update(5, 5, 5)
+	if st := q.get(); st.EstimatedWait != 2*time.Minute {
+		t.Fatalf("Expected 5 tasks waiting on 5 nodes to wait 2m, got %v", st.EstimatedWait)
+	}
+
+	// Nothing waiting, or no nodes to estimate from.
+	q.update(0, 5, 5)
+	if st := q.get(); st.EstimatedWait != 0 {
+		t.Fatalf("Expected no wait with nothing queued, got %v", st.EstimatedWait)
+	}
+	q.update(5, 0, 0)
+	if st := q.get(); st.EstimatedWait != 0 {
+		t.Fatalf("Expected no estimate with no nodes, got %v", st.EstimatedWait)
+	}
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// Maximum number of tasks migrated off cordoned nodes per scheduler step.
+const DefaultMaxMigrations = 10
+
+// Error for running tasks aborted to migrate them off a cordoned node, they're rerun on another node.
+const TaskMigratedErrStr = "TaskMigrated"
+
+// Env var a Scoot task sets to "true" to declare it safe to abort and rerun from scratch on another node.
+// Bazel actions are idempotent unless they're marked DoNotCache.
+const IdempotentEnvVar = "SCOOT_IDEMPOTENT"
+
+// RebalanceConfig evens out load after the cluster scales up and drains cordoned nodes faster.
+// Workers run one task at a time, so rebalancing decides which tasks get new nodes and moves tasks off nodes being drained.
+//
+// QueuedAge - when healthy nodes join a cluster that had no free nodes, waiting tasks of jobs created longer ago
+// than this are given the new nodes first, oldest job first, ahead of regular scheduling by priority. Disabled if zero.
+// MigrateAfter - idempotent tasks that have run longer than this on a cordoned node are aborted and rescheduled
+// when there are free nodes, rather than holding up the drain. Disabled if zero.
+// MaxMigrations - limit on tasks migrated per scheduler step, DefaultMaxMigrations if zero.
+type RebalanceConfig struct {
+	QueuedAge     time.Duration
+	MigrateAfter  time.Duration
+	MaxMigrations int
+}
+
+// Cluster size and saturation as of the end of the last scheduler step, used to detect scale-ups.
+type rebalanceState struct {
+	numNodes  int
+	saturated bool
+}
+
+// Records the cluster's state at the end of a scheduler step.
+func (s *statefulScheduler) updateRebalanceState() {
+	s.rebalance.numNodes = len(s.clusterState.nodes)
+	s.rebalance.saturated = s.clusterState.numFree() == 0
+}
+
+// Returns true if the task can be aborted and rerun from scratch without side effects.
+func idempotent(def *sched.TaskDefinition) bool {
+	if def.ExecuteRequest != nil {
+		return !def.ExecuteRequest.GetAction().GetDoNotCache()
+	}
+	return def.EnvVars[IdempotentEnvVar] == "true"
+}
+
+// Returns assignments of nodes that joined a saturated cluster since the last step to the waiting tasks
+// of the oldest jobs created more than QueuedAge ago, and applies them to clusterState.nodeGroups.
+func (s *statefulScheduler) getRebalanceAssignments() []taskAssignment {
+	config := s.config.Rebalance
+	joined := len(s.clusterState.nodes) - s.rebalance.numNodes
+	if config.QueuedAge == 0 || !s.rebalance.saturated || joined <= 0 {
+		return nil
+	}
+	numNodes := min(joined, s.clusterState.numFree())
+
+	jobs := []*jobState{}
+	now := time.Now()
+	for _, js := range s.inProgressJobs {
+		// Gang jobs' tasks only start together, in regular scheduling.
+		if !js.JobKilled && !js.Job.Def.Gang && now.Sub(js.TimeCreated) > config.QueuedAge {
+			jobs = append(jobs, js)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].TimeCreated.Before(jobs[j].TimeCreated) })
+	tasks := []*taskState{}
+	for _, js := range jobs {
+		if len(tasks) >= numNodes {
+			break
+		}
+		tasks = append(tasks, js.getUnScheduledTasks()...)
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+	if len(tasks) > numNodes {
+		tasks = tasks[:numNodes]
+	}
+
+	nodeGroups, snapIds := copyNodeGroups(s.clusterState)
+	assignments := assign(s.clusterState, tasks, nodeGroups, append([]string{""}, snapIds...), s.stat)
+	if len(assignments) > 0 {
+		s.clusterState.nodeGroups = nodeGroups
+	}
+	s.stat.Counter(stats.SchedRebalancedTasksCounter).Inc(int64(len(assignments)))
+	log.WithFields(
+		log.Fields{
+			"joinedNodes": joined,
+			"numTasks":    len(tasks),
+			"assigned":    len(assignments),
+			"queuedAge":   config.QueuedAge,
+		}).Info("Cluster scaled up, assigning new nodes to long queued tasks")
+	return assignments
+}
+
+// Aborts idempotent tasks that have run longer than MigrateAfter on cordoned nodes, up to the number of free nodes
+// they could be rerun on. Migrated tasks go back to waiting and are rescheduled like any other.
+func (s *statefulScheduler) migrateTasks() {
+	config := s.config.Rebalance
+	if config.MigrateAfter == 0 {
+		return
+	}
+	maxMigrations := config.MaxMigrations
+	if maxMigrations == 0 {
+		maxMigrations = DefaultMaxMigrations
+	}
+	numMigrations := min(maxMigrations, s.clusterState.numFree())
+
+	now := time.Now()
+	for _, js := range s.inProgressJobs {
+		// A gang job's task rerun on its own would miss the rest of its gang.
+		if js.JobKilled || js.Paused || js.Job.Def.Gang {
+			continue
+		}
+		for _, task := range js.Tasks {
+			if numMigrations == 0 {
+				return
+			}
+			if task.Status != sched.InProgress || task.TaskRunner == nil || task.TaskRunner.attempts == nil {
+				continue
+			}
+			tr := task.TaskRunner
+			// Tasks with a speculative duplicate already have an attempt that can finish them elsewhere.
+			attempts := tr.attempts
+			if !tr.nodeSt.cordoned || attempts.speculated || attempts.aborted[tr] ||
+				now.Sub(task.TimeStarted) < config.MigrateAfter || !idempotent(&task.Def) {
+				continue
+			}
+			attempts.abort(false, TaskMigratedErrStr)
+			numMigrations--
+			s.stat.Counter(stats.SchedMigratedTasksCounter).Inc(1)
+			log.WithFields(
+				log.Fields{
+					"jobID":   task.JobId,
+					"taskID":  task.TaskId,
+					"node":    tr.nodeSt.node,
+					"started": task.TimeStarted,
+					"tag":     task.Def.Tag,
+				}).Info("Migrating long running task off cordoned node")
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/runners"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapshots"
+)
+
+func makeRebalanceScheduler(config RebalanceConfig) (
+	*statefulScheduler, *testCluster, map[cluster.NodeId]*execers.SimExecer, stats.StatsRegistry) {
+	tmp, _ := temp.NewTempDir("", "stateful_scheduler_test")
+	cl := makeTestCluster("node1")
+	execs := map[cluster.NodeId]*execers.SimExecer{}
+	deps := &schedulerDeps{
+		initialCl: cl.nodes,
+		clUpdates: cl.ch,
+		sc:        sagalogs.MakeInMemorySagaCoordinatorNoGC(),
+		rf: func(n cluster.Node) runner.Service {
+			ex := execers.NewSimExecer()
+			execs[n.Id()] = ex
+			filerMap := runner.MakeRunTypeMap()
+			filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeInvalidFiler(), IDC: nil}
+			return runners.NewSingleRunner(ex, filerMap, runners.NewNullOutputCreator(), tmp, nil)
+		},
+		config: SchedulerConfig{
+			DebugMode:          true,
+			DefaultTaskTimeout: time.Minute,
+			Rebalance:          config,
+		},
+		statsRegistry: stats.NewFinagleStatsRegistry(),
+	}
+	return makeStatefulSchedulerDeps(deps), cl, execs, deps.statsRegistry
+}
+
+func scheduleRebalanceJob(t *testing.T, s *statefulScheduler, jobDef *sched.JobDefinition) string {
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, err := s.ScheduleJob(*jobDef)
+	if err != nil {
+		t.Fatalf("Unexpected error scheduling job: %v", err)
+	}
+	return jobId
+}
+
+func Test_StatefulScheduler_RebalanceQueuedTasks(t *testing.T) {
+	s, cl, _, statsRegistry := makeRebalanceScheduler(RebalanceConfig{QueuedAge: time.Nanosecond})
+
+	// The older job takes the only node and has a task left waiting.
+	oldDef := sched.GenJobDef(2)
+	for i := range oldDef.Tasks {
+		oldDef.Tasks[i].Argv = []string{"pause", "complete 0"}
+	}
+	oldJobId := scheduleRebalanceJob(t, s, &oldDef)
+	s.step()
+
+	// A newer, higher priority job would normally be given the next free node.
+	newDef := sched.GenJobDef(1)
+	newDef.Priority = sched.P2
+	newDef.Tasks[0].Argv = []string{"pause", "complete 0"}
+	newJobId := scheduleRebalanceJob(t, s, &newDef)
+	s.step()
+	if s.clusterState.numRunning != 1 || !s.rebalance.saturated {
+		t.Fatalf("Expected the cluster to be saturated, got %d running nodes", s.clusterState.numRunning)
+	}
+
+	// The node that joins goes to the task that waited longest.
+	cl.add("node2")
+	s.step()
+	if s.clusterState.numRunning != 2 {
+		t.Fatalf("Expected the new node to be running a task, got %d running nodes", s.clusterState.numRunning)
+	}
+	if running := s.getJob(oldJobId).TasksRunning; running != 2 {
+		t.Fatalf("Expected both tasks of the older job to be running, got %d", running)
+	}
+	if running := s.getJob(newJobId).TasksRunning; running != 0 {
+		t.Fatalf("Expected the newer job to keep waiting, got %d running", running)
+	}
+
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedRebalancedTasksCounter: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func Test_StatefulScheduler_MigrateTaskOffCordonedNode(t *testing.T) {
+	s, cl, execs, statsRegistry := makeRebalanceScheduler(RebalanceConfig{MigrateAfter: time.Nanosecond})
+
+	jobDef := sched.GenJobDef(1)
+	jobDef.Tasks[0].Argv = []string{"pause", "complete 0"}
+	jobDef.Tasks[0].EnvVars = map[string]string{IdempotentEnvVar: "true"}
+	taskId := jobDef.Tasks[0].TaskID
+	jobId := scheduleRebalanceJob(t, s, &jobDef)
+	s.step()
+	task := s.getJob(jobId).getTask(taskId)
+	if task.TaskRunner == nil || task.TaskRunner.nodeSt.node.Id() != "node1" {
+		t.Fatalf("Expected the task to be running on node1")
+	}
+
+	// Nothing is migrated until the node is cordoned.
+	cl.add("node2")
+	s.step()
+	if task.TaskRunner == nil || task.TaskRunner.nodeSt.node.Id() != "node1" {
+		t.Fatalf("Expected the task to still be running on node1")
+	}
+
+	// Once it is, the task is aborted and rerun on node2.
+	cl.ch <- []cluster.NodeUpdate{cluster.NewCordon("node1", true)}
+	for task.TaskRunner == nil || task.TaskRunner.nodeSt.node.Id() != "node2" {
+		s.step()
+	}
+	execs["node2"].Resume()
+	for task.Status != sched.Completed || s.clusterState.numRunning != 0 {
+		s.step()
+	}
+	if task.NumTimesTried != 1 {
+		t.Fatalf("Expected the migration not to count as a try, got %d tries", task.NumTimesTried)
+	}
+
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedMigratedTasksCounter: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	def := &sched.TaskDefinition{}
+	if idempotent(def) {
+		t.Fatal("Expected Scoot tasks not to be idempotent by default")
+	}
+	def.EnvVars = map[string]string{IdempotentEnvVar: "true"}
+	if !idempotent(def) {
+		t.Fatalf("Expected a task setting %s to be idempotent", IdempotentEnvVar)
+	}
+}
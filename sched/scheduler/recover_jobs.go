@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
 )
@@ -13,15 +14,19 @@ import (
 // recovers all active sagas from the specified SagaCoordinator with ForwardRecovery.
 // ActiveSagas are recovered in parallel and are added to the addJobCh to be rescheduled
 // This method returns once all activeSagas have been successfully recovered.
-func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
+func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg, stat stats.StatsReceiver) {
 	log.Infof("INFO: Recovering Sagas")
+	start := time.Now()
+	defer func() {
+		stat.Gauge(stats.SagaRecoveryTime_ms).Update(int64(time.Since(start) / time.Millisecond))
+	}()
 
 	recoveryActiveSagaAttempts := 0
 	activeSagas, err := sc.Startup()
 	for err != nil {
 		// Retry until we succeed,  This has to eventually succeed
 		// for us to make progress.
-		// TODO: Add metrics for failure rate, this would be something we should alert on.
+		stat.Counter(stats.SagaRecoveryErrorsCounter).Inc(1)
 		recoveryActiveSagaAttempts++
 		log.Infof("ERROR: occurred getting ActiveSagas from SagaLog %v", err)
 
@@ -47,7 +52,7 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 
 		go func(sagaId string) {
 			defer wg.Done()
-			activeSaga := recoverSaga(sc, sagaId)
+			activeSaga := recoverSaga(sc, sagaId, stat)
 			if activeSaga != nil {
 				job, err := sched.DeserializeJob(activeSaga.GetState().Job())
 				if err != nil {
@@ -58,6 +63,7 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 				}
 
 				log.Infof("INFO: Rescheduling Saga %v", sagaId)
+				stat.Counter(stats.SagaRecoveredCounter).Inc(1)
 				// reschedule saga
 				addJobCh <- jobAddedMsg{
 					job:  job,
@@ -77,22 +83,21 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 // If a Fatal Error occurrs while recovering the saga nil will be returned.
 // If a Retryable Error occurs, like SagaLog temporarily unavailable recovery will
 // be retried until it succeeds
-func recoverSaga(sc saga.SagaCoordinator, sagaId string) *saga.Saga {
+func recoverSaga(sc saga.SagaCoordinator, sagaId string, stat stats.StatsReceiver) *saga.Saga {
 	recoverSagaStateAttempts := 0
 	activeSaga, err := sc.RecoverSagaState(sagaId, saga.ForwardRecovery)
 	for err != nil {
-		// TODO: add metrics for failure rate, this would be something we should alert on
-
 		// check if recoverable error
 		if saga.FatalErr(err) {
-			// TODO: add metrics for fatal failure rate, this would be something we should alert on, this is a bad bug
-			// if we can't recover the saga from the long, means something is very wrong.
+			// This is a bad bug, if we can't recover the saga from the log, means something is very wrong.
+			stat.Counter(stats.SagaRecoveryFatalErrorsCounter).Inc(1)
 			log.Infof("ERROR: Fatal Error occurred recovering saga %v, with error: %v, skipping recovery for this saga", sagaId, err)
 			err = nil
 			activeSaga = nil
 		} else {
 			// Recovering SagaState must eventually succeed if it doesn't continue to retry with
 			// exponential backoff.
+			stat.Counter(stats.SagaRecoveryErrorsCounter).Inc(1)
 			recoverSagaStateAttempts++
 			log.Infof("ERROR: occurred recovering Saga %v, from SagaLog %v", sagaId, err)
 
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"errors"
 	"github.com/golang/mock/gomock"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
 	"testing"
@@ -25,7 +26,7 @@ func Test_RecoverJobs_NilActiveSagas(t *testing.T) {
 
 	// Expect no messages added to addJobCh
 	addJobCh := make(chan jobAddedMsg, 1)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 
 	select {
 	case msg := <-addJobCh:
@@ -44,7 +45,7 @@ func Test_RecoverJobs_EmptyActiveSagas(t *testing.T) {
 
 	// Expect no messages added to addJobCh
 	addJobCh := make(chan jobAddedMsg, 1)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 
 	select {
 	case msg := <-addJobCh:
@@ -68,7 +69,7 @@ func Test_RecoverJobs_GetActiveSagasFails(t *testing.T) {
 
 	// Expect no messages added to addJobCh
 	addJobCh := make(chan jobAddedMsg, 1)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 
 	// Nothing to verify just ensuring that recoverJobs eventually succeeds
 }
@@ -92,7 +93,7 @@ func Test_RecoverJobs_ActiveSagas(t *testing.T) {
 	}, nil)
 
 	addJobCh := make(chan jobAddedMsg, 5)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 	recoveredJobs := make(map[string]jobAddedMsg)
 
 	for i := 0; i < 2; i++ {
@@ -129,7 +130,7 @@ func Test_RecoverJob_NilActiveSaga(t *testing.T) {
 	slog.EXPECT().GetMessages("saga2").Return(nil, nil)
 
 	addJobCh := make(chan jobAddedMsg, 5)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 	recoveredJobs := make(map[string]jobAddedMsg)
 
 	for i := 0; i < 2; i++ {
@@ -163,7 +164,7 @@ func Test_RecoverJob_NonDeSerializableJob(t *testing.T) {
 	}, nil)
 
 	addJobCh := make(chan jobAddedMsg, 5)
-	recoverJobs(sc, addJobCh)
+	recoverJobs(sc, addJobCh, stats.NilStatsReceiver())
 
 	select {
 	case msg := <-addJobCh:
@@ -183,7 +184,7 @@ func Test_RecoverSaga_ActiveSaga(t *testing.T) {
 		saga.MakeStartSagaMessage("saga1", jobData),
 	}, nil)
 
-	s := recoverSaga(sc, "saga1")
+	s := recoverSaga(sc, "saga1", stats.NilStatsReceiver())
 	if s == nil {
 		t.Errorf("Expeceted in progress saga to be returned not nil")
 	}
@@ -196,7 +197,7 @@ func Test_RecoverSaga_NonExistantSaga(t *testing.T) {
 	sc, slog := makeMockSagaCoord(mockCtrl)
 	slog.EXPECT().GetMessages("saga1").Return(nil, nil)
 
-	s := recoverSaga(sc, "saga1")
+	s := recoverSaga(sc, "saga1", stats.NilStatsReceiver())
 	if s != nil {
 		t.Errorf("expected nil saga to be returned when saga is not in the log. Actual: %+v", s)
 	}
@@ -214,7 +215,7 @@ func Test_RecoverSaga_CompletedActiveSaga(t *testing.T) {
 		saga.MakeEndSagaMessage("saga1"),
 	}, nil)
 
-	s := recoverSaga(sc, "saga1")
+	s := recoverSaga(sc, "saga1", stats.NilStatsReceiver())
 
 	if s != nil {
 		t.Errorf("expected nil saga to be returned when saga is completed. Actual: %+v", s)
@@ -232,7 +233,7 @@ func Test_RecoverSaga_FatalError(t *testing.T) {
 		saga.MakeEndSagaMessage("saga1"),
 	}, nil)
 
-	s := recoverSaga(sc, "saga1")
+	s := recoverSaga(sc, "saga1", stats.NilStatsReceiver())
 	if s != nil {
 		t.Errorf("expected returned saga to be nil, when unrecoverable error occurs, Actual: %+v", s)
 	}
@@ -247,7 +248,7 @@ func Test_RecoverSaga_RetryableError(t *testing.T) {
 		saga.MakeStartSagaMessage("saga1", nil),
 	}, nil)
 
-	s := recoverSaga(sc, "saga1")
+	s := recoverSaga(sc, "saga1", stats.NilStatsReceiver())
 	if s == nil {
 		t.Errorf("expected saga to be not nil, saga recovery should retry")
 	}
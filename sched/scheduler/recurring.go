@@ -0,0 +1,320 @@
+package scheduler
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// Parameters that are always available to job templates when run as recurring jobs.
+// TimeParam is the time the job was scheduled to run, formatted with RecurringTimeFormat,
+// and NameParam is the name of the recurring job.
+const (
+	TimeParam = "time"
+	NameParam = "name"
+
+	RecurringTimeFormat = "20060102T1504Z"
+)
+
+// How long the recurring job loop sleeps when there are no recurring jobs.
+const recurringIdleWait = time.Hour
+
+// Matches parameter placeholders of the form {{name}}
+var templateParamRe = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+
+// JobTemplate is a named job definition with parameter placeholders of the form {{name}}.
+// Placeholders may appear in the job's Basis and Tag, and in each task's TaskID,
+// SnapshotID, Argv and EnvVars values.
+type JobTemplate struct {
+	Name string
+	Def  sched.JobDefinition
+}
+
+// Returns a copy of the template's job definition with placeholders replaced by params.
+// Returns an error if any placeholder has no value in params.
+func (t JobTemplate) Instantiate(params map[string]string) (sched.JobDefinition, error) {
+	missing := map[string]bool{}
+	sub := func(s string) string {
+		return templateParamRe.ReplaceAllStringFunc(s, func(m string) string {
+			name := templateParamRe.FindStringSubmatch(m)[1]
+			v, ok := params[name]
+			if !ok {
+				missing[name] = true
+			}
+			return v
+		})
+	}
+
+	def := t.Def
+	def.Basis = sub(def.Basis)
+	def.Tag = sub(def.Tag)
+	def.Tasks = make([]sched.TaskDefinition, len(t.Def.Tasks))
+	for i, task := range t.Def.Tasks {
+		task.TaskID = sub(task.TaskID)
+		task.SnapshotID = sub(task.SnapshotID)
+		argv := make([]string, len(task.Argv))
+		for j, arg := range task.Argv {
+			argv[j] = sub(arg)
+		}
+		task.Argv = argv
+		if task.EnvVars != nil {
+			env := make(map[string]string, len(task.EnvVars))
+			for k, v := range task.EnvVars {
+				env[k] = sub(v)
+			}
+			task.EnvVars = env
+		}
+		def.Tasks[i] = task
+	}
+
+	if len(missing) > 0 {
+		names := []string{}
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return sched.JobDefinition{}, fmt.Errorf("Template %s is missing params: %s", t.Name, strings.Join(names, ", "))
+	}
+	return def, nil
+}
+
+// RecurringJob runs a JobTemplate on a cron-like schedule, see ParseCronSchedule.
+// Params fill the template's placeholders in addition to TimeParam and NameParam.
+// Unless AllowOverlap is set, a run is skipped while the previous run's job is still in progress.
+// Runs missed while the scheduler is down are not made up.
+type RecurringJob struct {
+	Name         string
+	Template     string
+	Schedule     string
+	Params       map[string]string
+	AllowOverlap bool
+}
+
+// Checks that each recurring job has a valid schedule and uses one of the given templates,
+// with all of the template's placeholders filled by the job's params.
+func ValidateRecurringJobs(templates []JobTemplate, jobs []RecurringJob) error {
+	byName := map[string]JobTemplate{}
+	for _, t := range templates {
+		byName[t.Name] = t
+	}
+	for _, j := range jobs {
+		if _, err := ParseCronSchedule(j.Schedule); err != nil {
+			return err
+		}
+		t, ok := byName[j.Template]
+		if !ok {
+			return fmt.Errorf("Recurring job %s uses unknown template %q", j.Name, j.Template)
+		}
+		if _, err := t.Instantiate(recurringParams(j, time.Now())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type recurringJobState struct {
+	job       RecurringJob
+	schedule  CronSchedule
+	next      time.Time
+	lastJobID string
+}
+
+// Runs recurring jobs by submitting them to a Scheduler when they're due.
+type recurringJobs struct {
+	scheduler Scheduler
+	stat      stats.StatsReceiver
+	now       func() time.Time
+
+	mu        sync.Mutex
+	templates map[string]JobTemplate
+	jobs      map[string]*recurringJobState
+	wakeCh    chan struct{}
+}
+
+func newRecurringJobs(s Scheduler, stat stats.StatsReceiver) *recurringJobs {
+	return &recurringJobs{
+		scheduler: s,
+		stat:      stat,
+		now:       time.Now,
+		templates: make(map[string]JobTemplate),
+		jobs:      make(map[string]*recurringJobState),
+		wakeCh:    make(chan struct{}, 1),
+	}
+}
+
+// Registers a template, replacing any existing template with the same name.
+// Recurring jobs already using the template will use the new one on their next run.
+func (r *recurringJobs) registerTemplate(t JobTemplate) error {
+	if t.Name == "" {
+		return fmt.Errorf("Job template must have a name")
+	}
+	if len(t.Def.Tasks) == 0 {
+		return fmt.Errorf("Job template %s has no tasks", t.Name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[t.Name] = t
+	return nil
+}
+
+// Adds a recurring job, replacing any existing recurring job with the same name.
+// The job's template must already be registered and fully parameterized by the job's params.
+func (r *recurringJobs) add(job RecurringJob) error {
+	if job.Name == "" {
+		return fmt.Errorf("Recurring job must have a name")
+	}
+	schedule, err := ParseCronSchedule(job.Schedule)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.templates[job.Template]
+	if !ok {
+		return fmt.Errorf("Recurring job %s uses unknown template %q", job.Name, job.Template)
+	}
+	if _, err := t.Instantiate(recurringParams(job, r.now())); err != nil {
+		return err
+	}
+	state := &recurringJobState{job: job, schedule: schedule, next: schedule.Next(r.now())}
+	if prev, ok := r.jobs[job.Name]; ok {
+		state.lastJobID = prev.lastJobID
+	}
+	r.jobs[job.Name] = state
+	log.WithFields(
+		log.Fields{
+			"name":     job.Name,
+			"template": job.Template,
+			"schedule": job.Schedule,
+			"next":     state.next,
+		}).Info("Added recurring job")
+	r.wake()
+	return nil
+}
+
+// Removes a recurring job, returning false if there was no job with that name.
+func (r *recurringJobs) remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.jobs[name]
+	delete(r.jobs, name)
+	return ok
+}
+
+// Wake the loop so it recomputes how long to sleep. Caller must hold the lock.
+func (r *recurringJobs) wake() {
+	select {
+	case r.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Runs due jobs until the process exits.
+func (r *recurringJobs) loop() {
+	for {
+		wait := recurringIdleWait
+		r.mu.Lock()
+		for _, state := range r.jobs {
+			if state.next.IsZero() {
+				continue
+			}
+			if d := state.next.Sub(r.now()); d < wait {
+				wait = d
+			}
+		}
+		r.mu.Unlock()
+
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-r.wakeCh:
+			}
+		}
+		r.runDue()
+	}
+}
+
+// Schedules each job that is due and advances it to its next run time.
+func (r *recurringJobs) runDue() {
+	now := r.now()
+	due := []*recurringJobState{}
+	r.mu.Lock()
+	for _, state := range r.jobs {
+		if !state.next.IsZero() && !state.next.After(now) {
+			due = append(due, state)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, state := range due {
+		r.run(state, now)
+	}
+}
+
+func (r *recurringJobs) run(state *recurringJobState, now time.Time) {
+	r.mu.Lock()
+	job, scheduled, lastJobID := state.job, state.next, state.lastJobID
+	t, ok := r.templates[job.Template]
+	state.next = state.schedule.Next(now)
+	r.mu.Unlock()
+
+	logFields := log.Fields{"name": job.Name, "template": job.Template, "scheduled": scheduled}
+	if !ok {
+		r.stat.Counter(stats.SchedRecurringJobsFailedCounter).Inc(1)
+		log.WithFields(logFields).Errorf("Recurring job template no longer registered")
+		return
+	}
+	if !job.AllowOverlap && lastJobID != "" && r.isRunning(lastJobID) {
+		r.stat.Counter(stats.SchedRecurringJobsSkippedCounter).Inc(1)
+		log.WithFields(logFields).Infof("Skipping recurring job, previous job %s is still running", lastJobID)
+		return
+	}
+
+	def, err := t.Instantiate(recurringParams(job, scheduled))
+	if err == nil {
+		if def.Tag == "" {
+			def.Tag = job.Name
+		}
+		var id string
+		if id, err = r.scheduler.ScheduleJob(def); err == nil {
+			r.mu.Lock()
+			state.lastJobID = id
+			r.mu.Unlock()
+			r.stat.Counter(stats.SchedRecurringJobsCounter).Inc(1)
+			log.WithFields(logFields).Infof("Scheduled recurring job as %s", id)
+			return
+		}
+	}
+	r.stat.Counter(stats.SchedRecurringJobsFailedCounter).Inc(1)
+	log.WithFields(logFields).Errorf("Failed to schedule recurring job: %v", err)
+}
+
+// Returns true if the job's saga hasn't completed. Jobs whose state can't be read aren't considered running.
+func (r *recurringJobs) isRunning(jobID string) bool {
+	state, err := r.scheduler.GetSagaCoord().GetSagaState(jobID)
+	if err != nil {
+		log.Infof("Failed to get state of recurring job %s: %v", jobID, err)
+		return false
+	}
+	return !state.IsSagaCompleted()
+}
+
+func recurringParams(job RecurringJob, scheduled time.Time) map[string]string {
+	params := map[string]string{
+		TimeParam: scheduled.UTC().Format(RecurringTimeFormat),
+		NameParam: job.Name,
+	}
+	for k, v := range job.Params {
+		params[k] = v
+	}
+	return params
+}
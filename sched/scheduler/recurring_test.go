@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+)
+
+var testTemplate = JobTemplate{
+	Name: "gc",
+	Def: sched.JobDefinition{
+		Requestor: "maintenance",
+		Tasks: []sched.TaskDefinition{
+			{Command: runner.Command{
+				Argv:       []string{"gc", "--before={{time}}", "--keep={{keep}}"},
+				EnvVars:    map[string]string{"JOB": "{{name}}"},
+				SnapshotID: "{{snapshot}}",
+			}},
+		},
+	},
+}
+
+func TestJobTemplateInstantiate(t *testing.T) {
+	def, err := testTemplate.Instantiate(map[string]string{"time": "t1", "keep": "3", "name": "nightly", "snapshot": "s1"})
+	if err != nil {
+		t.Fatalf("Unexpected error instantiating template: %v", err)
+	}
+	task := def.Tasks[0]
+	if !reflect.DeepEqual(task.Argv, []string{"gc", "--before=t1", "--keep=3"}) ||
+		task.EnvVars["JOB"] != "nightly" || task.SnapshotID != "s1" {
+		t.Fatalf("Unexpected instantiated task: %+v", task)
+	}
+	if testTemplate.Def.Tasks[0].Argv[1] != "--before={{time}}" {
+		t.Fatalf("Expected template to be unmodified, got %+v", testTemplate.Def.Tasks[0])
+	}
+
+	if _, err := testTemplate.Instantiate(map[string]string{"time": "t1"}); err == nil {
+		t.Fatalf("Expected error instantiating template with missing params")
+	}
+}
+
+func TestRecurringJobs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+	s := NewMockScheduler(mockCtrl)
+	s.EXPECT().GetSagaCoord().Return(sc).AnyTimes()
+
+	now := time.Date(2018, time.March, 30, 10, 0, 0, 0, time.UTC)
+	r := newRecurringJobs(s, stats.NilStatsReceiver())
+	r.now = func() time.Time { return now }
+
+	if err := r.registerTemplate(testTemplate); err != nil {
+		t.Fatal(err)
+	}
+	job := RecurringJob{Name: "nightly", Template: "gc", Schedule: "0 2 * * *", Params: map[string]string{"keep": "3"}}
+	if err := r.add(job); err == nil {
+		t.Fatalf("Expected error adding recurring job missing template params")
+	}
+	job.Params["snapshot"] = "s1"
+	if err := r.add(job); err != nil {
+		t.Fatalf("Unexpected error adding recurring job: %v", err)
+	}
+
+	// Nothing is due yet.
+	r.runDue()
+
+	// Once due, the job is scheduled with the scheduled time and a default tag.
+	now = time.Date(2018, time.March, 31, 2, 0, 30, 0, time.UTC)
+	s.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		if def.Tasks[0].Argv[1] != "--before=20180331T0200Z" || def.Tag != "nightly" {
+			t.Errorf("Unexpected job definition: %+v", def)
+		}
+	}).Return("job1", nil)
+	r.runDue()
+	saga, err := sc.MakeSaga("job1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The next run is skipped while job1 is still running.
+	now = now.Add(24 * time.Hour)
+	r.runDue()
+
+	// Once job1 completes the job is scheduled again.
+	if err := saga.EndSaga(); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(24 * time.Hour)
+	s.EXPECT().ScheduleJob(gomock.Any()).Return("job2", nil)
+	r.runDue()
+
+	if !r.remove("nightly") || r.remove("nightly") {
+		t.Fatalf("Expected remove to succeed only once")
+	}
+}
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+)
+
+// Error for tasks failed without running because they need more resources than any node has.
+const UnsatisfiableErrStr = "Unsatisfiable"
+
+// The CPU and memory each node's worker reports as available to runs, recorded when the node
+// becomes ready. It is safe to use outside the scheduler loop. A nil nodeCapacities knows of no nodes.
+//
+// Workers still run one task at a time, so a task is placed on the single idle node that best fits it.
+// Capacity isn't divided into slots, several tasks aren't packed onto one node.
+type nodeCapacities struct {
+	mu         sync.Mutex
+	capacities map[cluster.NodeId]runner.Resources
+}
+
+func newNodeCapacities() *nodeCapacities {
+	return &nodeCapacities{capacities: map[cluster.NodeId]runner.Resources{}}
+}
+
+func (n *nodeCapacities) set(id cluster.NodeId, capacity runner.Resources) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if capacity.Unspecified() {
+		delete(n.capacities, id)
+		return
+	}
+	n.capacities[id] = capacity
+}
+
+// Returns the node's capacity, unspecified if its worker didn't report one.
+func (n *nodeCapacities) get(id cluster.NodeId) runner.Resources {
+	if n == nil {
+		return runner.Resources{}
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.capacities[id]
+}
+
+func (n *nodeCapacities) remove(id cluster.NodeId) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.capacities, id)
+}
+
+func (n *nodeCapacities) empty() bool {
+	if n == nil {
+		return true
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.capacities) == 0
+}
+
+// Returns the node in candidates that best fits a task needing required: the one with the least capacity
+// left over, so larger nodes remain free for larger tasks. Nodes with unspecified capacity fit any task but
+// are used only if no node with a known capacity fits. Returns nil if no candidate fits.
+func (n *nodeCapacities) bestFit(required runner.Resources, candidates []*nodeState) *nodeState {
+	var best, unknown *nodeState
+	var bestCapacity runner.Resources
+	for _, ns := range candidates {
+		capacity := n.get(ns.node.Id())
+		if capacity.Unspecified() {
+			if unknown == nil {
+				unknown = ns
+			}
+			continue
+		}
+		if !required.FitsIn(capacity) {
+			continue
+		}
+		if best == nil || capacity.MemoryBytes < bestCapacity.MemoryBytes ||
+			(capacity.MemoryBytes == bestCapacity.MemoryBytes && capacity.MilliCPUs < bestCapacity.MilliCPUs) {
+			best, bestCapacity = ns, capacity
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return unknown
+}
+
+// Returns a node from idle that isn't suspended, cordoned, blacklisted or in used, and best fits required, or nil if none fits.
+func (c *clusterState) fitIdleNode(
+	required runner.Resources, idle map[cluster.NodeId]*nodeState, used map[*nodeState]bool) *nodeState {
+	anyCapacity := !c.capacities.empty()
+	candidates := []*nodeState{}
+	for _, ns := range idle {
+		if ns.suspended() || ns.cordoned || ns.blacklisted() || used[ns] {
+			continue
+		}
+		if !anyCapacity {
+			// No worker reported its capacity, so every node fits.
+			return ns
+		}
+		candidates = append(candidates, ns)
+	}
+	return c.capacities.bestFit(required, candidates)
+}
+
+// Returns a function reporting whether a task needing required can't run on any node in the cluster:
+// every node, including suspended and offlined ones that may return, reported a capacity and none fits it.
+// Returns nil if every task can run, ex: because a node didn't report a capacity and so fits any task.
+func (c *clusterState) unsatisfiableCheck() func(required runner.Resources) bool {
+	if c.capacities.empty() {
+		return nil
+	}
+	capacities := []runner.Resources{}
+	for _, nodes := range []map[cluster.NodeId]*nodeState{c.nodes, c.suspendedNodes, c.offlinedNodes} {
+		for id := range nodes {
+			capacity := c.capacities.get(id)
+			if capacity.Unspecified() {
+				return nil
+			}
+			capacities = append(capacities, capacity)
+		}
+	}
+	if len(capacities) == 0 {
+		// An empty cluster may be starting up or scaling, tasks wait for its nodes.
+		return nil
+	}
+	return func(required runner.Resources) bool {
+		if required.Unspecified() {
+			return false
+		}
+		for _, capacity := range capacities {
+			if required.FitsIn(capacity) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Returns an error naming the first task of jobDef that needs more resources than any node has.
+func (c *clusterState) checkResources(jobDef *sched.JobDefinition) error {
+	unsatisfiable := c.unsatisfiableCheck()
+	if unsatisfiable == nil {
+		return nil
+	}
+	for _, t := range jobDef.Tasks {
+		if unsatisfiable(t.Resources) {
+			return fmt.Errorf("Task %s needs %s, more than any worker has", t.TaskID, t.Resources)
+		}
+	}
+	return nil
+}
+
+// Fails the tasks that haven't started and need more resources than any node has, ex: because the nodes
+// large enough for them were removed after their job was accepted, rather than leaving them waiting forever.
+// A gang job is killed along with its task.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) failUnsatisfiableTasks() {
+	unsatisfiable := s.clusterState.unsatisfiableCheck()
+	if unsatisfiable == nil {
+		return
+	}
+	for _, jobState := range s.inProgressJobs {
+		if jobState.JobKilled {
+			continue
+		}
+		for _, task := range jobState.Tasks {
+			if task.Status != sched.NotStarted || !unsatisfiable(task.Def.Resources) {
+				continue
+			}
+			log.WithFields(
+				log.Fields{
+					"jobID":     jobState.Job.Id,
+					"taskID":    task.TaskId,
+					"resources": task.Def.Resources,
+					"requestor": jobState.Job.Def.Requestor,
+					"jobType":   jobState.Job.Def.JobType,
+					"tag":       jobState.Job.Def.Tag,
+				}).Info("Task needs more resources than any worker has, failing it")
+			err := fmt.Errorf("%s: task needs %s, more than any worker has", UnsatisfiableErrStr, task.Def.Resources)
+			s.endUnstartedTask(jobState, task, runner.FailedStatus("", err, tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId}))
+			s.stat.Counter(stats.SchedUnsatisfiableTasksCounter).Inc(1)
+			if jobState.Job.Def.Gang {
+				s.failGang(jobState, task.TaskId)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// Number of previous durations needed for a command before its runs are considered stragglers.
+const DefaultSpeculationMinSamples = 5
+
+// Maximum number of speculative duplicates running at once.
+const DefaultMaxSpeculativeTasks = 10
+
+// Error set on an attempt aborted because another attempt at the same task finished first.
+const SpeculationLostErrStr = "Aborted, another attempt at this task finished first"
+
+// SpeculationConfig enables speculative execution of stragglers: when a task has been running longer
+// than the given percentile of its previous durations, a duplicate is started on an idle node.
+// Whichever attempt finishes first provides the task's result and the other is aborted.
+// Duplicates only use nodes left idle after regular scheduling.
+//
+// Percentile - in (0,1], ex: 0.95. Speculation is disabled if zero.
+// MinRuntime - tasks are never duplicated before they've run this long.
+// MinSamples - number of previous durations needed for a command, DefaultSpeculationMinSamples if zero.
+// MaxSpeculativeTasks - limit on concurrent duplicates, DefaultMaxSpeculativeTasks if zero.
+type SpeculationConfig struct {
+	Percentile          float64
+	MinRuntime          time.Duration
+	MinSamples          int
+	MaxSpeculativeTasks int
+}
+
+func (c SpeculationConfig) enabled() bool {
+	return c.Percentile > 0
+}
+
+// taskAttempts tracks the concurrent runs of a scheduled task: the original and any speculative duplicate.
+// The first attempt with a result to log claims the task, the others finish without writing to the saga.
+type taskAttempts struct {
+	mu      sync.Mutex
+	claimed *taskRunner // Set from taskRunner goroutines.
+
+	// The following are only used in the scheduler loop.
+	running    []*taskRunner
+	aborted    map[*taskRunner]bool
+	speculated bool
+}
+
+func newTaskAttempts(tr *taskRunner) *taskAttempts {
+	a := &taskAttempts{aborted: map[*taskRunner]bool{}}
+	a.add(tr)
+	return a
+}
+
+func (a *taskAttempts) add(tr *taskRunner) {
+	tr.attempts = a
+	a.running = append(a.running, tr)
+}
+
+// Returns true if tr may log the task's result. A nil taskAttempts has a single attempt.
+func (a *taskAttempts) claim(tr *taskRunner) bool {
+	if a == nil {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.claimed == nil {
+		a.claimed = tr
+	}
+	return a.claimed == tr
+}
+
+func (a *taskAttempts) winner() *taskRunner {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.claimed
+}
+
+// Called when tr's run completes. Returns true if tr decides the outcome for the job, either because it
+// claimed the task, or because no attempt did and it's the last to finish. The winner aborts the others.
+func (a *taskAttempts) done(tr *taskRunner) bool {
+	if a == nil {
+		return true
+	}
+	for i, r := range a.running {
+		if r == tr {
+			a.running = append(a.running[:i], a.running[i+1:]...)
+			break
+		}
+	}
+	switch a.winner() {
+	case tr:
+		a.abort(false, SpeculationLostErrStr)
+		return true
+	case nil:
+		return len(a.running) == 0
+	default:
+		return false
+	}
+}
+
+// Aborts the running attempts that haven't been aborted already.
+func (a *taskAttempts) abort(endTask bool, err string) {
+	for _, r := range a.running {
+		if !a.aborted[r] {
+			a.aborted[r] = true
+			r.Abort(endTask, err)
+		}
+	}
+}
+
+func (a *taskAttempts) numSpeculative() int {
+	n := 0
+	for _, r := range a.running {
+		if r.speculative {
+			n++
+		}
+	}
+	return n
+}
+
+// Returns assignments of duplicates for tasks running longer than the configured percentile of their
+// previous durations, to idle nodes not already in assigned.
+func (s *statefulScheduler) getSpeculativeAssignments(assigned []taskAssignment) []taskAssignment {
+	config := s.config.Speculation
+	if !config.enabled() {
+		return nil
+	}
+	minSamples := config.MinSamples
+	if minSamples == 0 {
+		minSamples = DefaultSpeculationMinSamples
+	}
+	maxSpeculative := config.MaxSpeculativeTasks
+	if maxSpeculative == 0 {
+		maxSpeculative = DefaultMaxSpeculativeTasks
+	}
+
+	numSpeculative := 0
+	stragglers := []*taskState{}
+	now := time.Now()
+	for _, js := range s.inProgressJobs {
+		for _, task := range js.Tasks {
+			if task.Status != sched.InProgress || task.TaskRunner == nil || task.TaskRunner.attempts == nil {
+				continue
+			}
+			attempts := task.TaskRunner.attempts
+			numSpeculative += attempts.numSpeculative()
+			// A duplicate of a gang job's task wouldn't start alongside the rest of its gang.
+			if js.JobKilled || js.Paused || js.Job.Def.Gang || attempts.speculated || attempts.winner() != nil {
+				continue
+			}
+			threshold, ok := s.taskHistory.percentile(task.Def, config.Percentile, minSamples)
+			if ok && now.Sub(task.TimeStarted) > threshold && now.Sub(task.TimeStarted) > config.MinRuntime {
+				stragglers = append(stragglers, task)
+			}
+		}
+	}
+	s.stat.Gauge(stats.SchedSpeculativeTasksGauge).Update(int64(numSpeculative))
+
+	used := map[*nodeState]bool{}
+	for _, ta := range assigned {
+		used[ta.nodeSt] = true
+	}
+	assignments := []taskAssignment{}
+	for _, task := range stragglers {
+		if numSpeculative+len(assignments) >= maxSpeculative {
+			break
+		}
+		nodeSt := s.findSpeculativeNode(task, used)
+		if nodeSt == nil {
+			continue
+		}
+		used[nodeSt] = true
+		assignments = append(assignments, taskAssignment{nodeSt: nodeSt, task: task, speculative: true})
+		log.WithFields(
+			log.Fields{
+				"jobID":   task.JobId,
+				"taskID":  task.TaskId,
+				"node":    nodeSt.node,
+				"started": task.TimeStarted,
+				"tag":     task.Def.Tag,
+			}).Info("Task is a straggler, assigning speculative duplicate")
+	}
+	return assignments
+}
+
+// Returns an idle node not in used that fits the task, preferring nodes last used for the task's snapshot,
+// or nil if there are none.
+func (s *statefulScheduler) findSpeculativeNode(task *taskState, used map[*nodeState]bool) *nodeState {
+	snapIds := []string{task.Def.SnapshotID}
+	for snapId := range s.clusterState.nodeGroups {
+		if snapId != task.Def.SnapshotID {
+			snapIds = append(snapIds, snapId)
+		}
+	}
+	for _, snapId := range snapIds {
+		if groups, ok := s.clusterState.nodeGroups[snapId]; ok {
+			if ns := s.clusterState.fitIdleNode(task.Def.Resources, groups.idle, used); ns != nil {
+				return ns
+			}
+		}
+	}
+	return nil
+}
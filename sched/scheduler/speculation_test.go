@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/runners"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapshots"
+)
+
+func Test_StatefulScheduler_SpeculativeDuplicateWins(t *testing.T) {
+	tmp, _ := temp.NewTempDir("", "stateful_scheduler_test")
+	cl := makeTestCluster("node1", "node2")
+	execs := map[cluster.NodeId]*execers.SimExecer{}
+	deps := &schedulerDeps{
+		initialCl: cl.nodes,
+		clUpdates: cl.ch,
+		sc:        sagalogs.MakeInMemorySagaCoordinatorNoGC(),
+		rf: func(n cluster.Node) runner.Service {
+			ex := execers.NewSimExecer()
+			execs[n.Id()] = ex
+			filerMap := runner.MakeRunTypeMap()
+			filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeInvalidFiler(), IDC: nil}
+			return runners.NewSingleRunner(ex, filerMap, runners.NewNullOutputCreator(), tmp, nil)
+		},
+		config: SchedulerConfig{
+			DebugMode:          true,
+			DefaultTaskTimeout: time.Minute,
+			Speculation:        SpeculationConfig{Percentile: .9, MinSamples: 1},
+		},
+		statsRegistry: stats.NewFinagleStatsRegistry(),
+	}
+	s := makeStatefulSchedulerDeps(deps)
+
+	jobDef := sched.GenJobDef(1)
+	jobDef.Tasks[0].Argv = []string{"pause", "complete 0"}
+	taskId := jobDef.Tasks[0].TaskID
+	// Previous runs of this command were all much faster than this one will be.
+	s.taskHistory.record(jobDef.Tasks[0], time.Nanosecond)
+
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, err := s.ScheduleJob(jobDef)
+	if err != nil {
+		t.Fatalf("Unexpected error scheduling job: %v", err)
+	}
+
+	// The first step starts the task, a later one duplicates it on the other node.
+	s.step()
+	js := s.getJob(jobId)
+	task := js.getTask(taskId)
+	for task.TaskRunner == nil || !task.TaskRunner.attempts.speculated {
+		s.step()
+	}
+	if s.clusterState.numRunning != 2 {
+		t.Fatalf("Expected the task and its duplicate to be running, got %d running nodes", s.clusterState.numRunning)
+	}
+	attempts := task.TaskRunner.attempts
+	duplicate := attempts.running[1]
+	if !duplicate.speculative || duplicate.nodeSt == task.TaskRunner.nodeSt {
+		t.Fatalf("Expected a speculative duplicate on a different node, got %+v", duplicate)
+	}
+
+	// The duplicate finishes first, the original is aborted and both nodes are freed.
+	execs[duplicate.nodeSt.node.Id()].Resume()
+	for task.Status != sched.Completed || s.clusterState.numRunning != 0 {
+		s.step()
+	}
+	if attempts.winner() != duplicate {
+		t.Fatalf("Expected the duplicate to provide the task's result")
+	}
+	if js.getJobStatus() != sched.Completed || !js.Saga.GetState().IsTaskCompleted(taskId) {
+		t.Fatalf("Expected the job and task to be completed")
+	}
+
+	if !stats.StatsOk("", deps.statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedSpeculativeTasksCounter: {Checker: stats.Int64EqTest, Value: 1},
+			stats.SchedSpeculativeWinsCounter:  {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/archive"
 	"github.com/twitter/scoot/workerapi"
 )
 
@@ -50,6 +52,18 @@ func stringInSlice(a string, list []string) bool {
 // Clients will check for this string to differentiate between scoot and user initiated actions.
 const UserRequestedErrStr = "UserRequested"
 
+// Error for tasks aborted because their job exceeded its timeout.
+const JobTimedOutErrStr = "JobTimedOut"
+
+// Error for running tasks aborted because their job was paused, they're rerun once it's resumed.
+const JobPausedErrStr = "JobPaused"
+
+// Error for running tasks of a gang job aborted because another of its tasks failed, they're rerun together with it.
+const GangRestartedErrStr = "GangRestarted"
+
+// Error for tasks of a gang job aborted because another of its tasks failed and won't be retried.
+const GangFailedErrStr = "GangFailed"
+
 // Provide defaults for config settings that should never be uninitialized/zero.
 // These are reasonable defaults for a small cluster of around a couple dozen nodes.
 
@@ -103,6 +117,21 @@ var NodeScaleAdjustment = []float32{.15, .3, .55}
 // TaskThrottle -
 //	   requestors will try not to schedule jobs that make the scheduler exceed
 //     the TaskThrottle.  Note: Sickle may exceed it with retries.
+// Admission -
+//     when to delay or reject new jobs because the cluster or CAS is saturated.
+// RequiredWorkerFeatures -
+//     nodes aren't scheduled until their worker reports supporting all of these, ex: RunTypes.
+//     Workers that don't report capabilities are never scheduled if this is set.
+// Speculation -
+//     when to start a duplicate of a task running much longer than it usually does. Disabled by default.
+// JobWebhooks -
+//     where to POST job events when jobs finish. Disabled by default.
+// Rebalance -
+//     how to use nodes that join a saturated cluster and when to migrate tasks off cordoned nodes. Disabled by default.
+// QueueSLO - how long tasks of each priority may wait to start before breaching their SLO. None by default.
+// DeadLetter - when to stop retrying tasks failing with infrastructure errors and dead letter them. Disabled by default.
+// Blacklist - when to stop scheduling to workers failing tasks that succeed on other workers. Disabled by default.
+// AdminToken - secret that requests to add or remove workers by hand must carry. They're refused if empty.
 type SchedulerConfig struct {
 	MaxRetriesPerTask       int
 	DebugMode               bool
@@ -117,6 +146,19 @@ type SchedulerConfig struct {
 	SoftMaxSchedulableTasks int
 	TaskThrottle            int
 	Admins                  []string
+	AdminToken              string
+	Admission               AdmissionConfig
+	RequiredWorkerFeatures  []string
+	JobTemplates            []JobTemplate
+	RecurringJobs           []RecurringJob
+	Speculation             SpeculationConfig
+	Autoscale               AutoscaleConfig
+	Archive                 archive.Archive // Finished jobs are added to Archive, if set.
+	JobWebhooks             JobWebhookConfig
+	Rebalance               RebalanceConfig
+	QueueSLO                QueueSLOConfig
+	DeadLetter              DeadLetterConfig
+	Blacklist               BlacklistConfig
 }
 
 // Used to calculate how many tasks a job can run without adversely affecting other jobs.
@@ -126,17 +168,6 @@ func (s *SchedulerConfig) GetNodeScaleFactor(numNodes int, p sched.Priority) flo
 	return sf * NodeScaleAdjustment[p]
 }
 
-// Used to keep a running average of duration for a specific task.
-type averageDuration struct {
-	count    int64
-	duration time.Duration
-}
-
-func (ad averageDuration) update(d time.Duration) {
-	ad.count++
-	ad.duration = ad.duration + time.Duration(int64(d-ad.duration)/ad.count)
-}
-
 type RunnerFactory func(node cluster.Node) runner.Service
 
 // Scheduler that keeps track of the state of running tasks & the cluster
@@ -157,19 +188,58 @@ type statefulScheduler struct {
 	checkJobCh    chan jobCheckMsg
 	addJobCh      chan jobAddedMsg
 	killJobCh     chan jobKillRequest
+	pauseJobCh    chan jobPauseRequest
+	requeueCh     chan deadLetterRequeueRequest
+	viewCh        chan chan View
+
+	// The cluster the scheduler's nodes come from, to add or remove nodes by hand.
+	// Nil if the scheduler was given its nodes directly.
+	cluster *cluster.Cluster
 
 	// Scheduler State
 	clusterState   *clusterState
 	inProgressJobs []*jobState // ordered list (by jobId) of jobs being scheduled.  Note: it might be
 	// no tasks have started yet.
-	requestorMap     map[string][]*jobState     // map of requestor to all its jobs. Default requestor="" is ok.
-	requestorHistory map[string][]string        // map of join(requestor, basis) to new tags in the order received.
-	taskDurations    map[string]averageDuration // map of taskId to averageDuration (note: we unconditionally dereference this).
+	requestorMap     map[string][]*jobState // map of requestor to all its jobs. Default requestor="" is ok.
+	requestorHistory map[string][]string    // map of join(requestor, basis) to new tags in the order received.
+	taskHistory      *taskHistory           // recent task durations by command, safe to use outside the scheduler loop.
 
 	requestorsCounts map[string]map[string]int // map of requestor to job and task stats counts
 
+	// Throttles new jobs when the system is saturated, safe to use outside the scheduler loop.
+	admission *admissionController
+
+	// Submits jobs from registered templates on a schedule, safe to use outside the scheduler loop.
+	recurring *recurringJobs
+
+	// Recent jobs searchable by label, safe to use outside the scheduler loop.
+	jobIndex *jobIndex
+
+	// Queue depth and estimated wait reported to clients, safe to use outside the scheduler loop.
+	queue *queueTracker
+
+	// Predicted completion of in progress jobs, safe to use outside the scheduler loop.
+	etas *etaTracker
+
+	// Workers not running a task, safe to use outside the scheduler loop.
+	idle *idleTracker
+
+	// Delivers events for finished jobs to webhooks, nil if there are none.
+	webhooks *jobWebhooks
+
+	// Tasks given up on after repeated infrastructure failures, safe to use outside the scheduler loop.
+	deadLetters *deadLetterQueue
+
+	// Workers tasks failed on before succeeding elsewhere, to blacklist bad hosts.
+	blacklist *workerBlacklist
+
+	// Cluster state as of the last step, to detect scale-ups to rebalance.
+	rebalance rebalanceState
+
 	// stats
 	stat stats.StatsReceiver
+	// stats broken down by job type, requestor and priority, safe to use outside the scheduler loop.
+	taggedStat *stats.TaggedStatsReceiver
 }
 
 // contains jobId to be killed and callback for the result of processing the request
@@ -192,7 +262,7 @@ func NewStatefulSchedulerFromCluster(
 	stat stats.StatsReceiver,
 ) Scheduler {
 	sub := cl.Subscribe()
-	return NewStatefulScheduler(
+	s := NewStatefulScheduler(
 		sub.InitialMembers,
 		sub.Updates,
 		sc,
@@ -200,6 +270,8 @@ func NewStatefulSchedulerFromCluster(
 		config,
 		stat,
 	)
+	s.cluster = cl
+	return s
 }
 
 // Create a New StatefulScheduler that implements the Scheduler interface
@@ -217,6 +289,7 @@ func NewStatefulScheduler(
 	stat stats.StatsReceiver,
 ) *statefulScheduler {
 
+	capacities := newNodeCapacities()
 	nodeReadyFn := func(node cluster.Node) (bool, time.Duration) {
 		run := rf(node)
 		st, svc, err := run.StatusAll()
@@ -231,6 +304,18 @@ func NewStatefulScheduler(
 			}
 			return false, config.ReadyFnBackoff
 		}
+		if missing := svc.Capabilities.MissingFeatures(config.RequiredWorkerFeatures); len(missing) > 0 {
+			log.WithFields(
+				log.Fields{
+					"node":         node,
+					"missing":      missing,
+					"capabilities": svc.Capabilities,
+				}).Info("New node is missing required worker features")
+			return false, config.ReadyFnBackoff
+		}
+		if svc.Capabilities != nil {
+			capacities.set(node.Id(), svc.Capabilities.Resources)
+		}
 		for _, s := range st {
 			log.WithFields(
 				log.Fields{
@@ -280,14 +365,40 @@ func NewStatefulScheduler(
 		checkJobCh:    make(chan jobCheckMsg, 1),
 		addJobCh:      make(chan jobAddedMsg, 1),
 		killJobCh:     make(chan jobKillRequest, 1), // TODO - what should this value be?
+		pauseJobCh:    make(chan jobPauseRequest, 1),
+		requeueCh:     make(chan deadLetterRequeueRequest, 1),
+		viewCh:        make(chan chan View, 1),
 
 		clusterState:     newClusterState(initialCluster, clusterUpdates, nodeReadyFn, stat),
 		inProgressJobs:   make([]*jobState, 0),
 		requestorMap:     make(map[string][]*jobState),
 		requestorHistory: make(map[string][]string),
-		taskDurations:    make(map[string]averageDuration),
+		taskHistory:      newTaskHistory(DefaultTaskHistorySize, DefaultTaskHistoryKeys),
 		requestorsCounts: make(map[string]map[string]int),
+		admission:        newAdmissionController(config.Admission, stat),
+		jobIndex:         newJobIndex(DefaultJobIndexSize),
+		queue:            newQueueTracker(stat),
+		etas:             newETATracker(),
+		idle:             newIdleTracker(),
+		webhooks:         newJobWebhooks(config.JobWebhooks, stat),
+		deadLetters:      newDeadLetterQueue(config.DeadLetter, stat),
+		blacklist:        newWorkerBlacklist(config.Blacklist),
 		stat:             stat,
+		taggedStat:       stats.NewTaggedStatsReceiver(stat, stats.DefaultMaxTagValues),
+	}
+
+	sched.clusterState.capacities = capacities
+
+	sched.recurring = newRecurringJobs(sched, stat)
+	for _, t := range config.JobTemplates {
+		if err := sched.recurring.registerTemplate(t); err != nil {
+			log.Errorf("Failed to register job template: %v", err)
+		}
+	}
+	for _, j := range config.RecurringJobs {
+		if err := sched.recurring.add(j); err != nil {
+			log.Errorf("Failed to add recurring job: %v", err)
+		}
 	}
 
 	if !config.DebugMode {
@@ -296,13 +407,17 @@ func NewStatefulScheduler(
 		go func() {
 			sched.loop()
 		}()
+		go sched.recurring.loop()
+		if config.Autoscale.Autoscaler != nil {
+			go newAutoscaler(config.Autoscale, sched.idle, sched.queue, stat).loop()
+		}
 	}
 
 	// Recover Jobs in a separate go routine to allow the scheduler
 	// to accept new jobs while recovering old ones.
 	if config.RecoverJobsOnStartup {
 		go func() {
-			recoverJobs(sched.sagaCoord, sched.addJobCh)
+			recoverJobs(sched.sagaCoord, sched.addJobCh, sched.stat)
 		}()
 	}
 	return sched
@@ -311,6 +426,8 @@ func NewStatefulScheduler(
 type jobCheckMsg struct {
 	jobDef   *sched.JobDefinition
 	resultCh chan error
+	// If set the job is only being validated and won't be added, so the check must not record it.
+	dryRun bool
 }
 
 type jobAddedMsg struct {
@@ -343,8 +460,14 @@ func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, err
 			"basis":     jobDef.Basis,
 			"priority":  jobDef.Priority,
 			"numTasks":  len(jobDef.Tasks),
+			"labels":    jobDef.Labels,
 		}).Info("New job request")
 
+	// Delay or reject the job if the cluster or CAS is saturated, before doing any other work for it.
+	if err := s.admission.admit(); err != nil {
+		return "", err
+	}
+
 	checkResultCh := make(chan error, 1)
 	s.checkJobCh <- jobCheckMsg{
 		jobDef:   &jobDef,
@@ -366,8 +489,9 @@ func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, err
 	}
 
 	job := &sched.Job{
-		Id:  generateJobId(),
-		Def: jobDef,
+		Id:      generateJobId(),
+		Def:     jobDef,
+		Created: time.Now(),
 	}
 	if job.Def.Tag == "" {
 		job.Def.Tag = job.Id
@@ -411,6 +535,7 @@ func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, err
 			"numTasks":  len(jobDef.Tasks),
 		}).Info("Queueing job request")
 	s.stat.Counter(stats.SchedJobsCounter).Inc(1)
+	s.jobStat(&jobDef).Counter(stats.SchedTaggedJobsCounter).Inc(1)
 	s.addJobCh <- jobAddedMsg{
 		job:  job,
 		saga: sagaObj,
@@ -459,9 +584,16 @@ func (s *statefulScheduler) step() {
 
 	s.checkForCompletedJobs()
 	s.killJobs()
+	s.pauseJobs()
+	s.requeueDeadLetters()
+	s.timeOutJobs()
+	s.failUnsatisfiableTasks()
 	s.scheduleTasks()
 
 	s.updateStats()
+	s.updateETAs()
+	s.updateIdleWorkers()
+	s.sendViews()
 }
 
 //update the stats monitoring values:
@@ -476,6 +608,7 @@ func (s *statefulScheduler) step() {
 //. number of jobs running or waiting to start
 func (s *statefulScheduler) updateStats() {
 	remainingTasks := 0
+	runningTasks := 0
 	jobsWaitingToStart := 0
 
 	// reset current counts to 0
@@ -504,6 +637,7 @@ func (s *statefulScheduler) updateStats() {
 		}
 
 		remainingTasks += (len(job.Tasks) - job.TasksCompleted)
+		runningTasks += job.TasksRunning
 		if job.TasksCompleted+job.TasksRunning == 0 {
 			jobsWaitingToStart += 1
 			s.requestorsCounts[requestor][jobsWaitingToStartKey]++
@@ -555,6 +689,31 @@ func (s *statefulScheduler) updateStats() {
 	s.stat.Gauge(stats.SchedWaitingJobsGauge).Update(int64(jobsWaitingToStart))
 	s.stat.Gauge(stats.SchedInProgressTasksGauge).Update(int64(remainingTasks))
 	s.stat.Gauge(stats.SchedNumRunningTasksGauge).Update(int64(s.asyncRunner.NumRunning()))
+	s.updateQueueSLOStats()
+
+	s.admission.updateLoad(remainingTasks, len(s.clusterState.nodes))
+	s.queue.update(remainingTasks-runningTasks, runningTasks, len(s.clusterState.nodes))
+}
+
+// Registers a named job template for use by recurring jobs, replacing any existing template with the same name.
+func (s *statefulScheduler) RegisterJobTemplate(t JobTemplate) error {
+	return s.recurring.registerTemplate(t)
+}
+
+// Adds a job that is run from a registered template on a cron-like schedule,
+// replacing any existing recurring job with the same name.
+func (s *statefulScheduler) AddRecurringJob(j RecurringJob) error {
+	return s.recurring.add(j)
+}
+
+// Stops running the named recurring job. Returns false if there was no such job.
+func (s *statefulScheduler) RemoveRecurringJob(name string) bool {
+	return s.recurring.remove(name)
+}
+
+// Implements CASHealthRecorder
+func (s *statefulScheduler) RecordCASResult(err error) {
+	s.admission.recordCASResult(err)
 }
 
 func (s *statefulScheduler) getSchedulerTaskCounts() (int, int, int) {
@@ -570,6 +729,58 @@ func (s *statefulScheduler) getSchedulerTaskCounts() (int, int, int) {
 	return total, completed, running
 }
 
+// Checks that a new job doesn't exceed the requestor's limits or number of requestors, has a valid priority,
+// doesn't duplicate task names and doesn't reuse an expired tag for its basis.
+// Unless dryRun is set, the job's tag is recorded for its requestor and basis.
+// Must be called from the scheduling thread.
+func (s *statefulScheduler) checkJob(jobDef *sched.JobDefinition, dryRun bool) error {
+	var err error
+	if jobs, ok := s.requestorMap[jobDef.Requestor]; !ok && len(s.requestorMap) >= s.config.MaxRequestors {
+		err = fmt.Errorf("Exceeds max number of requestors: %s (%d)", jobDef.Requestor, s.config.MaxRequestors)
+	} else if len(jobs) >= s.config.MaxJobsPerRequestor {
+		err = fmt.Errorf("Exceeds max jobs per requestor: %s (%d)", jobDef.Requestor, s.config.MaxJobsPerRequestor)
+	} else if jobDef.Priority < sched.P0 || jobDef.Priority > sched.P2 {
+		err = fmt.Errorf("Invalid priority %d, must be between 0-2 inclusive", jobDef.Priority)
+	} else {
+		// Check for duplicate task names
+		seenTasks := map[string]bool{}
+		for _, t := range jobDef.Tasks {
+			if _, ok := seenTasks[t.TaskID]; ok {
+				err = fmt.Errorf("Invalid dup taskID %s", t.TaskID)
+				break
+			}
+			seenTasks[t.TaskID] = true
+		}
+	}
+	if err == nil {
+		err = s.clusterState.checkResources(jobDef)
+	}
+	if err == nil && jobDef.Basis != "" {
+		// Check if the given tag is expired for the given requestor & basis.
+		rb := jobDef.Requestor + jobDef.Basis
+		if stringInSlice(jobDef.Tag, s.requestorHistory[rb]) &&
+			s.requestorHistory[rb][len(s.requestorHistory[rb])-1] != jobDef.Tag {
+			err = fmt.Errorf("Expired tag=%s for basis=%s. Expected either tag=%s or new tag.",
+				jobDef.Tag, jobDef.Basis, s.requestorHistory[rb][len(s.requestorHistory[rb])-1])
+		} else if !dryRun {
+			if _, ok := s.requestorHistory[rb]; !ok {
+				s.requestorHistory[rb] = []string{}
+			}
+			s.requestorHistory[rb] = append(s.requestorHistory[rb], jobDef.Tag)
+		}
+	}
+	return err
+}
+
+// Returns a StatsReceiver whose stats are broken down by the job's type, requestor and priority.
+func (s *statefulScheduler) jobStat(def *sched.JobDefinition) stats.StatsReceiver {
+	return s.taggedStat.Tagged(
+		stats.Tag{Key: "jobType", Value: def.JobType},
+		stats.Tag{Key: "requestor", Value: def.Requestor},
+		stats.Tag{Key: "priority", Value: strconv.Itoa(int(def.Priority))},
+	)
+}
+
 // Checks if any new jobs have been requested since the last loop and adds
 // them to the jobs the scheduler is handling
 func (s *statefulScheduler) addJobs() {
@@ -588,39 +799,7 @@ checkLoop:
 	for {
 		select {
 		case checkJobMsg := <-s.checkJobCh:
-			var err error
-			if jobs, ok := s.requestorMap[checkJobMsg.jobDef.Requestor]; !ok && len(s.requestorMap) >= s.config.MaxRequestors {
-				err = fmt.Errorf("Exceeds max number of requestors: %s (%d)", checkJobMsg.jobDef.Requestor, s.config.MaxRequestors)
-			} else if len(jobs) >= s.config.MaxJobsPerRequestor {
-				err = fmt.Errorf("Exceeds max jobs per requestor: %s (%d)", checkJobMsg.jobDef.Requestor, s.config.MaxJobsPerRequestor)
-			} else if checkJobMsg.jobDef.Priority < sched.P0 || checkJobMsg.jobDef.Priority > sched.P2 {
-				err = fmt.Errorf("Invalid priority %d, must be between 0-2 inclusive", checkJobMsg.jobDef.Priority)
-			} else {
-				// Check for duplicate task names
-				seenTasks := map[string]bool{}
-				for _, t := range checkJobMsg.jobDef.Tasks {
-					if _, ok := seenTasks[t.TaskID]; ok {
-						err = fmt.Errorf("Invalid dup taskID %s", t.TaskID)
-						break
-					}
-					seenTasks[t.TaskID] = true
-				}
-			}
-			if err == nil && checkJobMsg.jobDef.Basis != "" {
-				// Check if the given tag is expired for the given requestor & basis.
-				rb := checkJobMsg.jobDef.Requestor + checkJobMsg.jobDef.Basis
-				if stringInSlice(checkJobMsg.jobDef.Tag, s.requestorHistory[rb]) &&
-					s.requestorHistory[rb][len(s.requestorHistory[rb])-1] != checkJobMsg.jobDef.Tag {
-					err = fmt.Errorf("Expired tag=%s for basis=%s. Expected either tag=%s or new tag.",
-						checkJobMsg.jobDef.Tag, checkJobMsg.jobDef.Basis, s.requestorHistory[rb][len(s.requestorHistory[rb])-1])
-				} else {
-					if _, ok := s.requestorHistory[rb]; !ok {
-						s.requestorHistory[rb] = []string{}
-					}
-					s.requestorHistory[rb] = append(s.requestorHistory[rb], checkJobMsg.jobDef.Tag)
-				}
-			}
-			checkJobMsg.resultCh <- err
+			checkJobMsg.resultCh <- s.checkJob(checkJobMsg.jobDef, checkJobMsg.dryRun)
 		default:
 			break checkLoop
 		}
@@ -672,8 +851,10 @@ addLoop:
 				newJobMsg.job.Def.Priority = MaxPriority
 			}
 
-			js := newJobState(newJobMsg.job, newJobMsg.saga, s.taskDurations)
+			js := newJobState(newJobMsg.job, newJobMsg.saga, s.taskHistory)
 			s.inProgressJobs = append(s.inProgressJobs, js)
+			s.jobIndex.add(js)
+			s.endDeadLetters(s.deadLetters.recover(js))
 
 			sort.Sort(sort.Reverse(taskStatesByDuration(js.Tasks)))
 			req := newJobMsg.job.Def.Requestor
@@ -744,6 +925,9 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 
 			s.asyncRunner.RunAsync(
 				func() error {
+					if j.JobTimedOut || j.Saga.GetState().IsSagaAborted() {
+						return rollBackSaga(j.Saga)
+					}
 					//FIXME: seeing panic on closed channel here after killjob().
 					return j.Saga.EndSaga()
 				},
@@ -756,6 +940,13 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 								"jobType":   j.Job.Def.JobType,
 								"tag":       j.Job.Def.Tag,
 							}).Info("Job completed and logged")
+						s.jobStat(&j.Job.Def).Histogram(stats.SchedTaggedJobLatencyHistogram_ms).Update(
+							int64(time.Since(j.TimeCreated) / time.Millisecond))
+						if s.config.Archive != nil || s.webhooks != nil {
+							rec := finishedJobRecord(j)
+							s.archiveJob(rec)
+							s.webhooks.notify(rec)
+						}
 						// This job is fully processed remove from InProgressJobs
 						s.deleteJob(j.Job.Id)
 					} else {
@@ -777,14 +968,52 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 	}
 }
 
+// Aborts the saga and compensates each started task before ending it, so the job reports as rolled back.
+// Scoot tasks have no compensating actions, so compensation only records the rollback.
+// Safe to retry if logging fails partway through.
+func rollBackSaga(sg *saga.Saga) error {
+	if err := sg.AbortSaga(); err != nil {
+		return err
+	}
+	state := sg.GetState()
+	for _, id := range state.GetTaskIds() {
+		if !state.IsTaskStarted(id) || state.IsCompTaskCompleted(id) {
+			continue
+		}
+		if !state.IsCompTaskStarted(id) {
+			if err := sg.StartCompensatingTask(id, nil); err != nil {
+				return err
+			}
+		}
+		if err := sg.EndCompensatingTask(id, nil); err != nil {
+			return err
+		}
+	}
+	return sg.EndSaga()
+}
+
 // figures out which tasks to schedule next and on which worker and then runs them
 func (s *statefulScheduler) scheduleTasks() {
+	// Give nodes that joined a saturated cluster to the tasks that have waited longest, before regular scheduling.
+	s.runTaskAssignments(s.getRebalanceAssignments())
+
 	// Calculate a list of Tasks to Node Assignments & start running all those jobs
 	// Pass nil config so taskScheduler can determine the most appropriate values itself.
 	taskAssignments, nodeGroups := getTaskAssignments(s.clusterState, s.inProgressJobs, s.requestorMap, nil, s.stat)
 	if taskAssignments != nil {
 		s.clusterState.nodeGroups = nodeGroups
 	}
+	// Duplicate stragglers on any nodes still idle.
+	taskAssignments = append(taskAssignments, s.getSpeculativeAssignments(taskAssignments)...)
+	s.runTaskAssignments(taskAssignments)
+
+	// Free up cordoned nodes by moving long running tasks to nodes that are still idle.
+	s.migrateTasks()
+	s.updateRebalanceState()
+}
+
+// starts each task on its assigned node and handles its result
+func (s *statefulScheduler) runTaskAssignments(taskAssignments []taskAssignment) {
 	for _, ta := range taskAssignments {
 		// Set up variables for async functions & callback
 		task := ta.task
@@ -798,6 +1027,7 @@ func (s *statefulScheduler) scheduleTasks() {
 		taskDef.JobID = jobID
 		taskDef.Tag = tag
 		jobState := s.getJob(jobID)
+		taskDef.Retention = jobState.Job.Def.Retention
 		sa := jobState.Saga
 		rs := s.runnerFactory(nodeSt.node)
 
@@ -807,13 +1037,14 @@ func (s *statefulScheduler) scheduleTasks() {
 		s.clusterState.taskScheduled(nodeSt.node.Id(), jobID, taskID, taskDef.SnapshotID)
 		log.WithFields(
 			log.Fields{
-				"jobID":     jobID,
-				"taskID":    taskID,
-				"node":      nodeSt.node,
-				"requestor": requestor,
-				"jobType":   jobType,
-				"tag":       tag,
-				"taskDef":   taskDef,
+				"jobID":       jobID,
+				"taskID":      taskID,
+				"node":        nodeSt.node,
+				"requestor":   requestor,
+				"jobType":     jobType,
+				"tag":         tag,
+				"taskDef":     taskDef,
+				"speculative": ta.speculative,
 			}).Info("Task scheduled")
 
 		tRunner := &taskRunner{
@@ -826,6 +1057,8 @@ func (s *statefulScheduler) scheduleTasks() {
 			runnerRetryTimeout:    s.config.RunnerRetryTimeout,
 			runnerRetryInterval:   s.config.RunnerRetryInterval,
 			markCompleteOnFailure: preventRetries,
+			maxInfraFailures:      s.config.DeadLetter.MaxInfraFailures,
+			infraFailures:         task.InfraFailures,
 
 			LogTags: tags.LogTags{
 				JobID:  jobID,
@@ -840,19 +1073,36 @@ func (s *statefulScheduler) scheduleTasks() {
 			queryAbortCh: make(chan interface{}, 1),
 
 			startTime: time.Now(),
+
+			speculative: ta.speculative,
 		}
 
-		// mark the task as started in the jobState and record its taskRunner
-		jobState.taskStarted(taskID, tRunner)
+		if ta.speculative {
+			// the task is already started, run this alongside its current attempt
+			attempts := task.TaskRunner.attempts
+			attempts.speculated = true
+			attempts.add(tRunner)
+			s.stat.Counter(stats.SchedSpeculativeTasksCounter).Inc(1)
+		} else {
+			// mark the task as started in the jobState and record its taskRunner
+			s.recordQueueTime(jobState, task)
+			newTaskAttempts(tRunner)
+			jobState.taskStarted(taskID, tRunner)
+		}
+		// a speculative duplicate shares the attempt number of the run it duplicates
+		tRunner.attempt = task.Attempts
 
 		s.asyncRunner.RunAsync(
 			tRunner.run,
 			func(err error) {
 				defer rs.Release()
-				// Update the average duration for this task so, for new jobs, we can schedule the likely long running tasks first.
+				// Record the duration of this command so, for new jobs, we can schedule the likely long running tasks first.
 				if err == nil || err.(*taskError).st.State == runner.TIMEDOUT ||
 					(err.(*taskError).st.State == runner.COMPLETE && err.(*taskError).st.ExitCode == 0) {
-					s.taskDurations[taskID].update(time.Now().Sub(tRunner.startTime))
+					s.taskHistory.record(taskDef, time.Now().Sub(tRunner.startTime))
+					s.jobStat(&jobState.Job.Def).Histogram(stats.SchedTaggedTaskLatencyHistogram_ms).Update(
+						int64(time.Now().Sub(tRunner.startTime) / time.Millisecond))
+					s.queue.recordTaskDuration(time.Now().Sub(tRunner.startTime))
 				}
 
 				// If the node is absent, or was deleted then re-added, then we need to selectively clean up.
@@ -886,23 +1136,77 @@ func (s *statefulScheduler) scheduleTasks() {
 					preempted = true
 				}
 
+				// With concurrent attempts at this task, only the one that logged its result, or the last
+				// to finish if none did, updates the job. The others just free their node.
+				if !tRunner.attempts.done(tRunner) {
+					log.WithFields(
+						log.Fields{
+							"jobId":       jobID,
+							"taskId":      taskID,
+							"node":        nodeSt.node,
+							"speculative": tRunner.speculative,
+							"requestor":   requestor,
+							"jobType":     jobType,
+							"tag":         tag,
+						}).Info("Attempt at task finished after another, freeing node.")
+					s.clusterState.taskCompleted(nodeId, err != nil && err.(*taskError).runnerErr != nil)
+					return
+				}
+				if tRunner.speculative && err == nil {
+					s.stat.Counter(stats.SchedSpeculativeWinsCounter).Inc(1)
+				}
+
 				flaky := false
+				succeeded := (err == nil)
 				aborted := (err != nil && err.(*taskError).st.State == runner.ABORTED)
+				paused := aborted && err.(*taskError).st.Error == JobPausedErrStr
+				migrated := aborted && err.(*taskError).st.Error == TaskMigratedErrStr
+				regang := aborted && err.(*taskError).st.Error == GangRestartedErrStr
+				// Set if this task was a gang job's and failed, so the rest of its gang has to be restarted or given up on.
+				gangRetry, gangFailed := false, false
 				if err != nil {
 					// Get the type of error. Currently we only care to distinguish runner (ex: thrift) errors to mark flaky nodes.
 					taskErr := err.(*taskError)
 					flaky = (taskErr.runnerErr != nil)
 
 					msg := "Error running job (will be retried):"
-					if aborted {
+					if paused {
+						msg = "Task aborted, job paused (will be rerun when resumed):"
+						jobState.errorRunningTask(taskID, err, true)
+					} else if migrated {
+						msg = "Task aborted to migrate it off a cordoned node (will be rescheduled):"
+						jobState.errorRunningTask(taskID, err, true)
+					} else if regang {
+						msg = "Task aborted, another task in its gang failed (will be rerun with its gang):"
+						jobState.errorRunningTask(taskID, err, true)
+					} else if aborted {
 						msg = "Error running task, but job kill request received, (will not retry):"
 						err = nil
 					} else {
-						if preventRetries {
+						if taskErr.runnerErr != nil {
+							task.InfraFailures++
+						}
+						gangFailed = jobState.Job.Def.Gang
+						if taskErr.deadLettered {
+							msg = fmt.Sprintf("Error running task (dead lettered after %d infrastructure failures, "+
+								"will be rerun if requeued):", task.InfraFailures)
+							jobState.errorRunningTask(taskID, err, preempted)
+							task.DeadLettered = true
+							s.endDeadLetters(s.deadLetters.add(newDeadLetter(jobState, task, task.InfraFailures, taskErr.st, time.Now())))
+							s.stat.Counter(stats.SchedDeadLetteredTasksCounter).Inc(1)
+						} else if preventRetries {
 							msg = fmt.Sprintf("Error running task (quitting, hit max retries of %d):", s.config.MaxRetriesPerTask)
 							err = nil
+						} else if taskErr.noRetry {
+							msg = fmt.Sprintf("Error running task (quitting, %s failure would recur if retried):", taskErr.st.FailureKind)
+							s.stat.Counter(stats.SchedNonRetryableTaskFailuresCounter).Inc(1)
+							err = nil
 						} else {
+							gangRetry, gangFailed = gangFailed, false
 							jobState.errorRunningTask(taskID, err, preempted)
+							if !nodeStChanged {
+								s.blacklist.taskFailed(jobID, taskID, nodeId)
+							}
 						}
 					}
 					log.WithFields(
@@ -939,7 +1243,7 @@ func (s *statefulScheduler) scheduleTasks() {
 						}()
 					}
 				}
-				if err == nil || aborted {
+				if err == nil || (aborted && !paused && !migrated && !regang) {
 					log.WithFields(
 						log.Fields{
 							"jobId":     jobID,
@@ -950,6 +1254,19 @@ func (s *statefulScheduler) scheduleTasks() {
 							"tag":       tag,
 						}).Info("Ending task.")
 					jobState.taskCompleted(taskID, true)
+					if succeeded && !nodeStChanged {
+						for _, n := range s.blacklist.taskSucceeded(jobID, taskID, nodeId) {
+							s.blacklistWorker(n)
+						}
+					} else {
+						s.blacklist.taskEnded(jobID, taskID)
+					}
+				}
+
+				if gangRetry {
+					s.restartGang(jobState, taskID)
+				} else if gangFailed && !jobState.JobKilled {
+					s.failGang(jobState, taskID)
 				}
 
 				// update cluster state that this node is now free and if we consider the runner to be flaky.
@@ -1079,45 +1396,141 @@ func (s *statefulScheduler) killJobs() {
 
 	// kill the jobs with valid ids
 	for _, req := range validKillRequests {
-		jobState := s.getJob(req.jobId)
-		inProgress, notStarted := 0, 0
-		logFields := log.Fields{
-			"jobID":     req.jobId,
-			"requestor": s.getJob(req.jobId).Job.Def.Requestor,
-			"jobType":   s.getJob(req.jobId).Job.Def.JobType,
-			"tag":       s.getJob(req.jobId).Job.Def.Tag,
+		s.abortJobTasks(s.getJob(req.jobId), UserRequestedErrStr)
+		req.responseCh <- nil
+	}
+}
+
+// Kills jobs that have been running longer than their Timeout, the same as a user requested
+// kill except that the job is rolled back once its aborted tasks have finished.
+// Paused jobs aren't timed out, since they can't finish until they're resumed.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) timeOutJobs() {
+	for _, jobState := range s.inProgressJobs {
+		timeout := jobState.Job.Def.Timeout
+		if timeout <= 0 || jobState.JobKilled || jobState.Paused || time.Since(jobState.TimeCreated) < timeout {
+			continue
 		}
-		for _, task := range jobState.Tasks {
-			logFields["taskID"] = task.TaskId
-			if task.Status == sched.InProgress {
-				task.TaskRunner.Abort(true, UserRequestedErrStr)
-				inProgress++
-			} else if task.Status == sched.NotStarted {
-				st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId})
-				st.Error = UserRequestedErrStr
-				statusAsBytes, err := workerapi.SerializeProcessStatus(st)
-				if err != nil {
-					s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
-				}
-				s.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
-				if err := jobState.Saga.StartTask(task.TaskId, nil); err != nil {
-					logFields["err"] = err
-					log.WithFields(logFields).Info("killJobs saga.StartTask failure.")
-				}
-				if err := jobState.Saga.EndTask(task.TaskId, statusAsBytes); err != nil {
-					logFields["err"] = err
-					log.WithFields(logFields).Info("killJobs saga.EndTask failure.")
-				}
-				jobState.taskCompleted(task.TaskId, false)
-				notStarted++
+		jobState.JobKilled = true
+		jobState.JobTimedOut = true
+		s.stat.Counter(stats.SchedJobTimeoutsCounter).Inc(1)
+		log.WithFields(
+			log.Fields{
+				"jobID":     jobState.Job.Id,
+				"requestor": jobState.Job.Def.Requestor,
+				"jobType":   jobState.Job.Def.JobType,
+				"tag":       jobState.Job.Def.Tag,
+				"timeout":   timeout,
+			}).Info("Job exceeded its timeout, killing")
+		s.abortJobTasks(jobState, fmt.Sprintf("%s after %s", JobTimedOutErrStr, timeout))
+	}
+}
+
+// Aborts the running tasks of a gang job after its task taskID failed and is being retried, so they're rerun
+// together with it rather than the retry running without the rest of its gang.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) restartGang(jobState *jobState, taskID string) {
+	restarted := 0
+	for _, task := range jobState.Tasks {
+		if task.Status != sched.InProgress {
+			continue
+		}
+		if task.TaskRunner.attempts != nil {
+			task.TaskRunner.attempts.abort(false, GangRestartedErrStr)
+		} else {
+			task.TaskRunner.Abort(false, GangRestartedErrStr)
+		}
+		restarted++
+	}
+	s.stat.Counter(stats.SchedGangJobsRestartedCounter).Inc(1)
+	log.WithFields(
+		log.Fields{
+			"jobID":     jobState.Job.Id,
+			"taskID":    taskID,
+			"restarted": restarted,
+			"requestor": jobState.Job.Def.Requestor,
+			"jobType":   jobState.Job.Def.JobType,
+			"tag":       jobState.Job.Def.Tag,
+		}).Info("Gang job's task failed, restarting its gang")
+}
+
+// Kills a gang job after its task taskID failed and won't be retried, since the rest of its gang can't
+// complete without it.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) failGang(jobState *jobState, taskID string) {
+	jobState.JobKilled = true
+	s.stat.Counter(stats.SchedGangJobsFailedCounter).Inc(1)
+	log.WithFields(
+		log.Fields{
+			"jobID":     jobState.Job.Id,
+			"taskID":    taskID,
+			"requestor": jobState.Job.Def.Requestor,
+			"jobType":   jobState.Job.Def.JobType,
+			"tag":       jobState.Job.Def.Tag,
+		}).Info("Gang job's task failed and won't be retried, killing the job")
+	s.abortJobTasks(jobState, GangFailedErrStr)
+}
+
+// Aborts the job's running tasks and completes its not started tasks with an aborted status, using errStr as the reason.
+func (s *statefulScheduler) abortJobTasks(jobState *jobState, errStr string) {
+	inProgress, notStarted := 0, 0
+	logFields := log.Fields{
+		"jobID":     jobState.Job.Id,
+		"requestor": jobState.Job.Def.Requestor,
+		"jobType":   jobState.Job.Def.JobType,
+		"tag":       jobState.Job.Def.Tag,
+	}
+	for _, task := range jobState.Tasks {
+		if task.Status == sched.InProgress {
+			if task.TaskRunner.attempts != nil {
+				task.TaskRunner.attempts.abort(true, errStr)
+			} else {
+				task.TaskRunner.Abort(true, errStr)
 			}
+			inProgress++
+		} else if task.Status == sched.NotStarted {
+			st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId})
+			st.Error = errStr
+			s.endUnstartedTask(jobState, task, st)
+			notStarted++
 		}
-		delete(logFields, "err")
-		delete(logFields, "taskID")
-		log.WithFields(logFields).Info("killJobs summary")
+	}
+	logFields["inProgress"] = inProgress
+	logFields["notStarted"] = notStarted
+	log.WithFields(logFields).Info("killJobs summary")
+}
 
-		req.responseCh <- nil
+// Completes a task that hasn't started with the status st, without running it.
+func (s *statefulScheduler) endUnstartedTask(jobState *jobState, task *taskState, st runner.RunStatus) {
+	logFields := log.Fields{
+		"jobID":     jobState.Job.Id,
+		"taskID":    task.TaskId,
+		"requestor": jobState.Job.Def.Requestor,
+		"jobType":   jobState.Job.Def.JobType,
+		"tag":       jobState.Job.Def.Tag,
+	}
+	if task.DeadLettered {
+		s.deadLetters.remove(jobState.Job.Id, task.TaskId)
+		task.DeadLettered = false
+	}
+	statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+	if err != nil {
+		s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
+	}
+	s.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
+	if err := jobState.Saga.StartTask(task.TaskId, nil); err != nil {
+		logFields["err"] = err
+		log.WithFields(logFields).Info("killJobs saga.StartTask failure.")
+	}
+	if err := jobState.Saga.EndTask(task.TaskId, statusAsBytes); err != nil {
+		logFields["err"] = err
+		log.WithFields(logFields).Info("killJobs saga.EndTask failure.")
 	}
+	jobState.taskCompleted(task.TaskId, false)
+	s.blacklist.taskEnded(jobState.Job.Id, task.TaskId)
 }
 
 // set the max schedulable tasks.   -1 = unlimited, 0 = don't accept any more requests, >0 = only accept job
@@ -19,6 +19,7 @@ import (
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/archive"
 	"github.com/twitter/scoot/sched/worker/workers"
 	"github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/snapshots"
@@ -331,7 +332,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 
 	// add additional saga data
 	gomock.InOrder(
-		sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage(jobId, taskId, nil)),
+		sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskAttemptMessage(jobId, taskId, 1, nil)),
 		sagaLogMock.EXPECT().LogMessage(
 			TaskMessageMatcher{Type: &sagaStartTask, JobId: jobId, TaskId: taskId, Data: gomock.Any()}).MinTimes(0),
 		sagaLogMock.EXPECT().LogMessage(
@@ -376,6 +377,32 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 
 }
 
+func Test_StatefulScheduler_ArchivesFinishedJobs(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	deps.config.Archive = archive.NewMemoryArchive(archive.Retention{})
+	s := makeStatefulSchedulerDeps(deps)
+
+	jobId, taskIds, _ := putJobInScheduler(2, s, "", "someone", sched.P0)
+	s.step()
+	for s.getJob(jobId) != nil {
+		s.step()
+	}
+
+	// Jobs are archived in the background.
+	var jobs []archive.Job
+	for start := time.Now(); len(jobs) == 0 && time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		jobs, _ = s.QueryArchive(archive.Query{Requestor: "someone"})
+	}
+	if len(jobs) != 1 || jobs[0].ID != jobId || jobs[0].RolledBack || len(jobs[0].Tasks) != len(taskIds) {
+		t.Fatalf("Expected job %s to be archived, got %+v", jobId, jobs)
+	}
+	for _, task := range jobs[0].Tasks {
+		if task.State != runner.COMPLETE || task.Attempts != 1 {
+			t.Errorf("Expected task %s to be completed on its first attempt, got %+v", task.ID, task)
+		}
+	}
+}
+
 func Test_StatefulScheduler_KillStartedJob(t *testing.T) {
 	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
 	s, _, _ := initializeServices(sc, false)
@@ -400,6 +427,112 @@ func Test_StatefulScheduler_KillStartedJob(t *testing.T) {
 	verifyJobStatus("verify kill", jobId, sched.Completed, []sched.Status{sched.Completed}, s, t)
 }
 
+func Test_StatefulScheduler_JobTimeout(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+	s, _, statsRegistry := initializeServices(sc, false)
+
+	// with more tasks than the 5 nodes, the timeout aborts both running and not started tasks.
+	jobId, taskIds, _ := putJobInScheduler(6, s, "pause", "", sched.P0)
+	s.step()
+	for s.getJob(jobId).getTask(taskIds[0]).Status == sched.NotStarted {
+		s.step()
+	}
+
+	// a paused job isn't timed out until it's resumed.
+	respCh := sendPauseRequest(sched.PauseJobReq{JobID: jobId, Paused: true}, s)
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from pauseJob request, instead got:%s", err.Error())
+	}
+	s.getJob(jobId).Job.Def.Timeout = time.Millisecond
+	time.Sleep(time.Millisecond)
+	for i := 0; i < 10; i++ {
+		s.step()
+	}
+	if s.getJob(jobId) == nil || s.getJob(jobId).JobKilled {
+		t.Fatal("Expected the paused job not to be timed out")
+	}
+	respCh = sendPauseRequest(sched.PauseJobReq{JobID: jobId, Paused: false}, s)
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from resumeJob request, instead got:%s", err.Error())
+	}
+
+	for s.getJob(jobId) != nil {
+		s.step()
+	}
+
+	state, err := sc.GetSagaState(jobId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.IsSagaAborted() || !state.IsSagaCompleted() {
+		t.Errorf("Expected the timed out job to be rolled back, got %v", state)
+	}
+	for _, id := range taskIds {
+		if !state.IsCompTaskCompleted(id) {
+			t.Errorf("Expected task %s to be compensated", id)
+		}
+		if data := string(state.GetEndTaskData(id)); !strings.Contains(data, JobTimedOutErrStr) {
+			t.Errorf("Expected task %s to be aborted for the job timeout, got %s", id, data)
+		}
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedJobTimeoutsCounter: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func Test_StatefulScheduler_PauseJob(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+	s, _, statsRegistry := initializeServices(sc, false)
+
+	jobId, taskIds, _ := putJobInScheduler(1, s, "pause", "", sched.P0)
+	s.step()
+	for s.getJob(jobId).getTask(taskIds[0]).Status == sched.NotStarted {
+		s.step()
+	}
+
+	// pausing with AbortRunning puts the running task back to be rerun once the job is resumed.
+	respCh := sendPauseRequest(sched.PauseJobReq{JobID: jobId, Paused: true, AbortRunning: true}, s)
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from pauseJob request, instead got:%s", err.Error())
+	}
+	for s.getJob(jobId).getTask(taskIds[0]).Status == sched.InProgress {
+		s.step()
+	}
+	for i := 0; i < 10; i++ {
+		s.step()
+	}
+	verifyJobStatus("verify pause", jobId, sched.InProgress, []sched.Status{sched.NotStarted}, s, t)
+	if state, _ := sc.GetSagaState(jobId); !state.IsSagaPaused() || state.IsTaskCompleted(taskIds[0]) {
+		t.Errorf("Expected the saga to be paused with its task not completed, got %v", state)
+	}
+
+	respCh = sendPauseRequest(sched.PauseJobReq{JobID: jobId, Paused: true}, s)
+	if err := waitForResponse(respCh, s); err == nil {
+		t.Errorf("Expected an error pausing an already paused job")
+	}
+
+	respCh = sendPauseRequest(sched.PauseJobReq{JobID: jobId, Paused: false}, s)
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from resumeJob request, instead got:%s", err.Error())
+	}
+	for s.getJob(jobId).getTask(taskIds[0]).Status == sched.NotStarted {
+		s.step()
+	}
+	if state, _ := sc.GetSagaState(jobId); state.IsSagaPaused() {
+		t.Errorf("Expected the saga to be resumed, got %v", state)
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedJobsPausedCounter:  {Checker: stats.Int64EqTest, Value: 1},
+			stats.SchedJobsResumedCounter: {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
 func Test_StatefulScheduler_KillNotFoundJob(t *testing.T) {
 	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
 	s, _, _ := initializeServices(sc, false)
@@ -644,6 +777,15 @@ func sendKillRequest(jobId string, s *statefulScheduler) chan error {
 	return respCh
 }
 
+func sendPauseRequest(req sched.PauseJobReq, s *statefulScheduler) chan error {
+	respCh := make(chan error)
+	go func(respCh chan error) {
+		respCh <- s.PauseJob(req)
+	}(respCh)
+
+	return respCh
+}
+
 func initializeServices(sc saga.SagaCoordinator, useDefaultDeps bool) (*statefulScheduler, []*execers.SimExecer, stats.StatsRegistry) {
 	var deps *schedulerDeps
 	var exs []*execers.SimExecer
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// Number of most recent durations kept for each command.
+const DefaultTaskHistorySize = 20
+
+// Number of distinct commands with durations kept, the least recently added command is dropped first.
+const DefaultTaskHistoryKeys = 20000
+
+// Recent durations of successful runs, keyed both by a task's command digest and snapshot, and by its
+// command digest alone, so a command run against a new snapshot is predicted from its other snapshots.
+// Predictions are used to run long tasks first, to report ETAs and to detect stragglers.
+// It is safe to use outside the scheduler loop. A nil taskHistory has no durations.
+type taskHistory struct {
+	size int
+
+	mu        sync.Mutex
+	durations map[string]*durationRing
+	keys      []string // keys in the order added, used as a ring once full.
+	next      int
+}
+
+type durationRing struct {
+	durations []time.Duration
+	next      int
+}
+
+func newTaskHistory(size, keys int) *taskHistory {
+	return &taskHistory{size: size, durations: map[string]*durationRing{}, keys: make([]string, 0, keys)}
+}
+
+func commandKey(def sched.TaskDefinition) string {
+	return sched.CommandDigest(def)
+}
+
+func snapshotKey(def sched.TaskDefinition) string {
+	return def.SnapshotID + "/" + sched.CommandDigest(def)
+}
+
+func (h *taskHistory) record(def sched.TaskDefinition, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recordKey(snapshotKey(def), d)
+	h.recordKey(commandKey(def), d)
+}
+
+// Must be called with h.mu held.
+func (h *taskHistory) recordKey(key string, d time.Duration) {
+	r, ok := h.durations[key]
+	if !ok {
+		r = &durationRing{durations: make([]time.Duration, 0, h.size)}
+		h.durations[key] = r
+		if len(h.keys) < cap(h.keys) {
+			h.keys = append(h.keys, key)
+		} else {
+			delete(h.durations, h.keys[h.next])
+			h.keys[h.next] = key
+			h.next = (h.next + 1) % len(h.keys)
+		}
+	}
+	if len(r.durations) < cap(r.durations) {
+		r.durations = append(r.durations, d)
+		return
+	}
+	r.durations[r.next] = d
+	r.next = (r.next + 1) % len(r.durations)
+}
+
+// Returns the p'th percentile of durations recorded for the task's command, preferring durations for the
+// same snapshot if there are enough. Returns false if there are fewer than minSamples.
+func (h *taskHistory) percentile(def sched.TaskDefinition, p float64, minSamples int) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range []string{snapshotKey(def), commandKey(def)} {
+		if r, ok := h.durations[key]; ok && len(r.durations) >= minSamples && len(r.durations) > 0 {
+			return percentile(r.durations, p), true
+		}
+	}
+	return 0, false
+}
+
+// Returns the median of previous durations of the task's command, or false if it hasn't run before.
+func (h *taskHistory) predict(def sched.TaskDefinition) (time.Duration, bool) {
+	return h.percentile(def, .5, 1)
+}
+
+func percentile(durations []time.Duration, p float64) time.Duration {
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	} else if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// DurationPredictor is implemented by schedulers that can predict how long a task will run.
+type DurationPredictor interface {
+	// Returns the predicted duration of a task, or false if there's no history for its command.
+	PredictDuration(def sched.TaskDefinition) (time.Duration, bool)
+}
+
+func (s *statefulScheduler) PredictDuration(def sched.TaskDefinition) (time.Duration, bool) {
+	return s.taskHistory.predict(def)
+}
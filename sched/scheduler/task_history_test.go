@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+)
+
+func makeHistoryTask(snapshotID string, argv ...string) sched.TaskDefinition {
+	return sched.TaskDefinition{Command: runner.Command{Argv: argv, SnapshotID: snapshotID}}
+}
+
+func Test_TaskHistory_Percentile(t *testing.T) {
+	h := newTaskHistory(4, 4)
+	task1 := makeHistoryTask("snap1", "task1")
+
+	if _, ok := h.percentile(task1, .5, 1); ok {
+		t.Fatalf("Expected no percentile for a command without history")
+	}
+	for i := 1; i <= 3; i++ {
+		h.record(task1, time.Duration(i)*time.Second)
+	}
+	if _, ok := h.percentile(task1, .5, 4); ok {
+		t.Fatalf("Expected no percentile with fewer than minSamples durations")
+	}
+	if d, _ := h.percentile(task1, .5, 1); d != 2*time.Second {
+		t.Fatalf("Expected median of 2s, got %v", d)
+	}
+	if d, _ := h.percentile(task1, 1, 1); d != 3*time.Second {
+		t.Fatalf("Expected 100th percentile of 3s, got %v", d)
+	}
+
+	// Only the most recent durations are kept.
+	for i := 0; i < 4; i++ {
+		h.record(task1, time.Minute)
+	}
+	if d, _ := h.percentile(task1, .01, 1); d != time.Minute {
+		t.Fatalf("Expected old durations to be dropped, got %v", d)
+	}
+
+	// Only the most recently added commands are kept.
+	h.record(makeHistoryTask("snap1", "task2"), time.Second)
+	h.record(makeHistoryTask("snap1", "task3"), time.Second)
+	if _, ok := h.percentile(task1, .5, 1); ok {
+		t.Fatalf("Expected the oldest command to be dropped")
+	}
+	if _, ok := h.percentile(makeHistoryTask("snap1", "task3"), .5, 1); !ok {
+		t.Fatalf("Expected history for the newest command")
+	}
+}
+
+func Test_TaskHistory_Predict(t *testing.T) {
+	h := newTaskHistory(DefaultTaskHistorySize, DefaultTaskHistoryKeys)
+	h.record(makeHistoryTask("snap1", "build"), time.Minute)
+	h.record(makeHistoryTask("snap2", "build"), 3*time.Minute)
+	h.record(makeHistoryTask("snap2", "build"), 3*time.Minute)
+
+	// The same command and snapshot is preferred.
+	if d, ok := h.predict(makeHistoryTask("snap1", "build")); !ok || d != time.Minute {
+		t.Fatalf("Expected prediction of 1m for snap1, got %v %t", d, ok)
+	}
+	// A new snapshot is predicted from the command's runs on other snapshots.
+	if d, ok := h.predict(makeHistoryTask("snap3", "build")); !ok || d != 3*time.Minute {
+		t.Fatalf("Expected prediction of 3m for a new snapshot, got %v %t", d, ok)
+	}
+	if _, ok := h.predict(makeHistoryTask("snap1", "test")); ok {
+		t.Fatalf("Expected no prediction for a new command")
+	}
+	var nilHistory *taskHistory
+	if _, ok := nilHistory.predict(makeHistoryTask("snap1", "build")); ok {
+		t.Fatalf("Expected no prediction from a nil history")
+	}
+}
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -31,6 +32,8 @@ type taskRunner struct {
 	stat   stats.StatsReceiver
 
 	markCompleteOnFailure bool
+	maxInfraFailures      int           // Infrastructure failures after which the task is dead lettered, zero if disabled.
+	infraFailures         int           // Infrastructure failures of the task before this run.
 	taskTimeoutOverhead   time.Duration // How long to wait for a response after the task has timed out.
 	defaultTaskTimeout    time.Duration // Use this timeout as the default for any cmds that don't have one.
 	runnerRetryTimeout    time.Duration // How long to keep retrying a runner req
@@ -44,6 +47,10 @@ type taskRunner struct {
 	queryAbortCh chan interface{} // Secondary channel to pass to blocking query.
 
 	startTime time.Time
+
+	attempts    *taskAttempts // Shared with any other attempt running the same task, nil if there is none.
+	speculative bool          // True if this attempt duplicates a straggling one.
+	attempt     int           // Numbers this run's saga messages so duplicates are ignored, zero if unnumbered.
 }
 
 // Return a custom error from run() so the scheduler has more context.
@@ -52,6 +59,9 @@ type taskError struct {
 	runnerErr error
 	resultErr error // Note: resultErr is the error from trying to get the results of the command, not an error from the command
 	st        runner.RunStatus
+	noRetry   bool // True if the failure would recur if the task were retried, see runner.FailureKind
+	// True if the task was given up on after an infrastructure failure and logged to the saga as dead lettered.
+	deadLettered bool
 }
 
 func (t *taskError) Error() string {
@@ -87,24 +97,58 @@ func (r *taskRunner) run() error {
 	taskErr.st = st
 
 	// We got a good message back, but it indicates an error. Update taskErr accordingly.
+	// Only infrastructure failures are retried, other failures would just recur.
 	completed := (st.State == runner.COMPLETE)
+	if err == nil && st.State.IsDone() {
+		taskErr.st.FailureKind = st.Failure()
+	}
 	if err == nil && !completed {
+		failure := st.Failure()
 		switch st.State {
 		case runner.FAILED, runner.UNKNOWN, runner.BADREQUEST:
-			// runnerErr can be thrift related above, or in this case some other failure that's likely our fault.
 			err = fmt.Errorf(st.Error)
-			taskErr.runnerErr = err
+			if failure == runner.InfraFailure {
+				// runnerErr can be thrift related above, or in this case some other failure that's likely our fault.
+				taskErr.runnerErr = err
+			} else {
+				taskErr.resultErr = err
+				taskErr.noRetry = true
+			}
 		default:
 			// resultErr can be (ABORTED,TIMEDOUT), which indicates a transient or user-related concern.
 			err = fmt.Errorf(st.State.String())
 			taskErr.resultErr = err
+			taskErr.noRetry = (failure == runner.CommandFailure)
 		}
 	}
 
 	// We should write to sagalog if there's no error, or there's an error but the caller won't be retrying.
-	shouldDeadLetter := (err != nil && (end || r.markCompleteOnFailure))
+	// Tasks aborted because their job was paused, to migrate them, or to restart their gang, are rerun later,
+	// so are never dead lettered.
+	paused := (st.State == runner.ABORTED &&
+		(st.Error == JobPausedErrStr || st.Error == TaskMigratedErrStr || st.Error == GangRestartedErrStr))
+	// With a dead letter queue, infrastructure failures are only retried until the task has had maxInfraFailures.
+	infra := (taskErr.runnerErr != nil && !paused && r.maxInfraFailures > 0)
+	infraLimit := (infra && r.infraFailures+1 >= r.maxInfraFailures)
+	shouldDeadLetter := (err != nil && !paused && (end || r.markCompleteOnFailure || taskErr.noRetry || infraLimit))
 	shouldLog := (err == nil) || shouldDeadLetter
 
+	// Only the first of concurrent attempts at this task to have a result logs it.
+	if shouldLog && !r.attempts.claim(r) {
+		log.WithFields(
+			log.Fields{
+				"jobID":       r.JobID,
+				"taskID":      r.TaskID,
+				"node":        r.nodeSt.node,
+				"speculative": r.speculative,
+				"tag":         r.Tag,
+			}).Info("Another attempt at this task finished first, not logging result")
+		if taskErr.resultErr == nil && taskErr.runnerErr == nil {
+			taskErr.resultErr = fmt.Errorf(SpeculationLostErrStr)
+		}
+		return taskErr
+	}
+
 	// Update taskErr state if it's empty or if we're doing deadletter..
 	if taskErr.st.State == runner.UNKNOWN {
 		taskErr.st.State = runner.FAILED
@@ -136,6 +180,9 @@ func (r *taskRunner) run() error {
 			"jobID":      taskErr.st.JobID,
 			"taskID":     taskErr.st.TaskID,
 			"tag":        taskErr.st.Tag,
+			"usage":      taskErr.st.Usage,
+			"hookError":  taskErr.st.HookError,
+			"logRef":     taskErr.st.LogRef,
 			"err":        taskErr,
 		}).Info("End task")
 	if !shouldLog {
@@ -145,6 +192,15 @@ func (r *taskRunner) run() error {
 		return taskErr
 	}
 
+	// Tasks given up on after an infrastructure failure are set aside in the dead letter queue rather than ended,
+	// so they can be requeued in their job.
+	if shouldDeadLetter && infra {
+		taskErr.sagaErr = r.logDeadLetter(&taskErr.st)
+		taskErr.deadLettered = (taskErr.sagaErr == nil)
+		r.stat.Counter(stats.SchedFailedTaskCounter).Inc(1)
+		return taskErr
+	}
+
 	err = r.logTaskStatus(&taskErr.st, saga.EndTask)
 	taskErr.sagaErr = err
 	if taskErr.sagaErr == nil && taskErr.runnerErr == nil && taskErr.resultErr == nil {
@@ -314,9 +370,11 @@ func (r *taskRunner) queryWithTimeout(id runner.RunID, endTime time.Time, includ
 	if len(sts) == 1 {
 		st = sts[0]
 	} else {
+		// The worker didn't report the run finishing, even after its timeout, so blame the worker.
 		st = runner.RunStatus{
-			RunID: id,
-			State: runner.TIMEDOUT,
+			RunID:       id,
+			State:       runner.TIMEDOUT,
+			FailureKind: runner.InfraFailure,
 		}
 	}
 	return st, false, nil
@@ -338,13 +396,19 @@ func (r *taskRunner) logTaskStatus(st *runner.RunStatus, msgType saga.SagaMessag
 			r.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
 			return err
 		}
+	} else if msgType == saga.StartTask && r.maxInfraFailures > 0 {
+		// Persists the task's infrastructure failures so they're recovered with its job, see DeadLetterConfig.
+		statusAsBytes, err = json.Marshal(taskStartRecord{InfraFailures: r.infraFailures})
+		if err != nil {
+			return err
+		}
 	}
 
 	switch msgType {
 	case saga.StartTask:
-		err = r.saga.StartTask(r.TaskID, statusAsBytes)
+		err = r.saga.StartTaskAttempt(r.TaskID, r.attempt, statusAsBytes)
 	case saga.EndTask:
-		err = r.saga.EndTask(r.TaskID, statusAsBytes)
+		err = r.saga.EndTaskAttempt(r.TaskID, r.attempt, statusAsBytes)
 	default:
 		err = fmt.Errorf("unexpected saga message type: %v", msgType)
 	}
@@ -352,6 +416,31 @@ func (r *taskRunner) logTaskStatus(st *runner.RunStatus, msgType saga.SagaMessag
 	return err
 }
 
+func (r *taskRunner) logDeadLetter(st *runner.RunStatus) error {
+	statusAsBytes, err := workerapi.SerializeProcessStatus(*st)
+	if err != nil {
+		r.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
+		return err
+	}
+	data, err := json.Marshal(deadLetterRecord{
+		InfraFailures: r.infraFailures + 1,
+		Error:         st.Error,
+		Status:        statusAsBytes,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	log.WithFields(
+		log.Fields{
+			"jobID":         r.JobID,
+			"taskID":        r.TaskID,
+			"infraFailures": r.infraFailures + 1,
+			"tag":           r.Tag,
+		}).Info("Dead lettering task")
+	return r.saga.DeadLetterTask(r.TaskID, r.attempt, data)
+}
+
 func (r *taskRunner) abortRequested() (aborted bool, req abortReq) {
 	select {
 	case req := <-r.abortCh:
@@ -194,6 +194,62 @@ func Test_runTaskAndLog_MarkFailedTaskAsFinished(t *testing.T) {
 	}
 }
 
+// A runner whose runs end immediately with a fixed status.
+type fixedStatusRunner struct {
+	runner.Service
+	st runner.RunStatus
+}
+
+func (r *fixedStatusRunner) Run(cmd *runner.Command) (runner.RunStatus, error) {
+	return r.st, nil
+}
+
+func Test_runTaskAndLog_NoRetryOfRequestFailure(t *testing.T) {
+	task := sched.GenTask()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// The task isn't on its last attempt, but it's still ended since retrying wouldn't help.
+	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
+	sagaLogMock.EXPECT().LogMessage(TaskMessageMatcher{Type: &sagaEndTask, JobId: "job1", TaskId: "task1", Data: gomock.Any()})
+	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
+	s, _ := sagaCoord.MakeSaga("job1", nil)
+
+	st := runner.FailedStatus("run1", errors.New("inputs missing"), tags.LogTags{JobID: "job1", TaskID: "task1"})
+	st.FailureKind = runner.RequestFailure
+	r := &fixedStatusRunner{Service: workers.MakeDoneWorker(tmp), st: st}
+	err := get_testTaskRunner(s, r, "job1", "task1", task, false, stats.NilStatsReceiver()).run()
+
+	taskErr, ok := err.(*taskError)
+	if !ok || !taskErr.noRetry || taskErr.runnerErr != nil || taskErr.st.FailureKind != runner.RequestFailure {
+		t.Errorf("Expected a non-retryable request failure that doesn't blame the worker, got: %v", err)
+	}
+}
+
+func Test_runTaskAndLog_RetryOfInfraFailure(t *testing.T) {
+	task := sched.GenTask()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// Not logging EndTask, the task will be retried.
+	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
+	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
+	s, _ := sagaCoord.MakeSaga("job1", nil)
+
+	st := runner.FailedStatus("run1", errors.New("checkout failed"), tags.LogTags{JobID: "job1", TaskID: "task1"})
+	r := &fixedStatusRunner{Service: workers.MakeDoneWorker(tmp), st: st}
+	err := get_testTaskRunner(s, r, "job1", "task1", task, false, stats.NilStatsReceiver()).run()
+
+	taskErr, ok := err.(*taskError)
+	if !ok || taskErr.noRetry || taskErr.runnerErr == nil || taskErr.st.FailureKind != runner.InfraFailure {
+		t.Errorf("Expected a retryable infrastructure failure, got: %v", err)
+	}
+}
+
 func Test_runTaskWithFailedStartTask(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -10,8 +10,9 @@ import (
 )
 
 type taskAssignment struct {
-	nodeSt *nodeState
-	task   *taskState
+	nodeSt      *nodeState
+	task        *taskState
+	speculative bool // A duplicate of the task's running attempt, see SpeculationConfig.
 }
 
 // Returns a list of taskAssigments of task to free node.
@@ -20,7 +21,7 @@ type taskAssignment struct {
 //       scheduling and also require that the caller apply final modifications to clusterState as a second step)
 //
 // Does best effort scheduling which tries to assign tasks to nodes already primed for similar tasks.
-// Not all tasks are guaranteed to be scheduled.
+// Not all tasks are guaranteed to be scheduled. Gang jobs are scheduled all or nothing, see assignGangs.
 func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	requestors map[string][]*jobState, config *SchedulerConfig, stat stats.StatsReceiver) (
 	[]taskAssignment, map[string]*nodeGroup,
@@ -29,6 +30,11 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 		stat = stats.NilStatsReceiver()
 	}
 	defer stat.Latency(stats.SchedTaskAssignmentsLatency_ms).Time().Stop()
+	numGangsWaiting, numGangNodesHeld := 0, 0
+	defer func() {
+		stat.Gauge(stats.SchedGangJobsWaitingGauge).Update(int64(numGangsWaiting))
+		stat.Gauge(stats.SchedGangNodesHeldGauge).Update(int64(numGangNodesHeld))
+	}()
 
 	// Exit if there are no unscheduled tasks.
 	totalOutstandingTasks := 0
@@ -50,25 +56,23 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	}
 
 	// Create a copy of cs.nodeGroups to modify based on new scheduling.
-	clusterSnapshotIds := []string{}
-	nodeGroups := map[string]*nodeGroup{}
-	for snapId, groups := range cs.nodeGroups {
-		nodeGroups[snapId] = newNodeGroup()
-		for nodeId, node := range groups.idle {
-			nodeGroups[snapId].idle[nodeId] = node
-		}
-		for nodeId, node := range groups.busy {
-			nodeGroups[snapId].busy[nodeId] = node
-		}
-		clusterSnapshotIds = append(clusterSnapshotIds, snapId)
-	}
+	nodeGroups, clusterSnapshotIds := copyNodeGroups(cs)
 
 	// Sort jobs by priority and count running tasks.
 	// An array indexed by priority. The value is the subset of jobs in fifo order for the given priority.
+	// Gang jobs are kept apart, as their tasks aren't grouped with other jobs' by requestor and tag.
 	priorityJobs := [][]*jobState{[]*jobState{}, []*jobState{}, []*jobState{}}
+	priorityGangJobs := [][]*jobState{[]*jobState{}, []*jobState{}, []*jobState{}}
 	for _, job := range jobs {
 		p := int(job.Job.Def.Priority)
-		priorityJobs[p] = append(priorityJobs[p], job)
+		if job.Job.Def.Gang {
+			priorityGangJobs[p] = append(priorityGangJobs[p], job)
+			if len(job.getUnScheduledTasks()) > 0 {
+				numGangsWaiting++
+			}
+		} else {
+			priorityJobs[p] = append(priorityJobs[p], job)
+		}
 	}
 	stat.Gauge(stats.SchedPriority0JobsGauge).Update(int64(len(priorityJobs[sched.P0])))
 	stat.Gauge(stats.SchedPriority1JobsGauge).Update(int64(len(priorityJobs[sched.P1])))
@@ -80,6 +84,7 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	// Priority0 jobs consume all remaining idle nodes up to a limit.
 	//
 	var tasks []*taskState
+	var gangAssignments []taskAssignment
 	// The number of healthy nodes we can assign
 	numFree := cs.numFree()
 	// A map[requestor]map[tag]bool{} that makes sure we process all tags for a given requestor once as a batch.
@@ -92,6 +97,13 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	remainingRequired := [][][]*taskState{[][]*taskState{}, [][]*taskState{}, [][]*taskState{}}
 Loop:
 	for _, p := range []sched.Priority{sched.P2, sched.P1, sched.P0} {
+		// Gang jobs go before other jobs of the same priority, taking or holding back free nodes.
+		gangs, started, held := assignGangs(cs, priorityGangJobs[p], nodeGroups, clusterSnapshotIds, numFree, stat)
+		gangAssignments = append(gangAssignments, gangs...)
+		numFree -= len(gangs) + held
+		numGangsWaiting -= started
+		numGangNodesHeld += held
+
 		for _, job := range priorityJobs[p] {
 			// The number of available nodes for this priority is the remaining free nodes
 			numAvailNodes := numFree
@@ -118,7 +130,7 @@ Loop:
 			numCompleted := 0
 			unsched := []*taskState{}
 			for _, j := range requestors[def.Requestor] {
-				if j.Job.Def.Tag == def.Tag {
+				if j.Job.Def.Tag == def.Tag && !j.Job.Def.Gang {
 					numTasks += len(j.Tasks)
 					numCompleted += j.TasksCompleted
 					numRunning += j.TasksRunning
@@ -234,7 +246,7 @@ LoopRemaining:
 		}
 	}
 	// Exit if no tasks qualify to be scheduled.
-	if len(tasks) == 0 {
+	if len(tasks) == 0 && len(gangAssignments) == 0 {
 		return nil, nil
 	}
 
@@ -242,7 +254,10 @@ LoopRemaining:
 	// - Hot node for the given snapshotId (one whose last task shared the same snapshotId).
 	// - New untouched node (or node whose last task used an empty snapshotId)
 	// - A random free node from the idle pools of nodes associated with other snapshotIds.
-	assignments := assign(cs, tasks, nodeGroups, append([]string{""}, clusterSnapshotIds...), stat)
+	assignments := append(gangAssignments, assign(cs, tasks, nodeGroups, append([]string{""}, clusterSnapshotIds...), stat)...)
+	for _, ta := range gangAssignments {
+		tasks = append(tasks, ta.task)
+	}
 	if len(assignments) == totalUnschedTasks {
 		log.WithFields(
 			log.Fields{
@@ -262,8 +277,85 @@ LoopRemaining:
 	return assignments, nodeGroups
 }
 
+// Assigns all the waiting tasks of each gang job at once, or none of them if there aren't enough free nodes
+// or their declared resources don't all fit. A gang job that can't start yet holds back as many free nodes
+// as it's waiting for from the jobs considered after it, so free nodes build up for it as running tasks finish
+// rather than going to jobs whose tasks start one at a time. A gang job waiting for more tasks than the
+// cluster has healthy nodes can't start until the cluster grows, and holds nothing back.
+//
+// Returns the assignments, applied to nodeGroups, the number of gang jobs started and the number of free nodes held back.
+func assignGangs(cs *clusterState, jobs []*jobState, nodeGroups map[string]*nodeGroup, snapIds []string,
+	numFree int, stat stats.StatsReceiver) (assignments []taskAssignment, started int, held int) {
+	for _, job := range jobs {
+		// A gang with running tasks is being restarted after one of its tasks failed, its waiting tasks
+		// start together once the running ones have been aborted.
+		unsched := job.getUnScheduledTasks()
+		if len(unsched) == 0 || job.TasksRunning > 0 {
+			continue
+		}
+		logFields := log.Fields{
+			"jobID":    job.Job.Id,
+			"priority": job.Job.Def.Priority,
+			"unsched":  len(unsched),
+			"numFree":  numFree,
+			"tag":      job.Job.Def.Tag,
+		}
+		if len(unsched) > len(cs.nodes) {
+			log.WithFields(logFields).Info("Gang job is waiting for more tasks than there are healthy nodes")
+			continue
+		}
+		if len(unsched) <= numFree {
+			// Assign on a copy of nodeGroups, which is only kept if every task found a node.
+			groups := copyGroups(nodeGroups)
+			gang := assign(cs, unsched, groups, append([]string{""}, snapIds...), stats.NilStatsReceiver())
+			if len(gang) == len(unsched) {
+				for snapId, group := range groups {
+					nodeGroups[snapId] = group
+				}
+				assignments = append(assignments, gang...)
+				numFree -= len(gang)
+				started++
+				stat.Counter(stats.SchedScheduledTasksCounter).Inc(int64(len(gang)))
+				stat.Counter(stats.SchedGangJobsStartedCounter).Inc(1)
+				log.WithFields(logFields).Info("Starting gang job's waiting tasks together")
+				continue
+			}
+		}
+		h := min(numFree, len(unsched))
+		numFree -= h
+		held += h
+		logFields["held"] = h
+		log.WithFields(logFields).Info("Gang job can't start all its waiting tasks, holding free nodes for it")
+	}
+	return assignments, started, held
+}
+
+// Returns a copy of cs.nodeGroups and the snapshotIds it's keyed by.
+func copyNodeGroups(cs *clusterState) (map[string]*nodeGroup, []string) {
+	snapIds := []string{}
+	for snapId := range cs.nodeGroups {
+		snapIds = append(snapIds, snapId)
+	}
+	return copyGroups(cs.nodeGroups), snapIds
+}
+
+func copyGroups(nodeGroups map[string]*nodeGroup) map[string]*nodeGroup {
+	groupsCopy := map[string]*nodeGroup{}
+	for snapId, groups := range nodeGroups {
+		groupsCopy[snapId] = newNodeGroup()
+		for nodeId, node := range groups.idle {
+			groupsCopy[snapId].idle[nodeId] = node
+		}
+		for nodeId, node := range groups.busy {
+			groupsCopy[snapId].busy[nodeId] = node
+		}
+	}
+	return groupsCopy
+}
+
 // Helper fn, appends to 'assignments' and updates nodeGroups.
-// Should successfully assign all given tasks if caller invokes this with self-consistent params.
+// Should successfully assign all given tasks if caller invokes this with self-consistent params,
+// except tasks whose declared resources don't fit on any idle node, which are left for a later pass.
 func assign(
 	cs *clusterState,
 	tasks []*taskState,
@@ -277,10 +369,7 @@ func assign(
 	SnapshotsLoop:
 		for _, snapId := range append([]string{task.Def.SnapshotID}, snapIds...) {
 			if groups, ok := nodeGroups[snapId]; ok {
-				for _, ns := range groups.idle {
-					if ns.suspended() {
-						continue
-					}
+				if ns := cs.fitIdleNode(task.Def.Resources, groups.idle, nil); ns != nil {
 					snapshotId = snapId
 					nodeSt = ns
 					break SnapshotsLoop
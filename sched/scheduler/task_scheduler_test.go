@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/luci/go-render/render"
 	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/log/tags"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga/sagalogs"
@@ -309,3 +311,144 @@ func Test_TaskAssignments_PriorityStages(t *testing.T) {
 		}
 	}
 }
+
+func Test_TaskAssignment_BestFit(t *testing.T) {
+	testCluster := makeTestCluster("small", "large", "medium")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	cs.capacities = newNodeCapacities()
+	cs.capacities.set("small", runner.Resources{MilliCPUs: 2000, MemoryBytes: 4 << 30})
+	cs.capacities.set("medium", runner.Resources{MilliCPUs: 8000, MemoryBytes: 16 << 30})
+	cs.capacities.set("large", runner.Resources{MilliCPUs: 32000, MemoryBytes: 64 << 30})
+	tasks := []*taskState{
+		&taskState{TaskId: "task1", Def: sched.TaskDefinition{Resources: runner.Resources{MemoryBytes: 8 << 30}}},
+		&taskState{TaskId: "task2", Def: sched.TaskDefinition{Resources: runner.Resources{MilliCPUs: 16000}}},
+		&taskState{TaskId: "task3", Def: sched.TaskDefinition{Resources: runner.Resources{MemoryBytes: 128 << 30}}},
+	}
+	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
+	req := map[string][]*jobState{"": []*jobState{js}}
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil)
+
+	expected := map[string]cluster.NodeId{"task1": "medium", "task2": "large"}
+	if len(assignments) != len(expected) {
+		t.Fatalf("Expected only the tasks that fit to be assigned, got %v", render.Render(assignments))
+	}
+	for _, as := range assignments {
+		if as.nodeSt.node.Id() != expected[as.task.TaskId] {
+			t.Errorf("Expected %s on %s, got %s", as.task.TaskId, expected[as.task.TaskId], as.nodeSt.node.Id())
+		}
+	}
+}
+
+func Test_TaskAssignments_Gang(t *testing.T) {
+	makeJobState := func(jobId string, numTasks int, gang bool) *jobState {
+		tasks := []*taskState{}
+		for i := 0; i < numTasks; i++ {
+			tasks = append(tasks, &taskState{JobId: jobId, TaskId: fmt.Sprintf("task%d", i)})
+		}
+		return &jobState{Job: &sched.Job{Id: jobId, Def: sched.JobDefinition{Tag: jobId, Gang: gang}}, Tasks: tasks}
+	}
+	countJob := func(assignments []taskAssignment, jobId string) int {
+		n := 0
+		for _, a := range assignments {
+			if a.task.JobId == jobId {
+				n++
+			}
+		}
+		return n
+	}
+	makeClusterState := func(numBusy int) *clusterState {
+		testCluster := makeTestCluster("node1", "node2", "node3", "node4", "node5")
+		cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+		for i := 1; i <= numBusy; i++ {
+			cs.taskScheduled(cluster.NodeId(fmt.Sprintf("node%d", i)), "busy", "task1", "")
+		}
+		return cs
+	}
+	gang := makeJobState("gang", 4, true)
+	other := makeJobState("other", 10, false)
+	js := []*jobState{gang, other}
+	req := map[string][]*jobState{"": js}
+
+	// With enough free nodes, the whole gang starts at once and other jobs get the rest.
+	assignments, _ := getTaskAssignments(makeClusterState(0), js, req, nil, nil)
+	if countJob(assignments, "gang") != 4 || len(assignments) != 5 {
+		t.Fatalf("Expected the gang's 4 tasks and 1 other task to be assigned, got: %v", spew.Sdump(assignments))
+	}
+
+	// Without enough free nodes, none of the gang starts and the free nodes are held for it.
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	assignments, _ = getTaskAssignments(makeClusterState(2), js, req, nil, stat)
+	if len(assignments) != 0 {
+		t.Fatalf("Expected no tasks to be assigned while the gang waits, got: %v", spew.Sdump(assignments))
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedGangJobsWaitingGauge: {Checker: stats.Int64EqTest, Value: 1},
+			stats.SchedGangNodesHeldGauge:   {Checker: stats.Int64EqTest, Value: 3},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	// A gang being restarted doesn't start its waiting tasks until its running ones have been aborted.
+	restarting := makeJobState("restarting", 2, true)
+	restarting.Tasks[0].Status = sched.InProgress
+	restarting.TasksRunning = 1
+	js = []*jobState{restarting}
+	req = map[string][]*jobState{"": js}
+	assignments, _ = getTaskAssignments(makeClusterState(0), js, req, nil, nil)
+	if len(assignments) != 0 {
+		t.Fatalf("Expected no tasks to be assigned while the gang restarts, got: %v", spew.Sdump(assignments))
+	}
+
+	// A gang larger than the cluster can't start, so it doesn't hold back nodes from other jobs.
+	big := makeJobState("big", 6, true)
+	js = []*jobState{big, other}
+	req = map[string][]*jobState{"": js}
+	assignments, _ = getTaskAssignments(makeClusterState(0), js, req, nil, nil)
+	if countJob(assignments, "big") != 0 || len(assignments) != 5 {
+		t.Fatalf("Expected only the other job's tasks to be assigned, got: %v", spew.Sdump(assignments))
+	}
+}
+
+func Test_ClusterState_UnsatisfiableResources(t *testing.T) {
+	testCluster := makeTestCluster("small", "large")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	if err := cs.checkResources(&sched.JobDefinition{Tasks: []sched.TaskDefinition{
+		sched.TaskDefinition{Command: runner.Command{LogTags: tags.LogTags{TaskID: "task1"}}, Resources: runner.Resources{MilliCPUs: 16000}},
+	}}); err != nil {
+		t.Fatalf("Expected any task to fit nodes that reported no capacity, got %v", err)
+	}
+
+	cs.capacities = newNodeCapacities()
+	cs.capacities.set("small", runner.Resources{MilliCPUs: 2000, MemoryBytes: 4 << 30})
+	cs.capacities.set("large", runner.Resources{MilliCPUs: 32000, MemoryBytes: 64 << 30})
+	jobDef := &sched.JobDefinition{Tasks: []sched.TaskDefinition{
+		sched.TaskDefinition{Command: runner.Command{LogTags: tags.LogTags{TaskID: "task1"}}, Resources: runner.Resources{MilliCPUs: 16000}},
+	}}
+	if err := cs.checkResources(jobDef); err != nil {
+		t.Fatalf("Expected task to fit the large node, got %v", err)
+	}
+	jobDef.Tasks = append(jobDef.Tasks,
+		sched.TaskDefinition{Command: runner.Command{LogTags: tags.LogTags{TaskID: "task2"}}, Resources: runner.Resources{MemoryBytes: 128 << 30}})
+	if err := cs.checkResources(jobDef); err == nil || !strings.Contains(err.Error(), "task2") {
+		t.Fatalf("Expected task2 to be rejected, got %v", err)
+	}
+
+	// A lost node that doesn't return is forgotten along with its capacity.
+	jobDef.Tasks = jobDef.Tasks[:1]
+	testCluster.remove("large")
+	cs.updateCluster()
+	if err := cs.checkResources(jobDef); err != nil {
+		t.Fatalf("Expected task to fit the lost large node while it may return, got %v", err)
+	}
+	cs.maxLostDuration = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+	cs.updateCluster()
+	if !cs.capacities.get("large").Unspecified() {
+		t.Fatalf("Expected the deleted large node's capacity to be removed")
+	}
+	if err := cs.checkResources(jobDef); err == nil {
+		t.Fatalf("Expected task1 to be rejected once the large node was deleted")
+	}
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"github.com/twitter/scoot/sched"
+)
+
+// JobValidator is implemented by schedulers that can check whether they'd accept a job without scheduling it.
+type JobValidator interface {
+	ValidateJob(jobDef sched.JobDefinition) error
+}
+
+// Runs the checks ScheduleJob makes against the scheduler's current state, ex: admission and requestor limits,
+// without scheduling the job. Unlike ScheduleJob, a saturated system is reported at once rather than waited on.
+func (s *statefulScheduler) ValidateJob(jobDef sched.JobDefinition) error {
+	if err := s.admission.check(); err != nil {
+		return err
+	}
+	checkResultCh := make(chan error, 1)
+	s.checkJobCh <- jobCheckMsg{
+		jobDef:   &jobDef,
+		resultCh: checkResultCh,
+		dryRun:   true,
+	}
+	return <-checkResultCh
+}
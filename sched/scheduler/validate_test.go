@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/sched"
+)
+
+func Test_StatefulScheduler_ValidateJobThrottled(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	deps.config.Admission = AdmissionConfig{MaxLoadFactor: 1}
+	s := makeStatefulSchedulerDeps(deps)
+
+	s.admission.updateLoad(100, len(s.clusterState.nodes))
+	if err := s.ValidateJob(sched.GenJobDef(1)); !IsThrottledError(err) {
+		t.Fatalf("Expected ThrottledError validating a job while saturated, got %v", err)
+	}
+}
+
+func Test_StatefulScheduler_CheckJobDryRun(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+
+	jobDef := sched.GenJobDef(2)
+	jobDef.Basis = "basis"
+	jobDef.Tag = "tag1"
+	if err := s.checkJob(&jobDef, true); err != nil {
+		t.Fatalf("Expected job to be valid, got %v", err)
+	}
+	if len(s.requestorHistory) != 0 {
+		t.Fatalf("Expected dry run not to record the job's tag, got %v", s.requestorHistory)
+	}
+
+	// Once tag1 is superseded by tag2 it's expired, and validation reports it without recording anything.
+	for _, tag := range []string{"tag1", "tag2"} {
+		jobDef.Tag = tag
+		if err := s.checkJob(&jobDef, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jobDef.Tag = "tag1"
+	if err := s.checkJob(&jobDef, true); err == nil {
+		t.Fatal("Expected expired tag to be invalid")
+	}
+
+	jobDef.Tag = "tag3"
+	jobDef.Tasks = append(jobDef.Tasks, jobDef.Tasks[0])
+	if err := s.checkJob(&jobDef, true); err == nil {
+		t.Fatal("Expected duplicate task ids to be invalid")
+	}
+	if h := s.requestorHistory[jobDef.Requestor+jobDef.Basis]; len(h) != 2 {
+		t.Fatalf("Expected dry runs not to record tags, got %v", h)
+	}
+}
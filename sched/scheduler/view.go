@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// How long GetView waits for the scheduler loop to produce a view.
+const DefaultViewTimeout = 5 * time.Second
+
+// Node states reported in NodeView.
+const (
+	NodeIdle         = "idle"
+	NodeBusy         = "busy"
+	NodeInitializing = "initializing"
+	NodeLost         = "lost"
+	NodeFlaky        = "flaky"
+	NodeOffline      = "offline"
+)
+
+// View is a point-in-time, read only copy of the scheduler's state for display, ex: by a web UI.
+type View struct {
+	Time         time.Time
+	Jobs         []JobView // in progress jobs, in the order they are scheduled
+	Nodes        []NodeView
+	TaskThrottle int // -1 if unlimited
+}
+
+type JobView struct {
+	ID             string
+	Requestor      string
+	JobType        string
+	Tag            string
+	Basis          string
+	Priority       sched.Priority
+	Status         sched.Status
+	Killed         bool
+	Paused         bool
+	Created        time.Time
+	TasksTotal     int
+	TasksCompleted int
+	TasksRunning   int
+	Tasks          []TaskView
+}
+
+type TaskView struct {
+	ID      string
+	Status  sched.Status
+	Node    string // set while the task is running
+	Started time.Time
+	Tries   int
+}
+
+type NodeView struct {
+	ID               string
+	State            string
+	JobID            string // set while the node is running a task
+	TaskID           string
+	SnapshotID       string
+	Cordoned         bool      // the node isn't given new tasks, whatever its state
+	BlacklistedUntil time.Time // set while the node isn't given new tasks after failing tasks that succeeded elsewhere
+}
+
+// Viewer is implemented by schedulers that can report their state as a View.
+type Viewer interface {
+	GetView() (View, error)
+}
+
+// Asks the scheduler loop for a view of its state, since the state may only be read from the loop.
+func (s *statefulScheduler) GetView() (View, error) {
+	ch := make(chan View, 1)
+	timeout := time.After(DefaultViewTimeout)
+	select {
+	case s.viewCh <- ch:
+	case <-timeout:
+		return View{}, fmt.Errorf("Timed out waiting %v for scheduler view", DefaultViewTimeout)
+	}
+	select {
+	case v := <-ch:
+		return v, nil
+	case <-timeout:
+		return View{}, fmt.Errorf("Timed out waiting %v for scheduler view", DefaultViewTimeout)
+	}
+}
+
+// Answers pending view requests. This function is part of the main scheduler loop.
+func (s *statefulScheduler) sendViews() {
+	for {
+		select {
+		case ch := <-s.viewCh:
+			ch <- s.view()
+		default:
+			return
+		}
+	}
+}
+
+func (s *statefulScheduler) view() View {
+	v := View{Time: time.Now(), TaskThrottle: s.config.TaskThrottle}
+	for _, js := range s.inProgressJobs {
+		jv := JobView{
+			ID:             js.Job.Id,
+			Requestor:      js.Job.Def.Requestor,
+			JobType:        js.Job.Def.JobType,
+			Tag:            js.Job.Def.Tag,
+			Basis:          js.Job.Def.Basis,
+			Priority:       js.Job.Def.Priority,
+			Status:         js.getJobStatus(),
+			Killed:         js.JobKilled,
+			Paused:         js.Paused,
+			Created:        js.TimeCreated,
+			TasksTotal:     len(js.Tasks),
+			TasksCompleted: js.TasksCompleted,
+			TasksRunning:   js.TasksRunning,
+		}
+		for _, ts := range js.Tasks {
+			tv := TaskView{ID: ts.TaskId, Status: ts.Status, Started: ts.TimeStarted, Tries: ts.NumTimesTried}
+			if ts.Status == sched.InProgress && ts.TaskRunner != nil && ts.TaskRunner.nodeSt != nil {
+				tv.Node = string(ts.TaskRunner.nodeSt.node.Id())
+			}
+			jv.Tasks = append(jv.Tasks, tv)
+		}
+		v.Jobs = append(v.Jobs, jv)
+	}
+
+	cs := s.clusterState
+	for _, ns := range cs.nodes {
+		state := NodeIdle
+		if ns.runningTask != "" {
+			state = NodeBusy
+		}
+		v.Nodes = append(v.Nodes, nodeView(ns, state))
+	}
+	for _, ns := range cs.suspendedNodes {
+		state := NodeInitializing
+		if ns.timeLost != nilTime {
+			state = NodeLost
+		} else if ns.timeFlaky != nilTime {
+			state = NodeFlaky
+		}
+		v.Nodes = append(v.Nodes, nodeView(ns, state))
+	}
+	for _, ns := range cs.offlinedNodes {
+		v.Nodes = append(v.Nodes, nodeView(ns, NodeOffline))
+	}
+	sort.Slice(v.Nodes, func(i, j int) bool { return v.Nodes[i].ID < v.Nodes[j].ID })
+	return v
+}
+
+func nodeView(ns *nodeState, state string) NodeView {
+	return NodeView{
+		ID:               string(ns.node.Id()),
+		State:            state,
+		JobID:            ns.runningJob,
+		TaskID:           ns.runningTask,
+		SnapshotID:       ns.snapshotId,
+		Cordoned:         ns.cordoned,
+		BlacklistedUntil: ns.blacklistedUntil,
+	}
+}
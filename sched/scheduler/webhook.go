@@ -0,0 +1,250 @@
+package scheduler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/archive"
+)
+
+// Total tries to deliver an event to a job webhook.
+const DefaultJobWebhookTries = 3
+
+// Delay before the first retry of a job webhook, doubling after each.
+const DefaultJobWebhookBackoff = time.Second
+
+// Timeout for requests to a job webhook.
+const DefaultJobWebhookTimeout = 10 * time.Second
+
+// Maximum number of deliveries waiting to be sent. Events for further finished jobs are dropped.
+const DefaultMaxPendingJobWebhooks = 1000
+
+// Number of job webhook deliveries sent concurrently.
+const jobWebhookWorkers = 4
+
+// Headers sent with each job webhook request.
+const (
+	JobWebhookEventHeader     = "X-Scoot-Event"
+	JobWebhookSignatureHeader = "X-Scoot-Signature"
+)
+
+// Events sent to job webhooks.
+const (
+	JobEventCompleted = "job_completed"
+	JobEventFailed    = "job_failed"
+)
+
+// JobWebhookConfig enables POSTing a JobEvent to webhooks when a job finishes, so clients like CI systems
+// are notified instead of polling GetStatus.
+//
+// URLs - each finished job is POSTed to every URL, webhooks are disabled if empty.
+// Secret - if set, the body's HMAC-SHA256 keyed by Secret is sent hex encoded in the X-Scoot-Signature header
+// as "sha256=<hex>", so receivers can verify events came from the scheduler.
+// Tries - total tries per delivery, retrying connection errors, 429 and 5xx responses. DefaultJobWebhookTries if zero.
+// Backoff - delay before the first retry, doubling after each. DefaultJobWebhookBackoff if zero.
+type JobWebhookConfig struct {
+	URLs    []string
+	Secret  string
+	Tries   int
+	Backoff time.Duration
+}
+
+// JSON body POSTed to job webhooks.
+type JobEvent struct {
+	Event     string            `json:"event"`
+	JobID     string            `json:"jobId"`
+	Requestor string            `json:"requestor,omitempty"`
+	JobType   string            `json:"jobType,omitempty"`
+	Tag       string            `json:"tag,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Killed    bool              `json:"killed"`
+	Created   time.Time         `json:"created"`
+	Completed time.Time         `json:"completed"`
+	Tasks     []TaskEvent       `json:"tasks"`
+}
+
+// Final status of one of a JobEvent's tasks.
+type TaskEvent struct {
+	TaskID     string `json:"taskId"`
+	State      string `json:"state"`
+	ExitCode   int    `json:"exitCode"`
+	Error      string `json:"error,omitempty"`
+	SnapshotID string `json:"snapshotId,omitempty"`
+	Attempts   int    `json:"attempts"`
+}
+
+// Makes the JobEvent for a finished job: job_failed if it was killed, rolled back, or
+// any task didn't complete with exit code 0, otherwise job_completed.
+func NewJobEvent(j archive.Job) JobEvent {
+	e := JobEvent{
+		Event:     JobEventCompleted,
+		JobID:     j.ID,
+		Requestor: j.Requestor,
+		JobType:   j.JobType,
+		Tag:       j.Tag,
+		Labels:    j.Labels,
+		Killed:    j.Killed,
+		Created:   j.Created,
+		Completed: j.Completed,
+		Tasks:     []TaskEvent{},
+	}
+	failed := j.Killed || j.RolledBack
+	for _, t := range j.Tasks {
+		if t.State != runner.COMPLETE || t.ExitCode != 0 {
+			failed = true
+		}
+		e.Tasks = append(e.Tasks, TaskEvent{
+			TaskID:     t.ID,
+			State:      t.State.String(),
+			ExitCode:   t.ExitCode,
+			Error:      t.Error,
+			SnapshotID: t.SnapshotID,
+			Attempts:   t.Attempts,
+		})
+	}
+	if failed {
+		e.Event = JobEventFailed
+	}
+	return e
+}
+
+// Returns the X-Scoot-Signature header value for body signed with secret.
+func SignJobWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// jobWebhooks delivers JobEvents to the configured webhooks in the background, so a slow or
+// unreachable webhook never stalls scheduling. A nil *jobWebhooks is valid and delivers nothing.
+type jobWebhooks struct {
+	config  JobWebhookConfig
+	client  *http.Client
+	stat    stats.StatsReceiver
+	pending chan jobWebhookDelivery
+
+	mu      sync.Mutex
+	waiting int
+}
+
+type jobWebhookDelivery struct {
+	url   string
+	event string
+	body  []byte
+}
+
+// Returns nil if config has no URLs.
+func newJobWebhooks(config JobWebhookConfig, stat stats.StatsReceiver) *jobWebhooks {
+	if len(config.URLs) == 0 {
+		return nil
+	}
+	if config.Tries <= 0 {
+		config.Tries = DefaultJobWebhookTries
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = DefaultJobWebhookBackoff
+	}
+	w := &jobWebhooks{
+		config:  config,
+		client:  &http.Client{Timeout: DefaultJobWebhookTimeout},
+		stat:    stat,
+		pending: make(chan jobWebhookDelivery, DefaultMaxPendingJobWebhooks),
+	}
+	for i := 0; i < jobWebhookWorkers; i++ {
+		go w.deliver()
+	}
+	return w
+}
+
+// Queues delivery of the finished job's event to every webhook.
+func (w *jobWebhooks) notify(j archive.Job) {
+	if w == nil {
+		return
+	}
+	e := NewJobEvent(j)
+	body, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("Failed to marshal webhook event for job %s: %v", j.ID, err)
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, url := range w.config.URLs {
+		select {
+		case w.pending <- jobWebhookDelivery{url: url, event: e.Event, body: body}:
+			w.waiting++
+		default:
+			log.Errorf("Not sending %s for job %s to %s, too many webhooks pending", e.Event, j.ID, url)
+			w.stat.Counter(stats.SchedJobWebhooksDroppedCounter).Inc(1)
+		}
+	}
+}
+
+// Returns the number of deliveries waiting to be sent.
+func (w *jobWebhooks) pendingDeliveries() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.waiting
+}
+
+// Sends queued deliveries. Never returns.
+func (w *jobWebhooks) deliver() {
+	for d := range w.pending {
+		if err := w.send(d); err != nil {
+			log.Errorf("Failed to send %s to webhook %s: %v", d.event, d.url, err)
+			w.stat.Counter(stats.SchedJobWebhookFailuresCounter).Inc(1)
+		} else {
+			w.stat.Counter(stats.SchedJobWebhooksSentCounter).Inc(1)
+		}
+		w.mu.Lock()
+		w.waiting--
+		w.mu.Unlock()
+	}
+}
+
+func (w *jobWebhooks) send(d jobWebhookDelivery) error {
+	backoff := w.config.Backoff
+	var err error
+	for try := 1; ; try++ {
+		var retry bool
+		if retry, err = w.post(d); err == nil || !retry || try >= w.config.Tries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// POSTs the delivery once, returning whether a failure may succeed if retried.
+func (w *jobWebhooks) post(d jobWebhookDelivery) (bool, error) {
+	req, err := http.NewRequest("POST", d.url, bytes.NewReader(d.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(JobWebhookEventHeader, d.event)
+	if w.config.Secret != "" {
+		req.Header.Set(JobWebhookSignatureHeader, SignJobWebhook(w.config.Secret, d.body))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("job webhook %s returned %s", d.url, resp.Status)
+	}
+	return false, nil
+}
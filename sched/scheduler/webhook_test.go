@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/archive"
+)
+
+func TestNewJobEvent(t *testing.T) {
+	j := archive.Job{ID: "job1", Tasks: []archive.Task{{ID: "task1", State: runner.COMPLETE}}}
+	if e := NewJobEvent(j); e.Event != JobEventCompleted || len(e.Tasks) != 1 || e.Tasks[0].State != "COMPLETE" {
+		t.Fatalf("Unexpected event %+v", e)
+	}
+	j.Tasks = append(j.Tasks, archive.Task{ID: "task2", State: runner.COMPLETE, ExitCode: 1})
+	if e := NewJobEvent(j); e.Event != JobEventFailed {
+		t.Fatalf("Expected a job with a failing task to fail, got %s", e.Event)
+	}
+	j.Tasks = j.Tasks[:1]
+	j.Killed = true
+	if e := NewJobEvent(j); e.Event != JobEventFailed {
+		t.Fatalf("Expected a killed job to fail, got %s", e.Event)
+	}
+}
+
+func TestJobWebhooksRetryAndSign(t *testing.T) {
+	var mu sync.Mutex
+	tries := 0
+	var received JobEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		tries++
+		if tries == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(JobWebhookSignatureHeader) != SignJobWebhook("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &received)
+	}))
+	defer srv.Close()
+
+	w := newJobWebhooks(JobWebhookConfig{URLs: []string{srv.URL}, Secret: "secret", Backoff: time.Millisecond},
+		stats.NilStatsReceiver())
+	w.notify(archive.Job{ID: "job1"})
+	for i := 0; w.pendingDeliveries() != 0; i++ {
+		if i == 100 {
+			t.Fatal("Expected webhook delivery to finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if tries != 2 || received.JobID != "job1" || received.Event != JobEventCompleted {
+		t.Fatalf("Expected a signed event after one retry, got %d tries and %+v", tries, received)
+	}
+}
@@ -53,6 +53,25 @@ func (c *CloudScootClient) RunJob(jobDef *scoot.JobDefinition) (r *scoot.JobId,
 	return jobId, err
 }
 
+// ValidateJob API. Returns whether RunJob would accept jobDef, and why not if it wouldn't,
+// without scheduling it.
+func (c *CloudScootClient) ValidateJob(jobDef *scoot.JobDefinition) (r *scoot.JobValidation, err error) {
+	err = c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	validation, err := c.client.ValidateJob(jobDef)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return validation, err
+}
+
 // GetStatus API. Returns the JobStatus of the specified JobId if successful,
 // otherwise an erorr.
 func (c *CloudScootClient) GetStatus(jobId string) (r *scoot.JobStatus, err error) {
@@ -81,12 +100,12 @@ func (c *CloudScootClient) Close() error {
 	return nil
 }
 
-func (c *CloudScootClient) KillJob(jobId string) (r *scoot.JobStatus, err error) {
+func (c *CloudScootClient) KillJob(jobId string, requestor string) (r *scoot.JobStatus, err error) {
 	err = c.checkForClient()
 	if err != nil {
 		return nil, err
 	}
-	jobStatus, err := c.client.KillJob(jobId)
+	jobStatus, err := c.client.KillJob(jobId, requestor)
 	// if an error occurred reset the connection, could be a broken pipe or other
 	// unrecoverable error.  reset connection so a new clean one gets created
 	// on the next request
@@ -132,7 +151,115 @@ func (c *CloudScootClient) ReinstateWorker(req *scoot.ReinstateWorkerReq) error
 	return err
 }
 
-func (c *CloudScootClient) SetSchedulerStatus(maxTasks int32) error {
+// PauseJob API. Stops giving new tasks to the job until it's resumed.
+func (c *CloudScootClient) PauseJob(req *scoot.PauseJobReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.PauseJob(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+// ResumeJob API. Lets a paused job be given new tasks again.
+func (c *CloudScootClient) ResumeJob(req *scoot.PauseJobReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.ResumeJob(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+// CordonWorker API. Stops giving new tasks to the worker without removing it from the cluster.
+func (c *CloudScootClient) CordonWorker(req *scoot.CordonWorkerReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.CordonWorker(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+// UncordonWorker API. Lets a cordoned worker be given new tasks again.
+func (c *CloudScootClient) UncordonWorker(req *scoot.CordonWorkerReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.UncordonWorker(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+// AddWorker API. Adds a worker to the cluster without waiting for discovery to find it.
+func (c *CloudScootClient) AddWorker(req *scoot.ManualWorkerReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.AddWorker(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+// RemoveWorker API. Removes a worker from the cluster without waiting for discovery to drop it.
+func (c *CloudScootClient) RemoveWorker(req *scoot.ManualWorkerReq) error {
+	err := c.checkForClient()
+	if err != nil {
+		return err
+	}
+	err = c.client.RemoveWorker(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return err
+}
+
+func (c *CloudScootClient) SetSchedulerStatus(maxTasks int32, requestor string) error {
 	// validation is also implemented in sched/definitions.go.  We cannot use it here because it
 	// causes a circular dependency.  The two implementations can be consolidated when the code
 	// is restructured
@@ -145,7 +272,7 @@ func (c *CloudScootClient) SetSchedulerStatus(maxTasks int32) error {
 		return err
 	}
 
-	err = c.client.SetSchedulerStatus(maxTasks)
+	err = c.client.SetSchedulerStatus(maxTasks, requestor)
 	return err
 }
 
@@ -166,6 +293,132 @@ func (c *CloudScootClient) GetSchedulerStatus() (*scoot.SchedulerStatus, error)
 	return schedulerStatus, err
 }
 
+// FindJobs API. Finds recent jobs having all of the query's labels, most recent first.
+func (c *CloudScootClient) FindJobs(query *scoot.JobQuery) (*scoot.JobList, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := c.client.FindJobs(query)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return jobs, err
+}
+
+// GetJobManifest API. Gets the input and output artifacts of a job's tasks.
+func (c *CloudScootClient) GetJobManifest(jobId string) (*scoot.JobManifest, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := c.client.GetJobManifest(jobId)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return manifest, err
+}
+
+// GetIdleWorkers API. Gets the workers idle for at least minIdleMs.
+func (c *CloudScootClient) GetIdleWorkers(minIdleMs int64) (*scoot.IdleWorkers, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	workers, err := c.client.GetIdleWorkers(minIdleMs)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return workers, err
+}
+
+// GetAuditLog API. Gets the administrative actions matching query, most recent first.
+func (c *CloudScootClient) GetAuditLog(query *scoot.AuditQuery) (*scoot.AuditLog, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := c.client.GetAuditLog(query)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return auditLog, err
+}
+
+// QueryJobArchive API. Gets finished jobs from the scheduler's job archive matching query, most recently completed first.
+func (c *CloudScootClient) QueryJobArchive(query *scoot.ArchiveQuery) (*scoot.JobArchive, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	jobArchive, err := c.client.QueryJobArchive(query)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return jobArchive, err
+}
+
+// GetDeadLetterTasks API. Gets the dead lettered tasks matching query, most recently dead lettered first.
+func (c *CloudScootClient) GetDeadLetterTasks(query *scoot.DeadLetterQuery) (*scoot.DeadLetterTasks, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := c.client.GetDeadLetterTasks(query)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return tasks, err
+}
+
+// RequeueDeadLetterTask API. Schedules a dead lettered task again in its job, returning the job's id.
+func (c *CloudScootClient) RequeueDeadLetterTask(req *scoot.RequeueTaskReq) (*scoot.JobId, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	jobId, err := c.client.RequeueDeadLetterTask(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return jobId, err
+}
+
 // helper method to check for a non-nil client / create one
 func (c *CloudScootClient) checkForClient() (err error) {
 	if c.client == nil {
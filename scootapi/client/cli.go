@@ -43,11 +43,24 @@ func NewSimpleCLIClient(d dialer.Dialer) (CLIClient, error) {
 
 	c.addCmd(&runJobCmd{})
 	c.addCmd(&getStatusCmd{})
+	c.addCmd(&findJobsCmd{})
+	c.addCmd(&getJobManifestCmd{})
+	c.addCmd(&getIdleWorkersCmd{})
+	c.addCmd(&getAuditLogCmd{})
+	c.addCmd(&queryJobArchiveCmd{})
+	c.addCmd(&getDeadLetterTasksCmd{})
+	c.addCmd(&requeueDeadLetterTaskCmd{})
 	c.addCmd(&smokeTestCmd{})
 	c.addCmd(&watchJobCmd{})
 	c.addCmd(&killJobCmd{})
+	c.addCmd(&pauseJobCmd{})
+	c.addCmd(&pauseJobCmd{resume: true})
 	c.addCmd(&offlineWorkerCmd{})
 	c.addCmd(&reinstateWorkerCmd{})
+	c.addCmd(&cordonWorkerCmd{})
+	c.addCmd(&cordonWorkerCmd{uncordon: true})
+	c.addCmd(&manualWorkerCmd{})
+	c.addCmd(&manualWorkerCmd{remove: true})
 	c.addCmd(&setSchedulerStatus{})
 	c.addCmd(&getSchedulerStatusCmd{})
 
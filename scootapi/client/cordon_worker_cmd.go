@@ -0,0 +1,71 @@
+package client
+
+/**
+implements the command line entries for the cordon and uncordon worker commands
+*/
+
+import (
+	"fmt"
+	"os/user"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type cordonWorkerCmd struct {
+	uncordon bool
+}
+
+func (c *cordonWorkerCmd) registerFlags() *cobra.Command {
+	if c.uncordon {
+		return &cobra.Command{
+			Use:   "uncordon_worker",
+			Short: "UncordonWorker, lets a cordoned worker be given new tasks again",
+		}
+	}
+	return &cobra.Command{
+		Use:   "cordon_worker",
+		Short: "CordonWorker, stops giving new tasks to a worker without removing it from the cluster",
+	}
+}
+
+func (c *cordonWorkerCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	action := "cordon"
+	if c.uncordon {
+		action = "uncordon"
+	}
+	log.Infof("Running %s on Scoot Worker %s", action, args)
+
+	if len(args) == 0 {
+		return fmt.Errorf("A worker id must be provided in order to %s", action)
+	}
+
+	id := args[0]
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	req := &scoot.CordonWorkerReq{ID: id, Requestor: requestor.Username}
+	if c.uncordon {
+		err = cl.scootClient.UncordonWorker(req)
+	} else {
+		err = cl.scootClient.CordonWorker(req)
+	}
+
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error running %s on worker: %v", action, err.Error())
+		}
+	}
+
+	log.Infof("Worker %s %sed", id, action)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package client
+
+/**
+implements the command line entries for listing and requeueing dead lettered tasks
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type getDeadLetterTasksCmd struct {
+	jobId       string
+	requestor   string
+	limit       int
+	printAsJson bool
+}
+
+func (c *getDeadLetterTasksCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:     "get_dead_letter_tasks",
+		Short:   "list tasks dead lettered after repeated infrastructure failures, most recent first",
+		Example: "scootapi get_dead_letter_tasks --requestor someone",
+	}
+	r.Flags().StringVar(&c.jobId, "job_id", "", "Only list tasks dead lettered from this job")
+	r.Flags().StringVar(&c.requestor, "requestor", "", "Only list tasks of jobs run by this requestor")
+	r.Flags().IntVar(&c.limit, "limit", 0, "Maximum number of tasks listed, zero for the server default")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out tasks as JSON")
+	return r
+}
+
+func (c *getDeadLetterTasksCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Getting dead lettered tasks")
+
+	query := &scoot.DeadLetterQuery{}
+	if c.jobId != "" {
+		query.JobId = &c.jobId
+	}
+	if c.requestor != "" {
+		query.Requestor = &c.requestor
+	}
+	if c.limit != 0 {
+		limit := int32(c.limit)
+		query.Limit = &limit
+	}
+
+	tasks, err := cl.scootClient.GetDeadLetterTasks(query)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error getting dead lettered tasks: %v", err.Error())
+		}
+	}
+
+	// Output must go to stdout in case caller looking in stdout for the results
+	if c.printAsJson {
+		asJson, err := json.Marshal(tasks)
+		if err != nil {
+			return fmt.Errorf("Error converting dead lettered tasks to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tTASK\tREQUESTOR\tTAG\tDEAD LETTERED\tINFRA FAILURES\tERROR")
+	for _, t := range tasks.Tasks {
+		deadLettered := time.Unix(0, t.DeadLetteredMs*int64(time.Millisecond))
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", t.JobId, t.TaskId, t.GetRequestor(), t.GetTag(),
+			deadLettered.Format(time.RFC3339), t.InfraFailures, t.GetError())
+	}
+	return tw.Flush()
+}
+
+type requeueDeadLetterTaskCmd struct{}
+
+func (c *requeueDeadLetterTaskCmd) registerFlags() *cobra.Command {
+	return &cobra.Command{
+		Use:     "requeue_dead_letter_task",
+		Short:   "RequeueDeadLetterTask, runs a dead lettered task again in its job",
+		Example: "scootapi requeue_dead_letter_task <job id> <task id>",
+	}
+}
+
+func (c *requeueDeadLetterTaskCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Infof("Requeueing dead lettered task %s", args)
+
+	if len(args) != 2 {
+		return fmt.Errorf("A job id and task id must be provided in order to requeue a task")
+	}
+
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	req := &scoot.RequeueTaskReq{JobId: args[0], TaskId: args[1], Requestor: requestor.Username}
+	jobId, err := cl.scootClient.RequeueDeadLetterTask(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error requeueing task: %v", err.Error())
+		}
+	}
+
+	log.Infof("Task %s of job %s requeued in job %s", args[1], args[0], jobId.ID)
+	// Output must go to stdout in case caller looking in stdout for the job id
+	fmt.Println(jobId.ID)
+	return nil
+}
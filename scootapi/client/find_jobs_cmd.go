@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type findJobsCmd struct {
+	labels      []string
+	limit       int
+	printAsJson bool
+}
+
+func (c *findJobsCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "find_jobs",
+		Short: "find recent jobs by label",
+	}
+	r.Flags().StringSliceVar(&c.labels, "label", nil, "key=value labels jobs must all have, ex: repo=source,pr=1234")
+	r.Flags().IntVar(&c.limit, "limit", 0, "Maximum number of jobs to find, if <= 0 a server default")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out jobs as JSON")
+	return r
+}
+
+func (c *findJobsCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	labels, err := parseLabels(c.labels)
+	if err != nil {
+		return err
+	}
+	log.Info("Finding Scoot Jobs with labels ", labels)
+
+	limit := int32(c.limit)
+	jobs, err := cl.scootClient.FindJobs(&scoot.JobQuery{Labels: labels, Limit: &limit})
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error finding jobs: %v", err.Error())
+		}
+	}
+
+	// Output must go to stdout in case caller looking in stdout for the results
+	if c.printAsJson {
+		asJson, err := json.Marshal(jobs)
+		if err != nil {
+			return fmt.Errorf("Error converting jobs to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tSTATUS\tCREATED\tREQUESTOR\tLABELS")
+	for _, j := range jobs.Jobs {
+		created := time.Unix(0, j.GetCreatedMs()*int64(time.Millisecond)).Format(time.RFC3339)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\n", j.ID, j.Status, created, j.GetRequestor(), j.Labels)
+	}
+	return tw.Flush()
+}
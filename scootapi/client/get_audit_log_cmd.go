@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type getAuditLogCmd struct {
+	action      string
+	actor       string
+	since       time.Duration
+	maxEntries  int
+	printAsJson bool
+}
+
+func (c *getAuditLogCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:     "get_audit_log",
+		Short:   "list administrative actions taken through the scheduler API, most recent first",
+		Example: "scootapi get_audit_log --action kill_job --since 24h",
+	}
+	r.Flags().StringVar(&c.action, "action", "", "Only list this action, ex: kill_job, offline_worker, reinstate_worker, set_scheduler_status")
+	r.Flags().StringVar(&c.actor, "actor", "", "Only list actions taken by this requestor")
+	r.Flags().DurationVar(&c.since, "since", 0, "Only list actions taken within this long, ex: 24h")
+	r.Flags().IntVar(&c.maxEntries, "max", 0, "Maximum number of actions listed, zero for the server default")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out actions as JSON")
+	return r
+}
+
+func (c *getAuditLogCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Getting Scoot audit log")
+
+	query := &scoot.AuditQuery{}
+	if c.action != "" {
+		query.Action = &c.action
+	}
+	if c.actor != "" {
+		query.Actor = &c.actor
+	}
+	if c.since > 0 {
+		sinceMs := time.Now().Add(-c.since).UnixNano() / int64(time.Millisecond)
+		query.SinceMs = &sinceMs
+	}
+	if c.maxEntries != 0 {
+		maxEntries := int32(c.maxEntries)
+		query.MaxEntries = &maxEntries
+	}
+
+	auditLog, err := cl.scootClient.GetAuditLog(query)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error getting audit log: %v", err.Error())
+		}
+	}
+
+	// Output must go to stdout in case caller looking in stdout for the results
+	if c.printAsJson {
+		asJson, err := json.Marshal(auditLog)
+		if err != nil {
+			return fmt.Errorf("Error converting audit log to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tACTOR\tACTION\tTARGET\tDETAILS\tERROR")
+	for _, e := range auditLog.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", time.Unix(0, e.TimeMs*int64(time.Millisecond)).Format(time.RFC3339),
+			e.Actor, e.Action, e.GetTarget(), e.GetDetails(), e.GetError())
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type getIdleWorkersCmd struct {
+	minIdle     time.Duration
+	printAsJson bool
+}
+
+func (c *getIdleWorkersCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "get_idle_workers",
+		Short: "list workers not running a task, longest idle first",
+	}
+	r.Flags().DurationVar(&c.minIdle, "min_idle", 0, "Only list workers idle at least this long, ex: 10m")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out workers as JSON")
+	return r
+}
+
+func (c *getIdleWorkersCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Getting Scoot workers idle for at least ", c.minIdle)
+
+	workers, err := cl.scootClient.GetIdleWorkers(int64(c.minIdle / time.Millisecond))
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error getting idle workers: %v", err.Error())
+		}
+	}
+
+	// Output must go to stdout in case caller looking in stdout for the results
+	if c.printAsJson {
+		asJson, err := json.Marshal(workers)
+		if err != nil {
+			return fmt.Errorf("Error converting workers to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKER\tIDLE SINCE")
+	for _, w := range workers.Workers {
+		fmt.Fprintf(tw, "%s\t%s\n", w.ID, time.Unix(0, w.IdleSinceMs*int64(time.Millisecond)).Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type getJobManifestCmd struct{}
+
+func (c *getJobManifestCmd) registerFlags() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get_job_manifest",
+		Short: "Print the input and output artifacts of a job's tasks as JSON",
+	}
+}
+
+func (c *getJobManifestCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("a job id must be provided")
+	}
+	log.Info("Getting manifest for Scoot Job", args)
+
+	manifest, err := cl.scootClient.GetJobManifest(args[0])
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error getting manifest: %v", err.Error())
+		}
+	}
+
+	asJson, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error converting manifest to JSON: %v", err.Error())
+	}
+	fmt.Printf("%s\n", asJson) // must go to stdout in case caller looking in stdout for the results
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os/user"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -36,8 +37,12 @@ func (c *killJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 	}
 
 	jobId := args[0]
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
 
-	status, err := cl.scootClient.KillJob(jobId)
+	status, err := cl.scootClient.KillJob(jobId, requestor.Username)
 
 	if err != nil {
 		switch err := err.(type) {
@@ -0,0 +1,81 @@
+package client
+
+/**
+implements the command line entries for the add and remove worker commands
+*/
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type manualWorkerCmd struct {
+	remove        bool
+	adminTokenEnv string
+}
+
+func (c *manualWorkerCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "add_worker",
+		Short: "AddWorker, adds a worker to the cluster without waiting for discovery to find it",
+	}
+	if c.remove {
+		r = &cobra.Command{
+			Use:   "remove_worker",
+			Short: "RemoveWorker, removes a worker from the cluster without waiting for discovery to drop it",
+		}
+	}
+	r.Flags().StringVar(&c.adminTokenEnv, "admin_token_env", "SCOOT_ADMIN_TOKEN",
+		"Name of the env var holding the scheduler's admin token")
+	return r
+}
+
+func (c *manualWorkerCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	action, done := "add", "added"
+	if c.remove {
+		action, done = "remove", "removed"
+	}
+	log.Infof("Running %s on Scoot Worker %s", action, args)
+
+	if len(args) == 0 {
+		return fmt.Errorf("A worker id must be provided in order to %s it", action)
+	}
+
+	id := args[0]
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	token := os.Getenv(c.adminTokenEnv)
+	if token == "" {
+		return fmt.Errorf("The scheduler's admin token must be set in %s in order to %s a worker", c.adminTokenEnv, action)
+	}
+
+	req := &scoot.ManualWorkerReq{ID: id, Requestor: requestor.Username, AdminToken: &token}
+	if c.remove {
+		err = cl.scootClient.RemoveWorker(req)
+	} else {
+		err = cl.scootClient.AddWorker(req)
+	}
+
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error running %s on worker: %v", action, err.Error())
+		}
+	}
+
+	log.Infof("Worker %s %s", id, done)
+
+	return nil
+}
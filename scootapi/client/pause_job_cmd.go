@@ -0,0 +1,75 @@
+package client
+
+/**
+implements the command line entries for the pause and resume job commands
+*/
+
+import (
+	"fmt"
+	"os/user"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type pauseJobCmd struct {
+	resume       bool
+	abortRunning bool
+}
+
+func (c *pauseJobCmd) registerFlags() *cobra.Command {
+	if c.resume {
+		return &cobra.Command{
+			Use:   "resume_job",
+			Short: "ResumeJob, lets a paused job be given new tasks again",
+		}
+	}
+	r := &cobra.Command{
+		Use:   "pause_job",
+		Short: "PauseJob, stops scheduling a job's tasks without killing it",
+	}
+	r.Flags().BoolVar(&c.abortRunning, "abort_running", false, "Abort the job's running tasks, they're rerun when the job is resumed")
+	return r
+}
+
+func (c *pauseJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	action := "pause"
+	if c.resume {
+		action = "resume"
+	}
+	log.Infof("Running %s on Scoot Job %s", action, args)
+
+	if len(args) == 0 {
+		return fmt.Errorf("A job id must be provided in order to %s", action)
+	}
+
+	id := args[0]
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	req := &scoot.PauseJobReq{ID: id, Requestor: requestor.Username}
+	if c.resume {
+		err = cl.scootClient.ResumeJob(req)
+	} else {
+		req.AbortRunning = &c.abortRunning
+		err = cl.scootClient.PauseJob(req)
+	}
+
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error running %s on job: %v", action, err.Error())
+		}
+	}
+
+	log.Infof("Job %s %sd", id, action)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type queryJobArchiveCmd struct {
+	since       time.Duration
+	until       time.Duration
+	tag         string
+	requestor   string
+	limit       int
+	printAsJson bool
+}
+
+func (c *queryJobArchiveCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:     "query_job_archive",
+		Short:   "list finished jobs from the scheduler's job archive, most recently completed first",
+		Example: "scootapi query_job_archive --tag mytag --since 48h --until 24h",
+	}
+	r.Flags().DurationVar(&c.since, "since", 0, "Only list jobs completed within this long, ex: 48h")
+	r.Flags().DurationVar(&c.until, "until", 0, "Only list jobs completed more than this long ago, ex: 24h")
+	r.Flags().StringVar(&c.tag, "tag", "", "Only list jobs with this tag")
+	r.Flags().StringVar(&c.requestor, "requestor", "", "Only list jobs run by this requestor")
+	r.Flags().IntVar(&c.limit, "limit", 0, "Maximum number of jobs listed, zero for the server default")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out jobs, including their tasks, as JSON")
+	return r
+}
+
+func (c *queryJobArchiveCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Querying Scoot job archive")
+
+	query := &scoot.ArchiveQuery{}
+	now := time.Now()
+	if c.since > 0 {
+		sinceMs := now.Add(-c.since).UnixNano() / int64(time.Millisecond)
+		query.SinceMs = &sinceMs
+	}
+	if c.until > 0 {
+		untilMs := now.Add(-c.until).UnixNano() / int64(time.Millisecond)
+		query.UntilMs = &untilMs
+	}
+	if c.tag != "" {
+		query.Tag = &c.tag
+	}
+	if c.requestor != "" {
+		query.Requestor = &c.requestor
+	}
+	if c.limit != 0 {
+		limit := int32(c.limit)
+		query.Limit = &limit
+	}
+
+	jobArchive, err := cl.scootClient.QueryJobArchive(query)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error querying job archive: %v", err.Error())
+		}
+	}
+
+	// Output must go to stdout in case caller looking in stdout for the results
+	if c.printAsJson {
+		asJson, err := json.Marshal(jobArchive)
+		if err != nil {
+			return fmt.Errorf("Error converting job archive to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tREQUESTOR\tTAG\tCOMPLETED\tDURATION\tTASKS\tFAILED")
+	for _, j := range jobArchive.Jobs {
+		failed := 0
+		for _, t := range j.Tasks {
+			if t.GetState() != scoot.RunStatusState_COMPLETE || t.GetExitCode() != 0 {
+				failed++
+			}
+		}
+		completed := time.Unix(0, j.CompletedMs*int64(time.Millisecond))
+		duration := time.Duration(j.CompletedMs-j.CreatedMs) * time.Millisecond
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\n", j.ID, j.Status, j.GetRequestor(), j.GetTag(),
+			completed.Format(time.RFC3339), duration, len(j.Tasks), failed)
+	}
+	return tw.Flush()
+}
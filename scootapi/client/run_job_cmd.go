@@ -7,11 +7,13 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/snapshot/snapid"
 )
 
 type runJobCmd struct {
@@ -19,6 +21,12 @@ type runJobCmd struct {
 	snapshotId  string
 	jobFilePath string
 	tag         string
+	labels      []string
+	timeout     time.Duration
+	retention   time.Duration
+	gang        bool
+	sparse      []string
+	dryRun      bool
 }
 
 func (c *runJobCmd) registerFlags() *cobra.Command {
@@ -30,6 +38,12 @@ func (c *runJobCmd) registerFlags() *cobra.Command {
 	r.Flags().StringVar(&c.snapshotId, "snapshot_id", "", "Repo checkout id: <master-sha> OR <backend>-<kind>(-<additional information>)+")
 	r.Flags().StringVar(&c.jobFilePath, "job_def", "", "JSON file to read jobs from. Error if snapshot_id flag is also provided.")
 	r.Flags().StringVar(&c.tag, "tag", "", "Tag can be specified by requestor in order to more easily trace a job through logs")
+	r.Flags().StringSliceVar(&c.labels, "label", nil, "key=value labels to find the job by later, ex: repo=source,pr=1234. Added to job_def labels.")
+	r.Flags().DurationVar(&c.timeout, "timeout", 0, "Maximum wall clock time for the whole job, after which it's killed and rolled back. Overrides job_def TimeoutMs.")
+	r.Flags().DurationVar(&c.retention, "retention", 0, "How long to keep the job's output snapshots and logs, ex: 24h for CI scratch runs. Overrides job_def RetentionMs.")
+	r.Flags().BoolVar(&c.gang, "gang", false, "Start the job's tasks all at once, only when there are enough free workers for all of them, ex: for test shards that coordinate at startup. Also set by job_def Gang.")
+	r.Flags().StringSliceVar(&c.sparse, "sparse", nil, "Only check out snapshot paths matching these git sparse-checkout patterns, ex: /src/myservice/. Ignored with job_def.")
+	r.Flags().BoolVar(&c.dryRun, "dry_run", false, "Validate the job as the scheduler would without running it. Exits non-zero if it's invalid.")
 	return r
 }
 
@@ -43,18 +57,27 @@ type CLIJobDef struct {
 	Basis                string
 	JobType              string
 	Requestor            string
+	Labels               map[string]string
+	TimeoutMs            int64
+	RetentionMs          int64
+	Gang                 bool
 }
 
 type TaskDef struct {
-	Args       []string
-	EnvVars    map[string]string
-	SnapshotID string
-	TimeoutMs  int32
-	TaskID     string
+	Args           []string
+	EnvVars        map[string]string
+	SnapshotID     string
+	TimeoutMs      int32
+	TaskID         string
+	SparsePatterns []string
 }
 
 func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
 	log.Info("Running on scoot, args:", args)
+	labels, err := parseLabels(c.labels)
+	if err != nil {
+		return err
+	}
 	jobDef := scoot.NewJobDefinition()
 	jobDef.Tag = &c.tag
 	switch {
@@ -65,7 +88,7 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 			log.Info("No snapshotID provided - cmd will be run in an empty tmpdir.")
 		} else if !strings.Contains(c.snapshotId, "-") {
 			//this is not a bundleID, assume it's a sha that's available upstream. Cf. snapshot/git/gitdb/README.md
-			streamId := fmt.Sprintf("stream-gc-%s-%s", c.streamName, c.snapshotId)
+			streamId := snapid.Stream(snapid.FormatGitCommit, c.streamName, c.snapshotId).String()
 			log.Infof("Converting sha to a stream-based snapshot_id: %s -> %s", c.snapshotId, streamId)
 			c.snapshotId = streamId
 		}
@@ -75,6 +98,7 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 		task.Command.Argv = args
 		task.SnapshotId = &c.snapshotId
 		task.TaskId = &taskId
+		task.SparsePatterns = c.sparse
 		jobDef.Tasks = []*scoot.TaskDefinition{task}
 	case c.jobFilePath != "":
 		f, err := os.Open(c.jobFilePath)
@@ -99,6 +123,20 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 		jobDef.JobType = &jsonJob.JobType
 		jobDef.Requestor = &jsonJob.Requestor
 		jobDef.Priority = &jsonJob.Priority
+		if jsonJob.TimeoutMs > 0 {
+			jobDef.TimeoutMs = &jsonJob.TimeoutMs
+		}
+		if jsonJob.RetentionMs > 0 {
+			jobDef.RetentionMs = &jsonJob.RetentionMs
+		}
+		if jsonJob.Gang {
+			jobDef.Gang = &jsonJob.Gang
+		}
+		for k, v := range jsonJob.Labels {
+			if _, ok := labels[k]; !ok {
+				labels[k] = v
+			}
+		}
 		jobDef.Tasks = []*scoot.TaskDefinition{}
 		for _, jsonTask := range jsonJob.Tasks {
 			jt := jsonTask
@@ -111,6 +149,7 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 			}
 			taskDef.SnapshotId = &jt.SnapshotID
 			taskDef.TaskId = &jt.TaskID
+			taskDef.SparsePatterns = jt.SparsePatterns
 			jobDef.Tasks = append(jobDef.Tasks, taskDef)
 			if jt.TimeoutMs > 0 {
 				taskDef.TimeoutMs = &jt.TimeoutMs
@@ -118,6 +157,25 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 		}
 	}
 
+	if len(labels) > 0 {
+		jobDef.Labels = labels
+	}
+	if c.timeout > 0 {
+		timeoutMs := int64(c.timeout / time.Millisecond)
+		jobDef.TimeoutMs = &timeoutMs
+	}
+	if c.retention > 0 {
+		retentionMs := int64(c.retention / time.Millisecond)
+		jobDef.RetentionMs = &retentionMs
+	}
+	if c.gang {
+		jobDef.Gang = &c.gang
+	}
+
+	if c.dryRun {
+		return validateJob(cl, jobDef)
+	}
+
 	jobId, err := cl.scootClient.RunJob(jobDef)
 	if err != nil {
 		switch err := err.(type) {
@@ -133,3 +191,41 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 
 	return nil
 }
+
+// Validates jobDef, printing each reason it's invalid.
+func validateJob(cl *simpleCLIClient, jobDef *scoot.JobDefinition) error {
+	validation, err := cl.scootClient.ValidateJob(jobDef)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		default:
+			return fmt.Errorf("Error validating job: %v %T", err, err)
+		}
+	}
+	for _, e := range validation.GetErrors() {
+		if e.IsSetTaskId() {
+			fmt.Printf("task %s: %s\n", e.GetTaskId(), e.GetMessage())
+		} else {
+			fmt.Println(e.GetMessage())
+		}
+	}
+	if !validation.GetValid() {
+		return fmt.Errorf("Invalid job: %d errors", len(validation.GetErrors()))
+	}
+	log.Info("Job is valid")
+	return nil
+}
+
+// Parses key=value labels.
+func parseLabels(kvs []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, kv := range kvs {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid label %q, expected key=value", kv)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
@@ -6,6 +6,7 @@ implements the command line entry for the throttle scheduler command
 
 import (
 	"fmt"
+	"os/user"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -34,7 +35,12 @@ func (c *setSchedulerStatus) run(cl *simpleCLIClient, cmd *cobra.Command, args [
 		" to run.  Note: the scheduler does not enforce this limit.  We expect the job"+
 		" requetor to adhere to it.", args)
 
-	err := cl.scootClient.SetSchedulerStatus(int32(c.maxTasks))
+	requestor, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	err = cl.scootClient.SetSchedulerStatus(int32(c.maxTasks), requestor.Username)
 
 	if err != nil {
 		switch err := err.(type) {
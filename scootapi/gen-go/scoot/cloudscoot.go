@@ -22,21 +22,65 @@ type CloudScoot interface {
 	//  - Job
 	RunJob(job *JobDefinition) (r *JobId, err error)
 	// Parameters:
+	//  - Job
+	ValidateJob(job *JobDefinition) (r *JobValidation, err error)
+	// Parameters:
 	//  - JobId
 	GetStatus(jobId string) (r *JobStatus, err error)
 	// Parameters:
 	//  - JobId
-	KillJob(jobId string) (r *JobStatus, err error)
+	//  - Requestor
+	KillJob(jobId string, requestor string) (r *JobStatus, err error)
 	// Parameters:
 	//  - Req
 	OfflineWorker(req *OfflineWorkerReq) (err error)
 	// Parameters:
 	//  - Req
 	ReinstateWorker(req *ReinstateWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	CordonWorker(req *CordonWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	UncordonWorker(req *CordonWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	AddWorker(req *ManualWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	RemoveWorker(req *ManualWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	PauseJob(req *PauseJobReq) (err error)
+	// Parameters:
+	//  - Req
+	ResumeJob(req *PauseJobReq) (err error)
 	GetSchedulerStatus() (r *SchedulerStatus, err error)
 	// Parameters:
 	//  - MaxTasks
-	SetSchedulerStatus(maxTasks int32) (err error)
+	//  - Requestor
+	SetSchedulerStatus(maxTasks int32, requestor string) (err error)
+	// Parameters:
+	//  - Query
+	FindJobs(query *JobQuery) (r *JobList, err error)
+	// Parameters:
+	//  - JobId
+	GetJobManifest(jobId string) (r *JobManifest, err error)
+	// Parameters:
+	//  - MinIdleMs
+	GetIdleWorkers(minIdleMs int64) (r *IdleWorkers, err error)
+	// Parameters:
+	//  - Query
+	GetAuditLog(query *AuditQuery) (r *AuditLog, err error)
+	// Parameters:
+	//  - Query
+	QueryJobArchive(query *ArchiveQuery) (r *JobArchive, err error)
+	// Parameters:
+	//  - Query
+	GetDeadLetterTasks(query *DeadLetterQuery) (r *DeadLetterTasks, err error)
+	// Parameters:
+	//  - Req
+	RequeueDeadLetterTask(req *RequeueTaskReq) (r *JobId, err error)
 }
 
 type CloudScootClient struct {
@@ -115,16 +159,16 @@ func (p *CloudScootClient) recvRunJob() (value *JobId, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error9 error
-		error9, err = error8.Read(iprot)
+		error24 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error25 error
+		error25, err = error24.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error9
+		err = error25
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -149,6 +193,90 @@ func (p *CloudScootClient) recvRunJob() (value *JobId, err error) {
 	return
 }
 
+// Parameters:
+//  - Job
+func (p *CloudScootClient) ValidateJob(job *JobDefinition) (r *JobValidation, err error) {
+	if err = p.sendValidateJob(job); err != nil {
+		return
+	}
+	return p.recvValidateJob()
+}
+
+func (p *CloudScootClient) sendValidateJob(job *JobDefinition) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("ValidateJob", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootValidateJobArgs{
+		Job: job,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvValidateJob() (value *JobValidation, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "ValidateJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ValidateJob failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ValidateJob failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error26 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error27 error
+		error27, err = error26.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error27
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ValidateJob failed: invalid message type")
+		return
+	}
+	result := CloudScootValidateJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 // Parameters:
 //  - JobId
 func (p *CloudScootClient) GetStatus(jobId string) (r *JobStatus, err error) {
@@ -199,16 +327,16 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error11 error
-		error11, err = error10.Read(iprot)
+		error28 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error29 error
+		error29, err = error28.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error11
+		err = error29
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -235,14 +363,15 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 
 // Parameters:
 //  - JobId
-func (p *CloudScootClient) KillJob(jobId string) (r *JobStatus, err error) {
-	if err = p.sendKillJob(jobId); err != nil {
+//  - Requestor
+func (p *CloudScootClient) KillJob(jobId string, requestor string) (r *JobStatus, err error) {
+	if err = p.sendKillJob(jobId, requestor); err != nil {
 		return
 	}
 	return p.recvKillJob()
 }
 
-func (p *CloudScootClient) sendKillJob(jobId string) (err error) {
+func (p *CloudScootClient) sendKillJob(jobId string, requestor string) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -253,7 +382,8 @@ func (p *CloudScootClient) sendKillJob(jobId string) (err error) {
 		return
 	}
 	args := CloudScootKillJobArgs{
-		JobId: jobId,
+		JobId:     jobId,
+		Requestor: requestor,
 	}
 	if err = args.Write(oprot); err != nil {
 		return
@@ -283,16 +413,16 @@ func (p *CloudScootClient) recvKillJob() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error13 error
-		error13, err = error12.Read(iprot)
+		error30 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error31 error
+		error31, err = error30.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error13
+		err = error31
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -367,16 +497,16 @@ func (p *CloudScootClient) recvOfflineWorker() (err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error15 error
-		error15, err = error14.Read(iprot)
+		error32 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error33 error
+		error33, err = error32.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error15
+		err = error33
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -450,16 +580,16 @@ func (p *CloudScootClient) recvReinstateWorker() (err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error17 error
-		error17, err = error16.Read(iprot)
+		error34 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error35 error
+		error35, err = error34.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error17
+		err = error35
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -483,24 +613,28 @@ func (p *CloudScootClient) recvReinstateWorker() (err error) {
 	return
 }
 
-func (p *CloudScootClient) GetSchedulerStatus() (r *SchedulerStatus, err error) {
-	if err = p.sendGetSchedulerStatus(); err != nil {
+// Parameters:
+//  - Req
+func (p *CloudScootClient) CordonWorker(req *CordonWorkerReq) (err error) {
+	if err = p.sendCordonWorker(req); err != nil {
 		return
 	}
-	return p.recvGetSchedulerStatus()
+	return p.recvCordonWorker()
 }
 
-func (p *CloudScootClient) sendGetSchedulerStatus() (err error) {
+func (p *CloudScootClient) sendCordonWorker(req *CordonWorkerReq) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("GetSchedulerStatus", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("CordonWorker", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := CloudScootGetSchedulerStatusArgs{}
+	args := CloudScootCordonWorkerArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -510,7 +644,7 @@ func (p *CloudScootClient) sendGetSchedulerStatus() (err error) {
 	return oprot.Flush()
 }
 
-func (p *CloudScootClient) recvGetSchedulerStatus() (value *SchedulerStatus, err error) {
+func (p *CloudScootClient) recvCordonWorker() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -520,67 +654,69 @@ func (p *CloudScootClient) recvGetSchedulerStatus() (value *SchedulerStatus, err
 	if err != nil {
 		return
 	}
-	if method != "GetSchedulerStatus" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetSchedulerStatus failed: wrong method name")
+	if method != "CordonWorker" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "CordonWorker failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetSchedulerStatus failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "CordonWorker failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error19 error
-		error19, err = error18.Read(iprot)
+		error36 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error37 error
+		error37, err = error36.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error19
+		err = error37
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetSchedulerStatus failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "CordonWorker failed: invalid message type")
 		return
 	}
-	result := CloudScootGetSchedulerStatusResult{}
+	result := CloudScootCordonWorkerResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
 	if err = iprot.ReadMessageEnd(); err != nil {
 		return
 	}
-	if result.Err != nil {
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
 		err = result.Err
 		return
 	}
-	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
-//  - MaxTasks
-func (p *CloudScootClient) SetSchedulerStatus(maxTasks int32) (err error) {
-	if err = p.sendSetSchedulerStatus(maxTasks); err != nil {
+//  - Req
+func (p *CloudScootClient) UncordonWorker(req *CordonWorkerReq) (err error) {
+	if err = p.sendUncordonWorker(req); err != nil {
 		return
 	}
-	return p.recvSetSchedulerStatus()
+	return p.recvUncordonWorker()
 }
 
-func (p *CloudScootClient) sendSetSchedulerStatus(maxTasks int32) (err error) {
+func (p *CloudScootClient) sendUncordonWorker(req *CordonWorkerReq) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("SetSchedulerStatus", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("UncordonWorker", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := CloudScootSetSchedulerStatusArgs{
-		MaxTasks: maxTasks,
+	args := CloudScootUncordonWorkerArgs{
+		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
 		return
@@ -591,7 +727,7 @@ func (p *CloudScootClient) sendSetSchedulerStatus(maxTasks int32) (err error) {
 	return oprot.Flush()
 }
 
-func (p *CloudScootClient) recvSetSchedulerStatus() (err error) {
+func (p *CloudScootClient) recvUncordonWorker() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -601,32 +737,32 @@ func (p *CloudScootClient) recvSetSchedulerStatus() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "SetSchedulerStatus" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SetSchedulerStatus failed: wrong method name")
+	if method != "UncordonWorker" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "UncordonWorker failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SetSchedulerStatus failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "UncordonWorker failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error21 error
-		error21, err = error20.Read(iprot)
+		error38 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error39 error
+		error39, err = error38.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error21
+		err = error39
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SetSchedulerStatus failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "UncordonWorker failed: invalid message type")
 		return
 	}
-	result := CloudScootSetSchedulerStatusResult{}
+	result := CloudScootUncordonWorkerResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -643,455 +779,5913 @@ func (p *CloudScootClient) recvSetSchedulerStatus() (err error) {
 	return
 }
 
-type CloudScootProcessor struct {
-	processorMap map[string]thrift.TProcessorFunction
-	handler      CloudScoot
-}
-
-func (p *CloudScootProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-	p.processorMap[key] = processor
-}
-
-func (p *CloudScootProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-	processor, ok = p.processorMap[key]
-	return processor, ok
-}
-
-func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-	return p.processorMap
+// Parameters:
+//  - Req
+func (p *CloudScootClient) AddWorker(req *ManualWorkerReq) (err error) {
+	if err = p.sendAddWorker(req); err != nil {
+		return
+	}
+	return p.recvAddWorker()
 }
 
-func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
-
-	self22 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self22.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
-	self22.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
-	self22.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
-	self22.processorMap["OfflineWorker"] = &cloudScootProcessorOfflineWorker{handler: handler}
-	self22.processorMap["ReinstateWorker"] = &cloudScootProcessorReinstateWorker{handler: handler}
-	self22.processorMap["GetSchedulerStatus"] = &cloudScootProcessorGetSchedulerStatus{handler: handler}
-	self22.processorMap["SetSchedulerStatus"] = &cloudScootProcessorSetSchedulerStatus{handler: handler}
-	return self22
+func (p *CloudScootClient) sendAddWorker(req *ManualWorkerReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("AddWorker", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootAddWorkerArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
 }
 
-func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	name, _, seqId, err := iprot.ReadMessageBegin()
+func (p *CloudScootClient) recvAddWorker() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
-		return false, err
+		return
 	}
-	if processor, ok := p.GetProcessorFunction(name); ok {
-		return processor.Process(seqId, iprot, oprot)
+	if method != "AddWorker" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "AddWorker failed: wrong method name")
+		return
 	}
-	iprot.Skip(thrift.STRUCT)
-	iprot.ReadMessageEnd()
-	x23 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
-	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x23.Write(oprot)
-	oprot.WriteMessageEnd()
-	oprot.Flush()
-	return false, x23
-
-}
-
-type cloudScootProcessorRunJob struct {
-	handler CloudScoot
-}
-
-func (p *cloudScootProcessorRunJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootRunJobArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "AddWorker failed: out of sequence response")
+		return
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootRunJobResult{}
-	var retval *JobId
-	var err2 error
-	if retval, err2 = p.handler.RunJob(args.Job); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *CanNotScheduleNow:
-			result.Cnsn = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RunJob: "+err2.Error())
-			oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+	if mTypeId == thrift.EXCEPTION {
+		error36 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error37 error
+		error37, err = error36.Read(iprot)
+		if err != nil {
+			return
 		}
-	} else {
-		result.Success = retval
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error37
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("RunJob", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "AddWorker failed: invalid message type")
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	result := CloudScootAddWorkerResult{}
+	if err = result.Read(iprot); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
 	}
-	if err != nil {
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *CloudScootClient) RemoveWorker(req *ManualWorkerReq) (err error) {
+	if err = p.sendRemoveWorker(req); err != nil {
 		return
 	}
-	return true, err
+	return p.recvRemoveWorker()
 }
 
-type cloudScootProcessorGetStatus struct {
-	handler CloudScoot
+func (p *CloudScootClient) sendRemoveWorker(req *ManualWorkerReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("RemoveWorker", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootRemoveWorkerArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
 }
 
-func (p *cloudScootProcessorGetStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootGetStatusArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootClient) recvRemoveWorker() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootGetStatusResult{}
-	var retval *JobStatus
-	var err2 error
-	if retval, err2 = p.handler.GetStatus(args.JobId); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetStatus: "+err2.Error())
-			oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if method != "RemoveWorker" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RemoveWorker failed: wrong method name")
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RemoveWorker failed: out of sequence response")
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId == thrift.EXCEPTION {
+		error38 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error39 error
+		error39, err = error38.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error39
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RemoveWorker failed: invalid message type")
+		return
 	}
-	if err != nil {
+	result := CloudScootRemoveWorkerResult{}
+	if err = result.Read(iprot); err != nil {
 		return
 	}
-	return true, err
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
 }
 
-type cloudScootProcessorKillJob struct {
-	handler CloudScoot
+// Parameters:
+//  - Req
+func (p *CloudScootClient) PauseJob(req *PauseJobReq) (err error) {
+	if err = p.sendPauseJob(req); err != nil {
+		return
+	}
+	return p.recvPauseJob()
 }
 
-func (p *cloudScootProcessorKillJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootKillJobArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootClient) sendPauseJob(req *PauseJobReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootKillJobResult{}
-	var retval *JobStatus
-	var err2 error
-	if retval, err2 = p.handler.KillJob(args.JobId); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing KillJob: "+err2.Error())
-			oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("PauseJob", thrift.CALL, p.SeqId); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("KillJob", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	args := CloudScootPauseJobArgs{
+		Req: req,
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err = args.Write(oprot); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvPauseJob() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	return true, err
-}
-
-type cloudScootProcessorOfflineWorker struct {
-	handler CloudScoot
-}
-
-func (p *cloudScootProcessorOfflineWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootOfflineWorkerArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("OfflineWorker", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+	if method != "PauseJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "PauseJob failed: wrong method name")
+		return
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootOfflineWorkerResult{}
-	var err2 error
-	if err2 = p.handler.OfflineWorker(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing OfflineWorker: "+err2.Error())
-			oprot.WriteMessageBegin("OfflineWorker", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "PauseJob failed: out of sequence response")
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("OfflineWorker", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if mTypeId == thrift.EXCEPTION {
+		error36 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error37 error
+		error37, err = error36.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error37
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "PauseJob failed: invalid message type")
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	result := CloudScootPauseJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
 	}
-	if err != nil {
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
 		return
 	}
-	return true, err
+	return
 }
 
-type cloudScootProcessorReinstateWorker struct {
-	handler CloudScoot
+// Parameters:
+//  - Req
+func (p *CloudScootClient) ResumeJob(req *PauseJobReq) (err error) {
+	if err = p.sendResumeJob(req); err != nil {
+		return
+	}
+	return p.recvResumeJob()
 }
 
-func (p *cloudScootProcessorReinstateWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootReinstateWorkerArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("ReinstateWorker", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootClient) sendResumeJob(req *PauseJobReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("ResumeJob", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootResumeJobArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvResumeJob() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "ResumeJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ResumeJob failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ResumeJob failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error38 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error39 error
+		error39, err = error38.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error39
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ResumeJob failed: invalid message type")
+		return
+	}
+	result := CloudScootResumeJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+func (p *CloudScootClient) GetSchedulerStatus() (r *SchedulerStatus, err error) {
+	if err = p.sendGetSchedulerStatus(); err != nil {
+		return
+	}
+	return p.recvGetSchedulerStatus()
+}
+
+func (p *CloudScootClient) sendGetSchedulerStatus() (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetSchedulerStatus", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetSchedulerStatusArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetSchedulerStatus() (value *SchedulerStatus, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetSchedulerStatus" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetSchedulerStatus failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetSchedulerStatus failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error40 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error41 error
+		error41, err = error40.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error41
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetSchedulerStatus failed: invalid message type")
+		return
+	}
+	result := CloudScootGetSchedulerStatusResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - MaxTasks
+//  - Requestor
+func (p *CloudScootClient) SetSchedulerStatus(maxTasks int32, requestor string) (err error) {
+	if err = p.sendSetSchedulerStatus(maxTasks, requestor); err != nil {
+		return
+	}
+	return p.recvSetSchedulerStatus()
+}
+
+func (p *CloudScootClient) sendSetSchedulerStatus(maxTasks int32, requestor string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("SetSchedulerStatus", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootSetSchedulerStatusArgs{
+		MaxTasks:  maxTasks,
+		Requestor: requestor,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvSetSchedulerStatus() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "SetSchedulerStatus" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SetSchedulerStatus failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SetSchedulerStatus failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error42 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error43 error
+		error43, err = error42.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error43
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SetSchedulerStatus failed: invalid message type")
+		return
+	}
+	result := CloudScootSetSchedulerStatusResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - Query
+func (p *CloudScootClient) FindJobs(query *JobQuery) (r *JobList, err error) {
+	if err = p.sendFindJobs(query); err != nil {
+		return
+	}
+	return p.recvFindJobs()
+}
+
+func (p *CloudScootClient) sendFindJobs(query *JobQuery) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("FindJobs", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootFindJobsArgs{
+		Query: query,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvFindJobs() (value *JobList, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "FindJobs" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "FindJobs failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "FindJobs failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error44 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error45 error
+		error45, err = error44.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error45
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "FindJobs failed: invalid message type")
+		return
+	}
+	result := CloudScootFindJobsResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - JobId
+func (p *CloudScootClient) GetJobManifest(jobId string) (r *JobManifest, err error) {
+	if err = p.sendGetJobManifest(jobId); err != nil {
+		return
+	}
+	return p.recvGetJobManifest()
+}
+
+func (p *CloudScootClient) sendGetJobManifest(jobId string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetJobManifest", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetJobManifestArgs{
+		JobId: jobId,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetJobManifest() (value *JobManifest, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetJobManifest" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetJobManifest failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetJobManifest failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error46 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error47 error
+		error47, err = error46.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error47
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetJobManifest failed: invalid message type")
+		return
+	}
+	result := CloudScootGetJobManifestResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - MinIdleMs
+func (p *CloudScootClient) GetIdleWorkers(minIdleMs int64) (r *IdleWorkers, err error) {
+	if err = p.sendGetIdleWorkers(minIdleMs); err != nil {
+		return
+	}
+	return p.recvGetIdleWorkers()
+}
+
+func (p *CloudScootClient) sendGetIdleWorkers(minIdleMs int64) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetIdleWorkers", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetIdleWorkersArgs{
+		MinIdleMs: minIdleMs,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetIdleWorkers() (value *IdleWorkers, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetIdleWorkers" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetIdleWorkers failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetIdleWorkers failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error48 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error49 error
+		error49, err = error48.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error49
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetIdleWorkers failed: invalid message type")
+		return
+	}
+	result := CloudScootGetIdleWorkersResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Query
+func (p *CloudScootClient) GetAuditLog(query *AuditQuery) (r *AuditLog, err error) {
+	if err = p.sendGetAuditLog(query); err != nil {
+		return
+	}
+	return p.recvGetAuditLog()
+}
+
+func (p *CloudScootClient) sendGetAuditLog(query *AuditQuery) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetAuditLog", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetAuditLogArgs{
+		Query: query,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetAuditLog() (value *AuditLog, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetAuditLog" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetAuditLog failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetAuditLog failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error50 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error51 error
+		error51, err = error50.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error51
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetAuditLog failed: invalid message type")
+		return
+	}
+	result := CloudScootGetAuditLogResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Query
+func (p *CloudScootClient) QueryJobArchive(query *ArchiveQuery) (r *JobArchive, err error) {
+	if err = p.sendQueryJobArchive(query); err != nil {
+		return
+	}
+	return p.recvQueryJobArchive()
+}
+
+func (p *CloudScootClient) sendQueryJobArchive(query *ArchiveQuery) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("QueryJobArchive", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootQueryJobArchiveArgs{
+		Query: query,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvQueryJobArchive() (value *JobArchive, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "QueryJobArchive" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "QueryJobArchive failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "QueryJobArchive failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error50 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error51 error
+		error51, err = error50.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error51
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "QueryJobArchive failed: invalid message type")
+		return
+	}
+	result := CloudScootQueryJobArchiveResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Query
+func (p *CloudScootClient) GetDeadLetterTasks(query *DeadLetterQuery) (r *DeadLetterTasks, err error) {
+	if err = p.sendGetDeadLetterTasks(query); err != nil {
+		return
+	}
+	return p.recvGetDeadLetterTasks()
+}
+
+func (p *CloudScootClient) sendGetDeadLetterTasks(query *DeadLetterQuery) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetDeadLetterTasks", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetDeadLetterTasksArgs{
+		Query: query,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetDeadLetterTasks() (value *DeadLetterTasks, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetDeadLetterTasks" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetDeadLetterTasks failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetDeadLetterTasks failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error52 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error53 error
+		error53, err = error52.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error53
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetDeadLetterTasks failed: invalid message type")
+		return
+	}
+	result := CloudScootGetDeadLetterTasksResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *CloudScootClient) RequeueDeadLetterTask(req *RequeueTaskReq) (r *JobId, err error) {
+	if err = p.sendRequeueDeadLetterTask(req); err != nil {
+		return
+	}
+	return p.recvRequeueDeadLetterTask()
+}
+
+func (p *CloudScootClient) sendRequeueDeadLetterTask(req *RequeueTaskReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("RequeueDeadLetterTask", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootRequeueDeadLetterTaskArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvRequeueDeadLetterTask() (value *JobId, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "RequeueDeadLetterTask" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RequeueDeadLetterTask failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RequeueDeadLetterTask failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error54 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error55 error
+		error55, err = error54.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error55
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RequeueDeadLetterTask failed: invalid message type")
+		return
+	}
+	result := CloudScootRequeueDeadLetterTaskResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+type CloudScootProcessor struct {
+	processorMap map[string]thrift.TProcessorFunction
+	handler      CloudScoot
+}
+
+func (p *CloudScootProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+	p.processorMap[key] = processor
+}
+
+func (p *CloudScootProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+	processor, ok = p.processorMap[key]
+	return processor, ok
+}
+
+func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+	return p.processorMap
+}
+
+func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
+
+	self52 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self52.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
+	self52.processorMap["ValidateJob"] = &cloudScootProcessorValidateJob{handler: handler}
+	self52.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
+	self52.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
+	self52.processorMap["OfflineWorker"] = &cloudScootProcessorOfflineWorker{handler: handler}
+	self52.processorMap["ReinstateWorker"] = &cloudScootProcessorReinstateWorker{handler: handler}
+	self52.processorMap["CordonWorker"] = &cloudScootProcessorCordonWorker{handler: handler}
+	self52.processorMap["UncordonWorker"] = &cloudScootProcessorUncordonWorker{handler: handler}
+	self52.processorMap["AddWorker"] = &cloudScootProcessorAddWorker{handler: handler}
+	self52.processorMap["RemoveWorker"] = &cloudScootProcessorRemoveWorker{handler: handler}
+	self52.processorMap["PauseJob"] = &cloudScootProcessorPauseJob{handler: handler}
+	self52.processorMap["ResumeJob"] = &cloudScootProcessorResumeJob{handler: handler}
+	self52.processorMap["GetSchedulerStatus"] = &cloudScootProcessorGetSchedulerStatus{handler: handler}
+	self52.processorMap["SetSchedulerStatus"] = &cloudScootProcessorSetSchedulerStatus{handler: handler}
+	self52.processorMap["FindJobs"] = &cloudScootProcessorFindJobs{handler: handler}
+	self52.processorMap["GetJobManifest"] = &cloudScootProcessorGetJobManifest{handler: handler}
+	self52.processorMap["GetIdleWorkers"] = &cloudScootProcessorGetIdleWorkers{handler: handler}
+	self52.processorMap["GetAuditLog"] = &cloudScootProcessorGetAuditLog{handler: handler}
+	self52.processorMap["QueryJobArchive"] = &cloudScootProcessorQueryJobArchive{handler: handler}
+	self52.processorMap["GetDeadLetterTasks"] = &cloudScootProcessorGetDeadLetterTasks{handler: handler}
+	self52.processorMap["RequeueDeadLetterTask"] = &cloudScootProcessorRequeueDeadLetterTask{handler: handler}
+	return self52
+}
+
+func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	name, _, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return false, err
+	}
+	if processor, ok := p.GetProcessorFunction(name); ok {
+		return processor.Process(seqId, iprot, oprot)
+	}
+	iprot.Skip(thrift.STRUCT)
+	iprot.ReadMessageEnd()
+	x53 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+	x53.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return false, x53
+
+}
+
+type cloudScootProcessorRunJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorRunJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootRunJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootRunJobResult{}
+	var retval *JobId
+	var err2 error
+	if retval, err2 = p.handler.RunJob(args.Job); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *CanNotScheduleNow:
+			result.Cnsn = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RunJob: "+err2.Error())
+			oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("RunJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorValidateJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorValidateJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootValidateJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ValidateJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootValidateJobResult{}
+	var retval *JobValidation
+	var err2 error
+	if retval, err2 = p.handler.ValidateJob(args.Job); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ValidateJob: "+err2.Error())
+			oprot.WriteMessageBegin("ValidateJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("ValidateJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetStatus struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetStatusResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.GetStatus(args.JobId); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetStatus: "+err2.Error())
+			oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorKillJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorKillJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootKillJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootKillJobResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.KillJob(args.JobId, args.Requestor); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing KillJob: "+err2.Error())
+			oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("KillJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorOfflineWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorOfflineWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootOfflineWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("OfflineWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootOfflineWorkerResult{}
+	var err2 error
+	if err2 = p.handler.OfflineWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing OfflineWorker: "+err2.Error())
+			oprot.WriteMessageBegin("OfflineWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("OfflineWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorReinstateWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorReinstateWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootReinstateWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ReinstateWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootReinstateWorkerResult{}
+	var err2 error
+	if err2 = p.handler.ReinstateWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ReinstateWorker: "+err2.Error())
+			oprot.WriteMessageBegin("ReinstateWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("ReinstateWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorCordonWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorCordonWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootCordonWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("CordonWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootCordonWorkerResult{}
+	var err2 error
+	if err2 = p.handler.CordonWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing CordonWorker: "+err2.Error())
+			oprot.WriteMessageBegin("CordonWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("CordonWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorUncordonWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorUncordonWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootUncordonWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("UncordonWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootUncordonWorkerResult{}
+	var err2 error
+	if err2 = p.handler.UncordonWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UncordonWorker: "+err2.Error())
+			oprot.WriteMessageBegin("UncordonWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("UncordonWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorAddWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorAddWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootAddWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("AddWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootAddWorkerResult{}
+	var err2 error
+	if err2 = p.handler.AddWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AddWorker: "+err2.Error())
+			oprot.WriteMessageBegin("AddWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("AddWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorRemoveWorker struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorRemoveWorker) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootRemoveWorkerArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("RemoveWorker", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootRemoveWorkerResult{}
+	var err2 error
+	if err2 = p.handler.RemoveWorker(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RemoveWorker: "+err2.Error())
+			oprot.WriteMessageBegin("RemoveWorker", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("RemoveWorker", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorPauseJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorPauseJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootPauseJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("PauseJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootPauseJobResult{}
+	var err2 error
+	if err2 = p.handler.PauseJob(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PauseJob: "+err2.Error())
+			oprot.WriteMessageBegin("PauseJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("PauseJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorResumeJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorResumeJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootResumeJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ResumeJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootResumeJobResult{}
+	var err2 error
+	if err2 = p.handler.ResumeJob(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ResumeJob: "+err2.Error())
+			oprot.WriteMessageBegin("ResumeJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("ResumeJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetSchedulerStatus struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetSchedulerStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetSchedulerStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetSchedulerStatus", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetSchedulerStatusResult{}
+	var retval *SchedulerStatus
+	var err2 error
+	if retval, err2 = p.handler.GetSchedulerStatus(); err2 != nil {
+		switch v := err2.(type) {
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSchedulerStatus: "+err2.Error())
+			oprot.WriteMessageBegin("GetSchedulerStatus", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetSchedulerStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorSetSchedulerStatus struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorSetSchedulerStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootSetSchedulerStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("SetSchedulerStatus", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootSetSchedulerStatusResult{}
+	var err2 error
+	if err2 = p.handler.SetSchedulerStatus(args.MaxTasks, args.Requestor); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SetSchedulerStatus: "+err2.Error())
+			oprot.WriteMessageBegin("SetSchedulerStatus", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("SetSchedulerStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorFindJobs struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorFindJobs) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootFindJobsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("FindJobs", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootFindJobsResult{}
+	var retval *JobList
+	var err2 error
+	if retval, err2 = p.handler.FindJobs(args.Query); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing FindJobs: "+err2.Error())
+			oprot.WriteMessageBegin("FindJobs", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("FindJobs", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetJobManifest struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetJobManifest) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetJobManifestArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetJobManifest", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetJobManifestResult{}
+	var retval *JobManifest
+	var err2 error
+	if retval, err2 = p.handler.GetJobManifest(args.JobId); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetJobManifest: "+err2.Error())
+			oprot.WriteMessageBegin("GetJobManifest", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetJobManifest", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetIdleWorkers struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetIdleWorkers) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetIdleWorkersArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetIdleWorkers", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetIdleWorkersResult{}
+	var retval *IdleWorkers
+	var err2 error
+	if retval, err2 = p.handler.GetIdleWorkers(args.MinIdleMs); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetIdleWorkers: "+err2.Error())
+			oprot.WriteMessageBegin("GetIdleWorkers", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetIdleWorkers", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetAuditLog struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetAuditLog) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetAuditLogArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetAuditLog", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetAuditLogResult{}
+	var retval *AuditLog
+	var err2 error
+	if retval, err2 = p.handler.GetAuditLog(args.Query); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetAuditLog: "+err2.Error())
+			oprot.WriteMessageBegin("GetAuditLog", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetAuditLog", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorQueryJobArchive struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorQueryJobArchive) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootQueryJobArchiveArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("QueryJobArchive", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
 
 	iprot.ReadMessageEnd()
-	result := CloudScootReinstateWorkerResult{}
+	result := CloudScootQueryJobArchiveResult{}
+	var retval *JobArchive
 	var err2 error
-	if err2 = p.handler.ReinstateWorker(args.Req); err2 != nil {
+	if retval, err2 = p.handler.QueryJobArchive(args.Query); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing QueryJobArchive: "+err2.Error())
+			oprot.WriteMessageBegin("QueryJobArchive", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("QueryJobArchive", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetDeadLetterTasks struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetDeadLetterTasks) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetDeadLetterTasksArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetDeadLetterTasks", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetDeadLetterTasksResult{}
+	var retval *DeadLetterTasks
+	var err2 error
+	if retval, err2 = p.handler.GetDeadLetterTasks(args.Query); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetDeadLetterTasks: "+err2.Error())
+			oprot.WriteMessageBegin("GetDeadLetterTasks", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetDeadLetterTasks", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorRequeueDeadLetterTask struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorRequeueDeadLetterTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootRequeueDeadLetterTaskArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("RequeueDeadLetterTask", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootRequeueDeadLetterTaskResult{}
+	var retval *JobId
+	var err2 error
+	if retval, err2 = p.handler.RequeueDeadLetterTask(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *InvalidRequest:
 			result.Ir = v
 		case *ScootServerError:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ReinstateWorker: "+err2.Error())
-			oprot.WriteMessageBegin("ReinstateWorker", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RequeueDeadLetterTask: "+err2.Error())
+			oprot.WriteMessageBegin("RequeueDeadLetterTask", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("RequeueDeadLetterTask", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Job
+type CloudScootRunJobArgs struct {
+	Job *JobDefinition `thrift:"job,1" json:"job"`
+}
+
+func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
+	return &CloudScootRunJobArgs{}
+}
+
+var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+
+func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
+	if !p.IsSetJob() {
+		return CloudScootRunJobArgs_Job_DEFAULT
+	}
+	return p.Job
+}
+func (p *CloudScootRunJobArgs) IsSetJob() bool {
+	return p.Job != nil
+}
+
+func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Job = &JobDefinition{}
+	if err := p.Job.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+	}
+	if err := p.Job.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootRunJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Cnsn
+type CloudScootRunJobResult struct {
+	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
+	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+}
+
+func NewCloudScootRunJobResult() *CloudScootRunJobResult {
+	return &CloudScootRunJobResult{}
+}
+
+var CloudScootRunJobResult_Success_DEFAULT *JobId
+
+func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+	if !p.IsSetSuccess() {
+		return CloudScootRunJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootRunJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+
+func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
+	if !p.IsSetCnsn() {
+		return CloudScootRunJobResult_Cnsn_DEFAULT
+	}
+	return p.Cnsn
+}
+func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetCnsn() bool {
+	return p.Cnsn != nil
+}
+
+func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobId{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Cnsn = &CanNotScheduleNow{}
+	if err := p.Cnsn.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCnsn() {
+		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+		}
+		if err := p.Cnsn.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Job
+type CloudScootValidateJobArgs struct {
+	Job *JobDefinition `thrift:"job,1" json:"job"`
+}
+
+func NewCloudScootValidateJobArgs() *CloudScootValidateJobArgs {
+	return &CloudScootValidateJobArgs{}
+}
+
+var CloudScootValidateJobArgs_Job_DEFAULT *JobDefinition
+
+func (p *CloudScootValidateJobArgs) GetJob() *JobDefinition {
+	if !p.IsSetJob() {
+		return CloudScootValidateJobArgs_Job_DEFAULT
+	}
+	return p.Job
+}
+func (p *CloudScootValidateJobArgs) IsSetJob() bool {
+	return p.Job != nil
+}
+
+func (p *CloudScootValidateJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Job = &JobDefinition{}
+	if err := p.Job.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ValidateJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+	}
+	if err := p.Job.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootValidateJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootValidateJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootValidateJobResult struct {
+	Success *JobValidation    `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootValidateJobResult() *CloudScootValidateJobResult {
+	return &CloudScootValidateJobResult{}
+}
+
+var CloudScootValidateJobResult_Success_DEFAULT *JobValidation
+
+func (p *CloudScootValidateJobResult) GetSuccess() *JobValidation {
+	if !p.IsSetSuccess() {
+		return CloudScootValidateJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootValidateJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootValidateJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootValidateJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootValidateJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootValidateJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootValidateJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootValidateJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootValidateJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootValidateJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootValidateJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobValidation{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ValidateJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootValidateJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootValidateJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootValidateJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootValidateJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootValidateJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+type CloudScootGetStatusArgs struct {
+	JobId string `thrift:"jobId,1" json:"jobId"`
+}
+
+func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
+	return &CloudScootGetStatusArgs{}
+}
+
+func (p *CloudScootGetStatusArgs) GetJobId() string {
+	return p.JobId
+}
+func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootGetStatusResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
+	return &CloudScootGetStatusResult{}
+}
+
+var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootGetStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootGetStatusResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootGetStatusResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+//  - Requestor
+type CloudScootKillJobArgs struct {
+	JobId     string `thrift:"jobId,1" json:"jobId"`
+	Requestor string `thrift:"requestor,2" json:"requestor"`
+}
+
+func NewCloudScootKillJobArgs() *CloudScootKillJobArgs {
+	return &CloudScootKillJobArgs{}
+}
+
+func (p *CloudScootKillJobArgs) GetJobId() string {
+	return p.JobId
+}
+
+func (p *CloudScootKillJobArgs) GetRequestor() string {
+	return p.Requestor
+}
+func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootKillJobArgs) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootKillJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootKillJobResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootKillJobResult() *CloudScootKillJobResult {
+	return &CloudScootKillJobResult{}
+}
+
+var CloudScootKillJobResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootKillJobResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootKillJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootKillJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootKillJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootKillJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootKillJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootKillJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootKillJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootKillJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootOfflineWorkerArgs struct {
+	Req *OfflineWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootOfflineWorkerArgs() *CloudScootOfflineWorkerArgs {
+	return &CloudScootOfflineWorkerArgs{}
+}
+
+var CloudScootOfflineWorkerArgs_Req_DEFAULT *OfflineWorkerReq
+
+func (p *CloudScootOfflineWorkerArgs) GetReq() *OfflineWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootOfflineWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootOfflineWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootOfflineWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &OfflineWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("OfflineWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootOfflineWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootOfflineWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootOfflineWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootOfflineWorkerResult() *CloudScootOfflineWorkerResult {
+	return &CloudScootOfflineWorkerResult{}
+}
+
+var CloudScootOfflineWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootOfflineWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootOfflineWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootOfflineWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootOfflineWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootOfflineWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootOfflineWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootOfflineWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootOfflineWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("OfflineWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootOfflineWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootOfflineWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootOfflineWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootOfflineWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootReinstateWorkerArgs struct {
+	Req *ReinstateWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootReinstateWorkerArgs() *CloudScootReinstateWorkerArgs {
+	return &CloudScootReinstateWorkerArgs{}
+}
+
+var CloudScootReinstateWorkerArgs_Req_DEFAULT *ReinstateWorkerReq
+
+func (p *CloudScootReinstateWorkerArgs) GetReq() *ReinstateWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootReinstateWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootReinstateWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootReinstateWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &ReinstateWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ReinstateWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootReinstateWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootReinstateWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootReinstateWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootReinstateWorkerResult() *CloudScootReinstateWorkerResult {
+	return &CloudScootReinstateWorkerResult{}
+}
+
+var CloudScootReinstateWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootReinstateWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootReinstateWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootReinstateWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootReinstateWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootReinstateWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootReinstateWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootReinstateWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootReinstateWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ReinstateWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootReinstateWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootReinstateWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootReinstateWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootReinstateWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootCordonWorkerArgs struct {
+	Req *CordonWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootCordonWorkerArgs() *CloudScootCordonWorkerArgs {
+	return &CloudScootCordonWorkerArgs{}
+}
+
+var CloudScootCordonWorkerArgs_Req_DEFAULT *CordonWorkerReq
+
+func (p *CloudScootCordonWorkerArgs) GetReq() *CordonWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootCordonWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootCordonWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootCordonWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &CordonWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("CordonWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootCordonWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootCordonWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootCordonWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootCordonWorkerResult() *CloudScootCordonWorkerResult {
+	return &CloudScootCordonWorkerResult{}
+}
+
+var CloudScootCordonWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootCordonWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootCordonWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootCordonWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootCordonWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootCordonWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootCordonWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootCordonWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootCordonWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("CordonWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootCordonWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootCordonWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootCordonWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootCordonWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootUncordonWorkerArgs struct {
+	Req *CordonWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootUncordonWorkerArgs() *CloudScootUncordonWorkerArgs {
+	return &CloudScootUncordonWorkerArgs{}
+}
+
+var CloudScootUncordonWorkerArgs_Req_DEFAULT *CordonWorkerReq
+
+func (p *CloudScootUncordonWorkerArgs) GetReq() *CordonWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootUncordonWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootUncordonWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootUncordonWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &CordonWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UncordonWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootUncordonWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootUncordonWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootUncordonWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootUncordonWorkerResult() *CloudScootUncordonWorkerResult {
+	return &CloudScootUncordonWorkerResult{}
+}
+
+var CloudScootUncordonWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootUncordonWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootUncordonWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootUncordonWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootUncordonWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootUncordonWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootUncordonWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootUncordonWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootUncordonWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UncordonWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootUncordonWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootUncordonWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootUncordonWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootUncordonWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootAddWorkerArgs struct {
+	Req *ManualWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootAddWorkerArgs() *CloudScootAddWorkerArgs {
+	return &CloudScootAddWorkerArgs{}
+}
+
+var CloudScootAddWorkerArgs_Req_DEFAULT *ManualWorkerReq
+
+func (p *CloudScootAddWorkerArgs) GetReq() *ManualWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootAddWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootAddWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootAddWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &ManualWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AddWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootAddWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootAddWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootAddWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootAddWorkerResult() *CloudScootAddWorkerResult {
+	return &CloudScootAddWorkerResult{}
+}
+
+var CloudScootAddWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootAddWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootAddWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootAddWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootAddWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootAddWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootAddWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootAddWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootAddWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AddWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootAddWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootAddWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootAddWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootAddWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootRemoveWorkerArgs struct {
+	Req *ManualWorkerReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootRemoveWorkerArgs() *CloudScootRemoveWorkerArgs {
+	return &CloudScootRemoveWorkerArgs{}
+}
+
+var CloudScootRemoveWorkerArgs_Req_DEFAULT *ManualWorkerReq
+
+func (p *CloudScootRemoveWorkerArgs) GetReq() *ManualWorkerReq {
+	if !p.IsSetReq() {
+		return CloudScootRemoveWorkerArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootRemoveWorkerArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootRemoveWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &ManualWorkerReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RemoveWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootRemoveWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRemoveWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootRemoveWorkerResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootRemoveWorkerResult() *CloudScootRemoveWorkerResult {
+	return &CloudScootRemoveWorkerResult{}
+}
+
+var CloudScootRemoveWorkerResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootRemoveWorkerResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootRemoveWorkerResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootRemoveWorkerResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootRemoveWorkerResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootRemoveWorkerResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootRemoveWorkerResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootRemoveWorkerResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootRemoveWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RemoveWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRemoveWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRemoveWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRemoveWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRemoveWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootPauseJobArgs struct {
+	Req *PauseJobReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootPauseJobArgs() *CloudScootPauseJobArgs {
+	return &CloudScootPauseJobArgs{}
+}
+
+var CloudScootPauseJobArgs_Req_DEFAULT *PauseJobReq
+
+func (p *CloudScootPauseJobArgs) GetReq() *PauseJobReq {
+	if !p.IsSetReq() {
+		return CloudScootPauseJobArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootPauseJobArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootPauseJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &PauseJobReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PauseJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootPauseJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootPauseJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootPauseJobResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootPauseJobResult() *CloudScootPauseJobResult {
+	return &CloudScootPauseJobResult{}
+}
+
+var CloudScootPauseJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootPauseJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootPauseJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootPauseJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootPauseJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootPauseJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootPauseJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootPauseJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootPauseJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PauseJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootPauseJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootPauseJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootPauseJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootPauseJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type CloudScootResumeJobArgs struct {
+	Req *PauseJobReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootResumeJobArgs() *CloudScootResumeJobArgs {
+	return &CloudScootResumeJobArgs{}
+}
+
+var CloudScootResumeJobArgs_Req_DEFAULT *PauseJobReq
+
+func (p *CloudScootResumeJobArgs) GetReq() *PauseJobReq {
+	if !p.IsSetReq() {
+		return CloudScootResumeJobArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootResumeJobArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootResumeJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &PauseJobReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ResumeJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootResumeJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootResumeJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootResumeJobResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootResumeJobResult() *CloudScootResumeJobResult {
+	return &CloudScootResumeJobResult{}
+}
+
+var CloudScootResumeJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootResumeJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootResumeJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootResumeJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootResumeJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootResumeJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootResumeJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootResumeJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootResumeJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ResumeJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootResumeJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootResumeJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootResumeJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootResumeJobResult(%+v)", *p)
+}
+
+type CloudScootGetSchedulerStatusArgs struct {
+}
+
+func NewCloudScootGetSchedulerStatusArgs() *CloudScootGetSchedulerStatusArgs {
+	return &CloudScootGetSchedulerStatusArgs{}
+}
+
+func (p *CloudScootGetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSchedulerStatus_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetSchedulerStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Err
+type CloudScootGetSchedulerStatusResult struct {
+	Success *SchedulerStatus  `thrift:"success,0" json:"success,omitempty"`
+	Err     *ScootServerError `thrift:"err,1" json:"err,omitempty"`
+}
+
+func NewCloudScootGetSchedulerStatusResult() *CloudScootGetSchedulerStatusResult {
+	return &CloudScootGetSchedulerStatusResult{}
+}
+
+var CloudScootGetSchedulerStatusResult_Success_DEFAULT *SchedulerStatus
+
+func (p *CloudScootGetSchedulerStatusResult) GetSuccess() *SchedulerStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootGetSchedulerStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetSchedulerStatusResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetSchedulerStatusResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootGetSchedulerStatusResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootGetSchedulerStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
 	}
-	if err2 = oprot.WriteMessageBegin("ReinstateWorker", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &SchedulerStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) readField1(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSchedulerStatus_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetSchedulerStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetSchedulerStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetSchedulerStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetSchedulerStatusResult(%+v)", *p)
+}
+
+// Attributes:
+//  - MaxTasks
+//  - Requestor
+type CloudScootSetSchedulerStatusArgs struct {
+	MaxTasks  int32  `thrift:"maxTasks,1" json:"maxTasks"`
+	Requestor string `thrift:"requestor,2" json:"requestor"`
+}
+
+func NewCloudScootSetSchedulerStatusArgs() *CloudScootSetSchedulerStatusArgs {
+	return &CloudScootSetSchedulerStatusArgs{}
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) GetMaxTasks() int32 {
+	return p.MaxTasks
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) GetRequestor() string {
+	return p.Requestor
+}
+func (p *CloudScootSetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MaxTasks = v
+	}
+	return nil
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetSchedulerStatus_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("maxTasks", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxTasks: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.MaxTasks)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.maxTasks (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxTasks: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootSetSchedulerStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return fmt.Sprintf("CloudScootSetSchedulerStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+//  - Err
+type CloudScootSetSchedulerStatusResult struct {
+	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootSetSchedulerStatusResult() *CloudScootSetSchedulerStatusResult {
+	return &CloudScootSetSchedulerStatusResult{}
+}
+
+var CloudScootSetSchedulerStatusResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootSetSchedulerStatusResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootSetSchedulerStatusResult_Ir_DEFAULT
 	}
-	if err != nil {
-		return
+	return p.Ir
+}
+
+var CloudScootSetSchedulerStatusResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootSetSchedulerStatusResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootSetSchedulerStatusResult_Err_DEFAULT
 	}
-	return true, err
+	return p.Err
+}
+func (p *CloudScootSetSchedulerStatusResult) IsSetIr() bool {
+	return p.Ir != nil
 }
 
-type cloudScootProcessorGetSchedulerStatus struct {
-	handler CloudScoot
+func (p *CloudScootSetSchedulerStatusResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *cloudScootProcessorGetSchedulerStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootGetSchedulerStatusArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("GetSchedulerStatus", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootSetSchedulerStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := CloudScootGetSchedulerStatusResult{}
-	var retval *SchedulerStatus
-	var err2 error
-	if retval, err2 = p.handler.GetSchedulerStatus(); err2 != nil {
-		switch v := err2.(type) {
-		case *ScootServerError:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSchedulerStatus: "+err2.Error())
-			oprot.WriteMessageBegin("GetSchedulerStatus", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("GetSchedulerStatus", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type cloudScootProcessorSetSchedulerStatus struct {
-	handler CloudScoot
+func (p *CloudScootSetSchedulerStatusResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
 }
 
-func (p *cloudScootProcessorSetSchedulerStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootSetSchedulerStatusArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("SetSchedulerStatus", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootSetSchedulerStatusResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := CloudScootSetSchedulerStatusResult{}
-	var err2 error
-	if err2 = p.handler.SetSchedulerStatus(args.MaxTasks); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SetSchedulerStatus: "+err2.Error())
-			oprot.WriteMessageBegin("SetSchedulerStatus", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
+func (p *CloudScootSetSchedulerStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetSchedulerStatus_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = oprot.WriteMessageBegin("SetSchedulerStatus", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := p.writeField1(oprot); err != nil {
+		return err
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := p.writeField2(oprot); err != nil {
+		return err
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err != nil {
-		return
+	return nil
+}
+
+func (p *CloudScootSetSchedulerStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *CloudScootSetSchedulerStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootSetSchedulerStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootSetSchedulerStatusResult(%+v)", *p)
+}
 
 // Attributes:
-//  - Job
-type CloudScootRunJobArgs struct {
-	Job *JobDefinition `thrift:"job,1" json:"job"`
+//  - Query
+type CloudScootFindJobsArgs struct {
+	Query *JobQuery `thrift:"query,1" json:"query"`
 }
 
-func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
-	return &CloudScootRunJobArgs{}
+func NewCloudScootFindJobsArgs() *CloudScootFindJobsArgs {
+	return &CloudScootFindJobsArgs{}
 }
 
-var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+var CloudScootFindJobsArgs_Query_DEFAULT *JobQuery
 
-func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
-	if !p.IsSetJob() {
-		return CloudScootRunJobArgs_Job_DEFAULT
+func (p *CloudScootFindJobsArgs) GetQuery() *JobQuery {
+	if !p.IsSetQuery() {
+		return CloudScootFindJobsArgs_Query_DEFAULT
 	}
-	return p.Job
+	return p.Query
 }
-func (p *CloudScootRunJobArgs) IsSetJob() bool {
-	return p.Job != nil
+func (p *CloudScootFindJobsArgs) IsSetQuery() bool {
+	return p.Query != nil
 }
 
-func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootFindJobsArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1124,16 +6718,16 @@ func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
-	p.Job = &JobDefinition{}
-	if err := p.Job.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+func (p *CloudScootFindJobsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Query = &JobQuery{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+func (p *CloudScootFindJobsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("FindJobs_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1148,79 +6742,79 @@ func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+func (p *CloudScootFindJobsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
-	if err := p.Job.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootRunJobArgs) String() string {
+func (p *CloudScootFindJobsArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootFindJobsArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
-//  - Cnsn
-type CloudScootRunJobResult struct {
-	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
-	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
-	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+//  - Err
+type CloudScootFindJobsResult struct {
+	Success *JobList          `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootRunJobResult() *CloudScootRunJobResult {
-	return &CloudScootRunJobResult{}
+func NewCloudScootFindJobsResult() *CloudScootFindJobsResult {
+	return &CloudScootFindJobsResult{}
 }
 
-var CloudScootRunJobResult_Success_DEFAULT *JobId
+var CloudScootFindJobsResult_Success_DEFAULT *JobList
 
-func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+func (p *CloudScootFindJobsResult) GetSuccess() *JobList {
 	if !p.IsSetSuccess() {
-		return CloudScootRunJobResult_Success_DEFAULT
+		return CloudScootFindJobsResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+var CloudScootFindJobsResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+func (p *CloudScootFindJobsResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootRunJobResult_Ir_DEFAULT
+		return CloudScootFindJobsResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+var CloudScootFindJobsResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
-	if !p.IsSetCnsn() {
-		return CloudScootRunJobResult_Cnsn_DEFAULT
+func (p *CloudScootFindJobsResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootFindJobsResult_Err_DEFAULT
 	}
-	return p.Cnsn
+	return p.Err
 }
-func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+func (p *CloudScootFindJobsResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetIr() bool {
+func (p *CloudScootFindJobsResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetCnsn() bool {
-	return p.Cnsn != nil
+func (p *CloudScootFindJobsResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootFindJobsResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1261,15 +6855,15 @@ func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobId{}
+func (p *CloudScootFindJobsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobList{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootFindJobsResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -1277,16 +6871,16 @@ func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
-	p.Cnsn = &CanNotScheduleNow{}
-	if err := p.Cnsn.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+func (p *CloudScootFindJobsResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+func (p *CloudScootFindJobsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("FindJobs_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1307,7 +6901,7 @@ func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootFindJobsResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1322,7 +6916,7 @@ func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootFindJobsResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -1337,42 +6931,42 @@ func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetCnsn() {
-		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+func (p *CloudScootFindJobsResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
 		}
-		if err := p.Cnsn.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *CloudScootRunJobResult) String() string {
+func (p *CloudScootFindJobsResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootFindJobsResult(%+v)", *p)
 }
 
 // Attributes:
 //  - JobId
-type CloudScootGetStatusArgs struct {
+type CloudScootGetJobManifestArgs struct {
 	JobId string `thrift:"jobId,1" json:"jobId"`
 }
 
-func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
-	return &CloudScootGetStatusArgs{}
+func NewCloudScootGetJobManifestArgs() *CloudScootGetJobManifestArgs {
+	return &CloudScootGetJobManifestArgs{}
 }
 
-func (p *CloudScootGetStatusArgs) GetJobId() string {
+func (p *CloudScootGetJobManifestArgs) GetJobId() string {
 	return p.JobId
 }
-func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetJobManifestArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1405,7 +6999,7 @@ func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootGetJobManifestArgs) readField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
@@ -1414,8 +7008,8 @@ func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+func (p *CloudScootGetJobManifestArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetJobManifest_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1430,7 +7024,7 @@ func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetJobManifestArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
 	}
@@ -1443,66 +7037,66 @@ func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *CloudScootGetStatusArgs) String() string {
+func (p *CloudScootGetJobManifestArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetJobManifestArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
 //  - Err
-type CloudScootGetStatusResult struct {
-	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+type CloudScootGetJobManifestResult struct {
+	Success *JobManifest      `thrift:"success,0" json:"success,omitempty"`
 	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
 	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
-	return &CloudScootGetStatusResult{}
+func NewCloudScootGetJobManifestResult() *CloudScootGetJobManifestResult {
+	return &CloudScootGetJobManifestResult{}
 }
 
-var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+var CloudScootGetJobManifestResult_Success_DEFAULT *JobManifest
 
-func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+func (p *CloudScootGetJobManifestResult) GetSuccess() *JobManifest {
 	if !p.IsSetSuccess() {
-		return CloudScootGetStatusResult_Success_DEFAULT
+		return CloudScootGetJobManifestResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+var CloudScootGetJobManifestResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+func (p *CloudScootGetJobManifestResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootGetStatusResult_Ir_DEFAULT
+		return CloudScootGetJobManifestResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+var CloudScootGetJobManifestResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+func (p *CloudScootGetJobManifestResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootGetStatusResult_Err_DEFAULT
+		return CloudScootGetJobManifestResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+func (p *CloudScootGetJobManifestResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetIr() bool {
+func (p *CloudScootGetJobManifestResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetErr() bool {
+func (p *CloudScootGetJobManifestResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetJobManifestResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1543,15 +7137,15 @@ func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobStatus{}
+func (p *CloudScootGetJobManifestResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobManifest{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootGetJobManifestResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -1559,7 +7153,7 @@ func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootGetJobManifestResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -1567,8 +7161,8 @@ func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+func (p *CloudScootGetJobManifestResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetJobManifest_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1589,7 +7183,7 @@ func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetJobManifestResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1604,7 +7198,7 @@ func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetJobManifestResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -1619,7 +7213,7 @@ func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetJobManifestResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -1634,27 +7228,27 @@ func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) String() string {
+func (p *CloudScootGetJobManifestResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetJobManifestResult(%+v)", *p)
 }
 
 // Attributes:
-//  - JobId
-type CloudScootKillJobArgs struct {
-	JobId string `thrift:"jobId,1" json:"jobId"`
+//  - MinIdleMs
+type CloudScootGetIdleWorkersArgs struct {
+	MinIdleMs int64 `thrift:"minIdleMs,1" json:"minIdleMs"`
 }
 
-func NewCloudScootKillJobArgs() *CloudScootKillJobArgs {
-	return &CloudScootKillJobArgs{}
+func NewCloudScootGetIdleWorkersArgs() *CloudScootGetIdleWorkersArgs {
+	return &CloudScootGetIdleWorkersArgs{}
 }
 
-func (p *CloudScootKillJobArgs) GetJobId() string {
-	return p.JobId
+func (p *CloudScootGetIdleWorkersArgs) GetMinIdleMs() int64 {
+	return p.MinIdleMs
 }
-func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetIdleWorkersArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1687,17 +7281,17 @@ func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *CloudScootGetIdleWorkersArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.JobId = v
+		p.MinIdleMs = v
 	}
 	return nil
 }
 
-func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("KillJob_args"); err != nil {
+func (p *CloudScootGetIdleWorkersArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetIdleWorkers_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1712,79 +7306,79 @@ func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+func (p *CloudScootGetIdleWorkersArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("minIdleMs", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:minIdleMs: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.JobId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.MinIdleMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.minIdleMs (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:minIdleMs: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootKillJobArgs) String() string {
+func (p *CloudScootGetIdleWorkersArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootKillJobArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetIdleWorkersArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
 //  - Err
-type CloudScootKillJobResult struct {
-	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+type CloudScootGetIdleWorkersResult struct {
+	Success *IdleWorkers      `thrift:"success,0" json:"success,omitempty"`
 	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
 	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootKillJobResult() *CloudScootKillJobResult {
-	return &CloudScootKillJobResult{}
+func NewCloudScootGetIdleWorkersResult() *CloudScootGetIdleWorkersResult {
+	return &CloudScootGetIdleWorkersResult{}
 }
 
-var CloudScootKillJobResult_Success_DEFAULT *JobStatus
+var CloudScootGetIdleWorkersResult_Success_DEFAULT *IdleWorkers
 
-func (p *CloudScootKillJobResult) GetSuccess() *JobStatus {
+func (p *CloudScootGetIdleWorkersResult) GetSuccess() *IdleWorkers {
 	if !p.IsSetSuccess() {
-		return CloudScootKillJobResult_Success_DEFAULT
+		return CloudScootGetIdleWorkersResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootKillJobResult_Ir_DEFAULT *InvalidRequest
+var CloudScootGetIdleWorkersResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootKillJobResult) GetIr() *InvalidRequest {
+func (p *CloudScootGetIdleWorkersResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootKillJobResult_Ir_DEFAULT
+		return CloudScootGetIdleWorkersResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootKillJobResult_Err_DEFAULT *ScootServerError
+var CloudScootGetIdleWorkersResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootKillJobResult) GetErr() *ScootServerError {
+func (p *CloudScootGetIdleWorkersResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootKillJobResult_Err_DEFAULT
+		return CloudScootGetIdleWorkersResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootKillJobResult) IsSetSuccess() bool {
+func (p *CloudScootGetIdleWorkersResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootKillJobResult) IsSetIr() bool {
+func (p *CloudScootGetIdleWorkersResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootKillJobResult) IsSetErr() bool {
+func (p *CloudScootGetIdleWorkersResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetIdleWorkersResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1825,15 +7419,15 @@ func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobStatus{}
+func (p *CloudScootGetIdleWorkersResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &IdleWorkers{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootGetIdleWorkersResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -1841,7 +7435,7 @@ func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootGetIdleWorkersResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -1849,8 +7443,8 @@ func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("KillJob_result"); err != nil {
+func (p *CloudScootGetIdleWorkersResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetIdleWorkers_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1871,7 +7465,7 @@ func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetIdleWorkersResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1886,7 +7480,7 @@ func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetIdleWorkersResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -1901,7 +7495,7 @@ func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetIdleWorkersResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -1916,36 +7510,36 @@ func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *CloudScootKillJobResult) String() string {
+func (p *CloudScootGetIdleWorkersResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootKillJobResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetIdleWorkersResult(%+v)", *p)
 }
 
 // Attributes:
-//  - Req
-type CloudScootOfflineWorkerArgs struct {
-	Req *OfflineWorkerReq `thrift:"req,1" json:"req"`
+//  - Query
+type CloudScootGetAuditLogArgs struct {
+	Query *AuditQuery `thrift:"query,1" json:"query"`
 }
 
-func NewCloudScootOfflineWorkerArgs() *CloudScootOfflineWorkerArgs {
-	return &CloudScootOfflineWorkerArgs{}
+func NewCloudScootGetAuditLogArgs() *CloudScootGetAuditLogArgs {
+	return &CloudScootGetAuditLogArgs{}
 }
 
-var CloudScootOfflineWorkerArgs_Req_DEFAULT *OfflineWorkerReq
+var CloudScootGetAuditLogArgs_Query_DEFAULT *AuditQuery
 
-func (p *CloudScootOfflineWorkerArgs) GetReq() *OfflineWorkerReq {
-	if !p.IsSetReq() {
-		return CloudScootOfflineWorkerArgs_Req_DEFAULT
+func (p *CloudScootGetAuditLogArgs) GetQuery() *AuditQuery {
+	if !p.IsSetQuery() {
+		return CloudScootGetAuditLogArgs_Query_DEFAULT
 	}
-	return p.Req
+	return p.Query
 }
-func (p *CloudScootOfflineWorkerArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *CloudScootGetAuditLogArgs) IsSetQuery() bool {
+	return p.Query != nil
 }
 
-func (p *CloudScootOfflineWorkerArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetAuditLogArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1978,16 +7572,16 @@ func (p *CloudScootOfflineWorkerArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerArgs) readField1(iprot thrift.TProtocol) error {
-	p.Req = &OfflineWorkerReq{}
-	if err := p.Req.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+func (p *CloudScootGetAuditLogArgs) readField1(iprot thrift.TProtocol) error {
+	p.Query = &AuditQuery{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("OfflineWorker_args"); err != nil {
+func (p *CloudScootGetAuditLogArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetAuditLog_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2002,64 +7596,79 @@ func (p *CloudScootOfflineWorkerArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+func (p *CloudScootGetAuditLogArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
-	if err := p.Req.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootOfflineWorkerArgs) String() string {
+func (p *CloudScootGetAuditLogArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootOfflineWorkerArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetAuditLogArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Ir
 //  - Err
-type CloudScootOfflineWorkerResult struct {
-	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
-	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+type CloudScootGetAuditLogResult struct {
+	Success *AuditLog         `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootOfflineWorkerResult() *CloudScootOfflineWorkerResult {
-	return &CloudScootOfflineWorkerResult{}
+func NewCloudScootGetAuditLogResult() *CloudScootGetAuditLogResult {
+	return &CloudScootGetAuditLogResult{}
 }
 
-var CloudScootOfflineWorkerResult_Ir_DEFAULT *InvalidRequest
+var CloudScootGetAuditLogResult_Success_DEFAULT *AuditLog
 
-func (p *CloudScootOfflineWorkerResult) GetIr() *InvalidRequest {
+func (p *CloudScootGetAuditLogResult) GetSuccess() *AuditLog {
+	if !p.IsSetSuccess() {
+		return CloudScootGetAuditLogResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetAuditLogResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootGetAuditLogResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootOfflineWorkerResult_Ir_DEFAULT
+		return CloudScootGetAuditLogResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootOfflineWorkerResult_Err_DEFAULT *ScootServerError
+var CloudScootGetAuditLogResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootOfflineWorkerResult) GetErr() *ScootServerError {
+func (p *CloudScootGetAuditLogResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootOfflineWorkerResult_Err_DEFAULT
+		return CloudScootGetAuditLogResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootOfflineWorkerResult) IsSetIr() bool {
+func (p *CloudScootGetAuditLogResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetAuditLogResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootOfflineWorkerResult) IsSetErr() bool {
+func (p *CloudScootGetAuditLogResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootOfflineWorkerResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetAuditLogResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2073,6 +7682,10 @@ func (p *CloudScootOfflineWorkerResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.readField1(iprot); err != nil {
 				return err
@@ -2096,7 +7709,15 @@ func (p *CloudScootOfflineWorkerResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootGetAuditLogResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &AuditLog{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetAuditLogResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -2104,7 +7725,7 @@ func (p *CloudScootOfflineWorkerResult) readField1(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootGetAuditLogResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -2112,10 +7733,13 @@ func (p *CloudScootOfflineWorkerResult) readField2(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("OfflineWorker_result"); err != nil {
+func (p *CloudScootGetAuditLogResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetAuditLog_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
@@ -2131,7 +7755,22 @@ func (p *CloudScootOfflineWorkerResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootOfflineWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetAuditLogResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetAuditLogResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -2146,7 +7785,7 @@ func (p *CloudScootOfflineWorkerResult) writeField1(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *CloudScootOfflineWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetAuditLogResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -2161,36 +7800,36 @@ func (p *CloudScootOfflineWorkerResult) writeField2(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *CloudScootOfflineWorkerResult) String() string {
+func (p *CloudScootGetAuditLogResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootOfflineWorkerResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetAuditLogResult(%+v)", *p)
 }
 
 // Attributes:
-//  - Req
-type CloudScootReinstateWorkerArgs struct {
-	Req *ReinstateWorkerReq `thrift:"req,1" json:"req"`
+//  - Query
+type CloudScootQueryJobArchiveArgs struct {
+	Query *ArchiveQuery `thrift:"query,1" json:"query"`
 }
 
-func NewCloudScootReinstateWorkerArgs() *CloudScootReinstateWorkerArgs {
-	return &CloudScootReinstateWorkerArgs{}
+func NewCloudScootQueryJobArchiveArgs() *CloudScootQueryJobArchiveArgs {
+	return &CloudScootQueryJobArchiveArgs{}
 }
 
-var CloudScootReinstateWorkerArgs_Req_DEFAULT *ReinstateWorkerReq
+var CloudScootQueryJobArchiveArgs_Query_DEFAULT *ArchiveQuery
 
-func (p *CloudScootReinstateWorkerArgs) GetReq() *ReinstateWorkerReq {
-	if !p.IsSetReq() {
-		return CloudScootReinstateWorkerArgs_Req_DEFAULT
+func (p *CloudScootQueryJobArchiveArgs) GetQuery() *ArchiveQuery {
+	if !p.IsSetQuery() {
+		return CloudScootQueryJobArchiveArgs_Query_DEFAULT
 	}
-	return p.Req
+	return p.Query
 }
-func (p *CloudScootReinstateWorkerArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *CloudScootQueryJobArchiveArgs) IsSetQuery() bool {
+	return p.Query != nil
 }
 
-func (p *CloudScootReinstateWorkerArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootQueryJobArchiveArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2223,16 +7862,16 @@ func (p *CloudScootReinstateWorkerArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerArgs) readField1(iprot thrift.TProtocol) error {
-	p.Req = &ReinstateWorkerReq{}
-	if err := p.Req.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+func (p *CloudScootQueryJobArchiveArgs) readField1(iprot thrift.TProtocol) error {
+	p.Query = &ArchiveQuery{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ReinstateWorker_args"); err != nil {
+func (p *CloudScootQueryJobArchiveArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryJobArchive_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2247,64 +7886,79 @@ func (p *CloudScootReinstateWorkerArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+func (p *CloudScootQueryJobArchiveArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
-	if err := p.Req.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootReinstateWorkerArgs) String() string {
+func (p *CloudScootQueryJobArchiveArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootReinstateWorkerArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootQueryJobArchiveArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Ir
 //  - Err
-type CloudScootReinstateWorkerResult struct {
-	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
-	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+type CloudScootQueryJobArchiveResult struct {
+	Success *JobArchive       `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootReinstateWorkerResult() *CloudScootReinstateWorkerResult {
-	return &CloudScootReinstateWorkerResult{}
+func NewCloudScootQueryJobArchiveResult() *CloudScootQueryJobArchiveResult {
+	return &CloudScootQueryJobArchiveResult{}
+}
+
+var CloudScootQueryJobArchiveResult_Success_DEFAULT *JobArchive
+
+func (p *CloudScootQueryJobArchiveResult) GetSuccess() *JobArchive {
+	if !p.IsSetSuccess() {
+		return CloudScootQueryJobArchiveResult_Success_DEFAULT
+	}
+	return p.Success
 }
 
-var CloudScootReinstateWorkerResult_Ir_DEFAULT *InvalidRequest
+var CloudScootQueryJobArchiveResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootReinstateWorkerResult) GetIr() *InvalidRequest {
+func (p *CloudScootQueryJobArchiveResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootReinstateWorkerResult_Ir_DEFAULT
+		return CloudScootQueryJobArchiveResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootReinstateWorkerResult_Err_DEFAULT *ScootServerError
+var CloudScootQueryJobArchiveResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootReinstateWorkerResult) GetErr() *ScootServerError {
+func (p *CloudScootQueryJobArchiveResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootReinstateWorkerResult_Err_DEFAULT
+		return CloudScootQueryJobArchiveResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootReinstateWorkerResult) IsSetIr() bool {
+func (p *CloudScootQueryJobArchiveResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootQueryJobArchiveResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootReinstateWorkerResult) IsSetErr() bool {
+func (p *CloudScootQueryJobArchiveResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootReinstateWorkerResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootQueryJobArchiveResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2318,6 +7972,10 @@ func (p *CloudScootReinstateWorkerResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.readField1(iprot); err != nil {
 				return err
@@ -2341,7 +7999,15 @@ func (p *CloudScootReinstateWorkerResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootQueryJobArchiveResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobArchive{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootQueryJobArchiveResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -2349,7 +8015,7 @@ func (p *CloudScootReinstateWorkerResult) readField1(iprot thrift.TProtocol) err
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootQueryJobArchiveResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -2357,10 +8023,13 @@ func (p *CloudScootReinstateWorkerResult) readField2(iprot thrift.TProtocol) err
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ReinstateWorker_result"); err != nil {
+func (p *CloudScootQueryJobArchiveResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryJobArchive_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
@@ -2376,7 +8045,22 @@ func (p *CloudScootReinstateWorkerResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootReinstateWorkerResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootQueryJobArchiveResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootQueryJobArchiveResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -2391,7 +8075,7 @@ func (p *CloudScootReinstateWorkerResult) writeField1(oprot thrift.TProtocol) (e
 	return err
 }
 
-func (p *CloudScootReinstateWorkerResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootQueryJobArchiveResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -2406,21 +8090,36 @@ func (p *CloudScootReinstateWorkerResult) writeField2(oprot thrift.TProtocol) (e
 	return err
 }
 
-func (p *CloudScootReinstateWorkerResult) String() string {
+func (p *CloudScootQueryJobArchiveResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootReinstateWorkerResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootQueryJobArchiveResult(%+v)", *p)
 }
 
-type CloudScootGetSchedulerStatusArgs struct {
+// Attributes:
+//  - Query
+type CloudScootGetDeadLetterTasksArgs struct {
+	Query *DeadLetterQuery `thrift:"query,1" json:"query"`
 }
 
-func NewCloudScootGetSchedulerStatusArgs() *CloudScootGetSchedulerStatusArgs {
-	return &CloudScootGetSchedulerStatusArgs{}
+func NewCloudScootGetDeadLetterTasksArgs() *CloudScootGetDeadLetterTasksArgs {
+	return &CloudScootGetDeadLetterTasksArgs{}
 }
 
-func (p *CloudScootGetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
+var CloudScootGetDeadLetterTasksArgs_Query_DEFAULT *DeadLetterQuery
+
+func (p *CloudScootGetDeadLetterTasksArgs) GetQuery() *DeadLetterQuery {
+	if !p.IsSetQuery() {
+		return CloudScootGetDeadLetterTasksArgs_Query_DEFAULT
+	}
+	return p.Query
+}
+func (p *CloudScootGetDeadLetterTasksArgs) IsSetQuery() bool {
+	return p.Query != nil
+}
+
+func (p *CloudScootGetDeadLetterTasksArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2433,8 +8132,15 @@ func (p *CloudScootGetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -2446,10 +8152,21 @@ func (p *CloudScootGetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSchedulerStatus_args"); err != nil {
+func (p *CloudScootGetDeadLetterTasksArgs) readField1(iprot thrift.TProtocol) error {
+	p.Query = &DeadLetterQuery{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetDeadLetterTasksArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetDeadLetterTasks_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2459,51 +8176,79 @@ func (p *CloudScootGetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusArgs) String() string {
+func (p *CloudScootGetDeadLetterTasksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+	}
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootGetDeadLetterTasksArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetSchedulerStatusArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetDeadLetterTasksArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
+//  - Ir
 //  - Err
-type CloudScootGetSchedulerStatusResult struct {
-	Success *SchedulerStatus  `thrift:"success,0" json:"success,omitempty"`
-	Err     *ScootServerError `thrift:"err,1" json:"err,omitempty"`
+type CloudScootGetDeadLetterTasksResult struct {
+	Success *DeadLetterTasks  `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootGetSchedulerStatusResult() *CloudScootGetSchedulerStatusResult {
-	return &CloudScootGetSchedulerStatusResult{}
+func NewCloudScootGetDeadLetterTasksResult() *CloudScootGetDeadLetterTasksResult {
+	return &CloudScootGetDeadLetterTasksResult{}
 }
 
-var CloudScootGetSchedulerStatusResult_Success_DEFAULT *SchedulerStatus
+var CloudScootGetDeadLetterTasksResult_Success_DEFAULT *DeadLetterTasks
 
-func (p *CloudScootGetSchedulerStatusResult) GetSuccess() *SchedulerStatus {
+func (p *CloudScootGetDeadLetterTasksResult) GetSuccess() *DeadLetterTasks {
 	if !p.IsSetSuccess() {
-		return CloudScootGetSchedulerStatusResult_Success_DEFAULT
+		return CloudScootGetDeadLetterTasksResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootGetSchedulerStatusResult_Err_DEFAULT *ScootServerError
+var CloudScootGetDeadLetterTasksResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootGetSchedulerStatusResult) GetErr() *ScootServerError {
+func (p *CloudScootGetDeadLetterTasksResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootGetDeadLetterTasksResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootGetDeadLetterTasksResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetDeadLetterTasksResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootGetSchedulerStatusResult_Err_DEFAULT
+		return CloudScootGetDeadLetterTasksResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootGetSchedulerStatusResult) IsSetSuccess() bool {
+func (p *CloudScootGetDeadLetterTasksResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) IsSetErr() bool {
+func (p *CloudScootGetDeadLetterTasksResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootGetDeadLetterTasksResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetDeadLetterTasksResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2525,6 +8270,10 @@ func (p *CloudScootGetSchedulerStatusResult) Read(iprot thrift.TProtocol) error
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2540,15 +8289,23 @@ func (p *CloudScootGetSchedulerStatusResult) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &SchedulerStatus{}
+func (p *CloudScootGetDeadLetterTasksResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DeadLetterTasks{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootGetDeadLetterTasksResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetDeadLetterTasksResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -2556,8 +8313,8 @@ func (p *CloudScootGetSchedulerStatusResult) readField1(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSchedulerStatus_result"); err != nil {
+func (p *CloudScootGetDeadLetterTasksResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetDeadLetterTasks_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -2566,6 +8323,9 @@ func (p *CloudScootGetSchedulerStatusResult) Write(oprot thrift.TProtocol) error
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2575,7 +8335,7 @@ func (p *CloudScootGetSchedulerStatusResult) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootGetSchedulerStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetDeadLetterTasksResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -2590,42 +8350,66 @@ func (p *CloudScootGetSchedulerStatusResult) writeField0(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *CloudScootGetSchedulerStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetDeadLetterTasksResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetDeadLetterTasksResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
-		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
 		}
 		if err := p.Err.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *CloudScootGetSchedulerStatusResult) String() string {
+func (p *CloudScootGetDeadLetterTasksResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetSchedulerStatusResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetDeadLetterTasksResult(%+v)", *p)
 }
 
 // Attributes:
-//  - MaxTasks
-type CloudScootSetSchedulerStatusArgs struct {
-	MaxTasks int32 `thrift:"maxTasks,1" json:"maxTasks"`
+//  - Req
+type CloudScootRequeueDeadLetterTaskArgs struct {
+	Req *RequeueTaskReq `thrift:"req,1" json:"req"`
 }
 
-func NewCloudScootSetSchedulerStatusArgs() *CloudScootSetSchedulerStatusArgs {
-	return &CloudScootSetSchedulerStatusArgs{}
+func NewCloudScootRequeueDeadLetterTaskArgs() *CloudScootRequeueDeadLetterTaskArgs {
+	return &CloudScootRequeueDeadLetterTaskArgs{}
 }
 
-func (p *CloudScootSetSchedulerStatusArgs) GetMaxTasks() int32 {
-	return p.MaxTasks
+var CloudScootRequeueDeadLetterTaskArgs_Req_DEFAULT *RequeueTaskReq
+
+func (p *CloudScootRequeueDeadLetterTaskArgs) GetReq() *RequeueTaskReq {
+	if !p.IsSetReq() {
+		return CloudScootRequeueDeadLetterTaskArgs_Req_DEFAULT
+	}
+	return p.Req
 }
-func (p *CloudScootSetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootRequeueDeadLetterTaskArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootRequeueDeadLetterTaskArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2658,17 +8442,16 @@ func (p *CloudScootSetSchedulerStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.MaxTasks = v
+func (p *CloudScootRequeueDeadLetterTaskArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &RequeueTaskReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("SetSchedulerStatus_args"); err != nil {
+func (p *CloudScootRequeueDeadLetterTaskArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RequeueDeadLetterTask_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2683,64 +8466,79 @@ func (p *CloudScootSetSchedulerStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("maxTasks", thrift.I32, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxTasks: ", p), err)
+func (p *CloudScootRequeueDeadLetterTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.MaxTasks)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.maxTasks (1) field write error: ", p), err)
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxTasks: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootSetSchedulerStatusArgs) String() string {
+func (p *CloudScootRequeueDeadLetterTaskArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootSetSchedulerStatusArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootRequeueDeadLetterTaskArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Ir
 //  - Err
-type CloudScootSetSchedulerStatusResult struct {
-	Ir  *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
-	Err *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+type CloudScootRequeueDeadLetterTaskResult struct {
+	Success *JobId            `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootSetSchedulerStatusResult() *CloudScootSetSchedulerStatusResult {
-	return &CloudScootSetSchedulerStatusResult{}
+func NewCloudScootRequeueDeadLetterTaskResult() *CloudScootRequeueDeadLetterTaskResult {
+	return &CloudScootRequeueDeadLetterTaskResult{}
 }
 
-var CloudScootSetSchedulerStatusResult_Ir_DEFAULT *InvalidRequest
+var CloudScootRequeueDeadLetterTaskResult_Success_DEFAULT *JobId
 
-func (p *CloudScootSetSchedulerStatusResult) GetIr() *InvalidRequest {
+func (p *CloudScootRequeueDeadLetterTaskResult) GetSuccess() *JobId {
+	if !p.IsSetSuccess() {
+		return CloudScootRequeueDeadLetterTaskResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootRequeueDeadLetterTaskResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootRequeueDeadLetterTaskResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootSetSchedulerStatusResult_Ir_DEFAULT
+		return CloudScootRequeueDeadLetterTaskResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootSetSchedulerStatusResult_Err_DEFAULT *ScootServerError
+var CloudScootRequeueDeadLetterTaskResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootSetSchedulerStatusResult) GetErr() *ScootServerError {
+func (p *CloudScootRequeueDeadLetterTaskResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootSetSchedulerStatusResult_Err_DEFAULT
+		return CloudScootRequeueDeadLetterTaskResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootSetSchedulerStatusResult) IsSetIr() bool {
+func (p *CloudScootRequeueDeadLetterTaskResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootRequeueDeadLetterTaskResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) IsSetErr() bool {
+func (p *CloudScootRequeueDeadLetterTaskResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootRequeueDeadLetterTaskResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2754,6 +8552,10 @@ func (p *CloudScootSetSchedulerStatusResult) Read(iprot thrift.TProtocol) error
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.readField1(iprot); err != nil {
 				return err
@@ -2777,7 +8579,15 @@ func (p *CloudScootSetSchedulerStatusResult) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootRequeueDeadLetterTaskResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobId{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRequeueDeadLetterTaskResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -2785,7 +8595,7 @@ func (p *CloudScootSetSchedulerStatusResult) readField1(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootRequeueDeadLetterTaskResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -2793,10 +8603,13 @@ func (p *CloudScootSetSchedulerStatusResult) readField2(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("SetSchedulerStatus_result"); err != nil {
+func (p *CloudScootRequeueDeadLetterTaskResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RequeueDeadLetterTask_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
@@ -2812,7 +8625,22 @@ func (p *CloudScootSetSchedulerStatusResult) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *CloudScootSetSchedulerStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootRequeueDeadLetterTaskResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRequeueDeadLetterTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -2827,7 +8655,7 @@ func (p *CloudScootSetSchedulerStatusResult) writeField1(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *CloudScootSetSchedulerStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootRequeueDeadLetterTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -2842,9 +8670,9 @@ func (p *CloudScootSetSchedulerStatusResult) writeField2(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *CloudScootSetSchedulerStatusResult) String() string {
+func (p *CloudScootRequeueDeadLetterTaskResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootSetSchedulerStatusResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootRequeueDeadLetterTaskResult(%+v)", *p)
 }
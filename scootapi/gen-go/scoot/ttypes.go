@@ -132,6 +132,63 @@ func (p *RunStatusState) UnmarshalText(text []byte) error {
 	return nil
 }
 
+type FailureKind int64
+
+const (
+	FailureKind_UNCLASSIFIED FailureKind = 0
+	FailureKind_NONE         FailureKind = 1
+	FailureKind_COMMAND      FailureKind = 2
+	FailureKind_INFRA        FailureKind = 3
+	FailureKind_REQUEST      FailureKind = 4
+)
+
+func (p FailureKind) String() string {
+	switch p {
+	case FailureKind_UNCLASSIFIED:
+		return "UNCLASSIFIED"
+	case FailureKind_NONE:
+		return "NONE"
+	case FailureKind_COMMAND:
+		return "COMMAND"
+	case FailureKind_INFRA:
+		return "INFRA"
+	case FailureKind_REQUEST:
+		return "REQUEST"
+	}
+	return "<UNSET>"
+}
+
+func FailureKindFromString(s string) (FailureKind, error) {
+	switch s {
+	case "UNCLASSIFIED":
+		return FailureKind_UNCLASSIFIED, nil
+	case "NONE":
+		return FailureKind_NONE, nil
+	case "COMMAND":
+		return FailureKind_COMMAND, nil
+	case "INFRA":
+		return FailureKind_INFRA, nil
+	case "REQUEST":
+		return FailureKind_REQUEST, nil
+	}
+	return FailureKind(0), fmt.Errorf("not a valid FailureKind string")
+}
+
+func FailureKindPtr(v FailureKind) *FailureKind { return &v }
+
+func (p FailureKind) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *FailureKind) UnmarshalText(text []byte) error {
+	q, err := FailureKindFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
 type Status int64
 
 const (
@@ -507,6 +564,246 @@ func (p *ScootServerError) Error() string {
 	return p.String()
 }
 
+// Attributes:
+//  - MaxRssBytes
+//  - UserTimeMs
+//  - SystemTimeMs
+//  - WallTimeMs
+type ResourceUsage struct {
+	MaxRssBytes  *int64 `thrift:"maxRssBytes,1" json:"maxRssBytes,omitempty"`
+	UserTimeMs   *int64 `thrift:"userTimeMs,2" json:"userTimeMs,omitempty"`
+	SystemTimeMs *int64 `thrift:"systemTimeMs,3" json:"systemTimeMs,omitempty"`
+	WallTimeMs   *int64 `thrift:"wallTimeMs,4" json:"wallTimeMs,omitempty"`
+}
+
+func NewResourceUsage() *ResourceUsage {
+	return &ResourceUsage{}
+}
+
+var ResourceUsage_MaxRssBytes_DEFAULT int64
+
+func (p *ResourceUsage) GetMaxRssBytes() int64 {
+	if !p.IsSetMaxRssBytes() {
+		return ResourceUsage_MaxRssBytes_DEFAULT
+	}
+	return *p.MaxRssBytes
+}
+
+var ResourceUsage_UserTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetUserTimeMs() int64 {
+	if !p.IsSetUserTimeMs() {
+		return ResourceUsage_UserTimeMs_DEFAULT
+	}
+	return *p.UserTimeMs
+}
+
+var ResourceUsage_SystemTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetSystemTimeMs() int64 {
+	if !p.IsSetSystemTimeMs() {
+		return ResourceUsage_SystemTimeMs_DEFAULT
+	}
+	return *p.SystemTimeMs
+}
+
+var ResourceUsage_WallTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetWallTimeMs() int64 {
+	if !p.IsSetWallTimeMs() {
+		return ResourceUsage_WallTimeMs_DEFAULT
+	}
+	return *p.WallTimeMs
+}
+func (p *ResourceUsage) IsSetMaxRssBytes() bool {
+	return p.MaxRssBytes != nil
+}
+
+func (p *ResourceUsage) IsSetUserTimeMs() bool {
+	return p.UserTimeMs != nil
+}
+
+func (p *ResourceUsage) IsSetSystemTimeMs() bool {
+	return p.SystemTimeMs != nil
+}
+
+func (p *ResourceUsage) IsSetWallTimeMs() bool {
+	return p.WallTimeMs != nil
+}
+
+func (p *ResourceUsage) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MaxRssBytes = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UserTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SystemTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.WallTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ResourceUsage"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ResourceUsage) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxRssBytes() {
+		if err := oprot.WriteFieldBegin("maxRssBytes", thrift.I64, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxRssBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MaxRssBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxRssBytes (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxRssBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUserTimeMs() {
+		if err := oprot.WriteFieldBegin("userTimeMs", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:userTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.UserTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.userTimeMs (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:userTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSystemTimeMs() {
+		if err := oprot.WriteFieldBegin("systemTimeMs", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:systemTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SystemTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.systemTimeMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:systemTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWallTimeMs() {
+		if err := oprot.WriteFieldBegin("wallTimeMs", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:wallTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.WallTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.wallTimeMs (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:wallTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ResourceUsage(%+v)", *p)
+}
+
 // Attributes:
 //  - Status
 //  - RunId
@@ -519,6 +816,12 @@ func (p *ScootServerError) Error() string {
 //  - TaskId
 //  - Tag
 //  - BazelResult_
+//  - Usage
+//  - HookError
+//  - LogRef
+//  - FailureKind
+//  - StdoutTail
+//  - StderrTail
 type RunStatus struct {
 	Status       RunStatusState       `thrift:"status,1,required" json:"status"`
 	RunId        string               `thrift:"runId,2,required" json:"runId"`
@@ -531,6 +834,12 @@ type RunStatus struct {
 	TaskId       *string              `thrift:"taskId,9" json:"taskId,omitempty"`
 	Tag          *string              `thrift:"tag,10" json:"tag,omitempty"`
 	BazelResult_ *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	Usage        *ResourceUsage       `thrift:"usage,12" json:"usage,omitempty"`
+	HookError    *string              `thrift:"hookError,13" json:"hookError,omitempty"`
+	LogRef       *string              `thrift:"logRef,14" json:"logRef,omitempty"`
+	FailureKind  *FailureKind         `thrift:"failureKind,15" json:"failureKind,omitempty"`
+	StdoutTail   *string              `thrift:"stdoutTail,16" json:"stdoutTail,omitempty"`
+	StderrTail   *string              `thrift:"stderrTail,17" json:"stderrTail,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -625,6 +934,60 @@ func (p *RunStatus) GetBazelResult_() *bazel.ActionResult_ {
 	}
 	return p.BazelResult_
 }
+
+var RunStatus_Usage_DEFAULT *ResourceUsage
+
+func (p *RunStatus) GetUsage() *ResourceUsage {
+	if !p.IsSetUsage() {
+		return RunStatus_Usage_DEFAULT
+	}
+	return p.Usage
+}
+
+var RunStatus_HookError_DEFAULT string
+
+func (p *RunStatus) GetHookError() string {
+	if !p.IsSetHookError() {
+		return RunStatus_HookError_DEFAULT
+	}
+	return *p.HookError
+}
+
+var RunStatus_LogRef_DEFAULT string
+
+func (p *RunStatus) GetLogRef() string {
+	if !p.IsSetLogRef() {
+		return RunStatus_LogRef_DEFAULT
+	}
+	return *p.LogRef
+}
+
+var RunStatus_FailureKind_DEFAULT FailureKind
+
+func (p *RunStatus) GetFailureKind() FailureKind {
+	if !p.IsSetFailureKind() {
+		return RunStatus_FailureKind_DEFAULT
+	}
+	return *p.FailureKind
+}
+
+var RunStatus_StdoutTail_DEFAULT string
+
+func (p *RunStatus) GetStdoutTail() string {
+	if !p.IsSetStdoutTail() {
+		return RunStatus_StdoutTail_DEFAULT
+	}
+	return *p.StdoutTail
+}
+
+var RunStatus_StderrTail_DEFAULT string
+
+func (p *RunStatus) GetStderrTail() string {
+	if !p.IsSetStderrTail() {
+		return RunStatus_StderrTail_DEFAULT
+	}
+	return *p.StderrTail
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -661,6 +1024,30 @@ func (p *RunStatus) IsSetBazelResult_() bool {
 	return p.BazelResult_ != nil
 }
 
+func (p *RunStatus) IsSetUsage() bool {
+	return p.Usage != nil
+}
+
+func (p *RunStatus) IsSetHookError() bool {
+	return p.HookError != nil
+}
+
+func (p *RunStatus) IsSetLogRef() bool {
+	return p.LogRef != nil
+}
+
+func (p *RunStatus) IsSetFailureKind() bool {
+	return p.FailureKind != nil
+}
+
+func (p *RunStatus) IsSetStdoutTail() bool {
+	return p.StdoutTail != nil
+}
+
+func (p *RunStatus) IsSetStderrTail() bool {
+	return p.StderrTail != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -724,6 +1111,30 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField11(iprot); err != nil {
 				return err
 			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.readField13(iprot); err != nil {
+				return err
+			}
+		case 14:
+			if err := p.readField14(iprot); err != nil {
+				return err
+			}
+		case 15:
+			if err := p.readField15(iprot); err != nil {
+				return err
+			}
+		case 16:
+			if err := p.readField16(iprot); err != nil {
+				return err
+			}
+		case 17:
+			if err := p.readField17(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -844,18 +1255,72 @@ func (p *RunStatus) readField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *RunStatus) readField12(iprot thrift.TProtocol) error {
+	p.Usage = &ResourceUsage{}
+	if err := p.Usage.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Usage), err)
 	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *RunStatus) readField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		p.HookError = &v
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *RunStatus) readField14(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 14: ", err)
+	} else {
+		p.LogRef = &v
 	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *RunStatus) readField15(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 15: ", err)
+	} else {
+		temp := FailureKind(v)
+		p.FailureKind = &temp
+	}
+	return nil
+}
+
+func (p *RunStatus) readField16(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 16: ", err)
+	} else {
+		p.StdoutTail = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField17(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 17: ", err)
+	} else {
+		p.StderrTail = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
 	}
 	if err := p.writeField4(oprot); err != nil {
 		return err
@@ -881,6 +1346,24 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField11(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField14(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField15(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField16(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField17(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1051,6 +1534,96 @@ func (p *RunStatus) writeField11(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUsage() {
+		if err := oprot.WriteFieldBegin("usage", thrift.STRUCT, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:usage: ", p), err)
+		}
+		if err := p.Usage.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Usage), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:usage: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetHookError() {
+		if err := oprot.WriteFieldBegin("hookError", thrift.STRING, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:hookError: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.HookError)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.hookError (13) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:hookError: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField14(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLogRef() {
+		if err := oprot.WriteFieldBegin("logRef", thrift.STRING, 14); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 14:logRef: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.LogRef)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.logRef (14) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 14:logRef: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField15(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFailureKind() {
+		if err := oprot.WriteFieldBegin("failureKind", thrift.I32, 15); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 15:failureKind: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.FailureKind)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.failureKind (15) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 15:failureKind: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField16(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStdoutTail() {
+		if err := oprot.WriteFieldBegin("stdoutTail", thrift.STRING, 16); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 16:stdoutTail: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.StdoutTail)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.stdoutTail (16) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 16:stdoutTail: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField17(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStderrTail() {
+		if err := oprot.WriteFieldBegin("stderrTail", thrift.STRING, 17); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 17:stderrTail: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.StderrTail)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.stderrTail (17) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 17:stderrTail: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1243,16 +1816,168 @@ func (p *Command) String() string {
 	return fmt.Sprintf("Command(%+v)", *p)
 }
 
+// Attributes:
+//  - MilliCpus
+//  - MemoryBytes
+type Resources struct {
+	MilliCpus   *int64 `thrift:"milliCpus,1" json:"milliCpus,omitempty"`
+	MemoryBytes *int64 `thrift:"memoryBytes,2" json:"memoryBytes,omitempty"`
+}
+
+func NewResources() *Resources {
+	return &Resources{}
+}
+
+var Resources_MilliCpus_DEFAULT int64
+
+func (p *Resources) GetMilliCpus() int64 {
+	if !p.IsSetMilliCpus() {
+		return Resources_MilliCpus_DEFAULT
+	}
+	return *p.MilliCpus
+}
+
+var Resources_MemoryBytes_DEFAULT int64
+
+func (p *Resources) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return Resources_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+func (p *Resources) IsSetMilliCpus() bool {
+	return p.MilliCpus != nil
+}
+
+func (p *Resources) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *Resources) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Resources) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MilliCpus = &v
+	}
+	return nil
+}
+
+func (p *Resources) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *Resources) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Resources"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Resources) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMilliCpus() {
+		if err := oprot.WriteFieldBegin("milliCpus", thrift.I64, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:milliCpus: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MilliCpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.milliCpus (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:milliCpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Resources(%+v)", *p)
+}
+
 // Attributes:
 //  - Command
 //  - SnapshotId
 //  - TaskId
 //  - TimeoutMs
+//  - Resources
+//  - SparsePatterns
 type TaskDefinition struct {
-	Command    *Command `thrift:"command,1,required" json:"command"`
-	SnapshotId *string  `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
-	TaskId     *string  `thrift:"taskId,3" json:"taskId,omitempty"`
-	TimeoutMs  *int32   `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	Command        *Command   `thrift:"command,1,required" json:"command"`
+	SnapshotId     *string    `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
+	TaskId         *string    `thrift:"taskId,3" json:"taskId,omitempty"`
+	TimeoutMs      *int32     `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	Resources      *Resources `thrift:"resources,5" json:"resources,omitempty"`
+	SparsePatterns []string   `thrift:"sparsePatterns,6" json:"sparsePatterns,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -1294,6 +2019,21 @@ func (p *TaskDefinition) GetTimeoutMs() int32 {
 	}
 	return *p.TimeoutMs
 }
+
+var TaskDefinition_Resources_DEFAULT *Resources
+
+func (p *TaskDefinition) GetResources() *Resources {
+	if !p.IsSetResources() {
+		return TaskDefinition_Resources_DEFAULT
+	}
+	return p.Resources
+}
+
+var TaskDefinition_SparsePatterns_DEFAULT []string
+
+func (p *TaskDefinition) GetSparsePatterns() []string {
+	return p.SparsePatterns
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
@@ -1310,6 +2050,14 @@ func (p *TaskDefinition) IsSetTimeoutMs() bool {
 	return p.TimeoutMs != nil
 }
 
+func (p *TaskDefinition) IsSetResources() bool {
+	return p.Resources != nil
+}
+
+func (p *TaskDefinition) IsSetSparsePatterns() bool {
+	return p.SparsePatterns != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1343,6 +2091,14 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1396,6 +2152,36 @@ func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	p.Resources = &Resources{}
+	if err := p.Resources.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Resources), err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.SparsePatterns = tSlice
+	for i := 0; i < size; i++ {
+		var _elem29 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem29 = v
+		}
+		p.SparsePatterns = append(p.SparsePatterns, _elem29)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1412,6 +2198,12 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1479,22 +2271,64 @@ func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *TaskDefinition) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResources() {
+		if err := oprot.WriteFieldBegin("resources", thrift.STRUCT, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:resources: ", p), err)
+		}
+		if err := p.Resources.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Resources), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:resources: ", p), err)
+		}
 	}
-	return fmt.Sprintf("TaskDefinition(%+v)", *p)
+	return err
 }
 
-// Attributes:
-//  - Tasks
-//  - DEPRECATEDJobType
-//  - DefaultTaskTimeoutMs
-//  - Priority
-//  - Tag
-//  - Basis
-//  - Requestor
-//  - JobType
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSparsePatterns() {
+		if err := oprot.WriteFieldBegin("sparsePatterns", thrift.LIST, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:sparsePatterns: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.SparsePatterns)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.SparsePatterns {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:sparsePatterns: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TaskDefinition(%+v)", *p)
+}
+
+// Attributes:
+//  - Tasks
+//  - DEPRECATEDJobType
+//  - DefaultTaskTimeoutMs
+//  - Priority
+//  - Tag
+//  - Basis
+//  - Requestor
+//  - JobType
+//  - Labels
+//  - TimeoutMs
+//  - RetentionMs
+//  - Gang
 type JobDefinition struct {
 	Tasks                []*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
 	DEPRECATEDJobType    *JobType          `thrift:"DEPRECATED_jobType,2" json:"DEPRECATED_jobType,omitempty"`
@@ -1504,6 +2338,10 @@ type JobDefinition struct {
 	Basis                *string           `thrift:"basis,6" json:"basis,omitempty"`
 	Requestor            *string           `thrift:"requestor,7" json:"requestor,omitempty"`
 	JobType              *string           `thrift:"jobType,8" json:"jobType,omitempty"`
+	Labels               map[string]string `thrift:"labels,9" json:"labels,omitempty"`
+	TimeoutMs            *int64            `thrift:"timeoutMs,10" json:"timeoutMs,omitempty"`
+	RetentionMs          *int64            `thrift:"retentionMs,11" json:"retentionMs,omitempty"`
+	Gang                 *bool             `thrift:"gang,12" json:"gang,omitempty"`
 }
 
 func NewJobDefinition() *JobDefinition {
@@ -1576,6 +2414,39 @@ func (p *JobDefinition) GetJobType() string {
 	}
 	return *p.JobType
 }
+
+var JobDefinition_Labels_DEFAULT map[string]string
+
+func (p *JobDefinition) GetLabels() map[string]string {
+	return p.Labels
+}
+
+var JobDefinition_TimeoutMs_DEFAULT int64
+
+func (p *JobDefinition) GetTimeoutMs() int64 {
+	if !p.IsSetTimeoutMs() {
+		return JobDefinition_TimeoutMs_DEFAULT
+	}
+	return *p.TimeoutMs
+}
+
+var JobDefinition_RetentionMs_DEFAULT int64
+
+func (p *JobDefinition) GetRetentionMs() int64 {
+	if !p.IsSetRetentionMs() {
+		return JobDefinition_RetentionMs_DEFAULT
+	}
+	return *p.RetentionMs
+}
+
+var JobDefinition_Gang_DEFAULT bool
+
+func (p *JobDefinition) GetGang() bool {
+	if !p.IsSetGang() {
+		return JobDefinition_Gang_DEFAULT
+	}
+	return *p.Gang
+}
 func (p *JobDefinition) IsSetDEPRECATEDJobType() bool {
 	return p.DEPRECATEDJobType != nil
 }
@@ -1604,6 +2475,22 @@ func (p *JobDefinition) IsSetJobType() bool {
 	return p.JobType != nil
 }
 
+func (p *JobDefinition) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *JobDefinition) IsSetTimeoutMs() bool {
+	return p.TimeoutMs != nil
+}
+
+func (p *JobDefinition) IsSetRetentionMs() bool {
+	return p.RetentionMs != nil
+}
+
+func (p *JobDefinition) IsSetGang() bool {
+	return p.Gang != nil
+}
+
 func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1653,6 +2540,22 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField8(iprot); err != nil {
 				return err
 			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1755,6 +2658,61 @@ func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *JobDefinition) readField9(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key4 = v
+		}
+		var _val5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val5 = v
+		}
+		p.Labels[_key4] = _val5
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.TimeoutMs = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.RetentionMs = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.Gang = &v
+	}
+	return nil
+}
+
 func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1783,6 +2741,18 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField8(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1918,6 +2888,77 @@ func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeoutMs() {
+		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I64, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:timeoutMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.TimeoutMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:timeoutMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetentionMs() {
+		if err := oprot.WriteFieldBegin("retentionMs", thrift.I64, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:retentionMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.RetentionMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retentionMs (11) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:retentionMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetGang() {
+		if err := oprot.WriteFieldBegin("gang", thrift.BOOL, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:gang: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Gang)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.gang (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:gang: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -2027,11 +3068,15 @@ func (p *JobId) String() string {
 //  - Status
 //  - TaskStatus
 //  - TaskData
+//  - EtaMs
+//  - TaskEtaMs
 type JobStatus struct {
 	ID         string                `thrift:"id,1,required" json:"id"`
 	Status     Status                `thrift:"status,2,required" json:"status"`
 	TaskStatus map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
 	TaskData   map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+	EtaMs      *int64                `thrift:"etaMs,5" json:"etaMs,omitempty"`
+	TaskEtaMs  map[string]int64      `thrift:"taskEtaMs,6" json:"taskEtaMs,omitempty"`
 }
 
 func NewJobStatus() *JobStatus {
@@ -2057,6 +3102,21 @@ var JobStatus_TaskData_DEFAULT map[string]*RunStatus
 func (p *JobStatus) GetTaskData() map[string]*RunStatus {
 	return p.TaskData
 }
+
+var JobStatus_EtaMs_DEFAULT int64
+
+func (p *JobStatus) GetEtaMs() int64 {
+	if !p.IsSetEtaMs() {
+		return JobStatus_EtaMs_DEFAULT
+	}
+	return *p.EtaMs
+}
+
+var JobStatus_TaskEtaMs_DEFAULT map[string]int64
+
+func (p *JobStatus) GetTaskEtaMs() map[string]int64 {
+	return p.TaskEtaMs
+}
 func (p *JobStatus) IsSetTaskStatus() bool {
 	return p.TaskStatus != nil
 }
@@ -2065,6 +3125,14 @@ func (p *JobStatus) IsSetTaskData() bool {
 	return p.TaskData != nil
 }
 
+func (p *JobStatus) IsSetEtaMs() bool {
+	return p.EtaMs != nil
+}
+
+func (p *JobStatus) IsSetTaskEtaMs() bool {
+	return p.TaskEtaMs != nil
+}
+
 func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -2100,6 +3168,14 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2148,20 +3224,20 @@ func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
 	tMap := make(map[string]Status, size)
 	p.TaskStatus = tMap
 	for i := 0; i < size; i++ {
-		var _key4 string
+		var _key6 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key4 = v
+			_key6 = v
 		}
-		var _val5 Status
+		var _val7 Status
 		if v, err := iprot.ReadI32(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
 			temp := Status(v)
-			_val5 = temp
+			_val7 = temp
 		}
-		p.TaskStatus[_key4] = _val5
+		p.TaskStatus[_key6] = _val7
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -2177,17 +3253,54 @@ func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
 	tMap := make(map[string]*RunStatus, size)
 	p.TaskData = tMap
 	for i := 0; i < size; i++ {
-		var _key6 string
+		var _key8 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key6 = v
+			_key8 = v
 		}
-		_val7 := &RunStatus{}
-		if err := _val7.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val7), err)
+		_val9 := &RunStatus{}
+		if err := _val9.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val9), err)
 		}
-		p.TaskData[_key6] = _val7
+		p.TaskData[_key8] = _val9
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.EtaMs = &v
+	}
+	return nil
+}
+
+func (p *JobStatus) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]int64, size)
+	p.TaskEtaMs = tMap
+	for i := 0; i < size; i++ {
+		var _key10 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key10 = v
+		}
+		var _val11 int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val11 = v
+		}
+		p.TaskEtaMs[_key10] = _val11
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -2211,6 +3324,12 @@ func (p *JobStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2298,6 +3417,47 @@ func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEtaMs() {
+		if err := oprot.WriteFieldBegin("etaMs", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:etaMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.EtaMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.etaMs (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:etaMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskEtaMs() {
+		if err := oprot.WriteFieldBegin("taskEtaMs", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:taskEtaMs: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.I64, len(p.TaskEtaMs)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskEtaMs {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteI64(int64(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:taskEtaMs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -2306,32 +3466,5509 @@ func (p *JobStatus) String() string {
 }
 
 // Attributes:
-//  - ID
-//  - Requestor
-type OfflineWorkerReq struct {
-	ID        string `thrift:"id,1,required" json:"id"`
-	Requestor string `thrift:"requestor,2,required" json:"requestor"`
+//  - Labels
+//  - Limit
+type JobQuery struct {
+	Labels map[string]string `thrift:"labels,1" json:"labels,omitempty"`
+	Limit  *int32            `thrift:"limit,2" json:"limit,omitempty"`
 }
 
-func NewOfflineWorkerReq() *OfflineWorkerReq {
+func NewJobQuery() *JobQuery {
+	return &JobQuery{}
+}
+
+var JobQuery_Labels_DEFAULT map[string]string
+
+func (p *JobQuery) GetLabels() map[string]string {
+	return p.Labels
+}
+
+var JobQuery_Limit_DEFAULT int32
+
+func (p *JobQuery) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return JobQuery_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *JobQuery) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *JobQuery) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *JobQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *JobQuery) readField1(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key12 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key12 = v
+		}
+		var _val13 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val13 = v
+		}
+		p.Labels[_key12] = _val13
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobQuery) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *JobQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Status
+//  - Labels
+//  - Requestor
+//  - Tag
+//  - CreatedMs
+type JobSummary struct {
+	ID        string            `thrift:"id,1,required" json:"id"`
+	Status    Status            `thrift:"status,2,required" json:"status"`
+	Labels    map[string]string `thrift:"labels,3" json:"labels,omitempty"`
+	Requestor *string           `thrift:"requestor,4" json:"requestor,omitempty"`
+	Tag       *string           `thrift:"tag,5" json:"tag,omitempty"`
+	CreatedMs *int64            `thrift:"createdMs,6" json:"createdMs,omitempty"`
+}
+
+func NewJobSummary() *JobSummary {
+	return &JobSummary{}
+}
+
+func (p *JobSummary) GetID() string {
+	return p.ID
+}
+
+func (p *JobSummary) GetStatus() Status {
+	return p.Status
+}
+
+var JobSummary_Labels_DEFAULT map[string]string
+
+func (p *JobSummary) GetLabels() map[string]string {
+	return p.Labels
+}
+
+var JobSummary_Requestor_DEFAULT string
+
+func (p *JobSummary) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return JobSummary_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var JobSummary_Tag_DEFAULT string
+
+func (p *JobSummary) GetTag() string {
+	if !p.IsSetTag() {
+		return JobSummary_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var JobSummary_CreatedMs_DEFAULT int64
+
+func (p *JobSummary) GetCreatedMs() int64 {
+	if !p.IsSetCreatedMs() {
+		return JobSummary_CreatedMs_DEFAULT
+	}
+	return *p.CreatedMs
+}
+func (p *JobSummary) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *JobSummary) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *JobSummary) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *JobSummary) IsSetCreatedMs() bool {
+	return p.CreatedMs != nil
+}
+
+func (p *JobSummary) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetStatus bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	return nil
+}
+
+func (p *JobSummary) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *JobSummary) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key14 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key14 = v
+		}
+		var _val15 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val15 = v
+		}
+		p.Labels[_key14] = _val15
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobSummary) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.CreatedMs = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobSummary"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobSummary) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *JobSummary) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *JobSummary) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCreatedMs() {
+		if err := oprot.WriteFieldBegin("createdMs", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:createdMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.CreatedMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.createdMs (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:createdMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobSummary(%+v)", *p)
+}
+
+// Attributes:
+//  - Jobs
+type JobList struct {
+	Jobs []*JobSummary `thrift:"jobs,1,required" json:"jobs"`
+}
+
+func NewJobList() *JobList {
+	return &JobList{}
+}
+
+func (p *JobList) GetJobs() []*JobSummary {
+	return p.Jobs
+}
+func (p *JobList) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetJobs = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Jobs is not set"))
+	}
+	return nil
+}
+
+func (p *JobList) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*JobSummary, 0, size)
+	p.Jobs = tSlice
+	for i := 0; i < size; i++ {
+		_elem16 := &JobSummary{}
+		if err := _elem16.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem16), err)
+		}
+		p.Jobs = append(p.Jobs, _elem16)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobList) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobList"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobList) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Jobs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Jobs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobs: ", p), err)
+	}
+	return err
+}
+
+func (p *JobList) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobList(%+v)", *p)
+}
+
+// Attributes:
+//  - Kind
+//  - Path
+//  - Digest
+type Artifact struct {
+	Kind   string  `thrift:"kind,1,required" json:"kind"`
+	Path   *string `thrift:"path,2" json:"path,omitempty"`
+	Digest string  `thrift:"digest,3,required" json:"digest"`
+}
+
+func NewArtifact() *Artifact {
+	return &Artifact{}
+}
+
+func (p *Artifact) GetKind() string {
+	return p.Kind
+}
+
+var Artifact_Path_DEFAULT string
+
+func (p *Artifact) GetPath() string {
+	if !p.IsSetPath() {
+		return Artifact_Path_DEFAULT
+	}
+	return *p.Path
+}
+
+func (p *Artifact) GetDigest() string {
+	return p.Digest
+}
+func (p *Artifact) IsSetPath() bool {
+	return p.Path != nil
+}
+
+func (p *Artifact) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetKind bool = false
+	var issetDigest bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetKind = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetDigest = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetKind {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Kind is not set"))
+	}
+	if !issetDigest {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Digest is not set"))
+	}
+	return nil
+}
+
+func (p *Artifact) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Kind = v
+	}
+	return nil
+}
+
+func (p *Artifact) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Path = &v
+	}
+	return nil
+}
+
+func (p *Artifact) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Digest = v
+	}
+	return nil
+}
+
+func (p *Artifact) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Artifact"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Artifact) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("kind", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:kind: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Kind)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.kind (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:kind: ", p), err)
+	}
+	return err
+}
+
+func (p *Artifact) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPath() {
+		if err := oprot.WriteFieldBegin("path", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:path: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Path)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.path (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:path: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Artifact) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("digest", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:digest: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Digest)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.digest (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:digest: ", p), err)
+	}
+	return err
+}
+
+func (p *Artifact) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Artifact(%+v)", *p)
+}
+
+// Attributes:
+//  - TaskId
+//  - Status
+//  - SnapshotId
+//  - CommandDigest
+//  - OutputSnapshotId
+//  - Outputs
+//  - RunStatus
+//  - ExitCode
+//  - Error
+type TaskManifest struct {
+	TaskId           string          `thrift:"taskId,1,required" json:"taskId"`
+	Status           Status          `thrift:"status,2,required" json:"status"`
+	SnapshotId       *string         `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
+	CommandDigest    *string         `thrift:"commandDigest,4" json:"commandDigest,omitempty"`
+	OutputSnapshotId *string         `thrift:"outputSnapshotId,5" json:"outputSnapshotId,omitempty"`
+	Outputs          []*Artifact     `thrift:"outputs,6" json:"outputs,omitempty"`
+	RunStatus        *RunStatusState `thrift:"runStatus,7" json:"runStatus,omitempty"`
+	ExitCode         *int32          `thrift:"exitCode,8" json:"exitCode,omitempty"`
+	Error            *string         `thrift:"error,9" json:"error,omitempty"`
+}
+
+func NewTaskManifest() *TaskManifest {
+	return &TaskManifest{}
+}
+
+func (p *TaskManifest) GetTaskId() string {
+	return p.TaskId
+}
+
+func (p *TaskManifest) GetStatus() Status {
+	return p.Status
+}
+
+var TaskManifest_SnapshotId_DEFAULT string
+
+func (p *TaskManifest) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return TaskManifest_SnapshotId_DEFAULT
+	}
+	return *p.SnapshotId
+}
+
+var TaskManifest_CommandDigest_DEFAULT string
+
+func (p *TaskManifest) GetCommandDigest() string {
+	if !p.IsSetCommandDigest() {
+		return TaskManifest_CommandDigest_DEFAULT
+	}
+	return *p.CommandDigest
+}
+
+var TaskManifest_OutputSnapshotId_DEFAULT string
+
+func (p *TaskManifest) GetOutputSnapshotId() string {
+	if !p.IsSetOutputSnapshotId() {
+		return TaskManifest_OutputSnapshotId_DEFAULT
+	}
+	return *p.OutputSnapshotId
+}
+
+var TaskManifest_Outputs_DEFAULT []*Artifact
+
+func (p *TaskManifest) GetOutputs() []*Artifact {
+	return p.Outputs
+}
+
+var TaskManifest_RunStatus_DEFAULT RunStatusState
+
+func (p *TaskManifest) GetRunStatus() RunStatusState {
+	if !p.IsSetRunStatus() {
+		return TaskManifest_RunStatus_DEFAULT
+	}
+	return *p.RunStatus
+}
+
+var TaskManifest_ExitCode_DEFAULT int32
+
+func (p *TaskManifest) GetExitCode() int32 {
+	if !p.IsSetExitCode() {
+		return TaskManifest_ExitCode_DEFAULT
+	}
+	return *p.ExitCode
+}
+
+var TaskManifest_Error_DEFAULT string
+
+func (p *TaskManifest) GetError() string {
+	if !p.IsSetError() {
+		return TaskManifest_Error_DEFAULT
+	}
+	return *p.Error
+}
+func (p *TaskManifest) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
+}
+
+func (p *TaskManifest) IsSetCommandDigest() bool {
+	return p.CommandDigest != nil
+}
+
+func (p *TaskManifest) IsSetOutputSnapshotId() bool {
+	return p.OutputSnapshotId != nil
+}
+
+func (p *TaskManifest) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
+func (p *TaskManifest) IsSetRunStatus() bool {
+	return p.RunStatus != nil
+}
+
+func (p *TaskManifest) IsSetExitCode() bool {
+	return p.ExitCode != nil
+}
+
+func (p *TaskManifest) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *TaskManifest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTaskId bool = false
+	var issetStatus bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTaskId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTaskId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TaskId is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.TaskId = v
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SnapshotId = &v
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.CommandDigest = &v
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.OutputSnapshotId = &v
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Artifact, 0, size)
+	p.Outputs = tSlice
+	for i := 0; i < size; i++ {
+		_elem17 := &Artifact{}
+		if err := _elem17.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem17), err)
+		}
+		p.Outputs = append(p.Outputs, _elem17)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		temp := RunStatusState(v)
+		p.RunStatus = &temp
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.ExitCode = &v
+	}
+	return nil
+}
+
+func (p *TaskManifest) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *TaskManifest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TaskManifest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *TaskManifest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:taskId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.TaskId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.taskId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:taskId: ", p), err)
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:snapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:snapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCommandDigest() {
+		if err := oprot.WriteFieldBegin("commandDigest", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:commandDigest: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.CommandDigest)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.commandDigest (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:commandDigest: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputSnapshotId() {
+		if err := oprot.WriteFieldBegin("outputSnapshotId", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:outputSnapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.OutputSnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.outputSnapshotId (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:outputSnapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.LIST, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:outputs: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Outputs {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRunStatus() {
+		if err := oprot.WriteFieldBegin("runStatus", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:runStatus: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.RunStatus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.runStatus (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:runStatus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExitCode() {
+		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:exitCode: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.exitCode (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:exitCode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskManifest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TaskManifest(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+//  - Status
+//  - Requestor
+//  - Tag
+//  - Labels
+//  - Tasks
+type JobManifest struct {
+	JobId     string            `thrift:"jobId,1,required" json:"jobId"`
+	Status    Status            `thrift:"status,2,required" json:"status"`
+	Requestor *string           `thrift:"requestor,3" json:"requestor,omitempty"`
+	Tag       *string           `thrift:"tag,4" json:"tag,omitempty"`
+	Labels    map[string]string `thrift:"labels,5" json:"labels,omitempty"`
+	Tasks     []*TaskManifest   `thrift:"tasks,6,required" json:"tasks"`
+}
+
+func NewJobManifest() *JobManifest {
+	return &JobManifest{}
+}
+
+func (p *JobManifest) GetJobId() string {
+	return p.JobId
+}
+
+func (p *JobManifest) GetStatus() Status {
+	return p.Status
+}
+
+var JobManifest_Requestor_DEFAULT string
+
+func (p *JobManifest) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return JobManifest_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var JobManifest_Tag_DEFAULT string
+
+func (p *JobManifest) GetTag() string {
+	if !p.IsSetTag() {
+		return JobManifest_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var JobManifest_Labels_DEFAULT map[string]string
+
+func (p *JobManifest) GetLabels() map[string]string {
+	return p.Labels
+}
+
+func (p *JobManifest) GetTasks() []*TaskManifest {
+	return p.Tasks
+}
+func (p *JobManifest) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *JobManifest) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *JobManifest) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *JobManifest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobId bool = false
+	var issetStatus bool = false
+	var issetTasks bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetJobId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetTasks = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field JobId is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
+	}
+	return nil
+}
+
+func (p *JobManifest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *JobManifest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *JobManifest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *JobManifest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *JobManifest) readField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key18 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key18 = v
+		}
+		var _val19 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val19 = v
+		}
+		p.Labels[_key18] = _val19
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobManifest) readField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*TaskManifest, 0, size)
+	p.Tasks = tSlice
+	for i := 0; i < size; i++ {
+		_elem20 := &TaskManifest{}
+		if err := _elem20.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem20), err)
+		}
+		p.Tasks = append(p.Tasks, _elem20)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobManifest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobManifest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobManifest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *JobManifest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *JobManifest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobManifest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobManifest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobManifest) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:tasks: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Tasks {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:tasks: ", p), err)
+	}
+	return err
+}
+
+func (p *JobManifest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobManifest(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Requestor
+type OfflineWorkerReq struct {
+	ID        string `thrift:"id,1,required" json:"id"`
+	Requestor string `thrift:"requestor,2,required" json:"requestor"`
+}
+
+func NewOfflineWorkerReq() *OfflineWorkerReq {
 	return &OfflineWorkerReq{}
 }
 
-func (p *OfflineWorkerReq) GetID() string {
-	return p.ID
+func (p *OfflineWorkerReq) GetID() string {
+	return p.ID
+}
+
+func (p *OfflineWorkerReq) GetRequestor() string {
+	return p.Requestor
+}
+func (p *OfflineWorkerReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetRequestor bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	}
+	return nil
+}
+
+func (p *OfflineWorkerReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *OfflineWorkerReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *OfflineWorkerReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("OfflineWorkerReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *OfflineWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *OfflineWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *OfflineWorkerReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OfflineWorkerReq(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Requestor
+type ReinstateWorkerReq struct {
+	ID        string `thrift:"id,1,required" json:"id"`
+	Requestor string `thrift:"requestor,2,required" json:"requestor"`
+}
+
+func NewReinstateWorkerReq() *ReinstateWorkerReq {
+	return &ReinstateWorkerReq{}
+}
+
+func (p *ReinstateWorkerReq) GetID() string {
+	return p.ID
+}
+
+func (p *ReinstateWorkerReq) GetRequestor() string {
+	return p.Requestor
+}
+func (p *ReinstateWorkerReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetRequestor bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	}
+	return nil
+}
+
+func (p *ReinstateWorkerReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *ReinstateWorkerReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *ReinstateWorkerReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ReinstateWorkerReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ReinstateWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *ReinstateWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *ReinstateWorkerReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReinstateWorkerReq(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Requestor
+type CordonWorkerReq struct {
+	ID        string `thrift:"id,1,required" json:"id"`
+	Requestor string `thrift:"requestor,2,required" json:"requestor"`
+}
+
+func NewCordonWorkerReq() *CordonWorkerReq {
+	return &CordonWorkerReq{}
+}
+
+func (p *CordonWorkerReq) GetID() string {
+	return p.ID
+}
+
+func (p *CordonWorkerReq) GetRequestor() string {
+	return p.Requestor
+}
+func (p *CordonWorkerReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetRequestor bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	}
+	return nil
+}
+
+func (p *CordonWorkerReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *CordonWorkerReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *CordonWorkerReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("CordonWorkerReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CordonWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *CordonWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *CordonWorkerReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CordonWorkerReq(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Requestor
+//  - AdminToken
+type ManualWorkerReq struct {
+	ID         string  `thrift:"id,1,required" json:"id"`
+	Requestor  string  `thrift:"requestor,2,required" json:"requestor"`
+	AdminToken *string `thrift:"adminToken,3" json:"adminToken,omitempty"`
+}
+
+func NewManualWorkerReq() *ManualWorkerReq {
+	return &ManualWorkerReq{}
+}
+
+func (p *ManualWorkerReq) GetID() string {
+	return p.ID
+}
+
+func (p *ManualWorkerReq) GetRequestor() string {
+	return p.Requestor
+}
+
+var ManualWorkerReq_AdminToken_DEFAULT string
+
+func (p *ManualWorkerReq) GetAdminToken() string {
+	if !p.IsSetAdminToken() {
+		return ManualWorkerReq_AdminToken_DEFAULT
+	}
+	return *p.AdminToken
+}
+func (p *ManualWorkerReq) IsSetAdminToken() bool {
+	return p.AdminToken != nil
+}
+
+func (p *ManualWorkerReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetRequestor bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	}
+	return nil
+}
+
+func (p *ManualWorkerReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *ManualWorkerReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *ManualWorkerReq) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.AdminToken = &v
+	}
+	return nil
+}
+
+func (p *ManualWorkerReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ManualWorkerReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ManualWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *ManualWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *ManualWorkerReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAdminToken() {
+		if err := oprot.WriteFieldBegin("adminToken", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:adminToken: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.AdminToken)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.adminToken (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:adminToken: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ManualWorkerReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ManualWorkerReq(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Requestor
+//  - AbortRunning
+type PauseJobReq struct {
+	ID           string `thrift:"id,1,required" json:"id"`
+	Requestor    string `thrift:"requestor,2,required" json:"requestor"`
+	AbortRunning *bool  `thrift:"abortRunning,3" json:"abortRunning,omitempty"`
+}
+
+func NewPauseJobReq() *PauseJobReq {
+	return &PauseJobReq{}
+}
+
+func (p *PauseJobReq) GetID() string {
+	return p.ID
+}
+
+func (p *PauseJobReq) GetRequestor() string {
+	return p.Requestor
+}
+
+var PauseJobReq_AbortRunning_DEFAULT bool
+
+func (p *PauseJobReq) GetAbortRunning() bool {
+	if !p.IsSetAbortRunning() {
+		return PauseJobReq_AbortRunning_DEFAULT
+	}
+	return *p.AbortRunning
+}
+func (p *PauseJobReq) IsSetAbortRunning() bool {
+	return p.AbortRunning != nil
+}
+
+func (p *PauseJobReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetRequestor bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	}
+	return nil
+}
+
+func (p *PauseJobReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *PauseJobReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *PauseJobReq) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.AbortRunning = &v
+	}
+	return nil
+}
+
+func (p *PauseJobReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PauseJobReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *PauseJobReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *PauseJobReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *PauseJobReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAbortRunning() {
+		if err := oprot.WriteFieldBegin("abortRunning", thrift.BOOL, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:abortRunning: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.AbortRunning)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.abortRunning (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:abortRunning: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *PauseJobReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PauseJobReq(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - IdleSinceMs
+type IdleWorker struct {
+	ID          string `thrift:"id,1,required" json:"id"`
+	IdleSinceMs int64  `thrift:"idleSinceMs,2,required" json:"idleSinceMs"`
+}
+
+func NewIdleWorker() *IdleWorker {
+	return &IdleWorker{}
+}
+
+func (p *IdleWorker) GetID() string {
+	return p.ID
+}
+
+func (p *IdleWorker) GetIdleSinceMs() int64 {
+	return p.IdleSinceMs
+}
+func (p *IdleWorker) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetIdleSinceMs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetIdleSinceMs = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetIdleSinceMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field IdleSinceMs is not set"))
+	}
+	return nil
+}
+
+func (p *IdleWorker) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *IdleWorker) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.IdleSinceMs = v
+	}
+	return nil
+}
+
+func (p *IdleWorker) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("IdleWorker"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *IdleWorker) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *IdleWorker) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("idleSinceMs", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:idleSinceMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.IdleSinceMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.idleSinceMs (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:idleSinceMs: ", p), err)
+	}
+	return err
+}
+
+func (p *IdleWorker) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("IdleWorker(%+v)", *p)
+}
+
+// Attributes:
+//  - Workers
+type IdleWorkers struct {
+	Workers []*IdleWorker `thrift:"workers,1,required" json:"workers"`
+}
+
+func NewIdleWorkers() *IdleWorkers {
+	return &IdleWorkers{}
+}
+
+func (p *IdleWorkers) GetWorkers() []*IdleWorker {
+	return p.Workers
+}
+func (p *IdleWorkers) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetWorkers bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetWorkers = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWorkers {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Workers is not set"))
+	}
+	return nil
+}
+
+func (p *IdleWorkers) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*IdleWorker, 0, size)
+	p.Workers = tSlice
+	for i := 0; i < size; i++ {
+		_elem21 := &IdleWorker{}
+		if err := _elem21.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem21), err)
+		}
+		p.Workers = append(p.Workers, _elem21)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *IdleWorkers) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("IdleWorkers"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *IdleWorkers) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("workers", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:workers: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Workers)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Workers {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:workers: ", p), err)
+	}
+	return err
+}
+
+func (p *IdleWorkers) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("IdleWorkers(%+v)", *p)
+}
+
+// Attributes:
+//  - CurrentTasks
+//  - MaxTasks
+type SchedulerStatus struct {
+	CurrentTasks int32 `thrift:"currentTasks,1,required" json:"currentTasks"`
+	MaxTasks     int32 `thrift:"maxTasks,2,required" json:"maxTasks"`
+}
+
+func NewSchedulerStatus() *SchedulerStatus {
+	return &SchedulerStatus{}
+}
+
+func (p *SchedulerStatus) GetCurrentTasks() int32 {
+	return p.CurrentTasks
+}
+
+func (p *SchedulerStatus) GetMaxTasks() int32 {
+	return p.MaxTasks
+}
+func (p *SchedulerStatus) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetCurrentTasks bool = false
+	var issetMaxTasks bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetCurrentTasks = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetMaxTasks = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetCurrentTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field CurrentTasks is not set"))
+	}
+	if !issetMaxTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field MaxTasks is not set"))
+	}
+	return nil
+}
+
+func (p *SchedulerStatus) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.CurrentTasks = v
+	}
+	return nil
+}
+
+func (p *SchedulerStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.MaxTasks = v
+	}
+	return nil
+}
+
+func (p *SchedulerStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SchedulerStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SchedulerStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("currentTasks", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:currentTasks: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.CurrentTasks)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.currentTasks (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:currentTasks: ", p), err)
+	}
+	return err
+}
+
+func (p *SchedulerStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("maxTasks", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:maxTasks: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.MaxTasks)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.maxTasks (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:maxTasks: ", p), err)
+	}
+	return err
+}
+
+func (p *SchedulerStatus) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SchedulerStatus(%+v)", *p)
+}
+
+// Attributes:
+//  - TimeMs
+//  - Actor
+//  - Action
+//  - Target
+//  - Details
+//  - Error
+type AuditEntry struct {
+	TimeMs  int64   `thrift:"timeMs,1,required" json:"timeMs"`
+	Actor   string  `thrift:"actor,2,required" json:"actor"`
+	Action  string  `thrift:"action,3,required" json:"action"`
+	Target  *string `thrift:"target,4" json:"target,omitempty"`
+	Details *string `thrift:"details,5" json:"details,omitempty"`
+	Error   *string `thrift:"error,6" json:"error,omitempty"`
+}
+
+func NewAuditEntry() *AuditEntry {
+	return &AuditEntry{}
+}
+
+func (p *AuditEntry) GetTimeMs() int64 {
+	return p.TimeMs
+}
+
+func (p *AuditEntry) GetActor() string {
+	return p.Actor
+}
+
+func (p *AuditEntry) GetAction() string {
+	return p.Action
+}
+
+var AuditEntry_Target_DEFAULT string
+
+func (p *AuditEntry) GetTarget() string {
+	if !p.IsSetTarget() {
+		return AuditEntry_Target_DEFAULT
+	}
+	return *p.Target
+}
+
+var AuditEntry_Details_DEFAULT string
+
+func (p *AuditEntry) GetDetails() string {
+	if !p.IsSetDetails() {
+		return AuditEntry_Details_DEFAULT
+	}
+	return *p.Details
+}
+
+var AuditEntry_Error_DEFAULT string
+
+func (p *AuditEntry) GetError() string {
+	if !p.IsSetError() {
+		return AuditEntry_Error_DEFAULT
+	}
+	return *p.Error
+}
+func (p *AuditEntry) IsSetTarget() bool {
+	return p.Target != nil
+}
+
+func (p *AuditEntry) IsSetDetails() bool {
+	return p.Details != nil
+}
+
+func (p *AuditEntry) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *AuditEntry) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTimeMs bool = false
+	var issetActor bool = false
+	var issetAction bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTimeMs = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetActor = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetAction = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TimeMs is not set"))
+	}
+	if !issetActor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Actor is not set"))
+	}
+	if !issetAction {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Action is not set"))
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.TimeMs = v
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Actor = v
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Action = v
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Target = &v
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Details = &v
+	}
+	return nil
+}
+
+func (p *AuditEntry) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *AuditEntry) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AuditEntry"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AuditEntry) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("timeMs", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:timeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.TimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.timeMs (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:timeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *AuditEntry) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("actor", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:actor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Actor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.actor (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:actor: ", p), err)
+	}
+	return err
+}
+
+func (p *AuditEntry) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("action", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:action: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Action)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.action (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:action: ", p), err)
+	}
+	return err
+}
+
+func (p *AuditEntry) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTarget() {
+		if err := oprot.WriteFieldBegin("target", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:target: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Target)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.target (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:target: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditEntry) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDetails() {
+		if err := oprot.WriteFieldBegin("details", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:details: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Details)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.details (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:details: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditEntry) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditEntry) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AuditEntry(%+v)", *p)
+}
+
+// Attributes:
+//  - Action
+//  - Actor
+//  - SinceMs
+//  - MaxEntries
+type AuditQuery struct {
+	Action     *string `thrift:"action,1" json:"action,omitempty"`
+	Actor      *string `thrift:"actor,2" json:"actor,omitempty"`
+	SinceMs    *int64  `thrift:"sinceMs,3" json:"sinceMs,omitempty"`
+	MaxEntries *int32  `thrift:"maxEntries,4" json:"maxEntries,omitempty"`
+}
+
+func NewAuditQuery() *AuditQuery {
+	return &AuditQuery{}
+}
+
+var AuditQuery_Action_DEFAULT string
+
+func (p *AuditQuery) GetAction() string {
+	if !p.IsSetAction() {
+		return AuditQuery_Action_DEFAULT
+	}
+	return *p.Action
+}
+
+var AuditQuery_Actor_DEFAULT string
+
+func (p *AuditQuery) GetActor() string {
+	if !p.IsSetActor() {
+		return AuditQuery_Actor_DEFAULT
+	}
+	return *p.Actor
+}
+
+var AuditQuery_SinceMs_DEFAULT int64
+
+func (p *AuditQuery) GetSinceMs() int64 {
+	if !p.IsSetSinceMs() {
+		return AuditQuery_SinceMs_DEFAULT
+	}
+	return *p.SinceMs
+}
+
+var AuditQuery_MaxEntries_DEFAULT int32
+
+func (p *AuditQuery) GetMaxEntries() int32 {
+	if !p.IsSetMaxEntries() {
+		return AuditQuery_MaxEntries_DEFAULT
+	}
+	return *p.MaxEntries
+}
+func (p *AuditQuery) IsSetAction() bool {
+	return p.Action != nil
+}
+
+func (p *AuditQuery) IsSetActor() bool {
+	return p.Actor != nil
+}
+
+func (p *AuditQuery) IsSetSinceMs() bool {
+	return p.SinceMs != nil
+}
+
+func (p *AuditQuery) IsSetMaxEntries() bool {
+	return p.MaxEntries != nil
+}
+
+func (p *AuditQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AuditQuery) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Action = &v
+	}
+	return nil
+}
+
+func (p *AuditQuery) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Actor = &v
+	}
+	return nil
+}
+
+func (p *AuditQuery) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SinceMs = &v
+	}
+	return nil
+}
+
+func (p *AuditQuery) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.MaxEntries = &v
+	}
+	return nil
+}
+
+func (p *AuditQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AuditQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AuditQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAction() {
+		if err := oprot.WriteFieldBegin("action", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:action: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Action)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.action (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:action: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetActor() {
+		if err := oprot.WriteFieldBegin("actor", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:actor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Actor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.actor (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:actor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditQuery) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSinceMs() {
+		if err := oprot.WriteFieldBegin("sinceMs", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sinceMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SinceMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.sinceMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sinceMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditQuery) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxEntries() {
+		if err := oprot.WriteFieldBegin("maxEntries", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:maxEntries: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxEntries)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxEntries (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:maxEntries: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AuditQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AuditQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Entries
+type AuditLog struct {
+	Entries []*AuditEntry `thrift:"entries,1,required" json:"entries"`
+}
+
+func NewAuditLog() *AuditLog {
+	return &AuditLog{}
+}
+
+func (p *AuditLog) GetEntries() []*AuditEntry {
+	return p.Entries
+}
+func (p *AuditLog) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetEntries bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetEntries = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetEntries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Entries is not set"))
+	}
+	return nil
+}
+
+func (p *AuditLog) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*AuditEntry, 0, size)
+	p.Entries = tSlice
+	for i := 0; i < size; i++ {
+		_elem22 := &AuditEntry{}
+		if err := _elem22.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem22), err)
+		}
+		p.Entries = append(p.Entries, _elem22)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *AuditLog) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AuditLog"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AuditLog) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("entries", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:entries: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Entries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Entries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:entries: ", p), err)
+	}
+	return err
+}
+
+func (p *AuditLog) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AuditLog(%+v)", *p)
+}
+
+// Attributes:
+//  - TaskId
+//  - Message
+type ValidationError struct {
+	TaskId  *string `thrift:"taskId,1" json:"taskId,omitempty"`
+	Message string  `thrift:"message,2,required" json:"message"`
+}
+
+func NewValidationError() *ValidationError {
+	return &ValidationError{}
+}
+
+var ValidationError_TaskId_DEFAULT string
+
+func (p *ValidationError) GetTaskId() string {
+	if !p.IsSetTaskId() {
+		return ValidationError_TaskId_DEFAULT
+	}
+	return *p.TaskId
+}
+
+func (p *ValidationError) GetMessage() string {
+	return p.Message
+}
+func (p *ValidationError) IsSetTaskId() bool {
+	return p.TaskId != nil
+}
+
+func (p *ValidationError) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetMessage bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetMessage = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetMessage {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Message is not set"))
+	}
+	return nil
+}
+
+func (p *ValidationError) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.TaskId = &v
+	}
+	return nil
+}
+
+func (p *ValidationError) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Message = v
+	}
+	return nil
+}
+
+func (p *ValidationError) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ValidationError"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ValidationError) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskId() {
+		if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:taskId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.TaskId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.taskId (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:taskId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ValidationError) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("message", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:message: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Message)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.message (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:message: ", p), err)
+	}
+	return err
+}
+
+func (p *ValidationError) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ValidationError(%+v)", *p)
+}
+
+// Attributes:
+//  - Valid
+//  - Errors
+type JobValidation struct {
+	Valid  bool               `thrift:"valid,1,required" json:"valid"`
+	Errors []*ValidationError `thrift:"errors,2,required" json:"errors"`
+}
+
+func NewJobValidation() *JobValidation {
+	return &JobValidation{}
+}
+
+func (p *JobValidation) GetValid() bool {
+	return p.Valid
+}
+
+func (p *JobValidation) GetErrors() []*ValidationError {
+	return p.Errors
+}
+func (p *JobValidation) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetValid bool = false
+	var issetErrors bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetValid = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetErrors = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetValid {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Valid is not set"))
+	}
+	if !issetErrors {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Errors is not set"))
+	}
+	return nil
+}
+
+func (p *JobValidation) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Valid = v
+	}
+	return nil
+}
+
+func (p *JobValidation) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*ValidationError, 0, size)
+	p.Errors = tSlice
+	for i := 0; i < size; i++ {
+		_elem23 := &ValidationError{}
+		if err := _elem23.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem23), err)
+		}
+		p.Errors = append(p.Errors, _elem23)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobValidation) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobValidation"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobValidation) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("valid", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:valid: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Valid)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.valid (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:valid: ", p), err)
+	}
+	return err
+}
+
+func (p *JobValidation) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("errors", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:errors: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Errors)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Errors {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:errors: ", p), err)
+	}
+	return err
+}
+
+func (p *JobValidation) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobValidation(%+v)", *p)
+}
+
+
+// Attributes:
+//  - TaskId
+//  - Attempts
+//  - State
+//  - ExitCode
+//  - Error
+//  - SnapshotId
+type ArchivedTask struct {
+	TaskId     string          `thrift:"taskId,1,required" json:"taskId"`
+	Attempts   int32           `thrift:"attempts,2,required" json:"attempts"`
+	State      *RunStatusState `thrift:"state,3" json:"state,omitempty"`
+	ExitCode   *int32          `thrift:"exitCode,4" json:"exitCode,omitempty"`
+	Error      *string         `thrift:"error,5" json:"error,omitempty"`
+	SnapshotId *string         `thrift:"snapshotId,6" json:"snapshotId,omitempty"`
+}
+
+func NewArchivedTask() *ArchivedTask {
+	return &ArchivedTask{}
+}
+
+func (p *ArchivedTask) GetTaskId() string {
+	return p.TaskId
+}
+
+func (p *ArchivedTask) GetAttempts() int32 {
+	return p.Attempts
+}
+
+var ArchivedTask_State_DEFAULT RunStatusState
+
+func (p *ArchivedTask) GetState() RunStatusState {
+	if !p.IsSetState() {
+		return ArchivedTask_State_DEFAULT
+	}
+	return *p.State
+}
+
+var ArchivedTask_ExitCode_DEFAULT int32
+
+func (p *ArchivedTask) GetExitCode() int32 {
+	if !p.IsSetExitCode() {
+		return ArchivedTask_ExitCode_DEFAULT
+	}
+	return *p.ExitCode
+}
+
+var ArchivedTask_Error_DEFAULT string
+
+func (p *ArchivedTask) GetError() string {
+	if !p.IsSetError() {
+		return ArchivedTask_Error_DEFAULT
+	}
+	return *p.Error
+}
+
+var ArchivedTask_SnapshotId_DEFAULT string
+
+func (p *ArchivedTask) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return ArchivedTask_SnapshotId_DEFAULT
+	}
+	return *p.SnapshotId
+}
+func (p *ArchivedTask) IsSetState() bool {
+	return p.State != nil
+}
+
+func (p *ArchivedTask) IsSetExitCode() bool {
+	return p.ExitCode != nil
+}
+
+func (p *ArchivedTask) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *ArchivedTask) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
+}
+
+func (p *ArchivedTask) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTaskId bool = false
+	var issetAttempts bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTaskId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetAttempts = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTaskId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TaskId is not set"))
+	}
+	if !issetAttempts {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Attempts is not set"))
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.TaskId = v
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Attempts = v
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		temp := RunStatusState(v)
+		p.State = &temp
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.ExitCode = &v
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *ArchivedTask) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.SnapshotId = &v
+	}
+	return nil
+}
+
+func (p *ArchivedTask) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ArchivedTask"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ArchivedTask) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:taskId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.TaskId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.taskId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:taskId: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedTask) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("attempts", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:attempts: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Attempts)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.attempts (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:attempts: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedTask) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetState() {
+		if err := oprot.WriteFieldBegin("state", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:state: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.State)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.state (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:state: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedTask) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExitCode() {
+		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:exitCode: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.exitCode (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:exitCode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedTask) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedTask) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:snapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:snapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedTask) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ArchivedTask(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Status
+//  - Requestor
+//  - JobType
+//  - Tag
+//  - Labels
+//  - Priority
+//  - Killed
+//  - CreatedMs
+//  - CompletedMs
+//  - Tasks
+type ArchivedJob struct {
+	ID          string            `thrift:"id,1,required" json:"id"`
+	Status      Status            `thrift:"status,2,required" json:"status"`
+	Requestor   *string           `thrift:"requestor,3" json:"requestor,omitempty"`
+	JobType     *string           `thrift:"jobType,4" json:"jobType,omitempty"`
+	Tag         *string           `thrift:"tag,5" json:"tag,omitempty"`
+	Labels      map[string]string `thrift:"labels,6" json:"labels,omitempty"`
+	Priority    int32             `thrift:"priority,7,required" json:"priority"`
+	Killed      bool              `thrift:"killed,8,required" json:"killed"`
+	CreatedMs   int64             `thrift:"createdMs,9,required" json:"createdMs"`
+	CompletedMs int64             `thrift:"completedMs,10,required" json:"completedMs"`
+	Tasks       []*ArchivedTask   `thrift:"tasks,11,required" json:"tasks"`
+}
+
+func NewArchivedJob() *ArchivedJob {
+	return &ArchivedJob{}
+}
+
+func (p *ArchivedJob) GetID() string {
+	return p.ID
+}
+
+func (p *ArchivedJob) GetStatus() Status {
+	return p.Status
+}
+
+var ArchivedJob_Requestor_DEFAULT string
+
+func (p *ArchivedJob) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return ArchivedJob_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var ArchivedJob_JobType_DEFAULT string
+
+func (p *ArchivedJob) GetJobType() string {
+	if !p.IsSetJobType() {
+		return ArchivedJob_JobType_DEFAULT
+	}
+	return *p.JobType
+}
+
+var ArchivedJob_Tag_DEFAULT string
+
+func (p *ArchivedJob) GetTag() string {
+	if !p.IsSetTag() {
+		return ArchivedJob_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var ArchivedJob_Labels_DEFAULT map[string]string
+
+func (p *ArchivedJob) GetLabels() map[string]string {
+	return p.Labels
+}
+
+func (p *ArchivedJob) GetPriority() int32 {
+	return p.Priority
+}
+
+func (p *ArchivedJob) GetKilled() bool {
+	return p.Killed
+}
+
+func (p *ArchivedJob) GetCreatedMs() int64 {
+	return p.CreatedMs
+}
+
+func (p *ArchivedJob) GetCompletedMs() int64 {
+	return p.CompletedMs
+}
+
+func (p *ArchivedJob) GetTasks() []*ArchivedTask {
+	return p.Tasks
+}
+func (p *ArchivedJob) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *ArchivedJob) IsSetJobType() bool {
+	return p.JobType != nil
+}
+
+func (p *ArchivedJob) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *ArchivedJob) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *ArchivedJob) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetStatus bool = false
+	var issetPriority bool = false
+	var issetKilled bool = false
+	var issetCreatedMs bool = false
+	var issetCompletedMs bool = false
+	var issetTasks bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+			issetPriority = true
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+			issetKilled = true
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+			issetCreatedMs = true
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+			issetCompletedMs = true
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+			issetTasks = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetPriority {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Priority is not set"))
+	}
+	if !issetKilled {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Killed is not set"))
+	}
+	if !issetCreatedMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field CreatedMs is not set"))
+	}
+	if !issetCompletedMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field CompletedMs is not set"))
+	}
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.JobType = &v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key24 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key24 = v
+		}
+		var _val25 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val25 = v
+		}
+		p.Labels[_key24] = _val25
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Priority = v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Killed = v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.CreatedMs = v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.CompletedMs = v
+	}
+	return nil
+}
+
+func (p *ArchivedJob) readField11(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*ArchivedTask, 0, size)
+	p.Tasks = tSlice
+	for i := 0; i < size; i++ {
+		_elem26 := &ArchivedTask{}
+		if err := _elem26.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem26), err)
+		}
+		p.Tasks = append(p.Tasks, _elem26)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ArchivedJob) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ArchivedJob"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ArchivedJob) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobType() {
+		if err := oprot.WriteFieldBegin("jobType", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:jobType: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.JobType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobType (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:jobType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:labels: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField7(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("priority", thrift.I32, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:priority: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Priority)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.priority (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:priority: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField8(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("killed", thrift.BOOL, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:killed: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Killed)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.killed (8) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:killed: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField9(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("createdMs", thrift.I64, 9); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:createdMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.CreatedMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.createdMs (9) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 9:createdMs: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField10(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("completedMs", thrift.I64, 10); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:completedMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.CompletedMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.completedMs (10) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 10:completedMs: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) writeField11(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 11); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:tasks: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Tasks {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 11:tasks: ", p), err)
+	}
+	return err
+}
+
+func (p *ArchivedJob) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ArchivedJob(%+v)", *p)
+}
+
+// Attributes:
+//  - SinceMs
+//  - UntilMs
+//  - Tag
+//  - Requestor
+//  - Limit
+type ArchiveQuery struct {
+	SinceMs   *int64  `thrift:"sinceMs,1" json:"sinceMs,omitempty"`
+	UntilMs   *int64  `thrift:"untilMs,2" json:"untilMs,omitempty"`
+	Tag       *string `thrift:"tag,3" json:"tag,omitempty"`
+	Requestor *string `thrift:"requestor,4" json:"requestor,omitempty"`
+	Limit     *int32  `thrift:"limit,5" json:"limit,omitempty"`
+}
+
+func NewArchiveQuery() *ArchiveQuery {
+	return &ArchiveQuery{}
+}
+
+var ArchiveQuery_SinceMs_DEFAULT int64
+
+func (p *ArchiveQuery) GetSinceMs() int64 {
+	if !p.IsSetSinceMs() {
+		return ArchiveQuery_SinceMs_DEFAULT
+	}
+	return *p.SinceMs
+}
+
+var ArchiveQuery_UntilMs_DEFAULT int64
+
+func (p *ArchiveQuery) GetUntilMs() int64 {
+	if !p.IsSetUntilMs() {
+		return ArchiveQuery_UntilMs_DEFAULT
+	}
+	return *p.UntilMs
+}
+
+var ArchiveQuery_Tag_DEFAULT string
+
+func (p *ArchiveQuery) GetTag() string {
+	if !p.IsSetTag() {
+		return ArchiveQuery_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var ArchiveQuery_Requestor_DEFAULT string
+
+func (p *ArchiveQuery) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return ArchiveQuery_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var ArchiveQuery_Limit_DEFAULT int32
+
+func (p *ArchiveQuery) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return ArchiveQuery_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *ArchiveQuery) IsSetSinceMs() bool {
+	return p.SinceMs != nil
+}
+
+func (p *ArchiveQuery) IsSetUntilMs() bool {
+	return p.UntilMs != nil
+}
+
+func (p *ArchiveQuery) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *ArchiveQuery) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *ArchiveQuery) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *ArchiveQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.SinceMs = &v
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UntilMs = &v
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ArchiveQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ArchiveQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSinceMs() {
+		if err := oprot.WriteFieldBegin("sinceMs", thrift.I64, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:sinceMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SinceMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.sinceMs (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:sinceMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchiveQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUntilMs() {
+		if err := oprot.WriteFieldBegin("untilMs", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:untilMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.UntilMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.untilMs (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:untilMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchiveQuery) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchiveQuery) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchiveQuery) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ArchiveQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ArchiveQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Jobs
+type JobArchive struct {
+	Jobs []*ArchivedJob `thrift:"jobs,1,required" json:"jobs"`
+}
+
+func NewJobArchive() *JobArchive {
+	return &JobArchive{}
+}
+
+func (p *JobArchive) GetJobs() []*ArchivedJob {
+	return p.Jobs
+}
+func (p *JobArchive) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetJobs = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Jobs is not set"))
+	}
+	return nil
+}
+
+func (p *JobArchive) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*ArchivedJob, 0, size)
+	p.Jobs = tSlice
+	for i := 0; i < size; i++ {
+		_elem27 := &ArchivedJob{}
+		if err := _elem27.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem27), err)
+		}
+		p.Jobs = append(p.Jobs, _elem27)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobArchive) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobArchive"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobArchive) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Jobs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Jobs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobs: ", p), err)
+	}
+	return err
+}
+
+func (p *JobArchive) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobArchive(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+//  - TaskId
+//  - Requestor
+//  - Tag
+//  - InfraFailures
+//  - DeadLetteredMs
+//  - Error
+type DeadLetterTask struct {
+	JobId          string  `thrift:"jobId,1,required" json:"jobId"`
+	TaskId         string  `thrift:"taskId,2,required" json:"taskId"`
+	Requestor      *string `thrift:"requestor,3" json:"requestor,omitempty"`
+	Tag            *string `thrift:"tag,4" json:"tag,omitempty"`
+	InfraFailures  int32   `thrift:"infraFailures,5,required" json:"infraFailures"`
+	DeadLetteredMs int64   `thrift:"deadLetteredMs,6,required" json:"deadLetteredMs"`
+	Error          *string `thrift:"error,7" json:"error,omitempty"`
+}
+
+func NewDeadLetterTask() *DeadLetterTask {
+	return &DeadLetterTask{}
+}
+
+func (p *DeadLetterTask) GetJobId() string {
+	return p.JobId
+}
+
+func (p *DeadLetterTask) GetTaskId() string {
+	return p.TaskId
+}
+
+var DeadLetterTask_Requestor_DEFAULT string
+
+func (p *DeadLetterTask) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return DeadLetterTask_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var DeadLetterTask_Tag_DEFAULT string
+
+func (p *DeadLetterTask) GetTag() string {
+	if !p.IsSetTag() {
+		return DeadLetterTask_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+func (p *DeadLetterTask) GetInfraFailures() int32 {
+	return p.InfraFailures
+}
+
+func (p *DeadLetterTask) GetDeadLetteredMs() int64 {
+	return p.DeadLetteredMs
+}
+
+var DeadLetterTask_Error_DEFAULT string
+
+func (p *DeadLetterTask) GetError() string {
+	if !p.IsSetError() {
+		return DeadLetterTask_Error_DEFAULT
+	}
+	return *p.Error
+}
+func (p *DeadLetterTask) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *DeadLetterTask) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *DeadLetterTask) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *DeadLetterTask) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobId bool = false
+	var issetTaskId bool = false
+	var issetInfraFailures bool = false
+	var issetDeadLetteredMs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetJobId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetTaskId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetInfraFailures = true
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetDeadLetteredMs = true
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field JobId is not set"))
+	}
+	if !issetTaskId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TaskId is not set"))
+	}
+	if !issetInfraFailures {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field InfraFailures is not set"))
+	}
+	if !issetDeadLetteredMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field DeadLetteredMs is not set"))
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.TaskId = v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.InfraFailures = v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.DeadLetteredMs = v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeadLetterTask"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DeadLetterTask) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:taskId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.TaskId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.taskId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:taskId: ", p), err)
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("infraFailures", thrift.I32, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:infraFailures: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.InfraFailures)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.infraFailures (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:infraFailures: ", p), err)
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("deadLetteredMs", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:deadLetteredMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.DeadLetteredMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.deadLetteredMs (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:deadLetteredMs: ", p), err)
+	}
+	return err
+}
+
+func (p *DeadLetterTask) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DeadLetterTask) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeadLetterTask(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+//  - Requestor
+//  - Limit
+type DeadLetterQuery struct {
+	JobId     *string `thrift:"jobId,1" json:"jobId,omitempty"`
+	Requestor *string `thrift:"requestor,2" json:"requestor,omitempty"`
+	Limit     *int32  `thrift:"limit,3" json:"limit,omitempty"`
+}
+
+func NewDeadLetterQuery() *DeadLetterQuery {
+	return &DeadLetterQuery{}
+}
+
+var DeadLetterQuery_JobId_DEFAULT string
+
+func (p *DeadLetterQuery) GetJobId() string {
+	if !p.IsSetJobId() {
+		return DeadLetterQuery_JobId_DEFAULT
+	}
+	return *p.JobId
+}
+
+var DeadLetterQuery_Requestor_DEFAULT string
+
+func (p *DeadLetterQuery) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return DeadLetterQuery_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var DeadLetterQuery_Limit_DEFAULT int32
+
+func (p *DeadLetterQuery) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return DeadLetterQuery_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *DeadLetterQuery) IsSetJobId() bool {
+	return p.JobId != nil
 }
 
-func (p *OfflineWorkerReq) GetRequestor() string {
-	return p.Requestor
+func (p *DeadLetterQuery) IsSetRequestor() bool {
+	return p.Requestor != nil
 }
-func (p *OfflineWorkerReq) Read(iprot thrift.TProtocol) error {
+
+func (p *DeadLetterQuery) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *DeadLetterQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
-	var issetRequestor bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -2345,12 +8982,14 @@ func (p *OfflineWorkerReq) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-			issetRequestor = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2363,35 +9002,38 @@ func (p *OfflineWorkerReq) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
-	}
-	if !issetRequestor {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
-	}
 	return nil
 }
 
-func (p *OfflineWorkerReq) readField1(iprot thrift.TProtocol) error {
+func (p *DeadLetterQuery) readField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.ID = v
+		p.JobId = &v
 	}
 	return nil
 }
 
-func (p *OfflineWorkerReq) readField2(iprot thrift.TProtocol) error {
+func (p *DeadLetterQuery) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Requestor = v
+		p.Requestor = &v
 	}
 	return nil
 }
 
-func (p *OfflineWorkerReq) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("OfflineWorkerReq"); err != nil {
+func (p *DeadLetterQuery) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *DeadLetterQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeadLetterQuery"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2400,6 +9042,9 @@ func (p *OfflineWorkerReq) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2409,65 +9054,77 @@ func (p *OfflineWorkerReq) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *OfflineWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+func (p *DeadLetterQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobId() {
+		if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.JobId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *OfflineWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Requestor)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+func (p *DeadLetterQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+		}
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+	return err
+}
+
+func (p *DeadLetterQuery) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limit: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *OfflineWorkerReq) String() string {
+func (p *DeadLetterQuery) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("OfflineWorkerReq(%+v)", *p)
+	return fmt.Sprintf("DeadLetterQuery(%+v)", *p)
 }
 
 // Attributes:
-//  - ID
-//  - Requestor
-type ReinstateWorkerReq struct {
-	ID        string `thrift:"id,1,required" json:"id"`
-	Requestor string `thrift:"requestor,2,required" json:"requestor"`
-}
-
-func NewReinstateWorkerReq() *ReinstateWorkerReq {
-	return &ReinstateWorkerReq{}
+//  - Tasks
+type DeadLetterTasks struct {
+	Tasks []*DeadLetterTask `thrift:"tasks,1,required" json:"tasks"`
 }
 
-func (p *ReinstateWorkerReq) GetID() string {
-	return p.ID
+func NewDeadLetterTasks() *DeadLetterTasks {
+	return &DeadLetterTasks{}
 }
 
-func (p *ReinstateWorkerReq) GetRequestor() string {
-	return p.Requestor
+func (p *DeadLetterTasks) GetTasks() []*DeadLetterTask {
+	return p.Tasks
 }
-func (p *ReinstateWorkerReq) Read(iprot thrift.TProtocol) error {
+func (p *DeadLetterTasks) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
-	var issetRequestor bool = false
+	var issetTasks bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -2482,12 +9139,7 @@ func (p *ReinstateWorkerReq) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
-		case 2:
-			if err := p.readField2(iprot); err != nil {
-				return err
-			}
-			issetRequestor = true
+			issetTasks = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2500,43 +9152,39 @@ func (p *ReinstateWorkerReq) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
-	}
-	if !issetRequestor {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
 	}
 	return nil
 }
 
-func (p *ReinstateWorkerReq) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.ID = v
+func (p *DeadLetterTasks) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	return nil
-}
-
-func (p *ReinstateWorkerReq) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Requestor = v
+	tSlice := make([]*DeadLetterTask, 0, size)
+	p.Tasks = tSlice
+	for i := 0; i < size; i++ {
+		_elem28 := &DeadLetterTask{}
+		if err := _elem28.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem28), err)
+		}
+		p.Tasks = append(p.Tasks, _elem28)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *ReinstateWorkerReq) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ReinstateWorkerReq"); err != nil {
+func (p *DeadLetterTasks) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeadLetterTasks"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2546,65 +9194,67 @@ func (p *ReinstateWorkerReq) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *ReinstateWorkerReq) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+func (p *DeadLetterTasks) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	return err
-}
-
-func (p *ReinstateWorkerReq) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:requestor: ", p), err)
+	for _, v := range p.Tasks {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
 	}
-	if err := oprot.WriteString(string(p.Requestor)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.requestor (2) field write error: ", p), err)
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:requestor: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
 	}
 	return err
 }
 
-func (p *ReinstateWorkerReq) String() string {
+func (p *DeadLetterTasks) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ReinstateWorkerReq(%+v)", *p)
+	return fmt.Sprintf("DeadLetterTasks(%+v)", *p)
 }
 
 // Attributes:
-//  - CurrentTasks
-//  - MaxTasks
-type SchedulerStatus struct {
-	CurrentTasks int32 `thrift:"currentTasks,1,required" json:"currentTasks"`
-	MaxTasks     int32 `thrift:"maxTasks,2,required" json:"maxTasks"`
+//  - JobId
+//  - TaskId
+//  - Requestor
+type RequeueTaskReq struct {
+	JobId     string `thrift:"jobId,1,required" json:"jobId"`
+	TaskId    string `thrift:"taskId,2,required" json:"taskId"`
+	Requestor string `thrift:"requestor,3,required" json:"requestor"`
 }
 
-func NewSchedulerStatus() *SchedulerStatus {
-	return &SchedulerStatus{}
+func NewRequeueTaskReq() *RequeueTaskReq {
+	return &RequeueTaskReq{}
 }
 
-func (p *SchedulerStatus) GetCurrentTasks() int32 {
-	return p.CurrentTasks
+func (p *RequeueTaskReq) GetJobId() string {
+	return p.JobId
 }
 
-func (p *SchedulerStatus) GetMaxTasks() int32 {
-	return p.MaxTasks
+func (p *RequeueTaskReq) GetTaskId() string {
+	return p.TaskId
 }
-func (p *SchedulerStatus) Read(iprot thrift.TProtocol) error {
+
+func (p *RequeueTaskReq) GetRequestor() string {
+	return p.Requestor
+}
+func (p *RequeueTaskReq) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetCurrentTasks bool = false
-	var issetMaxTasks bool = false
+	var issetJobId bool = false
+	var issetTaskId bool = false
+	var issetRequestor bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -2619,12 +9269,17 @@ func (p *SchedulerStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetCurrentTasks = true
+			issetJobId = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-			issetMaxTasks = true
+			issetTaskId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetRequestor = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2637,35 +9292,47 @@ func (p *SchedulerStatus) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetCurrentTasks {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field CurrentTasks is not set"))
+	if !issetJobId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field JobId is not set"))
 	}
-	if !issetMaxTasks {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field MaxTasks is not set"))
+	if !issetTaskId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TaskId is not set"))
+	}
+	if !issetRequestor {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Requestor is not set"))
 	}
 	return nil
 }
 
-func (p *SchedulerStatus) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *RequeueTaskReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.CurrentTasks = v
+		p.JobId = v
 	}
 	return nil
 }
 
-func (p *SchedulerStatus) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *RequeueTaskReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.MaxTasks = v
+		p.TaskId = v
 	}
 	return nil
 }
 
-func (p *SchedulerStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("SchedulerStatus"); err != nil {
+func (p *RequeueTaskReq) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Requestor = v
+	}
+	return nil
+}
+
+func (p *RequeueTaskReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RequeueTaskReq"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2674,6 +9341,9 @@ func (p *SchedulerStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2683,35 +9353,48 @@ func (p *SchedulerStatus) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SchedulerStatus) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("currentTasks", thrift.I32, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:currentTasks: ", p), err)
+func (p *RequeueTaskReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.CurrentTasks)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.currentTasks (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:currentTasks: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
 	}
 	return err
 }
 
-func (p *SchedulerStatus) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("maxTasks", thrift.I32, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:maxTasks: ", p), err)
+func (p *RequeueTaskReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:taskId: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.MaxTasks)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.maxTasks (2) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.TaskId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.taskId (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:maxTasks: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:taskId: ", p), err)
 	}
 	return err
 }
 
-func (p *SchedulerStatus) String() string {
+func (p *RequeueTaskReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:requestor: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Requestor)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.requestor (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:requestor: ", p), err)
+	}
+	return err
+}
+
+func (p *RequeueTaskReq) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("SchedulerStatus(%+v)", *p)
+	return fmt.Sprintf("RequeueTaskReq(%+v)", *p)
 }
@@ -39,6 +39,23 @@ enum RunStatusState {
   BADREQUEST = 7   # Request rejected due to unexpected failure.
 }
 
+// Resources consumed by a run's command, as reported by wait4/rusage.
+struct ResourceUsage {
+  1: optional i64 maxRssBytes  # Peak resident set size.
+  2: optional i64 userTimeMs   # CPU time spent in user mode.
+  3: optional i64 systemTimeMs # CPU time spent in kernel mode.
+  4: optional i64 wallTimeMs   # Elapsed time from process start to exit.
+}
+
+// Why a run failed. The scheduler only retries INFRA failures.
+enum FailureKind {
+  UNCLASSIFIED = 0  # Not set by the worker, inferred from the run's status.
+  NONE = 1          # The run didn't fail.
+  COMMAND = 2       # The command ran and failed, ex: it exited nonzero or timed out.
+  INFRA = 3         # The worker or infrastructure failed. Retrying may succeed.
+  REQUEST = 4       # The request can't be run as given, ex: its inputs are missing.
+}
+
 // Note, each worker has its own runId space which is unrelated to any external ids.
 struct RunStatus {
   1: required RunStatusState status
@@ -52,6 +69,12 @@ struct RunStatus {
   9: optional string taskId
   10: optional string tag
   11: optional bazel.ActionResult bazelResult
+  12: optional ResourceUsage usage
+  13: optional string hookError     # Set if a worker pre-run or post-run hook failed.
+  14: optional string logRef        # Combined stdout/stderr persisted in the store, if enabled.
+  15: optional FailureKind failureKind  # Why the run failed, if the worker classified it.
+  16: optional string stdoutTail    # Last bytes of stdout, for triage. Full logs are at outUri or logRef.
+  17: optional string stderrTail    # Last bytes of stderr.
 }
 
 
@@ -60,12 +83,23 @@ struct Command {
   2: optional map<string, string> envVars
 }
 
+# CPU and memory a task needs. Tasks are only placed on workers reporting at least this much.
+struct Resources {
+  1: optional i64 milliCpus    # Thousandths of a CPU core, ex: 1500 for one and a half cores.
+  2: optional i64 memoryBytes
+}
+
 struct TaskDefinition {
   1: required Command command
   2: optional string snapshotId
   # TaskId should generally be unique, otherwise previous tasks with the same Requestor and Tag will be stomped.
   3: optional string taskId
   4: optional i32 timeoutMs
+  5: optional Resources resources
+  # Check out only the paths of the snapshot matching these git sparse-checkout patterns, gitignore style,
+  # ex: "/src/myservice/" for one subtree of a monorepo. Unset checks out the whole snapshot.
+  # Only git commit snapshots are checked out sparsely, other snapshots are always checked out in full.
+  6: optional list<string> sparsePatterns
 }
 
 struct JobDefinition {
@@ -83,6 +117,18 @@ struct JobDefinition {
   7: optional string requestor
   # JobType is used for stats and does not affect scheduling.
   8: optional string jobType
+  # Labels are key/value tags describing the job, ex: repo, branch, CI build url.
+  # They don't affect scheduling, jobs can be found by label with FindJobs.
+  9: optional map<string, string> labels
+  # Maximum wall clock time for the whole job, from when the scheduler accepts it. Once exceeded,
+  # unfinished tasks are aborted and the job is rolled back. Unset or <= 0 means no limit.
+  10: optional i64 timeoutMs
+  # How long the job's output snapshots and persisted logs are kept, ex: short for CI scratch
+  # runs and long for releases. Unset or <= 0 uses the worker's and store's defaults.
+  11: optional i64 retentionMs
+  # If true, the job's tasks are gang scheduled: tasks waiting to start are only started all at once,
+  # when there are enough free workers for all of them, ex: for test shards that coordinate at startup.
+  12: optional bool gang
 }
 
 struct JobId {
@@ -114,6 +160,60 @@ struct JobStatus {
   2: required Status status
   3: optional map<string, Status> taskStatus
   4: optional map<string, RunStatus> taskData
+  # Predicted milliseconds until an in progress job completes, based on previous runs of its commands.
+  # Unset if the scheduler can't predict it, ex: some command hasn't run before.
+  5: optional i64 etaMs
+  # Predicted milliseconds until each unfinished task completes, for tasks whose command has run before.
+  6: optional map<string, i64> taskEtaMs
+}
+
+# Finds recent jobs having all of the given labels, most recent first.
+struct JobQuery {
+  1: optional map<string, string> labels
+  # Maximum number of jobs returned, if unset or <= 0 a server default.
+  2: optional i32 limit
+}
+
+struct JobSummary {
+  1: required string id
+  2: required Status status
+  3: optional map<string, string> labels
+  4: optional string requestor
+  5: optional string tag
+  6: optional i64 createdMs   # Unix time the scheduler received the job
+}
+
+struct JobList {
+  1: required list<JobSummary> jobs
+}
+
+# A task output: a Bazel output file or directory, or a Bazel run's stdout or stderr.
+struct Artifact {
+  1: required string kind      # "file", "directory", "stdout" or "stderr"
+  2: optional string path      # Set for files and directories
+  3: required string digest    # "<hash>/<sizeBytes>"
+}
+
+struct TaskManifest {
+  1: required string taskId
+  2: required Status status
+  3: optional string snapshotId        # Input snapshot the task ran against
+  4: optional string commandDigest     # Bazel Action digest, or sha256 of the command's argv, env and timeout
+  5: optional string outputSnapshotId  # Snapshot of outputs, if the run produced one
+  6: optional list<Artifact> outputs
+  7: optional RunStatusState runStatus
+  8: optional i32 exitCode
+  9: optional string error
+}
+
+# Provenance of a job's tasks, read from the job's saga so it's as durable as the scheduler's SagaLog.
+struct JobManifest {
+  1: required string jobId
+  2: required Status status
+  3: optional string requestor
+  4: optional string tag
+  5: optional map<string, string> labels
+  6: required list<TaskManifest> tasks
 }
 
 struct OfflineWorkerReq {
@@ -126,21 +226,163 @@ struct ReinstateWorkerReq {
   2: required string requestor
 }
 
+# A cordoned worker stays in the cluster but isn't given new tasks, running tasks are left to finish.
+struct CordonWorkerReq {
+  1: required string id
+  2: required string requestor
+}
+
+# A paused job isn't given new tasks until it's resumed. Running tasks are left to finish unless
+# abortRunning is set, in which case they're aborted and rerun once the job is resumed.
+struct PauseJobReq {
+  1: required string id
+  2: required string requestor
+  3: optional bool abortRunning      # Ignored by ResumeJob
+}
+
+# Adds or removes a worker by hand, ahead of the cluster's discovery.
+struct ManualWorkerReq {
+  1: required string id
+  2: required string requestor
+  3: optional string adminToken  # Must match the scheduler's configured admin token
+}
+
+struct IdleWorker {
+  1: required string id
+  2: required i64 idleSinceMs  # Unix time the worker last finished a task or joined
+}
+
+struct IdleWorkers {
+  1: required list<IdleWorker> workers  # Longest idle first
+}
+
 struct SchedulerStatus {
   1: required i32 currentTasks
   2: required i32 maxTasks
 }
 
+# An administrative action taken through the API, ex: killing a job or taking a worker offline
+struct AuditEntry {
+  1: required i64 timeMs
+  2: required string actor             # Requestor given by the client, or "unknown"
+  3: required string action            # ex: kill_job, pause_job, offline_worker, cordon_worker, set_scheduler_status
+  4: optional string target            # Job or worker acted on
+  5: optional string details
+  6: optional string error             # Set if the action failed
+}
+
+# Zero values match everything
+struct AuditQuery {
+  1: optional string action
+  2: optional string actor
+  3: optional i64 sinceMs
+  4: optional i32 maxEntries           # Most recent entries returned, default 100
+}
+
+struct AuditLog {
+  1: required list<AuditEntry> entries  # Most recent first
+}
+
+# A reason a job would be rejected by RunJob
+struct ValidationError {
+  1: optional string taskId            # Set if the error is specific to one task
+  2: required string message
+}
+
+struct JobValidation {
+  1: required bool valid
+  2: required list<ValidationError> errors
+}
+
+# The outcome of an archived job's task
+struct ArchivedTask {
+  1: required string taskId
+  2: required i32 attempts             # Runs started, including retries
+  3: optional RunStatusState state     # State of the run whose result was kept, unset if none was
+  4: optional i32 exitCode
+  5: optional string error
+  6: optional string snapshotId
+}
+
+# The final status of a finished job, kept in the scheduler's job archive after its saga is gone
+struct ArchivedJob {
+  1: required string id
+  2: required Status status            # COMPLETED or ROLLED_BACK
+  3: optional string requestor
+  4: optional string jobType
+  5: optional string tag
+  6: optional map<string, string> labels
+  7: required i32 priority
+  8: required bool killed              # Killed by a user or for exceeding its timeout
+  9: required i64 createdMs
+  10: required i64 completedMs
+  11: required list<ArchivedTask> tasks
+}
+
+# Zero values match everything
+struct ArchiveQuery {
+  1: optional i64 sinceMs              # Jobs completed at or after sinceMs
+  2: optional i64 untilMs              # Jobs completed before untilMs
+  3: optional string tag
+  4: optional string requestor
+  5: optional i32 limit                # Most recently completed jobs returned, default 100
+}
+
+struct JobArchive {
+  1: required list<ArchivedJob> jobs   # Most recently completed first
+}
+
+# A task moved to the scheduler's dead letter queue after repeatedly failing with infrastructure errors
+struct DeadLetterTask {
+  1: required string jobId
+  2: required string taskId
+  3: optional string requestor         # Of the task's job
+  4: optional string tag
+  5: required i32 infraFailures
+  6: required i64 deadLetteredMs       # Unix time the task was dead lettered
+  7: optional string error             # Error of the run it was dead lettered after
+}
+
+# Zero values match everything
+struct DeadLetterQuery {
+  1: optional string jobId
+  2: optional string requestor         # Of the task's job
+  3: optional i32 limit                # Most recently dead lettered tasks returned, default 100
+}
+
+struct DeadLetterTasks {
+  1: required list<DeadLetterTask> tasks  # Most recently dead lettered first
+}
+
+struct RequeueTaskReq {
+  1: required string jobId
+  2: required string taskId
+  3: required string requestor
+}
+
 service CloudScoot {
    JobId RunJob(1: JobDefinition job) throws (
     1: InvalidRequest ir
     2: CanNotScheduleNow cnsn
   )
+  # Runs the same checks as RunJob without scheduling the job.
+  JobValidation ValidateJob(1: JobDefinition job) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
   JobStatus GetStatus(1: string jobId) throws (
     1: InvalidRequest ir
     2: ScootServerError err
   )
-  JobStatus KillJob(1: string jobId) throws (
+  JobStatus KillJob(1: string jobId, 2: string requestor) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  void PauseJob(1: PauseJobReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  void ResumeJob(1: PauseJobReq req) throws (
     1: InvalidRequest ir
     2: ScootServerError err
   )
@@ -152,10 +394,57 @@ service CloudScoot {
     1: InvalidRequest ir
     2: ScootServerError err
   )
+  void CordonWorker(1: CordonWorkerReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  void UncordonWorker(1: CordonWorkerReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  void AddWorker(1: ManualWorkerReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  void RemoveWorker(1: ManualWorkerReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
   SchedulerStatus GetSchedulerStatus() throws (
     1: ScootServerError err
   )
-  void SetSchedulerStatus(1: i32 maxTasks) throws (
+  void SetSchedulerStatus(1: i32 maxTasks, 2: string requestor) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  JobList FindJobs(1: JobQuery query) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  JobManifest GetJobManifest(1: string jobId) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  IdleWorkers GetIdleWorkers(1: i64 minIdleMs) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  AuditLog GetAuditLog(1: AuditQuery query) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  # Finished jobs from the scheduler's job archive, if it keeps one.
+  JobArchive QueryJobArchive(1: ArchiveQuery query) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  # Tasks in the scheduler's dead letter queue, if it keeps one.
+  DeadLetterTasks GetDeadLetterTasks(1: DeadLetterQuery query) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  # Removes a task from the dead letter queue and runs it again in its job, returning the job's id.
+  JobId RequeueDeadLetterTask(1: RequeueTaskReq req) throws (
     1: InvalidRequest ir
     2: ScootServerError err
   )
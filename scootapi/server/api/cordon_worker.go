@@ -0,0 +1,25 @@
+package api
+
+import (
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the CordonWorker and UncordonWorker APIs.
+func CordonWorker(req *scoot.CordonWorkerReq, cordoned bool, s scheduler.Scheduler) error {
+	cordoner, ok := s.(scheduler.Cordoner)
+	if !ok {
+		msg := "Scheduler doesn't support cordoning workers"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	if req == nil || req.GetID() == "" {
+		msg := "A worker id must be provided"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	return cordoner.CordonWorker(sched.CordonWorkerReq{
+		ID:        req.GetID(),
+		Requestor: req.GetRequestor(),
+		Cordoned:  cordoned,
+	})
+}
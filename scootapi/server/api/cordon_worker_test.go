@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler that records cordon requests.
+type cordonScheduler struct {
+	*scheduler.MockScheduler
+	reqs []sched.CordonWorkerReq
+}
+
+func (s *cordonScheduler) CordonWorker(req sched.CordonWorkerReq) error {
+	s.reqs = append(s.reqs, req)
+	return nil
+}
+
+func Test_CordonWorker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &cordonScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl)}
+
+	req := &scoot.CordonWorkerReq{ID: "node1", Requestor: "admin"}
+	if err := CordonWorker(req, true, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := CordonWorker(req, false, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []sched.CordonWorkerReq{
+		{ID: "node1", Requestor: "admin", Cordoned: true},
+		{ID: "node1", Requestor: "admin", Cordoned: false},
+	}
+	if len(s.reqs) != 2 || s.reqs[0] != expected[0] || s.reqs[1] != expected[1] {
+		t.Fatalf("Expected %v, got %v", expected, s.reqs)
+	}
+
+	if err := CordonWorker(&scoot.CordonWorkerReq{}, true, s); err == nil {
+		t.Fatal("Expected a request without a worker id to be rejected")
+	}
+	if err := CordonWorker(req, true, s.MockScheduler); err == nil {
+		t.Fatal("Expected a scheduler that doesn't support cordoning to be rejected")
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the GetDeadLetterTasks API. Lists dead lettered tasks matching query, most recently dead lettered first.
+func GetDeadLetterTasks(query *scoot.DeadLetterQuery, s scheduler.Scheduler) (*scoot.DeadLetterTasks, error) {
+	dlq, ok := s.(scheduler.DeadLetterQueue)
+	if !ok {
+		msg := "Scheduler doesn't support dead lettering tasks"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if query == nil {
+		query = &scoot.DeadLetterQuery{}
+	}
+	if query.GetLimit() < 0 {
+		msg := "limit must not be negative"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+
+	result := &scoot.DeadLetterTasks{Tasks: []*scoot.DeadLetterTask{}}
+	for _, dl := range dlq.GetDeadLetters(scheduler.DeadLetterQuery{
+		JobID:     query.GetJobId(),
+		Requestor: query.GetRequestor(),
+		Limit:     int(query.GetLimit()),
+	}) {
+		result.Tasks = append(result.Tasks, deadLetterToThrift(dl))
+	}
+	return result, nil
+}
+
+func deadLetterToThrift(dl scheduler.DeadLetter) *scoot.DeadLetterTask {
+	t := &scoot.DeadLetterTask{
+		JobId:          dl.JobID,
+		TaskId:         dl.TaskID,
+		InfraFailures:  int32(dl.InfraFailures),
+		DeadLetteredMs: timeToMs(dl.Time),
+	}
+	if dl.Job.Requestor != "" {
+		t.Requestor = &dl.Job.Requestor
+	}
+	if dl.Job.Tag != "" {
+		t.Tag = &dl.Job.Tag
+	}
+	if dl.Status.Error != "" {
+		t.Error = &dl.Status.Error
+	}
+	return t
+}
+
+// Implementation of the RequeueDeadLetterTask API. Returns the id of the job the task is requeued in.
+func RequeueDeadLetterTask(req *scoot.RequeueTaskReq, s scheduler.Scheduler) (*scoot.JobId, error) {
+	dlq, ok := s.(scheduler.DeadLetterQueue)
+	if !ok {
+		msg := "Scheduler doesn't support dead lettering tasks"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if req == nil || req.GetJobId() == "" || req.GetTaskId() == "" {
+		msg := "A job id and task id must be provided"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	id, err := dlq.RequeueDeadLetter(sched.RequeueTaskReq{
+		JobID:     req.GetJobId(),
+		TaskID:    req.GetTaskId(),
+		Requestor: req.GetRequestor(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scoot.JobId{ID: id}, nil
+}
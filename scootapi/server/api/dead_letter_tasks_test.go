@@ -0,0 +1,99 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler with a fixed list of dead lettered tasks that records requeue requests.
+type deadLetterScheduler struct {
+	*scheduler.MockScheduler
+	tasks []scheduler.DeadLetter
+	query scheduler.DeadLetterQuery
+	reqs  []sched.RequeueTaskReq
+}
+
+func (s *deadLetterScheduler) GetDeadLetters(q scheduler.DeadLetterQuery) []scheduler.DeadLetter {
+	s.query = q
+	return s.tasks
+}
+
+func (s *deadLetterScheduler) RequeueDeadLetter(req sched.RequeueTaskReq) (string, error) {
+	s.reqs = append(s.reqs, req)
+	return "job3", nil
+}
+
+func Test_GetDeadLetterTasks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	now := time.Now().Truncate(time.Millisecond)
+	s := &deadLetterScheduler{
+		MockScheduler: scheduler.NewMockScheduler(mockCtrl),
+		tasks: []scheduler.DeadLetter{
+			{JobID: "job2", TaskID: "task1", Job: sched.JobDefinition{Requestor: "someone", Tag: "a"},
+				InfraFailures: 3, Status: runner.RunStatus{Error: "lost worker"}, Time: now},
+			{JobID: "job1", TaskID: "task2", InfraFailures: 1, Time: now.Add(-time.Minute)},
+		},
+	}
+
+	requestor := "someone"
+	limit := int32(5)
+	result, err := GetDeadLetterTasks(&scoot.DeadLetterQuery{Requestor: &requestor, Limit: &limit}, s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.query != (scheduler.DeadLetterQuery{Requestor: "someone", Limit: 5}) {
+		t.Errorf("Unexpected query: %+v", s.query)
+	}
+	if len(result.Tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %v", result.Tasks)
+	}
+	task := result.Tasks[0]
+	if task.JobId != "job2" || task.TaskId != "task1" || task.GetRequestor() != "someone" || task.GetTag() != "a" ||
+		task.InfraFailures != 3 || task.DeadLetteredMs != timeToMs(now) || task.GetError() != "lost worker" {
+		t.Errorf("Unexpected task: %v", task)
+	}
+	if task := result.Tasks[1]; task.IsSetRequestor() || task.IsSetTag() || task.IsSetError() {
+		t.Errorf("Expected unset fields for empty values, got %v", task)
+	}
+
+	limit = -1
+	if _, err := GetDeadLetterTasks(&scoot.DeadLetterQuery{Limit: &limit}, s); err == nil {
+		t.Error("Expected a negative limit to be rejected")
+	}
+	if _, err := GetDeadLetterTasks(nil, s.MockScheduler); err == nil {
+		t.Error("Expected a scheduler that doesn't dead letter tasks to be rejected")
+	}
+}
+
+func Test_RequeueDeadLetterTask(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &deadLetterScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl)}
+
+	id, err := RequeueDeadLetterTask(&scoot.RequeueTaskReq{JobId: "job1", TaskId: "task1", Requestor: "admin"}, s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id.ID != "job3" {
+		t.Errorf("Expected job3, got %v", id.ID)
+	}
+	expected := sched.RequeueTaskReq{JobID: "job1", TaskID: "task1", Requestor: "admin"}
+	if len(s.reqs) != 1 || s.reqs[0] != expected {
+		t.Fatalf("Expected %v, got %v", expected, s.reqs)
+	}
+
+	if _, err := RequeueDeadLetterTask(&scoot.RequeueTaskReq{JobId: "job1"}, s); err == nil {
+		t.Error("Expected a request without a task id to be rejected")
+	}
+	if _, err := RequeueDeadLetterTask(&scoot.RequeueTaskReq{JobId: "job1", TaskId: "task1"}, s.MockScheduler); err == nil {
+		t.Error("Expected a scheduler that doesn't dead letter tasks to be rejected")
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the FindJobs API. Finds recent jobs by label, with their current status.
+func FindJobs(query *scoot.JobQuery, s scheduler.Scheduler, sc saga.SagaCoordinator) (*scoot.JobList, error) {
+	finder, ok := s.(scheduler.JobFinder)
+	if !ok {
+		msg := "Scheduler doesn't support finding jobs"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if query == nil {
+		msg := "nil job query"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if err := sched.ValidateLabels(query.Labels); err != nil {
+		msg := err.Error()
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+
+	list := &scoot.JobList{Jobs: []*scoot.JobSummary{}}
+	for _, j := range finder.FindJobs(query.Labels, int(query.GetLimit())) {
+		js, err := GetJobStatus(j.ID, sc)
+		if err != nil {
+			// Still report the job, its status can be retried with GetStatus.
+			log.Errorf("Failed to get status of job %s: %v", j.ID, err)
+		}
+		requestor := j.Requestor
+		tag := j.Tag
+		createdMs := j.Created.UnixNano() / int64(time.Millisecond)
+		list.Jobs = append(list.Jobs, &scoot.JobSummary{
+			ID:        j.ID,
+			Status:    js.Status,
+			Labels:    j.Labels,
+			Requestor: &requestor,
+			Tag:       &tag,
+			CreatedMs: &createdMs,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler that finds a fixed list of jobs.
+type findingScheduler struct {
+	*scheduler.MockScheduler
+	jobs []scheduler.JobSummary
+}
+
+func (s *findingScheduler) FindJobs(labels map[string]string, limit int) []scheduler.JobSummary {
+	return s.jobs
+}
+
+func (s *findingScheduler) FindJob(id string) (scheduler.JobSummary, bool) {
+	for _, j := range s.jobs {
+		if j.ID == id {
+			return j, true
+		}
+	}
+	return scheduler.JobSummary{}, false
+}
+
+func Test_FindJobs(t *testing.T) {
+	sc := makeMockSagaCoordinator(t)
+	defer mockCtrl.Finish()
+	s := &findingScheduler{
+		MockScheduler: scheduler.NewMockScheduler(mockCtrl),
+		jobs:          []scheduler.JobSummary{{ID: "1", Requestor: "ci", Labels: map[string]string{"pr": "1234"}}},
+	}
+
+	list, err := FindJobs(&scoot.JobQuery{Labels: map[string]string{"pr": "1234"}}, s, sc)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list.Jobs) != 1 || list.Jobs[0].ID != "1" || list.Jobs[0].Status != scoot.Status_COMPLETED ||
+		list.Jobs[0].GetRequestor() != "ci" || list.Jobs[0].Labels["pr"] != "1234" {
+		t.Fatalf("Unexpected jobs: %v", list.Jobs)
+	}
+
+	if _, err := FindJobs(&scoot.JobQuery{Labels: map[string]string{"": "x"}}, s, sc); err == nil {
+		t.Fatal("Expected invalid label to be rejected")
+	}
+	if _, err := FindJobs(&scoot.JobQuery{}, s.MockScheduler, sc); err == nil {
+		t.Fatal("Expected error from scheduler that can't find jobs")
+	}
+}
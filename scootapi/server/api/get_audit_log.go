@@ -0,0 +1,54 @@
+package api
+
+import (
+	"time"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/audit"
+)
+
+// Implementation of the GetAuditLog API. Lists administrative actions matching query, most recent first.
+func GetAuditLog(query *scoot.AuditQuery, l audit.Log) (*scoot.AuditLog, error) {
+	if query == nil {
+		query = &scoot.AuditQuery{}
+	}
+	if query.GetMaxEntries() < 0 {
+		msg := "maxEntries must not be negative"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	q := audit.Query{
+		Action:     query.GetAction(),
+		Actor:      query.GetActor(),
+		MaxEntries: int(query.GetMaxEntries()),
+	}
+	if query.IsSetSinceMs() {
+		q.Since = time.Unix(0, query.GetSinceMs()*int64(time.Millisecond))
+	}
+
+	entries, err := l.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	result := &scoot.AuditLog{Entries: []*scoot.AuditEntry{}}
+	for _, e := range entries {
+		entry := &scoot.AuditEntry{
+			TimeMs: e.Time.UnixNano() / int64(time.Millisecond),
+			Actor:  e.Actor,
+			Action: e.Action,
+		}
+		if e.Target != "" {
+			target := e.Target
+			entry.Target = &target
+		}
+		if e.Details != "" {
+			details := e.Details
+			entry.Details = &details
+		}
+		if e.Error != "" {
+			errStr := e.Error
+			entry.Error = &errStr
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	return result, nil
+}
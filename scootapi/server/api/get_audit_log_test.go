@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/audit"
+)
+
+func Test_GetAuditLog(t *testing.T) {
+	l := audit.NewMemoryLog()
+	audit.Record(l, "alice", audit.KillJob, "job1", "", nil)
+	audit.Record(l, "bob", audit.SetSchedulerStatus, "", "maxTasks=10", nil)
+
+	result, err := GetAuditLog(nil, l)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Entries) != 2 || result.Entries[0].Actor != "bob" || result.Entries[0].GetDetails() != "maxTasks=10" ||
+		result.Entries[1].GetTarget() != "job1" || result.Entries[1].IsSetDetails() {
+		t.Errorf("Unexpected entries: %v", result.Entries)
+	}
+
+	action := audit.KillJob
+	if result, _ := GetAuditLog(&scoot.AuditQuery{Action: &action}, l); len(result.Entries) != 1 {
+		t.Errorf("Expected one kill_job entry, got %v", result.Entries)
+	}
+
+	max := int32(-1)
+	if _, err := GetAuditLog(&scoot.AuditQuery{MaxEntries: &max}, l); err == nil {
+		t.Error("Expected an error for negative maxEntries")
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the GetIdleWorkers API. Lists workers idle for at least minIdleMs, longest idle first.
+func GetIdleWorkers(minIdleMs int64, s scheduler.Scheduler) (*scoot.IdleWorkers, error) {
+	reporter, ok := s.(scheduler.IdleReporter)
+	if !ok {
+		msg := "Scheduler doesn't support reporting idle workers"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if minIdleMs < 0 {
+		msg := "minIdleMs must not be negative"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+
+	workers := &scoot.IdleWorkers{Workers: []*scoot.IdleWorker{}}
+	for _, w := range reporter.GetIdleWorkers(time.Duration(minIdleMs) * time.Millisecond) {
+		workers.Workers = append(workers.Workers, &scoot.IdleWorker{
+			ID:          string(w.Id),
+			IdleSinceMs: w.IdleSince.UnixNano() / int64(time.Millisecond),
+		})
+	}
+	return workers, nil
+}
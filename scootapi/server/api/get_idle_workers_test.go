@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/sched/scheduler"
+)
+
+// A MockScheduler that reports a fixed list of idle workers.
+type idleScheduler struct {
+	*scheduler.MockScheduler
+	workers []scheduler.IdleWorker
+	minIdle time.Duration
+}
+
+func (s *idleScheduler) GetIdleWorkers(minIdle time.Duration) []scheduler.IdleWorker {
+	s.minIdle = minIdle
+	return s.workers
+}
+
+func Test_GetIdleWorkers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	since := time.Unix(1500000000, 0)
+	s := &idleScheduler{
+		MockScheduler: scheduler.NewMockScheduler(mockCtrl),
+		workers:       []scheduler.IdleWorker{{Id: "node1", IdleSince: since}},
+	}
+
+	workers, err := GetIdleWorkers(60000, s)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if s.minIdle != time.Minute {
+		t.Fatalf("Expected minIdle of a minute, got %v", s.minIdle)
+	}
+	if len(workers.Workers) != 1 || workers.Workers[0].ID != "node1" || workers.Workers[0].IdleSinceMs != 1500000000000 {
+		t.Fatalf("Unexpected workers: %v", workers.Workers)
+	}
+
+	if _, err := GetIdleWorkers(-1, s); err == nil {
+		t.Fatal("Expected negative minIdleMs to be rejected")
+	}
+	if _, err := GetIdleWorkers(0, s.MockScheduler); err == nil {
+		t.Fatal("Expected a scheduler that doesn't track idle workers to be rejected")
+	}
+}
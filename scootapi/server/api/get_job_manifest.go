@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/execution/bazelapi"
+	s "github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Artifact kinds reported in task manifests
+const (
+	ArtifactFile      = "file"
+	ArtifactDirectory = "directory"
+	ArtifactStdout    = "stdout"
+	ArtifactStderr    = "stderr"
+)
+
+// Implementation of the GetJobManifest API. Builds the manifest from the job's saga, which records
+// the job definition when the job starts and each task's run status when the task ends.
+func GetJobManifest(jobId string, sc s.SagaCoordinator) (*scoot.JobManifest, error) {
+	state, err := sc.GetSagaState(jobId)
+	if err != nil {
+		switch err.(type) {
+		case s.InvalidRequestError:
+			err = scoot.NewInvalidRequest()
+		case s.InternalLogError:
+			err = scoot.NewScootServerError()
+		}
+		return nil, err
+	}
+	if state == nil {
+		msg := fmt.Sprintf("Job %s not found", jobId)
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	job, err := sched.DeserializeJob(state.Job())
+	if err != nil {
+		return nil, scoot.NewScootServerError()
+	}
+
+	js := convertSagaStateToJobStatus(state)
+	m := &scoot.JobManifest{
+		JobId:     jobId,
+		Status:    js.Status,
+		Requestor: &job.Def.Requestor,
+		Tag:       &job.Def.Tag,
+		Labels:    job.Def.Labels,
+		Tasks:     []*scoot.TaskManifest{},
+	}
+	for _, t := range job.Def.Tasks {
+		snapshotId := t.SnapshotID
+		digest := sched.CommandDigest(t)
+		tm := &scoot.TaskManifest{
+			TaskId:        t.TaskID,
+			Status:        js.TaskStatus[t.TaskID],
+			SnapshotId:    &snapshotId,
+			CommandDigest: &digest,
+		}
+		// Only the final run status of a completed task is reported, earlier statuses may have no outputs yet.
+		if rs := js.TaskData[t.TaskID]; rs != nil && tm.Status == scoot.Status_COMPLETED {
+			runStatus := rs.Status
+			tm.RunStatus = &runStatus
+			tm.ExitCode = rs.ExitCode
+			tm.Error = rs.Error
+			tm.OutputSnapshotId = rs.SnapshotId
+			tm.Outputs = bazelArtifacts(bazelapi.MakeActionResultDomainFromThrift(rs.BazelResult_))
+		}
+		m.Tasks = append(m.Tasks, tm)
+	}
+	return m, nil
+}
+
+func bazelArtifacts(ar *bazelapi.ActionResult) []*scoot.Artifact {
+	if ar == nil || ar.Result == nil {
+		return nil
+	}
+	artifacts := []*scoot.Artifact{}
+	add := func(kind, path, digest string) {
+		if digest == "" {
+			return
+		}
+		a := &scoot.Artifact{Kind: kind, Digest: digest}
+		if path != "" {
+			a.Path = &path
+		}
+		artifacts = append(artifacts, a)
+	}
+	for _, f := range ar.Result.GetOutputFiles() {
+		add(ArtifactFile, f.GetPath(), bazel.DigestToStr(f.GetDigest()))
+	}
+	for _, d := range ar.Result.GetOutputDirectories() {
+		add(ArtifactDirectory, d.GetPath(), bazel.DigestToStr(d.GetTreeDigest()))
+	}
+	add(ArtifactStdout, "", bazel.DigestToStr(ar.Result.GetStdoutDigest()))
+	add(ArtifactStderr, "", bazel.DigestToStr(ar.Result.GetStderrDigest()))
+	return artifacts
+}
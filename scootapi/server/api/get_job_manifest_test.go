@@ -0,0 +1,98 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/bazel/execution/bazelapi"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/workerapi"
+)
+
+func Test_GetJobManifest(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+
+	actionDigest := &remoteexecution.Digest{Hash: "abc", SizeBytes: 10}
+	scootTask := sched.TaskDefinition{}
+	scootTask.TaskID = "scoot"
+	scootTask.SnapshotID = "snap-in"
+	scootTask.Argv = []string{"make", "test"}
+	bazelTask := sched.TaskDefinition{}
+	bazelTask.TaskID = "bazel"
+	bazelTask.Argv = []string{"bazel"}
+	bazelTask.ExecuteRequest = &bazelapi.ExecuteRequest{
+		Request: &remoteexecution.ExecuteRequest{ActionDigest: actionDigest},
+	}
+	job := &sched.Job{Id: "job1", Def: sched.JobDefinition{
+		Requestor: "ci",
+		Labels:    map[string]string{"pr": "1234"},
+		Tasks:     []sched.TaskDefinition{scootTask, bazelTask, {}},
+	}}
+	job.Def.Tasks[2].TaskID = "pending"
+	asBytes, err := job.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg, err := sc.MakeSaga(job.Id, asBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endTask := func(id string, st runner.RunStatus) {
+		data, err := workerapi.SerializeProcessStatus(st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sg.StartTask(id, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := sg.EndTask(id, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	endTask("scoot", runner.RunStatus{State: runner.COMPLETE, SnapshotID: "snap-out", ExitCode: 1})
+	endTask("bazel", runner.RunStatus{State: runner.COMPLETE, ActionResult: &bazelapi.ActionResult{
+		Result: &remoteexecution.ActionResult{
+			OutputFiles:  []*remoteexecution.OutputFile{{Path: "out/lib.a", Digest: &remoteexecution.Digest{Hash: "def", SizeBytes: 3}}},
+			StdoutDigest: &remoteexecution.Digest{Hash: "123", SizeBytes: 4},
+		},
+		ActionDigest: actionDigest,
+	}})
+
+	m, err := GetJobManifest("job1", sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Status != scoot.Status_IN_PROGRESS || m.GetRequestor() != "ci" || m.Labels["pr"] != "1234" || len(m.Tasks) != 3 {
+		t.Fatalf("Unexpected manifest: %v", m)
+	}
+
+	st := m.Tasks[0]
+	if st.Status != scoot.Status_COMPLETED || st.GetSnapshotId() != "snap-in" || st.GetOutputSnapshotId() != "snap-out" ||
+		st.GetExitCode() != 1 || st.GetRunStatus() != scoot.RunStatusState_COMPLETE || st.GetCommandDigest() != sched.CommandDigest(scootTask) {
+		t.Fatalf("Unexpected scoot task manifest: %v", st)
+	}
+	bt := m.Tasks[1]
+	if bt.GetCommandDigest() != "abc/10" || len(bt.Outputs) != 2 ||
+		bt.Outputs[0].Kind != ArtifactFile || bt.Outputs[0].GetPath() != "out/lib.a" || bt.Outputs[0].Digest != "def/3" ||
+		bt.Outputs[1].Kind != ArtifactStdout || bt.Outputs[1].Digest != "123/4" {
+		t.Fatalf("Unexpected bazel task manifest: %v", bt)
+	}
+	if pt := m.Tasks[2]; pt.Status != scoot.Status_NOT_STARTED || pt.RunStatus != nil {
+		t.Fatalf("Unexpected pending task manifest: %v", pt)
+	}
+
+	// Commands differing only in env have different digests.
+	other := scootTask
+	other.EnvVars = map[string]string{"A": "1"}
+	if sched.CommandDigest(other) == sched.CommandDigest(scootTask) {
+		t.Fatal("Expected env to change the command digest")
+	}
+
+	if _, err := GetJobManifest("missing", sc); err == nil {
+		t.Fatal("Expected error for missing job")
+	}
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"time"
+
 	"github.com/twitter/scoot/common/thrifthelpers"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
@@ -40,6 +43,37 @@ func GetJobStatus(jobId string, sc s.SagaCoordinator) (*scoot.JobStatus, error)
 	return convertSagaStateToJobStatus(state), nil
 }
 
+// Sets the predicted time remaining for an in progress job and its unfinished tasks,
+// if the scheduler can predict them.
+func AddJobETA(js *scoot.JobStatus, sc scheduler.Scheduler) {
+	reporter, ok := sc.(scheduler.ETAReporter)
+	if !ok || js.Status != scoot.Status_IN_PROGRESS {
+		return
+	}
+	eta, ok := reporter.GetJobETA(js.ID)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if eta.Known {
+		etaMs := remainingMs(eta.Done, now)
+		js.EtaMs = &etaMs
+	}
+	if len(eta.Tasks) > 0 {
+		js.TaskEtaMs = make(map[string]int64)
+		for id, done := range eta.Tasks {
+			js.TaskEtaMs[id] = remainingMs(done, now)
+		}
+	}
+}
+
+func remainingMs(t, now time.Time) int64 {
+	if t.Before(now) {
+		return 0
+	}
+	return int64(t.Sub(now) / time.Millisecond)
+}
+
 // Converts a SagaState to a corresponding JobStatus
 func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 
@@ -66,6 +100,12 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 			} else if sagaState.IsTaskStarted(id) {
 				taskStatus = scoot.Status_ROLLING_BACK
 			}
+			// Keep the results of tasks that finished before the rollback, ex: why a timed out job's tasks were aborted.
+			if sagaState.IsTaskCompleted(id) {
+				if thriftJobStatus, err := workerRunStatusToScootRunStatus(sagaState.GetEndTaskData(id)); err == nil {
+					js.TaskData[id] = thriftJobStatus
+				}
+			}
 		} else {
 			if sagaState.IsTaskCompleted(id) {
 				taskStatus = scoot.Status_COMPLETED
@@ -114,8 +154,11 @@ func workerRunStatusToScootRunStatus(resultsFromSaga []byte) (*scoot.RunStatus,
 		return nil, nil
 	}
 
+	// Task data that isn't a run status, ex: the StartTask data recorded for the dead letter queue, is skipped.
 	workerRunStatus := worker.RunStatus{}
-	thrifthelpers.JsonDeserialize(&workerRunStatus, resultsFromSaga)
+	if err := thrifthelpers.JsonDeserialize(&workerRunStatus, resultsFromSaga); err != nil {
+		return nil, err
+	}
 
 	status, err := scoot.RunStatusStateFromString(workerRunStatus.Status.String())
 	if err != nil {
@@ -131,6 +174,22 @@ func workerRunStatusToScootRunStatus(resultsFromSaga []byte) (*scoot.RunStatus,
 		Error:        workerRunStatus.Error,
 		SnapshotId:   workerRunStatus.SnapshotId,
 		BazelResult_: workerRunStatus.BazelResult_,
+		HookError:    workerRunStatus.HookError,
+		LogRef:       workerRunStatus.LogRef,
+		StdoutTail:   workerRunStatus.StdoutTail,
+		StderrTail:   workerRunStatus.StderrTail,
+	}
+	if workerRunStatus.FailureKind != nil {
+		kind := scoot.FailureKind(*workerRunStatus.FailureKind)
+		scootRunStatus.FailureKind = &kind
+	}
+	if workerRunStatus.Usage != nil {
+		scootRunStatus.Usage = &scoot.ResourceUsage{
+			MaxRssBytes:  workerRunStatus.Usage.MaxRssBytes,
+			UserTimeMs:   workerRunStatus.Usage.UserTimeMs,
+			SystemTimeMs: workerRunStatus.Usage.SystemTimeMs,
+			WallTimeMs:   workerRunStatus.Usage.WallTimeMs,
+		}
 	}
 
 	return &scootRunStatus, nil
@@ -3,6 +3,7 @@ package api
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -10,6 +11,7 @@ import (
 	"github.com/twitter/scoot/runner"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
@@ -231,3 +233,55 @@ func TestRunStatusRoundTrip(t *testing.T) {
 		t.Fatalf("runStatus.OutUri: %v (expected %v)", *runStatus.OutUri, stdoutRef)
 	}
 }
+
+// A MockScheduler that reports a fixed ETA for job1.
+type etaScheduler struct {
+	*scheduler.MockScheduler
+	eta scheduler.JobETA
+}
+
+func (s *etaScheduler) GetJobETA(jobId string) (scheduler.JobETA, bool) {
+	return s.eta, jobId == "job1"
+}
+
+func Test_AddJobETA(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	now := time.Now()
+	sc := &etaScheduler{
+		MockScheduler: scheduler.NewMockScheduler(mockCtrl),
+		eta: scheduler.JobETA{
+			Done:  now.Add(time.Hour),
+			Known: true,
+			Tasks: map[string]time.Time{"task1": now.Add(time.Hour), "task2": now.Add(-time.Minute)},
+		},
+	}
+
+	js := &scoot.JobStatus{ID: "job1", Status: scoot.Status_IN_PROGRESS}
+	AddJobETA(js, sc)
+	if js.GetEtaMs() <= 59*60*1000 || js.GetEtaMs() > 60*60*1000 {
+		t.Fatalf("Expected job ETA of about an hour, got %dms", js.GetEtaMs())
+	}
+	if len(js.TaskEtaMs) != 2 || js.TaskEtaMs["task1"] != js.GetEtaMs() || js.TaskEtaMs["task2"] != 0 {
+		t.Fatalf("Unexpected task ETAs: %v", js.TaskEtaMs)
+	}
+
+	// Unpredictable, completed and unknown jobs have no ETA.
+	sc.eta.Known = false
+	sc.eta.Tasks = nil
+	js = &scoot.JobStatus{ID: "job1", Status: scoot.Status_IN_PROGRESS}
+	AddJobETA(js, sc)
+	if js.EtaMs != nil || js.TaskEtaMs != nil {
+		t.Fatalf("Expected no ETA for an unpredictable job, got %v", js)
+	}
+	js = &scoot.JobStatus{ID: "job1", Status: scoot.Status_COMPLETED}
+	AddJobETA(js, sc)
+	if js.EtaMs != nil {
+		t.Fatalf("Expected no ETA for a completed job")
+	}
+	js = &scoot.JobStatus{ID: "job2", Status: scoot.Status_IN_PROGRESS}
+	AddJobETA(js, sc.MockScheduler)
+	if js.EtaMs != nil {
+		t.Fatalf("Expected no ETA from a scheduler that can't predict")
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the AddWorker and RemoveWorker APIs.
+func ManageWorker(req *scoot.ManualWorkerReq, add bool, s scheduler.Scheduler) error {
+	manager, ok := s.(scheduler.WorkerManager)
+	if !ok {
+		msg := "Scheduler doesn't support adding or removing workers"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	if req == nil || req.GetID() == "" {
+		msg := "A worker id must be provided"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	r := sched.ManualWorkerReq{ID: req.GetID(), Requestor: req.GetRequestor(), AdminToken: req.GetAdminToken()}
+	if add {
+		return manager.AddWorker(r)
+	}
+	return manager.RemoveWorker(r)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler that records add and remove worker requests.
+type workerManagerScheduler struct {
+	*scheduler.MockScheduler
+	added   []sched.ManualWorkerReq
+	removed []sched.ManualWorkerReq
+}
+
+func (s *workerManagerScheduler) AddWorker(req sched.ManualWorkerReq) error {
+	s.added = append(s.added, req)
+	return nil
+}
+
+func (s *workerManagerScheduler) RemoveWorker(req sched.ManualWorkerReq) error {
+	s.removed = append(s.removed, req)
+	return nil
+}
+
+func Test_ManageWorker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &workerManagerScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl)}
+
+	token := "secret"
+	req := &scoot.ManualWorkerReq{ID: "host1:1234", Requestor: "admin", AdminToken: &token}
+	if err := ManageWorker(req, true, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := ManageWorker(req, false, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := sched.ManualWorkerReq{ID: "host1:1234", Requestor: "admin", AdminToken: "secret"}
+	if len(s.added) != 1 || s.added[0] != expected || len(s.removed) != 1 || s.removed[0] != expected {
+		t.Fatalf("Expected %v to be added and removed, got %v and %v", expected, s.added, s.removed)
+	}
+
+	if err := ManageWorker(&scoot.ManualWorkerReq{}, true, s); err == nil {
+		t.Fatal("Expected a request without a worker id to be rejected")
+	}
+	if err := ManageWorker(req, true, s.MockScheduler); err == nil {
+		t.Fatal("Expected a scheduler that doesn't support managing workers to be rejected")
+	}
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the PauseJob and ResumeJob APIs.
+func PauseJob(req *scoot.PauseJobReq, paused bool, s scheduler.Scheduler) error {
+	pauser, ok := s.(scheduler.Pauser)
+	if !ok {
+		msg := "Scheduler doesn't support pausing jobs"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	if req == nil || req.GetID() == "" {
+		msg := "A job id must be provided"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	return pauser.PauseJob(sched.PauseJobReq{
+		JobID:        req.GetID(),
+		Requestor:    req.GetRequestor(),
+		Paused:       paused,
+		AbortRunning: paused && req.GetAbortRunning(),
+	})
+}
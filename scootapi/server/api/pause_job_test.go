@@ -0,0 +1,51 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler that records pause requests.
+type pauseScheduler struct {
+	*scheduler.MockScheduler
+	reqs []sched.PauseJobReq
+}
+
+func (s *pauseScheduler) PauseJob(req sched.PauseJobReq) error {
+	s.reqs = append(s.reqs, req)
+	return nil
+}
+
+func Test_PauseJob(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &pauseScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl)}
+
+	abort := true
+	req := &scoot.PauseJobReq{ID: "job1", Requestor: "admin", AbortRunning: &abort}
+	if err := PauseJob(req, true, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := PauseJob(req, false, s); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []sched.PauseJobReq{
+		{JobID: "job1", Requestor: "admin", Paused: true, AbortRunning: true},
+		{JobID: "job1", Requestor: "admin", Paused: false, AbortRunning: false},
+	}
+	if len(s.reqs) != 2 || s.reqs[0] != expected[0] || s.reqs[1] != expected[1] {
+		t.Fatalf("Expected %v, got %v", expected, s.reqs)
+	}
+
+	if err := PauseJob(&scoot.PauseJobReq{}, true, s); err == nil {
+		t.Fatal("Expected a request without a job id to be rejected")
+	}
+	if err := PauseJob(req, true, s.MockScheduler); err == nil {
+		t.Fatal("Expected a scheduler that doesn't support pausing to be rejected")
+	}
+}
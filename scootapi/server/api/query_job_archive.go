@@ -0,0 +1,100 @@
+package api
+
+import (
+	"time"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/archive"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the QueryJobArchive API. Lists finished jobs matching query, most recently completed first.
+func QueryJobArchive(query *scoot.ArchiveQuery, s scheduler.Scheduler) (*scoot.JobArchive, error) {
+	archiver, ok := s.(scheduler.JobArchiver)
+	if !ok {
+		msg := "Scheduler doesn't support archiving jobs"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	if query == nil {
+		query = &scoot.ArchiveQuery{}
+	}
+	if query.GetLimit() < 0 {
+		msg := "limit must not be negative"
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+	q := archive.Query{
+		Tag:       query.GetTag(),
+		Requestor: query.GetRequestor(),
+		Limit:     int(query.GetLimit()),
+	}
+	if query.IsSetSinceMs() {
+		q.Since = msToTime(query.GetSinceMs())
+	}
+	if query.IsSetUntilMs() {
+		q.Until = msToTime(query.GetUntilMs())
+	}
+
+	jobs, err := archiver.QueryArchive(q)
+	if err == scheduler.ErrNoArchive {
+		msg := err.Error()
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	} else if err != nil {
+		return nil, err
+	}
+	result := &scoot.JobArchive{Jobs: []*scoot.ArchivedJob{}}
+	for _, j := range jobs {
+		result.Jobs = append(result.Jobs, archivedJobToThrift(j))
+	}
+	return result, nil
+}
+
+func archivedJobToThrift(j archive.Job) *scoot.ArchivedJob {
+	aj := &scoot.ArchivedJob{
+		ID:          j.ID,
+		Status:      scoot.Status_COMPLETED,
+		Labels:      j.Labels,
+		Priority:    int32(j.Priority),
+		Killed:      j.Killed,
+		CreatedMs:   timeToMs(j.Created),
+		CompletedMs: timeToMs(j.Completed),
+		Tasks:       []*scoot.ArchivedTask{},
+	}
+	if j.RolledBack {
+		aj.Status = scoot.Status_ROLLED_BACK
+	}
+	if j.Requestor != "" {
+		aj.Requestor = &j.Requestor
+	}
+	if j.JobType != "" {
+		aj.JobType = &j.JobType
+	}
+	if j.Tag != "" {
+		aj.Tag = &j.Tag
+	}
+	for i := range j.Tasks {
+		t := &j.Tasks[i]
+		at := &scoot.ArchivedTask{TaskId: t.ID, Attempts: int32(t.Attempts)}
+		if state, err := scoot.RunStatusStateFromString(t.State.String()); t.State != runner.UNKNOWN && err == nil {
+			exitCode := int32(t.ExitCode)
+			at.State = &state
+			at.ExitCode = &exitCode
+		}
+		if t.Error != "" {
+			at.Error = &t.Error
+		}
+		if t.SnapshotID != "" {
+			at.SnapshotId = &t.SnapshotID
+		}
+		aj.Tasks = append(aj.Tasks, at)
+	}
+	return aj
+}
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func timeToMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/archive"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// A MockScheduler that queries a memory archive.
+type archiveScheduler struct {
+	*scheduler.MockScheduler
+	archive archive.Archive
+}
+
+func (s *archiveScheduler) QueryArchive(q archive.Query) ([]archive.Job, error) {
+	if s.archive == nil {
+		return nil, scheduler.ErrNoArchive
+	}
+	return s.archive.Query(q)
+}
+
+func Test_QueryJobArchive(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &archiveScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl), archive: archive.NewMemoryArchive(archive.Retention{})}
+	completed := time.Now().Truncate(time.Millisecond)
+	s.archive.Add(archive.Job{ID: "job1", Tag: "a", Completed: completed.Add(-time.Minute),
+		Tasks: []archive.Task{{ID: "task1", Attempts: 1, State: runner.COMPLETE, ExitCode: 1}, {ID: "task2"}}})
+	s.archive.Add(archive.Job{ID: "job2", Tag: "b", RolledBack: true, Completed: completed})
+
+	result, err := QueryJobArchive(nil, s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Jobs) != 2 || result.Jobs[0].ID != "job2" || result.Jobs[0].Status != scoot.Status_ROLLED_BACK ||
+		result.Jobs[0].CompletedMs != timeToMs(completed) {
+		t.Fatalf("Unexpected jobs: %v", result.Jobs)
+	}
+	tasks := result.Jobs[1].Tasks
+	if len(tasks) != 2 || tasks[0].GetState() != scoot.RunStatusState_COMPLETE || tasks[0].GetExitCode() != 1 ||
+		tasks[1].IsSetState() {
+		t.Errorf("Unexpected tasks: %v", tasks)
+	}
+
+	tag := "a"
+	if result, _ := QueryJobArchive(&scoot.ArchiveQuery{Tag: &tag}, s); len(result.Jobs) != 1 || result.Jobs[0].ID != "job1" {
+		t.Errorf("Expected job1, got %v", result.Jobs)
+	}
+	until := timeToMs(completed)
+	if result, _ := QueryJobArchive(&scoot.ArchiveQuery{UntilMs: &until}, s); len(result.Jobs) != 1 || result.Jobs[0].ID != "job1" {
+		t.Errorf("Expected job1, got %v", result.Jobs)
+	}
+
+	limit := int32(-1)
+	if _, err := QueryJobArchive(&scoot.ArchiveQuery{Limit: &limit}, s); err == nil {
+		t.Error("Expected an error for a negative limit")
+	}
+	s.archive = nil
+	if _, err := QueryJobArchive(nil, s); err == nil {
+		t.Error("Expected an error without an archive")
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 // Implementation of the RunJob API
-func RunJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
+func RunJob(s scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
 
 	jobDef, err := thriftJobToScoot(def)
 	// TODO: change to return scoot.NewInvalidRequest()
@@ -24,10 +24,14 @@ func RunJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.
 		return nil, NewInvalidJobRequest(err.Error())
 	}
 
-	id, err := scheduler.ScheduleJob(jobDef)
+	id, err := s.ScheduleJob(jobDef)
 
-	if err != nil {
-		return nil, err //TODO: use or delete scoot.NewCanNotScheduleNow()
+	if te, ok := err.(*scheduler.ThrottledError); ok {
+		// Let the client know when to resubmit
+		retryAfterMs := int64(te.RetryAfter / time.Millisecond)
+		return nil, &scoot.CanNotScheduleNow{RetryAfterMs: &retryAfterMs}
+	} else if err != nil {
+		return nil, err
 	}
 
 	return &scoot.JobId{ID: id}, nil
@@ -56,6 +60,7 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 		if t.SnapshotId != nil {
 			task.SnapshotID = *t.SnapshotId
 		}
+		task.SparsePatterns = t.SparsePatterns
 		if t.TimeoutMs != nil && *t.TimeoutMs > 0 {
 			task.Command.Timeout = time.Duration(*t.TimeoutMs) * time.Millisecond
 		} else if def.DefaultTaskTimeoutMs != nil {
@@ -65,6 +70,10 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 			return result, fmt.Errorf("nil taskId")
 		}
 		task.TaskID = *t.TaskId
+		if r := t.Resources; r != nil {
+			task.Resources.MilliCPUs = r.GetMilliCpus()
+			task.Resources.MemoryBytes = r.GetMemoryBytes()
+		}
 
 		result.Tasks = append(result.Tasks, task)
 	}
@@ -84,6 +93,19 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 	if def.Priority != nil {
 		result.Priority = sched.Priority(*def.Priority)
 	}
+	if def.TimeoutMs != nil && *def.TimeoutMs > 0 {
+		result.Timeout = time.Duration(*def.TimeoutMs) * time.Millisecond
+	}
+	if def.RetentionMs != nil && *def.RetentionMs > 0 {
+		result.Retention = time.Duration(*def.RetentionMs) * time.Millisecond
+	}
+	result.Gang = def.GetGang()
+	if len(def.Labels) > 0 {
+		result.Labels = make(map[string]string)
+		for k, v := range def.Labels {
+			result.Labels[k] = v
+		}
+	}
 
 	return result, nil
 }
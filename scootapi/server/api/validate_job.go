@@ -0,0 +1,128 @@
+package api
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/twitter/scoot/bazel"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/snapshot/snapid"
+)
+
+// How long ValidateJob waits for the CAS to report missing inputs
+const validateCASTimeout = 10 * time.Second
+
+// BlobFinder is the CAS functionality used to check that the inputs of Bazel tasks exist.
+type BlobFinder interface {
+	FindMissingBlobs(ctx context.Context, digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, error)
+}
+
+// BundleFinder is the bundlestore functionality used to check that the bundles of git snapshots exist.
+type BundleFinder interface {
+	Exists(name string) (bool, error)
+}
+
+// Implementation of the ValidateJob API. Runs the checks RunJob would without scheduling the job:
+// the job definition is validated, each task's snapshot ID must parse, the input roots of Bazel tasks
+// must exist in the CAS if cas is non-nil, the bundles of bundlestore snapshots must exist if bundles
+// is non-nil, and the scheduler must accept the job if it's a JobValidator, including its admission check.
+// Other git snapshots are fetched by workers from their streams and aren't checked.
+func ValidateJob(s scheduler.Scheduler, cas BlobFinder, bundles BundleFinder,
+	def *scoot.JobDefinition) (*scoot.JobValidation, error) {
+	result := &scoot.JobValidation{Errors: []*scoot.ValidationError{}}
+	addError := func(taskID *string, msg string) {
+		result.Errors = append(result.Errors, &scoot.ValidationError{TaskId: taskID, Message: msg})
+	}
+
+	jobDef, err := thriftJobToScoot(def)
+	if err != nil {
+		addError(nil, err.Error())
+		return result, nil
+	}
+	if err := sched.ValidateJob(jobDef); err != nil {
+		addError(nil, err.Error())
+	}
+
+	// Input roots of Bazel tasks and bundles of bundlestore snapshots, by the tasks using them
+	inputs := map[string][]string{}
+	digests := []*remoteexecution.Digest{}
+	bundleTasks := map[string][]string{}
+	bundleNames := []string{}
+	for _, task := range jobDef.Tasks {
+		if task.SnapshotID == "" {
+			continue
+		}
+		taskID := task.TaskID
+		id, err := snapid.Parse(task.SnapshotID)
+		if err != nil {
+			addError(&taskID, err.Error())
+			continue
+		}
+		if !id.IsBazel() {
+			if id.Backend == snapid.BackendBundlestore {
+				name := snapid.BundleName(id.BundleKey)
+				if _, ok := bundleTasks[name]; !ok {
+					bundleNames = append(bundleNames, name)
+				}
+				bundleTasks[name] = append(bundleTasks[name], taskID)
+			}
+			continue
+		}
+		d, _ := id.Digest()
+		if bazel.IsEmptyDigest(d) {
+			continue
+		}
+		key := bazel.DigestToStr(d)
+		if _, ok := inputs[key]; !ok {
+			digests = append(digests, d)
+		}
+		inputs[key] = append(inputs[key], taskID)
+	}
+
+	if cas != nil && len(digests) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), validateCASTimeout)
+		defer cancel()
+		missing, err := cas.FindMissingBlobs(ctx, digests)
+		if err != nil {
+			log.Errorf("Failed to check CAS for job inputs: %s", err)
+			return nil, scoot.NewScootServerError()
+		}
+		for _, d := range missing {
+			for _, taskID := range inputs[bazel.DigestToStr(d)] {
+				id := taskID
+				addError(&id, "input root "+bazel.DigestToStr(d)+" is missing from the CAS")
+			}
+		}
+	}
+
+	if bundles != nil {
+		for _, name := range bundleNames {
+			ok, err := bundles.Exists(name)
+			if err != nil {
+				log.Errorf("Failed to check bundlestore for job snapshots: %s", err)
+				return nil, scoot.NewScootServerError()
+			}
+			if ok {
+				continue
+			}
+			for _, taskID := range bundleTasks[name] {
+				id := taskID
+				addError(&id, "snapshot bundle "+name+" is missing from the bundlestore")
+			}
+		}
+	}
+
+	if v, ok := s.(scheduler.JobValidator); ok {
+		if err := v.ValidateJob(jobDef); err != nil {
+			addError(nil, err.Error())
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"golang.org/x/net/context"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/snapshot/snapid"
+)
+
+const (
+	presentSha = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b856"
+	missingSha = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b857"
+)
+
+// A MockScheduler that rejects jobs from a given requestor.
+type validatingScheduler struct {
+	*scheduler.MockScheduler
+	reject string
+}
+
+func (s *validatingScheduler) ValidateJob(jobDef sched.JobDefinition) error {
+	if jobDef.Requestor == s.reject {
+		return errors.New("Exceeds max jobs per requestor")
+	}
+	return nil
+}
+
+// A BlobFinder missing every blob with missingSha.
+type fakeBlobFinder struct {
+	err error
+}
+
+func (f *fakeBlobFinder) FindMissingBlobs(
+	ctx context.Context, digests []*remoteexecution.Digest) ([]*remoteexecution.Digest, error) {
+	missing := []*remoteexecution.Digest{}
+	for _, d := range digests {
+		if d.GetHash() == missingSha {
+			missing = append(missing, d)
+		}
+	}
+	return missing, f.err
+}
+
+// A BundleFinder with the given bundles.
+type fakeBundleFinder struct {
+	bundles map[string]bool
+	err     error
+}
+
+func (f *fakeBundleFinder) Exists(name string) (bool, error) {
+	return f.bundles[name], f.err
+}
+
+func makeValidateJobDef(snapshotIDs ...string) *scoot.JobDefinition {
+	def := scoot.NewJobDefinition()
+	requestor := "ci"
+	def.Requestor = &requestor
+	for i, id := range snapshotIDs {
+		taskID := string('a' + rune(i))
+		snapshotID := id
+		def.Tasks = append(def.Tasks, &scoot.TaskDefinition{
+			Command:    &scoot.Command{Argv: []string{"true"}},
+			SnapshotId: &snapshotID,
+			TaskId:     &taskID,
+		})
+	}
+	return def
+}
+
+func Test_ValidateJob(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &validatingScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl), reject: "greedy"}
+	cas := &fakeBlobFinder{}
+
+	def := makeValidateJobDef("", snapid.Bazel(presentSha, 10).String())
+	v, err := ValidateJob(s, cas, nil, def)
+	if err != nil || !v.GetValid() || len(v.GetErrors()) != 0 {
+		t.Fatalf("Expected valid job, got %v %v", v, err)
+	}
+
+	// Errors specific to a task name it.
+	def = makeValidateJobDef("bad-id", snapid.Bazel(missingSha, 10).String(), snapid.Bazel(presentSha, 10).String())
+	v, err = ValidateJob(s, cas, nil, def)
+	if err != nil || v.GetValid() || len(v.GetErrors()) != 2 {
+		t.Fatalf("Expected 2 errors, got %v %v", v, err)
+	}
+	for i, e := range v.GetErrors() {
+		if expected := string('a' + rune(i)); e.GetTaskId() != expected {
+			t.Fatalf("Expected error for task %s, got %v", expected, e)
+		}
+	}
+	if !strings.Contains(v.GetErrors()[1].GetMessage(), "missing from the CAS") {
+		t.Fatalf("Expected missing input error, got %v", v.GetErrors()[1])
+	}
+
+	// Scheduler and job definition errors apply to the whole job.
+	def = makeValidateJobDef("")
+	greedy := "greedy"
+	def.Requestor = &greedy
+	v, err = ValidateJob(s, cas, nil, def)
+	if err != nil || v.GetValid() || len(v.GetErrors()) != 1 || v.GetErrors()[0].IsSetTaskId() {
+		t.Fatalf("Expected scheduler error, got %v %v", v, err)
+	}
+	v, err = ValidateJob(s, cas, nil, scoot.NewJobDefinition())
+	if err != nil || v.GetValid() || len(v.GetErrors()) == 0 {
+		t.Fatalf("Expected job without tasks to be invalid, got %v %v", v, err)
+	}
+
+	// Without a CAS inputs aren't checked, and failing to reach one is a server error.
+	def = makeValidateJobDef(snapid.Bazel(missingSha, 10).String())
+	if v, err = ValidateJob(s, nil, nil, def); err != nil || !v.GetValid() {
+		t.Fatalf("Expected valid job without a CAS, got %v %v", v, err)
+	}
+	cas.err = errors.New("unavailable")
+	if _, err = ValidateJob(s, cas, nil, def); err == nil {
+		t.Fatal("Expected error when the CAS can't be reached")
+	}
+}
+
+func Test_ValidateJobBundles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := &validatingScheduler{MockScheduler: scheduler.NewMockScheduler(mockCtrl)}
+	sha := strings.Repeat("a", 40)
+	present := snapid.Bundlestore(snapid.FormatGitCommit, "present", "master", sha).String()
+	missing := snapid.Bundlestore(snapid.FormatGitCommit, "missing", "master", sha).String()
+	bundles := &fakeBundleFinder{bundles: map[string]bool{snapid.BundleName("present"): true}}
+
+	// Only bundlestore snapshots are checked, stream snapshots are fetched by workers.
+	def := makeValidateJobDef(present, missing, snapid.Stream(snapid.FormatGitCommit, "master", sha).String())
+	v, err := ValidateJob(s, nil, bundles, def)
+	if err != nil || v.GetValid() || len(v.GetErrors()) != 1 || v.GetErrors()[0].GetTaskId() != "b" {
+		t.Fatalf("Expected missing bundle error for task b, got %v %v", v, err)
+	}
+	if !strings.Contains(v.GetErrors()[0].GetMessage(), "missing from the bundlestore") {
+		t.Fatalf("Expected missing bundle error, got %v", v.GetErrors()[0])
+	}
+
+	if v, err = ValidateJob(s, nil, nil, def); err != nil || !v.GetValid() {
+		t.Fatalf("Expected valid job without a bundlestore, got %v %v", v, err)
+	}
+	bundles.err = errors.New("unavailable")
+	if _, err = ValidateJob(s, nil, bundles, def); err == nil {
+		t.Fatal("Expected error when the bundlestore can't be reached")
+	}
+}
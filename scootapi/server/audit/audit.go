@@ -0,0 +1,194 @@
+// Package audit records administrative actions taken through the Scheduler API, like killing jobs
+// and taking workers offline, in an append-only log so they can be reviewed after an incident.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Audited actions
+const (
+	KillJob            = "kill_job"
+	PauseJob           = "pause_job"
+	ResumeJob          = "resume_job"
+	OfflineWorker      = "offline_worker"
+	ReinstateWorker    = "reinstate_worker"
+	CordonWorker       = "cordon_worker"
+	UncordonWorker     = "uncordon_worker"
+	AddWorker          = "add_worker"
+	RemoveWorker       = "remove_worker"
+	SetSchedulerStatus = "set_scheduler_status"
+	RequeueDeadLetter  = "requeue_dead_letter"
+
+	// Actor recorded when the client didn't identify itself
+	UnknownActor = "unknown"
+
+	// Entries returned by a Query with no MaxEntries
+	DefaultMaxEntries = 100
+
+	// Most recent entries kept by a memory Log, older ones are dropped
+	DefaultMemoryLogEntries = 10000
+)
+
+// An administrative action. Error is set if the action failed.
+type Entry struct {
+	Time    time.Time
+	Actor   string
+	Action  string
+	Target  string `json:",omitempty"`
+	Details string `json:",omitempty"`
+	Error   string `json:",omitempty"`
+}
+
+// Selects entries from a Log. Zero values match everything.
+type Query struct {
+	Action     string
+	Actor      string
+	Since      time.Time
+	MaxEntries int
+}
+
+func (q Query) matches(e Entry) bool {
+	return (q.Action == "" || q.Action == e.Action) &&
+		(q.Actor == "" || q.Actor == e.Actor) &&
+		!e.Time.Before(q.Since)
+}
+
+// Log is an append-only record of administrative actions.
+type Log interface {
+	// Appends an entry to the log.
+	Record(e Entry) error
+
+	// Returns the most recent entries matching q, most recent first.
+	Query(q Query) ([]Entry, error)
+}
+
+// Records an action taken by actor on target, logging rather than returning failures to record it
+// so an unavailable audit log doesn't block administration. err is the result of the action.
+func Record(l Log, actor, action, target, details string, err error) {
+	if actor == "" {
+		actor = UnknownActor
+	}
+	e := Entry{Time: time.Now(), Actor: actor, Action: action, Target: target, Details: details}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	log.Infof("Audit: %+v", e)
+	if recordErr := l.Record(e); recordErr != nil {
+		log.Errorf("Failed recording audit entry %+v: %v", e, recordErr)
+	}
+}
+
+// Returns a Log kept in memory, which is lost when the scheduler restarts.
+// Only the most recent DefaultMemoryLogEntries are kept.
+func NewMemoryLog() Log {
+	return newMemoryLog(DefaultMemoryLogEntries)
+}
+
+func newMemoryLog(capacity int) *memoryLog {
+	return &memoryLog{capacity: capacity}
+}
+
+// Ring buffer of the most recent entries.
+type memoryLog struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries []Entry // oldest first until full, then oldest at next
+	next    int
+}
+
+func (l *memoryLog) Record(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) < l.capacity {
+		l.entries = append(l.entries, e)
+		return nil
+	}
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % l.capacity
+	return nil
+}
+
+func (l *memoryLog) Query(q Query) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := append(append([]Entry{}, l.entries[l.next:]...), l.entries[:l.next]...)
+	return query(entries, q), nil
+}
+
+// Returns a Log that appends entries to the file at path as lines of JSON, creating it if needed.
+func NewFileLog(path string) (Log, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Recording audit log to %s", path)
+	return &fileLog{path: path, f: f}, nil
+}
+
+type fileLog struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (l *fileLog) Record(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+func (l *fileLog) Query(q Query) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			log.Errorf("Skipping unreadable audit entry in %s: %v", l.path, err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return query(entries, q), nil
+}
+
+// Returns the most recent of entries, which are oldest first, matching q.
+func query(entries []Entry, q Query) []Entry {
+	max := q.MaxEntries
+	if max <= 0 {
+		max = DefaultMaxEntries
+	}
+	matched := []Entry{}
+	for i := len(entries) - 1; i >= 0 && len(matched) < max; i-- {
+		if q.matches(entries[i]) {
+			matched = append(matched, entries[i])
+		}
+	}
+	return matched
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := NewFileLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Record(l, "alice", KillJob, "job1", "", nil)
+	Record(l, "", OfflineWorker, "worker1", "", errors.New("no such worker"))
+	Record(l, "bob", SetSchedulerStatus, "", "maxTasks=10", nil)
+
+	// Entries survive reopening the log, which is appended to rather than truncated.
+	l, err = NewFileLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Record(l, "alice", KillJob, "job2", "", nil)
+
+	entries, err := l.Query(Query{})
+	if err != nil {
+		t.Fatalf("Unexpected error querying: %v", err)
+	}
+	if len(entries) != 4 || entries[0].Target != "job2" || entries[3].Target != "job1" {
+		t.Fatalf("Expected 4 entries most recent first, got %+v", entries)
+	}
+	if entries[2].Actor != UnknownActor || entries[2].Error != "no such worker" {
+		t.Errorf("Expected unknown actor and error, got %+v", entries[2])
+	}
+
+	entries, _ = l.Query(Query{Action: KillJob, Actor: "alice", MaxEntries: 1})
+	if len(entries) != 1 || entries[0].Target != "job2" {
+		t.Errorf("Expected only the most recent kill by alice, got %+v", entries)
+	}
+	entries, _ = l.Query(Query{Since: time.Now().Add(time.Hour)})
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries in the future, got %+v", entries)
+	}
+}
+
+func TestMemoryLogCapacity(t *testing.T) {
+	l := newMemoryLog(3)
+	for _, target := range []string{"job1", "job2", "job3", "job4", "job5"} {
+		Record(l, "alice", KillJob, target, "", nil)
+	}
+
+	// Only the most recent entries are kept once the log is full.
+	entries, err := l.Query(Query{})
+	if err != nil {
+		t.Fatalf("Unexpected error querying: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Target != "job5" || entries[2].Target != "job3" {
+		t.Fatalf("Expected the 3 most recent entries most recent first, got %+v", entries)
+	}
+}
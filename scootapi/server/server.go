@@ -4,18 +4,22 @@ package server
 // (they are call from cloudscoot.go)
 
 import (
+	"fmt"
+
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/scootapi/server/api"
+	"github.com/twitter/scoot/scootapi/server/audit"
 )
 
-// Creates and returns a new server Handler, which combines the scheduler,
-// saga coordinator and stats receivers.
-func NewHandler(scheduler scheduler.Scheduler, sc saga.SagaCoordinator, stat stats.StatsReceiver) scoot.CloudScoot {
-	handler := &Handler{scheduler: scheduler, sagaCoord: sc, stat: stat}
+// Creates and returns a new server Handler, which combines the scheduler, saga coordinator, audit log,
+// CAS and bundlestore used to validate jobs (either may be nil) and stats receivers.
+func NewHandler(scheduler scheduler.Scheduler, sc saga.SagaCoordinator, auditLog audit.Log,
+	cas api.BlobFinder, bundles api.BundleFinder, stat stats.StatsReceiver) scoot.CloudScoot {
+	handler := &Handler{scheduler: scheduler, sagaCoord: sc, auditLog: auditLog, cas: cas, bundles: bundles, stat: stat}
 	go stats.StartUptimeReporting(stat, stats.SchedUptime_ms, stats.SchedServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	return handler
 }
@@ -30,10 +34,14 @@ func MakeServer(handler scoot.CloudScoot,
 		transport, transportFactory, protocolFactory)
 }
 
-// Wrapping type that combines a scheduler, saga coordinator and stat receiver into a server
+// Wrapping type that combines a scheduler, saga coordinator, audit log, CAS, bundlestore and stat receiver into a server.
+// Administrative actions are recorded in the audit log.
 type Handler struct {
 	scheduler scheduler.Scheduler
 	sagaCoord saga.SagaCoordinator
+	auditLog  audit.Log
+	cas       api.BlobFinder
+	bundles   api.BundleFinder
 	stat      stats.StatsReceiver
 }
 
@@ -44,28 +52,125 @@ func (h *Handler) RunJob(def *scoot.JobDefinition) (*scoot.JobId, error) {
 	return api.RunJob(h.scheduler, def, h.stat)
 }
 
+// Implements ValidateJob Cloud Scoot API
+func (h *Handler) ValidateJob(def *scoot.JobDefinition) (*scoot.JobValidation, error) {
+	h.stat.Counter(stats.SchedServerValidateJobCounter).Inc(1)
+	return api.ValidateJob(h.scheduler, h.cas, h.bundles, def)
+}
+
 // Implements GetStatus Cloud Scoot API
 func (h *Handler) GetStatus(jobId string) (*scoot.JobStatus, error) {
 	defer h.stat.Latency(stats.SchedServerJobStatusLatency_ms).Time().Stop()
 	h.stat.Counter(stats.SchedServerJobStatusCounter).Inc(1)
-	return api.GetJobStatus(jobId, h.sagaCoord)
+	js, err := api.GetJobStatus(jobId, h.sagaCoord)
+	if err == nil {
+		api.AddJobETA(js, h.scheduler)
+	}
+	return js, err
 }
 
 // Implements KillJob Cloud Scoot API
-func (h *Handler) KillJob(jobId string) (*scoot.JobStatus, error) {
+func (h *Handler) KillJob(jobId string, requestor string) (*scoot.JobStatus, error) {
 	defer h.stat.Latency(stats.SchedServerJobKillLatency_ms).Time().Stop()
 	h.stat.Counter(stats.SchedServerJobKillCounter).Inc(1)
-	return api.KillJob(jobId, h.scheduler, h.sagaCoord)
+	js, err := api.KillJob(jobId, h.scheduler, h.sagaCoord)
+	audit.Record(h.auditLog, requestor, audit.KillJob, jobId, "", err)
+	return js, err
+}
+
+// Implements PauseJob Cloud Scoot API
+func (h *Handler) PauseJob(req *scoot.PauseJobReq) error {
+	err := api.PauseJob(req, true, h.scheduler)
+	if req != nil {
+		details := ""
+		if req.GetAbortRunning() {
+			details = "abortRunning"
+		}
+		audit.Record(h.auditLog, req.GetRequestor(), audit.PauseJob, req.GetID(), details, err)
+	}
+	return err
+}
+
+// Implements ResumeJob Cloud Scoot API
+func (h *Handler) ResumeJob(req *scoot.PauseJobReq) error {
+	err := api.PauseJob(req, false, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.ResumeJob, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements FindJobs Cloud Scoot API
+func (h *Handler) FindJobs(query *scoot.JobQuery) (*scoot.JobList, error) {
+	defer h.stat.Latency(stats.SchedServerFindJobsLatency_ms).Time().Stop()
+	h.stat.Counter(stats.SchedServerFindJobsCounter).Inc(1)
+	return api.FindJobs(query, h.scheduler, h.sagaCoord)
+}
+
+// Implements GetJobManifest Cloud Scoot API
+func (h *Handler) GetJobManifest(jobId string) (*scoot.JobManifest, error) {
+	defer h.stat.Latency(stats.SchedServerJobManifestLatency_ms).Time().Stop()
+	h.stat.Counter(stats.SchedServerJobManifestCounter).Inc(1)
+	return api.GetJobManifest(jobId, h.sagaCoord)
 }
 
 // Implements OfflineWorker Cloud Scoot API
 func (h *Handler) OfflineWorker(req *scoot.OfflineWorkerReq) error {
-	return api.OfflineWorker(req, h.scheduler)
+	err := api.OfflineWorker(req, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.OfflineWorker, req.GetID(), "", err)
+	}
+	return err
 }
 
 // Implements ReinstateWorker Cloud Scoot API
 func (h *Handler) ReinstateWorker(req *scoot.ReinstateWorkerReq) error {
-	return api.ReinstateWorker(req, h.scheduler)
+	err := api.ReinstateWorker(req, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.ReinstateWorker, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements CordonWorker Cloud Scoot API
+func (h *Handler) CordonWorker(req *scoot.CordonWorkerReq) error {
+	err := api.CordonWorker(req, true, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.CordonWorker, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements UncordonWorker Cloud Scoot API
+func (h *Handler) UncordonWorker(req *scoot.CordonWorkerReq) error {
+	err := api.CordonWorker(req, false, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.UncordonWorker, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements AddWorker Cloud Scoot API
+func (h *Handler) AddWorker(req *scoot.ManualWorkerReq) error {
+	err := api.ManageWorker(req, true, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.AddWorker, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements RemoveWorker Cloud Scoot API
+func (h *Handler) RemoveWorker(req *scoot.ManualWorkerReq) error {
+	err := api.ManageWorker(req, false, h.scheduler)
+	if req != nil {
+		audit.Record(h.auditLog, req.GetRequestor(), audit.RemoveWorker, req.GetID(), "", err)
+	}
+	return err
+}
+
+// Implements GetIdleWorkers Cloud Scoot API
+func (h *Handler) GetIdleWorkers(minIdleMs int64) (*scoot.IdleWorkers, error) {
+	return api.GetIdleWorkers(minIdleMs, h.scheduler)
 }
 
 // Implements GetSchedulerStatus Cloud Scoot API
@@ -74,6 +179,36 @@ func (h *Handler) GetSchedulerStatus() (*scoot.SchedulerStatus, error) {
 }
 
 // Implements SetSchedulerStatus Cloud Scoot API
-func (h *Handler) SetSchedulerStatus(maxNumTasks int32) error {
-	return api.SetSchedulerStatus(h.scheduler, maxNumTasks)
+func (h *Handler) SetSchedulerStatus(maxNumTasks int32, requestor string) error {
+	err := api.SetSchedulerStatus(h.scheduler, maxNumTasks)
+	audit.Record(h.auditLog, requestor, audit.SetSchedulerStatus, "", fmt.Sprintf("maxTasks=%d", maxNumTasks), err)
+	return err
+}
+
+// Implements GetAuditLog Cloud Scoot API
+func (h *Handler) GetAuditLog(query *scoot.AuditQuery) (*scoot.AuditLog, error) {
+	return api.GetAuditLog(query, h.auditLog)
+}
+
+// Implements QueryJobArchive Cloud Scoot API
+func (h *Handler) QueryJobArchive(query *scoot.ArchiveQuery) (*scoot.JobArchive, error) {
+	return api.QueryJobArchive(query, h.scheduler)
+}
+
+// Implements GetDeadLetterTasks Cloud Scoot API
+func (h *Handler) GetDeadLetterTasks(query *scoot.DeadLetterQuery) (*scoot.DeadLetterTasks, error) {
+	return api.GetDeadLetterTasks(query, h.scheduler)
+}
+
+// Implements RequeueDeadLetterTask Cloud Scoot API
+func (h *Handler) RequeueDeadLetterTask(req *scoot.RequeueTaskReq) (*scoot.JobId, error) {
+	jobId, err := api.RequeueDeadLetterTask(req, h.scheduler)
+	if req != nil {
+		details := "task=" + req.GetTaskId()
+		if jobId != nil {
+			details += " newJob=" + jobId.GetID()
+		}
+		audit.Record(h.auditLog, req.GetRequestor(), audit.RequeueDeadLetter, req.GetJobId(), details, err)
+	}
+	return jobId, err
 }
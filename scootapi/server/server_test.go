@@ -11,6 +11,7 @@ import (
 	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/audit"
 )
 
 // ensure a scheduler initializes to the correct state
@@ -26,7 +27,8 @@ func Test_RequestCounters(t *testing.T) {
 
 	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
 
-	handler := NewHandler(s, sc, statsReceiver)
+	auditLog := audit.NewMemoryLog()
+	handler := NewHandler(s, sc, auditLog, nil, nil, statsReceiver)
 
 	domainJobDef := sched.GenJobDef(1)
 	domainJobDef.Tasks[0].Argv = []string{}
@@ -46,10 +48,14 @@ func Test_RequestCounters(t *testing.T) {
 		t.Errorf("GetStatus returned err:%s", err.Error())
 	}
 
-	_, err = handler.KillJob("testJobId")
+	_, err = handler.KillJob("testJobId", "tester")
 	if err != nil {
 		t.Errorf("GetStatus returned err:%s", err.Error())
 	}
+	entries, _ := auditLog.Query(audit.Query{})
+	if len(entries) != 1 || entries[0].Action != audit.KillJob || entries[0].Actor != "tester" || entries[0].Target != "testJobId" {
+		t.Errorf("Expected KillJob to be audited, got %+v", entries)
+	}
 
 	time.Sleep(stats.StatReportIntvl + (10 * time.Millisecond)) // wait to make sure stats are generated
 	if !stats.StatsOk("", statsRegistry, t,
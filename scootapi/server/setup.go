@@ -1,12 +1,14 @@
 package server
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/client"
 	"github.com/twitter/scoot/bazel/execution"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/endpoints"
@@ -19,6 +21,9 @@ import (
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/api"
+	"github.com/twitter/scoot/scootapi/server/audit"
+	"github.com/twitter/scoot/scootapi/server/ui"
 )
 
 type servers struct {
@@ -57,11 +62,31 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 			return scheduler.NewStatefulSchedulerFromCluster(cl, sc, rf, config, stat)
 		},
 
+		func() (audit.Log, error) {
+			return audit.NewMemoryLog(), nil
+		},
+
+		// Jobs are validated against the same CAS as Bazel Execute requests, if there is one.
+		func(cr execution.CASResolver) api.BlobFinder {
+			if cr == nil {
+				return nil
+			}
+			return client.NewClient(cr, client.DefaultRetryPolicy)
+		},
+
+		// Bundles of jobs' snapshots aren't checked unless a bundlestore is given.
+		func() api.BundleFinder {
+			return nil
+		},
+
 		func(
 			s scheduler.Scheduler,
 			sc saga.SagaCoordinator,
+			al audit.Log,
+			cas api.BlobFinder,
+			bundles api.BundleFinder,
 			stat stats.StatsReceiver) scoot.CloudScoot {
-			return NewHandler(s, sc, stat)
+			return NewHandler(s, sc, al, cas, bundles, stat)
 		},
 
 		func(
@@ -72,16 +97,24 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 			return MakeServer(h, t, tf, pf)
 		},
 
-		func(s stats.StatsReceiver) *endpoints.TwitterServer {
-			return endpoints.NewTwitterServer(endpoints.Addr(scootapi.DefaultSched_HTTP), s, nil)
+		func() ui.Config {
+			return ui.Config{}
+		},
+
+		func(s scheduler.Scheduler, cfg ui.Config) map[string]http.Handler {
+			return map[string]http.Handler{ui.HttpPath: ui.NewHandler(s, cfg)}
+		},
+
+		func(s stats.StatsReceiver, handlers map[string]http.Handler) *endpoints.TwitterServer {
+			return endpoints.NewTwitterServer(endpoints.Addr(scootapi.DefaultSched_HTTP), s, handlers)
 		},
 
 		func(t thrift.TServer, h *endpoints.TwitterServer, g bazel.GRPCServer) servers {
 			return makeServers(t, h, g)
 		},
 
-		func(log saga.SagaLog) saga.SagaCoordinator {
-			return saga.MakeSagaCoordinator(log)
+		func(log saga.SagaLog, stat stats.StatsReceiver) saga.SagaCoordinator {
+			return saga.MakeSagaCoordinatorWithStats(log, stat)
 		},
 
 		func() thrift.TProtocolFactory {
@@ -94,8 +127,21 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 			}
 		},
 
-		func(gc *bazel.GRPCConfig, s scheduler.Scheduler, stat stats.StatsReceiver) bazel.GRPCServer {
-			return execution.MakeExecutionServer(gc, s, stat)
+		func() execution.CASResolver {
+			return nil
+		},
+
+		func() execution.ExecuteLimit {
+			return 0
+		},
+
+		func() execution.BESResolver {
+			return nil
+		},
+
+		func(gc *bazel.GRPCConfig, s scheduler.Scheduler, cr execution.CASResolver,
+			el execution.ExecuteLimit, br execution.BESResolver, stat stats.StatsReceiver) bazel.GRPCServer {
+			return execution.MakeExecutionServer(gc, s, cr, el, br, stat)
 		},
 	)
 
@@ -103,11 +149,14 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 		"SagaLog": {
 			"memory": &scootconfig.InMemorySagaLogConfig{},
 			"file":   &scootconfig.FileSagaLogConfig{},
+			"bolt":   &scootconfig.BoltSagaLogConfig{},
 			"":       &scootconfig.InMemorySagaLogConfig{},
 		},
 		"Cluster": {
 			"memory": &scootconfig.ClusterMemoryConfig{},
 			"local":  &scootconfig.ClusterLocalConfig{},
+			"file":   &scootconfig.ClusterFileConfig{},
+			"merged": &scootconfig.ClusterMergedConfig{},
 			"": &scootconfig.ClusterMemoryConfig{
 				Type:  "memory",
 				Count: 10,
@@ -0,0 +1,316 @@
+// Package ui serves a read only web UI for the scheduler, showing the job queue, per-job
+// task progress with links to task logs, and the status of the worker fleet.
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/api"
+)
+
+// Path the UI is served under.
+const HttpPath = "/ui/"
+
+// How often UI pages reload themselves.
+const refreshSeconds = "10"
+
+type Config struct {
+	// Prefix joined with a task's persisted log reference to link to it, ex: "http://apiserver:9098/log/".
+	// If empty persisted logs aren't linked.
+	LogURLPrefix string
+}
+
+// Handler serves the UI. It only reads scheduler state, it never changes it.
+type Handler struct {
+	scheduler scheduler.Scheduler
+	viewer    scheduler.Viewer // nil if the scheduler can't report its state
+	cfg       Config
+}
+
+func NewHandler(s scheduler.Scheduler, cfg Config) *Handler {
+	h := &Handler{scheduler: s, cfg: cfg}
+	if v, ok := s.(scheduler.Viewer); ok {
+		h.viewer = v
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, HttpPath)
+	switch {
+	case path == "":
+		h.serveIndex(w, req)
+	case strings.HasPrefix(path, "job/"):
+		h.serveJob(w, req, strings.TrimPrefix(path, "job/"))
+	case path == "api/view":
+		h.serveViewJSON(w, req)
+	case strings.HasPrefix(path, "saga/"):
+		h.serveSaga(w, req, strings.TrimPrefix(path, "saga/"), false)
+	case strings.HasPrefix(path, "api/saga/"):
+		h.serveSaga(w, req, strings.TrimPrefix(path, "api/saga/"), true)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (h *Handler) getView() (scheduler.View, error) {
+	if h.viewer == nil {
+		return scheduler.View{}, fmt.Errorf("Scheduler doesn't support viewing its state")
+	}
+	return h.viewer.GetView()
+}
+
+type indexPage struct {
+	View         scheduler.View
+	NodeCounts   []stateCount
+	TasksRunning int
+	TasksWaiting int
+}
+
+type stateCount struct {
+	State string
+	Count int
+}
+
+func (h *Handler) serveIndex(w http.ResponseWriter, req *http.Request) {
+	v, err := h.getView()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	page := indexPage{View: v}
+	counts := map[string]int{}
+	for _, n := range v.Nodes {
+		counts[n.State]++
+	}
+	for state, count := range counts {
+		page.NodeCounts = append(page.NodeCounts, stateCount{state, count})
+	}
+	sort.Slice(page.NodeCounts, func(i, j int) bool { return page.NodeCounts[i].State < page.NodeCounts[j].State })
+	for _, j := range v.Jobs {
+		page.TasksRunning += j.TasksRunning
+		page.TasksWaiting += j.TasksTotal - j.TasksCompleted - j.TasksRunning
+	}
+	render(w, indexTmpl, page)
+}
+
+type jobPage struct {
+	ID        string
+	Job       *scheduler.JobView // nil if the job isn't in progress
+	Status    scoot.Status
+	Tasks     []taskRow
+	LogPrefix string
+}
+
+type taskRow struct {
+	ID     string
+	Status string
+	Node   string
+	Tries  int
+	Run    *scoot.RunStatus // nil if the task hasn't started
+}
+
+func (h *Handler) serveJob(w http.ResponseWriter, req *http.Request, jobID string) {
+	js, err := api.GetJobStatus(jobID, h.scheduler.GetSagaCoord())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting status of job %s: %v", jobID, err), http.StatusNotFound)
+		return
+	}
+	page := jobPage{ID: jobID, Status: js.Status, LogPrefix: h.cfg.LogURLPrefix}
+
+	// Prefer the scheduler's view for task order and placement, the saga only has completed tasks' results.
+	tasks := map[string]*taskRow{}
+	if v, err := h.getView(); err == nil {
+		for i := range v.Jobs {
+			if v.Jobs[i].ID != jobID {
+				continue
+			}
+			page.Job = &v.Jobs[i]
+			for _, t := range page.Job.Tasks {
+				page.Tasks = append(page.Tasks, taskRow{ID: t.ID, Status: t.Status.String(), Node: t.Node, Tries: t.Tries})
+			}
+		}
+	} else {
+		log.Infof("UI couldn't get scheduler view: %v", err)
+	}
+	if page.Job == nil && len(js.TaskStatus) == 0 && js.Status == scoot.Status_NOT_STARTED {
+		http.Error(w, fmt.Sprintf("Unknown job %s", jobID), http.StatusNotFound)
+		return
+	}
+	if page.Job == nil {
+		for id, st := range js.TaskStatus {
+			page.Tasks = append(page.Tasks, taskRow{ID: id, Status: st.String()})
+		}
+		sort.Slice(page.Tasks, func(i, j int) bool { return page.Tasks[i].ID < page.Tasks[j].ID })
+	}
+	for i := range page.Tasks {
+		tasks[page.Tasks[i].ID] = &page.Tasks[i]
+	}
+	for id, run := range js.TaskData {
+		if t, ok := tasks[id]; ok {
+			t.Run = run
+		}
+	}
+	render(w, jobTmpl, page)
+}
+
+func (h *Handler) serveViewJSON(w http.ResponseWriter, req *http.Request) {
+	v, err := h.getView()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Infof("Error writing scheduler view: %v", err)
+	}
+}
+
+// Serves the timeline of a job's saga as text, or as JSON including message data if asJSON is set.
+func (h *Handler) serveSaga(w http.ResponseWriter, req *http.Request, jobID string, asJSON bool) {
+	tl, err := h.scheduler.GetSagaCoord().GetTimeline(jobID, asJSON)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading saga of job %s: %v", jobID, err), http.StatusInternalServerError)
+		return
+	}
+	if tl.State == saga.TimelineNotFound {
+		http.Error(w, fmt.Sprintf("Unknown job %s", jobID), http.StatusNotFound)
+		return
+	}
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(w).Encode(tl)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		err = tl.WriteText(w)
+	}
+	if err != nil {
+		log.Infof("Error writing saga of job %s: %v", jobID, err)
+	}
+}
+
+func render(w http.ResponseWriter, tmpl *template.Template, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, data); err != nil {
+		log.Infof("Error rendering UI template %s: %v", tmpl.Name(), err)
+	}
+}
+
+var funcs = template.FuncMap{
+	"pct": func(done, total int) int {
+		if total == 0 {
+			return 100
+		}
+		return done * 100 / total
+	},
+	"ago": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return time.Since(t).Truncate(time.Second).String()
+	},
+	"deref": func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	},
+	"exitCode": func(c *int32) string {
+		if c == nil {
+			return ""
+		}
+		return fmt.Sprint(*c)
+	},
+}
+
+const header = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta http-equiv="refresh" content="` + refreshSeconds + `">
+<title>Scoot Scheduler</title>
+<style>
+body { font-family: sans-serif; margin: 1em 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: left; font-size: 90%; }
+th { background: #eee; }
+.bar { width: 120px; background: #eee; display: inline-block; vertical-align: middle; }
+.bar div { background: #5a5; height: 10px; }
+.idle { color: #393; } .busy { color: #36c; } .lost, .flaky, .offline { color: #c33; } .initializing { color: #999; }
+</style></head><body>
+<p><a href="/ui/">Scheduler</a> &middot; <a href="/ui/api/view">JSON</a> &middot; <a href="/admin/metrics.json?pretty=true">Metrics</a></p>
+`
+
+var indexTmpl = template.Must(template.New("index").Funcs(funcs).Parse(header + `
+<h2>Jobs</h2>
+<p>{{len .View.Jobs}} jobs in progress, {{.TasksRunning}} tasks running, {{.TasksWaiting}} waiting.
+{{if ge .View.TaskThrottle 0}}Accepting up to {{.View.TaskThrottle}} tasks.{{end}}</p>
+<table>
+<tr><th>Job</th><th>Requestor</th><th>Tag</th><th>Priority</th><th>Status</th><th>Progress</th><th>Age</th></tr>
+{{range .View.Jobs}}
+<tr>
+<td><a href="/ui/job/{{.ID}}">{{.ID}}</a></td><td>{{.Requestor}}</td><td>{{.Tag}}</td><td>{{.Priority}}</td>
+<td>{{.Status}}{{if .Killed}} (killed){{end}}</td>
+<td><span class="bar"><div style="width: {{pct .TasksCompleted .TasksTotal}}%"></div></span>
+{{.TasksCompleted}}/{{.TasksTotal}} done, {{.TasksRunning}} running</td>
+<td>{{ago .Created}}</td>
+</tr>
+{{else}}
+<tr><td colspan="7">No jobs in progress</td></tr>
+{{end}}
+</table>
+<h2>Workers</h2>
+<p>{{len .View.Nodes}} workers{{range .NodeCounts}}, {{.Count}} <span class="{{.State}}">{{.State}}</span>{{end}}</p>
+<table>
+<tr><th>Worker</th><th>State</th><th>Job</th><th>Task</th><th>Snapshot</th></tr>
+{{range .View.Nodes}}
+<tr><td>{{.ID}}</td><td class="{{.State}}">{{.State}}</td>
+<td>{{if .JobID}}<a href="/ui/job/{{.JobID}}">{{.JobID}}</a>{{end}}</td><td>{{.TaskID}}</td><td>{{.SnapshotID}}</td></tr>
+{{end}}
+</table>
+</body></html>
+`))
+
+var jobTmpl = template.Must(template.New("job").Funcs(funcs).Parse(header + `
+<h2>Job {{.ID}}</h2>
+<p><a href="/ui/saga/{{.ID}}">Saga log</a> &middot; <a href="/ui/api/saga/{{.ID}}">JSON</a></p>
+{{with .Job}}
+<p>Requested by {{.Requestor}}{{if .Tag}}, tag {{.Tag}}{{end}}, priority {{.Priority}}, {{ago .Created}} ago.</p>
+<p>{{.Status}}{{if .Killed}} (killed){{end}}:
+<span class="bar"><div style="width: {{pct .TasksCompleted .TasksTotal}}%"></div></span>
+{{.TasksCompleted}}/{{.TasksTotal}} done, {{.TasksRunning}} running</p>
+{{else}}
+<p>{{.Status}}</p>
+{{end}}
+<table>
+<tr><th>Task</th><th>Status</th><th>Worker</th><th>Tries</th><th>Exit code</th><th>Error</th><th>Logs</th></tr>
+{{$prefix := .LogPrefix}}
+{{range .Tasks}}
+<tr><td>{{.ID}}</td><td>{{.Status}}</td><td>{{.Node}}</td><td>{{if .Tries}}{{.Tries}}{{end}}</td>
+{{with .Run}}
+<td>{{exitCode .ExitCode}}</td><td>{{deref .Error}}</td>
+<td>{{with deref .OutUri}}<a href="{{.}}">stdout</a>{{end}}
+{{with deref .ErrUri}}<a href="{{.}}">stderr</a>{{end}}
+{{with deref .LogRef}}{{if $prefix}}<a href="{{$prefix}}{{.}}">log</a>{{else}}log: {{.}}{{end}}{{end}}</td>
+{{else}}
+<td></td><td></td><td></td>
+{{end}}
+</tr>
+{{with .Run}}{{if or .StdoutTail .StderrTail}}
+<tr><td colspan="7"><details><summary>Output tail</summary>
+{{with deref .StdoutTail}}stdout:<pre>{{.}}</pre>{{end}}
+{{with deref .StderrTail}}stderr:<pre>{{.}}</pre>{{end}}
+</details></td></tr>
+{{end}}{{end}}
+{{end}}
+</table>
+</body></html>
+`))
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/twitter/scoot/common/thrifthelpers"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/workerapi/gen-go/worker"
+)
+
+// Implements just enough of scheduler.Scheduler, plus scheduler.Viewer, for the UI.
+type fakeScheduler struct {
+	scheduler.Scheduler
+	view  scheduler.View
+	sagas saga.SagaCoordinator
+}
+
+func (f *fakeScheduler) GetView() (scheduler.View, error) { return f.view, nil }
+
+func (f *fakeScheduler) GetSagaCoord() saga.SagaCoordinator { return f.sagas }
+
+func makeFake(t *testing.T) *fakeScheduler {
+	sc := sagalogs.MakeInMemorySagaCoordinatorNoGC()
+	sg, err := sc.MakeSaga("job1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logRef := "log-" + strings.Repeat("a", 40) + ".json"
+	outURI := "http://worker1/output/stdout"
+	stderrTail := "error: <missing> dependency"
+	data, _ := thrifthelpers.JsonSerialize(&worker.RunStatus{
+		Status: worker.Status_COMPLETE, RunId: "0", OutUri: &outURI, LogRef: &logRef, StderrTail: &stderrTail})
+	if err := sg.StartTask("task1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := sg.EndTask("task1", data); err != nil {
+		t.Fatal(err)
+	}
+	if err := sg.StartTask("task2", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return &fakeScheduler{
+		sagas: sc,
+		view: scheduler.View{
+			TaskThrottle: -1,
+			Jobs: []scheduler.JobView{{
+				ID:             "job1",
+				Requestor:      "alice",
+				Status:         sched.InProgress,
+				TasksTotal:     3,
+				TasksCompleted: 1,
+				TasksRunning:   1,
+				Tasks: []scheduler.TaskView{
+					{ID: "task1", Status: sched.Completed, Tries: 1},
+					{ID: "task2", Status: sched.InProgress, Node: "worker2", Tries: 1},
+					{ID: "task3", Status: sched.NotStarted},
+				},
+			}},
+			Nodes: []scheduler.NodeView{
+				{ID: "worker1", State: scheduler.NodeIdle},
+				{ID: "worker2", State: scheduler.NodeBusy, JobID: "job1", TaskID: "task2"},
+				{ID: "worker3", State: scheduler.NodeLost},
+			},
+		},
+	}
+}
+
+func get(t *testing.T, h *Handler, path string) (int, string) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w.Code, w.Body.String()
+}
+
+func assertContains(t *testing.T, body string, expected ...string) {
+	for _, e := range expected {
+		if !strings.Contains(body, e) {
+			t.Errorf("Expected page to contain %q, got:\n%s", e, body)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	h := NewHandler(makeFake(t), Config{})
+	code, body := get(t, h, HttpPath)
+	if code != 200 {
+		t.Fatalf("Expected 200, got %d: %s", code, body)
+	}
+	assertContains(t, body, `href="/ui/job/job1"`, "alice", "1/3 done, 1 running", "1 tasks running, 1 waiting",
+		"3 workers", "worker3", "1 <span class=\"lost\">lost</span>")
+}
+
+func TestJob(t *testing.T) {
+	h := NewHandler(makeFake(t), Config{LogURLPrefix: "http://apiserver/log/"})
+	code, body := get(t, h, HttpPath+"job/job1")
+	if code != 200 {
+		t.Fatalf("Expected 200, got %d: %s", code, body)
+	}
+	assertContains(t, body, "task1", "task2", "task3", "worker2",
+		`href="http://worker1/output/stdout"`, `href="http://apiserver/log/log-`+strings.Repeat("a", 40)+`.json"`,
+		"stderr:<pre>error: &lt;missing&gt; dependency</pre>")
+
+	if code, _ := get(t, h, HttpPath+"job/nosuchjob"); code != 404 {
+		t.Fatalf("Expected 404 for unknown job, got %d", code)
+	}
+}
+
+func TestSaga(t *testing.T) {
+	h := NewHandler(makeFake(t), Config{})
+	code, body := get(t, h, HttpPath+"saga/job1")
+	if code != 200 {
+		t.Fatalf("Expected 200, got %d: %s", code, body)
+	}
+	assertContains(t, body, "Saga job1: In Progress, 4 messages", "End Task", "1 pending tasks: [task2]")
+
+	code, body = get(t, h, HttpPath+"api/saga/job1")
+	if code != 200 {
+		t.Fatalf("Expected 200, got %d: %s", code, body)
+	}
+	tl := saga.Timeline{}
+	if err := json.Unmarshal([]byte(body), &tl); err != nil {
+		t.Fatal(err)
+	}
+	if len(tl.Entries) != 4 || len(tl.Entries[2].RawData) == 0 || tl.Entries[2].DataLen != len(tl.Entries[2].RawData) {
+		t.Fatalf("Expected 4 entries with task1's result, got %+v", tl.Entries)
+	}
+
+	if code, _ := get(t, h, HttpPath+"saga/nosuchjob"); code != 404 {
+		t.Fatalf("Expected 404 for unknown job, got %d", code)
+	}
+}
+
+func TestViewJSON(t *testing.T) {
+	h := NewHandler(makeFake(t), Config{})
+	code, body := get(t, h, HttpPath+"api/view")
+	if code != 200 {
+		t.Fatalf("Expected 200, got %d: %s", code, body)
+	}
+	v := scheduler.View{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Jobs) != 1 || len(v.Nodes) != 3 {
+		t.Fatalf("Unexpected view: %+v", v)
+	}
+}
@@ -68,7 +68,7 @@ func (s *LocalApiStrategy) Startup() ([]string, error) {
 		return nil, err
 	}
 
-	bin, err := s.builder.ApiServer()
+	bin, err := s.builder.Scoot()
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func (s *LocalApiStrategy) Startup() ([]string, error) {
 		grpcPort := scootapi.ApiBundlestoreGRPCPorts + i
 		httpAddr := fmt.Sprintf("localhost:%d", httpPort)
 		grpcAddr := fmt.Sprintf("localhost:%d", grpcPort)
-		cmd := s.cmds.Command(bin, "-http_addr", httpAddr, "-grpc_addr", grpcAddr, "-log_level", s.apiCfg.LogLevel.String())
+		cmd := s.cmds.Command(bin, "apiserver", "-http_addr", httpAddr, "-grpc_addr", grpcAddr, "-log_level", s.apiCfg.LogLevel.String())
 		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", bundlestore.BundlestoreDirEnvVar, bundlestoreStoreDir.Dir))
 		if err := s.cmds.StartCmd(cmd); err != nil {
 			return nil, err
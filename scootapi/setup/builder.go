@@ -9,12 +9,9 @@ import (
 
 // Builder is able to create Scoot binaries
 type Builder interface {
-	// Scheduler returns the path to the Scheduler binary (or an error if it can't be built)
-	Scheduler() (string, error)
-	// Worker returns the path to the Worker binary (or an error if it can't be built)
-	Worker() (string, error)
-	// ApiServer returns the path to the Worker binary (or an error if it can't be built)
-	ApiServer() (string, error)
+	// Scoot returns the path to the scoot binary, which runs the scheduler, worker and apiserver
+	// as subcommands (or an error if it can't be built)
+	Scoot() (string, error)
 }
 
 const repoName = "github.com/twitter/scoot"
@@ -23,11 +20,9 @@ const repoName = "github.com/twitter/scoot"
 type GoBuilder struct {
 	cmds *Cmds
 
-	installed    bool
-	err          error
-	schedBin     string
-	workerBin    string
-	apiserverBin string
+	installed bool
+	err       error
+	scootBin  string
 }
 
 // NewGoBuilder creates a GoBuilder
@@ -67,23 +62,10 @@ func (b *GoBuilder) install() {
 		b.err = err
 		return
 	}
-	binDir := path.Join(goPath, "bin")
-	b.schedBin = path.Join(binDir, "scheduler")
-	b.workerBin = path.Join(binDir, "workerserver")
-	b.apiserverBin = path.Join(binDir, "apiserver")
+	b.scootBin = path.Join(goPath, "bin", "scoot")
 }
 
-func (b *GoBuilder) Scheduler() (string, error) {
+func (b *GoBuilder) Scoot() (string, error) {
 	b.install()
-	return b.schedBin, b.err
-}
-
-func (b *GoBuilder) Worker() (string, error) {
-	b.install()
-	return b.workerBin, b.err
-}
-
-func (b *GoBuilder) ApiServer() (string, error) {
-	b.install()
-	return b.apiserverBin, b.err
+	return b.scootBin, b.err
 }
@@ -42,12 +42,12 @@ func (s *LocalSchedStrategy) Startup() (string, error) {
 		return "", err
 	}
 
-	bin, err := s.builder.Scheduler()
+	bin, err := s.builder.Scoot()
 	if err != nil {
 		return "", err
 	}
 
-	if err := s.cmds.Start(bin,
+	if err := s.cmds.Start(bin, "scheduler",
 		"-thrift_addr", scootapi.DefaultSched_Thrift,
 		"-http_addr", scootapi.DefaultSched_HTTP,
 		"-log_level", s.workersCfg.LogLevel.String(),
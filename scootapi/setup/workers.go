@@ -91,7 +91,7 @@ func (s *LocalWorkersStrategy) StartupWorkers() (string, error) {
 
 	log.Infof("Using %d local workers", s.workersCfg.Count)
 
-	bin, err := s.builder.Worker()
+	bin, err := s.builder.Scoot()
 	if err != nil {
 		return "", err
 	}
@@ -101,7 +101,7 @@ func (s *LocalWorkersStrategy) StartupWorkers() (string, error) {
 		s.nextPort++
 		thriftPort := s.nextPort
 		s.nextPort++
-		if err := s.cmds.Start(bin,
+		if err := s.cmds.Start(bin, "worker",
 			"-thrift_addr", "localhost:"+strconv.Itoa(thriftPort),
 			"-http_addr", "localhost:"+strconv.Itoa(httpPort),
 			"-log_level", s.workersCfg.LogLevel.String(),
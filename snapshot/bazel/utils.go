@@ -3,11 +3,12 @@ package bazel
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/snapshot/snapid"
 )
 
 // Used as arg for fs_util binary
@@ -36,9 +37,11 @@ func validateFsUtilSaveOutput(output []byte) error {
 	if err != nil {
 		return err
 	}
-	sha, size := s[0], s[1]
-	id := fmt.Sprintf("%s-%s-%s", bazel.SnapshotIDPrefix, sha, size)
-	return bazel.ValidateID(id)
+	size, err := strconv.ParseInt(s[1], 10, 64)
+	if err != nil {
+		return err
+	}
+	return snapid.Bazel(s[0], size).Validate()
 }
 
 func splitFsUtilSaveOutput(output []byte) ([]string, error) {
@@ -1,25 +1,37 @@
 package bundlestore
 
 import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/snapshot/store"
 )
 
 type httpServer struct {
 	storeConfig *store.StoreConfig
+	uploads     *uploadSessions
+	listToken   ListToken
+	quota       *cas.QuotaTracker
+	mode        *cas.ModeSwitch
 }
 
-func MakeHTTPServer(cfg *store.StoreConfig) *httpServer {
-	return &httpServer{cfg}
+// Bundles uploaded count against the quota of their tenant in quota, which may be nil for no quotas.
+// Uploads are refused while ms is in a mode that blocks writes, ms may be nil to always allow them.
+func MakeHTTPServer(cfg *store.StoreConfig, listToken ListToken, quota *cas.QuotaTracker, ms *cas.ModeSwitch) *httpServer {
+	return &httpServer{storeConfig: cfg, uploads: newUploadSessions(cfg.Stat), listToken: listToken, quota: quota, mode: ms}
 }
 
 func (s *httpServer) HandleUpload(w http.ResponseWriter, req *http.Request) {
@@ -33,7 +45,13 @@ func (s *httpServer) HandleUpload(w http.ResponseWriter, req *http.Request) {
 		s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
 		return
 	}
-	bundleData := req.Body
+	if err := s.mode.CheckWrite(); err != nil {
+		log.Infof("Mode err: %v --> StatusServiceUnavailable (from %v)", err, req.RemoteAddr)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
+		return
+	}
+	var bundleData io.Reader = req.Body
 
 	exists, err := s.storeConfig.Store.Exists(bundleName)
 	if err != nil {
@@ -48,7 +66,52 @@ func (s *httpServer) HandleUpload(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// Get ttl if defaults were provided during Server construction or if it comes in this request header.
+	ttl, err := s.requestTTL(req)
+	if err != nil {
+		log.Infof("TTL err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error parsing TTL: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
+		return
+	}
+	if cas.IsBlobName(bundleName) {
+		blob, err := spoolVerifiedBlob(bundleName, req.Body)
+		if err != nil {
+			log.Infof("Blob err: %v --> StatusBadRequest (from %v)", err, req.RemoteAddr)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
+			return
+		}
+		defer os.Remove(blob.Name())
+		defer blob.Close()
+		bundleData = blob
+	}
+	// Bundles count against the tenant's quota as they're stored, since their size isn't known up front.
+	var quotaData *cas.QuotaReader
+	if s.quota != nil {
+		quotaData = s.quota.Reader(s.quota.Tenant(req.Header.Get("Authorization")), bundleData)
+		bundleData = quotaData
+	}
+	if err := s.storeConfig.Store.Write(bundleName, bundleData, ttl); err != nil {
+		if quotaData != nil {
+			quotaData.Release()
+			if qerr := quotaData.Exceeded(); qerr != nil {
+				log.Infof("Quota err: %v --> StatusTooManyRequests (from %v)", qerr, req.RemoteAddr)
+				http.Error(w, qerr.Error(), http.StatusTooManyRequests)
+				s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
+				return
+			}
+		}
+		log.Infof("Write err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
+		return
+	}
+	fmt.Fprintf(w, "Successfully wrote bundle %s\n", bundleName)
+	s.storeConfig.Stat.Counter(stats.BundlestoreUploadOkCounter).Inc(1)
+}
+
+// Get ttl if defaults were provided during Server construction or if it comes in this request header.
+func (s *httpServer) requestTTL(req *http.Request) (*store.TTLValue, error) {
 	var ttl *store.TTLValue
 	if s.storeConfig.TTLCfg != nil {
 		ttl = &store.TTLValue{TTL: time.Now().Add(s.storeConfig.TTLCfg.TTL), TTLKey: s.storeConfig.TTLCfg.TTLKey}
@@ -58,10 +121,7 @@ func (s *httpServer) HandleUpload(w http.ResponseWriter, req *http.Request) {
 			continue
 		}
 		if ttlTime, err := time.Parse(time.RFC1123, req.Header.Get(k)); err != nil {
-			log.Infof("TTL err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
-			http.Error(w, fmt.Sprintf("Error parsing TTL: %s", err), http.StatusInternalServerError)
-			s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
-			return
+			return nil, err
 		} else if ttl != nil {
 			ttl.TTL = ttlTime
 		} else {
@@ -69,14 +129,7 @@ func (s *httpServer) HandleUpload(w http.ResponseWriter, req *http.Request) {
 		}
 		break
 	}
-	if err := s.storeConfig.Store.Write(bundleName, bundleData, ttl); err != nil {
-		log.Infof("Write err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
-		http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusInternalServerError)
-		s.storeConfig.Stat.Counter(stats.BundlestoreUploadErrCounter).Inc(1)
-		return
-	}
-	fmt.Fprintf(w, "Successfully wrote bundle %s\n", bundleName)
-	s.storeConfig.Stat.Counter(stats.BundlestoreUploadOkCounter).Inc(1)
+	return ttl, nil
 }
 
 func (s *httpServer) HandleDownload(w http.ResponseWriter, req *http.Request) {
@@ -113,12 +166,95 @@ func (s *httpServer) HandleDownload(w http.ResponseWriter, req *http.Request) {
 	s.storeConfig.Stat.Counter(stats.BundlestoreDownloadOkCounter).Inc(1)
 }
 
+func isListRequest(req *http.Request) bool {
+	_, list := req.URL.Query()[store.ListParam]
+	return list && req.Method == "GET"
+}
+
+// Lists the stored bundles, see store.ListParam.
+func (s *httpServer) HandleList(w http.ResponseWriter, req *http.Request) {
+	log.Infof("Listing %v %v (from %v)", req.Host, req.URL, req.RemoteAddr)
+	defer s.storeConfig.Stat.Latency(stats.BundlestoreListLatency_ms).Time().Stop()
+	s.storeConfig.Stat.Counter(stats.BundlestoreListCounter).Inc(1)
+	if err := s.checkListToken(req); err != nil {
+		log.Infof("List auth err: %v --> StatusUnauthorized (from %v)", err, req.RemoteAddr)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		s.storeConfig.Stat.Counter(stats.BundlestoreListErrCounter).Inc(1)
+		return
+	}
+	q := req.URL.Query()
+	result, err := s.storeConfig.Store.List(q.Get(store.ListPrefixParam), q.Get(store.ListCursorParam))
+	if err != nil {
+		log.Infof("List err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error listing bundles: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreListErrCounter).Inc(1)
+		return
+	}
+	asJson, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreListErrCounter).Inc(1)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(asJson)
+	s.storeConfig.Stat.Counter(stats.BundlestoreListOkCounter).Inc(1)
+}
+
+// Checks the request carries the configured list token. Requests are refused if there's no token to check against.
+func (s *httpServer) checkListToken(req *http.Request) error {
+	if s.listToken == "" {
+		return errors.New("Listing is disabled, the bundlestore has no list token configured")
+	}
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.listToken)) != 1 {
+		return errors.New("Invalid list token")
+	}
+	return nil
+}
+
+// Writes a CAS blob uploaded over HTTP to a temp file, returning it ready to be read
+// if its contents match its name, since the HTTP API doesn't check digests like the CAS API does.
+func spoolVerifiedBlob(name string, data io.Reader) (*os.File, error) {
+	f, err := ioutil.TempFile("", "blob-upload-")
+	if err != nil {
+		return nil, err
+	}
+	ok := false
+	defer func() {
+		if !ok {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := io.Copy(f, data); err != nil {
+		return nil, err
+	}
+	if valid, err := cas.VerifyBlobFile(f.Name(), name); err != nil {
+		return nil, err
+	} else if !valid {
+		return nil, fmt.Errorf("Contents of %s don't match its digest", name)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	ok = true
+	return f, nil
+}
+
 var bundleRE *regexp.Regexp = regexp.MustCompile("^bs-[a-z0-9]{40}.bundle")
 
-// Check for name enforcement for HTTP API
+// Check for name enforcement for HTTP API. Persisted run logs and CAS blobs, so regions can
+// replicate them to each other (see store.RoutingStore), are also accepted.
 func checkBundleName(name string) error {
 	if ok := bundleRE.MatchString(name); ok {
 		return nil
 	}
+	if cas.IsBlobName(name) {
+		return nil
+	}
+	if store.IsLogName(name) {
+		return nil
+	}
 	return fmt.Errorf("Error with bundleName, expected %q, got: %s", bundleRE, name)
 }
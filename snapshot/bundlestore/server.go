@@ -24,25 +24,55 @@ type Server struct {
 	casServer   bazel.GRPCServer
 }
 
+// Secret that list requests must carry as "Authorization: Bearer <token>", see store.ListParam.
+// Listing is refused if it's empty.
+type ListToken string
+
 // Make a new server that delegates to an underlying store.
 // TTL may be nil, in which case defaults are applied downstream.
 // TTL duration may be overriden by request headers, but we always pass this TTLKey to the store.
-func MakeServer(s store.Store, ttl *store.TTLConfig, stat stats.StatsReceiver, gc *bazel.GRPCConfig) *Server {
+// ec configures caching of CAS existence checks and may be nil, in which case defaults are applied.
+// shc configures sharding CAS digests across a cluster of servers and may be nil to store them all locally.
+// bl limits the size of blobs uploaded to the CAS, and may be nil, in which case defaults are applied.
+// ms sets whether the CAS and bundle uploads are read-only or in maintenance while serving, and may be nil to always serve normally.
+// up configures the CAS as a caching proxy for an upstream CAS, and may be nil to serve only from the store.
+// qc limits the bytes each tenant stores through the CAS, ActionCache and bundle uploads, and may be nil for no limits.
+// tc configures the CAS TTL report, and may be nil for defaults.
+// lt authenticates requests listing the store, which are refused if it's empty.
+// gc may be nil to serve only bundles, without a CAS.
+func MakeServer(s store.Store, ttl *store.TTLConfig, stat stats.StatsReceiver, gc *bazel.GRPCConfig,
+	ec *cas.ExistenceCacheConfig, shc *cas.ShardConfig, bl *cas.BlobLimitConfig, ms *cas.ModeSwitch,
+	up *cas.UpstreamConfig, qc *cas.QuotaConfig, tc *cas.TTLReportConfig, lt ListToken) *Server {
 	scopedStat := stat.Scope("bundlestoreServer")
 	go stats.StartUptimeReporting(scopedStat, stats.BundlestoreUptime_ms, stats.BundlestoreServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	cfg := &store.StoreConfig{Store: s, TTLCfg: ttl, Stat: scopedStat}
 	log.Infof("Starting new bundlestore.Server with root: %s", s.Root())
+	quota := cas.StartQuotaTracker(qc, stat)
 
-	return &Server{
+	server := &Server{
 		storeConfig: cfg,
-		httpServer:  MakeHTTPServer(cfg),
-		casServer:   cas.MakeCASServer(gc, cfg, stat),
+		httpServer:  MakeHTTPServer(cfg, lt, quota, ms),
+	}
+	// Left unset rather than holding a nil *casServer, so a Server without a CAS has a nil casServer.
+	if gc != nil {
+		server.casServer = cas.MakeCASServer(gc, cfg, ec, shc, bl, ms, up, quota, tc, stat)
 	}
+	return server
 }
 
 // Implements http.Handler interface
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.storeConfig.Stat.Counter(stats.BundlestoreRequestCounter).Inc(1)
+	if isUploadSessionRequest(req) {
+		s.httpServer.HandleUploadSession(w, req)
+		s.storeConfig.Stat.Counter(stats.BundlestoreRequestOkCounter).Inc(1)
+		return
+	}
+	if isListRequest(req) {
+		s.httpServer.HandleList(w, req)
+		s.storeConfig.Stat.Counter(stats.BundlestoreRequestOkCounter).Inc(1)
+		return
+	}
 	switch req.Method {
 	case "POST":
 		s.httpServer.HandleUpload(w, req)
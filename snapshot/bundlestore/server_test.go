@@ -2,14 +2,19 @@ package bundlestore
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/snapshot/store"
 )
@@ -29,7 +34,7 @@ func TestServer(t *testing.T) {
 	statsRegistry := stats.NewFinagleStatsRegistry()
 	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
 	stats.StatReportIntvl = 20 * time.Millisecond
-	server := MakeServer(fakeStore, nil, statsReceiver, nil)
+	server := MakeServer(fakeStore, nil, statsReceiver, nil, nil, nil, nil, nil, nil, nil, nil, "")
 	mux := http.NewServeMux()
 	mux.Handle("/bundle/", server)
 	go func() {
@@ -278,3 +283,295 @@ func TestRetry(t *testing.T) {
 		t.Fatalf("Expected 3 tries, got: %d", server.counter)
 	}
 }
+
+// A Client that loses the response to every other chunk it sends, after the server received the chunk.
+type lossyClient struct {
+	client *http.Client
+	chunks int
+}
+
+func (c *lossyClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil || req.Method != "PUT" {
+		return resp, err
+	}
+	c.chunks++
+	if c.chunks%2 == 1 {
+		resp.Body.Close()
+		return nil, errors.New("connection reset")
+	}
+	return resp, nil
+}
+
+// A Client that fails every chunk after the first few it sends, without sending them.
+type cutClient struct {
+	client *http.Client
+	chunks int
+}
+
+func (c *cutClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == "PUT" {
+		if c.chunks == 0 {
+			return nil, errors.New("connection lost")
+		}
+		c.chunks--
+	}
+	return c.client.Do(req)
+}
+
+func TestResumableUpload(t *testing.T) {
+	now := time.Time{}.Add(time.Minute)
+	ttl := &store.TTLValue{TTL: now.Add(time.Hour), TTLKey: store.DefaultTTLKey}
+	fakeStore := &store.FakeStore{TTL: ttl}
+
+	listener, _ := net.Listen("tcp", "localhost:0")
+	defer listener.Close()
+	server := MakeServer(fakeStore, nil, stats.NilStatsReceiver(), nil, nil, nil, nil, nil, nil, nil, nil, "")
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", server)
+	go func() {
+		http.Serve(listener, mux)
+	}()
+	rootUri := "http://" + listener.Addr().String() + "/bundle/"
+
+	// Upload in chunks, resuming after each lost response.
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	hs := store.MakeCustomHTTPStore(rootUri, &lossyClient{client: &http.Client{Timeout: 1 * time.Second}})
+	bundle1ID := "bs-0000000000000000000000000000000000000001.bundle"
+	var progress []int64
+	err := hs.(store.ResumableWriter).WriteResumable(bundle1ID, bytes.NewReader(data), int64(len(data)), ttl, 16,
+		func(p store.UploadProgress) {
+			if p.Bundle != bundle1ID || p.Length != int64(len(data)) {
+				t.Errorf("Unexpected progress: %+v", p)
+			}
+			progress = append(progress, p.Received)
+		})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(progress, []int64{16, 32, 48, 64, 80, 96, 100}) {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+	if storeData, _ := fakeStore.Files.Load(bundle1ID); !reflect.DeepEqual(storeData, data) {
+		t.Fatalf("Failed to assemble data, got: %s", storeData)
+	}
+	if n := server.httpServer.uploads.count(); n != 0 {
+		t.Errorf("Expected no upload sessions left, got %d", n)
+	}
+
+	// Step through a session, resending an overlapping chunk.
+	client := &http.Client{Timeout: 1 * time.Second}
+	do := func(method, uri, body string, expected int) *http.Response {
+		req, _ := http.NewRequest(method, uri, strings.NewReader(body))
+		req.Header.Set(store.UploadLengthHeader, "10")
+		req.Header.Set(store.DefaultTTLKey, ttl.TTL.Format(time.RFC1123))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if resp.StatusCode != expected {
+			t.Fatalf("%s %s: expected %d, got %s", method, uri, expected, resp.Status)
+		}
+		return resp
+	}
+	bundle2ID := "bs-0000000000000000000000000000000000000002.bundle"
+	resp := do("POST", rootUri+bundle2ID+"?uploads", "", http.StatusCreated)
+	resp.Body.Close()
+	sessionUri := rootUri + bundle2ID + "?uploadId=" + resp.Header.Get(store.UploadIdHeader)
+
+	do("PUT", sessionUri+"&offset=5", "56789", http.StatusConflict).Body.Close()
+	do("PUT", sessionUri+"&offset=0", "01234", http.StatusOK).Body.Close()
+	resp = do("PUT", sessionUri+"&offset=3", "34567", http.StatusOK)
+	resp.Body.Close()
+	if offset := resp.Header.Get(store.UploadOffsetHeader); offset != "8" {
+		t.Errorf("Expected 8 bytes received, got %s", offset)
+	}
+	resp = do("GET", sessionUri, "", http.StatusOK)
+	p := store.UploadProgress{}
+	if body, err := ioutil.ReadAll(resp.Body); err != nil {
+		t.Fatalf(err.Error())
+	} else if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf(err.Error())
+	}
+	resp.Body.Close()
+	if p.Bundle != bundle2ID || p.Received != 8 || p.Length != 10 {
+		t.Errorf("Unexpected progress: %+v", p)
+	}
+	do("POST", sessionUri, "", http.StatusConflict).Body.Close()
+	do("PUT", sessionUri+"&offset=8", "89", http.StatusOK).Body.Close()
+	do("POST", sessionUri, "", http.StatusOK).Body.Close()
+	if storeData, _ := fakeStore.Files.Load(bundle2ID); !reflect.DeepEqual(storeData, []byte("0123456789")) {
+		t.Fatalf("Failed to assemble data, got: %s", storeData)
+	}
+	do("GET", sessionUri, "", http.StatusNotFound).Body.Close()
+
+	// Abort a session.
+	bundle3ID := "bs-0000000000000000000000000000000000000003.bundle"
+	resp = do("POST", rootUri+bundle3ID+"?uploads", "", http.StatusCreated)
+	resp.Body.Close()
+	sessionUri = rootUri + bundle3ID + "?uploadId=" + resp.Header.Get(store.UploadIdHeader)
+	do("DELETE", sessionUri, "", http.StatusOK).Body.Close()
+	do("PUT", sessionUri+"&offset=0", "0", http.StatusNotFound).Body.Close()
+	if ok, _ := fakeStore.Exists(bundle3ID); ok {
+		t.Errorf("Expected aborted upload not to be written")
+	}
+
+	// Resume an interrupted upload by id, the session is kept when its chunks fail.
+	bundle4ID := "bs-0000000000000000000000000000000000000004.bundle"
+	hs = store.MakeCustomHTTPStore(rootUri, &cutClient{client: client, chunks: 2})
+	err = hs.(store.ResumableWriter).WriteResumable(bundle4ID, bytes.NewReader(data), int64(len(data)), ttl, 16, nil)
+	ie, ok := err.(*store.UploadInterruptedError)
+	if !ok || ie.Received != 32 {
+		t.Fatalf("Expected upload to be interrupted after 32 bytes, got: %v", err)
+	}
+	if n := server.httpServer.uploads.count(); n != 1 {
+		t.Errorf("Expected the interrupted upload session to be kept, got %d", n)
+	}
+	hs = store.MakeCustomHTTPStore(rootUri, client)
+	if err := hs.(store.ResumableWriter).ResumeUpload(bundle4ID, ie.UploadId, bytes.NewReader(data), 16,
+		func(p store.UploadProgress) {
+			if p.Received <= 32 || p.Length != int64(len(data)) {
+				t.Errorf("Unexpected progress: %+v", p)
+			}
+		}); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if storeData, _ := fakeStore.Files.Load(bundle4ID); !reflect.DeepEqual(storeData, data) {
+		t.Fatalf("Failed to assemble resumed data, got: %s", storeData)
+	}
+	if n := server.httpServer.uploads.count(); n != 0 {
+		t.Errorf("Expected no upload sessions left, got %d", n)
+	}
+
+	// Sessions and the bytes they hold are capped.
+	defer func(sessions int, bytes int64) {
+		MaxUploadSessions, MaxUploadSessionBytes = sessions, bytes
+	}(MaxUploadSessions, MaxUploadSessionBytes)
+	MaxUploadSessions, MaxUploadSessionBytes = 1, 4
+	bundle5ID := "bs-0000000000000000000000000000000000000005.bundle"
+	resp = do("POST", rootUri+bundle5ID+"?uploads", "", http.StatusCreated)
+	resp.Body.Close()
+	sessionUri = rootUri + bundle5ID + "?uploadId=" + resp.Header.Get(store.UploadIdHeader)
+	do("POST", rootUri+bundle3ID+"?uploads", "", http.StatusServiceUnavailable).Body.Close()
+	do("PUT", sessionUri+"&offset=0", "01234", http.StatusOK).Body.Close()
+	do("PUT", sessionUri+"&offset=5", "56789", http.StatusInsufficientStorage).Body.Close()
+	do("DELETE", sessionUri, "", http.StatusOK).Body.Close()
+	do("POST", rootUri+bundle3ID+"?uploads", "", http.StatusCreated).Body.Close()
+}
+
+func TestList(t *testing.T) {
+	fakeStore := &store.FakeStore{}
+	bundle1ID := "bs-0000000000000000000000000000000000000001.bundle"
+	bundle2ID := "bs-0000000000000000000000000000000000000002.bundle"
+	fakeStore.Files.Store(bundle2ID, []byte("two"))
+	fakeStore.Files.Store(bundle1ID, []byte("one"))
+	fakeStore.Files.Store("other", []byte("other"))
+
+	listener, _ := net.Listen("tcp", "localhost:0")
+	defer listener.Close()
+	server := MakeServer(fakeStore, nil, stats.NilStatsReceiver(), nil, nil, nil, nil, nil, nil, nil, nil, "secret")
+	unauthServer := MakeServer(fakeStore, nil, stats.NilStatsReceiver(), nil, nil, nil, nil, nil, nil, nil, nil, "")
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", server)
+	mux.Handle("/unauth/", unauthServer)
+	go func() {
+		http.Serve(listener, mux)
+	}()
+
+	rootUri := "http://" + listener.Addr().String()
+	hs := store.MakeHTTPStoreWithListToken(rootUri+"/bundle/", "secret")
+	r, err := hs.List("bs-", "")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(r.Names, []string{bundle1ID, bundle2ID}) || r.Cursor != "" {
+		t.Fatalf("Expected both bundles in order, got %+v", r)
+	}
+	if r, err = hs.List("bs-", bundle1ID); err != nil || !reflect.DeepEqual(r.Names, []string{bundle2ID}) {
+		t.Fatalf("Expected the bundle after the cursor, got %+v %v", r, err)
+	}
+
+	// Listing without the token, with the wrong one, or from a server without one is refused.
+	for _, s := range []store.Store{
+		store.MakeCustomHTTPStore(rootUri+"/bundle/", &http.Client{Timeout: 1 * time.Second}),
+		store.MakeHTTPStoreWithListToken(rootUri+"/bundle/", "guess"),
+		store.MakeHTTPStoreWithListToken(rootUri+"/unauth/", "secret"),
+	} {
+		if r, err := s.List("bs-", ""); err == nil || !strings.Contains(err.Error(), "401") {
+			t.Fatalf("Expected listing %s to be unauthorized, got %+v %v", s.Root(), r, err)
+		}
+	}
+}
+
+func TestUploadQuota(t *testing.T) {
+	fakeStore := &store.FakeStore{}
+	qc := &cas.QuotaConfig{
+		Bytes:        map[string]int64{"teamA": 10},
+		DefaultBytes: 1,
+		Tokens:       map[string]string{"secretA": "teamA"},
+	}
+
+	listener, _ := net.Listen("tcp", "localhost:0")
+	defer listener.Close()
+	server := MakeServer(fakeStore, nil, stats.NilStatsReceiver(), nil, nil, nil, nil, nil, nil, qc, nil, "")
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", server)
+	go func() {
+		http.Serve(listener, mux)
+	}()
+	rootUri := "http://" + listener.Addr().String() + "/bundle/"
+	client := &http.Client{Timeout: 1 * time.Second}
+	upload := func(bundle, token string, status int) {
+		req, _ := http.NewRequest("POST", rootUri+bundle, strings.NewReader("baz_data"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("Expected %d uploading %s, got %s", status, bundle, resp.Status)
+		}
+	}
+
+	// Uploads count against the quota of the tenant whose token they carry, or the anonymous tenant's.
+	bundle1ID := "bs-0000000000000000000000000000000000000001.bundle"
+	bundle2ID := "bs-0000000000000000000000000000000000000002.bundle"
+	upload(bundle1ID, "", http.StatusTooManyRequests)
+	upload(bundle1ID, "guess", http.StatusTooManyRequests)
+	upload(bundle1ID, "secretA", http.StatusOK)
+	upload(bundle2ID, "secretA", http.StatusTooManyRequests)
+	if _, ok := fakeStore.Files.Load(bundle2ID); ok {
+		t.Fatal("Expected the bundle over quota not to be stored")
+	}
+}
+
+func TestUploadMode(t *testing.T) {
+	fakeStore := &store.FakeStore{}
+	ms := cas.NewModeSwitch(cas.ModeReadOnly, "", nil)
+	server := MakeServer(fakeStore, nil, stats.NilStatsReceiver(), nil, nil, nil, nil, ms, nil, nil, nil, "")
+	bundleID := "bs-0000000000000000000000000000000000000001.bundle"
+	upload := func() int {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest("POST", "/bundle/"+bundleID, strings.NewReader("baz_data")))
+		return w.Code
+	}
+
+	// Uploads are refused while the CAS is read-only or in maintenance.
+	for _, m := range []cas.Mode{cas.ModeReadOnly, cas.ModeMaintenance} {
+		ms.Set(m)
+		if code := upload(); code != http.StatusServiceUnavailable {
+			t.Fatalf("Expected uploads refused in mode %s, got %d", m, code)
+		}
+	}
+	if _, ok := fakeStore.Files.Load(bundleID); ok {
+		t.Fatal("Expected the refused bundle not to be stored")
+	}
+
+	ms.Set(cas.ModeNormal)
+	if code := upload(); code != http.StatusOK {
+		t.Fatalf("Expected uploads served in normal mode, got %d", code)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/ice"
@@ -48,6 +49,14 @@ func (m module) Install(b *ice.MagicBag) {
 	b.Put(MakeFileStoreInEnvOrTemp)
 	b.Put(MakeServer)
 	b.Put(DefaultStore)
+	b.Put(func() *cas.ExistenceCacheConfig { return nil })
+	b.Put(func() *cas.ShardConfig { return nil })
+	b.Put(func() *cas.BlobLimitConfig { return nil })
+	b.Put(func() *cas.ModeSwitch { return nil })
+	b.Put(func() *cas.UpstreamConfig { return nil })
+	b.Put(func() *cas.QuotaConfig { return nil })
+	b.Put(func() *cas.TTLReportConfig { return nil })
+	b.Put(func() ListToken { return "" })
 }
 
 // Creates a MagicBag for a default bundlestore server and returns it
@@ -93,8 +102,11 @@ func RunServer(bag *ice.MagicBag, schema jsonconfig.Schema, config []byte) {
 	go func() {
 		errCh <- servers.http.Serve()
 	}()
-	go func() {
-		errCh <- servers.grpc.Serve()
-	}()
+	// Without a GRPCConfig there's no CAS to serve, only bundles over HTTP.
+	if servers.grpc != nil {
+		go func() {
+			errCh <- servers.grpc.Serve()
+		}()
+	}
 	log.Fatal("Error serving:", <-errCh)
 }
@@ -0,0 +1,381 @@
+package bundlestore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+var (
+	// Sessions that haven't been used in this long are removed, checked every UploadSessionExpiryInterval
+	// and whenever a new session is started.
+	UploadSessionExpiry         = 24 * time.Hour
+	UploadSessionExpiryInterval = time.Hour
+
+	// Most sessions in progress, further sessions are rejected until some finish or expire.
+	MaxUploadSessions = 1000
+	// Most bytes held in the temp files of sessions in progress. Chunks are rejected once it's reached,
+	// so it can be exceeded by up to a chunk per session.
+	MaxUploadSessionBytes int64 = 64 * 1024 * 1024 * 1024
+)
+
+// Returned when starting a session while MaxUploadSessions are in progress.
+var errTooManyUploadSessions = errors.New("too many upload sessions in progress")
+
+// A resumable upload in progress, with the chunks received so far appended to a temp file.
+type uploadSession struct {
+	mu       sync.Mutex // Serializes writes of chunks and assembly of the bundle.
+	id       string
+	bundle   string
+	ttl      *store.TTLValue
+	length   int64 // -1 if unknown.
+	file     *os.File
+	received int64
+	done     bool      // Set once assembled, aborted or expired, after which the session's file is removed.
+	updated  time.Time // When the session was last used, guarded by uploadSessions.mu.
+}
+
+func (u *uploadSession) progress() store.UploadProgress {
+	return store.UploadProgress{UploadId: u.id, Bundle: u.bundle, Received: u.received, Length: u.length}
+}
+
+// Appends the part of the chunk past what was already received. offset is where the chunk starts in the bundle,
+// and may be before the end of what was received if the response to an earlier chunk was lost.
+// Returns false without reading the chunk if offset is past the end of what was received.
+// Whatever part of the chunk was written before a failed read is kept, for the client to resume after it.
+func (u *uploadSession) write(offset int64, chunk io.Reader) (ok bool, err error) {
+	if offset > u.received {
+		return false, nil
+	}
+	if _, err := io.CopyN(ioutil.Discard, chunk, u.received-offset); err != nil {
+		if err == io.EOF {
+			return true, nil
+		}
+		return true, err
+	}
+	n, err := io.Copy(u.file, chunk)
+	u.received += n
+	return true, err
+}
+
+type uploadSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*uploadSession
+	bytes    int64 // Received by sessions in progress, updated atomically.
+	stat     stats.StatsReceiver
+}
+
+// Sessions are expired every UploadSessionExpiryInterval for as long as the server runs.
+func newUploadSessions(stat stats.StatsReceiver) *uploadSessions {
+	s := &uploadSessions{sessions: map[string]*uploadSession{}, stat: stat}
+	go func() {
+		for range time.Tick(UploadSessionExpiryInterval) {
+			s.expire()
+		}
+	}()
+	return s
+}
+
+func (s *uploadSessions) start(bundle string, length int64, ttl *store.TTLValue) (*uploadSession, error) {
+	s.expire()
+	if s.count() >= MaxUploadSessions {
+		return nil, errTooManyUploadSessions
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	f, err := ioutil.TempFile("", "bundle-upload-")
+	if err != nil {
+		return nil, err
+	}
+	u := &uploadSession{
+		id:      hex.EncodeToString(b),
+		bundle:  bundle,
+		ttl:     ttl,
+		length:  length,
+		file:    f,
+		updated: time.Now(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[u.id] = u
+	return u, nil
+}
+
+// Returns the session with the given id uploading bundle, or nil. The session is marked as updated now.
+func (s *uploadSessions) get(id, bundle string) *uploadSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.sessions[id]; ok && u.bundle == bundle {
+		u.updated = time.Now()
+		return u
+	}
+	return nil
+}
+
+// Drops the session and removes its temp file. Callers hold u.mu.
+func (s *uploadSessions) remove(u *uploadSession) {
+	s.mu.Lock()
+	delete(s.sessions, u.id)
+	s.mu.Unlock()
+	s.close(u)
+}
+
+// Removes the session's temp file. Callers hold u.mu.
+func (s *uploadSessions) close(u *uploadSession) {
+	u.done = true
+	u.file.Close()
+	os.Remove(u.file.Name())
+	s.addBytes(-u.received)
+}
+
+// Records n more bytes received, or removed if negative, by sessions in progress.
+func (s *uploadSessions) addBytes(n int64) {
+	s.stat.Gauge(stats.BundlestoreUploadSessionBytesGauge).Update(atomic.AddInt64(&s.bytes, n))
+}
+
+// Returns whether sessions in progress hold MaxUploadSessionBytes.
+func (s *uploadSessions) full() bool {
+	return atomic.LoadInt64(&s.bytes) >= MaxUploadSessionBytes
+}
+
+func (s *uploadSessions) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// Removes sessions that haven't been used in UploadSessionExpiry.
+func (s *uploadSessions) expire() {
+	s.mu.Lock()
+	var expired []*uploadSession
+	for id, u := range s.sessions {
+		if time.Since(u.updated) > UploadSessionExpiry {
+			delete(s.sessions, id)
+			expired = append(expired, u)
+		}
+	}
+	s.mu.Unlock()
+	for _, u := range expired {
+		u.mu.Lock()
+		if !u.done {
+			log.Infof("Expiring upload session %s of %s after receiving %d bytes", u.id, u.bundle, u.received)
+			s.close(u)
+		}
+		u.mu.Unlock()
+	}
+	if len(expired) > 0 {
+		s.stat.Gauge(stats.BundlestoreUploadSessionsGauge).Update(int64(s.count()))
+	}
+}
+
+// Resumable upload requests either start a session or carry the id of one.
+func isUploadSessionRequest(req *http.Request) bool {
+	q := req.URL.Query()
+	_, start := q[store.UploadsParam]
+	return (start && req.Method == "POST") || q.Get(store.UploadIdParam) != ""
+}
+
+// Handles the requests of resumable uploads, see store.ResumableWriter.
+func (s *httpServer) HandleUploadSession(w http.ResponseWriter, req *http.Request) {
+	log.Infof("Upload session request %v %v (from %v)", req.Method, req.URL, req.RemoteAddr)
+	bundleName := strings.TrimPrefix(req.URL.Path, "/bundle/")
+	err := checkBundleName(bundleName)
+	if err == nil && cas.IsBlobName(bundleName) {
+		err = fmt.Errorf("CAS blobs are verified as they're uploaded, so they can't be uploaded in chunks: %s", bundleName)
+	}
+	if err != nil {
+		log.Infof("Bundlename err: %v --> StatusBadRequest (from %v)", err, req.RemoteAddr)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	id := req.URL.Query().Get(store.UploadIdParam)
+	// Starting, writing to and assembling uploads are refused while the mode blocks writes.
+	if id == "" || req.Method == "PUT" || req.Method == "POST" {
+		if err := s.mode.CheckWrite(); err != nil {
+			log.Infof("Mode err: %v --> StatusServiceUnavailable (from %v)", err, req.RemoteAddr)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+			return
+		}
+	}
+	if id == "" {
+		s.startUploadSession(w, req, bundleName)
+		return
+	}
+
+	u := s.uploads.get(id, bundleName)
+	if u == nil {
+		log.Infof("Upload session %s of %s not found --> StatusNotFound (from %v)", id, bundleName, req.RemoteAddr)
+		http.NotFound(w, req)
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.done {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set(store.UploadOffsetHeader, strconv.FormatInt(u.received, 10))
+
+	switch req.Method {
+	case "PUT":
+		s.writeUploadChunk(w, req, u)
+	case "HEAD":
+		fallthrough
+	case "GET":
+		asJson, err := json.Marshal(u.progress())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(asJson)
+	case "POST":
+		s.assembleUpload(w, req, u)
+	case "DELETE":
+		log.Infof("Aborting upload session %s of %s after receiving %d bytes", u.id, u.bundle, u.received)
+		s.uploads.remove(u)
+		s.storeConfig.Stat.Gauge(stats.BundlestoreUploadSessionsGauge).Update(int64(s.uploads.count()))
+		fmt.Fprintf(w, "Aborted upload session %s\n", u.id)
+	default:
+		log.Infof("Request err: %v --> StatusMethodNotAllowed (from %v)", req.Method, req.RemoteAddr)
+		http.Error(w, "only support PUT, GET, POST and DELETE of upload sessions", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *httpServer) startUploadSession(w http.ResponseWriter, req *http.Request, bundleName string) {
+	exists, err := s.storeConfig.Store.Exists(bundleName)
+	if err != nil {
+		log.Infof("Exists err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error checking if bundle exists: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	if exists {
+		// No session is started, the missing UploadIdHeader tells the client there's nothing to upload.
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadExistingCounter).Inc(1)
+		fmt.Fprintf(w, "Bundle %s already exists, no-op and return\n", bundleName)
+		return
+	}
+
+	length := int64(-1)
+	if l := req.Header.Get(store.UploadLengthHeader); l != "" {
+		if length, err = strconv.ParseInt(l, 10, 64); err != nil || length < 0 {
+			log.Infof("Length err: %q --> StatusBadRequest (from %v)", l, req.RemoteAddr)
+			http.Error(w, fmt.Sprintf("Invalid %s: %q", store.UploadLengthHeader, l), http.StatusBadRequest)
+			s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+			return
+		}
+	}
+	ttl, err := s.requestTTL(req)
+	if err != nil {
+		log.Infof("TTL err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error parsing TTL: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	u, err := s.uploads.start(bundleName, length, ttl)
+	if err == errTooManyUploadSessions {
+		log.Infof("Upload session err: %v --> StatusServiceUnavailable (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error starting upload session: %s, retry later", err), http.StatusServiceUnavailable)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionFullCounter).Inc(1)
+		return
+	} else if err != nil {
+		log.Infof("Upload session err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error starting upload session: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	log.Infof("Started upload session %s of %s, length: %d", u.id, bundleName, length)
+	s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionCounter).Inc(1)
+	s.storeConfig.Stat.Gauge(stats.BundlestoreUploadSessionsGauge).Update(int64(s.uploads.count()))
+	w.Header().Set(store.UploadIdHeader, u.id)
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, u.id)
+}
+
+func (s *httpServer) writeUploadChunk(w http.ResponseWriter, req *http.Request, u *uploadSession) {
+	offset, err := strconv.ParseInt(req.URL.Query().Get(store.OffsetParam), 10, 64)
+	if err != nil || offset < 0 {
+		log.Infof("Offset err: %v --> StatusBadRequest (from %v)", err, req.RemoteAddr)
+		http.Error(w, "A non-negative offset must be provided with each chunk", http.StatusBadRequest)
+		return
+	}
+	s.storeConfig.Stat.Counter(stats.BundlestoreUploadChunkCounter).Inc(1)
+	if s.uploads.full() {
+		log.Infof("Upload sessions hold %d bytes --> StatusInsufficientStorage (from %v)", MaxUploadSessionBytes, req.RemoteAddr)
+		http.Error(w, "Too many bytes held by upload sessions in progress, retry later", http.StatusInsufficientStorage)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionFullCounter).Inc(1)
+		return
+	}
+	received := u.received
+	ok, err := u.write(offset, req.Body)
+	s.uploads.addBytes(u.received - received)
+	w.Header().Set(store.UploadOffsetHeader, strconv.FormatInt(u.received, 10))
+	if !ok {
+		log.Infof("Chunk at %d past %d bytes received --> StatusConflict (from %v)", offset, u.received, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Chunk starts at %d, only %d bytes received", offset, u.received), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Infof("Chunk err: %v after %d bytes received --> StatusInternalServerError (from %v)", err, u.received, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error writing chunk: %s", err), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "Received %d bytes of %s\n", u.received, u.bundle)
+}
+
+// Writes the bundle from the chunks received to the store and ends the session.
+// The session is kept if writing fails, for the client to retry.
+func (s *httpServer) assembleUpload(w http.ResponseWriter, req *http.Request, u *uploadSession) {
+	if u.length >= 0 && u.received != u.length {
+		log.Infof("Received %d of %d bytes --> StatusConflict (from %v)", u.received, u.length, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Received %d of %d bytes", u.received, u.length), http.StatusConflict)
+		return
+	}
+	if _, err := u.file.Seek(0, io.SeekStart); err != nil {
+		log.Infof("Seek err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error reading upload: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	tenant := s.quota.Tenant(req.Header.Get("Authorization"))
+	if err := s.quota.Reserve(tenant, u.received); err != nil {
+		log.Infof("Quota err: %v --> StatusTooManyRequests (from %v)", err, req.RemoteAddr)
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	if err := s.storeConfig.Store.Write(u.bundle, io.LimitReader(u.file, u.received), u.ttl); err != nil {
+		s.quota.Release(tenant, u.received)
+		log.Infof("Write err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionErrCounter).Inc(1)
+		return
+	}
+	log.Infof("Assembled %s from upload session %s, %d bytes", u.bundle, u.id, u.received)
+	s.uploads.remove(u)
+	s.storeConfig.Stat.Counter(stats.BundlestoreUploadSessionOkCounter).Inc(1)
+	s.storeConfig.Stat.Gauge(stats.BundlestoreUploadSessionsGauge).Update(int64(s.uploads.count()))
+	fmt.Fprintf(w, "Successfully wrote bundle %s\n", u.bundle)
+}
@@ -87,6 +87,7 @@ func MakeDBCLI(injector DBInjector) *cobra.Command {
 
 	add(&ingestGitWorkingDirCommand{}, createCobraCmd)
 	add(&ingestGitCommitCommand{}, createCobraCmd)
+	add(&ingestGitRemoteRefCommand{}, createCobraCmd)
 	add(&ingestDirCommand{}, createCobraCmd)
 	add(&createGitBundleCommand{}, createCobraCmd)
 
@@ -177,6 +178,35 @@ func (c *ingestGitCommitCommand) run(db snapshot.DB, _ *cobra.Command, _ []strin
 	return nil
 }
 
+type ingestGitRemoteRefCommand struct {
+	url string
+	ref string
+}
+
+func (c *ingestGitRemoteRefCommand) register() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest_git_remote_ref",
+		Short: "fetches a commit from a remote repo (no local clone needed) and uploads it",
+	}
+	cmd.Flags().StringVar(&c.url, "url", "", "url of the repo to fetch from")
+	cmd.Flags().StringVar(&c.ref, "ref", "", "branch, tag, or sha to ingest")
+	return cmd
+}
+
+func (c *ingestGitRemoteRefCommand) run(db snapshot.DB, _ *cobra.Command, _ []string) error {
+	if c.url == "" || c.ref == "" {
+		return fmt.Errorf("both --url and --ref must be specified")
+	}
+
+	id, err := db.IngestGitRemoteRef(c.url, c.ref)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(id)
+	return nil
+}
+
 // Subcommand for creating and uploading git bundles.
 // This is a workaround for creating arbitrary git bundles and keeping them in a Bundlestore.
 // We need this for now because generic bundles do not fit well with the existing
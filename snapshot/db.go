@@ -32,6 +32,12 @@ type Creator interface {
 	// Creates a GitCommitSnapshot that mirrors the ingested commit.
 	IngestGitCommit(ingestRepo *repo.Repository, commitish string) (ID, error)
 
+	// IngestGitRemoteRef ingests the commit identified by ref from the repo at url, without
+	// needing a local clone. ref may be a branch, tag, or full sha. The commit is fetched with its history,
+	// so only the objects missing from the data repo are transferred and the data repo doesn't become shallow.
+	// Creates a GitCommitSnapshot that mirrors the ingested commit.
+	IngestGitRemoteRef(url, ref string) (ID, error)
+
 	// IngestGitWorkingDir ingests HEAD + working dir modifications from ingestRepo.
 	// Creates a GitCommitSnapshot that mirrors the ingested commit.
 	IngestGitWorkingDir(ingestRepo *repo.Repository) (ID, error)
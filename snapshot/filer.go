@@ -28,6 +28,14 @@ type Checkouter interface {
 	CheckoutAt(id string, dir string) (Checkout, error)
 }
 
+// SparseCheckouter is optionally implemented by Checkouters that can check out only some paths of a Snapshot,
+// for tasks that only need part of a large snapshot, ex: one subtree of a monorepo.
+type SparseCheckouter interface {
+	// Like Checkout, but only paths matching patterns need be checked out.
+	// Patterns are git sparse-checkout patterns, gitignore style, ex: "/src/myservice/".
+	CheckoutSparse(id string, patterns []string) (Checkout, error)
+}
+
 // Checkout represents one checkout of a Snapshot.
 // A Checkout is a copy of a Snapshot that lives in the local filesystem at a path.
 type Checkout interface {
@@ -53,6 +61,13 @@ type Ingester interface {
 	IngestMap(srcToDest map[string]string) (id string, err error)
 }
 
+// RetentionIngester is optionally implemented by Ingesters whose stored snapshots can expire,
+// so callers can choose how long a snapshot is kept instead of the store's default TTL.
+type RetentionIngester interface {
+	// Like Ingest, but the stored snapshot is kept for retention. Zero uses the store's default.
+	IngestWithRetention(path string, retention time.Duration) (id string, err error)
+}
+
 const NoDuration time.Duration = time.Duration(0)
 
 // Updater allows Filers to have a means to manage updates on the underlying resources
@@ -81,6 +96,21 @@ func (dba *dbAdapter) Checkout(id string) (Checkout, error) {
 	}
 }
 
+// Uses the DB's CheckoutSparse if it has one, else checks out the whole snapshot.
+func (dba *dbAdapter) CheckoutSparse(id string, patterns []string) (Checkout, error) {
+	db, ok := dba.db.(interface {
+		CheckoutSparse(id ID, patterns []string) (path string, err error)
+	})
+	if !ok || len(patterns) == 0 {
+		return dba.Checkout(id)
+	}
+	if dir, err := db.CheckoutSparse(ID(id), patterns); err != nil {
+		return nil, err
+	} else {
+		return &dbCheckout{db: dba.db, dir: dir, id: id}, nil
+	}
+}
+
 func (dba *dbAdapter) CheckoutAt(id string, dir string) (Checkout, error) {
 	if co, err := dba.Checkout(id); err != nil {
 		return nil, err
@@ -99,6 +129,21 @@ func (dba *dbAdapter) Ingest(path string) (id string, err error) {
 	}
 }
 
+// Uses the DB's IngestDirWithRetention if it has one, else ingests with the default TTL.
+func (dba *dbAdapter) IngestWithRetention(path string, retention time.Duration) (id string, err error) {
+	db, ok := dba.db.(interface {
+		IngestDirWithRetention(dir string, retention time.Duration) (ID, error)
+	})
+	if !ok {
+		return dba.Ingest(path)
+	}
+	if ident, err := db.IngestDirWithRetention(path, retention); err != nil {
+		return "", err
+	} else {
+		return string(ident), nil
+	}
+}
+
 func (dba *dbAdapter) IngestMap(srcToDest map[string]string) (string, error) {
 	errMsg := "Not implemented"
 	log.Error(errMsg)
@@ -2,20 +2,22 @@ package gitdb
 
 import (
 	"fmt"
-	"strings"
 
 	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapid"
+	"github.com/twitter/scoot/snapshot/store"
 )
 
 // backend allows getting a snapshot for an ID, which can then be used to download the ID
 type backend interface {
-	parseID(id snap.ID, kind SnapshotKind, extraParts []string) (snapshot, error)
+	parseID(id snapid.ID) (snapshot, error)
 }
 
 // upload allow uploading the ID. Note: The ID to upload will often be in another backend.
 // E.g., for the git tags uploader, it will often pass a local snapshot
+// ttl sets when the upload expires, for backends whose stores support it. Nil uses the store's default.
 type uploader interface {
-	upload(s snapshot, db *DB) (snapshot, error)
+	upload(s snapshot, db *DB, ttl *store.TTLValue) (snapshot, error)
 	backend
 }
 
@@ -31,31 +33,22 @@ type snapshot interface {
 
 // parseID parses ID into a snapshot
 func (db *DB) parseID(id snap.ID) (snapshot, error) {
-	if id == "" {
-		return nil, fmt.Errorf("empty snapshot ID")
-	}
-	parts := strings.Split(string(id), "-")
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("could not determine backend and kind in ID %s", id)
-	}
-
-	backendType, kind := parts[0], SnapshotKind(parts[1])
-
-	if !kinds[kind] {
-		return nil, fmt.Errorf("invalid kind: %s", kind)
+	s, err := snapid.Parse(string(id))
+	if err != nil {
+		return nil, err
 	}
 
-	switch backendType {
-	case localIDText:
-		return db.local.parseID(id, kind, parts[2:])
-	case streamIDText:
-		return db.stream.parseID(id, kind, parts[2:])
-	case tagsIDText:
-		return db.tags.parseID(id, kind, parts[2:])
-	case bundlestoreIDText:
-		return db.bundles.parseID(id, kind, parts[2:])
+	switch s.Backend {
+	case snapid.BackendLocal:
+		return db.local.parseID(s)
+	case snapid.BackendStream:
+		return db.stream.parseID(s)
+	case snapid.BackendTags:
+		return db.tags.parseID(s)
+	case snapid.BackendBundlestore:
+		return db.bundles.parseID(s)
 	default:
-		return nil, fmt.Errorf("unrecognized snapshot backend %s in ID %s", backendType, id)
+		return nil, fmt.Errorf("snapshot ID %s is not a git snapshot", id)
 	}
 }
 
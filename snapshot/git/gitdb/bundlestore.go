@@ -11,41 +11,56 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/common/stats"
 	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapid"
 	"github.com/twitter/scoot/snapshot/store"
 )
 
 // BundlestoreConfig defines how to talk to Bundlestore
 type BundlestoreConfig struct {
 	Store store.Store
+
+	// Bundlestores, e.g. in other regions, to fall back to in order when Store fails.
+	Fallbacks []store.Store
+	// How failing bundlestores are taken out of rotation. Only used with Fallbacks.
+	Failover store.FailoverConfig
+
+	// Bundles larger than this are uploaded in chunks of this size over a resumable session, if Store is
+	// a store.ResumableWriter and there are no Fallbacks. Zero uploads bundles in one request, as servers
+	// without upload sessions expect.
+	UploadChunkSize int64
 }
 
 type bundlestoreBackend struct {
-	cfg *BundlestoreConfig
+	cfg   *BundlestoreConfig
+	store store.Store
 }
 
-const bundlestoreIDText = "bs"
+func makeBundlestoreBackend(cfg *BundlestoreConfig, stat stats.StatsReceiver) *bundlestoreBackend {
+	b := &bundlestoreBackend{cfg: cfg}
+	if cfg == nil {
+		return b
+	}
+	b.store = cfg.Store
+	if len(cfg.Fallbacks) > 0 {
+		upstreams := append([]store.Store{cfg.Store}, cfg.Fallbacks...)
+		// Can't fail, there's at least one upstream.
+		b.store, _ = store.MakeFailoverStore(upstreams, cfg.Failover, stat)
+	}
+	return b
+}
 
 // "bs-gc-<bundle>-<stream>-<sha>"
 
-func (b *bundlestoreBackend) parseID(id snap.ID, kind SnapshotKind, extraParts []string) (snapshot, error) {
+func (b *bundlestoreBackend) parseID(id snapid.ID) (snapshot, error) {
 	if b.cfg == nil {
 		return nil, errors.New("Bundlestore backend not initialized.")
 	}
-
-	if len(extraParts) != 3 {
-		return nil, fmt.Errorf("cannot parse snapshot ID: expected 5 parts in bundlestore id: %s", id)
-	}
-	bundleKey, streamName, sha := extraParts[0], extraParts[1], extraParts[2]
-
-	if err := validSha(sha); err != nil {
-		return nil, err
-	}
-
-	return &bundlestoreSnapshot{kind: kind, sha: sha, bundleKey: bundleKey, streamName: streamName}, nil
+	return &bundlestoreSnapshot{kind: SnapshotKind(id.Format), sha: id.SHA, bundleKey: id.BundleKey, streamName: id.Name}, nil
 }
 
-func (b *bundlestoreBackend) upload(s snapshot, db *DB) (snapshot, error) {
+func (b *bundlestoreBackend) upload(s snapshot, db *DB, ttl *store.TTLValue) (snapshot, error) {
 	// We only have to upload a localSnapshot
 	switch s := s.(type) {
 	case *tagsSnapshot:
@@ -57,7 +72,7 @@ func (b *bundlestoreBackend) upload(s snapshot, db *DB) (snapshot, error) {
 	case *bundlestoreSnapshot:
 		return s, nil
 	case *localSnapshot:
-		return b.uploadLocalSnapshot(s, db)
+		return b.uploadLocalSnapshot(s, db, ttl)
 	default:
 		return nil, fmt.Errorf("cannot upload %v: unknown type %T", s, s)
 	}
@@ -72,7 +87,7 @@ func (b *bundlestoreBackend) upload(s snapshot, db *DB) (snapshot, error) {
 // )
 const bundlestoreTempRef = "reserved_scoot/bundlestore/__temp_for_writing"
 
-func (b *bundlestoreBackend) uploadLocalSnapshot(s *localSnapshot, db *DB) (sn snapshot, err error) {
+func (b *bundlestoreBackend) uploadLocalSnapshot(s *localSnapshot, db *DB, ttl *store.TTLValue) (sn snapshot, err error) {
 	// the sha of the commit we're going to use as the ref
 	commitSha := s.sha
 
@@ -156,7 +171,7 @@ func (b *bundlestoreBackend) uploadLocalSnapshot(s *localSnapshot, db *DB) (sn s
 		return nil, err
 	}
 
-	_, err = b.uploadFile(bundleFilename, nil)
+	_, err = b.uploadFile(bundleFilename, ttl)
 	if err != nil {
 		return nil, err
 	}
@@ -185,7 +200,7 @@ type bundlestoreSnapshot struct {
 }
 
 func (s *bundlestoreSnapshot) ID() snap.ID {
-	return snap.ID(strings.Join([]string{bundlestoreIDText, string(s.kind), s.bundleKey, s.streamName, s.sha}, "-"))
+	return snap.ID(snapid.Bundlestore(snapid.Format(s.kind), s.bundleKey, s.streamName, s.sha).String())
 }
 func (s *bundlestoreSnapshot) Kind() SnapshotKind { return s.kind }
 func (s *bundlestoreSnapshot) SHA() string        { return s.sha }
@@ -263,7 +278,7 @@ func (s *bundlestoreSnapshot) downloadBundle(db *DB) (filename string, err error
 	}
 	defer f.Close()
 
-	r, err := db.bundles.cfg.Store.OpenForRead(bundleName)
+	r, err := db.bundles.store.OpenForRead(bundleName)
 	if err != nil {
 		return "", err
 	}
@@ -276,7 +291,7 @@ func (s *bundlestoreSnapshot) downloadBundle(db *DB) (filename string, err error
 }
 
 func makeBundleName(key string) string {
-	return fmt.Sprintf("bs-%s.bundle", key)
+	return snapid.BundleName(key)
 }
 
 func (b *bundlestoreBackend) uploadFile(filePath string, ttl *store.TTLValue) (string, error) {
@@ -291,9 +306,48 @@ func (b *bundlestoreBackend) uploadFile(filePath string, ttl *store.TTLValue) (s
 		return "", fmt.Errorf("Invalid path %v, base parsed to %v", filePath, name)
 	}
 
-	if err := b.cfg.Store.Write(name, f, ttl); err != nil {
+	if w, ok := b.store.(store.ResumableWriter); ok && b.cfg.UploadChunkSize > 0 {
+		if fi, err := f.Stat(); err == nil && fi.Size() > b.cfg.UploadChunkSize {
+			if err := writeResumable(w, name, f, fi.Size(), ttl, b.cfg.UploadChunkSize); err != nil {
+				return "", err
+			}
+			return b.store.Root() + name, nil
+		}
+	}
+
+	if fs, ok := b.store.(*store.FailoverStore); ok {
+		// Return the URL of the upstream that took the bundle, it may not be the first.
+		root, err := fs.WriteUpstream(name, f, ttl)
+		if err != nil {
+			return "", err
+		}
+		return root + name, nil
+	}
+
+	if err := b.store.Write(name, f, ttl); err != nil {
 		return "", err
 	}
 
-	return b.cfg.Store.Root() + name, nil
+	return b.store.Root() + name, nil
+}
+
+// Uploads the bundle in chunks, resuming the upload for as long as each attempt gets more of it to the server.
+func writeResumable(w store.ResumableWriter, name string, f *os.File, size int64, ttl *store.TTLValue,
+	chunkSize int64) error {
+	err := w.WriteResumable(name, f, size, ttl, chunkSize, nil)
+	received := int64(-1)
+	for {
+		ie, ok := err.(*store.UploadInterruptedError)
+		if !ok {
+			return err
+		}
+		if ie.Received <= received {
+			log.Infof("Giving up on upload %s of %s after %d of %d bytes: %v", ie.UploadId, name, ie.Received, size, ie.Err)
+			w.AbortUpload(name, ie.UploadId)
+			return ie
+		}
+		received = ie.Received
+		log.Infof("Upload %s of %s interrupted after %d of %d bytes, resuming: %v", ie.UploadId, name, received, size, ie.Err)
+		err = w.ResumeUpload(name, ie.UploadId, f, chunkSize, nil)
+	}
 }
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/common/stats"
 	snap "github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/git/repo"
 )
@@ -28,16 +31,12 @@ func (db *DB) readFileAll(id snap.ID, path string) (string, error) {
 	return db.dataRepo.Run("cat-file", "-p", fmt.Sprintf("%s:%s", v.SHA(), path))
 }
 
-// checkout creates a checkout of id.
-func (db *DB) checkout(id snap.ID) (path string, err error) {
-	defer func() {
-		// If we're returning our repo dir, we need to keep the work tree locked, otherwise, we can unlock it.
-		// Note: we defer this to capture the various places 'path' is returned.
-		if path != db.dataRepo.Dir() {
-			db.workTreeLock.Unlock()
-		}
-	}()
-
+// checkout creates a checkout of id, with only the paths matching sparse if it's a GitCommitSnapshot
+// and sparse isn't empty. Checkouts may run concurrently.
+func (db *DB) checkout(id snap.ID, sparse []string) (path string, err error) {
+	if len(sparse) == 0 {
+		sparse = nil
+	}
 	v, err := db.parseID(id)
 	if err != nil {
 		return "", err
@@ -52,25 +51,58 @@ func (db *DB) checkout(id snap.ID) (path string, err error) {
 		// For FSSnapshots, we make a "bare checkout".
 		return db.checkoutFSSnapshot(v.SHA())
 	case KindGitCommitSnapshot:
-		// For GitCommitSnapshot's, we use dataRepo's work tree.
-		if id == db.currentSnapID {
-			log.Infof("Using cached checkout for id=%s", id)
-			return db.dataRepo.Dir(), nil
+		// For GitCommitSnapshot's, we use a work tree of dataRepo that isn't in use.
+		wt, cached := db.workTrees.acquire(id, sparse)
+		if cached {
+			log.Infof("Using cached checkout for id=%s in %s", id, wt.repo.Dir())
+			return wt.repo.Dir(), nil
 		}
-		path, err := db.checkoutGitCommitSnapshot(v.SHA())
-		if err != nil {
-			db.currentSnapID = ""
-		} else {
-			db.currentSnapID = id
+		if wt == nil {
+			if wt, err = db.addWorkTree(v.SHA()); err != nil {
+				return "", err
+			}
+			db.workTrees.add(wt)
+		}
+		if err := db.checkoutGitCommitSnapshot(wt, v.SHA(), sparse); err != nil {
+			// The work tree's state is unknown, so it isn't reused.
+			db.workTrees.drop(wt)
+			db.removeWorkTree(wt.repo.Dir(), wt.branch)
+			return "", err
 		}
-		return path, err
+		wt.id, wt.sparse = id, sparse
+		return wt.repo.Dir(), nil
 	default:
 		return "", fmt.Errorf("cannot checkout value kind %v; id %v", v.Kind(), v.ID())
 	}
 }
 
 // checkoutFSSnapshot creates a new dir with a new index and checks out exactly that tree.
+// If the checkout doesn't match the tree, it's discarded and checked out again once.
 func (db *DB) checkoutFSSnapshot(sha string) (path string, err error) {
+	path, err = db.checkoutFSSnapshotOnce(sha)
+	if err != nil {
+		return "", err
+	}
+	if verifyErr := verifyCheckout(db.dataRepo, path, sha, CheckoutSpotChecks); verifyErr != nil {
+		db.stat.Counter(stats.GitDBCheckoutCorruptionsCounter).Inc(1)
+		log.Errorf("Checkout of tree %s in %s is corrupt, checking out again: %v", sha, path, verifyErr)
+		os.RemoveAll(path)
+		if path, err = db.checkoutFSSnapshotOnce(sha); err != nil {
+			return "", err
+		}
+		if err := verifyCheckout(db.dataRepo, path, sha, CheckoutSpotChecks); err != nil {
+			db.stat.Counter(stats.GitDBCheckoutRepairFailuresCounter).Inc(1)
+			os.RemoveAll(path)
+			return "", fmt.Errorf("Checkout of tree %s is still corrupt after checking out again: %v", sha, err)
+		}
+	}
+	db.checkoutsMu.Lock()
+	db.checkouts[path] = true
+	db.checkoutsMu.Unlock()
+	return path, nil
+}
+
+func (db *DB) checkoutFSSnapshotOnce(sha string) (path string, err error) {
 	// we don't need the work tree
 	indexDir, err := db.tmp.TempDir("git-index")
 	if err != nil {
@@ -97,50 +129,105 @@ func (db *DB) checkoutFSSnapshot(sha string) (path string, err error) {
 		return "", err
 	}
 
-	db.checkouts[coDir.Dir] = true
-
 	return coDir.Dir, nil
 }
 
-// checkoutGitCommitSnapshot checks out a commit into our work tree.
-// We could use multiple work trees, except our internal git doesn't yet have work-tree support.
-// TODO(dbentley): migrate to work-trees.
-func (db *DB) checkoutGitCommitSnapshot(sha string) (path string, err error) {
+// checkoutGitCommitSnapshot checks out a commit into wt.
+//
+// Git trusts its index to know which files are already up to date, so a corrupt index or work tree
+// survives a checkout. If the checkout doesn't match the commit, the index is discarded so
+// every file is written again.
+//
+// If sparse isn't empty, only paths matching its patterns are checked out and the rest are
+// marked skip-worktree in the index.
+func (db *DB) checkoutGitCommitSnapshot(wt *workTree, sha string, sparse []string) error {
+	if err := db.checkoutGitCommitSnapshotOnce(wt, sha, sparse); err != nil {
+		return err
+	}
+	if verifyErr := verifyCheckout(wt.repo, wt.repo.Dir(), sha, CheckoutSpotChecks); verifyErr != nil {
+		db.stat.Counter(stats.GitDBCheckoutCorruptionsCounter).Inc(1)
+		log.Errorf("Checkout of commit %s in %s is corrupt, discarding the index and checking out again: %v",
+			sha, wt.repo.Dir(), verifyErr)
+		// A linked work tree's index is in the data repo's git dir, not under the work tree.
+		index, err := wt.repo.Run("rev-parse", "--git-path", "index")
+		if err != nil {
+			return err
+		}
+		if index = strings.TrimSpace(index); !filepath.IsAbs(index) {
+			index = filepath.Join(wt.repo.Dir(), index)
+		}
+		if err := os.Remove(index); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := db.checkoutGitCommitSnapshotOnce(wt, sha, sparse); err != nil {
+			return err
+		}
+		if err := verifyCheckout(wt.repo, wt.repo.Dir(), sha, CheckoutSpotChecks); err != nil {
+			db.stat.Counter(stats.GitDBCheckoutRepairFailuresCounter).Inc(1)
+			return fmt.Errorf("Checkout of commit %s is still corrupt after checking out again: %v", sha, err)
+		}
+	}
+	return nil
+}
+
+func (db *DB) checkoutGitCommitSnapshotOnce(wt *workTree, sha string, sparse []string) error {
 	cmds := [][]string{
 		// -d removes directories. -x ignores gitignore and removes everything.
 		// -f is force. -f the second time removes directories even if they're git repos themselves
 		{"clean", "-f", "-f", "-d", "-x"},
-		// -f overrides modified files
-		// -B resets or creates the named branch when checking out the given sha.
-		// Note: our worktree cannot be in detached head state after checkout since [Twitter] git needs a valid ref to fetch.
-		//       we use scoot's tmp branch name so here subsequent fetch operations, ex: those in stream.go, can succeed.
-		{"checkout", "-fB", tempCheckoutBranch, sha},
 	}
-
+	// Set the patterns before checking out so paths outside them are never written.
+	// --no-cone takes gitignore style patterns rather than only directories.
+	// Sparse-checkout settings are per work tree, so they don't affect other checkouts.
+	if len(sparse) > 0 {
+		cmds = append(cmds, append([]string{"sparse-checkout", "set", "--no-cone"}, sparse...))
+	} else if wt.sparse != nil {
+		cmds = append(cmds, []string{"sparse-checkout", "disable"})
+	}
+	// -f overrides modified files
+	// -B resets or creates the named branch when checking out the given sha.
+	// Note: our worktree cannot be in detached head state after checkout since [Twitter] git needs a valid ref to fetch.
+	//       we use a branch per work tree so subsequent fetch operations, ex: those in stream.go, can succeed.
+	cmds = append(cmds, []string{"checkout", "-fB", wt.branch, sha})
+
+	// Until the checkout succeeds the work tree holds no known snapshot.
+	wt.id, wt.sparse = "", sparse
 	for _, argv := range cmds {
-		if _, err := db.dataRepo.Run(argv...); err != nil {
-			return "", fmt.Errorf("Unable to run git %v: %v", argv, err)
+		if _, err := wt.repo.Run(argv...); err != nil {
+			return fmt.Errorf("Unable to run git %v: %v", argv, err)
 		}
 	}
+	return nil
+}
 
-	return db.dataRepo.Dir(), nil
+func sparsePatternsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (db *DB) releaseCheckout(path string) error {
-	if path == db.dataRepo.Dir() {
-		db.workTreeLock.Unlock()
+	if evicted, ok := db.workTrees.release(path); ok {
+		if evicted != nil {
+			db.removeWorkTree(evicted.repo.Dir(), evicted.branch)
+		}
 		return nil
 	}
 
-	if exists := db.checkouts[path]; !exists {
-		return nil
-	}
-	err := os.RemoveAll(path)
-	if err == nil {
+	db.checkoutsMu.Lock()
+	exists := db.checkouts[path]
+	delete(db.checkouts, path)
+	db.checkoutsMu.Unlock()
+	if !exists {
 		return nil
 	}
-	delete(db.checkouts, path)
-	return err
+	return os.RemoveAll(path)
 }
 
 func (db *DB) exportGitCommit(id snap.ID, externalRepo *repo.Repository) (string, error) {
@@ -164,6 +251,8 @@ func (db *DB) exportGitCommit(id snap.ID, externalRepo *repo.Repository) (string
 	return v.SHA(), nil
 }
 
+var moveCommitCounter int64
+
 func moveCommit(from *repo.Repository, to *repo.Repository, sha string) error {
 	// Strategy: move a commit from 'from' to 'to'
 	// first, check if it's in 'to' (if so; skip)
@@ -180,23 +269,26 @@ func moveCommit(from *repo.Repository, to *repo.Repository, sha string) error {
 	}
 	log.Infof("Could not find commit=%s, continuing with moveCommit()", sha)
 
-	if _, err := to.Run("update-ref", "-d", tempRef); err != nil {
+	// Each move uses its own ref so concurrent moves, ex: two ingests, don't clobber each other.
+	ref := fmt.Sprintf("%s-%d", tempRef, atomic.AddInt64(&moveCommitCounter, 1))
+
+	if _, err := to.Run("update-ref", "-d", ref); err != nil {
 		return err
 	}
 
-	if _, err := from.Run("update-ref", tempRef, sha); err != nil {
+	if _, err := from.Run("update-ref", ref, sha); err != nil {
 		return err
 	}
 
-	if _, err := from.Run("push", "-f", to.Dir(), tempRef); err != nil {
+	if _, err := from.Run("push", "-f", to.Dir(), ref); err != nil {
 		return err
 	}
 
-	if _, err := from.Run("update-ref", "-d", tempRef); err != nil {
+	if _, err := from.Run("update-ref", "-d", ref); err != nil {
 		return err
 	}
 
-	if _, err := to.Run("update-ref", "-d", tempRef); err != nil {
+	if _, err := to.Run("update-ref", "-d", ref); err != nil {
 		return err
 	}
 
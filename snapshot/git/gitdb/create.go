@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 
@@ -66,6 +67,41 @@ func (db *DB) ingestGitCommit(ingestRepo *repo.Repository, commitish string) (sn
 	return &localSnapshot{sha: sha, kind: KindGitCommitSnapshot}, nil
 }
 
+// Prefix for refs that hold commits fetched from a remote while they're being ingested.
+// Each fetch gets its own ref (and doesn't use FETCH_HEAD) so concurrent ingests don't race.
+const tempRemoteRefPrefix = "refs/scoot/__temp_for_remote_ingest/"
+
+var remoteIngestCounter int64
+
+func (db *DB) ingestGitRemoteRef(url, ref string) (snapshot, error) {
+	// If we already have the commit, there's nothing to fetch.
+	if validSha(ref) == nil {
+		if err := db.shaPresent(ref); err == nil {
+			return &localSnapshot{sha: ref, kind: KindGitCommitSnapshot}, nil
+		}
+	}
+
+	tempRemoteRef := fmt.Sprintf("%s%d", tempRemoteRefPrefix, atomic.AddInt64(&remoteIngestCounter, 1))
+	defer db.dataRepo.Run("update-ref", "-d", tempRemoteRef)
+
+	// Fetch the commit with its history rather than a shallow fetch: a shallow fetch would make the data repo
+	// shallow, which every later fetch, bundle and push from it would have to contend with. Only the objects
+	// the data repo doesn't already have are transferred.
+	// Note: fetching a sha (rather than a branch or tag) requires the remote to allow it,
+	// ex: uploadpack.allowReachableSHA1InWant
+	log.Infof("Fetching %s from %s", ref, url)
+	if _, err := db.dataRepo.Run("fetch", "--no-tags", url, fmt.Sprintf("+%s:%s", ref, tempRemoteRef)); err != nil {
+		return nil, fmt.Errorf("could not fetch %s from %s: %v", ref, url, err)
+	}
+
+	sha, err := db.dataRepo.RunSha("rev-parse", "--verify", fmt.Sprintf("%s^{commit}", tempRemoteRef))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid commit: %s from %s, %v", ref, url, err)
+	}
+
+	return &localSnapshot{sha: sha, kind: KindGitCommitSnapshot}, nil
+}
+
 func (db *DB) ingestGitWorkingDir(ingestRepo *repo.Repository) (snapshot, error) {
 	indexDir, err := db.tmp.TempDir("git-index")
 	if err != nil {
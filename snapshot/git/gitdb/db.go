@@ -5,10 +5,13 @@ import (
 	"sync"
 	"time"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/os/temp"
 	snap "github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/git/repo"
+	"github.com/twitter/scoot/snapshot/snapid"
 	"github.com/twitter/scoot/snapshot/store"
 )
 
@@ -17,15 +20,10 @@ import (
 type SnapshotKind string
 
 const (
-	KindFSSnapshot        SnapshotKind = "fs"
-	KindGitCommitSnapshot SnapshotKind = "gc"
+	KindFSSnapshot        SnapshotKind = SnapshotKind(snapid.FormatFS)
+	KindGitCommitSnapshot SnapshotKind = SnapshotKind(snapid.FormatGitCommit)
 )
 
-var kinds = map[SnapshotKind]bool{
-	KindFSSnapshot:        true,
-	KindGitCommitSnapshot: true,
-}
-
 type AutoUploadDest int
 
 const (
@@ -81,10 +79,11 @@ func makeDB(
 		updater:    updater,
 		tmp:        tmp,
 		checkouts:  make(map[string]bool),
+		workTrees:  newWorkTreePool(stat),
 		local:      &localBackend{},
-		stream:     &streamBackend{cfg: stream, stat: stat},
+		stream:     makeStreamBackend(stream, stat),
 		tags:       &tagsBackend{cfg: tags},
-		bundles:    &bundlestoreBackend{cfg: bundles},
+		bundles:    makeBundlestoreBackend(bundles, stat),
 		stat:       stat,
 	}
 
@@ -128,23 +127,24 @@ type DB struct {
 	InitDoneCh chan error
 	err        error
 
-	// must hold workTreeLock before sending a checkoutReq to reqCh
-	workTreeLock sync.Mutex
+	// Checkouts run concurrently, so their state is safe to use outside the loop() goroutine.
+	workTrees    *workTreePool
+	workTreesDir *temp.TempDir // Set by loop() before serving requests.
+	checkoutsMu  sync.Mutex
+	checkouts    map[string]bool // checkouts stores bare checkouts, but not git work trees
 
 	// All data below here should be accessed only by the loop() goroutine
+	// dataRepo's own work tree is never read or written, checkouts use linked work trees or bare
+	// checkouts instead, so it may be cloned with --no-checkout.
 	dataRepo   *repo.Repository
 	updater    RepoUpdater
 	tmp        *temp.TempDir
-	checkouts  map[string]bool // checkouts stores bare checkouts, but not the git worktree
 	local      *localBackend
 	stream     *streamBackend
 	tags       *tagsBackend
 	bundles    *bundlestoreBackend
 	autoUpload uploader // This is one of our backends that we use to upload automatically
 
-	// TODO: reusing git checkout if its snap.ID matches the request - make this configurable at runtime...
-	currentSnapID snap.ID
-
 	stat stats.StatsReceiver
 }
 
@@ -184,8 +184,8 @@ func (db *DB) UpdateInterval() time.Duration {
 	return db.updater.UpdateInterval()
 }
 
-// loop loops serving requests serially, blocking only if there are multiple checkout requests.
-// only one checkout is allowed at a time in the underlying repo, everything else should be ok if concurrent
+// loop loops serving requests, each in its own goroutine.
+// Checkouts of GitCommitSnapshots each get their own work tree, so they're safe to run concurrently too.
 func (db *DB) loop(initer RepoIniter) {
 	if db.init(initer); db.err != nil {
 		// we couldn't create our repo, so all operations will fail before
@@ -193,19 +193,10 @@ func (db *DB) loop(initer RepoIniter) {
 		return
 	}
 
-	// Handle checkout logic separately and block until each request completes
-	checkoutCh := make(chan interface{})
-	go func() {
-		for req := range checkoutCh {
-			switch req := req.(type) {
-			case checkoutReq:
-				path, err := db.checkout(req.id)
-				req.resultCh <- stringAndError{str: path, err: err}
-			case releaseCheckoutReq:
-				req.resultCh <- db.releaseCheckout(req.path)
-			}
-		}
-	}()
+	if err := db.initWorkTrees(); err != nil {
+		log.Errorf("Unable to clean up work trees of %s, adding work trees in %s: %v", db.dataRepo.Dir(), db.tmp.Dir, err)
+		db.workTreesDir = db.tmp
+	}
 
 	// Handle all request types
 	for db.reqCh != nil {
@@ -219,7 +210,7 @@ func (db *DB) loop(initer RepoIniter) {
 			go func() {
 				s, err := db.ingestDir(req.dir)
 				if err == nil && db.autoUpload != nil {
-					s, err = db.autoUpload.upload(s, db)
+					s, err = db.autoUpload.upload(s, db, req.ttl)
 				}
 				if err != nil {
 					req.resultCh <- idAndError{err: err}
@@ -231,7 +222,19 @@ func (db *DB) loop(initer RepoIniter) {
 			go func() {
 				s, err := db.ingestGitCommit(req.ingestRepo, req.commitish)
 				if err == nil && db.autoUpload != nil {
-					s, err = db.autoUpload.upload(s, db)
+					s, err = db.autoUpload.upload(s, db, nil)
+				}
+				if err != nil {
+					req.resultCh <- idAndError{err: err}
+				} else {
+					req.resultCh <- idAndError{id: s.ID()}
+				}
+			}()
+		case ingestGitRemoteRefReq:
+			go func() {
+				s, err := db.ingestGitRemoteRef(req.url, req.ref)
+				if err == nil && db.autoUpload != nil {
+					s, err = db.autoUpload.upload(s, db, nil)
 				}
 				if err != nil {
 					req.resultCh <- idAndError{err: err}
@@ -243,7 +246,7 @@ func (db *DB) loop(initer RepoIniter) {
 			go func() {
 				s, err := db.ingestGitWorkingDir(req.ingestRepo)
 				if err == nil && db.autoUpload != nil {
-					s, err = db.autoUpload.upload(s, db)
+					s, err = db.autoUpload.upload(s, db, nil)
 				}
 				if err != nil {
 					req.resultCh <- idAndError{err: err}
@@ -261,9 +264,14 @@ func (db *DB) loop(initer RepoIniter) {
 				data, err := db.readFileAll(req.id, req.path)
 				req.resultCh <- stringAndError{str: data, err: err}
 			}()
-		case checkoutReq, releaseCheckoutReq:
+		case checkoutReq:
 			go func() {
-				checkoutCh <- req
+				path, err := db.checkout(req.id, req.sparse)
+				req.resultCh <- stringAndError{str: path, err: err}
+			}()
+		case releaseCheckoutReq:
+			go func() {
+				req.resultCh <- db.releaseCheckout(req.path)
 			}()
 		case exportGitCommitReq:
 			go func() {
@@ -279,13 +287,17 @@ func (db *DB) loop(initer RepoIniter) {
 		}
 	}
 
-	close(checkoutCh)
+	// Checkouts not yet released are removed too, as nothing can release them once the DB is closed.
+	for _, wt := range db.workTrees.drain() {
+		db.removeWorkTree(wt.repo.Dir(), wt.branch)
+	}
 }
 
 // Request entry points and request/result type defs
 
 type ingestReq struct {
 	dir      string
+	ttl      *store.TTLValue
 	resultCh chan idAndError
 }
 
@@ -307,6 +319,22 @@ func (db *DB) IngestDir(dir string) (snap.ID, error) {
 	return result.id, result.err
 }
 
+// IngestDirWithRetention ingests a directory directly, like IngestDir, and if it's uploaded
+// to a store that supports TTLs, the upload expires after retention instead of the store's default.
+func (db *DB) IngestDirWithRetention(dir string, retention time.Duration) (snap.ID, error) {
+	if <-db.initDoneCh; db.err != nil {
+		return "", db.err
+	}
+	var ttl *store.TTLValue
+	if retention > 0 {
+		ttl = &store.TTLValue{TTL: time.Now().Add(retention), TTLKey: store.DefaultTTLKey}
+	}
+	resultCh := make(chan idAndError)
+	db.reqCh <- ingestReq{dir: dir, ttl: ttl, resultCh: resultCh}
+	result := <-resultCh
+	return result.id, result.err
+}
+
 type ingestGitCommitReq struct {
 	ingestRepo *repo.Repository
 	commitish  string
@@ -326,6 +354,25 @@ func (db *DB) IngestGitCommit(ingestRepo *repo.Repository, commitish string) (sn
 	return result.id, result.err
 }
 
+type ingestGitRemoteRefReq struct {
+	url      string
+	ref      string
+	resultCh chan idAndError
+}
+
+func (r ingestGitRemoteRefReq) req() {}
+
+// IngestGitRemoteRef fetches the commit identified by ref from the repo at url and ingests it
+func (db *DB) IngestGitRemoteRef(url, ref string) (snap.ID, error) {
+	if <-db.initDoneCh; db.err != nil {
+		return "", db.err
+	}
+	resultCh := make(chan idAndError)
+	db.reqCh <- ingestGitRemoteRefReq{url: url, ref: ref, resultCh: resultCh}
+	result := <-resultCh
+	return result.id, result.err
+}
+
 type ingestGitWorkingDirReq struct {
 	ingestRepo *repo.Repository
 	resultCh   chan idAndError
@@ -370,6 +417,7 @@ func (db *DB) ReadFileAll(id snap.ID, path string) ([]byte, error) {
 
 type checkoutReq struct {
 	id       snap.ID
+	sparse   []string
 	resultCh chan stringAndError
 }
 
@@ -381,13 +429,24 @@ func (db *DB) Checkout(id snap.ID) (path string, err error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	db.workTreeLock.Lock()
 	resultCh := make(chan stringAndError)
 	db.reqCh <- checkoutReq{id: id, resultCh: resultCh}
 	result := <-resultCh
 	return result.str, result.err
 }
 
+// CheckoutSparse is like Checkout, but a GitCommitSnapshot is checked out with only the paths
+// matching patterns, using git sparse-checkout. Other snapshots are checked out in full.
+func (db *DB) CheckoutSparse(id snap.ID, patterns []string) (path string, err error) {
+	if <-db.initDoneCh; db.err != nil {
+		return "", db.err
+	}
+	resultCh := make(chan stringAndError)
+	db.reqCh <- checkoutReq{id: id, sparse: patterns, resultCh: resultCh}
+	result := <-resultCh
+	return result.str, result.err
+}
+
 type releaseCheckoutReq struct {
 	path     string
 	resultCh chan error
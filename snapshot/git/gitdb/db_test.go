@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -161,6 +162,43 @@ func TestStream(t *testing.T) {
 
 }
 
+func TestStreamMirror(t *testing.T) {
+	// Use our own data repo whose stream Remote is down, so the stream is fetched from its mirror.
+	dataRepo, err := createRepo(fixture.tmp, "mirror-data-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dataRepo.Run("remote", "add", "down", filepath.Join(fixture.tmp.Dir, "no-such-repo")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dataRepo.Run("remote", "add", "upstream", fixture.upstream.Dir()); err != nil {
+		t.Fatal(err)
+	}
+	streamCfg := &StreamConfig{
+		Name:    "sm",
+		Remote:  "down",
+		Mirrors: []string{"upstream"},
+		RefSpec: "refs/remotes/upstream/master",
+	}
+	db := MakeDBFromRepo(dataRepo, nil, fixture.tmp, streamCfg, nil, nil, AutoUploadNone, stats.NilStatsReceiver())
+	defer db.Close()
+
+	upstreamCommitID, err := commitText(fixture.upstream, "upstream_mirrored")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	co, err := db.Checkout(db.IDForStreamCommitSHA("sm", upstreamCommitID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.ReleaseCheckout(co)
+
+	if err := assertFileContents(co, "file.txt", "upstream_mirrored"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestInitUpdate(t *testing.T) {
 	// This test doesn't use our fixture DBs because it has such specific git setup
 	// Our git repos are:
@@ -279,6 +317,341 @@ func TestInitFails(t *testing.T) {
 	}
 }
 
+func TestIngestGitRemoteRef(t *testing.T) {
+	// Use our own data repo so the fetch is checked against an empty one.
+	dataRepo, err := createRepo(fixture.tmp, "remote-ingest-data-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := MakeDBFromRepo(dataRepo, nil, fixture.tmp, nil, nil, nil, AutoUploadNone, stats.NilStatsReceiver())
+	defer db.Close()
+
+	commitID, err := commitText(fixture.external, "remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixture.external.Run("branch", "-f", "remote_ingest", commitID); err != nil {
+		t.Fatal(err)
+	}
+
+	url := "file://" + fixture.external.Dir()
+	id, err := db.IngestGitRemoteRef(url, "remote_ingest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != (&localSnapshot{sha: commitID, kind: KindGitCommitSnapshot}).ID() {
+		t.Fatalf("Expected snapshot of %s, got %s", commitID, id)
+	}
+	if shallow, err := dataRepo.Run("rev-parse", "--is-shallow-repository"); err != nil || strings.TrimSpace(shallow) != "false" {
+		t.Fatalf("Expected the data repo not to be shallow, got %q, %v", shallow, err)
+	}
+
+	path, err := db.Checkout(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := assertFileContents(path, "file.txt", "remote"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ReleaseCheckout(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ingesting a sha we already have shouldn't need the remote.
+	if id2, err := db.IngestGitRemoteRef("file:///dev/null", commitID); err != nil || id2 != id {
+		t.Fatalf("Expected %s, got %s, %v", id, id2, err)
+	}
+
+	if _, err := db.IngestGitRemoteRef(url, "no_such_ref"); err == nil {
+		t.Fatal("Expected error ingesting a missing ref")
+	}
+}
+
+func TestCheckoutSelfHeal(t *testing.T) {
+	defer func(n int) { CheckoutSpotChecks = n }(CheckoutSpotChecks)
+	CheckoutSpotChecks = testSpotChecks
+
+	r, err := createRepo(fixture.tmp, "heal-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Make git trust the index when a file's mtime and size are unchanged, so a corrupt file survives checkout.
+	for _, kv := range [][]string{{"core.trustctime", "false"}, {"core.checkStat", "minimal"}} {
+		if _, err := r.Run("config", kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	db := MakeDBFromRepo(r, nil, fixture.tmp, nil, nil, nil, AutoUploadNone, stat)
+	defer db.Close()
+
+	sha, err := commitText(r, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := db.IngestGitCommit(r, sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	co, err := db.Checkout(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Backdate the file and refresh the index so it isn't racily clean, git would recheck its contents otherwise.
+	filename := filepath.Join(co, "file.txt")
+	mtime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filename, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	coRepo, err := repo.NewRepository(co)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := coRepo.Run("update-index", "--refresh"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileText(co, "file.txt", "frist"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filename, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ReleaseCheckout(co); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check out directly into the released work tree since the DB would reuse its checkout of id.
+	wt, cached := db.workTrees.acquire(id, nil)
+	if !cached || wt.repo.Dir() != co {
+		t.Fatalf("Expected the released work tree %s to be reused, got %v", co, wt)
+	}
+	if err := db.checkoutGitCommitSnapshot(wt, sha, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := assertFileContents(co, "file.txt", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.GitDBCheckoutCorruptionsCounter:    {Checker: stats.Int64EqTest, Value: 1},
+			stats.GitDBCheckoutRepairFailuresCounter: {Checker: stats.Int64EqTest, Value: nil},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+const testSpotChecks = 20
+
+func TestVerifyCheckout(t *testing.T) {
+	ingestDir, err := fixture.tmp.TempDir("verify_dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileText(ingestDir.Dir, "foo.txt", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	// Git quotes paths like this one unless it's asked for NUL separated output.
+	if err := writeFileText(ingestDir.Dir, "na\u00efve \"quoted\"\ttab.txt", "qux"); err != nil {
+		t.Fatal(err)
+	}
+	id, err := fixture.simpleDB.IngestDir(ingestDir.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	co, err := fixture.simpleDB.Checkout(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fixture.simpleDB.ReleaseCheckout(co)
+	v, err := fixture.simpleDB.parseID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyCheckout(fixture.simpleDB.dataRepo, co, v.SHA(), testSpotChecks); err != nil {
+		t.Fatalf("Expected a fresh checkout to verify, got %v", err)
+	}
+	if err := writeFileText(co, "foo.txt", "baz"); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCheckout(fixture.simpleDB.dataRepo, co, v.SHA(), testSpotChecks); err == nil {
+		t.Fatal("Expected a modified file to fail verification")
+	}
+	if err := os.Remove(filepath.Join(co, "foo.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCheckout(fixture.simpleDB.dataRepo, co, v.SHA(), testSpotChecks); err == nil {
+		t.Fatal("Expected a missing file to fail verification")
+	}
+	if err := verifyCheckout(fixture.simpleDB.dataRepo, co, v.SHA(), 0); err != nil {
+		t.Fatalf("Expected verification to be skipped without spot checks, got %v", err)
+	}
+}
+
+func TestCheckoutSparse(t *testing.T) {
+	r, err := createRepo(fixture.tmp, "sparse-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := MakeDBFromRepo(r, nil, fixture.tmp, nil, nil, nil, AutoUploadNone, stats.NilStatsReceiver())
+	defer db.Close()
+
+	if err := os.MkdirAll(filepath.Join(r.Dir(), "src"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileText(r.Dir(), "src/main.txt", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Run("add", "src/main.txt"); err != nil {
+		t.Fatal(err)
+	}
+	sha, err := commitText(r, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := db.IngestGitCommit(r, sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	co, err := db.CheckoutSparse(id, []string{"/src/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := assertFileContents(co, "src/main.txt", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(co, "file.txt")); !os.IsNotExist(err) {
+		t.Fatalf("Expected file.txt outside the sparse patterns not to be checked out, got %v", err)
+	}
+	coRepo, err := repo.NewRepository(co)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCheckout(coRepo, co, sha, testSpotChecks); err != nil {
+		t.Fatalf("Expected a sparse checkout to verify, got %v", err)
+	}
+	if err := db.ReleaseCheckout(co); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same snapshot checked out in full isn't served by the sparse checkout.
+	if co, err = db.Checkout(id); err != nil {
+		t.Fatal(err)
+	}
+	defer db.ReleaseCheckout(co)
+	if err := assertFileContents(co, "file.txt", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := assertFileContents(co, "src/main.txt", "main"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentCheckouts(t *testing.T) {
+	r, err := createRepo(fixture.tmp, "concurrent-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := MakeDBFromRepo(r, nil, fixture.tmp, nil, nil, nil, AutoUploadNone, stats.NilStatsReceiver())
+	defer db.Close()
+
+	ids := []snap.ID{}
+	for _, text := range []string{"first", "second", "third"} {
+		sha, err := commitText(r, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := db.IngestGitCommit(r, sha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	// Check out every commit at once, none released until all are checked out.
+	type result struct {
+		co  string
+		err error
+	}
+	results := make([]chan result, len(ids))
+	for i, id := range ids {
+		results[i] = make(chan result, 1)
+		go func(id snap.ID, ch chan result) {
+			co, err := db.Checkout(id)
+			ch <- result{co, err}
+		}(id, results[i])
+	}
+	cos := map[string]bool{}
+	for i, text := range []string{"first", "second", "third"} {
+		res := <-results[i]
+		if res.err != nil {
+			t.Fatal(res.err)
+		}
+		defer db.ReleaseCheckout(res.co)
+		if err := assertFileContents(res.co, "file.txt", text); err != nil {
+			t.Fatal(err)
+		}
+		cos[res.co] = true
+	}
+	if len(cos) != len(ids) {
+		t.Fatalf("Expected each checkout in its own work tree, got %v", cos)
+	}
+	if err := assertFileContents(r.Dir(), "file.txt", "third"); err != nil {
+		t.Fatalf("Expected the data repo's own work tree untouched, got %v", err)
+	}
+}
+
+func TestStaleWorkTrees(t *testing.T) {
+	r, err := createRepo(fixture.tmp, "stale-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha, err := commitText(r, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Leave a work tree and its branch as a DB that crashed would.
+	stale := filepath.Join(r.Dir(), ".git", workTreesDirName, "worktree-stale")
+	staleBranch := tempCheckoutBranch + "-worktree-stale"
+	if _, err := r.Run("worktree", "add", "--detach", stale, sha); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Run("branch", staleBranch, sha); err != nil {
+		t.Fatal(err)
+	}
+
+	db := MakeDBFromRepo(r, nil, fixture.tmp, nil, nil, nil, AutoUploadNone, stats.NilStatsReceiver())
+	id, err := db.IngestGitCommit(r, sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	co, err := db.Checkout(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(co) != filepath.Dir(stale) {
+		t.Fatalf("Expected checkout in %s, got %s", filepath.Dir(stale), co)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("Expected stale work tree %s removed, got %v", stale, err)
+	}
+	if _, err := r.Run("rev-parse", "--verify", "refs/heads/"+staleBranch); err == nil {
+		t.Fatalf("Expected stale branch %s deleted", staleBranch)
+	}
+
+	// Closing removes checkouts that weren't released.
+	db.Close()
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if _, err := os.Stat(co); os.IsNotExist(err) {
+			break
+		} else if time.Since(start) > 5*time.Second {
+			t.Fatalf("Expected unreleased checkout %s removed on close, got %v", co, err)
+		}
+	}
+}
+
 func TestClean(t *testing.T) {
 	tmp, err := temp.NewTempDir("", "db_test")
 	if err != nil {
@@ -3,9 +3,10 @@ package gitdb
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapid"
+	"github.com/twitter/scoot/snapshot/store"
 )
 
 // TagsConfig specifies how GitDB should store Snapshots as tags in another git repo
@@ -25,26 +26,14 @@ type tagsBackend struct {
 	cfg *TagsConfig
 }
 
-const tagsIDText = "tags"
-
-func (b *tagsBackend) parseID(id snap.ID, kind SnapshotKind, extraParts []string) (snapshot, error) {
+func (b *tagsBackend) parseID(id snapid.ID) (snapshot, error) {
 	if b.cfg == nil {
 		return nil, errors.New("Tags backend not initialized.")
 	}
-
-	if len(extraParts) != 2 {
-		return nil, fmt.Errorf("cannot parse snapshot ID: expected 4 parts in tags id: %s", id)
-	}
-	name, sha := extraParts[0], extraParts[1]
-
-	if err := validSha(sha); err != nil {
-		return nil, err
-	}
-
-	return &tagsSnapshot{kind: kind, sha: sha, name: name}, nil
+	return &tagsSnapshot{kind: SnapshotKind(id.Format), sha: id.SHA, name: id.Name}, nil
 }
 
-func (b *tagsBackend) upload(s snapshot, db *DB) (snapshot, error) {
+func (b *tagsBackend) upload(s snapshot, db *DB, ttl *store.TTLValue) (snapshot, error) {
 	// We only have to upload a localSnapshot
 	switch s := s.(type) {
 	case *tagsSnapshot:
@@ -77,7 +66,7 @@ type tagsSnapshot struct {
 }
 
 func (s *tagsSnapshot) ID() snap.ID {
-	return snap.ID(strings.Join([]string{tagsIDText, string(s.kind), s.name, s.sha}, "-"))
+	return snap.ID(snapid.Tags(snapid.Format(s.kind), s.name, s.sha).String())
 }
 func (s *tagsSnapshot) Kind() SnapshotKind { return s.kind }
 func (s *tagsSnapshot) SHA() string        { return s.sha }
@@ -1,14 +1,10 @@
 package gitdb
 
 import (
-	"fmt"
-
 	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapid"
 )
 
-const localIDText = "local"
-const localIDFmt = "%s-%s-%s"
-
 type localBackend struct{}
 
 // localSnapshot holds a reference to a value that is in the local DB
@@ -17,20 +13,12 @@ type localSnapshot struct {
 	kind SnapshotKind
 }
 
-func (b *localBackend) parseID(id snap.ID, kind SnapshotKind, extraParts []string) (*localSnapshot, error) {
-	if len(extraParts) != 1 {
-		return nil, fmt.Errorf("cannot parse snapshot ID: expected 3 extraParts in local id %s", id)
-	}
-	sha := extraParts[0]
-	if err := validSha(sha); err != nil {
-		return nil, err
-	}
-
-	return &localSnapshot{kind: kind, sha: sha}, nil
+func (b *localBackend) parseID(id snapid.ID) (*localSnapshot, error) {
+	return &localSnapshot{kind: SnapshotKind(id.Format), sha: id.SHA}, nil
 }
 
 func (s *localSnapshot) ID() snap.ID {
-	return snap.ID(fmt.Sprintf(localIDFmt, localIDText, s.kind, s.sha))
+	return snap.ID(snapid.Local(snapid.Format(s.kind), s.sha).String())
 }
 func (s *localSnapshot) Kind() SnapshotKind { return s.kind }
 func (s *localSnapshot) SHA() string        { return s.sha }
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/common/stats"
 	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapid"
+	"github.com/twitter/scoot/snapshot/store"
 )
 
 // A Stream is a sequence of GitCommitSnapshots that updates.
@@ -21,36 +25,41 @@ type StreamConfig struct {
 	// Remote to fetch from (e.g. https://github.com/twitter/scoot)
 	Remote string
 
+	// Mirrors of Remote to fall back to in order when fetching from Remote fails.
+	// Mirrors are fetched the same way as Remote, so should be remotes configured in the data repo
+	// whose fetch refspecs update RefSpec.
+	Mirrors []string
+	// How failing remotes are taken out of rotation. Only used with Mirrors.
+	Failover store.FailoverConfig
+
 	// Name of ref to follow in data repo (e.g. refs/remotes/upstream/master)
 	RefSpec string
 }
 
-const streamIDText = "stream"
-const streamIDFmt = "%s-%s-%s-%s"
 const streamNameShaSuffix = ":sha"
 
 type streamBackend struct {
-	cfg  *StreamConfig
-	stat stats.StatsReceiver
+	cfg     *StreamConfig
+	remotes []string
+	health  *store.UpstreamHealth
+	stat    stats.StatsReceiver
 }
 
-func (b *streamBackend) parseID(id snap.ID, kind SnapshotKind, extraParts []string) (*streamSnapshot, error) {
-	if b.cfg == nil {
-		return nil, errors.New("Stream backend not initialized.")
-	}
-
-	if len(extraParts) < 2 {
-		return nil, fmt.Errorf("cannot parse snapshot ID: expected 4 extraParts in stream id: %s", id)
+func makeStreamBackend(cfg *StreamConfig, stat stats.StatsReceiver) *streamBackend {
+	b := &streamBackend{cfg: cfg, stat: stat}
+	if cfg == nil {
+		return b
 	}
-	// The last token is the sha, anything before that is a stream name that may include '-'.
-	streamName := strings.Join(extraParts[0:len(extraParts)-1], "-")
-	sha := extraParts[len(extraParts)-1]
+	b.remotes = append([]string{cfg.Remote}, cfg.Mirrors...)
+	b.health = store.MakeUpstreamHealth(len(b.remotes), cfg.Failover, stat)
+	return b
+}
 
-	if err := validSha(sha); err != nil {
-		return nil, err
+func (b *streamBackend) parseID(id snapid.ID) (*streamSnapshot, error) {
+	if b.cfg == nil {
+		return nil, errors.New("Stream backend not initialized.")
 	}
-
-	return &streamSnapshot{streamName: streamName, kind: kind, sha: sha}, nil
+	return &streamSnapshot{streamName: id.Name, kind: SnapshotKind(id.Format), sha: id.SHA}, nil
 }
 
 // streamSnapshot represents a Snapshot that lives in a Stream
@@ -61,7 +70,7 @@ type streamSnapshot struct {
 }
 
 func (s *streamSnapshot) ID() snap.ID {
-	return snap.ID(fmt.Sprintf(streamIDFmt, streamIDText, s.kind, s.streamName, s.sha))
+	return snap.ID(snapid.Stream(snapid.Format(s.kind), s.streamName, s.sha).String())
 }
 func (s *streamSnapshot) Kind() SnapshotKind { return s.kind }
 func (s *streamSnapshot) SHA() string        { return s.sha }
@@ -92,14 +101,27 @@ func (s *streamSnapshot) Download(db *DB) error {
 func (b *streamBackend) updateStream(name string, db *DB) error {
 	b.stat.Counter(stats.GitStreamUpdateFetches).Inc(1)
 
-	args := []string{"fetch", b.cfg.Remote}
 	if !strings.HasPrefix(name, b.cfg.Name) {
 		return fmt.Errorf("cannot update stream %s: does not match stream %s", name, db.stream.cfg.Name)
 	}
+	var refArgs []string
 	if strings.HasPrefix(name, b.cfg.Name+":") {
 		// If the stream name includes a ref then fetch will override the default refspec
-		args = append(args, strings.Replace(name, b.cfg.Name+":", "", 1))
+		refArgs = append(refArgs, strings.Replace(name, b.cfg.Name+":", "", 1))
+	}
+
+	// Try Remote, then each mirror, with remotes that keep failing tried last.
+	var err error
+	for _, i := range b.health.Order() {
+		if _, err = db.dataRepo.Run(append([]string{"fetch", b.remotes[i]}, refArgs...)...); err == nil {
+			b.health.Succeeded(i)
+			if i != 0 {
+				b.stat.Counter(stats.GitStreamMirrorFetches).Inc(1)
+			}
+			return nil
+		}
+		log.Infof("Failed updating stream %s from %s: %v", name, b.remotes[i], err)
+		b.health.Failed(i)
 	}
-	_, err := db.dataRepo.Run(args...)
 	return err
 }
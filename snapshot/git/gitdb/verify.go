@@ -0,0 +1,118 @@
+package gitdb
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+
+	"github.com/twitter/scoot/snapshot/git/repo"
+)
+
+// Number of files per checkout whose contents are hashed and compared to the snapshot, zero to not
+// verify checkouts. Checking a random sample keeps verification cheap for large snapshots while still
+// catching a corrupt work tree over repeated checkouts. Set before checking out.
+var CheckoutSpotChecks = 0
+
+// verifyCheckout checks that dir holds treeish as stored in r: if treeish is a commit that it's
+// r's HEAD, and that a random sample of up to n regular files have the contents recorded in treeish.
+// A missing file fails the check since git can't hash it, except files a sparse checkout of r's
+// work tree left out. Always passes if n isn't positive.
+func verifyCheckout(r *repo.Repository, dir, treeish string, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	skipped := map[string]bool{}
+	if typ, err := r.Run("cat-file", "-t", treeish); err != nil {
+		return fmt.Errorf("can't read %s: %v", treeish, err)
+	} else if strings.TrimSpace(typ) == "commit" {
+		if skipped, err = sparseSkippedPaths(r); err != nil {
+			return err
+		}
+		head, err := r.RunSha("rev-parse", "HEAD")
+		if err != nil {
+			return err
+		}
+		expected, err := r.RunSha("rev-parse", treeish)
+		if err != nil {
+			return err
+		}
+		if head != expected {
+			return fmt.Errorf("HEAD is %s, expected %s", head, expected)
+		}
+	}
+
+	// -z separates entries with NULs and doesn't quote paths with unusual characters.
+	out, err := r.Run("ls-tree", "-r", "-z", treeish)
+	if err != nil {
+		return fmt.Errorf("can't list %s: %v", treeish, err)
+	}
+	type blob struct{ sha, path string }
+	blobs := []blob{}
+	for _, line := range strings.Split(out, "\x00") {
+		// Format: <mode> SP <type> SP <sha> TAB <path>
+		tab := strings.Index(line, "\t")
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(line[:tab])
+		// Skip symlinks (120000) and submodules, whose contents aren't a blob on disk.
+		if len(fields) != 3 || fields[1] != "blob" || (fields[0] != "100644" && fields[0] != "100755") {
+			continue
+		}
+		if skipped[line[tab+1:]] {
+			continue
+		}
+		blobs = append(blobs, blob{sha: fields[2], path: line[tab+1:]})
+	}
+
+	if len(blobs) > n {
+		perm := rand.Perm(len(blobs))
+		sample := make([]blob, n)
+		for i := range sample {
+			sample[i] = blobs[perm[i]]
+		}
+		blobs = sample
+	}
+	if len(blobs) == 0 {
+		return nil
+	}
+	args := []string{"hash-object", "--"}
+	for _, b := range blobs {
+		args = append(args, filepath.Join(dir, b.path))
+	}
+	out, err = r.Run(args...)
+	if err != nil {
+		return fmt.Errorf("can't hash checked out files: %v", err)
+	}
+	shas := strings.Fields(out)
+	if len(shas) != len(blobs) {
+		return fmt.Errorf("expected %d hashes, got %q", len(blobs), out)
+	}
+	for i, b := range blobs {
+		if shas[i] != b.sha {
+			return fmt.Errorf("%s has sha %s, expected %s", b.path, shas[i], b.sha)
+		}
+	}
+	return nil
+}
+
+// sparseSkippedPaths returns the paths a sparse checkout of r's work tree left out, which git marks
+// skip-worktree in the index. Empty if the work tree isn't a sparse checkout.
+func sparseSkippedPaths(r *repo.Repository) (map[string]bool, error) {
+	skipped := map[string]bool{}
+	if sparse, err := r.Run("config", "--bool", "core.sparseCheckout"); err != nil || strings.TrimSpace(sparse) != "true" {
+		return skipped, nil
+	}
+	// -t tags each path with its status, S for skip-worktree. -z as in verifyCheckout.
+	out, err := r.Run("ls-files", "-t", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("can't list index: %v", err)
+	}
+	for _, line := range strings.Split(out, "\x00") {
+		if strings.HasPrefix(line, "S ") {
+			skipped[line[2:]] = true
+		}
+	}
+	return skipped, nil
+}
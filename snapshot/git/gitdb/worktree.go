@@ -0,0 +1,192 @@
+package gitdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/git/repo"
+)
+
+// Most released work trees kept to be reused by later checkouts, others are removed when released.
+const maxIdleWorkTrees = 4
+
+// Dir in the data repo's git dir holding the work trees, so work trees left by a DB that didn't close,
+// ex: one that crashed, are found and removed by the next DB using the data repo.
+const workTreesDirName = "scoot-worktrees"
+
+// A linked work tree of the data repo, created with git worktree add, holding one checkout of a GitCommitSnapshot.
+// Each work tree checks out commits on its own branch, since git won't check out a branch in two work trees,
+// named tempCheckoutBranch suffixed with the work tree's dir name.
+type workTree struct {
+	repo   *repo.Repository
+	branch string
+	id     snap.ID  // Snapshot checked out, empty if the last checkout failed.
+	sparse []string // Sparse-checkout patterns applied, nil for a full checkout.
+}
+
+// workTreePool lets GitCommitSnapshots be checked out concurrently, each in its own work tree.
+// Released work trees are kept for reuse since checking out a commit over another one only
+// writes the files that differ, and a work tree already holding the requested snapshot is reused as is.
+type workTreePool struct {
+	mu    sync.Mutex
+	idle  []*workTree          // Most recently released last.
+	inUse map[string]*workTree // Keyed by path.
+	stat  stats.StatsReceiver
+}
+
+func newWorkTreePool(stat stats.StatsReceiver) *workTreePool {
+	return &workTreePool{inUse: map[string]*workTree{}, stat: stat}
+}
+
+// Takes an idle work tree out of the pool, preferring one that already holds id checked out with sparse,
+// in which case cached is true. Returns nil if there are no idle work trees.
+func (p *workTreePool) acquire(id snap.ID, sparse []string) (wt *workTree, cached bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.idle) == 0 {
+		return nil, false
+	}
+	i := len(p.idle) - 1
+	for j, idle := range p.idle {
+		if idle.id == id && sparsePatternsEqual(idle.sparse, sparse) {
+			i, cached = j, true
+			break
+		}
+	}
+	wt = p.idle[i]
+	p.idle = append(p.idle[:i], p.idle[i+1:]...)
+	p.inUse[wt.repo.Dir()] = wt
+	p.updateStats()
+	return wt, cached
+}
+
+func (p *workTreePool) add(wt *workTree) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inUse[wt.repo.Dir()] = wt
+	p.updateStats()
+}
+
+// Returns the work tree at path to the pool, and the work tree it evicts to stay within maxIdleWorkTrees, if any.
+// ok is false if there's no work tree in use at path.
+func (p *workTreePool) release(path string) (evicted *workTree, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wt, ok := p.inUse[path]
+	if !ok {
+		return nil, false
+	}
+	delete(p.inUse, path)
+	p.idle = append(p.idle, wt)
+	if len(p.idle) > maxIdleWorkTrees {
+		evicted = p.idle[0]
+		p.idle = p.idle[1:]
+	}
+	p.updateStats()
+	return evicted, true
+}
+
+// Drops a work tree that's in use from the pool, ex: after a failed checkout.
+func (p *workTreePool) drop(wt *workTree) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inUse, wt.repo.Dir())
+	p.updateStats()
+}
+
+// Drops and returns all work trees, idle or in use.
+func (p *workTreePool) drain() []*workTree {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	all := p.idle
+	for _, wt := range p.inUse {
+		all = append(all, wt)
+	}
+	p.idle = nil
+	p.inUse = map[string]*workTree{}
+	p.updateStats()
+	return all
+}
+
+func (p *workTreePool) updateStats() {
+	p.stat.Gauge(stats.GitDBWorkTreesInUseGauge).Update(int64(len(p.inUse)))
+	p.stat.Gauge(stats.GitDBWorkTreesIdleGauge).Update(int64(len(p.idle)))
+}
+
+// Makes an empty workTreesDirName dir for the work trees, removing any left by a previous DB and their branches.
+// Only one DB may use a data repo at a time.
+func (db *DB) initWorkTrees() error {
+	gitDir, err := db.dataRepo.Run("rev-parse", "--git-common-dir")
+	if err != nil {
+		return err
+	}
+	if gitDir = strings.TrimSpace(gitDir); !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(db.dataRepo.Dir(), gitDir)
+	}
+	dir := filepath.Join(gitDir, workTreesDirName)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	db.workTreesDir = &temp.TempDir{Dir: dir}
+
+	// Forget the removed work trees, and any others whose dirs are gone.
+	if _, err := db.dataRepo.Run("worktree", "prune"); err != nil {
+		return err
+	}
+	branches, err := db.dataRepo.Run("for-each-ref", "--format=%(refname:short)", "refs/heads/"+tempCheckoutBranch+"-*")
+	if err != nil {
+		return err
+	}
+	if branches := strings.Fields(branches); len(branches) > 0 {
+		log.Infof("Deleting %d branches of removed work trees", len(branches))
+		if _, err := db.dataRepo.Run(append([]string{"branch", "-D"}, branches...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Creates a new work tree of the data repo with nothing checked out, sha is only used as its initial HEAD.
+func (db *DB) addWorkTree(sha string) (*workTree, error) {
+	dir, err := db.workTreesDir.TempDir("worktree")
+	if err != nil {
+		return nil, err
+	}
+	// --no-checkout leaves writing files to the checkout, after any sparse-checkout patterns are set.
+	if _, err := db.dataRepo.Run("worktree", "add", "--no-checkout", "--detach", dir.Dir, sha); err != nil {
+		os.RemoveAll(dir.Dir)
+		return nil, fmt.Errorf("Unable to add work tree for %s: %v", sha, err)
+	}
+	r, err := repo.NewRepository(dir.Dir)
+	if err != nil {
+		db.removeWorkTree(dir.Dir, "")
+		return nil, err
+	}
+	wt := &workTree{repo: r, branch: tempCheckoutBranch + "-" + filepath.Base(dir.Dir)}
+	log.Infof("Added work tree %s", r.Dir())
+	return wt, nil
+}
+
+// Removes the work tree at dir, its git metadata and its branch, if it has one.
+func (db *DB) removeWorkTree(dir, branch string) {
+	if _, err := db.dataRepo.Run("worktree", "remove", "--force", dir); err != nil {
+		log.Errorf("Unable to remove work tree %s, removing its dir: %v", dir, err)
+		os.RemoveAll(dir)
+		db.dataRepo.Run("worktree", "prune")
+	}
+	if branch != "" {
+		db.dataRepo.Run("branch", "-D", branch)
+	}
+	log.Infof("Removed work tree %s", dir)
+}
@@ -70,8 +70,8 @@ func (r *Repository) RunCmd(cmd *exec.Cmd, ctx context.Context, cancel context.C
 	}
 
 	log.Info("repo.Repository.Run complete. Err: ", err)
-	if err != nil && err.(*exec.ExitError) != nil {
-		log.Info("repo.Repository.Run error: ", string(err.(*exec.ExitError).Stderr))
+	if ee, ok := err.(*exec.ExitError); ok {
+		log.Info("repo.Repository.Run error: ", string(ee.Stderr))
 	}
 	return string(data), err
 }
@@ -0,0 +1,280 @@
+/*
+package snapid parses, validates and builds Snapshot IDs.
+
+A Snapshot ID is a string of dash-separated parts. The first part says where the Snapshot lives,
+and so how the rest of the ID is laid out:
+
+	bz-<hash>-<sizeBytes>                    Bazel digest in the CAS
+	local-<format>-<sha>                     git object in the local gitdb
+	stream-<format>-<stream>-<sha>           git object fetched from a stream (stream may contain '-')
+	tags-<format>-<name>-<sha>               git object pushed as a tag
+	bs-<format>-<bundleKey>-<stream>-<sha>   git object in a bundle in the bundlestore
+
+where format is "gc" for a git commit or "fs" for a filesystem snapshot (a git tree), and hash is
+hex encoded by a digest function bazel Snapshots may be addressed by, which its length identifies.
+These are SHA256, SHA512 and any added with RegisterDigestFunction.
+
+Formats are versioned. IDs don't carry a version marker: the layouts above are Version1 and are
+recognized by their prefix. A future incompatible layout must use a new prefix and Version
+so IDs already stored in jobs and caches keep parsing.
+*/
+package snapid
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// Format is the kind of data a Snapshot holds.
+type Format string
+
+const (
+	FormatGitCommit Format = "gc"
+	FormatFS        Format = "fs"
+	FormatBazel     Format = "bz"
+)
+
+// Backend is where a git Snapshot lives. Bazel Snapshots have no Backend.
+type Backend string
+
+const (
+	BackendLocal       Backend = "local"
+	BackendStream      Backend = "stream"
+	BackendTags        Backend = "tags"
+	BackendBundlestore Backend = "bs"
+)
+
+const (
+	Version1       = 1
+	CurrentVersion = Version1
+)
+
+const (
+	gitShaLen    = 40
+	bazelIDParts = 3
+)
+
+var (
+	hashLensMu sync.RWMutex
+	hashLens   = map[remoteexecution.DigestFunction]int{
+		remoteexecution.DigestFunction_SHA256: sha256.Size * 2,
+		remoteexecution.DigestFunction_SHA512: sha512.Size * 2,
+	}
+)
+
+// RegisterDigestFunction allows bazel Snapshots addressed by digest function f, whose hex encoded
+// hashes are hexLen characters. See bazel.RegisterDigestFunction, which calls it.
+func RegisterDigestFunction(f remoteexecution.DigestFunction, hexLen int) {
+	hashLensMu.Lock()
+	defer hashLensMu.Unlock()
+	hashLens[f] = hexLen
+}
+
+// Returns the digest function identified by the length of a hash, or an error listing the allowed lengths.
+func hashDigestFunction(hash string) (remoteexecution.DigestFunction, error) {
+	hashLensMu.RLock()
+	defer hashLensMu.RUnlock()
+	expected := []string{}
+	for f, n := range hashLens {
+		if len(hash) == n {
+			return f, nil
+		}
+		expected = append(expected, fmt.Sprintf("%d for %s", n, strings.ToLower(f.String())))
+	}
+	sort.Strings(expected)
+	return remoteexecution.DigestFunction_UNKNOWN,
+		fmt.Errorf("hash %q is %d characters, expected %s", hash, len(hash), strings.Join(expected, " or "))
+}
+
+// ID is a parsed Snapshot ID.
+type ID struct {
+	Format  Format
+	Version int
+	Backend Backend
+	SHA     string
+
+	// Size of the digest of a bazel Snapshot. -1 means unknown.
+	Size int64
+	// Name of the stream for stream and bundlestore Snapshots, or of the tags backend for tags Snapshots.
+	Name string
+	// Key of the bundle holding a bundlestore Snapshot.
+	BundleKey string
+}
+
+// Bazel makes the ID of the bazel Snapshot with the given digest sha and size.
+func Bazel(sha string, size int64) ID {
+	return ID{Format: FormatBazel, Version: CurrentVersion, SHA: sha, Size: size}
+}
+
+// FromDigest makes the ID of the bazel Snapshot with digest d.
+func FromDigest(d *remoteexecution.Digest) ID {
+	return Bazel(d.GetHash(), d.GetSizeBytes())
+}
+
+// Local makes the ID of a Snapshot in the local gitdb.
+func Local(f Format, sha string) ID {
+	return ID{Format: f, Version: CurrentVersion, Backend: BackendLocal, SHA: sha}
+}
+
+// Stream makes the ID of a Snapshot fetched from the named stream.
+func Stream(f Format, stream, sha string) ID {
+	return ID{Format: f, Version: CurrentVersion, Backend: BackendStream, SHA: sha, Name: stream}
+}
+
+// Tags makes the ID of a Snapshot pushed to the named tags backend.
+func Tags(f Format, name, sha string) ID {
+	return ID{Format: f, Version: CurrentVersion, Backend: BackendTags, SHA: sha, Name: name}
+}
+
+// Bundlestore makes the ID of a Snapshot in a bundlestore bundle, based on the named stream.
+func Bundlestore(f Format, bundleKey, stream, sha string) ID {
+	return ID{Format: f, Version: CurrentVersion, Backend: BackendBundlestore, SHA: sha, Name: stream, BundleKey: bundleKey}
+}
+
+// Parse parses and validates a Snapshot ID.
+func Parse(id string) (ID, error) {
+	if id == "" {
+		return ID{}, fmt.Errorf("empty snapshot ID")
+	}
+	if strings.HasPrefix(id, string(FormatBazel)+"-") {
+		// Keep the size whole, it is -1 when unknown.
+		return parseBazel(id, strings.SplitN(id, "-", bazelIDParts))
+	}
+	return parseGit(id, strings.Split(id, "-"))
+}
+
+// Validate checks that id is a well formed Snapshot ID.
+func Validate(id string) error {
+	_, err := Parse(id)
+	return err
+}
+
+func parseBazel(id string, parts []string) (ID, error) {
+	if len(parts) != bazelIDParts {
+		return ID{}, invalid(id, "expected bz-<hash>-<sizeBytes>")
+	}
+	size, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return ID{}, invalid(id, "size %q is not a number", parts[2])
+	}
+	s := Bazel(parts[1], size)
+	if err := s.validate(); err != nil {
+		return ID{}, invalid(id, "%v", err)
+	}
+	return s, nil
+}
+
+func parseGit(id string, parts []string) (ID, error) {
+	if len(parts) < 3 {
+		return ID{}, invalid(id, "expected <backend>-<format>-...-<sha> or bz-<hash>-<sizeBytes>")
+	}
+	backend, f, extra := Backend(parts[0]), Format(parts[1]), parts[2:]
+	sha := extra[len(extra)-1]
+
+	var s ID
+	switch backend {
+	case BackendLocal:
+		if len(extra) != 1 {
+			return ID{}, invalid(id, "expected local-<format>-<sha>")
+		}
+		s = Local(f, sha)
+	case BackendStream:
+		if len(extra) < 2 {
+			return ID{}, invalid(id, "expected stream-<format>-<stream>-<sha>")
+		}
+		// The stream name may itself contain '-'.
+		s = Stream(f, strings.Join(extra[:len(extra)-1], "-"), sha)
+	case BackendTags:
+		if len(extra) != 2 {
+			return ID{}, invalid(id, "expected tags-<format>-<name>-<sha>")
+		}
+		s = Tags(f, extra[0], sha)
+	case BackendBundlestore:
+		if len(extra) != 3 {
+			return ID{}, invalid(id, "expected bs-<format>-<bundleKey>-<stream>-<sha>")
+		}
+		s = Bundlestore(f, extra[0], extra[1], sha)
+	default:
+		return ID{}, invalid(id, "unrecognized backend %q, expected one of %s, %s, %s, %s or %s",
+			backend, BackendLocal, BackendStream, BackendTags, BackendBundlestore, FormatBazel)
+	}
+	if err := s.validate(); err != nil {
+		return ID{}, invalid(id, "%v", err)
+	}
+	return s, nil
+}
+
+func invalid(id, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid snapshot ID %q: %s", id, fmt.Sprintf(format, args...))
+}
+
+func (s ID) validate() error {
+	if s.Version != Version1 {
+		return fmt.Errorf("unsupported version %d", s.Version)
+	}
+	switch s.Format {
+	case FormatBazel:
+		if _, err := hashDigestFunction(s.SHA); err != nil {
+			return err
+		}
+		if s.Size < -1 {
+			return fmt.Errorf("size %d is negative, expected -1 if unknown", s.Size)
+		}
+		return nil
+	case FormatGitCommit, FormatFS:
+		if len(s.SHA) != gitShaLen {
+			return fmt.Errorf("sha %q is %d characters, expected %d", s.SHA, len(s.SHA), gitShaLen)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unrecognized format %q, expected %s or %s", s.Format, FormatGitCommit, FormatFS)
+	}
+}
+
+// Validate checks that s can be used as a Snapshot ID.
+func (s ID) Validate() error {
+	if err := s.validate(); err != nil {
+		return fmt.Errorf("invalid snapshot ID %q: %v", s.String(), err)
+	}
+	return nil
+}
+
+// String returns the Snapshot ID s represents.
+func (s ID) String() string {
+	if s.Format == FormatBazel {
+		return fmt.Sprintf("%s-%s-%d", FormatBazel, s.SHA, s.Size)
+	}
+	parts := []string{string(s.Backend), string(s.Format)}
+	switch s.Backend {
+	case BackendStream, BackendTags:
+		parts = append(parts, s.Name)
+	case BackendBundlestore:
+		parts = append(parts, s.BundleKey, s.Name)
+	}
+	return strings.Join(append(parts, s.SHA), "-")
+}
+
+// BundleName returns the name in the bundlestore of the bundle with the given key.
+func BundleName(bundleKey string) string {
+	return fmt.Sprintf("bs-%s.bundle", bundleKey)
+}
+
+// IsBazel returns whether s is a bazel Snapshot.
+func (s ID) IsBazel() bool {
+	return s.Format == FormatBazel
+}
+
+// Digest converts a bazel Snapshot's ID to its digest.
+func (s ID) Digest() (*remoteexecution.Digest, error) {
+	if !s.IsBazel() {
+		return nil, fmt.Errorf("snapshot ID %q is not a bazel digest", s.String())
+	}
+	return &remoteexecution.Digest{Hash: s.SHA, SizeBytes: s.Size}, nil
+}
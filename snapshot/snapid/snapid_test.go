@@ -0,0 +1,92 @@
+package snapid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+const (
+	gitSha    = "0123456789abcdef0123456789abcdef01234567"
+	bazelSha  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	otherSha  = "fedcba9876543210fedcba9876543210fedcba98"
+	bazelSize = int64(5)
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	for _, s := range []ID{
+		Bazel(bazelSha, bazelSize),
+		Bazel(bazelSha, -1),
+		Bazel(bazelSha+bazelSha, bazelSize),
+		Local(FormatFS, gitSha),
+		Local(FormatGitCommit, gitSha),
+		Stream(FormatGitCommit, "sm", gitSha),
+		Stream(FormatGitCommit, "sm:refs/heads/some-branch", gitSha),
+		Tags(FormatFS, "sss", gitSha),
+		Bundlestore(FormatFS, otherSha, "sm", gitSha),
+	} {
+		p, err := Parse(s.String())
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %v", s, err)
+		}
+		if p != s {
+			t.Fatalf("Expected %s to parse to %+v, got %+v", s, s, p)
+		}
+		if p.Version != CurrentVersion {
+			t.Fatalf("Expected version %d, got %d", CurrentVersion, p.Version)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for id, msg := range map[string]string{
+		"":                                   "empty",
+		"abc":                                "expected <backend>-<format>",
+		"bz-" + bazelSha:                     "expected bz-<hash>-<sizeBytes>",
+		"bz-" + bazelSha + "-five":           "not a number",
+		"bz-" + bazelSha + "-5-6":            "not a number",
+		"bz-abc-5":                           "expected 128 for sha512 or 64 for sha256",
+		"bz-" + bazelSha + "--2":             "negative",
+		"local-gc-abc":                       "sha",
+		"local-xx-" + gitSha:                 "unrecognized format",
+		"local-gc-sm-" + gitSha:              "expected local-<format>-<sha>",
+		"stream-gc-" + gitSha:                "expected stream-<format>-<stream>-<sha>",
+		"tags-gc-a-b-" + gitSha:              "expected tags-<format>-<name>-<sha>",
+		"bs-gc-sm-" + gitSha:                 "expected bs-<format>-<bundleKey>-<stream>-<sha>",
+		"bs-" + bazelSha + "-5":              "expected bs-<format>-<bundleKey>-<stream>-<sha>",
+		"remote-gc-" + gitSha:                "unrecognized backend",
+		"bs-gc-" + otherSha + "-sm-" + "abc": "sha",
+	} {
+		_, err := Parse(id)
+		if err == nil {
+			t.Fatalf("Expected %q to be invalid", id)
+		}
+		if !strings.Contains(err.Error(), msg) {
+			t.Fatalf("Expected error parsing %q to contain %q, got %v", id, msg, err)
+		}
+	}
+}
+
+func TestDigest(t *testing.T) {
+	d := &remoteexecution.Digest{Hash: bazelSha, SizeBytes: bazelSize}
+	id := FromDigest(d).String()
+	if id != "bz-"+bazelSha+"-5" {
+		t.Fatalf("Unexpected ID %s", id)
+	}
+	p, err := Parse(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rd, err := p.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rd.GetHash() != bazelSha || rd.GetSizeBytes() != bazelSize {
+		t.Fatalf("Expected digest %v, got %v", d, rd)
+	}
+
+	if _, err := Local(FormatGitCommit, gitSha).Digest(); err == nil {
+		t.Fatal("Expected error converting a git snapshot to a digest")
+	}
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// How long whether a remote Store has a bundle is remembered for.
+	DefaultRemoteExistsTTL = 10 * time.Minute
+
+	// Maximum number of remembered remote lookups.
+	DefaultRemoteExistsEntries = 1000000
+
+	// Number of remote lookups made concurrently in the background.
+	DefaultRemoteExistsWorkers = 8
+)
+
+// Remembers whether a remote Store, ex: in another region or a cold tier, has bundles, looking them up
+// in the background, so checking for a bundle that's missing locally doesn't wait on the remote store.
+type remoteExists struct {
+	remote  Store
+	ttl     time.Duration
+	max     int
+	lookups chan string
+
+	mu      sync.Mutex
+	known   map[string]existsEntry
+	pending map[string]bool
+}
+
+type existsEntry struct {
+	exists bool
+	at     time.Time
+}
+
+func newRemoteExists(remote Store) *remoteExists {
+	c := &remoteExists{
+		remote:  remote,
+		ttl:     DefaultRemoteExistsTTL,
+		max:     DefaultRemoteExistsEntries,
+		lookups: make(chan string, DefaultRemoteExistsWorkers*100),
+		known:   map[string]existsEntry{},
+		pending: map[string]bool{},
+	}
+	for i := 0; i < DefaultRemoteExistsWorkers; i++ {
+		go c.lookup()
+	}
+	return c
+}
+
+// Returns whether the remote store has name, and whether that's known. If it isn't known it's looked up
+// in the background, unless too many lookups are already waiting.
+func (c *remoteExists) get(name string) (exists, known bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.known[name]; ok && time.Since(e.at) < c.ttl {
+		return e.exists, true
+	}
+	if !c.pending[name] {
+		select {
+		case c.lookups <- name:
+			c.pending[name] = true
+		default:
+		}
+	}
+	return false, false
+}
+
+// Returns whether the remote store has name, looking it up and waiting for the result if it isn't known.
+func (c *remoteExists) check(name string) (bool, error) {
+	c.mu.Lock()
+	e, ok := c.known[name]
+	c.mu.Unlock()
+	if ok && time.Since(e.at) < c.ttl {
+		return e.exists, nil
+	}
+	exists, err := c.remote.Exists(name)
+	if err != nil {
+		return false, err
+	}
+	c.set(name, exists)
+	return exists, nil
+}
+
+// Records whether the remote store has name, ex: after writing it.
+func (c *remoteExists) set(name string, exists bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.known[name]; !ok && len(c.known) >= c.max {
+		c.evict()
+	}
+	c.known[name] = existsEntry{exists: exists, at: time.Now()}
+}
+
+// Removes expired entries, or an arbitrary tenth of them if none have expired. Caller must hold mu.
+func (c *remoteExists) evict() {
+	for name, e := range c.known {
+		if time.Since(e.at) >= c.ttl {
+			delete(c.known, name)
+		}
+	}
+	for name := range c.known {
+		if len(c.known) < c.max-c.max/10 {
+			break
+		}
+		delete(c.known, name)
+	}
+}
+
+func (c *remoteExists) lookup() {
+	for name := range c.lookups {
+		exists, err := c.remote.Exists(name)
+		if err != nil {
+			log.Infof("Failed checking if %s exists in %s: %v", name, c.remote.Root(), err)
+		} else {
+			c.set(name, exists)
+		}
+		c.mu.Lock()
+		delete(c.pending, name)
+		c.mu.Unlock()
+	}
+}
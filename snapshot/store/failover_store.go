@@ -0,0 +1,215 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Number of consecutive failures after which an upstream is considered unhealthy
+	DefaultFailoverMaxFailures = 3
+
+	// How long an unhealthy upstream is tried only after the healthy ones
+	DefaultFailoverCooldown = 30 * time.Second
+)
+
+type FailoverConfig struct {
+	// If <= 0, DefaultFailoverMaxFailures.
+	MaxFailures int
+	// If <= 0, DefaultFailoverCooldown.
+	Cooldown time.Duration
+}
+
+// UpstreamHealth tracks the health of an ordered list of upstreams. An upstream that fails MaxFailures
+// times in a row is unhealthy until Cooldown has passed, during which it's tried after the healthy ones.
+type UpstreamHealth struct {
+	maxFailures int
+	cooldown    time.Duration
+	stat        stats.StatsReceiver
+	now         func() time.Time
+
+	mu        sync.Mutex
+	failures  []int
+	downUntil []time.Time
+}
+
+func MakeUpstreamHealth(n int, cfg FailoverConfig, stat stats.StatsReceiver) *UpstreamHealth {
+	maxFailures := cfg.MaxFailures
+	if maxFailures <= 0 {
+		maxFailures = DefaultFailoverMaxFailures
+	}
+	cooldown := cfg.Cooldown
+	if cooldown <= 0 {
+		cooldown = DefaultFailoverCooldown
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	return &UpstreamHealth{
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+		stat:        stat,
+		now:         time.Now,
+		failures:    make([]int, n),
+		downUntil:   make([]time.Time, n),
+	}
+}
+
+// Returns the indexes of upstreams in the order they should be tried:
+// healthy upstreams in their configured order, then unhealthy ones.
+func (h *UpstreamHealth) Order() []int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	healthy, unhealthy := []int{}, []int{}
+	for i := range h.failures {
+		if now.Before(h.downUntil[i]) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// Records that upstream i responded, making it healthy again.
+func (h *UpstreamHealth) Succeeded(i int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures[i] = 0
+	h.downUntil[i] = time.Time{}
+}
+
+// Records that upstream i failed, marking it unhealthy if it has failed too many times in a row.
+func (h *UpstreamHealth) Failed(i int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures[i]++
+	if h.failures[i] >= h.maxFailures {
+		if !h.now().Before(h.downUntil[i]) {
+			h.stat.Counter(stats.FailoverUpstreamUnhealthyCounter).Inc(1)
+		}
+		h.downUntil[i] = h.now().Add(h.cooldown)
+	}
+}
+
+// Implements Store. FailoverStore uses an ordered list of upstream Stores, e.g. bundlestores in different
+// regions, so losing one upstream doesn't make bundles unavailable.
+//
+// Reads try each upstream in order until one has the bundle, and writes go to the first upstream that
+// accepts them. Upstreams that keep failing are tried last until they've had time to recover.
+type FailoverStore struct {
+	upstreams []Store
+	health    *UpstreamHealth
+	stat      stats.StatsReceiver
+}
+
+func MakeFailoverStore(upstreams []Store, cfg FailoverConfig, stat stats.StatsReceiver) (*FailoverStore, error) {
+	if len(upstreams) == 0 {
+		return nil, fmt.Errorf("FailoverStore needs at least one upstream")
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	roots := []string{}
+	for _, u := range upstreams {
+		roots = append(roots, u.Root())
+	}
+	log.Infof("Making new FailoverStore with upstreams: %v", roots)
+	return &FailoverStore{
+		upstreams: upstreams,
+		health:    MakeUpstreamHealth(len(upstreams), cfg, stat),
+		stat:      stat,
+	}, nil
+}
+
+func (s *FailoverStore) OpenForRead(name string) (io.ReadCloser, error) {
+	var notExistErr, lastErr error
+	for _, i := range s.health.Order() {
+		r, err := s.upstreams[i].OpenForRead(name)
+		if err == nil {
+			s.health.Succeeded(i)
+			if i != 0 {
+				s.stat.Counter(stats.BundlestoreFailoverReadCounter).Inc(1)
+			}
+			return r, nil
+		}
+		if os.IsNotExist(err) {
+			s.health.Succeeded(i)
+			notExistErr = err
+			continue
+		}
+		log.Infof("Failed reading %s from %s, trying next upstream: %v", name, s.upstreams[i].Root(), err)
+		s.health.Failed(i)
+		lastErr = err
+	}
+	// An upstream that failed may have the bundle, so only report it missing if every upstream said so.
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, notExistErr
+}
+
+func (s *FailoverStore) Exists(name string) (bool, error) {
+	var lastErr error
+	for _, i := range s.health.Order() {
+		exists, err := s.upstreams[i].Exists(name)
+		if err != nil {
+			s.health.Failed(i)
+			lastErr = err
+			continue
+		}
+		s.health.Succeeded(i)
+		if exists {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
+
+func (s *FailoverStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	_, err := s.WriteUpstream(name, data, ttl)
+	return err
+}
+
+// Writes like Write, returning the Root of the upstream that accepted the write,
+// which is where the bundle can be read from until it's replicated elsewhere.
+func (s *FailoverStore) WriteUpstream(name string, data io.Reader, ttl *TTLValue) (string, error) {
+	// Data is buffered so it can be written again if an upstream fails part way through.
+	b, err := ioutil.ReadAll(data)
+	if err != nil {
+		return "", err
+	}
+	for _, i := range s.health.Order() {
+		err = s.upstreams[i].Write(name, bytes.NewReader(b), ttl)
+		if err == nil {
+			s.health.Succeeded(i)
+			if i != 0 {
+				s.stat.Counter(stats.BundlestoreFailoverWriteCounter).Inc(1)
+			}
+			return s.upstreams[i].Root(), nil
+		}
+		log.Infof("Failed writing %s to %s, trying next upstream: %v", name, s.upstreams[i].Root(), err)
+		s.health.Failed(i)
+	}
+	return "", err
+}
+
+// Returns the Root of the first healthy upstream.
+func (s *FailoverStore) Root() string {
+	return s.upstreams[s.health.Order()[0]].Root()
+}
+
+// Lists every upstream, since writes that failed over may be in any of them.
+func (s *FailoverStore) List(prefix, cursor string) (*ListResult, error) {
+	return listMerged(s.upstreams, prefix, cursor)
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeFailoverStore(t *testing.T, n int) ([]*flakyStore, *FailoverStore) {
+	flaky, upstreams := makeReplicas(n)
+	s, err := MakeFailoverStore(upstreams, FailoverConfig{MaxFailures: 2, Cooldown: time.Hour}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return flaky, s
+}
+
+func TestFailoverStoreRead(t *testing.T) {
+	flaky, s := makeFailoverStore(t, 3)
+	flaky[1].Files.Store("bs-1.bundle", []byte("one"))
+
+	// Upstreams missing the bundle are skipped.
+	assertHas(t, s, "bs-1.bundle", "one")
+	if exists, err := s.Exists("bs-1.bundle"); err != nil || !exists {
+		t.Fatalf("Expected bundle to exist: %v %v", exists, err)
+	}
+	if _, err := s.OpenForRead("bs-missing.bundle"); !os.IsNotExist(err) {
+		t.Fatalf("Expected not exist reading missing bundle, got %v", err)
+	}
+
+	// Down upstreams are skipped, and a bundle only they might have is an error rather than missing.
+	flaky[0].setDown(true)
+	assertHas(t, s, "bs-1.bundle", "one")
+	flaky[1].setDown(true)
+	if _, err := s.OpenForRead("bs-1.bundle"); err == nil || os.IsNotExist(err) {
+		t.Fatalf("Expected error reading bundle with its upstream down, got %v", err)
+	}
+	if exists, err := s.Exists("bs-1.bundle"); err == nil || exists {
+		t.Fatalf("Expected error checking bundle with its upstream down: %v %v", exists, err)
+	}
+}
+
+func TestFailoverStoreWrite(t *testing.T) {
+	flaky, s := makeFailoverStore(t, 2)
+
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), nil); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	assertHas(t, flaky[0], "bs-1.bundle", "one")
+
+	flaky[0].setDown(true)
+	if err := s.Write("bs-2.bundle", strings.NewReader("two"), nil); err != nil {
+		t.Fatalf("Expected write to fail over: %v", err)
+	}
+	assertHas(t, flaky[1], "bs-2.bundle", "two")
+
+	flaky[1].setDown(true)
+	if err := s.Write("bs-3.bundle", strings.NewReader("three"), nil); err == nil {
+		t.Fatal("Expected write to fail with all upstreams down")
+	}
+}
+
+// A flakyStore with its own Root, to tell upstreams apart.
+type rootedStore struct {
+	*flakyStore
+	root string
+}
+
+func (r rootedStore) Root() string { return r.root }
+
+func TestFailoverStoreWriteUpstream(t *testing.T) {
+	flaky, _ := makeReplicas(2)
+	s, err := MakeFailoverStore(
+		[]Store{rootedStore{flaky[0], "http://bs1/"}, rootedStore{flaky[1], "http://bs2/"}}, FailoverConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if root, err := s.WriteUpstream("bs-1.bundle", strings.NewReader("one"), nil); err != nil || root != "http://bs1/" {
+		t.Fatalf("Expected write to the first upstream, got %q, %v", root, err)
+	}
+	flaky[0].setDown(true)
+	if root, err := s.WriteUpstream("bs-2.bundle", strings.NewReader("two"), nil); err != nil || root != "http://bs2/" {
+		t.Fatalf("Expected write to fail over to the second upstream, got %q, %v", root, err)
+	}
+	assertHas(t, flaky[1], "bs-2.bundle", "two")
+}
+
+func TestFailoverStoreHealth(t *testing.T) {
+	flaky, s := makeFailoverStore(t, 2)
+	now := time.Now()
+	s.health.now = func() time.Time { return now }
+
+	// After MaxFailures the first upstream is tried last, even once it's back.
+	flaky[0].setDown(true)
+	for i := 0; i < 2; i++ {
+		s.Exists("bs-1.bundle")
+	}
+	flaky[0].setDown(false)
+	if order := s.health.Order(); order[0] != 1 {
+		t.Fatalf("Expected unhealthy upstream to be tried last, got %v", order)
+	}
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), nil); err != nil {
+		t.Fatal(err)
+	}
+	assertHas(t, flaky[1], "bs-1.bundle", "one")
+	if exists, _ := flaky[0].Exists("bs-1.bundle"); exists {
+		t.Fatal("Expected write to skip unhealthy upstream")
+	}
+
+	// After the cooldown it's tried first again.
+	now = now.Add(2 * time.Hour)
+	if order := s.health.Order(); order[0] != 0 {
+		t.Fatalf("Expected recovered upstream to be tried first, got %v", order)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"sync"
 )
 
@@ -25,7 +26,7 @@ func (f *FakeStore) Exists(name string) (bool, error) {
 func (f *FakeStore) OpenForRead(name string) (io.ReadCloser, error) {
 	v, ok := f.Files.Load(name)
 	if !ok {
-		return nil, errors.New("Doesn't exist :" + name)
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
 	}
 	b, ok := v.([]byte)
 	if !ok {
@@ -36,6 +37,15 @@ func (f *FakeStore) OpenForRead(name string) (io.ReadCloser, error) {
 
 func (f *FakeStore) Root() string { return "" }
 
+func (f *FakeStore) List(prefix, cursor string) (*ListResult, error) {
+	names := []string{}
+	f.Files.Range(func(k, v interface{}) bool {
+		names = append(names, k.(string))
+		return true
+	})
+	return listNames(names, prefix, cursor), nil
+}
+
 func (f *FakeStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 	if (f.TTL == nil) != (ttl == nil) || (ttl != nil && (f.TTL.TTLKey != ttl.TTLKey || f.TTL.TTL.Sub(ttl.TTL) != 0)) {
 		return fmt.Errorf("TTL mismatch: expected: %v, got: %v", f.TTL, ttl)
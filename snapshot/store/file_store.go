@@ -3,9 +3,13 @@ package store
 import (
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -24,16 +28,41 @@ func MakeFileStoreInTemp(tmp *temp.TempDir) (*FileStore, error) {
 
 func MakeFileStore(dir string) (*FileStore, error) {
 	log.Infof("Making new FileStore at dir: %s", dir)
-	return &FileStore{dir}, nil
+	packs, err := loadPackIndex(filepath.Join(dir, PackDir))
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{bundleDir: dir, packs: packs}, nil
 }
 
+// How long the sorted names listed for the first page of a listing are reused for its later pages
+const ListIndexMaxAge = time.Minute
+
+// Blobs are stored as files in bundleDir, or in pack files once compacted (see Compact).
 type FileStore struct {
 	bundleDir string
+	packs     *packIndex
+
+	// Sorted names of the blobs when last listed, so pages after the first are found without
+	// listing the dir again.
+	listMu    sync.Mutex
+	listNames []string
+	listedAt  time.Time
 }
 
 func (s *FileStore) OpenForRead(name string) (io.ReadCloser, error) {
 	bundlePath := filepath.Join(s.bundleDir, name)
-	return os.Open(bundlePath)
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		// Blobs whose pack is missing are misses like any other
+		if os.IsNotExist(err) && !strings.Contains(name, "/") {
+			if r, err := s.packs.openBlob(name); !os.IsNotExist(err) {
+				return r, err
+			}
+		}
+		return nil, err
+	}
+	return f, nil
 }
 
 func (s *FileStore) Exists(name string) (bool, error) {
@@ -44,10 +73,17 @@ func (s *FileStore) Exists(name string) (bool, error) {
 	if err == nil {
 		return true, nil
 	}
+	if !os.IsNotExist(err) {
+		return false, err
+	}
+	r, err := s.packs.openBlob(name)
 	if os.IsNotExist(err) {
 		return false, nil
+	} else if err != nil {
+		return false, err
 	}
-	return false, err
+	r.Close()
+	return true, nil
 }
 
 func (s *FileStore) Write(name string, data io.Reader, ttl *TTLValue) error {
@@ -71,3 +107,56 @@ func (s *FileStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 func (s *FileStore) Root() string {
 	return s.bundleDir
 }
+
+// Lists the blobs in the root and in its pack files. Dirs and temp files, which start with '.', are skipped.
+// The first page of a listing lists the dir, later ones reuse its sorted names for up to ListIndexMaxAge,
+// so blobs written after the first page may be left out.
+func (s *FileStore) List(prefix, cursor string) (*ListResult, error) {
+	names, err := s.sortedNames(cursor == "")
+	if err != nil {
+		return nil, err
+	}
+	i := sort.SearchStrings(names, prefix)
+	if cursor >= prefix {
+		i = sort.Search(len(names), func(j int) bool { return names[j] > cursor })
+	}
+	page := []string{}
+	for ; i < len(names) && strings.HasPrefix(names[i], prefix); i++ {
+		if len(page) == ListPageSize {
+			return &ListResult{Names: page, Cursor: page[len(page)-1]}, nil
+		}
+		page = append(page, names[i])
+	}
+	return &ListResult{Names: page}, nil
+}
+
+// Returns the sorted names of the blobs in the root and in its pack files, listing them again if relist is set
+// or they were listed more than ListIndexMaxAge ago. The returned slice isn't modified.
+func (s *FileStore) sortedNames(relist bool) ([]string, error) {
+	s.listMu.Lock()
+	defer s.listMu.Unlock()
+	if !relist && s.listNames != nil && time.Since(s.listedAt) < ListIndexMaxAge {
+		return s.listNames, nil
+	}
+	infos, err := ioutil.ReadDir(s.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, i := range infos {
+		if !i.IsDir() && !strings.HasPrefix(i.Name(), ".") {
+			names = append(names, i.Name())
+		}
+	}
+	names = append(names, s.packs.names()...)
+	sort.Strings(names)
+	unique := names[:0]
+	for _, name := range names {
+		if len(unique) == 0 || name != unique[len(unique)-1] {
+			unique = append(unique, name)
+		}
+	}
+	s.listNames = unique
+	s.listedAt = time.Now()
+	return unique, nil
+}
@@ -0,0 +1,643 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Dir in a FileStore's root holding its pack files
+	PackDir = "packs"
+
+	// Only blobs at most this many bytes are packed
+	DefaultCompactMaxBlobSize = 64 * 1024
+	// Only blobs written at least this long ago are packed
+	DefaultCompactMinAge = time.Hour
+	// Pack files are closed once they reach this many bytes
+	DefaultMaxPackSize = 256 * 1024 * 1024
+	// How often CompactPeriodically runs a compaction pass
+	DefaultCompactInterval = time.Hour
+
+	packExt      = ".pack"
+	packIndexExt = ".idx"
+	packTmpExt   = ".tmp"
+)
+
+// Configuration for compacting a FileStore, which packs its small blobs into pack files so they use
+// fewer inodes and are read with fewer opens and seeks. Zero values use the defaults.
+//
+// Packed blobs are still read through the FileStore, but no longer exist as files in its root.
+// Tools that walk the root, like the CAS scrubber and the run log sweeper, find them with PackedBlobs
+// and delete them with RemovePacked. Their modification times are kept in the pack index for this.
+type CompactionConfig struct {
+	// Only names with this prefix are packed, ex: "blob-" for CAS blobs. Empty packs everything.
+	Prefix      string
+	MaxBlobSize int64
+	MinAge      time.Duration
+	MaxPackSize int64
+	Interval    time.Duration
+}
+
+// Results of a single compaction pass
+type CompactionResult struct {
+	Packs       int
+	Packed      int
+	PackedBytes int64
+	// Index entries dropped because their blob was rewritten, removed or packed again,
+	// and pack files removed because few of their blobs were left.
+	Pruned       int
+	RemovedPacks int
+}
+
+// A blob in one of a FileStore's pack files
+type PackedBlob struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Distinguishes packs written by this process
+var packSeq int64
+
+// Serializes rewriting pack indexes in this process. Stores made for the same dir, ex: by the scrubber,
+// share the files but not a packIndex.
+var packIndexWriteMu sync.Mutex
+
+// Location of a packed blob
+type packEntry struct {
+	pack    string
+	offset  int64
+	size    int64
+	modTime time.Time
+}
+
+// In-memory index of the blobs in a FileStore's pack files, loaded from the pack index files written
+// alongside them. Since other processes may compact a shared dir, it's reloaded whenever the pack dir changes.
+// A blob in more than one pack, ex: rewritten and packed again, is read from the newest.
+type packIndex struct {
+	dir string
+
+	mu      sync.RWMutex
+	entries map[string]packEntry
+	modTime time.Time
+}
+
+func loadPackIndex(dir string) (*packIndex, error) {
+	p := &packIndex{dir: dir, entries: map[string]packEntry{}}
+	if err := p.refresh(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Returns the location of the packed blob name, reloading the index if the pack dir changed,
+// ex: because another store removed the blob.
+func (p *packIndex) lookup(name string) (packEntry, bool) {
+	if err := p.refresh(); err != nil {
+		log.Errorf("Failed reloading pack index in %s: %v", p.dir, err)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.entries[name]
+	return e, ok
+}
+
+// Reloads the index if the pack dir changed since it was last loaded.
+func (p *packIndex) refresh() error {
+	return p.reload(false)
+}
+
+// Reloads the index from the index files in the pack dir, if it changed or force is set.
+func (p *packIndex) reload(force bool) error {
+	info, err := os.Stat(p.dir)
+	if os.IsNotExist(err) {
+		p.mu.Lock()
+		p.entries = map[string]packEntry{}
+		p.mu.Unlock()
+		return nil
+	} else if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !force && info.ModTime().Equal(p.modTime) {
+		return nil
+	}
+	packs, err := listPacks(p.dir)
+	if err != nil {
+		return err
+	}
+	entries := map[string]packEntry{}
+	// Packs are listed oldest first, so newer entries replace older ones
+	for _, pack := range packs {
+		packEntries, err := readPackIndex(p.dir, pack)
+		if os.IsNotExist(err) {
+			// Removed since the dir was listed
+			continue
+		} else if err != nil {
+			return err
+		}
+		for name, e := range packEntries {
+			entries[name] = e
+		}
+	}
+	p.entries = entries
+	// Changes made within the dir's timestamp granularity of this load can't be told apart from it,
+	// so loads of recently changed dirs are redone
+	p.modTime = time.Time{}
+	if time.Since(info.ModTime()) > time.Second {
+		p.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// Returns the names of the packs with index files in dir, oldest first.
+func listPacks(dir string) ([]string, error) {
+	names, err := readDirNames(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	packs := []string{}
+	for _, name := range names {
+		if pack := strings.TrimSuffix(name, packIndexExt); pack != name {
+			packs = append(packs, pack)
+		}
+	}
+	// Pack names start with the time they were written
+	sort.Strings(packs)
+	return packs, nil
+}
+
+func readDirNames(dir string) ([]string, error) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+	return d.Readdirnames(-1)
+}
+
+// Reads the entries in pack's index file. Indexes written before modification times were recorded
+// use the index file's.
+func readPackIndex(dir, pack string) (map[string]packEntry, error) {
+	f, err := os.Open(filepath.Join(dir, pack+packIndexExt))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]packEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 && len(fields) != 4 {
+			return nil, fmt.Errorf("Malformed line in index of pack %s: %q", pack, scanner.Text())
+		}
+		offset, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Malformed offset in index of pack %s: %v", pack, err)
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Malformed size in index of pack %s: %v", pack, err)
+		}
+		modTime := info.ModTime()
+		if len(fields) == 4 {
+			nanos, err := strconv.ParseInt(fields[3], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Malformed modification time in index of pack %s: %v", pack, err)
+			}
+			modTime = time.Unix(0, nanos)
+		}
+		entries[fields[0]] = packEntry{pack: pack, offset: offset, size: size, modTime: modTime}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Atomically replaces pack's index file with entries. If there are none the pack is removed,
+// its index first since packs without one are ignored.
+func writePackIndex(dir, pack string, entries map[string]packEntry) error {
+	idxPath := filepath.Join(dir, pack+packIndexExt)
+	if len(entries) == 0 {
+		if err := os.Remove(idxPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Remove(filepath.Join(dir, pack+packExt)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var index bytes.Buffer
+	for _, name := range names {
+		e := entries[name]
+		fmt.Fprintf(&index, "%s %d %d %d\n", name, e.offset, e.size, e.modTime.UnixNano())
+	}
+	tmpPath := idxPath + packTmpExt
+	if err := ioutil.WriteFile(tmpPath, index.Bytes(), 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, idxPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Returns the packed blobs, reloading the index if the pack dir changed.
+func (p *packIndex) blobs() []PackedBlob {
+	if err := p.refresh(); err != nil {
+		log.Errorf("Failed reloading pack index in %s: %v", p.dir, err)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	blobs := make([]PackedBlob, 0, len(p.entries))
+	for name, e := range p.entries {
+		blobs = append(blobs, PackedBlob{Name: name, Size: e.size, ModTime: e.modTime})
+	}
+	return blobs
+}
+
+// Returns the names of the packed blobs, reloading the index if the pack dir changed.
+func (p *packIndex) names() []string {
+	blobs := p.blobs()
+	names := make([]string, 0, len(blobs))
+	for _, b := range blobs {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+// Opens the packed blob name for reading. If its pack is gone, ex: removed by a compaction that
+// repacked it, the index is reloaded once. Returns a not exist error if the blob isn't packed.
+func (p *packIndex) openBlob(name string) (io.ReadCloser, error) {
+	for reloaded := false; ; reloaded = true {
+		e, ok := p.lookup(name)
+		if !ok {
+			return nil, &os.PathError{Op: "open", Path: filepath.Join(p.dir, name), Err: os.ErrNotExist}
+		}
+		r, err := p.open(e)
+		if err == nil || !os.IsNotExist(err) || reloaded {
+			return r, err
+		}
+		if err := p.reload(true); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Opens the packed blob e for reading.
+func (p *packIndex) open(e packEntry) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(p.dir, e.pack+packExt))
+	if err != nil {
+		return nil, err
+	}
+	return &packedBlobReader{io.NewSectionReader(f, e.offset, e.size), f}, nil
+}
+
+type packedBlobReader struct {
+	*io.SectionReader
+	f *os.File
+}
+
+func (r *packedBlobReader) Close() error {
+	return r.f.Close()
+}
+
+// Returns the blobs in the store's pack files. Blobs also written to its root since they were packed are included.
+func (s *FileStore) PackedBlobs() []PackedBlob {
+	return s.packs.blobs()
+}
+
+// Removes the named blobs from the store's pack files, removing packs left empty, and returns how many were removed.
+// Files of the same name in the store's root aren't touched.
+func (s *FileStore) RemovePacked(names []string) (int, error) {
+	remove := map[string]bool{}
+	for _, name := range names {
+		remove[name] = true
+	}
+	packIndexWriteMu.Lock()
+	defer packIndexWriteMu.Unlock()
+	packs, err := listPacks(s.packs.dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, pack := range packs {
+		entries, err := readPackIndex(s.packs.dir, pack)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return removed, err
+		}
+		n := len(entries)
+		for name := range entries {
+			if remove[name] {
+				delete(entries, name)
+			}
+		}
+		if len(entries) == n {
+			continue
+		}
+		if err := writePackIndex(s.packs.dir, pack, entries); err != nil {
+			return removed, err
+		}
+		removed += n - len(entries)
+	}
+	return removed, s.packs.reload(true)
+}
+
+// A blob to be packed
+type packSource struct {
+	name    string
+	size    int64
+	modTime time.Time
+	open    func() (io.ReadCloser, error)
+}
+
+// Packs the small blobs written to the store before cfg.MinAge into new pack files, then removes them from its root.
+// Blobs are added to the index before they're removed, so reads never miss a blob being packed.
+// Existing packs are then garbage collected, see gcPacks.
+func (s *FileStore) Compact(cfg CompactionConfig) (CompactionResult, error) {
+	cfg = compactionConfigWithDefaults(cfg)
+	result := CompactionResult{}
+	if err := os.MkdirAll(s.packs.dir, 0755); err != nil {
+		return result, err
+	}
+	removeStalePackTmps(s.packs.dir)
+
+	infos, err := ioutil.ReadDir(s.bundleDir)
+	if err != nil {
+		return result, err
+	}
+	cutoff := time.Now().Add(-cfg.MinAge)
+	candidates := []packSource{}
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || !strings.HasPrefix(info.Name(), cfg.Prefix) ||
+			info.Size() > cfg.MaxBlobSize || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		path := filepath.Join(s.bundleDir, info.Name())
+		candidates = append(candidates, packSource{
+			name:    info.Name(),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+			open:    func() (io.ReadCloser, error) { return os.Open(path) },
+		})
+	}
+
+	for len(candidates) > 0 {
+		n, packed, packedBytes, err := s.writePack(candidates, cfg.MaxPackSize)
+		candidates = candidates[n:]
+		for _, name := range packed {
+			if err := os.Remove(filepath.Join(s.bundleDir, name)); err != nil && !os.IsNotExist(err) {
+				return result, err
+			}
+		}
+		if len(packed) == 0 {
+			if err != nil {
+				return result, err
+			}
+			continue
+		}
+		result.Packs++
+		result.Packed += len(packed)
+		result.PackedBytes += packedBytes
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, s.gcPacks(cfg, &result)
+}
+
+// Prunes the index entries of blobs that were rewritten to the store's root or packed again since,
+// which are never read. Packs left with no blobs are removed, and those left with under half their bytes
+// are repacked so the space of the pruned blobs is freed.
+func (s *FileStore) gcPacks(cfg CompactionConfig, result *CompactionResult) error {
+	packIndexWriteMu.Lock()
+	defer packIndexWriteMu.Unlock()
+	if err := s.packs.reload(true); err != nil {
+		return err
+	}
+	packs, err := listPacks(s.packs.dir)
+	if err != nil {
+		return err
+	}
+	for _, pack := range packs {
+		entries, err := readPackIndex(s.packs.dir, pack)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		live := map[string]packEntry{}
+		liveBytes := int64(0)
+		for name, e := range entries {
+			if newest, ok := s.packs.lookup(name); ok && newest.pack != pack {
+				continue
+			}
+			if _, err := os.Lstat(filepath.Join(s.bundleDir, name)); err == nil {
+				continue
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+			live[name] = e
+			liveBytes += e.size
+		}
+		if len(live) == len(entries) {
+			continue
+		}
+
+		pruned := len(entries) - len(live)
+		info, err := os.Stat(filepath.Join(s.packs.dir, pack+packExt))
+		if err == nil && len(live) > 0 && liveBytes < info.Size()/2 {
+			if err := s.repack(pack, live, cfg.MaxPackSize); err != nil {
+				return err
+			}
+			live = nil
+		} else if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := writePackIndex(s.packs.dir, pack, live); err != nil {
+			return err
+		}
+		result.Pruned += pruned
+		if len(live) == 0 {
+			result.RemovedPacks++
+		}
+	}
+	return s.packs.reload(true)
+}
+
+// Copies the live entries of pack into new packs, keeping their modification times. The caller removes pack.
+func (s *FileStore) repack(pack string, live map[string]packEntry, maxPackSize int64) error {
+	srcs := []packSource{}
+	for name, e := range live {
+		e := e
+		srcs = append(srcs, packSource{
+			name:    name,
+			size:    e.size,
+			modTime: e.modTime,
+			open:    func() (io.ReadCloser, error) { return s.packs.open(e) },
+		})
+	}
+	for len(srcs) > 0 {
+		n, packed, _, err := s.writePack(srcs, maxPackSize)
+		if err != nil {
+			return err
+		}
+		if len(packed) < n {
+			return fmt.Errorf("Failed reading %d blobs from pack %s", n-len(packed), pack)
+		}
+		srcs = srcs[n:]
+	}
+	return nil
+}
+
+// Packs blobs from the start of srcs into a single pack file of up to maxPackSize bytes and indexes it.
+// Returns how many of srcs were consumed and the names of those that were packed.
+// Blobs that can't be read, most likely because they were removed since the dir was listed, are skipped.
+func (s *FileStore) writePack(srcs []packSource, maxPackSize int64) (int, []string, int64, error) {
+	// Unique across processes compacting the same dir
+	pack := fmt.Sprintf("%d-%d-%d", time.Now().UnixNano(), os.Getpid(), atomic.AddInt64(&packSeq, 1))
+	tmpPath := filepath.Join(s.packs.dir, pack+packExt+packTmpExt)
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return len(srcs), nil, 0, err
+	}
+	defer os.Remove(tmpPath)
+	defer f.Close()
+
+	entries := map[string]packEntry{}
+	names := []string{}
+	offset := int64(0)
+	n := 0
+	for ; n < len(srcs); n++ {
+		if offset > 0 && offset+srcs[n].size > maxPackSize {
+			break
+		}
+		size, err := appendBlob(f, srcs[n])
+		if err != nil {
+			log.Infof("Not packing %s: %v", srcs[n].name, err)
+			if _, err := f.Seek(offset, io.SeekStart); err != nil {
+				return n, nil, 0, err
+			}
+			continue
+		}
+		entries[srcs[n].name] = packEntry{pack: pack, offset: offset, size: size, modTime: srcs[n].modTime}
+		names = append(names, srcs[n].name)
+		offset += size
+	}
+	if len(names) == 0 {
+		return n, nil, 0, nil
+	}
+	if err := f.Truncate(offset); err != nil {
+		return n, nil, 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return n, nil, 0, err
+	}
+	packPath := filepath.Join(s.packs.dir, pack+packExt)
+	if err := os.Rename(tmpPath, packPath); err != nil {
+		return n, nil, 0, err
+	}
+
+	// The index is written last and atomically, since packs without one are ignored
+	if err := writePackIndex(s.packs.dir, pack, entries); err != nil {
+		os.Remove(packPath)
+		return n, nil, 0, err
+	}
+	if err := s.packs.reload(true); err != nil {
+		return n, nil, 0, err
+	}
+	log.Infof("Packed %d blobs (%d bytes) into %s", len(names), offset, pack+packExt)
+	return n, names, offset, nil
+}
+
+// Copies the blob src to the end of w, returning its size.
+func appendBlob(w io.Writer, src packSource) (int64, error) {
+	r, err := src.open()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+// Removes pack and index files left behind by compactions that didn't finish. Only old ones are removed,
+// since another process may be compacting the same dir.
+func removeStalePackTmps(dir string) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, info := range infos {
+		if strings.HasSuffix(info.Name(), packTmpExt) && time.Since(info.ModTime()) > DefaultCompactInterval {
+			log.Infof("Removing unfinished pack file %s", info.Name())
+			os.Remove(filepath.Join(dir, info.Name()))
+		}
+	}
+}
+
+// Compacts the store every cfg.Interval. Never returns.
+func (s *FileStore) CompactPeriodically(cfg CompactionConfig, stat stats.StatsReceiver) {
+	cfg = compactionConfigWithDefaults(cfg)
+	for range time.NewTicker(cfg.Interval).C {
+		start := time.Now()
+		result, err := s.Compact(cfg)
+		stat.Counter(stats.BundlestoreCompactedBlobsCounter).Inc(int64(result.Packed))
+		stat.Counter(stats.BundlestoreCompactedBytesCounter).Inc(result.PackedBytes)
+		stat.Counter(stats.BundlestoreCompactionPrunedCounter).Inc(int64(result.Pruned))
+		if err != nil {
+			stat.Counter(stats.BundlestoreCompactionErrCounter).Inc(1)
+			log.Errorf("Error compacting %s: %v", s.bundleDir, err)
+		}
+		log.Infof("Packed %d blobs (%d bytes) into %d packs, pruned %d and removed %d packs in %s in %v",
+			result.Packed, result.PackedBytes, result.Packs, result.Pruned, result.RemovedPacks, s.bundleDir, time.Since(start))
+	}
+}
+
+func compactionConfigWithDefaults(cfg CompactionConfig) CompactionConfig {
+	if cfg.MaxBlobSize <= 0 {
+		cfg.MaxBlobSize = DefaultCompactMaxBlobSize
+	}
+	if cfg.MinAge <= 0 {
+		cfg.MinAge = DefaultCompactMinAge
+	}
+	if cfg.MaxPackSize <= 0 {
+		cfg.MaxPackSize = DefaultMaxPackSize
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultCompactInterval
+	}
+	return cfg
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/os/temp"
+)
+
+func makePackTestStore(t *testing.T) (*FileStore, *temp.TempDir) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := MakeFileStore(tmp.Dir)
+	if err != nil {
+		os.RemoveAll(tmp.Dir)
+		t.Fatal(err)
+	}
+	return s, tmp
+}
+
+// Writes blobs to s as if they were written at modTime.
+func writeOldBlobs(t *testing.T, s *FileStore, blobs map[string]string, modTime time.Time) {
+	for name, data := range blobs {
+		if err := s.Write(name, strings.NewReader(data), nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filepath.Join(s.Root(), name), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func assertMissing(t *testing.T, s Store, name string) {
+	if exists, err := s.Exists(name); err != nil || exists {
+		t.Fatalf("Expected %s not to exist: %v %v", name, exists, err)
+	}
+	if _, err := s.OpenForRead(name); !os.IsNotExist(err) {
+		t.Fatalf("Expected reading %s to miss, got %v", name, err)
+	}
+}
+
+func TestFileStoreCompact(t *testing.T) {
+	s, tmp := makePackTestStore(t)
+	defer os.RemoveAll(tmp.Dir)
+
+	blobs := map[string]string{
+		"blob-1.blob":  "one",
+		"blob-2.blob":  "two",
+		"blob-3.blob":  "three",
+		"blob-4.blob":  strings.Repeat("4", 100),
+		"bs-1.bundle":  "bundle",
+		"blob-new.new": "new",
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for name, data := range blobs {
+		if err := s.Write(name, strings.NewReader(data), nil); err != nil {
+			t.Fatal(err)
+		}
+		if name != "blob-new.new" {
+			if err := os.Chtimes(filepath.Join(tmp.Dir, name), old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Blobs 1-3 fill two packs of up to 8 bytes. Blob 4 is too large, the bundle lacks the prefix
+	// and the new blob is too recent.
+	result, err := s.Compact(CompactionConfig{Prefix: "blob-", MaxBlobSize: 10, MaxPackSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Packs != 2 || result.Packed != 3 || result.PackedBytes != 11 {
+		t.Fatalf("Unexpected compaction result: %+v", result)
+	}
+	for name, packed := range map[string]bool{
+		"blob-1.blob": true, "blob-2.blob": true, "blob-3.blob": true,
+		"blob-4.blob": false, "bs-1.bundle": false, "blob-new.new": false,
+	} {
+		if _, err := os.Stat(filepath.Join(tmp.Dir, name)); os.IsNotExist(err) != packed {
+			t.Fatalf("Expected %s packed: %t, stat: %v", name, packed, err)
+		}
+	}
+
+	// Packed blobs are read as before, including by stores made after compaction.
+	reopened, err := MakeFileStore(tmp.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range []Store{s, reopened} {
+		for name, data := range blobs {
+			assertHas(t, st, name, data)
+		}
+		assertMissing(t, st, "blob-5.blob")
+	}
+
+	// Packed blobs keep the time they were written.
+	for _, b := range s.PackedBlobs() {
+		if d := b.ModTime.Sub(old); d < -time.Second || d > time.Second {
+			t.Fatalf("Expected %s to keep its modification time %v, got %v", b.Name, old, b.ModTime)
+		}
+	}
+
+	// Rewritten blobs are read from their new file.
+	if err := s.Write("blob-1.blob", strings.NewReader("uno"), nil); err != nil {
+		t.Fatal(err)
+	}
+	assertHas(t, s, "blob-1.blob", "uno")
+}
+
+func TestFileStoreCompactGC(t *testing.T) {
+	s, tmp := makePackTestStore(t)
+	defer os.RemoveAll(tmp.Dir)
+	old := time.Now().Add(-2 * time.Hour)
+	cfg := CompactionConfig{MaxPackSize: 1024}
+
+	// Two packs, one for blobs 1 and 2 and one for blobs 3 and 4.
+	writeOldBlobs(t, s, map[string]string{"blob-1": "11111111", "blob-2": "2"}, old)
+	if _, err := s.Compact(cfg); err != nil {
+		t.Fatal(err)
+	}
+	writeOldBlobs(t, s, map[string]string{"blob-3": "3", "blob-4": "4"}, old)
+	if _, err := s.Compact(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewriting blob 1 leaves less than half its pack live, so blob 2 is repacked.
+	// Rewriting both blobs 3 and 4 leaves their pack empty, so it's removed.
+	writeOldBlobs(t, s, map[string]string{"blob-1": "uno", "blob-3": "tres", "blob-4": "cuatro"}, time.Now())
+	result, err := s.Compact(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Packed != 0 || result.Pruned != 3 || result.RemovedPacks != 2 {
+		t.Fatalf("Unexpected compaction result: %+v", result)
+	}
+	packs, err := listPacks(filepath.Join(tmp.Dir, PackDir))
+	if err != nil || len(packs) != 1 {
+		t.Fatalf("Expected 1 pack left, got %v %v", packs, err)
+	}
+	if names := s.packs.names(); len(names) != 1 || names[0] != "blob-2" {
+		t.Fatalf("Expected only blob-2 left packed, got %v", names)
+	}
+	for name, data := range map[string]string{"blob-1": "uno", "blob-2": "2", "blob-3": "tres", "blob-4": "cuatro"} {
+		assertHas(t, s, name, data)
+	}
+
+	// Removed packed blobs are misses, and packs left empty are removed.
+	if n, err := s.RemovePacked([]string{"blob-2", "blob-5"}); err != nil || n != 1 {
+		t.Fatalf("Expected 1 packed blob removed, got %d %v", n, err)
+	}
+	assertMissing(t, s, "blob-2")
+	if packs, err := listPacks(filepath.Join(tmp.Dir, PackDir)); err != nil || len(packs) != 0 {
+		t.Fatalf("Expected no packs left, got %v %v", packs, err)
+	}
+}
+
+func TestFileStoreMissingPack(t *testing.T) {
+	s, tmp := makePackTestStore(t)
+	defer os.RemoveAll(tmp.Dir)
+	writeOldBlobs(t, s, map[string]string{"blob-1": "one"}, time.Now().Add(-2*time.Hour))
+	if _, err := s.Compact(CompactionConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	assertHas(t, s, "blob-1", "one")
+
+	// A pack removed without its index, ex: by hand, holds no blobs.
+	packs, _ := listPacks(filepath.Join(tmp.Dir, PackDir))
+	if err := os.Remove(filepath.Join(tmp.Dir, PackDir, packs[0]+packExt)); err != nil {
+		t.Fatal(err)
+	}
+	assertMissing(t, s, "blob-1")
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileStoreList(t *testing.T) {
+	s, tmp := makePackTestStore(t)
+	defer os.RemoveAll(tmp.Dir)
+	for i := 0; i < ListPageSize+1; i++ {
+		if err := s.Write(fmt.Sprintf("bs-%05d.bundle", i), strings.NewReader("bundle"), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"blob-1.blob", "zz"} {
+		if err := s.Write(name, strings.NewReader(name), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := s.List("bs-", "")
+	if err != nil || len(r.Names) != ListPageSize || r.Names[0] != "bs-00000.bundle" || r.Cursor != r.Names[ListPageSize-1] {
+		t.Fatalf("Expected a full first page, got %d names, cursor %q, %v", len(r.Names), r.Cursor, err)
+	}
+
+	// Later pages are found in the names listed for the first, so a blob written since isn't listed.
+	if err := s.Write("bs-99999.bundle", strings.NewReader("bundle"), nil); err != nil {
+		t.Fatal(err)
+	}
+	next, err := s.List("bs-", r.Cursor)
+	if err != nil || len(next.Names) != 1 || next.Names[0] != fmt.Sprintf("bs-%05d.bundle", ListPageSize) || next.Cursor != "" {
+		t.Fatalf("Expected the last bundle on the last page, got %+v %v", next, err)
+	}
+
+	if r, err = s.List("", "bs-00999.bundle"); err != nil || !reflect.DeepEqual(r.Names, []string{"bs-01000.bundle", "zz"}) {
+		t.Fatalf("Expected names after the cursor, got %+v %v", r, err)
+	}
+
+	// A new listing lists the dir again.
+	if r, err = s.List("bs-9", ""); err != nil || len(r.Names) != 1 || r.Names[0] != "bs-99999.bundle" {
+		t.Fatalf("Expected the new bundle once relisted, got %+v %v", r, err)
+	}
+	if r, err = s.List("blob-", ""); err != nil || len(r.Names) != 1 || r.Names[0] != "blob-1.blob" {
+		t.Fatalf("Expected only the blob, got %+v %v", r, err)
+	}
+}
@@ -169,6 +169,11 @@ func (s *groupcacheStore) Root() string {
 	return s.underlying.Root()
 }
 
+// Lists the underlying store, since the cache only holds what's been read or written recently.
+func (s *groupcacheStore) List(prefix, cursor string) (*ListResult, error) {
+	return s.underlying.List(prefix, cursor)
+}
+
 // The groupcache lib updates its stats in the background - we need to convert those to our own stat representation.
 // Gauges are expected to fluctuate, counters are expected to only ever increase.
 func updateCacheStats(cache *groupcache.Group, stat stats.StatsReceiver) {
@@ -1,10 +1,12 @@
 package store
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -15,6 +17,15 @@ import (
 
 const DefaultHttpTries = 7 // ~2min total of trying with exponential backoff (0 and 1 both mean 1 try total)
 
+// A GET of the root URI with ListParam lists the stored bundles starting with ListPrefixParam
+// after ListCursorParam, returning a ListResult as JSON. It must carry the server's list token
+// as "Authorization: Bearer <token>", see MakeHTTPStoreWithListToken.
+const (
+	ListParam       = "list"
+	ListPrefixParam = "prefix"
+	ListCursorParam = "cursor"
+)
+
 func MakePesterClient() *pester.Client {
 	client := pester.New()
 	client.Backoff = pester.ExponentialBackoff
@@ -34,7 +45,14 @@ func MakeCustomHTTPStore(rootURI string, client Client) Store {
 		rootURI = rootURI + "/"
 	}
 	log.Infof("Making new HTTP Store with root URI: %s", rootURI)
-	return &httpStore{rootURI, client}
+	return &httpStore{rootURI: rootURI, client: client, writeMethod: "POST"}
+}
+
+// Makes an HTTP Store that authenticates its list requests with listToken.
+func MakeHTTPStoreWithListToken(rootURI, listToken string) Store {
+	s := MakeHTTPStore(rootURI).(*httpStore)
+	s.listToken = listToken
+	return s
 }
 
 type Client interface {
@@ -42,8 +60,10 @@ type Client interface {
 }
 
 type httpStore struct {
-	rootURI string
-	client  Client
+	rootURI     string
+	client      Client
+	writeMethod string
+	listToken   string
 }
 
 func (s *httpStore) OpenForRead(name string) (io.ReadCloser, error) {
@@ -109,8 +129,8 @@ func (s *httpStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 	}
 	uri := s.rootURI + name
 
-	post := func() (*http.Response, error) {
-		req, err := http.NewRequest("POST", uri, data)
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequest(s.writeMethod, uri, data)
 		if err != nil {
 			return nil, err
 		}
@@ -125,12 +145,12 @@ func (s *httpStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 		return s.client.Do(req)
 	}
 
-	resp, err := post()
+	resp, err := send()
 	if err != nil {
 		log.Infof("Write error: %s %v", uri, err)
 	} else {
 		resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 			log.Infof("Write response status error: %s %v -- %s", uri, resp.Status)
 			return errors.New(resp.Status)
 		}
@@ -141,3 +161,27 @@ func (s *httpStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 func (s *httpStore) Root() string {
 	return s.rootURI
 }
+
+func (s *httpStore) List(prefix, cursor string) (*ListResult, error) {
+	uri := s.rootURI + "?" + url.Values{ListParam: {""}, ListPrefixParam: {prefix}, ListCursorParam: {cursor}}.Encode()
+	log.Infof("Listing %s", uri)
+	req, _ := http.NewRequest("GET", uri, nil)
+	if s.listToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.listToken)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Infof("List error: %s %v", uri, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Infof("List response status error: %s %v", uri, resp.Status)
+		return nil, errors.New(resp.Status)
+	}
+	result := &ListResult{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("could not decode list of %s: %v", uri, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Resumable uploads send a bundle to a bundlestore server in chunks over an upload session,
+// so a failed request only loses the chunk in flight instead of the whole upload.
+// A POST to <bundle>?uploads starts a session and returns its id in UploadIdHeader.
+// A PUT to <bundle>?uploadId=<id>&offset=<n> appends the chunk in the body, which starts at byte n of the bundle.
+// A GET of <bundle>?uploadId=<id> returns the session's UploadProgress as JSON.
+// A POST to <bundle>?uploadId=<id> assembles the received chunks and writes the bundle to the store,
+// and a DELETE aborts the session.
+const (
+	UploadsParam  = "uploads"
+	UploadIdParam = "uploadId"
+	OffsetParam   = "offset"
+
+	// The id of the session started for an upload.
+	UploadIdHeader = "X-Scoot-Upload-Id"
+	// The number of bytes of the bundle received so far, set on chunk and progress responses.
+	UploadOffsetHeader = "X-Scoot-Upload-Offset"
+	// Optionally set when starting a session to the bundle's total length, to report progress against it
+	// and check the upload is complete before assembling it.
+	UploadLengthHeader = "X-Scoot-Upload-Length"
+)
+
+const DefaultUploadChunkSize = 8 * 1024 * 1024
+
+// Progress of a resumable upload session, as returned by the server.
+type UploadProgress struct {
+	UploadId string `json:"uploadId"`
+	Bundle   string `json:"bundle"`
+	Received int64  `json:"received"`
+	// Total length of the bundle, or -1 if it wasn't given when the session was started.
+	Length int64 `json:"length"`
+}
+
+// Stores that can upload in chunks over a resumable session.
+type ResumableWriter interface {
+	// Writes the bundle in chunks of chunkSize bytes, or DefaultUploadChunkSize if not positive.
+	// length is the bundle's total length or -1 if unknown. progress, if not nil, is called after each chunk.
+	// If the upload fails after its session was started, an *UploadInterruptedError is returned and
+	// the session is kept on the server, to be continued with ResumeUpload or ended with AbortUpload.
+	WriteResumable(name string, data io.Reader, length int64, ttl *TTLValue, chunkSize int64,
+		progress func(UploadProgress)) error
+
+	// Continues the upload session of the bundle with the given id from the chunks the server has received,
+	// seeking data, which must hold the whole bundle, past them. Returns an *UploadInterruptedError
+	// if the upload fails again, as WriteResumable does.
+	ResumeUpload(name, uploadId string, data io.ReadSeeker, chunkSize int64, progress func(UploadProgress)) error
+
+	// Ends the upload session of the bundle with the given id, dropping the chunks received.
+	AbortUpload(name, uploadId string) error
+}
+
+// Returned by resumable uploads that failed after their session was started.
+type UploadInterruptedError struct {
+	UploadId string
+	// The number of bytes of the bundle the server had received when the upload failed.
+	Received int64
+	Err      error
+}
+
+func (e *UploadInterruptedError) Error() string {
+	return fmt.Sprintf("upload %s interrupted after %d bytes: %v", e.UploadId, e.Received, e.Err)
+}
+
+func (s *httpStore) WriteResumable(name string, data io.Reader, length int64, ttl *TTLValue, chunkSize int64,
+	progress func(UploadProgress)) error {
+	if strings.Contains(name, "/") {
+		log.Infof("Write error: %s '/' not allowed", name)
+		return errors.New("'/' not allowed in name when writing bundles.")
+	}
+	uri := s.rootURI + name
+
+	id, err := s.startUpload(uri, length, ttl)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		log.Infof("Write skipped, %s already exists", uri)
+		return nil
+	}
+	return s.continueUpload(name, UploadProgress{UploadId: id, Bundle: name, Length: length}, data, chunkSize, progress)
+}
+
+func (s *httpStore) ResumeUpload(name, uploadId string, data io.ReadSeeker, chunkSize int64,
+	progress func(UploadProgress)) error {
+	if strings.Contains(name, "/") {
+		log.Infof("Write error: %s '/' not allowed", name)
+		return errors.New("'/' not allowed in name when writing bundles.")
+	}
+	p, err := s.uploadProgress(uploadSessionURI(s.rootURI+name, uploadId))
+	if err != nil {
+		log.Infof("Upload progress error: %s %s %v", name, uploadId, err)
+		return err
+	}
+	if _, err := data.Seek(p.Received, io.SeekStart); err != nil {
+		return &UploadInterruptedError{UploadId: uploadId, Received: p.Received, Err: err}
+	}
+	log.Infof("Resuming upload %s of %s after %d bytes", uploadId, name, p.Received)
+	return s.continueUpload(name, *p, data, chunkSize, progress)
+}
+
+func (s *httpStore) AbortUpload(name, uploadId string) error {
+	req, _ := http.NewRequest("DELETE", uploadSessionURI(s.rootURI+name, uploadId), nil)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Infof("Upload abort error: %s %s %v", name, uploadId, err)
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		log.Infof("Upload abort response status error: %s %s %v", name, uploadId, resp.Status)
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+func uploadSessionURI(uri, uploadId string) string {
+	return uri + "?" + url.Values{UploadIdParam: {uploadId}}.Encode()
+}
+
+// Sends data in chunks to the session, from the point given by p.Received, then assembles the bundle.
+func (s *httpStore) continueUpload(name string, p UploadProgress, data io.Reader, chunkSize int64,
+	progress func(UploadProgress)) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	uri := s.rootURI + name
+	sessionURI := uploadSessionURI(uri, p.UploadId)
+	log.Infof("Writing %s in chunks of %d bytes, upload id: %s", uri, chunkSize, p.UploadId)
+	interrupted := func(err error) error {
+		return &UploadInterruptedError{UploadId: p.UploadId, Received: p.Received, Err: err}
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(data, buf)
+		if err == io.EOF {
+			break
+		} else if err != nil && err != io.ErrUnexpectedEOF {
+			return interrupted(err)
+		}
+		received, err := s.sendChunk(sessionURI, buf[:n], p.Received)
+		if err != nil {
+			return interrupted(err)
+		}
+		p.Received = received
+		if progress != nil {
+			progress(p)
+		}
+		if n < len(buf) {
+			break
+		}
+	}
+
+	req, _ := http.NewRequest("POST", sessionURI, nil)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Infof("Write error: %s %v", uri, err)
+		return interrupted(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Infof("Write response status error: %s %v", uri, resp.Status)
+		return interrupted(errors.New(resp.Status))
+	}
+	return nil
+}
+
+// Returns the id of the session started, or an empty id if the bundle already exists.
+func (s *httpStore) startUpload(uri string, length int64, ttl *TTLValue) (string, error) {
+	req, _ := http.NewRequest("POST", uri+"?"+UploadsParam, nil)
+	if ttl == nil {
+		ttl = &TTLValue{TTL: time.Now().Add(DefaultTTL), TTLKey: DefaultTTLKey}
+	}
+	if ttl.TTLKey != "" {
+		req.Header[ttl.TTLKey] = []string{ttl.TTL.Format(time.RFC1123)}
+	}
+	if length >= 0 {
+		req.Header.Set(UploadLengthHeader, strconv.FormatInt(length, 10))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Infof("Upload start error: %s %v", uri, err)
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		log.Infof("Upload start response status error: %s %v", uri, resp.Status)
+		return "", errors.New(resp.Status)
+	}
+	return resp.Header.Get(UploadIdHeader), nil
+}
+
+// Sends the chunk starting at offset, resending whatever part of it the server doesn't report having received
+// after a failed request. Returns the number of bytes of the bundle received by the server.
+func (s *httpStore) sendChunk(sessionURI string, chunk []byte, offset int64) (int64, error) {
+	end := offset + int64(len(chunk))
+	var err error
+	for try := 0; try < DefaultHttpTries; try++ {
+		if try > 0 {
+			var p *UploadProgress
+			if p, err = s.uploadProgress(sessionURI); err != nil {
+				continue
+			}
+			if p.Received < offset || p.Received > end {
+				return 0, fmt.Errorf("upload %s received %d bytes, expected %d to %d", sessionURI, p.Received, offset, end)
+			}
+			chunk, offset = chunk[p.Received-offset:], p.Received
+			if len(chunk) == 0 {
+				return end, nil
+			}
+		}
+		req, _ := http.NewRequest("PUT", sessionURI+"&"+OffsetParam+"="+strconv.FormatInt(offset, 10), bytes.NewReader(chunk))
+		var resp *http.Response
+		if resp, err = s.client.Do(req); err != nil {
+			log.Infof("Upload chunk error, resuming: %s %v", sessionURI, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+			err = errors.New(resp.Status)
+			log.Infof("Upload chunk response status error, resuming: %s %v", sessionURI, resp.Status)
+			continue
+		}
+		if resp.StatusCode == http.StatusOK {
+			return end, nil
+		}
+		err = fmt.Errorf("upload %s out of sync at offset %d", sessionURI, offset)
+	}
+	return 0, err
+}
+
+func (s *httpStore) uploadProgress(sessionURI string) (*UploadProgress, error) {
+	req, _ := http.NewRequest("GET", sessionURI, nil)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	p := &UploadProgress{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/os/temp"
+)
+
+// Default bytes kept in memory before the least recently used entries are spilled to disk.
+const DefaultMemoryStoreMemBytes = 256 * 1024 * 1024
+
+// Default bytes kept in total, in memory and on disk, before the least recently used entries are evicted.
+const DefaultMemoryStoreMaxBytes = 4 * 1024 * 1024 * 1024
+
+// Create a MemoryStore that spills to a fixed dir in tmp.
+func MakeMemoryStoreInTemp(tmp *temp.TempDir, memBytes, maxBytes int64) (*MemoryStore, error) {
+	spillDir, err := tmp.FixedDir("spill")
+	if err != nil {
+		return nil, err
+	}
+	return MakeMemoryStore(spillDir.Dir, memBytes, maxBytes)
+}
+
+// Create a MemoryStore that keeps up to memBytes in memory, spilling the least recently used
+// entries beyond that to dir, and evicts the least recently used entries once the total size
+// exceeds maxBytes. A maxBytes of 0 means no limit.
+// Note: this implementation does not currently support TTL.
+func MakeMemoryStore(dir string, memBytes, maxBytes int64) (*MemoryStore, error) {
+	if maxBytes > 0 && memBytes > maxBytes {
+		return nil, fmt.Errorf("memBytes %d exceeds maxBytes %d", memBytes, maxBytes)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	log.Infof("Making new MemoryStore spilling to dir: %s, memBytes: %d, maxBytes: %d", dir, memBytes, maxBytes)
+	return &MemoryStore{
+		spillDir: dir,
+		memBytes: memBytes,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// Implements Store. MemoryStore is a size bounded in-memory Store intended for tests and small
+// deployments. Entries are kept in memory until memBytes is exceeded, then spilled to disk.
+// Once maxBytes is exceeded entries are evicted altogether, so reads of evicted entries will fail.
+type MemoryStore struct {
+	spillDir string
+	memBytes int64
+	maxBytes int64
+
+	mu         sync.Mutex
+	lru        *list.List               // of *memoryEntry, most recently used at the front
+	entries    map[string]*list.Element // name -> element in lru
+	inMemBytes int64
+	totalBytes int64
+}
+
+type memoryEntry struct {
+	name string
+	size int64
+	data []byte // nil once spilled to disk
+}
+
+func (s *MemoryStore) Exists(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entries[name]
+	return ok, nil
+}
+
+func (s *MemoryStore) OpenForRead(name string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[name]
+	if !ok {
+		return nil, errors.New("Doesn't exist :" + name)
+	}
+	s.lru.MoveToFront(el)
+	e := el.Value.(*memoryEntry)
+	if e.data != nil {
+		return ioutil.NopCloser(bytes.NewReader(e.data)), nil
+	}
+	// The file may be evicted while the caller is reading, which is fine since the open fd keeps it alive.
+	return os.Open(s.spillPath(name))
+}
+
+func (s *MemoryStore) Root() string {
+	return s.spillDir
+}
+
+func (s *MemoryStore) List(prefix, cursor string) (*ListResult, error) {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.entries))
+	for name := range s.entries {
+		names = append(names, name)
+	}
+	s.mu.Unlock()
+	return listNames(names, prefix, cursor), nil
+}
+
+func (s *MemoryStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	if strings.Contains(name, "/") {
+		return errors.New("'/' not allowed in name unless reading bundle contents.")
+	}
+	b, err := ioutil.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[name]; ok {
+		s.remove(el)
+	}
+	e := &memoryEntry{name: name, size: int64(len(b)), data: b}
+	s.entries[name] = s.lru.PushFront(e)
+	s.inMemBytes += e.size
+	s.totalBytes += e.size
+
+	if err := s.spill(); err != nil {
+		return err
+	}
+	s.evict()
+	return nil
+}
+
+// Spill the least recently used in-memory entries to disk until we're within memBytes.
+// Caller must hold the lock.
+func (s *MemoryStore) spill() error {
+	for el := s.lru.Back(); el != nil && s.inMemBytes > s.memBytes; el = el.Prev() {
+		e := el.Value.(*memoryEntry)
+		if e.data == nil {
+			continue
+		}
+		if err := ioutil.WriteFile(s.spillPath(e.name), e.data, 0644); err != nil {
+			return fmt.Errorf("Failed to spill %s to disk: %v", e.name, err)
+		}
+		e.data = nil
+		s.inMemBytes -= e.size
+	}
+	return nil
+}
+
+// Evict the least recently used entries until we're within maxBytes, always keeping the newest entry.
+// Caller must hold the lock.
+func (s *MemoryStore) evict() {
+	for s.maxBytes > 0 && s.totalBytes > s.maxBytes && s.lru.Len() > 1 {
+		el := s.lru.Back()
+		log.Infof("Evicting %s from MemoryStore", el.Value.(*memoryEntry).name)
+		s.remove(el)
+	}
+}
+
+// Caller must hold the lock.
+func (s *MemoryStore) remove(el *list.Element) {
+	e := s.lru.Remove(el).(*memoryEntry)
+	delete(s.entries, e.name)
+	s.totalBytes -= e.size
+	if e.data != nil {
+		s.inMemBytes -= e.size
+	} else if err := os.Remove(s.spillPath(e.name)); err != nil && !os.IsNotExist(err) {
+		log.Errorf("Failed to remove spilled file for %s: %v", e.name, err)
+	}
+}
+
+func (s *MemoryStore) spillPath(name string) string {
+	return filepath.Join(s.spillDir, name)
+}
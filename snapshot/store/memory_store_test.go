@@ -0,0 +1,105 @@
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/os/temp"
+)
+
+func TestMemoryStoreSpillAndEvict(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	// Keep up to 2 entries of 10 bytes in memory and up to 3 in total.
+	s, err := MakeMemoryStore(tmp.Dir, 20, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string][]byte{
+		"a": bytes.Repeat([]byte("a"), 10),
+		"b": bytes.Repeat([]byte("b"), 10),
+		"c": bytes.Repeat([]byte("c"), 10),
+		"d": bytes.Repeat([]byte("d"), 10),
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		if err := s.Write(name, bytes.NewReader(data[name]), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmp.Dir, "a")); err != nil {
+		t.Fatalf("Expected a to be spilled to disk: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp.Dir, "c")); !os.IsNotExist(err) {
+		t.Fatalf("Expected c to be in memory, got %v", err)
+	}
+	readAndCheck(t, s, "a", data["a"])
+
+	// Reading a made it more recently used than b, so b is evicted.
+	if err := s.Write("d", bytes.NewReader(data["d"]), nil); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Exists("b"); ok {
+		t.Fatalf("Expected b to be evicted")
+	}
+	if _, err := os.Stat(filepath.Join(tmp.Dir, "b")); !os.IsNotExist(err) {
+		t.Fatalf("Expected b to be removed from disk, got %v", err)
+	}
+	for _, name := range []string{"a", "c", "d"} {
+		readAndCheck(t, s, name, data[name])
+	}
+	if s.totalBytes != 30 || s.inMemBytes > 20 {
+		t.Fatalf("Unexpected sizes, total: %d, inMem: %d", s.totalBytes, s.inMemBytes)
+	}
+}
+
+func readAndCheck(t *testing.T, s *MemoryStore, name string, expected []byte) {
+	r, err := s.OpenForRead(name)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	if !bytes.Equal(b, expected) {
+		t.Fatalf("Expected %s to contain %q, got %q", name, expected, b)
+	}
+}
+
+func TestMemoryStoreList(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	s, err := MakeMemoryStore(tmp.Dir, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"b2", "a1", "b1", "b3"} {
+		if err := s.Write(name, bytes.NewReader([]byte(name)), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := s.List("b", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.Names, []string{"b1", "b2", "b3"}) || r.Cursor != "" {
+		t.Fatalf("Expected names starting with b in order, got %+v", r)
+	}
+	if r, _ = s.List("b", "b1"); !reflect.DeepEqual(r.Names, []string{"b2", "b3"}) {
+		t.Fatalf("Expected names after the cursor, got %+v", r)
+	}
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Maximum number of blobs waiting to be mirrored to the cold store. Further writes aren't mirrored.
+	DefaultMaxPendingMirrors = 10000
+
+	// Number of blobs mirrored to the cold store concurrently
+	DefaultMirrorWorkers = 4
+)
+
+type MirroringStoreConfig struct {
+	// Only names with this prefix are mirrored and restored, ex: "blob-" for CAS blobs. Empty mirrors everything.
+	Prefix string
+	// If <= 0, DefaultMaxPendingMirrors.
+	MaxPendingMirrors int
+	// If <= 0, DefaultMirrorWorkers.
+	MirrorWorkers int
+}
+
+// Implements Store. MirroringStore mirrors blobs written to a hot Store into a cheaper cold Store,
+// like an infrequent access S3 bucket, so blobs outlive the hot Store's retention without growing its disks.
+//
+// Writes complete once the hot Store has the blob and are mirrored to the cold Store in the background,
+// with the cold Store's own TTL rather than the hot one. Reads that miss the hot Store are served from
+// the cold Store, restoring the blob into the hot Store so later reads don't go to cold storage.
+// Whether the cold Store has a blob is remembered for DefaultRemoteExistsTTL, so repeated checks for
+// blobs missing from both stores don't each wait on cold storage.
+type MirroringStore struct {
+	hot        Store
+	cold       Store
+	coldExists *remoteExists
+	prefix     string
+	stat       stats.StatsReceiver
+	pending    chan string
+
+	mu      sync.Mutex
+	waiting int
+}
+
+// Create a MirroringStore and start mirroring to cold in the background.
+func MakeMirroringStore(hot, cold Store, cfg MirroringStoreConfig, stat stats.StatsReceiver) *MirroringStore {
+	maxPending := cfg.MaxPendingMirrors
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPendingMirrors
+	}
+	workers := cfg.MirrorWorkers
+	if workers <= 0 {
+		workers = DefaultMirrorWorkers
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	log.Infof("Making new MirroringStore with hot: %s, cold: %s, prefix: %q", hot.Root(), cold.Root(), cfg.Prefix)
+	s := &MirroringStore{
+		hot:        hot,
+		cold:       cold,
+		coldExists: newRemoteExists(cold),
+		prefix:     cfg.Prefix,
+		stat:       stat,
+		pending:    make(chan string, maxPending),
+	}
+	for i := 0; i < workers; i++ {
+		go s.mirror()
+	}
+	return s
+}
+
+func (s *MirroringStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	if err := s.hot.Write(name, data, ttl); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(name, s.prefix) {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.pending <- name:
+		s.waiting++
+	default:
+		log.Errorf("Not mirroring %s to %s, too many mirrors pending", name, s.cold.Root())
+		s.stat.Counter(stats.BundlestoreMirrorDroppedCounter).Inc(1)
+	}
+	return nil
+}
+
+func (s *MirroringStore) OpenForRead(name string) (io.ReadCloser, error) {
+	r, err := s.hot.OpenForRead(name)
+	if err == nil || !strings.HasPrefix(name, s.prefix) {
+		return r, err
+	}
+	if !os.IsNotExist(err) {
+		log.Infof("Failed reading %s from hot store, trying cold: %v", name, err)
+	}
+
+	if exists, err := s.coldExists.check(name); err == nil && !exists {
+		return nil, os.ErrNotExist
+	}
+	r, err = s.cold.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	s.coldExists.set(name, true)
+
+	// The blob is streamed into the hot Store, then read from there, so restoring doesn't hold it in memory.
+	// The blob's TTL isn't known, so the hot Store's default is used.
+	err = s.hot.Write(name, r, nil)
+	r.Close()
+	if err == nil {
+		if r, err = s.hot.OpenForRead(name); err == nil {
+			s.stat.Counter(stats.BundlestoreMirrorRestoreCounter).Inc(1)
+			return r, nil
+		}
+	}
+	log.Errorf("Failed restoring %s from cold store, reading it from there: %v", name, err)
+	s.stat.Counter(stats.BundlestoreMirrorRestoreErrCounter).Inc(1)
+	return s.cold.OpenForRead(name)
+}
+
+func (s *MirroringStore) Exists(name string) (bool, error) {
+	exists, err := s.hot.Exists(name)
+	if (err == nil && exists) || !strings.HasPrefix(name, s.prefix) {
+		return exists, err
+	}
+	return s.coldExists.check(name)
+}
+
+func (s *MirroringStore) Root() string {
+	return s.hot.Root()
+}
+
+// Lists both the hot and cold stores, matching the blobs that can be read.
+func (s *MirroringStore) List(prefix, cursor string) (*ListResult, error) {
+	return listMerged([]Store{s.hot, s.cold}, prefix, cursor)
+}
+
+// Returns the number of blobs waiting to be mirrored to the cold store.
+func (s *MirroringStore) PendingMirrors() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiting
+}
+
+// Copies blobs written to the hot Store to the cold Store. Never returns.
+func (s *MirroringStore) mirror() {
+	for name := range s.pending {
+		if err := s.copyToCold(name); err != nil {
+			log.Errorf("Failed mirroring %s to %s: %v", name, s.cold.Root(), err)
+			s.stat.Counter(stats.BundlestoreMirrorErrCounter).Inc(1)
+		} else {
+			s.stat.Counter(stats.BundlestoreMirrorCounter).Inc(1)
+		}
+		s.mu.Lock()
+		s.waiting--
+		s.mu.Unlock()
+	}
+}
+
+func (s *MirroringStore) copyToCold(name string) error {
+	// Blobs are immutable, so one already in cold storage needn't be uploaded again.
+	if exists, err := s.coldExists.check(name); err == nil && exists {
+		return nil
+	}
+	r, err := s.hot.OpenForRead(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := s.cold.Write(name, r, nil); err != nil {
+		return err
+	}
+	s.coldExists.set(name, true)
+	return nil
+}
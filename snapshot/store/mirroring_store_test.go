@@ -0,0 +1,81 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitForMirrors(t *testing.T, s *MirroringStore) {
+	for i := 0; s.PendingMirrors() != 0; i++ {
+		if i == 100 {
+			t.Fatalf("Expected mirrors to finish, %d pending", s.PendingMirrors())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMirroringStoreWrite(t *testing.T) {
+	hot, cold := &flakyStore{}, &flakyStore{}
+	s := MakeMirroringStore(hot, cold, MirroringStoreConfig{Prefix: "blob-"}, nil)
+
+	if err := s.Write("blob-1.blob", strings.NewReader("one"), nil); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	assertHas(t, hot, "blob-1.blob", "one")
+	waitForMirrors(t, s)
+	assertHas(t, cold, "blob-1.blob", "one")
+
+	// Names without the prefix aren't mirrored.
+	if err := s.Write("bs-1.bundle", strings.NewReader("bundle"), nil); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	waitForMirrors(t, s)
+	if exists, _ := cold.Exists("bs-1.bundle"); exists {
+		t.Fatal("Expected bundle not to be mirrored")
+	}
+
+	// Writes don't wait for the cold store.
+	cold.setDown(true)
+	if err := s.Write("blob-2.blob", strings.NewReader("two"), nil); err != nil {
+		t.Fatalf("Expected write to succeed with cold store down: %v", err)
+	}
+	assertHas(t, s, "blob-2.blob", "two")
+}
+
+func TestMirroringStoreRestore(t *testing.T) {
+	hot, cold := &flakyStore{}, &flakyStore{}
+	s := MakeMirroringStore(hot, cold, MirroringStoreConfig{Prefix: "blob-"}, nil)
+	cold.Files.Store("blob-1.blob", []byte("one"))
+	cold.Files.Store("bs-1.bundle", []byte("bundle"))
+
+	// Blobs evicted from the hot store are restored from the cold one when read.
+	if exists, err := s.Exists("blob-1.blob"); err != nil || !exists {
+		t.Fatalf("Expected blob to exist: %v %v", exists, err)
+	}
+	assertHas(t, s, "blob-1.blob", "one")
+	assertHas(t, hot, "blob-1.blob", "one")
+
+	cold.setDown(true)
+	assertHas(t, s, "blob-1.blob", "one")
+
+	cold.setDown(false)
+	if _, err := s.OpenForRead("bs-1.bundle"); err == nil {
+		t.Fatal("Expected names without the prefix not to be read from the cold store")
+	}
+
+	// Blobs missing from both stores are remembered as missing, rather than looked up in the cold store again.
+	if exists, err := s.Exists("blob-2.blob"); err != nil || exists {
+		t.Fatalf("Expected blob not to exist: %v %v", exists, err)
+	}
+	cold.setDown(true)
+	if exists, err := s.Exists("blob-2.blob"); err != nil || exists {
+		t.Fatalf("Expected missing blob to be remembered: %v %v", exists, err)
+	}
+	cold.setDown(false)
+
+	// Blobs the hot store fails to restore are read from the cold store.
+	cold.Files.Store("blob-3.blob", []byte("three"))
+	hot.setDown(true)
+	assertHas(t, s, "blob-3.blob", "three")
+}
@@ -0,0 +1,15 @@
+package store
+
+import "regexp"
+
+// Prefix of the names of run logs persisted by workers, see package runner/runlogs.
+const LogNamePrefix = "log-"
+
+// Matches the names of run log indexes and chunks, ex: log-<sha1>.json and log-<sha1>-0.gz
+var logNameRE = regexp.MustCompile(`^` + LogNamePrefix + `[a-f0-9]{40}(\.json|-[0-9]+\.gz)$`)
+
+// Returns true if name is the name of a persisted run log index or chunk.
+// Defined here so servers can accept run logs without depending on the runner.
+func IsLogName(name string) bool {
+	return logNameRE.MatchString(name)
+}
@@ -0,0 +1,263 @@
+package store
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// Path on which PeerStores serve the bundles they've fetched to their peers.
+const PeerBundlePath = "/peerbundle/"
+
+// Maximum number of peers asked for a bundle before falling back to the upstream store.
+const DefaultMaxPeerQueries = 3
+
+// Timeout for each request to a peer. Peers are an optimization so we fail over to upstream quickly.
+const DefaultPeerTimeout = 30 * time.Second
+
+// Defaults bounding the bundles a PeerStore keeps, see PeerStoreConfig.
+const (
+	DefaultPeerBundlesMaxBytes = 10 * 1024 * 1024 * 1024
+	DefaultPeerBundlesTTL      = 24 * time.Hour
+)
+
+// Bounds on the bundles a PeerStore keeps in its dir. Bundles not read for TTL are removed, and the least
+// recently read are removed while the dir holds more than MaxBytes. Zero disables either bound.
+type PeerStoreConfig struct {
+	MaxBytes int64
+	TTL      time.Duration
+}
+
+// The members of a cluster of peers, ex: a *cluster.Cluster.
+type Peers interface {
+	Members() []cluster.Node
+}
+
+// Implements Store and http.Handler. PeerStore fetches bundles from peers that already have them,
+// falling back to an upstream Store (ex: the central bundlestore), and keeps every bundle it
+// fetches in a local dir so it can in turn serve them to its peers. This spreads the load for
+// hot snapshots, which every worker needs at about the same time, across the cluster.
+//
+// Peers are the members of a cluster whose node ids are the peers' http 'host:port' addrs,
+// and advertise that they have a bundle by answering a HEAD request for it.
+// Writes go directly to upstream.
+type PeerStore struct {
+	dir        string
+	upstream   Store
+	peers      Peers
+	self       string
+	cfg        PeerStoreConfig
+	maxQueries int
+	client     Client
+	stat       stats.StatsReceiver
+
+	evictMu sync.Mutex
+}
+
+// Create a PeerStore that keeps fetched bundles in dir, within the bounds of cfg, and finds peers among
+// the members of peers. self is this node's id and is excluded from the peers.
+func MakePeerStore(dir string, upstream Store, peers Peers, self string,
+	cfg PeerStoreConfig, stat stats.StatsReceiver) (*PeerStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	log.Infof("Making new PeerStore at dir: %s, upstream: %s", dir, upstream.Root())
+	return &PeerStore{
+		dir:        dir,
+		upstream:   upstream,
+		peers:      peers,
+		self:       self,
+		cfg:        cfg,
+		maxQueries: DefaultMaxPeerQueries,
+		client:     &http.Client{Timeout: DefaultPeerTimeout},
+		stat:       stat,
+	}, nil
+}
+
+func (s *PeerStore) Exists(name string) (bool, error) {
+	if s.existsLocally(name) {
+		return true, nil
+	}
+	return s.upstream.Exists(name)
+}
+
+func (s *PeerStore) OpenForRead(name string) (io.ReadCloser, error) {
+	if strings.Contains(name, "/") {
+		return nil, errors.New("'/' not allowed in name when reading bundles.")
+	}
+	if s.existsLocally(name) {
+		s.touch(name)
+		return os.Open(s.localPath(name))
+	}
+
+	if err := s.fetchFromPeers(name); err == nil {
+		s.stat.Counter(stats.BundlestorePeerHitCounter).Inc(1)
+		return os.Open(s.localPath(name))
+	}
+
+	s.stat.Counter(stats.BundlestorePeerMissCounter).Inc(1)
+	r, err := s.upstream.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if err := s.writeLocal(name, r); err != nil {
+		return nil, err
+	}
+	return os.Open(s.localPath(name))
+}
+
+func (s *PeerStore) Root() string {
+	return s.upstream.Root()
+}
+
+// Lists upstream, since the local dir only holds the bundles this node has fetched.
+func (s *PeerStore) List(prefix, cursor string) (*ListResult, error) {
+	return s.upstream.List(prefix, cursor)
+}
+
+func (s *PeerStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	return s.upstream.Write(name, data, ttl)
+}
+
+// Serves bundles from the local dir to peers, only GET and HEAD are supported.
+func (s *PeerStore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" && req.Method != "HEAD" {
+		http.Error(w, "only GET and HEAD are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(req.URL.Path, PeerBundlePath)
+	if name == "" || strings.Contains(name, "/") || strings.HasPrefix(name, ".") {
+		http.Error(w, "invalid bundle name", http.StatusBadRequest)
+		return
+	}
+	if !s.existsLocally(name) {
+		http.NotFound(w, req)
+		return
+	}
+	s.stat.Counter(stats.BundlestorePeerServedCounter).Inc(1)
+	s.touch(name)
+	http.ServeFile(w, req, s.localPath(name))
+}
+
+// Ask up to maxQueries random peers for the bundle, and copy it from the first that has it.
+func (s *PeerStore) fetchFromPeers(name string) error {
+	nodes := s.peers.Members()
+	queried := 0
+	for _, i := range rand.Perm(len(nodes)) {
+		peer := string(nodes[i].Id())
+		if peer == s.self {
+			continue
+		}
+		if queried >= s.maxQueries {
+			break
+		}
+		queried++
+
+		ps := MakeCustomHTTPStore("http://"+peer+PeerBundlePath, s.client)
+		if ok, err := ps.Exists(name); err != nil || !ok {
+			continue
+		}
+		r, err := ps.OpenForRead(name)
+		if err != nil {
+			continue
+		}
+		err = s.writeLocal(name, r)
+		r.Close()
+		if err != nil {
+			log.Infof("Failed to copy %s from peer %s: %v", name, peer, err)
+			continue
+		}
+		log.Infof("Fetched %s from peer %s", name, peer)
+		return nil
+	}
+	return os.ErrNotExist
+}
+
+// Write data to a temp file and then move it into place, so peers never see partial bundles.
+func (s *PeerStore) writeLocal(name string, data io.Reader) error {
+	f, err := ioutil.TempFile(s.dir, ".tmp-"+name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), s.localPath(name)); err != nil {
+		return err
+	}
+	s.evict(name)
+	return nil
+}
+
+// Records a read of the bundle, so the bundles read least recently are evicted first.
+func (s *PeerStore) touch(name string) {
+	now := time.Now()
+	os.Chtimes(s.localPath(name), now, now)
+}
+
+// Removes the bundles not read within the TTL, then the least recently read while the dir holds more than MaxBytes.
+// keep, the bundle just written, is never removed so it can be read. Bundles being served when they're removed
+// are still read to the end.
+func (s *PeerStore) evict(keep string) {
+	s.evictMu.Lock()
+	defer s.evictMu.Unlock()
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		log.Infof("Failed to list peer bundles in %s: %v", s.dir, err)
+		return
+	}
+	bundles := []os.FileInfo{}
+	var total int64
+	for _, fi := range infos {
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		bundles = append(bundles, fi)
+		total += fi.Size()
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].ModTime().Before(bundles[j].ModTime()) })
+	expired := time.Now().Add(-s.cfg.TTL)
+	for _, fi := range bundles {
+		overTTL := s.cfg.TTL > 0 && fi.ModTime().Before(expired)
+		overSize := s.cfg.MaxBytes > 0 && total > s.cfg.MaxBytes
+		if !overTTL && !overSize {
+			break
+		}
+		if fi.Name() == keep {
+			continue
+		}
+		if err := os.Remove(s.localPath(fi.Name())); err != nil && !os.IsNotExist(err) {
+			log.Infof("Failed to evict peer bundle %s: %v", fi.Name(), err)
+			continue
+		}
+		total -= fi.Size()
+	}
+}
+
+func (s *PeerStore) existsLocally(name string) bool {
+	_, err := os.Stat(s.localPath(name))
+	return err == nil
+}
+
+func (s *PeerStore) localPath(name string) string {
+	return filepath.Join(s.dir, name)
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+)
+
+type fakePeers struct {
+	nodes []cluster.Node
+}
+
+func (f *fakePeers) Members() []cluster.Node {
+	return f.nodes
+}
+
+func TestPeerStoreFetchFromPeer(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	upstream := &FakeStore{}
+	upstream.Files.Store("bs-1.bundle", []byte("bundle data"))
+	peers := &fakePeers{}
+	stat := stats.NilStatsReceiver()
+
+	// The first worker has no peers and fetches from upstream, caching the bundle.
+	dir1, _ := tmp.FixedDir("worker1")
+	ps1, err := MakePeerStore(dir1.Dir, upstream, peers, "", PeerStoreConfig{}, stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server1 := httptest.NewServer(peerBundleMux(ps1))
+	defer server1.Close()
+	addr1 := strings.TrimPrefix(server1.URL, "http://")
+	ps1.self = addr1
+	peers.nodes = []cluster.Node{cluster.NewIdNode(addr1)}
+	readPeerBundle(t, ps1, "bs-1.bundle", "bundle data")
+
+	// The second worker gets the bundle from the first even once upstream no longer has it.
+	upstream.Files.Delete("bs-1.bundle")
+	dir2, _ := tmp.FixedDir("worker2")
+	ps2, err := MakePeerStore(dir2.Dir, upstream, peers, "localhost:0", PeerStoreConfig{}, stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readPeerBundle(t, ps2, "bs-1.bundle", "bundle data")
+	if ok, _ := ps2.Exists("bs-1.bundle"); !ok {
+		t.Fatalf("Expected bundle fetched from peer to be cached locally")
+	}
+
+	// Bundles no peer has fall back to upstream.
+	upstream.Files.Store("bs-2.bundle", []byte("more data"))
+	readPeerBundle(t, ps2, "bs-2.bundle", "more data")
+	if _, err := ps2.OpenForRead("bs-3.bundle"); err == nil {
+		t.Fatalf("Expected error reading a bundle that doesn't exist anywhere")
+	}
+}
+
+func TestPeerStoreServeHTTP(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	stat := stats.NilStatsReceiver()
+	ps, err := MakePeerStore(tmp.Dir, &FakeStore{}, &fakePeers{}, "", PeerStoreConfig{}, stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.writeLocal("bs-1.bundle", bytes.NewReader([]byte("data"))); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(peerBundleMux(ps))
+	defer server.Close()
+
+	for path, code := range map[string]int{
+		"bs-1.bundle": http.StatusOK,
+		"bs-2.bundle": http.StatusNotFound,
+		".tmp-foo":    http.StatusBadRequest,
+	} {
+		resp, err := http.Head(server.URL + PeerBundlePath + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Errorf("Expected %d for %s, got %d", code, path, resp.StatusCode)
+		}
+	}
+	resp, err := http.Post(server.URL+PeerBundlePath+"bs-3.bundle", "text/plain", bytes.NewReader(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected writes to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestPeerStoreEvict(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	cfg := PeerStoreConfig{MaxBytes: 8, TTL: time.Hour}
+	ps, err := MakePeerStore(tmp.Dir, &FakeStore{}, &fakePeers{}, "", cfg, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bundles not read within the TTL are removed.
+	ps.writeLocal("bs-old.bundle", bytes.NewReader([]byte("1")))
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(ps.localPath("bs-old.bundle"), old, old)
+	ps.writeLocal("bs-1.bundle", bytes.NewReader([]byte("1234")))
+	if ps.existsLocally("bs-old.bundle") || !ps.existsLocally("bs-1.bundle") {
+		t.Fatal("Expected only the expired bundle to be evicted")
+	}
+
+	// The least recently read bundles are removed to stay under MaxBytes, but never the one just written.
+	past := time.Now().Add(-time.Minute)
+	os.Chtimes(ps.localPath("bs-1.bundle"), past, past)
+	ps.writeLocal("bs-2.bundle", bytes.NewReader([]byte("1234")))
+	ps.touch("bs-1.bundle")
+	ps.writeLocal("bs-3.bundle", bytes.NewReader([]byte("1234")))
+	if !ps.existsLocally("bs-1.bundle") || ps.existsLocally("bs-2.bundle") || !ps.existsLocally("bs-3.bundle") {
+		t.Fatal("Expected the least recently read bundle to be evicted")
+	}
+	ps.writeLocal("bs-big.bundle", bytes.NewReader([]byte("123456789")))
+	if !ps.existsLocally("bs-big.bundle") || ps.existsLocally("bs-1.bundle") || ps.existsLocally("bs-3.bundle") {
+		t.Fatal("Expected every other bundle to be evicted to make room for one over MaxBytes")
+	}
+}
+
+func peerBundleMux(ps *PeerStore) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(PeerBundlePath, ps)
+	return mux
+}
+
+func readPeerBundle(t *testing.T, s *PeerStore, name, expected string) {
+	r, err := s.OpenForRead(name)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Expected %s to contain %q, got %q", name, expected, b)
+	}
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sethgrid/pester"
+	log "github.com/sirupsen/logrus"
+)
+
+// Configuration for a Store backed by an arbitrary HTTP artifact service, like a generic
+// Artifactory repository, that serves blobs with GET and HEAD and accepts them with PUT at RootURI + name.
+type ProxyStoreConfig struct {
+	RootURI string
+	// Headers added to every request, typically for auth. Values are expanded with os.ExpandEnv
+	// so credentials can be kept out of config, ex: "Authorization": "Bearer ${ARTIFACT_TOKEN}".
+	Headers map[string]string
+	// Total tries per request, retrying connection errors and 5xx responses.
+	// Zero uses DefaultHttpTries, 1 disables retries.
+	Tries int
+	// Delay before each retry, nil uses pester.ExponentialBackoff.
+	Backoff pester.BackoffStrategy
+}
+
+// Makes a Store that reads and writes the artifact service described by cfg.
+func MakeProxyStore(cfg ProxyStoreConfig) Store {
+	client := MakePesterClient()
+	if cfg.Tries > 0 {
+		client.MaxRetries = cfg.Tries
+	}
+	if cfg.Backoff != nil {
+		client.Backoff = cfg.Backoff
+	}
+	headers := http.Header{}
+	for k, v := range cfg.Headers {
+		headers.Set(k, os.ExpandEnv(v))
+	}
+	s := MakeCustomHTTPStore(cfg.RootURI, &headerClient{client, headers}).(*httpStore)
+	s.writeMethod = "PUT"
+	log.Infof("Using proxy store at %s with headers %v", s.rootURI, headerNames(headers))
+	return s
+}
+
+// Parses headers in the form "Name: value,Name2: value2", as taken on the command line.
+func ParseProxyHeaders(s string) (map[string]string, error) {
+	headers := map[string]string{}
+	if s == "" {
+		return headers, nil
+	}
+	for _, h := range strings.Split(s, ",") {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid header %q, expected 'Name: value'", h)
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers, nil
+}
+
+// A Client that adds headers to every request before sending it with client.
+type headerClient struct {
+	client  Client
+	headers http.Header
+}
+
+func (c *headerClient) Do(req *http.Request) (*http.Response, error) {
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	return c.client.Do(req)
+}
+
+// Header values are usually credentials, so only their names are logged.
+func headerNames(h http.Header) []string {
+	names := []string{}
+	for k := range h {
+		names = append(names, k)
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// An artifact service that requires a bearer token and fails the first write to each blob.
+type fakeArtifactService struct {
+	mu     sync.Mutex
+	blobs  map[string][]byte
+	failed map[string]bool
+}
+
+func (f *fakeArtifactService) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch req.Method {
+	case "PUT":
+		if !f.failed[req.URL.Path] {
+			f.failed[req.URL.Path] = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		f.blobs[req.URL.Path], _ = ioutil.ReadAll(req.Body)
+		w.WriteHeader(http.StatusCreated)
+	case "GET", "HEAD":
+		data, ok := f.blobs[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestProxyStore(t *testing.T) {
+	service := &fakeArtifactService{blobs: map[string][]byte{}, failed: map[string]bool{}}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	os.Setenv("PROXY_STORE_TEST_TOKEN", "secret")
+	defer os.Unsetenv("PROXY_STORE_TEST_TOKEN")
+	s := MakeProxyStore(ProxyStoreConfig{
+		RootURI: server.URL + "/artifactory/scoot",
+		Headers: map[string]string{"Authorization": "Bearer ${PROXY_STORE_TEST_TOKEN}"},
+		Tries:   2,
+		Backoff: func(int) time.Duration { return 0 },
+	})
+
+	if ok, err := s.Exists("blob-1.blob"); err != nil || ok {
+		t.Fatalf("Expected blob not to exist, got %t, %v", ok, err)
+	}
+	if err := s.Write("blob-1.blob", bytes.NewBufferString("data"), nil); err != nil {
+		t.Fatalf("Unexpected error writing after a retry: %v", err)
+	}
+	if ok, err := s.Exists("blob-1.blob"); err != nil || !ok {
+		t.Fatalf("Expected blob to exist, got %t, %v", ok, err)
+	}
+	r, err := s.OpenForRead("blob-1.blob")
+	if err != nil {
+		t.Fatalf("Unexpected error reading: %v", err)
+	}
+	defer r.Close()
+	if data, _ := ioutil.ReadAll(r); string(data) != "data" {
+		t.Errorf("Expected 'data', got %q", data)
+	}
+	if _, ok := service.blobs["/artifactory/scoot/blob-1.blob"]; !ok {
+		t.Errorf("Expected blob to be written under the root URI, got %v", service.blobs)
+	}
+
+	unauthorized := MakeProxyStore(ProxyStoreConfig{RootURI: server.URL, Tries: 1})
+	if _, err := unauthorized.Exists("blob-1.blob"); err == nil {
+		t.Errorf("Expected an error without credentials")
+	}
+}
+
+func TestParseProxyHeaders(t *testing.T) {
+	headers, err := ParseProxyHeaders("Authorization: Bearer ${TOKEN}, X-JFrog-Art-Api:key")
+	expected := map[string]string{"Authorization": "Bearer ${TOKEN}", "X-JFrog-Art-Api": "key"}
+	if err != nil || !reflect.DeepEqual(headers, expected) {
+		t.Errorf("Expected %v, got %v, %v", expected, headers, err)
+	}
+	if _, err := ParseProxyHeaders("Authorization"); err == nil {
+		t.Errorf("Expected an error for a header without a value")
+	}
+}
@@ -0,0 +1,400 @@
+package store
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Writes return once a quorum of replicas, by default a majority, have succeeded.
+	ReplicateQuorum = "quorum"
+	// Writes return once any replica has succeeded, the rest complete in the background.
+	ReplicateAsync = "async"
+
+	// How often replicas that missed writes are repaired
+	DefaultRepairInterval = 10 * time.Second
+
+	// Maximum number of bundles waiting to be repaired. Further repairs are dropped.
+	DefaultMaxPendingRepairs = 100000
+
+	// Number of times repairing a bundle is attempted before giving up
+	maxRepairAttempts = 5
+
+	// Latency charged to a replica when an operation on it fails, so failing replicas are read last
+	replicaErrorLatency = time.Second
+
+	// Weight of the most recent latency in a replica's moving average
+	replicaLatencyAlpha = 0.2
+
+	// Size of the chunks written bundles are streamed to the replicas in
+	replicaChunkSize = 32 * 1024
+)
+
+type ReplicatingStoreConfig struct {
+	// ReplicateQuorum or ReplicateAsync. Empty means ReplicateQuorum.
+	Mode string
+	// Number of replicas that must succeed before a quorum write returns. If <= 0, a majority.
+	WriteQuorum int
+	// How often replicas that missed writes are repaired. If <= 0, DefaultRepairInterval.
+	RepairInterval time.Duration
+	// If <= 0, DefaultMaxPendingRepairs.
+	MaxPendingRepairs int
+	// TTL of bundles repaired on replicas found missing them on read, whose original TTL isn't known.
+	// Repairs of failed writes keep the TTL they were written with. If nil, the replicas' defaults are used.
+	TTL *TTLConfig
+}
+
+// Implements Store. ReplicatingStore writes each bundle to several replica Stores and reads it from the
+// fastest replica that has it, so losing a single replica doesn't make bundles unavailable.
+//
+// Replicas that fail a write, or are found missing a bundle on read, are repaired in the background
+// by copying the bundle from a replica that has it.
+type ReplicatingStore struct {
+	replicas   []Store
+	quorum     int
+	maxRepairs int
+	ttl        *TTLConfig
+	stat       stats.StatsReceiver
+
+	mu      sync.Mutex
+	latency []float64          // moving average latency of each replica in ns
+	pending map[string]*repair // name -> replicas missing it
+}
+
+type repair struct {
+	ttl      *TTLValue
+	replicas map[int]bool
+	attempts int
+}
+
+type replicaResult struct {
+	replica int
+	err     error
+}
+
+// Create a ReplicatingStore over replicas and start repairing them in the background.
+func MakeReplicatingStore(replicas []Store, cfg ReplicatingStoreConfig, stat stats.StatsReceiver) (*ReplicatingStore, error) {
+	s, err := makeReplicatingStore(replicas, cfg, stat)
+	if err != nil {
+		return nil, err
+	}
+	interval := cfg.RepairInterval
+	if interval <= 0 {
+		interval = DefaultRepairInterval
+	}
+	go func() {
+		for range time.NewTicker(interval).C {
+			s.Repair()
+		}
+	}()
+	return s, nil
+}
+
+func makeReplicatingStore(replicas []Store, cfg ReplicatingStoreConfig, stat stats.StatsReceiver) (*ReplicatingStore, error) {
+	if len(replicas) == 0 {
+		return nil, fmt.Errorf("ReplicatingStore needs at least one replica")
+	}
+	quorum := 1
+	switch cfg.Mode {
+	case ReplicateQuorum, "":
+		quorum = cfg.WriteQuorum
+		if quorum <= 0 {
+			quorum = len(replicas)/2 + 1
+		}
+		if quorum > len(replicas) {
+			return nil, fmt.Errorf("Write quorum %d larger than the number of replicas %d", quorum, len(replicas))
+		}
+	case ReplicateAsync:
+	default:
+		return nil, fmt.Errorf("Unknown replication mode %q, expected %q or %q", cfg.Mode, ReplicateQuorum, ReplicateAsync)
+	}
+	maxRepairs := cfg.MaxPendingRepairs
+	if maxRepairs <= 0 {
+		maxRepairs = DefaultMaxPendingRepairs
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	roots := []string{}
+	for _, r := range replicas {
+		roots = append(roots, r.Root())
+	}
+	log.Infof("Making new ReplicatingStore with replicas: %v, write quorum: %d", roots, quorum)
+	return &ReplicatingStore{
+		replicas:   replicas,
+		quorum:     quorum,
+		maxRepairs: maxRepairs,
+		ttl:        cfg.TTL,
+		stat:       stat,
+		latency:    make([]float64, len(replicas)),
+		pending:    make(map[string]*repair),
+	}, nil
+}
+
+// Streams data to every replica as it's read, so bundles aren't held in memory.
+func (s *ReplicatingStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	results := make(chan replicaResult, len(s.replicas))
+	writers := make([]*io.PipeWriter, len(s.replicas))
+	for i, r := range s.replicas {
+		pr, pw := io.Pipe()
+		writers[i] = pw
+		go func(i int, r Store, pr *io.PipeReader) {
+			start := time.Now()
+			err := r.Write(name, pr, ttl)
+			// Replicas that return without reading everything, ex: on error, are dropped from the fan out.
+			pr.CloseWithError(io.ErrClosedPipe)
+			s.observe(i, start, err)
+			results <- replicaResult{i, err}
+		}(i, r, pr)
+	}
+	fanOut(data, writers)
+	done := make(chan error, 1)
+	go s.collectWrites(name, ttl, results, done)
+	return <-done
+}
+
+// Copies data to each of writers, dropping those that fail so the rest still get all of it,
+// then closes them with the error reading data, if any, so replicas don't store a partial bundle.
+func fanOut(data io.Reader, writers []*io.PipeWriter) {
+	live := append([]*io.PipeWriter{}, writers...)
+	buf := make([]byte, replicaChunkSize)
+	var err error
+	for len(live) > 0 {
+		n, readErr := data.Read(buf)
+		if n > 0 {
+			next := live[:0]
+			for _, w := range live {
+				if _, writeErr := w.Write(buf[:n]); writeErr == nil {
+					next = append(next, w)
+				}
+			}
+			live = next
+		}
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			err = readErr
+			break
+		}
+	}
+	for _, w := range writers {
+		w.CloseWithError(err)
+	}
+}
+
+// Reports the result of a write on done as soon as it's known, then waits for the remaining replicas
+// and queues repairs for any that failed.
+func (s *ReplicatingStore) collectWrites(name string, ttl *TTLValue, results chan replicaResult, done chan error) {
+	ok := 0
+	failed := []int{}
+	for range s.replicas {
+		r := <-results
+		if r.err != nil {
+			log.Errorf("Failed writing %s to replica %s: %v", name, s.replicas[r.replica].Root(), r.err)
+			s.stat.Counter(stats.BundlestoreReplicaWriteErrCounter).Inc(1)
+			failed = append(failed, r.replica)
+			if len(failed) == len(s.replicas)-s.quorum+1 {
+				done <- fmt.Errorf("Failed writing %s to %d of %d replicas, last error: %v", name, len(failed), len(s.replicas), r.err)
+			}
+		} else {
+			ok++
+			if ok == s.quorum {
+				done <- nil
+			}
+		}
+	}
+	if ok > 0 {
+		for _, i := range failed {
+			s.queueRepair(name, ttl, i)
+		}
+	}
+}
+
+func (s *ReplicatingStore) OpenForRead(name string) (io.ReadCloser, error) {
+	var err error
+	missing := []int{}
+	for _, i := range s.order() {
+		start := time.Now()
+		var r io.ReadCloser
+		r, err = s.replicas[i].OpenForRead(name)
+		if err == nil || os.IsNotExist(err) {
+			s.observe(i, start, nil) // a replica missing the bundle isn't slow
+		} else {
+			s.observe(i, start, err)
+		}
+		if err == nil {
+			s.repairMissing(name, missing)
+			return r, nil
+		}
+		missing = append(missing, i)
+	}
+	return nil, err
+}
+
+func (s *ReplicatingStore) Exists(name string) (bool, error) {
+	var err error
+	answered := false
+	missing := []int{}
+	for _, i := range s.order() {
+		start := time.Now()
+		exists, existsErr := s.replicas[i].Exists(name)
+		s.observe(i, start, existsErr)
+		if existsErr != nil {
+			err = existsErr
+			continue
+		}
+		if exists {
+			s.repairMissing(name, missing)
+			return true, nil
+		}
+		answered = true
+		missing = append(missing, i)
+	}
+	if answered {
+		return false, nil
+	}
+	return false, err
+}
+
+func (s *ReplicatingStore) Root() string {
+	return s.replicas[0].Root()
+}
+
+// Lists every replica, so bundles are listed even if the replicas missing them haven't been repaired yet.
+func (s *ReplicatingStore) List(prefix, cursor string) (*ListResult, error) {
+	return listMerged(s.replicas, prefix, cursor)
+}
+
+// Copies bundles to the replicas found missing them. Called periodically in the background.
+func (s *ReplicatingStore) Repair() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]*repair)
+	s.mu.Unlock()
+
+	for name, rep := range pending {
+		s.repair(name, rep)
+	}
+}
+
+func (s *ReplicatingStore) repair(name string, rep *repair) {
+	ttl := rep.ttl
+	if ttl == nil {
+		ttl = GetTTLValue(s.ttl)
+	}
+	for i := range rep.replicas {
+		if err := s.copyTo(name, i, rep.replicas, ttl); err != nil {
+			s.repairFailed(name, &repair{ttl: rep.ttl, replicas: map[int]bool{i: true}, attempts: rep.attempts}, err)
+			continue
+		}
+		log.Infof("Repaired %s on replica %s", name, s.replicas[i].Root())
+		s.stat.Counter(stats.BundlestoreReplicaRepairCounter).Inc(1)
+	}
+}
+
+// Streams the bundle from the fastest replica that has it, not one of missing, to replica dst.
+func (s *ReplicatingStore) copyTo(name string, dst int, missing map[int]bool, ttl *TTLValue) error {
+	var err error = fmt.Errorf("no replica has %s", name)
+	for _, i := range s.order() {
+		if missing[i] {
+			continue
+		}
+		var r io.ReadCloser
+		if r, err = s.replicas[i].OpenForRead(name); err != nil {
+			continue
+		}
+		defer r.Close()
+		return s.replicas[dst].Write(name, r, ttl)
+	}
+	return err
+}
+
+func (s *ReplicatingStore) repairFailed(name string, rep *repair, err error) {
+	s.stat.Counter(stats.BundlestoreReplicaRepairErrCounter).Inc(1)
+	rep.attempts++
+	if rep.attempts >= maxRepairAttempts {
+		log.Errorf("Giving up repairing %s after %d attempts: %v", name, rep.attempts, err)
+		return
+	}
+	log.Infof("Failed repairing %s, will retry: %v", name, err)
+	for i := range rep.replicas {
+		s.queueRepairAttempt(name, rep.ttl, i, rep.attempts)
+	}
+}
+
+// Queues repairs for replicas found missing a bundle that another replica has.
+// The bundle's TTL isn't known, so the configured TTL is used.
+func (s *ReplicatingStore) repairMissing(name string, missing []int) {
+	if len(missing) == 0 {
+		return
+	}
+	s.stat.Counter(stats.BundlestoreReplicaReadFailoverCounter).Inc(1)
+	for _, i := range missing {
+		s.queueRepair(name, nil, i)
+	}
+}
+
+func (s *ReplicatingStore) queueRepair(name string, ttl *TTLValue, replica int) {
+	s.queueRepairAttempt(name, ttl, replica, 0)
+}
+
+func (s *ReplicatingStore) queueRepairAttempt(name string, ttl *TTLValue, replica, attempts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rep, ok := s.pending[name]
+	if !ok {
+		if len(s.pending) >= s.maxRepairs {
+			s.stat.Counter(stats.BundlestoreReplicaRepairDroppedCounter).Inc(1)
+			return
+		}
+		rep = &repair{ttl: ttl, replicas: map[int]bool{}, attempts: attempts}
+		s.pending[name] = rep
+	}
+	if rep.ttl == nil {
+		rep.ttl = ttl
+	}
+	rep.replicas[replica] = true
+}
+
+// Returns the number of bundles waiting to be repaired.
+func (s *ReplicatingStore) PendingRepairs() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// Returns replica indexes, fastest first.
+func (s *ReplicatingStore) order() []int {
+	s.mu.Lock()
+	latency := append([]float64{}, s.latency...)
+	s.mu.Unlock()
+	order := make([]int, len(latency))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return latency[order[i]] < latency[order[j]] })
+	return order
+}
+
+// Updates the moving average latency of replica with an operation started at start.
+func (s *ReplicatingStore) observe(replica int, start time.Time, err error) {
+	d := time.Since(start)
+	if err != nil {
+		d += replicaErrorLatency
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latency[replica] == 0 {
+		s.latency[replica] = float64(d)
+	} else {
+		s.latency[replica] = (1-replicaLatencyAlpha)*s.latency[replica] + replicaLatencyAlpha*float64(d)
+	}
+}
@@ -0,0 +1,317 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+// A FakeStore that fails every operation while down is set.
+type flakyStore struct {
+	FakeStore
+	down int32
+	ttls sync.Map // name -> *TTLValue written with
+}
+
+var errDown = errors.New("replica down")
+
+func (f *flakyStore) setDown(down bool) {
+	v := int32(0)
+	if down {
+		v = 1
+	}
+	atomic.StoreInt32(&f.down, v)
+}
+
+func (f *flakyStore) isDown() bool { return atomic.LoadInt32(&f.down) == 1 }
+
+func (f *flakyStore) Exists(name string) (bool, error) {
+	if f.isDown() {
+		return false, errDown
+	}
+	return f.FakeStore.Exists(name)
+}
+
+func (f *flakyStore) OpenForRead(name string) (io.ReadCloser, error) {
+	if f.isDown() {
+		return nil, errDown
+	}
+	return f.FakeStore.OpenForRead(name)
+}
+
+func (f *flakyStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	if f.isDown() {
+		return errDown
+	}
+	// TTLs are recorded for tests to check rather than checked by FakeStore
+	f.ttls.Store(name, ttl)
+	return f.FakeStore.Write(name, data, f.TTL)
+}
+
+func (f *flakyStore) List(prefix, cursor string) (*ListResult, error) {
+	if f.isDown() {
+		return nil, errDown
+	}
+	return f.FakeStore.List(prefix, cursor)
+}
+
+func makeReplicas(n int) ([]*flakyStore, []Store) {
+	flaky := []*flakyStore{}
+	replicas := []Store{}
+	for i := 0; i < n; i++ {
+		f := &flakyStore{}
+		flaky = append(flaky, f)
+		replicas = append(replicas, f)
+	}
+	return flaky, replicas
+}
+
+func assertHas(t *testing.T, s Store, name, data string) {
+	r, err := s.OpenForRead(name)
+	if err != nil {
+		t.Fatalf("Expected to read %s: %v", name, err)
+	}
+	defer r.Close()
+	b, _ := ioutil.ReadAll(r)
+	if string(b) != data {
+		t.Fatalf("Expected %s to contain %q, got %q", name, data, b)
+	}
+}
+
+func TestReplicatingStoreQuorumWrite(t *testing.T) {
+	flaky, replicas := makeReplicas(3)
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A majority is enough, the replica that missed the write is repaired once it's back.
+	flaky[2].setDown(true)
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), nil); err != nil {
+		t.Fatalf("Expected quorum write to succeed: %v", err)
+	}
+	// The failed write is queued once all replicas have answered.
+	for i := 0; s.PendingRepairs() == 0; i++ {
+		if i == 100 {
+			t.Fatal("Expected the failed write to be queued for repair")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	flaky[2].setDown(false)
+	s.Repair()
+	assertHas(t, flaky[2], "bs-1.bundle", "one")
+	if s.PendingRepairs() != 0 {
+		t.Fatalf("Expected no pending repairs, got %d", s.PendingRepairs())
+	}
+
+	flaky[1].setDown(true)
+	flaky[2].setDown(true)
+	if err := s.Write("bs-2.bundle", strings.NewReader("two"), nil); err == nil {
+		t.Fatal("Expected write without quorum to fail")
+	}
+}
+
+func TestReplicatingStoreAsyncWrite(t *testing.T) {
+	flaky, replicas := makeReplicas(2)
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{Mode: ReplicateAsync}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flaky[0].setDown(true)
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), nil); err != nil {
+		t.Fatalf("Expected async write to succeed with one replica: %v", err)
+	}
+	flaky[1].setDown(true)
+	if err := s.Write("bs-2.bundle", strings.NewReader("two"), nil); err == nil {
+		t.Fatal("Expected write to fail with all replicas down")
+	}
+}
+
+func TestReplicatingStoreReadFailover(t *testing.T) {
+	flaky, replicas := makeReplicas(3)
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flaky[2].Files.Store("bs-1.bundle", []byte("one"))
+
+	// Reads find the bundle on whichever replica has it, and repair the others.
+	assertHas(t, s, "bs-1.bundle", "one")
+	if exists, err := s.Exists("bs-1.bundle"); err != nil || !exists {
+		t.Fatalf("Expected bundle to exist: %v %v", exists, err)
+	}
+	if s.PendingRepairs() != 1 {
+		t.Fatalf("Expected a pending repair, got %d", s.PendingRepairs())
+	}
+	s.Repair()
+	assertHas(t, flaky[0], "bs-1.bundle", "one")
+	assertHas(t, flaky[1], "bs-1.bundle", "one")
+
+	// Losing replicas doesn't make the bundle unavailable.
+	flaky[0].setDown(true)
+	flaky[2].setDown(true)
+	assertHas(t, s, "bs-1.bundle", "one")
+
+	if exists, err := s.Exists("bs-missing.bundle"); err != nil || exists {
+		t.Fatalf("Expected missing bundle not to exist: %v %v", exists, err)
+	}
+	flaky[1].setDown(true)
+	if _, err := s.Exists("bs-1.bundle"); err == nil {
+		t.Fatal("Expected error with all replicas down")
+	}
+}
+
+func TestReplicatingStoreRepairTTL(t *testing.T) {
+	flaky, replicas := makeReplicas(2)
+	ttlc := &TTLConfig{TTL: time.Hour, TTLKey: DefaultTTLKey}
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{WriteQuorum: 1, TTL: ttlc}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repairs of failed writes keep the TTL the bundle was written with.
+	written := &TTLValue{TTL: time.Now().Add(time.Minute), TTLKey: DefaultTTLKey}
+	flaky[1].setDown(true)
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), written); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; s.PendingRepairs() == 0; i++ {
+		if i == 100 {
+			t.Fatal("Expected the failed write to be queued for repair")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	flaky[1].setDown(false)
+	s.Repair()
+	if ttl, _ := flaky[1].ttls.Load("bs-1.bundle"); ttl != written {
+		t.Fatalf("Expected the repaired copy to keep TTL %v, got %v", written, ttl)
+	}
+
+	// Repairs of bundles found missing on read, whose TTL isn't known, get the configured TTL.
+	// The replica that was down is read last, after the one missing the bundle.
+	flaky[1].Files.Store("bs-2.bundle", []byte("two"))
+	assertHas(t, s, "bs-2.bundle", "two")
+	s.Repair()
+	v, _ := flaky[0].ttls.Load("bs-2.bundle")
+	if ttl, _ := v.(*TTLValue); ttl == nil || ttl.TTLKey != DefaultTTLKey || time.Until(ttl.TTL) <= 59*time.Minute {
+		t.Fatalf("Expected the repaired copy to get the configured TTL, got %v", v)
+	}
+}
+
+func TestReplicatingStoreWriteReadError(t *testing.T) {
+	flaky, replicas := makeReplicas(3)
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bundles are streamed to the replicas, which don't store them if reading the bundle fails part way.
+	data := io.MultiReader(strings.NewReader("partial"), &errReader{errors.New("read failed")})
+	if err := s.Write("bs-1.bundle", data, nil); err == nil {
+		t.Fatal("Expected write to fail when reading the bundle fails")
+	}
+	for i, f := range flaky {
+		if ok, _ := f.Exists("bs-1.bundle"); ok {
+			t.Fatalf("Expected replica %d not to store a partial bundle", i)
+		}
+	}
+
+	// Large bundles reach every replica whole, the last after the write returns on quorum.
+	big := strings.Repeat("x", 3*replicaChunkSize+1)
+	if err := s.Write("bs-2.bundle", strings.NewReader(big), nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range flaky {
+		for i := 0; ; i++ {
+			if ok, _ := f.Exists("bs-2.bundle"); ok {
+				break
+			} else if i == 100 {
+				t.Fatal("Expected every replica to store the bundle")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		assertHas(t, f, "bs-2.bundle", big)
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestReplicatingStoreConfig(t *testing.T) {
+	_, replicas := makeReplicas(2)
+	for _, cfg := range []ReplicatingStoreConfig{{Mode: "nope"}, {WriteQuorum: 3}} {
+		if _, err := makeReplicatingStore(replicas, cfg, nil); err == nil {
+			t.Fatalf("Expected error for config %+v", cfg)
+		}
+	}
+	if _, err := makeReplicatingStore(nil, ReplicatingStoreConfig{}, nil); err == nil {
+		t.Fatal("Expected error without replicas")
+	}
+}
+
+func TestReplicatingStoreList(t *testing.T) {
+	flaky, replicas := makeReplicas(2)
+	s, err := makeReplicatingStore(replicas, ReplicatingStoreConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replicas split more than a page of bundles between them, sharing the first one.
+	n := 2*ListPageSize + 1
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("bs-%05d.bundle", i)
+		flaky[i%2].Files.Store(name, []byte{})
+		if i == 0 {
+			flaky[1].Files.Store(name, []byte{})
+		}
+	}
+	flaky[0].Files.Store("other", []byte{})
+
+	names := []string{}
+	cursor := ""
+	for pages := 1; ; pages++ {
+		r, err := s.List("bs-", cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Names) > ListPageSize {
+			t.Fatalf("Expected at most %d names in a page, got %d", ListPageSize, len(r.Names))
+		}
+		names = append(names, r.Names...)
+		if cursor = r.Cursor; cursor == "" {
+			break
+		}
+		if pages > n {
+			t.Fatal("Expected listing to end")
+		}
+	}
+	if len(names) != n {
+		t.Fatalf("Expected %d names, got %d", n, len(names))
+	}
+	for i, name := range names {
+		if expected := fmt.Sprintf("bs-%05d.bundle", i); name != expected {
+			t.Fatalf("Expected %s at %d, got %s", expected, i, name)
+		}
+	}
+
+	// Replicas that are down are skipped.
+	flaky[0].setDown(true)
+	if r, err := s.List("bs-", ""); err != nil || len(r.Names) != ListPageSize || r.Names[1] != "bs-00001.bundle" {
+		t.Fatalf("Expected a page from the replica that's up, got %v %v", r, err)
+	}
+	flaky[1].setDown(true)
+	if _, err := s.List("bs-", ""); err == nil {
+		t.Fatal("Expected error with all replicas down")
+	}
+}
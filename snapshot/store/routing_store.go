@@ -0,0 +1,181 @@
+package store
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+)
+
+const (
+	// Maximum number of bundles waiting to be replicated to the remote region. Further writes aren't replicated.
+	DefaultMaxPendingReplications = 10000
+
+	// Number of bundles replicated to the remote region concurrently
+	DefaultReplicationWorkers = 4
+)
+
+type RoutingStoreConfig struct {
+	// If <= 0, DefaultMaxPendingReplications.
+	MaxPendingReplications int
+	// If <= 0, DefaultReplicationWorkers.
+	ReplicationWorkers int
+}
+
+// Implements Store. RoutingStore routes bundles between the Store in this region and the Store in a remote
+// region, so geo-distributed worker pools mostly read from their own region.
+//
+// Writes complete once the local Store has the bundle and are replicated to the remote Store in the background.
+// Reads prefer the local Store and fall back to the remote one, copying bundles found there into the local
+// Store so later reads in this region don't cross the WAN.
+//
+// Regions can route to each other: the bundlestore ignores uploads of bundles it already has,
+// so a replicated bundle isn't replicated back. CAS blobs are replicated the same way, since
+// the bundlestore's HTTP API accepts them once it verifies their contents.
+//
+// Exists doesn't wait on the remote region, since it's on the CAS's FindMissingBlobs path: a bundle missing
+// locally is reported missing until a background lookup finds it in the remote Store. At worst a client
+// uploads a bundle the remote region has.
+type RoutingStore struct {
+	local        Store
+	remote       Store
+	remoteExists *remoteExists
+	stat         stats.StatsReceiver
+	pending      chan replication
+
+	mu      sync.Mutex
+	waiting int
+}
+
+type replication struct {
+	name string
+	ttl  *TTLValue
+}
+
+// Create a RoutingStore and start replicating to remote in the background.
+func MakeRoutingStore(local, remote Store, cfg RoutingStoreConfig, stat stats.StatsReceiver) *RoutingStore {
+	maxPending := cfg.MaxPendingReplications
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPendingReplications
+	}
+	workers := cfg.ReplicationWorkers
+	if workers <= 0 {
+		workers = DefaultReplicationWorkers
+	}
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
+	log.Infof("Making new RoutingStore with local: %s, remote: %s", local.Root(), remote.Root())
+	s := &RoutingStore{
+		local:        local,
+		remote:       remote,
+		remoteExists: newRemoteExists(remote),
+		stat:         stat,
+		pending:      make(chan replication, maxPending),
+	}
+	for i := 0; i < workers; i++ {
+		go s.replicate()
+	}
+	return s
+}
+
+func (s *RoutingStore) Write(name string, data io.Reader, ttl *TTLValue) error {
+	if err := s.local.Write(name, data, ttl); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.pending <- replication{name, ttl}:
+		s.waiting++
+	default:
+		log.Errorf("Not replicating %s to %s, too many replications pending", name, s.remote.Root())
+		s.stat.Counter(stats.BundlestoreRoutingReplicateDroppedCounter).Inc(1)
+	}
+	return nil
+}
+
+func (s *RoutingStore) OpenForRead(name string) (io.ReadCloser, error) {
+	r, err := s.local.OpenForRead(name)
+	if err == nil {
+		s.stat.Counter(stats.BundlestoreRoutingLocalHitCounter).Inc(1)
+		return r, nil
+	}
+	if !os.IsNotExist(err) {
+		log.Infof("Failed reading %s from local store, trying remote: %v", name, err)
+	}
+
+	r, err = s.remote.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s.stat.Counter(stats.BundlestoreRoutingRemoteHitCounter).Inc(1)
+	s.remoteExists.set(name, true)
+	// The bundle's TTL isn't known, so the local Store's default is used.
+	if err := s.local.Write(name, bytes.NewReader(data), nil); err != nil {
+		log.Errorf("Failed copying %s from remote store to local: %v", name, err)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *RoutingStore) Exists(name string) (bool, error) {
+	if exists, err := s.local.Exists(name); err == nil && exists {
+		return true, nil
+	}
+	exists, _ := s.remoteExists.get(name)
+	return exists, nil
+}
+
+func (s *RoutingStore) Root() string {
+	return s.local.Root()
+}
+
+// Lists both regions, matching the bundles that can be read.
+func (s *RoutingStore) List(prefix, cursor string) (*ListResult, error) {
+	return listMerged([]Store{s.local, s.remote}, prefix, cursor)
+}
+
+// Returns the number of bundles waiting to be replicated to the remote region.
+func (s *RoutingStore) PendingReplications() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiting
+}
+
+// Copies bundles written locally to the remote Store. Never returns.
+func (s *RoutingStore) replicate() {
+	for rep := range s.pending {
+		if err := s.copyToRemote(rep); err != nil {
+			log.Errorf("Failed replicating %s to %s: %v", rep.name, s.remote.Root(), err)
+			s.stat.Counter(stats.BundlestoreRoutingReplicateErrCounter).Inc(1)
+		} else {
+			s.stat.Counter(stats.BundlestoreRoutingReplicateCounter).Inc(1)
+		}
+		s.mu.Lock()
+		s.waiting--
+		s.mu.Unlock()
+	}
+}
+
+func (s *RoutingStore) copyToRemote(rep replication) error {
+	r, err := s.local.OpenForRead(rep.name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := s.remote.Write(rep.name, r, rep.ttl); err != nil {
+		return err
+	}
+	s.remoteExists.set(rep.name, true)
+	return nil
+}
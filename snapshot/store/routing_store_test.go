@@ -0,0 +1,72 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitForReplications(t *testing.T, s *RoutingStore) {
+	for i := 0; s.PendingReplications() != 0; i++ {
+		if i == 100 {
+			t.Fatalf("Expected replications to finish, %d pending", s.PendingReplications())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRoutingStoreWrite(t *testing.T) {
+	local, remote := &flakyStore{}, &flakyStore{}
+	s := MakeRoutingStore(local, remote, RoutingStoreConfig{}, nil)
+
+	if err := s.Write("bs-1.bundle", strings.NewReader("one"), nil); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	assertHas(t, local, "bs-1.bundle", "one")
+	waitForReplications(t, s)
+	assertHas(t, remote, "bs-1.bundle", "one")
+
+	// Writes don't wait for the remote region.
+	remote.setDown(true)
+	if err := s.Write("bs-2.bundle", strings.NewReader("two"), nil); err != nil {
+		t.Fatalf("Expected write to succeed with remote down: %v", err)
+	}
+	assertHas(t, s, "bs-2.bundle", "two")
+
+	local.setDown(true)
+	if err := s.Write("bs-3.bundle", strings.NewReader("three"), nil); err == nil {
+		t.Fatal("Expected write to fail with local down")
+	}
+}
+
+func TestRoutingStoreRead(t *testing.T) {
+	local, remote := &flakyStore{}, &flakyStore{}
+	s := MakeRoutingStore(local, remote, RoutingStoreConfig{}, nil)
+	remote.Files.Store("bs-1.bundle", []byte("one"))
+
+	// Exists doesn't wait on the remote region, it's looked up in the background.
+	if exists, err := s.Exists("bs-1.bundle"); err != nil || exists {
+		t.Fatalf("Expected bundle to be missing until it's looked up remotely: %v %v", exists, err)
+	}
+	for i := 0; ; i++ {
+		if exists, _ := s.Exists("bs-1.bundle"); exists {
+			break
+		} else if i == 100 {
+			t.Fatal("Expected bundle to exist once it's looked up remotely")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Bundles only in the remote region are copied locally when read.
+	assertHas(t, s, "bs-1.bundle", "one")
+	assertHas(t, local, "bs-1.bundle", "one")
+
+	remote.setDown(true)
+	assertHas(t, s, "bs-1.bundle", "one")
+	if exists, err := s.Exists("bs-1.bundle"); err != nil || !exists {
+		t.Fatalf("Expected bundle to exist with remote down: %v %v", exists, err)
+	}
+	if _, err := s.OpenForRead("bs-missing.bundle"); err == nil {
+		t.Fatal("Expected error reading missing bundle with remote down")
+	}
+}
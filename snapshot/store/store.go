@@ -4,8 +4,12 @@ package store
 
 import (
 	"io"
+	"sort"
+	"strings"
 	"time"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/common/stats"
 )
 
@@ -54,6 +58,94 @@ type StoreRead interface {
 
 	// Get the base location, like a directory or base URI that the Store writes to
 	Root() string
+
+	// List the names of stored bundles starting with prefix, in lexical order, one page of up to ListPageSize
+	// names at a time. cursor is empty for the first page and the previous page's Cursor for the next ones.
+	List(prefix, cursor string) (*ListResult, error)
+}
+
+// Maximum number of names in a page listed from a Store
+const ListPageSize = 1000
+
+// A page of names listed from a Store.
+type ListResult struct {
+	Names []string `json:"names"`
+	// Passed to List to get the page after this one, empty if this is the last page.
+	// This is the last name in the page, so listing resumes after it even if the Store changed in between.
+	Cursor string `json:"cursor"`
+}
+
+// Returns the page of names starting with prefix after cursor, for Stores that can hold all their names in memory.
+// Names may be repeated.
+func listNames(names []string, prefix, cursor string) *ListResult {
+	page := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && name > cursor {
+			page = append(page, name)
+		}
+	}
+	sort.Strings(page)
+	unique := page[:0]
+	for _, name := range page {
+		if len(unique) == 0 || name != unique[len(unique)-1] {
+			unique = append(unique, name)
+		}
+	}
+	page = unique
+	if len(page) <= ListPageSize {
+		return &ListResult{Names: page}
+	}
+	page = page[:ListPageSize]
+	return &ListResult{Names: page, Cursor: page[len(page)-1]}
+}
+
+// Merges the pages listed with the same prefix and cursor from Stores that may hold the same names.
+// Names past the end of a page that isn't the last one are left for the next page, since the Store
+// it came from may have names before them that haven't been listed yet.
+func mergeListResults(results []*ListResult) *ListResult {
+	end := ""
+	seen := map[string]bool{}
+	names := []string{}
+	for _, r := range results {
+		if r.Cursor != "" && (end == "" || r.Cursor < end) {
+			end = r.Cursor
+		}
+		for _, name := range r.Names {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	if end != "" {
+		names = names[:sort.SearchStrings(names, end)+1]
+	}
+	if len(names) > ListPageSize {
+		names = names[:ListPageSize]
+		end = names[len(names)-1]
+	}
+	return &ListResult{Names: names, Cursor: end}
+}
+
+// Lists a page from each of stores and merges them. Stores that fail are skipped unless they all do,
+// so a page may be missing the names only a failed Store has.
+func listMerged(stores []Store, prefix, cursor string) (*ListResult, error) {
+	results := []*ListResult{}
+	var err error
+	for _, s := range stores {
+		r, listErr := s.List(prefix, cursor)
+		if listErr != nil {
+			log.Infof("Failed listing %s, skipping it: %v", s.Root(), listErr)
+			err = listErr
+			continue
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 {
+		return nil, err
+	}
+	return mergeListResults(results), nil
 }
 
 // Write operations on store, limited to a one-shot writing operation since bundles are immutable.
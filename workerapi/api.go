@@ -8,6 +8,7 @@ import (
 	"github.com/twitter/scoot/common/log/tags"
 	"github.com/twitter/scoot/common/thrifthelpers"
 	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
@@ -17,9 +18,10 @@ import (
 
 // TODO: test workerStatus.
 type WorkerStatus struct {
-	Runs        []runner.RunStatus
-	Initialized bool
-	Error       string
+	Runs         []runner.RunStatus
+	Initialized  bool
+	Error        string
+	Capabilities *runner.Capabilities // nil if the worker didn't report any
 }
 
 func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
@@ -27,7 +29,7 @@ func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
 	for _, r := range thrift.Runs {
 		runs = append(runs, ThriftRunStatusToDomain(r))
 	}
-	return WorkerStatus{runs, thrift.Initialized, thrift.Error}
+	return WorkerStatus{runs, thrift.Initialized, thrift.Error, ThriftCapabilitiesToDomain(thrift.Capabilities)}
 }
 
 func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
@@ -38,6 +40,60 @@ func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
 		thrift.Initialized = domain.Initialized
 		thrift.Error = domain.Error
 	}
+	thrift.Capabilities = DomainCapabilitiesToThrift(domain.Capabilities)
+	return thrift
+}
+
+func ThriftCapabilitiesToDomain(thrift *worker.WorkerCapabilities) *runner.Capabilities {
+	if thrift == nil {
+		return nil
+	}
+	caps := &runner.Capabilities{
+		Version:       thrift.GetVersion(),
+		SnapshotType:  thrift.GetSnapshotType(),
+		Features:      thrift.GetFeatures(),
+		OS:            thrift.GetOs(),
+		Arch:          thrift.GetArch(),
+		FreeDiskBytes: thrift.GetFreeDiskBytes(),
+		Resources:     runner.Resources{MilliCPUs: thrift.GetMilliCpus(), MemoryBytes: thrift.GetMemoryBytes()},
+	}
+	for _, g := range thrift.GetGpus() {
+		caps.GPUs = append(caps.GPUs, runner.GPU{ID: g.GetID(), Model: g.GetModel()})
+	}
+	return caps
+}
+
+func DomainCapabilitiesToThrift(domain *runner.Capabilities) *worker.WorkerCapabilities {
+	if domain == nil {
+		return nil
+	}
+	thrift := worker.NewWorkerCapabilities()
+	version := domain.Version
+	thrift.Version = &version
+	snapshotType := domain.SnapshotType
+	thrift.SnapshotType = &snapshotType
+	thrift.Features = domain.Features
+	os := domain.OS
+	thrift.Os = &os
+	arch := domain.Arch
+	thrift.Arch = &arch
+	freeDiskBytes := domain.FreeDiskBytes
+	thrift.FreeDiskBytes = &freeDiskBytes
+	for _, g := range domain.GPUs {
+		device := worker.NewGPUDevice()
+		device.ID = g.ID
+		model := g.Model
+		device.Model = &model
+		thrift.Gpus = append(thrift.Gpus, device)
+	}
+	if domain.Resources.MilliCPUs != 0 {
+		milliCpus := domain.Resources.MilliCPUs
+		thrift.MilliCpus = &milliCpus
+	}
+	if domain.Resources.MemoryBytes != 0 {
+		memoryBytes := domain.Resources.MemoryBytes
+		thrift.MemoryBytes = &memoryBytes
+	}
 	return thrift
 }
 
@@ -45,6 +101,7 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	argv := make([]string, 0)
 	env := make(map[string]string)
 	timeout := time.Duration(0)
+	retention := time.Duration(0)
 	snapshotID := ""
 	jobID := ""
 	taskID := ""
@@ -58,6 +115,9 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	if thrift.TimeoutMs != nil {
 		timeout = time.Millisecond * time.Duration(*thrift.TimeoutMs)
 	}
+	if thrift.RetentionMs != nil && *thrift.RetentionMs > 0 {
+		retention = time.Millisecond * time.Duration(*thrift.RetentionMs)
+	}
 	if thrift.SnapshotId != nil {
 		snapshotID = *thrift.SnapshotId
 	}
@@ -72,10 +132,12 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	}
 	er := bazelapi.MakeExecReqDomainFromThrift(thrift.BazelRequest)
 	return &runner.Command{
-		Argv:       argv,
-		EnvVars:    env,
-		Timeout:    timeout,
-		SnapshotID: snapshotID,
+		Argv:           argv,
+		EnvVars:        env,
+		Timeout:        timeout,
+		SnapshotID:     snapshotID,
+		Retention:      retention,
+		SparsePatterns: thrift.SparsePatterns,
 		LogTags: tags.LogTags{
 			JobID:  jobID,
 			TaskID: taskID,
@@ -89,8 +151,13 @@ func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
 	thrift := worker.NewRunCommand()
 	timeoutMs := int32(domain.Timeout / time.Millisecond)
 	thrift.TimeoutMs = &timeoutMs
+	if domain.Retention > 0 {
+		retentionMs := int64(domain.Retention / time.Millisecond)
+		thrift.RetentionMs = &retentionMs
+	}
 	thrift.Env = domain.EnvVars
 	thrift.Argv = domain.Argv
+	thrift.SparsePatterns = domain.SparsePatterns
 	snapID := domain.SnapshotID
 	thrift.SnapshotId = &snapID
 	jobID := domain.JobID
@@ -150,6 +217,23 @@ func ThriftRunStatusToDomain(thrift *worker.RunStatus) runner.RunStatus {
 		domain.Tag = *thrift.Tag
 	}
 	domain.ActionResult = bazelapi.MakeActionResultDomainFromThrift(thrift.BazelResult_)
+	domain.Usage = ThriftResourceUsageToDomain(thrift.Usage)
+	if thrift.HookError != nil {
+		domain.HookError = *thrift.HookError
+	}
+	if thrift.LogRef != nil {
+		domain.LogRef = *thrift.LogRef
+	}
+	if thrift.FailureKind != nil {
+		domain.FailureKind = runner.FailureKind(*thrift.FailureKind)
+	}
+	if thrift.StdoutTail != nil {
+		domain.StdoutTail = *thrift.StdoutTail
+	}
+	if thrift.StderrTail != nil {
+		domain.StderrTail = *thrift.StderrTail
+	}
+	domain.GracefulShutdown = thrift.GetGracefulShutdown()
 	return domain
 }
 
@@ -184,9 +268,131 @@ func DomainRunStatusToThrift(domain runner.RunStatus) *worker.RunStatus {
 	thrift.TaskId = helpers.CopyStringToPointer(domain.TaskID)
 	thrift.Tag = helpers.CopyStringToPointer(domain.Tag)
 	thrift.BazelResult_ = bazelapi.MakeActionResultThriftFromDomain(domain.ActionResult)
+	thrift.Usage = DomainResourceUsageToThrift(domain.Usage)
+	thrift.HookError = helpers.CopyStringToPointer(domain.HookError)
+	thrift.LogRef = helpers.CopyStringToPointer(domain.LogRef)
+	if domain.FailureKind != runner.UnclassifiedFailure {
+		kind := worker.FailureKind(domain.FailureKind)
+		thrift.FailureKind = &kind
+	}
+	thrift.StdoutTail = helpers.CopyStringToPointer(domain.StdoutTail)
+	thrift.StderrTail = helpers.CopyStringToPointer(domain.StderrTail)
+	if domain.GracefulShutdown {
+		thrift.GracefulShutdown = &domain.GracefulShutdown
+	}
+	return thrift
+}
+
+func ThriftResourceUsageToDomain(thrift *worker.ResourceUsage) execer.ResourceUsage {
+	domain := execer.ResourceUsage{}
+	if thrift == nil {
+		return domain
+	}
+	if thrift.MaxRssBytes != nil {
+		domain.MaxRSS = execer.Memory(*thrift.MaxRssBytes)
+	}
+	if thrift.UserTimeMs != nil {
+		domain.UserTime = time.Duration(*thrift.UserTimeMs) * time.Millisecond
+	}
+	if thrift.SystemTimeMs != nil {
+		domain.SystemTime = time.Duration(*thrift.SystemTimeMs) * time.Millisecond
+	}
+	if thrift.WallTimeMs != nil {
+		domain.WallTime = time.Duration(*thrift.WallTimeMs) * time.Millisecond
+	}
+	return domain
+}
+
+// Returns nil if usage is unknown so we don't report zeros.
+func DomainResourceUsageToThrift(domain execer.ResourceUsage) *worker.ResourceUsage {
+	if domain == (execer.ResourceUsage{}) {
+		return nil
+	}
+	thrift := worker.NewResourceUsage()
+	maxRss := int64(domain.MaxRSS)
+	userMs := int64(domain.UserTime / time.Millisecond)
+	systemMs := int64(domain.SystemTime / time.Millisecond)
+	wallMs := int64(domain.WallTime / time.Millisecond)
+	thrift.MaxRssBytes = &maxRss
+	thrift.UserTimeMs = &userMs
+	thrift.SystemTimeMs = &systemMs
+	thrift.WallTimeMs = &wallMs
+	return thrift
+}
+
+func ThriftRunHistoryQueryToDomain(thrift *worker.RunHistoryQuery) runner.HistoryQuery {
+	q := runner.HistoryQuery{}
+	if thrift == nil {
+		return q
+	}
+	if thrift.RunId != nil {
+		q.RunID = runner.RunID(*thrift.RunId)
+	}
+	if thrift.StartTimeMs != nil {
+		q.Start = msToTime(*thrift.StartTimeMs)
+	}
+	if thrift.EndTimeMs != nil {
+		q.End = msToTime(*thrift.EndTimeMs)
+	}
+	if thrift.Limit != nil {
+		q.Limit = int(*thrift.Limit)
+	}
+	return q
+}
+
+func DomainRunHistoryQueryToThrift(domain runner.HistoryQuery) *worker.RunHistoryQuery {
+	thrift := worker.NewRunHistoryQuery()
+	if domain.RunID != "" {
+		thrift.RunId = helpers.CopyStringToPointer(string(domain.RunID))
+	}
+	if !domain.Start.IsZero() {
+		startMs := timeToMs(domain.Start)
+		thrift.StartTimeMs = &startMs
+	}
+	if !domain.End.IsZero() {
+		endMs := timeToMs(domain.End)
+		thrift.EndTimeMs = &endMs
+	}
+	if domain.Limit > 0 {
+		limit := int32(domain.Limit)
+		thrift.Limit = &limit
+	}
 	return thrift
 }
 
+func ThriftRunHistoryToDomain(thrift *worker.RunHistory) []runner.RunRecord {
+	recs := make([]runner.RunRecord, 0)
+	for _, r := range thrift.Runs {
+		recs = append(recs, runner.RunRecord{
+			RunStatus: ThriftRunStatusToDomain(r.Status),
+			StartTime: msToTime(r.StartTimeMs),
+			EndTime:   msToTime(r.EndTimeMs),
+		})
+	}
+	return recs
+}
+
+func DomainRunHistoryToThrift(domain []runner.RunRecord) *worker.RunHistory {
+	thrift := worker.NewRunHistory()
+	thrift.Runs = make([]*worker.RunHistoryRecord, 0)
+	for _, r := range domain {
+		rec := worker.NewRunHistoryRecord()
+		rec.Status = DomainRunStatusToThrift(r.RunStatus)
+		rec.StartTimeMs = timeToMs(r.StartTime)
+		rec.EndTimeMs = timeToMs(r.EndTime)
+		thrift.Runs = append(thrift.Runs, rec)
+	}
+	return thrift
+}
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func timeToMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
 func SerializeProcessStatus(processStatus runner.RunStatus) ([]byte, error) {
 
 	runStatus := DomainRunStatusToThrift(processStatus)
@@ -199,3 +405,12 @@ func SerializeProcessStatus(processStatus runner.RunStatus) ([]byte, error) {
 
 	return asBytes, err
 }
+
+// Inverse of SerializeProcessStatus, ex: to read a task's result from its saga.
+func DeserializeProcessStatus(data []byte) (runner.RunStatus, error) {
+	runStatus := worker.NewRunStatus()
+	if err := thrifthelpers.JsonDeserialize(runStatus, data); err != nil {
+		return runner.RunStatus{}, err
+	}
+	return ThriftRunStatusToDomain(runStatus), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/twitter/scoot/common/log/tags"
 	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
@@ -17,6 +18,16 @@ var nonzero = int32(12345)
 var emptystr = ""
 var nonemptystr = "abcdef"
 var deadbeefID = "snap-id-deadbeef"
+var someRss = int64(1 << 20)
+var someMs = int64(1500)
+var someOS = "linux"
+var someArch = "amd64"
+var someDisk = int64(1 << 30)
+var someGPUModel = "Tesla V100-SXM2-16GB"
+var someMilliCPUs = int64(8000)
+var someMemory = int64(16 << 30)
+var requestFailure = worker.FailureKind_REQUEST
+var graceful = true
 
 var cmdFromThrift = func(x interface{}) interface{} { return ThriftRunCommandToDomain(x.(*worker.RunCommand)) }
 var cmdToThrift = func(x interface{}) interface{} { return DomainRunCommandToThrift(x.(*runner.Command)) }
@@ -84,19 +95,23 @@ var tests = []struct {
 		cmdFromThrift,
 		cmdToThrift,
 		&worker.RunCommand{
-			Argv:       someCmd,
-			Env:        someEnv,
-			SnapshotId: &nonemptystr,
-			TimeoutMs:  &nonzero,
-			JobId:      &emptystr,
-			TaskId:     &emptystr,
-			Tag:        &nonemptystr,
+			Argv:           someCmd,
+			Env:            someEnv,
+			SnapshotId:     &nonemptystr,
+			TimeoutMs:      &nonzero,
+			JobId:          &emptystr,
+			TaskId:         &emptystr,
+			Tag:            &nonemptystr,
+			RetentionMs:    &someMs,
+			SparsePatterns: []string{"/src/"},
 		},
 		&runner.Command{
-			Argv:       someCmd,
-			EnvVars:    someEnv,
-			SnapshotID: nonemptystr,
-			Timeout:    time.Duration(nonzero) * time.Millisecond,
+			Argv:           someCmd,
+			EnvVars:        someEnv,
+			SnapshotID:     nonemptystr,
+			Timeout:        time.Duration(nonzero) * time.Millisecond,
+			Retention:      time.Duration(someMs) * time.Millisecond,
+			SparsePatterns: []string{"/src/"},
 			LogTags: tags.LogTags{
 				JobID:  emptystr,
 				TaskID: emptystr,
@@ -379,6 +394,180 @@ var tests = []struct {
 			},
 		},
 	},
+	{
+		16,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:   worker.Status_COMPLETE,
+			RunId:    "id",
+			ExitCode: &nonzero,
+			Usage: &worker.ResourceUsage{
+				MaxRssBytes:  &someRss,
+				UserTimeMs:   &someMs,
+				SystemTimeMs: &someMs,
+				WallTimeMs:   &someMs,
+			},
+		},
+		runner.RunStatus{
+			RunID:    "id",
+			State:    runner.COMPLETE,
+			ExitCode: int(nonzero),
+			Usage: execer.ResourceUsage{
+				MaxRSS:     execer.Memory(someRss),
+				UserTime:   time.Duration(someMs) * time.Millisecond,
+				SystemTime: time.Duration(someMs) * time.Millisecond,
+				WallTime:   time.Duration(someMs) * time.Millisecond,
+			},
+		},
+	},
+	{
+		17,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:     worker.Status_FAILED,
+			RunId:      "id",
+			Error:      &nonemptystr,
+			ExitCode:   &zero,
+			HookError:  &nonemptystr,
+			LogRef:     &nonemptystr,
+			StdoutTail: &nonemptystr,
+			StderrTail: &nonemptystr,
+		},
+		runner.RunStatus{
+			RunID:      "id",
+			State:      runner.FAILED,
+			Error:      nonemptystr,
+			HookError:  nonemptystr,
+			LogRef:     nonemptystr,
+			StdoutTail: nonemptystr,
+			StderrTail: nonemptystr,
+		},
+	},
+	{
+		18,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs: []*worker.RunStatus{},
+			Capabilities: &worker.WorkerCapabilities{
+				Version:       &nonemptystr,
+				SnapshotType:  &deadbeefID,
+				Features:      []string{"Scoot", "Bazel"},
+				Os:            &someOS,
+				Arch:          &someArch,
+				FreeDiskBytes: &someDisk,
+			},
+		},
+		WorkerStatus{
+			Runs: []runner.RunStatus{},
+			Capabilities: &runner.Capabilities{
+				Version:       nonemptystr,
+				SnapshotType:  deadbeefID,
+				Features:      []string{"Scoot", "Bazel"},
+				OS:            someOS,
+				Arch:          someArch,
+				FreeDiskBytes: someDisk,
+			},
+		},
+	},
+	{
+		19,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs: []*worker.RunStatus{},
+			Capabilities: &worker.WorkerCapabilities{
+				Version:       &nonemptystr,
+				SnapshotType:  &deadbeefID,
+				Features:      []string{"GPU", "Scoot"},
+				Os:            &someOS,
+				Arch:          &someArch,
+				FreeDiskBytes: &someDisk,
+				Gpus: []*worker.GPUDevice{
+					&worker.GPUDevice{ID: "0", Model: &someGPUModel},
+					&worker.GPUDevice{ID: "1", Model: &someGPUModel},
+				},
+			},
+		},
+		WorkerStatus{
+			Runs: []runner.RunStatus{},
+			Capabilities: &runner.Capabilities{
+				Version:       nonemptystr,
+				SnapshotType:  deadbeefID,
+				Features:      []string{"GPU", "Scoot"},
+				OS:            someOS,
+				Arch:          someArch,
+				FreeDiskBytes: someDisk,
+				GPUs:          []runner.GPU{{ID: "0", Model: someGPUModel}, {ID: "1", Model: someGPUModel}},
+			},
+		},
+	},
+	{
+		20,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs: []*worker.RunStatus{},
+			Capabilities: &worker.WorkerCapabilities{
+				Version:       &nonemptystr,
+				SnapshotType:  &deadbeefID,
+				Features:      []string{"Scoot"},
+				Os:            &someOS,
+				Arch:          &someArch,
+				FreeDiskBytes: &someDisk,
+				MilliCpus:     &someMilliCPUs,
+				MemoryBytes:   &someMemory,
+			},
+		},
+		WorkerStatus{
+			Runs: []runner.RunStatus{},
+			Capabilities: &runner.Capabilities{
+				Version:       nonemptystr,
+				SnapshotType:  deadbeefID,
+				Features:      []string{"Scoot"},
+				OS:            someOS,
+				Arch:          someArch,
+				FreeDiskBytes: someDisk,
+				Resources:     runner.Resources{MilliCPUs: someMilliCPUs, MemoryBytes: someMemory},
+			},
+		},
+	},
+	{
+		21,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:      worker.Status_FAILED,
+			RunId:       "id",
+			Error:       &nonemptystr,
+			ExitCode:    &zero,
+			FailureKind: &requestFailure,
+		},
+		runner.RunStatus{
+			RunID:       "id",
+			State:       runner.FAILED,
+			Error:       nonemptystr,
+			FailureKind: runner.RequestFailure,
+		},
+	},
+	{
+		22,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:           worker.Status_ABORTED,
+			RunId:            "id",
+			ExitCode:         &zero,
+			GracefulShutdown: &graceful,
+		},
+		runner.RunStatus{
+			RunID:            "id",
+			State:            runner.ABORTED,
+			GracefulShutdown: true,
+		},
+	},
 }
 
 func TestTranslation(t *testing.T) {
@@ -47,6 +47,14 @@ func NewSimpleCLIClient(di dialer.Dialer) (CLIClient, error) {
 		Use:   "queryworker",
 		Short: "queries worker status",
 	})
+	c.addCmd(&queryHistoryCmd{client: &c.client}, &cobra.Command{
+		Use:   "queryhistory",
+		Short: "queries the history of finished runs",
+	})
+	c.addCmd(&debugCmd{client: &c.client}, &cobra.Command{
+		Use:   "debug",
+		Short: "changes the worker's log level, request dumping and pprof port at runtime",
+	})
 
 	return c, nil
 }
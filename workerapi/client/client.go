@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/apache/thrift/lib/go/thrift"
+	log "github.com/sirupsen/logrus"
+
 	"github.com/twitter/scoot/common/dialer"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/runners"
@@ -14,6 +17,10 @@ import (
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
+// Version of the client binary sent in Handshake, for workers to log, set at build time with:
+// go install -ldflags "-X github.com/twitter/scoot/workerapi/client.Version=<version>"
+var Version = "unknown"
+
 type Client interface {
 	// Connection funtions
 	Dial() error
@@ -21,6 +28,8 @@ type Client interface {
 
 	// Worker API Interactions
 	QueryWorker() (workerapi.WorkerStatus, error)
+	Handshake() (workerapi.Handshake, error)
+	runner.HistoryReader
 	runner.Controller
 	runner.StatusQueryNower
 	runner.LegacyStatusReader
@@ -31,6 +40,7 @@ type simpleClient struct {
 	addr         string
 	dialer       dialer.Dialer
 	workerClient *worker.WorkerClient
+	handshake    *workerapi.Handshake // Negotiated on first use, until the client is closed
 }
 
 // Create basic implementation of Client interface for interaction with Scoot worker API
@@ -63,14 +73,71 @@ func (c *simpleClient) dial() (*worker.WorkerClient, error) {
 }
 
 func (c *simpleClient) Close() error {
+	c.handshake = nil
 	if c.workerClient != nil {
-		return c.workerClient.Transport.Close()
+		err := c.workerClient.Transport.Close()
+		c.workerClient = nil
+		return err
+	}
+	return nil
+}
+
+// Negotiates the API version and features to use with the worker, once per connection.
+// Workers predating the handshake are reported as API version 0 with no optional features.
+func (c *simpleClient) Handshake() (workerapi.Handshake, error) {
+	if c.handshake != nil {
+		return *c.handshake, nil
+	}
+	workerClient, err := c.dial()
+	if err != nil {
+		return workerapi.Handshake{}, err
+	}
+
+	req := worker.NewHandshakeRequest()
+	req.ApiVersion = workerapi.APIVersion
+	req.Features = workerapi.APIFeatures
+	version := Version
+	req.Version = &version
+	resp, err := workerClient.Handshake(req)
+	var hs workerapi.Handshake
+	if ae, ok := err.(thrift.TApplicationException); ok && ae.TypeId() == thrift.UNKNOWN_METHOD {
+		// The worker closes the connection after an unknown method, so it's dialed again on next use.
+		c.Close()
+	} else if err != nil {
+		return workerapi.Handshake{}, err
+	} else {
+		hs = workerapi.ThriftHandshakeToDomain(resp)
+	}
+	log.Infof("Negotiated worker API version %d with %s, features: %v", hs.APIVersion, c.addr, hs.Features)
+	c.handshake = &hs
+	return hs, nil
+}
+
+// Returns an error naming the API feature if the worker doesn't support it.
+func (c *simpleClient) require(feature string) error {
+	hs, err := c.Handshake()
+	if err != nil {
+		return err
+	}
+	if !hs.Supports(feature) {
+		return fmt.Errorf("Worker %s doesn't support %s, its API version is %d", c.addr, feature, hs.APIVersion)
 	}
 	return nil
 }
 
 // Implements Scoot Worker API
 func (c *simpleClient) Run(cmd *runner.Command) (runner.RunStatus, error) {
+	hs, err := c.Handshake()
+	if err != nil {
+		return runner.RunStatus{}, err
+	}
+	if cmd.ExecuteRequest != nil && hs.Capabilities != nil &&
+		len(hs.Capabilities.MissingFeatures([]string{string(runner.RunTypeBazel)})) > 0 {
+		return runner.RunStatus{}, fmt.Errorf("Worker %s can't run Bazel commands, features: %v", c.addr, hs.Capabilities.Features)
+	}
+	if len(cmd.SparsePatterns) > 0 && !hs.Supports(workerapi.FeatureSparseCheckout) {
+		log.Infof("Worker %s doesn't support sparse checkouts, it will check out all of %s", c.addr, cmd.SnapshotID)
+	}
 	workerClient, err := c.dial()
 	if err != nil {
 		return runner.RunStatus{}, err
@@ -116,6 +183,23 @@ func (c *simpleClient) QueryWorker() (workerapi.WorkerStatus, error) {
 	return workerapi.ThriftWorkerStatusToDomain(status), nil
 }
 
+// Implements Scoot Worker API
+func (c *simpleClient) QueryHistory(q runner.HistoryQuery) ([]runner.RunRecord, error) {
+	if err := c.require(workerapi.FeatureRunHistory); err != nil {
+		return nil, err
+	}
+	workerClient, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := workerClient.QueryRunHistory(workerapi.DomainRunHistoryQueryToThrift(q))
+	if err != nil {
+		return nil, err
+	}
+	return workerapi.ThriftRunHistoryToDomain(history), nil
+}
+
 // Implements Scoot Worker API
 func (c *simpleClient) Status(id runner.RunID) (runner.RunStatus, runner.ServiceStatus, error) {
 	ws, err := c.QueryWorker()
@@ -126,7 +210,7 @@ func (c *simpleClient) Status(id runner.RunID) (runner.RunStatus, runner.Service
 	if ws.Error != "" {
 		svcErr = errors.New(ws.Error)
 	}
-	svc := runner.ServiceStatus{Initialized: ws.Initialized, Error: svcErr}
+	svc := runner.ServiceStatus{Initialized: ws.Initialized, Error: svcErr, Capabilities: ws.Capabilities}
 	for _, p := range ws.Runs {
 		if p.RunID == id {
 			return p, svc, nil
@@ -145,7 +229,7 @@ func (c *simpleClient) StatusAll() ([]runner.RunStatus, runner.ServiceStatus, er
 	if ws.Error != "" {
 		svcErr = errors.New(ws.Error)
 	}
-	return ws.Runs, runner.ServiceStatus{Initialized: ws.Initialized, Error: svcErr}, nil
+	return ws.Runs, runner.ServiceStatus{Initialized: ws.Initialized, Error: svcErr, Capabilities: ws.Capabilities}, nil
 }
 
 func (c *simpleClient) QueryNow(q runner.Query) ([]runner.RunStatus, runner.ServiceStatus, error) {
@@ -161,3 +245,16 @@ func (c *simpleClient) QueryNow(q runner.Query) ([]runner.RunStatus, runner.Serv
 func (c *simpleClient) Erase(run runner.RunID) error {
 	panic(fmt.Errorf("workerapi/client:Erase not yet implemented"))
 }
+
+// Changes the worker's debug settings, leaving unset fields unchanged, and returns the resulting settings.
+// settings must carry the worker's admin token.
+func (c *simpleClient) SetDebugSettings(settings *worker.DebugSettings) (*worker.DebugSettings, error) {
+	if err := c.require(workerapi.FeatureDebugSettings); err != nil {
+		return nil, err
+	}
+	workerClient, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	return workerClient.SetDebugSettings(settings)
+}
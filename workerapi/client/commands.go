@@ -1,11 +1,14 @@
 package client
 
 import (
+	"fmt"
 	log "github.com/sirupsen/logrus"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
 // Run
@@ -70,4 +73,77 @@ func (qc *queryWorkerCmd) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// QueryRunHistory
+type queryHistoryCmd struct {
+	client *simpleClient
+
+	// Flags
+	runId string
+	since time.Duration
+	limit int
+}
+
+func (hc *queryHistoryCmd) registerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&hc.runId, "id", "", "run id to query (all runs if empty)")
+	cmd.Flags().DurationVar(&hc.since, "since", 0, "only return runs that ended within this duration (0 for all)")
+	cmd.Flags().IntVar(&hc.limit, "limit", 0, "maximum number of runs to return (0 for no limit)")
+}
+
+func (hc *queryHistoryCmd) run(cmd *cobra.Command, args []string) error {
+	log.Info("Calling queryrunhistory rpc to cloud worker", args)
+
+	q := runner.HistoryQuery{RunID: runner.RunID(hc.runId), Limit: hc.limit}
+	if hc.since > 0 {
+		q.Start = time.Now().Add(-hc.since)
+	}
+	recs, err := hc.client.QueryHistory(q)
+	for _, rec := range recs {
+		log.Infof("Started: %v # Ended: %v # %v", rec.StartTime, rec.EndTime, rec.RunStatus)
+	}
+	log.Infof("Error: %v\n", err)
+	return nil
+}
+
+// SetDebugSettings
+type debugCmd struct {
+	client *simpleClient
+
+	// Flags
+	logLevel      string
+	dumpRequests  bool
+	pprofPort     int32
+	adminTokenEnv string
+}
+
+func (dc *debugCmd) registerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&dc.logLevel, "log_level", "", "log everything at this level and above (error|info|debug)")
+	cmd.Flags().BoolVar(&dc.dumpRequests, "dump_requests", false, "log the full contents of every request the worker receives")
+	cmd.Flags().Int32Var(&dc.pprofPort, "pprof_port", 0, "serve /debug/pprof on this port (0 to stop serving it)")
+	cmd.Flags().StringVar(&dc.adminTokenEnv, "admin_token_env", "SCOOT_ADMIN_TOKEN", "name of the env var holding the worker's admin token")
+}
+
+func (dc *debugCmd) run(cmd *cobra.Command, args []string) error {
+	log.Info("Calling setdebugsettings rpc to cloud worker", args)
+
+	token := os.Getenv(dc.adminTokenEnv)
+	if token == "" {
+		return fmt.Errorf("The worker's admin token must be set in %s in order to change its debug settings", dc.adminTokenEnv)
+	}
+
+	// Only send the settings given on the command line so the rest are left unchanged.
+	settings := &worker.DebugSettings{Token: &token}
+	if cmd.Flags().Changed("log_level") {
+		settings.LogLevel = &dc.logLevel
+	}
+	if cmd.Flags().Changed("dump_requests") {
+		settings.DumpRequests = &dc.dumpRequests
+	}
+	if cmd.Flags().Changed("pprof_port") {
+		settings.PprofPort = &dc.pprofPort
+	}
+	result, err := dc.client.SetDebugSettings(settings)
+	log.Infof("%v\nError: %v\n", result, err)
+	return nil
+}
+
 //TODO: implement Erase()
@@ -90,6 +90,303 @@ func (p *Status) UnmarshalText(text []byte) error {
 	return nil
 }
 
+type FailureKind int64
+
+const (
+	FailureKind_UNCLASSIFIED FailureKind = 0
+	FailureKind_NONE         FailureKind = 1
+	FailureKind_COMMAND      FailureKind = 2
+	FailureKind_INFRA        FailureKind = 3
+	FailureKind_REQUEST      FailureKind = 4
+)
+
+func (p FailureKind) String() string {
+	switch p {
+	case FailureKind_UNCLASSIFIED:
+		return "UNCLASSIFIED"
+	case FailureKind_NONE:
+		return "NONE"
+	case FailureKind_COMMAND:
+		return "COMMAND"
+	case FailureKind_INFRA:
+		return "INFRA"
+	case FailureKind_REQUEST:
+		return "REQUEST"
+	}
+	return "<UNSET>"
+}
+
+func FailureKindFromString(s string) (FailureKind, error) {
+	switch s {
+	case "UNCLASSIFIED":
+		return FailureKind_UNCLASSIFIED, nil
+	case "NONE":
+		return FailureKind_NONE, nil
+	case "COMMAND":
+		return FailureKind_COMMAND, nil
+	case "INFRA":
+		return FailureKind_INFRA, nil
+	case "REQUEST":
+		return FailureKind_REQUEST, nil
+	}
+	return FailureKind(0), fmt.Errorf("not a valid FailureKind string")
+}
+
+func FailureKindPtr(v FailureKind) *FailureKind { return &v }
+
+func (p FailureKind) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *FailureKind) UnmarshalText(text []byte) error {
+	q, err := FailureKindFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+// Attributes:
+//  - MaxRssBytes
+//  - UserTimeMs
+//  - SystemTimeMs
+//  - WallTimeMs
+type ResourceUsage struct {
+	MaxRssBytes  *int64 `thrift:"maxRssBytes,1" json:"maxRssBytes,omitempty"`
+	UserTimeMs   *int64 `thrift:"userTimeMs,2" json:"userTimeMs,omitempty"`
+	SystemTimeMs *int64 `thrift:"systemTimeMs,3" json:"systemTimeMs,omitempty"`
+	WallTimeMs   *int64 `thrift:"wallTimeMs,4" json:"wallTimeMs,omitempty"`
+}
+
+func NewResourceUsage() *ResourceUsage {
+	return &ResourceUsage{}
+}
+
+var ResourceUsage_MaxRssBytes_DEFAULT int64
+
+func (p *ResourceUsage) GetMaxRssBytes() int64 {
+	if !p.IsSetMaxRssBytes() {
+		return ResourceUsage_MaxRssBytes_DEFAULT
+	}
+	return *p.MaxRssBytes
+}
+
+var ResourceUsage_UserTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetUserTimeMs() int64 {
+	if !p.IsSetUserTimeMs() {
+		return ResourceUsage_UserTimeMs_DEFAULT
+	}
+	return *p.UserTimeMs
+}
+
+var ResourceUsage_SystemTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetSystemTimeMs() int64 {
+	if !p.IsSetSystemTimeMs() {
+		return ResourceUsage_SystemTimeMs_DEFAULT
+	}
+	return *p.SystemTimeMs
+}
+
+var ResourceUsage_WallTimeMs_DEFAULT int64
+
+func (p *ResourceUsage) GetWallTimeMs() int64 {
+	if !p.IsSetWallTimeMs() {
+		return ResourceUsage_WallTimeMs_DEFAULT
+	}
+	return *p.WallTimeMs
+}
+func (p *ResourceUsage) IsSetMaxRssBytes() bool {
+	return p.MaxRssBytes != nil
+}
+
+func (p *ResourceUsage) IsSetUserTimeMs() bool {
+	return p.UserTimeMs != nil
+}
+
+func (p *ResourceUsage) IsSetSystemTimeMs() bool {
+	return p.SystemTimeMs != nil
+}
+
+func (p *ResourceUsage) IsSetWallTimeMs() bool {
+	return p.WallTimeMs != nil
+}
+
+func (p *ResourceUsage) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MaxRssBytes = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UserTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SystemTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.WallTimeMs = &v
+	}
+	return nil
+}
+
+func (p *ResourceUsage) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ResourceUsage"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ResourceUsage) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxRssBytes() {
+		if err := oprot.WriteFieldBegin("maxRssBytes", thrift.I64, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxRssBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MaxRssBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxRssBytes (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxRssBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUserTimeMs() {
+		if err := oprot.WriteFieldBegin("userTimeMs", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:userTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.UserTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.userTimeMs (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:userTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSystemTimeMs() {
+		if err := oprot.WriteFieldBegin("systemTimeMs", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:systemTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SystemTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.systemTimeMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:systemTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWallTimeMs() {
+		if err := oprot.WriteFieldBegin("wallTimeMs", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:wallTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.WallTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.wallTimeMs (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:wallTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ResourceUsage) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ResourceUsage(%+v)", *p)
+}
+
 // Attributes:
 //  - Status
 //  - RunId
@@ -102,18 +399,32 @@ func (p *Status) UnmarshalText(text []byte) error {
 //  - TaskId
 //  - Tag
 //  - BazelResult_
+//  - Usage
+//  - HookError
+//  - LogRef
+//  - FailureKind
+//  - StdoutTail
+//  - StderrTail
+//  - GracefulShutdown
 type RunStatus struct {
-	Status       Status               `thrift:"status,1,required" json:"status"`
-	RunId        string               `thrift:"runId,2,required" json:"runId"`
-	OutUri       *string              `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri       *string              `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error        *string              `thrift:"error,5" json:"error,omitempty"`
-	ExitCode     *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId   *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
-	JobId        *string              `thrift:"jobId,8" json:"jobId,omitempty"`
-	TaskId       *string              `thrift:"taskId,9" json:"taskId,omitempty"`
-	Tag          *string              `thrift:"tag,10" json:"tag,omitempty"`
-	BazelResult_ *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	Status           Status               `thrift:"status,1,required" json:"status"`
+	RunId            string               `thrift:"runId,2,required" json:"runId"`
+	OutUri           *string              `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri           *string              `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error            *string              `thrift:"error,5" json:"error,omitempty"`
+	ExitCode         *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId       *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	JobId            *string              `thrift:"jobId,8" json:"jobId,omitempty"`
+	TaskId           *string              `thrift:"taskId,9" json:"taskId,omitempty"`
+	Tag              *string              `thrift:"tag,10" json:"tag,omitempty"`
+	BazelResult_     *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	Usage            *ResourceUsage       `thrift:"usage,12" json:"usage,omitempty"`
+	HookError        *string              `thrift:"hookError,13" json:"hookError,omitempty"`
+	LogRef           *string              `thrift:"logRef,14" json:"logRef,omitempty"`
+	FailureKind      *FailureKind         `thrift:"failureKind,15" json:"failureKind,omitempty"`
+	StdoutTail       *string              `thrift:"stdoutTail,16" json:"stdoutTail,omitempty"`
+	StderrTail       *string              `thrift:"stderrTail,17" json:"stderrTail,omitempty"`
+	GracefulShutdown *bool                `thrift:"gracefulShutdown,18" json:"gracefulShutdown,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -208,6 +519,69 @@ func (p *RunStatus) GetBazelResult_() *bazel.ActionResult_ {
 	}
 	return p.BazelResult_
 }
+
+var RunStatus_Usage_DEFAULT *ResourceUsage
+
+func (p *RunStatus) GetUsage() *ResourceUsage {
+	if !p.IsSetUsage() {
+		return RunStatus_Usage_DEFAULT
+	}
+	return p.Usage
+}
+
+var RunStatus_HookError_DEFAULT string
+
+func (p *RunStatus) GetHookError() string {
+	if !p.IsSetHookError() {
+		return RunStatus_HookError_DEFAULT
+	}
+	return *p.HookError
+}
+
+var RunStatus_LogRef_DEFAULT string
+
+func (p *RunStatus) GetLogRef() string {
+	if !p.IsSetLogRef() {
+		return RunStatus_LogRef_DEFAULT
+	}
+	return *p.LogRef
+}
+
+var RunStatus_FailureKind_DEFAULT FailureKind
+
+func (p *RunStatus) GetFailureKind() FailureKind {
+	if !p.IsSetFailureKind() {
+		return RunStatus_FailureKind_DEFAULT
+	}
+	return *p.FailureKind
+}
+
+var RunStatus_StdoutTail_DEFAULT string
+
+func (p *RunStatus) GetStdoutTail() string {
+	if !p.IsSetStdoutTail() {
+		return RunStatus_StdoutTail_DEFAULT
+	}
+	return *p.StdoutTail
+}
+
+var RunStatus_StderrTail_DEFAULT string
+
+func (p *RunStatus) GetStderrTail() string {
+	if !p.IsSetStderrTail() {
+		return RunStatus_StderrTail_DEFAULT
+	}
+	return *p.StderrTail
+}
+
+var RunStatus_GracefulShutdown_DEFAULT bool
+
+func (p *RunStatus) GetGracefulShutdown() bool {
+	if !p.IsSetGracefulShutdown() {
+		return RunStatus_GracefulShutdown_DEFAULT
+	}
+	return *p.GracefulShutdown
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -244,6 +618,34 @@ func (p *RunStatus) IsSetBazelResult_() bool {
 	return p.BazelResult_ != nil
 }
 
+func (p *RunStatus) IsSetUsage() bool {
+	return p.Usage != nil
+}
+
+func (p *RunStatus) IsSetHookError() bool {
+	return p.HookError != nil
+}
+
+func (p *RunStatus) IsSetLogRef() bool {
+	return p.LogRef != nil
+}
+
+func (p *RunStatus) IsSetFailureKind() bool {
+	return p.FailureKind != nil
+}
+
+func (p *RunStatus) IsSetStdoutTail() bool {
+	return p.StdoutTail != nil
+}
+
+func (p *RunStatus) IsSetStderrTail() bool {
+	return p.StderrTail != nil
+}
+
+func (p *RunStatus) IsSetGracefulShutdown() bool {
+	return p.GracefulShutdown != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -307,6 +709,34 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField11(iprot); err != nil {
 				return err
 			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.readField13(iprot); err != nil {
+				return err
+			}
+		case 14:
+			if err := p.readField14(iprot); err != nil {
+				return err
+			}
+		case 15:
+			if err := p.readField15(iprot); err != nil {
+				return err
+			}
+		case 16:
+			if err := p.readField16(iprot); err != nil {
+				return err
+			}
+		case 17:
+			if err := p.readField17(iprot); err != nil {
+				return err
+			}
+		case 18:
+			if err := p.readField18(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -427,18 +857,81 @@ func (p *RunStatus) readField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
+func (p *RunStatus) readField12(iprot thrift.TProtocol) error {
+	p.Usage = &ResourceUsage{}
+	if err := p.Usage.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Usage), err)
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *RunStatus) readField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		p.HookError = &v
 	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *RunStatus) readField14(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 14: ", err)
+	} else {
+		p.LogRef = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField15(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 15: ", err)
+	} else {
+		temp := FailureKind(v)
+		p.FailureKind = &temp
+	}
+	return nil
+}
+
+func (p *RunStatus) readField16(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 16: ", err)
+	} else {
+		p.StdoutTail = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField17(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 17: ", err)
+	} else {
+		p.StderrTail = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField18(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 18: ", err)
+	} else {
+		p.GracefulShutdown = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
 	}
 	if err := p.writeField4(oprot); err != nil {
 		return err
@@ -464,6 +957,27 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField11(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField14(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField15(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField16(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField17(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField18(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -634,6 +1148,111 @@ func (p *RunStatus) writeField11(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUsage() {
+		if err := oprot.WriteFieldBegin("usage", thrift.STRUCT, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:usage: ", p), err)
+		}
+		if err := p.Usage.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Usage), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:usage: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetHookError() {
+		if err := oprot.WriteFieldBegin("hookError", thrift.STRING, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:hookError: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.HookError)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.hookError (13) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:hookError: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField14(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLogRef() {
+		if err := oprot.WriteFieldBegin("logRef", thrift.STRING, 14); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 14:logRef: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.LogRef)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.logRef (14) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 14:logRef: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField15(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFailureKind() {
+		if err := oprot.WriteFieldBegin("failureKind", thrift.I32, 15); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 15:failureKind: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.FailureKind)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.failureKind (15) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 15:failureKind: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField16(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStdoutTail() {
+		if err := oprot.WriteFieldBegin("stdoutTail", thrift.STRING, 16); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 16:stdoutTail: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.StdoutTail)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.stdoutTail (16) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 16:stdoutTail: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField17(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStderrTail() {
+		if err := oprot.WriteFieldBegin("stderrTail", thrift.STRING, 17); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 17:stderrTail: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.StderrTail)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.stderrTail (17) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 17:stderrTail: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField18(oprot thrift.TProtocol) (err error) {
+	if p.IsSetGracefulShutdown() {
+		if err := oprot.WriteFieldBegin("gracefulShutdown", thrift.BOOL, 18); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 18:gracefulShutdown: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.GracefulShutdown)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.gracefulShutdown (18) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 18:gracefulShutdown: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -642,38 +1261,39 @@ func (p *RunStatus) String() string {
 }
 
 // Attributes:
-//  - Runs
-//  - Initialized
-//  - Error
-type WorkerStatus struct {
-	Runs        []*RunStatus `thrift:"runs,1,required" json:"runs"`
-	Initialized bool         `thrift:"initialized,2,required" json:"initialized"`
-	Error       string       `thrift:"error,3,required" json:"error"`
+//  - ID
+//  - Model
+type GPUDevice struct {
+	ID    string  `thrift:"id,1,required" json:"id"`
+	Model *string `thrift:"model,2" json:"model,omitempty"`
 }
 
-func NewWorkerStatus() *WorkerStatus {
-	return &WorkerStatus{}
+func NewGPUDevice() *GPUDevice {
+	return &GPUDevice{}
 }
 
-func (p *WorkerStatus) GetRuns() []*RunStatus {
-	return p.Runs
+func (p *GPUDevice) GetID() string {
+	return p.ID
 }
 
-func (p *WorkerStatus) GetInitialized() bool {
-	return p.Initialized
-}
+var GPUDevice_Model_DEFAULT string
 
-func (p *WorkerStatus) GetError() string {
-	return p.Error
+func (p *GPUDevice) GetModel() string {
+	if !p.IsSetModel() {
+		return GPUDevice_Model_DEFAULT
+	}
+	return *p.Model
 }
-func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
+func (p *GPUDevice) IsSetModel() bool {
+	return p.Model != nil
+}
+
+func (p *GPUDevice) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetRuns bool = false
-	var issetInitialized bool = false
-	var issetError bool = false
+	var issetID bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -688,17 +1308,11 @@ func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetRuns = true
+			issetID = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-			issetInitialized = true
-		case 3:
-			if err := p.readField3(iprot); err != nil {
-				return err
-			}
-			issetError = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -711,58 +1325,32 @@ func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetRuns {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Runs is not set"))
-	}
-	if !issetInitialized {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Initialized is not set"))
-	}
-	if !issetError {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Error is not set"))
-	}
-	return nil
-}
-
-func (p *WorkerStatus) readField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*RunStatus, 0, size)
-	p.Runs = tSlice
-	for i := 0; i < size; i++ {
-		_elem0 := &RunStatus{}
-		if err := _elem0.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
-		}
-		p.Runs = append(p.Runs, _elem0)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
 	}
 	return nil
 }
 
-func (p *WorkerStatus) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
+func (p *GPUDevice) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Initialized = v
+		p.ID = v
 	}
 	return nil
 }
 
-func (p *WorkerStatus) readField3(iprot thrift.TProtocol) error {
+func (p *GPUDevice) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
+		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Error = v
+		p.Model = &v
 	}
 	return nil
 }
 
-func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("WorkerStatus"); err != nil {
+func (p *GPUDevice) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GPUDevice"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -771,9 +1359,6 @@ func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -783,182 +1368,182 @@ func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerStatus) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("runs", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runs: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Runs)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Runs {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runs: ", p), err)
-	}
-	return err
-}
-
-func (p *WorkerStatus) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("initialized", thrift.BOOL, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:initialized: ", p), err)
+func (p *GPUDevice) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Initialized)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.initialized (2) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:initialized: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
 	}
 	return err
 }
 
-func (p *WorkerStatus) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Error)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err)
+func (p *GPUDevice) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetModel() {
+		if err := oprot.WriteFieldBegin("model", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:model: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Model)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.model (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:model: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *WorkerStatus) String() string {
+func (p *GPUDevice) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerStatus(%+v)", *p)
+	return fmt.Sprintf("GPUDevice(%+v)", *p)
 }
 
 // Attributes:
-//  - Argv
-//  - Env
-//  - SnapshotId
-//  - TimeoutMs
-//  - JobId
-//  - TaskId
-//  - Tag
-//  - BazelRequest
-type RunCommand struct {
-	Argv         []string              `thrift:"argv,1,required" json:"argv"`
-	Env          map[string]string     `thrift:"env,2" json:"env,omitempty"`
-	SnapshotId   *string               `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
-	TimeoutMs    *int32                `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
-	JobId        *string               `thrift:"jobId,5" json:"jobId,omitempty"`
-	TaskId       *string               `thrift:"taskId,6" json:"taskId,omitempty"`
-	Tag          *string               `thrift:"tag,7" json:"tag,omitempty"`
-	BazelRequest *bazel.ExecuteRequest `thrift:"bazelRequest,8" json:"bazelRequest,omitempty"`
+//  - Version
+//  - SnapshotType
+//  - Features
+//  - Os
+//  - Arch
+//  - FreeDiskBytes
+//  - Gpus
+//  - MilliCpus
+//  - MemoryBytes
+type WorkerCapabilities struct {
+	Version       *string      `thrift:"version,1" json:"version,omitempty"`
+	SnapshotType  *string      `thrift:"snapshotType,2" json:"snapshotType,omitempty"`
+	Features      []string     `thrift:"features,3" json:"features,omitempty"`
+	Os            *string      `thrift:"os,4" json:"os,omitempty"`
+	Arch          *string      `thrift:"arch,5" json:"arch,omitempty"`
+	FreeDiskBytes *int64       `thrift:"freeDiskBytes,6" json:"freeDiskBytes,omitempty"`
+	Gpus          []*GPUDevice `thrift:"gpus,7" json:"gpus,omitempty"`
+	MilliCpus     *int64       `thrift:"milliCpus,8" json:"milliCpus,omitempty"`
+	MemoryBytes   *int64       `thrift:"memoryBytes,9" json:"memoryBytes,omitempty"`
 }
 
-func NewRunCommand() *RunCommand {
-	return &RunCommand{}
+func NewWorkerCapabilities() *WorkerCapabilities {
+	return &WorkerCapabilities{}
 }
 
-func (p *RunCommand) GetArgv() []string {
-	return p.Argv
+var WorkerCapabilities_Version_DEFAULT string
+
+func (p *WorkerCapabilities) GetVersion() string {
+	if !p.IsSetVersion() {
+		return WorkerCapabilities_Version_DEFAULT
+	}
+	return *p.Version
 }
 
-var RunCommand_Env_DEFAULT map[string]string
+var WorkerCapabilities_SnapshotType_DEFAULT string
 
-func (p *RunCommand) GetEnv() map[string]string {
-	return p.Env
+func (p *WorkerCapabilities) GetSnapshotType() string {
+	if !p.IsSetSnapshotType() {
+		return WorkerCapabilities_SnapshotType_DEFAULT
+	}
+	return *p.SnapshotType
 }
 
-var RunCommand_SnapshotId_DEFAULT string
+var WorkerCapabilities_Features_DEFAULT []string
 
-func (p *RunCommand) GetSnapshotId() string {
-	if !p.IsSetSnapshotId() {
-		return RunCommand_SnapshotId_DEFAULT
+func (p *WorkerCapabilities) GetFeatures() []string {
+	return p.Features
+}
+
+var WorkerCapabilities_Os_DEFAULT string
+
+func (p *WorkerCapabilities) GetOs() string {
+	if !p.IsSetOs() {
+		return WorkerCapabilities_Os_DEFAULT
 	}
-	return *p.SnapshotId
+	return *p.Os
 }
 
-var RunCommand_TimeoutMs_DEFAULT int32
+var WorkerCapabilities_Arch_DEFAULT string
 
-func (p *RunCommand) GetTimeoutMs() int32 {
-	if !p.IsSetTimeoutMs() {
-		return RunCommand_TimeoutMs_DEFAULT
+func (p *WorkerCapabilities) GetArch() string {
+	if !p.IsSetArch() {
+		return WorkerCapabilities_Arch_DEFAULT
 	}
-	return *p.TimeoutMs
+	return *p.Arch
 }
 
-var RunCommand_JobId_DEFAULT string
+var WorkerCapabilities_FreeDiskBytes_DEFAULT int64
 
-func (p *RunCommand) GetJobId() string {
-	if !p.IsSetJobId() {
-		return RunCommand_JobId_DEFAULT
+func (p *WorkerCapabilities) GetFreeDiskBytes() int64 {
+	if !p.IsSetFreeDiskBytes() {
+		return WorkerCapabilities_FreeDiskBytes_DEFAULT
 	}
-	return *p.JobId
+	return *p.FreeDiskBytes
 }
 
-var RunCommand_TaskId_DEFAULT string
+var WorkerCapabilities_Gpus_DEFAULT []*GPUDevice
 
-func (p *RunCommand) GetTaskId() string {
-	if !p.IsSetTaskId() {
-		return RunCommand_TaskId_DEFAULT
-	}
-	return *p.TaskId
+func (p *WorkerCapabilities) GetGpus() []*GPUDevice {
+	return p.Gpus
 }
 
-var RunCommand_Tag_DEFAULT string
+var WorkerCapabilities_MilliCpus_DEFAULT int64
 
-func (p *RunCommand) GetTag() string {
-	if !p.IsSetTag() {
-		return RunCommand_Tag_DEFAULT
+func (p *WorkerCapabilities) GetMilliCpus() int64 {
+	if !p.IsSetMilliCpus() {
+		return WorkerCapabilities_MilliCpus_DEFAULT
 	}
-	return *p.Tag
+	return *p.MilliCpus
 }
 
-var RunCommand_BazelRequest_DEFAULT *bazel.ExecuteRequest
+var WorkerCapabilities_MemoryBytes_DEFAULT int64
 
-func (p *RunCommand) GetBazelRequest() *bazel.ExecuteRequest {
-	if !p.IsSetBazelRequest() {
-		return RunCommand_BazelRequest_DEFAULT
+func (p *WorkerCapabilities) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return WorkerCapabilities_MemoryBytes_DEFAULT
 	}
-	return p.BazelRequest
+	return *p.MemoryBytes
 }
-func (p *RunCommand) IsSetEnv() bool {
-	return p.Env != nil
+func (p *WorkerCapabilities) IsSetVersion() bool {
+	return p.Version != nil
 }
 
-func (p *RunCommand) IsSetSnapshotId() bool {
-	return p.SnapshotId != nil
+func (p *WorkerCapabilities) IsSetSnapshotType() bool {
+	return p.SnapshotType != nil
 }
 
-func (p *RunCommand) IsSetTimeoutMs() bool {
-	return p.TimeoutMs != nil
+func (p *WorkerCapabilities) IsSetFeatures() bool {
+	return p.Features != nil
 }
 
-func (p *RunCommand) IsSetJobId() bool {
-	return p.JobId != nil
+func (p *WorkerCapabilities) IsSetOs() bool {
+	return p.Os != nil
 }
 
-func (p *RunCommand) IsSetTaskId() bool {
-	return p.TaskId != nil
+func (p *WorkerCapabilities) IsSetArch() bool {
+	return p.Arch != nil
 }
 
-func (p *RunCommand) IsSetTag() bool {
-	return p.Tag != nil
+func (p *WorkerCapabilities) IsSetFreeDiskBytes() bool {
+	return p.FreeDiskBytes != nil
 }
 
-func (p *RunCommand) IsSetBazelRequest() bool {
-	return p.BazelRequest != nil
+func (p *WorkerCapabilities) IsSetGpus() bool {
+	return p.Gpus != nil
 }
 
-func (p *RunCommand) Read(iprot thrift.TProtocol) error {
+func (p *WorkerCapabilities) IsSetMilliCpus() bool {
+	return p.MilliCpus != nil
+}
+
+func (p *WorkerCapabilities) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *WorkerCapabilities) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetArgv bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -972,7 +1557,6 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetArgv = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
@@ -1001,6 +1585,10 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 			if err := p.readField8(iprot); err != nil {
 				return err
 			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1013,27 +1601,42 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetArgv {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Argv is not set"))
+	return nil
+}
+
+func (p *WorkerCapabilities) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Version = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) readField1(iprot thrift.TProtocol) error {
+func (p *WorkerCapabilities) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.SnapshotType = &v
+	}
+	return nil
+}
+
+func (p *WorkerCapabilities) readField3(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
 	tSlice := make([]string, 0, size)
-	p.Argv = tSlice
+	p.Features = tSlice
 	for i := 0; i < size; i++ {
-		var _elem1 string
+		var _elem0 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_elem1 = v
+			_elem0 = v
 		}
-		p.Argv = append(p.Argv, _elem1)
+		p.Features = append(p.Features, _elem0)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -1041,89 +1644,73 @@ func (p *RunCommand) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunCommand) readField2(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]string, size)
-	p.Env = tMap
-	for i := 0; i < size; i++ {
-		var _key2 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key2 = v
-		}
-		var _val3 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_val3 = v
-		}
-		p.Env[_key2] = _val3
-	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
-	}
-	return nil
-}
-
-func (p *RunCommand) readField3(iprot thrift.TProtocol) error {
+func (p *WorkerCapabilities) readField4(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.SnapshotId = &v
-	}
-	return nil
-}
-
-func (p *RunCommand) readField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 4: ", err)
 	} else {
-		p.TimeoutMs = &v
+		p.Os = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) readField5(iprot thrift.TProtocol) error {
+func (p *WorkerCapabilities) readField5(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 5: ", err)
 	} else {
-		p.JobId = &v
+		p.Arch = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) readField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *WorkerCapabilities) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 6: ", err)
 	} else {
-		p.TaskId = &v
+		p.FreeDiskBytes = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) readField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
+func (p *WorkerCapabilities) readField7(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*GPUDevice, 0, size)
+	p.Gpus = tSlice
+	for i := 0; i < size; i++ {
+		_elem1 := &GPUDevice{}
+		if err := _elem1.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem1), err)
+		}
+		p.Gpus = append(p.Gpus, _elem1)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerCapabilities) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
 	} else {
-		p.Tag = &v
+		p.MilliCpus = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) readField8(iprot thrift.TProtocol) error {
-	p.BazelRequest = &bazel.ExecuteRequest{}
-	if err := p.BazelRequest.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BazelRequest), err)
+func (p *WorkerCapabilities) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.MemoryBytes = &v
 	}
 	return nil
 }
 
-func (p *RunCommand) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunCommand"); err != nil {
+func (p *WorkerCapabilities) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WorkerCapabilities"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1150,6 +1737,9 @@ func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField8(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1159,146 +1749,2171 @@ func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunCommand) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("argv", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:argv: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRING, len(p.Argv)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Argv {
-		if err := oprot.WriteString(string(v)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+func (p *WorkerCapabilities) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:version: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:version: ", p), err)
 		}
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:argv: ", p), err)
+	return err
+}
+
+func (p *WorkerCapabilities) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotType() {
+		if err := oprot.WriteFieldBegin("snapshotType", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:snapshotType: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotType (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:snapshotType: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetEnv() {
-		if err := oprot.WriteFieldBegin("env", thrift.MAP, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:env: ", p), err)
+func (p *WorkerCapabilities) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFeatures() {
+		if err := oprot.WriteFieldBegin("features", thrift.LIST, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:features: ", p), err)
 		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Env)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Features)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
 		}
-		for k, v := range p.Env {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
+		for _, v := range p.Features {
 			if err := oprot.WriteString(string(v)); err != nil {
 				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
 			}
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:env: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:features: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSnapshotId() {
-		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:snapshotId: ", p), err)
+func (p *WorkerCapabilities) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOs() {
+		if err := oprot.WriteFieldBegin("os", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:os: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (3) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.Os)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.os (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:snapshotId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:os: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTimeoutMs() {
-		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:timeoutMs: ", p), err)
+func (p *WorkerCapabilities) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetArch() {
+		if err := oprot.WriteFieldBegin("arch", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:arch: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.TimeoutMs)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (4) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.Arch)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.arch (5) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:timeoutMs: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:arch: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetJobId() {
-		if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:jobId: ", p), err)
+func (p *WorkerCapabilities) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFreeDiskBytes() {
+		if err := oprot.WriteFieldBegin("freeDiskBytes", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:freeDiskBytes: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.JobId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.jobId (5) field write error: ", p), err)
+		if err := oprot.WriteI64(int64(*p.FreeDiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.freeDiskBytes (6) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:jobId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:freeDiskBytes: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskId() {
-		if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:taskId: ", p), err)
+func (p *WorkerCapabilities) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetGpus() {
+		if err := oprot.WriteFieldBegin("gpus", thrift.LIST, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:gpus: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.TaskId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.taskId (6) field write error: ", p), err)
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Gpus)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Gpus {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:taskId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:gpus: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTag() {
-		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:tag: ", p), err)
+func (p *WorkerCapabilities) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMilliCpus() {
+		if err := oprot.WriteFieldBegin("milliCpus", thrift.I64, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:milliCpus: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.Tag)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.tag (7) field write error: ", p), err)
+		if err := oprot.WriteI64(int64(*p.MilliCpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.milliCpus (8) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:tag: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:milliCpus: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetBazelRequest() {
-		if err := oprot.WriteFieldBegin("bazelRequest", thrift.STRUCT, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:bazelRequest: ", p), err)
+func (p *WorkerCapabilities) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:memoryBytes: ", p), err)
 		}
-		if err := p.BazelRequest.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BazelRequest), err)
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (9) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:bazelRequest: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:memoryBytes: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunCommand) String() string {
+func (p *WorkerCapabilities) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("RunCommand(%+v)", *p)
+	return fmt.Sprintf("WorkerCapabilities(%+v)", *p)
+}
+
+// Attributes:
+//  - Runs
+//  - Initialized
+//  - Error
+//  - Capabilities
+type WorkerStatus struct {
+	Runs         []*RunStatus        `thrift:"runs,1,required" json:"runs"`
+	Initialized  bool                `thrift:"initialized,2,required" json:"initialized"`
+	Error        string              `thrift:"error,3,required" json:"error"`
+	Capabilities *WorkerCapabilities `thrift:"capabilities,4" json:"capabilities,omitempty"`
+}
+
+func NewWorkerStatus() *WorkerStatus {
+	return &WorkerStatus{}
+}
+
+func (p *WorkerStatus) GetRuns() []*RunStatus {
+	return p.Runs
+}
+
+func (p *WorkerStatus) GetInitialized() bool {
+	return p.Initialized
+}
+
+func (p *WorkerStatus) GetError() string {
+	return p.Error
+}
+
+var WorkerStatus_Capabilities_DEFAULT *WorkerCapabilities
+
+func (p *WorkerStatus) GetCapabilities() *WorkerCapabilities {
+	if !p.IsSetCapabilities() {
+		return WorkerStatus_Capabilities_DEFAULT
+	}
+	return p.Capabilities
+}
+func (p *WorkerStatus) IsSetCapabilities() bool {
+	return p.Capabilities != nil
+}
+
+func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetRuns bool = false
+	var issetInitialized bool = false
+	var issetError bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetRuns = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetInitialized = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetError = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetRuns {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Runs is not set"))
+	}
+	if !issetInitialized {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Initialized is not set"))
+	}
+	if !issetError {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Error is not set"))
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*RunStatus, 0, size)
+	p.Runs = tSlice
+	for i := 0; i < size; i++ {
+		_elem2 := &RunStatus{}
+		if err := _elem2.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem2), err)
+		}
+		p.Runs = append(p.Runs, _elem2)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Initialized = v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Error = v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField4(iprot thrift.TProtocol) error {
+	p.Capabilities = &WorkerCapabilities{}
+	if err := p.Capabilities.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Capabilities), err)
+	}
+	return nil
+}
+
+func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WorkerStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("runs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Runs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Runs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runs: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("initialized", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:initialized: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Initialized)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.initialized (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:initialized: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Error)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCapabilities() {
+		if err := oprot.WriteFieldBegin("capabilities", thrift.STRUCT, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:capabilities: ", p), err)
+		}
+		if err := p.Capabilities.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Capabilities), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:capabilities: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerStatus(%+v)", *p)
+}
+
+// Attributes:
+//  - Argv
+//  - Env
+//  - SnapshotId
+//  - TimeoutMs
+//  - JobId
+//  - TaskId
+//  - Tag
+//  - BazelRequest
+//  - RetentionMs
+//  - SparsePatterns
+type RunCommand struct {
+	Argv           []string              `thrift:"argv,1,required" json:"argv"`
+	Env            map[string]string     `thrift:"env,2" json:"env,omitempty"`
+	SnapshotId     *string               `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
+	TimeoutMs      *int32                `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	JobId          *string               `thrift:"jobId,5" json:"jobId,omitempty"`
+	TaskId         *string               `thrift:"taskId,6" json:"taskId,omitempty"`
+	Tag            *string               `thrift:"tag,7" json:"tag,omitempty"`
+	BazelRequest   *bazel.ExecuteRequest `thrift:"bazelRequest,8" json:"bazelRequest,omitempty"`
+	RetentionMs    *int64                `thrift:"retentionMs,9" json:"retentionMs,omitempty"`
+	SparsePatterns []string              `thrift:"sparsePatterns,10" json:"sparsePatterns,omitempty"`
+}
+
+func NewRunCommand() *RunCommand {
+	return &RunCommand{}
+}
+
+func (p *RunCommand) GetArgv() []string {
+	return p.Argv
+}
+
+var RunCommand_Env_DEFAULT map[string]string
+
+func (p *RunCommand) GetEnv() map[string]string {
+	return p.Env
+}
+
+var RunCommand_SnapshotId_DEFAULT string
+
+func (p *RunCommand) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return RunCommand_SnapshotId_DEFAULT
+	}
+	return *p.SnapshotId
+}
+
+var RunCommand_TimeoutMs_DEFAULT int32
+
+func (p *RunCommand) GetTimeoutMs() int32 {
+	if !p.IsSetTimeoutMs() {
+		return RunCommand_TimeoutMs_DEFAULT
+	}
+	return *p.TimeoutMs
+}
+
+var RunCommand_JobId_DEFAULT string
+
+func (p *RunCommand) GetJobId() string {
+	if !p.IsSetJobId() {
+		return RunCommand_JobId_DEFAULT
+	}
+	return *p.JobId
+}
+
+var RunCommand_TaskId_DEFAULT string
+
+func (p *RunCommand) GetTaskId() string {
+	if !p.IsSetTaskId() {
+		return RunCommand_TaskId_DEFAULT
+	}
+	return *p.TaskId
+}
+
+var RunCommand_Tag_DEFAULT string
+
+func (p *RunCommand) GetTag() string {
+	if !p.IsSetTag() {
+		return RunCommand_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var RunCommand_BazelRequest_DEFAULT *bazel.ExecuteRequest
+
+func (p *RunCommand) GetBazelRequest() *bazel.ExecuteRequest {
+	if !p.IsSetBazelRequest() {
+		return RunCommand_BazelRequest_DEFAULT
+	}
+	return p.BazelRequest
+}
+
+var RunCommand_RetentionMs_DEFAULT int64
+
+func (p *RunCommand) GetRetentionMs() int64 {
+	if !p.IsSetRetentionMs() {
+		return RunCommand_RetentionMs_DEFAULT
+	}
+	return *p.RetentionMs
+}
+
+var RunCommand_SparsePatterns_DEFAULT []string
+
+func (p *RunCommand) GetSparsePatterns() []string {
+	return p.SparsePatterns
+}
+func (p *RunCommand) IsSetEnv() bool {
+	return p.Env != nil
+}
+
+func (p *RunCommand) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
+}
+
+func (p *RunCommand) IsSetTimeoutMs() bool {
+	return p.TimeoutMs != nil
+}
+
+func (p *RunCommand) IsSetJobId() bool {
+	return p.JobId != nil
+}
+
+func (p *RunCommand) IsSetTaskId() bool {
+	return p.TaskId != nil
+}
+
+func (p *RunCommand) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *RunCommand) IsSetBazelRequest() bool {
+	return p.BazelRequest != nil
+}
+
+func (p *RunCommand) IsSetRetentionMs() bool {
+	return p.RetentionMs != nil
+}
+
+func (p *RunCommand) IsSetSparsePatterns() bool {
+	return p.SparsePatterns != nil
+}
+
+func (p *RunCommand) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetArgv bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetArgv = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetArgv {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Argv is not set"))
+	}
+	return nil
+}
+
+func (p *RunCommand) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Argv = tSlice
+	for i := 0; i < size; i++ {
+		var _elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem3 = v
+		}
+		p.Argv = append(p.Argv, _elem3)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RunCommand) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Env = tMap
+	for i := 0; i < size; i++ {
+		var _key4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key4 = v
+		}
+		var _val5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val5 = v
+		}
+		p.Env[_key4] = _val5
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *RunCommand) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SnapshotId = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.TimeoutMs = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.JobId = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.TaskId = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField8(iprot thrift.TProtocol) error {
+	p.BazelRequest = &bazel.ExecuteRequest{}
+	if err := p.BazelRequest.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BazelRequest), err)
+	}
+	return nil
+}
+
+func (p *RunCommand) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.RetentionMs = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField10(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.SparsePatterns = tSlice
+	for i := 0; i < size; i++ {
+		var _elem7 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem7 = v
+		}
+		p.SparsePatterns = append(p.SparsePatterns, _elem7)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RunCommand) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunCommand"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunCommand) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("argv", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:argv: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Argv)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Argv {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:argv: ", p), err)
+	}
+	return err
+}
+
+func (p *RunCommand) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEnv() {
+		if err := oprot.WriteFieldBegin("env", thrift.MAP, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:env: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Env)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Env {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:env: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:snapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:snapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeoutMs() {
+		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:timeoutMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.TimeoutMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:timeoutMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobId() {
+		if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:jobId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.JobId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobId (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:jobId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskId() {
+		if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:taskId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.TaskId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.taskId (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:taskId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBazelRequest() {
+		if err := oprot.WriteFieldBegin("bazelRequest", thrift.STRUCT, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:bazelRequest: ", p), err)
+		}
+		if err := p.BazelRequest.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BazelRequest), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:bazelRequest: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetentionMs() {
+		if err := oprot.WriteFieldBegin("retentionMs", thrift.I64, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:retentionMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.RetentionMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retentionMs (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:retentionMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSparsePatterns() {
+		if err := oprot.WriteFieldBegin("sparsePatterns", thrift.LIST, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:sparsePatterns: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.SparsePatterns)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.SparsePatterns {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:sparsePatterns: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunCommand(%+v)", *p)
+}
+
+// Attributes:
+//  - RunId
+//  - StartTimeMs
+//  - EndTimeMs
+//  - Limit
+type RunHistoryQuery struct {
+	RunId       *string `thrift:"runId,1" json:"runId,omitempty"`
+	StartTimeMs *int64  `thrift:"startTimeMs,2" json:"startTimeMs,omitempty"`
+	EndTimeMs   *int64  `thrift:"endTimeMs,3" json:"endTimeMs,omitempty"`
+	Limit       *int32  `thrift:"limit,4" json:"limit,omitempty"`
+}
+
+func NewRunHistoryQuery() *RunHistoryQuery {
+	return &RunHistoryQuery{}
+}
+
+var RunHistoryQuery_RunId_DEFAULT string
+
+func (p *RunHistoryQuery) GetRunId() string {
+	if !p.IsSetRunId() {
+		return RunHistoryQuery_RunId_DEFAULT
+	}
+	return *p.RunId
+}
+
+var RunHistoryQuery_StartTimeMs_DEFAULT int64
+
+func (p *RunHistoryQuery) GetStartTimeMs() int64 {
+	if !p.IsSetStartTimeMs() {
+		return RunHistoryQuery_StartTimeMs_DEFAULT
+	}
+	return *p.StartTimeMs
+}
+
+var RunHistoryQuery_EndTimeMs_DEFAULT int64
+
+func (p *RunHistoryQuery) GetEndTimeMs() int64 {
+	if !p.IsSetEndTimeMs() {
+		return RunHistoryQuery_EndTimeMs_DEFAULT
+	}
+	return *p.EndTimeMs
+}
+
+var RunHistoryQuery_Limit_DEFAULT int32
+
+func (p *RunHistoryQuery) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return RunHistoryQuery_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *RunHistoryQuery) IsSetRunId() bool {
+	return p.RunId != nil
+}
+
+func (p *RunHistoryQuery) IsSetStartTimeMs() bool {
+	return p.StartTimeMs != nil
+}
+
+func (p *RunHistoryQuery) IsSetEndTimeMs() bool {
+	return p.EndTimeMs != nil
+}
+
+func (p *RunHistoryQuery) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *RunHistoryQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.RunId = &v
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.StartTimeMs = &v
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.EndTimeMs = &v
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunHistoryQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunHistoryQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRunId() {
+		if err := oprot.WriteFieldBegin("runId", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.RunId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.runId (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunHistoryQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStartTimeMs() {
+		if err := oprot.WriteFieldBegin("startTimeMs", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:startTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.StartTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.startTimeMs (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:startTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunHistoryQuery) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEndTimeMs() {
+		if err := oprot.WriteFieldBegin("endTimeMs", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:endTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.EndTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.endTimeMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:endTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunHistoryQuery) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:limit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunHistoryQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunHistoryQuery(%+v)", *p)
+}
+
+// Attributes:
+//  - Status
+//  - StartTimeMs
+//  - EndTimeMs
+type RunHistoryRecord struct {
+	Status      *RunStatus `thrift:"status,1,required" json:"status"`
+	StartTimeMs int64      `thrift:"startTimeMs,2,required" json:"startTimeMs"`
+	EndTimeMs   int64      `thrift:"endTimeMs,3,required" json:"endTimeMs"`
+}
+
+func NewRunHistoryRecord() *RunHistoryRecord {
+	return &RunHistoryRecord{}
+}
+
+var RunHistoryRecord_Status_DEFAULT *RunStatus
+
+func (p *RunHistoryRecord) GetStatus() *RunStatus {
+	if !p.IsSetStatus() {
+		return RunHistoryRecord_Status_DEFAULT
+	}
+	return p.Status
+}
+
+func (p *RunHistoryRecord) GetStartTimeMs() int64 {
+	return p.StartTimeMs
+}
+
+func (p *RunHistoryRecord) GetEndTimeMs() int64 {
+	return p.EndTimeMs
+}
+func (p *RunHistoryRecord) IsSetStatus() bool {
+	return p.Status != nil
+}
+
+func (p *RunHistoryRecord) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetStatus bool = false
+	var issetStartTimeMs bool = false
+	var issetEndTimeMs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStartTimeMs = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetEndTimeMs = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetStartTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field StartTimeMs is not set"))
+	}
+	if !issetEndTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field EndTimeMs is not set"))
+	}
+	return nil
+}
+
+func (p *RunHistoryRecord) readField1(iprot thrift.TProtocol) error {
+	p.Status = &RunStatus{}
+	if err := p.Status.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Status), err)
+	}
+	return nil
+}
+
+func (p *RunHistoryRecord) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.StartTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunHistoryRecord) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.EndTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunHistoryRecord) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunHistoryRecord"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunHistoryRecord) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:status: ", p), err)
+	}
+	if err := p.Status.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Status), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:status: ", p), err)
+	}
+	return err
+}
+
+func (p *RunHistoryRecord) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("startTimeMs", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:startTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.StartTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.startTimeMs (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:startTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunHistoryRecord) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("endTimeMs", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:endTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.EndTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.endTimeMs (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:endTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunHistoryRecord) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunHistoryRecord(%+v)", *p)
+}
+
+// Attributes:
+//  - Runs
+type RunHistory struct {
+	Runs []*RunHistoryRecord `thrift:"runs,1,required" json:"runs"`
+}
+
+func NewRunHistory() *RunHistory {
+	return &RunHistory{}
+}
+
+func (p *RunHistory) GetRuns() []*RunHistoryRecord {
+	return p.Runs
+}
+func (p *RunHistory) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetRuns bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetRuns = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetRuns {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Runs is not set"))
+	}
+	return nil
+}
+
+func (p *RunHistory) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*RunHistoryRecord, 0, size)
+	p.Runs = tSlice
+	for i := 0; i < size; i++ {
+		_elem6 := &RunHistoryRecord{}
+		if err := _elem6.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem6), err)
+		}
+		p.Runs = append(p.Runs, _elem6)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RunHistory) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunHistory"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunHistory) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("runs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Runs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Runs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunHistory) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunHistory(%+v)", *p)
+}
+
+// Attributes:
+//  - LogLevel
+//  - DumpRequests
+//  - PprofPort
+//  - Token
+type DebugSettings struct {
+	LogLevel     *string `thrift:"logLevel,1" json:"logLevel,omitempty"`
+	DumpRequests *bool   `thrift:"dumpRequests,2" json:"dumpRequests,omitempty"`
+	PprofPort    *int32  `thrift:"pprofPort,3" json:"pprofPort,omitempty"`
+	Token        *string `thrift:"token,4" json:"token,omitempty"`
+}
+
+func NewDebugSettings() *DebugSettings {
+	return &DebugSettings{}
+}
+
+var DebugSettings_LogLevel_DEFAULT string
+
+func (p *DebugSettings) GetLogLevel() string {
+	if !p.IsSetLogLevel() {
+		return DebugSettings_LogLevel_DEFAULT
+	}
+	return *p.LogLevel
+}
+
+var DebugSettings_DumpRequests_DEFAULT bool
+
+func (p *DebugSettings) GetDumpRequests() bool {
+	if !p.IsSetDumpRequests() {
+		return DebugSettings_DumpRequests_DEFAULT
+	}
+	return *p.DumpRequests
+}
+
+var DebugSettings_PprofPort_DEFAULT int32
+
+func (p *DebugSettings) GetPprofPort() int32 {
+	if !p.IsSetPprofPort() {
+		return DebugSettings_PprofPort_DEFAULT
+	}
+	return *p.PprofPort
+}
+
+var DebugSettings_Token_DEFAULT string
+
+func (p *DebugSettings) GetToken() string {
+	if !p.IsSetToken() {
+		return DebugSettings_Token_DEFAULT
+	}
+	return *p.Token
+}
+func (p *DebugSettings) IsSetLogLevel() bool {
+	return p.LogLevel != nil
+}
+
+func (p *DebugSettings) IsSetDumpRequests() bool {
+	return p.DumpRequests != nil
+}
+
+func (p *DebugSettings) IsSetPprofPort() bool {
+	return p.PprofPort != nil
+}
+
+func (p *DebugSettings) IsSetToken() bool {
+	return p.Token != nil
+}
+
+func (p *DebugSettings) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DebugSettings) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.LogLevel = &v
+	}
+	return nil
+}
+
+func (p *DebugSettings) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.DumpRequests = &v
+	}
+	return nil
+}
+
+func (p *DebugSettings) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.PprofPort = &v
+	}
+	return nil
+}
+
+func (p *DebugSettings) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Token = &v
+	}
+	return nil
+}
+
+func (p *DebugSettings) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugSettings"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugSettings) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLogLevel() {
+		if err := oprot.WriteFieldBegin("logLevel", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:logLevel: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.LogLevel)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.logLevel (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:logLevel: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugSettings) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDumpRequests() {
+		if err := oprot.WriteFieldBegin("dumpRequests", thrift.BOOL, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:dumpRequests: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.DumpRequests)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.dumpRequests (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:dumpRequests: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugSettings) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPprofPort() {
+		if err := oprot.WriteFieldBegin("pprofPort", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:pprofPort: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.PprofPort)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.pprofPort (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:pprofPort: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugSettings) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetToken() {
+		if err := oprot.WriteFieldBegin("token", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:token: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Token)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.token (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:token: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugSettings) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugSettings(%+v)", *p)
+}
+
+// Attributes:
+//  - ApiVersion
+//  - Features
+//  - Version
+type HandshakeRequest struct {
+	ApiVersion int32    `thrift:"apiVersion,1,required" json:"apiVersion"`
+	Features   []string `thrift:"features,2" json:"features,omitempty"`
+	Version    *string  `thrift:"version,3" json:"version,omitempty"`
+}
+
+func NewHandshakeRequest() *HandshakeRequest {
+	return &HandshakeRequest{}
+}
+
+func (p *HandshakeRequest) GetApiVersion() int32 {
+	return p.ApiVersion
+}
+
+var HandshakeRequest_Features_DEFAULT []string
+
+func (p *HandshakeRequest) GetFeatures() []string {
+	return p.Features
+}
+
+var HandshakeRequest_Version_DEFAULT string
+
+func (p *HandshakeRequest) GetVersion() string {
+	if !p.IsSetVersion() {
+		return HandshakeRequest_Version_DEFAULT
+	}
+	return *p.Version
+}
+func (p *HandshakeRequest) IsSetFeatures() bool {
+	return p.Features != nil
+}
+
+func (p *HandshakeRequest) IsSetVersion() bool {
+	return p.Version != nil
+}
+
+func (p *HandshakeRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetApiVersion bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetApiVersion = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetApiVersion {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ApiVersion is not set"))
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ApiVersion = v
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Features = tSlice
+	for i := 0; i < size; i++ {
+		var _elem8 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem8 = v
+		}
+		p.Features = append(p.Features, _elem8)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Version = &v
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HandshakeRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("apiVersion", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:apiVersion: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.ApiVersion)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.apiVersion (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:apiVersion: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFeatures() {
+		if err := oprot.WriteFieldBegin("features", thrift.LIST, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:features: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Features)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Features {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:features: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HandshakeRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:version: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:version: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HandshakeRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HandshakeRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - ApiVersion
+//  - Features
+//  - Capabilities
+type HandshakeResponse struct {
+	ApiVersion   int32               `thrift:"apiVersion,1,required" json:"apiVersion"`
+	Features     []string            `thrift:"features,2" json:"features,omitempty"`
+	Capabilities *WorkerCapabilities `thrift:"capabilities,3" json:"capabilities,omitempty"`
+}
+
+func NewHandshakeResponse() *HandshakeResponse {
+	return &HandshakeResponse{}
+}
+
+func (p *HandshakeResponse) GetApiVersion() int32 {
+	return p.ApiVersion
+}
+
+var HandshakeResponse_Features_DEFAULT []string
+
+func (p *HandshakeResponse) GetFeatures() []string {
+	return p.Features
+}
+
+var HandshakeResponse_Capabilities_DEFAULT *WorkerCapabilities
+
+func (p *HandshakeResponse) GetCapabilities() *WorkerCapabilities {
+	if !p.IsSetCapabilities() {
+		return HandshakeResponse_Capabilities_DEFAULT
+	}
+	return p.Capabilities
+}
+func (p *HandshakeResponse) IsSetFeatures() bool {
+	return p.Features != nil
+}
+
+func (p *HandshakeResponse) IsSetCapabilities() bool {
+	return p.Capabilities != nil
+}
+
+func (p *HandshakeResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetApiVersion bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetApiVersion = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetApiVersion {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ApiVersion is not set"))
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ApiVersion = v
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Features = tSlice
+	for i := 0; i < size; i++ {
+		var _elem9 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem9 = v
+		}
+		p.Features = append(p.Features, _elem9)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) readField3(iprot thrift.TProtocol) error {
+	p.Capabilities = &WorkerCapabilities{}
+	if err := p.Capabilities.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Capabilities), err)
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HandshakeResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("apiVersion", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:apiVersion: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.ApiVersion)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.apiVersion (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:apiVersion: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFeatures() {
+		if err := oprot.WriteFieldBegin("features", thrift.LIST, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:features: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Features)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Features {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:features: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HandshakeResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCapabilities() {
+		if err := oprot.WriteFieldBegin("capabilities", thrift.STRUCT, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:capabilities: ", p), err)
+		}
+		if err := p.Capabilities.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Capabilities), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:capabilities: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *HandshakeResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HandshakeResponse(%+v)", *p)
 }
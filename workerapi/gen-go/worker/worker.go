@@ -28,6 +28,19 @@ type Worker interface {
 	// Parameters:
 	//  - RunId
 	Erase(runId string) (err error)
+	// Parameters:
+	//  - Query
+	QueryRunHistory(query *RunHistoryQuery) (r *RunHistory, err error)
+	// Parameters:
+	//  - Level
+	//  - Token
+	SetLogLevel(level string, token string) (r *DebugSettings, err error)
+	// Parameters:
+	//  - Settings
+	SetDebugSettings(settings *DebugSettings) (r *DebugSettings, err error)
+	// Parameters:
+	//  - Req
+	Handshake(req *HandshakeRequest) (r *HandshakeResponse, err error)
 }
 
 type WorkerClient struct {
@@ -102,16 +115,16 @@ func (p *WorkerClient) recvQueryWorker() (value *WorkerStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error4 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error5 error
-		error5, err = error4.Read(iprot)
+		error7 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error8 error
+		error8, err = error7.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error5
+		err = error8
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -179,16 +192,16 @@ func (p *WorkerClient) recvRun() (value *RunStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error6 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error7 error
-		error7, err = error6.Read(iprot)
+		error9 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error10 error
+		error10, err = error9.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error7
+		err = error10
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -256,16 +269,16 @@ func (p *WorkerClient) recvAbort() (value *RunStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error9 error
-		error9, err = error8.Read(iprot)
+		error11 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error12 error
+		error12, err = error11.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error9
+		err = error12
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -333,16 +346,16 @@ func (p *WorkerClient) recvErase() (err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error11 error
-		error11, err = error10.Read(iprot)
+		error13 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error14 error
+		error14, err = error13.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error11
+		err = error14
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -359,6 +372,316 @@ func (p *WorkerClient) recvErase() (err error) {
 	return
 }
 
+// Parameters:
+//  - Query
+func (p *WorkerClient) QueryRunHistory(query *RunHistoryQuery) (r *RunHistory, err error) {
+	if err = p.sendQueryRunHistory(query); err != nil {
+		return
+	}
+	return p.recvQueryRunHistory()
+}
+
+func (p *WorkerClient) sendQueryRunHistory(query *RunHistoryQuery) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("QueryRunHistory", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := WorkerQueryRunHistoryArgs{
+		Query: query,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *WorkerClient) recvQueryRunHistory() (value *RunHistory, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "QueryRunHistory" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "QueryRunHistory failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "QueryRunHistory failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error15 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error16 error
+		error16, err = error15.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error16
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "QueryRunHistory failed: invalid message type")
+		return
+	}
+	result := WorkerQueryRunHistoryResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Level
+//  - Token
+func (p *WorkerClient) SetLogLevel(level string, token string) (r *DebugSettings, err error) {
+	if err = p.sendSetLogLevel(level, token); err != nil {
+		return
+	}
+	return p.recvSetLogLevel()
+}
+
+func (p *WorkerClient) sendSetLogLevel(level string, token string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("SetLogLevel", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := WorkerSetLogLevelArgs{
+		Level: level,
+		Token: token,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *WorkerClient) recvSetLogLevel() (value *DebugSettings, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "SetLogLevel" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SetLogLevel failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SetLogLevel failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error17 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error18 error
+		error18, err = error17.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error18
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SetLogLevel failed: invalid message type")
+		return
+	}
+	result := WorkerSetLogLevelResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Settings
+func (p *WorkerClient) SetDebugSettings(settings *DebugSettings) (r *DebugSettings, err error) {
+	if err = p.sendSetDebugSettings(settings); err != nil {
+		return
+	}
+	return p.recvSetDebugSettings()
+}
+
+func (p *WorkerClient) sendSetDebugSettings(settings *DebugSettings) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("SetDebugSettings", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := WorkerSetDebugSettingsArgs{
+		Settings: settings,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *WorkerClient) recvSetDebugSettings() (value *DebugSettings, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "SetDebugSettings" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SetDebugSettings failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SetDebugSettings failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error19 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error20 error
+		error20, err = error19.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error20
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SetDebugSettings failed: invalid message type")
+		return
+	}
+	result := WorkerSetDebugSettingsResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *WorkerClient) Handshake(req *HandshakeRequest) (r *HandshakeResponse, err error) {
+	if err = p.sendHandshake(req); err != nil {
+		return
+	}
+	return p.recvHandshake()
+}
+
+func (p *WorkerClient) sendHandshake(req *HandshakeRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Handshake", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := WorkerHandshakeArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *WorkerClient) recvHandshake() (value *HandshakeResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Handshake" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Handshake failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Handshake failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error23 error
+		error23, err = error22.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error23
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Handshake failed: invalid message type")
+		return
+	}
+	result := WorkerHandshakeResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 type WorkerProcessor struct {
 	processorMap map[string]thrift.TProcessorFunction
 	handler      Worker
@@ -379,12 +702,16 @@ func (p *WorkerProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
 
 func NewWorkerProcessor(handler Worker) *WorkerProcessor {
 
-	self12 := &WorkerProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self12.processorMap["QueryWorker"] = &workerProcessorQueryWorker{handler: handler}
-	self12.processorMap["Run"] = &workerProcessorRun{handler: handler}
-	self12.processorMap["Abort"] = &workerProcessorAbort{handler: handler}
-	self12.processorMap["Erase"] = &workerProcessorErase{handler: handler}
-	return self12
+	self21 := &WorkerProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self21.processorMap["QueryWorker"] = &workerProcessorQueryWorker{handler: handler}
+	self21.processorMap["Run"] = &workerProcessorRun{handler: handler}
+	self21.processorMap["Abort"] = &workerProcessorAbort{handler: handler}
+	self21.processorMap["Erase"] = &workerProcessorErase{handler: handler}
+	self21.processorMap["QueryRunHistory"] = &workerProcessorQueryRunHistory{handler: handler}
+	self21.processorMap["SetLogLevel"] = &workerProcessorSetLogLevel{handler: handler}
+	self21.processorMap["SetDebugSettings"] = &workerProcessorSetDebugSettings{handler: handler}
+	self21.processorMap["Handshake"] = &workerProcessorHandshake{handler: handler}
+	return self21
 }
 
 func (p *WorkerProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
@@ -397,12 +724,12 @@ func (p *WorkerProcessor) Process(iprot, oprot thrift.TProtocol) (success bool,
 	}
 	iprot.Skip(thrift.STRUCT)
 	iprot.ReadMessageEnd()
-	x13 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	x22 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
 	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x13.Write(oprot)
+	x22.Write(oprot)
 	oprot.WriteMessageEnd()
 	oprot.Flush()
-	return false, x13
+	return false, x22
 
 }
 
@@ -589,22 +916,919 @@ func (p *workerProcessorErase) Process(seqId int32, iprot, oprot thrift.TProtoco
 	if err2 = oprot.Flush(); err == nil && err2 != nil {
 		err = err2
 	}
-	if err != nil {
-		return
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type workerProcessorQueryRunHistory struct {
+	handler Worker
+}
+
+func (p *workerProcessorQueryRunHistory) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := WorkerQueryRunHistoryArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("QueryRunHistory", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := WorkerQueryRunHistoryResult{}
+	var retval *RunHistory
+	var err2 error
+	if retval, err2 = p.handler.QueryRunHistory(args.Query); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing QueryRunHistory: "+err2.Error())
+		oprot.WriteMessageBegin("QueryRunHistory", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("QueryRunHistory", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type workerProcessorSetLogLevel struct {
+	handler Worker
+}
+
+func (p *workerProcessorSetLogLevel) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := WorkerSetLogLevelArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("SetLogLevel", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := WorkerSetLogLevelResult{}
+	var retval *DebugSettings
+	var err2 error
+	if retval, err2 = p.handler.SetLogLevel(args.Level, args.Token); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SetLogLevel: "+err2.Error())
+		oprot.WriteMessageBegin("SetLogLevel", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("SetLogLevel", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type workerProcessorSetDebugSettings struct {
+	handler Worker
+}
+
+func (p *workerProcessorSetDebugSettings) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := WorkerSetDebugSettingsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("SetDebugSettings", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := WorkerSetDebugSettingsResult{}
+	var retval *DebugSettings
+	var err2 error
+	if retval, err2 = p.handler.SetDebugSettings(args.Settings); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SetDebugSettings: "+err2.Error())
+		oprot.WriteMessageBegin("SetDebugSettings", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("SetDebugSettings", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type workerProcessorHandshake struct {
+	handler Worker
+}
+
+func (p *workerProcessorHandshake) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := WorkerHandshakeArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Handshake", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := WorkerHandshakeResult{}
+	var retval *HandshakeResponse
+	var err2 error
+	if retval, err2 = p.handler.Handshake(args.Req); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Handshake: "+err2.Error())
+		oprot.WriteMessageBegin("Handshake", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Handshake", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+type WorkerQueryWorkerArgs struct {
+}
+
+func NewWorkerQueryWorkerArgs() *WorkerQueryWorkerArgs {
+	return &WorkerQueryWorkerArgs{}
+}
+
+func (p *WorkerQueryWorkerArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryWorkerArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryWorker_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryWorkerArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerQueryWorkerArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type WorkerQueryWorkerResult struct {
+	Success *WorkerStatus `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewWorkerQueryWorkerResult() *WorkerQueryWorkerResult {
+	return &WorkerQueryWorkerResult{}
+}
+
+var WorkerQueryWorkerResult_Success_DEFAULT *WorkerStatus
+
+func (p *WorkerQueryWorkerResult) GetSuccess() *WorkerStatus {
+	if !p.IsSetSuccess() {
+		return WorkerQueryWorkerResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *WorkerQueryWorkerResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *WorkerQueryWorkerResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryWorkerResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &WorkerStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryWorkerResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryWorker_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryWorkerResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerQueryWorkerResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerQueryWorkerResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Cmd
+type WorkerRunArgs struct {
+	Cmd *RunCommand `thrift:"cmd,1" json:"cmd"`
+}
+
+func NewWorkerRunArgs() *WorkerRunArgs {
+	return &WorkerRunArgs{}
+}
+
+var WorkerRunArgs_Cmd_DEFAULT *RunCommand
+
+func (p *WorkerRunArgs) GetCmd() *RunCommand {
+	if !p.IsSetCmd() {
+		return WorkerRunArgs_Cmd_DEFAULT
+	}
+	return p.Cmd
+}
+func (p *WorkerRunArgs) IsSetCmd() bool {
+	return p.Cmd != nil
+}
+
+func (p *WorkerRunArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerRunArgs) readField1(iprot thrift.TProtocol) error {
+	p.Cmd = &RunCommand{}
+	if err := p.Cmd.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cmd), err)
+	}
+	return nil
+}
+
+func (p *WorkerRunArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Run_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerRunArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("cmd", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cmd: ", p), err)
+	}
+	if err := p.Cmd.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cmd), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cmd: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerRunArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerRunArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type WorkerRunResult struct {
+	Success *RunStatus `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewWorkerRunResult() *WorkerRunResult {
+	return &WorkerRunResult{}
+}
+
+var WorkerRunResult_Success_DEFAULT *RunStatus
+
+func (p *WorkerRunResult) GetSuccess() *RunStatus {
+	if !p.IsSetSuccess() {
+		return WorkerRunResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *WorkerRunResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *WorkerRunResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerRunResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &RunStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *WorkerRunResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Run_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerRunResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerRunResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerRunResult(%+v)", *p)
+}
+
+// Attributes:
+//  - RunId
+type WorkerAbortArgs struct {
+	RunId string `thrift:"runId,1" json:"runId"`
+}
+
+func NewWorkerAbortArgs() *WorkerAbortArgs {
+	return &WorkerAbortArgs{}
+}
+
+func (p *WorkerAbortArgs) GetRunId() string {
+	return p.RunId
+}
+func (p *WorkerAbortArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerAbortArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.RunId = v
+	}
+	return nil
+}
+
+func (p *WorkerAbortArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Abort_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerAbortArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.RunId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.runId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runId: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerAbortArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerAbortArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type WorkerAbortResult struct {
+	Success *RunStatus `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewWorkerAbortResult() *WorkerAbortResult {
+	return &WorkerAbortResult{}
+}
+
+var WorkerAbortResult_Success_DEFAULT *RunStatus
+
+func (p *WorkerAbortResult) GetSuccess() *RunStatus {
+	if !p.IsSetSuccess() {
+		return WorkerAbortResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *WorkerAbortResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *WorkerAbortResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerAbortResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &RunStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *WorkerAbortResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Abort_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerAbortResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerAbortResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerAbortResult(%+v)", *p)
+}
+
+// Attributes:
+//  - RunId
+type WorkerEraseArgs struct {
+	RunId string `thrift:"runId,1" json:"runId"`
+}
+
+func NewWorkerEraseArgs() *WorkerEraseArgs {
+	return &WorkerEraseArgs{}
+}
+
+func (p *WorkerEraseArgs) GetRunId() string {
+	return p.RunId
+}
+func (p *WorkerEraseArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerEraseArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.RunId = v
+	}
+	return nil
+}
+
+func (p *WorkerEraseArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Erase_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerEraseArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.RunId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.runId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runId: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerEraseArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WorkerEraseArgs(%+v)", *p)
+}
+
+type WorkerEraseResult struct {
+}
+
+func NewWorkerEraseResult() *WorkerEraseResult {
+	return &WorkerEraseResult{}
+}
+
+func (p *WorkerEraseResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *WorkerEraseResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Erase_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerEraseResult) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("WorkerEraseResult(%+v)", *p)
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+// Attributes:
+//  - Query
+type WorkerQueryRunHistoryArgs struct {
+	Query *RunHistoryQuery `thrift:"query,1" json:"query"`
+}
 
-type WorkerQueryWorkerArgs struct {
+func NewWorkerQueryRunHistoryArgs() *WorkerQueryRunHistoryArgs {
+	return &WorkerQueryRunHistoryArgs{}
 }
 
-func NewWorkerQueryWorkerArgs() *WorkerQueryWorkerArgs {
-	return &WorkerQueryWorkerArgs{}
+var WorkerQueryRunHistoryArgs_Query_DEFAULT *RunHistoryQuery
+
+func (p *WorkerQueryRunHistoryArgs) GetQuery() *RunHistoryQuery {
+	if !p.IsSetQuery() {
+		return WorkerQueryRunHistoryArgs_Query_DEFAULT
+	}
+	return p.Query
+}
+func (p *WorkerQueryRunHistoryArgs) IsSetQuery() bool {
+	return p.Query != nil
 }
 
-func (p *WorkerQueryWorkerArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkerQueryRunHistoryArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -617,8 +1841,15 @@ func (p *WorkerQueryWorkerArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -630,10 +1861,21 @@ func (p *WorkerQueryWorkerArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerQueryWorkerArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryWorker_args"); err != nil {
+func (p *WorkerQueryRunHistoryArgs) readField1(iprot thrift.TProtocol) error {
+	p.Query = &RunHistoryQuery{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
+	}
+	return nil
+}
+
+func (p *WorkerQueryRunHistoryArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryRunHistory_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -643,36 +1885,49 @@ func (p *WorkerQueryWorkerArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerQueryWorkerArgs) String() string {
+func (p *WorkerQueryRunHistoryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+	}
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerQueryRunHistoryArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerQueryWorkerArgs(%+v)", *p)
+	return fmt.Sprintf("WorkerQueryRunHistoryArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type WorkerQueryWorkerResult struct {
-	Success *WorkerStatus `thrift:"success,0" json:"success,omitempty"`
+type WorkerQueryRunHistoryResult struct {
+	Success *RunHistory `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewWorkerQueryWorkerResult() *WorkerQueryWorkerResult {
-	return &WorkerQueryWorkerResult{}
+func NewWorkerQueryRunHistoryResult() *WorkerQueryRunHistoryResult {
+	return &WorkerQueryRunHistoryResult{}
 }
 
-var WorkerQueryWorkerResult_Success_DEFAULT *WorkerStatus
+var WorkerQueryRunHistoryResult_Success_DEFAULT *RunHistory
 
-func (p *WorkerQueryWorkerResult) GetSuccess() *WorkerStatus {
+func (p *WorkerQueryRunHistoryResult) GetSuccess() *RunHistory {
 	if !p.IsSetSuccess() {
-		return WorkerQueryWorkerResult_Success_DEFAULT
+		return WorkerQueryRunHistoryResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *WorkerQueryWorkerResult) IsSetSuccess() bool {
+func (p *WorkerQueryRunHistoryResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *WorkerQueryWorkerResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkerQueryRunHistoryResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -705,16 +1960,16 @@ func (p *WorkerQueryWorkerResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerQueryWorkerResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &WorkerStatus{}
+func (p *WorkerQueryRunHistoryResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &RunHistory{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *WorkerQueryWorkerResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryWorker_result"); err != nil {
+func (p *WorkerQueryRunHistoryResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryRunHistory_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -729,7 +1984,7 @@ func (p *WorkerQueryWorkerResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerQueryWorkerResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkerQueryRunHistoryResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -744,36 +1999,33 @@ func (p *WorkerQueryWorkerResult) writeField0(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *WorkerQueryWorkerResult) String() string {
+func (p *WorkerQueryRunHistoryResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerQueryWorkerResult(%+v)", *p)
+	return fmt.Sprintf("WorkerQueryRunHistoryResult(%+v)", *p)
 }
 
 // Attributes:
-//  - Cmd
-type WorkerRunArgs struct {
-	Cmd *RunCommand `thrift:"cmd,1" json:"cmd"`
+//  - Level
+//  - Token
+type WorkerSetLogLevelArgs struct {
+	Level string `thrift:"level,1" json:"level"`
+	Token string `thrift:"token,2" json:"token"`
 }
 
-func NewWorkerRunArgs() *WorkerRunArgs {
-	return &WorkerRunArgs{}
+func NewWorkerSetLogLevelArgs() *WorkerSetLogLevelArgs {
+	return &WorkerSetLogLevelArgs{}
 }
 
-var WorkerRunArgs_Cmd_DEFAULT *RunCommand
-
-func (p *WorkerRunArgs) GetCmd() *RunCommand {
-	if !p.IsSetCmd() {
-		return WorkerRunArgs_Cmd_DEFAULT
-	}
-	return p.Cmd
-}
-func (p *WorkerRunArgs) IsSetCmd() bool {
-	return p.Cmd != nil
+func (p *WorkerSetLogLevelArgs) GetLevel() string {
+	return p.Level
 }
 
-func (p *WorkerRunArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkerSetLogLevelArgs) GetToken() string {
+	return p.Token
+}
+func (p *WorkerSetLogLevelArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -791,6 +2043,10 @@ func (p *WorkerRunArgs) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -806,21 +2062,34 @@ func (p *WorkerRunArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerRunArgs) readField1(iprot thrift.TProtocol) error {
-	p.Cmd = &RunCommand{}
-	if err := p.Cmd.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cmd), err)
+func (p *WorkerSetLogLevelArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Level = v
 	}
 	return nil
 }
 
-func (p *WorkerRunArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Run_args"); err != nil {
+func (p *WorkerSetLogLevelArgs) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Token = v
+	}
+	return nil
+}
+
+func (p *WorkerSetLogLevelArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetLogLevel_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -830,49 +2099,62 @@ func (p *WorkerRunArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerRunArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("cmd", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cmd: ", p), err)
+func (p *WorkerSetLogLevelArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("level", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:level: ", p), err)
 	}
-	if err := p.Cmd.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cmd), err)
+	if err := oprot.WriteString(string(p.Level)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.level (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cmd: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:level: ", p), err)
 	}
 	return err
 }
 
-func (p *WorkerRunArgs) String() string {
+func (p *WorkerSetLogLevelArgs) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("token", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:token: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Token)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.token (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:token: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerSetLogLevelArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerRunArgs(%+v)", *p)
+	return fmt.Sprintf("WorkerSetLogLevelArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type WorkerRunResult struct {
-	Success *RunStatus `thrift:"success,0" json:"success,omitempty"`
+type WorkerSetLogLevelResult struct {
+	Success *DebugSettings `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewWorkerRunResult() *WorkerRunResult {
-	return &WorkerRunResult{}
+func NewWorkerSetLogLevelResult() *WorkerSetLogLevelResult {
+	return &WorkerSetLogLevelResult{}
 }
 
-var WorkerRunResult_Success_DEFAULT *RunStatus
+var WorkerSetLogLevelResult_Success_DEFAULT *DebugSettings
 
-func (p *WorkerRunResult) GetSuccess() *RunStatus {
+func (p *WorkerSetLogLevelResult) GetSuccess() *DebugSettings {
 	if !p.IsSetSuccess() {
-		return WorkerRunResult_Success_DEFAULT
+		return WorkerSetLogLevelResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *WorkerRunResult) IsSetSuccess() bool {
+func (p *WorkerSetLogLevelResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *WorkerRunResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkerSetLogLevelResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -905,16 +2187,16 @@ func (p *WorkerRunResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerRunResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &RunStatus{}
+func (p *WorkerSetLogLevelResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DebugSettings{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *WorkerRunResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Run_result"); err != nil {
+func (p *WorkerSetLogLevelResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetLogLevel_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -929,7 +2211,7 @@ func (p *WorkerRunResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerRunResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkerSetLogLevelResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -944,27 +2226,36 @@ func (p *WorkerRunResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *WorkerRunResult) String() string {
+func (p *WorkerSetLogLevelResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerRunResult(%+v)", *p)
+	return fmt.Sprintf("WorkerSetLogLevelResult(%+v)", *p)
 }
 
 // Attributes:
-//  - RunId
-type WorkerAbortArgs struct {
-	RunId string `thrift:"runId,1" json:"runId"`
+//  - Settings
+type WorkerSetDebugSettingsArgs struct {
+	Settings *DebugSettings `thrift:"settings,1" json:"settings"`
 }
 
-func NewWorkerAbortArgs() *WorkerAbortArgs {
-	return &WorkerAbortArgs{}
+func NewWorkerSetDebugSettingsArgs() *WorkerSetDebugSettingsArgs {
+	return &WorkerSetDebugSettingsArgs{}
 }
 
-func (p *WorkerAbortArgs) GetRunId() string {
-	return p.RunId
+var WorkerSetDebugSettingsArgs_Settings_DEFAULT *DebugSettings
+
+func (p *WorkerSetDebugSettingsArgs) GetSettings() *DebugSettings {
+	if !p.IsSetSettings() {
+		return WorkerSetDebugSettingsArgs_Settings_DEFAULT
+	}
+	return p.Settings
 }
-func (p *WorkerAbortArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkerSetDebugSettingsArgs) IsSetSettings() bool {
+	return p.Settings != nil
+}
+
+func (p *WorkerSetDebugSettingsArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -997,17 +2288,16 @@ func (p *WorkerAbortArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerAbortArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.RunId = v
+func (p *WorkerSetDebugSettingsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Settings = &DebugSettings{}
+	if err := p.Settings.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Settings), err)
 	}
 	return nil
 }
 
-func (p *WorkerAbortArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Abort_args"); err != nil {
+func (p *WorkerSetDebugSettingsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetDebugSettings_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1022,49 +2312,49 @@ func (p *WorkerAbortArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerAbortArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runId: ", p), err)
+func (p *WorkerSetDebugSettingsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("settings", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:settings: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.RunId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.runId (1) field write error: ", p), err)
+	if err := p.Settings.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Settings), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:settings: ", p), err)
 	}
 	return err
 }
 
-func (p *WorkerAbortArgs) String() string {
+func (p *WorkerSetDebugSettingsArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerAbortArgs(%+v)", *p)
+	return fmt.Sprintf("WorkerSetDebugSettingsArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type WorkerAbortResult struct {
-	Success *RunStatus `thrift:"success,0" json:"success,omitempty"`
+type WorkerSetDebugSettingsResult struct {
+	Success *DebugSettings `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewWorkerAbortResult() *WorkerAbortResult {
-	return &WorkerAbortResult{}
+func NewWorkerSetDebugSettingsResult() *WorkerSetDebugSettingsResult {
+	return &WorkerSetDebugSettingsResult{}
 }
 
-var WorkerAbortResult_Success_DEFAULT *RunStatus
+var WorkerSetDebugSettingsResult_Success_DEFAULT *DebugSettings
 
-func (p *WorkerAbortResult) GetSuccess() *RunStatus {
+func (p *WorkerSetDebugSettingsResult) GetSuccess() *DebugSettings {
 	if !p.IsSetSuccess() {
-		return WorkerAbortResult_Success_DEFAULT
+		return WorkerSetDebugSettingsResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *WorkerAbortResult) IsSetSuccess() bool {
+func (p *WorkerSetDebugSettingsResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *WorkerAbortResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkerSetDebugSettingsResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1097,16 +2387,16 @@ func (p *WorkerAbortResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerAbortResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &RunStatus{}
+func (p *WorkerSetDebugSettingsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DebugSettings{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *WorkerAbortResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Abort_result"); err != nil {
+func (p *WorkerSetDebugSettingsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SetDebugSettings_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1121,7 +2411,7 @@ func (p *WorkerAbortResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerAbortResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkerSetDebugSettingsResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1136,27 +2426,36 @@ func (p *WorkerAbortResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *WorkerAbortResult) String() string {
+func (p *WorkerSetDebugSettingsResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerAbortResult(%+v)", *p)
+	return fmt.Sprintf("WorkerSetDebugSettingsResult(%+v)", *p)
 }
 
 // Attributes:
-//  - RunId
-type WorkerEraseArgs struct {
-	RunId string `thrift:"runId,1" json:"runId"`
+//  - Req
+type WorkerHandshakeArgs struct {
+	Req *HandshakeRequest `thrift:"req,1" json:"req"`
 }
 
-func NewWorkerEraseArgs() *WorkerEraseArgs {
-	return &WorkerEraseArgs{}
+func NewWorkerHandshakeArgs() *WorkerHandshakeArgs {
+	return &WorkerHandshakeArgs{}
 }
 
-func (p *WorkerEraseArgs) GetRunId() string {
-	return p.RunId
+var WorkerHandshakeArgs_Req_DEFAULT *HandshakeRequest
+
+func (p *WorkerHandshakeArgs) GetReq() *HandshakeRequest {
+	if !p.IsSetReq() {
+		return WorkerHandshakeArgs_Req_DEFAULT
+	}
+	return p.Req
 }
-func (p *WorkerEraseArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkerHandshakeArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *WorkerHandshakeArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1189,17 +2488,16 @@ func (p *WorkerEraseArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerEraseArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.RunId = v
+func (p *WorkerHandshakeArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &HandshakeRequest{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *WorkerEraseArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Erase_args"); err != nil {
+func (p *WorkerHandshakeArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Handshake_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1214,34 +2512,49 @@ func (p *WorkerEraseArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerEraseArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:runId: ", p), err)
+func (p *WorkerHandshakeArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.RunId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.runId (1) field write error: ", p), err)
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:runId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
 	return err
 }
 
-func (p *WorkerEraseArgs) String() string {
+func (p *WorkerHandshakeArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerEraseArgs(%+v)", *p)
+	return fmt.Sprintf("WorkerHandshakeArgs(%+v)", *p)
 }
 
-type WorkerEraseResult struct {
+// Attributes:
+//  - Success
+type WorkerHandshakeResult struct {
+	Success *HandshakeResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewWorkerEraseResult() *WorkerEraseResult {
-	return &WorkerEraseResult{}
+func NewWorkerHandshakeResult() *WorkerHandshakeResult {
+	return &WorkerHandshakeResult{}
 }
 
-func (p *WorkerEraseResult) Read(iprot thrift.TProtocol) error {
+var WorkerHandshakeResult_Success_DEFAULT *HandshakeResponse
+
+func (p *WorkerHandshakeResult) GetSuccess() *HandshakeResponse {
+	if !p.IsSetSuccess() {
+		return WorkerHandshakeResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *WorkerHandshakeResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *WorkerHandshakeResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1254,8 +2567,15 @@ func (p *WorkerEraseResult) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -1267,10 +2587,21 @@ func (p *WorkerEraseResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerEraseResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Erase_result"); err != nil {
+func (p *WorkerHandshakeResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &HandshakeResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *WorkerHandshakeResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Handshake_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1280,9 +2611,24 @@ func (p *WorkerEraseResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *WorkerEraseResult) String() string {
+func (p *WorkerHandshakeResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerHandshakeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("WorkerEraseResult(%+v)", *p)
+	return fmt.Sprintf("WorkerHandshakeResult(%+v)", *p)
 }
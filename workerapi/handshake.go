@@ -0,0 +1,70 @@
+package workerapi
+
+import (
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/workerapi/gen-go/worker"
+)
+
+// Version of the worker API spoken by this build, sent and compared in Handshake.
+// Workers predating the handshake are treated as speaking version 0.
+const APIVersion int32 = 1
+
+// Optional worker API features negotiated in Handshake, so a client only relies on what a worker of
+// another version supports instead of failing with opaque errors, or having fields silently dropped.
+const (
+	FeatureRunHistory     = "RunHistory"     // QueryRunHistory
+	FeatureDebugSettings  = "DebugSettings"  // SetLogLevel and SetDebugSettings
+	FeatureSparseCheckout = "SparseCheckout" // RunCommand.sparsePatterns
+	FeatureRetention      = "Retention"      // RunCommand.retentionMs
+)
+
+// The API features this build supports.
+var APIFeatures = []string{FeatureRunHistory, FeatureDebugSettings, FeatureSparseCheckout, FeatureRetention}
+
+// The result of a Handshake: the API version and features both a client and a worker support,
+// and what the worker can run.
+type Handshake struct {
+	APIVersion   int32                // Zero if the worker predates the handshake.
+	Features     []string             // Empty if the worker predates the handshake.
+	Capabilities *runner.Capabilities // nil if the worker didn't report any.
+}
+
+// Returns whether both the client and the worker support feature.
+func (h Handshake) Supports(feature string) bool {
+	for _, f := range h.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the features in both ours and theirs, in the order of ours.
+func NegotiateFeatures(ours, theirs []string) []string {
+	features := []string{}
+	for _, f := range ours {
+		for _, t := range theirs {
+			if f == t {
+				features = append(features, f)
+				break
+			}
+		}
+	}
+	return features
+}
+
+func ThriftHandshakeToDomain(thrift *worker.HandshakeResponse) Handshake {
+	return Handshake{
+		APIVersion:   thrift.GetApiVersion(),
+		Features:     thrift.GetFeatures(),
+		Capabilities: ThriftCapabilitiesToDomain(thrift.GetCapabilities()),
+	}
+}
+
+func DomainHandshakeToThrift(domain Handshake) *worker.HandshakeResponse {
+	thrift := worker.NewHandshakeResponse()
+	thrift.ApiVersion = domain.APIVersion
+	thrift.Features = domain.Features
+	thrift.Capabilities = DomainCapabilitiesToThrift(domain.Capabilities)
+	return thrift
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/gpu"
+)
+
+// Version of the worker binary reported in QueryWorker, set at build time with:
+// go install -ldflags "-X github.com/twitter/scoot/workerapi/server.Version=<version>"
+var Version = "unknown"
+
+// What a worker reports about itself in QueryWorker, besides OS/arch and free disk which are read when queried.
+type CapabilitiesConfig struct {
+	Version      string
+	SnapshotType string
+	Features     []string
+	// Directory on the filesystem runs use, for reporting free disk. Free disk isn't reported if empty.
+	DiskDir string
+	GPUs    []gpu.Device
+	// CPU and memory available to runs, used by the scheduler to place tasks that declare requirements.
+	Resources runner.Resources
+}
+
+// Creates a CapabilitiesConfig reporting the RunTypes in rtm as features,
+// and the machine's CPUs and total memory as the resources available to runs.
+func NewCapabilitiesConfig(snapshotType string, rtm runner.RunTypeMap, diskDir string) *CapabilitiesConfig {
+	features := []string{}
+	for rt := range rtm {
+		features = append(features, string(rt))
+	}
+	sort.Strings(features)
+	return &CapabilitiesConfig{
+		Version:      Version,
+		SnapshotType: snapshotType,
+		Features:     features,
+		DiskDir:      diskDir,
+		Resources:    runner.Resources{MilliCPUs: int64(runtime.NumCPU()) * 1000, MemoryBytes: totalMemoryBytes()},
+	}
+}
+
+// Returns MemTotal from /proc/meminfo, or zero if it can't be read, ex: on OSX.
+func totalMemoryBytes() int64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// ex: "MemTotal:       16318412 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "MemTotal:" && fields[2] == "kB" {
+			if kb, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				return kb * 1024
+			}
+		}
+	}
+	return 0
+}
+
+// Reports devices as GPUs runs may request, adding the GPU feature if there are any.
+func (c *CapabilitiesConfig) AddGPUs(devices []gpu.Device) {
+	if len(devices) == 0 {
+		return
+	}
+	c.GPUs = append(c.GPUs, devices...)
+	for _, f := range c.Features {
+		if f == gpu.Feature {
+			return
+		}
+	}
+	c.Features = append(c.Features, gpu.Feature)
+	sort.Strings(c.Features)
+}
+
+// Returns the worker's current capabilities, or nil if c is nil.
+func (c *CapabilitiesConfig) Capabilities() *runner.Capabilities {
+	if c == nil {
+		return nil
+	}
+	caps := &runner.Capabilities{
+		Version:      c.Version,
+		SnapshotType: c.SnapshotType,
+		Features:     c.Features,
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		Resources:    c.Resources,
+	}
+	for _, d := range c.GPUs {
+		caps.GPUs = append(caps.GPUs, runner.GPU{ID: d.ID, Model: d.Model})
+	}
+	if c.DiskDir != "" {
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(c.DiskDir, &st); err != nil {
+			log.Errorf("Couldn't read free disk of %s: %v", c.DiskDir, err)
+		} else {
+			caps.FreeDiskBytes = int64(st.Bavail) * int64(st.Bsize)
+		}
+	}
+	return caps
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/workerapi/gen-go/worker"
+)
+
+// Debugging settings that can be changed at runtime, so a misbehaving worker
+// can be investigated without a restart that loses its state.
+type debugSettings struct {
+	token        string // Set once when the handler's created, so read without mu.
+	mu           sync.Mutex
+	dumpRequests bool
+	pprofPort    int32
+	pprofServer  *http.Server
+}
+
+// Checks token matches the configured admin token. Changes are refused if there's none to check against,
+// as pprof and request dumps expose the worker's memory and its runs' commands.
+func (d *debugSettings) authorize(token string) error {
+	if d.token == "" {
+		return errors.New("Debug settings can't be changed, the worker has no admin token configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(d.token)) != 1 {
+		return errors.New("Unauthorized to change debug settings, invalid admin token")
+	}
+	return nil
+}
+
+// Applies the fields set in s and returns the resulting settings.
+// Settings before the first failing field are still applied.
+func (d *debugSettings) update(s *worker.DebugSettings) (*worker.DebugSettings, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s.LogLevel != nil {
+		level, err := log.ParseLevel(s.GetLogLevel())
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Worker setting log level to %s", level)
+		log.SetLevel(level)
+	}
+	if s.DumpRequests != nil {
+		log.Infof("Worker setting request dumping to %t", s.GetDumpRequests())
+		d.dumpRequests = s.GetDumpRequests()
+	}
+	if s.PprofPort != nil && s.GetPprofPort() != d.pprofPort {
+		if err := d.servePprof(s.GetPprofPort()); err != nil {
+			return nil, err
+		}
+	}
+	return d.current(), nil
+}
+
+// Stops serving pprof on the current port, if any, and starts serving it on port unless it's 0.
+// Must be called with mu held.
+func (d *debugSettings) servePprof(port int32) error {
+	if port < 0 {
+		return fmt.Errorf("Invalid pprof port %d", port)
+	}
+	if d.pprofServer != nil {
+		log.Infof("Worker no longer serving pprof on port %d", d.pprofPort)
+		d.pprofServer.Close()
+		d.pprofServer, d.pprofPort = nil, 0
+	}
+	if port == 0 {
+		return nil
+	}
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(l); err != http.ErrServerClosed {
+			log.Errorf("Worker pprof server on port %d stopped: %v", port, err)
+		}
+	}()
+	log.Infof("Worker serving pprof on port %d", port)
+	d.pprofServer, d.pprofPort = server, port
+	return nil
+}
+
+// Must be called with mu held.
+func (d *debugSettings) current() *worker.DebugSettings {
+	level := log.GetLevel().String()
+	dump := d.dumpRequests
+	port := d.pprofPort
+	return &worker.DebugSettings{LogLevel: &level, DumpRequests: &dump, PprofPort: &port}
+}
+
+// Logs the full contents of a request to method if request dumping is enabled.
+// Env values of commands are redacted, as they may hold secrets.
+func (d *debugSettings) dump(method string, req interface{}) {
+	d.mu.Lock()
+	enabled := d.dumpRequests
+	d.mu.Unlock()
+	if enabled {
+		log.Infof("Worker request dump, %s: %+v", method, redact(req))
+	}
+}
+
+const redactedValue = "<redacted>"
+
+// Returns req with the values of any env vars it carries replaced, leaving req unchanged.
+func redact(req interface{}) interface{} {
+	cmd, ok := req.(*worker.RunCommand)
+	if !ok || cmd == nil || len(cmd.Env) == 0 {
+		return req
+	}
+	redacted := *cmd
+	redacted.Env = make(map[string]string, len(cmd.Env))
+	for k := range cmd.Env {
+		redacted.Env[k] = redactedValue
+	}
+	return &redacted
+}
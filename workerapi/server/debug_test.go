@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/workerapi/gen-go/worker"
+)
+
+func TestDebugSettings(t *testing.T) {
+	origLevel := log.GetLevel()
+	defer log.SetLevel(origLevel)
+	d := &debugSettings{}
+
+	level := "debug"
+	s, err := d.update(&worker.DebugSettings{LogLevel: &level})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.GetLevel() != log.DebugLevel || s.GetLogLevel() != "debug" || s.GetDumpRequests() || s.GetPprofPort() != 0 {
+		t.Errorf("Expected only the log level to change, got %v at level %s", s, log.GetLevel())
+	}
+
+	dump := true
+	if s, _ = d.update(&worker.DebugSettings{DumpRequests: &dump}); !s.GetDumpRequests() || s.GetLogLevel() != "debug" {
+		t.Errorf("Expected request dumping enabled and log level unchanged, got %v", s)
+	}
+
+	bad := "chatty"
+	if _, err := d.update(&worker.DebugSettings{LogLevel: &bad}); err == nil {
+		t.Error("Expected an error for an unknown log level")
+	}
+}
+
+func TestDebugSettingsPprof(t *testing.T) {
+	d := &debugSettings{}
+	port := int32(freePort(t))
+	s, err := d.update(&worker.DebugSettings{PprofPort: &port})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GetPprofPort() != port {
+		t.Errorf("Expected pprof port %d, got %v", port, s)
+	}
+	url := fmt.Sprintf("http://localhost:%d/debug/pprof/", port)
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Expected pprof to be served: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected OK from pprof, got %d", resp.StatusCode)
+	}
+
+	off := int32(0)
+	if s, _ = d.update(&worker.DebugSettings{PprofPort: &off}); s.GetPprofPort() != 0 {
+		t.Errorf("Expected pprof disabled, got %v", s)
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("Expected pprof to no longer be served")
+	}
+}
+
+func TestDebugSettingsAuthorize(t *testing.T) {
+	d := &debugSettings{}
+	if err := d.authorize(""); err == nil {
+		t.Error("Expected changes to be refused without a configured admin token")
+	}
+	d.token = "secret"
+	if err := d.authorize("guess"); err == nil {
+		t.Error("Expected changes with the wrong token to be refused")
+	}
+	if err := d.authorize("secret"); err != nil {
+		t.Errorf("Expected changes with the admin token to be allowed, got %v", err)
+	}
+}
+
+func TestDumpRedactsEnv(t *testing.T) {
+	cmd := &worker.RunCommand{Argv: []string{"true"}, Env: map[string]string{"API_KEY": "hunter2"}}
+	redacted := redact(cmd).(*worker.RunCommand)
+	if redacted.Env["API_KEY"] != redactedValue || len(redacted.Argv) != 1 {
+		t.Errorf("Expected only env values to be redacted, got %v", redacted)
+	}
+	if cmd.Env["API_KEY"] != "hunter2" {
+		t.Errorf("Expected the request to be left unchanged, got %v", cmd)
+	}
+	if redact("run1") != "run1" {
+		t.Error("Expected requests without env vars to be dumped as is")
+	}
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/bazel/cas"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/snapshot"
+	bzsnapshot "github.com/twitter/scoot/snapshot/bazel"
+)
+
+// How long the worker waits before rerunning a failed startup self-test
+const DefaultSelfTestRetryInterval = 30 * time.Second
+
+// Output of the self-test's trivial command
+const selfTestOutput = "scoot-selftest"
+
+// A check the worker must pass before serving tasks.
+type SelfCheck struct {
+	Name string
+	Run  func() error
+}
+
+// SelfTest runs checks that a worker can do the basic work every task needs: run a command, check out
+// a snapshot and reach the CAS. The worker doesn't serve thrift, so the scheduler can't send it tasks,
+// until they pass, rather than registering and failing every task it's sent.
+type SelfTest struct {
+	Checks        []SelfCheck
+	RetryInterval time.Duration
+	stat          stats.StatsReceiver
+}
+
+// Creates a SelfTest that runs a trivial command with ex, does a snapshot round trip through the Scoot filer
+// once r is initialized, and does a CAS round trip if the Bazel filer has a CAS server to talk to.
+func NewSelfTest(ex execer.Execer, rtm runner.RunTypeMap, r runner.Service, tmp *temp.TempDir,
+	stat stats.StatsReceiver) *SelfTest {
+	t := &SelfTest{RetryInterval: DefaultSelfTestRetryInterval, stat: stat}
+	t.Checks = append(t.Checks, SelfCheck{"command", func() error { return commandCheck(ex, tmp) }})
+	if f, ok := rtm[runner.RunTypeScoot]; ok {
+		t.Checks = append(t.Checks, SelfCheck{"checkout", func() error { return checkoutCheck(f.Filer, r, tmp) }})
+	}
+	if f, ok := rtm[runner.RunTypeBazel]; ok {
+		if bzFiler, ok := f.Filer.(*bzsnapshot.BzFiler); ok {
+			t.Checks = append(t.Checks, SelfCheck{"cas", func() error { return casCheck(bzFiler) }})
+		}
+	}
+	return t
+}
+
+// Runs every check, returning an error naming those that failed.
+func (t *SelfTest) Run() error {
+	failed := []string{}
+	for _, c := range t.Checks {
+		start := time.Now()
+		if err := c.Run(); err != nil {
+			log.Errorf("Self-test check %s failed: %v", c.Name, err)
+			failed = append(failed, fmt.Sprintf("%s: %v", c.Name, err))
+			continue
+		}
+		log.Infof("Self-test check %s passed in %v", c.Name, time.Since(start))
+	}
+	if len(failed) > 0 {
+		if t.stat != nil {
+			t.stat.Counter(stats.WorkerSelfTestFailures).Inc(1)
+		}
+		return fmt.Errorf("Self-test failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Runs the self-test until it passes, waiting RetryInterval between runs.
+func (t *SelfTest) WaitForPass() {
+	interval := t.RetryInterval
+	if interval <= 0 {
+		interval = DefaultSelfTestRetryInterval
+	}
+	for {
+		err := t.Run()
+		if err == nil {
+			return
+		}
+		log.Errorf("Not serving tasks, retrying self-test in %v: %v", interval, err)
+		time.Sleep(interval)
+	}
+}
+
+func commandCheck(ex execer.Execer, tmp *temp.TempDir) error {
+	dir, err := tmp.TempDir("selftest")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir.Dir)
+	var stdout bytes.Buffer
+	p, err := ex.Exec(execer.Command{
+		Argv:   []string{"sh", "-c", "echo " + selfTestOutput},
+		Dir:    dir.Dir,
+		Stdout: &stdout,
+		Stderr: ioutil.Discard,
+	})
+	if err != nil {
+		return err
+	}
+	st := p.Wait()
+	if st.State != execer.COMPLETE || st.ExitCode != 0 {
+		return fmt.Errorf("Unexpected status %v", st)
+	}
+	if out := strings.TrimSpace(stdout.String()); out != selfTestOutput {
+		return fmt.Errorf("Unexpected output %q", out)
+	}
+	return nil
+}
+
+// Waits for r to be initialized, since that's when the filer can be used, then ingests
+// a dir and checks it out again.
+func checkoutCheck(f snapshot.Filer, r runner.Service, tmp *temp.TempDir) error {
+	for {
+		_, svc, err := r.StatusAll()
+		if err != nil {
+			return err
+		}
+		if svc.Error != nil {
+			return fmt.Errorf("Runner failed to initialize: %v", svc.Error)
+		}
+		if svc.Initialized {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	dir, err := tmp.TempDir("selftest")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir.Dir)
+	if err := ioutil.WriteFile(filepath.Join(dir.Dir, "selftest"), []byte(selfTestOutput), 0644); err != nil {
+		return err
+	}
+	id, err := f.Ingest(dir.Dir)
+	if err != nil {
+		return fmt.Errorf("Error ingesting: %v", err)
+	}
+	co, err := f.Checkout(id)
+	if err != nil {
+		return fmt.Errorf("Error checking out %s: %v", id, err)
+	}
+	defer co.Release()
+	data, err := ioutil.ReadFile(filepath.Join(co.Path(), "selftest"))
+	if err != nil {
+		return fmt.Errorf("Error reading checkout of %s: %v", id, err)
+	}
+	if string(data) != selfTestOutput {
+		return fmt.Errorf("Unexpected contents %q in checkout of %s", data, id)
+	}
+	return nil
+}
+
+// Writes a blob to the CAS and reads it back. Passes if no CAS server is configured,
+// since such workers only run Scoot tasks.
+func casCheck(bzFiler *bzsnapshot.BzFiler) error {
+	if addr, err := bzFiler.CASResolver.Resolve(); err != nil {
+		return err
+	} else if addr == "" {
+		log.Info("No CAS server to self-test against, skipping")
+		return nil
+	}
+	data := []byte(fmt.Sprintf("%s %d", selfTestOutput, time.Now().UnixNano()))
+	digest := &remoteexecution.Digest{Hash: fmt.Sprintf("%x", sha256.Sum256(data)), SizeBytes: int64(len(data))}
+	if err := cas.ByteStreamWrite(bzFiler.CASResolver, digest, data, 2); err != nil {
+		return fmt.Errorf("Error writing to CAS: %v", err)
+	}
+	read, err := cas.ByteStreamRead(bzFiler.CASResolver, digest, 2)
+	if err != nil {
+		return fmt.Errorf("Error reading from CAS: %v", err)
+	}
+	if !bytes.Equal(read, data) {
+		return fmt.Errorf("Read %d bytes from CAS, expected %d", len(read), len(data))
+	}
+	return nil
+}
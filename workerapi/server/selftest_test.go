@@ -0,0 +1,43 @@
+package server
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner/execer/execers"
+	osexec "github.com/twitter/scoot/runner/execer/os"
+)
+
+func TestSelfTestCommand(t *testing.T) {
+	tmp, err := temp.NewTempDir("", "selftest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commandCheck(osexec.NewExecer(), tmp); err != nil {
+		t.Fatalf("Expected command check to pass: %v", err)
+	}
+
+	broken := execers.NewDoneExecer()
+	broken.ExitCode = 1
+	if err := commandCheck(broken, tmp); err == nil {
+		t.Fatal("Expected command check to fail when the command fails")
+	}
+}
+
+func TestSelfTestRun(t *testing.T) {
+	st := &SelfTest{Checks: []SelfCheck{
+		{"ok", func() error { return nil }},
+		{"broken", func() error { return errors.New("broken") }},
+	}}
+	err := st.Run()
+	if err == nil || !strings.Contains(err.Error(), "broken") || strings.Contains(err.Error(), "ok:") {
+		t.Fatalf("Expected only the broken check to be reported, got %v", err)
+	}
+
+	st.Checks = st.Checks[:1]
+	if err := st.Run(); err != nil {
+		t.Fatalf("Expected self-test to pass: %v", err)
+	}
+}
@@ -3,6 +3,7 @@
 package server
 
 import (
+	"errors"
 	"reflect"
 	"sync"
 	"time"
@@ -35,81 +36,163 @@ func MakeServer(
 // (avoid conflict with other injected integers)
 type StatsCollectInterval time.Duration
 
+// Secret that requests to change the worker's debug settings must carry. They're refused if empty.
+type AdminToken string
+
 type handler struct {
 	stat         stats.StatsReceiver
 	run          runner.Service
+	history      runner.HistoryReader
+	caps         *CapabilitiesConfig
+	debug        debugSettings
 	timeLastRpc  time.Time
 	mu           sync.RWMutex
 	currentCmd   *runner.Command
 	currentRunID runner.RunID
 }
 
-// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver
-func NewHandler(stat stats.StatsReceiver, run runner.Service) worker.Worker {
+// Creates a new Handler which combines a runner.Service to do work, a StatsReceiver,
+// a runner.HistoryReader to serve finished runs (history may be nil),
+// the capabilities reported in QueryWorker (caps may be nil),
+// and the token debug settings changes must carry
+func NewHandler(
+	stat stats.StatsReceiver,
+	run runner.Service,
+	history runner.HistoryReader,
+	caps *CapabilitiesConfig,
+	token AdminToken) worker.Worker {
 	scopedStat := stat.Scope("handler")
-	h := &handler{stat: scopedStat, run: run, timeLastRpc: time.Now()}
+	h := &handler{stat: scopedStat, run: run, history: history, caps: caps, timeLastRpc: time.Now()}
+	h.debug.token = string(token)
 	stats.ReportServerRestart(scopedStat, stats.WorkerServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	go h.stats()
 	return h
 }
 
-// Periodically output stats
+// How often stats are resynced from StatusAll when the runner also sends status events.
+// Events can be dropped, so this bounds how long the gauges can drift from the runner's statuses.
+var StatsResyncIntvl time.Duration = 10 * time.Second
+
+// How many status events can be pending before the runner starts dropping them.
+const statusEventBuffer = 1000
+
+// Output stats, updating the run gauges as the runner's statuses change when the runner sends
+// status events, and resyncing them by polling StatusAll as a fallback.
 //TODO: runner should eventually be extended to support stats, multiple runs, etc. (replacing loop here).
 func (h *handler) stats() {
 	var startTime time.Time = time.Now()
 	var initTime time.Duration
 	nilTime := time.Time{}
 	initDoneTime := nilTime
+	initialized := false
+	counts := newRunCounts()
+
+	// Subscribe before the first resync so no change made after it is missed.
+	var events <-chan runner.StatusEvent
+	resyncIntvl := stats.StatReportIntvl
+	if sub, ok := h.run.(runner.StatusSubscriber); ok {
+		if events = sub.SubscribeStatus(statusEventBuffer); events != nil {
+			resyncIntvl = StatsResyncIntvl
+		}
+	}
+	resync := func() {
+		processes, svcStatus, err := h.run.StatusAll()
+		if err != nil {
+			return
+		}
+		counts.reset(processes)
+		initialized = svcStatus.Initialized
+	}
+	resync()
+
 	ticker := time.NewTicker(time.Duration(stats.StatReportIntvl))
+	resyncTicker := time.NewTicker(resyncIntvl)
 	for {
 		select {
-		case <-ticker.C:
-			h.mu.Lock()
-
-			processes, svcStatus, err := h.run.StatusAll()
-			if err != nil {
-				continue
+		case ev := <-events:
+			switch {
+			case ev.Run != nil:
+				counts.set(*ev.Run)
+			case ev.Service != nil:
+				initialized = ev.Service.Initialized
+			default:
+				counts.remove(ev.Erased)
 			}
+		case <-resyncTicker.C:
+			resync()
+		case <-ticker.C:
+		}
 
-			if svcStatus.Initialized {
-				if initDoneTime == nilTime {
-					initDoneTime = time.Now()
-					initTime = initDoneTime.Sub(startTime)
-				}
-
-				var numFailed int64
-				var numActive int64
-
-				for _, process := range processes {
-					if process.State == runner.FAILED {
-						numFailed++
-					}
-					if !process.State.IsDone() {
-						numActive++
-					}
-				}
-				// if its done initializing, record the final initLatency time
-				// compute the uptime as time since init finished
-				uptime := time.Since(initDoneTime)
-				timeSincelastContact_ms := int64(time.Now().Sub(h.timeLastRpc) / time.Millisecond)
-				h.stat.Gauge(stats.WorkerFinalInitLatency_ms).Update(int64(initTime / time.Millisecond))
-				h.stat.Gauge(stats.WorkerActiveInitLatency_ms).Update(0)
-				h.stat.Gauge(stats.WorkerActiveRunsGauge).Update(numActive)
-				h.stat.Gauge(stats.WorkerFailedCachedRunsGauge).Update(numFailed)
-				h.stat.Gauge(stats.WorkerEndedCachedRunsGauge).Update(int64(len(processes)) - numActive) // TODO errata metric - remove if unused
-				h.stat.Gauge(stats.WorkerTimeSinceLastContactGauge_ms).Update(timeSincelastContact_ms)   // TODO errata metric - remove if unused
-				uptimeMs := int64(uptime / time.Millisecond)
-				h.stat.Gauge(stats.WorkerUptimeGauge_ms).Update(uptimeMs)
-			} else {
-				initTime := time.Now().Sub(startTime)
-				h.stat.Gauge(stats.WorkerActiveInitLatency_ms).Update(int64(initTime / time.Millisecond))
+		if initialized {
+			if initDoneTime == nilTime {
+				initDoneTime = time.Now()
+				initTime = initDoneTime.Sub(startTime)
 			}
 
-			h.mu.Unlock()
+			// if its done initializing, record the final initLatency time
+			// compute the uptime as time since init finished
+			uptime := time.Since(initDoneTime)
+			h.mu.RLock()
+			timeSincelastContact_ms := int64(time.Now().Sub(h.timeLastRpc) / time.Millisecond)
+			h.mu.RUnlock()
+			h.stat.Gauge(stats.WorkerFinalInitLatency_ms).Update(int64(initTime / time.Millisecond))
+			h.stat.Gauge(stats.WorkerActiveInitLatency_ms).Update(0)
+			h.stat.Gauge(stats.WorkerActiveRunsGauge).Update(counts.active)
+			h.stat.Gauge(stats.WorkerFailedCachedRunsGauge).Update(counts.failed)
+			h.stat.Gauge(stats.WorkerEndedCachedRunsGauge).Update(int64(len(counts.states)) - counts.active) // TODO errata metric - remove if unused
+			h.stat.Gauge(stats.WorkerTimeSinceLastContactGauge_ms).Update(timeSincelastContact_ms)           // TODO errata metric - remove if unused
+			uptimeMs := int64(uptime / time.Millisecond)
+			h.stat.Gauge(stats.WorkerUptimeGauge_ms).Update(uptimeMs)
+		} else {
+			initTime := time.Now().Sub(startTime)
+			h.stat.Gauge(stats.WorkerActiveInitLatency_ms).Update(int64(initTime / time.Millisecond))
 		}
 	}
 }
 
+// runCounts tracks the state of each run known to the runner, counting active and failed runs
+// so the gauges can be updated one status change at a time.
+type runCounts struct {
+	states map[runner.RunID]runner.RunState
+	active int64
+	failed int64
+}
+
+func newRunCounts() *runCounts {
+	return &runCounts{states: make(map[runner.RunID]runner.RunState)}
+}
+
+// Replaces all the tracked runs with processes.
+func (c *runCounts) reset(processes []runner.RunStatus) {
+	c.states = make(map[runner.RunID]runner.RunState)
+	c.active, c.failed = 0, 0
+	for _, process := range processes {
+		c.set(process)
+	}
+}
+
+func (c *runCounts) set(process runner.RunStatus) {
+	c.remove(process.RunID)
+	c.states[process.RunID] = process.State
+	c.count(process.State, 1)
+}
+
+func (c *runCounts) remove(id runner.RunID) {
+	if state, ok := c.states[id]; ok {
+		delete(c.states, id)
+		c.count(state, -1)
+	}
+}
+
+func (c *runCounts) count(state runner.RunState, delta int64) {
+	if state == runner.FAILED {
+		c.failed += delta
+	}
+	if !state.IsDone() {
+		c.active += delta
+	}
+}
+
 // Convenience
 func (h *handler) updateTimeLastRpc() {
 	h.mu.Lock()
@@ -128,6 +211,7 @@ func (h *handler) QueryWorker() (*worker.WorkerStatus, error) {
 		ws.Error = err.Error()
 	}
 	ws.Initialized = svc.Initialized
+	ws.Capabilities = domain.DomainCapabilitiesToThrift(h.caps.Capabilities())
 
 	for _, status := range st {
 		if status.State.IsDone() {
@@ -159,6 +243,7 @@ func (h *handler) Run(cmd *worker.RunCommand) (*worker.RunStatus, error) {
 		}).Info("Worker trying to run cmd")
 
 	h.updateTimeLastRpc()
+	h.debug.dump("Run", cmd)
 	c := domain.ThriftRunCommandToDomain(cmd)
 	status, err := h.run.Run(c)
 	//Check if this is a dup retry for an already running command and if so get its status.
@@ -196,6 +281,7 @@ func (h *handler) Run(cmd *worker.RunCommand) (*worker.RunStatus, error) {
 func (h *handler) Abort(runId string) (*worker.RunStatus, error) {
 	h.stat.Counter(stats.WorkerServerAborts).Inc(1)
 	h.updateTimeLastRpc()
+	h.debug.dump("Abort", runId)
 	log.Infof("Worker aborting runID: %s", runId)
 	status, err := h.run.Abort(runner.RunID(runId))
 	if err != nil {
@@ -211,7 +297,86 @@ func (h *handler) Abort(runId string) (*worker.RunStatus, error) {
 func (h *handler) Erase(runId string) error {
 	h.stat.Counter(stats.WorkerServerClears).Inc(1)
 	h.updateTimeLastRpc()
+	h.debug.dump("Erase", runId)
 	log.Infof("Worker erasing runID: %s", runId)
 	h.run.Erase(runner.RunID(runId))
 	return nil
 }
+
+// Implements worker.thrift Worker.QueryRunHistory interface
+func (h *handler) QueryRunHistory(query *worker.RunHistoryQuery) (*worker.RunHistory, error) {
+	h.stat.Counter(stats.WorkerServerHistoryQueries).Inc(1)
+	h.updateTimeLastRpc()
+	h.debug.dump("QueryRunHistory", query)
+	if h.history == nil {
+		return nil, errors.New("Worker is not configured with a run history")
+	}
+	q := domain.ThriftRunHistoryQueryToDomain(query)
+	log.WithFields(
+		log.Fields{
+			"runID": q.RunID,
+			"start": q.Start,
+			"end":   q.End,
+			"limit": q.Limit,
+		}).Info("Worker querying run history")
+	recs, err := h.history.QueryHistory(q)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DomainRunHistoryToThrift(recs), nil
+}
+
+// Implements worker.thrift Worker.SetLogLevel interface
+func (h *handler) SetLogLevel(level string, token string) (*worker.DebugSettings, error) {
+	return h.SetDebugSettings(&worker.DebugSettings{LogLevel: &level, Token: &token})
+}
+
+// Implements worker.thrift Worker.SetDebugSettings interface
+func (h *handler) SetDebugSettings(settings *worker.DebugSettings) (*worker.DebugSettings, error) {
+	h.stat.Counter(stats.WorkerServerDebugSettingsChanges).Inc(1)
+	h.updateTimeLastRpc()
+	if settings == nil {
+		settings = worker.NewDebugSettings()
+	}
+	if err := h.debug.authorize(settings.GetToken()); err != nil {
+		log.Infof("Worker refused to change debug settings: %v", err)
+		return nil, err
+	}
+	logged := *settings
+	logged.Token = nil
+	log.Infof("Worker changing debug settings: %v", &logged)
+	return h.debug.update(settings)
+}
+
+// Implements worker.thrift Worker.Handshake interface
+func (h *handler) Handshake(req *worker.HandshakeRequest) (*worker.HandshakeResponse, error) {
+	h.stat.Counter(stats.WorkerServerHandshakes).Inc(1)
+	h.updateTimeLastRpc()
+	h.debug.dump("Handshake", req)
+	if req == nil {
+		req = worker.NewHandshakeRequest()
+	}
+	// Run history is only served by workers that keep one.
+	features := []string{}
+	for _, f := range domain.APIFeatures {
+		if f != domain.FeatureRunHistory || h.history != nil {
+			features = append(features, f)
+		}
+	}
+	hs := domain.Handshake{
+		APIVersion:   req.GetApiVersion(),
+		Features:     domain.NegotiateFeatures(features, req.GetFeatures()),
+		Capabilities: h.caps.Capabilities(),
+	}
+	if hs.APIVersion > domain.APIVersion {
+		hs.APIVersion = domain.APIVersion
+	}
+	log.WithFields(
+		log.Fields{
+			"clientVersion":    req.GetVersion(),
+			"clientAPIVersion": req.GetApiVersion(),
+			"apiVersion":       hs.APIVersion,
+			"features":         hs.Features,
+		}).Info("Worker negotiated API with client")
+	return domain.DomainHandshakeToThrift(hs), nil
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"runtime"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/twitter/scoot/runner/runners"
 	"github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/git/repo"
+	domain "github.com/twitter/scoot/workerapi"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
@@ -127,6 +129,58 @@ func TestFailedRunsStats(t *testing.T) {
 	}
 }
 
+func TestQueryWorkerCapabilities(t *testing.T) {
+	h, initDoneCh, _, _ := setupTestEnv(false)
+	initDoneCh <- nil
+
+	ws, err := h.QueryWorker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	caps := domain.ThriftCapabilitiesToDomain(ws.Capabilities)
+	if caps == nil || caps.Version != "test" || caps.OS != runtime.GOOS || caps.Arch != runtime.GOARCH {
+		t.Fatalf("Unexpected capabilities: %v", caps)
+	}
+	if caps.FreeDiskBytes <= 0 {
+		t.Fatalf("Expected free disk to be reported, got %d", caps.FreeDiskBytes)
+	}
+	if missing := caps.MissingFeatures([]string{"Scoot", "Bazel"}); len(missing) != 1 || missing[0] != "Bazel" {
+		t.Fatalf("Expected Bazel to be missing, got %v", missing)
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	h, initDoneCh, _, _ := setupTestEnv(false)
+	initDoneCh <- nil
+
+	req := &worker.HandshakeRequest{ApiVersion: domain.APIVersion + 1, Features: []string{domain.FeatureSparseCheckout, "Unknown"}}
+	resp, err := h.Handshake(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := domain.ThriftHandshakeToDomain(resp)
+	if hs.APIVersion != domain.APIVersion {
+		t.Errorf("Expected a newer client to be downgraded to API version %d, got %d", domain.APIVersion, hs.APIVersion)
+	}
+	if len(hs.Features) != 1 || !hs.Supports(domain.FeatureSparseCheckout) {
+		t.Errorf("Expected only the features both sides support, got %v", hs.Features)
+	}
+	if hs.Capabilities == nil || hs.Capabilities.Version != "test" {
+		t.Errorf("Unexpected capabilities: %v", hs.Capabilities)
+	}
+
+	// A worker without a run history doesn't offer it.
+	h.history = nil
+	resp, err = h.Handshake(&worker.HandshakeRequest{ApiVersion: 0, Features: domain.APIFeatures})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs = domain.ThriftHandshakeToDomain(resp)
+	if hs.APIVersion != 0 || hs.Supports(domain.FeatureRunHistory) || !hs.Supports(domain.FeatureDebugSettings) {
+		t.Errorf("Expected API version 0 without run history, got %v %v", hs.APIVersion, hs.Features)
+	}
+}
+
 func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRegistry stats.StatsRegistry, simExecer *execers.SimExecer) {
 
 	stats.StatReportIntvl = 100 * time.Millisecond
@@ -170,8 +224,8 @@ func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRe
 			statsRec, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
 			return statsRec
 		},
-		func(stat stats.StatsReceiver, run runner.Service) worker.Worker {
-			return NewHandler(stat, run)
+		func(stat stats.StatsReceiver, run runner.Service, hist runner.HistoryReader) worker.Worker {
+			return NewHandler(stat, run, hist, &CapabilitiesConfig{Version: "test", Features: []string{"Scoot"}, DiskDir: tmpDir.Dir}, "")
 		},
 	)
 	if useErrorExec {
@@ -233,6 +287,10 @@ func (pdb *pausingDB) IngestGitCommit(ingestRepo *repo.Repository, commitish str
 	pdb.wait()
 	return "nilSnapshoId", nil
 }
+func (pdb *pausingDB) IngestGitRemoteRef(url, ref string) (snapshot.ID, error) {
+	pdb.wait()
+	return "nilSnapshoId", nil
+}
 func (pdb *pausingDB) IngestGitWorkingDir(ingestRepo *repo.Repository) (snapshot.ID, error) {
 	pdb.wait()
 	return "nilSnapshoId", nil
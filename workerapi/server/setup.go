@@ -1,6 +1,11 @@
 package server
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/apache/thrift/lib/go/thrift"
 	log "github.com/sirupsen/logrus"
 
@@ -8,20 +13,28 @@ import (
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/backends"
 	"github.com/twitter/scoot/runner/execer/execers"
 	osexec "github.com/twitter/scoot/runner/execer/os"
+	"github.com/twitter/scoot/runner/execer/persistent"
+	"github.com/twitter/scoot/runner/gpu"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
 type servers struct {
-	thrift thrift.TServer
-	http   *endpoints.TwitterServer
+	thrift   thrift.TServer
+	http     *endpoints.TwitterServer
+	selfTest *SelfTest
+	// Stopped when the worker shuts down, since its processes are in their own process groups.
+	persistentWorkers *persistent.Execer
 }
 
-func makeServers(thrift thrift.TServer, http *endpoints.TwitterServer) servers {
-	return servers{thrift, http}
+func makeServers(thrift thrift.TServer, http *endpoints.TwitterServer, selfTest *SelfTest,
+	persistentWorkers *persistent.Execer) servers {
+	return servers{thrift, http, selfTest, persistentWorkers}
 }
 
 // Module returns a module that supports serving Thrift and HTTP
@@ -43,11 +56,43 @@ func (m module) Install(b *ice.MagicBag) {
 		func() execer.Memory {
 			return 0
 		},
-		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
-			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
+		func() execer.AbortGracePeriod {
+			return 0
+		},
+		func() *execer.RunAs {
+			return nil
+		},
+		func() execer.PIDNamespace {
+			return false
+		},
+		func(m execer.Memory, g execer.AbortGracePeriod, ra *execer.RunAs, pn execer.PIDNamespace,
+			s stats.StatsReceiver) *persistent.Execer {
+			return persistent.NewExecer(osexec.NewBoundedExecer(m, g, ra, pn, s), persistent.DefaultMaxIdleWorkers, 0, s)
+		},
+		func(pe *persistent.Execer, s stats.StatsReceiver) (execer.Execer, error) {
+			b, err := backends.NewExecer(pe, s)
+			if err != nil {
+				return nil, err
+			}
+			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), b), nil
 		},
-		func(stat stats.StatsReceiver, r runner.Service) worker.Worker {
-			return NewHandler(stat, r)
+		// Reports the RunTypes the worker can run, free disk of its temp dir, and GPUs runs may request
+		func(rtm runner.RunTypeMap, tmp *temp.TempDir, gpus *gpu.Allocator) *CapabilitiesConfig {
+			c := NewCapabilitiesConfig("", rtm, tmp.Dir)
+			c.AddGPUs(gpus.Devices())
+			return c
+		},
+		// Checks the worker can run a command, check out a snapshot and reach the CAS before it serves tasks
+		func(ex execer.Execer, rtm runner.RunTypeMap, r runner.Service, tmp *temp.TempDir, stat stats.StatsReceiver) *SelfTest {
+			return NewSelfTest(ex, rtm, r, tmp, stat)
+		},
+		// No admin token, so debug settings can't be changed unless the binary provides one
+		func() AdminToken {
+			return ""
+		},
+		func(stat stats.StatsReceiver, r runner.Service, hist runner.HistoryReader, caps *CapabilitiesConfig,
+			token AdminToken) worker.Worker {
+			return NewHandler(stat, r, hist, caps, token)
 		},
 		func(
 			handler worker.Worker,
@@ -89,7 +134,44 @@ func RunServer(
 		errCh <- servers.http.Serve()
 	}()
 	go func() {
+		// The scheduler only sends tasks to workers serving thrift, so don't until the self-test passes.
+		if servers.selfTest != nil {
+			servers.selfTest.WaitForPass()
+		}
 		errCh <- servers.thrift.Serve()
 	}()
-	log.Fatal("Error serving: ", <-errCh)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-errCh:
+		servers.persistentWorkers.Close()
+		log.Fatal("Error serving: ", err)
+	case sig := <-sigCh:
+		log.Infof("Shutting down on %v", sig)
+		servers.persistentWorkers.Close()
+		os.Exit(1)
+	}
+}
+
+// Runs the worker's self-test once without serving, for the worker's --selftest mode.
+// Returns nil if it passed or the MagicBag provides no self-test.
+func RunSelfTest(
+	bag *ice.MagicBag,
+	schema jsonconfig.Schema,
+	config []byte) error {
+
+	mod, err := schema.Parse(config)
+	if err != nil {
+		return fmt.Errorf("Error configuring Worker: %v", err)
+	}
+	bag.InstallModule(mod)
+
+	var selfTest *SelfTest
+	if err := bag.Extract(&selfTest); err != nil {
+		return fmt.Errorf("Error injecting self-test: %v", err)
+	}
+	if selfTest == nil {
+		return nil
+	}
+	return selfTest.Run()
 }
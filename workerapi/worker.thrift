@@ -21,6 +21,23 @@ enum Status {
   BADREQUEST = 7   # Request rejected due to unexpected failure.
 }
 
+// Why a run failed. The scheduler only retries INFRA failures.
+enum FailureKind {
+  UNCLASSIFIED = 0  # Not set by the worker, inferred from the run's status.
+  NONE = 1          # The run didn't fail.
+  COMMAND = 2       # The command ran and failed, ex: it exited nonzero or timed out.
+  INFRA = 3         # The worker or infrastructure failed. Retrying may succeed.
+  REQUEST = 4       # The request can't be run as given, ex: its inputs are missing.
+}
+
+// Resources consumed by a run's command, as reported by wait4/rusage.
+struct ResourceUsage {
+  1: optional i64 maxRssBytes  # Peak resident set size.
+  2: optional i64 userTimeMs   # CPU time spent in user mode.
+  3: optional i64 systemTimeMs # CPU time spent in kernel mode.
+  4: optional i64 wallTimeMs   # Elapsed time from process start to exit.
+}
+
 // Note, each worker has its own runId space which is unrelated to any external ids.
 struct RunStatus {
   1: required Status status
@@ -34,6 +51,32 @@ struct RunStatus {
   9: optional string taskId
   10: optional string tag
   11: optional bazel.ActionResult bazelResult
+  12: optional ResourceUsage usage
+  13: optional string hookError     # Set if a worker pre-run or post-run hook failed.
+  14: optional string logRef        # Combined stdout/stderr persisted in the store, if enabled.
+  15: optional FailureKind failureKind  # Why the run failed, if the worker classified it.
+  16: optional string stdoutTail    # Last bytes of stdout, for triage. Full logs are at outUri or logRef.
+  17: optional string stderrTail    # Last bytes of stderr.
+  18: optional bool gracefulShutdown  # Set if an aborted or timed out run's command exited on SIGTERM within the grace period.
+}
+
+// A GPU device runs may be allocated.
+struct GPUDevice {
+  1: required string id     # Index of the device as used in CUDA_VISIBLE_DEVICES.
+  2: optional string model  # ex: "Tesla V100-SXM2-16GB".
+}
+
+// What a worker can run, so the scheduler can avoid assigning it incompatible tasks.
+struct WorkerCapabilities {
+  1: optional string version         # Worker binary version, ex: a git sha.
+  2: optional string snapshotType    # Type of snapshots the worker checks out for Scoot runs, ex: "gitdb".
+  3: optional list<string> features  # Supported features, ex: run types "Scoot" and "Bazel".
+  4: optional string os              # Operating system, as Go's GOOS.
+  5: optional string arch            # Architecture, as Go's GOARCH.
+  6: optional i64 freeDiskBytes      # Free space on the filesystem runs use.
+  7: optional list<GPUDevice> gpus   # GPUs runs may request by setting SCOOT_GPUS.
+  8: optional i64 milliCpus          # Thousandths of a CPU core available to runs.
+  9: optional i64 memoryBytes        # Memory available to runs.
 }
 
 // TODO: add useful load information when it comes time to have multiple runs.
@@ -41,6 +84,7 @@ struct WorkerStatus {
   1: required list<RunStatus> runs  # All runs excepting what's been Erase()'d
   2: required bool initialized      # True if the worker has finished with any long-running init tasks.
   3: required string error          # Set when a general worker error unrelated to a specific run has occurred.
+  4: optional WorkerCapabilities capabilities  # Unset by workers predating capabilities.
 }
 
 struct RunCommand {
@@ -52,6 +96,48 @@ struct RunCommand {
   6: optional string taskId
   7: optional string tag
   8: optional bazel.ExecuteRequest bazelRequest
+  9: optional i64 retentionMs         # How long to keep the run's output snapshot and log, <= 0 for the defaults.
+  10: optional list<string> sparsePatterns  # Git sparse-checkout patterns, only matching paths are checked out.
+}
+
+// All fields are optional and and'ed together, an empty query matches all runs in the history.
+struct RunHistoryQuery {
+  1: optional string runId     # Only match runs with this runId (which may recur across worker restarts).
+  2: optional i64 startTimeMs  # Only match runs that ended at or after this time, in ms since the epoch.
+  3: optional i64 endTimeMs    # Only match runs that ended before this time, in ms since the epoch.
+  4: optional i32 limit        # Maximum number of runs to return, most recent first.
+}
+
+struct RunHistoryRecord {
+  1: required RunStatus status  # Final status of the run, including exit code and log locations.
+  2: required i64 startTimeMs   # Time the run was created, in ms since the epoch.
+  3: required i64 endTimeMs     # Time the run finished, in ms since the epoch.
+}
+
+struct RunHistory {
+  1: required list<RunHistoryRecord> runs  # Matching runs, most recently ended first.
+}
+
+// Runtime debugging settings. Unset fields are left unchanged by SetDebugSettings.
+struct DebugSettings {
+  1: optional string logLevel    # Log everything at this level and above, ex: "debug".
+  2: optional bool dumpRequests  # Log the full contents of every request the worker receives.
+  3: optional i32 pprofPort      # Serve /debug/pprof on this port, or 0 if not serving it.
+  4: optional string token       # The worker's admin token, required to change settings. Never returned.
+}
+
+// Exchanged when a client, ex: the scheduler, first connects to a worker, so clients and workers of different
+// versions only use the API features both support during rolling upgrades.
+struct HandshakeRequest {
+  1: required i32 apiVersion         # Worker API version the client speaks.
+  2: optional list<string> features  # API features the client supports, ex: "SparseCheckout".
+  3: optional string version         # Client binary version, for logging.
+}
+
+struct HandshakeResponse {
+  1: required i32 apiVersion                  # The lower of the client's and the worker's API versions.
+  2: optional list<string> features           # API features both the client and the worker support.
+  3: optional WorkerCapabilities capabilities
 }
 
 //TODO: add a method to kill the worker if we can articulate unrecoverable issues.
@@ -61,4 +147,8 @@ service Worker {
   RunStatus Run(1: RunCommand cmd)   # Run a command and return job Status.
   RunStatus Abort(1: string runId)   # Returns ABORTED if aborted, FAILED if already ended, and UNKNOWN otherwise.
   void Erase(1: string runId)        # Remove run from the history of runs (trims WorkerStatus.ended). Optional.
+  RunHistory QueryRunHistory(1: RunHistoryQuery query)  # Finished runs persisted on disk, including Erase()'d ones.
+  DebugSettings SetLogLevel(1: string level, 2: string token) # Change the log level, returning the resulting settings.
+  DebugSettings SetDebugSettings(1: DebugSettings settings)  # Change debug settings at runtime, returning the resulting settings.
+  HandshakeResponse Handshake(1: HandshakeRequest req)       # Negotiate the API version and features to use. Older workers fail with UNKNOWN_METHOD.
 }